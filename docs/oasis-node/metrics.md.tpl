@@ -1,11 +1,13 @@
 # Metrics
 
-`oasis-node` can report a number of metrics to Prometheus server. By default,
-no metrics are collected and reported. There is one way to enable metrics
-reporting:
+`oasis-node` can report a number of metrics to Prometheus server or to an
+OpenTelemetry collector. By default, no metrics are collected and reported.
+There are two ways to enable metrics reporting:
 
 * *Pull mode* listens on given address and waits for Prometheus to scrape the
   metrics.
+* *OTLP mode* periodically pushes the metrics to an OpenTelemetry collector
+  using the OTLP/HTTP protocol.
 
 ## Configuring `oasis-node` in Pull Mode
 
@@ -28,6 +30,32 @@ Prometheus:
       - targets: ['localhost:3000']
 ```
 
+## Configuring `oasis-node` in OTLP Mode
+
+To run `oasis-node` in *OTLP mode* set `metrics.mode` to `otlp` and configure
+the collector's OTLP/HTTP metrics endpoint in the node configuration file. For
+example
+
+```yaml
+metrics:
+  mode: otlp
+  otlp:
+    endpoint: http://localhost:4318/v1/metrics
+    interval: 30s
+    headers:
+      Authorization: Bearer <token>
+    resource_attributes:
+      service.instance.id: my-node
+    subsystems:
+      oasis_worker: false
+      oasis_worker_executor: true
+```
+
+Metrics are exported using the JSON encoding with cumulative temporality. The
+`subsystems` map can be used to enable or disable the export of metrics by
+metric name prefix, where the longest matching prefix takes precedence.
+Metrics that do not match any of the listed prefixes are exported.
+
 ## Metrics Reported by `oasis-node`
 
 `oasis-node` reports metrics starting with `oasis_`.
@@ -26,6 +26,9 @@ const (
 
 	// BackendVRF is the name of the VRF backend.
 	BackendVRF = "vrf"
+
+	// BackendExternal is the name of the external randomness backend.
+	BackendExternal = "external"
 )
 
 var (
@@ -102,6 +105,10 @@ type Backend interface {
 	// return the beacon for the latest finalized block.
 	GetBeacon(context.Context, int64) ([]byte, error)
 
+	// GetVRFProofs gets the VRF proofs from which the VRF alpha valid at
+	// the provided block height was derived.
+	GetVRFProofs(context.Context, int64) (*VRFProofs, error)
+
 	// StateToGenesis returns the genesis state at specified block height.
 	StateToGenesis(context.Context, int64) (*Genesis, error)
 
@@ -139,6 +146,9 @@ type ConsensusParameters struct {
 
 	// VRFParameters are the beacon parameters for the VRF backend.
 	VRFParameters *VRFParameters `json:"vrf_parameters,omitempty"`
+
+	// ExternalParameters are the beacon parameters for the external backend.
+	ExternalParameters *ExternalParameters `json:"external_parameters,omitempty"`
 }
 
 // Interval returns the epoch interval (in blocks).
@@ -148,6 +158,8 @@ func (cp *ConsensusParameters) Interval() int64 {
 		return cp.InsecureParameters.Interval
 	case BackendVRF:
 		return cp.VRFParameters.Interval
+	case BackendExternal:
+		return cp.ExternalParameters.Interval
 	default:
 		panic("invalid backend")
 	}
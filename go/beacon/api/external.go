@@ -0,0 +1,170 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+)
+
+const (
+	// GasOpExternalSubmit is the gas operation identifier for external randomness submission.
+	GasOpExternalSubmit transaction.Op = "external_submit"
+
+	// ExternalSourceSigned is the name of the signed external randomness source.
+	ExternalSourceSigned = "signed"
+)
+
+var (
+	// MethodExternalSubmit is the method name for an external randomness submission.
+	MethodExternalSubmit = transaction.NewMethodName(ModuleName, "ExternalSubmit", ExternalSubmit{})
+
+	// DefaultExternalGasCosts are the default gas costs for external randomness operations.
+	DefaultExternalGasCosts = transaction.Costs{
+		GasOpExternalSubmit: 1000,
+	}
+
+	// ExternalSignatureContext is the signature context used by the signed external randomness
+	// source.
+	ExternalSignatureContext = signature.NewContext("oasis-core/beacon: external randomness")
+
+	externalSourcesLock sync.RWMutex
+	externalSources     = map[string]ExternalSourceFactory{
+		ExternalSourceSigned: newSignedExternalSource,
+		ExternalSourceDrand:  newDrandExternalSource,
+	}
+)
+
+// ExternalParameters are the beacon parameters for the external backend.
+//
+// The external backend keeps the same block interval based epoch semantics as the other
+// backends, but derives the beacon from randomness produced by an external source (e.g., drand)
+// that has been submitted to the chain via transactions.
+type ExternalParameters struct {
+	// Interval is the epoch interval (in blocks).
+	Interval int64 `json:"interval,omitempty"`
+
+	// Source is the name of the external randomness source.
+	Source string `json:"source"`
+
+	// SourceParameters are the source-specific parameters.
+	SourceParameters cbor.RawMessage `json:"source_parameters,omitempty"`
+
+	// GasCosts are the external randomness submission gas costs.
+	GasCosts transaction.Costs `json:"gas_costs,omitempty"`
+}
+
+// ExternalSubmit is an external randomness submission transaction payload.
+type ExternalSubmit struct {
+	// Epoch is the epoch that the randomness is submitted for.
+	Epoch EpochTime `json:"epoch"`
+
+	// Round is the external source's round the randomness was produced in.
+	Round uint64 `json:"round"`
+
+	// Proof is the source-specific proof of the randomness (e.g., a signature).
+	Proof []byte `json:"proof"`
+}
+
+// ExternalState is the external backend state.
+type ExternalState struct {
+	// Epoch is the epoch for which the pending randomness will be used.
+	Epoch EpochTime `json:"epoch"`
+
+	// Round is the external source's round of the pending randomness. Zero means that no
+	// randomness has been submitted yet.
+	Round uint64 `json:"round,omitempty"`
+
+	// Randomness is the pending verified randomness.
+	Randomness []byte `json:"randomness,omitempty"`
+
+	// LastRound is the external source's round of the randomness that was used to derive the
+	// current beacon. Submissions must be for newer rounds.
+	LastRound uint64 `json:"last_round,omitempty"`
+}
+
+// ExternalSource is an external randomness source.
+//
+// Implementations must be fully deterministic as they are used during transaction execution.
+type ExternalSource interface {
+	// VerifyRandomness verifies the proof of randomness for the given round and returns the
+	// randomness on success.
+	VerifyRandomness(round uint64, proof []byte) ([]byte, error)
+}
+
+// ExternalSourceFactory creates a new external randomness source from its parameters.
+type ExternalSourceFactory func(params cbor.RawMessage) (ExternalSource, error)
+
+// RegisterExternalSource registers a new external randomness source under the given name.
+//
+// This should only be called during initialization and all nodes of a network must register
+// the same sources.
+func RegisterExternalSource(name string, factory ExternalSourceFactory) {
+	externalSourcesLock.Lock()
+	defer externalSourcesLock.Unlock()
+
+	if _, exists := externalSources[name]; exists {
+		panic(fmt.Sprintf("beacon: external source already registered: %s", name))
+	}
+	externalSources[name] = factory
+}
+
+// NewExternalSource creates the external randomness source configured by the parameters.
+func (p *ExternalParameters) NewExternalSource() (ExternalSource, error) {
+	externalSourcesLock.RLock()
+	factory, ok := externalSources[p.Source]
+	externalSourcesLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown external source: '%s'", p.Source)
+	}
+	return factory(p.SourceParameters)
+}
+
+// SignedSourceParameters are the parameters of the signed external randomness source.
+type SignedSourceParameters struct {
+	// PublicKey is the public key of the randomness source.
+	PublicKey signature.PublicKey `json:"public_key"`
+}
+
+// signedExternalSource is an external randomness source which signs each round using an
+// Ed25519 key.
+//
+// Similar to drand's unchained scheme, the signed message only depends on the round so the
+// signature is unique and unpredictable, and the randomness is the hash of the signature.
+type signedExternalSource struct {
+	publicKey signature.PublicKey
+}
+
+func (s *signedExternalSource) VerifyRandomness(round uint64, proof []byte) ([]byte, error) {
+	if !s.publicKey.Verify(ExternalSignatureContext, SignedSourceMessage(round), proof) {
+		return nil, fmt.Errorf("invalid signature for round %d", round)
+	}
+	randomness := sha256.Sum256(proof)
+	return randomness[:], nil
+}
+
+func newSignedExternalSource(raw cbor.RawMessage) (ExternalSource, error) {
+	var params SignedSourceParameters
+	if err := cbor.Unmarshal(raw, &params); err != nil {
+		return nil, fmt.Errorf("malformed signed source parameters: %w", err)
+	}
+	if !params.PublicKey.IsValid() {
+		return nil, fmt.Errorf("invalid signed source public key")
+	}
+	return &signedExternalSource{
+		publicKey: params.PublicKey,
+	}, nil
+}
+
+// SignedSourceMessage returns the message that the signed external randomness source signs for
+// the given round.
+func SignedSourceMessage(round uint64) []byte {
+	var roundBytes [8]byte
+	binary.BigEndian.PutUint64(roundBytes[:], round)
+	h := sha256.Sum256(roundBytes[:])
+	return h[:]
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+)
+
+// ExternalSourceDrand is the name of the drand external randomness source.
+const ExternalSourceDrand = "drand"
+
+// DrandRelaySignatureContext is the signature context used by drand relays.
+var DrandRelaySignatureContext = signature.NewContext("oasis-core/beacon: drand relay")
+
+// DrandSourceParameters are the parameters of the drand external randomness source.
+type DrandSourceParameters struct {
+	// ChainHash is the hash of the drand chain the randomness is taken from.
+	ChainHash []byte `json:"chain_hash"`
+
+	// Relays are the public keys of the relays that are trusted to submit drand rounds.
+	Relays []signature.PublicKey `json:"relays"`
+}
+
+// DrandProof is the proof of randomness submitted for the drand external randomness source.
+type DrandProof struct {
+	// Signature is the drand signature of the round.
+	Signature []byte `json:"signature"`
+
+	// RelaySignature is the relay's signature of the drand round.
+	RelaySignature signature.Signature `json:"relay_signature"`
+}
+
+// drandExternalSource is an external randomness source which takes randomness from a drand
+// chain.
+//
+// As with drand itself, the randomness of a round is the hash of the round's signature. The
+// drand signature is verified by the relays (the consensus layer does not support the BLS
+// signatures used by drand) which attest to it using their Ed25519 keys.
+type drandExternalSource struct {
+	chainHash []byte
+	relays    map[signature.PublicKey]bool
+}
+
+func (s *drandExternalSource) VerifyRandomness(round uint64, rawProof []byte) ([]byte, error) {
+	var proof DrandProof
+	if err := cbor.Unmarshal(rawProof, &proof); err != nil {
+		return nil, fmt.Errorf("malformed drand proof: %w", err)
+	}
+	if len(proof.Signature) == 0 {
+		return nil, fmt.Errorf("missing drand signature for round %d", round)
+	}
+	if !s.relays[proof.RelaySignature.PublicKey] {
+		return nil, fmt.Errorf("untrusted drand relay: %s", proof.RelaySignature.PublicKey)
+	}
+	if !proof.RelaySignature.Verify(DrandRelaySignatureContext, DrandRelayMessage(s.chainHash, round, proof.Signature)) {
+		return nil, fmt.Errorf("invalid relay signature for round %d", round)
+	}
+	randomness := sha256.Sum256(proof.Signature)
+	return randomness[:], nil
+}
+
+func newDrandExternalSource(raw cbor.RawMessage) (ExternalSource, error) {
+	var params DrandSourceParameters
+	if err := cbor.Unmarshal(raw, &params); err != nil {
+		return nil, fmt.Errorf("malformed drand source parameters: %w", err)
+	}
+	if len(params.ChainHash) != sha256.Size {
+		return nil, fmt.Errorf("malformed drand chain hash")
+	}
+	if len(params.Relays) == 0 {
+		return nil, fmt.Errorf("no drand relays configured")
+	}
+	relays := make(map[signature.PublicKey]bool)
+	for _, pk := range params.Relays {
+		if !pk.IsValid() {
+			return nil, fmt.Errorf("invalid drand relay public key: %s", pk)
+		}
+		relays[pk] = true
+	}
+	return &drandExternalSource{
+		chainHash: params.ChainHash,
+		relays:    relays,
+	}, nil
+}
+
+// DrandRelayMessage returns the message that drand relays sign for the given round.
+func DrandRelayMessage(chainHash []byte, round uint64, drandSignature []byte) []byte {
+	var roundBytes [8]byte
+	binary.BigEndian.PutUint64(roundBytes[:], round)
+
+	h := sha256.New()
+	_, _ = h.Write(chainHash)
+	_, _ = h.Write(roundBytes[:])
+	_, _ = h.Write(drandSignature)
+	return h.Sum(nil)
+}
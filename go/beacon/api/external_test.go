@@ -0,0 +1,156 @@
+package api
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+)
+
+type testExternalSource struct{}
+
+func (s *testExternalSource) VerifyRandomness(round uint64, _ []byte) ([]byte, error) {
+	return []byte{byte(round)}, nil
+}
+
+func TestSignedExternalSource(t *testing.T) {
+	require := require.New(t)
+
+	signer := memorySigner.NewTestSigner("beacon/api: signed external source")
+	params := &ExternalParameters{
+		Interval: 100,
+		Source:   ExternalSourceSigned,
+		SourceParameters: cbor.Marshal(&SignedSourceParameters{
+			PublicKey: signer.Public(),
+		}),
+	}
+	source, err := params.NewExternalSource()
+	require.NoError(err, "NewExternalSource")
+
+	sig, err := signer.ContextSign(ExternalSignatureContext, SignedSourceMessage(42))
+	require.NoError(err, "ContextSign")
+
+	randomness, err := source.VerifyRandomness(42, sig)
+	require.NoError(err, "VerifyRandomness")
+	require.Len(randomness, 32)
+
+	again, err := source.VerifyRandomness(42, sig)
+	require.NoError(err, "VerifyRandomness")
+	require.Equal(randomness, again, "randomness should be deterministic")
+
+	_, err = source.VerifyRandomness(43, sig)
+	require.Error(err, "VerifyRandomness should fail for a different round")
+
+	otherSigner := memorySigner.NewTestSigner("beacon/api: other signer")
+	sig, err = otherSigner.ContextSign(ExternalSignatureContext, SignedSourceMessage(42))
+	require.NoError(err, "ContextSign")
+	_, err = source.VerifyRandomness(42, sig)
+	require.Error(err, "VerifyRandomness should fail for a different signer")
+
+	// Invalid source parameters.
+	blacklistedSigner := memorySigner.NewTestSigner("beacon/api: blacklisted signer")
+	require.NoError(blacklistedSigner.Public().Blacklist(), "Blacklist")
+	params.SourceParameters = cbor.Marshal(&SignedSourceParameters{
+		PublicKey: blacklistedSigner.Public(),
+	})
+	_, err = params.NewExternalSource()
+	require.Error(err, "NewExternalSource should fail with an invalid public key")
+	params.SourceParameters = []byte("malformed")
+	_, err = params.NewExternalSource()
+	require.Error(err, "NewExternalSource should fail with malformed parameters")
+}
+
+func TestExternalSourceRegistry(t *testing.T) {
+	require := require.New(t)
+
+	params := &ExternalParameters{
+		Interval: 100,
+		Source:   "test",
+	}
+	_, err := params.NewExternalSource()
+	require.Error(err, "NewExternalSource should fail for an unknown source")
+
+	cp := &ConsensusParameters{
+		Backend:            BackendExternal,
+		ExternalParameters: params,
+	}
+	require.Error(cp.SanityCheck(), "SanityCheck should fail for an unknown source")
+
+	RegisterExternalSource("test", func(cbor.RawMessage) (ExternalSource, error) {
+		return &testExternalSource{}, nil
+	})
+	require.Panics(func() {
+		RegisterExternalSource("test", nil)
+	}, "registering the same source twice should panic")
+
+	source, err := params.NewExternalSource()
+	require.NoError(err, "NewExternalSource")
+	randomness, err := source.VerifyRandomness(7, nil)
+	require.NoError(err, "VerifyRandomness")
+	require.Equal([]byte{7}, randomness)
+
+	require.NoError(cp.SanityCheck(), "SanityCheck")
+	require.EqualValues(100, cp.Interval())
+
+	params.Interval = 0
+	require.Error(cp.SanityCheck(), "SanityCheck should fail for a zero interval")
+}
+
+func TestDrandExternalSource(t *testing.T) {
+	require := require.New(t)
+
+	relay := memorySigner.NewTestSigner("beacon/api: drand relay")
+	chainHash := make([]byte, 32)
+	params := &ExternalParameters{
+		Interval: 100,
+		Source:   ExternalSourceDrand,
+		SourceParameters: cbor.Marshal(&DrandSourceParameters{
+			ChainHash: chainHash,
+			Relays:    []signature.PublicKey{relay.Public()},
+		}),
+	}
+	source, err := params.NewExternalSource()
+	require.NoError(err, "NewExternalSource")
+
+	newProof := func(signer signature.Signer, round uint64, drandSignature []byte) []byte {
+		sig, err := signature.Sign(signer, DrandRelaySignatureContext, DrandRelayMessage(chainHash, round, drandSignature))
+		require.NoError(err, "Sign")
+		return cbor.Marshal(&DrandProof{
+			Signature:      drandSignature,
+			RelaySignature: *sig,
+		})
+	}
+
+	drandSignature := []byte("drand signature")
+	randomness, err := source.VerifyRandomness(42, newProof(relay, 42, drandSignature))
+	require.NoError(err, "VerifyRandomness")
+	expected := sha256.Sum256(drandSignature)
+	require.Equal(expected[:], randomness, "randomness should be the hash of the drand signature")
+
+	_, err = source.VerifyRandomness(43, newProof(relay, 42, drandSignature))
+	require.Error(err, "VerifyRandomness should fail for a different round")
+
+	otherSigner := memorySigner.NewTestSigner("beacon/api: other signer")
+	_, err = source.VerifyRandomness(42, newProof(otherSigner, 42, drandSignature))
+	require.Error(err, "VerifyRandomness should fail for an untrusted relay")
+
+	_, err = source.VerifyRandomness(42, newProof(relay, 42, nil))
+	require.Error(err, "VerifyRandomness should fail without a drand signature")
+
+	// Invalid source parameters.
+	params.SourceParameters = cbor.Marshal(&DrandSourceParameters{
+		ChainHash: chainHash,
+	})
+	_, err = params.NewExternalSource()
+	require.Error(err, "NewExternalSource should fail without relays")
+	params.SourceParameters = cbor.Marshal(&DrandSourceParameters{
+		ChainHash: chainHash[:16],
+		Relays:    []signature.PublicKey{relay.Public()},
+	})
+	_, err = params.NewExternalSource()
+	require.Error(err, "NewExternalSource should fail with a malformed chain hash")
+}
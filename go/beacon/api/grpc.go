@@ -26,6 +26,8 @@ var (
 	methodWaitEpoch = serviceName.NewMethod("WaitEpoch", EpochTime(0))
 	// methodGetBeacon is the GetBeacon method.
 	methodGetBeacon = serviceName.NewMethod("GetBeacon", int64(0))
+	// methodGetVRFProofs is the GetVRFProofs method.
+	methodGetVRFProofs = serviceName.NewMethod("GetVRFProofs", int64(0))
 	// methodStateToGenesis is the StateToGenesis method.
 	methodStateToGenesis = serviceName.NewMethod("StateToGenesis", int64(0))
 	// methodConsensusParameters is the ConsensusParameters method.
@@ -63,6 +65,10 @@ var (
 				MethodName: methodGetBeacon.ShortName(),
 				Handler:    handlerGetBeacon,
 			},
+			{
+				MethodName: methodGetVRFProofs.ShortName(),
+				Handler:    handlerGetVRFProofs,
+			},
 			{
 				MethodName: methodStateToGenesis.ShortName(),
 				Handler:    handlerStateToGenesis,
@@ -216,6 +222,29 @@ func handlerGetBeacon(
 	return interceptor(ctx, height, info, handler)
 }
 
+func handlerGetVRFProofs(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var height int64
+	if err := dec(&height); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetVRFProofs(ctx, height)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetVRFProofs.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetVRFProofs(ctx, req.(int64))
+	}
+	return interceptor(ctx, height, info, handler)
+}
+
 func handlerStateToGenesis(
 	srv interface{},
 	ctx context.Context,
@@ -384,6 +413,14 @@ func (c *beaconClient) GetBeacon(ctx context.Context, height int64) ([]byte, err
 	return rsp, nil
 }
 
+func (c *beaconClient) GetVRFProofs(ctx context.Context, height int64) (*VRFProofs, error) {
+	var rsp VRFProofs
+	if err := c.conn.Invoke(ctx, methodGetVRFProofs.FullName(), height, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *beaconClient) StateToGenesis(ctx context.Context, height int64) (*Genesis, error) {
 	var rsp Genesis
 	if err := c.conn.Invoke(ctx, methodStateToGenesis.FullName(), height, &rsp); err != nil {
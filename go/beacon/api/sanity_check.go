@@ -3,7 +3,7 @@ package api
 import (
 	"fmt"
 
-	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+	"github.com/oasisprotocol/oasis-core/go/common/debug"
 )
 
 // SanityCheck does basic sanity checking on the genesis state.
@@ -49,12 +49,24 @@ func (p *ConsensusParameters) SanityCheck() error {
 		if params.ProofSubmissionDelay >= params.Interval {
 			return fmt.Errorf("submission delay must be < epoch interval")
 		}
+	case BackendExternal:
+		params := p.ExternalParameters
+		if params == nil {
+			return fmt.Errorf("external backend not configured")
+		}
+
+		if params.Interval <= 0 && !p.DebugMockBackend {
+			return fmt.Errorf("epoch interval must be > 0")
+		}
+		if _, err := params.NewExternalSource(); err != nil {
+			return fmt.Errorf("invalid external source: %w", err)
+		}
 	default:
 		return fmt.Errorf("unknown backend: '%s'", p.Backend)
 	}
 
 	unsafeFlags := p.DebugMockBackend
-	if unsafeFlags && !flags.DebugDontBlameOasis() {
+	if unsafeFlags && !debug.CapabilityEnabled(debug.CapabilityInsecureBeacon) {
 		return fmt.Errorf("one or more unsafe debug flags set")
 	}
 
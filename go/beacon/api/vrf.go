@@ -1,9 +1,14 @@
 package api
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
+	"fmt"
+	"sort"
 
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/tuplehash"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 )
@@ -19,6 +24,8 @@ var (
 	DefaultVRFGasCosts = transaction.Costs{
 		GasOpVRFProve: 1000,
 	}
+
+	vrfAlphaDomainSep = []byte("oasis-core:vrf/alpha")
 )
 
 // VRFParameters are the beacon parameters for the VRF backend.
@@ -108,6 +115,127 @@ func (ev *VRFEvent) EventKind() string {
 	return "vrf"
 }
 
+// VRFProofs are the VRF proofs from which the VRF alpha of an epoch was derived.
+type VRFProofs struct {
+	// Epoch is the epoch for which the alpha is valid.
+	Epoch EpochTime `json:"epoch"`
+
+	// Alpha is the VRF alpha_string input derived from the proofs.
+	Alpha []byte `json:"alpha"`
+
+	// AlphaIsHighQuality is true iff the alpha was derived from the
+	// proofs. Otherwise the alpha was derived from block entropy and
+	// cannot be independently verified.
+	AlphaIsHighQuality bool `json:"alpha_hq,omitempty"`
+
+	// PrevAlpha is the VRF alpha_string input of the previous epoch,
+	// over which the proofs were generated.
+	PrevAlpha []byte `json:"prev_alpha,omitempty"`
+
+	// Contributions are the VRF proofs submitted by nodes during the
+	// previous epoch, sorted by node identifier.
+	Contributions []*VRFContribution `json:"contributions,omitempty"`
+}
+
+// VRFContribution is a VRF proof submitted by a node.
+type VRFContribution struct {
+	// NodeID is the identifier of the contributing node.
+	NodeID signature.PublicKey `json:"node_id"`
+
+	// Pi is the pi_string (VRF proof) submitted by the node.
+	Pi *signature.Proof `json:"pi"`
+
+	// Beta is the beta_string (VRF output) of the proof.
+	Beta []byte `json:"beta"`
+}
+
+// NewVRFContributions converts the accumulated VRF proofs, keyed by node
+// identifier, into contributions sorted by node identifier.
+func NewVRFContributions(pi map[signature.PublicKey]*signature.Proof) []*VRFContribution {
+	contributions := make([]*VRFContribution, 0, len(pi))
+	for nodeID, proof := range pi {
+		contributions = append(contributions, &VRFContribution{
+			NodeID: nodeID,
+			Pi:     proof,
+			Beta:   proof.UnsafeToHash(), // Ok because invalid proofs don't get stored.
+		})
+	}
+	sort.Slice(contributions, func(i, j int) bool {
+		return bytes.Compare(contributions[i].NodeID[:], contributions[j].NodeID[:]) < 0
+	})
+	return contributions
+}
+
+// Verify verifies that all contributed VRF proofs are valid proofs over
+// the previous alpha, generated by the given nodes, and that the alpha
+// was derived from them.
+//
+// The vrfKeys map must contain the VRF public keys of the nodes that are
+// trusted to contribute (e.g., the validator set), keyed by node
+// identifier.
+func (p *VRFProofs) Verify(chainContext string, vrfKeys map[signature.PublicKey]signature.PublicKey) error {
+	if !p.AlphaIsHighQuality {
+		return fmt.Errorf("beacon: alpha for epoch %d not derived from VRF proofs", p.Epoch)
+	}
+	if len(p.PrevAlpha) == 0 {
+		return fmt.Errorf("beacon: missing previous alpha")
+	}
+
+	pi := make(map[signature.PublicKey]*signature.Proof, len(p.Contributions))
+	for _, c := range p.Contributions {
+		if c.Pi == nil {
+			return fmt.Errorf("beacon: missing proof from node %s", c.NodeID)
+		}
+		if _, ok := pi[c.NodeID]; ok {
+			return fmt.Errorf("beacon: duplicate proof from node %s", c.NodeID)
+		}
+		vrfKey, ok := vrfKeys[c.NodeID]
+		if !ok {
+			return fmt.Errorf("beacon: proof from unknown node %s", c.NodeID)
+		}
+		if !c.Pi.PublicKey.Equal(vrfKey) {
+			return fmt.Errorf("beacon: proof from node %s not generated by its VRF key", c.NodeID)
+		}
+		ok, beta := c.Pi.Verify(p.PrevAlpha)
+		if !ok {
+			return fmt.Errorf("beacon: invalid proof from node %s", c.NodeID)
+		}
+		if !bytes.Equal(beta, c.Beta) {
+			return fmt.Errorf("beacon: beta mismatch for node %s", c.NodeID)
+		}
+		pi[c.NodeID] = c.Pi
+	}
+
+	alpha := DeriveHighQualityVRFAlpha([]byte(chainContext), p.Epoch, pi)
+	if !bytes.Equal(alpha, p.Alpha) {
+		return fmt.Errorf("beacon: alpha not derived from the given proofs")
+	}
+	return nil
+}
+
+// NewVRFAlphaHasher returns a hasher for deriving the VRF alpha of the
+// given epoch.
+func NewVRFAlphaHasher(chainContext []byte, epoch EpochTime) *tuplehash.Hasher {
+	h := tuplehash.New256(32, vrfAlphaDomainSep)
+	_, _ = h.Write(chainContext)
+	var epochBytes [8]byte
+	binary.BigEndian.PutUint64(epochBytes[:], uint64(epoch))
+	_, _ = h.Write(epochBytes[:])
+	return h
+}
+
+// DeriveHighQualityVRFAlpha derives the VRF alpha of the given epoch from
+// the VRF proofs accumulated during the previous epoch.
+//
+// The proofs are assumed to be valid.
+func DeriveHighQualityVRFAlpha(chainContext []byte, epoch EpochTime, pi map[signature.PublicKey]*signature.Proof) []byte {
+	h := NewVRFAlphaHasher(chainContext, epoch)
+	for _, c := range NewVRFContributions(pi) {
+		_, _ = h.Write(c.Beta)
+	}
+	return h.Sum(nil)
+}
+
 // VRFBackend is a Backend that is backed by VRFs.
 type VRFBackend interface {
 	Backend
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+)
+
+func TestVRFProofsVerify(t *testing.T) {
+	require := require.New(t)
+
+	const (
+		chainContext = "test chain context"
+		epoch        = EpochTime(42)
+	)
+	prevAlpha := []byte("previous alpha")
+
+	vrfKeys := make(map[signature.PublicKey]signature.PublicKey)
+	pi := make(map[signature.PublicKey]*signature.Proof)
+	factory := memorySigner.NewFactory()
+	for i := range 3 {
+		nodeSigner := memorySigner.NewTestSigner(fmt.Sprintf("beacon/api: vrf test node %d", i))
+		vrfSigner, err := factory.Generate(signature.SignerVRF, rand.Reader)
+		require.NoError(err, "Generate")
+		proof, err := signature.Prove(vrfSigner, prevAlpha)
+		require.NoError(err, "Prove")
+
+		vrfKeys[nodeSigner.Public()] = vrfSigner.Public()
+		pi[nodeSigner.Public()] = proof
+	}
+
+	newProofs := func() *VRFProofs {
+		return &VRFProofs{
+			Epoch:              epoch,
+			Alpha:              DeriveHighQualityVRFAlpha([]byte(chainContext), epoch, pi),
+			AlphaIsHighQuality: true,
+			PrevAlpha:          prevAlpha,
+			Contributions:      NewVRFContributions(pi),
+		}
+	}
+
+	proofs := newProofs()
+	require.Len(proofs.Contributions, 3)
+	require.NoError(proofs.Verify(chainContext, vrfKeys), "Verify")
+
+	// Different chain context.
+	require.Error(proofs.Verify("other chain context", vrfKeys), "Verify should fail with different chain context")
+
+	// Low quality alpha.
+	proofs = newProofs()
+	proofs.AlphaIsHighQuality = false
+	require.Error(proofs.Verify(chainContext, vrfKeys), "Verify should fail for low quality alpha")
+
+	// Wrong previous alpha.
+	proofs = newProofs()
+	proofs.PrevAlpha = []byte("other alpha")
+	require.Error(proofs.Verify(chainContext, vrfKeys), "Verify should fail with wrong previous alpha")
+
+	// Omitted contribution.
+	proofs = newProofs()
+	proofs.Contributions = proofs.Contributions[1:]
+	require.Error(proofs.Verify(chainContext, vrfKeys), "Verify should fail with omitted contribution")
+
+	// Duplicate contribution.
+	proofs = newProofs()
+	proofs.Contributions = append(proofs.Contributions, proofs.Contributions[0])
+	require.Error(proofs.Verify(chainContext, vrfKeys), "Verify should fail with duplicate contribution")
+
+	// Tampered beta.
+	proofs = newProofs()
+	proofs.Contributions[0].Beta = make([]byte, len(proofs.Contributions[0].Beta))
+	require.Error(proofs.Verify(chainContext, vrfKeys), "Verify should fail with tampered beta")
+
+	// Unknown node.
+	proofs = newProofs()
+	delete(vrfKeys, proofs.Contributions[0].NodeID)
+	require.Error(proofs.Verify(chainContext, vrfKeys), "Verify should fail with unknown node")
+}
@@ -15,6 +15,9 @@ import (
 const (
 	gcInterval     = 5 * time.Minute
 	gcDiscardRatio = 0.5
+
+	// compactionWorkers is the number of workers used for manual compactions.
+	compactionWorkers = 2
 )
 
 // NewLogAdapter returns a badger.Logger backed by an oasis-node logger.
@@ -96,6 +99,28 @@ func (gc *GCWorker) worker() {
 	}
 }
 
+// Compact performs a manual compaction of the given database.
+//
+// All LSM tree levels are compacted into a single level, discarding deleted and expired entries,
+// after which the value log is garbage collected.
+func Compact(db *badger.DB) error {
+	if err := db.Flatten(compactionWorkers); err != nil {
+		return fmt.Errorf("failed to compact LSM tree: %w", err)
+	}
+
+	for {
+		err := db.RunValueLogGC(gcDiscardRatio)
+		switch err {
+		case nil:
+		case badger.ErrNoRewrite, badger.ErrRejected:
+			// Nothing more to collect or the GC worker is already collecting.
+			return nil
+		default:
+			return fmt.Errorf("failed to GC value log: %w", err)
+		}
+	}
+}
+
 // NewGCWorker creates a new BadgerDB value log GC worker for the provided
 // db, logging to the specified logger.
 func NewGCWorker(logger *logging.Logger, db *badger.DB) *GCWorker {
@@ -13,9 +13,9 @@ import (
 	flag "github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
+	"github.com/oasisprotocol/oasis-core/go/common/debug"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/random"
-	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 )
 
 var testForceEnable bool
@@ -150,7 +150,7 @@ func Here(crashPointID string) {
 
 // Here crashes at this point based on the passed in crashPointID's probability.
 func (c *Crasher) Here(crashPointID string) {
-	if !cmdFlags.DebugDontBlameOasis() && !testForceEnable {
+	if !debug.CapabilityEnabled(debug.CapabilityCrashInjection) && !testForceEnable {
 		return
 	}
 
@@ -0,0 +1,144 @@
+// Package ledger implements a Ledger hardware wallet backed signer.
+//
+// The actual device communication is handled by the Oasis Ledger signer
+// plugin (see https://github.com/oasisprotocol/oasis-core-ledger), this
+// package takes care of configuring the plugin, key derivation and of
+// providing the metadata needed to review transactions on the device.
+package ledger
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	pluginSigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/plugin"
+)
+
+const (
+	// SignerName is the name used to identify the Ledger backed signer.
+	SignerName = "ledger"
+
+	// PluginName is the name of the Ledger signer plugin.
+	PluginName = "ledger"
+
+	// PathPurposeBIP44 is set to 44 to indicate the use of the BIP-0044's
+	// hierarchy.
+	PathPurposeBIP44 uint32 = 44
+
+	// ListingPathCoinType is set to 474, the index registered to Oasis in
+	// the SLIP-0044 registry.
+	ListingPathCoinType uint32 = 474
+
+	// ListingPathAccount is the account index used to list and connect to
+	// Ledger devices by Wallet ID.
+	ListingPathAccount uint32 = 0
+
+	// ListingPathChange indicates an external chain.
+	ListingPathChange uint32 = 0
+
+	// ListingPathIndex is the address index used to list and connect to
+	// Ledger devices by Wallet ID.
+	ListingPathIndex uint32 = 0
+
+	hardenedKeyStart uint32 = 0x80000000
+)
+
+// FactoryConfig is the Ledger factory configuration.
+type FactoryConfig struct {
+	// Path is the path to the Ledger signer plugin binary.
+	Path string
+
+	// WalletID is the (optional) hex-encoded identifier of the wallet to
+	// use in case multiple Ledger devices are connected.
+	WalletID string
+
+	// Index is the address index used for key derivation.
+	Index uint32
+}
+
+// PluginConfig returns the Ledger signer plugin configuration string.
+func (cfg *FactoryConfig) PluginConfig() string {
+	parts := []string{fmt.Sprintf("index:%d", cfg.Index)}
+	if cfg.WalletID != "" {
+		parts = append([]string{"wallet_id:" + cfg.WalletID}, parts...)
+	}
+	return strings.Join(parts, ",")
+}
+
+// DerivationPath returns the BIP-0044 derivation path used by the Ledger
+// device to derive the key at the given address index.
+func DerivationPath(index uint32) []uint32 {
+	return []uint32{
+		PathPurposeBIP44,
+		ListingPathCoinType,
+		ListingPathAccount,
+		ListingPathChange,
+		index,
+	}
+}
+
+// FormatDerivationPath formats the derivation path in the usual BIP-0032
+// notation where all components are hardened (e.g., m/44'/474'/0'/0'/1').
+func FormatDerivationPath(path []uint32) string {
+	var b strings.Builder
+	b.WriteString("m")
+	for _, v := range path {
+		b.WriteString("/")
+		b.WriteString(strconv.FormatUint(uint64(v&^hardenedKeyStart), 10))
+		b.WriteString("'")
+	}
+	return b.String()
+}
+
+// ParseDerivationPath parses a derivation path in BIP-0032 notation and
+// returns the address index, ensuring that the path is one that is
+// supported by the Ledger app.
+func ParseDerivationPath(s string) (uint32, error) {
+	parts := strings.Split(strings.TrimSpace(s), "/")
+	expected := DerivationPath(0)
+	if len(parts) != len(expected)+1 || parts[0] != "m" {
+		return 0, fmt.Errorf("signature/signer/ledger: malformed derivation path: '%s'", s)
+	}
+
+	components := make([]uint32, 0, len(expected))
+	for _, p := range parts[1:] {
+		p = strings.TrimRight(p, "'hH")
+		v, err := strconv.ParseUint(p, 10, 31)
+		if err != nil {
+			return 0, fmt.Errorf("signature/signer/ledger: malformed derivation path component '%s': %w", p, err)
+		}
+		components = append(components, uint32(v))
+	}
+	for i := range expected[:len(expected)-1] {
+		if components[i] != expected[i] {
+			return 0, fmt.Errorf("signature/signer/ledger: unsupported derivation path: '%s'", s)
+		}
+	}
+
+	return components[len(components)-1], nil
+}
+
+// NewFactory creates a new factory backed by the Ledger signer plugin.
+func NewFactory(config interface{}, roles ...signature.SignerRole) (signature.SignerFactory, error) {
+	cfg, ok := config.(*FactoryConfig)
+	if !ok {
+		return nil, fmt.Errorf("signature/signer/ledger: invalid Ledger signer configuration provided")
+	}
+	if cfg.Path == "" {
+		return nil, fmt.Errorf("signature/signer/ledger: path to the Ledger signer plugin must be specified")
+	}
+
+	// The Oasis Ledger app can only be used to sign with the entity key.
+	for _, role := range roles {
+		if role != signature.SignerEntity {
+			return nil, fmt.Errorf("signature/signer/ledger: unsupported role: %s", role)
+		}
+	}
+
+	return pluginSigner.NewFactory(&pluginSigner.FactoryConfig{
+		Name:   PluginName,
+		Path:   cfg.Path,
+		Config: cfg.PluginConfig(),
+	}, roles...)
+}
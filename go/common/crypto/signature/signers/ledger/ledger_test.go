@@ -0,0 +1,55 @@
+package ledger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+)
+
+func TestDerivationPath(t *testing.T) {
+	require := require.New(t)
+
+	path := DerivationPath(5)
+	require.Equal("m/44'/474'/0'/0'/5'", FormatDerivationPath(path))
+
+	index, err := ParseDerivationPath("m/44'/474'/0'/0'/5'")
+	require.NoError(err, "ParseDerivationPath")
+	require.EqualValues(5, index)
+
+	for _, s := range []string{
+		"",
+		"m",
+		"44'/474'/0'/0'/5'",
+		"m/44'/60'/0'/0'/5'",
+		"m/44'/474'/0'/0'/x'",
+		"m/44'/474'/0'/0'/5'/1'",
+	} {
+		_, err = ParseDerivationPath(s)
+		require.Error(err, "ParseDerivationPath(%s)", s)
+	}
+}
+
+func TestPluginConfig(t *testing.T) {
+	require := require.New(t)
+
+	cfg := &FactoryConfig{Index: 3}
+	require.Equal("index:3", cfg.PluginConfig())
+
+	cfg.WalletID = "1fc3be0a"
+	require.Equal("wallet_id:1fc3be0a,index:3", cfg.PluginConfig())
+}
+
+func TestNewFactory(t *testing.T) {
+	require := require.New(t)
+
+	_, err := NewFactory(nil, signature.SignerEntity)
+	require.Error(err, "NewFactory should fail with invalid config")
+
+	_, err = NewFactory(&FactoryConfig{}, signature.SignerEntity)
+	require.Error(err, "NewFactory should fail without plugin path")
+
+	_, err = NewFactory(&FactoryConfig{Path: "/nonexistent"}, signature.SignerNode)
+	require.Error(err, "NewFactory should fail with unsupported role")
+}
@@ -0,0 +1,130 @@
+// Package debug implements checks for unsafe debug capabilities.
+//
+// The checks only depend on the settings provided via SetSettingsProvider (which the node
+// command sets up based on its configuration and command line flags), so that library packages
+// can consult them without depending on the node command packages. Unless a provider is set, all
+// unsafe debug behavior is disabled.
+package debug
+
+import (
+	"slices"
+	"sync"
+)
+
+// Unsafe debug capabilities that can be individually enabled.
+const (
+	// CapabilityDummySigstruct allows SGX enclaves without a SIGSTRUCT to be launched using
+	// a dummy SIGSTRUCT.
+	CapabilityDummySigstruct = "dummy_sigstruct"
+	// CapabilityMockAttestation allows the use of mock SGX and skipping attestation report
+	// verification.
+	CapabilityMockAttestation = "mock_attestation"
+	// CapabilityInsecureBeacon allows running a network that uses the insecure or the mock
+	// random beacon.
+	CapabilityInsecureBeacon = "insecure_beacon"
+	// CapabilityTestKeys allows the use of test keys and the test entity.
+	CapabilityTestKeys = "test_keys"
+	// CapabilityUnsafeGenesis allows genesis documents and consensus parameters that are
+	// unsafe for production networks (e.g., debug parameters and tiny intervals).
+	CapabilityUnsafeGenesis = "unsafe_genesis"
+	// CapabilityUnsafeConsensus allows unsafe consensus behavior, like forced committee
+	// elections and recovering a corrupted consensus WAL.
+	CapabilityUnsafeConsensus = "unsafe_consensus"
+	// CapabilityUnsafeP2P allows lenient consensus P2P address book handling.
+	CapabilityUnsafeP2P = "unsafe_p2p"
+	// CapabilityUnsafeRuntimeHost allows unsafe runtime host configurations, like the mock
+	// and unconfined provisioners and runtimes in the ELF environment.
+	CapabilityUnsafeRuntimeHost = "unsafe_runtime_host"
+	// CapabilityProtocolCapture allows capturing the runtime host protocol.
+	CapabilityProtocolCapture = "protocol_capture"
+	// CapabilityCrashInjection allows injecting crashes at predefined crash points.
+	CapabilityCrashInjection = "crash_injection"
+	// CapabilityDebugServices allows exposing debug services (e.g., debug control, direct
+	// storage access, pushed metrics).
+	CapabilityDebugServices = "debug_services"
+	// CapabilityUnsafeProcess allows running the node as root and changing its resource
+	// limits.
+	CapabilityUnsafeProcess = "unsafe_process"
+)
+
+// AllCapabilities are all of the supported unsafe debug capabilities.
+var AllCapabilities = []string{
+	CapabilityDummySigstruct,
+	CapabilityMockAttestation,
+	CapabilityInsecureBeacon,
+	CapabilityTestKeys,
+	CapabilityUnsafeGenesis,
+	CapabilityUnsafeConsensus,
+	CapabilityUnsafeP2P,
+	CapabilityUnsafeRuntimeHost,
+	CapabilityProtocolCapture,
+	CapabilityCrashInjection,
+	CapabilityDebugServices,
+	CapabilityUnsafeProcess,
+}
+
+// Settings are the unsafe debug settings.
+type Settings struct {
+	// DontBlameOasis is true iff the "don't blame oasis" flag is set.
+	DontBlameOasis bool
+	// Capabilities are the unsafe debug capabilities to enable.
+	Capabilities []string
+}
+
+var (
+	settingsLock     sync.RWMutex
+	settingsProvider func() *Settings
+)
+
+// SetSettingsProvider sets the function used to obtain the current unsafe debug settings.
+//
+// The provider is consulted on every check so that it can reflect configuration changes.
+func SetSettingsProvider(provider func() *Settings) {
+	settingsLock.Lock()
+	defer settingsLock.Unlock()
+
+	settingsProvider = provider
+}
+
+func getSettings() *Settings {
+	settingsLock.RLock()
+	provider := settingsProvider
+	settingsLock.RUnlock()
+
+	if provider == nil {
+		return &Settings{}
+	}
+	return provider()
+}
+
+// DontBlameOasis returns true iff the "don't blame oasis" flag is set.
+func DontBlameOasis() bool {
+	return getSettings().DontBlameOasis
+}
+
+// CapabilityEnabled returns true iff the given unsafe debug capability is enabled.
+//
+// Capabilities are only ever enabled in case the "don't blame oasis" flag is set.
+func CapabilityEnabled(capability string) bool {
+	settings := getSettings()
+	if !settings.DontBlameOasis {
+		return false
+	}
+	return slices.Contains(settings.Capabilities, capability)
+}
+
+// Capabilities returns the sorted list of enabled unsafe debug capabilities.
+func Capabilities() []string {
+	settings := getSettings()
+	if !settings.DontBlameOasis {
+		return nil
+	}
+	var capabilities []string
+	for _, capability := range settings.Capabilities {
+		if slices.Contains(AllCapabilities, capability) {
+			capabilities = append(capabilities, capability)
+		}
+	}
+	slices.Sort(capabilities)
+	return slices.Compact(capabilities)
+}
@@ -29,9 +29,10 @@ var (
 var registeredErrors sync.Map
 
 type codedError struct {
-	module string
-	code   uint32
-	msg    string
+	module    string
+	code      uint32
+	msg       string
+	retriable bool
 }
 
 func (e *codedError) Error() string {
@@ -76,6 +77,47 @@ func Context(err error) string {
 	return ""
 }
 
+type retriableError struct {
+	err error
+}
+
+func (e *retriableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retriableError) Unwrap() error {
+	return e.err
+}
+
+// Retriable creates a wrapped error that is marked as retriable.
+func Retriable(err error) error {
+	if err == nil || IsRetriable(err) {
+		return err
+	}
+
+	return &retriableError{
+		err: err,
+	}
+}
+
+// IsRetriable returns true iff the given error represents a transient failure and the
+// operation that caused it may succeed if retried.
+func IsRetriable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var re *retriableError
+	if As(err, &re) {
+		return true
+	}
+	var ce *codedError
+	if As(err, &ce) {
+		return ce.retriable
+	}
+	return false
+}
+
 // New creates a new error.
 //
 // Module and code pair must be unique. If they are not, this method
@@ -83,14 +125,26 @@ func Context(err error) string {
 //
 // The error code must not be equal to the reserved "no error" code.
 func New(module string, code uint32, msg string) error {
+	return newCodedError(module, code, msg, false)
+}
+
+// NewRetriable creates a new error that represents a transient failure.
+//
+// The same requirements as for New apply.
+func NewRetriable(module string, code uint32, msg string) error {
+	return newCodedError(module, code, msg, true)
+}
+
+func newCodedError(module string, code uint32, msg string, retriable bool) error {
 	if code == CodeNoError {
 		panic(fmt.Errorf("error: code reserved 'no error' code: %d", CodeNoError))
 	}
 
 	e := &codedError{
-		module: module,
-		code:   code,
-		msg:    msg,
+		module:    module,
+		code:      code,
+		msg:       msg,
+		retriable: retriable,
 	}
 
 	key := errorKey(module, code)
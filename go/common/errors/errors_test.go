@@ -82,3 +82,34 @@ func TestErrors(t *testing.T) {
 	err = FromCode("test/errors", 3, "a test error occurred")
 	require.Equal(New("test/errors", 3, "a test error occurred"), err)
 }
+
+func TestRetriable(t *testing.T) {
+	require := require.New(t)
+
+	errPermanent := New("test/errors/retriable", 1, "test: permanent error")
+	errTransient := NewRetriable("test/errors/retriable", 2, "test: transient error")
+
+	require.False(IsRetriable(nil))
+	require.False(IsRetriable(errPermanent))
+	require.False(IsRetriable(fmt.Errorf("a different kind of error")))
+	require.True(IsRetriable(errTransient))
+	require.True(IsRetriable(fmt.Errorf("wrapped: %w", errTransient)))
+	require.True(IsRetriable(WithContext(errTransient, "test context")))
+
+	// Registering a retriable error should still enforce uniqueness.
+	require.Panics(func() { _ = NewRetriable("test/errors/retriable", 1, "test: duplicate") })
+
+	// Errors reconstructed from code should retain the flag.
+	require.True(IsRetriable(FromCode("test/errors/retriable", 2, "test: transient error: ctx")))
+
+	// Marking errors as retriable should preserve the module and code.
+	err := Retriable(errPermanent)
+	require.True(IsRetriable(err))
+	require.True(Is(err, errPermanent))
+	require.Equal(errPermanent.Error(), err.Error())
+	module, code := Code(err)
+	require.Equal("test/errors/retriable", module)
+	require.EqualValues(1, code)
+	require.Equal(errTransient, Retriable(errTransient), "retriable errors should not be wrapped again")
+	require.Nil(Retriable(nil))
+}
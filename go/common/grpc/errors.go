@@ -38,8 +38,36 @@ func GetErrorStatus(err error) *status.Status {
 
 // grpcError is a serializable error.
 type grpcError struct {
-	Module string `json:"module,omitempty"`
-	Code   uint32 `json:"code,omitempty"`
+	Module    string `json:"module,omitempty"`
+	Code      uint32 `json:"code,omitempty"`
+	Retriable bool   `json:"retriable,omitempty"`
+}
+
+// grpcErrorFromStatus extracts the serialized error from gRPC status details.
+func grpcErrorFromStatus(s *status.Status) (*grpcError, bool) {
+	sp := s.Proto()
+	if len(sp.Details) != 1 {
+		return nil, false
+	}
+	var ge grpcError
+	if err := cbor.Unmarshal(sp.Details[0].Value, &ge); err != nil {
+		return nil, false
+	}
+	return &ge, true
+}
+
+// IsRetriable returns true if the given error represents a transient failure, either because
+// the error has been marked as retriable or because the remote end reported it as such.
+func IsRetriable(err error) bool {
+	if errors.IsRetriable(err) {
+		return true
+	}
+	s, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+	ge, ok := grpcErrorFromStatus(s)
+	return ok && ge.Retriable
 }
 
 func errorToGrpc(err error) error {
@@ -65,7 +93,11 @@ func errorToGrpc(err error) error {
 			{
 				// Double serialization seems ugly, but there is no way around
 				// it as the format for errors is predefined.
-				Value: cbor.Marshal(&grpcError{Module: module, Code: code}),
+				Value: cbor.Marshal(&grpcError{
+					Module:    module,
+					Code:      code,
+					Retriable: errors.IsRetriable(err),
+				}),
 			},
 		},
 	}).Err()
@@ -78,16 +110,16 @@ func errorFromGrpc(err error) error {
 
 	// Convert the error back.
 	if s, ok := status.FromError(err); ok {
-		sp := s.Proto()
-		if len(sp.Details) != 1 {
-			return err
-		}
-		var ge grpcError
-		if cerr := cbor.Unmarshal(sp.Details[0].Value, &ge); cerr != nil {
+		ge, ok := grpcErrorFromStatus(s)
+		if !ok {
 			return err
 		}
 
-		if mappedErr := errors.FromCode(ge.Module, ge.Code, sp.Message); mappedErr != nil {
+		if mappedErr := errors.FromCode(ge.Module, ge.Code, s.Message()); mappedErr != nil {
+			if ge.Retriable {
+				// Preserve the retriable flag even when the error is not known locally.
+				mappedErr = errors.Retriable(mappedErr)
+			}
 			return mappedErr
 		}
 	}
@@ -136,5 +168,18 @@ func clientStreamErrorMapper(
 	opts ...grpc.CallOption,
 ) (grpc.ClientStream, error) {
 	cs, err := streamer(ctx, desc, cc, method, opts...)
-	return cs, errorFromGrpc(err)
+	if err != nil {
+		return nil, errorFromGrpc(err)
+	}
+	return &errorMappingClientStream{cs}, nil
+}
+
+// errorMappingClientStream is a client stream that maps errors reported by the remote end
+// while receiving messages.
+type errorMappingClientStream struct {
+	grpc.ClientStream
+}
+
+func (cs *errorMappingClientStream) RecvMsg(m interface{}) error {
+	return errorFromGrpc(cs.ClientStream.RecvMsg(m))
 }
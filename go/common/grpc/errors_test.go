@@ -6,16 +6,21 @@ import (
 	"os"
 	"testing"
 
+	any "github.com/golang/protobuf/ptypes/any"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
 
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
 )
 
-var errTest = errors.New("test/grpc/errors", 1, "just testing errors")
+var (
+	errTest          = errors.New("test/grpc/errors", 1, "just testing errors")
+	errTestRetriable = errors.NewRetriable("test/grpc/errors", 2, "just testing retriable errors")
+)
 
 type ErrorTestRequest struct{}
 
@@ -25,6 +30,7 @@ type ErrorTestService interface {
 	ErrorTest(context.Context, *ErrorTestRequest) (*ErrorTestResponse, error)
 	ErrorTestWithContext(context.Context, *ErrorTestRequest) (*ErrorTestResponse, error)
 	ErrorStatusTest(context.Context, *ErrorTestRequest) (*ErrorTestResponse, error)
+	ErrorRetriableTest(context.Context, *ErrorTestRequest) (*ErrorTestResponse, error)
 }
 
 type errorTestServer struct{}
@@ -41,6 +47,10 @@ func (s *errorTestServer) ErrorStatusTest(context.Context, *ErrorTestRequest) (*
 	return nil, io.ErrUnexpectedEOF
 }
 
+func (s *errorTestServer) ErrorRetriableTest(context.Context, *ErrorTestRequest) (*ErrorTestResponse, error) {
+	return nil, errTestRetriable
+}
+
 type errorTestClient struct {
 	cc *grpc.ClientConn
 }
@@ -72,6 +82,15 @@ func (c *errorTestClient) ErrorStatusTest(ctx context.Context, req *ErrorTestReq
 	return rsp, nil
 }
 
+func (c *errorTestClient) ErrorRetriableTest(ctx context.Context, req *ErrorTestRequest) (*ErrorTestResponse, error) {
+	rsp := new(ErrorTestResponse)
+	err := c.cc.Invoke(ctx, "/ErrorTestService/ErrorRetriableTest", req, rsp)
+	if err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
 var errorTestServiceDesc = grpc.ServiceDesc{
 	ServiceName: "ErrorTestService",
 	HandlerType: (*ErrorTestService)(nil),
@@ -88,6 +107,10 @@ var errorTestServiceDesc = grpc.ServiceDesc{
 			MethodName: "ErrorStatusTest",
 			Handler:    handlerErrorStatusTest,
 		},
+		{
+			MethodName: "ErrorRetriableTest",
+			Handler:    handlerErrorRetriableTest,
+		},
 	},
 	Streams: []grpc.StreamDesc{},
 }
@@ -161,6 +184,29 @@ func handlerErrorStatusTest(
 	return interceptor(ctx, req, info, handler)
 }
 
+func handlerErrorRetriableTest(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	req := new(ErrorTestRequest)
+	if err := dec(req); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ErrorTestService).ErrorRetriableTest(ctx, req)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ErrorTestService/ErrorRetriableTest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ErrorTestService).ErrorRetriableTest(ctx, req.(*ErrorTestRequest))
+	}
+	return interceptor(ctx, req, info, handler)
+}
+
 func TestErrorMapping(t *testing.T) {
 	require := require.New(t)
 
@@ -198,6 +244,13 @@ func TestErrorMapping(t *testing.T) {
 	require.Equal("just testing errors: my test context", err.Error())
 	require.Equal("my test context", errors.Context(err))
 
+	require.False(IsRetriable(err), "permanent errors should not be retriable")
+
+	_, err = client.ErrorRetriableTest(context.Background(), &ErrorTestRequest{})
+	require.Error(err, "ErrorRetriableTest should return an error")
+	require.Equal(errTestRetriable, err, "errors should be properly mapped")
+	require.True(IsRetriable(err), "retriable errors should be retriable")
+
 	_, err = client.ErrorStatusTest(context.Background(), &ErrorTestRequest{})
 	require.Error(err, "ErrorStatusTest should return an error")
 	require.True(IsErrorCode(err, codes.Unknown), "ErrorStatusTest should have code unknown")
@@ -206,3 +259,21 @@ func TestErrorMapping(t *testing.T) {
 	s, _ := status.FromError(io.ErrUnexpectedEOF)
 	require.Equal(s.Err().Error(), st.Err().Error(), "GetErrorStatus.Status should be io.ErrUnexpectedEOF")
 }
+
+func TestErrorMappingRetriable(t *testing.T) {
+	require := require.New(t)
+
+	// Retriable errors should be mapped to status details.
+	err := errorToGrpc(errors.WithContext(errTestRetriable, "ctx"))
+	require.True(IsRetriable(err), "status details should carry the retriable flag")
+	require.False(IsRetriable(errorToGrpc(errTest)))
+
+	// Errors unknown to the client should retain the retriable flag.
+	ge := status.New(codes.Unknown, "unknown error").Proto()
+	ge.Details = []*any.Any{{Value: cbor.Marshal(&grpcError{Module: "test/grpc/unknown", Code: 1, Retriable: true})}}
+	err = errorFromGrpc(status.FromProto(ge).Err())
+	require.True(errors.IsRetriable(err), "unknown retriable errors should be retriable")
+	module, code := errors.Code(err)
+	require.Equal("test/grpc/unknown", module)
+	require.EqualValues(1, code)
+}
@@ -20,7 +20,10 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/grpclog"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
 
 	cmnTLS "github.com/oasisprotocol/oasis-core/go/common/crypto/tls"
 	"github.com/oasisprotocol/oasis-core/go/common/grpc/auth"
@@ -93,6 +96,9 @@ var (
 		grpcClientCalls,
 		grpcClientLatency,
 		grpcClientStreamWrites,
+		grpcClientRetries,
+		grpcClientCircuitBreakerState,
+		grpcClientCircuitBreakerRejections,
 		grpcServerCalls,
 		grpcServerLatency,
 		grpcServerStreamWrites,
@@ -418,6 +424,7 @@ type Server struct {
 	unsafeDebug bool
 
 	wrapper *grpcWrapper
+	health  *health.Server
 }
 
 // ServerConfig holds the configuration used for creating a server.
@@ -443,11 +450,23 @@ type ServerConfig struct { // nolint: maligned
 	ClientCommonName string
 	// CustomOptions is an array of extra options for the grpc server.
 	CustomOptions []grpc.ServerOption
+	// EnableIntrospection specifies whether the standard gRPC health (grpc.health.v1) and
+	// server reflection services should be registered on this server.
+	EnableIntrospection bool
+	// Listeners are additional listeners on which the server exposes (a subset of) its services.
+	//
+	// Additional listeners are only supported on servers that do not use TLS themselves.
+	Listeners []ListenerConfig
 }
 
 type listenerConfig struct {
 	network string
 	address string
+
+	// The following fields are only set for additional listeners.
+	name     string
+	services map[string]bool
+	creds    credentials.TransportCredentials
 }
 
 // Start starts the Server.
@@ -466,9 +485,28 @@ func (s *Server) Start() error {
 		s.Logger.Warn("The debug gRPC port is NOT FOR PRODUCTION USE.")
 	}
 
+	if s.health != nil {
+		// All services have been registered by now, so report them as serving.
+		for name := range server.GetServiceInfo() {
+			s.health.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+		}
+	}
+
+	serviceInfo := server.GetServiceInfo()
+	for _, cfg := range s.listenerCfgs {
+		for name := range cfg.services {
+			if _, ok := serviceInfo[name]; !ok {
+				s.Logger.Warn("service exposed on listener is not registered",
+					"listener", cfg.name,
+					"service", name,
+				)
+			}
+		}
+	}
+
 	var wg sync.WaitGroup
-	for _, v := range s.listenerCfgs {
-		cfg := v
+	for i := range s.listenerCfgs {
+		cfg := &s.listenerCfgs[i]
 
 		ln, err := net.Listen(cfg.network, cfg.address)
 		if err != nil {
@@ -477,7 +515,14 @@ func (s *Server) Start() error {
 			)
 			return err
 		}
-		s.Logger.Info("gRPC server started", "network", cfg.network, "address", cfg.address)
+		if cfg.name != "" {
+			ln = &taggedListener{Listener: ln, cfg: cfg}
+		}
+		s.Logger.Info("gRPC server started",
+			"network", cfg.network,
+			"address", cfg.address,
+			"listener", cfg.name,
+		)
 
 		s.startedListeners = append(s.startedListeners, ln)
 
@@ -524,6 +569,10 @@ func (s *Server) Stop() {
 		default:
 		}
 
+		if s.health != nil {
+			s.health.Shutdown()
+		}
+
 		// Attempt to stop gracefully, but if that doesn't work, stop forcibly.
 		gracefulCh := make(chan struct{})
 		go func() {
@@ -595,6 +644,37 @@ func NewServer(config *ServerConfig) (*Server, error) {
 		clientAuthType = tls.NoClientCert
 	}
 
+	var listenerCreds bool
+	if len(config.Listeners) > 0 && config.Identity != nil && config.Identity.TLSCertificate != nil {
+		return nil, fmt.Errorf("grpc: additional listeners are not supported on TLS servers")
+	}
+	for i := range config.Listeners {
+		l := &config.Listeners[i]
+		if err := l.validate(); err != nil {
+			return nil, fmt.Errorf("grpc: %w", err)
+		}
+
+		cfg := listenerConfig{
+			network: l.Network,
+			address: l.Address,
+			name:    l.Name,
+			creds:   l.tlsCredentials(),
+		}
+		if len(l.Services) > 0 {
+			cfg.services = make(map[string]bool)
+			for _, name := range l.Services {
+				cfg.services[name] = true
+			}
+		}
+		if cfg.creds != nil {
+			listenerCreds = true
+		}
+		if l.Network == "unix" {
+			_ = os.Remove(l.Address)
+		}
+		listenerParams = append(listenerParams, cfg)
+	}
+
 	grpcMetricsOnce.Do(func() {
 		prometheus.MustRegister(grpcCollectors...)
 	})
@@ -618,16 +698,22 @@ func NewServer(config *ServerConfig) (*Server, error) {
 		config.ClientCommonName = identity.CommonName
 	}
 	var wrapper *grpcWrapper
-	unaryInterceptors := []grpc.UnaryServerInterceptor{
+	var unaryInterceptors []grpc.UnaryServerInterceptor
+	var streamInterceptors []grpc.StreamServerInterceptor
+	if len(config.Listeners) > 0 {
+		unaryInterceptors = append(unaryInterceptors, serverUnaryListenerFilter)
+		streamInterceptors = append(streamInterceptors, serverStreamListenerFilter)
+	}
+	unaryInterceptors = append(unaryInterceptors,
 		logAdapter.unaryLogger,
 		serverUnaryErrorMapper,
 		auth.UnaryServerInterceptor(config.AuthFunc),
-	}
-	streamInterceptors := []grpc.StreamServerInterceptor{
+	)
+	streamInterceptors = append(streamInterceptors,
 		logAdapter.streamLogger,
 		serverStreamErrorMapper,
 		auth.StreamServerInterceptor(config.AuthFunc),
-	}
+	)
 	if config.InstallWrapper {
 		wrapper = newWrapper()
 		unaryInterceptors = append(unaryInterceptors, wrapper.unaryInterceptor)
@@ -658,20 +744,36 @@ func NewServer(config *ServerConfig) (*Server, error) {
 
 		sOpts = append(sOpts, grpc.Creds(credentials.NewTLS(tlsConfig)))
 	}
+	if listenerCreds {
+		sOpts = append(sOpts, grpc.Creds(newListenerCredentials()))
+	}
 	sOpts = append(sOpts, config.CustomOptions...)
 
+	server := grpc.NewServer(sOpts...)
+
+	var healthServer *health.Server
+	if config.EnableIntrospection {
+		healthServer = health.NewServer()
+		healthpb.RegisterHealthServer(server, healthServer)
+		reflection.Register(server)
+	}
+
 	return &Server{
 		BaseBackgroundService: svc,
 		listenerCfgs:          listenerParams,
 		startedListeners:      []net.Listener{},
-		server:                grpc.NewServer(sOpts...),
+		server:                server,
 		errCh:                 make(chan error, len(listenerParams)),
 		unsafeDebug:           unsafeDebug,
 		wrapper:               wrapper,
+		health:                healthServer,
 	}, nil
 }
 
 // Dial creates a client connection to the given target.
+//
+// A call policy (retries, timeouts, hedging and circuit breaking) can be configured for unary
+// calls via the WithCallPolicy option.
 func Dial(target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
 	// If debug gRPC logs are enabled, setup the global gRPC logger.
 	if viper.GetBool(CfgLogDebug) {
@@ -700,6 +802,13 @@ func Dial(target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
 		grpc.WithChainUnaryInterceptor(logAdapter.unaryClientLogger, clientUnaryErrorMapper),
 		grpc.WithChainStreamInterceptor(logAdapter.streamClientLogger, clientStreamErrorMapper),
 	}
+	for _, opt := range opts {
+		if cpo, ok := opt.(callPolicyOption); ok {
+			// Apply the policy after error mapping so that it sees the raw gRPC status.
+			ci := newCallPolicyInterceptor(target, cpo.policy)
+			dialOpts = append(dialOpts, grpc.WithChainUnaryInterceptor(ci.unaryInterceptor))
+		}
+	}
 	dialOpts = append(dialOpts, opts...)
 	return grpc.Dial(target, dialOpts...)
 }
@@ -1,9 +1,19 @@
 package grpc
 
 import (
+	"context"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
 )
 
 func TestIsLocalRPC(t *testing.T) {
@@ -43,3 +53,116 @@ func TestIsLocalRPC(t *testing.T) {
 		require.Equal(t, tc.expected, IsLocalAddress(tc.addr), tc.name+": "+tc.addr)
 	}
 }
+
+func TestIntrospection(t *testing.T) {
+	require := require.New(t)
+
+	// Generate temporary filename for the socket.
+	f, err := os.CreateTemp("", "oasis-grpc-introspection-test-socket")
+	require.NoError(err, "TempFile")
+	// Remove the file as we only need the name.
+	f.Close()
+	os.Remove(f.Name())
+
+	cfg := &ServerConfig{
+		Path:                f.Name(),
+		EnableIntrospection: true,
+	}
+	grpcServer, err := NewServer(cfg)
+	require.NoError(err, "NewServer")
+	defer os.Remove(f.Name())
+
+	grpcServer.Server().RegisterService(&errorTestServiceDesc, &errorTestServer{})
+
+	err = grpcServer.Start()
+	require.NoErrorf(err, "Failed to start the gRPC server")
+	defer grpcServer.Stop()
+
+	conn, err := Dial("unix:"+f.Name(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(err, "Dial")
+	defer conn.Close()
+
+	ctx := context.Background()
+
+	// Health checks.
+	healthClient := healthpb.NewHealthClient(conn)
+	rsp, err := healthClient.Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(err, "Check")
+	require.Equal(healthpb.HealthCheckResponse_SERVING, rsp.GetStatus())
+
+	rsp, err = healthClient.Check(ctx, &healthpb.HealthCheckRequest{Service: errorTestServiceDesc.ServiceName})
+	require.NoError(err, "Check")
+	require.Equal(healthpb.HealthCheckResponse_SERVING, rsp.GetStatus())
+
+	// Server reflection.
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	require.NoError(err, "ServerReflectionInfo")
+	err = stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	})
+	require.NoError(err, "Send")
+	refRsp, err := stream.Recv()
+	require.NoError(err, "Recv")
+
+	var services []string
+	for _, svc := range refRsp.GetListServicesResponse().GetService() {
+		services = append(services, svc.GetName())
+	}
+	require.Contains(services, errorTestServiceDesc.ServiceName, "registered services should be listed")
+	require.Contains(services, healthpb.Health_ServiceDesc.ServiceName, "health service should be listed")
+}
+
+func TestListenerServices(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	primaryPath := dir + "/primary.sock"
+	listenerPath := dir + "/listener.sock"
+
+	cfg := &ServerConfig{
+		Path:                primaryPath,
+		EnableIntrospection: true,
+		Listeners: []ListenerConfig{
+			{
+				Name:     "health",
+				Network:  "unix",
+				Address:  listenerPath,
+				Services: []string{healthpb.Health_ServiceDesc.ServiceName},
+			},
+		},
+	}
+	grpcServer, err := NewServer(cfg)
+	require.NoError(err, "NewServer")
+
+	grpcServer.Server().RegisterService(&errorTestServiceDesc, &errorTestServer{})
+
+	err = grpcServer.Start()
+	require.NoErrorf(err, "Failed to start the gRPC server")
+	defer grpcServer.Stop()
+
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		path             string
+		errorTestAllowed bool
+	}{
+		{primaryPath, true},
+		{listenerPath, false},
+	} {
+		conn, err := Dial("unix:"+tc.path, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		require.NoError(err, "Dial")
+		defer conn.Close()
+
+		// The health service is exposed on all listeners.
+		_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+		require.NoError(err, "Check")
+
+		_, err = (&errorTestClient{conn}).ErrorTest(ctx, &ErrorTestRequest{})
+		require.Error(err, "ErrorTest")
+		if tc.errorTestAllowed {
+			require.True(errors.Is(err, errTest), "service should be exposed on %s", tc.path)
+		} else {
+			require.Equal(codes.Unimplemented, status.Code(err), "service should be hidden on %s", tc.path)
+		}
+	}
+}
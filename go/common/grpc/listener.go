@@ -0,0 +1,217 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	cmnTLS "github.com/oasisprotocol/oasis-core/go/common/crypto/tls"
+	"github.com/oasisprotocol/oasis-core/go/common/identity"
+)
+
+// ListenerConfig is the configuration of an additional listener on which a server exposes
+// (a subset of) its services.
+type ListenerConfig struct {
+	// Name is the name of the listener.
+	Name string
+	// Network is the listener network (tcp or unix).
+	Network string
+	// Address is the listener address.
+	Address string
+	// Services is the set of gRPC service names (e.g., oasis-core.Consensus) exposed on the
+	// listener. If empty, all services registered on the server are exposed.
+	Services []string
+	// Identity is the identity used to serve TLS on the listener. If nil, TLS is not used.
+	Identity *identity.Identity
+	// AuthorizedPubkeys is the set of client TLS public keys that are allowed to connect. If
+	// empty, any client is allowed to connect. Requires Identity to be set.
+	AuthorizedPubkeys map[signature.PublicKey]bool
+}
+
+func (c *ListenerConfig) validate() error {
+	switch c.Network {
+	case "tcp", "unix":
+	default:
+		return fmt.Errorf("listener '%s': unsupported network: %s", c.Name, c.Network)
+	}
+	if c.Address == "" {
+		return fmt.Errorf("listener '%s': missing address", c.Name)
+	}
+	if len(c.AuthorizedPubkeys) > 0 && c.Identity == nil {
+		return fmt.Errorf("listener '%s': client authorization requires TLS", c.Name)
+	}
+	if c.Identity != nil && c.Identity.TLSCertificate == nil {
+		return fmt.Errorf("listener '%s': identity is missing a TLS certificate", c.Name)
+	}
+	return nil
+}
+
+func (c *ListenerConfig) tlsCredentials() credentials.TransportCredentials {
+	if c.Identity == nil {
+		return nil
+	}
+
+	clientAuth := tls.RequestClientCert
+	if len(c.AuthorizedPubkeys) > 0 {
+		clientAuth = tls.RequireAnyClientCert
+	}
+	tlsConfig := &tls.Config{
+		ClientAuth: clientAuth,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			var keys map[signature.PublicKey]bool
+			if len(c.AuthorizedPubkeys) > 0 {
+				keys = c.AuthorizedPubkeys
+			}
+			return cmnTLS.VerifyCertificate(rawCerts, cmnTLS.VerifyOptions{
+				CommonName:         identity.CommonName,
+				Keys:               keys,
+				AllowUnknownKeys:   keys == nil,
+				AllowNoCertificate: keys == nil,
+			})
+		},
+		GetCertificate: func(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return c.Identity.TLSCertificate, nil
+		},
+	}
+	return credentials.NewTLS(tlsConfig)
+}
+
+// listenerAddr is the local address of connections accepted on an additional listener.
+//
+// It is used to recover the listener a request arrived on from the request's peer information.
+type listenerAddr struct {
+	net.Addr
+
+	listener *listenerConfig
+}
+
+type listenerConn struct {
+	net.Conn
+
+	addr *listenerAddr
+}
+
+func (c *listenerConn) LocalAddr() net.Addr {
+	return c.addr
+}
+
+type taggedListener struct {
+	net.Listener
+
+	cfg *listenerConfig
+}
+
+func (l *taggedListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return &listenerConn{
+		Conn: conn,
+		addr: &listenerAddr{
+			Addr:     conn.LocalAddr(),
+			listener: l.cfg,
+		},
+	}, nil
+}
+
+// listenerFromContext returns the configuration of the listener that the request in the given
+// context arrived on or nil in case the request arrived on one of the server's primary listeners.
+func listenerFromContext(ctx context.Context) *listenerConfig {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	addr, ok := p.LocalAddr.(*listenerAddr)
+	if !ok {
+		return nil
+	}
+	return addr.listener
+}
+
+func checkListenerService(ctx context.Context, fullMethod string) error {
+	cfg := listenerFromContext(ctx)
+	if cfg == nil || cfg.services == nil {
+		return nil
+	}
+
+	// Full method names are of the form /service/method.
+	serviceName, _, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !cfg.services[serviceName] {
+		// Pretend that the service does not exist, the same as gRPC does for unknown services.
+		return status.Errorf(codes.Unimplemented, "unknown service %v", serviceName)
+	}
+	return nil
+}
+
+func serverUnaryListenerFilter(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if err := checkListenerService(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func serverStreamListenerFilter(
+	srv interface{},
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if err := checkListenerService(stream.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, stream)
+}
+
+// listenerCredentials are server transport credentials that perform the TLS handshake only for
+// connections accepted on additional listeners that are configured to use TLS.
+type listenerCredentials struct {
+	insecure credentials.TransportCredentials
+}
+
+func newListenerCredentials() credentials.TransportCredentials {
+	return &listenerCredentials{
+		insecure: insecure.NewCredentials(),
+	}
+}
+
+func (c *listenerCredentials) ClientHandshake(context.Context, string, net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return nil, nil, fmt.Errorf("grpc: listener credentials are server-only")
+}
+
+func (c *listenerCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	if addr, ok := conn.LocalAddr().(*listenerAddr); ok && addr.listener.creds != nil {
+		return addr.listener.creds.ServerHandshake(conn)
+	}
+	return c.insecure.ServerHandshake(conn)
+}
+
+func (c *listenerCredentials) Info() credentials.ProtocolInfo {
+	return c.insecure.Info()
+}
+
+func (c *listenerCredentials) Clone() credentials.TransportCredentials {
+	return &listenerCredentials{
+		insecure: c.insecure.Clone(),
+	}
+}
+
+func (c *listenerCredentials) OverrideServerName(string) error {
+	return nil
+}
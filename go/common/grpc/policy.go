@@ -0,0 +1,368 @@
+package grpc
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	circuitBreakerClosed   = 0
+	circuitBreakerOpen     = 1
+	circuitBreakerHalfOpen = 2
+)
+
+var (
+	grpcClientRetries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_grpc_client_retries",
+			Help: "Number of gRPC call retries (including hedged attempts).",
+		},
+		[]string{"call"},
+	)
+	grpcClientCircuitBreakerState = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "oasis_grpc_client_circuit_breaker_state",
+			Help: "gRPC client circuit breaker state (0 = closed, 1 = open, 2 = half-open).",
+		},
+		[]string{"target"},
+	)
+	grpcClientCircuitBreakerRejections = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_grpc_client_circuit_breaker_rejections",
+			Help: "Number of gRPC calls rejected by an open circuit breaker.",
+		},
+		[]string{"target"},
+	)
+
+	// DefaultCallPolicy is the default call policy for connections to remote nodes.
+	DefaultCallPolicy = CallPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     2 * time.Second,
+		CircuitBreaker: &CircuitBreakerPolicy{
+			FailureThreshold: 5,
+			OpenDuration:     10 * time.Second,
+		},
+	}
+)
+
+// CallPolicy is the policy applied to unary calls made over a client connection.
+//
+// Streaming calls are not affected by the policy.
+type CallPolicy struct {
+	// Timeout is the timeout of each individual attempt. Zero means no timeout.
+	Timeout time.Duration
+
+	// MaxAttempts is the maximum number of attempts (including the first one). Zero or one
+	// means that calls are never retried.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+
+	// MaxBackoff is the maximum delay between retries.
+	MaxBackoff time.Duration
+
+	// HedgingDelay enables hedging when non-zero. Instead of waiting for an attempt to fail
+	// before retrying, a new attempt is started after the given delay if no response has been
+	// received yet. The first non-retryable response wins and other attempts are canceled.
+	HedgingDelay time.Duration
+
+	// RetryableCodes are the status codes that cause an attempt to be retried. If empty,
+	// only codes.Unavailable is retried. Errors reported as retriable by the remote end are
+	// always retried.
+	RetryableCodes []codes.Code
+
+	// CircuitBreaker is the optional circuit breaker policy.
+	CircuitBreaker *CircuitBreakerPolicy
+}
+
+// CircuitBreakerPolicy is the policy of a client connection circuit breaker.
+//
+// After the given number of consecutive failed attempts, the circuit breaker opens and all calls
+// are rejected without contacting the peer. After the open duration elapses, a single trial call
+// is allowed and the circuit breaker closes again if it succeeds.
+type CircuitBreakerPolicy struct {
+	// FailureThreshold is the number of consecutive failed attempts that open the circuit
+	// breaker.
+	FailureThreshold int
+
+	// OpenDuration is the duration for which the circuit breaker stays open.
+	OpenDuration time.Duration
+}
+
+type callPolicyOption struct {
+	grpc.EmptyDialOption
+
+	policy CallPolicy
+}
+
+// WithCallPolicy configures the call policy of a connection created via Dial.
+func WithCallPolicy(policy CallPolicy) grpc.DialOption {
+	return callPolicyOption{policy: policy}
+}
+
+func (p *CallPolicy) isRetryable(err error) bool {
+	if IsRetriable(err) {
+		return true
+	}
+
+	code := status.Code(err)
+	if len(p.RetryableCodes) == 0 {
+		return code == codes.Unavailable
+	}
+	for _, c := range p.RetryableCodes {
+		if code == c {
+			return true
+		}
+	}
+	return false
+}
+
+type circuitBreaker struct {
+	sync.Mutex
+
+	policy CircuitBreakerPolicy
+	target string
+	now    func() time.Time
+
+	state     int
+	failures  int
+	openUntil time.Time
+	trialBusy bool
+}
+
+func (cb *circuitBreaker) setStateLocked(state int) {
+	cb.state = state
+	grpcClientCircuitBreakerState.WithLabelValues(cb.target).Set(float64(state))
+}
+
+// allow checks whether an attempt is allowed to proceed.
+func (cb *circuitBreaker) allow() bool {
+	cb.Lock()
+	defer cb.Unlock()
+
+	switch cb.state {
+	case circuitBreakerOpen:
+		if cb.now().Before(cb.openUntil) {
+			return false
+		}
+		cb.setStateLocked(circuitBreakerHalfOpen)
+	case circuitBreakerHalfOpen:
+	default:
+		return true
+	}
+
+	// Only allow a single trial attempt while half-open.
+	if cb.trialBusy {
+		return false
+	}
+	cb.trialBusy = true
+	return true
+}
+
+// release releases an allowed attempt without recording its outcome.
+func (cb *circuitBreaker) release() {
+	cb.Lock()
+	defer cb.Unlock()
+
+	cb.trialBusy = false
+}
+
+// record records the outcome of an allowed attempt.
+func (cb *circuitBreaker) record(failed bool) {
+	cb.Lock()
+	defer cb.Unlock()
+
+	cb.trialBusy = false
+	if !failed {
+		cb.failures = 0
+		if cb.state != circuitBreakerClosed {
+			cb.setStateLocked(circuitBreakerClosed)
+		}
+		return
+	}
+
+	cb.failures++
+	if cb.state == circuitBreakerHalfOpen || cb.failures >= cb.policy.FailureThreshold {
+		cb.openUntil = cb.now().Add(cb.policy.OpenDuration)
+		cb.setStateLocked(circuitBreakerOpen)
+	}
+}
+
+type callPolicyInterceptor struct {
+	policy  CallPolicy
+	breaker *circuitBreaker
+}
+
+func newCallPolicyInterceptor(target string, policy CallPolicy) *callPolicyInterceptor {
+	ci := &callPolicyInterceptor{
+		policy: policy,
+	}
+	if policy.CircuitBreaker != nil {
+		ci.breaker = &circuitBreaker{
+			policy: *policy.CircuitBreaker,
+			target: target,
+			now:    time.Now,
+		}
+	}
+	return ci
+}
+
+// attempt performs a single call attempt, subject to the circuit breaker and attempt timeout.
+func (ci *callPolicyInterceptor) attempt(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	if ci.breaker != nil && !ci.breaker.allow() {
+		grpcClientCircuitBreakerRejections.WithLabelValues(ci.breaker.target).Inc()
+		return status.Error(codes.Unavailable, "grpc: circuit breaker is open")
+	}
+
+	attemptCtx := ctx
+	if ci.policy.Timeout > 0 {
+		var cancel context.CancelFunc
+		attemptCtx, cancel = context.WithTimeout(ctx, ci.policy.Timeout)
+		defer cancel()
+	}
+	err := invoker(attemptCtx, method, req, reply, cc, opts...)
+
+	// Attempts that timed out while the call itself is still alive are treated as unavailable.
+	if err != nil && ctx.Err() == nil && attemptCtx.Err() != nil {
+		err = status.Error(codes.Unavailable, status.Convert(err).Message())
+	}
+	if ci.breaker != nil {
+		switch ctx.Err() {
+		case nil:
+			ci.breaker.record(err != nil && ci.policy.isRetryable(err))
+		default:
+			// The outcome is unknown as the call has been canceled.
+			ci.breaker.release()
+		}
+	}
+	return err
+}
+
+func (ci *callPolicyInterceptor) newBackoff() backoff.BackOff {
+	boff := backoff.NewExponentialBackOff()
+	boff.InitialInterval = ci.policy.InitialBackoff
+	boff.MaxInterval = ci.policy.MaxBackoff
+	boff.MaxElapsedTime = 0
+	boff.Reset()
+	return boff
+}
+
+func (ci *callPolicyInterceptor) unaryInterceptor(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	if ci.policy.HedgingDelay > 0 && ci.policy.MaxAttempts > 1 {
+		return ci.hedge(ctx, method, req, reply, cc, invoker, opts...)
+	}
+
+	boff := ci.newBackoff()
+	for attempt := 1; ; attempt++ {
+		err := ci.attempt(ctx, method, req, reply, cc, invoker, opts...)
+		if err == nil || attempt >= ci.policy.MaxAttempts || !ci.policy.isRetryable(err) {
+			return err
+		}
+
+		select {
+		case <-time.After(boff.NextBackOff()):
+		case <-ctx.Done():
+			return err
+		}
+		grpcClientRetries.WithLabelValues(method).Inc()
+	}
+}
+
+func (ci *callPolicyInterceptor) hedge(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		reply interface{}
+		err   error
+	}
+	// Results channel is buffered so that canceled attempts never block.
+	resultCh := make(chan result, ci.policy.MaxAttempts)
+	started, pending := 0, 0
+	start := func() {
+		// Each attempt needs its own reply as attempts may complete concurrently.
+		attemptReply := reflect.New(reflect.TypeOf(reply).Elem()).Interface()
+		go func() {
+			err := ci.attempt(ctx, method, req, attemptReply, cc, invoker, opts...)
+			resultCh <- result{attemptReply, err}
+		}()
+		if started > 0 {
+			grpcClientRetries.WithLabelValues(method).Inc()
+		}
+		started++
+		pending++
+	}
+
+	start()
+	timer := time.NewTimer(ci.policy.HedgingDelay)
+	defer timer.Stop()
+
+	var lastErr error
+	for {
+		select {
+		case res := <-resultCh:
+			pending--
+			if res.err == nil || !ci.policy.isRetryable(res.err) {
+				if res.err == nil {
+					reflect.ValueOf(reply).Elem().Set(reflect.ValueOf(res.reply).Elem())
+				}
+				return res.err
+			}
+			lastErr = res.err
+			if pending > 0 {
+				continue
+			}
+			if started >= ci.policy.MaxAttempts {
+				return lastErr
+			}
+			// All in-flight attempts have failed, start the next one immediately.
+			if !timer.Stop() {
+				<-timer.C
+			}
+			start()
+			timer.Reset(ci.policy.HedgingDelay)
+		case <-timer.C:
+			if started >= ci.policy.MaxAttempts {
+				continue
+			}
+			start()
+			timer.Reset(ci.policy.HedgingDelay)
+		case <-ctx.Done():
+			if lastErr != nil {
+				return lastErr
+			}
+			return status.FromContextError(ctx.Err()).Err()
+		}
+	}
+}
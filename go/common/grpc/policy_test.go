@@ -0,0 +1,175 @@
+package grpc
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCallPolicyRetry(t *testing.T) {
+	require := require.New(t)
+
+	ci := newCallPolicyInterceptor("test", CallPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+	})
+
+	var attempts int
+	invoker := func(_ context.Context, _ string, _, reply interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		attempts++
+		if attempts < 3 {
+			return status.Error(codes.Unavailable, "unavailable")
+		}
+		*reply.(*string) = "ok"
+		return nil
+	}
+
+	var reply string
+	err := ci.unaryInterceptor(context.Background(), "test", nil, &reply, nil, invoker)
+	require.NoError(err, "call should succeed after retries")
+	require.Equal("ok", reply)
+	require.Equal(3, attempts)
+
+	// Non-retryable errors should not be retried.
+	attempts = 0
+	invoker = func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+		attempts++
+		return status.Error(codes.InvalidArgument, "invalid")
+	}
+	err = ci.unaryInterceptor(context.Background(), "test", nil, &reply, nil, invoker)
+	require.Equal(codes.InvalidArgument, status.Code(err))
+	require.Equal(1, attempts, "non-retryable errors should not be retried")
+
+	// Attempts should be limited.
+	attempts = 0
+	invoker = func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+		attempts++
+		return status.Error(codes.Unavailable, "unavailable")
+	}
+	err = ci.unaryInterceptor(context.Background(), "test", nil, &reply, nil, invoker)
+	require.Equal(codes.Unavailable, status.Code(err))
+	require.Equal(3, attempts, "attempts should be limited")
+
+	// Errors reported as retriable should be retried regardless of their status code.
+	attempts = 0
+	invoker = func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+		attempts++
+		return errorToGrpc(errTestRetriable)
+	}
+	err = ci.unaryInterceptor(context.Background(), "test", nil, &reply, nil, invoker)
+	require.Error(err)
+	require.Equal(3, attempts, "retriable errors should be retried")
+}
+
+func TestCallPolicyTimeout(t *testing.T) {
+	require := require.New(t)
+
+	ci := newCallPolicyInterceptor("test", CallPolicy{
+		Timeout:     10 * time.Millisecond,
+		MaxAttempts: 2,
+	})
+
+	var attempts int
+	invoker := func(ctx context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		attempts++
+		if attempts == 1 {
+			<-ctx.Done()
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		return nil
+	}
+
+	var reply string
+	err := ci.unaryInterceptor(context.Background(), "test", nil, &reply, nil, invoker)
+	require.NoError(err, "timed out attempts should be retried")
+	require.Equal(2, attempts)
+}
+
+func TestCallPolicyHedging(t *testing.T) {
+	require := require.New(t)
+
+	ci := newCallPolicyInterceptor("test", CallPolicy{
+		MaxAttempts:  3,
+		HedgingDelay: 10 * time.Millisecond,
+	})
+
+	var attempts int32
+	invoker := func(ctx context.Context, _ string, _, reply interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			// The first attempt hangs until canceled.
+			<-ctx.Done()
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		*reply.(*string) = "hedged"
+		return nil
+	}
+
+	var reply string
+	err := ci.unaryInterceptor(context.Background(), "test", nil, &reply, nil, invoker)
+	require.NoError(err, "hedged attempt should succeed")
+	require.Equal("hedged", reply)
+	require.EqualValues(2, atomic.LoadInt32(&attempts), "only a single hedged attempt should be needed")
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1_000_000, 0)
+	ci := newCallPolicyInterceptor("test", CallPolicy{
+		CircuitBreaker: &CircuitBreakerPolicy{
+			FailureThreshold: 2,
+			OpenDuration:     time.Second,
+		},
+	})
+	ci.breaker.now = func() time.Time { return now }
+
+	var attempts int
+	failing := true
+	invoker := func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+		attempts++
+		if failing {
+			return status.Error(codes.Unavailable, "unavailable")
+		}
+		return nil
+	}
+	call := func() error {
+		var reply string
+		return ci.unaryInterceptor(context.Background(), "test", nil, &reply, nil, invoker)
+	}
+
+	// Consecutive failures should open the circuit breaker.
+	require.Error(call())
+	require.Error(call())
+	require.Equal(2, attempts)
+	require.Equal(codes.Unavailable, status.Code(call()))
+	require.Equal(2, attempts, "calls should be rejected while the circuit breaker is open")
+
+	// A failed trial call should open the circuit breaker again.
+	now = now.Add(time.Second)
+	require.Error(call())
+	require.Equal(3, attempts, "a trial call should be allowed after the open duration")
+	require.Error(call())
+	require.Equal(3, attempts, "calls should be rejected after a failed trial call")
+
+	// A successful trial call should close the circuit breaker.
+	now = now.Add(time.Second)
+	failing = false
+	require.NoError(call())
+	require.NoError(call())
+	require.Equal(5, attempts, "calls should be allowed after a successful trial call")
+
+	// Non-retryable errors should not count as failures.
+	invoker = func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+		return status.Error(codes.InvalidArgument, "invalid")
+	}
+	for i := 0; i < 3; i++ {
+		require.Equal(codes.InvalidArgument, status.Code(call()))
+	}
+}
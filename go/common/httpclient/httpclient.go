@@ -0,0 +1,131 @@
+// Package httpclient provides HTTP clients for outbound node traffic.
+//
+// All outbound HTTP(S) requests made by the node (e.g., to Intel PCS and IAS) should use clients
+// created by this package so that they honor the centrally configured proxy.
+package httpclient
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// ProxySchemes are the supported proxy URL schemes.
+var ProxySchemes = []string{"http", "https", "socks5", "socks5h"}
+
+// ProxyConfig is the outbound HTTP proxy configuration.
+type ProxyConfig struct {
+	// URL is the URL of the proxy.
+	URL *url.URL
+	// NoProxy is a list of hosts that should be accessed directly. Each entry is either a host
+	// name, a domain suffix starting with a dot (e.g. .example.com), an IP address or a CIDR
+	// range.
+	NoProxy []string
+}
+
+// NewProxyConfig parses and validates the given proxy configuration.
+func NewProxyConfig(rawURL string, noProxy []string) (*ProxyConfig, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("malformed proxy URL: %w", err)
+	}
+	if !slices.Contains(ProxySchemes, u.Scheme) {
+		return nil, fmt.Errorf("unsupported proxy URL scheme '%s'", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("proxy URL must contain a host")
+	}
+
+	for _, entry := range noProxy {
+		if entry == "" {
+			return nil, fmt.Errorf("empty no proxy entry")
+		}
+		if strings.Contains(entry, "/") {
+			if _, _, err = net.ParseCIDR(entry); err != nil {
+				return nil, fmt.Errorf("malformed no proxy CIDR range '%s': %w", entry, err)
+			}
+		}
+	}
+
+	return &ProxyConfig{
+		URL:     u,
+		NoProxy: noProxy,
+	}, nil
+}
+
+// useProxy returns true iff requests to the given host should go through the proxy.
+func (cfg *ProxyConfig) useProxy(host string) bool {
+	host = strings.ToLower(host)
+	ip := net.ParseIP(host)
+
+	for _, entry := range cfg.NoProxy {
+		entry = strings.ToLower(entry)
+		switch {
+		case strings.Contains(entry, "/"):
+			_, ipNet, err := net.ParseCIDR(entry)
+			if err == nil && ip != nil && ipNet.Contains(ip) {
+				return false
+			}
+		case strings.HasPrefix(entry, "."):
+			if strings.HasSuffix(host, entry) || host == entry[1:] {
+				return false
+			}
+		default:
+			if entryIP := net.ParseIP(entry); entryIP != nil {
+				if ip != nil && entryIP.Equal(ip) {
+					return false
+				}
+				continue
+			}
+			if host == entry {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+var proxyConfig atomic.Pointer[ProxyConfig]
+
+// SetProxy configures the proxy used by all clients created by this package. Passing nil
+// restores the default behavior of using the proxy configured via the standard HTTP_PROXY,
+// HTTPS_PROXY and NO_PROXY environment variables.
+func SetProxy(cfg *ProxyConfig) {
+	proxyConfig.Store(cfg)
+}
+
+// Proxy returns the proxy URL to use for the given request.
+//
+// It is suitable for use as http.Transport.Proxy.
+func Proxy(req *http.Request) (*url.URL, error) {
+	cfg := proxyConfig.Load()
+	if cfg == nil {
+		return http.ProxyFromEnvironment(req)
+	}
+	if !cfg.useProxy(req.URL.Hostname()) {
+		return nil, nil
+	}
+	return cfg.URL, nil
+}
+
+// NewTransport returns a new HTTP transport that uses the configured proxy.
+func NewTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = Proxy
+	return transport
+}
+
+// New returns a new HTTP client with the given timeout that uses the configured proxy.
+//
+// A zero timeout means no timeout.
+func New(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Transport: NewTransport(),
+		Timeout:   timeout,
+	}
+}
@@ -0,0 +1,76 @@
+package httpclient
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProxyConfig(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		url     string
+		noProxy []string
+		ok      bool
+	}{
+		{"http://proxy.example.com:3128", nil, true},
+		{"https://proxy.example.com", nil, true},
+		{"socks5://127.0.0.1:1080", nil, true},
+		{"socks5h://127.0.0.1:1080", []string{".internal", "10.0.0.0/8", "127.0.0.1"}, true},
+		{"ftp://proxy.example.com", nil, false},
+		{"http://", nil, false},
+		{"proxy.example.com:3128", nil, false},
+		{"http://proxy.example.com:3128", []string{""}, false},
+		{"http://proxy.example.com:3128", []string{"10.0.0.0/33"}, false},
+	} {
+		_, err := NewProxyConfig(tc.url, tc.noProxy)
+		if tc.ok {
+			require.NoError(err, "NewProxyConfig(%s, %v)", tc.url, tc.noProxy)
+		} else {
+			require.Error(err, "NewProxyConfig(%s, %v)", tc.url, tc.noProxy)
+		}
+	}
+}
+
+func TestProxy(t *testing.T) {
+	require := require.New(t)
+
+	cfg, err := NewProxyConfig("http://proxy.example.com:3128", []string{
+		".internal.example.com",
+		"direct.example.com",
+		"10.0.0.0/8",
+		"192.168.1.1",
+	})
+	require.NoError(err, "NewProxyConfig")
+	SetProxy(cfg)
+	defer SetProxy(nil)
+
+	for _, tc := range []struct {
+		url     string
+		proxied bool
+	}{
+		{"https://api.trustedservices.intel.com/sgx", true},
+		{"http://127.0.0.1:8080/", true},
+		{"http://internal.example.com/", false},
+		{"http://node.internal.example.com/", false},
+		{"http://direct.example.com/", false},
+		{"http://other.direct.example.com/", true},
+		{"http://10.1.2.3:8080/", false},
+		{"http://11.1.2.3:8080/", true},
+		{"http://192.168.1.1/", false},
+		{"http://192.168.1.2/", true},
+	} {
+		req, err := http.NewRequest(http.MethodGet, tc.url, nil)
+		require.NoError(err, "NewRequest")
+
+		proxyURL, err := Proxy(req)
+		require.NoError(err, "Proxy")
+		if tc.proxied {
+			require.Equal(cfg.URL, proxyURL, "request to %s should be proxied", tc.url)
+		} else {
+			require.Nil(proxyURL, "request to %s should not be proxied", tc.url)
+		}
+	}
+}
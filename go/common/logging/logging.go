@@ -11,6 +11,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
@@ -83,21 +84,6 @@ const (
 	LevelError
 )
 
-func (l Level) toOption() level.Option {
-	switch l {
-	case LevelDebug:
-		return level.AllowDebug()
-	case LevelInfo:
-		return level.AllowInfo()
-	case LevelWarn:
-		return level.AllowWarn()
-	case LevelError:
-		return level.AllowError()
-	default:
-		panic("logging: unsupported log level")
-	}
-}
-
 // String returns the string representation of a Level.
 func (l *Level) String() string {
 	switch *l {
@@ -140,13 +126,17 @@ func (l *Level) Type() string {
 // Logger is a logger instance.
 type Logger struct {
 	logger log.Logger
-	level  Level
+	level  *atomic.Uint32
 	module string
 }
 
+func (l *Logger) getLevel() Level {
+	return Level(l.level.Load())
+}
+
 // Debug logs the message and key value pairs at the Debug log level.
 func (l *Logger) Debug(msg string, keyvals ...interface{}) {
-	if l.level > LevelDebug {
+	if l.getLevel() > LevelDebug {
 		return
 	}
 	keyvals = append([]interface{}{"msg", msg}, keyvals...)
@@ -155,7 +145,7 @@ func (l *Logger) Debug(msg string, keyvals ...interface{}) {
 
 // Info logs the message and key value pairs at the Info log level.
 func (l *Logger) Info(msg string, keyvals ...interface{}) {
-	if l.level > LevelInfo {
+	if l.getLevel() > LevelInfo {
 		return
 	}
 	keyvals = append([]interface{}{"msg", msg}, keyvals...)
@@ -164,7 +154,7 @@ func (l *Logger) Info(msg string, keyvals ...interface{}) {
 
 // Warn logs the message and key value pairs at the Warn log level.
 func (l *Logger) Warn(msg string, keyvals ...interface{}) {
-	if l.level > LevelWarn {
+	if l.getLevel() > LevelWarn {
 		return
 	}
 	keyvals = append([]interface{}{"msg", msg}, keyvals...)
@@ -173,7 +163,7 @@ func (l *Logger) Warn(msg string, keyvals ...interface{}) {
 
 // Error logs the message and key value pairs at the Error log level.
 func (l *Logger) Error(msg string, keyvals ...interface{}) {
-	if l.level > LevelError {
+	if l.getLevel() > LevelError {
 		return
 	}
 	keyvals = append([]interface{}{"msg", msg}, keyvals...)
@@ -186,6 +176,7 @@ func (l *Logger) With(keyvals ...interface{}) *Logger {
 	return &Logger{
 		logger: log.With(l.logger, keyvals...),
 		level:  l.level,
+		module: l.module,
 	}
 }
 
@@ -193,14 +184,47 @@ func (l *Logger) With(keyvals ...interface{}) *Logger {
 func NewNopLogger() *Logger {
 	return &Logger{
 		logger: log.NewNopLogger(),
+		level:  new(atomic.Uint32),
 	}
 }
 
 // GetLevel returns the current global log level.
 func GetLevel() Level {
+	backend.Lock()
+	defer backend.Unlock()
+
 	return backend.defaultLevel
 }
 
+// SetModuleLevel changes the log level of the given module at runtime. All existing and future
+// loggers of modules with the given prefix are affected, unless a more specific module level is
+// configured. If the module is empty or "default", the default log level is changed instead.
+func SetModuleLevel(module string, lvl Level) error {
+	if lvl > LevelError {
+		return fmt.Errorf("logging: unsupported log level: %d", lvl)
+	}
+
+	backend.Lock()
+	defer backend.Unlock()
+
+	switch module {
+	case "", "default":
+		backend.defaultLevel = lvl
+	default:
+		if backend.moduleLevels == nil {
+			backend.moduleLevels = make(map[string]Level)
+		}
+		backend.moduleLevels[module] = lvl
+	}
+
+	// Re-evaluate the log levels of all existing modules.
+	for module, moduleLvl := range backend.levels {
+		moduleLvl.Store(uint32(backend.getModuleLevelLocked(module)))
+	}
+
+	return nil
+}
+
 // GetLogger creates a new logger instance with the specified module.
 //
 // This may be called from any point, including before Initialize is
@@ -258,10 +282,13 @@ func Initialize(w io.Writer, format Format, defaultLvl Level, moduleLvls map[str
 		}
 	}
 
-	logger = level.NewFilter(logger, defaultLvl.toOption())
-
+	// NOTE: Log levels are not filtered here as they are checked by each logger and may be
+	//       changed at runtime.
 	backend.baseLogger = logger
-	backend.moduleLevels = moduleLvls
+	backend.moduleLevels = make(map[string]Level, len(moduleLvls))
+	for module, lvl := range moduleLvls {
+		backend.moduleLevels[module] = lvl
+	}
 	backend.defaultLevel = defaultLvl
 	backend.initialized = true
 
@@ -270,9 +297,6 @@ func Initialize(w io.Writer, format Format, defaultLvl Level, moduleLvls map[str
 		l.swapLogger.Swap(backend.baseLogger)
 
 		// Re-evaluate log level.
-		// NOTE: This introduces a potential race condition if loggers are used
-		//       before the logging system is initialized. Protecting the log
-		//       level with a mutex just for this one case seems overkill.
 		backend.setupLogLevelLocked(l.logger)
 	}
 	backend.earlyLoggers = nil
@@ -304,10 +328,13 @@ type logBackend struct {
 	defaultLevel Level
 	moduleLevels map[string]Level
 
+	// levels are the current log levels shared by all loggers of each module.
+	levels map[string]*atomic.Uint32
+
 	initialized bool
 }
 
-func (b *logBackend) setupLogLevelLocked(l *Logger) {
+func (b *logBackend) getModuleLevelLocked(module string) Level {
 	// Check, whether there is a specific logging level set for the module.
 	// The longest prefix match of the module name provided in the config file will be taken.
 	// Otherwise, fallback to level defined by "default" key.
@@ -317,15 +344,26 @@ func (b *logBackend) setupLogLevelLocked(l *Logger) {
 	}
 	sort.Sort(sort.Reverse(sort.StringSlice(modulePrefixes)))
 
-	lvl := b.defaultLevel
 	for _, k := range modulePrefixes {
-		if strings.HasPrefix(l.module, k) {
-			lvl = b.moduleLevels[k]
-			break
+		if strings.HasPrefix(module, k) {
+			return b.moduleLevels[k]
+		}
+	}
+	return b.defaultLevel
+}
+
+func (b *logBackend) setupLogLevelLocked(l *Logger) {
+	moduleLvl, ok := b.levels[l.module]
+	if !ok {
+		if b.levels == nil {
+			b.levels = make(map[string]*atomic.Uint32)
 		}
+		moduleLvl = new(atomic.Uint32)
+		b.levels[l.module] = moduleLvl
 	}
+	moduleLvl.Store(uint32(b.getModuleLevelLocked(l.module)))
 
-	l.level = lvl
+	l.level = moduleLvl
 }
 
 func (b *logBackend) getLogger(module string, extraUnwind int) *Logger {
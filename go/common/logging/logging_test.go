@@ -0,0 +1,40 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetModuleLevel(t *testing.T) {
+	require := require.New(t)
+
+	err := SetModuleLevel("default", LevelWarn)
+	require.NoError(err)
+
+	hostLogger := GetLogger("runtime/host/sgx")
+	otherLogger := GetLogger("runtime/registry")
+	childLogger := hostLogger.With("runtime_id", "test")
+	require.Equal(LevelWarn, hostLogger.getLevel())
+	require.Equal(LevelWarn, otherLogger.getLevel())
+
+	err = SetModuleLevel("runtime/host", LevelDebug)
+	require.NoError(err)
+	require.Equal(LevelDebug, hostLogger.getLevel(), "existing loggers should be updated")
+	require.Equal(LevelDebug, childLogger.getLevel(), "derived loggers should be updated")
+	require.Equal(LevelWarn, otherLogger.getLevel(), "other modules should not be affected")
+	require.Equal(LevelDebug, GetLogger("runtime/host/sandbox").getLevel(), "new loggers should use the module level")
+
+	err = SetModuleLevel("runtime/host/sgx", LevelError)
+	require.NoError(err)
+	require.Equal(LevelError, hostLogger.getLevel(), "more specific module level should take precedence")
+
+	err = SetModuleLevel("", LevelInfo)
+	require.NoError(err)
+	require.Equal(LevelInfo, GetLevel())
+	require.Equal(LevelInfo, otherLogger.getLevel())
+	require.Equal(LevelError, hostLogger.getLevel())
+
+	err = SetModuleLevel("runtime", Level(42))
+	require.Error(err, "invalid levels should be rejected")
+}
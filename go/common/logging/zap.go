@@ -220,16 +220,16 @@ func newZapCore(logger log.Logger, module string, unwind int) *zapCore {
 func (l *zapCore) Enabled(level zapcore.Level) bool {
 	switch level {
 	case zapcore.DebugLevel:
-		return l.logger.level <= LevelDebug
+		return l.logger.getLevel() <= LevelDebug
 	case zapcore.InfoLevel:
-		return l.logger.level <= LevelInfo
+		return l.logger.getLevel() <= LevelInfo
 	case zapcore.WarnLevel:
-		return l.logger.level <= LevelWarn
+		return l.logger.getLevel() <= LevelWarn
 	case zapcore.ErrorLevel:
-		return l.logger.level <= LevelError
+		return l.logger.getLevel() <= LevelError
 	default:
 		// DPanic, Panic, Fatal levels..
-		return l.logger.level <= LevelError
+		return l.logger.getLevel() <= LevelError
 	}
 }
 
@@ -63,6 +63,9 @@ const (
 	maxNodeDescriptorVersion = LatestNodeDescriptorVersion
 
 	nodeSoftwareVersionMaxLength = 128
+
+	nodeDebugCapabilitiesMaxCount = 16
+	nodeDebugCapabilityMaxLength  = 64
 )
 
 // Node represents public connectivity information about an Oasis node.
@@ -101,6 +104,9 @@ type Node struct { // nolint: maligned
 
 	// SoftwareVersion is the node's oasis-node software version.
 	SoftwareVersion SoftwareVersion `json:"software_version,omitempty"`
+
+	// DebugCapabilities are the unsafe debug capabilities enabled on the node.
+	DebugCapabilities []string `json:"debug_capabilities,omitempty"`
 }
 
 // nodeV2 represents (to be deprecated) V2 version of node descriptors.
@@ -357,6 +363,16 @@ func (n *Node) ValidateBasic(strictVersion bool) error {
 		return err
 	}
 
+	// Validate debug capabilities.
+	if l := len(n.DebugCapabilities); l > nodeDebugCapabilitiesMaxCount {
+		return fmt.Errorf("too many debug capabilities (max: %d, count: %d)", nodeDebugCapabilitiesMaxCount, l)
+	}
+	for _, capability := range n.DebugCapabilities {
+		if l := len(capability); l == 0 || l > nodeDebugCapabilityMaxLength {
+			return fmt.Errorf("malformed debug capability: invalid length (max length: %d, length: %d)", nodeDebugCapabilityMaxLength, l)
+		}
+	}
+
 	// Make sure that a node has at least one valid role.
 	switch {
 	case n.Roles == 0:
@@ -503,6 +519,10 @@ type VRFInfo struct {
 type Capabilities struct {
 	// TEE is the capability of a node executing batches in a TEE.
 	TEE *CapabilityTEE `json:"tee,omitempty"`
+
+	// BackupOnly signals that the node only wants to participate in executor committees as a
+	// backup worker and never schedules transactions.
+	BackupOnly bool `json:"backup_only,omitempty"`
 }
 
 // TEEHardware is a TEE hardware implementation.
@@ -617,6 +637,19 @@ type EndorsedCapabilityTEE struct {
 	NodeEndorsement signature.Signature `json:"node_endorsement"`
 }
 
+// EndorseCapabilityTEE endorses the given TEE capability using the given node signer.
+func EndorseCapabilityTEE(signer signature.Signer, capabilityTEE *CapabilityTEE) (*EndorsedCapabilityTEE, error) {
+	nodeSignature, err := signature.Sign(signer, EndorseCapabilityTEESignatureContext, cbor.Marshal(capabilityTEE))
+	if err != nil {
+		return nil, err
+	}
+
+	return &EndorsedCapabilityTEE{
+		CapabilityTEE:   *capabilityTEE,
+		NodeEndorsement: *nodeSignature,
+	}, nil
+}
+
 // String returns a string representation of itself.
 func (n *Node) String() string {
 	return "<Node id=" + n.ID.String() + ">"
@@ -14,6 +14,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 )
 
@@ -480,3 +481,44 @@ func TestNodeSoftwareVersion(t *testing.T) {
 	sw = SoftwareVersion(strings.Repeat("a", 1000))
 	require.Error(sw.ValidateBasic(), "invalid software version")
 }
+
+func TestNodeDebugCapabilities(t *testing.T) {
+	require := require.New(t)
+
+	n := Node{
+		Versioned: cbor.NewVersioned(LatestNodeDescriptorVersion),
+		Roles:     RoleValidator,
+	}
+	require.NoError(n.ValidateBasic(false), "no debug capabilities are allowed")
+
+	n.DebugCapabilities = []string{"dummy_sigstruct", "insecure_beacon"}
+	require.NoError(n.ValidateBasic(false), "debug capabilities are allowed")
+
+	n.DebugCapabilities = []string{""}
+	require.Error(n.ValidateBasic(false), "empty debug capability")
+
+	n.DebugCapabilities = []string{strings.Repeat("a", 1000)}
+	require.Error(n.ValidateBasic(false), "invalid debug capability")
+
+	n.DebugCapabilities = make([]string, 100)
+	for i := range n.DebugCapabilities {
+		n.DebugCapabilities[i] = "a"
+	}
+	require.Error(n.ValidateBasic(false), "too many debug capabilities")
+}
+
+func TestEndorseCapabilityTEE(t *testing.T) {
+	require := require.New(t)
+
+	signer := memorySigner.NewTestSigner("node endorsement test signer")
+	capabilityTEE := &CapabilityTEE{
+		Hardware: TEEHardwareIntelSGX,
+		RAK:      memorySigner.NewTestSigner("rak test signer").Public(),
+	}
+
+	ect, err := EndorseCapabilityTEE(signer, capabilityTEE)
+	require.NoError(err, "EndorseCapabilityTEE")
+	require.Equal(*capabilityTEE, ect.CapabilityTEE)
+	require.Equal(signer.Public(), ect.NodeEndorsement.PublicKey)
+	require.True(ect.NodeEndorsement.Verify(EndorseCapabilityTEESignatureContext, cbor.Marshal(capabilityTEE)))
+}
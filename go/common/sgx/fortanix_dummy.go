@@ -4,8 +4,7 @@ import (
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
-
-	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+	"sync/atomic"
 )
 
 var (
@@ -14,14 +13,24 @@ var (
 	// enclave-runner.
 	FortanixDummyMrSigner MrSigner
 
-	fortanixDummyKey *rsa.PrivateKey
+	fortanixDummyKey        *rsa.PrivateKey
+	fortanixDummyKeyEnabled atomic.Bool
 )
 
-// UnsafeFortanixDummyKey returns the Fortanix dummy signing key.
+// UnsafeEnableFortanixDummyKey makes the Fortanix dummy signing key available via
+// UnsafeFortanixDummyKey.
+//
+// This MUST only ever be used for launching test enclaves.
+func UnsafeEnableFortanixDummyKey() {
+	fortanixDummyKeyEnabled.Store(true)
+}
+
+// UnsafeFortanixDummyKey returns the Fortanix dummy signing key, or nil in case it has not been
+// explicitly enabled via UnsafeEnableFortanixDummyKey.
 //
 // This MUST only ever be used for launching test enclaves.
 func UnsafeFortanixDummyKey() *rsa.PrivateKey {
-	if !cmdFlags.DebugDontBlameOasis() {
+	if !fortanixDummyKeyEnabled.Load() {
 		return nil
 	}
 	return fortanixDummyKey
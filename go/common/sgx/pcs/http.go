@@ -16,6 +16,7 @@ import (
 
 	"golang.org/x/net/context/ctxhttp"
 
+	"github.com/oasisprotocol/oasis-core/go/common/httpclient"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 )
 
@@ -216,9 +217,7 @@ func (hc *httpClient) GetPCKCertificateChain(ctx context.Context, platformData [
 // NewHTTPClient returns a new PCS HTTP endpoint.
 func NewHTTPClient(cfg *HTTPClientConfig) (Client, error) {
 	hc := &httpClient{
-		httpClient: &http.Client{
-			Timeout: pcsAPITimeout,
-		},
+		httpClient:      httpclient.New(pcsAPITimeout),
 		subscriptionKey: cfg.SubscriptionKey,
 		trustRoots:      IntelTrustRoots,
 		logger:          logging.GetLogger("common/sgx/pcs/http"),
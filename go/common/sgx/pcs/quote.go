@@ -126,16 +126,21 @@ func (q *Quote) UnmarshalBinary(data []byte) error {
 	return nil
 }
 
+// defaultQuotePolicy returns the quote policy used when no policy is configured.
+func defaultQuotePolicy() *QuotePolicy {
+	return &QuotePolicy{
+		TCBValidityPeriod:          30,
+		MinTCBEvaluationDataNumber: DefaultMinTCBEvaluationDataNumber,
+		FMSPCBlacklist:             []string{},
+	}
+}
+
 // Verify verifies the quote.
 //
 // In case of successful verification it returns the TCB level.
 func (q *Quote) Verify(policy *QuotePolicy, ts time.Time, tcb *TCBBundle) (*sgx.VerifiedQuote, error) {
 	if policy == nil {
-		policy = &QuotePolicy{
-			TCBValidityPeriod:          30,
-			MinTCBEvaluationDataNumber: DefaultMinTCBEvaluationDataNumber,
-			FMSPCBlacklist:             []string{},
-		}
+		policy = defaultQuotePolicy()
 	}
 
 	if policy.Disabled {
@@ -80,6 +80,13 @@ func TestQuoteV3_ECDSA_P256_PCK_CertificateChain(t *testing.T) {
 	require.EqualValues("68823bc62f409ee33a32ea270cfe45d4b19a6fb3c8570d7bc186cbe062398e8f", verifiedQuote.Identity.MrEnclave.String())
 	require.EqualValues("9affcfae47b848ec2caf1c49b4b283531e1cc425f93582b36806e52a43d78d1a", verifiedQuote.Identity.MrSigner.String())
 
+	// Test platform TCB status.
+	pckInfo, err := qs.VerifyPCK(now)
+	require.NoError(err, "VerifyPCK")
+	tcbStatus, err := tcbBundle.PlatformTCBStatus(now, nil, pckInfo)
+	require.NoError(err, "PlatformTCBStatus")
+	require.Equal(StatusSWHardeningNeeded, tcbStatus, "PlatformTCBStatus")
+
 	// Test X509 certificate has expired (not after 1891163521).
 	now2a := time.Unix(1891163522, 0)
 	_, err = quote.Verify(nil, now2a, &tcbBundle)
@@ -71,6 +71,30 @@ func (bnd *TCBBundle) Verify(
 	return nil
 }
 
+// PlatformTCBStatus returns the TCB status of the SGX platform with the given PCK information
+// based on the TCB info contained in the bundle.
+//
+// Note that the returned status is not checked for acceptability.
+func (bnd *TCBBundle) PlatformTCBStatus(ts time.Time, policy *QuotePolicy, pckInfo *PCKInfo) (TCBStatus, error) {
+	if policy == nil {
+		policy = defaultQuotePolicy()
+	}
+
+	pk, err := bnd.getPublicKey(ts)
+	if err != nil {
+		return statusFieldMissing, err
+	}
+	tcbInfo, err := bnd.TCBInfo.open(TeeTypeSGX, ts, policy, pk)
+	if err != nil {
+		return statusFieldMissing, fmt.Errorf("pcs/tcb: invalid TCB info: %w", err)
+	}
+	tcbLevel, err := tcbInfo.getTCBLevel(pckInfo.TCBCompSVN, nil, pckInfo.PCESVN)
+	if err != nil {
+		return statusFieldMissing, fmt.Errorf("pcs/tcb: failed to get TCB level: %w", err)
+	}
+	return tcbLevel.Status, nil
+}
+
 // verifyQEIdentity verifies the QE identity.
 func (bnd *TCBBundle) verifyQEIdentity(
 	teeType TeeType,
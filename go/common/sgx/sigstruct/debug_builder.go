@@ -11,7 +11,7 @@ import (
 //
 // This routine is deterministic, and MUST only ever be used for testing.
 func UnsafeDebugForEnclave(sgxs []byte) ([]byte, error) {
-	// Note: The key is unavailable unless DontBlameOasis is enabled.
+	// Note: The key is unavailable unless it has been explicitly enabled.
 	signingKey := sgx.UnsafeFortanixDummyKey()
 	if signingKey == nil {
 		return nil, fmt.Errorf("sgx/sigstruct: debug signing key unavailable")
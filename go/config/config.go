@@ -17,6 +17,7 @@ import (
 	pprof "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/pprof/config"
 	p2p "github.com/oasisprotocol/oasis-core/go/p2p/config"
 	runtime "github.com/oasisprotocol/oasis-core/go/runtime/config"
+	workerBeacon "github.com/oasisprotocol/oasis-core/go/worker/beacon/config"
 	workerKM "github.com/oasisprotocol/oasis-core/go/worker/keymanager/config"
 	workerRegistration "github.com/oasisprotocol/oasis-core/go/worker/registration/config"
 	workerSentry "github.com/oasisprotocol/oasis-core/go/worker/sentry/config"
@@ -83,6 +84,7 @@ type Config struct {
 	Keymanager   workerKM.Config           `yaml:"keymanager,omitempty"`
 	Storage      workerStorage.Config      `yaml:"storage,omitempty"`
 	Sentry       workerSentry.Config       `yaml:"sentry,omitempty"`
+	Beacon       workerBeacon.Config       `yaml:"beacon,omitempty"`
 }
 
 // Validate validates the configuration settings.
@@ -128,6 +130,9 @@ func (c *Config) Validate() error {
 	if err = c.Sentry.Validate(); err != nil {
 		return fmt.Errorf("sentry: %w", err)
 	}
+	if err = c.Beacon.Validate(); err != nil {
+		return fmt.Errorf("beacon: %w", err)
+	}
 	if err = c.IAS.Validate(); err != nil {
 		return fmt.Errorf("ias: %w", err)
 	}
@@ -154,6 +159,7 @@ func DefaultConfig() Config {
 		Keymanager:   workerKM.DefaultConfig(),
 		Storage:      workerStorage.DefaultConfig(),
 		Sentry:       workerSentry.DefaultConfig(),
+		Beacon:       workerBeacon.DefaultConfig(),
 		IAS:          ias.DefaultConfig(),
 		Pprof:        pprof.DefaultConfig(),
 		Metrics:      metrics.DefaultConfig(),
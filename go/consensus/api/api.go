@@ -4,10 +4,13 @@ package api
 import (
 	"context"
 	"fmt"
+	"io"
+	"slices"
 	"strings"
 	"time"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
@@ -45,7 +48,7 @@ const (
 var (
 	// ErrNoCommittedBlocks is the error returned when there are no committed
 	// blocks and as such no state can be queried.
-	ErrNoCommittedBlocks = errors.New(ModuleName, 1, "consensus: no committed blocks")
+	ErrNoCommittedBlocks = errors.NewRetriable(ModuleName, 1, "consensus: no committed blocks")
 
 	// ErrOversizedTx is the error returned when the given transaction is too big to be processed.
 	ErrOversizedTx = errors.New(ModuleName, 2, "consensus: oversized transaction")
@@ -64,6 +67,14 @@ var (
 	// ErrInvalidArgument is the error returned when the request contains an invalid argument.
 	ErrInvalidArgument = errors.New(ModuleName, 6, "consensus: invalid argument")
 
+	// ErrTransactionNotFound is the error returned when the transaction with the given hash cannot
+	// be found.
+	ErrTransactionNotFound = errors.New(ModuleName, 7, "consensus: transaction not found")
+
+	// ErrTooManyPendingTxs is the error returned when the transaction signer already has too many
+	// transactions pending in the mempool.
+	ErrTooManyPendingTxs = errors.NewRetriable(ModuleName, 8, "consensus: too many pending transactions")
+
 	// SystemMethods is a map of all system methods.
 	SystemMethods = map[transaction.MethodName]struct{}{
 		MethodMeta: {},
@@ -125,22 +136,47 @@ type ClientBackend interface {
 	// included in a block and returns a proof of inclusion.
 	SubmitTxWithProof(ctx context.Context, tx *transaction.SignedTransaction) (*transaction.Proof, error)
 
+	// SubmitTxWithResults submits a signed consensus transaction, waits for the transaction to be
+	// included in a block and returns its execution result, including emitted events and gas used.
+	//
+	// Transactions that are included in a block but fail during execution do not cause an error
+	// to be returned. Instead, the error is reported as part of the execution result.
+	SubmitTxWithResults(ctx context.Context, tx *transaction.SignedTransaction) (*SubmitTxResult, error)
+
+	// SubmitTxAsync submits a signed consensus transaction and returns the transaction hash as soon
+	// as the transaction has been accepted into the mempool.
+	SubmitTxAsync(ctx context.Context, tx *transaction.SignedTransaction) (hash.Hash, error)
+
 	// StateToGenesis returns the genesis state at the specified block height.
 	StateToGenesis(ctx context.Context, height int64) (*genesis.Document, error)
 
 	// EstimateGas calculates the amount of gas required to execute the given transaction.
 	EstimateGas(ctx context.Context, req *EstimateGasRequest) (transaction.Gas, error)
 
+	// EstimateFee estimates the fee required to execute the given transaction, based on the
+	// estimated amount of gas and the gas price suggested by recent block utilization.
+	EstimateFee(ctx context.Context, req *EstimateGasRequest) (*transaction.Fee, error)
+
 	// MinGasPrice returns the minimum gas price.
 	MinGasPrice(ctx context.Context) (*quantity.Quantity, error)
 
 	// GetBlock returns a consensus block at a specific height.
 	GetBlock(ctx context.Context, height int64) (*Block, error)
 
+	// GetBlocks returns consensus blocks in the given height range.
+	GetBlocks(ctx context.Context, req *GetBlocksRequest) ([]*Block, error)
+
+	// GetBlockByHash returns a consensus block with the given hash.
+	GetBlockByHash(ctx context.Context, hash hash.Hash) (*Block, error)
+
 	// GetLightBlock returns a light version of the consensus layer block that can be used for light
 	// client verification.
 	GetLightBlock(ctx context.Context, height int64) (*LightBlock, error)
 
+	// GetLightBlockRange returns a batch of light blocks in the given height range that can be
+	// used for light client verification, with shared validator sets deduplicated.
+	GetLightBlockRange(ctx context.Context, req *GetLightBlockRangeRequest) (*LightBlockRange, error)
+
 	// State returns a MKVS read syncer that can be used to read consensus state from a remote node
 	// and verify it against the trusted local root.
 	State() syncer.ReadSyncer
@@ -148,6 +184,14 @@ type ClientBackend interface {
 	// GetParameters returns the consensus parameters for a specific height.
 	GetParameters(ctx context.Context, height int64) (*Parameters, error)
 
+	// GetCheckpoints returns the metadata of all consensus state checkpoints available on the
+	// node. Together with the light blocks at the checkpoint height these can be used to
+	// bootstrap a fresh node without going through state sync.
+	GetCheckpoints(ctx context.Context) ([]*checkpoint.Metadata, error)
+
+	// GetCheckpointChunk fetches a specific chunk of a consensus state checkpoint.
+	GetCheckpointChunk(ctx context.Context, chunk *checkpoint.ChunkMetadata, w io.Writer) error
+
 	// SubmitEvidence submits evidence of misbehavior.
 	SubmitEvidence(ctx context.Context, evidence *Evidence) error
 
@@ -166,6 +210,16 @@ type ClientBackend interface {
 	// contained within a consensus block at a specific height.
 	GetTransactionsWithProofs(ctx context.Context, height int64) (*TransactionsWithProofs, error)
 
+	// GetTransactionByHash returns the transaction with the given hash together with its execution
+	// result.
+	//
+	// NOTE: This is only supported by archive nodes.
+	GetTransactionByHash(ctx context.Context, txHash hash.Hash) (*TransactionWithResult, error)
+
+	// GetEventsByHeight returns all staking, registry, roothash, governance and vault events
+	// emitted in the consensus block at a specific height, in the order in which they were emitted.
+	GetEventsByHeight(ctx context.Context, height int64) ([]*results.Event, error)
+
 	// GetUnconfirmedTransactions returns a list of transactions currently in the local node's
 	// mempool. These have not yet been included in a block.
 	GetUnconfirmedTransactions(ctx context.Context) ([][]byte, error)
@@ -174,6 +228,16 @@ type ClientBackend interface {
 	// blocks as they are being finalized.
 	WatchBlocks(ctx context.Context) (<-chan *Block, pubsub.ClosableSubscription, error)
 
+	// WatchEvents returns a channel that produces a stream of staking, registry, roothash,
+	// governance and vault events matching the given filter, starting with the given height or
+	// immediately after the given cursor.
+	WatchEvents(ctx context.Context, req *WatchEventsRequest) (<-chan *WatchedEvent, pubsub.ClosableSubscription, error)
+
+	// WatchValidatorSetChanges returns a channel that produces a stream of validator set changes
+	// (added and removed validators and voting power changes), starting with the given height.
+	// Only heights at which the validator set changed are included in the stream.
+	WatchValidatorSetChanges(ctx context.Context, req *WatchValidatorSetChangesRequest) (<-chan *ValidatorSetChange, pubsub.ClosableSubscription, error)
+
 	// GetGenesisDocument returns the original genesis document.
 	GetGenesisDocument(ctx context.Context) (*genesis.Document, error)
 
@@ -227,6 +291,43 @@ type Block struct {
 	Meta cbor.RawMessage `json:"meta"`
 }
 
+// MaxGetBlocksLimit is the maximum number of blocks returned by a single GetBlocks call.
+const MaxGetBlocksLimit = 100
+
+// GetBlocksRequest is a GetBlocks request.
+type GetBlocksRequest struct {
+	// StartHeight is the height of the first block to return.
+	StartHeight int64 `json:"start_height"`
+	// EndHeight is the height of the last block to return (inclusive). If zero, the latest height
+	// is used.
+	EndHeight int64 `json:"end_height,omitempty"`
+	// Limit is the maximum number of blocks to return. If zero or larger than MaxGetBlocksLimit,
+	// MaxGetBlocksLimit is used.
+	//
+	// Clients should continue with the height following the last returned block to fetch the
+	// remaining blocks in the range.
+	Limit uint64 `json:"limit,omitempty"`
+}
+
+// SanityCheck performs a basic sanity check on the GetBlocks request.
+func (r *GetBlocksRequest) SanityCheck() error {
+	if r.StartHeight <= 0 {
+		return fmt.Errorf("%w: malformed start height", ErrInvalidArgument)
+	}
+	if r.EndHeight != HeightLatest && r.EndHeight < r.StartHeight {
+		return fmt.Errorf("%w: end height must not be lower than start height", ErrInvalidArgument)
+	}
+	return nil
+}
+
+// EffectiveLimit returns the maximum number of blocks that should be returned.
+func (r *GetBlocksRequest) EffectiveLimit() uint64 {
+	if r.Limit == 0 || r.Limit > MaxGetBlocksLimit {
+		return MaxGetBlocksLimit
+	}
+	return r.Limit
+}
+
 // NextBlockState has the state of the next block being voted on by validators.
 type NextBlockState struct {
 	Height int64 `json:"height"`
@@ -435,6 +536,299 @@ type TransactionsWithResults struct {
 	Results      []*results.Result `json:"results"`
 }
 
+// TransactionWithResult is a GetTransactionByHash response.
+type TransactionWithResult struct {
+	// Height is the height of the block that includes the transaction.
+	Height int64 `json:"height"`
+	// Index is the index of the transaction within the block.
+	Index uint32 `json:"index"`
+	// Transaction is the raw signed transaction.
+	Transaction []byte `json:"transaction"`
+	// Result is the transaction execution result.
+	Result *results.Result `json:"result"`
+}
+
+// SubmitTxResult is a SubmitTxWithResults response.
+type SubmitTxResult struct {
+	// Hash is the hash of the raw signed transaction.
+	Hash hash.Hash `json:"hash"`
+	// Height is the height of the block that includes the transaction.
+	Height int64 `json:"height"`
+	// Index is the index of the transaction within the block.
+	Index uint32 `json:"index"`
+	// Result is the transaction execution result.
+	Result *results.Result `json:"result"`
+}
+
+// DecodedTransaction is a decoded consensus transaction together with its execution result.
+type DecodedTransaction struct {
+	// Hash is the hash of the raw signed transaction.
+	Hash hash.Hash `json:"hash"`
+	// Signer is the public key of the transaction signer.
+	Signer signature.PublicKey `json:"signer"`
+	// Transaction is the decoded transaction. It is nil in case the transaction is malformed or
+	// the signature is invalid.
+	Transaction *transaction.Transaction `json:"transaction,omitempty"`
+	// Result is the transaction execution result.
+	Result *results.Result `json:"result"`
+	// Error is the error encountered while decoding the transaction, if any.
+	Error error `json:"-"`
+}
+
+// Decode decodes all transactions and pairs them with their execution results.
+//
+// Transactions that fail to decode are still returned (with Transaction set to nil and Error
+// set accordingly) as blocks may contain malformed transactions.
+func (t *TransactionsWithResults) Decode() ([]*DecodedTransaction, error) {
+	if len(t.Transactions) != len(t.Results) {
+		return nil, fmt.Errorf("consensus: malformed transactions with results (%d transactions, %d results)",
+			len(t.Transactions), len(t.Results),
+		)
+	}
+
+	signers, txs, errs := transaction.OpenRawTransactions(t.Transactions)
+	decoded := make([]*DecodedTransaction, 0, len(t.Transactions))
+	for i, raw := range t.Transactions {
+		decoded = append(decoded, &DecodedTransaction{
+			Hash:        hash.NewFromBytes(raw),
+			Signer:      signers[i],
+			Transaction: txs[i],
+			Result:      t.Results[i],
+			Error:       errs[i],
+		})
+	}
+	return decoded, nil
+}
+
+// EventKind is the kind of a consensus event.
+type EventKind string
+
+const (
+	// EventKindStaking is the kind of staking events.
+	EventKindStaking EventKind = "staking"
+	// EventKindRegistry is the kind of registry events.
+	EventKindRegistry EventKind = "registry"
+	// EventKindRootHash is the kind of roothash events.
+	EventKindRootHash EventKind = "roothash"
+	// EventKindGovernance is the kind of governance events.
+	EventKindGovernance EventKind = "governance"
+	// EventKindVault is the kind of vault events.
+	EventKindVault EventKind = "vault"
+)
+
+func eventKind(ev *results.Event) EventKind {
+	switch {
+	case ev.Staking != nil:
+		return EventKindStaking
+	case ev.Registry != nil:
+		return EventKindRegistry
+	case ev.RootHash != nil:
+		return EventKindRootHash
+	case ev.Governance != nil:
+		return EventKindGovernance
+	case ev.Vault != nil:
+		return EventKindVault
+	default:
+		return ""
+	}
+}
+
+func eventTxHash(ev *results.Event) hash.Hash {
+	switch {
+	case ev.Staking != nil:
+		return ev.Staking.TxHash
+	case ev.Registry != nil:
+		return ev.Registry.TxHash
+	case ev.RootHash != nil:
+		return ev.RootHash.TxHash
+	case ev.Governance != nil:
+		return ev.Governance.TxHash
+	case ev.Vault != nil:
+		return ev.Vault.TxHash
+	default:
+		return hash.Hash{}
+	}
+}
+
+// EventFilter is a consensus event filter.
+//
+// All of the specified conditions must be satisfied for an event to match.
+type EventFilter struct {
+	// Kinds restricts events to the given kinds. If empty, events of all kinds match.
+	Kinds []EventKind `json:"kinds,omitempty"`
+
+	// TxHash restricts events to the ones emitted by the given transaction.
+	TxHash *hash.Hash `json:"tx_hash,omitempty"`
+
+	// Addresses restricts staking events to the ones involving any of the given accounts.
+	Addresses []staking.Address `json:"addresses,omitempty"`
+
+	// RuntimeIDs restricts roothash events to the ones for any of the given runtimes.
+	RuntimeIDs []common.Namespace `json:"runtime_ids,omitempty"`
+}
+
+// SanityCheck performs a basic sanity check on the event filter.
+func (f *EventFilter) SanityCheck() error {
+	for _, kind := range f.Kinds {
+		switch kind {
+		case EventKindStaking, EventKindRegistry, EventKindRootHash, EventKindGovernance, EventKindVault:
+		default:
+			return fmt.Errorf("%w: unknown event kind: '%s'", ErrInvalidArgument, kind)
+		}
+	}
+	return nil
+}
+
+// Matches returns true iff the given event matches the filter.
+func (f *EventFilter) Matches(ev *results.Event) bool {
+	kind := eventKind(ev)
+	if kind == "" {
+		return false
+	}
+	if len(f.Kinds) > 0 && !slices.Contains(f.Kinds, kind) {
+		return false
+	}
+	if f.TxHash != nil {
+		if txHash := eventTxHash(ev); !txHash.Equal(f.TxHash) {
+			return false
+		}
+	}
+	if len(f.Addresses) > 0 && kind == EventKindStaking {
+		if !slices.ContainsFunc(f.Addresses, ev.Staking.Involves) {
+			return false
+		}
+	}
+	if len(f.RuntimeIDs) > 0 && kind == EventKindRootHash {
+		if !slices.Contains(f.RuntimeIDs, ev.RootHash.RuntimeID) {
+			return false
+		}
+	}
+	return true
+}
+
+// EventCursor is a stable position of an event in the consensus event stream.
+type EventCursor struct {
+	// Height is the height of the block in which the event was emitted.
+	Height int64 `json:"height"`
+	// Index is the index of the event among all events emitted in the block.
+	Index uint32 `json:"index"`
+}
+
+// String returns a string representation of the event cursor.
+func (c EventCursor) String() string {
+	return fmt.Sprintf("%d:%d", c.Height, c.Index)
+}
+
+// WatchedEvent is an event returned by WatchEvents.
+type WatchedEvent struct {
+	// Cursor is the position of the event in the event stream. It can be used to resume watching
+	// events after a disconnect without duplicates or gaps.
+	Cursor EventCursor `json:"cursor"`
+
+	// Event is the event.
+	Event *results.Event `json:"event"`
+}
+
+// WatchEventsRequest is a WatchEvents request.
+type WatchEventsRequest struct {
+	// Height is the height of the first block to stream events for. If zero, events are streamed
+	// starting with the next block.
+	Height int64 `json:"height,omitempty"`
+
+	// After is the cursor of the last event received by the client. If set, events are streamed
+	// starting with the event immediately following the cursor and Height must be zero.
+	After *EventCursor `json:"after,omitempty"`
+
+	// Filter is the filter that events need to match.
+	Filter EventFilter `json:"filter"`
+}
+
+// SanityCheck performs a basic sanity check on the WatchEvents request.
+func (r *WatchEventsRequest) SanityCheck() error {
+	if r.After != nil {
+		if r.Height != HeightLatest {
+			return fmt.Errorf("%w: height and cursor are mutually exclusive", ErrInvalidArgument)
+		}
+		if r.After.Height <= 0 {
+			return fmt.Errorf("%w: malformed cursor", ErrInvalidArgument)
+		}
+	}
+	if r.Height < 0 {
+		return fmt.Errorf("%w: malformed height", ErrInvalidArgument)
+	}
+	return r.Filter.SanityCheck()
+}
+
+// StartCursor returns the cursor of the first event that should be streamed, if any.
+func (r *WatchEventsRequest) StartCursor() (EventCursor, bool) {
+	switch {
+	case r.After != nil:
+		return EventCursor{Height: r.After.Height, Index: r.After.Index + 1}, true
+	case r.Height != HeightLatest:
+		return EventCursor{Height: r.Height}, true
+	default:
+		return EventCursor{}, false
+	}
+}
+
+// ValidatorSetProposerHints is the number of upcoming proposers included in validator set changes.
+const ValidatorSetProposerHints = 10
+
+// WatchValidatorSetChangesRequest is a WatchValidatorSetChanges request.
+type WatchValidatorSetChangesRequest struct {
+	// Height is the height of the first block to stream validator set changes for. If zero,
+	// changes are streamed starting with the next block.
+	Height int64 `json:"height,omitempty"`
+}
+
+// SanityCheck performs a basic sanity check on the WatchValidatorSetChanges request.
+func (r *WatchValidatorSetChangesRequest) SanityCheck() error {
+	if r.Height < 0 {
+		return fmt.Errorf("%w: malformed height", ErrInvalidArgument)
+	}
+	return nil
+}
+
+// ValidatorSetChange is a change of the validator set at a given height.
+type ValidatorSetChange struct {
+	// Height is the height of the first block validated by the changed validator set.
+	Height int64 `json:"height"`
+
+	// Added are the validators added to the validator set.
+	Added []*ValidatorPower `json:"added,omitempty"`
+	// Removed are the consensus public keys of the validators removed from the validator set.
+	Removed []signature.PublicKey `json:"removed,omitempty"`
+	// Updated are the validators whose voting power changed.
+	Updated []*ValidatorPowerChange `json:"updated,omitempty"`
+
+	// TotalVotingPower is the total voting power of the changed validator set.
+	TotalVotingPower int64 `json:"total_voting_power"`
+	// Proposers are the consensus public keys of the expected proposers of the next
+	// ValidatorSetProposerHints blocks, starting with the block at Height.
+	//
+	// NOTE: This is only a hint as the schedule assumes that all blocks are proposed in the
+	// first round and that the validator set does not change in the meantime.
+	Proposers []signature.PublicKey `json:"proposers,omitempty"`
+}
+
+// ValidatorPower is a validator together with its voting power.
+type ValidatorPower struct {
+	// PublicKey is the validator's consensus public key.
+	PublicKey signature.PublicKey `json:"public_key"`
+	// VotingPower is the validator's voting power.
+	VotingPower int64 `json:"voting_power"`
+}
+
+// ValidatorPowerChange is a change of a validator's voting power.
+type ValidatorPowerChange struct {
+	// PublicKey is the validator's consensus public key.
+	PublicKey signature.PublicKey `json:"public_key"`
+	// OldVotingPower is the validator's previous voting power.
+	OldVotingPower int64 `json:"old_voting_power"`
+	// NewVotingPower is the validator's new voting power.
+	NewVotingPower int64 `json:"new_voting_power"`
+}
+
 // TransactionsWithProofs is GetTransactionsWithProofs response.
 //
 // Proofs[i] is a proof of block inclusion for Transactions[i].
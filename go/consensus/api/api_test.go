@@ -0,0 +1,179 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction/results"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+func TestEventFilter(t *testing.T) {
+	require := require.New(t)
+
+	addr1 := staking.NewAddress(signature.NewPublicKey("badfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+	addr2 := staking.NewAddress(signature.NewPublicKey("badaffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"))
+	var runtimeID1, runtimeID2 common.Namespace
+	_ = runtimeID2.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000001")
+	txHash := hash.NewFromBytes([]byte("tx"))
+
+	transferEv := &results.Event{Staking: &staking.Event{
+		TxHash:   txHash,
+		Transfer: &staking.TransferEvent{From: addr1, To: addr1},
+	}}
+	roothashEv := &results.Event{RootHash: &roothash.Event{
+		RuntimeID: runtimeID1,
+		Finalized: &roothash.FinalizedEvent{},
+	}}
+	governanceEv := &results.Event{Governance: &governance.Event{
+		Vote: &governance.VoteEvent{},
+	}}
+
+	var f EventFilter
+	require.NoError(f.SanityCheck(), "empty filter should be valid")
+	require.True(f.Matches(transferEv), "empty filter should match everything")
+	require.True(f.Matches(roothashEv), "empty filter should match everything")
+	require.False(f.Matches(&results.Event{}), "empty events should never match")
+
+	f = EventFilter{Kinds: []EventKind{"invalid"}}
+	require.Error(f.SanityCheck(), "unknown event kinds should be invalid")
+
+	f = EventFilter{Kinds: []EventKind{EventKindStaking, EventKindGovernance}}
+	require.NoError(f.SanityCheck())
+	require.True(f.Matches(transferEv))
+	require.True(f.Matches(governanceEv))
+	require.False(f.Matches(roothashEv))
+
+	f = EventFilter{TxHash: &txHash}
+	require.True(f.Matches(transferEv))
+	require.False(f.Matches(governanceEv))
+
+	f = EventFilter{Addresses: []staking.Address{addr1}}
+	require.True(f.Matches(transferEv))
+	require.True(f.Matches(roothashEv), "address filter should only apply to staking events")
+	f = EventFilter{Addresses: []staking.Address{addr2}}
+	require.False(f.Matches(transferEv))
+
+	f = EventFilter{RuntimeIDs: []common.Namespace{runtimeID1}}
+	require.True(f.Matches(roothashEv))
+	require.True(f.Matches(transferEv), "runtime filter should only apply to roothash events")
+	f = EventFilter{RuntimeIDs: []common.Namespace{runtimeID2}}
+	require.False(f.Matches(roothashEv))
+}
+
+func TestWatchEventsRequest(t *testing.T) {
+	require := require.New(t)
+
+	var req WatchEventsRequest
+	require.NoError(req.SanityCheck(), "empty request should be valid")
+	_, ok := req.StartCursor()
+	require.False(ok, "empty request should not have a start cursor")
+
+	req = WatchEventsRequest{Height: 10}
+	require.NoError(req.SanityCheck())
+	cursor, ok := req.StartCursor()
+	require.True(ok)
+	require.Equal(EventCursor{Height: 10}, cursor)
+
+	req = WatchEventsRequest{After: &EventCursor{Height: 10, Index: 3}}
+	require.NoError(req.SanityCheck())
+	cursor, ok = req.StartCursor()
+	require.True(ok)
+	require.Equal(EventCursor{Height: 10, Index: 4}, cursor, "stream should resume after the cursor")
+
+	req = WatchEventsRequest{Height: 5, After: &EventCursor{Height: 10}}
+	require.Error(req.SanityCheck(), "height and cursor should be mutually exclusive")
+
+	req = WatchEventsRequest{After: &EventCursor{}}
+	require.Error(req.SanityCheck(), "malformed cursor should be invalid")
+
+	req = WatchEventsRequest{Height: -1}
+	require.Error(req.SanityCheck(), "negative height should be invalid")
+}
+
+func TestGetBlocksRequest(t *testing.T) {
+	require := require.New(t)
+
+	req := GetBlocksRequest{StartHeight: 1}
+	require.NoError(req.SanityCheck())
+	require.EqualValues(MaxGetBlocksLimit, req.EffectiveLimit(), "zero limit should use the maximum")
+
+	req = GetBlocksRequest{StartHeight: 1, EndHeight: 10, Limit: 5}
+	require.NoError(req.SanityCheck())
+	require.EqualValues(5, req.EffectiveLimit())
+
+	req = GetBlocksRequest{StartHeight: 1, Limit: MaxGetBlocksLimit + 1}
+	require.EqualValues(MaxGetBlocksLimit, req.EffectiveLimit(), "limit should be capped")
+
+	req = GetBlocksRequest{}
+	require.Error(req.SanityCheck(), "zero start height should be invalid")
+
+	req = GetBlocksRequest{StartHeight: 10, EndHeight: 5}
+	require.Error(req.SanityCheck(), "end height lower than start height should be invalid")
+}
+
+func TestGetLightBlockRangeRequest(t *testing.T) {
+	require := require.New(t)
+
+	req := GetLightBlockRangeRequest{StartHeight: 1}
+	require.NoError(req.SanityCheck())
+
+	req = GetLightBlockRangeRequest{StartHeight: 5, EndHeight: 5}
+	require.NoError(req.SanityCheck())
+
+	req = GetLightBlockRangeRequest{}
+	require.Error(req.SanityCheck(), "zero start height should be invalid")
+
+	req = GetLightBlockRangeRequest{StartHeight: 10, EndHeight: 5}
+	require.Error(req.SanityCheck(), "end height lower than start height should be invalid")
+}
+
+func TestLightBlockRangeSanityCheck(t *testing.T) {
+	require := require.New(t)
+
+	req := &GetLightBlockRangeRequest{StartHeight: 10, EndHeight: 11}
+	lbr := LightBlockRange{
+		Blocks: []*CompactLightBlock{
+			{Height: 10, ValidatorSetIndex: 0},
+			{Height: 11, ValidatorSetIndex: 0},
+		},
+		ValidatorSets: [][]byte{{}},
+	}
+	require.NoError(lbr.SanityCheck(req))
+
+	empty := LightBlockRange{}
+	require.Error(empty.SanityCheck(req), "empty range should be invalid")
+
+	gap := LightBlockRange{
+		Blocks: []*CompactLightBlock{
+			{Height: 10, ValidatorSetIndex: 0},
+			{Height: 12, ValidatorSetIndex: 0},
+		},
+		ValidatorSets: [][]byte{{}},
+	}
+	require.Error(gap.SanityCheck(req), "non-consecutive range should be invalid")
+
+	beyond := LightBlockRange{
+		Blocks: []*CompactLightBlock{
+			{Height: 10, ValidatorSetIndex: 0},
+			{Height: 11, ValidatorSetIndex: 0},
+			{Height: 12, ValidatorSetIndex: 0},
+		},
+		ValidatorSets: [][]byte{{}},
+	}
+	require.Error(beyond.SanityCheck(req), "range beyond end height should be invalid")
+
+	badIndex := LightBlockRange{
+		Blocks: []*CompactLightBlock{
+			{Height: 10, ValidatorSetIndex: 1},
+		},
+		ValidatorSets: [][]byte{{}},
+	}
+	require.Error(badIndex.SanityCheck(req), "out of bounds validator set index should be invalid")
+}
@@ -2,20 +2,24 @@ package api
 
 import (
 	"context"
+	"io"
 
 	"google.golang.org/grpc"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction/results"
 	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
 	vault "github.com/oasisprotocol/oasis-core/go/vault/api"
 )
@@ -30,24 +34,40 @@ var (
 	methodSubmitTxNoWait = serviceName.NewMethod("SubmitTxNoWait", transaction.SignedTransaction{})
 	// methodSubmitTxWithProof is the SubmitTxWithProof method.
 	methodSubmitTxWithProof = serviceName.NewMethod("SubmitTxWithProof", transaction.SignedTransaction{})
+	// methodSubmitTxWithResults is the SubmitTxWithResults method.
+	methodSubmitTxWithResults = serviceName.NewMethod("SubmitTxWithResults", transaction.SignedTransaction{})
+	// methodSubmitTxAsync is the SubmitTxAsync method.
+	methodSubmitTxAsync = serviceName.NewMethod("SubmitTxAsync", transaction.SignedTransaction{})
 	// methodStateToGenesis is the StateToGenesis method.
 	methodStateToGenesis = serviceName.NewMethod("StateToGenesis", int64(0))
 	// methodEstimateGas is the EstimateGas method.
 	methodEstimateGas = serviceName.NewMethod("EstimateGas", &EstimateGasRequest{})
+	// methodEstimateFee is the EstimateFee method.
+	methodEstimateFee = serviceName.NewMethod("EstimateFee", &EstimateGasRequest{})
 	// methodMinGasPrice is the MinGasPrice method.
 	methodMinGasPrice = serviceName.NewMethod("MinGasPrice", nil)
 	// methodGetSignerNonce is a GetSignerNonce method.
 	methodGetSignerNonce = serviceName.NewMethod("GetSignerNonce", &GetSignerNonceRequest{})
 	// methodGetBlock is the GetBlock method.
 	methodGetBlock = serviceName.NewMethod("GetBlock", int64(0))
+	// methodGetBlocks is the GetBlocks method.
+	methodGetBlocks = serviceName.NewMethod("GetBlocks", &GetBlocksRequest{})
+	// methodGetBlockByHash is the GetBlockByHash method.
+	methodGetBlockByHash = serviceName.NewMethod("GetBlockByHash", hash.Hash{})
 	// methodGetLightBlock is the GetLightBlock method.
 	methodGetLightBlock = serviceName.NewMethod("GetLightBlock", int64(0))
+	// methodGetLightBlockRange is the GetLightBlockRange method.
+	methodGetLightBlockRange = serviceName.NewMethod("GetLightBlockRange", &GetLightBlockRangeRequest{})
 	// methodGetTransactions is the GetTransactions method.
 	methodGetTransactions = serviceName.NewMethod("GetTransactions", int64(0))
 	// methodGetTransactionsWithResults is the GetTransactionsWithResults method.
 	methodGetTransactionsWithResults = serviceName.NewMethod("GetTransactionsWithResults", int64(0))
 	// methodGetTransactionsWithProofs is the GetTransactionsWithProofs method.
 	methodGetTransactionsWithProofs = serviceName.NewMethod("GetTransactionsWithProofs", int64(0))
+	// methodGetTransactionByHash is the GetTransactionByHash method.
+	methodGetTransactionByHash = serviceName.NewMethod("GetTransactionByHash", hash.Hash{})
+	// methodGetEventsByHeight is the GetEventsByHeight method.
+	methodGetEventsByHeight = serviceName.NewMethod("GetEventsByHeight", int64(0))
 	// methodGetUnconfirmedTransactions is the GetUnconfirmedTransactions method.
 	methodGetUnconfirmedTransactions = serviceName.NewMethod("GetUnconfirmedTransactions", nil)
 	// methodGetGenesisDocument is the GetGenesisDocument method.
@@ -66,11 +86,19 @@ var (
 	methodGetNextBlockState = serviceName.NewMethod("GetNextBlockState", nil)
 	// methodGetParameters is the GetParameters method.
 	methodGetParameters = serviceName.NewMethod("GetParameters", int64(0))
+	// methodGetCheckpoints is the GetCheckpoints method.
+	methodGetCheckpoints = serviceName.NewMethod("GetCheckpoints", nil)
+	// methodGetCheckpointChunk is the GetCheckpointChunk method.
+	methodGetCheckpointChunk = serviceName.NewMethod("GetCheckpointChunk", checkpoint.ChunkMetadata{})
 	// methodSubmitEvidence is the SubmitEvidence method.
 	methodSubmitEvidence = serviceName.NewMethod("SubmitEvidence", &Evidence{})
 
 	// methodWatchBlocks is the WatchBlocks method.
 	methodWatchBlocks = serviceName.NewMethod("WatchBlocks", nil)
+	// methodWatchEvents is the WatchEvents method.
+	methodWatchEvents = serviceName.NewMethod("WatchEvents", &WatchEventsRequest{})
+	// methodWatchValidatorSetChanges is the WatchValidatorSetChanges method.
+	methodWatchValidatorSetChanges = serviceName.NewMethod("WatchValidatorSetChanges", &WatchValidatorSetChangesRequest{})
 
 	// serviceDesc is the gRPC service descriptor.
 	serviceDesc = grpc.ServiceDesc{
@@ -89,6 +117,14 @@ var (
 				MethodName: methodSubmitTxWithProof.ShortName(),
 				Handler:    handlerSubmitTxWithProof,
 			},
+			{
+				MethodName: methodSubmitTxWithResults.ShortName(),
+				Handler:    handlerSubmitTxWithResults,
+			},
+			{
+				MethodName: methodSubmitTxAsync.ShortName(),
+				Handler:    handlerSubmitTxAsync,
+			},
 			{
 				MethodName: methodStateToGenesis.ShortName(),
 				Handler:    handlerStateToGenesis,
@@ -97,6 +133,10 @@ var (
 				MethodName: methodEstimateGas.ShortName(),
 				Handler:    handlerEstimateGas,
 			},
+			{
+				MethodName: methodEstimateFee.ShortName(),
+				Handler:    handlerEstimateFee,
+			},
 			{
 				MethodName: methodMinGasPrice.ShortName(),
 				Handler:    handlerMinGasPrice,
@@ -109,10 +149,22 @@ var (
 				MethodName: methodGetBlock.ShortName(),
 				Handler:    handlerGetBlock,
 			},
+			{
+				MethodName: methodGetBlocks.ShortName(),
+				Handler:    handlerGetBlocks,
+			},
+			{
+				MethodName: methodGetBlockByHash.ShortName(),
+				Handler:    handlerGetBlockByHash,
+			},
 			{
 				MethodName: methodGetLightBlock.ShortName(),
 				Handler:    handlerGetLightBlock,
 			},
+			{
+				MethodName: methodGetLightBlockRange.ShortName(),
+				Handler:    handlerGetLightBlockRange,
+			},
 			{
 				MethodName: methodGetTransactions.ShortName(),
 				Handler:    handlerGetTransactions,
@@ -125,6 +177,14 @@ var (
 				MethodName: methodGetTransactionsWithProofs.ShortName(),
 				Handler:    handlerGetTransactionsWithProofs,
 			},
+			{
+				MethodName: methodGetTransactionByHash.ShortName(),
+				Handler:    handlerGetTransactionByHash,
+			},
+			{
+				MethodName: methodGetEventsByHeight.ShortName(),
+				Handler:    handlerGetEventsByHeight,
+			},
 			{
 				MethodName: methodGetUnconfirmedTransactions.ShortName(),
 				Handler:    handlerGetUnconfirmedTransactions,
@@ -161,6 +221,10 @@ var (
 				MethodName: methodGetParameters.ShortName(),
 				Handler:    handlerGetParameters,
 			},
+			{
+				MethodName: methodGetCheckpoints.ShortName(),
+				Handler:    handlerGetCheckpoints,
+			},
 			{
 				MethodName: methodSubmitEvidence.ShortName(),
 				Handler:    handlerSubmitEvidence,
@@ -172,6 +236,21 @@ var (
 				Handler:       handlerWatchBlocks,
 				ServerStreams: true,
 			},
+			{
+				StreamName:    methodWatchEvents.ShortName(),
+				Handler:       handlerWatchEvents,
+				ServerStreams: true,
+			},
+			{
+				StreamName:    methodGetCheckpointChunk.ShortName(),
+				Handler:       handlerGetCheckpointChunk,
+				ServerStreams: true,
+			},
+			{
+				StreamName:    methodWatchValidatorSetChanges.ShortName(),
+				Handler:       handlerWatchValidatorSetChanges,
+				ServerStreams: true,
+			},
 		},
 	}
 )
@@ -245,6 +324,52 @@ func handlerSubmitTxWithProof(
 	return interceptor(ctx, rq, info, handler)
 }
 
+func handlerSubmitTxWithResults(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	rq := new(transaction.SignedTransaction)
+	if err := dec(rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientBackend).SubmitTxWithResults(ctx, rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodSubmitTxWithResults.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientBackend).SubmitTxWithResults(ctx, req.(*transaction.SignedTransaction))
+	}
+	return interceptor(ctx, rq, info, handler)
+}
+
+func handlerSubmitTxAsync(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	rq := new(transaction.SignedTransaction)
+	if err := dec(rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientBackend).SubmitTxAsync(ctx, rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodSubmitTxAsync.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientBackend).SubmitTxAsync(ctx, req.(*transaction.SignedTransaction))
+	}
+	return interceptor(ctx, rq, info, handler)
+}
+
 func handlerStateToGenesis(
 	srv interface{},
 	ctx context.Context,
@@ -291,6 +416,29 @@ func handlerEstimateGas(
 	return interceptor(ctx, rq, info, handler)
 }
 
+func handlerEstimateFee(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	rq := new(EstimateGasRequest)
+	if err := dec(rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientBackend).EstimateFee(ctx, rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodEstimateFee.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientBackend).EstimateFee(ctx, req.(*EstimateGasRequest))
+	}
+	return interceptor(ctx, rq, info, handler)
+}
+
 func handlerMinGasPrice(
 	srv interface{},
 	ctx context.Context,
@@ -356,6 +504,52 @@ func handlerGetBlock(
 	return interceptor(ctx, height, info, handler)
 }
 
+func handlerGetBlocks(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	rq := new(GetBlocksRequest)
+	if err := dec(rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientBackend).GetBlocks(ctx, rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetBlocks.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientBackend).GetBlocks(ctx, req.(*GetBlocksRequest))
+	}
+	return interceptor(ctx, rq, info, handler)
+}
+
+func handlerGetBlockByHash(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var blkHash hash.Hash
+	if err := dec(&blkHash); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientBackend).GetBlockByHash(ctx, blkHash)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetBlockByHash.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientBackend).GetBlockByHash(ctx, req.(hash.Hash))
+	}
+	return interceptor(ctx, blkHash, info, handler)
+}
+
 func handlerGetLightBlock(
 	srv interface{},
 	ctx context.Context,
@@ -379,6 +573,29 @@ func handlerGetLightBlock(
 	return interceptor(ctx, height, info, handler)
 }
 
+func handlerGetLightBlockRange(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	rq := new(GetLightBlockRangeRequest)
+	if err := dec(rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientBackend).GetLightBlockRange(ctx, rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetLightBlockRange.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientBackend).GetLightBlockRange(ctx, req.(*GetLightBlockRangeRequest))
+	}
+	return interceptor(ctx, rq, info, handler)
+}
+
 func handlerGetTransactions(
 	srv interface{},
 	ctx context.Context,
@@ -448,6 +665,52 @@ func handlerGetTransactionsWithProofs(
 	return interceptor(ctx, height, info, handler)
 }
 
+func handlerGetTransactionByHash(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var txHash hash.Hash
+	if err := dec(&txHash); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientBackend).GetTransactionByHash(ctx, txHash)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetTransactionByHash.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientBackend).GetTransactionByHash(ctx, req.(hash.Hash))
+	}
+	return interceptor(ctx, txHash, info, handler)
+}
+
+func handlerGetEventsByHeight(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var height int64
+	if err := dec(&height); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientBackend).GetEventsByHeight(ctx, height)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetEventsByHeight.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientBackend).GetEventsByHeight(ctx, req.(int64))
+	}
+	return interceptor(ctx, height, info, handler)
+}
+
 func handlerGetUnconfirmedTransactions(
 	srv interface{},
 	ctx context.Context,
@@ -635,6 +898,25 @@ func handlerGetParameters(
 	return interceptor(ctx, height, info, handler)
 }
 
+func handlerGetCheckpoints(
+	srv interface{},
+	ctx context.Context,
+	_ func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	if interceptor == nil {
+		return srv.(ClientBackend).GetCheckpoints(ctx)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetCheckpoints.FullName(),
+	}
+	handler := func(ctx context.Context, _ interface{}) (interface{}, error) {
+		return srv.(ClientBackend).GetCheckpoints(ctx)
+	}
+	return interceptor(ctx, nil, info, handler)
+}
+
 func handlerSubmitEvidence(
 	srv interface{},
 	ctx context.Context,
@@ -686,6 +968,73 @@ func handlerWatchBlocks(srv interface{}, stream grpc.ServerStream) error {
 	}
 }
 
+func handlerGetCheckpointChunk(srv interface{}, stream grpc.ServerStream) error {
+	var md checkpoint.ChunkMetadata
+	if err := stream.RecvMsg(&md); err != nil {
+		return err
+	}
+
+	return srv.(ClientBackend).GetCheckpointChunk(stream.Context(), &md, cmnGrpc.NewStreamWriter(stream))
+}
+
+func handlerWatchEvents(srv interface{}, stream grpc.ServerStream) error {
+	var req WatchEventsRequest
+	if err := stream.RecvMsg(&req); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	ch, sub, err := srv.(ClientBackend).WatchEvents(ctx, &req)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case ev, ok := <-ch:
+			if !ok {
+				return nil
+			}
+
+			if err := stream.SendMsg(ev); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func handlerWatchValidatorSetChanges(srv interface{}, stream grpc.ServerStream) error {
+	var req WatchValidatorSetChangesRequest
+	if err := stream.RecvMsg(&req); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	ch, sub, err := srv.(ClientBackend).WatchValidatorSetChanges(ctx, &req)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case change, ok := <-ch:
+			if !ok {
+				return nil
+			}
+
+			if err := stream.SendMsg(change); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
 // RegisterService registers a new client backend service with the given gRPC server.
 func RegisterService(server *grpc.Server, service ClientBackend) {
 	server.RegisterService(&serviceDesc, service)
@@ -711,6 +1060,22 @@ func (c *consensusClient) SubmitTxWithProof(ctx context.Context, tx *transaction
 	return &proof, nil
 }
 
+func (c *consensusClient) SubmitTxWithResults(ctx context.Context, tx *transaction.SignedTransaction) (*SubmitTxResult, error) {
+	var rsp SubmitTxResult
+	if err := c.conn.Invoke(ctx, methodSubmitTxWithResults.FullName(), tx, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *consensusClient) SubmitTxAsync(ctx context.Context, tx *transaction.SignedTransaction) (hash.Hash, error) {
+	var rsp hash.Hash
+	if err := c.conn.Invoke(ctx, methodSubmitTxAsync.FullName(), tx, &rsp); err != nil {
+		return hash.Hash{}, err
+	}
+	return rsp, nil
+}
+
 func (c *consensusClient) StateToGenesis(ctx context.Context, height int64) (*genesis.Document, error) {
 	var rsp genesis.Document
 	if err := c.conn.Invoke(ctx, methodStateToGenesis.FullName(), height, &rsp); err != nil {
@@ -727,6 +1092,14 @@ func (c *consensusClient) EstimateGas(ctx context.Context, req *EstimateGasReque
 	return gas, nil
 }
 
+func (c *consensusClient) EstimateFee(ctx context.Context, req *EstimateGasRequest) (*transaction.Fee, error) {
+	var fee transaction.Fee
+	if err := c.conn.Invoke(ctx, methodEstimateFee.FullName(), req, &fee); err != nil {
+		return nil, err
+	}
+	return &fee, nil
+}
+
 func (c *consensusClient) MinGasPrice(ctx context.Context) (*quantity.Quantity, error) {
 	var rsp quantity.Quantity
 	if err := c.conn.Invoke(ctx, methodMinGasPrice.FullName(), nil, &rsp); err != nil {
@@ -751,6 +1124,22 @@ func (c *consensusClient) GetBlock(ctx context.Context, height int64) (*Block, e
 	return &rsp, nil
 }
 
+func (c *consensusClient) GetBlocks(ctx context.Context, req *GetBlocksRequest) ([]*Block, error) {
+	var rsp []*Block
+	if err := c.conn.Invoke(ctx, methodGetBlocks.FullName(), req, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+func (c *consensusClient) GetBlockByHash(ctx context.Context, hash hash.Hash) (*Block, error) {
+	var rsp Block
+	if err := c.conn.Invoke(ctx, methodGetBlockByHash.FullName(), hash, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *consensusClient) GetLightBlock(ctx context.Context, height int64) (*LightBlock, error) {
 	var rsp LightBlock
 	if err := c.conn.Invoke(ctx, methodGetLightBlock.FullName(), height, &rsp); err != nil {
@@ -759,6 +1148,14 @@ func (c *consensusClient) GetLightBlock(ctx context.Context, height int64) (*Lig
 	return &rsp, nil
 }
 
+func (c *consensusClient) GetLightBlockRange(ctx context.Context, req *GetLightBlockRangeRequest) (*LightBlockRange, error) {
+	var rsp LightBlockRange
+	if err := c.conn.Invoke(ctx, methodGetLightBlockRange.FullName(), req, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *consensusClient) GetTransactions(ctx context.Context, height int64) ([][]byte, error) {
 	var rsp [][]byte
 	if err := c.conn.Invoke(ctx, methodGetTransactions.FullName(), height, &rsp); err != nil {
@@ -783,6 +1180,22 @@ func (c *consensusClient) GetTransactionsWithProofs(ctx context.Context, height
 	return &rsp, nil
 }
 
+func (c *consensusClient) GetTransactionByHash(ctx context.Context, txHash hash.Hash) (*TransactionWithResult, error) {
+	var rsp TransactionWithResult
+	if err := c.conn.Invoke(ctx, methodGetTransactionByHash.FullName(), txHash, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *consensusClient) GetEventsByHeight(ctx context.Context, height int64) ([]*results.Event, error) {
+	var rsp []*results.Event
+	if err := c.conn.Invoke(ctx, methodGetEventsByHeight.FullName(), height, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
 func (c *consensusClient) GetUnconfirmedTransactions(ctx context.Context) ([][]byte, error) {
 	var rsp [][]byte
 	if err := c.conn.Invoke(ctx, methodGetUnconfirmedTransactions.FullName(), nil, &rsp); err != nil {
@@ -866,6 +1279,42 @@ func (c *consensusClient) GetParameters(ctx context.Context, height int64) (*Par
 	return &rsp, nil
 }
 
+func (c *consensusClient) GetCheckpoints(ctx context.Context) ([]*checkpoint.Metadata, error) {
+	var rsp []*checkpoint.Metadata
+	if err := c.conn.Invoke(ctx, methodGetCheckpoints.FullName(), nil, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+func (c *consensusClient) GetCheckpointChunk(ctx context.Context, chunk *checkpoint.ChunkMetadata, w io.Writer) error {
+	stream, err := c.conn.NewStream(ctx, &serviceDesc.Streams[2], methodGetCheckpointChunk.FullName())
+	if err != nil {
+		return err
+	}
+	if err = stream.SendMsg(chunk); err != nil {
+		return err
+	}
+	if err = stream.CloseSend(); err != nil {
+		return err
+	}
+
+	for {
+		var part []byte
+		switch err = stream.RecvMsg(&part); err {
+		case nil:
+		case io.EOF:
+			return nil
+		default:
+			return err
+		}
+
+		if _, err = w.Write(part); err != nil {
+			return err
+		}
+	}
+}
+
 func (c *consensusClient) SubmitEvidence(ctx context.Context, evidence *Evidence) error {
 	return c.conn.Invoke(ctx, methodSubmitEvidence.FullName(), evidence, nil)
 }
@@ -905,6 +1354,88 @@ func (c *consensusClient) WatchBlocks(ctx context.Context) (<-chan *Block, pubsu
 	return ch, sub, nil
 }
 
+func (c *consensusClient) WatchEvents(ctx context.Context, req *WatchEventsRequest) (<-chan *WatchedEvent, pubsub.ClosableSubscription, error) {
+	// Streaming errors are only reported once the first message is received, so make sure that
+	// malformed requests are rejected early.
+	if err := req.SanityCheck(); err != nil {
+		return nil, nil, err
+	}
+
+	ctx, sub := pubsub.NewContextSubscription(ctx)
+
+	stream, err := c.conn.NewStream(ctx, &serviceDesc.Streams[1], methodWatchEvents.FullName())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = stream.SendMsg(req); err != nil {
+		return nil, nil, err
+	}
+	if err = stream.CloseSend(); err != nil {
+		return nil, nil, err
+	}
+
+	ch := make(chan *WatchedEvent)
+	go func() {
+		defer close(ch)
+
+		for {
+			var ev WatchedEvent
+			if serr := stream.RecvMsg(&ev); serr != nil {
+				return
+			}
+
+			select {
+			case ch <- &ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, sub, nil
+}
+
+func (c *consensusClient) WatchValidatorSetChanges(ctx context.Context, req *WatchValidatorSetChangesRequest) (<-chan *ValidatorSetChange, pubsub.ClosableSubscription, error) {
+	// Streaming errors are only reported once the first message is received, so make sure that
+	// malformed requests are rejected early.
+	if err := req.SanityCheck(); err != nil {
+		return nil, nil, err
+	}
+
+	ctx, sub := pubsub.NewContextSubscription(ctx)
+
+	stream, err := c.conn.NewStream(ctx, &serviceDesc.Streams[3], methodWatchValidatorSetChanges.FullName())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = stream.SendMsg(req); err != nil {
+		return nil, nil, err
+	}
+	if err = stream.CloseSend(); err != nil {
+		return nil, nil, err
+	}
+
+	ch := make(chan *ValidatorSetChange)
+	go func() {
+		defer close(ch)
+
+		for {
+			var change ValidatorSetChange
+			if serr := stream.RecvMsg(&change); serr != nil {
+				return
+			}
+
+			select {
+			case ch <- &change:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, sub, nil
+}
+
 func (c *consensusClient) Beacon() beacon.Backend {
 	return beacon.NewBeaconClient(c.conn)
 }
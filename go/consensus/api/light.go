@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
@@ -29,6 +30,9 @@ type LightClient interface {
 	// GetLightBlock queries peers for a specific light block.
 	GetLightBlock(ctx context.Context, height int64) (*LightBlock, rpc.PeerFeedback, error)
 
+	// GetLightBlockRange queries peers for a batch of light blocks in the given height range.
+	GetLightBlockRange(ctx context.Context, req *GetLightBlockRangeRequest) (*LightBlockRange, rpc.PeerFeedback, error)
+
 	// GetParameters queries peers for consensus parameters for a specific height.
 	GetParameters(ctx context.Context, height int64) (*Parameters, rpc.PeerFeedback, error)
 
@@ -44,6 +48,73 @@ type LightBlock struct {
 	Meta []byte `json:"meta"`
 }
 
+// MaxLightBlockRangeSize is the maximum number of light blocks returned by a single
+// GetLightBlockRange call.
+const MaxLightBlockRangeSize = 100
+
+// GetLightBlockRangeRequest is a GetLightBlockRange request.
+type GetLightBlockRangeRequest struct {
+	// StartHeight is the height of the first light block to return.
+	StartHeight int64 `json:"start_height"`
+	// EndHeight is the height of the last light block to return (inclusive). If zero, the latest
+	// height is used.
+	//
+	// At most MaxLightBlockRangeSize light blocks are returned. Clients should continue with the
+	// height following the last returned light block to fetch the remaining light blocks.
+	EndHeight int64 `json:"end_height,omitempty"`
+}
+
+// SanityCheck performs a basic sanity check on the GetLightBlockRange request.
+func (r *GetLightBlockRangeRequest) SanityCheck() error {
+	if r.StartHeight <= 0 {
+		return fmt.Errorf("%w: malformed start height", ErrInvalidArgument)
+	}
+	if r.EndHeight != HeightLatest && r.EndHeight < r.StartHeight {
+		return fmt.Errorf("%w: end height must not be lower than start height", ErrInvalidArgument)
+	}
+	return nil
+}
+
+// LightBlockRange is a batch of consecutive light blocks with validator sets shared between
+// the light blocks stored only once.
+type LightBlockRange struct {
+	// Blocks are the light blocks ordered by height.
+	Blocks []*CompactLightBlock `json:"blocks"`
+	// ValidatorSets are the deduplicated consensus backend specific validator sets.
+	ValidatorSets [][]byte `json:"validator_sets"`
+}
+
+// SanityCheck performs a basic sanity check on the light block range returned for the given
+// request.
+func (r *LightBlockRange) SanityCheck(req *GetLightBlockRangeRequest) error {
+	if len(r.Blocks) == 0 || len(r.Blocks) > MaxLightBlockRangeSize {
+		return fmt.Errorf("%w: malformed number of light blocks: %d", ErrInvalidArgument, len(r.Blocks))
+	}
+	for i, blk := range r.Blocks {
+		if blk == nil || blk.Height != req.StartHeight+int64(i) {
+			return fmt.Errorf("%w: light blocks not consecutive from start height", ErrInvalidArgument)
+		}
+		if req.EndHeight != HeightLatest && blk.Height > req.EndHeight {
+			return fmt.Errorf("%w: light block beyond end height", ErrInvalidArgument)
+		}
+		if int(blk.ValidatorSetIndex) >= len(r.ValidatorSets) {
+			return fmt.Errorf("%w: malformed validator set index", ErrInvalidArgument)
+		}
+	}
+	return nil
+}
+
+// CompactLightBlock is a light consensus block without the validator set.
+type CompactLightBlock struct {
+	// Height contains the block height.
+	Height int64 `json:"height"`
+	// Meta contains the consensus backend specific light block without the validator set.
+	Meta []byte `json:"meta"`
+	// ValidatorSetIndex is the index of the light block's validator set in the validator sets
+	// of the containing range.
+	ValidatorSetIndex uint32 `json:"validator_set_index"`
+}
+
 // Parameters are the consensus backend parameters.
 type Parameters struct {
 	// Height contains the block height these consensus parameters are for.
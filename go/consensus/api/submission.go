@@ -33,6 +33,10 @@ type SubmissionManager interface {
 	// PriceDiscovery returns the configured price discovery mechanism instance.
 	PriceDiscovery() PriceDiscovery
 
+	// EstimateFee estimates the fee required to execute the given transaction based on the
+	// estimated amount of gas and the current gas price.
+	EstimateFee(ctx context.Context, signer signature.PublicKey, tx *transaction.Transaction) (*transaction.Fee, error)
+
 	// EstimateGasAndSetFee populates the fee field in the transaction if not already set.
 	EstimateGasAndSetFee(ctx context.Context, signer signature.Signer, tx *transaction.Transaction) error
 
@@ -67,47 +71,52 @@ func (m *submissionManager) PriceDiscovery() PriceDiscovery {
 }
 
 // Implements SubmissionManager.
-func (m *submissionManager) EstimateGasAndSetFee(ctx context.Context, signer signature.Signer, tx *transaction.Transaction) error {
-	if tx.Fee != nil {
-		return nil
-	}
-
+func (m *submissionManager) EstimateFee(ctx context.Context, signer signature.PublicKey, tx *transaction.Transaction) (*transaction.Fee, error) {
 	// Estimate amount of gas needed to perform the update.
-	var (
-		gas transaction.Gas
-		err error
-	)
-	gas, err = m.backend.EstimateGas(ctx, &EstimateGasRequest{Signer: signer.Public(), Transaction: tx})
+	gas, err := m.backend.EstimateGas(ctx, &EstimateGasRequest{Signer: signer, Transaction: tx})
 	if err != nil {
-		return fmt.Errorf("failed to estimate gas: %w", err)
+		return nil, fmt.Errorf("failed to estimate gas: %w", err)
 	}
 
 	// Fetch current consensus gas price and compute the fee.
-	var amount *quantity.Quantity
-	amount, err = m.priceDiscovery.GasPrice()
+	amount, err := m.priceDiscovery.GasPrice()
 	if err != nil {
-		return fmt.Errorf("failed to determine gas price: %w", err)
+		return nil, fmt.Errorf("failed to determine gas price: %w", err)
 	}
 	var gasQuantity quantity.Quantity
 	if err = gasQuantity.FromUint64(uint64(gas)); err != nil {
-		return fmt.Errorf("failed to compute fee amount: %w", err)
+		return nil, fmt.Errorf("failed to compute fee amount: %w", err)
 	}
 	if err = amount.Mul(&gasQuantity); err != nil {
-		return fmt.Errorf("failed to compute fee amount: %w", err)
+		return nil, fmt.Errorf("failed to compute fee amount: %w", err)
+	}
+
+	return &transaction.Fee{
+		Gas:    gas,
+		Amount: *amount,
+	}, nil
+}
+
+// Implements SubmissionManager.
+func (m *submissionManager) EstimateGasAndSetFee(ctx context.Context, signer signature.Signer, tx *transaction.Transaction) error {
+	if tx.Fee != nil {
+		return nil
+	}
+
+	fee, err := m.EstimateFee(ctx, signer.Public(), tx)
+	if err != nil {
+		return err
 	}
 
 	// Verify that the fee doesn't exceed a configured ceiling.
-	if !m.maxFee.IsZero() && amount.Cmp(&m.maxFee) == 1 {
+	if !m.maxFee.IsZero() && fee.Amount.Cmp(&m.maxFee) == 1 {
 		return fmt.Errorf("computed fee exceeds configured maximum: %s (max: %s)",
-			amount,
+			fee.Amount,
 			m.maxFee,
 		)
 	}
 
-	tx.Fee = &transaction.Fee{
-		Gas:    gas,
-		Amount: *amount,
-	}
+	tx.Fee = fee
 	return nil
 }
 
@@ -282,6 +291,11 @@ func (m *NoOpSubmissionManager) PriceDiscovery() PriceDiscovery {
 	return &noOpPriceDiscovery{}
 }
 
+// EstimateFee implements SubmissionManager.
+func (m *NoOpSubmissionManager) EstimateFee(context.Context, signature.PublicKey, *transaction.Transaction) (*transaction.Fee, error) {
+	return nil, transaction.ErrMethodNotSupported
+}
+
 // EstimateGasAndSetFee implements SubmissionManager.
 func (m *NoOpSubmissionManager) EstimateGasAndSetFee(context.Context, signature.Signer, *transaction.Transaction) error {
 	return transaction.ErrMethodNotSupported
@@ -6,6 +6,7 @@ import (
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	vault "github.com/oasisprotocol/oasis-core/go/vault/api"
 )
 
 // Event is a consensus service event that may be emitted during processing of
@@ -15,6 +16,25 @@ type Event struct {
 	Registry   *registry.Event   `json:"registry,omitempty"`
 	RootHash   *roothash.Event   `json:"roothash,omitempty"`
 	Governance *governance.Event `json:"governance,omitempty"`
+	Vault      *vault.Event      `json:"vault,omitempty"`
+}
+
+// Index returns the block-level index of the event.
+func (e *Event) Index() uint32 {
+	switch {
+	case e.Staking != nil:
+		return e.Staking.Index
+	case e.Registry != nil:
+		return e.Registry.Index
+	case e.RootHash != nil:
+		return e.RootHash.Index
+	case e.Governance != nil:
+		return e.Governance.Index
+	case e.Vault != nil:
+		return e.Vault.Index
+	default:
+		return 0
+	}
 }
 
 // Error is a transaction execution error.
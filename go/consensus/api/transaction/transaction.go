@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"slices"
 	"sync"
 
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
@@ -153,6 +154,23 @@ func NewTransaction(nonce uint64, fee *Fee, method MethodName, body interface{})
 	}
 }
 
+// NewTransactionFromJSON creates a new transaction with the given JSON-encoded body.
+//
+// The body is decoded into the body type registered for the given method.
+func NewTransactionFromJSON(nonce uint64, fee *Fee, method MethodName, rawBody []byte) (*Transaction, error) {
+	bodyType := method.BodyType()
+	if bodyType == nil {
+		return nil, fmt.Errorf("transaction: unknown method: %s", method)
+	}
+
+	body := reflect.New(reflect.TypeOf(bodyType)).Interface()
+	if err := json.Unmarshal(rawBody, body); err != nil {
+		return nil, fmt.Errorf("transaction: malformed body of method %s: %w", method, err)
+	}
+
+	return NewTransaction(nonce, fee, method, body), nil
+}
+
 // PrettyTransaction is used for pretty-printing transactions so that the actual content is
 // displayed instead of the binary blob.
 //
@@ -331,6 +349,17 @@ func (m MethodName) IsCritical() bool {
 	return m.Metadata().Priority == MethodPriorityCritical
 }
 
+// RegisteredMethods returns the names of all registered methods in sorted order.
+func RegisteredMethods() []MethodName {
+	var methods []MethodName
+	registeredMethods.Range(func(name, _ interface{}) bool {
+		methods = append(methods, MethodName(name.(string)))
+		return true
+	})
+	slices.Sort(methods)
+	return methods
+}
+
 // NewMethodName creates a new method name.
 //
 // Module and method pair must be unique. If they are not, this method
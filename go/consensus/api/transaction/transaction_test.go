@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 )
 
 type testMethodBodyNormal struct{}
@@ -24,3 +26,31 @@ func TestMethodMetadata(t *testing.T) {
 	require.False(methodNormal.IsCritical())
 	require.True(methodCritical.IsCritical())
 }
+
+type testMethodBodyJSON struct {
+	Value uint64 `json:"value"`
+}
+
+func TestNewTransactionFromJSON(t *testing.T) {
+	require := require.New(t)
+
+	method := NewMethodName("test", "JSON", testMethodBodyJSON{})
+	require.Contains(RegisteredMethods(), method)
+
+	fee := Fee{Gas: 1000}
+	tx, err := NewTransactionFromJSON(42, &fee, method, []byte(`{"value": 7}`))
+	require.NoError(err, "NewTransactionFromJSON")
+	require.EqualValues(42, tx.Nonce)
+	require.Equal(&fee, tx.Fee)
+	require.Equal(method, tx.Method)
+
+	var body testMethodBodyJSON
+	require.NoError(cbor.Unmarshal(tx.Body, &body))
+	require.EqualValues(7, body.Value)
+
+	_, err = NewTransactionFromJSON(42, &fee, method, []byte(`{"value": "seven"}`))
+	require.Error(err, "malformed body should fail")
+
+	_, err = NewTransactionFromJSON(42, &fee, MethodName("test.Unknown"), []byte(`{}`))
+	require.Error(err, "unknown method should fail")
+}
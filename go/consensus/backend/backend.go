@@ -0,0 +1,102 @@
+// Package backend implements the consensus backend registry.
+//
+// Consensus backends register a factory under their name so that the node can select the
+// consensus engine based on its configuration while the public consensus API stays the same.
+package backend
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/oasisprotocol/oasis-core/go/common/identity"
+	consensusAPI "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	genesisAPI "github.com/oasisprotocol/oasis-core/go/genesis/api"
+	"github.com/oasisprotocol/oasis-core/go/p2p/rpc"
+	upgradeAPI "github.com/oasisprotocol/oasis-core/go/upgrade/api"
+)
+
+// Args are the arguments used to construct a consensus backend.
+type Args struct {
+	// DataDir is the node's data directory.
+	DataDir string
+	// Identity is the node's identity.
+	Identity *identity.Identity
+	// Upgrader is the upgrade backend.
+	Upgrader upgradeAPI.Backend
+	// GenesisProvider is the genesis document provider.
+	GenesisProvider genesisAPI.Provider
+}
+
+// LightClientArgs are the arguments used to construct a consensus light client service.
+type LightClientArgs struct {
+	// DataDir is the node's data directory.
+	DataDir string
+	// Genesis is the genesis document.
+	Genesis *genesisAPI.Document
+	// Consensus is the local consensus backend.
+	Consensus consensusAPI.Backend
+	// P2P is the P2P service used to talk to remote nodes.
+	P2P rpc.P2P
+}
+
+// Factory is a consensus backend factory.
+type Factory interface {
+	// Name returns the name of the consensus backend.
+	Name() string
+
+	// New creates a new consensus backend.
+	New(ctx context.Context, args *Args) (consensusAPI.Backend, error)
+
+	// NewLightClient creates a new consensus light client service.
+	NewLightClient(ctx context.Context, args *LightClientArgs) (consensusAPI.LightService, error)
+}
+
+var (
+	factoriesLock sync.RWMutex
+	factories     = make(map[string]Factory)
+)
+
+// Register registers a new consensus backend factory.
+//
+// Registering multiple factories under the same name will panic.
+func Register(factory Factory) {
+	factoriesLock.Lock()
+	defer factoriesLock.Unlock()
+
+	name := factory.Name()
+	if _, exists := factories[name]; exists {
+		panic(fmt.Sprintf("consensus/backend: backend '%s' already registered", name))
+	}
+	factories[name] = factory
+}
+
+// Get returns the consensus backend factory registered under the given name.
+func Get(name string) (Factory, error) {
+	factoriesLock.RLock()
+	defer factoriesLock.RUnlock()
+
+	factory, exists := factories[name]
+	if !exists {
+		return nil, fmt.Errorf("consensus/backend: unsupported backend '%s' (available: %v)", name, namesLocked())
+	}
+	return factory, nil
+}
+
+// Names returns the sorted names of all registered consensus backends.
+func Names() []string {
+	factoriesLock.RLock()
+	defer factoriesLock.RUnlock()
+
+	return namesLocked()
+}
+
+func namesLocked() []string {
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
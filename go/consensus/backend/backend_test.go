@@ -0,0 +1,49 @@
+package backend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	consensusAPI "github.com/oasisprotocol/oasis-core/go/consensus/api"
+)
+
+type testFactory struct {
+	name string
+}
+
+func (f *testFactory) Name() string {
+	return f.name
+}
+
+func (f *testFactory) New(context.Context, *Args) (consensusAPI.Backend, error) {
+	return nil, nil
+}
+
+func (f *testFactory) NewLightClient(context.Context, *LightClientArgs) (consensusAPI.LightService, error) {
+	return nil, nil
+}
+
+func TestRegistry(t *testing.T) {
+	require := require.New(t)
+
+	_, err := Get("test-a")
+	require.Error(err, "Get should fail for unregistered backends")
+
+	factoryA := &testFactory{name: "test-a"}
+	factoryB := &testFactory{name: "test-b"}
+	Register(factoryB)
+	Register(factoryA)
+
+	f, err := Get("test-a")
+	require.NoError(err, "Get")
+	require.Equal(factoryA, f)
+	f, err = Get("test-b")
+	require.NoError(err, "Get")
+	require.Equal(factoryB, f)
+
+	require.Equal([]string{"test-a", "test-b"}, Names(), "names should be sorted")
+
+	require.Panics(func() { Register(&testFactory{name: "test-a"}) }, "duplicate registration should panic")
+}
@@ -0,0 +1,51 @@
+package abci
+
+import (
+	abcicli "github.com/cometbft/cometbft/abci/client"
+	"github.com/cometbft/cometbft/abci/types"
+	cmtsync "github.com/cometbft/cometbft/libs/sync"
+	cmtproxy "github.com/cometbft/cometbft/proxy"
+)
+
+type localClientCreator struct {
+	mtx *cmtsync.Mutex
+	app types.Application
+}
+
+func (c *localClientCreator) NewABCIClient() (abcicli.Client, error) {
+	return &localClient{
+		Client: abcicli.NewLocalClient(c.mtx, c.app),
+		app:    c.app,
+	}, nil
+}
+
+// NewLocalClientCreator returns a CometBFT ABCI client creator for the given in-process
+// application.
+//
+// Unlike the CometBFT local client creator, snapshot chunks are loaded without holding the lock
+// shared by all ABCI connections. This way chunks can be served to multiple state sync peers
+// concurrently (subject to the snapshot provider limits) without stalling consensus.
+func NewLocalClientCreator(app types.Application) cmtproxy.ClientCreator {
+	return &localClientCreator{
+		mtx: new(cmtsync.Mutex),
+		app: app,
+	}
+}
+
+// localClient is a local ABCI client which does not serialize snapshot chunk loading.
+//
+// Loading a chunk does not touch any consensus state. It only reads checkpoint files that are
+// created and pruned by the checkpointer in the background, independently of the ABCI lock, so
+// taking the lock would not make it any safer with respect to Commit or pruning. Checkpoint
+// metadata is written after all of its chunks and removed before them, so a concurrently loaded
+// chunk is either missing or complete, and served chunks are verified against the metadata.
+type localClient struct {
+	abcicli.Client
+
+	app types.Application
+}
+
+func (c *localClient) LoadSnapshotChunkSync(req types.RequestLoadSnapshotChunk) (*types.ResponseLoadSnapshotChunk, error) {
+	res := c.app.LoadSnapshotChunk(req)
+	return &res, nil
+}
@@ -0,0 +1,95 @@
+package abci
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cometbft/cometbft/abci/types"
+	"github.com/stretchr/testify/require"
+)
+
+type testSnapshotApp struct {
+	types.BaseApplication
+
+	snapshots *snapshotProvider
+	entered   chan struct{}
+	unblock   chan struct{}
+}
+
+func (app *testSnapshotApp) Info(types.RequestInfo) types.ResponseInfo {
+	app.entered <- struct{}{}
+	<-app.unblock
+	return types.ResponseInfo{}
+}
+
+func (app *testSnapshotApp) LoadSnapshotChunk(types.RequestLoadSnapshotChunk) types.ResponseLoadSnapshotChunk {
+	ok, _ := app.snapshots.acquire()
+	if !ok {
+		return types.ResponseLoadSnapshotChunk{}
+	}
+	defer app.snapshots.release(1)
+
+	app.entered <- struct{}{}
+	<-app.unblock
+	return types.ResponseLoadSnapshotChunk{Chunk: []byte{1}}
+}
+
+func TestLocalClientConcurrentChunks(t *testing.T) {
+	require := require.New(t)
+
+	app := &testSnapshotApp{
+		snapshots: newSnapshotProvider(SnapshotProviderConfig{MaxConcurrentChunks: 2}),
+		entered:   make(chan struct{}, 1),
+		unblock:   make(chan struct{}),
+	}
+	creator := NewLocalClientCreator(app)
+	newClient := func() interface {
+		InfoSync(types.RequestInfo) (*types.ResponseInfo, error)
+		LoadSnapshotChunkSync(types.RequestLoadSnapshotChunk) (*types.ResponseLoadSnapshotChunk, error)
+	} {
+		c, err := creator.NewABCIClient()
+		require.NoError(err, "NewABCIClient")
+		return c
+	}
+	waitEntered := func(msg string) {
+		select {
+		case <-app.entered:
+		case <-time.After(time.Second):
+			require.FailNow(msg)
+		}
+	}
+
+	// Block another ABCI connection while holding the shared lock.
+	infoDone := make(chan struct{})
+	go func() {
+		defer close(infoDone)
+		_, _ = newClient().InfoSync(types.RequestInfo{})
+	}()
+	waitEntered("Info should be called")
+
+	// Chunks should be loaded concurrently up to the limit, without waiting for the lock.
+	chunks := make(chan []byte, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			res, _ := newClient().LoadSnapshotChunkSync(types.RequestLoadSnapshotChunk{})
+			chunks <- res.Chunk
+		}()
+		waitEntered("chunk should be loaded concurrently")
+	}
+
+	// Chunks exceeding the limit should be rejected.
+	res, err := newClient().LoadSnapshotChunkSync(types.RequestLoadSnapshotChunk{})
+	require.NoError(err, "LoadSnapshotChunkSync")
+	require.Empty(res.Chunk, "chunk exceeding the concurrency limit should be rejected")
+
+	close(app.unblock)
+	<-infoDone
+	for i := 0; i < 2; i++ {
+		require.Equal([]byte{1}, <-chunks)
+	}
+
+	// Once served, further chunks should be allowed again.
+	res, err = newClient().LoadSnapshotChunkSync(types.RequestLoadSnapshotChunk{})
+	require.NoError(err, "LoadSnapshotChunkSync")
+	require.Equal([]byte{1}, res.Chunk)
+}
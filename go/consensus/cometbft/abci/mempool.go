@@ -0,0 +1,154 @@
+package abci
+
+import (
+	"math"
+	"sync"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+)
+
+// MempoolConfig is the local transaction admission policy configuration.
+//
+// The policy only affects which transactions this node accepts into its mempool and in which
+// order it proposes them, so it can differ between nodes without affecting consensus.
+type MempoolConfig struct {
+	// FeeOrdering enables ordering of pending transactions by fee per gas unit.
+	FeeOrdering bool
+
+	// MaxTxsPerSender is the maximum number of pending transactions per sender. Zero means no
+	// limit.
+	MaxTxsPerSender uint64
+}
+
+// mempoolPolicy implements the local transaction admission policy.
+//
+// Pending transactions are tracked by hash so that they stop counting against their sender as
+// soon as they are removed from the mempool, either by being included in a block or by failing a
+// re-check. As the mempool does not report other removals (e.g., evictions of lower-priority
+// transactions when it is full), all transactions remaining in the mempool are counted from
+// scratch while they are re-checked after each block is committed.
+type mempoolPolicy struct {
+	sync.Mutex
+
+	cfg MempoolConfig
+
+	pending map[signature.PublicKey]map[hash.Hash]struct{}
+	signers map[hash.Hash]signature.PublicKey
+}
+
+// exempt returns true iff the given transaction is exempt from the policy.
+func (p *mempoolPolicy) exempt(tx *transaction.Transaction) bool {
+	// Critical protocol methods are exempt, the same as for fees.
+	return tx.Method.IsCritical()
+}
+
+// checkSender checks whether a new transaction from the context's signer can be admitted.
+func (p *mempoolPolicy) checkSender(ctx *api.Context, txHash hash.Hash, tx *transaction.Transaction) error {
+	if p.cfg.MaxTxsPerSender == 0 || p.exempt(tx) {
+		return nil
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	pending := p.pending[ctx.TxSigner()]
+	if _, ok := pending[txHash]; ok {
+		// Already pending (e.g., when re-checking).
+		return nil
+	}
+	if uint64(len(pending)) >= p.cfg.MaxTxsPerSender {
+		ctx.Logger().Debug("rejecting transaction, too many pending transactions from sender",
+			"tx_signer", ctx.TxSigner(),
+			"max_txs_per_sender", p.cfg.MaxTxsPerSender,
+		)
+		return consensus.ErrTooManyPendingTxs
+	}
+	return nil
+}
+
+// admit records the given transaction as pending and adjusts its priority.
+func (p *mempoolPolicy) admit(ctx *api.Context, txHash hash.Hash, tx *transaction.Transaction) {
+	if p.exempt(tx) {
+		return
+	}
+
+	if p.cfg.FeeOrdering {
+		ctx.SetPriority(feePriority(ctx.GetPriority(), tx.Fee))
+	}
+
+	if p.cfg.MaxTxsPerSender == 0 {
+		return
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	signer := ctx.TxSigner()
+	pending := p.pending[signer]
+	if pending == nil {
+		pending = make(map[hash.Hash]struct{})
+		p.pending[signer] = pending
+	}
+	pending[txHash] = struct{}{}
+	p.signers[txHash] = signer
+}
+
+// remove records that the given transaction is no longer pending.
+func (p *mempoolPolicy) remove(txHash hash.Hash) {
+	if p.cfg.MaxTxsPerSender == 0 {
+		return
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	signer, ok := p.signers[txHash]
+	if !ok {
+		return
+	}
+	delete(p.signers, txHash)
+
+	pending := p.pending[signer]
+	delete(pending, txHash)
+	if len(pending) == 0 {
+		delete(p.pending, signer)
+	}
+}
+
+// reset clears all pending transactions.
+func (p *mempoolPolicy) reset() {
+	p.Lock()
+	defer p.Unlock()
+
+	p.pending = make(map[signature.PublicKey]map[hash.Hash]struct{})
+	p.signers = make(map[hash.Hash]signature.PublicKey)
+}
+
+func newMempoolPolicy(cfg MempoolConfig) *mempoolPolicy {
+	return &mempoolPolicy{
+		cfg:     cfg,
+		pending: make(map[signature.PublicKey]map[hash.Hash]struct{}),
+		signers: make(map[hash.Hash]signature.PublicKey),
+	}
+}
+
+// feePriority combines the application priority with the transaction's fee per gas unit.
+//
+// The application priority still takes precedence so that protocol transactions are not starved
+// by well-paying ones, while transactions of the same application are ordered by fee per gas.
+// Application priorities outside of the 32-bit range are clamped.
+func feePriority(appPriority int64, fee *transaction.Fee) int64 {
+	var gasPrice uint64
+	if fee != nil {
+		gasPrice = math.MaxUint32
+		if gp := fee.GasPrice().ToBigInt(); gp.IsUint64() {
+			gasPrice = min(gp.Uint64(), gasPrice)
+		}
+	}
+	appPriority = max(min(appPriority, math.MaxInt32), math.MinInt32)
+	return appPriority<<32 | int64(gasPrice)
+}
@@ -0,0 +1,161 @@
+package abci
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+type testCriticalBody struct{}
+
+func (testCriticalBody) MethodMetadata() transaction.MethodMetadata {
+	return transaction.MethodMetadata{Priority: transaction.MethodPriorityCritical}
+}
+
+var testMethodCritical = transaction.NewMethodName("abci-test", "Critical", testCriticalBody{})
+
+func newTestFee(amount uint64, gas transaction.Gas) *transaction.Fee {
+	fee := &transaction.Fee{Gas: gas}
+	_ = fee.Amount.FromUint64(amount)
+	return fee
+}
+
+func TestFeePriority(t *testing.T) {
+	require := require.New(t)
+
+	require.EqualValues(100<<32, feePriority(100, nil))
+	require.EqualValues(100<<32, feePriority(100, newTestFee(0, 1000)))
+	require.EqualValues(100<<32|10, feePriority(100, newTestFee(10_000, 1000)))
+	require.EqualValues(100<<32|math.MaxUint32, feePriority(100, newTestFee(math.MaxUint64, 1)))
+
+	// Application priority takes precedence over fees.
+	require.Greater(feePriority(101, nil), feePriority(100, newTestFee(math.MaxUint64, 1)))
+	// Within the same application, higher fee per gas means higher priority.
+	require.Greater(feePriority(100, newTestFee(2000, 1000)), feePriority(100, newTestFee(1000, 1000)))
+
+	// Application priorities outside of the 32-bit range should be clamped without overflowing.
+	require.EqualValues(math.MaxInt32<<32|10, feePriority(math.MaxInt64, newTestFee(10_000, 1000)))
+	require.EqualValues(math.MinInt32<<32|10, feePriority(math.MinInt64, newTestFee(10_000, 1000)))
+	require.Greater(feePriority(math.MaxInt64, nil), feePriority(math.MaxInt32-1, newTestFee(math.MaxUint64, 1)))
+	require.Less(feePriority(math.MinInt64, newTestFee(math.MaxUint64, 1)), feePriority(-1, nil))
+	require.Less(feePriority(-1, newTestFee(math.MaxUint64, 1)), feePriority(0, nil))
+}
+
+func TestMempoolPolicy(t *testing.T) {
+	require := require.New(t)
+
+	appState := api.NewMockApplicationState(&api.MockApplicationStateConfig{})
+	newCtx := func(signer signature.PublicKey) *api.Context {
+		ctx := appState.NewContext(api.ContextCheckTx)
+		ctx.SetTxSigner(signer)
+		ctx.SetPriority(100)
+		return ctx
+	}
+
+	alice := signature.NewPublicKey("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	bob := signature.NewPublicKey("bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	transfer := &transaction.Transaction{
+		Method: staking.MethodTransfer,
+		Fee:    newTestFee(2000, 1000),
+	}
+	critical := &transaction.Transaction{
+		Method: testMethodCritical,
+	}
+
+	p := newMempoolPolicy(MempoolConfig{
+		FeeOrdering:     true,
+		MaxTxsPerSender: 2,
+	})
+
+	var nonce byte
+	admit := func(signer signature.PublicKey, tx *transaction.Transaction) (hash.Hash, error) {
+		ctx := newCtx(signer)
+		defer ctx.Close()
+
+		nonce++
+		txHash := hash.NewFromBytes([]byte{nonce})
+		if err := p.checkSender(ctx, txHash, tx); err != nil {
+			return txHash, err
+		}
+		p.admit(ctx, txHash, tx)
+		return txHash, nil
+	}
+	readmit := func(signer signature.PublicKey, txHash hash.Hash, tx *transaction.Transaction) error {
+		ctx := newCtx(signer)
+		defer ctx.Close()
+
+		if err := p.checkSender(ctx, txHash, tx); err != nil {
+			return err
+		}
+		p.admit(ctx, txHash, tx)
+		return nil
+	}
+
+	// Per-sender limit should be enforced.
+	tx1, err := admit(alice, transfer)
+	require.NoError(err)
+	tx2, err := admit(alice, transfer)
+	require.NoError(err)
+	_, err = admit(alice, transfer)
+	require.ErrorIs(err, consensus.ErrTooManyPendingTxs)
+	_, err = admit(bob, transfer)
+	require.NoError(err)
+
+	// Critical methods should be exempt.
+	_, err = admit(alice, critical)
+	require.NoError(err)
+
+	// Re-checking pending transactions should not count them again.
+	require.NoError(readmit(alice, tx1, transfer))
+	require.NoError(readmit(alice, tx2, transfer))
+
+	// Removed transactions should no longer count against the sender.
+	p.remove(tx1)
+	p.remove(tx1)
+	_, err = admit(alice, transfer)
+	require.NoError(err)
+	_, err = admit(alice, transfer)
+	require.ErrorIs(err, consensus.ErrTooManyPendingTxs)
+	p.remove(tx2)
+	require.NoError(readmit(alice, tx2, transfer))
+
+	// Removing unknown transactions should be a no-op.
+	p.remove(hash.NewFromBytes([]byte("unknown")))
+	_, err = admit(alice, transfer)
+	require.ErrorIs(err, consensus.ErrTooManyPendingTxs)
+
+	// Limits should be cleared on reset.
+	p.reset()
+	_, err = admit(alice, transfer)
+	require.NoError(err)
+
+	// Fee ordering should adjust the priority.
+	ctx := newCtx(alice)
+	defer ctx.Close()
+	p.admit(ctx, hash.Hash{}, transfer)
+	require.EqualValues(feePriority(100, transfer.Fee), ctx.GetPriority())
+
+	ctx = newCtx(alice)
+	defer ctx.Close()
+	p.admit(ctx, hash.Hash{}, critical)
+	require.EqualValues(100, ctx.GetPriority(), "critical method priority should not be adjusted")
+
+	// Without limits and fee ordering the policy should be a no-op.
+	p = newMempoolPolicy(MempoolConfig{})
+	for i := 0; i < 10; i++ {
+		_, err = admit(alice, transfer)
+		require.NoError(err)
+	}
+	ctx = newCtx(alice)
+	defer ctx.Close()
+	p.admit(ctx, hash.Hash{}, transfer)
+	require.EqualValues(100, ctx.GetPriority())
+}
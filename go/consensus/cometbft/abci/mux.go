@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"runtime/debug"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -27,7 +28,9 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	abciState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/abci/state"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/features"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
+	"github.com/oasisprotocol/oasis-core/go/upgrade/migrations"
 )
 
 const (
@@ -36,6 +39,11 @@ const (
 	// LogEventABCIStateSyncComplete is a log event value that signals an ABCI state syncing
 	// completed event.
 	LogEventABCIStateSyncComplete = "cometbft/abci/state_sync_complete"
+
+	// peerFilterIDPath is the query path prefix used by CometBFT to filter peers by node ID.
+	peerFilterIDPath = "/p2p/filter/id/"
+	// peerFilterRejectedCode is the query response code signalling that a peer is rejected.
+	peerFilterRejectedCode = 1
 )
 
 var (
@@ -78,6 +86,16 @@ type ApplicationConfig struct { // nolint: maligned
 
 	// ChainContext is the chain context for the network.
 	ChainContext string
+
+	// PeerFilter is an optional filter for consensus peers. In case it returns an error, the
+	// peer with the given CometBFT node ID is rejected.
+	PeerFilter func(id string) error
+
+	// Mempool is the local transaction admission policy configuration.
+	Mempool MempoolConfig
+
+	// SnapshotProvider is the state sync snapshot provider configuration.
+	SnapshotProvider SnapshotProviderConfig
 }
 
 // ApplicationServer implements a CometBFT ABCI application + socket server,
@@ -193,6 +211,7 @@ func (a *ApplicationServer) Pruner() api.StatePruner {
 func NewApplicationServer(ctx context.Context, upgrader upgrade.Backend, cfg *ApplicationConfig) (*ApplicationServer, error) {
 	metricsOnce.Do(func() {
 		prometheus.MustRegister(abciCollectors...)
+		prometheus.MustRegister(snapshotCollectors...)
 	})
 
 	mux, err := newABCIMux(ctx, upgrader, cfg)
@@ -213,6 +232,11 @@ type abciMux struct {
 	logger *logging.Logger
 	state  *applicationState
 
+	peerFilter func(id string) error
+
+	mempool   *mempoolPolicy
+	snapshots *snapshotProvider
+
 	appsByName     map[string]api.Application
 	appsByMethod   map[transaction.MethodName]api.Application
 	appsByLexOrder []api.Application
@@ -272,6 +296,26 @@ func (mux *abciMux) Info(types.RequestInfo) types.ResponseInfo {
 	}
 }
 
+func (mux *abciMux) Query(req types.RequestQuery) types.ResponseQuery {
+	// The only queries served by the ABCI application are CometBFT peer filter queries.
+	id, ok := strings.CutPrefix(req.Path, peerFilterIDPath)
+	if !ok || mux.peerFilter == nil {
+		return types.ResponseQuery{Code: types.CodeTypeOK}
+	}
+
+	if err := mux.peerFilter(id); err != nil {
+		mux.logger.Debug("rejecting consensus peer",
+			"peer_id", id,
+			"err", err,
+		)
+		return types.ResponseQuery{
+			Code: peerFilterRejectedCode,
+			Log:  err.Error(),
+		}
+	}
+	return types.ResponseQuery{Code: types.CodeTypeOK}
+}
+
 func (mux *abciMux) InitChain(req types.RequestInitChain) types.ResponseInitChain {
 	mux.logger.Debug("InitChain")
 
@@ -642,11 +686,20 @@ func (mux *abciMux) BeginBlock(req types.RequestBeginBlock) types.ResponseBeginB
 
 	// Collect and return events from the application's BeginBlock calls.
 	response.Events = append(response.Events, ctx.GetEvents()...)
+	mux.indexEvents(response.Events)
 	mux.processProvableEvents(ctx)
 
 	return response
 }
 
+// indexEvents assigns block-level indices to the given events in the order in which they are
+// returned to CometBFT, so that indices are stable across BeginBlock, DeliverTx and EndBlock.
+func (mux *abciMux) indexEvents(evs []types.Event) []types.Event {
+	blockCtx := mux.state.blockCtx
+	blockCtx.NextEventIndex = api.IndexEvents(evs, blockCtx.NextEventIndex)
+	return evs
+}
+
 func (mux *abciMux) notifyInvalidatedCheckTx(txHash hash.Hash, err error) {
 	if item, exists := mux.invalidatedTxs.Load(txHash); exists {
 		// Notify subscriber.
@@ -674,6 +727,7 @@ func (mux *abciMux) CheckTx(req types.RequestCheckTx) types.ResponseCheckTx {
 			//      of us hacking our way through this here.
 			txHash := hash.NewFromBytes(req.Tx)
 
+			mux.mempool.remove(txHash)
 			mux.notifyInvalidatedCheckTx(txHash, err)
 		}
 
@@ -709,6 +763,12 @@ func (mux *abciMux) DeliverTx(req types.RequestDeliverTx) types.ResponseDeliverT
 	ctx := mux.state.NewContext(api.ContextDeliverTx)
 	defer ctx.Close()
 
+	// Transactions included in a block are removed from the mempool.
+	mux.mempool.remove(hash.NewFromBytes(req.Tx))
+
+	blockCtx := mux.state.blockCtx
+	blockCtx.TxEventIndices = append(blockCtx.TxEventIndices, blockCtx.NextEventIndex)
+
 	if err := mux.executeTx(ctx, req.Tx); err != nil {
 		if api.IsUnavailableStateError(err) {
 			// Make sure to not commit any transactions which include results based on unavailable
@@ -726,7 +786,7 @@ func (mux *abciMux) DeliverTx(req types.RequestDeliverTx) types.ResponseDeliverT
 			Codespace: module,
 			Code:      code,
 			Log:       err.Error(),
-			Events:    ctx.GetEvents(),
+			Events:    mux.indexEvents(ctx.GetEvents()),
 			GasWanted: int64(ctx.Gas().GasWanted()),
 			GasUsed:   int64(ctx.Gas().GasUsed()),
 		}
@@ -737,7 +797,7 @@ func (mux *abciMux) DeliverTx(req types.RequestDeliverTx) types.ResponseDeliverT
 	return types.ResponseDeliverTx{
 		Code:      types.CodeTypeOK,
 		Data:      cbor.Marshal(ctx.Data()),
-		Events:    ctx.GetEvents(),
+		Events:    mux.indexEvents(ctx.GetEvents()),
 		GasWanted: int64(ctx.Gas().GasWanted()),
 		GasUsed:   int64(ctx.Gas().GasUsed()),
 	}
@@ -792,9 +852,15 @@ func (mux *abciMux) EndBlock(req types.RequestEndBlock) types.ResponseEndBlock {
 	}
 
 	// Collect and return events.
-	resp.Events = ctx.GetEvents()
+	endBlockEventIndex := mux.state.blockCtx.NextEventIndex
+	resp.Events = mux.indexEvents(ctx.GetEvents())
 	mux.processProvableEvents(ctx)
 
+	// Record the assigned event indices in state.
+	if err := mux.recordEventIndex(ctx, endBlockEventIndex); err != nil {
+		panic(fmt.Errorf("mux: failed to record event index: %w", err))
+	}
+
 	// Update version to what we are actually running.
 	resp.ConsensusParamUpdates = &cmtproto.ConsensusParams{
 		Version: &cmtproto.VersionParams{
@@ -810,6 +876,25 @@ func (mux *abciMux) EndBlock(req types.RequestEndBlock) types.ResponseEndBlock {
 	return resp
 }
 
+// recordEventIndex stores the block-level event indices assigned in the current block in state.
+func (mux *abciMux) recordEventIndex(ctx *api.Context, endBlockEventIndex uint32) error {
+	enabled, err := features.IsFeatureVersion(ctx, migrations.Version250)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return nil
+	}
+
+	blockCtx := mux.state.blockCtx
+	consState := abciState.NewMutableState(ctx.State())
+	return consState.SetEventIndex(ctx, &abciState.EventIndexRecord{
+		Transactions: blockCtx.TxEventIndices,
+		EndBlock:     endBlockEventIndex,
+		Total:        blockCtx.NextEventIndex,
+	})
+}
+
 func (mux *abciMux) Commit() types.ResponseCommit {
 	lastRetainedVersion, err := mux.state.doCommit()
 	if err != nil {
@@ -822,6 +907,9 @@ func (mux *abciMux) Commit() types.ResponseCommit {
 		panic(err)
 	}
 
+	// All transactions remaining in the mempool will be re-checked against the new state.
+	mux.mempool.reset()
+
 	mux.logger.Debug("Commit",
 		"block_height", mux.state.BlockHeight(),
 		"state_root_hash", hex.EncodeToString(mux.state.StateRootHash()),
@@ -982,6 +1070,9 @@ func newABCIMux(ctx context.Context, upgrader upgrade.Backend, cfg *ApplicationC
 	mux := &abciMux{
 		logger:       logging.GetLogger("abci-mux"),
 		state:        state,
+		peerFilter:   cfg.PeerFilter,
+		mempool:      newMempoolPolicy(cfg.Mempool),
+		snapshots:    newSnapshotProvider(cfg.SnapshotProvider),
 		appsByName:   make(map[string]api.Application),
 		appsByMethod: make(map[transaction.MethodName]api.Application),
 	}
@@ -0,0 +1,154 @@
+package abci
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	snapshotChunkRejectDisabled    = "disabled"
+	snapshotChunkRejectConcurrency = "concurrency"
+	snapshotChunkRejectBandwidth   = "bandwidth"
+	snapshotChunkRejectIntegrity   = "integrity"
+)
+
+var (
+	snapshotsAvailable = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "oasis_abci_snapshots_available",
+			Help: "Number of ABCI state snapshots offered to state sync peers.",
+		},
+	)
+	snapshotChunksServed = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "oasis_abci_snapshot_chunks_served",
+			Help: "Number of ABCI state snapshot chunks served to state sync peers.",
+		},
+	)
+	snapshotChunkBytesServed = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "oasis_abci_snapshot_chunk_bytes_served",
+			Help: "Total size of ABCI state snapshot chunks served to state sync peers (bytes).",
+		},
+	)
+	snapshotChunksRejected = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_abci_snapshot_chunks_rejected",
+			Help: "Number of ABCI state snapshot chunk requests rejected.",
+		},
+		[]string{"reason"},
+	)
+	snapshotCollectors = []prometheus.Collector{
+		snapshotsAvailable,
+		snapshotChunksServed,
+		snapshotChunkBytesServed,
+		snapshotChunksRejected,
+	}
+)
+
+// SnapshotProviderConfig is the state sync snapshot provider configuration.
+type SnapshotProviderConfig struct {
+	// Disabled disables serving state snapshots to state sync peers.
+	Disabled bool
+
+	// NumKept is the minimum number of snapshots to keep available for state sync peers. In case
+	// the consensus parameters require more snapshots to be kept, those take precedence.
+	NumKept uint64
+
+	// Interval is the number of blocks between created snapshots. It is rounded up to a multiple
+	// of the consensus state checkpoint interval so that snapshots are created at the same heights
+	// as on other providers. Zero means that the consensus state checkpoint interval is used.
+	Interval uint64
+
+	// MaxConcurrentChunks is the maximum number of snapshot chunks served concurrently. Zero
+	// means no limit.
+	MaxConcurrentChunks uint64
+
+	// MaxBandwidth is the maximum snapshot chunk serving bandwidth (in bytes per second). Zero
+	// means no limit.
+	MaxBandwidth uint64
+}
+
+// interval returns the snapshot creation interval given the consensus state checkpoint interval.
+func (cfg *SnapshotProviderConfig) interval(consensusInterval uint64) uint64 {
+	if consensusInterval == 0 || cfg.Interval <= consensusInterval {
+		return consensusInterval
+	}
+	return (cfg.Interval + consensusInterval - 1) / consensusInterval * consensusInterval
+}
+
+// snapshotProvider enforces the local snapshot serving limits.
+//
+// Requests that would exceed the limits are rejected instead of delayed so that they do not
+// hold up the ABCI connection, state sync peers will fetch the chunk from other providers.
+type snapshotProvider struct {
+	sync.Mutex
+
+	cfg SnapshotProviderConfig
+
+	inFlight uint64
+	budget   float64
+	lastFill time.Time
+
+	now func() time.Time
+}
+
+// acquire checks whether a new chunk can be served and, if so, reserves a serving slot which
+// must be released via release.
+func (p *snapshotProvider) acquire() (bool, string) {
+	if p.cfg.Disabled {
+		return false, snapshotChunkRejectDisabled
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	if p.cfg.MaxConcurrentChunks > 0 && p.inFlight >= p.cfg.MaxConcurrentChunks {
+		return false, snapshotChunkRejectConcurrency
+	}
+	if p.cfg.MaxBandwidth > 0 {
+		p.refillLocked()
+		if p.budget <= 0 {
+			return false, snapshotChunkRejectBandwidth
+		}
+	}
+
+	p.inFlight++
+	return true, ""
+}
+
+// release releases a serving slot, charging the given number of loaded bytes against the
+// bandwidth budget.
+func (p *snapshotProvider) release(size int) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.inFlight--
+	if p.cfg.MaxBandwidth > 0 {
+		// Since the chunk size is only known once a chunk is loaded, the budget may go negative
+		// in which case no further chunks are served until it is refilled.
+		p.refillLocked()
+		p.budget -= float64(size)
+	}
+}
+
+func (p *snapshotProvider) refillLocked() {
+	now := p.now()
+	elapsed := now.Sub(p.lastFill).Seconds()
+	p.lastFill = now
+
+	// Allow bursts of up to one second worth of bandwidth.
+	maxBudget := float64(p.cfg.MaxBandwidth)
+	p.budget = min(p.budget+elapsed*maxBudget, maxBudget)
+}
+
+func newSnapshotProvider(cfg SnapshotProviderConfig) *snapshotProvider {
+	return &snapshotProvider{
+		cfg:      cfg,
+		budget:   float64(cfg.MaxBandwidth),
+		lastFill: time.Now(),
+		now:      time.Now,
+	}
+}
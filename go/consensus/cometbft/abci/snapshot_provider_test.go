@@ -0,0 +1,92 @@
+package abci
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotProvider(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1_000_000, 0)
+	p := newSnapshotProvider(SnapshotProviderConfig{
+		MaxConcurrentChunks: 2,
+		MaxBandwidth:        1000,
+	})
+	p.lastFill = now
+	p.now = func() time.Time { return now }
+
+	// Concurrency limit should be enforced.
+	ok, _ := p.acquire()
+	require.True(ok)
+	ok, _ = p.acquire()
+	require.True(ok)
+	ok, reason := p.acquire()
+	require.False(ok)
+	require.Equal(snapshotChunkRejectConcurrency, reason)
+	p.release(100)
+	ok, _ = p.acquire()
+	require.True(ok, "released slot should be available again")
+	p.release(100)
+	p.release(100)
+
+	// Bandwidth limit should be enforced.
+	ok, _ = p.acquire()
+	require.True(ok)
+	p.release(1500)
+	ok, reason = p.acquire()
+	require.False(ok)
+	require.Equal(snapshotChunkRejectBandwidth, reason)
+
+	// Bandwidth budget should be refilled over time.
+	now = now.Add(500 * time.Millisecond)
+	ok, _ = p.acquire()
+	require.False(ok, "budget should still be exhausted")
+	now = now.Add(500 * time.Millisecond)
+	ok, _ = p.acquire()
+	require.True(ok)
+	p.release(0)
+
+	// Budget should be capped to allow bursts of at most one second.
+	now = now.Add(time.Hour)
+	ok, _ = p.acquire()
+	require.True(ok)
+	p.release(1001)
+	ok, _ = p.acquire()
+	require.False(ok)
+
+	// Without limits everything should be served.
+	p = newSnapshotProvider(SnapshotProviderConfig{})
+	for i := 0; i < 10; i++ {
+		ok, _ = p.acquire()
+		require.True(ok)
+	}
+
+	// Serving can be disabled.
+	p = newSnapshotProvider(SnapshotProviderConfig{Disabled: true})
+	ok, reason = p.acquire()
+	require.False(ok)
+	require.Equal(snapshotChunkRejectDisabled, reason)
+}
+
+func TestSnapshotProviderInterval(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		interval          uint64
+		consensusInterval uint64
+		expected          uint64
+	}{
+		{0, 1000, 1000},
+		{500, 1000, 1000},
+		{1000, 1000, 1000},
+		{1001, 1000, 2000},
+		{5000, 1000, 5000},
+		{5000, 0, 0},
+	} {
+		cfg := SnapshotProviderConfig{Interval: tc.interval}
+		require.Equal(tc.expected, cfg.interval(tc.consensusInterval), "interval %d consensus interval %d", tc.interval, tc.consensusInterval)
+	}
+}
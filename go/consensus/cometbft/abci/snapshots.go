@@ -4,6 +4,7 @@ import (
 	"bytes"
 
 	"github.com/cometbft/cometbft/abci/types"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
@@ -15,6 +16,10 @@ import (
 )
 
 func (mux *abciMux) ListSnapshots(types.RequestListSnapshots) types.ResponseListSnapshots {
+	if mux.snapshots.cfg.Disabled {
+		return types.ResponseListSnapshots{}
+	}
+
 	// Get a list of all current checkpoints.
 	cps, err := mux.state.storage.Checkpointer().GetCheckpoints(mux.state.ctx, &checkpoint.GetCheckpointsRequest{
 		Version: 1,
@@ -38,6 +43,7 @@ func (mux *abciMux) ListSnapshots(types.RequestListSnapshots) types.ResponseList
 			Metadata: cbor.Marshal(cp),
 		})
 	}
+	snapshotsAvailable.Set(float64(len(rsp.Snapshots)))
 
 	return rsp
 }
@@ -127,6 +133,22 @@ func (mux *abciMux) OfferSnapshot(req types.RequestOfferSnapshot) types.Response
 }
 
 func (mux *abciMux) LoadSnapshotChunk(req types.RequestLoadSnapshotChunk) types.ResponseLoadSnapshotChunk {
+	// Make sure that serving the chunk does not exceed the serving limits.
+	ok, reason := mux.snapshots.acquire()
+	if !ok {
+		mux.logger.Debug("rejecting snapshot chunk request",
+			"height", req.Height,
+			"chunk", req.Chunk,
+			"reason", reason,
+		)
+		snapshotChunksRejected.With(prometheus.Labels{"reason": reason}).Inc()
+		return types.ResponseLoadSnapshotChunk{}
+	}
+	var loaded int
+	defer func() {
+		mux.snapshots.release(loaded)
+	}()
+
 	// Fetch the metadata for the specified checkpoint.
 	cps, err := mux.state.storage.Checkpointer().GetCheckpoints(mux.state.ctx, &checkpoint.GetCheckpointsRequest{
 		Version:     uint16(req.Format),
@@ -160,6 +182,23 @@ func (mux *abciMux) LoadSnapshotChunk(req types.RequestLoadSnapshotChunk) types.
 		)
 		return types.ResponseLoadSnapshotChunk{}
 	}
+	loaded = buf.Len()
+
+	// Make sure to never serve chunks that do not match the snapshot metadata as those would
+	// cause peers to reject the chunk and retry.
+	if h := hash.NewFromBytes(buf.Bytes()); !h.Equal(&chunk.Digest) {
+		mux.logger.Error("refusing to serve corrupted snapshot chunk",
+			"height", req.Height,
+			"chunk", req.Chunk,
+			"expected_digest", chunk.Digest,
+			"digest", h,
+		)
+		snapshotChunksRejected.With(prometheus.Labels{"reason": snapshotChunkRejectIntegrity}).Inc()
+		return types.ResponseLoadSnapshotChunk{}
+	}
+
+	snapshotChunksServed.Inc()
+	snapshotChunkBytesServed.Add(float64(buf.Len()))
 
 	return types.ResponseLoadSnapshotChunk{Chunk: buf.Bytes()}
 }
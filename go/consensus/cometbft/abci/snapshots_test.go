@@ -0,0 +1,143 @@
+package abci
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/cometbft/cometbft/abci/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
+	mkvsDB "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+	mkvsBadgerDB "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/badger"
+	mkvsNode "github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+)
+
+type testCheckpointStorage struct {
+	storage.LocalBackend
+
+	checkpointer checkpoint.CreateRestorer
+}
+
+func (s *testCheckpointStorage) Checkpointer() checkpoint.CreateRestorer {
+	return s.checkpointer
+}
+
+func TestLoadSnapshotChunkConcurrentPruning(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := os.MkdirTemp("", "abci-snapshots.test.badger")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dir)
+
+	ndb, err := mkvsBadgerDB.New(&mkvsDB.Config{
+		DB:           filepath.Join(dir, "db"),
+		NoFsync:      true,
+		MaxCacheSize: 16 * 1024 * 1024,
+	})
+	require.NoError(err, "New")
+	defer ndb.Close()
+
+	ctx := context.Background()
+	tree := mkvs.New(nil, ndb, mkvsNode.RootTypeState)
+	for i := 0; i < 1000; i++ {
+		err = tree.Insert(ctx, []byte(fmt.Sprintf("key:%d", i)), []byte(fmt.Sprintf("value:%d", i)))
+		require.NoError(err, "Insert")
+	}
+	_, rootHash, err := tree.Commit(ctx, common.Namespace{}, 1)
+	require.NoError(err, "Commit")
+	root := mkvsNode.Root{
+		Version: 1,
+		Type:    mkvsNode.RootTypeState,
+		Hash:    rootHash,
+	}
+
+	fc, err := checkpoint.NewFileCreator(filepath.Join(dir, "checkpoints"), ndb)
+	require.NoError(err, "NewFileCreator")
+	cp, err := fc.CreateCheckpoint(ctx, root, 4*1024)
+	require.NoError(err, "CreateCheckpoint")
+	require.Greater(len(cp.Chunks), 1, "checkpoint should have multiple chunks")
+
+	var chunks [][]byte
+	for i := range cp.Chunks {
+		var meta *checkpoint.ChunkMetadata
+		meta, err = cp.GetChunkMetadata(uint64(i))
+		require.NoError(err, "GetChunkMetadata")
+		var buf bytes.Buffer
+		err = fc.GetCheckpointChunk(ctx, meta, &buf)
+		require.NoError(err, "GetCheckpointChunk")
+		chunks = append(chunks, buf.Bytes())
+	}
+
+	mux := &abciMux{
+		logger: logging.GetLogger("consensus/cometbft/abci/test"),
+		state: &applicationState{
+			ctx:     ctx,
+			storage: &testCheckpointStorage{checkpointer: checkpoint.NewCreateRestorer(fc, nil)},
+		},
+		snapshots: newSnapshotProvider(SnapshotProviderConfig{MaxConcurrentChunks: 4}),
+	}
+
+	// Chunks are loaded without holding the ABCI lock, so they may be served while the checkpointer
+	// concurrently prunes and creates checkpoints. Loading must then either fail or return the
+	// exact chunk, but never a partial or corrupted one.
+	var wg sync.WaitGroup
+	doneCh := make(chan struct{})
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for {
+				select {
+				case <-doneCh:
+					return
+				default:
+				}
+
+				for i, expected := range chunks {
+					res := mux.LoadSnapshotChunk(types.RequestLoadSnapshotChunk{
+						Height: root.Version,
+						Format: uint32(cp.Version),
+						Chunk:  uint32(i),
+					})
+					if len(res.Chunk) == 0 {
+						continue
+					}
+					if !bytes.Equal(expected, res.Chunk) {
+						t.Errorf("served corrupted chunk %d", i)
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 20; i++ {
+		err = fc.DeleteCheckpoint(ctx, cp.Version, root)
+		require.NoError(err, "DeleteCheckpoint")
+		_, err = fc.CreateCheckpoint(ctx, root, 4*1024)
+		require.NoError(err, "CreateCheckpoint")
+	}
+	close(doneCh)
+	wg.Wait()
+
+	// Once the checkpoint is available again, all chunks should be served.
+	for i, expected := range chunks {
+		res := mux.LoadSnapshotChunk(types.RequestLoadSnapshotChunk{
+			Height: root.Version,
+			Format: uint32(cp.Version),
+			Chunk:  uint32(i),
+		})
+		require.Equal(expected, res.Chunk, "chunk %d should be served", i)
+	}
+}
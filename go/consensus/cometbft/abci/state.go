@@ -757,8 +757,8 @@ func newApplicationState(ctx context.Context, upgrader upgrade.Backend, cfg *App
 			GetParameters: func(_ context.Context) (*checkpoint.CreationParameters, error) {
 				params := s.ConsensusParameters()
 				return &checkpoint.CreationParameters{
-					Interval:       params.StateCheckpointInterval,
-					NumKept:        params.StateCheckpointNumKept,
+					Interval:       cfg.SnapshotProvider.interval(params.StateCheckpointInterval),
+					NumKept:        max(params.StateCheckpointNumKept, cfg.SnapshotProvider.NumKept),
 					ChunkSize:      params.StateCheckpointChunkSize,
 					InitialVersion: cfg.InitialHeight,
 				}, nil
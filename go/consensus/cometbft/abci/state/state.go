@@ -20,8 +20,27 @@ var (
 	//
 	// Value is CBOR-serialized consensusGenesis.Parameters.
 	parametersKeyFmt = consensus.KeyFormat.New(0xF1)
+	// eventIndexKeyFmt is the key format used for the event index record of the last block.
+	//
+	// Value is CBOR-serialized EventIndexRecord.
+	eventIndexKeyFmt = consensus.KeyFormat.New(0xF2)
 )
 
+// EventIndexRecord describes the block-level event indices assigned to events emitted in a
+// block. Events emitted in BeginBlock are always assigned indices starting at zero.
+//
+// Since only the record of the last block is kept, records of earlier blocks can be obtained by
+// querying state at the corresponding height.
+type EventIndexRecord struct {
+	// Transactions are the indices assigned to the first event attribute emitted by each
+	// transaction, in block order.
+	Transactions []uint32 `json:"transactions,omitempty"`
+	// EndBlock is the index assigned to the first event attribute emitted in EndBlock.
+	EndBlock uint32 `json:"end_block"`
+	// Total is the total number of indices assigned in the block.
+	Total uint32 `json:"total"`
+}
+
 // ImmutableState is an immutable consensus backend state wrapper.
 type ImmutableState struct {
 	is *api.ImmutableState
@@ -63,6 +82,26 @@ func (s *ImmutableState) ConsensusParameters(ctx context.Context) (*consensusGen
 	return &params, nil
 }
 
+// EventIndex returns the event index record of the last block.
+//
+// In case no record is present (e.g., because event indices were not yet recorded in state),
+// nil is returned.
+func (s *ImmutableState) EventIndex(ctx context.Context) (*EventIndexRecord, error) {
+	raw, err := s.is.Get(ctx, eventIndexKeyFmt.Encode())
+	if err != nil {
+		return nil, api.UnavailableStateError(err)
+	}
+	if raw == nil {
+		return nil, nil
+	}
+
+	var rec EventIndexRecord
+	if err := cbor.Unmarshal(raw, &rec); err != nil {
+		return nil, api.UnavailableStateError(err)
+	}
+	return &rec, nil
+}
+
 // MutableState is a mutable consensus backend state wrapper.
 type MutableState struct {
 	*ImmutableState
@@ -92,6 +131,17 @@ func (s *MutableState) SetConsensusParameters(ctx context.Context, params *conse
 	return api.UnavailableStateError(err)
 }
 
+// SetEventIndex sets the event index record of the current block.
+//
+// NOTE: This method must only be called from EndBlock context.
+func (s *MutableState) SetEventIndex(ctx context.Context, rec *EventIndexRecord) error {
+	if err := s.is.CheckContextMode(ctx, []api.ContextMode{api.ContextEndBlock}); err != nil {
+		return err
+	}
+	err := s.ms.Insert(ctx, eventIndexKeyFmt.Encode(), cbor.Marshal(rec))
+	return api.UnavailableStateError(err)
+}
+
 // NewMutableState creates a new mutable consensus backend state wrapper.
 func NewMutableState(tree mkvs.KeyValueTree) *MutableState {
 	return &MutableState{
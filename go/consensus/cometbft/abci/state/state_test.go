@@ -0,0 +1,42 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+)
+
+func TestEventIndex(t *testing.T) {
+	require := require.New(t)
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	consState := NewMutableState(ctx.State())
+
+	rec, err := consState.EventIndex(ctx)
+	require.NoError(err, "EventIndex")
+	require.Nil(rec, "event index record should not be present")
+
+	expected := &EventIndexRecord{
+		Transactions: []uint32{3, 7, 7},
+		EndBlock:     12,
+		Total:        15,
+	}
+	err = consState.SetEventIndex(ctx, expected)
+	require.NoError(err, "SetEventIndex")
+
+	rec, err = consState.EventIndex(ctx)
+	require.NoError(err, "EventIndex")
+	require.EqualValues(expected, rec, "event index record should be stored")
+
+	// Event indices can only be recorded in EndBlock.
+	txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+	defer txCtx.Close()
+
+	err = NewMutableState(txCtx.State()).SetEventIndex(txCtx, expected)
+	require.Error(err, "SetEventIndex should fail outside EndBlock")
+}
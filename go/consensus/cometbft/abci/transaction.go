@@ -6,6 +6,7 @@ import (
 	"math"
 
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
@@ -146,7 +147,23 @@ func (mux *abciMux) executeTx(ctx *api.Context, rawTx []byte) error {
 		}
 	}
 
-	return mux.processTx(ctx, tx, len(rawTx))
+	// Apply the local transaction admission policy when checking transactions for the mempool.
+	var txHash hash.Hash
+	if ctx.IsCheckOnly() {
+		txHash = hash.NewFromBytes(rawTx)
+		if err = mux.mempool.checkSender(ctx, txHash, tx); err != nil {
+			return err
+		}
+	}
+
+	if err = mux.processTx(ctx, tx, len(rawTx)); err != nil {
+		return err
+	}
+
+	if ctx.IsCheckOnly() {
+		mux.mempool.admit(ctx, txHash, tx)
+	}
+	return nil
 }
 
 func (mux *abciMux) EstimateGas(caller signature.PublicKey, tx *transaction.Transaction) (transaction.Gas, error) {
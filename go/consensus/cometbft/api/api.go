@@ -4,6 +4,7 @@ package api
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/cometbft/cometbft/abci/types"
@@ -150,6 +151,53 @@ func EventTypeForApp(eventApp string) string {
 	return "oasis_event_" + eventApp
 }
 
+// EventIndexAttributeKey is the key of the ABCI event attribute which records the block-level
+// index of the first attribute of the event.
+//
+// Each attribute emitted via an EventBuilder is assigned a block-level index based on the order
+// in which it was emitted (BeginBlock, then transactions in block order, then EndBlock), so
+// events can be ordered consistently across all applications.
+const EventIndexAttributeKey = "event_index"
+
+// IsEventIndexAttribute returns true iff the given ABCI event attribute key is the event index
+// attribute key.
+func IsEventIndexAttribute(key string) bool {
+	return key == EventIndexAttributeKey
+}
+
+// IndexEvents records block-level indices in the given ABCI events, starting with the given
+// index, and returns the index that should be assigned next.
+func IndexEvents(evs []types.Event, next uint32) uint32 {
+	for i := range evs {
+		// Make sure to not modify the attributes of any other copies of the event.
+		attrs := evs[i].Attributes
+		evs[i].Attributes = append(attrs[:len(attrs):len(attrs)], types.EventAttribute{
+			Key:   EventIndexAttributeKey,
+			Value: strconv.FormatUint(uint64(next), 10),
+		})
+		next += uint32(len(attrs))
+	}
+	return next
+}
+
+// EventIndex returns the block-level index of the first attribute of the given ABCI event.
+//
+// In case the event does not have an index recorded (e.g., because it was emitted before event
+// indexing was introduced), zero is returned.
+func EventIndex(ev types.Event) uint32 {
+	for _, pair := range ev.Attributes {
+		if !IsEventIndexAttribute(pair.GetKey()) {
+			continue
+		}
+		index, err := strconv.ParseUint(pair.GetValue(), 10, 32)
+		if err != nil {
+			return 0
+		}
+		return uint32(index)
+	}
+	return 0
+}
+
 // QueryForApp generates a cmtquery.Query for events belonging to the
 // specified App.
 func QueryForApp(eventApp string) cmtpubsub.Query {
@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/cometbft/cometbft/abci/types"
 	cmtpubsub "github.com/cometbft/cometbft/libs/pubsub"
 	cmtquery "github.com/cometbft/cometbft/libs/pubsub/query"
 )
@@ -22,3 +23,39 @@ func TestServiceDescriptor(t *testing.T) {
 	_, ok := <-sd.Queries()
 	require.False(ok, "query channel must be closed")
 }
+
+func TestEventIndex(t *testing.T) {
+	require := require.New(t)
+
+	newEvent := func(keys ...string) types.Event {
+		ev := types.Event{Type: "test"}
+		for _, key := range keys {
+			ev.Attributes = append(ev.Attributes, types.EventAttribute{Key: key, Value: "value"})
+		}
+		return ev
+	}
+
+	require.EqualValues(0, EventIndex(newEvent("a")), "unindexed events should have a zero index")
+
+	evs := []types.Event{
+		newEvent("a", "b"),
+		newEvent("c"),
+		newEvent("d", "e", "f"),
+	}
+	evsCopy := append([]types.Event{}, evs...)
+	next := IndexEvents(evs, 5)
+	require.EqualValues(11, next)
+	require.EqualValues(5, EventIndex(evs[0]))
+	require.EqualValues(7, EventIndex(evs[1]))
+	require.EqualValues(8, EventIndex(evs[2]))
+
+	// Indexing a copy of the events should not affect the original events.
+	IndexEvents(evsCopy, 100)
+	require.EqualValues(5, EventIndex(evs[0]))
+	require.EqualValues(100, EventIndex(evsCopy[0]))
+
+	for _, ev := range evs {
+		last := ev.Attributes[len(ev.Attributes)-1]
+		require.True(IsEventIndexAttribute(last.GetKey()), "index attribute should be appended")
+	}
+}
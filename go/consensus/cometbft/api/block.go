@@ -19,6 +19,13 @@ type BlockInfo struct {
 	GasAccountant      GasAccountant
 	SystemTransactions []*transaction.Transaction
 	ProvableEvents     []events.Provable
+
+	// NextEventIndex is the block-level index that will be assigned to the next emitted event
+	// attribute.
+	NextEventIndex uint32
+	// TxEventIndices are the block-level indices assigned to the first event attribute emitted
+	// by each executed transaction, in block order.
+	TxEventIndices []uint32
 }
 
 // BlockContextKey is an interface for a block context key.
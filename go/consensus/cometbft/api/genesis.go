@@ -77,7 +77,7 @@ func genesisToCometBFT(d *genesis.Document) (*cmttypes.GenesisDoc, error) {
 
 	// Automatically compute evidence parameters based on debonding period.
 	debondingInterval := int64(d.Staking.Parameters.DebondingInterval)
-	if debondingInterval == 0 && cmdFlags.DebugDontBlameOasis() {
+	if debondingInterval == 0 && cmdFlags.DebugUnsafeGenesis() {
 		// Use a default of 1 epoch in case debonding is disabled and we are using debug mode. If
 		// not in debug mode, this will just cause startup to fail which is good.
 		debondingInterval = 1
@@ -87,7 +87,7 @@ func genesisToCometBFT(d *genesis.Document) (*cmttypes.GenesisDoc, error) {
 	case beacon.BackendInsecure:
 		params := d.Beacon.Parameters.InsecureParameters
 		epochInterval = params.Interval
-		if epochInterval == 0 && cmdFlags.DebugDontBlameOasis() && d.Beacon.Parameters.DebugMockBackend {
+		if epochInterval == 0 && cmdFlags.DebugUnsafeGenesis() && d.Beacon.Parameters.DebugMockBackend {
 			// Use a default of 100 blocks in case epoch interval is unset
 			// and we are using debug mode.
 			epochInterval = 100
@@ -95,7 +95,15 @@ func genesisToCometBFT(d *genesis.Document) (*cmttypes.GenesisDoc, error) {
 	case beacon.BackendVRF:
 		params := d.Beacon.Parameters.VRFParameters
 		epochInterval = params.Interval
-		if epochInterval == 0 && cmdFlags.DebugDontBlameOasis() && d.Beacon.Parameters.DebugMockBackend {
+		if epochInterval == 0 && cmdFlags.DebugUnsafeGenesis() && d.Beacon.Parameters.DebugMockBackend {
+			// Use a default of 100 blocks in case epoch interval is unset
+			// and we are using debug mode.
+			epochInterval = 100
+		}
+	case beacon.BackendExternal:
+		params := d.Beacon.Parameters.ExternalParameters
+		epochInterval = params.Interval
+		if epochInterval == 0 && cmdFlags.DebugUnsafeGenesis() && d.Beacon.Parameters.DebugMockBackend {
 			// Use a default of 100 blocks in case epoch interval is unset
 			// and we are using debug mode.
 			epochInterval = 100
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -21,6 +22,9 @@ import (
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 )
 
+// iteratePrefetch is the number of nodes prefetched when iterating over remote state.
+const iteratePrefetch = 1000
+
 // ErrNoState is the error returned when state is nil.
 var ErrNoState = errors.New("cometbft: no state available (app not registered?)")
 
@@ -263,6 +267,31 @@ func (s *ImmutableState) CheckContextMode(ctx context.Context, allowedModes []Co
 	return fmt.Errorf("abci: method cannot be called from the specified ABCI context mode (%s)", abciCtx.Mode())
 }
 
+// Iterate iterates over all state entries with keys starting with the given prefix in key order
+// and invokes the given function with the key and value of each entry.
+//
+// This is intended for bulk exports (e.g., genesis dumps) where a single pass over a part of the
+// key space is much faster than looking up each of the entries separately. The passed key and
+// value must not be retained after the function returns. Iteration stops at the first error
+// returned by the function and that error is returned.
+func (s *ImmutableState) Iterate(ctx context.Context, prefix []byte, fn func(key, value []byte) error) error {
+	it := s.NewIterator(ctx, mkvs.IteratorPrefetch(iteratePrefetch))
+	defer it.Close()
+
+	for it.Seek(prefix); it.Valid(); it.Next() {
+		if !bytes.HasPrefix(it.Key(), prefix) {
+			break
+		}
+		if err := fn(it.Key(), it.Value()); err != nil {
+			return err
+		}
+	}
+	if it.Err() != nil {
+		return UnavailableStateError(it.Err())
+	}
+	return nil
+}
+
 // Close releases the resources associated with the immutable state wrapper.
 //
 // After calling this method, the immutable state wrapper should not be used anymore.
@@ -0,0 +1,302 @@
+package beacon
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	beaconState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/beacon/state"
+	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
+)
+
+var externalEntropyCtx = []byte("EkB-extn")
+
+type backendExternal struct {
+	app *beaconApplication
+}
+
+func (impl *backendExternal) OnInitChain(
+	ctx *api.Context,
+	state *beaconState.MutableState,
+	params *beacon.ConsensusParameters,
+	doc *genesis.Document,
+) error {
+	// Set the initial epoch.
+	baseEpoch := doc.Beacon.Base
+	if err := state.SetEpoch(ctx, baseEpoch, ctx.InitialHeight()); err != nil {
+		return fmt.Errorf("beacon: failed to set initial epoch: %w", err)
+	}
+	if err := state.SetExternalState(ctx, &beacon.ExternalState{Epoch: baseEpoch + 1}); err != nil {
+		return fmt.Errorf("beacon: failed to initialize external state: %w", err)
+	}
+
+	// If the backend is configured to use explicitly set epochs, there
+	// is nothing further to do.
+	if params.DebugMockBackend {
+		return nil
+	}
+
+	impl.app.doEmitEpochEvent(ctx, baseEpoch)
+
+	// Arm the initial epoch transition.
+	return impl.scheduleEpochTransitionBlock(ctx, state, params.ExternalParameters, baseEpoch+1, ctx.InitialHeight())
+}
+
+func (impl *backendExternal) OnBeginBlock(
+	ctx *api.Context,
+	state *beaconState.MutableState,
+	params *beacon.ConsensusParameters,
+) error {
+	future, err := state.GetFutureEpoch(ctx)
+	if err != nil {
+		return fmt.Errorf("beacon: failed to get future epoch: %w", err)
+	}
+	if future == nil {
+		return nil
+	}
+
+	height := ctx.BlockHeight() + 1 // Current height is ctx.BlockHeight() + 1
+	switch {
+	case future.Height < height:
+		ctx.Logger().Error("height mismatch in defered set",
+			"height", height,
+			"expected_height", future.Height,
+		)
+		return fmt.Errorf("beacon: height mismatch in defered set")
+	case future.Height > height:
+		// The epoch transition is scheduled to happen in the future.
+		return nil
+	case future.Height == height:
+		// Time to fire the scheduled epoch transition.
+	}
+
+	// The beacon must be derived from external randomness, so the epoch transition is postponed
+	// until randomness for the new epoch has been submitted.
+	extState, err := state.ExternalState(ctx)
+	if err != nil {
+		return fmt.Errorf("beacon: failed to get external state: %w", err)
+	}
+	if !hasExternalRandomness(extState, future.Epoch) {
+		if params.DebugMockBackend {
+			// Explicitly set epochs may skip epochs for which randomness will never be
+			// submitted, so the transition is not postponed and the beacon is derived from
+			// insecure block entropy instead.
+			ctx.Logger().Warn("no external randomness, using insecure block entropy",
+				"epoch", future.Epoch,
+				"current_height", height,
+			)
+		} else {
+			ctx.Logger().Warn("no external randomness, postponing epoch transition",
+				"epoch", future.Epoch,
+				"current_height", height,
+			)
+			if err = state.ClearFutureEpoch(ctx); err != nil {
+				return fmt.Errorf("beacon: failed to clear future epoch: %w", err)
+			}
+			return impl.app.scheduleEpochTransitionBlock(ctx, state, future.Epoch, height+1)
+		}
+	}
+
+	// Transition the epoch.
+	ctx.Logger().Info("setting epoch",
+		"epoch", future.Epoch,
+		"current_height", height,
+	)
+
+	if err = state.SetEpoch(ctx, future.Epoch, height); err != nil {
+		return fmt.Errorf("beacon: failed to set epoch: %w", err)
+	}
+	if err = state.ClearFutureEpoch(ctx); err != nil {
+		return fmt.Errorf("beacon: failed to clear future epoch: %w", err)
+	}
+	if !params.DebugMockBackend {
+		if err = impl.scheduleEpochTransitionBlock(ctx, state, params.ExternalParameters, future.Epoch+1, height); err != nil {
+			return err
+		}
+	}
+	impl.app.doEmitEpochEvent(ctx, future.Epoch)
+
+	// Generate the beacon.
+	return impl.onEpochChangeBeacon(ctx, state, future.Epoch, extState)
+}
+
+func (impl *backendExternal) scheduleEpochTransitionBlock(
+	ctx *api.Context,
+	state *beaconState.MutableState,
+	params *beacon.ExternalParameters,
+	nextEpoch beacon.EpochTime,
+	height int64,
+) error {
+	// Schedule the epoch transition based on block height. In case earlier transitions have been
+	// postponed, make sure that the epoch still lasts for the whole interval so that there is
+	// enough time for the randomness to be submitted.
+	nextHeight := int64(nextEpoch) * params.Interval
+	if nextHeight <= height {
+		nextHeight = height + params.Interval
+	}
+	return impl.app.scheduleEpochTransitionBlock(ctx, state, nextEpoch, nextHeight)
+}
+
+func (impl *backendExternal) onEpochChangeBeacon(
+	ctx *api.Context,
+	state *beaconState.MutableState,
+	epoch beacon.EpochTime,
+	extState *beacon.ExternalState,
+) error {
+	var lastRound uint64
+	if extState != nil {
+		lastRound = max(extState.Round, extState.LastRound)
+	}
+
+	// Start accepting randomness for the next epoch.
+	if err := state.SetExternalState(ctx, &beacon.ExternalState{
+		Epoch:     epoch + 1,
+		LastRound: lastRound,
+	}); err != nil {
+		return fmt.Errorf("beacon: failed to update external state: %w", err)
+	}
+
+	// Without external randomness (only possible with the mock backend), fall back to
+	// insecure block entropy.
+	b := GetBeacon(epoch, prodEntropyCtx, insecureBlockEntropy(ctx))
+	if hasExternalRandomness(extState, epoch) {
+		b = GetBeacon(epoch, externalEntropyCtx, extState.Randomness)
+	}
+
+	ctx.Logger().Debug("onBeaconEpochChange: generated beacon",
+		"epoch", epoch,
+		"beacon", hex.EncodeToString(b),
+		"round", lastRound,
+		"height", ctx.BlockHeight(),
+	)
+
+	return impl.app.onNewBeacon(ctx, b)
+}
+
+// hasExternalRandomness returns true iff external randomness for the given epoch has been
+// submitted.
+func hasExternalRandomness(extState *beacon.ExternalState, epoch beacon.EpochTime) bool {
+	return extState != nil && extState.Epoch == epoch && extState.Randomness != nil
+}
+
+func (impl *backendExternal) ExecuteTx(
+	ctx *api.Context,
+	state *beaconState.MutableState,
+	params *beacon.ConsensusParameters,
+	tx *transaction.Transaction,
+) error {
+	switch tx.Method {
+	case beacon.MethodExternalSubmit:
+		return impl.doSubmitTx(ctx, state, params, tx)
+	case MethodSetEpoch:
+		if !params.DebugMockBackend {
+			return fmt.Errorf("beacon: method '%s' is disabled via consensus", MethodSetEpoch)
+		}
+		return impl.doSetEpochTx(ctx, state, tx.Body)
+	default:
+		return fmt.Errorf("beacon: invalid method: %s", tx.Method)
+	}
+}
+
+func (impl *backendExternal) doSubmitTx(
+	ctx *api.Context,
+	state *beaconState.MutableState,
+	params *beacon.ConsensusParameters,
+	tx *transaction.Transaction,
+) error {
+	if err := ctx.Gas().UseGas(1, beacon.GasOpExternalSubmit, params.ExternalParameters.GasCosts); err != nil {
+		return err
+	}
+
+	// Return early if simulating since this is just estimating gas.
+	if ctx.IsSimulation() {
+		return nil
+	}
+
+	var submitTx beacon.ExternalSubmit
+	if err := cbor.Unmarshal(tx.Body, &submitTx); err != nil {
+		return beacon.ErrInvalidArgument
+	}
+
+	extState, err := state.ExternalState(ctx)
+	if err != nil {
+		return fmt.Errorf("beacon: failed to get external state: %w", err)
+	}
+	if extState == nil {
+		return fmt.Errorf("beacon: no external state")
+	}
+	if submitTx.Epoch != extState.Epoch {
+		return fmt.Errorf("beacon: randomness for invalid epoch: %d", submitTx.Epoch)
+	}
+	if submitTx.Round <= extState.LastRound {
+		return fmt.Errorf("beacon: stale randomness round: %d", submitTx.Round)
+	}
+	if submitTx.Round <= extState.Round {
+		// The latest available round is used so that nobody can pick among the already
+		// published rounds. Older or duplicate submissions are a no-op.
+		return nil
+	}
+
+	source, err := params.ExternalParameters.NewExternalSource()
+	if err != nil {
+		return fmt.Errorf("beacon: failed to create external source: %w", err)
+	}
+	randomness, err := source.VerifyRandomness(submitTx.Round, submitTx.Proof)
+	if err != nil {
+		return fmt.Errorf("beacon: failed to verify external randomness: %w", err)
+	}
+
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	extState.Round = submitTx.Round
+	extState.Randomness = randomness
+	if err = state.SetExternalState(ctx, extState); err != nil {
+		return fmt.Errorf("beacon: failed to update state: %w", err)
+	}
+
+	ctx.Logger().Debug("processed ExternalSubmit tx",
+		"epoch", submitTx.Epoch,
+		"round", submitTx.Round,
+	)
+
+	return nil
+}
+
+func (impl *backendExternal) doSetEpochTx(
+	ctx *api.Context,
+	state *beaconState.MutableState,
+	txBody []byte,
+) error {
+	now, _, err := state.GetEpoch(ctx)
+	if err != nil {
+		return err
+	}
+
+	var epoch beacon.EpochTime
+	if err = cbor.Unmarshal(txBody, &epoch); err != nil {
+		return err
+	}
+
+	if epoch <= now {
+		ctx.Logger().Error("explicit epoch transition does not advance time",
+			"epoch", now,
+			"new_epoch", epoch,
+		)
+		return fmt.Errorf("beacon: explicit epoch does not advance time")
+	}
+
+	height := ctx.BlockHeight() + 1 // Current height is ctx.BlockHeight() + 1
+
+	ctx.Logger().Info("scheduling explicit epoch transition",
+		"epoch", epoch,
+		"next_height", height+1,
+		"is_check_only", ctx.IsCheckOnly(),
+	)
+
+	return state.SetFutureEpoch(ctx, epoch, height+1)
+}
@@ -0,0 +1,174 @@
+package beacon
+
+import (
+	"testing"
+
+	"github.com/cometbft/cometbft/abci/types"
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	beaconState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/beacon/state"
+	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
+)
+
+type externalTestApp struct {
+	t        *testing.T
+	app      *beaconApplication
+	appState abciAPI.MockApplicationState
+	genesis  *genesis.Document
+}
+
+func newExternalTestApp(t *testing.T, debugMockBackend bool) *externalTestApp {
+	doc := &genesis.Document{
+		Height: 1,
+		Beacon: beacon.Genesis{
+			Parameters: beacon.ConsensusParameters{
+				Backend:          beacon.BackendExternal,
+				DebugMockBackend: debugMockBackend,
+				ExternalParameters: &beacon.ExternalParameters{
+					Interval: 10,
+				},
+			},
+		},
+	}
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{
+		Genesis: doc,
+	})
+
+	app := New().(*beaconApplication)
+	app.OnRegister(appState, nil)
+
+	ctx := appState.NewContext(abciAPI.ContextInitChain)
+	defer ctx.Close()
+	err := app.InitChain(ctx, types.RequestInitChain{}, doc)
+	require.NoError(t, err, "InitChain")
+
+	return &externalTestApp{
+		t:        t,
+		app:      app,
+		appState: appState,
+		genesis:  doc,
+	}
+}
+
+func (ta *externalTestApp) newContext(mode abciAPI.ContextMode, height int64) *abciAPI.Context {
+	// The context block height is the height of the last committed block.
+	ta.appState.UpdateMockApplicationStateConfig(&abciAPI.MockApplicationStateConfig{
+		BlockHeight: height - 1,
+		Genesis:     ta.genesis,
+	})
+	return ta.appState.NewContext(mode)
+}
+
+func (ta *externalTestApp) beginBlock(height int64) {
+	ctx := ta.newContext(abciAPI.ContextBeginBlock, height)
+	defer ctx.Close()
+
+	err := ta.app.BeginBlock(ctx)
+	require.NoError(ta.t, err, "BeginBlock at height %d", height)
+}
+
+func (ta *externalTestApp) state(height int64) (*abciAPI.Context, *beaconState.MutableState) {
+	ctx := ta.newContext(abciAPI.ContextDeliverTx, height)
+	return ctx, beaconState.NewMutableState(ctx.State())
+}
+
+func TestExternalPostponeEpochTransition(t *testing.T) {
+	require := require.New(t)
+
+	ta := newExternalTestApp(t, false)
+
+	requireEpoch := func(height int64, epoch beacon.EpochTime, future *beacon.EpochTimeState) {
+		ctx, state := ta.state(height)
+		defer ctx.Close()
+
+		current, _, err := state.GetEpoch(ctx)
+		require.NoError(err, "GetEpoch")
+		require.Equal(epoch, current, "current epoch")
+
+		pending, err := state.GetFutureEpoch(ctx)
+		require.NoError(err, "GetFutureEpoch")
+		require.EqualValues(future, pending, "future epoch")
+	}
+
+	// The first transition is scheduled at the end of the interval.
+	requireEpoch(1, 0, &beacon.EpochTimeState{Epoch: 1, Height: 10})
+	ta.beginBlock(9)
+	requireEpoch(9, 0, &beacon.EpochTimeState{Epoch: 1, Height: 10})
+
+	// Without external randomness, the transition should be postponed by one block at a time.
+	ta.beginBlock(10)
+	requireEpoch(10, 0, &beacon.EpochTimeState{Epoch: 1, Height: 11})
+	ta.beginBlock(11)
+	requireEpoch(11, 0, &beacon.EpochTimeState{Epoch: 1, Height: 12})
+
+	// Submit randomness for the pending epoch.
+	randomness := make([]byte, 32)
+	randomness[0] = 0x42
+	ctx, state := ta.state(11)
+	err := state.SetExternalState(ctx, &beacon.ExternalState{
+		Epoch:      1,
+		Round:      5,
+		Randomness: randomness,
+	})
+	ctx.Close()
+	require.NoError(err, "SetExternalState")
+
+	// Once randomness is available, the transition should fire and the next transition should
+	// be scheduled at the end of its interval.
+	ta.beginBlock(12)
+	requireEpoch(12, 1, &beacon.EpochTimeState{Epoch: 2, Height: 20})
+
+	ctx, state = ta.state(12)
+	defer ctx.Close()
+
+	b, err := state.Beacon(ctx)
+	require.NoError(err, "Beacon")
+	require.Equal(GetBeacon(1, externalEntropyCtx, randomness), b, "beacon should be derived from external randomness")
+
+	extState, err := state.ExternalState(ctx)
+	require.NoError(err, "ExternalState")
+	require.EqualValues(&beacon.ExternalState{Epoch: 2, LastRound: 5}, extState, "external state should be reset")
+}
+
+func TestExternalMockSetEpoch(t *testing.T) {
+	require := require.New(t)
+
+	ta := newExternalTestApp(t, true)
+
+	// Explicitly set an epoch for which randomness is never submitted.
+	ta.beginBlock(2)
+	ctx := ta.newContext(abciAPI.ContextDeliverTx, 2)
+	err := ta.app.ExecuteTx(ctx, &transaction.Transaction{
+		Method: MethodSetEpoch,
+		Body:   cbor.Marshal(beacon.EpochTime(5)),
+	})
+	ctx.Close()
+	require.NoError(err, "ExecuteTx(SetEpoch)")
+
+	// The transition should not be postponed.
+	ta.beginBlock(3)
+
+	ctx, state := ta.state(3)
+	defer ctx.Close()
+
+	epoch, height, err := state.GetEpoch(ctx)
+	require.NoError(err, "GetEpoch")
+	require.EqualValues(5, epoch, "epoch should be set")
+	require.EqualValues(3, height, "epoch should be set at the scheduled height")
+
+	future, err := state.GetFutureEpoch(ctx)
+	require.NoError(err, "GetFutureEpoch")
+	require.Nil(future, "no further transition should be scheduled")
+
+	b, err := state.Beacon(ctx)
+	require.NoError(err, "Beacon")
+	require.Len(b, beacon.BeaconSize, "beacon should be set")
+
+	extState, err := state.ExternalState(ctx)
+	require.NoError(err, "ExternalState")
+	require.EqualValues(6, extState.Epoch, "randomness for the next epoch should be accepted")
+}
@@ -2,14 +2,11 @@ package beacon
 
 import (
 	"bytes"
-	"encoding/binary"
 	"fmt"
-	"sort"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
-	"github.com/oasisprotocol/oasis-core/go/common/crypto/tuplehash"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	abciState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/abci/state"
@@ -19,8 +16,6 @@ import (
 	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
 )
 
-var vrfAlphaDomainsep = []byte("oasis-core:vrf/alpha")
-
 type backendVRF struct {
 	app *beaconApplication
 }
@@ -428,37 +423,11 @@ func (impl *backendVRF) scheduleEpochTransitionBlock(
 	return impl.app.scheduleEpochTransitionBlock(ctx, state, nextEpoch, nextHeight)
 }
 
-func (impl *backendVRF) initAlphaCommon(
-	ctx *api.Context,
-	epoch beacon.EpochTime,
-) *tuplehash.Hasher {
-	h := tuplehash.New256(32, vrfAlphaDomainsep)
-	_, _ = h.Write(MustGetChainContext(ctx))
-	var epochBytes [8]byte
-	binary.BigEndian.PutUint64(epochBytes[:], uint64(epoch))
-	_, _ = h.Write(epochBytes[:])
-	return h
-}
-
 func (impl *backendVRF) newHighQualityAlpha(
 	ctx *api.Context,
 	vrfState *beacon.VRFState,
 ) []byte {
-	sorted := make([]signature.PublicKey, 0, len(vrfState.Pi))
-	for mk := range vrfState.Pi {
-		sorted = append(sorted, mk)
-	}
-	sort.Slice(sorted, func(i, j int) bool {
-		return bytes.Compare(sorted[i][:], sorted[j][:]) < 0
-	})
-
-	h := impl.initAlphaCommon(ctx, vrfState.Epoch)
-	for _, pk := range sorted {
-		pi := vrfState.Pi[pk]
-		beta := pi.UnsafeToHash() // Ok because invalid proofs don't get stored.
-		_, _ = h.Write(beta)
-	}
-	return h.Sum(nil)
+	return beacon.DeriveHighQualityVRFAlpha(MustGetChainContext(ctx), vrfState.Epoch, vrfState.Pi)
 }
 
 func (impl *backendVRF) newLowQualityAlpha(
@@ -472,7 +441,7 @@ func (impl *backendVRF) newLowQualityAlpha(
 	// This being predictable is ok because the collected proofs from this alpha
 	// are only used to generate an actually good alpha, and not for actual
 	// elections.
-	h := impl.initAlphaCommon(ctx, epoch)
+	h := beacon.NewVRFAlphaHasher(MustGetChainContext(ctx), epoch)
 	_, _ = h.Write(insecureBlockEntropy(ctx)) // XXX: Is this really required?
 	return h.Sum(nil)
 }
@@ -49,6 +49,8 @@ func (app *beaconApplication) doInitBackend(params *beacon.ConsensusParameters)
 		app.backend = &backendInsecure{app}
 	case beacon.BackendVRF:
 		app.backend = &backendVRF{app}
+	case beacon.BackendExternal:
+		app.backend = &backendExternal{app}
 	default:
 		return fmt.Errorf("beacon: unsupported backend: '%s'", backendName)
 	}
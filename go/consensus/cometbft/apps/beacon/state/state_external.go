@@ -0,0 +1,36 @@
+package state
+
+import (
+	"context"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+)
+
+// externalStateKeyFmt is the current external backend state key format.
+var externalStateKeyFmt = consensus.KeyFormat.New(0x47)
+
+// ExternalState returns the external backend state.
+func (s *ImmutableState) ExternalState(ctx context.Context) (*beacon.ExternalState, error) {
+	data, err := s.is.Get(ctx, externalStateKeyFmt.Encode())
+	if err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	if data == nil {
+		return nil, nil
+	}
+
+	var state beacon.ExternalState
+	if err = cbor.Unmarshal(data, &state); err != nil {
+		return nil, abciAPI.UnavailableStateError(err)
+	}
+	return &state, nil
+}
+
+// SetExternalState sets the external backend state.
+func (s *MutableState) SetExternalState(ctx context.Context, state *beacon.ExternalState) error {
+	err := s.ms.Insert(ctx, externalStateKeyFmt.Encode(), cbor.Marshal(state))
+	return abciAPI.UnavailableStateError(err)
+}
@@ -13,3 +13,23 @@ var MessageChangeParameters = messageKind(0)
 // successful and with error otherwise. Other modules should ignore the message and return a nil
 // response.
 var MessageValidateParameterChanges = messageKind(1)
+
+// MessageFreezeEntity is the message kind for when the freeze entity proposal closes as accepted.
+// The message is the freeze entity proposal. The registry should respond with an empty struct if
+// the entity has been frozen and with error otherwise.
+var MessageFreezeEntity = messageKind(2)
+
+// MessageThawEntity is the message kind for when the thaw entity proposal closes as accepted.
+// The message is the thaw entity proposal. The registry should respond with an empty struct if
+// the entity has been thawed and with error otherwise.
+var MessageThawEntity = messageKind(3)
+
+// MessageValidateRuntimeUpdate is the message kind for when the update runtime proposal's changes
+// should be validated. The message is the update runtime proposal. The registry should respond
+// with an empty struct if validation is successful and with error otherwise.
+var MessageValidateRuntimeUpdate = messageKind(4)
+
+// MessageUpdateRuntime is the message kind for when the update runtime proposal closes as
+// accepted. The message is the update runtime proposal. The registry should respond with an empty
+// struct if the runtime has been updated and with error otherwise.
+var MessageUpdateRuntime = messageKind(5)
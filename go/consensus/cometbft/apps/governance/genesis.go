@@ -76,14 +76,13 @@ func (gq *governanceQuerier) Genesis(ctx context.Context) (*governance.Genesis,
 		return nil, err
 	}
 
+	allVotes, err := gq.state.AllVotes(ctx)
+	if err != nil {
+		return nil, err
+	}
 	voteEntries := make(map[uint64][]*governance.VoteEntry)
 	for _, proposal := range proposals {
-		var votes []*governance.VoteEntry
-		votes, err = gq.state.Votes(ctx, proposal.ID)
-		if err != nil {
-			return nil, err
-		}
-		voteEntries[proposal.ID] = votes
+		voteEntries[proposal.ID] = allVotes[proposal.ID]
 	}
 
 	return &governance.Genesis{
@@ -1,11 +1,14 @@
 package governance
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/cometbft/cometbft/abci/types"
 
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
@@ -94,6 +97,15 @@ func (app *governanceApplication) ExecuteTx(ctx *api.Context, tx *transaction.Tr
 			return governance.ErrInvalidArgument
 		}
 		return app.castVote(ctx, state, &proposalVote)
+	case governance.MethodCastAggregatedVote:
+		var aggregatedVote governance.AggregatedVote
+		if err := cbor.Unmarshal(tx.Body, &aggregatedVote); err != nil {
+			ctx.Logger().Debug("governance: failed to unmarshal aggregated vote",
+				"err", err,
+			)
+			return governance.ErrInvalidArgument
+		}
+		return app.castAggregatedVote(ctx, state, &aggregatedVote)
 	default:
 		return governance.ErrInvalidArgument
 	}
@@ -288,6 +300,14 @@ func (app *governanceApplication) executeProposal(ctx *api.Context, state *gover
 			return governance.ErrInvalidArgument
 		}
 
+		// The whitelist may have changed since the proposal was submitted.
+		if err = proposal.Content.ChangeParameters.CheckAllowed(params.AllowedParameterChanges); err != nil {
+			ctx.Logger().Debug("parameter changes not allowed",
+				"err", err,
+			)
+			return governance.ErrInvalidArgument
+		}
+
 		// Notify other interested applications about the change parameters proposal.
 		res, err := app.md.Publish(ctx, governanceApi.MessageChangeParameters, proposal.Content.ChangeParameters)
 		if err != nil {
@@ -303,6 +323,64 @@ func (app *governanceApplication) executeProposal(ctx *api.Context, state *gover
 			ctx.Logger().Debug("governance: no module applied change parameters proposal")
 			return governance.ErrInvalidArgument
 		}
+	case proposal.Content.FreezeEntity != nil, proposal.Content.ThawEntity != nil:
+		// To not violate the consensus, entity freeze proposals should be ignored when disabled.
+		params, err := state.ConsensusParameters(ctx)
+		if err != nil {
+			ctx.Logger().Error("failed to query consensus parameters",
+				"err", err,
+			)
+			return governance.ErrInvalidArgument
+		}
+		if !params.EnableEntityFreezeProposal {
+			ctx.Logger().Debug("entity freeze proposals are disabled")
+			return governance.ErrInvalidArgument
+		}
+
+		// Notify the registry about the entity status change.
+		var res interface{}
+		switch {
+		case proposal.Content.FreezeEntity != nil:
+			res, err = app.md.Publish(ctx, governanceApi.MessageFreezeEntity, proposal.Content.FreezeEntity)
+		default:
+			res, err = app.md.Publish(ctx, governanceApi.MessageThawEntity, proposal.Content.ThawEntity)
+		}
+		if err != nil {
+			ctx.Logger().Debug("failed to dispatch entity freeze proposal message",
+				"err", err,
+			)
+			return err
+		}
+		if res == nil {
+			ctx.Logger().Debug("governance: no module applied entity freeze proposal")
+			return governance.ErrInvalidArgument
+		}
+	case proposal.Content.UpdateRuntime != nil:
+		// To not violate the consensus, update runtime proposals should be ignored when disabled.
+		params, err := state.ConsensusParameters(ctx)
+		if err != nil {
+			ctx.Logger().Error("failed to query consensus parameters",
+				"err", err,
+			)
+			return governance.ErrInvalidArgument
+		}
+		if !params.EnableUpdateRuntimeProposal {
+			ctx.Logger().Debug("update runtime proposals are disabled")
+			return governance.ErrInvalidArgument
+		}
+
+		// Notify the registry about the runtime update.
+		res, err := app.md.Publish(ctx, governanceApi.MessageUpdateRuntime, proposal.Content.UpdateRuntime)
+		if err != nil {
+			ctx.Logger().Debug("failed to dispatch update runtime proposal message",
+				"err", err,
+			)
+			return err
+		}
+		if res == nil {
+			ctx.Logger().Debug("governance: no module applied update runtime proposal")
+			return governance.ErrInvalidArgument
+		}
 	default:
 		return governance.ErrInvalidArgument
 	}
@@ -352,6 +430,100 @@ func validatorsEscrow(
 	return totalVotingStake, validatorEntitiesEscrow, nil
 }
 
+// validatorEntities returns the sorted staking account addresses of the entities in the current
+// validator set.
+func validatorEntities(
+	ctx context.Context,
+	schedulerState *schedulerState.ImmutableState,
+) ([]stakingAPI.Address, error) {
+	currentValidators, err := schedulerState.CurrentValidators(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query current validators: %w", err)
+	}
+
+	seen := make(map[stakingAPI.Address]struct{})
+	var entities []stakingAPI.Address
+	for _, validator := range currentValidators {
+		entityAddr := stakingAPI.NewAddress(validator.EntityID)
+		if _, ok := seen[entityAddr]; ok {
+			continue
+		}
+		seen[entityAddr] = struct{}{}
+		entities = append(entities, entityAddr)
+	}
+	slices.SortFunc(entities, func(a, b stakingAPI.Address) int {
+		return bytes.Compare(a[:], b[:])
+	})
+	return entities, nil
+}
+
+// missingVoters returns the subset of the given entities that have not yet voted on the given
+// proposal, preserving the order of the entities.
+func missingVoters(
+	ctx context.Context,
+	state *governanceState.ImmutableState,
+	proposalID uint64,
+	entities []stakingAPI.Address,
+) ([]stakingAPI.Address, error) {
+	votes, err := state.Votes(ctx, proposalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query votes: %w", err)
+	}
+
+	voted := make(map[stakingAPI.Address]struct{}, len(votes))
+	for _, vote := range votes {
+		voted[vote.Voter] = struct{}{}
+	}
+
+	var missing []stakingAPI.Address
+	for _, entity := range entities {
+		if _, ok := voted[entity]; ok {
+			continue
+		}
+		missing = append(missing, entity)
+	}
+	return missing, nil
+}
+
+// emitVotingReminders emits voting reminder events for all active proposals that close in the
+// next epoch and have not yet been voted on by all validator entities.
+func (app *governanceApplication) emitVotingReminders(
+	ctx *api.Context,
+	state *governanceState.ImmutableState,
+	activeProposals []*governance.Proposal,
+	epoch beacon.EpochTime,
+) error {
+	var entities []stakingAPI.Address
+	for _, proposal := range activeProposals {
+		if proposal.ClosesAt != epoch+1 {
+			continue
+		}
+
+		if entities == nil {
+			var err error
+			entities, err = validatorEntities(ctx, schedulerState.NewMutableState(ctx.State()).ImmutableState)
+			if err != nil {
+				return err
+			}
+		}
+
+		missing, err := missingVoters(ctx, state, proposal.ID, entities)
+		if err != nil {
+			return err
+		}
+		if len(missing) == 0 {
+			continue
+		}
+
+		ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&governance.ProposalVotingReminderEvent{
+			ID:            proposal.ID,
+			ClosesAt:      proposal.ClosesAt,
+			MissingVoters: missing,
+		}))
+	}
+	return nil
+}
+
 // closeProposal closes an active proposal.
 //
 // This method modifies the passed proposal.
@@ -516,6 +688,10 @@ func (app *governanceApplication) EndBlock(ctx *api.Context) (types.ResponseEndB
 	if err != nil {
 		return types.ResponseEndBlock{}, fmt.Errorf("cometbft/governance: couldn't get active proposals: %w", err)
 	}
+	if err = app.emitVotingReminders(ctx, state.ImmutableState, activeProposals, epoch); err != nil {
+		return types.ResponseEndBlock{}, fmt.Errorf("cometbft/governance: failed to emit voting reminders: %w", err)
+	}
+
 	// Get proposals that are closed this epoch.
 	var closingProposals []*governance.Proposal
 	for _, proposal := range activeProposals {
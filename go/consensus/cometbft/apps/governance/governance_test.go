@@ -174,6 +174,44 @@ func TestValidatorsEscrow(t *testing.T) {
 	require.EqualValues(expectedValidatorsEscrow, validatorsEscrow, "validators escrow should match expected")
 }
 
+func TestMissingVoters(t *testing.T) {
+	require := require.New(t)
+
+	// Setup state.
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextDeliverTx)
+	defer ctx.Close()
+
+	state := governanceState.NewMutableState(ctx.State())
+	registryState := registryState.NewMutableState(ctx.State())
+	stakeState := stakingState.NewMutableState(ctx.State())
+	schedulerState := schedulerState.NewMutableState(ctx.State())
+	_, addresses, _ := initValidatorsEscrowState(t, stakeState, registryState, schedulerState)
+
+	// Test validatorEntities.
+	entities, err := validatorEntities(ctx, schedulerState.ImmutableState)
+	require.NoError(err, "validatorEntities")
+	require.Len(entities, numValidators, "each validator entity should be included once")
+	require.ElementsMatch(addresses[:numValidators], entities, "validator entities should match")
+
+	// Test missingVoters.
+	missing, err := missingVoters(ctx, state.ImmutableState, 1, entities)
+	require.NoError(err, "missingVoters")
+	require.EqualValues(entities, missing, "all entities should be missing votes")
+
+	require.NoError(state.SetVote(ctx, 1, entities[0], governance.VoteYes), "SetVote")
+	require.NoError(state.SetVote(ctx, 1, entities[2], governance.VoteNo), "SetVote")
+	require.NoError(state.SetVote(ctx, 1, addresses[numValidators], governance.VoteYes), "SetVote")
+
+	missing, err = missingVoters(ctx, state.ImmutableState, 1, entities)
+	require.NoError(err, "missingVoters")
+	require.EqualValues([]staking.Address{entities[1], entities[3]}, missing, "missing voters should match")
+
+	missing, err = missingVoters(ctx, state.ImmutableState, 2, entities[:1])
+	require.NoError(err, "missingVoters")
+	require.EqualValues(entities[:1], missing, "votes on other proposals should be ignored")
+}
+
 func TestCloseProposal(t *testing.T) {
 	require := require.New(t)
 	var err error
@@ -1,11 +1,16 @@
 package governance
 
 import (
+	"cmp"
 	"context"
+	"slices"
 
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	governanceState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/governance/state"
+	schedulerState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/scheduler/state"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 )
 
@@ -15,6 +20,7 @@ type Query interface {
 	Proposals(context.Context) ([]*governance.Proposal, error)
 	Proposal(context.Context, uint64) (*governance.Proposal, error)
 	Votes(context.Context, uint64) ([]*governance.VoteEntry, error)
+	MissingVotes(context.Context, beacon.EpochTime, []staking.Address) ([]*governance.MissingVotes, error)
 	PendingUpgrades(context.Context) ([]*upgrade.Descriptor, error)
 	Genesis(context.Context) (*governance.Genesis, error)
 	ConsensusParameters(context.Context) (*governance.ConsensusParameters, error)
@@ -31,11 +37,17 @@ func (qf *QueryFactory) QueryAt(ctx context.Context, height int64) (Query, error
 	if err != nil {
 		return nil, err
 	}
-	return &governanceQuerier{state}, nil
+	return &governanceQuerier{
+		queryState: qf.state,
+		state:      state,
+		height:     height,
+	}, nil
 }
 
 type governanceQuerier struct {
-	state *governanceState.ImmutableState
+	queryState abciAPI.ApplicationQueryState
+	state      *governanceState.ImmutableState
+	height     int64
 }
 
 func (gq *governanceQuerier) ActiveProposals(ctx context.Context) ([]*governance.Proposal, error) {
@@ -54,6 +66,61 @@ func (gq *governanceQuerier) Votes(ctx context.Context, id uint64) ([]*governanc
 	return gq.state.Votes(ctx, id)
 }
 
+func (gq *governanceQuerier) MissingVotes(
+	ctx context.Context,
+	closingWithin beacon.EpochTime,
+	entities []staking.Address,
+) ([]*governance.MissingVotes, error) {
+	proposals, err := gq.state.ActiveProposals(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var epoch beacon.EpochTime
+	if closingWithin != 0 {
+		if epoch, err = gq.queryState.GetEpoch(ctx, gq.height); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(entities) == 0 {
+		// Some queries need access to the scheduler to give useful responses.
+		schedState, err := schedulerState.NewImmutableState(ctx, gq.queryState, gq.height)
+		if err != nil {
+			return nil, err
+		}
+		if entities, err = validatorEntities(ctx, schedState); err != nil {
+			return nil, err
+		}
+	}
+
+	slices.SortFunc(proposals, func(a, b *governance.Proposal) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	var result []*governance.MissingVotes
+	for _, proposal := range proposals {
+		if closingWithin != 0 && proposal.ClosesAt > epoch+closingWithin {
+			continue
+		}
+
+		missing, err := missingVoters(ctx, gq.state, proposal.ID, entities)
+		if err != nil {
+			return nil, err
+		}
+		if len(missing) == 0 {
+			continue
+		}
+
+		result = append(result, &governance.MissingVotes{
+			ProposalID: proposal.ID,
+			ClosesAt:   proposal.ClosesAt,
+			Voters:     missing,
+		})
+	}
+	return result, nil
+}
+
 func (gq *governanceQuerier) PendingUpgrades(ctx context.Context) ([]*upgrade.Descriptor, error) {
 	return gq.state.PendingUpgrades(ctx)
 }
@@ -174,6 +174,32 @@ func (s *ImmutableState) Votes(ctx context.Context, id uint64) ([]*governance.Vo
 	return voteEntries, nil
 }
 
+// AllVotes returns the vote entries of all proposals, keyed by proposal identifier.
+func (s *ImmutableState) AllVotes(ctx context.Context) (map[uint64][]*governance.VoteEntry, error) {
+	voteEntries := make(map[uint64][]*governance.VoteEntry)
+	err := s.is.Iterate(ctx, votesKeyFmt.Encode(), func(key, value []byte) error {
+		var proposalID uint64
+		var voter staking.Address
+		if !votesKeyFmt.Decode(key, &proposalID, &voter) {
+			return nil
+		}
+
+		var vote governance.Vote
+		if err := cbor.Unmarshal(value, &vote); err != nil {
+			return api.UnavailableStateError(err)
+		}
+		voteEntries[proposalID] = append(voteEntries[proposalID], &governance.VoteEntry{
+			Voter: voter,
+			Vote:  vote,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return voteEntries, nil
+}
+
 func (s *ImmutableState) isProposalPendingUpgrade(ctx context.Context, proposal *governance.Proposal) (bool, error) {
 	if proposal.Content.Upgrade == nil {
 		return false, nil
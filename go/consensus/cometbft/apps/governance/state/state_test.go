@@ -251,6 +251,15 @@ func TestVotes(t *testing.T) {
 	votes0, err = s.Votes(ctx, proposals[0].ID)
 	require.NoError(err, "Votes()")
 	require.ElementsMatch(votes0, expectedVote0Entries, "Vote entries should match after update")
+
+	// Query all votes.
+	allVotes, err := s.AllVotes(ctx)
+	require.NoError(err, "AllVotes()")
+	require.Len(allVotes, 4, "AllVotes() should return votes of all proposals")
+	require.ElementsMatch(allVotes[proposals[0].ID], expectedVote0Entries, "Vote entries should match")
+	require.ElementsMatch(allVotes[proposals[1].ID], expectedVote1Entries, "Vote entries should match")
+	require.ElementsMatch(allVotes[proposals[2].ID], expectedVote2Entries, "Vote entries should match")
+	require.ElementsMatch(allVotes[proposals[3].ID], expectedVote3Entries, "Vote entries should match")
 }
 
 func TestPendingUpgrades(t *testing.T) {
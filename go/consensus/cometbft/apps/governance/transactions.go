@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	governanceApi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/governance/api"
 	governanceState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/governance/state"
@@ -48,6 +49,12 @@ func (app *governanceApplication) submitProposal(
 	if proposalContent.ChangeParameters != nil && !params.EnableChangeParametersProposal {
 		return nil, governance.ErrInvalidArgument
 	}
+	if (proposalContent.FreezeEntity != nil || proposalContent.ThawEntity != nil) && !params.EnableEntityFreezeProposal {
+		return nil, governance.ErrInvalidArgument
+	}
+	if proposalContent.UpdateRuntime != nil && !params.EnableUpdateRuntimeProposal {
+		return nil, governance.ErrInvalidArgument
+	}
 
 	// Charge gas for this transaction.
 	if err = ctx.Gas().UseGas(1, governance.GasOpSubmitProposal, params.GasCosts); err != nil {
@@ -141,6 +148,14 @@ func (app *governanceApplication) submitProposal(
 		}
 
 	case proposalContent.ChangeParameters != nil:
+		// Ensure only whitelisted parameters are being changed.
+		if err = proposalContent.ChangeParameters.CheckAllowed(params.AllowedParameterChanges); err != nil {
+			ctx.Logger().Debug("governance: parameter changes not allowed",
+				"err", err,
+			)
+			return nil, governance.ErrInvalidArgument
+		}
+
 		// Notify other interested applications to validate the parameter changes.
 		var res interface{}
 		res, err = app.md.Publish(ctx, governanceApi.MessageValidateParameterChanges, proposalContent.ChangeParameters)
@@ -156,6 +171,22 @@ func (app *governanceApplication) submitProposal(
 			ctx.Logger().Debug("governance: no module interested in change parameters proposal")
 			return nil, governance.ErrInvalidArgument
 		}
+	case proposalContent.FreezeEntity != nil, proposalContent.ThawEntity != nil:
+		// The entity status is checked by the registry when the proposal is executed.
+	case proposalContent.UpdateRuntime != nil:
+		// Notify the registry to validate the runtime parameter changes.
+		var res interface{}
+		res, err = app.md.Publish(ctx, governanceApi.MessageValidateRuntimeUpdate, proposalContent.UpdateRuntime)
+		if err != nil {
+			ctx.Logger().Debug("governance: failed to dispatch validate runtime update message",
+				"err", err,
+			)
+			return nil, err
+		}
+		if res == nil {
+			ctx.Logger().Debug("governance: no module interested in update runtime proposal")
+			return nil, governance.ErrInvalidArgument
+		}
 	default:
 		return nil, governance.ErrInvalidArgument
 	}
@@ -244,15 +275,116 @@ func (app *governanceApplication) castVote(
 		return stakingAPI.ErrForbidden
 	}
 
-	// Query signer entity descriptor.
-	var submitterNodes []signature.PublicKey
+	if err = app.checkVoterEligibility(ctx, params, ctx.TxSigner(), submitterAddr, params.AllowVoteWithoutEntity); err != nil {
+		return err
+	}
+
+	return app.recordVote(ctx, state, submitterAddr, proposalVote)
+}
+
+func (app *governanceApplication) castAggregatedVote(
+	ctx *api.Context,
+	state *governanceState.MutableState,
+	aggregatedVote *governance.AggregatedVote,
+) error {
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("governance: failed to fetch consensus parameters: %w", err)
+	}
+	if !params.EnableAggregatedVotes {
+		return governance.ErrInvalidArgument
+	}
+
+	// Verify entity signatures.
+	var body governance.AggregatedVoteBody
+	if err = aggregatedVote.Open(&body); err != nil {
+		return err
+	}
+
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	// Charge gas for this transaction, one vote per entity.
+	if err = ctx.Gas().UseGas(len(body.Voters), governance.GasOpCastVote, params.GasCosts); err != nil {
+		return err
+	}
+
+	// Return early if simulating since this is just estimating gas.
+	if ctx.IsSimulation() {
+		return nil
+	}
+
+	submitterAddr := ctx.CallerAddress()
+	if !submitterAddr.IsValid() {
+		return stakingAPI.ErrForbidden
+	}
+
+	// Make sure all voters are registered and eligible entities before casting any votes.
+	regState := registryState.NewMutableState(ctx.State())
+	voterAddrs := make([]stakingAPI.Address, 0, len(body.Voters))
+	for _, voter := range body.Voters {
+		var ent *entity.Entity
+		ent, err = regState.Entity(ctx, voter.EntityID)
+		switch err {
+		case nil:
+		case registryAPI.ErrNoSuchEntity:
+			return governance.ErrNotEligible
+		default:
+			return fmt.Errorf("governance: failed to query voter entity: %w", err)
+		}
+
+		entityAddr := stakingAPI.NewAddress(ent.ID)
+		if !entityAddr.IsValid() {
+			return stakingAPI.ErrForbidden
+		}
+		if err = app.checkVoterEligibility(ctx, params, ent.ID, entityAddr, false); err != nil {
+			return err
+		}
+		voterAddrs = append(voterAddrs, entityAddr)
+	}
+
+	stakeState := stakingState.NewMutableState(ctx.State())
+	for i, voter := range body.Voters {
+		// Increment the entity's account nonce to prevent replays.
+		if err = stakeState.IncrementNonce(ctx, voterAddrs[i], voter.Nonce); err != nil {
+			ctx.Logger().Debug("governance: invalid entity vote nonce",
+				"err", err,
+				"entity_id", voter.EntityID,
+				"nonce", voter.Nonce,
+			)
+			return err
+		}
+
+		if err = app.recordVote(ctx, state, voterAddrs[i], &body.Vote); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkVoterEligibility checks whether the given voter is eligible to vote.
+//
+// A voter is eligible if any of its entity's nodes are current validators or if it delegates to
+// a current validator. In case allowWithoutEntity is true, voters without a registered entity
+// are also considered.
+func (app *governanceApplication) checkVoterEligibility(
+	ctx *api.Context,
+	params *governance.ConsensusParameters,
+	voterID signature.PublicKey,
+	voterAddr stakingAPI.Address,
+	allowWithoutEntity bool,
+) error {
+	// Query voter entity descriptor.
+	var voterNodes []signature.PublicKey
 	registryState := registryState.NewMutableState(ctx.State())
-	submitterEntity, err := registryState.Entity(ctx, ctx.TxSigner())
+	voterEntity, err := registryState.Entity(ctx, voterID)
 	switch err {
 	case nil:
-		submitterNodes = submitterEntity.Nodes
+		voterNodes = voterEntity.Nodes
 	case registryAPI.ErrNoSuchEntity:
-		if !params.AllowVoteWithoutEntity {
+		if !allowWithoutEntity {
 			return governance.ErrNotEligible
 		}
 		// Default to an empty set of nodes so delegators without entities can vote.
@@ -271,15 +403,15 @@ func (app *governanceApplication) castVote(
 		currentValidatorsByNodeID[v.ID] = v
 	}
 
-	// Submitter is eligible if any of its nodes are a current validator.
+	// Voter is eligible if any of its nodes are a current validator.
 	var eligible bool
-	for _, nID := range submitterNodes {
+	for _, nID := range voterNodes {
 		if _, ok := currentValidatorsByNodeID[nID]; ok {
 			eligible = true
 			break
 		}
 	}
-	// Or if the submitter is a delegator to a current validator.
+	// Or if the voter is a delegator to a current validator.
 	if !eligible {
 		// Validators map by entity address.
 		currentValidatorsByEntityAddress := make(map[stakingAPI.Address]*schedulerAPI.Validator, len(currentValidators))
@@ -289,11 +421,11 @@ func (app *governanceApplication) castVote(
 		// Query delegations.
 		stakingState := stakingState.NewMutableState(ctx.State())
 		var delegs map[stakingAPI.Address]*stakingAPI.Delegation
-		delegs, err = stakingState.DelegationsFor(ctx, submitterAddr)
+		delegs, err = stakingState.DelegationsFor(ctx, voterAddr)
 		if err != nil {
-			return fmt.Errorf("governance: failed to query submitter delegations: %w", err)
+			return fmt.Errorf("governance: failed to query voter delegations: %w", err)
 		}
-		// Check if submitter delegates to any validator entity.
+		// Check if voter delegates to any validator entity.
 		for d := range delegs {
 			if _, ok := currentValidatorsByEntityAddress[d]; ok {
 				eligible = true
@@ -303,12 +435,21 @@ func (app *governanceApplication) castVote(
 	}
 
 	if !eligible {
-		ctx.Logger().Debug("governance: submitter not eligible to vote",
-			"submitter", ctx.CallerAddress(),
+		ctx.Logger().Debug("governance: voter not eligible to vote",
+			"voter", voterAddr,
 		)
 		return governance.ErrNotEligible
 	}
+	return nil
+}
 
+// recordVote records the vote of an eligible voter.
+func (app *governanceApplication) recordVote(
+	ctx *api.Context,
+	state *governanceState.MutableState,
+	voterAddr stakingAPI.Address,
+	proposalVote *governance.ProposalVote,
+) error {
 	// Load proposal.
 	proposal, err := state.Proposal(ctx, proposalVote.ID)
 	switch err {
@@ -336,14 +477,14 @@ func (app *governanceApplication) castVote(
 	}
 
 	// Save the vote.
-	if err := state.SetVote(ctx, proposal.ID, submitterAddr, proposalVote.Vote); err != nil {
+	if err := state.SetVote(ctx, proposal.ID, voterAddr, proposalVote.Vote); err != nil {
 		return fmt.Errorf("governance: failed to save the vote: %w", err)
 	}
 
 	// Emit event.
 	ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&governance.VoteEvent{
 		ID:        proposal.ID,
-		Submitter: submitterAddr,
+		Submitter: voterAddr,
 		Vote:      proposalVote.Vote,
 	}))
 
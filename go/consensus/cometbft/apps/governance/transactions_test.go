@@ -9,6 +9,7 @@ import (
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	governanceState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/governance/state"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
@@ -489,3 +490,146 @@ func TestCastVote(t *testing.T) {
 		tc.check()
 	}
 }
+
+func TestCastAggregatedVote(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	signature.SetChainContext("test: oasis-core tests")
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	// Setup state.
+	registryState := registryState.NewMutableState(ctx.State())
+	stakeState := stakingState.NewMutableState(ctx.State())
+	schedulerState := schedulerState.NewMutableState(ctx.State())
+	signers, addresses, _ := initValidatorsEscrowState(t, stakeState, registryState, schedulerState)
+	submitterPK := signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+
+	// Setup governance state.
+	state := governanceState.NewMutableState(ctx.State())
+	app := &governanceApplication{
+		state: appState,
+	}
+	params := &governance.ConsensusParameters{
+		GasCosts:                  governance.DefaultGasCosts,
+		MinProposalDeposit:        *quantity.NewFromUint64(100),
+		StakeThreshold:            90,
+		UpgradeCancelMinEpochDiff: beacon.EpochTime(100),
+		UpgradeMinEpochDiff:       beacon.EpochTime(100),
+		VotingPeriod:              beacon.EpochTime(50),
+		AllowVoteWithoutEntity:    true,
+	}
+	err = state.SetConsensusParameters(ctx, params)
+	require.NoError(err, "setting governance consensus parameters should not error")
+
+	p1 := &governance.Proposal{ID: 1, State: governance.StateActive}
+	err = state.SetActiveProposal(ctx, p1)
+	require.NoError(err, "SetActiveProposal")
+
+	vote := governance.ProposalVote{
+		ID:   p1.ID,
+		Vote: governance.VoteYes,
+	}
+	voter := func(signer signature.Signer, nonce uint64) governance.AggregatedVoter {
+		return governance.AggregatedVoter{
+			EntityID: signer.Public(),
+			Nonce:    nonce,
+		}
+	}
+	sign := func(signers []signature.Signer, voters ...governance.AggregatedVoter) *governance.AggregatedVote {
+		av, sErr := governance.SignAggregatedVote(signers, &governance.AggregatedVoteBody{
+			Vote:   vote,
+			Voters: voters,
+		})
+		require.NoError(sErr, "SignAggregatedVote")
+		return av
+	}
+
+	txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+	defer txCtx.Close()
+	txCtx.SetTxSigner(submitterPK)
+
+	// Aggregated votes should be rejected while disabled.
+	err = app.castAggregatedVote(txCtx, state, sign(signers[1:2], voter(signers[1], 0)))
+	require.ErrorIs(err, governance.ErrInvalidArgument, "aggregated votes should be disabled")
+
+	params.EnableAggregatedVotes = true
+	err = state.SetConsensusParameters(ctx, params)
+	require.NoError(err, "setting governance consensus parameters should not error")
+
+	for _, tc := range []struct {
+		msg  string
+		vote *governance.AggregatedVote
+		err  error
+	}{
+		{
+			"should fail without voters",
+			sign(nil),
+			governance.ErrInvalidArgument,
+		},
+		{
+			"should fail when not signed by a voter",
+			sign(signers[1:2], voter(signers[1], 0), voter(signers[2], 0)),
+			governance.ErrInvalidArgument,
+		},
+		{
+			"should fail when signed by a non-voter",
+			sign(signers[1:3], voter(signers[1], 0)),
+			governance.ErrInvalidArgument,
+		},
+		{
+			"should fail with duplicate voters",
+			sign(signers[1:2], voter(signers[1], 0), voter(signers[1], 0)),
+			governance.ErrInvalidArgument,
+		},
+		{
+			"should fail with an invalid nonce",
+			sign(signers[1:2], voter(signers[1], 5)),
+			transaction.ErrInvalidNonce,
+		},
+		{
+			"should fail for entities that are not registered",
+			sign(
+				[]signature.Signer{signers[1], signers[numValidators]},
+				voter(signers[1], 0), voter(signers[numValidators], 0),
+			),
+			governance.ErrNotEligible,
+		},
+		{
+			"should work for multiple validator entities",
+			sign(signers[1:3], voter(signers[1], 0), voter(signers[2], 0)),
+			nil,
+		},
+		{
+			"should fail when replayed",
+			sign(signers[1:3], voter(signers[1], 0), voter(signers[2], 0)),
+			transaction.ErrInvalidNonce,
+		},
+	} {
+		txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+		defer txCtx.Close()
+		txCtx.SetTxSigner(submitterPK)
+
+		err = app.castAggregatedVote(txCtx, state, tc.vote)
+		require.ErrorIs(err, tc.err, tc.msg)
+	}
+
+	// Ensure votes exist for both entities.
+	votes, err := state.Votes(ctx, p1.ID)
+	require.NoError(err, "Votes()")
+	require.Len(votes, 2, "two votes should exist")
+	for _, v := range votes {
+		require.EqualValues(governance.VoteYes, v.Vote, "vote should match submitted vote")
+		require.Contains([]staking.Address{addresses[1], addresses[2]}, v.Voter, "voter should be one of the signing entities")
+	}
+
+	// Ensure entity nonces have been incremented.
+	for _, addr := range []staking.Address{addresses[1], addresses[2]} {
+		acct, err := stakeState.Account(ctx, addr)
+		require.NoError(err, "Account")
+		require.EqualValues(1, acct.General.Nonce, "entity nonce should be incremented")
+	}
+}
@@ -122,6 +122,17 @@ func (app *registryApplication) InitChain(ctx *abciAPI.Context, _ types.RequestI
 		}
 	}
 
+	// Freeze entities last so that their existing nodes and runtimes can be registered.
+	for _, id := range st.FrozenEntities {
+		if err := state.FreezeEntity(ctx, id); err != nil {
+			ctx.Logger().Error("InitChain: failed to freeze entity",
+				"err", err,
+				"entity_id", id,
+			)
+			return fmt.Errorf("registry: genesis entity freeze failure: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -170,6 +181,11 @@ func (rq *registryQuerier) Genesis(ctx context.Context) (*registry.Genesis, erro
 		nodeStatuses[n.ID] = status
 	}
 
+	frozenEntities, err := rq.state.FrozenEntities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	params, err := rq.state.ConsensusParameters(ctx)
 	if err != nil {
 		return nil, err
@@ -182,6 +198,7 @@ func (rq *registryQuerier) Genesis(ctx context.Context) (*registry.Genesis, erro
 		SuspendedRuntimes: suspendedRuntimes,
 		Nodes:             validatorNodes,
 		NodeStatuses:      nodeStatuses,
+		FrozenEntities:    frozenEntities,
 	}
 	return &gen, nil
 }
@@ -4,7 +4,9 @@ import (
 	"fmt"
 
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	registryApi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/api"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
@@ -52,3 +54,152 @@ func (app *registryApplication) changeParameters(ctx *api.Context, msg interface
 	// Non-nil response signals that changes are valid and were successfully applied (if required).
 	return struct{}{}, nil
 }
+
+func (app *registryApplication) freezeEntity(ctx *api.Context, msg interface{}) (interface{}, error) {
+	proposal, ok := msg.(*governance.FreezeEntityProposal)
+	if !ok {
+		return nil, fmt.Errorf("registry: failed to type assert freeze entity proposal")
+	}
+
+	state := registryState.NewMutableState(ctx.State())
+	if err := app.setEntityFrozen(ctx, state, proposal.EntityID, true); err != nil {
+		return nil, err
+	}
+
+	// Non-nil response signals that the entity has been frozen.
+	return struct{}{}, nil
+}
+
+func (app *registryApplication) thawEntity(ctx *api.Context, msg interface{}) (interface{}, error) {
+	proposal, ok := msg.(*governance.ThawEntityProposal)
+	if !ok {
+		return nil, fmt.Errorf("registry: failed to type assert thaw entity proposal")
+	}
+
+	state := registryState.NewMutableState(ctx.State())
+	if err := app.setEntityFrozen(ctx, state, proposal.EntityID, false); err != nil {
+		return nil, err
+	}
+
+	// Non-nil response signals that the entity has been thawed.
+	return struct{}{}, nil
+}
+
+func (app *registryApplication) updateRuntime(ctx *api.Context, msg interface{}, apply bool) (interface{}, error) {
+	proposal, ok := msg.(*governance.UpdateRuntimeProposal)
+	if !ok {
+		return nil, fmt.Errorf("registry: failed to type assert update runtime proposal")
+	}
+
+	state := registryState.NewMutableState(ctx.State())
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("registry: failed to load consensus parameters: %w", err)
+	}
+	epoch, err := app.state.GetEpoch(ctx, ctx.BlockHeight()+1)
+	if err != nil {
+		return nil, fmt.Errorf("registry: failed to get current epoch: %w", err)
+	}
+
+	// Fetch the existing runtime, which may also be suspended.
+	var suspended bool
+	existingRt, err := state.Runtime(ctx, proposal.RuntimeID)
+	switch err {
+	case nil:
+	case registry.ErrNoSuchRuntime:
+		existingRt, err = state.SuspendedRuntime(ctx, proposal.RuntimeID)
+		if err != nil {
+			return nil, err
+		}
+		suspended = true
+	default:
+		return nil, fmt.Errorf("registry: failed to fetch runtime: %w", err)
+	}
+
+	// Only runtimes governed by the consensus layer can be updated via governance proposals.
+	if existingRt.GovernanceModel != registry.GovernanceConsensus {
+		return nil, fmt.Errorf("%w: runtime is not governed by the consensus layer", registry.ErrForbidden)
+	}
+
+	var changes registry.RuntimeParameterChanges
+	if err = cbor.Unmarshal(proposal.Changes, &changes); err != nil {
+		return nil, fmt.Errorf("registry: failed to unmarshal runtime parameter changes: %w", err)
+	}
+	if err = changes.SanityCheck(); err != nil {
+		return nil, fmt.Errorf("registry: failed to validate runtime parameter changes: %w", err)
+	}
+
+	// Apply changes to a copy of the existing descriptor and verify the result.
+	var rt registry.Runtime
+	if err = cbor.Unmarshal(cbor.Marshal(existingRt), &rt); err != nil {
+		return nil, fmt.Errorf("registry: failed to copy runtime descriptor: %w", err)
+	}
+	changes.Apply(&rt)
+
+	if err = registry.VerifyRuntime(params, ctx.Logger(), &rt, false, false, epoch); err != nil {
+		return nil, err
+	}
+	if rt.Kind == registry.KindCompute {
+		if err = registry.VerifyRegisterComputeRuntimeArgs(ctx, ctx.Logger(), &rt, state); err != nil {
+			return nil, err
+		}
+	}
+	if err = registry.VerifyRuntimeUpdate(ctx.Logger(), existingRt, &rt, epoch, params); err != nil {
+		return nil, err
+	}
+
+	if !apply {
+		// Non-nil response signals that the changes are valid.
+		return struct{}{}, nil
+	}
+
+	if _, err = app.md.Publish(ctx, registryApi.MessageRuntimeUpdated, &rt); err != nil {
+		return nil, fmt.Errorf("registry: failed to dispatch runtime updated message: %w", err)
+	}
+	if err = state.SetRuntime(ctx, &rt, suspended); err != nil {
+		return nil, fmt.Errorf("registry: failed to set runtime: %w", err)
+	}
+
+	if !suspended {
+		ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&registry.RuntimeStartedEvent{Runtime: &rt}))
+	}
+
+	// Non-nil response signals that the runtime has been updated.
+	return struct{}{}, nil
+}
+
+func (app *registryApplication) setEntityFrozen(
+	ctx *api.Context,
+	state *registryState.MutableState,
+	id signature.PublicKey,
+	freeze bool,
+) error {
+	frozen, err := state.IsEntityFrozen(ctx, id)
+	if err != nil {
+		return fmt.Errorf("registry: failed to check entity status: %w", err)
+	}
+	if frozen == freeze {
+		return fmt.Errorf("%w: entity frozen status is already %t", registry.ErrInvalidArgument, freeze)
+	}
+
+	switch freeze {
+	case true:
+		// Only registered entities can be frozen.
+		if _, err = state.Entity(ctx, id); err != nil {
+			return err
+		}
+		err = state.FreezeEntity(ctx, id)
+	case false:
+		err = state.ThawEntity(ctx, id)
+	}
+	if err != nil {
+		return fmt.Errorf("registry: failed to update entity status: %w", err)
+	}
+
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&registry.EntityFrozenEvent{
+		EntityID: id,
+		Frozen:   freeze,
+	}))
+
+	return nil
+}
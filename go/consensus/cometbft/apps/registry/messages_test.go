@@ -2,10 +2,15 @@ package registry
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
@@ -102,3 +107,208 @@ func TestChangeParameters(t *testing.T) {
 		require.EqualError(err, "registry: failed to validate consensus parameters: maximum node expiration not specified")
 	})
 }
+
+func TestFreezeEntity(t *testing.T) {
+	require := require.New(t)
+
+	// Prepare context.
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	// Setup state.
+	state := registryState.NewMutableState(ctx.State())
+	app := &registryApplication{
+		state: appState,
+	}
+
+	entitySigner := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: freeze entity signer")
+	ent := entity.Entity{
+		Versioned: cbor.NewVersioned(entity.LatestDescriptorVersion),
+		ID:        entitySigner.Public(),
+	}
+	sigEnt, err := entity.SignEntity(entitySigner, registry.RegisterEntitySignatureContext, &ent)
+	require.NoError(err, "SignEntity")
+	err = state.SetEntity(ctx, &ent, sigEnt)
+	require.NoError(err, "SetEntity")
+
+	// Freezing a registered entity should succeed.
+	res, err := app.freezeEntity(ctx, &governance.FreezeEntityProposal{EntityID: ent.ID})
+	require.NoError(err, "freezing an entity should succeed")
+	require.Equal(struct{}{}, res)
+
+	frozen, err := state.IsEntityFrozen(ctx, ent.ID)
+	require.NoError(err, "IsEntityFrozen")
+	require.True(frozen, "entity should be frozen")
+
+	frozenEntities, err := state.FrozenEntities(ctx)
+	require.NoError(err, "FrozenEntities")
+	require.Equal([]signature.PublicKey{ent.ID}, frozenEntities)
+
+	// Freezing an already frozen entity should fail.
+	_, err = app.freezeEntity(ctx, &governance.FreezeEntityProposal{EntityID: ent.ID})
+	require.ErrorIs(err, registry.ErrInvalidArgument, "freezing a frozen entity should fail")
+
+	// Thawing a frozen entity should succeed.
+	res, err = app.thawEntity(ctx, &governance.ThawEntityProposal{EntityID: ent.ID})
+	require.NoError(err, "thawing an entity should succeed")
+	require.Equal(struct{}{}, res)
+
+	frozen, err = state.IsEntityFrozen(ctx, ent.ID)
+	require.NoError(err, "IsEntityFrozen")
+	require.False(frozen, "entity should not be frozen")
+
+	// Thawing an entity that is not frozen should fail.
+	_, err = app.thawEntity(ctx, &governance.ThawEntityProposal{EntityID: ent.ID})
+	require.ErrorIs(err, registry.ErrInvalidArgument, "thawing an entity that is not frozen should fail")
+
+	// Freezing an unknown entity should fail.
+	unknownSigner := memorySigner.NewTestSigner("consensus/cometbft/apps/registry: unknown entity signer")
+	_, err = app.freezeEntity(ctx, &governance.FreezeEntityProposal{EntityID: unknownSigner.Public()})
+	require.ErrorIs(err, registry.ErrNoSuchEntity, "freezing an unknown entity should fail")
+
+	// Invalid message types should fail.
+	_, err = app.freezeEntity(ctx, &governance.ThawEntityProposal{EntityID: ent.ID})
+	require.Error(err, "invalid message type should fail")
+	_, err = app.thawEntity(ctx, &governance.FreezeEntityProposal{EntityID: ent.ID})
+	require.Error(err, "invalid message type should fail")
+}
+
+func TestUpdateRuntime(t *testing.T) {
+	// Prepare context.
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	// Setup state.
+	var md abciAPI.NoopMessageDispatcher
+	state := registryState.NewMutableState(ctx.State())
+	app := &registryApplication{
+		state: appState,
+		md:    &md,
+	}
+	err := state.SetConsensusParameters(ctx, &registry.ConsensusParameters{
+		DebugAllowTestRuntimes: true,
+		EnableRuntimeGovernanceModels: map[registry.RuntimeGovernanceModel]bool{
+			registry.GovernanceEntity:    true,
+			registry.GovernanceConsensus: true,
+		},
+		MaxNodeExpiration: 10,
+	})
+	require.NoError(t, err, "setting consensus parameters should succeed")
+
+	newRuntime := func(seed string, gm registry.RuntimeGovernanceModel) *registry.Runtime {
+		rt := registry.Runtime{
+			Versioned: cbor.NewVersioned(registry.LatestRuntimeDescriptorVersion),
+			ID:        common.NewTestNamespaceFromSeed([]byte(seed), 0),
+			Kind:      registry.KindCompute,
+			Executor: registry.ExecutorParameters{
+				GroupSize:    1,
+				RoundTimeout: 5,
+			},
+			TxnScheduler: registry.TxnSchedulerParameters{
+				BatchFlushTimeout: time.Second,
+				MaxBatchSize:      1,
+				MaxBatchSizeBytes: 1024,
+				ProposerTimeout:   2 * time.Second,
+			},
+			AdmissionPolicy: registry.RuntimeAdmissionPolicy{
+				AnyNode: &registry.AnyNodeRuntimeAdmissionPolicy{},
+			},
+			GovernanceModel: gm,
+			Deployments: []*registry.VersionInfo{
+				{},
+			},
+		}
+		err = state.SetRuntime(ctx, &rt, false)
+		require.NoError(t, err, "SetRuntime")
+		return &rt
+	}
+	rt := newRuntime("consensus/cometbft/apps/registry: update runtime: consensus", registry.GovernanceConsensus)
+	entityRt := newRuntime("consensus/cometbft/apps/registry: update runtime: entity", registry.GovernanceEntity)
+
+	changes := registry.RuntimeParameterChanges{
+		Executor: &registry.ExecutorParameters{
+			GroupSize:       3,
+			GroupBackupSize: 1,
+			RoundTimeout:    10,
+		},
+	}
+	proposal := governance.UpdateRuntimeProposal{
+		RuntimeID: rt.ID,
+		Changes:   cbor.Marshal(changes),
+	}
+
+	t.Run("happy path - validate only", func(t *testing.T) {
+		require := require.New(t)
+
+		res, err := app.updateRuntime(ctx, &proposal, false)
+		require.NoError(err, "validation of runtime parameter changes should succeed")
+		require.Equal(struct{}{}, res)
+
+		stored, err := state.Runtime(ctx, rt.ID)
+		require.NoError(err, "Runtime")
+		require.Equal(rt.Executor, stored.Executor, "runtime descriptor shouldn't change")
+	})
+	t.Run("happy path - apply changes", func(t *testing.T) {
+		require := require.New(t)
+
+		res, err := app.updateRuntime(ctx, &proposal, true)
+		require.NoError(err, "updating runtime parameters should succeed")
+		require.Equal(struct{}{}, res)
+
+		stored, err := state.Runtime(ctx, rt.ID)
+		require.NoError(err, "Runtime")
+		require.Equal(*changes.Executor, stored.Executor, "executor parameters should change")
+		require.Equal(rt.TxnScheduler, stored.TxnScheduler, "other parameters shouldn't change")
+	})
+	t.Run("invalid proposal", func(t *testing.T) {
+		require := require.New(t)
+
+		_, err := app.updateRuntime(ctx, "proposal", true)
+		require.EqualError(err, "registry: failed to type assert update runtime proposal")
+	})
+	t.Run("unknown runtime", func(t *testing.T) {
+		require := require.New(t)
+
+		proposal := governance.UpdateRuntimeProposal{
+			RuntimeID: common.NewTestNamespaceFromSeed([]byte("consensus/cometbft/apps/registry: update runtime: unknown"), 0),
+			Changes:   cbor.Marshal(changes),
+		}
+		_, err := app.updateRuntime(ctx, &proposal, true)
+		require.ErrorIs(err, registry.ErrNoSuchRuntime)
+	})
+	t.Run("runtime not governed by consensus", func(t *testing.T) {
+		require := require.New(t)
+
+		proposal := governance.UpdateRuntimeProposal{
+			RuntimeID: entityRt.ID,
+			Changes:   cbor.Marshal(changes),
+		}
+		_, err := app.updateRuntime(ctx, &proposal, true)
+		require.ErrorIs(err, registry.ErrForbidden)
+	})
+	t.Run("empty changes", func(t *testing.T) {
+		require := require.New(t)
+
+		proposal := governance.UpdateRuntimeProposal{
+			RuntimeID: rt.ID,
+			Changes:   cbor.Marshal(registry.RuntimeParameterChanges{}),
+		}
+		_, err := app.updateRuntime(ctx, &proposal, true)
+		require.EqualError(err, "registry: failed to validate runtime parameter changes: runtime parameter changes should not be empty")
+	})
+	t.Run("invalid changes", func(t *testing.T) {
+		require := require.New(t)
+
+		changes := registry.RuntimeParameterChanges{
+			Executor: &registry.ExecutorParameters{},
+		}
+		proposal := governance.UpdateRuntimeProposal{
+			RuntimeID: rt.ID,
+			Changes:   cbor.Marshal(changes),
+		}
+		_, err := app.updateRuntime(ctx, &proposal, true)
+		require.ErrorIs(err, registry.ErrInvalidArgument)
+	})
+}
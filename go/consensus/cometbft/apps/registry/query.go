@@ -17,12 +17,17 @@ import (
 type Query interface {
 	Entity(context.Context, signature.PublicKey) (*entity.Entity, error)
 	Entities(context.Context) ([]*entity.Entity, error)
+	FrozenEntities(context.Context) ([]signature.PublicKey, error)
 	Node(context.Context, signature.PublicKey) (*node.Node, error)
 	NodeByConsensusAddress(context.Context, []byte) (*node.Node, error)
 	NodeStatus(context.Context, signature.PublicKey) (*registry.NodeStatus, error)
 	Nodes(context.Context) ([]*node.Node, error)
+	NodesForRuntime(ctx context.Context, id common.Namespace) ([]*node.Node, error)
+	ListNodes(ctx context.Context, after *signature.PublicKey, limit uint32, filter *registry.NodeFilter) (*registry.ListNodesResponse, error)
 	Runtime(ctx context.Context, id common.Namespace, includeSuspended bool) (*registry.Runtime, error)
 	Runtimes(ctx context.Context, includeSuspended bool) ([]*registry.Runtime, error)
+	ListRuntimes(ctx context.Context, includeSuspended bool, after *common.Namespace, limit uint32, filter *registry.RuntimeFilter) (*registry.ListRuntimesResponse, error)
+	RuntimeTEEHistory(ctx context.Context, id common.Namespace) ([]*registry.RuntimeTEEHistoryRecord, error)
 	Genesis(context.Context) (*registry.Genesis, error)
 	ConsensusParameters(context.Context) (*registry.ConsensusParameters, error)
 }
@@ -55,6 +60,10 @@ func (rq *registryQuerier) Entities(ctx context.Context) ([]*entity.Entity, erro
 	return rq.state.Entities(ctx)
 }
 
+func (rq *registryQuerier) FrozenEntities(ctx context.Context) ([]signature.PublicKey, error) {
+	return rq.state.FrozenEntities(ctx)
+}
+
 func (rq *registryQuerier) Node(ctx context.Context, id signature.PublicKey) (*node.Node, error) {
 	epoch, err := rq.queryState.GetEpoch(ctx, rq.height)
 	if err != nil {
@@ -103,6 +112,74 @@ func (rq *registryQuerier) Nodes(ctx context.Context) ([]*node.Node, error) {
 	return filteredNodes, nil
 }
 
+func (rq *registryQuerier) NodesForRuntime(ctx context.Context, id common.Namespace) ([]*node.Node, error) {
+	epoch, err := rq.queryState.GetEpoch(ctx, rq.height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get epoch: %w", err)
+	}
+
+	params, err := rq.state.ConsensusParameters(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fall back to scanning all nodes in case the index is not available.
+	var nodes []*node.Node
+	if params.EnableNodeByRuntimeIndex {
+		nodes, err = rq.state.NodesForRuntime(ctx, id)
+	} else {
+		nodes, err = rq.state.Nodes(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Filter out expired nodes and nodes not supporting the runtime.
+	var filteredNodes []*node.Node
+	for _, n := range nodes {
+		if n.IsExpired(uint64(epoch)) || !n.HasRuntime(id) {
+			continue
+		}
+		filteredNodes = append(filteredNodes, n)
+	}
+	return filteredNodes, nil
+}
+
+func (rq *registryQuerier) ListNodes(
+	ctx context.Context,
+	after *signature.PublicKey,
+	limit uint32,
+	filter *registry.NodeFilter,
+) (*registry.ListNodesResponse, error) {
+	epoch, err := rq.queryState.GetEpoch(ctx, rq.height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get epoch: %w", err)
+	}
+
+	// Fetch one more node than requested to determine whether there are more pages.
+	n := registry.ListLimit(limit)
+	nodes := make([]*node.Node, 0, n+1)
+	err = rq.state.IterateNodes(ctx, after, func(nd *node.Node) bool {
+		// Filter out expired nodes.
+		if nd.IsExpired(uint64(epoch)) || !filter.Matches(nd) {
+			return true
+		}
+		nodes = append(nodes, nd)
+		return len(nodes) <= n
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var rsp registry.ListNodesResponse
+	if len(nodes) > n {
+		nodes = nodes[:n]
+		rsp.Next = &nodes[n-1].ID
+	}
+	rsp.Nodes = nodes
+	return &rsp, nil
+}
+
 func (rq *registryQuerier) Runtime(ctx context.Context, id common.Namespace, includeSuspended bool) (*registry.Runtime, error) {
 	if includeSuspended {
 		return rq.state.AnyRuntime(ctx, id)
@@ -117,6 +194,50 @@ func (rq *registryQuerier) Runtimes(ctx context.Context, includeSuspended bool)
 	return rq.state.Runtimes(ctx)
 }
 
+func (rq *registryQuerier) ListRuntimes(
+	ctx context.Context,
+	includeSuspended bool,
+	after *common.Namespace,
+	limit uint32,
+	filter *registry.RuntimeFilter,
+) (*registry.ListRuntimesResponse, error) {
+	// Fetch one more runtime than requested to determine whether there are more pages.
+	n := registry.ListLimit(limit)
+	runtimes := make([]*registry.Runtime, 0, n+1)
+	err := rq.state.IterateRuntimes(ctx, includeSuspended, after, func(rt *registry.Runtime) bool {
+		if !filter.Matches(rt) {
+			return true
+		}
+		runtimes = append(runtimes, rt)
+		return len(runtimes) <= n
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var rsp registry.ListRuntimesResponse
+	if len(runtimes) > n {
+		runtimes = runtimes[:n]
+		rsp.Next = &runtimes[n-1].ID
+	}
+	rsp.Runtimes = runtimes
+	return &rsp, nil
+}
+
+func (rq *registryQuerier) RuntimeTEEHistory(ctx context.Context, id common.Namespace) ([]*registry.RuntimeTEEHistoryRecord, error) {
+	records, err := rq.state.RuntimeTEEHistory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		// Distinguish between unknown runtimes and runtimes without a TEE.
+		if _, err = rq.state.AnyRuntime(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	return records, nil
+}
+
 func (rq *registryQuerier) ConsensusParameters(ctx context.Context) (*registry.ConsensusParameters, error) {
 	return rq.state.ConsensusParameters(ctx)
 }
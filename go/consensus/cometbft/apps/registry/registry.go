@@ -58,6 +58,10 @@ func (app *registryApplication) OnRegister(state api.ApplicationState, md api.Me
 	md.Subscribe(roothashApi.RuntimeMessageRegistry, app)
 	md.Subscribe(governanceApi.MessageChangeParameters, app)
 	md.Subscribe(governanceApi.MessageValidateParameterChanges, app)
+	md.Subscribe(governanceApi.MessageFreezeEntity, app)
+	md.Subscribe(governanceApi.MessageThawEntity, app)
+	md.Subscribe(governanceApi.MessageValidateRuntimeUpdate, app)
+	md.Subscribe(governanceApi.MessageUpdateRuntime, app)
 }
 
 func (app *registryApplication) OnCleanup() {
@@ -89,6 +93,19 @@ func (app *registryApplication) ExecuteMessage(ctx *api.Context, kind, msg inter
 		// A change parameters proposal has just been accepted and closed. Validate and apply
 		// changes.
 		return app.changeParameters(ctx, msg, true)
+	case governanceApi.MessageFreezeEntity:
+		// A freeze entity proposal has just been accepted and closed.
+		return app.freezeEntity(ctx, msg)
+	case governanceApi.MessageThawEntity:
+		// A thaw entity proposal has just been accepted and closed.
+		return app.thawEntity(ctx, msg)
+	case governanceApi.MessageValidateRuntimeUpdate:
+		// An update runtime proposal is about to be submitted. Validate changes.
+		return app.updateRuntime(ctx, msg, false)
+	case governanceApi.MessageUpdateRuntime:
+		// An update runtime proposal has just been accepted and closed. Validate and apply
+		// changes.
+		return app.updateRuntime(ctx, msg, true)
 	default:
 		return nil, registry.ErrInvalidArgument
 	}
@@ -177,6 +194,14 @@ func (app *registryApplication) onRegistryEpochChanged(ctx *api.Context, registr
 		return fmt.Errorf("registry: onRegistryEpochChanged: failed to get debonding interval: %w", err)
 	}
 
+	regParams, err := regState.ConsensusParameters(ctx)
+	if err != nil {
+		ctx.Logger().Error("onRegistryEpochChanged: failed to fetch registry consensus parameters",
+			"err", err,
+		)
+		return fmt.Errorf("registry: onRegistryEpochChanged: failed to fetch registry consensus parameters: %w", err)
+	}
+
 	params, err := stakeState.ConsensusParameters(ctx)
 	if err != nil {
 		ctx.Logger().Error("onRegistryEpochChanged: failed to fetch consensus parameters",
@@ -232,6 +257,11 @@ func (app *registryApplication) onRegistryEpochChanged(ctx *api.Context, registr
 			if err = regState.RemoveNode(ctx, node); err != nil {
 				return fmt.Errorf("registry: onRegistryEpochChanged: couldn't remove node: %w", err)
 			}
+			if regParams.EnableNodeByRuntimeIndex {
+				if err = regState.RemoveNodeRuntimes(ctx, node); err != nil {
+					return fmt.Errorf("registry: onRegistryEpochChanged: couldn't remove node runtimes: %w", err)
+				}
+			}
 
 			// Remove the stake claim for the given node.
 			if !params.DebugBypassStake {
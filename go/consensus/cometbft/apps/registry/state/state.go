@@ -1,6 +1,7 @@
 package state
 
 import (
+	"bytes"
 	"context"
 	"errors"
 
@@ -76,6 +77,21 @@ var (
 	//
 	// Value is empty.
 	runtimeByEntityKeyFmt = consensus.KeyFormat.New(0x19, keyformat.H(&signature.PublicKey{}), keyformat.H(&common.Namespace{}))
+	// runtimeTEEHistoryKeyFmt is the key format used for the runtime TEE configuration history.
+	//
+	// Key format is: 0x1a H(<runtime-id>) <height>
+	// Value is CBOR-serialized registry.RuntimeTEEHistoryRecord.
+	runtimeTEEHistoryKeyFmt = consensus.KeyFormat.New(0x1a, keyformat.H(&common.Namespace{}), uint64(0))
+	// nodeByRuntimeKeyFmt is the key format used for the node by runtime index.
+	//
+	// Key format is: 0x1b H(<runtime-id>) H(<node-id>)
+	// Value is empty.
+	nodeByRuntimeKeyFmt = consensus.KeyFormat.New(0x1b, keyformat.H(&common.Namespace{}), keyformat.H(&signature.PublicKey{}))
+	// frozenEntityKeyFmt is the key format used for frozen entities.
+	//
+	// Key format is: 0x1c <entity-id>
+	// Value is empty.
+	frozenEntityKeyFmt = consensus.KeyFormat.New(0x1c, &signature.PublicKey{})
 )
 
 // ImmutableState is the immutable registry state wrapper.
@@ -137,6 +153,39 @@ func (s *ImmutableState) Entities(ctx context.Context) ([]*entity.Entity, error)
 	return entities, nil
 }
 
+// IsEntityFrozen returns true iff the given entity is frozen.
+func (s *ImmutableState) IsEntityFrozen(ctx context.Context, id signature.PublicKey) (bool, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	key := frozenEntityKeyFmt.Encode(&id)
+	it.Seek(key)
+	if it.Err() != nil {
+		return false, abciAPI.UnavailableStateError(it.Err())
+	}
+	return it.Valid() && bytes.Equal(it.Key(), key), nil
+}
+
+// FrozenEntities returns a list of all frozen entities.
+func (s *ImmutableState) FrozenEntities(ctx context.Context) ([]signature.PublicKey, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	var ids []signature.PublicKey
+	for it.Seek(frozenEntityKeyFmt.Encode()); it.Valid(); it.Next() {
+		var id signature.PublicKey
+		if !frozenEntityKeyFmt.Decode(it.Key(), &id) {
+			break
+		}
+
+		ids = append(ids, id)
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+	return ids, nil
+}
+
 // SignedEntities returns a list of all registered entities (signed).
 func (s *ImmutableState) SignedEntities(ctx context.Context) ([]*entity.SignedEntity, error) {
 	it := s.is.NewIterator(ctx)
@@ -244,6 +293,42 @@ func (s *ImmutableState) Nodes(ctx context.Context) ([]*node.Node, error) {
 	return nodes, nil
 }
 
+// IterateNodes iterates over registered nodes in storage order.
+//
+// Iteration starts after the node with the given ID (if any) and stops early when the callback
+// returns false.
+func (s *ImmutableState) IterateNodes(ctx context.Context, after *signature.PublicKey, cb func(*node.Node) bool) error {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	start := signedNodeKeyFmt.Encode()
+	if after != nil {
+		start = signedNodeKeyFmt.Encode(after)
+	}
+	for it.Seek(start); it.Valid(); it.Next() {
+		if !signedNodeKeyFmt.Decode(it.Key()) {
+			break
+		}
+		if after != nil && bytes.Equal(it.Key(), start) {
+			continue
+		}
+
+		var signedNode node.MultiSignedNode
+		if err := cbor.Unmarshal(it.Value(), &signedNode); err != nil {
+			return abciAPI.UnavailableStateError(err)
+		}
+		var node node.Node
+		if err := cbor.Unmarshal(signedNode.Blob, &node); err != nil {
+			return abciAPI.UnavailableStateError(err)
+		}
+
+		if !cb(&node) {
+			return nil
+		}
+	}
+	return abciAPI.UnavailableStateError(it.Err())
+}
+
 // SignedNodes returns a list of all registered nodes (in signed form).
 func (s *ImmutableState) SignedNodes(ctx context.Context) ([]*node.MultiSignedNode, error) {
 	it := s.is.NewIterator(ctx)
@@ -310,23 +395,31 @@ func (s *ImmutableState) AnyRuntime(ctx context.Context, id common.Namespace) (r
 func (s *ImmutableState) iterateRuntimes(
 	ctx context.Context,
 	keyFmt *keyformat.KeyFormat,
-	cb func(*registry.Runtime) error,
+	after *common.Namespace,
+	cb func(*registry.Runtime) bool,
 ) error {
 	it := s.is.NewIterator(ctx)
 	defer it.Close()
 
-	for it.Seek(keyFmt.Encode()); it.Valid(); it.Next() {
+	start := keyFmt.Encode()
+	if after != nil {
+		start = keyFmt.Encode(after)
+	}
+	for it.Seek(start); it.Valid(); it.Next() {
 		if !keyFmt.Decode(it.Key()) {
 			break
 		}
+		if after != nil && bytes.Equal(it.Key(), start) {
+			continue
+		}
 
 		var rt registry.Runtime
 		if err := cbor.Unmarshal(it.Value(), &rt); err != nil {
 			return abciAPI.UnavailableStateError(err)
 		}
 
-		if err := cb(&rt); err != nil {
-			return err
+		if !cb(&rt) {
+			return nil
 		}
 	}
 	return abciAPI.UnavailableStateError(it.Err())
@@ -337,9 +430,9 @@ func (s *ImmutableState) iterateRuntimes(
 // This excludes any suspended runtimes.
 func (s *ImmutableState) Runtimes(ctx context.Context) ([]*registry.Runtime, error) {
 	var runtimes []*registry.Runtime
-	err := s.iterateRuntimes(ctx, runtimeKeyFmt, func(rt *registry.Runtime) error {
+	err := s.iterateRuntimes(ctx, runtimeKeyFmt, nil, func(rt *registry.Runtime) bool {
 		runtimes = append(runtimes, rt)
-		return nil
+		return true
 	})
 	if err != nil {
 		return nil, err
@@ -350,9 +443,9 @@ func (s *ImmutableState) Runtimes(ctx context.Context) ([]*registry.Runtime, err
 // SuspendedRuntimes returns a list of all suspended runtimes.
 func (s *ImmutableState) SuspendedRuntimes(ctx context.Context) ([]*registry.Runtime, error) {
 	var runtimes []*registry.Runtime
-	err := s.iterateRuntimes(ctx, suspendedRuntimeKeyFmt, func(rt *registry.Runtime) error {
+	err := s.iterateRuntimes(ctx, suspendedRuntimeKeyFmt, nil, func(rt *registry.Runtime) bool {
 		runtimes = append(runtimes, rt)
-		return nil
+		return true
 	})
 	if err != nil {
 		return nil, err
@@ -363,19 +456,87 @@ func (s *ImmutableState) SuspendedRuntimes(ctx context.Context) ([]*registry.Run
 // AllRuntimes returns a list of all registered runtimes (suspended included).
 func (s *ImmutableState) AllRuntimes(ctx context.Context) ([]*registry.Runtime, error) {
 	var runtimes []*registry.Runtime
-	unpackFn := func(rt *registry.Runtime) error {
+	unpackFn := func(rt *registry.Runtime) bool {
 		runtimes = append(runtimes, rt)
-		return nil
+		return true
 	}
-	if err := s.iterateRuntimes(ctx, runtimeKeyFmt, unpackFn); err != nil {
+	if err := s.iterateRuntimes(ctx, runtimeKeyFmt, nil, unpackFn); err != nil {
 		return nil, err
 	}
-	if err := s.iterateRuntimes(ctx, suspendedRuntimeKeyFmt, unpackFn); err != nil {
+	if err := s.iterateRuntimes(ctx, suspendedRuntimeKeyFmt, nil, unpackFn); err != nil {
 		return nil, err
 	}
 	return runtimes, nil
 }
 
+// IterateRuntimes iterates over registered runtimes in storage order, optionally including
+// suspended runtimes which are iterated after all other runtimes.
+//
+// Iteration starts after the runtime with the given ID (if any) and stops early when the
+// callback returns false.
+func (s *ImmutableState) IterateRuntimes(
+	ctx context.Context,
+	includeSuspended bool,
+	after *common.Namespace,
+	cb func(*registry.Runtime) bool,
+) error {
+	var done bool
+	wrappedCb := func(rt *registry.Runtime) bool {
+		done = !cb(rt)
+		return !done
+	}
+
+	if includeSuspended && after != nil {
+		// Resume from the suspended runtimes in case the cursor is a suspended runtime.
+		_, err := s.SuspendedRuntime(ctx, *after)
+		switch err {
+		case nil:
+			return s.iterateRuntimes(ctx, suspendedRuntimeKeyFmt, after, wrappedCb)
+		case registry.ErrNoSuchRuntime:
+		default:
+			return err
+		}
+	}
+
+	if err := s.iterateRuntimes(ctx, runtimeKeyFmt, after, wrappedCb); err != nil {
+		return err
+	}
+	if !includeSuspended || done {
+		return nil
+	}
+	return s.iterateRuntimes(ctx, suspendedRuntimeKeyFmt, nil, wrappedCb)
+}
+
+// RuntimeTEEHistory returns the TEE configuration history of the given runtime, ordered by height.
+func (s *ImmutableState) RuntimeTEEHistory(ctx context.Context, id common.Namespace) ([]*registry.RuntimeTEEHistoryRecord, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	// We need to pre-hash the runtime ID, so we can compare it below.
+	hID := keyformat.PreHashed(id.Hash())
+
+	var records []*registry.RuntimeTEEHistoryRecord
+	for it.Seek(runtimeTEEHistoryKeyFmt.Encode(&id)); it.Valid(); it.Next() {
+		var (
+			rtID   keyformat.PreHashed
+			height uint64
+		)
+		if !runtimeTEEHistoryKeyFmt.Decode(it.Key(), &rtID, &height) || rtID != hID {
+			break
+		}
+
+		var record registry.RuntimeTEEHistoryRecord
+		if err := cbor.Unmarshal(it.Value(), &record); err != nil {
+			return nil, abciAPI.UnavailableStateError(err)
+		}
+		records = append(records, &record)
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+	return records, nil
+}
+
 // NodeStatus returns a specific node status.
 func (s *ImmutableState) NodeStatus(ctx context.Context, id signature.PublicKey) (*registry.NodeStatus, error) {
 	value, err := s.is.Get(ctx, nodeStatusKeyFmt.Encode(&id))
@@ -433,6 +594,49 @@ func (s *ImmutableState) GetEntityNodes(ctx context.Context, id signature.Public
 	return nodes, nil
 }
 
+// NodesForRuntime returns nodes registered for the given runtime.
+// Note that this returns both active and expired nodes.
+func (s *ImmutableState) NodesForRuntime(ctx context.Context, id common.Namespace) ([]*node.Node, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	hID := keyformat.PreHashed(id.Hash())
+
+	var nodes []*node.Node
+	for it.Seek(nodeByRuntimeKeyFmt.Encode(&id)); it.Valid(); it.Next() {
+		var hRuntimeID keyformat.PreHashed
+		var hNodeID keyformat.PreHashed
+
+		if !nodeByRuntimeKeyFmt.Decode(it.Key(), &hRuntimeID, &hNodeID) || !hRuntimeID.Equal(&hID) {
+			break
+		}
+
+		rawSignedNode, err := s.is.Get(ctx, signedNodeKeyFmt.Encode(&hNodeID))
+		if err != nil {
+			return nil, abciAPI.UnavailableStateError(err)
+		}
+		if rawSignedNode == nil {
+			return nil, abciAPI.UnavailableStateError(registry.ErrNoSuchNode)
+		}
+		var signedNode node.MultiSignedNode
+		if err = cbor.Unmarshal(rawSignedNode, &signedNode); err != nil {
+			return nil, abciAPI.UnavailableStateError(err)
+		}
+		var node node.Node
+		if err = cbor.Unmarshal(signedNode.Blob, &node); err != nil {
+			return nil, abciAPI.UnavailableStateError(err)
+		}
+
+		nodes = append(nodes, &node)
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+
+	registry.SortNodeList(nodes)
+	return nodes, nil
+}
+
 // HasEntityNodes checks whether an entity has any registered nodes.
 func (s *ImmutableState) HasEntityNodes(ctx context.Context, id signature.PublicKey) (bool, error) {
 	it := s.is.NewIterator(ctx)
@@ -541,6 +745,18 @@ func (s *MutableState) RemoveEntity(ctx context.Context, id signature.PublicKey)
 	return nil, registry.ErrNoSuchEntity
 }
 
+// FreezeEntity marks the given entity as frozen.
+func (s *MutableState) FreezeEntity(ctx context.Context, id signature.PublicKey) error {
+	err := s.ms.Insert(ctx, frozenEntityKeyFmt.Encode(&id), []byte(""))
+	return abciAPI.UnavailableStateError(err)
+}
+
+// ThawEntity removes the frozen mark from the given entity.
+func (s *MutableState) ThawEntity(ctx context.Context, id signature.PublicKey) error {
+	err := s.ms.Remove(ctx, frozenEntityKeyFmt.Encode(&id))
+	return abciAPI.UnavailableStateError(err)
+}
+
 // SetNode sets a signed node descriptor for a registered node.
 func (s *MutableState) SetNode(ctx context.Context, existingNode, node *node.Node, signedNode *node.MultiSignedNode) error { //nolint: gocyclo
 	rawNodeID, err := node.ID.MarshalBinary()
@@ -612,6 +828,37 @@ func (s *MutableState) SetNode(ctx context.Context, existingNode, node *node.Nod
 	return nil
 }
 
+// SetNodeRuntimes updates the node by runtime index for the given node.
+func (s *MutableState) SetNodeRuntimes(ctx context.Context, existingNode, node *node.Node) error {
+	if existingNode != nil {
+		// Remove runtime mappings for runtimes that are no longer supported.
+		for _, rt := range existingNode.Runtimes {
+			if node.HasRuntime(rt.ID) {
+				continue
+			}
+			if err := s.ms.Remove(ctx, nodeByRuntimeKeyFmt.Encode(&rt.ID, &node.ID)); err != nil {
+				return abciAPI.UnavailableStateError(err)
+			}
+		}
+	}
+	for _, rt := range node.Runtimes {
+		if err := s.ms.Insert(ctx, nodeByRuntimeKeyFmt.Encode(&rt.ID, &node.ID), []byte("")); err != nil {
+			return abciAPI.UnavailableStateError(err)
+		}
+	}
+	return nil
+}
+
+// RemoveNodeRuntimes removes the given node from the node by runtime index.
+func (s *MutableState) RemoveNodeRuntimes(ctx context.Context, node *node.Node) error {
+	for _, rt := range node.Runtimes {
+		if err := s.ms.Remove(ctx, nodeByRuntimeKeyFmt.Encode(&rt.ID, &node.ID)); err != nil {
+			return abciAPI.UnavailableStateError(err)
+		}
+	}
+	return nil
+}
+
 // RemoveNode removes a registered node.
 func (s *MutableState) RemoveNode(ctx context.Context, node *node.Node) error {
 	if err := s.ms.Remove(ctx, signedNodeKeyFmt.Encode(&node.ID)); err != nil {
@@ -660,6 +907,28 @@ func (s *MutableState) SetRuntime(ctx context.Context, rt *registry.Runtime, sus
 	return abciAPI.UnavailableStateError(err)
 }
 
+// AppendRuntimeTEEHistory records the TEE configuration of the given runtime as accepted at the
+// given height in case it differs from the previously recorded one.
+//
+// Runtimes which do not use a TEE have no TEE configuration history.
+func (s *MutableState) AppendRuntimeTEEHistory(ctx context.Context, rt *registry.Runtime, height int64) error {
+	if rt.TEEHardware == node.TEEHardwareInvalid {
+		return nil
+	}
+
+	records, err := s.RuntimeTEEHistory(ctx, rt.ID)
+	if err != nil {
+		return err
+	}
+	record := registry.NewRuntimeTEEHistoryRecord(rt, height)
+	if n := len(records); n > 0 && records[n-1].EqualTEE(record) {
+		return nil
+	}
+
+	err = s.ms.Insert(ctx, runtimeTEEHistoryKeyFmt.Encode(&rt.ID, uint64(height)), cbor.Marshal(record))
+	return abciAPI.UnavailableStateError(err)
+}
+
 // SuspendRuntime marks a runtime as suspended.
 func (s *MutableState) SuspendRuntime(ctx *abciAPI.Context, id common.Namespace) error {
 	data, err := s.ms.RemoveExisting(ctx, runtimeKeyFmt.Encode(&id))
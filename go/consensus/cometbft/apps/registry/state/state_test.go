@@ -1,10 +1,12 @@
 package state
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
@@ -158,3 +160,210 @@ func TestNodeUpdate(t *testing.T) {
 	require.Error(err, "TLS mapping should be gone")
 	require.Equal(registry.ErrNoSuchNode, err, "TLS mapping should be gone")
 }
+
+func TestRuntimeTEEHistory(t *testing.T) {
+	require := require.New(t)
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextBeginBlock)
+	defer ctx.Close()
+
+	s := NewMutableState(ctx.State())
+
+	var rtID, otherRtID common.Namespace
+	require.NoError(rtID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000"))
+	require.NoError(otherRtID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000001"))
+
+	newRuntime := func(id common.Namespace, tee []byte) *registry.Runtime {
+		return &registry.Runtime{
+			ID:          id,
+			TEEHardware: node.TEEHardwareIntelSGX,
+			Deployments: []*registry.VersionInfo{
+				{ValidFrom: 0, TEE: tee},
+			},
+		}
+	}
+
+	records, err := s.RuntimeTEEHistory(ctx, rtID)
+	require.NoError(err, "RuntimeTEEHistory")
+	require.Empty(records, "history should be empty")
+
+	// Runtimes without a TEE should not have any history.
+	nonTEE := newRuntime(rtID, nil)
+	nonTEE.TEEHardware = node.TEEHardwareInvalid
+	require.NoError(s.AppendRuntimeTEEHistory(ctx, nonTEE, 1), "AppendRuntimeTEEHistory")
+	records, err = s.RuntimeTEEHistory(ctx, rtID)
+	require.NoError(err, "RuntimeTEEHistory")
+	require.Empty(records, "history should be empty for runtimes without a TEE")
+
+	require.NoError(s.AppendRuntimeTEEHistory(ctx, newRuntime(rtID, []byte("tee1")), 10), "AppendRuntimeTEEHistory")
+	// Unchanged TEE configuration should not be recorded again.
+	require.NoError(s.AppendRuntimeTEEHistory(ctx, newRuntime(rtID, []byte("tee1")), 20), "AppendRuntimeTEEHistory")
+	require.NoError(s.AppendRuntimeTEEHistory(ctx, newRuntime(rtID, []byte("tee2")), 30), "AppendRuntimeTEEHistory")
+	require.NoError(s.AppendRuntimeTEEHistory(ctx, newRuntime(otherRtID, []byte("tee3")), 40), "AppendRuntimeTEEHistory")
+
+	records, err = s.RuntimeTEEHistory(ctx, rtID)
+	require.NoError(err, "RuntimeTEEHistory")
+	require.Len(records, 2, "history should contain all distinct TEE configurations")
+	require.EqualValues(10, records[0].Height)
+	require.EqualValues([]byte("tee1"), records[0].Deployments[0].TEE)
+	require.EqualValues(30, records[1].Height)
+	require.EqualValues([]byte("tee2"), records[1].Deployments[0].TEE)
+
+	records, err = s.RuntimeTEEHistory(ctx, otherRtID)
+	require.NoError(err, "RuntimeTEEHistory")
+	require.Len(records, 1, "history should be tracked per runtime")
+	require.EqualValues(40, records[0].Height)
+}
+
+func TestIterateNodes(t *testing.T) {
+	require := require.New(t)
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextBeginBlock)
+	defer ctx.Close()
+
+	s := NewMutableState(ctx.State())
+
+	for i := 0; i < 5; i++ {
+		signer := memorySigner.NewTestSigner(fmt.Sprintf("consensus/cometbft/apps/registry/state: iterate node signer %d", i))
+		n := node.Node{
+			Versioned: cbor.NewVersioned(node.LatestNodeDescriptorVersion),
+			ID:        signer.Public(),
+			EntityID:  entitySigner.Public(),
+		}
+		err := s.SetNode(ctx, nil, &n, mustMultiSignNode(t, &n))
+		require.NoError(err, "SetNode")
+	}
+
+	var all []*node.Node
+	err := s.IterateNodes(ctx, nil, func(n *node.Node) bool {
+		all = append(all, n)
+		return true
+	})
+	require.NoError(err, "IterateNodes")
+	require.Len(all, 5, "all nodes should be iterated")
+
+	// Iteration should stop early when requested.
+	var count int
+	err = s.IterateNodes(ctx, nil, func(*node.Node) bool {
+		count++
+		return count < 2
+	})
+	require.NoError(err, "IterateNodes")
+	require.Equal(2, count, "iteration should stop early")
+
+	// Iteration should resume after the given node.
+	var rest []*node.Node
+	err = s.IterateNodes(ctx, &all[1].ID, func(n *node.Node) bool {
+		rest = append(rest, n)
+		return true
+	})
+	require.NoError(err, "IterateNodes")
+	require.EqualValues(all[2:], rest, "iteration should resume after the given node")
+}
+
+func TestIterateRuntimes(t *testing.T) {
+	require := require.New(t)
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextBeginBlock)
+	defer ctx.Close()
+
+	s := NewMutableState(ctx.State())
+
+	for i := 0; i < 6; i++ {
+		var rt registry.Runtime
+		require.NoError(rt.ID.UnmarshalHex(fmt.Sprintf("80000000000000000000000000000000000000000000000000000000000000%02x", i)))
+		err := s.SetRuntime(ctx, &rt, i%2 == 1)
+		require.NoError(err, "SetRuntime")
+	}
+
+	iterate := func(includeSuspended bool, after *common.Namespace) []*registry.Runtime {
+		rts := []*registry.Runtime{}
+		err := s.IterateRuntimes(ctx, includeSuspended, after, func(rt *registry.Runtime) bool {
+			rts = append(rts, rt)
+			return true
+		})
+		require.NoError(err, "IterateRuntimes")
+		return rts
+	}
+
+	active := iterate(false, nil)
+	require.Len(active, 3, "only active runtimes should be iterated")
+	all := iterate(true, nil)
+	require.Len(all, 6, "suspended runtimes should be iterated when requested")
+	require.EqualValues(active, all[:3], "suspended runtimes should follow active runtimes")
+
+	// Iteration should resume after the given runtime, both active and suspended.
+	for i := range all {
+		require.EqualValues(all[i+1:], iterate(true, &all[i].ID), "iteration should resume after the given runtime")
+	}
+	require.Empty(iterate(false, &active[2].ID), "iteration should end after the last active runtime")
+}
+
+func TestNodesForRuntime(t *testing.T) {
+	require := require.New(t)
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextBeginBlock)
+	defer ctx.Close()
+
+	s := NewMutableState(ctx.State())
+
+	var rtID1, rtID2 common.Namespace
+	require.NoError(rtID1.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000"))
+	require.NoError(rtID2.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000001"))
+
+	n := node.Node{
+		Versioned: cbor.NewVersioned(node.LatestNodeDescriptorVersion),
+		ID:        nodeSigner.Public(),
+		EntityID:  entitySigner.Public(),
+		Runtimes: []*node.Runtime{
+			{ID: rtID1},
+			{ID: rtID2},
+		},
+	}
+	err := s.SetNode(ctx, nil, &n, mustMultiSignNode(t, &n))
+	require.NoError(err, "SetNode")
+
+	// Nodes should only be indexed once requested.
+	nodes, err := s.NodesForRuntime(ctx, rtID1)
+	require.NoError(err, "NodesForRuntime")
+	require.Empty(nodes, "node should not be indexed before requested")
+
+	err = s.SetNodeRuntimes(ctx, nil, &n)
+	require.NoError(err, "SetNodeRuntimes")
+
+	for _, id := range []common.Namespace{rtID1, rtID2} {
+		nodes, err := s.NodesForRuntime(ctx, id)
+		require.NoError(err, "NodesForRuntime")
+		require.Len(nodes, 1, "node should be indexed for all its runtimes")
+		require.EqualValues(n, *nodes[0], "returned node should be correct")
+	}
+
+	// Dropping a runtime should update the index.
+	newNode := n
+	newNode.Runtimes = []*node.Runtime{{ID: rtID2}}
+	err = s.SetNode(ctx, &n, &newNode, mustMultiSignNode(t, &newNode))
+	require.NoError(err, "SetNode")
+	err = s.SetNodeRuntimes(ctx, &n, &newNode)
+	require.NoError(err, "SetNodeRuntimes")
+
+	nodes, err = s.NodesForRuntime(ctx, rtID1)
+	require.NoError(err, "NodesForRuntime")
+	require.Empty(nodes, "node should no longer be indexed for a dropped runtime")
+	nodes, err = s.NodesForRuntime(ctx, rtID2)
+	require.NoError(err, "NodesForRuntime")
+	require.Len(nodes, 1, "node should still be indexed for remaining runtimes")
+
+	// Removing the node should update the index.
+	err = s.RemoveNode(ctx, &newNode)
+	require.NoError(err, "RemoveNode")
+	err = s.RemoveNodeRuntimes(ctx, &newNode)
+	require.NoError(err, "RemoveNodeRuntimes")
+
+	nodes, err = s.NodesForRuntime(ctx, rtID2)
+	require.NoError(err, "NodesForRuntime")
+	require.Empty(nodes, "removed node should no longer be indexed")
+}
@@ -5,6 +5,7 @@ import (
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/entity"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
@@ -209,6 +210,9 @@ func (app *registryApplication) registerNode( // nolint: gocyclo
 		)
 		return err
 	}
+	if err = checkEntityNotFrozen(ctx, state, untrustedEntity.ID); err != nil {
+		return err
+	}
 
 	params, err := state.ConsensusParameters(ctx)
 	if err != nil {
@@ -371,6 +375,15 @@ func (app *registryApplication) registerNode( // nolint: gocyclo
 		)
 		return fmt.Errorf("failed to set node: %w", err)
 	}
+	if params.EnableNodeByRuntimeIndex {
+		if err = state.SetNodeRuntimes(ctx, existingNode, newNode); err != nil {
+			ctx.Logger().Error("RegisterNode: failed to index node runtimes",
+				"err", err,
+				"node", newNode,
+			)
+			return fmt.Errorf("failed to index node runtimes: %w", err)
+		}
+	}
 
 	// Query the current node status if it exists.
 	var status *registry.NodeStatus
@@ -625,6 +638,12 @@ func (app *registryApplication) registerRuntime( // nolint: gocyclo
 	default:
 		return nil, fmt.Errorf("failed to fetch runtime: %w", err)
 	}
+	// Frozen entities can neither register new runtimes nor update runtimes they govern.
+	if existingRt == nil || existingRt.GovernanceModel == registry.GovernanceEntity {
+		if err = checkEntityNotFrozen(ctx, state, rt.EntityID); err != nil {
+			return nil, err
+		}
+	}
 	// Invoke the right verification logic.
 	switch {
 	case existingRt != nil:
@@ -747,6 +766,17 @@ func (app *registryApplication) registerRuntime( // nolint: gocyclo
 		return nil, fmt.Errorf("failed to set runtime: %w", err)
 	}
 
+	// Record the accepted TEE configuration so it can be audited later.
+	if params.EnableRuntimeTEEHistory {
+		if err = state.AppendRuntimeTEEHistory(ctx, rt, ctx.BlockHeight()+1); err != nil {
+			ctx.Logger().Error("RegisterRuntime: failed to record runtime TEE history",
+				"err", err,
+				"runtime", rt.ID,
+			)
+			return nil, fmt.Errorf("failed to record runtime TEE history: %w", err)
+		}
+	}
+
 	if !suspended {
 		ctx.Logger().Debug("RegisterRuntime: registered",
 			"runtime", rt,
@@ -788,3 +818,17 @@ func (app *registryApplication) proveFreshness(
 
 	return nil
 }
+
+func checkEntityNotFrozen(ctx *api.Context, state *registryState.MutableState, id signature.PublicKey) error {
+	frozen, err := state.IsEntityFrozen(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to check entity status: %w", err)
+	}
+	if frozen {
+		ctx.Logger().Debug("rejecting registration of a frozen entity",
+			"entity_id", id,
+		)
+		return registry.ErrEntityFrozen
+	}
+	return nil
+}
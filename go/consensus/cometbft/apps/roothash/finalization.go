@@ -99,6 +99,12 @@ func (app *rootHashApplication) tryFinalizeRoundInsideTx( //nolint: gocyclo
 				TypedAttribute(&roothash.RuntimeIDAttribute{ID: rtState.Runtime.ID}),
 		)
 
+		if err = updateRuntimeStatistics(ctx, rtState.Runtime.ID, func(stats *roothash.RuntimeStatistics) {
+			stats.Discrepancies++
+		}); err != nil {
+			return err
+		}
+
 		// Re-arm round timeout. Give backup workers enough time to submit commitments.
 		prevTimeout := rtState.NextTimeout
 		rtState.NextTimeout = ctx.BlockHeight() + 1 + (rtState.Runtime.Executor.RoundTimeout*backupWorkerTimeoutFactorNumerator)/backupWorkerTimeoutFactorDenominator // Current height is ctx.BlockHeight() + 1
@@ -188,6 +194,7 @@ func (app *rootHashApplication) tryFinalizeRoundInsideTx( //nolint: gocyclo
 	var (
 		goodComputeEntities []signature.PublicKey
 		badComputeEntities  []signature.PublicKey
+		badComputeNodes     []signature.PublicKey
 	)
 	seen := make(map[signature.PublicKey]struct{})
 	regState := registryState.NewMutableState(ctx.State())
@@ -234,6 +241,7 @@ func (app *rootHashApplication) tryFinalizeRoundInsideTx( //nolint: gocyclo
 			livenessStats.LiveRounds[i]++
 		case false:
 			badComputeEntities = append(badComputeEntities, node.EntityID)
+			badComputeNodes = append(badComputeNodes, n.PublicKey)
 		}
 	}
 
@@ -275,6 +283,19 @@ func (app *rootHashApplication) tryFinalizeRoundInsideTx( //nolint: gocyclo
 		return fmt.Errorf("failed to set last round results: %w", err)
 	}
 
+	// Update runtime statistics.
+	latency := uint64(ctx.BlockHeight() + 1 - rtState.LastBlockHeight) // Current height is ctx.BlockHeight() + 1
+	if err = updateRuntimeStatistics(ctx, rtState.Runtime.ID, func(stats *roothash.RuntimeStatistics) {
+		stats.FinalizedRounds++
+		stats.TotalCommitLatency += latency
+		if len(badComputeNodes) > 0 {
+			stats.LastFaultyRound = round
+			stats.LastFaultyNodes = badComputeNodes
+		}
+	}); err != nil {
+		return err
+	}
+
 	// Generate the final block.
 	return app.finalizeBlock(ctx, rtState, block.Normal, &sc.Commitment.Header.Header)
 }
@@ -353,6 +374,12 @@ func (app *rootHashApplication) failRound(
 
 	rtState.LivenessStatistics.MissedProposals[firstSchedulerIdx]++
 
+	if err := updateRuntimeStatistics(ctx, rtState.Runtime.ID, func(stats *roothash.RuntimeStatistics) {
+		stats.FailedRounds++
+	}); err != nil {
+		return err
+	}
+
 	if err := app.finalizeBlock(ctx, rtState, block.RoundFailed, nil); err != nil {
 		return fmt.Errorf("failed to emit empty block: %w", err)
 	}
@@ -19,6 +19,7 @@ type Query interface {
 	LastRoundResults(context.Context, common.Namespace) (*roothash.RoundResults, error)
 	RoundRoots(context.Context, common.Namespace, uint64) (*roothash.RoundRoots, error)
 	PastRoundRoots(context.Context, common.Namespace) (map[uint64]roothash.RoundRoots, error)
+	RuntimeStatistics(context.Context, common.Namespace) (*roothash.RuntimeStatistics, error)
 	IncomingMessageQueueMeta(context.Context, common.Namespace) (*message.IncomingMessageQueueMeta, error)
 	IncomingMessageQueue(ctx context.Context, id common.Namespace, offset uint64, limit uint32) ([]*message.IncomingMessage, error)
 	Genesis(context.Context) (*roothash.Genesis, error)
@@ -75,6 +76,10 @@ func (rq *rootHashQuerier) PastRoundRoots(ctx context.Context, id common.Namespa
 	return rq.state.PastRoundRoots(ctx, id)
 }
 
+func (rq *rootHashQuerier) RuntimeStatistics(ctx context.Context, id common.Namespace) (*roothash.RuntimeStatistics, error) {
+	return rq.state.RuntimeStatistics(ctx, id)
+}
+
 func (rq *rootHashQuerier) IncomingMessageQueueMeta(ctx context.Context, id common.Namespace) (*message.IncomingMessageQueueMeta, error) {
 	return rq.state.IncomingMessageQueueMeta(ctx, id)
 }
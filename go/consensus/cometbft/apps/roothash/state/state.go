@@ -59,6 +59,10 @@ var (
 	// The maximum number of rounds that this map stores is defined by the
 	// roothash consensus parameters as MaxPastRootsStored.
 	pastRootsKeyFmt = consensus.KeyFormat.New(0x2a, keyformat.H(&common.Namespace{}), uint64(0))
+	// runtimeStatisticsKeyFmt is the key format used for per-runtime execution statistics.
+	//
+	// Value is CBOR-serialized roothash.RuntimeStatistics.
+	runtimeStatisticsKeyFmt = consensus.KeyFormat.New(0x2b, keyformat.H(&common.Namespace{}))
 )
 
 // ImmutableState is the immutable roothash state wrapper.
@@ -154,6 +158,23 @@ func (s *ImmutableState) LastRoundResults(ctx context.Context, id common.Namespa
 	return &results, nil
 }
 
+// RuntimeStatistics returns the execution statistics for a specific runtime.
+func (s *ImmutableState) RuntimeStatistics(ctx context.Context, id common.Namespace) (*roothash.RuntimeStatistics, error) {
+	raw, err := s.is.Get(ctx, runtimeStatisticsKeyFmt.Encode(&id))
+	if err != nil {
+		return nil, api.UnavailableStateError(err)
+	}
+	if raw == nil {
+		return &roothash.RuntimeStatistics{}, nil
+	}
+
+	var stats roothash.RuntimeStatistics
+	if err = cbor.Unmarshal(raw, &stats); err != nil {
+		return nil, api.UnavailableStateError(err)
+	}
+	return &stats, nil
+}
+
 func (s *ImmutableState) getRoot(ctx context.Context, id common.Namespace, kf *keyformat.KeyFormat) (hash.Hash, error) {
 	raw, err := s.is.Get(ctx, kf.Encode(&id))
 	if err != nil {
@@ -496,6 +517,12 @@ func (s *MutableState) SetLastRoundResults(ctx context.Context, runtimeID common
 	return api.UnavailableStateError(err)
 }
 
+// SetRuntimeStatistics sets a runtime's execution statistics.
+func (s *MutableState) SetRuntimeStatistics(ctx context.Context, runtimeID common.Namespace, stats *roothash.RuntimeStatistics) error {
+	err := s.ms.Insert(ctx, runtimeStatisticsKeyFmt.Encode(&runtimeID), cbor.Marshal(stats))
+	return api.UnavailableStateError(err)
+}
+
 // SetConsensusParameters sets roothash consensus parameters.
 //
 // NOTE: This method must only be called from InitChain/EndBlock contexts.
@@ -0,0 +1,39 @@
+package roothash
+
+import (
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	tmapi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	roothashState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/roothash/state"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+)
+
+// updateRuntimeStatistics updates the execution statistics of the given runtime in case runtime
+// statistics aggregation is enabled.
+func updateRuntimeStatistics(
+	ctx *tmapi.Context,
+	runtimeID common.Namespace,
+	update func(*roothash.RuntimeStatistics),
+) error {
+	state := roothashState.NewMutableState(ctx.State())
+
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get consensus parameters: %w", err)
+	}
+	if !params.EnableRuntimeStatistics {
+		return nil
+	}
+
+	stats, err := state.RuntimeStatistics(ctx, runtimeID)
+	if err != nil {
+		return fmt.Errorf("failed to get runtime statistics: %w", err)
+	}
+	update(stats)
+
+	if err = state.SetRuntimeStatistics(ctx, runtimeID, stats); err != nil {
+		return fmt.Errorf("failed to set runtime statistics: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package roothash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	roothashState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/roothash/state"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+)
+
+func TestUpdateRuntimeStatistics(t *testing.T) {
+	require := require.New(t)
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	var runtimeID common.Namespace
+	require.NoError(runtimeID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000"))
+
+	state := roothashState.NewMutableState(ctx.State())
+	params := &roothash.ConsensusParameters{}
+	err := state.SetConsensusParameters(ctx, params)
+	require.NoError(err, "SetConsensusParameters")
+
+	finalize := func(stats *roothash.RuntimeStatistics) {
+		stats.FinalizedRounds++
+		stats.TotalCommitLatency += 3
+	}
+
+	// Statistics should not be aggregated when disabled.
+	err = updateRuntimeStatistics(ctx, runtimeID, finalize)
+	require.NoError(err, "updateRuntimeStatistics")
+	stats, err := state.RuntimeStatistics(ctx, runtimeID)
+	require.NoError(err, "RuntimeStatistics")
+	require.Equal(&roothash.RuntimeStatistics{}, stats, "statistics should not be aggregated when disabled")
+
+	// Statistics should be aggregated when enabled.
+	params.EnableRuntimeStatistics = true
+	err = state.SetConsensusParameters(ctx, params)
+	require.NoError(err, "SetConsensusParameters")
+
+	for i := 0; i < 2; i++ {
+		err = updateRuntimeStatistics(ctx, runtimeID, finalize)
+		require.NoError(err, "updateRuntimeStatistics")
+	}
+	err = updateRuntimeStatistics(ctx, runtimeID, func(stats *roothash.RuntimeStatistics) {
+		stats.Discrepancies++
+	})
+	require.NoError(err, "updateRuntimeStatistics")
+
+	stats, err = state.RuntimeStatistics(ctx, runtimeID)
+	require.NoError(err, "RuntimeStatistics")
+	require.EqualValues(2, stats.FinalizedRounds)
+	require.EqualValues(1, stats.Discrepancies)
+	require.EqualValues(6, stats.TotalCommitLatency)
+	require.EqualValues(3, stats.AverageCommitLatency())
+}
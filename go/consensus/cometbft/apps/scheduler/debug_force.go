@@ -27,7 +27,7 @@ func (app *schedulerApplication) debugForceElect(
 	wantedNodes int,
 ) (bool, []*scheduler.CommitteeNode, *debugForceElectState) {
 	elected := make([]*scheduler.CommitteeNode, 0, wantedNodes)
-	if !flags.DebugDontBlameOasis() || schedulerParameters.DebugForceElect == nil {
+	if !flags.DebugUnsafeConsensus() || schedulerParameters.DebugForceElect == nil {
 		return true, elected, nil
 	}
 
@@ -101,7 +101,7 @@ func (app *schedulerApplication) debugForceRoles(
 	elected []*scheduler.CommitteeNode,
 	role scheduler.Role,
 ) (bool, []*scheduler.CommitteeNode) {
-	if !flags.DebugDontBlameOasis() || state == nil || len(state.elected) == 0 || role != scheduler.RoleWorker {
+	if !flags.DebugUnsafeConsensus() || state == nil || len(state.elected) == 0 || role != scheduler.RoleWorker {
 		return true, elected
 	}
 
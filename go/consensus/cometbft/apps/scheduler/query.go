@@ -16,6 +16,7 @@ type Query interface {
 	KindsCommittees(context.Context, []scheduler.CommitteeKind) ([]*scheduler.Committee, error)
 	Genesis(context.Context) (*scheduler.Genesis, error)
 	ConsensusParameters(context.Context) (*scheduler.ConsensusParameters, error)
+	SimulateElection(context.Context, *scheduler.SimulateElectionRequest) (*scheduler.ElectionSimulation, error)
 }
 
 // QueryFactory is the scheduler query factory.
@@ -36,12 +37,16 @@ func (sf *QueryFactory) QueryAt(ctx context.Context, height int64) (Query, error
 		return nil, err
 	}
 
-	return &schedulerQuerier{state, regState}, nil
+	return &schedulerQuerier{state, regState, sf.state, height}, nil
 }
 
 type schedulerQuerier struct {
 	state    *schedulerState.ImmutableState
 	regState *registryState.ImmutableState
+
+	// The application state and height are needed to simulate elections.
+	appState abciAPI.ApplicationQueryState
+	height   int64
 }
 
 func (sq *schedulerQuerier) Validators(ctx context.Context) ([]*scheduler.Validator, error) {
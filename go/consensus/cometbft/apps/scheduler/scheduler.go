@@ -119,66 +119,11 @@ func (app *schedulerApplication) BeginBlock(ctx *api.Context) error {
 			return err
 		}
 
-		beaconState := beaconState.NewMutableState(ctx.State())
-		beaconParameters, err := beaconState.ConsensusParameters(ctx)
-		if err != nil {
-			return fmt.Errorf("cometbft/scheduler: couldn't get beacon parameters: %w", err)
-		}
-		// If weak alphas are allowed then skip the eligibility check as
-		// well because the byzantine node and associated tests are extremely
-		// fragile, and breaks in hard-to-debug ways if timekeeping isn't
-		// exactly how it expects.
-		filterCommitteeNodes := beaconParameters.Backend == beacon.BackendVRF && !params.DebugAllowWeakAlpha
-
 		regState := registryState.NewMutableState(ctx.State())
-		registryParameters, err := regState.ConsensusParameters(ctx)
-		if err != nil {
-			return fmt.Errorf("cometbft/scheduler: couldn't get registry parameters: %w", err)
-		}
 		runtimes, err := regState.Runtimes(ctx)
 		if err != nil {
 			return fmt.Errorf("cometbft/scheduler: couldn't get runtimes: %w", err)
 		}
-		allNodes, err := regState.Nodes(ctx)
-		if err != nil {
-			return fmt.Errorf("cometbft/scheduler: couldn't get nodes: %w", err)
-		}
-
-		// Filter nodes.
-		var (
-			nodes          []*node.Node
-			committeeNodes []*nodeWithStatus
-		)
-		for _, node := range allNodes {
-			var status *registry.NodeStatus
-			status, err = regState.NodeStatus(ctx, node.ID)
-			if err != nil {
-				return fmt.Errorf("cometbft/scheduler: couldn't get node status: %w", err)
-			}
-
-			// Nodes which are currently frozen cannot be scheduled.
-			if status.IsFrozen() {
-				continue
-			}
-			// Expired nodes cannot be scheduled (nodes can be expired and not yet removed).
-			if node.IsExpired(uint64(epoch)) {
-				continue
-			}
-
-			nodes = append(nodes, node)
-			if !filterCommitteeNodes || (status.ElectionEligibleAfter != beacon.EpochInvalid && epoch > status.ElectionEligibleAfter) {
-				committeeNodes = append(committeeNodes, &nodeWithStatus{node, status})
-			}
-		}
-
-		var stakeAcc *stakingState.StakeAccumulatorCache
-		if !params.DebugBypassStake {
-			stakeAcc, err = stakingState.NewStakeAccumulatorCache(ctx)
-			if err != nil {
-				return fmt.Errorf("cometbft/scheduler: failed to create stake accumulator cache: %w", err)
-			}
-			defer stakeAcc.Discard()
-		}
 
 		var entitiesEligibleForReward map[staking.Address]bool
 		if epochChanged {
@@ -186,44 +131,11 @@ func (app *schedulerApplication) BeginBlock(ctx *api.Context) error {
 			entitiesEligibleForReward = make(map[staking.Address]bool)
 		}
 
-		// Handle the validator election first, because no consensus is
-		// catastrophic, while failing to elect other committees is not.
-		var validatorEntities map[staking.Address]bool
-		if validatorEntities, err = app.electValidators(
-			ctx,
-			app.state,
-			beaconState,
-			beaconParameters,
-			stakeAcc,
-			entitiesEligibleForReward,
-			nodes,
-			params,
-		); err != nil {
-			// It is unclear what the behavior should be if the validator
-			// election fails.  The system can not ensure integrity, so
-			// presumably manual intervention is required...
-			return fmt.Errorf("cometbft/scheduler: couldn't elect validators: %w", err)
-		}
-
 		kinds := []scheduler.CommitteeKind{
 			scheduler.KindComputeExecutor,
 		}
-		for _, kind := range kinds {
-			if err = app.electAllCommittees(
-				ctx,
-				params,
-				beaconState,
-				beaconParameters,
-				registryParameters,
-				stakeAcc,
-				entitiesEligibleForReward,
-				validatorEntities,
-				runtimes,
-				committeeNodes,
-				kind,
-			); err != nil {
-				return fmt.Errorf("cometbft/scheduler: couldn't elect %s committees: %w", kind, err)
-			}
+		if err = app.elect(ctx, epoch, params, runtimes, kinds, entitiesEligibleForReward); err != nil {
+			return err
 		}
 		ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&scheduler.ElectedEvent{Kinds: kinds}))
 
@@ -252,6 +164,112 @@ func (app *schedulerApplication) BeginBlock(ctx *api.Context) error {
 	return nil
 }
 
+// elect performs the validator and committee elections for the given epoch and stores the
+// elected validator set and committees into the state of the given context.
+func (app *schedulerApplication) elect(
+	ctx *api.Context,
+	epoch beacon.EpochTime,
+	params *scheduler.ConsensusParameters,
+	runtimes []*registry.Runtime,
+	kinds []scheduler.CommitteeKind,
+	entitiesEligibleForReward map[staking.Address]bool,
+) error {
+	beaconState := beaconState.NewMutableState(ctx.State())
+	beaconParameters, err := beaconState.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("cometbft/scheduler: couldn't get beacon parameters: %w", err)
+	}
+	// If weak alphas are allowed then skip the eligibility check as
+	// well because the byzantine node and associated tests are extremely
+	// fragile, and breaks in hard-to-debug ways if timekeeping isn't
+	// exactly how it expects.
+	filterCommitteeNodes := beaconParameters.Backend == beacon.BackendVRF && !params.DebugAllowWeakAlpha
+
+	regState := registryState.NewMutableState(ctx.State())
+	registryParameters, err := regState.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("cometbft/scheduler: couldn't get registry parameters: %w", err)
+	}
+	allNodes, err := regState.Nodes(ctx)
+	if err != nil {
+		return fmt.Errorf("cometbft/scheduler: couldn't get nodes: %w", err)
+	}
+
+	// Filter nodes.
+	var (
+		nodes          []*node.Node
+		committeeNodes []*nodeWithStatus
+	)
+	for _, node := range allNodes {
+		var status *registry.NodeStatus
+		status, err = regState.NodeStatus(ctx, node.ID)
+		if err != nil {
+			return fmt.Errorf("cometbft/scheduler: couldn't get node status: %w", err)
+		}
+
+		// Nodes which are currently frozen cannot be scheduled.
+		if status.IsFrozen() {
+			continue
+		}
+		// Expired nodes cannot be scheduled (nodes can be expired and not yet removed).
+		if node.IsExpired(uint64(epoch)) {
+			continue
+		}
+
+		nodes = append(nodes, node)
+		if !filterCommitteeNodes || (status.ElectionEligibleAfter != beacon.EpochInvalid && epoch > status.ElectionEligibleAfter) {
+			committeeNodes = append(committeeNodes, &nodeWithStatus{node, status})
+		}
+	}
+
+	var stakeAcc *stakingState.StakeAccumulatorCache
+	if !params.DebugBypassStake {
+		stakeAcc, err = stakingState.NewStakeAccumulatorCache(ctx)
+		if err != nil {
+			return fmt.Errorf("cometbft/scheduler: failed to create stake accumulator cache: %w", err)
+		}
+		defer stakeAcc.Discard()
+	}
+
+	// Handle the validator election first, because no consensus is
+	// catastrophic, while failing to elect other committees is not.
+	var validatorEntities map[staking.Address]bool
+	if validatorEntities, err = app.electValidators(
+		ctx,
+		app.state,
+		beaconState,
+		beaconParameters,
+		stakeAcc,
+		entitiesEligibleForReward,
+		nodes,
+		params,
+	); err != nil {
+		// It is unclear what the behavior should be if the validator
+		// election fails.  The system can not ensure integrity, so
+		// presumably manual intervention is required...
+		return fmt.Errorf("cometbft/scheduler: couldn't elect validators: %w", err)
+	}
+
+	for _, kind := range kinds {
+		if err = app.electAllCommittees(
+			ctx,
+			params,
+			beaconState,
+			beaconParameters,
+			registryParameters,
+			stakeAcc,
+			entitiesEligibleForReward,
+			validatorEntities,
+			runtimes,
+			committeeNodes,
+			kind,
+		); err != nil {
+			return fmt.Errorf("cometbft/scheduler: couldn't elect %s committees: %w", kind, err)
+		}
+	}
+	return nil
+}
+
 func (app *schedulerApplication) ExecuteMessage(ctx *api.Context, kind, msg interface{}) (interface{}, error) {
 	switch kind {
 	case governanceApi.MessageValidateParameterChanges:
@@ -399,6 +417,21 @@ func (app *schedulerApplication) isSuitableExecutorWorker(
 	return false
 }
 
+// isBackupOnlyExecutorWorker returns true iff the node prefers to only be elected as a backup
+// worker for the active deployment of the given runtime.
+func isBackupOnlyExecutorWorker(n *node.Node, rt *registry.Runtime, epoch beacon.EpochTime) bool {
+	activeDeployment := rt.ActiveDeployment(epoch)
+	if activeDeployment == nil {
+		return false
+	}
+
+	nrt := n.GetRuntime(rt.ID, activeDeployment.Version)
+	if nrt == nil {
+		return false
+	}
+	return nrt.Capabilities.BackupOnly
+}
+
 // GetPerm generates a permutation that we use to choose nodes from a list of eligible nodes to elect.
 func GetPerm(beacon []byte, runtimeID common.Namespace, rngCtx []byte, nrNodes int) ([]int, error) {
 	drbg, err := drbg.New(crypto.SHA512, beacon, runtimeID[:], rngCtx)
@@ -106,7 +106,9 @@ func TestElectCommittee(t *testing.T) {
 		Backend: beacon.BackendInsecure,
 	}
 
-	registryParameters := &registry.ConsensusParameters{}
+	registryParameters := &registry.ConsensusParameters{
+		EnableBackupOnlyNodes: true,
+	}
 
 	rtID1 := common.NewTestNamespaceFromSeed([]byte("runtime 1"), 0)
 	rtID2 := common.NewTestNamespaceFromSeed([]byte("runtime 2"), 0)
@@ -779,6 +781,74 @@ func TestElectCommittee(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"executor: backup-only nodes are not elected as workers",
+			scheduler.KindComputeExecutor,
+			[]*node.Node{
+				{
+					ID: nodeID1,
+					Runtimes: []*node.Runtime{
+						{ID: rtID1, Capabilities: node.Capabilities{BackupOnly: true}},
+					},
+					Roles: node.RoleComputeWorker,
+				},
+				{
+					ID: nodeID3,
+					Runtimes: []*node.Runtime{
+						{ID: rtID1, Capabilities: node.Capabilities{BackupOnly: true}},
+					},
+					Roles: node.RoleComputeWorker,
+				},
+			},
+			map[signature.PublicKey]*registry.NodeStatus{},
+			map[staking.Address]bool{},
+			registry.Runtime{
+				ID:   rtID1,
+				Kind: registry.KindCompute,
+				Executor: registry.ExecutorParameters{
+					GroupSize:       1,
+					GroupBackupSize: 1,
+				},
+				Deployments: []*registry.VersionInfo{
+					{},
+				},
+			},
+			false,
+		},
+		{
+			"executor: backup-only nodes are elected as backup workers",
+			scheduler.KindComputeExecutor,
+			[]*node.Node{
+				{
+					ID: nodeID1,
+					Runtimes: []*node.Runtime{
+						{ID: rtID1, Capabilities: node.Capabilities{BackupOnly: true}},
+					},
+					Roles: node.RoleComputeWorker,
+				},
+				{
+					ID: nodeID3,
+					Runtimes: []*node.Runtime{
+						{ID: rtID1},
+					},
+					Roles: node.RoleComputeWorker,
+				},
+			},
+			map[signature.PublicKey]*registry.NodeStatus{},
+			map[staking.Address]bool{},
+			registry.Runtime{
+				ID:   rtID1,
+				Kind: registry.KindCompute,
+				Executor: registry.ExecutorParameters{
+					GroupSize:       1,
+					GroupBackupSize: 2,
+				},
+				Deployments: []*registry.VersionInfo{
+					{},
+				},
+			},
+			true,
+		},
 	} {
 		var nodes []*nodeWithStatus
 		for _, node := range tc.nodes {
@@ -814,4 +884,48 @@ func TestElectCommittee(t *testing.T) {
 
 		require.NotNil(c, "Committee should have been elected (%s)", tc.msg)
 	}
+
+	// Backup-only preferences should be ignored unless enabled.
+	registryParameters.EnableBackupOnlyNodes = false
+	rt := registry.Runtime{
+		ID:   rtID2,
+		Kind: registry.KindCompute,
+		Executor: registry.ExecutorParameters{
+			GroupSize:       1,
+			GroupBackupSize: 1,
+		},
+		Deployments: []*registry.VersionInfo{
+			{},
+		},
+	}
+	nodes := []*nodeWithStatus{
+		{
+			&node.Node{
+				ID: nodeID1,
+				Runtimes: []*node.Runtime{
+					{ID: rtID2, Capabilities: node.Capabilities{BackupOnly: true}},
+				},
+				Roles: node.RoleComputeWorker,
+			},
+			&registry.NodeStatus{},
+		},
+	}
+	err := app.electCommittee(
+		ctx,
+		schedulerParameters,
+		beaconState,
+		beaconParameters,
+		registryParameters,
+		nil,
+		nil,
+		map[staking.Address]bool{},
+		&rt,
+		nodes,
+		scheduler.KindComputeExecutor,
+	)
+	require.NoError(err, "committee election should not fail")
+
+	c, err := schedulerState.Committee(ctx, scheduler.KindComputeExecutor, rt.ID)
+	require.NoError(err, "Committee")
+	require.NotNil(c, "Committee should have been elected when backup-only nodes are disabled")
 }
@@ -253,7 +253,7 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 			// ... as long as we aren't testing with mandatory committee
 			// members.
 			isForceElect := false
-			if flags.DebugDontBlameOasis() && schedulerParameters.DebugForceElect != nil {
+			if flags.DebugUnsafeConsensus() && schedulerParameters.DebugForceElect != nil {
 				if rtNodeMap := schedulerParameters.DebugForceElect[rt.ID]; rtNodeMap != nil {
 					if ri := rtNodeMap[n.node.ID]; ri != nil {
 						isForceElect = kind == ri.Kind
@@ -271,12 +271,20 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 		}
 
 		// Check pre-election scheduling constraints.
+		backupOnly := kind == scheduler.KindComputeExecutor &&
+			registryParameters.EnableBackupOnlyNodes &&
+			isBackupOnlyExecutorWorker(n.node, rt, epoch)
 		var eligible bool
 		for _, role := range committeeRoles {
 			if groupSizes[role] == 0 {
 				continue
 			}
 
+			// Backup-only nodes are never elected as workers.
+			if backupOnly && role == scheduler.RoleWorker {
+				continue
+			}
+
 			// Validator set membership constraint.
 			if cs[role].ValidatorSet != nil {
 				if !validatorEntities[entAddr] {
@@ -311,7 +319,7 @@ func (app *schedulerApplication) electCommittee( //nolint: gocyclo
 		// but is still not ideal if the constraint is larger.
 		nodeList := nodeLists[role]
 		if mn := cs[role].MaxNodes; mn != nil && mn.Limit > 0 {
-			if flags.DebugDontBlameOasis() && schedulerParameters.DebugForceElect != nil {
+			if flags.DebugUnsafeConsensus() && schedulerParameters.DebugForceElect != nil {
 				ctx.Logger().Error("debug force elect is incompatible with de-duplication",
 					"kind", kind,
 					"role", role,
@@ -0,0 +1,239 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	beaconState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/beacon/state"
+	schedulerState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/scheduler/state"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+)
+
+// simulatedCommitteeKinds are the committee kinds elected in simulated elections.
+var simulatedCommitteeKinds = []scheduler.CommitteeKind{
+	scheduler.KindComputeExecutor,
+}
+
+func (sq *schedulerQuerier) SimulateElection(ctx context.Context, req *scheduler.SimulateElectionRequest) (*scheduler.ElectionSimulation, error) {
+	params, err := sq.state.ConsensusParameters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	proposedParams := *params
+	if req.Changes != nil {
+		if err = req.Changes.SanityCheck(); err != nil {
+			return nil, fmt.Errorf("cometbft/scheduler: invalid parameter changes: %w", err)
+		}
+		if err = req.Changes.Apply(&proposedParams); err != nil {
+			return nil, fmt.Errorf("cometbft/scheduler: failed to apply parameter changes: %w", err)
+		}
+		if err = proposedParams.SanityCheck(); err != nil {
+			return nil, fmt.Errorf("cometbft/scheduler: invalid proposed parameters: %w", err)
+		}
+	}
+
+	runtimes, err := sq.regState.Runtimes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	proposedRuntimes, err := applyRuntimeChanges(runtimes, req.Runtimes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Elections write their results into the state, so each simulation runs on top of a separate
+	// overlay which is discarded afterwards.
+	is, err := abciAPI.NewImmutableState(ctx, sq.appState, sq.height)
+	if err != nil {
+		return nil, err
+	}
+	defer is.Close()
+	tree, ok := is.ImmutableKeyValueTree.(mkvs.KeyValueTree)
+	if !ok {
+		return nil, fmt.Errorf("cometbft/scheduler: state does not support simulation")
+	}
+
+	height := sq.height
+	if height <= 0 || height > sq.appState.BlockHeight() {
+		height = sq.appState.BlockHeight()
+	}
+	epoch, _, err := beaconState.NewMutableState(tree).GetEpoch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cometbft/scheduler: failed to query current epoch: %w", err)
+	}
+
+	current, err := simulateElection(ctx, tree, height, epoch, params, runtimes)
+	if err != nil {
+		return nil, err
+	}
+	proposed, err := simulateElection(ctx, tree, height, epoch, &proposedParams, proposedRuntimes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &scheduler.ElectionSimulation{
+		Height:   height,
+		Epoch:    epoch,
+		Current:  current,
+		Proposed: proposed,
+	}, nil
+}
+
+// simulateElection performs an election on top of the given state without persisting the results
+// and returns its outcome.
+func simulateElection(
+	ctx context.Context,
+	tree mkvs.KeyValueTree,
+	height int64,
+	epoch beacon.EpochTime,
+	params *scheduler.ConsensusParameters,
+	runtimes []*registry.Runtime,
+) (*scheduler.ElectionOutcome, error) {
+	overlay := mkvs.NewOverlay(tree)
+	defer overlay.Close()
+
+	simCtx := abciAPI.NewContext(ctx, abciAPI.ContextSimulateTx, time.Now(), abciAPI.NewNopGasAccountant(), nil, overlay, height, nil, 0)
+	defer simCtx.Close()
+
+	// Remove any previously elected committees so that runtimes for which the election does not
+	// produce a committee are reported correctly.
+	state := schedulerState.NewMutableState(overlay)
+	for _, rt := range runtimes {
+		for _, kind := range simulatedCommitteeKinds {
+			if err := state.DropCommittee(simCtx, kind, rt.ID); err != nil {
+				return nil, fmt.Errorf("cometbft/scheduler: failed to drop committee: %w", err)
+			}
+		}
+	}
+
+	var app schedulerApplication
+	if err := app.elect(simCtx, epoch, params, runtimes, simulatedCommitteeKinds, nil); err != nil {
+		return &scheduler.ElectionOutcome{Error: err.Error()}, nil
+	}
+
+	var outcome scheduler.ElectionOutcome
+	validators, err := state.PendingValidators(simCtx)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range validators {
+		outcome.Validators = append(outcome.Validators, v)
+	}
+	sort.Slice(outcome.Validators, func(i, j int) bool {
+		vi, vj := outcome.Validators[i], outcome.Validators[j]
+		if vi.VotingPower != vj.VotingPower {
+			return vi.VotingPower > vj.VotingPower
+		}
+		return bytes.Compare(vi.ID[:], vj.ID[:]) < 0
+	})
+
+	for _, rt := range runtimes {
+		for _, kind := range simulatedCommitteeKinds {
+			var committee *scheduler.Committee
+			if committee, err = state.Committee(simCtx, kind, rt.ID); err != nil {
+				return nil, err
+			}
+			if committee != nil {
+				outcome.Committees = append(outcome.Committees, committee)
+			}
+		}
+	}
+
+	return &outcome, nil
+}
+
+// applyRuntimeChanges returns a copy of the given runtimes with the proposed executor committee
+// changes applied.
+func applyRuntimeChanges(
+	runtimes []*registry.Runtime,
+	changes map[common.Namespace]map[scheduler.Role]*scheduler.CommitteeRoleChanges,
+) ([]*registry.Runtime, error) {
+	known := make(map[common.Namespace]bool, len(runtimes))
+	for _, rt := range runtimes {
+		known[rt.ID] = true
+	}
+	for id := range changes {
+		if !known[id] {
+			return nil, fmt.Errorf("cometbft/scheduler: %w: %s", registry.ErrNoSuchRuntime, id)
+		}
+	}
+
+	proposed := make([]*registry.Runtime, 0, len(runtimes))
+	for _, rt := range runtimes {
+		roleChanges, ok := changes[rt.ID]
+		if !ok {
+			proposed = append(proposed, rt)
+			continue
+		}
+
+		prt := *rt
+		prt.Constraints = make(map[scheduler.CommitteeKind]map[scheduler.Role]registry.SchedulingConstraints)
+		for kind, cs := range rt.Constraints {
+			prt.Constraints[kind] = make(map[scheduler.Role]registry.SchedulingConstraints)
+			for role, c := range cs {
+				prt.Constraints[kind][role] = c
+			}
+		}
+		if prt.Constraints[scheduler.KindComputeExecutor] == nil {
+			prt.Constraints[scheduler.KindComputeExecutor] = make(map[scheduler.Role]registry.SchedulingConstraints)
+		}
+
+		for role, rc := range roleChanges {
+			if rc == nil {
+				continue
+			}
+			if err := applyCommitteeRoleChanges(&prt, role, rc); err != nil {
+				return nil, fmt.Errorf("cometbft/scheduler: invalid changes for runtime %s: %w", rt.ID, err)
+			}
+		}
+		proposed = append(proposed, &prt)
+	}
+
+	return proposed, nil
+}
+
+func applyCommitteeRoleChanges(rt *registry.Runtime, role scheduler.Role, changes *scheduler.CommitteeRoleChanges) error {
+	switch role {
+	case scheduler.RoleWorker:
+		if changes.Size != nil {
+			rt.Executor.GroupSize = *changes.Size
+		}
+	case scheduler.RoleBackupWorker:
+		if changes.Size != nil {
+			rt.Executor.GroupBackupSize = *changes.Size
+		}
+	default:
+		return fmt.Errorf("invalid role: %s", role)
+	}
+
+	cs := rt.Constraints[scheduler.KindComputeExecutor][role]
+	if changes.MaxNodesPerEntity != nil {
+		cs.MaxNodes = nil
+		if limit := *changes.MaxNodesPerEntity; limit > 0 {
+			cs.MaxNodes = &registry.MaxNodesConstraint{Limit: limit}
+		}
+	}
+	if changes.MinPoolSize != nil {
+		cs.MinPoolSize = nil
+		if limit := *changes.MinPoolSize; limit > 0 {
+			cs.MinPoolSize = &registry.MinPoolSizeConstraint{Limit: limit}
+		}
+	}
+	if changes.ValidatorSet != nil {
+		cs.ValidatorSet = nil
+		if *changes.ValidatorSet {
+			cs.ValidatorSet = &registry.ValidatorSetConstraint{}
+		}
+	}
+	rt.Constraints[scheduler.KindComputeExecutor][role] = cs
+
+	return nil
+}
@@ -285,6 +285,38 @@ func (app *stakingApplication) initDebondingDelegations(ctx *abciAPI.Context, st
 	return nil
 }
 
+func (app *stakingApplication) initDebondingTransfers(ctx *abciAPI.Context, state *stakingState.MutableState, st *staking.Genesis) error {
+	for escrowAddr, delegators := range st.DebondingTransfers {
+		if !escrowAddr.IsValid() {
+			return fmt.Errorf("cometbft/staking: failed to set genesis debonding transfers from %s: address is invalid",
+				escrowAddr,
+			)
+		}
+		for delegatorAddr, transfers := range delegators {
+			if !delegatorAddr.IsValid() {
+				return fmt.Errorf(
+					"cometbft/staking: failed to set genesis debonding transfer of %s from %s: delegator address is invalid",
+					delegatorAddr, escrowAddr,
+				)
+			}
+			for idx, xfer := range transfers {
+				if xfer == nil {
+					return fmt.Errorf(
+						"cometbft/staking: genesis debonding transfer of %s from %s with index %d is nil",
+						delegatorAddr, escrowAddr, idx,
+					)
+				}
+				if err := state.AddDebondingTransfer(ctx, delegatorAddr, escrowAddr, xfer); err != nil {
+					return fmt.Errorf("cometbft/staking: failed to set debonding transfer of %s from %s index %d: %w",
+						delegatorAddr, escrowAddr, idx, err,
+					)
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // InitChain initializes the chain from genesis.
 func (app *stakingApplication) InitChain(ctx *abciAPI.Context, _ types.RequestInitChain, doc *genesis.Document) error {
 	st := &doc.Staking
@@ -326,6 +358,10 @@ func (app *stakingApplication) InitChain(ctx *abciAPI.Context, _ types.RequestIn
 		return err
 	}
 
+	if err := app.initDebondingTransfers(ctx, state, st); err != nil {
+		return err
+	}
+
 	ctx.Logger().Debug("InitChain: allocations complete",
 		"common_pool", st.CommonPool,
 		"total_supply", totalSupply,
@@ -356,21 +392,14 @@ func (sq *stakingQuerier) Genesis(ctx context.Context) (*staking.Genesis, error)
 		return nil, err
 	}
 
-	addresses, err := sq.state.Addresses(ctx)
+	ledger, err := sq.state.Accounts(ctx)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("cometbft/staking: failed to fetch accounts: %w", err)
 	}
-	ledger := make(map[staking.Address]*staking.Account)
-	for _, addr := range addresses {
-		var acct *staking.Account
-		acct, err = sq.state.Account(ctx, addr)
-		if err != nil {
-			return nil, fmt.Errorf("cometbft/staking: failed to fetch account: %w", err)
-		}
+	for _, acct := range ledger {
 		// Make sure that export resets the stake accumulator state as that should be re-initialized
 		// during genesis (a genesis document with non-empty stake accumulator is invalid).
 		acct.Escrow.StakeAccumulator = staking.StakeAccumulator{}
-		ledger[addr] = acct
 	}
 
 	delegations, err := sq.state.Delegations(ctx)
@@ -381,6 +410,10 @@ func (sq *stakingQuerier) Genesis(ctx context.Context) (*staking.Genesis, error)
 	if err != nil {
 		return nil, err
 	}
+	debondingTransfers, err := sq.state.DebondingTransfers(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	params, err := sq.state.ConsensusParameters(ctx)
 	if err != nil {
@@ -396,6 +429,7 @@ func (sq *stakingQuerier) Genesis(ctx context.Context) (*staking.Genesis, error)
 		Ledger:               ledger,
 		Delegations:          delegations,
 		DebondingDelegations: debondingDelegations,
+		DebondingTransfers:   debondingTransfers,
 	}
 	return &gen, nil
 }
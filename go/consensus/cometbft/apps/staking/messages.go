@@ -35,6 +35,9 @@ func (app *stakingApplication) changeParameters(ctx *api.Context, msg interface{
 	if err = changes.SanityCheck(); err != nil {
 		return nil, fmt.Errorf("staking: failed to validate consensus parameter changes: %w", err)
 	}
+	if changes.ChangesTokenDisplay() && !params.EnableTokenDisplayChanges {
+		return nil, fmt.Errorf("staking: token display changes are not enabled")
+	}
 	if err = changes.Apply(params); err != nil {
 		return nil, fmt.Errorf("staking: failed to apply consensus parameter changes: %w", err)
 	}
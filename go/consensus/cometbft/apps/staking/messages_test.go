@@ -112,4 +112,32 @@ func TestChangeParameters(t *testing.T) {
 		_, err := app.changeParameters(ctx, &proposal, true)
 		require.EqualError(err, "staking: failed to validate consensus parameters: fee split proportions are all zero")
 	})
+	t.Run("token display changes", func(t *testing.T) {
+		require := require.New(t)
+
+		exp := uint8(0)
+		changes := staking.ConsensusParameterChanges{
+			TokenValueExponent: &exp,
+		}
+		proposal := governance.ChangeParametersProposal{
+			Module:  staking.ModuleName,
+			Changes: cbor.Marshal(changes),
+		}
+		_, err := app.changeParameters(ctx, &proposal, true)
+		require.EqualError(err, "staking: token display changes are not enabled")
+
+		params, err := state.ConsensusParameters(ctx)
+		require.NoError(err, "fetching consensus parameters should succeed")
+		params.EnableTokenDisplayChanges = true
+		err = state.SetConsensusParameters(ctx, params)
+		require.NoError(err, "setting consensus parameters should succeed")
+
+		_, err = app.changeParameters(ctx, &proposal, true)
+		require.NoError(err, "token display changes should succeed when enabled")
+
+		params, err = state.ConsensusParameters(ctx)
+		require.NoError(err, "fetching consensus parameters should succeed")
+		require.NotNil(params.TokenValueExponent, "zero exponent should be recorded")
+		require.EqualValues(0, *params.TokenValueExponent)
+	})
 }
@@ -27,6 +27,9 @@ type Query interface {
 	DebondingDelegationsFor(context.Context, staking.Address) (map[staking.Address][]*staking.DebondingDelegation, error)
 	DebondingDelegationInfosFor(context.Context, staking.Address) (map[staking.Address][]*staking.DebondingDelegationInfo, error)
 	DebondingDelegationsTo(context.Context, staking.Address) (map[staking.Address][]*staking.DebondingDelegation, error)
+	DelegationHistoryFor(context.Context, staking.Address, int64, int64) (map[staking.Address][]*staking.DelegationHistoryEntry, error)
+	DelegationHistoryTo(context.Context, staking.Address, int64, int64) (map[staking.Address][]*staking.DelegationHistoryEntry, error)
+	Rewards(context.Context, staking.Address, int64, int64) ([]*staking.RewardEntry, error)
 	Genesis(context.Context) (*staking.Genesis, error)
 	ConsensusParameters(context.Context) (*staking.ConsensusParameters, error)
 }
@@ -186,6 +189,38 @@ func (sq *stakingQuerier) DebondingDelegationsTo(ctx context.Context, addr staki
 	return sq.state.DebondingDelegationsTo(ctx, addr)
 }
 
+func (sq *stakingQuerier) checkHistoryEnabled(ctx context.Context) error {
+	params, err := sq.state.ConsensusParameters(ctx)
+	if err != nil {
+		return err
+	}
+	if !params.EnableHistoryIndices {
+		return staking.ErrHistoryUnavailable
+	}
+	return nil
+}
+
+func (sq *stakingQuerier) DelegationHistoryFor(ctx context.Context, addr staking.Address, fromHeight, toHeight int64) (map[staking.Address][]*staking.DelegationHistoryEntry, error) {
+	if err := sq.checkHistoryEnabled(ctx); err != nil {
+		return nil, err
+	}
+	return sq.state.DelegationHistoryFor(ctx, addr, fromHeight, toHeight)
+}
+
+func (sq *stakingQuerier) DelegationHistoryTo(ctx context.Context, addr staking.Address, fromHeight, toHeight int64) (map[staking.Address][]*staking.DelegationHistoryEntry, error) {
+	if err := sq.checkHistoryEnabled(ctx); err != nil {
+		return nil, err
+	}
+	return sq.state.DelegationHistoryTo(ctx, addr, fromHeight, toHeight)
+}
+
+func (sq *stakingQuerier) Rewards(ctx context.Context, addr staking.Address, fromHeight, toHeight int64) ([]*staking.RewardEntry, error) {
+	if err := sq.checkHistoryEnabled(ctx); err != nil {
+		return nil, err
+	}
+	return sq.state.Rewards(ctx, addr, fromHeight, toHeight)
+}
+
 func (sq *stakingQuerier) ConsensusParameters(ctx context.Context) (*staking.ConsensusParameters, error) {
 	return sq.state.ConsensusParameters(ctx)
 }
@@ -187,6 +187,13 @@ func (app *stakingApplication) ExecuteTx(ctx *api.Context, tx *transaction.Trans
 
 		_, err := app.reclaimEscrow(ctx, state, &reclaim)
 		return err
+	case staking.MethodReclaimEscrowAndTransfer:
+		var reclaim staking.ReclaimEscrowAndTransfer
+		if err := cbor.Unmarshal(tx.Body, &reclaim); err != nil {
+			return staking.ErrInvalidArgument
+		}
+
+		return app.reclaimEscrowAndTransfer(ctx, state, &reclaim)
 	case staking.MethodAmendCommissionSchedule:
 		var amend staking.AmendCommissionSchedule
 		if err := cbor.Unmarshal(tx.Body, &amend); err != nil {
@@ -215,20 +222,54 @@ func (app *stakingApplication) ExecuteTx(ctx *api.Context, tx *transaction.Trans
 }
 
 func (app *stakingApplication) EndBlock(ctx *api.Context) (types.ResponseEndBlock, error) {
+	state := stakingState.NewMutableState(ctx.State())
+
 	fees := stakingState.BlockFees(ctx)
-	if err := app.disburseFeesP(ctx, stakingState.NewMutableState(ctx.State()), stakingState.BlockProposer(ctx), &fees); err != nil {
+	if err := app.disburseFeesP(ctx, state, stakingState.BlockProposer(ctx), &fees); err != nil {
 		return types.ResponseEndBlock{}, fmt.Errorf("disburse fees proposer: %w", err)
 	}
 
+	if err := app.pruneHistory(ctx, state); err != nil {
+		return types.ResponseEndBlock{}, fmt.Errorf("prune history: %w", err)
+	}
+
 	if changed, epoch := app.state.EpochChanged(ctx); changed {
 		return types.ResponseEndBlock{}, app.onEpochChange(ctx, epoch)
 	}
 	return types.ResponseEndBlock{}, nil
 }
 
+// pruneHistory removes the delegation and reward history entries that fell out of the
+// configured retention window.
+//
+// Nothing is pruned while the history indices are disabled, so that the history queue is not
+// scanned on every block. Any entries left over from when the indices were enabled are pruned
+// once they are enabled again.
+func (app *stakingApplication) pruneHistory(ctx *api.Context, state *stakingState.MutableState) error {
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load consensus parameters: %w", err)
+	}
+	if !params.EnableHistoryIndices || params.HistoryRetention == 0 {
+		return nil
+	}
+
+	// Current height is ctx.BlockHeight() + 1.
+	height := ctx.BlockHeight() + 1
+	if uint64(height) <= params.HistoryRetention {
+		return nil
+	}
+	return state.PruneHistory(ctx, height-int64(params.HistoryRetention))
+}
+
 func (app *stakingApplication) onEpochChange(ctx *api.Context, epoch beacon.EpochTime) error {
 	state := stakingState.NewMutableState(ctx.State())
 
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load consensus parameters: %w", err)
+	}
+
 	// Delegation unbonding after debonding period elapses.
 	expiredDebondingQueue, err := state.ExpiredDebondingQueue(ctx, epoch)
 	if err != nil {
@@ -254,7 +295,7 @@ func (app *stakingApplication) onEpochChange(ctx *api.Context, epoch beacon.Epoc
 		}
 
 		var baseUnits quantity.Quantity
-		if err = escrow.Escrow.Debonding.Withdraw(&baseUnits, &deb.Shares, shareAmount); err != nil {
+		if err = escrow.Escrow.Debonding.Withdraw(&baseUnits, &deb.Shares, deb.Shares.Clone()); err != nil {
 			ctx.Logger().Error("failed to redeem debonding shares",
 				"err", err,
 				"escrow_addr", e.EscrowAddr,
@@ -265,6 +306,43 @@ func (app *stakingApplication) onEpochChange(ctx *api.Context, epoch beacon.Epoc
 		}
 		stakeAmount := baseUnits.Clone()
 
+		// Split the redeemed stake among any scheduled debonding transfers in proportion to their
+		// shares. The whole debonding delegation is redeemed at once so that partial transfers do
+		// not leave any additional rounding dust in the debonding pool.
+		var xfers []*staking.DebondingTransfer
+		if params.EnableDebondingTransfers {
+			xfers, err = state.DebondingTransfersFor(ctx, e.DelegatorAddr, e.EscrowAddr, e.Epoch)
+			if err != nil {
+				return fmt.Errorf("failed to query debonding transfers: %w", err)
+			}
+		}
+		xferAmounts := make([]*quantity.Quantity, 0, len(xfers))
+		remaining := stakeAmount.Clone()
+		for _, xfer := range xfers {
+			xferUnits := quantity.NewQuantity()
+			if !shareAmount.IsZero() {
+				// amount = stake_amount * transfer_shares / debonding_shares
+				xferUnits = stakeAmount.Clone()
+				if err = xferUnits.Mul(&xfer.Shares); err != nil {
+					return fmt.Errorf("cometbft/staking: failed to compute debonding transfer amount: %w", err)
+				}
+				if err = xferUnits.Quo(shareAmount); err != nil {
+					return fmt.Errorf("cometbft/staking: failed to compute debonding transfer amount: %w", err)
+				}
+			}
+			if err = remaining.Sub(xferUnits); err != nil {
+				ctx.Logger().Error("debonding transfer shares exceed debonding delegation",
+					"err", err,
+					"escrow_addr", e.EscrowAddr,
+					"delegator_addr", e.DelegatorAddr,
+					"to", xfer.To,
+					"shares", xfer.Shares,
+				)
+				return fmt.Errorf("cometbft/staking: failed to compute debonding transfer amount: %w", err)
+			}
+			xferAmounts = append(xferAmounts, xferUnits)
+		}
+
 		if err = quantity.Move(&delegator.General.Balance, &baseUnits, stakeAmount); err != nil {
 			ctx.Logger().Error("failed to move debonded stake",
 				"err", err,
@@ -305,6 +383,18 @@ func (app *stakingApplication) onEpochChange(ctx *api.Context, epoch beacon.Epoc
 			Amount: *stakeAmount,
 			Shares: *shareAmount,
 		}))
+
+		// Execute any scheduled debonding transfers.
+		for i, xfer := range xfers {
+			if err = app.executeDebondingTransfer(ctx, state, e.DelegatorAddr, xfer.To, xferAmounts[i]); err != nil {
+				return err
+			}
+		}
+		if len(xfers) > 0 {
+			if err = state.RemoveDebondingTransfers(ctx, e.DelegatorAddr, e.EscrowAddr, e.Epoch); err != nil {
+				return fmt.Errorf("failed to remove debonding transfers: %w", err)
+			}
+		}
 	}
 
 	// Add signing rewards.
@@ -318,6 +408,51 @@ func (app *stakingApplication) onEpochChange(ctx *api.Context, epoch beacon.Epoc
 	return nil
 }
 
+// executeDebondingTransfer transfers the given amount of debonded stake from the delegator's
+// general account to the destination account.
+func (app *stakingApplication) executeDebondingTransfer(
+	ctx *api.Context,
+	state *stakingState.MutableState,
+	fromAddr, toAddr staking.Address,
+	amount *quantity.Quantity,
+) error {
+	if amount.IsZero() || fromAddr.Equal(toAddr) {
+		return nil
+	}
+
+	from, err := state.Account(ctx, fromAddr)
+	if err != nil {
+		return fmt.Errorf("failed to query delegator account: %w", err)
+	}
+	to, err := state.Account(ctx, toAddr)
+	if err != nil {
+		return fmt.Errorf("failed to query destination account: %w", err)
+	}
+	if err = quantity.Move(&to.General.Balance, &from.General.Balance, amount); err != nil {
+		return fmt.Errorf("cometbft/staking: failed to transfer debonded stake: %w", err)
+	}
+	if err = state.SetAccount(ctx, toAddr, to); err != nil {
+		return fmt.Errorf("failed to set destination (%s) account: %w", toAddr, err)
+	}
+	if err = state.SetAccount(ctx, fromAddr, from); err != nil {
+		return fmt.Errorf("failed to set delegator (%s) account: %w", fromAddr, err)
+	}
+
+	ctx.Logger().Debug("transferred debonded stake",
+		"from", fromAddr,
+		"to", toAddr,
+		"base_units", amount,
+	)
+
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&staking.TransferEvent{
+		From:   fromAddr,
+		To:     toAddr,
+		Amount: *amount,
+	}))
+
+	return nil
+}
+
 // New constructs a new staking application instance.
 func New() api.Application {
 	return &stakingApplication{}
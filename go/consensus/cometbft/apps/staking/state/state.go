@@ -15,6 +15,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
@@ -80,9 +81,45 @@ var (
 	// Value is empty.
 	commissionScheduleAddressesKeyFmt = consensus.KeyFormat.New(0x5B, &staking.Address{})
 
+	// delegationHistoryKeyFmt is the key format used for the delegation history index
+	// (escrow address, delegator address, height).
+	//
+	// Value is CBOR-serialized delegation history entry.
+	delegationHistoryKeyFmt = consensus.KeyFormat.New(0x5C, &staking.Address{}, &staking.Address{}, uint64(0))
+	// delegationHistoryReverseKeyFmt is the key format used for reverse mapping of the
+	// delegation history index (delegator address, escrow address, height).
+	//
+	// Value is CBOR-serialized delegation history entry.
+	delegationHistoryReverseKeyFmt = consensus.KeyFormat.New(0x5D, &staking.Address{}, &staking.Address{}, uint64(0))
+	// rewardHistoryKeyFmt is the key format used for the reward history index
+	// (escrow address, height).
+	//
+	// Value is CBOR-serialized reward entry.
+	rewardHistoryKeyFmt = consensus.KeyFormat.New(0x5E, &staking.Address{}, uint64(0))
+	// debondingTransferKeyFmt is the key format used for scheduled debonding transfers
+	// (delegator address, escrow address, epoch, destination address).
+	//
+	// Value is CBOR-serialized debonding transfer.
+	debondingTransferKeyFmt = consensus.KeyFormat.New(0x5F, &staking.Address{}, &staking.Address{}, uint64(0), &staking.Address{})
+	// historyQueueKeyFmt is the key format used for the history index pruning queue
+	// (height, history kind, escrow address, delegator address). As the 0x5X range is exhausted,
+	// this key uses the 0x9X range.
+	//
+	// Value is empty.
+	historyQueueKeyFmt = consensus.KeyFormat.New(0x90, uint64(0), uint8(0), &staking.Address{}, &staking.Address{})
+
 	logger = logging.GetLogger("cometbft/staking")
 )
 
+const (
+	historyKindDelegation uint8 = 0
+	historyKindReward     uint8 = 1
+
+	// maxHistoryPrunedPerBlock is the maximum number of history entries that are pruned in a
+	// single block, bounding the work in case retention is reduced.
+	maxHistoryPrunedPerBlock = 1000
+)
+
 // ImmutableState is the immutable staking state wrapper.
 type ImmutableState struct {
 	is *abciAPI.ImmutableState
@@ -229,6 +266,28 @@ func (s *ImmutableState) Account(ctx context.Context, address staking.Address) (
 	return &ent, nil
 }
 
+// Accounts returns all accounts.
+func (s *ImmutableState) Accounts(ctx context.Context) (map[staking.Address]*staking.Account, error) {
+	accounts := make(map[staking.Address]*staking.Account)
+	err := s.is.Iterate(ctx, accountKeyFmt.Encode(), func(key, value []byte) error {
+		var addr staking.Address
+		if !accountKeyFmt.Decode(key, &addr) {
+			return nil
+		}
+
+		var acct staking.Account
+		if err := cbor.Unmarshal(value, &acct); err != nil {
+			return abciAPI.UnavailableStateError(err)
+		}
+		accounts[addr] = &acct
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
 // EscrowBalance returns the escrow balance for the given account address.
 func (s *ImmutableState) EscrowBalance(ctx context.Context, address staking.Address) (*quantity.Quantity, error) {
 	account, err := s.Account(ctx, address)
@@ -351,6 +410,102 @@ func (s *ImmutableState) DelegationsTo(
 	return delegations, nil
 }
 
+// DelegationHistoryFor returns the history of (outgoing) delegation changes for the given
+// delegator in the given (inclusive) height range, keyed by the escrow address.
+func (s *ImmutableState) DelegationHistoryFor(
+	ctx context.Context,
+	delegatorAddr staking.Address,
+	fromHeight, toHeight int64,
+) (map[staking.Address][]*staking.DelegationHistoryEntry, error) {
+	return s.delegationHistory(ctx, delegationHistoryReverseKeyFmt, delegatorAddr, fromHeight, toHeight)
+}
+
+// DelegationHistoryTo returns the history of (incoming) delegation changes to the given escrow
+// account in the given (inclusive) height range, keyed by the delegator address.
+func (s *ImmutableState) DelegationHistoryTo(
+	ctx context.Context,
+	escrowAddr staking.Address,
+	fromHeight, toHeight int64,
+) (map[staking.Address][]*staking.DelegationHistoryEntry, error) {
+	return s.delegationHistory(ctx, delegationHistoryKeyFmt, escrowAddr, fromHeight, toHeight)
+}
+
+func (s *ImmutableState) delegationHistory(
+	ctx context.Context,
+	keyFmt *keyformat.KeyFormat,
+	addr staking.Address,
+	fromHeight, toHeight int64,
+) (map[staking.Address][]*staking.DelegationHistoryEntry, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	query := staking.HistoryQuery{FromHeight: fromHeight, ToHeight: toHeight}
+	history := make(map[staking.Address][]*staking.DelegationHistoryEntry)
+	for it.Seek(keyFmt.Encode(&addr)); it.Valid(); it.Next() {
+		var (
+			decAddr, otherAddr staking.Address
+			height             uint64
+		)
+		if !keyFmt.Decode(it.Key(), &decAddr, &otherAddr, &height) {
+			break
+		}
+		if !decAddr.Equal(addr) {
+			break
+		}
+		if !query.Contains(int64(height)) {
+			continue
+		}
+
+		var entry staking.DelegationHistoryEntry
+		if err := cbor.Unmarshal(it.Value(), &entry); err != nil {
+			return nil, abciAPI.UnavailableStateError(err)
+		}
+
+		history[otherAddr] = append(history[otherAddr], &entry)
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+	return history, nil
+}
+
+// Rewards returns the rewards distributed to the given escrow account in the given (inclusive)
+// height range, ordered by height.
+func (s *ImmutableState) Rewards(
+	ctx context.Context,
+	escrowAddr staking.Address,
+	fromHeight, toHeight int64,
+) ([]*staking.RewardEntry, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	query := staking.HistoryQuery{FromHeight: fromHeight, ToHeight: toHeight}
+	var rewards []*staking.RewardEntry
+	for it.Seek(rewardHistoryKeyFmt.Encode(&escrowAddr, uint64(max(fromHeight, 0)))); it.Valid(); it.Next() {
+		var (
+			decAddr staking.Address
+			height  uint64
+		)
+		if !rewardHistoryKeyFmt.Decode(it.Key(), &decAddr, &height) {
+			break
+		}
+		if !decAddr.Equal(escrowAddr) || !query.Contains(int64(height)) {
+			break
+		}
+
+		var entry staking.RewardEntry
+		if err := cbor.Unmarshal(it.Value(), &entry); err != nil {
+			return nil, abciAPI.UnavailableStateError(err)
+		}
+
+		rewards = append(rewards, &entry)
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+	return rewards, nil
+}
+
 func (s *ImmutableState) DebondingDelegations(
 	ctx context.Context,
 ) (map[staking.Address]map[staking.Address][]*staking.DebondingDelegation, error) {
@@ -463,6 +618,73 @@ func (s *ImmutableState) DebondingDelegationsTo(
 	return delegations, nil
 }
 
+// DebondingTransfers returns all scheduled debonding transfers.
+func (s *ImmutableState) DebondingTransfers(
+	ctx context.Context,
+) (map[staking.Address]map[staking.Address][]*staking.DebondingTransfer, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	transfers := make(map[staking.Address]map[staking.Address][]*staking.DebondingTransfer)
+	for it.Seek(debondingTransferKeyFmt.Encode()); it.Valid(); it.Next() {
+		var escrowAddr staking.Address
+		var delegatorAddr staking.Address
+		if !debondingTransferKeyFmt.Decode(it.Key(), &delegatorAddr, &escrowAddr) {
+			break
+		}
+
+		var xfer staking.DebondingTransfer
+		if err := cbor.Unmarshal(it.Value(), &xfer); err != nil {
+			return nil, abciAPI.UnavailableStateError(err)
+		}
+
+		if transfers[escrowAddr] == nil {
+			transfers[escrowAddr] = make(map[staking.Address][]*staking.DebondingTransfer)
+		}
+		transfers[escrowAddr][delegatorAddr] = append(transfers[escrowAddr][delegatorAddr], &xfer)
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+	return transfers, nil
+}
+
+// DebondingTransfersFor returns the debonding transfers scheduled for the debonding delegation
+// of the given delegator to the given escrow account that ends at the given epoch.
+func (s *ImmutableState) DebondingTransfersFor(
+	ctx context.Context,
+	delegatorAddr, escrowAddr staking.Address,
+	epoch beacon.EpochTime,
+) ([]*staking.DebondingTransfer, error) {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	var transfers []*staking.DebondingTransfer
+	for it.Seek(debondingTransferKeyFmt.Encode(&delegatorAddr, &escrowAddr, uint64(epoch))); it.Valid(); it.Next() {
+		var (
+			decDelegatorAddr staking.Address
+			decEscrowAddr    staking.Address
+			decEpoch         uint64
+		)
+		if !debondingTransferKeyFmt.Decode(it.Key(), &decDelegatorAddr, &decEscrowAddr, &decEpoch) {
+			break
+		}
+		if !decDelegatorAddr.Equal(delegatorAddr) || !decEscrowAddr.Equal(escrowAddr) || decEpoch != uint64(epoch) {
+			break
+		}
+
+		var xfer staking.DebondingTransfer
+		if err := cbor.Unmarshal(it.Value(), &xfer); err != nil {
+			return nil, abciAPI.UnavailableStateError(err)
+		}
+		transfers = append(transfers, &xfer)
+	}
+	if it.Err() != nil {
+		return nil, abciAPI.UnavailableStateError(it.Err())
+	}
+	return transfers, nil
+}
+
 type DebondingQueueEntry struct {
 	Epoch         beacon.EpochTime
 	DelegatorAddr staking.Address
@@ -630,6 +852,21 @@ func (s *MutableState) SetAccountHook(ctx context.Context, addr staking.Address,
 	return s.SetAccount(ctx, addr, acct)
 }
 
+// IncrementNonce increments the nonce of the given account after verifying that it matches the
+// expected nonce.
+func (s *MutableState) IncrementNonce(ctx context.Context, addr staking.Address, nonce uint64) error {
+	acct, err := s.Account(ctx, addr)
+	if err != nil {
+		return err
+	}
+	if acct.General.Nonce != nonce {
+		return transaction.ErrInvalidNonce
+	}
+	acct.General.Nonce++
+
+	return s.SetAccount(ctx, addr, acct)
+}
+
 func (s *MutableState) SetTotalSupply(ctx context.Context, q *quantity.Quantity) error {
 	err := s.ms.Insert(ctx, totalSupplyKeyFmt.Encode(), cbor.Marshal(q))
 	return abciAPI.UnavailableStateError(err)
@@ -656,6 +893,10 @@ func (s *MutableState) SetDelegation(
 	delegatorAddr, escrowAddr staking.Address,
 	d *staking.Delegation,
 ) error {
+	if err := s.recordDelegationHistory(ctx, delegatorAddr, escrowAddr, d); err != nil {
+		return err
+	}
+
 	// Remove delegation if there are no more shares in it.
 	if d.Shares.IsZero() {
 		if err := s.ms.Remove(ctx, delegationKeyFmt.Encode(&escrowAddr, &delegatorAddr)); err != nil {
@@ -673,6 +914,144 @@ func (s *MutableState) SetDelegation(
 	return abciAPI.UnavailableStateError(err)
 }
 
+// historyHeight returns the height at which history entries should be recorded and whether
+// history indices are enabled.
+func (s *MutableState) historyHeight(ctx context.Context) (int64, bool, error) {
+	abciCtx := abciAPI.FromCtx(ctx)
+	if abciCtx == nil {
+		return 0, false, nil
+	}
+
+	// Consensus parameters may not be present yet (e.g., during genesis initialization).
+	raw, err := s.is.Get(ctx, parametersKeyFmt.Encode())
+	if err != nil {
+		return 0, false, abciAPI.UnavailableStateError(err)
+	}
+	if raw == nil {
+		return 0, false, nil
+	}
+	var params staking.ConsensusParameters
+	if err = cbor.Unmarshal(raw, &params); err != nil {
+		return 0, false, abciAPI.UnavailableStateError(err)
+	}
+	if !params.EnableHistoryIndices {
+		return 0, false, nil
+	}
+	return abciCtx.BlockHeight() + 1, true, nil // Current height is ctx.BlockHeight() + 1
+}
+
+func (s *MutableState) recordDelegationHistory(
+	ctx context.Context,
+	delegatorAddr, escrowAddr staking.Address,
+	d *staking.Delegation,
+) error {
+	height, enabled, err := s.historyHeight(ctx)
+	if err != nil || !enabled {
+		return err
+	}
+
+	entry := cbor.Marshal(&staking.DelegationHistoryEntry{
+		Height: height,
+		Shares: d.Shares,
+	})
+	if err = s.ms.Insert(ctx, delegationHistoryKeyFmt.Encode(&escrowAddr, &delegatorAddr, uint64(height)), entry); err != nil {
+		return abciAPI.UnavailableStateError(err)
+	}
+	if err = s.ms.Insert(ctx, delegationHistoryReverseKeyFmt.Encode(&delegatorAddr, &escrowAddr, uint64(height)), entry); err != nil {
+		return abciAPI.UnavailableStateError(err)
+	}
+	err = s.ms.Insert(ctx, historyQueueKeyFmt.Encode(uint64(height), historyKindDelegation, &escrowAddr, &delegatorAddr), []byte{})
+	return abciAPI.UnavailableStateError(err)
+}
+
+func (s *MutableState) recordReward(
+	ctx context.Context,
+	escrowAddr staking.Address,
+	amount, commission *quantity.Quantity,
+) error {
+	height, enabled, err := s.historyHeight(ctx)
+	if err != nil || !enabled {
+		return err
+	}
+
+	// Multiple rewards can be distributed to the same account at the same height.
+	key := rewardHistoryKeyFmt.Encode(&escrowAddr, uint64(height))
+	entry := staking.RewardEntry{Height: height}
+	value, err := s.ms.Get(ctx, key)
+	if err != nil {
+		return abciAPI.UnavailableStateError(err)
+	}
+	if value != nil {
+		if err = cbor.Unmarshal(value, &entry); err != nil {
+			return abciAPI.UnavailableStateError(err)
+		}
+	}
+
+	if amount != nil {
+		if err = entry.Amount.Add(amount); err != nil {
+			return fmt.Errorf("cometbft/staking: failed to add reward amount: %w", err)
+		}
+	}
+	if commission != nil {
+		if err = entry.Commission.Add(commission); err != nil {
+			return fmt.Errorf("cometbft/staking: failed to add reward commission: %w", err)
+		}
+	}
+
+	if err = s.ms.Insert(ctx, key, cbor.Marshal(&entry)); err != nil {
+		return abciAPI.UnavailableStateError(err)
+	}
+	err = s.ms.Insert(ctx, historyQueueKeyFmt.Encode(uint64(height), historyKindReward, &escrowAddr, &staking.Address{}), []byte{})
+	return abciAPI.UnavailableStateError(err)
+}
+
+// PruneHistory removes delegation and reward history entries recorded before the given height.
+//
+// At most maxHistoryPrunedPerBlock entries are removed per call, the rest is removed in
+// subsequent calls.
+func (s *MutableState) PruneHistory(ctx context.Context, beforeHeight int64) error {
+	it := s.is.NewIterator(ctx)
+	defer it.Close()
+
+	var (
+		keys   [][]byte
+		pruned int
+	)
+	for it.Seek(historyQueueKeyFmt.Encode()); it.Valid() && pruned < maxHistoryPrunedPerBlock; it.Next() {
+		var (
+			height        uint64
+			kind          uint8
+			escrowAddr    staking.Address
+			delegatorAddr staking.Address
+		)
+		if !historyQueueKeyFmt.Decode(it.Key(), &height, &kind, &escrowAddr, &delegatorAddr) || height >= uint64(max(beforeHeight, 0)) {
+			break
+		}
+
+		switch kind {
+		case historyKindDelegation:
+			keys = append(keys,
+				delegationHistoryKeyFmt.Encode(&escrowAddr, &delegatorAddr, height),
+				delegationHistoryReverseKeyFmt.Encode(&delegatorAddr, &escrowAddr, height),
+			)
+		case historyKindReward:
+			keys = append(keys, rewardHistoryKeyFmt.Encode(&escrowAddr, height))
+		}
+		keys = append(keys, it.Key())
+		pruned++
+	}
+	if it.Err() != nil {
+		return abciAPI.UnavailableStateError(it.Err())
+	}
+
+	for _, key := range keys {
+		if err := s.ms.Remove(ctx, key); err != nil {
+			return abciAPI.UnavailableStateError(err)
+		}
+	}
+	return nil
+}
+
 func (s *MutableState) SetDebondingDelegation(
 	ctx context.Context,
 	delegatorAddr, escrowAddr staking.Address,
@@ -728,6 +1107,62 @@ func (s *MutableState) SetDebondingDelegation(
 	return nil
 }
 
+// AddDebondingTransfer schedules a transfer of debonding shares of the given debonding
+// delegation. If a transfer to the same destination is already scheduled for the same debonding
+// delegation, the transfers are merged.
+func (s *MutableState) AddDebondingTransfer(
+	ctx context.Context,
+	delegatorAddr, escrowAddr staking.Address,
+	xfer *staking.DebondingTransfer,
+) error {
+	key := debondingTransferKeyFmt.Encode(&delegatorAddr, &escrowAddr, uint64(xfer.DebondEndTime), &xfer.To)
+
+	// Create a copy so we don't modify the passed in object in case we are merging
+	// it with an existing transfer.
+	merged := staking.DebondingTransfer{
+		To:            xfer.To,
+		Shares:        *xfer.Shares.Clone(),
+		DebondEndTime: xfer.DebondEndTime,
+	}
+
+	value, err := s.is.Get(ctx, key)
+	if err != nil {
+		return abciAPI.UnavailableStateError(err)
+	}
+	if value != nil {
+		var existing staking.DebondingTransfer
+		if err = cbor.Unmarshal(value, &existing); err != nil {
+			return abciAPI.UnavailableStateError(err)
+		}
+		if err = merged.Shares.Add(&existing.Shares); err != nil {
+			return fmt.Errorf("error merging debonding transfers: %w", err)
+		}
+	}
+
+	err = s.ms.Insert(ctx, key, cbor.Marshal(merged))
+	return abciAPI.UnavailableStateError(err)
+}
+
+// RemoveDebondingTransfers removes all debonding transfers scheduled for the debonding delegation
+// of the given delegator to the given escrow account that ends at the given epoch.
+func (s *MutableState) RemoveDebondingTransfers(
+	ctx context.Context,
+	delegatorAddr, escrowAddr staking.Address,
+	epoch beacon.EpochTime,
+) error {
+	transfers, err := s.DebondingTransfersFor(ctx, delegatorAddr, escrowAddr, epoch)
+	if err != nil {
+		return err
+	}
+	for _, xfer := range transfers {
+		key := debondingTransferKeyFmt.Encode(&delegatorAddr, &escrowAddr, uint64(epoch), &xfer.To)
+		if err = s.ms.Remove(ctx, key); err != nil {
+			return abciAPI.UnavailableStateError(err)
+		}
+	}
+	return nil
+}
+
 func (s *MutableState) RemoveFromDebondingQueue(
 	ctx context.Context,
 	epoch beacon.EpochTime,
@@ -1293,6 +1728,10 @@ func (s *MutableState) AddRewards(
 		if err = s.SetAccount(ctx, addr, ent); err != nil {
 			return fmt.Errorf("cometbft/staking: failed to set account: %w", err)
 		}
+
+		if err = s.recordReward(ctx, addr, q, com); err != nil {
+			return fmt.Errorf("cometbft/staking: failed to record reward: %w", err)
+		}
 	}
 
 	if err = s.SetCommonPool(ctx, commonPool); err != nil {
@@ -1428,6 +1867,10 @@ func (s *MutableState) AddRewardSingleAttenuated(
 		return fmt.Errorf("cometbft/staking: failed to set account: %w", err)
 	}
 
+	if err = s.recordReward(ctx, address, q, com); err != nil {
+		return fmt.Errorf("cometbft/staking: failed to record reward: %w", err)
+	}
+
 	if err = s.SetCommonPool(ctx, commonPool); err != nil {
 		return fmt.Errorf("cometbft/staking: failed to set common pool: %w", err)
 	}
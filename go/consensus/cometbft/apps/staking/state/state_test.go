@@ -1034,3 +1034,154 @@ func TestCommissionScheduleAccounts(t *testing.T) {
 	require.NoError(err, "CommissionScheduleAddresses")
 	require.ElementsMatch([]staking.Address{acc1Addr, acc4Addr}, addrs, "expected addresses should be returned")
 }
+
+func TestAccounts(t *testing.T) {
+	require := require.New(t)
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextBeginBlock)
+	defer ctx.Close()
+	s := NewMutableState(ctx.State())
+
+	// Make sure other state entries are not included.
+	require.NoError(s.SetTotalSupply(ctx, mustInitQuantityP(t, 1000)))
+	require.NoError(s.SetCommonPool(ctx, mustInitQuantityP(t, 500)))
+
+	fac := memorySigner.NewFactory()
+	expected := make(map[staking.Address]*staking.Account)
+	for i := 0; i < 5; i++ {
+		signer, err := fac.Generate(signature.SignerEntity, rand.Reader)
+		require.NoError(err, "generating account signer")
+		addr := staking.NewAddress(signer.Public())
+
+		acct := &staking.Account{}
+		acct.General.Nonce = uint64(i)
+		acct.General.Balance = mustInitQuantity(t, int64(100*i))
+		require.NoError(s.SetAccount(ctx, addr, acct), "SetAccount")
+		expected[addr] = acct
+	}
+
+	accounts, err := s.Accounts(ctx)
+	require.NoError(err, "Accounts")
+	require.Len(accounts, len(expected), "all accounts should be returned")
+	for addr, acct := range expected {
+		require.EqualValues(acct, accounts[addr], "account should match")
+
+		single, err := s.Account(ctx, addr)
+		require.NoError(err, "Account")
+		require.EqualValues(single, accounts[addr], "bulk and single account queries should match")
+	}
+}
+
+func TestHistoryIndices(t *testing.T) {
+	require := require.New(t)
+
+	delegatorSigner, err := memorySigner.NewSigner(rand.Reader)
+	require.NoError(err, "generating delegator signer")
+	delegatorAddr := staking.NewAddress(delegatorSigner.Public())
+	delegatorAccount := &staking.Account{}
+	err = delegatorAccount.General.Balance.FromBigInt(big.NewInt(300))
+	require.NoError(err, "initialize delegator account general balance")
+
+	escrowSigner, err := memorySigner.NewSigner(rand.Reader)
+	require.NoError(err, "generating escrow signer")
+	escrowAddr := staking.NewAddress(escrowSigner.Public())
+	escrowAccount := &staking.Account{}
+
+	del := &staking.Delegation{}
+	_, err = escrowAccount.Escrow.Active.Deposit(&del.Shares, &delegatorAccount.General.Balance, mustInitQuantityP(t, 100))
+	require.NoError(err, "active escrow deposit")
+
+	for _, enabled := range []bool{false, true} {
+		appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{
+			BlockHeight: 10,
+		})
+		ctx := appState.NewContext(abciAPI.ContextEndBlock)
+		defer ctx.Close()
+
+		s := NewMutableState(ctx.State())
+		err = s.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+			RewardSchedule: []staking.RewardStep{
+				{
+					Until: 30,
+					Scale: mustInitQuantity(t, 1000),
+				},
+			},
+			EnableHistoryIndices: enabled,
+		})
+		require.NoError(err, "SetConsensusParameters")
+		err = s.SetCommonPool(ctx, mustInitQuantityP(t, 10_000))
+		require.NoError(err, "SetCommonPool")
+		err = s.SetAccount(ctx, delegatorAddr, delegatorAccount)
+		require.NoError(err, "SetAccount")
+		err = s.SetAccount(ctx, escrowAddr, escrowAccount)
+		require.NoError(err, "SetAccount")
+		err = s.SetDelegation(ctx, delegatorAddr, escrowAddr, del)
+		require.NoError(err, "SetDelegation")
+
+		err = s.AddRewards(ctx, 10, mustInitQuantityP(t, 100_000), []staking.Address{escrowAddr})
+		require.NoError(err, "AddRewards")
+		err = s.AddRewardSingleAttenuated(ctx, 10, mustInitQuantityP(t, 100_000), 1, 2, escrowAddr)
+		require.NoError(err, "AddRewardSingleAttenuated")
+
+		delsFor, err := s.DelegationHistoryFor(ctx, delegatorAddr, 0, 0)
+		require.NoError(err, "DelegationHistoryFor")
+		delsTo, err := s.DelegationHistoryTo(ctx, escrowAddr, 0, 0)
+		require.NoError(err, "DelegationHistoryTo")
+		rewards, err := s.Rewards(ctx, escrowAddr, 0, 0)
+		require.NoError(err, "Rewards")
+
+		if !enabled {
+			require.Empty(delsFor, "delegation history should not be recorded when disabled")
+			require.Empty(delsTo, "delegation history should not be recorded when disabled")
+			require.Empty(rewards, "reward history should not be recorded when disabled")
+			continue
+		}
+
+		expectedDel := []*staking.DelegationHistoryEntry{{Height: 11, Shares: del.Shares}}
+		require.Equal(map[staking.Address][]*staking.DelegationHistoryEntry{escrowAddr: expectedDel}, delsFor)
+		require.Equal(map[staking.Address][]*staking.DelegationHistoryEntry{delegatorAddr: expectedDel}, delsTo)
+
+		// Rewards distributed at the same height should be accumulated.
+		require.Len(rewards, 1, "rewards at the same height should be accumulated")
+		require.EqualValues(11, rewards[0].Height)
+		require.Equal(mustInitQuantity(t, 200), rewards[0].Amount, "reward amount should be accumulated")
+		require.True(rewards[0].Commission.IsZero(), "reward commission should be zero")
+
+		// Entries outside of the height range should be skipped.
+		rewards, err = s.Rewards(ctx, escrowAddr, 12, 0)
+		require.NoError(err, "Rewards")
+		require.Empty(rewards, "rewards outside of the height range should be skipped")
+		rewards, err = s.Rewards(ctx, escrowAddr, 0, 10)
+		require.NoError(err, "Rewards")
+		require.Empty(rewards, "rewards outside of the height range should be skipped")
+		delsFor, err = s.DelegationHistoryFor(ctx, delegatorAddr, 12, 20)
+		require.NoError(err, "DelegationHistoryFor")
+		require.Empty(delsFor, "delegation history outside of the height range should be skipped")
+
+		// Pruning should keep entries at or after the given height.
+		err = s.PruneHistory(ctx, 11)
+		require.NoError(err, "PruneHistory")
+		rewards, err = s.Rewards(ctx, escrowAddr, 0, 0)
+		require.NoError(err, "Rewards")
+		require.Len(rewards, 1, "rewards within retention should not be pruned")
+
+		// Pruning should remove all entries before the given height.
+		err = s.PruneHistory(ctx, 12)
+		require.NoError(err, "PruneHistory")
+		delsFor, err = s.DelegationHistoryFor(ctx, delegatorAddr, 0, 0)
+		require.NoError(err, "DelegationHistoryFor")
+		require.Empty(delsFor, "delegation history should be pruned")
+		delsTo, err = s.DelegationHistoryTo(ctx, escrowAddr, 0, 0)
+		require.NoError(err, "DelegationHistoryTo")
+		require.Empty(delsTo, "delegation history should be pruned")
+		rewards, err = s.Rewards(ctx, escrowAddr, 0, 0)
+		require.NoError(err, "Rewards")
+		require.Empty(rewards, "reward history should be pruned")
+
+		it := s.is.NewIterator(ctx)
+		it.Seek(historyQueueKeyFmt.Encode())
+		require.False(it.Valid() && historyQueueKeyFmt.Decode(it.Key()), "pruning queue should be empty")
+		it.Close()
+	}
+}
@@ -408,6 +408,21 @@ func (app *stakingApplication) reclaimEscrow(ctx *api.Context, state *stakingSta
 		return nil, staking.ErrForbidden
 	}
 
+	return app.reclaimEscrowImpl(ctx, state, params, toAddr, reclaim)
+}
+
+func (app *stakingApplication) reclaimEscrowImpl(
+	ctx *api.Context,
+	state *stakingState.MutableState,
+	params *staking.ConsensusParameters,
+	toAddr staking.Address,
+	reclaim *staking.ReclaimEscrow,
+) (*staking.ReclaimEscrowResult, error) {
+	// Preconditions: Gas charged, toAddr is valid
+	if ctx.IsCheckOnly() || ctx.IsSimulation() {
+		panic("BUG: reclaimEscrowImpl called for invalid ctx state")
+	}
+
 	to, err := state.Account(ctx, toAddr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch account: %w", err)
@@ -531,6 +546,90 @@ func (app *stakingApplication) reclaimEscrow(ctx *api.Context, state *stakingSta
 	}, nil
 }
 
+func (app *stakingApplication) reclaimEscrowAndTransfer(
+	ctx *api.Context,
+	state *stakingState.MutableState,
+	reclaim *staking.ReclaimEscrowAndTransfer,
+) error {
+	// No sense if there is nothing to reclaim.
+	if reclaim.Shares.IsZero() {
+		return staking.ErrInvalidArgument
+	}
+
+	params, err := state.ConsensusParameters(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch consensus parameters: %w", err)
+	}
+	if !params.EnableDebondingTransfers {
+		return staking.ErrForbidden
+	}
+
+	if ctx.IsCheckOnly() {
+		return nil
+	}
+
+	// Charge gas for this transaction.
+	gasCosts := params.GasCosts
+	if _, ok := gasCosts[staking.GasOpReclaimEscrowAndTransfer]; !ok {
+		gasCosts = staking.DefaultGasCosts
+	}
+	if err = ctx.Gas().UseGas(1, staking.GasOpReclaimEscrowAndTransfer, gasCosts); err != nil {
+		return err
+	}
+
+	// Return early for simulation as we only need gas accounting.
+	if ctx.IsSimulation() {
+		return nil
+	}
+
+	ownerAddr := ctx.CallerAddress()
+	if ownerAddr.IsReserved() || !isTransferPermitted(params, ownerAddr) {
+		return staking.ErrForbidden
+	}
+	if reclaim.To.IsReserved() {
+		return staking.ErrForbidden
+	}
+	if reclaim.To.Equal(ownerAddr) {
+		// A plain reclaim escrow should be used instead.
+		return staking.ErrInvalidArgument
+	}
+
+	result, err := app.reclaimEscrowImpl(ctx, state, params, ownerAddr, &staking.ReclaimEscrow{
+		Account: reclaim.Account,
+		Shares:  reclaim.Shares,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Schedule the transfer of the debonding shares once debonding completes.
+	if err = state.AddDebondingTransfer(ctx, ownerAddr, reclaim.Account, &staking.DebondingTransfer{
+		To:            reclaim.To,
+		Shares:        result.DebondingShares,
+		DebondEndTime: result.DebondEndTime,
+	}); err != nil {
+		return fmt.Errorf("failed to add debonding transfer: %w", err)
+	}
+
+	ctx.Logger().Debug("ReclaimEscrowAndTransfer: scheduled debonding transfer",
+		"owner", ownerAddr,
+		"escrow", reclaim.Account,
+		"to", reclaim.To,
+		"debonding_shares", result.DebondingShares,
+		"debond_end_time", result.DebondEndTime,
+	)
+
+	ctx.EmitEvent(api.NewEventBuilder(app.Name()).TypedAttribute(&staking.DebondingTransferEscrowEvent{
+		Owner:           ownerAddr,
+		Escrow:          reclaim.Account,
+		To:              reclaim.To,
+		DebondingShares: result.DebondingShares,
+		DebondEndTime:   result.DebondEndTime,
+	}))
+
+	return nil
+}
+
 func (app *stakingApplication) amendCommissionSchedule(
 	ctx *api.Context,
 	state *stakingState.MutableState,
@@ -763,6 +763,285 @@ func TestAddEscrow(t *testing.T) {
 	}
 }
 
+func TestReclaimEscrowAndTransfer(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	stakeState := stakingState.NewMutableState(ctx.State())
+
+	app := &stakingApplication{
+		state: appState,
+	}
+
+	pk1 := signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr1 := staking.NewAddress(pk1)
+	pk2 := signature.NewPublicKey("bbbfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr2 := staking.NewAddress(pk2)
+	pk3 := signature.NewPublicKey("cccfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr3 := staking.NewAddress(pk3)
+
+	reservedPK := signature.NewPublicKey("badaaaafffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	reservedAddr := staking.NewReservedAddress(reservedPK)
+
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		DebondingInterval: 1,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	err = stakeState.SetAccount(ctx, addr1, &staking.Account{
+		General: staking.GeneralAccount{
+			Balance: *quantity.NewFromUint64(100_000),
+		},
+	})
+	require.NoError(err, "SetAccount1")
+
+	// Delegate from addr2 to addr1.
+	txCtx := appState.NewContext(abciAPI.ContextDeliverTx)
+	defer txCtx.Close()
+	txCtx.SetTxSigner(pk2)
+	err = stakeState.SetAccount(ctx, addr2, &staking.Account{
+		General: staking.GeneralAccount{
+			Balance: *quantity.NewFromUint64(10_000),
+		},
+	})
+	require.NoError(err, "SetAccount2")
+	_, err = app.addEscrow(txCtx, stakeState, &staking.Escrow{
+		Account: addr1,
+		Amount:  *quantity.NewFromUint64(10_000),
+	})
+	require.NoError(err, "addEscrow")
+
+	// Debonding transfers should be disabled unless explicitly enabled.
+	err = app.reclaimEscrowAndTransfer(txCtx, stakeState, &staking.ReclaimEscrowAndTransfer{
+		Account: addr1,
+		Shares:  *quantity.NewFromUint64(1000),
+		To:      addr3,
+	})
+	require.ErrorIs(err, staking.ErrForbidden, "reclaimEscrowAndTransfer should fail when disabled")
+
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		DebondingInterval:        1,
+		EnableDebondingTransfers: true,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	for _, tc := range []struct {
+		msg      string
+		txSigner signature.PublicKey
+		reclaim  *staking.ReclaimEscrowAndTransfer
+		err      error
+	}{
+		{
+			"should fail with zero shares",
+			pk2,
+			&staking.ReclaimEscrowAndTransfer{
+				Account: addr1,
+				To:      addr3,
+			},
+			staking.ErrInvalidArgument,
+		},
+		{
+			"should fail when transferring to self",
+			pk2,
+			&staking.ReclaimEscrowAndTransfer{
+				Account: addr1,
+				Shares:  *quantity.NewFromUint64(1000),
+				To:      addr2,
+			},
+			staking.ErrInvalidArgument,
+		},
+		{
+			"should fail when transferring to a reserved address",
+			pk2,
+			&staking.ReclaimEscrowAndTransfer{
+				Account: addr1,
+				Shares:  *quantity.NewFromUint64(1000),
+				To:      reservedAddr,
+			},
+			staking.ErrForbidden,
+		},
+		{
+			"should fail when reclaiming from a reserved address",
+			reservedPK,
+			&staking.ReclaimEscrowAndTransfer{
+				Account: addr1,
+				Shares:  *quantity.NewFromUint64(1000),
+				To:      addr3,
+			},
+			staking.ErrForbidden,
+		},
+		{
+			"should fail when reclaiming more than delegated",
+			pk2,
+			&staking.ReclaimEscrowAndTransfer{
+				Account: addr1,
+				Shares:  *quantity.NewFromUint64(20_000),
+				To:      addr3,
+			},
+			quantity.ErrInsufficientBalance,
+		},
+		{
+			"should succeed",
+			pk2,
+			&staking.ReclaimEscrowAndTransfer{
+				Account: addr1,
+				Shares:  *quantity.NewFromUint64(4000),
+				To:      addr3,
+			},
+			nil,
+		},
+		{
+			"should succeed when scheduling an additional transfer",
+			pk2,
+			&staking.ReclaimEscrowAndTransfer{
+				Account: addr1,
+				Shares:  *quantity.NewFromUint64(2000),
+				To:      addr3,
+			},
+			nil,
+		},
+	} {
+		txCtx = appState.NewContext(abciAPI.ContextDeliverTx)
+		defer txCtx.Close()
+		txCtx.SetTxSigner(tc.txSigner)
+
+		err = app.reclaimEscrowAndTransfer(txCtx, stakeState, tc.reclaim)
+		require.ErrorIs(err, tc.err, tc.msg)
+	}
+
+	// Transfers to the same destination should be merged.
+	xfers, err := stakeState.DebondingTransfersFor(ctx, addr2, addr1, 1)
+	require.NoError(err, "DebondingTransfersFor")
+	require.Len(xfers, 1, "transfers should be merged")
+	require.Equal(addr3, xfers[0].To)
+	require.Equal(*quantity.NewFromUint64(6000), xfers[0].Shares)
+
+	// Also reclaim some of the remaining shares without a transfer.
+	txCtx = appState.NewContext(abciAPI.ContextDeliverTx)
+	defer txCtx.Close()
+	txCtx.SetTxSigner(pk2)
+	_, err = app.reclaimEscrow(txCtx, stakeState, &staking.ReclaimEscrow{
+		Account: addr1,
+		Shares:  *quantity.NewFromUint64(1000),
+	})
+	require.NoError(err, "reclaimEscrow")
+
+	// Complete debonding.
+	err = app.onEpochChange(ctx, 1)
+	require.NoError(err, "onEpochChange")
+
+	acct2, err := stakeState.Account(ctx, addr2)
+	require.NoError(err, "Account2")
+	require.Equal(*quantity.NewFromUint64(1000), acct2.General.Balance, "delegator should receive non-transferred stake")
+	acct1, err := stakeState.Account(ctx, addr1)
+	require.NoError(err, "Account1")
+	require.Equal(*quantity.NewFromUint64(3000), acct1.Escrow.Active.Balance, "remaining delegation should stay active")
+	require.True(acct1.Escrow.Debonding.Balance.IsZero(), "debonding balance should be released")
+	acct3, err := stakeState.Account(ctx, addr3)
+	require.NoError(err, "Account3")
+	require.Equal(*quantity.NewFromUint64(6000), acct3.General.Balance, "destination should receive transferred stake")
+
+	xfers, err = stakeState.DebondingTransfersFor(ctx, addr2, addr1, 1)
+	require.NoError(err, "DebondingTransfersFor")
+	require.Empty(xfers, "transfers should be removed after execution")
+}
+
+func TestPartialDebondingTransferPoolBalance(t *testing.T) {
+	require := require.New(t)
+	var err error
+
+	appState := abciAPI.NewMockApplicationState(&abciAPI.MockApplicationStateConfig{})
+	ctx := appState.NewContext(abciAPI.ContextEndBlock)
+	defer ctx.Close()
+
+	stakeState := stakingState.NewMutableState(ctx.State())
+
+	app := &stakingApplication{
+		state: appState,
+	}
+
+	pk1 := signature.NewPublicKey("aaafffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr1 := staking.NewAddress(pk1)
+	pk2 := signature.NewPublicKey("bbbfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr2 := staking.NewAddress(pk2)
+	pk3 := signature.NewPublicKey("cccfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr3 := staking.NewAddress(pk3)
+	pk4 := signature.NewPublicKey("dddfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	addr4 := staking.NewAddress(pk4)
+
+	err = stakeState.SetConsensusParameters(ctx, &staking.ConsensusParameters{
+		DebondingInterval:        1,
+		EnableDebondingTransfers: true,
+	})
+	require.NoError(err, "SetConsensusParameters")
+
+	// Use a debonding pool where shares do not map to whole base units.
+	err = stakeState.SetAccount(ctx, addr1, &staking.Account{
+		Escrow: staking.EscrowAccount{
+			Debonding: staking.SharePool{
+				Balance:     *quantity.NewFromUint64(1000),
+				TotalShares: *quantity.NewFromUint64(2999),
+			},
+		},
+	})
+	require.NoError(err, "SetAccount1")
+	err = stakeState.SetDebondingDelegation(ctx, addr2, addr1, 1, &staking.DebondingDelegation{
+		Shares:        *quantity.NewFromUint64(1000),
+		DebondEndTime: 1,
+	})
+	require.NoError(err, "SetDebondingDelegation2")
+	err = stakeState.SetDebondingDelegation(ctx, addr4, addr1, 2, &staking.DebondingDelegation{
+		Shares:        *quantity.NewFromUint64(1999),
+		DebondEndTime: 2,
+	})
+	require.NoError(err, "SetDebondingDelegation4")
+
+	// Transfer only part of the debonding shares.
+	err = stakeState.AddDebondingTransfer(ctx, addr2, addr1, &staking.DebondingTransfer{
+		To:            addr3,
+		Shares:        *quantity.NewFromUint64(333),
+		DebondEndTime: 1,
+	})
+	require.NoError(err, "AddDebondingTransfer")
+
+	err = app.onEpochChange(ctx, 1)
+	require.NoError(err, "onEpochChange")
+
+	// The debonding delegation should be redeemed as a whole (1000 * 1000 / 2999 = 333) and
+	// split in proportion to the transferred shares (333 * 333 / 1000 = 110).
+	acct2, err := stakeState.Account(ctx, addr2)
+	require.NoError(err, "Account2")
+	require.Equal(*quantity.NewFromUint64(223), acct2.General.Balance, "delegator should receive non-transferred stake")
+	acct3, err := stakeState.Account(ctx, addr3)
+	require.NoError(err, "Account3")
+	require.Equal(*quantity.NewFromUint64(110), acct3.General.Balance, "destination should receive transferred stake")
+
+	// The debonding pool balance should match the remaining shares.
+	acct1, err := stakeState.Account(ctx, addr1)
+	require.NoError(err, "Account1")
+	require.Equal(*quantity.NewFromUint64(1999), acct1.Escrow.Debonding.TotalShares, "remaining debonding shares")
+	require.Equal(*quantity.NewFromUint64(667), acct1.Escrow.Debonding.Balance, "debonding pool balance")
+	stake, err := acct1.Escrow.Debonding.StakeForShares(&acct1.Escrow.Debonding.TotalShares)
+	require.NoError(err, "StakeForShares")
+	require.Equal(acct1.Escrow.Debonding.Balance, *stake, "remaining shares should be worth the pool balance")
+
+	// Once the remaining delegation is redeemed, the debonding pool should be empty.
+	err = app.onEpochChange(ctx, 2)
+	require.NoError(err, "onEpochChange")
+
+	acct4, err := stakeState.Account(ctx, addr4)
+	require.NoError(err, "Account4")
+	require.Equal(*quantity.NewFromUint64(667), acct4.General.Balance, "delegator should receive debonded stake")
+	acct1, err = stakeState.Account(ctx, addr1)
+	require.NoError(err, "Account1")
+	require.True(acct1.Escrow.Debonding.TotalShares.IsZero(), "no debonding shares should remain")
+	require.True(acct1.Escrow.Debonding.Balance.IsZero(), "no dust should remain in the debonding pool")
+}
+
 func TestAllowEscrowMessages(t *testing.T) {
 	require := require.New(t)
 	var err error
@@ -9,7 +9,6 @@ import (
 	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	vaultState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/vault/state"
 	genesis "github.com/oasisprotocol/oasis-core/go/genesis/api"
-	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 	vault "github.com/oasisprotocol/oasis-core/go/vault/api"
 )
 
@@ -66,27 +65,13 @@ func (vq *vaultQuerier) Genesis(ctx context.Context) (*vault.Genesis, error) {
 	}
 
 	// Account states and pending actions.
-	pendingActions := make(map[staking.Address][]*vault.PendingAction)
-	states := make(map[staking.Address]map[staking.Address]*vault.AddressState)
-	for _, vlt := range vaults {
-		var actions []*vault.PendingAction
-		actions, err = vq.state.PendingActions(ctx, vlt.Address())
-		if err != nil {
-			return nil, err
-		}
-
-		var vaultStates map[staking.Address]*vault.AddressState
-		vaultStates, err := vq.state.AddressStates(ctx, vlt.Address())
-		if err != nil {
-			return nil, err
-		}
-
-		if len(actions) > 0 {
-			pendingActions[vlt.Address()] = actions
-		}
-		if len(vaultStates) > 0 {
-			states[vlt.Address()] = vaultStates
-		}
+	pendingActions, err := vq.state.AllPendingActions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	states, err := vq.state.AllAddressStates(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	return &vault.Genesis{
@@ -131,6 +131,34 @@ func (s *ImmutableState) AddressStates(ctx context.Context, vaultAddr staking.Ad
 	return states, nil
 }
 
+// AllAddressStates returns the address states of all vaults.
+func (s *ImmutableState) AllAddressStates(ctx context.Context) (map[staking.Address]map[staking.Address]*vault.AddressState, error) {
+	states := make(map[staking.Address]map[staking.Address]*vault.AddressState)
+	err := s.is.Iterate(ctx, addressStateKeyFmt.Encode(), func(key, value []byte) error {
+		var (
+			vaultAddr staking.Address
+			addr      staking.Address
+		)
+		if !addressStateKeyFmt.Decode(key, &vaultAddr, &addr) {
+			return nil
+		}
+
+		var state vault.AddressState
+		if err := cbor.Unmarshal(value, &state); err != nil {
+			return api.UnavailableStateError(err)
+		}
+		if states[vaultAddr] == nil {
+			states[vaultAddr] = make(map[staking.Address]*vault.AddressState)
+		}
+		states[vaultAddr][addr] = &state
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return states, nil
+}
+
 func (s *ImmutableState) PendingAction(ctx context.Context, vaultAddr staking.Address, nonce uint64) (*vault.PendingAction, error) {
 	raw, err := s.is.Get(ctx, pendingActionsKeyFmt.Encode(vaultAddr, nonce))
 	if err != nil {
@@ -170,6 +198,28 @@ func (s *ImmutableState) PendingActions(ctx context.Context, vaultAddr staking.A
 	return actions, nil
 }
 
+// AllPendingActions returns the pending actions of all vaults.
+func (s *ImmutableState) AllPendingActions(ctx context.Context) (map[staking.Address][]*vault.PendingAction, error) {
+	actions := make(map[staking.Address][]*vault.PendingAction)
+	err := s.is.Iterate(ctx, pendingActionsKeyFmt.Encode(), func(key, value []byte) error {
+		var vaultAddr staking.Address
+		if !pendingActionsKeyFmt.Decode(key, &vaultAddr) {
+			return nil
+		}
+
+		var pa vault.PendingAction
+		if err := cbor.Unmarshal(value, &pa); err != nil {
+			return api.UnavailableStateError(err)
+		}
+		actions[vaultAddr] = append(actions[vaultAddr], &pa)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return actions, nil
+}
+
 // ConsensusParameters returns the vault consensus parameters.
 func (s *ImmutableState) ConsensusParameters(ctx context.Context) (*vault.ConsensusParameters, error) {
 	raw, err := s.is.Get(ctx, parametersKeyFmt.Encode())
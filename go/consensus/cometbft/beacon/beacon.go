@@ -230,6 +230,44 @@ func (sc *serviceClient) GetVRFState(ctx context.Context, height int64) (*beacon
 	return q.VRFState(ctx)
 }
 
+func (sc *serviceClient) GetVRFProofs(ctx context.Context, height int64) (*beaconAPI.VRFProofs, error) {
+	state, err := sc.GetVRFState(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		return nil, beaconAPI.ErrBeaconNotAvailable
+	}
+
+	proofs := beaconAPI.VRFProofs{
+		Epoch:              state.Epoch,
+		Alpha:              state.Alpha,
+		AlphaIsHighQuality: state.AlphaIsHighQuality,
+	}
+	if state.PrevState == nil {
+		// No proofs were accumulated before the first epoch.
+		return &proofs, nil
+	}
+	proofs.Contributions = beaconAPI.NewVRFContributions(state.PrevState.Pi)
+
+	// The proofs were generated over the alpha of the previous epoch, which
+	// is available in the state right before the epoch transition.
+	epochHeight, err := sc.GetEpochBlock(ctx, state.Epoch)
+	if err != nil {
+		return nil, fmt.Errorf("beacon: failed to query epoch block: %w", err)
+	}
+	prevState, err := sc.GetVRFState(ctx, epochHeight-1)
+	if err != nil {
+		return nil, fmt.Errorf("beacon: failed to query previous VRF state: %w", err)
+	}
+	if prevState == nil || prevState.Epoch+1 != state.Epoch {
+		return nil, fmt.Errorf("beacon: previous VRF state not available")
+	}
+	proofs.PrevAlpha = prevState.Alpha
+
+	return &proofs, nil
+}
+
 func (sc *serviceClient) WatchLatestVRFEvent(context.Context) (<-chan *beaconAPI.VRFEvent, *pubsub.Subscription, error) {
 	typedCh := make(chan *beaconAPI.VRFEvent)
 	sub := sc.vrfNotifier.Subscribe()
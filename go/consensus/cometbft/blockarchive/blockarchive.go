@@ -0,0 +1,293 @@
+// Package blockarchive implements archival of pruned CometBFT blocks.
+//
+// When consensus blocks are pruned, they can optionally be streamed to an archival sink together
+// with their results so that occasional historical queries can still be answered from the archive.
+package blockarchive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	cmtstateproto "github.com/cometbft/cometbft/proto/tendermint/state"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmtstate "github.com/cometbft/cometbft/state"
+	"github.com/cometbft/cometbft/store"
+	cmttypes "github.com/cometbft/cometbft/types"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+)
+
+var (
+	// ErrNotFound is the error returned when a block is not available in the archive.
+	ErrNotFound = errors.New("blockarchive: block not found")
+
+	// ErrNotArchived is the error returned by the prune handler when a block has not yet been
+	// archived and must not be pruned.
+	ErrNotArchived = errors.New("blockarchive: block not archived yet")
+)
+
+// Index is the index of archived blocks.
+type Index struct {
+	// EarliestHeight is the height of the earliest archived block.
+	EarliestHeight int64 `json:"earliest_height"`
+	// LatestHeight is the height of the latest archived block.
+	LatestHeight int64 `json:"latest_height"`
+}
+
+// Contains returns true iff the given height is within the range of archived blocks.
+func (idx *Index) Contains(height int64) bool {
+	return idx.LatestHeight > 0 && height >= idx.EarliestHeight && height <= idx.LatestHeight
+}
+
+// Sink is an archival sink for pruned blocks.
+type Sink interface {
+	// Put stores the serialized block record at the given height.
+	//
+	// Storing the same block multiple times must be supported.
+	Put(height int64, data []byte) error
+
+	// Get returns the serialized block record at the given height.
+	//
+	// If the block is not available, ErrNotFound is returned.
+	Get(height int64) ([]byte, error)
+
+	// Index returns the index of archived blocks.
+	Index() (*Index, error)
+}
+
+// record is an archived block together with its results.
+type record struct {
+	// Block is the protobuf-encoded block.
+	Block []byte `json:"block"`
+	// Results is the protobuf-encoded ABCI responses of the block, if available.
+	Results []byte `json:"results,omitempty"`
+}
+
+// Archive is a CometBFT block archive backed by an archival sink.
+type Archive struct {
+	sink Sink
+
+	logger *logging.Logger
+}
+
+// ArchiveBlock archives the given block together with its results.
+//
+// The results may be nil in case they are not available.
+func (a *Archive) ArchiveBlock(blk *cmttypes.Block, results *cmtstateproto.ABCIResponses) error {
+	pb, err := blk.ToProto()
+	if err != nil {
+		return fmt.Errorf("blockarchive: failed to convert block: %w", err)
+	}
+
+	var rec record
+	if rec.Block, err = pb.Marshal(); err != nil {
+		return fmt.Errorf("blockarchive: failed to marshal block: %w", err)
+	}
+	if results != nil {
+		if rec.Results, err = results.Marshal(); err != nil {
+			return fmt.Errorf("blockarchive: failed to marshal block results: %w", err)
+		}
+	}
+	return a.sink.Put(blk.Height, cbor.Marshal(&rec))
+}
+
+func (a *Archive) getRecord(height int64) (*record, error) {
+	data, err := a.sink.Get(height)
+	if err != nil {
+		return nil, err
+	}
+
+	var rec record
+	if err = cbor.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("blockarchive: malformed record: %w", err)
+	}
+	return &rec, nil
+}
+
+// GetBlock returns the archived block at the given height.
+//
+// If the block is not available, ErrNotFound is returned.
+func (a *Archive) GetBlock(height int64) (*cmttypes.Block, error) {
+	rec, err := a.getRecord(height)
+	if err != nil {
+		return nil, err
+	}
+
+	var pb cmtproto.Block
+	if err = pb.Unmarshal(rec.Block); err != nil {
+		return nil, fmt.Errorf("blockarchive: failed to unmarshal block: %w", err)
+	}
+	blk, err := cmttypes.BlockFromProto(&pb)
+	if err != nil {
+		return nil, fmt.Errorf("blockarchive: malformed block: %w", err)
+	}
+	if blk.Height != height {
+		return nil, fmt.Errorf("blockarchive: archived block has unexpected height %d (expected: %d)", blk.Height, height)
+	}
+	return blk, nil
+}
+
+// GetBlockResults returns the archived results of the block at the given height.
+//
+// If the block results are not available, ErrNotFound is returned.
+func (a *Archive) GetBlockResults(height int64) (*cmtstateproto.ABCIResponses, error) {
+	rec, err := a.getRecord(height)
+	if err != nil {
+		return nil, err
+	}
+	if rec.Results == nil {
+		return nil, ErrNotFound
+	}
+
+	var results cmtstateproto.ABCIResponses
+	if err = results.Unmarshal(rec.Results); err != nil {
+		return nil, fmt.Errorf("blockarchive: failed to unmarshal block results: %w", err)
+	}
+	return &results, nil
+}
+
+// Index returns the index of archived blocks.
+func (a *Archive) Index() (*Index, error) {
+	return a.sink.Index()
+}
+
+// PruneHandler returns a state prune handler that makes sure that blocks from the given block
+// store are archived together with their results from the given state store before they are
+// pruned.
+//
+// Blocks are archived in the background until the context is canceled. Pruning is blocked
+// until the pruned blocks have been archived, so that no block is discarded without first being
+// archived.
+func (a *Archive) PruneHandler(ctx context.Context, blockStore *store.BlockStore, stateStore cmtstate.Store) (api.StatePruneHandler, error) {
+	index, err := a.Index()
+	if err != nil {
+		return nil, err
+	}
+
+	h := &pruneHandler{
+		archive:    a,
+		blockStore: blockStore,
+		stateStore: stateStore,
+		notifyCh:   make(chan struct{}, 1),
+	}
+	if index.LatestHeight > 0 {
+		h.nextHeight = index.LatestHeight + 1
+	}
+	go h.worker(ctx)
+
+	return h, nil
+}
+
+type pruneHandler struct {
+	sync.Mutex
+
+	archive    *Archive
+	blockStore *store.BlockStore
+	stateStore cmtstate.Store
+
+	// nextHeight is the height of the next block to be archived, zero if not yet known.
+	nextHeight int64
+	notifyCh   chan struct{}
+}
+
+// Implements api.StatePruneHandler.
+func (h *pruneHandler) Prune(version uint64) error {
+	height := int64(version)
+
+	h.Lock()
+	defer h.Unlock()
+
+	if h.nextHeight == 0 {
+		// Start archiving from the first pruned block.
+		h.nextHeight = height
+	}
+	if height < h.nextHeight {
+		return nil
+	}
+
+	select {
+	case h.notifyCh <- struct{}{}:
+	default:
+	}
+	return ErrNotArchived
+}
+
+func (h *pruneHandler) worker(ctx context.Context) {
+	for {
+		select {
+		case <-h.notifyCh:
+		case <-ctx.Done():
+			return
+		}
+
+		h.archivePending(ctx)
+	}
+}
+
+// archivePending archives all blocks that have not yet been archived.
+func (h *pruneHandler) archivePending(ctx context.Context) {
+	h.Lock()
+	height := h.nextHeight
+	h.Unlock()
+
+	// The results of the latest block may not have been persisted yet.
+	latestHeight := h.blockStore.Height() - 1
+
+	for ; height <= latestHeight; height++ {
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err := h.archiveHeight(height); err != nil {
+			// Archival will be retried on the next prune attempt.
+			h.archive.logger.Error("failed to archive block",
+				"err", err,
+				"height", height,
+			)
+			return
+		}
+
+		h.Lock()
+		h.nextHeight = height + 1
+		h.Unlock()
+	}
+}
+
+func (h *pruneHandler) archiveHeight(height int64) error {
+	blk := h.blockStore.LoadBlock(height)
+	if blk == nil {
+		// Block is not available (e.g., the node was state synced), nothing to archive.
+		return nil
+	}
+
+	results, err := h.stateStore.LoadABCIResponses(height)
+	switch {
+	case err == nil:
+	case errors.Is(err, cmtstate.ErrABCIResponsesNotPersisted), errors.As(err, &cmtstate.ErrNoABCIResponsesForHeight{}):
+		// Results are not available, archive the block alone.
+		results = nil
+	default:
+		return fmt.Errorf("blockarchive: failed to load block results: %w", err)
+	}
+
+	if err = h.archive.ArchiveBlock(blk, results); err != nil {
+		return err
+	}
+
+	h.archive.logger.Debug("archived block",
+		"height", height,
+	)
+	return nil
+}
+
+// New creates a new block archive backed by the given archival sink.
+func New(sink Sink) *Archive {
+	return &Archive{
+		sink:   sink,
+		logger: logging.GetLogger("cometbft/blockarchive"),
+	}
+}
@@ -0,0 +1,142 @@
+package blockarchive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+)
+
+const (
+	// fileSinkBlocksDir is the name of the directory holding archived blocks.
+	fileSinkBlocksDir = "blocks"
+	// fileSinkIndexFile is the name of the index file.
+	fileSinkIndexFile = "index.json"
+	// fileSinkBlocksPerDir is the number of blocks stored in a single subdirectory.
+	fileSinkBlocksPerDir = 10_000
+)
+
+type fileSink struct {
+	sync.Mutex
+
+	dir   string
+	index Index
+}
+
+func (s *fileSink) blockPath(height int64) string {
+	return filepath.Join(
+		s.dir,
+		fileSinkBlocksDir,
+		strconv.FormatInt(height/fileSinkBlocksPerDir, 10),
+		strconv.FormatInt(height, 10),
+	)
+}
+
+func (s *fileSink) indexPath() string {
+	return filepath.Join(s.dir, fileSinkIndexFile)
+}
+
+// Implements Sink.
+func (s *fileSink) Put(height int64, data []byte) error {
+	if height <= 0 {
+		return fmt.Errorf("blockarchive: invalid height: %d", height)
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	fn := s.blockPath(height)
+	if err := os.MkdirAll(filepath.Dir(fn), 0o700); err != nil {
+		return fmt.Errorf("blockarchive: failed to create directory: %w", err)
+	}
+	if err := writeFileAtomic(fn, data); err != nil {
+		return fmt.Errorf("blockarchive: failed to write block: %w", err)
+	}
+
+	// Update the index.
+	index := s.index
+	if index.LatestHeight == 0 || height < index.EarliestHeight {
+		index.EarliestHeight = height
+	}
+	if height > index.LatestHeight {
+		index.LatestHeight = height
+	}
+	if index == s.index {
+		return nil
+	}
+
+	raw, err := json.Marshal(&index)
+	if err != nil {
+		return fmt.Errorf("blockarchive: failed to marshal index: %w", err)
+	}
+	if err = writeFileAtomic(s.indexPath(), raw); err != nil {
+		return fmt.Errorf("blockarchive: failed to write index: %w", err)
+	}
+	s.index = index
+
+	return nil
+}
+
+// Implements Sink.
+func (s *fileSink) Get(height int64) ([]byte, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	if !s.index.Contains(height) {
+		return nil, ErrNotFound
+	}
+
+	data, err := os.ReadFile(s.blockPath(height))
+	switch {
+	case err == nil:
+		return data, nil
+	case errors.Is(err, os.ErrNotExist):
+		return nil, ErrNotFound
+	default:
+		return nil, fmt.Errorf("blockarchive: failed to read block: %w", err)
+	}
+}
+
+// Implements Sink.
+func (s *fileSink) Index() (*Index, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	index := s.index
+	return &index, nil
+}
+
+func writeFileAtomic(fn string, data []byte) error {
+	tmpFn := fn + ".tmp"
+	if err := os.WriteFile(tmpFn, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmpFn, fn)
+}
+
+// NewFileSink creates a new archival sink storing blocks in the given local directory.
+func NewFileSink(dir string) (Sink, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("blockarchive: failed to create archive directory: %w", err)
+	}
+
+	s := &fileSink{
+		dir: dir,
+	}
+	raw, err := os.ReadFile(s.indexPath())
+	switch {
+	case err == nil:
+		if err = json.Unmarshal(raw, &s.index); err != nil {
+			return nil, fmt.Errorf("blockarchive: malformed index: %w", err)
+		}
+	case errors.Is(err, os.ErrNotExist):
+		// No blocks archived yet.
+	default:
+		return nil, fmt.Errorf("blockarchive: failed to read index: %w", err)
+	}
+
+	return s, nil
+}
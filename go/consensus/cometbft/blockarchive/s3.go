@@ -0,0 +1,304 @@
+package blockarchive
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// s3RequestTimeout is the timeout of a single S3 request.
+	s3RequestTimeout = 30 * time.Second
+	// s3MaxObjectSize is the maximum size of an object fetched from S3.
+	s3MaxObjectSize = 128 * 1024 * 1024
+
+	s3Algorithm  = "AWS4-HMAC-SHA256"
+	s3Service    = "s3"
+	s3DateFormat = "20060102T150405Z"
+)
+
+// S3Config is the configuration of an S3 archival sink.
+type S3Config struct {
+	// Endpoint is the S3 endpoint URL (e.g., https://s3.us-east-1.amazonaws.com).
+	Endpoint string
+	// Region is the S3 region used for signing requests.
+	Region string
+	// Bucket is the name of the bucket where blocks are archived.
+	Bucket string
+	// Prefix is the key prefix under which blocks are archived.
+	Prefix string
+
+	// AccessKeyID is the access key identifier.
+	AccessKeyID string
+	// SecretAccessKey is the secret access key.
+	SecretAccessKey string
+}
+
+type s3Sink struct {
+	sync.Mutex
+
+	cfg      S3Config
+	endpoint *url.URL
+	client   *http.Client
+
+	index Index
+
+	now func() time.Time
+}
+
+func (s *s3Sink) blockKey(height int64) string {
+	return path.Join(
+		s.cfg.Prefix,
+		fileSinkBlocksDir,
+		strconv.FormatInt(height/fileSinkBlocksPerDir, 10),
+		strconv.FormatInt(height, 10),
+	)
+}
+
+func (s *s3Sink) indexKey() string {
+	return path.Join(s.cfg.Prefix, fileSinkIndexFile)
+}
+
+// Implements Sink.
+func (s *s3Sink) Put(height int64, data []byte) error {
+	if height <= 0 {
+		return fmt.Errorf("blockarchive: invalid height: %d", height)
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	if err := s.putObject(s.blockKey(height), data); err != nil {
+		return fmt.Errorf("blockarchive: failed to write block: %w", err)
+	}
+
+	// Update the index.
+	index := s.index
+	if index.LatestHeight == 0 || height < index.EarliestHeight {
+		index.EarliestHeight = height
+	}
+	if height > index.LatestHeight {
+		index.LatestHeight = height
+	}
+	if index == s.index {
+		return nil
+	}
+
+	raw, err := json.Marshal(&index)
+	if err != nil {
+		return fmt.Errorf("blockarchive: failed to marshal index: %w", err)
+	}
+	if err = s.putObject(s.indexKey(), raw); err != nil {
+		return fmt.Errorf("blockarchive: failed to write index: %w", err)
+	}
+	s.index = index
+
+	return nil
+}
+
+// Implements Sink.
+func (s *s3Sink) Get(height int64) ([]byte, error) {
+	s.Lock()
+	contains := s.index.Contains(height)
+	s.Unlock()
+
+	if !contains {
+		return nil, ErrNotFound
+	}
+
+	data, err := s.getObject(s.blockKey(height))
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Implements Sink.
+func (s *s3Sink) Index() (*Index, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	index := s.index
+	return &index, nil
+}
+
+func (s *s3Sink) putObject(key string, data []byte) error {
+	rsp, err := s.do(http.MethodPut, key, data)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+	_, _ = io.Copy(io.Discard, rsp.Body)
+
+	if rsp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", rsp.StatusCode)
+	}
+	return nil
+}
+
+func (s *s3Sink) getObject(key string) ([]byte, error) {
+	rsp, err := s.do(http.MethodGet, key, nil)
+	if err != nil {
+		return nil, fmt.Errorf("blockarchive: failed to read object: %w", err)
+	}
+	defer rsp.Body.Close()
+
+	switch rsp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, fmt.Errorf("blockarchive: failed to read object: unexpected status code: %d", rsp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(rsp.Body, s3MaxObjectSize))
+	if err != nil {
+		return nil, fmt.Errorf("blockarchive: failed to read object: %w", err)
+	}
+	return data, nil
+}
+
+func (s *s3Sink) do(method, key string, body []byte) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s3RequestTimeout)
+
+	u := *s.endpoint
+	u.Path = path.Join("/", s.endpoint.Path, s.cfg.Bucket, key)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	req.ContentLength = int64(len(body))
+	s.sign(req, body)
+
+	rsp, err := s.client.Do(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	rsp.Body = &cancelReadCloser{rsp.Body, cancel}
+	return rsp, nil
+}
+
+// sign signs the given request using AWS Signature Version 4.
+func (s *s3Sink) sign(req *http.Request, body []byte) {
+	now := s.now().UTC()
+	amzDate := now.Format(s3DateFormat)
+	date := amzDate[:8]
+
+	payloadHash := sha256.Sum256(body)
+	payloadHashHex := hex.EncodeToString(payloadHash[:])
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHashHex)
+
+	const signedHeaders = "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		s3EscapePath(req.URL.Path),
+		"",
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + payloadHashHex,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		payloadHashHex,
+	}, "\n")
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+
+	scope := strings.Join([]string{date, s.cfg.Region, s3Service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		amzDate,
+		scope,
+		hex.EncodeToString(canonicalRequestHash[:]),
+	}, "\n")
+
+	key := s3HMAC([]byte("AWS4"+s.cfg.SecretAccessKey), date)
+	key = s3HMAC(key, s.cfg.Region)
+	key = s3HMAC(key, s3Service)
+	key = s3HMAC(key, "aws4_request")
+	signature := hex.EncodeToString(s3HMAC(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.cfg.AccessKeyID, scope, signedHeaders, signature,
+	))
+}
+
+func s3HMAC(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	_, _ = h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// s3EscapePath escapes the given path as required by AWS Signature Version 4.
+func s3EscapePath(p string) string {
+	var b strings.Builder
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9':
+			b.WriteByte(c)
+		case c == '-', c == '_', c == '.', c == '~', c == '/':
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+type cancelReadCloser struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (r *cancelReadCloser) Close() error {
+	defer r.cancel()
+	return r.ReadCloser.Close()
+}
+
+// NewS3Sink creates a new archival sink storing blocks in an S3-compatible object store.
+func NewS3Sink(cfg S3Config) (Sink, error) {
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("blockarchive: missing S3 bucket")
+	}
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("blockarchive: malformed S3 endpoint: %s", cfg.Endpoint)
+	}
+	// Make sure the escaped path matches the path used for signing.
+	endpoint.RawPath = ""
+
+	s := &s3Sink{
+		cfg:      cfg,
+		endpoint: endpoint,
+		client:   &http.Client{},
+		now:      time.Now,
+	}
+	raw, err := s.getObject(s.indexKey())
+	switch err {
+	case nil:
+		if err = json.Unmarshal(raw, &s.index); err != nil {
+			return nil, fmt.Errorf("blockarchive: malformed index: %w", err)
+		}
+	case ErrNotFound:
+		// No blocks archived yet.
+	default:
+		return nil, fmt.Errorf("blockarchive: failed to read index: %w", err)
+	}
+
+	return s, nil
+}
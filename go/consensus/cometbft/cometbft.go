@@ -6,6 +6,8 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	"github.com/oasisprotocol/oasis-core/go/config"
 	consensusAPI "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/backend"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/full"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/light"
 	lightAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/light/api"
@@ -42,3 +44,24 @@ func NewLightClient(
 ) (lightAPI.ClientService, error) {
 	return light.New(ctx, dataDir, genesis, consensus, p2p)
 }
+
+type factory struct{}
+
+// Implements backend.Factory.
+func (factory) Name() string {
+	return api.BackendName
+}
+
+// Implements backend.Factory.
+func (factory) New(ctx context.Context, args *backend.Args) (consensusAPI.Backend, error) {
+	return New(ctx, args.DataDir, args.Identity, args.Upgrader, args.GenesisProvider)
+}
+
+// Implements backend.Factory.
+func (factory) NewLightClient(ctx context.Context, args *backend.LightClientArgs) (consensusAPI.LightService, error) {
+	return NewLightClient(ctx, args.DataDir, args.Genesis, args.Consensus, args.P2P)
+}
+
+func init() {
+	backend.Register(factory{})
+}
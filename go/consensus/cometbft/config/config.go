@@ -8,6 +8,10 @@ import (
 
 // Config is the CometBFT configuration structure.
 type Config struct {
+	// Backend is the name of the consensus backend to use. If not set, the backend specified in
+	// the genesis document is used.
+	Backend string `yaml:"backend,omitempty"`
+
 	// Node is a consensus validator.
 	// This additional option exists because it is currently possible to
 	// run a node that is simultaneously a validator and a compute node.
@@ -30,6 +34,9 @@ type Config struct {
 	// Transaction submission configuration.
 	Submission SubmissionConfig `yaml:"submission,omitempty"`
 
+	// Mempool configuration.
+	Mempool MempoolConfig `yaml:"mempool,omitempty"`
+
 	// Epoch at which to force-shutdown the node (in epochs, zero disables shutdown).
 	HaltEpoch uint64 `yaml:"halt_epoch,omitempty"`
 
@@ -48,9 +55,18 @@ type Config struct {
 	// Consensus state sync configuration.
 	StateSync StateSyncConfig `yaml:"state_sync,omitempty"`
 
+	// State sync snapshot provider configuration.
+	SnapshotProvider SnapshotProviderConfig `yaml:"snapshot_provider,omitempty"`
+
 	// Supplementary sanity checks configuration.
 	SupplementarySanity SupplementarySanityConfig `yaml:"supplementary_sanity,omitempty"`
 
+	// Clock skew detection configuration.
+	ClockSkew ClockSkewConfig `yaml:"clock_skew,omitempty"`
+
+	// Low-level consensus engine configuration.
+	Engine EngineConfig `yaml:"engine,omitempty"`
+
 	// Enable CometBFT debug logs (very verbose).
 	LogDebug bool `yaml:"log_debug,omitempty"`
 
@@ -87,6 +103,20 @@ type SubmissionConfig struct {
 	MaxFee uint64 `yaml:"max_fee"`
 }
 
+// MempoolConfig is the CometBFT mempool configuration structure.
+type MempoolConfig struct {
+	// Maximum number of transactions in the mempool.
+	Size int `yaml:"size"`
+	// Maximum total size of all transactions in the mempool (in bytes).
+	MaxTxsBytes int64 `yaml:"max_txs_bytes"`
+	// Size of the cache of recently seen transactions.
+	CacheSize int `yaml:"cache_size"`
+	// Order pending transactions by fee per gas unit (within the same application).
+	FeeOrdering bool `yaml:"fee_ordering"`
+	// Maximum number of pending transactions per sender (zero means no limit).
+	MaxTxsPerSender uint64 `yaml:"max_txs_per_sender"`
+}
+
 const (
 	// PruneStrategyNone is the identifier of the strategy that disables pruning.
 	PruneStrategyNone = "none"
@@ -104,6 +134,24 @@ type PruneConfig struct {
 	Interval time.Duration `yaml:"interval"`
 	// Light blocks kept in trusted store.
 	NumLightBlocksKept uint16 `yaml:"num_light_blocks_kept"`
+	// Directory where blocks are archived before being pruned, so that historical queries can
+	// still be answered from the archive. Empty disables block archival.
+	ArchiveDir string `yaml:"archive_dir,omitempty"`
+	// S3-compatible object store where blocks are archived before being pruned. Credentials are
+	// taken from the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables.
+	ArchiveS3 ArchiveS3Config `yaml:"archive_s3,omitempty"`
+}
+
+// ArchiveS3Config is the S3 block archival sink configuration structure.
+type ArchiveS3Config struct {
+	// S3 endpoint URL.
+	Endpoint string `yaml:"endpoint"`
+	// S3 region.
+	Region string `yaml:"region"`
+	// Bucket where blocks are archived. Empty disables S3 block archival.
+	Bucket string `yaml:"bucket"`
+	// Key prefix under which blocks are archived.
+	Prefix string `yaml:"prefix,omitempty"`
 }
 
 // CheckpointerConfig is the CometBFT ABCI state pruning configuration structure.
@@ -126,6 +174,21 @@ type StateSyncConfig struct {
 	TrustHash string `yaml:"trust_hash"`
 }
 
+// SnapshotProviderConfig is the state sync snapshot provider configuration structure.
+type SnapshotProviderConfig struct {
+	// Disable serving ABCI state snapshots to state sync peers.
+	Disabled bool `yaml:"disabled"`
+	// Minimum number of snapshots to keep available (consensus parameters take precedence if higher).
+	NumKept uint64 `yaml:"num_kept"`
+	// Number of blocks between created snapshots, rounded up to a multiple of the consensus state
+	// checkpoint interval (zero means the consensus state checkpoint interval).
+	Interval uint64 `yaml:"interval"`
+	// Maximum number of snapshot chunks served concurrently (zero means no limit).
+	MaxConcurrentChunks uint64 `yaml:"max_concurrent_chunks"`
+	// Maximum snapshot chunk serving bandwidth (bytes/s, zero means no limit).
+	MaxBandwidth uint64 `yaml:"max_bandwidth"`
+}
+
 // SupplementarySanityConfig is the supplementary sanity configuration structure.
 type SupplementarySanityConfig struct {
 	// Enable supplementary sanity checks (slows down consensus).
@@ -134,6 +197,15 @@ type SupplementarySanityConfig struct {
 	Interval uint64 `yaml:"interval"`
 }
 
+// ClockSkewConfig is the clock skew detection configuration structure.
+type ClockSkewConfig struct {
+	// Clock skew above which a warning is emitted (zero disables warnings).
+	WarnThreshold time.Duration `yaml:"warn_threshold"`
+	// Clock skew above which the node refuses to sign consensus votes and proposals
+	// (zero disables refusal).
+	RefuseThreshold time.Duration `yaml:"refuse_threshold"`
+}
+
 // DebugConfig is the debug configuration structure.
 type DebugConfig struct {
 	// Allow non-routable addresses in P2P address book.
@@ -164,6 +236,23 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("p2p.recv_rate must be >= 0")
 	}
 
+	if c.Mempool.Size < 1 {
+		return fmt.Errorf("mempool.size must be >= 1")
+	}
+	if c.Mempool.MaxTxsBytes < 1 {
+		return fmt.Errorf("mempool.max_txs_bytes must be >= 1")
+	}
+	if c.Mempool.CacheSize < 0 {
+		return fmt.Errorf("mempool.cache_size must be >= 0")
+	}
+
+	if c.Prune.ArchiveDir != "" && c.Prune.ArchiveS3.Bucket != "" {
+		return fmt.Errorf("only one of {prune.archive_dir, prune.archive_s3} can be set")
+	}
+	if c.Prune.ArchiveS3.Bucket != "" && (c.Prune.ArchiveS3.Endpoint == "" || c.Prune.ArchiveS3.Region == "") {
+		return fmt.Errorf("prune.archive_s3 requires endpoint and region to be set")
+	}
+
 	if c.HaltHeight > 0 && c.HaltEpoch > 0 {
 		return fmt.Errorf("only one of {halt_epoch, halt_height} can be set")
 	}
@@ -180,6 +269,14 @@ func (c *Config) Validate() error {
 	if c.SupplementarySanity.Enabled && c.SupplementarySanity.Interval < 1 {
 		return fmt.Errorf("supplementary_sanity.interval must be >= 1")
 	}
+
+	if c.ClockSkew.WarnThreshold < 0 || c.ClockSkew.RefuseThreshold < 0 {
+		return fmt.Errorf("clock_skew thresholds must be >= 0")
+	}
+
+	if err := c.Engine.Validate(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -205,6 +302,13 @@ func DefaultConfig() Config {
 			GasPrice: 0,
 			MaxFee:   10_000_000_000,
 		},
+		Mempool: MempoolConfig{
+			Size:            5000,
+			MaxTxsBytes:     1024 * 1024 * 1024,
+			CacheSize:       10000,
+			FeeOrdering:     true,
+			MaxTxsPerSender: 64,
+		},
 		HaltEpoch:        0,
 		HaltHeight:       0,
 		UpgradeStopDelay: 60 * time.Second,
@@ -224,10 +328,24 @@ func DefaultConfig() Config {
 			TrustHeight: 0,
 			TrustHash:   "",
 		},
+		SnapshotProvider: SnapshotProviderConfig{
+			Disabled:            false,
+			NumKept:             0,
+			Interval:            0,
+			MaxConcurrentChunks: 0,
+			MaxBandwidth:        0,
+		},
 		SupplementarySanity: SupplementarySanityConfig{
 			Enabled:  false,
 			Interval: 10,
 		},
+		ClockSkew: ClockSkewConfig{
+			WarnThreshold:   5 * time.Second,
+			RefuseThreshold: 0,
+		},
+		Engine: EngineConfig{
+			Profile: EngineProfileDefault,
+		},
 		LogDebug: false,
 		Debug: DebugConfig{
 			P2PAddrBookLenient:              false,
@@ -0,0 +1,154 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	// EngineProfileDefault is the name of the default consensus engine profile.
+	EngineProfileDefault = "default"
+	// EngineProfileLowLatency is the name of the consensus engine profile that trades bandwidth
+	// for lower message propagation latency (e.g., for validators with well connected sentries).
+	EngineProfileLowLatency = "low_latency"
+	// EngineProfileLowBandwidth is the name of the consensus engine profile that trades message
+	// propagation latency for lower bandwidth use (e.g., for non-validator nodes).
+	EngineProfileLowBandwidth = "low_bandwidth"
+)
+
+// engineProfiles are the consensus engine profile presets.
+var engineProfiles = map[string]EngineConfig{
+	EngineProfileDefault: {
+		P2P: EngineP2PConfig{
+			HandshakeTimeout:     20 * time.Second,
+			DialTimeout:          3 * time.Second,
+			FlushThrottleTimeout: 100 * time.Millisecond,
+		},
+		Consensus: EngineConsensusConfig{
+			PeerGossipSleepDuration:     100 * time.Millisecond,
+			PeerQueryMaj23SleepDuration: 2 * time.Second,
+		},
+	},
+	EngineProfileLowLatency: {
+		P2P: EngineP2PConfig{
+			HandshakeTimeout:     20 * time.Second,
+			DialTimeout:          3 * time.Second,
+			FlushThrottleTimeout: 10 * time.Millisecond,
+		},
+		Consensus: EngineConsensusConfig{
+			PeerGossipSleepDuration:     10 * time.Millisecond,
+			PeerQueryMaj23SleepDuration: 1 * time.Second,
+		},
+	},
+	EngineProfileLowBandwidth: {
+		P2P: EngineP2PConfig{
+			HandshakeTimeout:     30 * time.Second,
+			DialTimeout:          5 * time.Second,
+			FlushThrottleTimeout: 300 * time.Millisecond,
+		},
+		Consensus: EngineConsensusConfig{
+			PeerGossipSleepDuration:     300 * time.Millisecond,
+			PeerQueryMaj23SleepDuration: 5 * time.Second,
+		},
+	},
+}
+
+// EngineProfiles returns the names of all supported consensus engine profiles.
+func EngineProfiles() []string {
+	names := make([]string, 0, len(engineProfiles))
+	for name := range engineProfiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// EngineConfig is the low-level consensus engine (CometBFT) configuration structure.
+//
+// Settings that are not set explicitly are taken from the selected profile.
+type EngineConfig struct {
+	// Name of the profile preset to use (default, low_latency, low_bandwidth).
+	Profile string `yaml:"profile,omitempty"`
+
+	// CometBFT P2P connection configuration.
+	P2P EngineP2PConfig `yaml:"p2p,omitempty"`
+
+	// CometBFT consensus reactor configuration.
+	Consensus EngineConsensusConfig `yaml:"consensus,omitempty"`
+}
+
+// EngineP2PConfig is the CometBFT P2P connection configuration structure.
+type EngineP2PConfig struct {
+	// Peer connection handshake timeout.
+	HandshakeTimeout time.Duration `yaml:"handshake_timeout,omitempty"`
+	// Peer dial timeout.
+	DialTimeout time.Duration `yaml:"dial_timeout,omitempty"`
+	// Time to wait before flushing messages out on a connection.
+	FlushThrottleTimeout time.Duration `yaml:"flush_throttle_timeout,omitempty"`
+}
+
+// EngineConsensusConfig is the CometBFT consensus reactor configuration structure.
+type EngineConsensusConfig struct {
+	// Sleep duration between consensus data gossip rounds.
+	PeerGossipSleepDuration time.Duration `yaml:"peer_gossip_sleep_duration,omitempty"`
+	// Sleep duration between queries for +2/3 majority votes.
+	PeerQueryMaj23SleepDuration time.Duration `yaml:"peer_query_maj23_sleep_duration,omitempty"`
+}
+
+// Resolve returns the effective engine configuration, with all settings that are not set
+// explicitly taken from the selected profile.
+func (c *EngineConfig) Resolve() (*EngineConfig, error) {
+	profile := c.Profile
+	if profile == "" {
+		profile = EngineProfileDefault
+	}
+	preset, ok := engineProfiles[profile]
+	if !ok {
+		return nil, fmt.Errorf("engine.profile: unknown profile '%s' (supported: %v)", c.Profile, EngineProfiles())
+	}
+
+	resolved := preset
+	resolved.Profile = profile
+	if c.P2P.HandshakeTimeout != 0 {
+		resolved.P2P.HandshakeTimeout = c.P2P.HandshakeTimeout
+	}
+	if c.P2P.DialTimeout != 0 {
+		resolved.P2P.DialTimeout = c.P2P.DialTimeout
+	}
+	if c.P2P.FlushThrottleTimeout != 0 {
+		resolved.P2P.FlushThrottleTimeout = c.P2P.FlushThrottleTimeout
+	}
+	if c.Consensus.PeerGossipSleepDuration != 0 {
+		resolved.Consensus.PeerGossipSleepDuration = c.Consensus.PeerGossipSleepDuration
+	}
+	if c.Consensus.PeerQueryMaj23SleepDuration != 0 {
+		resolved.Consensus.PeerQueryMaj23SleepDuration = c.Consensus.PeerQueryMaj23SleepDuration
+	}
+	return &resolved, nil
+}
+
+// Validate validates the engine configuration settings.
+func (c *EngineConfig) Validate() error {
+	if _, err := c.Resolve(); err != nil {
+		return err
+	}
+
+	if c.P2P.HandshakeTimeout < 0 {
+		return fmt.Errorf("engine.p2p.handshake_timeout: must be >= 0")
+	}
+	if c.P2P.DialTimeout < 0 {
+		return fmt.Errorf("engine.p2p.dial_timeout: must be >= 0")
+	}
+	if c.P2P.FlushThrottleTimeout < 0 {
+		return fmt.Errorf("engine.p2p.flush_throttle_timeout: must be >= 0")
+	}
+
+	if c.Consensus.PeerGossipSleepDuration < 0 {
+		return fmt.Errorf("engine.consensus.peer_gossip_sleep_duration: must be >= 0")
+	}
+	if c.Consensus.PeerQueryMaj23SleepDuration < 0 {
+		return fmt.Errorf("engine.consensus.peer_query_maj23_sleep_duration: must be >= 0")
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestEngineConfig(t *testing.T) {
+	require := require.New(t)
+
+	// Defaults.
+	cfg := DefaultConfig()
+	require.NoError(cfg.Validate(), "default configuration should be valid")
+	resolved, err := cfg.Engine.Resolve()
+	require.NoError(err, "Resolve")
+	require.EqualValues(engineProfiles[EngineProfileDefault].P2P, resolved.P2P)
+	require.EqualValues(engineProfiles[EngineProfileDefault].Consensus, resolved.Consensus)
+
+	// Profile with overrides.
+	yamlCfg := `
+profile: low_latency
+p2p:
+  dial_timeout: 10s
+`
+	var engineCfg EngineConfig
+	err = yaml.Unmarshal([]byte(yamlCfg), &engineCfg)
+	require.NoError(err, "yaml.Unmarshal")
+	require.NoError(engineCfg.Validate(), "Validate")
+
+	resolved, err = engineCfg.Resolve()
+	require.NoError(err, "Resolve")
+	require.Equal(EngineProfileLowLatency, resolved.Profile)
+	require.Equal(10*time.Second, resolved.P2P.DialTimeout, "explicit setting should override the profile")
+	require.Equal(engineProfiles[EngineProfileLowLatency].P2P.FlushThrottleTimeout, resolved.P2P.FlushThrottleTimeout)
+	require.Equal(engineProfiles[EngineProfileLowLatency].Consensus, resolved.Consensus)
+
+	// Invalid configurations should point at the offending field.
+	for _, tc := range []struct {
+		cfg EngineConfig
+		err string
+	}{
+		{EngineConfig{Profile: "fast"}, "engine.profile: unknown profile 'fast'"},
+		{EngineConfig{P2P: EngineP2PConfig{DialTimeout: -time.Second}}, "engine.p2p.dial_timeout"},
+		{EngineConfig{Consensus: EngineConsensusConfig{PeerGossipSleepDuration: -time.Second}}, "engine.consensus.peer_gossip_sleep_duration"},
+	} {
+		err = tc.cfg.Validate()
+		require.ErrorContains(err, tc.err)
+
+		fullCfg := DefaultConfig()
+		fullCfg.Engine = tc.cfg
+		require.ErrorContains(fullCfg.Validate(), tc.err, "full configuration validation should fail")
+	}
+}
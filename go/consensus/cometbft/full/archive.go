@@ -19,6 +19,7 @@ import (
 	cmttypes "github.com/cometbft/cometbft/types"
 	"github.com/spf13/viper"
 
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/config"
@@ -39,6 +40,11 @@ type archiveService struct {
 
 	abciClient abcicli.Client
 	eb         *cmttypes.EventBus
+	blockStore *store.BlockStore
+
+	txIndex       *txIndex
+	txIndexCancel context.CancelFunc
+	txIndexDoneCh chan struct{}
 
 	quitCh chan struct{}
 
@@ -76,6 +82,18 @@ func (srv *archiveService) Start() error {
 		}
 	}()
 
+	// Index any transactions not yet indexed.
+	var txIndexCtx context.Context
+	txIndexCtx, srv.txIndexCancel = context.WithCancel(srv.ctx)
+	go func() {
+		defer close(srv.txIndexDoneCh)
+		if err := srv.txIndex.build(txIndexCtx, srv.blockStore); err != nil {
+			srv.Logger.Error("failed to build transaction index",
+				"err", err,
+			)
+		}
+	}()
+
 	// Start command dispatchers for all the service clients.
 	srv.serviceClientsWg.Add(len(srv.serviceClients))
 	for _, svc := range srv.serviceClients {
@@ -97,6 +115,7 @@ func (srv *archiveService) Stop() {
 	}
 
 	srv.stopOnce.Do(func() {
+		srv.txIndexCancel()
 		if err := srv.abciClient.Stop(); err != nil {
 			srv.Logger.Error("error on stopping abci client", "err", err)
 		}
@@ -104,6 +123,14 @@ func (srv *archiveService) Stop() {
 	})
 }
 
+// Implements consensusAPI.Backend.
+func (srv *archiveService) Cleanup() {
+	if srv.started() {
+		<-srv.txIndexDoneCh
+	}
+	srv.commonNode.Cleanup()
+}
+
 // Implements consensusAPI.Backend.
 func (srv *archiveService) Quit() <-chan struct{} {
 	return srv.quitCh
@@ -143,6 +170,33 @@ func (srv *archiveService) GetSignerNonce(context.Context, *consensusAPI.GetSign
 	return 0, consensusAPI.ErrUnsupported
 }
 
+// Implements consensusAPI.Backend.
+func (srv *archiveService) GetTransactionByHash(ctx context.Context, txHash hash.Hash) (*consensusAPI.TransactionWithResult, error) {
+	if err := srv.ensureStarted(ctx); err != nil {
+		return nil, err
+	}
+
+	entry, err := srv.txIndex.lookup(txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	txs, err := srv.GetTransactionsWithResults(ctx, entry.Height)
+	if err != nil {
+		return nil, err
+	}
+	if int(entry.Index) >= len(txs.Transactions) {
+		return nil, fmt.Errorf("cometbft/archive: corrupted transaction index")
+	}
+
+	return &consensusAPI.TransactionWithResult{
+		Height:      entry.Height,
+		Index:       entry.Index,
+		Transaction: txs.Transactions[entry.Index],
+		Result:      txs.Results[entry.Index],
+	}, nil
+}
+
 // Implements consensusAPI.Backend.
 func (srv *archiveService) WatchBlocks(ctx context.Context) (<-chan *consensusAPI.Block, pubsub.ClosableSubscription, error) {
 	ctx, sub := pubsub.NewContextSubscription(ctx)
@@ -167,8 +221,9 @@ func NewArchive(
 	}
 
 	srv := &archiveService{
-		commonNode: commonNode,
-		quitCh:     make(chan struct{}),
+		commonNode:    commonNode,
+		quitCh:        make(chan struct{}),
+		txIndexDoneCh: make(chan struct{}),
 	}
 	// Common node needs access to parent struct for initializing consensus services.
 	srv.commonNode.parentNode = srv
@@ -222,6 +277,17 @@ func NewArchive(
 	}
 	stateDB = db.WithCloser(stateDB, srv.dbCloser)
 	srv.stateStore = state.NewStore(stateDB, state.StoreOptions{})
+	srv.blockStore = store.NewBlockStore(srv.blockStoreDB)
+
+	// NOTE: DBContext uses a full CometBFT config but the only thing that is actually used
+	// is the data dir field.
+	var txIndexDB dbm.DB
+	txIndexDB, err = dbProvider(&cmtnode.DBContext{ID: "archive_txindex", Config: cmtConfig})
+	if err != nil {
+		return nil, err
+	}
+	txIndexDB = db.WithCloser(txIndexDB, srv.dbCloser)
+	srv.txIndex = newTxIndex(txIndexDB)
 
 	tmGenDoc, err := api.GetCometBFTGenesisDocument(genesisProvider)
 	if err != nil {
@@ -234,7 +300,7 @@ func NewArchive(
 		ProxyAppQuery:    cmtproxy.NewAppConnQuery(srv.abciClient, nil),
 		ProxyAppMempool:  nil,
 		StateStore:       srv.stateStore,
-		BlockStore:       store.NewBlockStore(srv.blockStoreDB),
+		BlockStore:       srv.blockStore,
 		EvidencePool:     state.EmptyEvidencePool{},
 		ConsensusState:   nil,
 		GenDoc:           tmGenDoc,
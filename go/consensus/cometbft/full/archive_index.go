@@ -0,0 +1,163 @@
+package full
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	dbm "github.com/cometbft/cometbft-db"
+	cmttypes "github.com/cometbft/cometbft/types"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/keyformat"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	consensusAPI "github.com/oasisprotocol/oasis-core/go/consensus/api"
+)
+
+// txIndexProgressInterval is the number of indexed blocks after which indexing progress is logged.
+const txIndexProgressInterval = 10_000
+
+var (
+	// txIndexKeyFmt is the key format used for transaction index entries.
+	//
+	// Value is CBOR-serialized txIndexEntry.
+	txIndexKeyFmt = keyformat.New(0x01, &hash.Hash{})
+	// txIndexHeightKeyFmt is the key format used for the height of the last indexed block.
+	//
+	// Value is CBOR-serialized block height.
+	txIndexHeightKeyFmt = keyformat.New(0x02)
+)
+
+// txIndexEntry is the location of an indexed transaction.
+type txIndexEntry struct {
+	Height int64  `json:"height"`
+	Index  uint32 `json:"index"`
+}
+
+// blockSource is a source of blocks to index.
+type blockSource interface {
+	// Base returns the height of the first available block.
+	Base() int64
+	// Height returns the height of the last available block.
+	Height() int64
+	// LoadBlock loads the block at the given height.
+	LoadBlock(height int64) *cmttypes.Block
+}
+
+// txIndex is an on-disk index of transactions by their hash.
+type txIndex struct {
+	db     dbm.DB
+	ready  atomic.Bool
+	logger *logging.Logger
+}
+
+// lastHeight returns the height of the last indexed block or zero if no blocks were indexed.
+func (idx *txIndex) lastHeight() (int64, error) {
+	raw, err := idx.db.Get(txIndexHeightKeyFmt.Encode())
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last indexed height: %w", err)
+	}
+	if raw == nil {
+		return 0, nil
+	}
+
+	var height int64
+	if err = cbor.Unmarshal(raw, &height); err != nil {
+		return 0, fmt.Errorf("malformed last indexed height: %w", err)
+	}
+	return height, nil
+}
+
+// indexBlock indexes all transactions in the given block.
+func (idx *txIndex) indexBlock(blk *cmttypes.Block) error {
+	batch := idx.db.NewBatch()
+	defer batch.Close()
+
+	for i, tx := range blk.Data.Txs {
+		txHash := hash.NewFromBytes(tx)
+		entry := txIndexEntry{
+			Height: blk.Height,
+			Index:  uint32(i),
+		}
+		if err := batch.Set(txIndexKeyFmt.Encode(&txHash), cbor.Marshal(entry)); err != nil {
+			return err
+		}
+	}
+	if err := batch.Set(txIndexHeightKeyFmt.Encode(), cbor.Marshal(blk.Height)); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// lookup returns the location of the transaction with the given hash.
+func (idx *txIndex) lookup(txHash hash.Hash) (*txIndexEntry, error) {
+	raw, err := idx.db.Get(txIndexKeyFmt.Encode(&txHash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query transaction index: %w", err)
+	}
+	if raw == nil {
+		if !idx.ready.Load() {
+			return nil, fmt.Errorf("%w: transaction index is still being built", consensusAPI.ErrTransactionNotFound)
+		}
+		return nil, consensusAPI.ErrTransactionNotFound
+	}
+
+	var entry txIndexEntry
+	if err = cbor.Unmarshal(raw, &entry); err != nil {
+		return nil, fmt.Errorf("malformed transaction index entry: %w", err)
+	}
+	return &entry, nil
+}
+
+// build indexes all blocks that have not yet been indexed, resuming from the last indexed block.
+func (idx *txIndex) build(ctx context.Context, blocks blockSource) error {
+	lastHeight, err := idx.lastHeight()
+	if err != nil {
+		return err
+	}
+	start := max(lastHeight+1, blocks.Base())
+	end := blocks.Height()
+
+	idx.logger.Info("building transaction index",
+		"start_height", start,
+		"end_height", end,
+	)
+
+	for height := start; height <= end; height++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		blk := blocks.LoadBlock(height)
+		if blk == nil {
+			return fmt.Errorf("block at height %d not found", height)
+		}
+		if err = idx.indexBlock(blk); err != nil {
+			return fmt.Errorf("failed to index block at height %d: %w", height, err)
+		}
+
+		if (height-start+1)%txIndexProgressInterval == 0 {
+			idx.logger.Info("transaction index progress",
+				"height", height,
+				"end_height", end,
+			)
+		}
+	}
+
+	idx.ready.Store(true)
+	idx.logger.Info("transaction index built",
+		"height", end,
+	)
+
+	return nil
+}
+
+func newTxIndex(db dbm.DB) *txIndex {
+	return &txIndex{
+		db:     db,
+		logger: logging.GetLogger("cometbft/archive/txindex"),
+	}
+}
@@ -0,0 +1,88 @@
+package full
+
+import (
+	"context"
+	"testing"
+
+	dbm "github.com/cometbft/cometbft-db"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	consensusAPI "github.com/oasisprotocol/oasis-core/go/consensus/api"
+)
+
+type testBlockSource struct {
+	base   int64
+	blocks map[int64]*cmttypes.Block
+}
+
+func (s *testBlockSource) Base() int64 {
+	return s.base
+}
+
+func (s *testBlockSource) Height() int64 {
+	return s.base + int64(len(s.blocks)) - 1
+}
+
+func (s *testBlockSource) LoadBlock(height int64) *cmttypes.Block {
+	return s.blocks[height]
+}
+
+func (s *testBlockSource) addBlock(txs ...[]byte) {
+	height := s.base + int64(len(s.blocks))
+
+	blk := &cmttypes.Block{}
+	blk.Height = height
+	for _, tx := range txs {
+		blk.Data.Txs = append(blk.Data.Txs, tx)
+	}
+	s.blocks[height] = blk
+}
+
+func TestTxIndex(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	src := &testBlockSource{
+		base:   5,
+		blocks: make(map[int64]*cmttypes.Block),
+	}
+	src.addBlock([]byte("tx 1"), []byte("tx 2"))
+	src.addBlock()
+	src.addBlock([]byte("tx 3"))
+
+	idx := newTxIndex(dbm.NewMemDB())
+
+	_, err := idx.lookup(hash.NewFromBytes([]byte("tx 1")))
+	require.ErrorIs(err, consensusAPI.ErrTransactionNotFound, "lookup should fail before the index is built")
+
+	err = idx.build(ctx, src)
+	require.NoError(err, "build")
+
+	entry, err := idx.lookup(hash.NewFromBytes([]byte("tx 2")))
+	require.NoError(err, "lookup")
+	require.EqualValues(&txIndexEntry{Height: 5, Index: 1}, entry)
+
+	entry, err = idx.lookup(hash.NewFromBytes([]byte("tx 3")))
+	require.NoError(err, "lookup")
+	require.EqualValues(&txIndexEntry{Height: 7, Index: 0}, entry)
+
+	_, err = idx.lookup(hash.NewFromBytes([]byte("tx 4")))
+	require.ErrorIs(err, consensusAPI.ErrTransactionNotFound, "lookup of unknown transaction should fail")
+
+	lastHeight, err := idx.lastHeight()
+	require.NoError(err, "lastHeight")
+	require.EqualValues(7, lastHeight)
+
+	// Building the index again should resume from the last indexed block.
+	src.addBlock([]byte("tx 4"))
+	delete(src.blocks, 5)
+	src.base = 6
+	err = idx.build(ctx, src)
+	require.NoError(err, "build (resume)")
+
+	entry, err = idx.lookup(hash.NewFromBytes([]byte("tx 4")))
+	require.NoError(err, "lookup")
+	require.EqualValues(&txIndexEntry{Height: 8, Index: 0}, entry)
+}
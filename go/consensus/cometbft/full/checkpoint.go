@@ -0,0 +1,537 @@
+package full
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	cmtabcitypes "github.com/cometbft/cometbft/abci/types"
+	cmtconfig "github.com/cometbft/cometbft/config"
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	cmtnode "github.com/cometbft/cometbft/node"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmtstate "github.com/cometbft/cometbft/state"
+	cmtstore "github.com/cometbft/cometbft/store"
+	cmttypes "github.com/cometbft/cometbft/types"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/config"
+	consensusAPI "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	tmcommon "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/common"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
+)
+
+const (
+	// checkpointVersion is the version of consensus state checkpoints.
+	checkpointVersion = 1
+
+	// checkpointFileVersion is the version of the portable checkpoint file format.
+	checkpointFileVersion = 1
+
+	// checkpointImportFilename is the name of the staged checkpoint file that is imported on the
+	// next start of the node.
+	checkpointImportFilename = "checkpoint-import.bin"
+
+	// checkpointImportTrustHashFilename is the name of the file holding the hex-encoded trusted
+	// block hash that the staged checkpoint file is verified against.
+	checkpointImportTrustHashFilename = "checkpoint-import.trust"
+
+	// maxCheckpointFileSegmentSize is the maximum size of a single checkpoint file segment (the
+	// header or a chunk).
+	maxCheckpointFileSegmentSize = 256 * 1024 * 1024
+
+	// checkpointLightBlocks is the number of light blocks included in a checkpoint file. These
+	// are the light blocks at the checkpoint height and the two following heights, which are
+	// needed to bootstrap CometBFT state.
+	checkpointLightBlocks = 3
+)
+
+// CheckpointFileHeader is the header of a portable consensus checkpoint file.
+//
+// The file consists of the length-prefixed CBOR-encoded header, followed by all of the
+// length-prefixed checkpoint chunks in order.
+type CheckpointFileHeader struct {
+	cbor.Versioned
+
+	// ChainContext is the chain domain separation context of the network.
+	ChainContext string `json:"chain_context"`
+	// Checkpoint is the consensus state checkpoint metadata.
+	Checkpoint *checkpoint.Metadata `json:"checkpoint"`
+	// LightBlocks are the light blocks at the checkpoint height and the two following heights.
+	LightBlocks []*consensusAPI.LightBlock `json:"light_blocks"`
+	// Parameters are the consensus parameters at the height of the last light block.
+	Parameters *consensusAPI.Parameters `json:"parameters"`
+}
+
+// BlockHash returns the hash of the block at the checkpoint height. It can be compared against a
+// block hash obtained from a trusted source.
+//
+// Note that this does not verify the header, use OpenCheckpointFile for that.
+func (h *CheckpointFileHeader) BlockHash() (cmtbytes.HexBytes, error) {
+	if len(h.LightBlocks) == 0 {
+		return nil, fmt.Errorf("missing light blocks")
+	}
+	var pb cmtproto.LightBlock
+	if err := pb.Unmarshal(h.LightBlocks[0].Meta); err != nil {
+		return nil, fmt.Errorf("malformed light block: %w", err)
+	}
+	lb, err := cmttypes.LightBlockFromProto(&pb)
+	if err != nil {
+		return nil, fmt.Errorf("malformed light block: %w", err)
+	}
+	return lb.Hash(), nil
+}
+
+// CheckpointImportPath returns the path of the staged checkpoint file that will be imported on
+// the next start of a node using the given data directory.
+func CheckpointImportPath(dataDir string) string {
+	return filepath.Join(dataDir, tmcommon.StateDir, checkpointImportFilename)
+}
+
+// CheckpointImportTrustHashPath returns the path of the file holding the trusted block hash of
+// the staged checkpoint file for a node using the given data directory.
+func CheckpointImportTrustHashPath(dataDir string) string {
+	return filepath.Join(dataDir, tmcommon.StateDir, checkpointImportTrustHashFilename)
+}
+
+// ExportCheckpoint writes a portable checkpoint file containing the consensus state checkpoint at
+// the given height (or the latest available checkpoint in case of HeightLatest) to the given
+// writer. The light blocks required to verify the checkpoint are included in the file.
+func ExportCheckpoint(
+	ctx context.Context,
+	backend consensusAPI.ClientBackend,
+	height int64,
+	w io.Writer,
+) (*CheckpointFileHeader, error) {
+	cps, err := backend.GetCheckpoints(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checkpoints: %w", err)
+	}
+	var cp *checkpoint.Metadata
+	for _, c := range cps {
+		switch height {
+		case consensusAPI.HeightLatest:
+			if cp == nil || c.Root.Version > cp.Root.Version {
+				cp = c
+			}
+		default:
+			if c.Root.Version == uint64(height) {
+				cp = c
+			}
+		}
+	}
+	if cp == nil {
+		return nil, fmt.Errorf("%w: no checkpoint available at height %d", consensusAPI.ErrVersionNotFound, height)
+	}
+
+	chainContext, err := backend.GetChainContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain context: %w", err)
+	}
+
+	hdr := CheckpointFileHeader{
+		Versioned:    cbor.NewVersioned(checkpointFileVersion),
+		ChainContext: chainContext,
+		Checkpoint:   cp,
+	}
+	for i := int64(0); i < checkpointLightBlocks; i++ {
+		var lb *consensusAPI.LightBlock
+		if lb, err = backend.GetLightBlock(ctx, int64(cp.Root.Version)+i); err != nil {
+			return nil, fmt.Errorf("failed to get light block at height %d: %w", int64(cp.Root.Version)+i, err)
+		}
+		hdr.LightBlocks = append(hdr.LightBlocks, lb)
+	}
+	lastHeight := hdr.LightBlocks[len(hdr.LightBlocks)-1].Height
+	if hdr.Parameters, err = backend.GetParameters(ctx, lastHeight); err != nil {
+		return nil, fmt.Errorf("failed to get consensus parameters at height %d: %w", lastHeight, err)
+	}
+
+	// Make sure that what we are about to export can actually be imported.
+	if _, err = verifyCheckpointFileHeader(&hdr, hdr.ChainContext); err != nil {
+		return nil, err
+	}
+
+	if err = writeCheckpointFileSegment(w, cbor.Marshal(hdr)); err != nil {
+		return nil, fmt.Errorf("failed to write header: %w", err)
+	}
+	for idx := range cp.Chunks {
+		chunk, _ := cp.GetChunkMetadata(uint64(idx))
+
+		var buf bytes.Buffer
+		if err = backend.GetCheckpointChunk(ctx, chunk, &buf); err != nil {
+			return nil, fmt.Errorf("failed to get chunk %d: %w", idx, err)
+		}
+		if digest := hash.NewFromBytes(buf.Bytes()); !digest.Equal(&chunk.Digest) {
+			return nil, fmt.Errorf("%w: chunk %d digest mismatch", checkpoint.ErrChunkCorrupted, idx)
+		}
+		if err = writeCheckpointFileSegment(w, buf.Bytes()); err != nil {
+			return nil, fmt.Errorf("failed to write chunk %d: %w", idx, err)
+		}
+	}
+
+	return &hdr, nil
+}
+
+// CheckpointFileReader is a reader of portable checkpoint files.
+type CheckpointFileReader struct {
+	r *bufio.Reader
+
+	header      *CheckpointFileHeader
+	lightBlocks *checkpointLightBlockSource
+	nextChunk   uint64
+}
+
+// Header returns the verified checkpoint file header.
+func (cr *CheckpointFileReader) Header() *CheckpointFileHeader {
+	return cr.header
+}
+
+// BlockHash returns the hash of the block at the checkpoint height.
+func (cr *CheckpointFileReader) BlockHash() cmtbytes.HexBytes {
+	return cr.lightBlocks.blocks[0].Hash()
+}
+
+// NextChunk returns the index and the content of the next checkpoint chunk after verifying it
+// against the checkpoint metadata. It returns io.EOF after all chunks have been read.
+func (cr *CheckpointFileReader) NextChunk() (uint64, []byte, error) {
+	cp := cr.header.Checkpoint
+	if cr.nextChunk >= uint64(len(cp.Chunks)) {
+		return 0, nil, io.EOF
+	}
+
+	idx := cr.nextChunk
+	data, err := readCheckpointFileSegment(cr.r)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read chunk %d: %w", idx, err)
+	}
+	if digest := hash.NewFromBytes(data); !digest.Equal(&cp.Chunks[idx]) {
+		return 0, nil, fmt.Errorf("%w: chunk %d digest mismatch", checkpoint.ErrChunkCorrupted, idx)
+	}
+	cr.nextChunk++
+
+	return idx, data, nil
+}
+
+// OpenCheckpointFile reads and verifies the header of a portable checkpoint file for the network
+// with the given chain context.
+//
+// The light blocks in the file are only self-consistent, so the hash of the block at the
+// checkpoint height must match the given block hash obtained from a trusted source.
+func OpenCheckpointFile(r io.Reader, chainContext string, trustHash []byte) (*CheckpointFileReader, error) {
+	if len(trustHash) == 0 {
+		return nil, fmt.Errorf("trusted block hash is required")
+	}
+
+	br := bufio.NewReader(r)
+	data, err := readCheckpointFileSegment(br)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	var hdr CheckpointFileHeader
+	if err = cbor.Unmarshal(data, &hdr); err != nil {
+		return nil, fmt.Errorf("malformed header: %w", err)
+	}
+	lbs, err := verifyCheckpointFileHeader(&hdr, chainContext)
+	if err != nil {
+		return nil, err
+	}
+	if blockHash := lbs.blocks[0].Hash(); !bytes.Equal(blockHash, trustHash) {
+		return nil, fmt.Errorf("block hash mismatch (expected: %X got: %X)", trustHash, blockHash)
+	}
+
+	return &CheckpointFileReader{
+		r:           br,
+		header:      &hdr,
+		lightBlocks: lbs,
+	}, nil
+}
+
+func writeCheckpointFileSegment(w io.Writer, data []byte) error {
+	if err := binary.Write(w, binary.BigEndian, uint32(len(data))); err != nil {
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+func readCheckpointFileSegment(r io.Reader) ([]byte, error) {
+	var size uint32
+	if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+		return nil, err
+	}
+	if size > maxCheckpointFileSegmentSize {
+		return nil, fmt.Errorf("segment too large (%d bytes)", size)
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// checkpointLightBlockSource is a source of light blocks and consensus parameters included in a
+// checkpoint file, which have been verified to be consistent with each other.
+type checkpointLightBlockSource struct {
+	blocks []*cmttypes.LightBlock
+	params *cmtproto.ConsensusParams
+}
+
+// Implements verifiedLightBlockSource.
+func (s *checkpointLightBlockSource) GetVerifiedLightBlock(_ context.Context, height int64) (*cmttypes.LightBlock, error) {
+	for _, lb := range s.blocks {
+		if lb.Height == height {
+			return lb, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: light block at height %d not included in checkpoint", consensusAPI.ErrVersionNotFound, height)
+}
+
+// Implements verifiedLightBlockSource.
+func (s *checkpointLightBlockSource) GetVerifiedParameters(_ context.Context, height int64) (*cmtproto.ConsensusParams, error) {
+	if last := s.blocks[len(s.blocks)-1]; last.Height != height {
+		return nil, fmt.Errorf("%w: parameters at height %d not included in checkpoint", consensusAPI.ErrVersionNotFound, height)
+	}
+	return s.params, nil
+}
+
+func verifyCheckpointFileHeader(hdr *CheckpointFileHeader, chainContext string) (*checkpointLightBlockSource, error) {
+	if hdr.V != checkpointFileVersion {
+		return nil, fmt.Errorf("unsupported checkpoint file version: %d", hdr.V)
+	}
+	if hdr.ChainContext != chainContext {
+		return nil, fmt.Errorf("checkpoint is for a different network (expected: %s got: %s)", chainContext, hdr.ChainContext)
+	}
+	cp := hdr.Checkpoint
+	if cp == nil {
+		return nil, fmt.Errorf("missing checkpoint metadata")
+	}
+	if cp.Version != checkpointVersion {
+		return nil, fmt.Errorf("unsupported checkpoint version: %d", cp.Version)
+	}
+	if len(hdr.LightBlocks) != checkpointLightBlocks {
+		return nil, fmt.Errorf("unexpected number of light blocks (expected: %d got: %d)", checkpointLightBlocks, len(hdr.LightBlocks))
+	}
+	if hdr.Parameters == nil {
+		return nil, fmt.Errorf("missing consensus parameters")
+	}
+
+	chainID := api.CometBFTChainID(chainContext)
+	src := &checkpointLightBlockSource{}
+	for i, rawLb := range hdr.LightBlocks {
+		height := int64(cp.Root.Version) + int64(i)
+
+		var pb cmtproto.LightBlock
+		if err := pb.Unmarshal(rawLb.Meta); err != nil {
+			return nil, fmt.Errorf("malformed light block at height %d: %w", height, err)
+		}
+		lb, err := cmttypes.LightBlockFromProto(&pb)
+		if err != nil {
+			return nil, fmt.Errorf("malformed light block at height %d: %w", height, err)
+		}
+		if err = lb.ValidateBasic(chainID); err != nil {
+			return nil, fmt.Errorf("invalid light block at height %d: %w", height, err)
+		}
+		if lb.Height != height || rawLb.Height != height {
+			return nil, fmt.Errorf("unexpected light block height (expected: %d got: %d)", height, lb.Height)
+		}
+		if err = lb.ValidatorSet.VerifyCommitLight(chainID, lb.Commit.BlockID, lb.Height, lb.Commit); err != nil {
+			return nil, fmt.Errorf("invalid commit at height %d: %w", height, err)
+		}
+		if i > 0 {
+			prev := src.blocks[i-1]
+			if !bytes.Equal(lb.LastBlockID.Hash, prev.Hash()) {
+				return nil, fmt.Errorf("light block at height %d does not extend the previous block", height)
+			}
+			if !bytes.Equal(lb.ValidatorsHash, prev.NextValidatorsHash) {
+				return nil, fmt.Errorf("light block at height %d has an unexpected validator set", height)
+			}
+		}
+		src.blocks = append(src.blocks, lb)
+	}
+
+	// The application hash for the checkpoint height is included in the following block.
+	if !bytes.Equal(src.blocks[1].AppHash, cp.Root.Hash[:]) {
+		return nil, fmt.Errorf("checkpoint root does not match the application hash (expected: %X got: %s)",
+			src.blocks[1].AppHash,
+			cp.Root.Hash,
+		)
+	}
+
+	last := src.blocks[len(src.blocks)-1]
+	var paramsPB cmtproto.ConsensusParams
+	if err := paramsPB.Unmarshal(hdr.Parameters.Meta); err != nil {
+		return nil, fmt.Errorf("malformed consensus parameters: %w", err)
+	}
+	params := cmttypes.ConsensusParamsFromProto(paramsPB)
+	if err := params.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid consensus parameters: %w", err)
+	}
+	if hdr.Parameters.Height != last.Height || !bytes.Equal(params.Hash(), last.ConsensusHash) {
+		return nil, fmt.Errorf("consensus parameters do not match the light block at height %d", last.Height)
+	}
+	src.params = &paramsPB
+
+	return src, nil
+}
+
+// stagedCheckpointTrustHash returns the trusted block hash that the staged checkpoint file should
+// be verified against. The hash staged together with the checkpoint file takes precedence over
+// the configured state sync trust root.
+func stagedCheckpointTrustHash(dataDir string) ([]byte, error) {
+	raw, err := os.ReadFile(CheckpointImportTrustHashPath(dataDir))
+	switch {
+	case err == nil:
+	case errors.Is(err, os.ErrNotExist):
+		raw = []byte(config.GlobalConfig.Consensus.StateSync.TrustHash)
+	default:
+		return nil, fmt.Errorf("failed to read staged checkpoint trust hash: %w", err)
+	}
+
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("no trusted block hash available for the staged checkpoint")
+	}
+	trustHash, err := hex.DecodeString(string(raw))
+	if err != nil {
+		return nil, fmt.Errorf("malformed staged checkpoint trust hash: %w", err)
+	}
+	return trustHash, nil
+}
+
+// importStagedCheckpoint imports the staged checkpoint file, if any, restoring the application
+// state and bootstrapping the CometBFT stores without going through state sync.
+func (t *fullService) importStagedCheckpoint(
+	genDoc *cmttypes.GenesisDoc,
+	dbProvider cmtnode.DBProvider,
+	cometConfig *cmtconfig.Config,
+) error {
+	path := CheckpointImportPath(t.dataDir)
+	f, err := os.Open(path)
+	switch {
+	case err == nil:
+	case errors.Is(err, os.ErrNotExist):
+		return nil
+	default:
+		return err
+	}
+	defer f.Close()
+
+	trustHash, err := stagedCheckpointTrustHash(t.dataDir)
+	if err != nil {
+		return err
+	}
+	cr, err := OpenCheckpointFile(f, t.genesis.ChainContext(), trustHash)
+	if err != nil {
+		return err
+	}
+	cp := cr.Header().Checkpoint
+
+	t.Logger.Info("importing staged consensus checkpoint",
+		"path", path,
+		"root", cp.Root,
+		"block_hash", cr.BlockHash(),
+	)
+
+	// Make sure that the node does not have any existing state.
+	blockStoreDB, err := dbProvider(&cmtnode.DBContext{ID: "blockstore", Config: cometConfig})
+	if err != nil {
+		return err
+	}
+	blockStore := cmtstore.NewBlockStore(blockStoreDB)
+	defer blockStore.Close()
+
+	stateDB, err := dbProvider(&cmtnode.DBContext{ID: "state", Config: cometConfig})
+	if err != nil {
+		return err
+	}
+	stateStore := cmtstate.NewBootstrapStore(stateDB, cmtstate.StoreOptions{})
+	defer stateStore.Close()
+
+	state, err := stateStore.Load()
+	if err != nil {
+		return err
+	}
+	if !blockStore.IsEmpty() || !state.IsEmpty() {
+		return fmt.Errorf("checkpoints can only be imported into a node without existing state")
+	}
+
+	// Restore the application state through the same path as state sync.
+	app := t.mux.Mux()
+	cpHash := cp.EncodedHash()
+	offerRsp := app.OfferSnapshot(cmtabcitypes.RequestOfferSnapshot{
+		Snapshot: &cmtabcitypes.Snapshot{
+			Height:   cp.Root.Version,
+			Format:   uint32(cp.Version),
+			Chunks:   uint32(len(cp.Chunks)),
+			Hash:     cpHash[:],
+			Metadata: cbor.Marshal(cp),
+		},
+		AppHash: cp.Root.Hash[:],
+	})
+	if offerRsp.Result != cmtabcitypes.ResponseOfferSnapshot_ACCEPT {
+		return fmt.Errorf("checkpoint rejected by the application: %s", offerRsp.Result)
+	}
+	for {
+		idx, chunk, cerr := cr.NextChunk()
+		if errors.Is(cerr, io.EOF) {
+			break
+		}
+		if cerr != nil {
+			return cerr
+		}
+
+		applyRsp := app.ApplySnapshotChunk(cmtabcitypes.RequestApplySnapshotChunk{
+			Index: uint32(idx),
+			Chunk: chunk,
+		})
+		if applyRsp.Result != cmtabcitypes.ResponseApplySnapshotChunk_ACCEPT {
+			return fmt.Errorf("chunk %d rejected by the application: %s", idx, applyRsp.Result)
+		}
+	}
+
+	// Bootstrap the CometBFT stores using the light blocks included in the checkpoint file.
+	sp := &stateProvider{
+		lc:              cr.lightBlocks,
+		genesisDocument: genDoc,
+		logger:          t.Logger,
+	}
+	if state, err = sp.State(t.ctx, cp.Root.Version); err != nil {
+		return fmt.Errorf("failed to construct state: %w", err)
+	}
+	commit, err := sp.Commit(t.ctx, cp.Root.Version)
+	if err != nil {
+		return fmt.Errorf("failed to obtain commit: %w", err)
+	}
+	if err = stateStore.Bootstrap(state); err != nil {
+		return fmt.Errorf("failed to bootstrap state store: %w", err)
+	}
+	if err = blockStore.SaveSeenCommit(state.LastBlockHeight, commit); err != nil {
+		return fmt.Errorf("failed to store last seen commit: %w", err)
+	}
+	if err = stateStore.SetOfflineStateSyncHeight(state.LastBlockHeight); err != nil {
+		return fmt.Errorf("failed to set synced height: %w", err)
+	}
+
+	// Remove the staged checkpoint so that it is not imported again.
+	if err = os.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove imported checkpoint file: %w", err)
+	}
+	if err = os.Remove(CheckpointImportTrustHashPath(t.dataDir)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove imported checkpoint trust hash: %w", err)
+	}
+
+	t.Logger.Info("consensus checkpoint imported",
+		"height", state.LastBlockHeight,
+	)
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package full
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+)
+
+func TestCheckpointFileSegments(t *testing.T) {
+	require := require.New(t)
+
+	var buf bytes.Buffer
+	segments := [][]byte{[]byte("header"), {}, []byte("chunk")}
+	for _, seg := range segments {
+		require.NoError(writeCheckpointFileSegment(&buf, seg))
+	}
+	for _, seg := range segments {
+		data, err := readCheckpointFileSegment(&buf)
+		require.NoError(err)
+		require.Equal(seg, data)
+	}
+	_, err := readCheckpointFileSegment(&buf)
+	require.Error(err, "reading past the last segment should fail")
+
+	// Oversized segments should be rejected without allocating them.
+	buf.Reset()
+	buf.Write([]byte{0xff, 0xff, 0xff, 0xff})
+	_, err = readCheckpointFileSegment(&buf)
+	require.ErrorContains(err, "segment too large")
+}
+
+func TestOpenCheckpointFileInvalidHeader(t *testing.T) {
+	require := require.New(t)
+
+	validHeader := func() CheckpointFileHeader {
+		return CheckpointFileHeader{
+			Versioned:    cbor.NewVersioned(checkpointFileVersion),
+			ChainContext: "test-chain",
+			Checkpoint: &checkpoint.Metadata{
+				Version: checkpointVersion,
+				Root: node.Root{
+					Version: 10,
+					Type:    node.RootTypeState,
+				},
+				Chunks: []hash.Hash{hash.NewFromBytes([]byte("chunk"))},
+			},
+		}
+	}
+
+	for _, tc := range []struct {
+		name   string
+		modify func(*CheckpointFileHeader)
+		msg    string
+	}{
+		{"version", func(h *CheckpointFileHeader) { h.V = 42 }, "unsupported checkpoint file version"},
+		{"chain context", func(h *CheckpointFileHeader) { h.ChainContext = "other-chain" }, "different network"},
+		{"checkpoint", func(h *CheckpointFileHeader) { h.Checkpoint = nil }, "missing checkpoint metadata"},
+		{"light blocks", func(h *CheckpointFileHeader) {}, "unexpected number of light blocks"},
+	} {
+		hdr := validHeader()
+		tc.modify(&hdr)
+
+		var buf bytes.Buffer
+		require.NoError(writeCheckpointFileSegment(&buf, cbor.Marshal(hdr)), tc.name)
+
+		_, err := OpenCheckpointFile(&buf, "test-chain", []byte("trusted"))
+		require.ErrorContains(err, tc.msg, tc.name)
+	}
+
+	// A trusted block hash should be required.
+	hdr := validHeader()
+	var buf bytes.Buffer
+	require.NoError(writeCheckpointFileSegment(&buf, cbor.Marshal(hdr)))
+	_, err := OpenCheckpointFile(&buf, "test-chain", nil)
+	require.ErrorContains(err, "trusted block hash is required")
+}
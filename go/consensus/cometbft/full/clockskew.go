@@ -0,0 +1,293 @@
+package full
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	cmtcrypto "github.com/cometbft/cometbft/crypto"
+	cmtpubsub "github.com/cometbft/cometbft/libs/pubsub"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/consensus/metrics"
+)
+
+const (
+	// clockSkewWindowSize is the number of skew samples used to estimate the clock skew.
+	clockSkewWindowSize = 16
+
+	// clockSkewMaxPropagationDelay is the maximum difference between the local interval between
+	// observing two consecutive blocks and the interval between their block times for the latter
+	// block to be considered as observed at the tip of the chain. Blocks observed during catch-up
+	// or after slow gossip exceed it and are not used to estimate the clock skew.
+	clockSkewMaxPropagationDelay = time.Second
+)
+
+var _ cmttypes.PrivValidator = (*clockSkewGuard)(nil)
+
+// clockSkewMonitor estimates the skew of the local clock against the clocks of the other
+// validators. For each committed block a single sample is taken from (in order of preference)
+// the timestamp of the node's own vote (when the node is a validator), the timestamps of votes
+// received from peers or the block time.
+type clockSkewMonitor struct {
+	sync.RWMutex
+
+	logger *logging.Logger
+
+	warnThreshold   time.Duration
+	refuseThreshold time.Duration
+
+	myAddr []byte
+	now    func() time.Time
+
+	samples         []time.Duration
+	voteSamples     map[int64][]time.Duration
+	prevBlockHeight int64
+	prevBlockTime   time.Time
+	prevObservedAt  time.Time
+	skew            time.Duration
+	warned          bool
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// observeBlock updates the clock skew estimate based on a newly committed block.
+func (m *clockSkewMonitor) observeBlock(blk *cmttypes.Block) {
+	m.Lock()
+	defer m.Unlock()
+
+	now := m.now()
+	sample, ok := m.sampleLocked(blk, now)
+	m.prevBlockHeight = blk.Height
+	m.prevBlockTime = blk.Time
+	m.prevObservedAt = now
+	for height := range m.voteSamples {
+		if height <= blk.Height {
+			delete(m.voteSamples, height)
+		}
+	}
+	if !ok {
+		return
+	}
+
+	m.samples = append(m.samples, sample)
+	if len(m.samples) > clockSkewWindowSize {
+		m.samples = m.samples[1:]
+	}
+
+	// Use the median of the recent samples to ignore outliers (e.g., slow blocks).
+	m.skew = medianDuration(m.samples)
+
+	metrics.ClockSkew.With(labelCometBFT).Set(m.skew.Seconds())
+
+	switch exceeded := m.warnThreshold > 0 && absDuration(m.skew) > m.warnThreshold; {
+	case exceeded && !m.warned:
+		m.logger.Warn("local clock appears to be skewed, make sure that the clock is synchronized (e.g., via NTP)",
+			"skew", m.skew,
+			"threshold", m.warnThreshold,
+		)
+		m.warned = true
+	case !exceeded && m.warned:
+		m.logger.Info("local clock skew is back within threshold",
+			"skew", m.skew,
+		)
+		m.warned = false
+	}
+}
+
+// observeVote records the offset between the local time and the timestamp of a vote received
+// from a peer.
+func (m *clockSkewMonitor) observeVote(vote *cmttypes.Vote) {
+	if vote.Type != cmtproto.PrecommitType {
+		return
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	if m.myAddr != nil && bytes.Equal(m.myAddr, vote.ValidatorAddress) {
+		return
+	}
+	// Ignore late votes for already committed blocks and votes received before the first block
+	// has been observed.
+	if m.prevBlockHeight == 0 || vote.Height != m.prevBlockHeight+1 {
+		return
+	}
+
+	if m.voteSamples == nil {
+		m.voteSamples = make(map[int64][]time.Duration)
+	}
+	// The offset includes the propagation delay of the vote which is negligible at the tip.
+	m.voteSamples[vote.Height] = append(m.voteSamples[vote.Height], m.now().Sub(vote.Timestamp))
+}
+
+func (m *clockSkewMonitor) sampleLocked(blk *cmttypes.Block, now time.Time) (time.Duration, bool) {
+	// If our vote is part of the last commit, compare its timestamp with the block time which is
+	// the (voting power weighted) median of all validator vote timestamps.
+	if blk.LastCommit != nil && m.myAddr != nil {
+		for _, sig := range blk.LastCommit.Signatures {
+			if sig.Absent() || !bytes.Equal(m.myAddr, sig.ValidatorAddress) {
+				continue
+			}
+			return sig.Timestamp.Sub(blk.Time), true
+		}
+	}
+
+	// Otherwise, compare the local time with the timestamps of the votes for the block received
+	// from peers, using the median to ignore peers with skewed clocks.
+	if samples := m.voteSamples[blk.Height]; len(samples) > 0 {
+		return medianDuration(samples), true
+	}
+
+	// Otherwise, compare the local time with the block time, accounting for the interval between
+	// blocks as the block time is derived from the votes for the previous block. This is only
+	// accurate when both blocks were observed at the tip of the chain, as otherwise the delay in
+	// observing the block would be counted as clock skew.
+	if m.prevBlockTime.IsZero() || blk.Height != m.prevBlockHeight+1 {
+		return 0, false
+	}
+	interval := blk.Time.Sub(m.prevBlockTime)
+	if interval < 0 {
+		return 0, false
+	}
+	if absDuration(now.Sub(m.prevObservedAt)-interval) > clockSkewMaxPropagationDelay {
+		return 0, false
+	}
+	return now.Sub(blk.Time) - interval, true
+}
+
+func medianDuration(samples []time.Duration) time.Duration {
+	sorted := append([]time.Duration{}, samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[len(sorted)/2]
+}
+
+// Skew returns the current clock skew estimate.
+func (m *clockSkewMonitor) Skew() time.Duration {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.skew
+}
+
+// checkParticipation returns an error in case the node should refuse to participate in consensus
+// due to excessive clock skew.
+func (m *clockSkewMonitor) checkParticipation() error {
+	if m.refuseThreshold == 0 {
+		return nil
+	}
+	if skew := m.Skew(); absDuration(skew) > m.refuseThreshold {
+		return fmt.Errorf("cometbft: refusing to sign due to clock skew (skew: %s, threshold: %s)", skew, m.refuseThreshold)
+	}
+	return nil
+}
+
+func newClockSkewMonitor(logger *logging.Logger, myAddr []byte, warnThreshold, refuseThreshold time.Duration) *clockSkewMonitor {
+	return &clockSkewMonitor{
+		logger:          logger,
+		warnThreshold:   warnThreshold,
+		refuseThreshold: refuseThreshold,
+		myAddr:          myAddr,
+		now:             time.Now,
+	}
+}
+
+// clockSkewGuard is a CometBFT private validator wrapper that refuses to sign votes and proposals
+// when the local clock is skewed too much.
+type clockSkewGuard struct {
+	inner   cmttypes.PrivValidator
+	monitor *clockSkewMonitor
+	logger  *logging.Logger
+}
+
+// Implements cmttypes.PrivValidator.
+func (g *clockSkewGuard) GetPubKey() (cmtcrypto.PubKey, error) {
+	return g.inner.GetPubKey()
+}
+
+// Implements cmttypes.PrivValidator.
+func (g *clockSkewGuard) SignVote(chainID string, vote *cmtproto.Vote) error {
+	if err := g.monitor.checkParticipation(); err != nil {
+		g.logger.Error("not signing vote",
+			"err", err,
+			"height", vote.Height,
+			"round", vote.Round,
+		)
+		return err
+	}
+	return g.inner.SignVote(chainID, vote)
+}
+
+// Implements cmttypes.PrivValidator.
+func (g *clockSkewGuard) SignProposal(chainID string, proposal *cmtproto.Proposal) error {
+	if err := g.monitor.checkParticipation(); err != nil {
+		g.logger.Error("not signing proposal",
+			"err", err,
+			"height", proposal.Height,
+			"round", proposal.Round,
+		)
+		return err
+	}
+	return g.inner.SignProposal(chainID, proposal)
+}
+
+// clockSkewWorker feeds committed blocks to the clock skew monitor once the node is synced.
+func (t *fullService) clockSkewWorker() {
+	select {
+	case <-t.node.Quit():
+		return
+	case <-t.syncedCh:
+	}
+
+	ch, sub, err := t.WatchCometBFTBlocks()
+	if err != nil {
+		return
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case <-t.node.Quit():
+			return
+		case blk := <-ch:
+			t.clockSkew.observeBlock(blk)
+		}
+	}
+}
+
+// clockSkewVoteWorker feeds votes received from peers to the clock skew monitor.
+func (t *fullService) clockSkewVoteWorker() {
+	sub, err := t.node.EventBus().SubscribeUnbuffered(t.ctx, tmSubscriberID, cmttypes.EventQueryVote)
+	if err != nil {
+		t.Logger.Error("failed to subscribe to vote events",
+			"err", err,
+		)
+		return
+	}
+	// Oh yes, this can actually return a nil subscription even though the error was also
+	// nil if the node is just shutting down.
+	if sub == (*cmtpubsub.Subscription)(nil) {
+		return
+	}
+	defer t.node.EventBus().Unsubscribe(t.ctx, tmSubscriberID, cmttypes.EventQueryVote) // nolint: errcheck
+
+	for {
+		select {
+		// Should not return on t.ctx.Done()/t.node.Quit() as that could lead to a deadlock.
+		case <-sub.Cancelled():
+			return
+		case v := <-sub.Out():
+			ev := v.Data().(cmttypes.EventDataVote)
+			t.clockSkew.observeVote(ev.Vote)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package full
+
+import (
+	"testing"
+	"time"
+
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) get() time.Time {
+	return c.now
+}
+
+func TestClockSkewMonitorBlockTime(t *testing.T) {
+	require := require.New(t)
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := fakeClock{}
+	m := newClockSkewMonitor(logging.GetLogger("test"), nil, time.Second, 10*time.Second)
+	m.now = clock.get
+
+	// Blocks are produced every 6 seconds and received 100ms after the next block time, while
+	// the local clock is 20 seconds ahead.
+	for i := 0; i < clockSkewWindowSize; i++ {
+		blkTime := base.Add(time.Duration(i) * 6 * time.Second)
+		clock.now = blkTime.Add(6*time.Second + 100*time.Millisecond + 20*time.Second)
+		m.observeBlock(&cmttypes.Block{Header: cmttypes.Header{Height: int64(i + 1), Time: blkTime}})
+	}
+	require.Equal(20*time.Second+100*time.Millisecond, m.Skew())
+	require.True(m.warned, "warning should be emitted")
+	require.Error(m.checkParticipation(), "participation should be refused")
+}
+
+func TestClockSkewMonitorOwnVotes(t *testing.T) {
+	require := require.New(t)
+
+	myAddr := []byte("my validator address")
+	m := newClockSkewMonitor(logging.GetLogger("test"), myAddr, time.Second, 0)
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		blkTime := base.Add(time.Duration(i) * 6 * time.Second)
+		m.observeBlock(&cmttypes.Block{
+			Header: cmttypes.Header{Time: blkTime},
+			LastCommit: &cmttypes.Commit{
+				Signatures: []cmttypes.CommitSig{
+					{
+						BlockIDFlag:      cmttypes.BlockIDFlagCommit,
+						ValidatorAddress: []byte("other validator"),
+						Timestamp:        blkTime,
+					},
+					{
+						BlockIDFlag:      cmttypes.BlockIDFlagCommit,
+						ValidatorAddress: myAddr,
+						Timestamp:        blkTime.Add(-300 * time.Millisecond),
+					},
+				},
+			},
+		})
+	}
+	require.Equal(-300*time.Millisecond, m.Skew())
+	require.False(m.warned, "skew should be within threshold")
+	// Refusal is disabled.
+	require.NoError(m.checkParticipation())
+}
+
+func TestClockSkewMonitorLateBlocks(t *testing.T) {
+	require := require.New(t)
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := fakeClock{now: base.Add(time.Hour)}
+	m := newClockSkewMonitor(logging.GetLogger("test"), nil, time.Second, 10*time.Second)
+	m.now = clock.get
+
+	// While catching up, blocks produced every 6 seconds are observed in quick succession.
+	height := int64(1)
+	for ; height <= 3*clockSkewWindowSize; height++ {
+		clock.now = clock.now.Add(10 * time.Millisecond)
+		blkTime := base.Add(time.Duration(height) * 6 * time.Second)
+		m.observeBlock(&cmttypes.Block{Header: cmttypes.Header{Height: height, Time: blkTime}})
+	}
+	require.Empty(m.samples, "blocks observed during catch-up should not be sampled")
+	require.Zero(m.Skew())
+	require.False(m.warned, "warning should not be emitted")
+	require.NoError(m.checkParticipation())
+
+	// Once at the tip, blocks are received 100ms after the next block time.
+	observe := func(delay time.Duration) {
+		blkTime := base.Add(time.Duration(height) * 6 * time.Second)
+		clock.now = blkTime.Add(6*time.Second + 100*time.Millisecond + delay)
+		m.observeBlock(&cmttypes.Block{Header: cmttypes.Header{Height: height, Time: blkTime}})
+		height++
+	}
+	for i := 0; i < 4; i++ {
+		observe(0)
+	}
+	require.Len(m.samples, 3)
+	require.Equal(100*time.Millisecond, m.Skew())
+
+	// A block received late due to slow gossip should not be sampled, neither should the block
+	// following it.
+	observe(5 * time.Second)
+	observe(0)
+	require.Len(m.samples, 3)
+	observe(0)
+	require.Len(m.samples, 4)
+	require.Equal(100*time.Millisecond, m.Skew())
+}
+
+func TestClockSkewMonitorPeerVotes(t *testing.T) {
+	require := require.New(t)
+
+	myAddr := []byte("my validator address")
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := fakeClock{}
+	m := newClockSkewMonitor(logging.GetLogger("test"), myAddr, time.Second, 10*time.Second)
+	m.now = clock.get
+
+	vote := func(height int64, addr []byte, timestamp time.Time) *cmttypes.Vote {
+		return &cmttypes.Vote{
+			Type:             cmtproto.PrecommitType,
+			Height:           height,
+			ValidatorAddress: addr,
+			Timestamp:        timestamp,
+		}
+	}
+
+	// The local clock is 3 seconds ahead of the clocks of most peers.
+	clock.now = base.Add(3 * time.Second)
+	m.observeBlock(&cmttypes.Block{Header: cmttypes.Header{Height: 1, Time: base}})
+
+	// Votes received before the block is committed are used, except for our own votes, late
+	// votes for committed blocks and prevotes.
+	voteTime := base.Add(6 * time.Second)
+	clock.now = voteTime.Add(3*time.Second + 50*time.Millisecond)
+	m.observeVote(vote(2, []byte("validator 1"), voteTime))
+	m.observeVote(vote(2, []byte("validator 2"), voteTime.Add(-20*time.Millisecond)))
+	m.observeVote(vote(2, []byte("skewed validator"), voteTime.Add(time.Minute)))
+	m.observeVote(vote(2, myAddr, clock.now))
+	m.observeVote(vote(1, []byte("validator 3"), voteTime.Add(time.Minute)))
+	prevote := vote(2, []byte("validator 3"), voteTime.Add(time.Minute))
+	prevote.Type = cmtproto.PrevoteType
+	m.observeVote(prevote)
+
+	// Our vote is not part of the last commit.
+	clock.now = clock.now.Add(time.Minute)
+	m.observeBlock(&cmttypes.Block{Header: cmttypes.Header{Height: 2, Time: voteTime}})
+	require.Equal(3*time.Second+50*time.Millisecond, m.Skew())
+	require.True(m.warned, "warning should be emitted")
+	require.NoError(m.checkParticipation())
+	require.Empty(m.voteSamples, "vote samples should be consumed")
+}
+
+func TestClockSkewGuard(t *testing.T) {
+	require := require.New(t)
+
+	pv := cmttypes.NewMockPV()
+	m := newClockSkewMonitor(logging.GetLogger("test"), nil, 0, time.Second)
+	g := &clockSkewGuard{inner: pv, monitor: m, logger: m.logger}
+
+	require.NoError(g.SignVote("chain", &cmtproto.Vote{}), "SignVote without skew")
+
+	m.skew = 2 * time.Second
+	require.Error(g.SignVote("chain", &cmtproto.Vote{}), "SignVote with skew")
+	require.Error(g.SignProposal("chain", &cmtproto.Proposal{}), "SignProposal with skew")
+}
@@ -3,12 +3,15 @@ package full
 import (
 	"context"
 	"crypto/sha256"
+	"errors"
 	"fmt"
+	"io"
 	"sync"
 	"sync/atomic"
 
 	dbm "github.com/cometbft/cometbft-db"
 	cmtmerkle "github.com/cometbft/cometbft/crypto/merkle"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	cmtcore "github.com/cometbft/cometbft/rpc/core"
 	cmtcoretypes "github.com/cometbft/cometbft/rpc/core/types"
 	cmtrpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
@@ -17,7 +20,9 @@ import (
 	cmttypes "github.com/cometbft/cometbft/types"
 
 	beaconAPI "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/cache/lru"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
@@ -29,12 +34,12 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/config"
 	consensusAPI "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
-	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction/results"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/abci"
 	coreState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/abci/state"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/supplementarysanity"
 	tmbeacon "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/beacon"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/blockarchive"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/common"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/crypto"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/db"
@@ -54,6 +59,7 @@ import (
 	p2pAPI "github.com/oasisprotocol/oasis-core/go/p2p/api"
 	"github.com/oasisprotocol/oasis-core/go/registry"
 	registryAPI "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash"
 	roothashAPI "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	schedulerAPI "github.com/oasisprotocol/oasis-core/go/scheduler/api"
 	stakingAPI "github.com/oasisprotocol/oasis-core/go/staking/api"
@@ -97,9 +103,14 @@ type commonNode struct {
 	stateStore   state.Store
 	dbCloser     *db.Closer
 
+	// blockArchive is the optional archive of pruned blocks.
+	blockArchive *blockarchive.Archive
+
 	state     uint32
 	startedCh chan struct{}
 
+	eventLog *lru.Cache
+
 	parentNode api.Backend
 }
 
@@ -263,6 +274,9 @@ func (n *commonNode) initialize() error {
 		return err
 	}
 	n.roothash = scRootHash
+	if cmmetrics.Enabled() && config.GlobalConfig.Mode == config.ModeValidator {
+		n.svcMgr.RegisterCleanupOnly(roothash.NewStatisticsMetricsUpdater(n.ctx, n.roothash, n.registry), "roothash statistics metrics updater")
+	}
 	n.serviceClients = append(n.serviceClients, scRootHash)
 	n.svcMgr.RegisterCleanupOnly(n.roothash, "roothash backend")
 
@@ -496,6 +510,14 @@ func (n *commonNode) EstimateGas(_ context.Context, req *consensusAPI.EstimateGa
 	return n.mux.EstimateGas(req.Signer, req.Transaction)
 }
 
+// Implements consensusAPI.Backend.
+func (n *commonNode) EstimateFee(ctx context.Context, req *consensusAPI.EstimateGasRequest) (*transaction.Fee, error) {
+	if req.Transaction == nil {
+		return nil, consensusAPI.ErrInvalidArgument
+	}
+	return n.parentNode.SubmissionManager().EstimateFee(ctx, req.Signer, req.Transaction)
+}
+
 // Implements consensusAPI.Backend.
 func (n *commonNode) MinGasPrice(ctx context.Context) (*quantity.Quantity, error) {
 	cs, err := coreState.NewImmutableState(ctx, n.mux.State(), consensusAPI.HeightLatest)
@@ -562,6 +584,21 @@ func (n *commonNode) GetCometBFTBlock(ctx context.Context, height int64) (*cmtty
 	default:
 		return nil, err
 	}
+	// Read pruned blocks from the archive, if available.
+	if n.blockArchive != nil && tmHeight < store.LoadBlockStoreState(n.blockStoreDB).Base {
+		blk, aerr := n.blockArchive.GetBlock(tmHeight)
+		switch {
+		case aerr == nil:
+			return blk, nil
+		case errors.Is(aerr, blockarchive.ErrNotFound):
+			return nil, fmt.Errorf("%w: height %d has been pruned and is not archived",
+				consensusAPI.ErrVersionNotFound, tmHeight,
+			)
+		default:
+			return nil, fmt.Errorf("cometbft: archived block query failed: %w", aerr)
+		}
+	}
+
 	result, err := cmtcore.Block(n.rpcCtx, &tmHeight)
 	if err != nil {
 		return nil, fmt.Errorf("cometbft: block query failed: %w", err)
@@ -579,6 +616,27 @@ func (n *commonNode) GetBlockResults(ctx context.Context, height int64) (*cmtcor
 	if err != nil {
 		return nil, err
 	}
+	// Read results of pruned blocks from the archive, if available.
+	if n.blockArchive != nil && tmHeight < store.LoadBlockStoreState(n.blockStoreDB).Base {
+		results, aerr := n.blockArchive.GetBlockResults(tmHeight)
+		switch {
+		case aerr == nil:
+			return &cmtcoretypes.ResultBlockResults{
+				Height:                tmHeight,
+				TxsResults:            results.GetDeliverTxs(),
+				BeginBlockEvents:      results.GetBeginBlock().GetEvents(),
+				EndBlockEvents:        results.GetEndBlock().GetEvents(),
+				ValidatorUpdates:      results.GetEndBlock().GetValidatorUpdates(),
+				ConsensusParamUpdates: results.GetEndBlock().GetConsensusParamUpdates(),
+			}, nil
+		case errors.Is(aerr, blockarchive.ErrNotFound):
+			return nil, fmt.Errorf("%w: results for height %d have been pruned and are not archived",
+				consensusAPI.ErrVersionNotFound, tmHeight,
+			)
+		default:
+			return nil, fmt.Errorf("cometbft: archived block results query failed: %w", aerr)
+		}
+	}
 	result, err := cmtcore.BlockResults(n.rpcCtx, &tmHeight)
 	if err != nil {
 		return nil, fmt.Errorf("cometbft: block results query failed: %w", err)
@@ -609,6 +667,59 @@ func (n *commonNode) GetBlock(ctx context.Context, height int64) (*consensusAPI.
 	return api.NewBlock(blk), nil
 }
 
+// Implements consensusAPI.Backend.
+func (n *commonNode) GetBlocks(ctx context.Context, req *consensusAPI.GetBlocksRequest) ([]*consensusAPI.Block, error) {
+	if err := req.SanityCheck(); err != nil {
+		return nil, err
+	}
+	if err := n.ensureStarted(ctx); err != nil {
+		return nil, err
+	}
+
+	latestHeight, err := n.heightToCometBFTHeight(consensusAPI.HeightLatest)
+	if err != nil {
+		return nil, err
+	}
+	endHeight := req.EndHeight
+	if endHeight == consensusAPI.HeightLatest || endHeight > latestHeight {
+		endHeight = latestHeight
+	}
+	if lastRetained := store.LoadBlockStoreState(n.blockStoreDB).Base; n.blockArchive == nil && req.StartHeight < lastRetained {
+		return nil, fmt.Errorf("%w: height %d is not available (last retained height: %d)",
+			consensusAPI.ErrVersionNotFound, req.StartHeight, lastRetained,
+		)
+	}
+
+	limit := req.EffectiveLimit()
+	var blocks []*consensusAPI.Block
+	for height := req.StartHeight; height <= endHeight && uint64(len(blocks)) < limit; height++ {
+		blk, err := n.GetBlock(ctx, height)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, blk)
+	}
+	return blocks, nil
+}
+
+// Implements consensusAPI.Backend.
+func (n *commonNode) GetBlockByHash(ctx context.Context, hash hash.Hash) (*consensusAPI.Block, error) {
+	if err := n.ensureStarted(ctx); err != nil {
+		return nil, err
+	}
+
+	result, err := cmtcore.BlockByHash(n.rpcCtx, hash[:])
+	if err != nil {
+		return nil, fmt.Errorf("cometbft: block query failed: %w", err)
+	}
+	// Do not return blocks for which local state does not yet exist.
+	if result.Block == nil || result.Block.Height > n.mux.State().BlockHeight() {
+		return nil, consensusAPI.ErrVersionNotFound
+	}
+
+	return api.NewBlock(result.Block), nil
+}
+
 // Implements consensusAPI.Backend.
 func (n *commonNode) GetLightBlock(ctx context.Context, height int64) (*consensusAPI.LightBlock, error) {
 	if err := n.ensureStarted(ctx); err != nil {
@@ -656,6 +767,90 @@ func (n *commonNode) GetLightBlock(ctx context.Context, height int64) (*consensu
 	}, nil
 }
 
+// Implements consensusAPI.Backend.
+func (n *commonNode) GetLightBlockRange(ctx context.Context, req *consensusAPI.GetLightBlockRangeRequest) (*consensusAPI.LightBlockRange, error) {
+	if err := req.SanityCheck(); err != nil {
+		return nil, err
+	}
+	if err := n.ensureStarted(ctx); err != nil {
+		return nil, err
+	}
+
+	latestHeight, err := n.heightToCometBFTHeight(consensusAPI.HeightLatest)
+	if err != nil {
+		return nil, err
+	}
+	endHeight := req.EndHeight
+	if endHeight == consensusAPI.HeightLatest || endHeight > latestHeight {
+		endHeight = latestHeight
+	}
+	if lastRetained := store.LoadBlockStoreState(n.blockStoreDB).Base; req.StartHeight < lastRetained {
+		return nil, fmt.Errorf("%w: height %d is not available (last retained height: %d)",
+			consensusAPI.ErrVersionNotFound, req.StartHeight, lastRetained,
+		)
+	}
+
+	var rsp consensusAPI.LightBlockRange
+	vsIndices := make(map[string]uint32)
+	for height := req.StartHeight; height <= endHeight && len(rsp.Blocks) < consensusAPI.MaxLightBlockRangeSize; height++ {
+		// Don't use the client as that imposes stupid pagination. Access the state database directly.
+		vs, err := n.stateStore.LoadValidators(height)
+		if err != nil {
+			return nil, consensusAPI.ErrVersionNotFound
+		}
+		commit, err := cmtcore.Commit(n.rpcCtx, &height)
+		if err != nil || commit == nil || commit.Header == nil {
+			return nil, consensusAPI.ErrVersionNotFound
+		}
+
+		// Validator sets usually only change on epoch transitions, so only include each distinct
+		// validator set once.
+		vsIndex, ok := vsIndices[string(vs.Hash())]
+		if !ok {
+			protoVs, err := validatorSetToProto(vs)
+			if err != nil {
+				return nil, err
+			}
+			rawVs, err := protoVs.Marshal()
+			if err != nil {
+				return nil, fmt.Errorf("cometbft: failed to marshal validator set: %w", err)
+			}
+
+			vsIndex = uint32(len(rsp.ValidatorSets))
+			vsIndices[string(vs.Hash())] = vsIndex
+			rsp.ValidatorSets = append(rsp.ValidatorSets, rawVs)
+		}
+
+		protoLb := cmtproto.LightBlock{
+			SignedHeader: commit.SignedHeader.ToProto(),
+		}
+		meta, err := protoLb.Marshal()
+		if err != nil {
+			return nil, fmt.Errorf("cometbft: failed to marshal light block: %w", err)
+		}
+
+		rsp.Blocks = append(rsp.Blocks, &consensusAPI.CompactLightBlock{
+			Height:            height,
+			Meta:              meta,
+			ValidatorSetIndex: vsIndex,
+		})
+	}
+
+	return &rsp, nil
+}
+
+// validatorSetToProto converts a validator set into its protobuf representation suitable for
+// light client verification.
+func validatorSetToProto(vs *cmttypes.ValidatorSet) (*cmtproto.ValidatorSet, error) {
+	protoVs, err := vs.ToProto()
+	if err != nil {
+		return nil, fmt.Errorf("cometbft: failed to convert validator set: %w", err)
+	}
+	// Same as in GetLightBlock, the rust side requires TotalVotingPower to be set.
+	protoVs.TotalVotingPower = vs.TotalVotingPower()
+	return protoVs, nil
+}
+
 // Implements consensusAPI.Backend.
 func (n *commonNode) GetTransactions(ctx context.Context, height int64) ([][]byte, error) {
 	blk, err := n.GetCometBFTBlock(ctx, height)
@@ -693,73 +888,21 @@ func (n *commonNode) GetTransactionsWithResults(ctx context.Context, height int6
 		return nil, err
 	}
 	for txIdx, rs := range res.TxsResults {
-		// Transaction result.
-		result := &results.Result{
-			Error: results.Error{
-				Module:  rs.GetCodespace(),
-				Code:    rs.GetCode(),
-				Message: rs.GetLog(),
-			},
-			GasUsed: uint64(rs.GetGasUsed()),
-		}
-
-		// Transaction staking events.
-		stakingEvents, err := tmstaking.EventsFromCometBFT(
-			txsWithResults.Transactions[txIdx],
-			blk.Height,
-			rs.Events,
-		)
-		if err != nil {
-			return nil, err
-		}
-		for _, e := range stakingEvents {
-			result.Events = append(result.Events, &results.Event{Staking: e})
-		}
-
-		// Transaction registry events.
-		registryEvents, _, err := tmregistry.EventsFromCometBFT(
-			txsWithResults.Transactions[txIdx],
-			blk.Height,
-			rs.Events,
-		)
-		if err != nil {
-			return nil, err
-		}
-		for _, e := range registryEvents {
-			result.Events = append(result.Events, &results.Event{Registry: e})
-		}
-
-		// Transaction roothash events.
-		roothashEvents, err := tmroothash.EventsFromCometBFT(
-			txsWithResults.Transactions[txIdx],
-			blk.Height,
-			rs.Events,
-		)
+		result, err := resultFromCometBFT(txsWithResults.Transactions[txIdx], blk.Height, rs)
 		if err != nil {
 			return nil, err
 		}
-		for _, e := range roothashEvents {
-			result.Events = append(result.Events, &results.Event{RootHash: e})
-		}
-
-		// Transaction governance events.
-		governanceEvents, err := tmgovernance.EventsFromCometBFT(
-			txsWithResults.Transactions[txIdx],
-			blk.Height,
-			rs.Events,
-		)
-		if err != nil {
-			return nil, err
-		}
-		for _, e := range governanceEvents {
-			result.Events = append(result.Events, &results.Event{Governance: e})
-		}
-
 		txsWithResults.Results = append(txsWithResults.Results, result)
 	}
 	return &txsWithResults, nil
 }
 
+// Implements consensusAPI.Backend.
+func (n *commonNode) GetTransactionByHash(context.Context, hash.Hash) (*consensusAPI.TransactionWithResult, error) {
+	// Transactions are only indexed by archive nodes.
+	return nil, consensusAPI.ErrUnsupported
+}
+
 // Implements consensusAPI.Backend.
 func (n *commonNode) GetTransactionsWithProofs(ctx context.Context, height int64) (*consensusAPI.TransactionsWithProofs, error) {
 	txs, err := n.GetTransactions(ctx, height)
@@ -830,6 +973,26 @@ func (n *commonNode) GetParameters(ctx context.Context, height int64) (*consensu
 	}, nil
 }
 
+// Implements consensusAPI.Backend.
+func (n *commonNode) GetCheckpoints(ctx context.Context) ([]*checkpoint.Metadata, error) {
+	if err := n.ensureStarted(ctx); err != nil {
+		return nil, err
+	}
+
+	return n.mux.State().Storage().Checkpointer().GetCheckpoints(ctx, &checkpoint.GetCheckpointsRequest{
+		Version: checkpointVersion,
+	})
+}
+
+// Implements consensusAPI.Backend.
+func (n *commonNode) GetCheckpointChunk(ctx context.Context, chunk *checkpoint.ChunkMetadata, w io.Writer) error {
+	if err := n.ensureStarted(ctx); err != nil {
+		return err
+	}
+
+	return n.mux.State().Storage().Checkpointer().GetCheckpointChunk(ctx, chunk, w)
+}
+
 func (n *commonNode) SupportedFeatures() consensusAPI.FeatureMask {
 	return n.parentNode.SupportedFeatures()
 }
@@ -945,6 +1108,16 @@ func (n *commonNode) SubmitTxWithProof(context.Context, *transaction.SignedTrans
 	return nil, consensusAPI.ErrUnsupported
 }
 
+// Implements consensusAPI.Backend.
+func (n *commonNode) SubmitTxWithResults(context.Context, *transaction.SignedTransaction) (*consensusAPI.SubmitTxResult, error) {
+	return nil, consensusAPI.ErrUnsupported
+}
+
+// Implements consensusAPI.Backend.
+func (n *commonNode) SubmitTxAsync(context.Context, *transaction.SignedTransaction) (hash.Hash, error) {
+	return hash.Hash{}, consensusAPI.ErrUnsupported
+}
+
 // Implements consensusAPI.Backend.
 func (n *commonNode) GetUnconfirmedTransactions(context.Context) ([][]byte, error) {
 	return nil, consensusAPI.ErrUnsupported
@@ -996,5 +1169,6 @@ func newCommonNode(
 		svcMgr:                cmbackground.NewServiceManager(logging.GetLogger("cometbft/servicemanager")),
 		dbCloser:              db.NewCloser(),
 		startedCh:             make(chan struct{}),
+		eventLog:              lru.New(lru.Capacity(eventLogCapacity, false)),
 	}, nil
 }
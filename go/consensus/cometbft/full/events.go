@@ -0,0 +1,268 @@
+package full
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	cmtabcitypes "github.com/cometbft/cometbft/abci/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	consensusAPI "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction/results"
+	tmgovernance "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/governance"
+	tmregistry "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/registry"
+	tmroothash "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/roothash"
+	tmstaking "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/staking"
+	tmvault "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/vault"
+)
+
+// eventLogCapacity is the number of recent blocks for which events are kept in the event log.
+const eventLogCapacity = 128
+
+// eventsFromCometBFT extracts all supported consensus events from CometBFT events.
+func eventsFromCometBFT(tx cmttypes.Tx, height int64, tmEvents []cmtabcitypes.Event) ([]*results.Event, error) {
+	var events []*results.Event
+
+	// Staking events.
+	stakingEvents, err := tmstaking.EventsFromCometBFT(tx, height, tmEvents)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range stakingEvents {
+		events = append(events, &results.Event{Staking: e})
+	}
+
+	// Registry events.
+	registryEvents, _, err := tmregistry.EventsFromCometBFT(tx, height, tmEvents)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range registryEvents {
+		events = append(events, &results.Event{Registry: e})
+	}
+
+	// Roothash events.
+	roothashEvents, err := tmroothash.EventsFromCometBFT(tx, height, tmEvents)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range roothashEvents {
+		events = append(events, &results.Event{RootHash: e})
+	}
+
+	// Governance events.
+	governanceEvents, err := tmgovernance.EventsFromCometBFT(tx, height, tmEvents)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range governanceEvents {
+		events = append(events, &results.Event{Governance: e})
+	}
+
+	// Vault events.
+	vaultEvents, err := tmvault.EventsFromCometBFT(tx, height, tmEvents)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range vaultEvents {
+		events = append(events, &results.Event{Vault: e})
+	}
+
+	// Restore the order in which events were emitted across all applications. Events emitted
+	// before event indexing was introduced all have a zero index and keep their order.
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Index() < events[j].Index()
+	})
+
+	return events, nil
+}
+
+// getEvents returns all consensus events emitted in the block at the given height, in the order
+// in which they were emitted.
+//
+// Events of recent blocks are kept in a short-lived event log so that clients resuming event
+// streams after a disconnect can be served without decoding block results again.
+func (n *commonNode) getEvents(ctx context.Context, height int64) ([]*results.Event, error) {
+	if events, ok := n.eventLog.Get(height); ok {
+		return events.([]*results.Event), nil
+	}
+
+	blk, err := n.GetCometBFTBlock(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	if blk == nil {
+		return nil, consensusAPI.ErrNoCommittedBlocks
+	}
+	res, err := n.GetBlockResults(ctx, blk.Height)
+	if err != nil {
+		return nil, err
+	}
+	if len(res.TxsResults) != len(blk.Data.Txs) {
+		return nil, fmt.Errorf("cometbft: mismatched number of transactions and results")
+	}
+
+	// Events emitted at the beginning of the block.
+	events, err := eventsFromCometBFT(nil, blk.Height, res.BeginBlockEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	// Events emitted by transactions.
+	for txIdx, rs := range res.TxsResults {
+		evs, err := eventsFromCometBFT(blk.Data.Txs[txIdx], blk.Height, rs.Events)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, evs...)
+	}
+
+	// Events emitted at the end of the block.
+	evs, err := eventsFromCometBFT(nil, blk.Height, res.EndBlockEvents)
+	if err != nil {
+		return nil, err
+	}
+	events = append(events, evs...)
+
+	_ = n.eventLog.Put(blk.Height, events)
+
+	return events, nil
+}
+
+// Implements consensusAPI.Backend.
+func (n *commonNode) GetEventsByHeight(ctx context.Context, height int64) ([]*results.Event, error) {
+	if err := n.ensureStarted(ctx); err != nil {
+		return nil, err
+	}
+	return n.getEvents(ctx, height)
+}
+
+// Implements consensusAPI.Backend.
+func (n *commonNode) WatchEvents(ctx context.Context, req *consensusAPI.WatchEventsRequest) (<-chan *consensusAPI.WatchedEvent, pubsub.ClosableSubscription, error) {
+	if err := req.SanityCheck(); err != nil {
+		return nil, nil, err
+	}
+	if err := n.ensureStarted(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	// Subscribe to new blocks before catching up so that no blocks are missed.
+	blkCh, blkSub, err := n.parentNode.WatchBlocks(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	next, catchUp := req.StartCursor()
+	if catchUp {
+		lastRetained, err := n.GetLastRetainedVersion(ctx)
+		if err != nil {
+			blkSub.Close()
+			return nil, nil, err
+		}
+		if next.Height < lastRetained {
+			blkSub.Close()
+			return nil, nil, fmt.Errorf("%w: height %d is not available (last retained height: %d)",
+				consensusAPI.ErrVersionNotFound, next.Height, lastRetained,
+			)
+		}
+	}
+
+	ctx, sub := pubsub.NewContextSubscription(ctx)
+	ch := make(chan *consensusAPI.WatchedEvent)
+
+	// sendEvents sends all matching events starting with the next cursor up to and including the
+	// given height.
+	sendEvents := func(height int64) bool {
+		for ; next.Height <= height; next.Height, next.Index = next.Height+1, 0 {
+			events, err := n.getEvents(ctx, next.Height)
+			if err != nil {
+				n.Logger.Error("failed to get events",
+					"err", err,
+					"height", next.Height,
+				)
+				return false
+			}
+
+			for idx := int(next.Index); idx < len(events); idx++ {
+				if !req.Filter.Matches(events[idx]) {
+					continue
+				}
+
+				ev := &consensusAPI.WatchedEvent{
+					Cursor: consensusAPI.EventCursor{
+						Height: next.Height,
+						Index:  uint32(idx),
+					},
+					Event: events[idx],
+				}
+				select {
+				case ch <- ev:
+				case <-ctx.Done():
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	go func() {
+		defer close(ch)
+		defer blkSub.Close()
+
+		// Catch up with the already finalized blocks, if requested.
+		if catchUp {
+			latest, err := n.GetBlock(ctx, consensusAPI.HeightLatest)
+			if err != nil {
+				n.Logger.Error("failed to get latest block",
+					"err", err,
+				)
+				return
+			}
+			if !sendEvents(latest.Height) {
+				return
+			}
+		}
+
+		for {
+			select {
+			case blk, ok := <-blkCh:
+				if !ok {
+					return
+				}
+				if next.Height == 0 {
+					next.Height = blk.Height
+				}
+				if !sendEvents(blk.Height) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, sub, nil
+}
+
+// resultFromCometBFT converts a CometBFT transaction execution result into a transaction result.
+func resultFromCometBFT(tx cmttypes.Tx, height int64, rs *cmtabcitypes.ResponseDeliverTx) (*results.Result, error) {
+	// Transaction result.
+	result := &results.Result{
+		Error: results.Error{
+			Module:  rs.GetCodespace(),
+			Code:    rs.GetCode(),
+			Message: rs.GetLog(),
+		},
+		GasUsed: uint64(rs.GetGasUsed()),
+	}
+
+	// Transaction events.
+	var err error
+	if result.Events, err = eventsFromCometBFT(tx, height, rs.Events); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"os"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -21,7 +22,6 @@ import (
 	cmtnode "github.com/cometbft/cometbft/node"
 	cmtp2p "github.com/cometbft/cometbft/p2p"
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
-	cmtproxy "github.com/cometbft/cometbft/proxy"
 	cmtcli "github.com/cometbft/cometbft/rpc/client/local"
 	cmtstate "github.com/cometbft/cometbft/state"
 	cmtstatesync "github.com/cometbft/cometbft/statesync"
@@ -42,6 +42,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/abci"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/blockarchive"
 	tmcommon "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/common"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/crypto"
 	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/db"
@@ -89,6 +90,8 @@ type fullService struct { // nolint: maligned
 	client        *cmtcli.Local
 	blockNotifier *pubsub.Broker
 	failMonitor   *failMonitor
+	clockSkew     *clockSkewMonitor
+	peerAllowlist *p2pAPI.PeerAllowlist
 
 	submissionMgr consensusAPI.SubmissionManager
 
@@ -142,6 +145,13 @@ func (t *fullService) Start() error {
 		go t.syncWorker()
 		// Start block notifier.
 		go t.blockNotifierWorker()
+		// Start clock skew monitor.
+		go t.clockSkewWorker()
+		go t.clockSkewVoteWorker()
+		// Optionally start peer allowlist updater.
+		if t.peerAllowlist != nil {
+			go t.peerAllowlistWorker()
+		}
 		// Optionally start metrics updater.
 		if cmmetrics.Enabled() {
 			go t.metrics()
@@ -199,6 +209,35 @@ func (t *fullService) SubmitTxNoWait(_ context.Context, tx *transaction.SignedTr
 	return t.broadcastTxRaw(cbor.Marshal(tx))
 }
 
+// Implements consensusAPI.Backend.
+func (t *fullService) SubmitTxAsync(_ context.Context, tx *transaction.SignedTransaction) (hash.Hash, error) {
+	data := cbor.Marshal(tx)
+	if err := t.broadcastTxRaw(data); err != nil {
+		return hash.Hash{}, err
+	}
+	return hash.NewFromBytes(data), nil
+}
+
+// Implements consensusAPI.Backend.
+func (t *fullService) SubmitTxWithResults(ctx context.Context, tx *transaction.SignedTransaction) (*consensusAPI.SubmitTxResult, error) {
+	data, err := t.submitTxAndWait(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := resultFromCometBFT(data.Tx, data.Height, &data.Result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &consensusAPI.SubmitTxResult{
+		Hash:   hash.NewFromBytes(data.Tx),
+		Height: data.Height,
+		Index:  data.Index,
+		Result: result,
+	}, nil
+}
+
 // Implements consensusAPI.Backend.
 func (t *fullService) SubmitTxWithProof(ctx context.Context, tx *transaction.SignedTransaction) (*transaction.Proof, error) {
 	data, err := t.submitTx(ctx, tx)
@@ -222,6 +261,19 @@ func (t *fullService) SubmitTxWithProof(ctx context.Context, tx *transaction.Sig
 }
 
 func (t *fullService) submitTx(ctx context.Context, tx *transaction.SignedTransaction) (*cmttypes.EventDataTx, error) {
+	data, err := t.submitTxAndWait(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	if result := data.Result; !result.IsOK() {
+		return nil, errors.FromCode(result.GetCodespace(), result.GetCode(), result.GetLog())
+	}
+	return data, nil
+}
+
+// submitTxAndWait broadcasts the transaction and waits for it to be included in a block. The
+// execution result is returned without checking whether the transaction was successful.
+func (t *fullService) submitTxAndWait(ctx context.Context, tx *transaction.SignedTransaction) (*cmttypes.EventDataTx, error) {
 	// Subscribe to the transaction being included in a block.
 	data := cbor.Marshal(tx)
 	query := cmttypes.EventQueryTxFor(data)
@@ -258,9 +310,6 @@ func (t *fullService) submitTx(ctx context.Context, tx *transaction.SignedTransa
 		return nil, v
 	case v := <-txSub.Out():
 		data := v.Data().(cmttypes.EventDataTx)
-		if result := data.Result; !result.IsOK() {
-			return nil, errors.FromCode(result.GetCodespace(), result.GetCode(), result.GetLog())
-		}
 		return &data, nil
 	case <-txSub.Cancelled():
 		return nil, context.Canceled
@@ -544,12 +593,53 @@ func (t *fullService) lazyInit() error { // nolint: gocyclo
 		CheckpointerCheckInterval: config.GlobalConfig.Consensus.Checkpointer.CheckInterval,
 		InitialHeight:             uint64(t.genesis.Height),
 		ChainContext:              t.genesis.ChainContext(),
+		Mempool: abci.MempoolConfig{
+			FeeOrdering:     config.GlobalConfig.Consensus.Mempool.FeeOrdering,
+			MaxTxsPerSender: config.GlobalConfig.Consensus.Mempool.MaxTxsPerSender,
+		},
+		SnapshotProvider: abci.SnapshotProviderConfig{
+			Disabled:            config.GlobalConfig.Consensus.SnapshotProvider.Disabled,
+			NumKept:             config.GlobalConfig.Consensus.SnapshotProvider.NumKept,
+			Interval:            config.GlobalConfig.Consensus.SnapshotProvider.Interval,
+			MaxConcurrentChunks: config.GlobalConfig.Consensus.SnapshotProvider.MaxConcurrentChunks,
+			MaxBandwidth:        config.GlobalConfig.Consensus.SnapshotProvider.MaxBandwidth,
+		},
+	}
+	if t.peerAllowlist, err = p2pAPI.LoadPeerAllowlist(); err != nil {
+		return err
+	}
+	if t.peerAllowlist != nil {
+		appConfig.PeerFilter = t.filterPeer
 	}
 	t.mux, err = abci.NewApplicationServer(t.ctx, t.upgrader, appConfig)
 	if err != nil {
 		return err
 	}
 
+	// Configure archival of pruned blocks if enabled.
+	var archiveSink blockarchive.Sink
+	switch pruneCfg := config.GlobalConfig.Consensus.Prune; {
+	case pruneCfg.ArchiveDir != "":
+		if archiveSink, err = blockarchive.NewFileSink(pruneCfg.ArchiveDir); err != nil {
+			return err
+		}
+	case pruneCfg.ArchiveS3.Bucket != "":
+		archiveSink, err = blockarchive.NewS3Sink(blockarchive.S3Config{
+			Endpoint:        pruneCfg.ArchiveS3.Endpoint,
+			Region:          pruneCfg.ArchiveS3.Region,
+			Bucket:          pruneCfg.ArchiveS3.Bucket,
+			Prefix:          pruneCfg.ArchiveS3.Prefix,
+			AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if archiveSink != nil {
+		t.blockArchive = blockarchive.New(archiveSink)
+	}
+
 	// CometBFT needs the on-disk directories to be present when
 	// launched like this, so create the relevant sub-directories
 	// under the node DataDir.
@@ -576,6 +666,11 @@ func (t *fullService) lazyInit() error { // nolint: gocyclo
 		return fmt.Errorf("cometbft: failed to convert unconditional peer public keys: %w", err)
 	}
 
+	engineConfig, err := config.GlobalConfig.Consensus.Engine.Resolve()
+	if err != nil {
+		return fmt.Errorf("cometbft: invalid engine configuration: %w", err)
+	}
+
 	// Create CometBFT node.
 	cometConfig := cmtconfig.DefaultConfig()
 	_ = viper.Unmarshal(&cometConfig)
@@ -586,8 +681,11 @@ func (t *fullService) lazyInit() error { // nolint: gocyclo
 	cometConfig.Consensus.SkipTimeoutCommit = t.genesis.Consensus.Parameters.SkipTimeoutCommit
 	cometConfig.Consensus.CreateEmptyBlocks = true
 	cometConfig.Consensus.CreateEmptyBlocksInterval = emptyBlockInterval
-	cometConfig.Consensus.DebugUnsafeReplayRecoverCorruptedWAL = config.GlobalConfig.Consensus.Debug.UnsafeReplayRecoverCorruptedWAL && cmflags.DebugDontBlameOasis()
+	cometConfig.Consensus.DebugUnsafeReplayRecoverCorruptedWAL = config.GlobalConfig.Consensus.Debug.UnsafeReplayRecoverCorruptedWAL && cmflags.DebugUnsafeConsensus()
 	cometConfig.Mempool.Version = cmtconfig.MempoolV1
+	cometConfig.Mempool.Size = config.GlobalConfig.Consensus.Mempool.Size
+	cometConfig.Mempool.MaxTxsBytes = config.GlobalConfig.Consensus.Mempool.MaxTxsBytes
+	cometConfig.Mempool.CacheSize = config.GlobalConfig.Consensus.Mempool.CacheSize
 	cometConfig.Instrumentation.Prometheus = true
 	cometConfig.Instrumentation.PrometheusListenAddr = ""
 	cometConfig.TxIndex.Indexer = "null"
@@ -602,9 +700,15 @@ func (t *fullService) lazyInit() error { // nolint: gocyclo
 	cometConfig.P2P.PersistentPeersMaxDialPeriod = config.GlobalConfig.Consensus.P2P.PersistenPeersMaxDialPeriod
 	cometConfig.P2P.UnconditionalPeerIDs = strings.Join(unconditionalPeers, ",")
 	cometConfig.P2P.Seeds = strings.Join(seeds, ",")
-	cometConfig.P2P.AddrBookStrict = !(config.GlobalConfig.Consensus.Debug.P2PAddrBookLenient && cmflags.DebugDontBlameOasis())
-	cometConfig.P2P.AllowDuplicateIP = config.GlobalConfig.Consensus.Debug.P2PAllowDuplicateIP && cmflags.DebugDontBlameOasis()
+	cometConfig.P2P.AddrBookStrict = !(config.GlobalConfig.Consensus.Debug.P2PAddrBookLenient && cmflags.DebugUnsafeP2P())
+	cometConfig.P2P.AllowDuplicateIP = config.GlobalConfig.Consensus.Debug.P2PAllowDuplicateIP && cmflags.DebugUnsafeP2P()
+	cometConfig.P2P.HandshakeTimeout = engineConfig.P2P.HandshakeTimeout
+	cometConfig.P2P.DialTimeout = engineConfig.P2P.DialTimeout
+	cometConfig.P2P.FlushThrottleTimeout = engineConfig.P2P.FlushThrottleTimeout
+	cometConfig.Consensus.PeerGossipSleepDuration = engineConfig.Consensus.PeerGossipSleepDuration
+	cometConfig.Consensus.PeerQueryMaj23SleepDuration = engineConfig.Consensus.PeerQueryMaj23SleepDuration
 	cometConfig.RPC.ListenAddress = ""
+	cometConfig.FilterPeers = t.peerAllowlist != nil
 
 	if len(sentryUpstreamAddrs) > 0 {
 		t.Logger.Info("Acting as a cometbft sentry", "addrs", sentryUpstreamAddrs)
@@ -637,6 +741,20 @@ func (t *fullService) lazyInit() error { // nolint: gocyclo
 		return err
 	}
 
+	// Monitor the clock skew and optionally refuse to sign when it is too large.
+	consensusPubKey := t.identity.ConsensusSigner.Public()
+	t.clockSkew = newClockSkewMonitor(
+		t.Logger,
+		[]byte(crypto.PublicKeyToCometBFT(&consensusPubKey).Address()),
+		config.GlobalConfig.Consensus.ClockSkew.WarnThreshold,
+		config.GlobalConfig.Consensus.ClockSkew.RefuseThreshold,
+	)
+	cometbftPV = &clockSkewGuard{
+		inner:   cometbftPV,
+		monitor: t.clockSkew,
+		logger:  t.Logger,
+	}
+
 	tmGenDoc, err := api.GetCometBFTGenesisDocument(t.genesisProvider)
 	if err != nil {
 		t.Logger.Error("failed to obtain genesis document",
@@ -734,10 +852,19 @@ func (t *fullService) lazyInit() error { // nolint: gocyclo
 			}
 		}
 
+		// Import a staged consensus checkpoint, if any. This must happen before the node is
+		// created as that opens the CometBFT stores.
+		if err = t.importStagedCheckpoint(tmGenDoc, dbProvider, cometConfig); err != nil {
+			t.Logger.Error("failed to import staged consensus checkpoint",
+				"err", err,
+			)
+			return fmt.Errorf("cometbft: failed to import checkpoint: %w", err)
+		}
+
 		t.node, err = cmtnode.NewNode(cometConfig,
 			cometbftPV,
 			&cmtp2p.NodeKey{PrivKey: crypto.SignerToCometBFT(t.identity.P2PSigner)},
-			cmtproxy.NewLocalClientCreator(t.mux.Mux()),
+			abci.NewLocalClientCreator(t.mux.Mux()),
 			cometbftGenesisProvider,
 			wrapDbProvider,
 			cmtnode.DefaultMetricsProvider(cometConfig.Instrumentation),
@@ -752,6 +879,13 @@ func (t *fullService) lazyInit() error { // nolint: gocyclo
 			return fmt.Errorf("cometbft: internal error: state database not set")
 		}
 		t.client = cmtcli.New(t.node)
+		if t.blockArchive != nil {
+			var ph api.StatePruneHandler
+			if ph, err = t.blockArchive.PruneHandler(t.ctx, t.node.BlockStore(), t.stateStore); err != nil {
+				return fmt.Errorf("cometbft: failed to create block archive prune handler: %w", err)
+			}
+			t.mux.Pruner().RegisterHandler(ph)
+		}
 		t.failMonitor = newFailMonitor(t.ctx, t.Logger, t.node.ConsensusState().Wait)
 
 		// Register a halt hook that handles upgrades gracefully.
@@ -795,6 +929,38 @@ func (t *fullService) lazyInit() error { // nolint: gocyclo
 	return nil
 }
 
+// filterPeer rejects consensus peers that are not in the peer allowlist.
+func (t *fullService) filterPeer(id string) error {
+	for _, pk := range t.peerAllowlist.Peers() {
+		if string(cmtp2p.PubKeyToID(crypto.PublicKeyToCometBFT(&pk))) == id {
+			return nil
+		}
+	}
+	return fmt.Errorf("peer not in allowlist")
+}
+
+func (t *fullService) peerAllowlistWorker() {
+	// The updater does not wait for the node to be synced as registered nodes are discovered
+	// while blocks are being replayed.
+	go t.peerAllowlist.Watch(t.ctx, t.Registry())
+
+	// Stop allowing bootstrap peers once the registry state is available.
+	select {
+	case <-t.Synced():
+	case <-t.ctx.Done():
+		return
+	}
+
+	nodes, err := t.Registry().GetNodes(t.ctx, consensusAPI.HeightLatest)
+	if err != nil {
+		t.Logger.Error("failed to fetch registered nodes, bootstrap peers remain allowed",
+			"err", err,
+		)
+		return
+	}
+	t.peerAllowlist.FinishBootstrap(nodes)
+}
+
 func (t *fullService) syncWorker() {
 	checkSyncFn := func() (isSyncing bool, err error) {
 		defer func() {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"sync"
 
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	cmtstate "github.com/cometbft/cometbft/state"
 	cmtstatesync "github.com/cometbft/cometbft/statesync"
 	cmttypes "github.com/cometbft/cometbft/types"
@@ -16,10 +17,19 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/p2p/rpc"
 )
 
+// verifiedLightBlockSource is a source of verified light blocks and consensus parameters.
+type verifiedLightBlockSource interface {
+	// GetVerifiedLightBlock returns a verified light block.
+	GetVerifiedLightBlock(ctx context.Context, height int64) (*cmttypes.LightBlock, error)
+
+	// GetVerifiedParameters returns verified consensus parameters.
+	GetVerifiedParameters(ctx context.Context, height int64) (*cmtproto.ConsensusParams, error)
+}
+
 type stateProvider struct {
 	sync.Mutex
 
-	lc              lightAPI.Client
+	lc              verifiedLightBlockSource
 	genesisDocument *cmttypes.GenesisDoc
 
 	logger *logging.Logger
@@ -0,0 +1,195 @@
+package full
+
+import (
+	"context"
+	"fmt"
+
+	cmted25519 "github.com/cometbft/cometbft/crypto/ed25519"
+	cmttypes "github.com/cometbft/cometbft/types"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	consensusAPI "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/crypto"
+)
+
+// validatorPublicKey returns the consensus public key of the given CometBFT validator.
+func validatorPublicKey(v *cmttypes.Validator) (signature.PublicKey, error) {
+	pk, ok := v.PubKey.(cmted25519.PubKey)
+	if !ok {
+		return signature.PublicKey{}, fmt.Errorf("cometbft: unsupported validator public key type: %s", v.PubKey.Type())
+	}
+	return crypto.PublicKeyFromCometBFT(&pk), nil
+}
+
+// getValidatorSetChange returns the change of the validator set at the given height, or nil in
+// case the validator set did not change.
+func (n *commonNode) getValidatorSetChange(height int64) (*consensusAPI.ValidatorSetChange, error) {
+	vals, err := n.stateStore.LoadValidators(height)
+	if err != nil {
+		return nil, fmt.Errorf("%w: validator set at height %d is not available: %w",
+			consensusAPI.ErrVersionNotFound, height, err,
+		)
+	}
+
+	// The validator set at the initial height is compared against an empty set.
+	prevVals := &cmttypes.ValidatorSet{}
+	if height > n.genesis.Height {
+		if prevVals, err = n.stateStore.LoadValidators(height - 1); err != nil {
+			return nil, fmt.Errorf("%w: validator set at height %d is not available: %w",
+				consensusAPI.ErrVersionNotFound, height-1, err,
+			)
+		}
+	}
+
+	change := consensusAPI.ValidatorSetChange{
+		Height:           height,
+		TotalVotingPower: vals.TotalVotingPower(),
+	}
+	for _, v := range vals.Validators {
+		pk, err := validatorPublicKey(v)
+		if err != nil {
+			return nil, err
+		}
+
+		_, prev := prevVals.GetByAddress(v.Address)
+		switch {
+		case prev == nil:
+			change.Added = append(change.Added, &consensusAPI.ValidatorPower{
+				PublicKey:   pk,
+				VotingPower: v.VotingPower,
+			})
+		case prev.VotingPower != v.VotingPower:
+			change.Updated = append(change.Updated, &consensusAPI.ValidatorPowerChange{
+				PublicKey:      pk,
+				OldVotingPower: prev.VotingPower,
+				NewVotingPower: v.VotingPower,
+			})
+		}
+	}
+	for _, v := range prevVals.Validators {
+		if vals.HasAddress(v.Address) {
+			continue
+		}
+		pk, err := validatorPublicKey(v)
+		if err != nil {
+			return nil, err
+		}
+		change.Removed = append(change.Removed, pk)
+	}
+
+	if len(change.Added) == 0 && len(change.Removed) == 0 && len(change.Updated) == 0 {
+		return nil, nil
+	}
+
+	// Derive the proposer schedule assuming that all blocks are proposed in the first round.
+	schedule := vals.Copy()
+	for i := 0; i < consensusAPI.ValidatorSetProposerHints; i++ {
+		pk, err := validatorPublicKey(schedule.GetProposer())
+		if err != nil {
+			return nil, err
+		}
+		change.Proposers = append(change.Proposers, pk)
+		schedule.IncrementProposerPriority(1)
+	}
+
+	return &change, nil
+}
+
+// Implements consensusAPI.Backend.
+func (n *commonNode) WatchValidatorSetChanges(ctx context.Context, req *consensusAPI.WatchValidatorSetChangesRequest) (<-chan *consensusAPI.ValidatorSetChange, pubsub.ClosableSubscription, error) {
+	if err := req.SanityCheck(); err != nil {
+		return nil, nil, err
+	}
+	if err := n.ensureStarted(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	// Subscribe to new blocks before catching up so that no blocks are missed.
+	blkCh, blkSub, err := n.parentNode.WatchBlocks(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	next := req.Height
+	catchUp := next != consensusAPI.HeightLatest
+	if catchUp {
+		lastRetained, err := n.GetLastRetainedVersion(ctx)
+		if err != nil {
+			blkSub.Close()
+			return nil, nil, err
+		}
+		if next < lastRetained {
+			blkSub.Close()
+			return nil, nil, fmt.Errorf("%w: height %d is not available (last retained height: %d)",
+				consensusAPI.ErrVersionNotFound, next, lastRetained,
+			)
+		}
+	}
+
+	ctx, sub := pubsub.NewContextSubscription(ctx)
+	ch := make(chan *consensusAPI.ValidatorSetChange)
+
+	// sendChanges sends all validator set changes starting with the next height up to and
+	// including the given height.
+	sendChanges := func(height int64) bool {
+		for ; next <= height; next++ {
+			change, err := n.getValidatorSetChange(next)
+			if err != nil {
+				n.Logger.Error("failed to get validator set change",
+					"err", err,
+					"height", next,
+				)
+				return false
+			}
+			if change == nil {
+				continue
+			}
+
+			select {
+			case ch <- change:
+			case <-ctx.Done():
+				return false
+			}
+		}
+		return true
+	}
+
+	go func() {
+		defer close(ch)
+		defer blkSub.Close()
+
+		// Catch up with the already finalized blocks, if requested.
+		if catchUp {
+			latest, err := n.GetBlock(ctx, consensusAPI.HeightLatest)
+			if err != nil {
+				n.Logger.Error("failed to get latest block",
+					"err", err,
+				)
+				return
+			}
+			if !sendChanges(latest.Height) {
+				return
+			}
+		}
+
+		for {
+			select {
+			case blk, ok := <-blkCh:
+				if !ok {
+					return
+				}
+				if next == consensusAPI.HeightLatest {
+					next = blk.Height
+				}
+				if !sendChanges(blk.Height) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, sub, nil
+}
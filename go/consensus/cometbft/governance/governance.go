@@ -74,6 +74,15 @@ func (sc *serviceClient) Votes(ctx context.Context, query *api.ProposalQuery) ([
 	return q.Votes(ctx, query.ProposalID)
 }
 
+func (sc *serviceClient) MissingVotes(ctx context.Context, query *api.MissingVotesQuery) ([]*api.MissingVotes, error) {
+	q, err := sc.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.MissingVotes(ctx, query.ClosingWithin, query.Entities)
+}
+
 func (sc *serviceClient) PendingUpgrades(ctx context.Context, height int64) ([]*upgrade.Descriptor, error) {
 	q, err := sc.querier.QueryAt(ctx, height)
 	if err != nil {
@@ -206,7 +215,8 @@ func EventsFromCometBFT(
 			continue
 		}
 
-		for _, pair := range tmEv.GetAttributes() {
+		firstIndex := tmapi.EventIndex(tmEv)
+		for idx, pair := range tmEv.GetAttributes() {
 			key := pair.GetKey()
 			val := pair.GetValue()
 
@@ -219,7 +229,7 @@ func EventsFromCometBFT(
 					continue
 				}
 
-				evt := &api.Event{Height: height, TxHash: txHash, ProposalSubmitted: &e}
+				evt := &api.Event{Height: height, TxHash: txHash, Index: firstIndex + uint32(idx), ProposalSubmitted: &e}
 				events = append(events, evt)
 			case eventsAPI.IsAttributeKind(key, &api.ProposalExecutedEvent{}):
 				//  Proposal executed event.
@@ -229,7 +239,7 @@ func EventsFromCometBFT(
 					continue
 				}
 
-				evt := &api.Event{Height: height, TxHash: txHash, ProposalExecuted: &e}
+				evt := &api.Event{Height: height, TxHash: txHash, Index: firstIndex + uint32(idx), ProposalExecuted: &e}
 				events = append(events, evt)
 			case eventsAPI.IsAttributeKind(key, &api.ProposalFinalizedEvent{}):
 				// Proposal finalized event.
@@ -239,7 +249,7 @@ func EventsFromCometBFT(
 					continue
 				}
 
-				evt := &api.Event{Height: height, TxHash: txHash, ProposalFinalized: &e}
+				evt := &api.Event{Height: height, TxHash: txHash, Index: firstIndex + uint32(idx), ProposalFinalized: &e}
 				events = append(events, evt)
 			case eventsAPI.IsAttributeKind(key, &api.VoteEvent{}):
 				// Vote event.
@@ -249,8 +259,20 @@ func EventsFromCometBFT(
 					continue
 				}
 
-				evt := &api.Event{Height: height, TxHash: txHash, Vote: &e}
+				evt := &api.Event{Height: height, TxHash: txHash, Index: firstIndex + uint32(idx), Vote: &e}
+				events = append(events, evt)
+			case eventsAPI.IsAttributeKind(key, &api.ProposalVotingReminderEvent{}):
+				// Proposal voting reminder event.
+				var e api.ProposalVotingReminderEvent
+				if err := eventsAPI.DecodeValue(val, &e); err != nil {
+					errs = errors.Join(errs, fmt.Errorf("governance: corrupt ProposalVotingReminder event: %w", err))
+					continue
+				}
+
+				evt := &api.Event{Height: height, TxHash: txHash, Index: firstIndex + uint32(idx), ProposalVotingReminder: &e}
 				events = append(events, evt)
+			case tmapi.IsEventIndexAttribute(key):
+				// Block-level event index, handled above.
 			default:
 				errs = errors.Join(errs, fmt.Errorf("governance: unknown event type: key: %s, val: %s", key, val))
 			}
@@ -77,6 +77,13 @@ func (lc *lightClient) GetLightBlock(ctx context.Context, height int64) (*consen
 	})
 }
 
+// GetLightBlockRange implements api.Client.
+func (lc *lightClient) GetLightBlockRange(ctx context.Context, req *consensus.GetLightBlockRangeRequest) (*consensus.LightBlockRange, rpc.PeerFeedback, error) {
+	return tryProviders(ctx, lc, func(p api.Provider) (*consensus.LightBlockRange, rpc.PeerFeedback, error) {
+		return p.GetLightBlockRange(ctx, req)
+	})
+}
+
 // GetParameters implements api.Client.
 func (lc *lightClient) GetParameters(ctx context.Context, height int64) (*consensus.Parameters, rpc.PeerFeedback, error) {
 	return tryProviders(ctx, lc, func(p api.Provider) (*consensus.Parameters, rpc.PeerFeedback, error) {
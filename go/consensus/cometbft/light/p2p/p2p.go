@@ -273,6 +273,28 @@ func (lp *lightClientProvider) GetLightBlock(ctx context.Context, height int64)
 	return &rsp, pf, nil
 }
 
+// GetLightBlockRange implements api.Provider.
+func (lp *lightClientProvider) GetLightBlockRange(ctx context.Context, req *consensus.GetLightBlockRangeRequest) (*consensus.LightBlockRange, rpc.PeerFeedback, error) {
+	peerID := lp.getPeer()
+	if peerID == nil {
+		return nil, nil, consensus.ErrVersionNotFound
+	}
+
+	var rsp consensus.LightBlockRange
+	pf, err := lp.rc.Call(ctx, *peerID, light.MethodGetLightBlockRange, req, &rsp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Ensure peer returned the blocks for the queried range.
+	if err = rsp.SanityCheck(req); err != nil {
+		pf.RecordBadPeer()
+		return nil, nil, consensus.ErrVersionNotFound
+	}
+
+	return &rsp, pf, nil
+}
+
 // GetParameters implements api.Provider.
 func (lp *lightClientProvider) GetParameters(ctx context.Context, height int64) (*consensus.Parameters, rpc.PeerFeedback, error) {
 	peerID := lp.getPeer()
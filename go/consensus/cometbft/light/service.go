@@ -326,6 +326,28 @@ func (c *client) GetLightBlock(ctx context.Context, height int64) (*consensus.Li
 	return nil, nil, mergedErr
 }
 
+// GetLightBlockRange implements api.Client.
+func (c *client) GetLightBlockRange(ctx context.Context, req *consensus.GetLightBlockRangeRequest) (*consensus.LightBlockRange, rpc.PeerFeedback, error) {
+	select {
+	case <-c.initCh:
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	}
+
+	// Try the local backend first and fall back to querying peers directly.
+	lbr, err := c.consensus.GetLightBlockRange(ctx, req)
+	if err == nil {
+		return lbr, rpc.NewNopPeerFeedback(), nil
+	}
+	c.logger.Debug("failed to fetch light block range from local full node",
+		"err", err,
+		"start_height", req.StartHeight,
+		"end_height", req.EndHeight,
+	)
+
+	return c.lc.GetLightBlockRange(ctx, req)
+}
+
 // GetParameters implements api.Client.
 func (c *client) GetParameters(ctx context.Context, height int64) (*consensus.Parameters, rpc.PeerFeedback, error) {
 	select {
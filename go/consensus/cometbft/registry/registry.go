@@ -13,6 +13,7 @@ import (
 	"github.com/eapache/channels"
 
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/entity"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
@@ -72,6 +73,15 @@ func (sc *serviceClient) GetEntities(ctx context.Context, height int64) ([]*enti
 	return q.Entities(ctx)
 }
 
+func (sc *serviceClient) GetFrozenEntities(ctx context.Context, height int64) ([]signature.PublicKey, error) {
+	q, err := sc.querier.QueryAt(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.FrozenEntities(ctx)
+}
+
 func (sc *serviceClient) WatchEntities(context.Context) (<-chan *api.EntityEvent, pubsub.ClosableSubscription, error) {
 	typedCh := make(chan *api.EntityEvent)
 	sub := sc.entityNotifier.Subscribe()
@@ -107,6 +117,24 @@ func (sc *serviceClient) GetNodes(ctx context.Context, height int64) ([]*node.No
 	return q.Nodes(ctx)
 }
 
+func (sc *serviceClient) GetNodesForRuntime(ctx context.Context, query *api.NamespaceQuery) ([]*node.Node, error) {
+	q, err := sc.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.NodesForRuntime(ctx, query.ID)
+}
+
+func (sc *serviceClient) ListNodes(ctx context.Context, query *api.ListNodesQuery) (*api.ListNodesResponse, error) {
+	q, err := sc.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.ListNodes(ctx, query.After, query.Limit, &query.Filter)
+}
+
 func (sc *serviceClient) GetNodeByConsensusAddress(ctx context.Context, query *api.ConsensusAddressQuery) (*node.Node, error) {
 	q, err := sc.querier.QueryAt(ctx, query.Height)
 	if err != nil {
@@ -152,6 +180,24 @@ func (sc *serviceClient) WatchRuntimes(_ context.Context) (<-chan *api.Runtime,
 func (sc *serviceClient) Cleanup() {
 }
 
+func (sc *serviceClient) ListRuntimes(ctx context.Context, query *api.ListRuntimesQuery) (*api.ListRuntimesResponse, error) {
+	q, err := sc.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.ListRuntimes(ctx, query.IncludeSuspended, query.After, query.Limit, &query.Filter)
+}
+
+func (sc *serviceClient) GetRuntimeTEEHistory(ctx context.Context, query *api.NamespaceQuery) ([]*api.RuntimeTEEHistoryRecord, error) {
+	q, err := sc.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.RuntimeTEEHistory(ctx, query.ID)
+}
+
 func (sc *serviceClient) GetRuntimes(ctx context.Context, query *api.GetRuntimesQuery) ([]*api.Runtime, error) {
 	q, err := sc.querier.QueryAt(ctx, query.Height)
 	if err != nil {
@@ -302,7 +348,8 @@ func EventsFromCometBFT(
 			continue
 		}
 
-		for _, pair := range tmEv.GetAttributes() {
+		firstIndex := tmapi.EventIndex(tmEv)
+		for idx, pair := range tmEv.GetAttributes() {
 			key := pair.GetKey()
 			val := pair.GetValue()
 
@@ -318,7 +365,7 @@ func EventsFromCometBFT(
 					continue
 				}
 
-				events = append(events, &api.Event{Height: height, TxHash: txHash, RuntimeStartedEvent: &e})
+				events = append(events, &api.Event{Height: height, TxHash: txHash, Index: firstIndex + uint32(idx), RuntimeStartedEvent: &e})
 			case eventsAPI.IsAttributeKind(key, &api.RuntimeSuspendedEvent{}):
 				// Runtime suspended event.
 				var e api.RuntimeSuspendedEvent
@@ -327,7 +374,7 @@ func EventsFromCometBFT(
 					continue
 				}
 
-				events = append(events, &api.Event{Height: height, TxHash: txHash, RuntimeSuspendedEvent: &e})
+				events = append(events, &api.Event{Height: height, TxHash: txHash, Index: firstIndex + uint32(idx), RuntimeSuspendedEvent: &e})
 			case eventsAPI.IsAttributeKind(key, &api.EntityEvent{}):
 				// Entity event.
 				var e api.EntityEvent
@@ -336,7 +383,7 @@ func EventsFromCometBFT(
 					continue
 				}
 
-				events = append(events, &api.Event{Height: height, TxHash: txHash, EntityEvent: &e})
+				events = append(events, &api.Event{Height: height, TxHash: txHash, Index: firstIndex + uint32(idx), EntityEvent: &e})
 			case eventsAPI.IsAttributeKind(key, &api.NodeEvent{}):
 				// Node event.
 				var e api.NodeEvent
@@ -345,7 +392,7 @@ func EventsFromCometBFT(
 					continue
 				}
 
-				events = append(events, &api.Event{Height: height, TxHash: txHash, NodeEvent: &e})
+				events = append(events, &api.Event{Height: height, TxHash: txHash, Index: firstIndex + uint32(idx), NodeEvent: &e})
 			case eventsAPI.IsAttributeKind(key, &api.NodeUnfrozenEvent{}):
 				// Node unfrozen event.
 				var e api.NodeUnfrozenEvent
@@ -353,7 +400,15 @@ func EventsFromCometBFT(
 					errs = errors.Join(errs, fmt.Errorf("registry: corrupt NodeUnfrozen event: %w", err))
 					continue
 				}
-				events = append(events, &api.Event{Height: height, TxHash: txHash, NodeUnfrozenEvent: &e})
+				events = append(events, &api.Event{Height: height, TxHash: txHash, Index: firstIndex + uint32(idx), NodeUnfrozenEvent: &e})
+			case eventsAPI.IsAttributeKind(key, &api.EntityFrozenEvent{}):
+				// Entity frozen event.
+				var e api.EntityFrozenEvent
+				if err := eventsAPI.DecodeValue(val, &e); err != nil {
+					errs = errors.Join(errs, fmt.Errorf("registry: corrupt EntityFrozen event: %w", err))
+					continue
+				}
+				events = append(events, &api.Event{Height: height, TxHash: txHash, Index: firstIndex + uint32(idx), EntityFrozenEvent: &e})
 			}
 		}
 	}
@@ -15,11 +15,13 @@ import (
 	"github.com/eapache/channels"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	eventsAPI "github.com/oasisprotocol/oasis-core/go/consensus/api/events"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	tmapi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	app "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/roothash"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api"
@@ -35,6 +37,12 @@ const crashPointBlockBeforeIndex = "roothash.before_index"
 type ServiceClient interface {
 	api.Backend
 	tmapi.ServiceClient
+
+	// GetRoundHeight returns the consensus block height at which the given runtime round was
+	// finalized.
+	//
+	// This requires the block history of the runtime to be available.
+	GetRoundHeight(ctx context.Context, runtimeID common.Namespace, round uint64) (int64, error)
 }
 
 type runtimeBrokers struct {
@@ -78,6 +86,7 @@ type serviceClient struct {
 	queryCh        chan cmtpubsub.Query
 	cmdCh          chan interface{}
 	trackedRuntime map[common.Namespace]*trackedRuntime
+	blockHistories map[common.Namespace]api.BlockHistory
 
 	pruneHandler *pruneHandler
 }
@@ -163,6 +172,184 @@ func (sc *serviceClient) GetPastRoundRoots(ctx context.Context, request *api.Run
 	return q.PastRoundRoots(ctx, request.RuntimeID)
 }
 
+// Implements api.Backend.
+func (sc *serviceClient) GetRuntimeStatistics(ctx context.Context, request *api.RuntimeRequest) (*api.RuntimeStatistics, error) {
+	q, err := sc.querier.QueryAt(ctx, request.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.RuntimeStatistics(ctx, request.RuntimeID)
+}
+
+func (sc *serviceClient) getBlockHistory(runtimeID common.Namespace) (api.BlockHistory, error) {
+	sc.RLock()
+	defer sc.RUnlock()
+
+	bh := sc.blockHistories[runtimeID]
+	if bh == nil {
+		return nil, api.ErrHistoryUnavailable
+	}
+	return bh, nil
+}
+
+func (sc *serviceClient) GetRoundHeight(ctx context.Context, runtimeID common.Namespace, round uint64) (int64, error) {
+	bh, err := sc.getBlockHistory(runtimeID)
+	if err != nil {
+		return 0, err
+	}
+	blk, err := bh.GetAnnotatedBlock(ctx, round)
+	if err != nil {
+		return 0, err
+	}
+	return blk.Height, nil
+}
+
+// Implements api.Backend.
+func (sc *serviceClient) GetRoundResults(ctx context.Context, request *api.RoundResultsRequest) (map[uint64]*api.RoundResults, error) {
+	bh, err := sc.getBlockHistory(request.RuntimeID)
+	if err != nil {
+		return nil, err
+	}
+
+	toRound := request.ToRound
+	if toRound == api.RoundLatest {
+		var blk *block.Block
+		if blk, err = bh.GetCommittedBlock(ctx, api.RoundLatest); err != nil {
+			return nil, err
+		}
+		toRound = blk.Header.Round
+	}
+	if request.FromRound > toRound || toRound-request.FromRound >= api.MaxRoundResultsRange {
+		return nil, fmt.Errorf("%w: invalid round range", api.ErrInvalidArgument)
+	}
+
+	results := make(map[uint64]*api.RoundResults)
+	for round := request.FromRound; round <= toRound; round++ {
+		var blk *block.Block
+		blk, err = bh.GetCommittedBlock(ctx, round)
+		switch err {
+		case nil:
+		case api.ErrNotFound:
+			// Round not available, e.g. because it has been pruned.
+			continue
+		default:
+			return nil, err
+		}
+		// Only normal rounds have results.
+		if blk.Header.HeaderType != block.Normal {
+			continue
+		}
+
+		var rr *api.RoundResults
+		if rr, err = bh.GetRoundResults(ctx, round); err != nil {
+			return nil, err
+		}
+		results[round] = rr
+	}
+	return results, nil
+}
+
+// Implements api.Backend.
+func (sc *serviceClient) GetMessageReceipts(ctx context.Context, request *api.MessageReceiptsRequest) ([]*api.MessageReceipt, error) {
+	bh, err := sc.getBlockHistory(request.RuntimeID)
+	if err != nil {
+		return nil, err
+	}
+
+	annBlk, err := bh.GetAnnotatedBlock(ctx, request.Round)
+	if err != nil {
+		return nil, err
+	}
+	if annBlk.Block.Header.HeaderType != block.Normal {
+		return nil, fmt.Errorf("%w: not a normal round", api.ErrInvalidArgument)
+	}
+	rr, err := bh.GetRoundResults(ctx, request.Round)
+	if err != nil {
+		return nil, err
+	}
+	if len(rr.Messages) == 0 {
+		return []*api.MessageReceipt{}, nil
+	}
+
+	// Commitments for the round can only be submitted after the previous round has been
+	// finalized, so only search the heights in between if the previous round is known.
+	minHeight := annBlk.Height
+	if request.Round > 0 {
+		var prevBlk *api.AnnotatedBlock
+		prevBlk, err = bh.GetAnnotatedBlock(ctx, request.Round-1)
+		switch err {
+		case nil:
+			minHeight = prevBlk.Height + 1
+		case api.ErrNotFound:
+		default:
+			return nil, err
+		}
+	}
+
+	for height := annBlk.Height; height >= minHeight; height-- {
+		var msgs []message.Message
+		msgs, err = sc.findEmittedMessages(ctx, height, request.RuntimeID, annBlk.Block)
+		if err != nil {
+			return nil, err
+		}
+		if msgs != nil {
+			return rr.MessageReceipts(msgs)
+		}
+	}
+	return nil, fmt.Errorf("%w: emitted messages not found", api.ErrNotFound)
+}
+
+// findEmittedMessages searches the transactions at the given height for the executor commitment
+// containing the messages emitted by the runtime in the given block.
+func (sc *serviceClient) findEmittedMessages(
+	ctx context.Context,
+	height int64,
+	runtimeID common.Namespace,
+	blk *block.Block,
+) ([]message.Message, error) {
+	txs, err := sc.backend.GetTransactions(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("roothash: failed to get transactions at height %d: %w", height, err)
+	}
+
+	for _, rawTx := range txs {
+		var sigTx transaction.SignedTransaction
+		if err = cbor.Unmarshal(rawTx, &sigTx); err != nil {
+			continue
+		}
+
+		// Signature already verified by the validators, skipping.
+
+		var tx transaction.Transaction
+		if err = cbor.Unmarshal(sigTx.Blob, &tx); err != nil {
+			continue
+		}
+		if tx.Method != api.MethodExecutorCommit {
+			continue
+		}
+
+		var xc api.ExecutorCommit
+		if err = cbor.Unmarshal(tx.Body, &xc); err != nil {
+			continue
+		}
+		if !xc.ID.Equal(&runtimeID) {
+			continue
+		}
+
+		for _, ec := range xc.Commits {
+			if ec.Header.Header.Round != blk.Header.Round || len(ec.Messages) == 0 {
+				continue
+			}
+			if h := message.MessagesHash(ec.Messages); !h.Equal(&blk.Header.MessagesHash) {
+				continue
+			}
+			return ec.Messages, nil
+		}
+	}
+	return nil, nil
+}
+
 // Implements api.Backend.
 func (sc *serviceClient) GetIncomingMessageQueueMeta(ctx context.Context, request *api.RuntimeRequest) (*message.IncomingMessageQueueMeta, error) {
 	q, err := sc.querier.QueryAt(ctx, request.Height)
@@ -269,6 +456,11 @@ func (sc *serviceClient) WatchExecutorCommitments(_ context.Context, id common.N
 // Implements api.Backend.
 func (sc *serviceClient) TrackRuntime(ctx context.Context, history api.BlockHistory) error {
 	sc.pruneHandler.trackRuntime(history)
+
+	sc.Lock()
+	sc.blockHistories[history.RuntimeID()] = history
+	sc.Unlock()
+
 	return sc.trackRuntime(ctx, history.RuntimeID(), history)
 }
 
@@ -772,8 +964,10 @@ EventLoop:
 		var (
 			runtimeID *common.Namespace
 			ev        *api.Event
+			evIdx     int
 		)
-		for _, pair := range tmEv.GetAttributes() {
+		firstIndex := tmapi.EventIndex(tmEv)
+		for idx, pair := range tmEv.GetAttributes() {
 			key := pair.GetKey()
 			val := pair.GetValue()
 
@@ -787,6 +981,7 @@ EventLoop:
 				}
 
 				ev = &api.Event{Finalized: &e}
+				evIdx = idx
 			case eventsAPI.IsAttributeKind(key, &api.ExecutionDiscrepancyDetectedEvent{}):
 				// An execution discrepancy has been detected.
 				var e api.ExecutionDiscrepancyDetectedEvent
@@ -796,6 +991,7 @@ EventLoop:
 				}
 
 				ev = &api.Event{ExecutionDiscrepancyDetected: &e}
+				evIdx = idx
 			case eventsAPI.IsAttributeKind(key, &api.ExecutorCommittedEvent{}):
 				// An executor commit has been processed.
 				var e api.ExecutorCommittedEvent
@@ -805,6 +1001,7 @@ EventLoop:
 				}
 
 				ev = &api.Event{ExecutorCommitted: &e}
+				evIdx = idx
 			case eventsAPI.IsAttributeKind(key, &api.InMsgProcessedEvent{}):
 				// Incoming message processed event.
 				var e api.InMsgProcessedEvent
@@ -814,6 +1011,7 @@ EventLoop:
 				}
 
 				ev = &api.Event{InMsgProcessed: &e}
+				evIdx = idx
 			case eventsAPI.IsAttributeKind(key, &api.RuntimeIDAttribute{}):
 				if runtimeID != nil {
 					errs = errors.Join(errs, fmt.Errorf("roothash: duplicate runtime ID attribute"))
@@ -825,6 +1023,8 @@ EventLoop:
 					continue EventLoop
 				}
 				runtimeID = &rtAttribute.ID
+			case tmapi.IsEventIndexAttribute(key):
+				// Block-level event index, handled above.
 			default:
 				errs = errors.Join(errs, fmt.Errorf("roothash: unknown event type: key: %s, val: %s", key, val))
 			}
@@ -838,6 +1038,7 @@ EventLoop:
 			ev.RuntimeID = *runtimeID
 			ev.Height = height
 			ev.TxHash = txHash
+			ev.Index = firstIndex + uint32(evIdx)
 			events = append(events, ev)
 		}
 	}
@@ -897,6 +1098,7 @@ func New(
 		queryCh:          make(chan cmtpubsub.Query, runtimeRegistry.MaxRuntimeCount),
 		cmdCh:            make(chan interface{}, runtimeRegistry.MaxRuntimeCount),
 		trackedRuntime:   make(map[common.Namespace]*trackedRuntime),
+		blockHistories:   make(map[common.Namespace]api.BlockHistory),
 	}
 
 	// Initialize and register the CometBFT service component.
@@ -3,6 +3,7 @@ package scheduler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	cmtabcitypes "github.com/cometbft/cometbft/abci/types"
@@ -10,12 +11,15 @@ import (
 	cmttypes "github.com/cometbft/cometbft/types"
 	"github.com/eapache/channels"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/events"
 	tmapi "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
 	app "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/scheduler"
+	tmroothash "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/roothash"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/scheduler/api"
 )
 
@@ -30,6 +34,7 @@ type serviceClient struct {
 
 	logger *logging.Logger
 
+	backend  tmapi.Backend
 	querier  *app.QueryFactory
 	notifier *pubsub.Broker
 }
@@ -50,6 +55,14 @@ func (sc *serviceClient) ConsensusParameters(ctx context.Context, height int64)
 	return q.ConsensusParameters(ctx)
 }
 
+func (sc *serviceClient) SimulateElection(ctx context.Context, request *api.SimulateElectionRequest) (*api.ElectionSimulation, error) {
+	q, err := sc.querier.QueryAt(ctx, request.Height)
+	if err != nil {
+		return nil, fmt.Errorf("scheduler: election simulation query failed: %w", err)
+	}
+	return q.SimulateElection(ctx, request)
+}
+
 func (sc *serviceClient) Cleanup() {
 }
 
@@ -83,6 +96,78 @@ func (sc *serviceClient) GetCommittees(ctx context.Context, request *api.GetComm
 	return runtimeCommittees, nil
 }
 
+func (sc *serviceClient) GetCommitteesAt(ctx context.Context, request *api.GetCommitteesAtRequest) (*api.CommitteesAt, error) {
+	height := request.Height
+	switch request.Round {
+	case nil:
+		if height != consensus.HeightLatest {
+			break
+		}
+		blk, err := sc.backend.GetCometBFTBlock(ctx, consensus.HeightLatest)
+		if err != nil {
+			return nil, err
+		}
+		height = blk.Height
+	default:
+		roundHeight, err := sc.getRoundHeight(ctx, request.RuntimeID, *request.Round, request.Height)
+		if err != nil {
+			return nil, err
+		}
+		// The committees responsible for the round are the ones that were active right before
+		// the round was finalized.
+		height = roundHeight - 1
+	}
+
+	committees, err := sc.GetCommittees(ctx, &api.GetCommitteesRequest{
+		Height:    height,
+		RuntimeID: request.RuntimeID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.CommitteesAt{
+		Height:     height,
+		Committees: committees,
+	}, nil
+}
+
+// getRoundHeight returns the consensus block height at which the given runtime round was
+// finalized, considering heights up to and including the given height.
+//
+// Heights of past rounds are resolved using the round index of the runtime's block history, so
+// only the latest round can be resolved for runtimes without a block history.
+func (sc *serviceClient) getRoundHeight(ctx context.Context, runtimeID common.Namespace, round uint64, height int64) (int64, error) {
+	state, err := sc.backend.RootHash().GetRuntimeState(ctx, &roothash.RuntimeRequest{
+		RuntimeID: runtimeID,
+		Height:    height,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("scheduler: failed to query runtime state: %w", err)
+	}
+	lastRound := state.LastBlock.Header.Round
+	switch {
+	case lastRound < round:
+		return 0, fmt.Errorf("scheduler: round %d not finalized at height %d", round, height)
+	case lastRound == round:
+		return state.LastBlockHeight, nil
+	}
+
+	rh, ok := sc.backend.RootHash().(tmroothash.ServiceClient)
+	if !ok {
+		return 0, roothash.ErrHistoryUnavailable
+	}
+	roundHeight, err := rh.GetRoundHeight(ctx, runtimeID, round)
+	switch {
+	case err == nil:
+		return roundHeight, nil
+	case errors.Is(err, roothash.ErrNotFound):
+		return 0, fmt.Errorf("scheduler: round %d no longer available", round)
+	default:
+		return 0, err
+	}
+}
+
 func (sc *serviceClient) WatchCommittees(_ context.Context) (<-chan *api.Committee, pubsub.ClosableSubscription, error) {
 	typedCh := make(chan *api.Committee)
 	sub := sc.notifier.Subscribe()
@@ -151,6 +236,7 @@ func New(backend tmapi.Backend) (ServiceClient, error) {
 
 	sc := &serviceClient{
 		logger:  logging.GetLogger("cometbft/scheduler"),
+		backend: backend,
 		querier: a.QueryFactory().(*app.QueryFactory),
 	}
 	sc.notifier = pubsub.NewBrokerEx(func(ch channels.Channel) {
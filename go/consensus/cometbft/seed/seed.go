@@ -172,8 +172,8 @@ func New(dataDir string, identity *identity.Identity, genesisProvider genesis.Pr
 	p2pCfg.MaxNumOutboundPeers = config.GlobalConfig.Consensus.P2P.MaxNumOutboundPeers
 	p2pCfg.SendRate = config.GlobalConfig.Consensus.P2P.SendRate
 	p2pCfg.RecvRate = config.GlobalConfig.Consensus.P2P.RecvRate
-	p2pCfg.AddrBookStrict = !(config.GlobalConfig.Consensus.Debug.P2PAddrBookLenient && cmflags.DebugDontBlameOasis())
-	p2pCfg.AllowDuplicateIP = config.GlobalConfig.Consensus.Debug.P2PAllowDuplicateIP && cmflags.DebugDontBlameOasis()
+	p2pCfg.AddrBookStrict = !(config.GlobalConfig.Consensus.Debug.P2PAddrBookLenient && cmflags.DebugUnsafeP2P())
+	p2pCfg.AllowDuplicateIP = config.GlobalConfig.Consensus.Debug.P2PAllowDuplicateIP && cmflags.DebugUnsafeP2P()
 
 	nodeKey := &cmtp2p.NodeKey{PrivKey: crypto.SignerToCometBFT(identity.P2PSigner)}
 
@@ -211,7 +211,7 @@ func New(dataDir string, identity *identity.Identity, genesisProvider genesis.Pr
 		return nil, fmt.Errorf("cometbft/seed: failed to start address book: %w", err)
 	}
 
-	if !(config.GlobalConfig.Consensus.Debug.DisableAddrBookFromGenesis && cmflags.DebugDontBlameOasis()) {
+	if !(config.GlobalConfig.Consensus.Debug.DisableAddrBookFromGenesis && cmflags.DebugUnsafeP2P()) {
 		if err = populateAddrBookFromGenesis(srv.addrBook, doc, srv.addr); err != nil {
 			return nil, fmt.Errorf("cometbft/seed: failed to populate address book from genesis: %w", err)
 		}
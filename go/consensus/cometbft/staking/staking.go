@@ -63,8 +63,8 @@ func (sc *serviceClient) TokenValueExponent(ctx context.Context, height int64) (
 		return 0, err
 	}
 
-	if params.TokenValueExponent > 0 {
-		return params.TokenValueExponent, nil
+	if params.TokenValueExponent != nil {
+		return *params.TokenValueExponent, nil
 	}
 
 	// Fallback to genesis document.
@@ -202,6 +202,33 @@ func (sc *serviceClient) DebondingDelegationsTo(ctx context.Context, query *api.
 	return q.DebondingDelegationsTo(ctx, query.Owner)
 }
 
+func (sc *serviceClient) DelegationHistoryFor(ctx context.Context, query *api.HistoryQuery) (map[api.Address][]*api.DelegationHistoryEntry, error) {
+	q, err := sc.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.DelegationHistoryFor(ctx, query.Owner, query.FromHeight, query.ToHeight)
+}
+
+func (sc *serviceClient) DelegationHistoryTo(ctx context.Context, query *api.HistoryQuery) (map[api.Address][]*api.DelegationHistoryEntry, error) {
+	q, err := sc.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.DelegationHistoryTo(ctx, query.Owner, query.FromHeight, query.ToHeight)
+}
+
+func (sc *serviceClient) RewardsAt(ctx context.Context, query *api.HistoryQuery) ([]*api.RewardEntry, error) {
+	q, err := sc.querier.QueryAt(ctx, query.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.Rewards(ctx, query.Owner, query.FromHeight, query.ToHeight)
+}
+
 func (sc *serviceClient) Allowance(ctx context.Context, query *api.AllowanceQuery) (*quantity.Quantity, error) {
 	acct, err := sc.Account(ctx, &api.OwnerQuery{
 		Height: query.Height,
@@ -353,7 +380,8 @@ func EventsFromCometBFT(
 			continue
 		}
 
-		for _, pair := range tmEv.GetAttributes() {
+		firstIndex := tmapi.EventIndex(tmEv)
+		for idx, pair := range tmEv.GetAttributes() {
 			key := pair.GetKey()
 			val := pair.GetValue()
 
@@ -366,7 +394,7 @@ func EventsFromCometBFT(
 					continue
 				}
 
-				evt := &api.Event{Height: height, TxHash: txHash, Escrow: &api.EscrowEvent{Take: &e}}
+				evt := &api.Event{Height: height, TxHash: txHash, Index: firstIndex + uint32(idx), Escrow: &api.EscrowEvent{Take: &e}}
 				events = append(events, evt)
 			case eventsAPI.IsAttributeKind(key, &api.TransferEvent{}):
 				// Transfer event.
@@ -376,7 +404,7 @@ func EventsFromCometBFT(
 					continue
 				}
 
-				evt := &api.Event{Height: height, TxHash: txHash, Transfer: &e}
+				evt := &api.Event{Height: height, TxHash: txHash, Index: firstIndex + uint32(idx), Transfer: &e}
 				events = append(events, evt)
 			case eventsAPI.IsAttributeKind(key, &api.ReclaimEscrowEvent{}):
 				// Reclaim escrow event.
@@ -386,7 +414,7 @@ func EventsFromCometBFT(
 					continue
 				}
 
-				evt := &api.Event{Height: height, TxHash: txHash, Escrow: &api.EscrowEvent{Reclaim: &e}}
+				evt := &api.Event{Height: height, TxHash: txHash, Index: firstIndex + uint32(idx), Escrow: &api.EscrowEvent{Reclaim: &e}}
 				events = append(events, evt)
 			case eventsAPI.IsAttributeKind(key, &api.AddEscrowEvent{}):
 				// Add escrow event.
@@ -396,7 +424,7 @@ func EventsFromCometBFT(
 					continue
 				}
 
-				evt := &api.Event{Height: height, TxHash: txHash, Escrow: &api.EscrowEvent{Add: &e}}
+				evt := &api.Event{Height: height, TxHash: txHash, Index: firstIndex + uint32(idx), Escrow: &api.EscrowEvent{Add: &e}}
 				events = append(events, evt)
 			case eventsAPI.IsAttributeKind(key, &api.DebondingStartEscrowEvent{}):
 				// Debonding start escrow event.
@@ -406,7 +434,17 @@ func EventsFromCometBFT(
 					continue
 				}
 
-				evt := &api.Event{Height: height, TxHash: txHash, Escrow: &api.EscrowEvent{DebondingStart: &e}}
+				evt := &api.Event{Height: height, TxHash: txHash, Index: firstIndex + uint32(idx), Escrow: &api.EscrowEvent{DebondingStart: &e}}
+				events = append(events, evt)
+			case eventsAPI.IsAttributeKind(key, &api.DebondingTransferEscrowEvent{}):
+				// Debonding transfer escrow event.
+				var e api.DebondingTransferEscrowEvent
+				if err := eventsAPI.DecodeValue(val, &e); err != nil {
+					errs = errors.Join(errs, fmt.Errorf("staking: corrupt DebondingTransfer escrow event: %w", err))
+					continue
+				}
+
+				evt := &api.Event{Height: height, TxHash: txHash, Index: firstIndex + uint32(idx), Escrow: &api.EscrowEvent{DebondingTransfer: &e}}
 				events = append(events, evt)
 			case eventsAPI.IsAttributeKind(key, &api.BurnEvent{}):
 				// Burn event.
@@ -416,7 +454,7 @@ func EventsFromCometBFT(
 					continue
 				}
 
-				evt := &api.Event{Height: height, TxHash: txHash, Burn: &e}
+				evt := &api.Event{Height: height, TxHash: txHash, Index: firstIndex + uint32(idx), Burn: &e}
 				events = append(events, evt)
 			case eventsAPI.IsAttributeKind(key, &api.AllowanceChangeEvent{}):
 				// Allowance change event.
@@ -426,8 +464,10 @@ func EventsFromCometBFT(
 					continue
 				}
 
-				evt := &api.Event{Height: height, TxHash: txHash, AllowanceChange: &e}
+				evt := &api.Event{Height: height, TxHash: txHash, Index: firstIndex + uint32(idx), AllowanceChange: &e}
 				events = append(events, evt)
+			case tmapi.IsEventIndexAttribute(key):
+				// Block-level event index, handled above.
 			default:
 				errs = errors.Join(errs, fmt.Errorf("staking: unknown event type: key: %s, val: %s", key, val))
 			}
@@ -70,6 +70,7 @@ func NewTestNodeGenesisProvider(identity *identity.Identity, ent *entity.Entity,
 				DebugAllowUnroutableAddresses: true,
 				DebugAllowTestRuntimes:        true,
 				DebugDeployImmediately:        true,
+				DebugAllowDebugCapabilities:   true,
 				EnableRuntimeGovernanceModels: map[registry.RuntimeGovernanceModel]bool{
 					registry.GovernanceEntity:  true,
 					registry.GovernanceRuntime: true,
@@ -98,6 +99,8 @@ func NewTestNodeGenesisProvider(identity *identity.Identity, ent *entity.Entity,
 				VotingPeriod:                   10,
 				MinProposalDeposit:             *quantity.NewFromUint64(100),
 				EnableChangeParametersProposal: true,
+				EnableEntityFreezeProposal:     true,
+				EnableUpdateRuntimeProposal:    true,
 			},
 		},
 		RootHash: roothash.Genesis{
@@ -196,11 +196,12 @@ func EventsFromCometBFT(
 			continue
 		}
 
-		for _, pair := range tmEv.GetAttributes() {
+		firstIndex := tmapi.EventIndex(tmEv)
+		for idx, pair := range tmEv.GetAttributes() {
 			key := pair.GetKey()
 			val := pair.GetValue()
 
-			evt := &api.Event{Height: height, TxHash: txHash}
+			evt := &api.Event{Height: height, TxHash: txHash, Index: firstIndex + uint32(idx)}
 			switch {
 			case eventsAPI.IsAttributeKind(key, &api.ActionSubmittedEvent{}):
 				// Action submitted event.
@@ -256,6 +257,9 @@ func EventsFromCometBFT(
 				}
 
 				evt.AuthorityUpdated = &e
+			case tmapi.IsEventIndexAttribute(key):
+				// Block-level event index, handled above.
+				continue
 			default:
 				errs = errors.Join(errs, fmt.Errorf("vault: unknown event type: key: %s, val: %s", key, val))
 				continue
@@ -21,10 +21,18 @@ var (
 		},
 		[]string{"backend"},
 	)
+	ClockSkew = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "oasis_consensus_clock_skew_seconds",
+			Help: "Estimated skew of the local clock against consensus time (seconds).",
+		},
+		[]string{"backend"},
+	)
 
 	consensusCollectors = []prometheus.Collector{
 		SignedBlocks,
 		ProposedBlocks,
+		ClockSkew,
 	}
 
 	metricsOnce sync.Once
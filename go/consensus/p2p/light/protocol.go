@@ -22,9 +22,10 @@ func ProtocolID(chainContext string) core.ProtocolID {
 }
 
 const (
-	MethodGetLightBlock  = "GetLightBlock"
-	MethodGetParameters  = "GetParameters"
-	MethodSubmitEvidence = "SubmitEvidence"
+	MethodGetLightBlock      = "GetLightBlock"
+	MethodGetLightBlockRange = "GetLightBlockRange"
+	MethodGetParameters      = "GetParameters"
+	MethodSubmitEvidence     = "SubmitEvidence"
 )
 
 func init() {
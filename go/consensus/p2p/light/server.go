@@ -33,6 +33,12 @@ func (s *service) HandleRequest(ctx context.Context, method string, body cbor.Ra
 			return nil, rpc.ErrBadRequest
 		}
 		return s.handleGetLightBlock(ctx, rq)
+	case MethodGetLightBlockRange:
+		var rq consensus.GetLightBlockRangeRequest
+		if err := cbor.Unmarshal(body, &rq); err != nil {
+			return nil, rpc.ErrBadRequest
+		}
+		return s.consensus.GetLightBlockRange(ctx, &rq)
 	case MethodGetParameters:
 		var rq int64
 		if err := cbor.Unmarshal(body, &rq); err != nil {
@@ -10,6 +10,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 )
 
 const (
@@ -18,6 +19,11 @@ const (
 	//
 	// NOTE: Code assumes that this is relatively small.
 	windowSize int = 6
+
+	// fullBlockThresholdNumerator and fullBlockThresholdDenominator define the block utilization
+	// (either in terms of gas or size) above which a block is considered full.
+	fullBlockThresholdNumerator   = 9
+	fullBlockThresholdDenominator = 10
 )
 
 type priceDiscovery struct {
@@ -62,14 +68,75 @@ func (pd *priceDiscovery) refreshMinGasPrice(ctx context.Context) {
 }
 
 // processBlock computes the gas price based on transactions in a block.
-func (pd *priceDiscovery) processBlock(_ context.Context, _ *consensus.Block) {
-	// Currently transactions are not ordered by price, so track price as zero. After the mempool
-	// is refactored, change this to properly compute the median gas price. Note that simply sorting
-	// transactions here wouldn't work as it wouldn't reflect the actual queuing process until the
-	// mempool is updated.
-	//
-	// We should also make sure to add some margin over the median in case of full blocks.
-	pd.trackPrice(quantity.NewQuantity())
+//
+// In case the block is (nearly) full, the minimum gas price paid by the included transactions is
+// tracked as it reflects the price needed to get a transaction included. Otherwise the price is
+// tracked as zero, meaning that the minimum gas price is enough.
+func (pd *priceDiscovery) processBlock(ctx context.Context, blk *consensus.Block) {
+	price, err := pd.computeBlockPrice(ctx, blk)
+	if err != nil {
+		pd.logger.Warn("failed to compute block gas price",
+			"err", err,
+			"height", blk.Height,
+		)
+		price = quantity.NewQuantity()
+	}
+	pd.trackPrice(price)
+}
+
+func (pd *priceDiscovery) computeBlockPrice(ctx context.Context, blk *consensus.Block) (*quantity.Quantity, error) {
+	params, err := pd.client.GetParameters(ctx, blk.Height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch consensus parameters: %w", err)
+	}
+	txsWithResults, err := pd.client.GetTransactionsWithResults(ctx, blk.Height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch transactions: %w", err)
+	}
+	txs, err := txsWithResults.Decode()
+	if err != nil {
+		return nil, err
+	}
+
+	var gasUsed transaction.Gas
+	for _, tx := range txs {
+		gasUsed += transaction.Gas(tx.Result.GasUsed)
+	}
+	if !isFull(uint64(gasUsed), uint64(params.Parameters.MaxBlockGas)) && !isFull(blk.Size, params.Parameters.MaxBlockSize) {
+		return quantity.NewQuantity(), nil
+	}
+
+	// Find the minimum gas price among the included transactions.
+	var minPrice *quantity.Quantity
+	for _, tx := range txs {
+		if tx.Transaction == nil || tx.Transaction.Fee == nil || tx.Transaction.Fee.Gas == 0 {
+			continue
+		}
+		price := tx.Transaction.Fee.GasPrice()
+		if minPrice == nil || price.Cmp(minPrice) < 0 {
+			minPrice = price
+		}
+	}
+	if minPrice == nil {
+		return quantity.NewQuantity(), nil
+	}
+
+	pd.logger.Debug("block is full, tracking gas price",
+		"height", blk.Height,
+		"gas_used", gasUsed,
+		"block_size", blk.Size,
+		"gas_price", minPrice,
+	)
+
+	return minPrice, nil
+}
+
+// isFull returns true iff the given usage reaches the full block threshold of the given limit.
+func isFull(used, limit uint64) bool {
+	if limit == 0 {
+		return false
+	}
+	return used*fullBlockThresholdDenominator >= limit*fullBlockThresholdNumerator
 }
 
 // trackPrice records the price for a block.
@@ -0,0 +1,255 @@
+package pricediscovery
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction/results"
+	consensusGenesis "github.com/oasisprotocol/oasis-core/go/consensus/genesis"
+)
+
+const (
+	testMaxBlockGas  = 1000
+	testMaxBlockSize = 10_000
+)
+
+type testTx struct {
+	gasUsed  uint64
+	feeGas   transaction.Gas
+	gasPrice uint64
+}
+
+type testBackend struct {
+	consensus.ClientBackend
+
+	signer   signature.Signer
+	blocks   map[int64][]testTx
+	notifier *pubsub.Broker
+}
+
+func (b *testBackend) MinGasPrice(context.Context) (*quantity.Quantity, error) {
+	return quantity.NewFromUint64(2), nil
+}
+
+func (b *testBackend) EstimateGas(context.Context, *consensus.EstimateGasRequest) (transaction.Gas, error) {
+	return 100, nil
+}
+
+func (b *testBackend) GetParameters(_ context.Context, height int64) (*consensus.Parameters, error) {
+	return &consensus.Parameters{
+		Height: height,
+		Parameters: consensusGenesis.Parameters{
+			MaxBlockGas:  testMaxBlockGas,
+			MaxBlockSize: testMaxBlockSize,
+		},
+	}, nil
+}
+
+func (b *testBackend) GetTransactionsWithResults(_ context.Context, height int64) (*consensus.TransactionsWithResults, error) {
+	var txs consensus.TransactionsWithResults
+	for _, tx := range b.blocks[height] {
+		var fee *transaction.Fee
+		if tx.feeGas > 0 {
+			fee = &transaction.Fee{Gas: tx.feeGas}
+			_ = fee.Amount.FromUint64(uint64(tx.feeGas) * tx.gasPrice)
+		}
+		sigTx, err := transaction.Sign(b.signer, &transaction.Transaction{
+			Fee:    fee,
+			Method: "test.Method",
+		})
+		if err != nil {
+			return nil, err
+		}
+		txs.Transactions = append(txs.Transactions, cbor.Marshal(sigTx))
+		txs.Results = append(txs.Results, &results.Result{GasUsed: tx.gasUsed})
+	}
+	return &txs, nil
+}
+
+func (b *testBackend) WatchBlocks(context.Context) (<-chan *consensus.Block, pubsub.ClosableSubscription, error) {
+	ch := make(chan *consensus.Block)
+	sub := b.notifier.Subscribe()
+	sub.Unwrap(ch)
+	return ch, sub, nil
+}
+
+func newTestBackend() *testBackend {
+	signature.SetChainContext("test: oasis-core tests")
+
+	return &testBackend{
+		signer:   memorySigner.NewTestSigner("pricediscovery test signer"),
+		blocks:   make(map[int64][]testTx),
+		notifier: pubsub.NewBroker(false),
+	}
+}
+
+func newTestPriceDiscovery(backend *testBackend) *priceDiscovery {
+	pd := &priceDiscovery{
+		client: backend,
+		logger: logging.GetLogger("consensus/pricediscovery/test"),
+	}
+	pd.blockPrices = make([]*quantity.Quantity, windowSize)
+	for i := range windowSize {
+		pd.blockPrices[i] = quantity.NewQuantity()
+	}
+	return pd
+}
+
+func TestComputeBlockPrice(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	backend := newTestBackend()
+	pd := newTestPriceDiscovery(backend)
+
+	for _, tc := range []struct {
+		name     string
+		size     uint64
+		txs      []testTx
+		expected uint64
+	}{
+		{
+			name: "empty block",
+		},
+		{
+			name: "non-full block",
+			size: 1_000,
+			txs: []testTx{
+				{gasUsed: 500, feeGas: 500, gasPrice: 10},
+				{gasUsed: 399, feeGas: 399, gasPrice: 5},
+			},
+		},
+		{
+			name: "full by gas",
+			size: 1_000,
+			txs: []testTx{
+				{gasUsed: 500, feeGas: 500, gasPrice: 10},
+				{gasUsed: 400, feeGas: 400, gasPrice: 5},
+			},
+			expected: 5,
+		},
+		{
+			name: "full by size",
+			size: 9_000,
+			txs: []testTx{
+				{gasUsed: 100, feeGas: 100, gasPrice: 7},
+				{gasUsed: 100, feeGas: 200, gasPrice: 8},
+			},
+			expected: 7,
+		},
+		{
+			name: "size just below threshold",
+			size: 8_999,
+			txs: []testTx{
+				{gasUsed: 100, feeGas: 100, gasPrice: 7},
+			},
+		},
+		{
+			name: "full block without fees",
+			size: 1_000,
+			txs: []testTx{
+				{gasUsed: 900},
+			},
+		},
+		{
+			name: "full block ignores transactions without fees",
+			size: 1_000,
+			txs: []testTx{
+				{gasUsed: 450},
+				{gasUsed: 450, feeGas: 450, gasPrice: 3},
+			},
+			expected: 3,
+		},
+	} {
+		height := int64(len(backend.blocks) + 1)
+		backend.blocks[height] = tc.txs
+
+		price, err := pd.computeBlockPrice(ctx, &consensus.Block{Height: height, Size: tc.size})
+		require.NoError(err, tc.name)
+		require.EqualValues(quantity.NewFromUint64(tc.expected), price, tc.name)
+	}
+}
+
+func TestTrackPrice(t *testing.T) {
+	require := require.New(t)
+
+	pd := newTestPriceDiscovery(newTestBackend())
+
+	// No full blocks.
+	pd.trackPrice(quantity.NewQuantity())
+	require.Nil(pd.computedGasPrice, "price should not be computed without full blocks")
+
+	// The maximum price within the window should be used.
+	pd.trackPrice(quantity.NewFromUint64(10))
+	pd.trackPrice(quantity.NewFromUint64(5))
+	require.EqualValues(quantity.NewFromUint64(10), pd.computedGasPrice)
+
+	// Prices should expire once they fall out of the window.
+	for range windowSize - 1 {
+		pd.trackPrice(quantity.NewQuantity())
+	}
+	require.EqualValues(quantity.NewFromUint64(5), pd.computedGasPrice)
+	pd.trackPrice(quantity.NewQuantity())
+	require.Nil(pd.computedGasPrice, "price should expire after the window")
+}
+
+func TestEstimateFee(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	backend := newTestBackend()
+	backend.blocks[1] = []testTx{
+		{gasUsed: 300, feeGas: 300, gasPrice: 9},
+		{gasUsed: 300, feeGas: 300, gasPrice: 7},
+		{gasUsed: 300, feeGas: 300, gasPrice: 8},
+	}
+	backend.blocks[2] = []testTx{
+		{gasUsed: 100, feeGas: 100, gasPrice: 20},
+	}
+
+	pd, err := New(ctx, backend, 1)
+	require.NoError(err, "New")
+	sm := consensus.NewSubmissionManager(backend, pd, 0)
+
+	estimateFee := func() *transaction.Fee {
+		fee, err := sm.EstimateFee(ctx, backend.signer.Public(), &transaction.Transaction{})
+		require.NoError(err, "EstimateFee")
+		return fee
+	}
+
+	// Before any blocks are processed, the fallback gas price is used.
+	fee := estimateFee()
+	require.EqualValues(100, fee.Gas)
+	require.EqualValues(quantity.NewFromUint64(100), &fee.Amount)
+
+	// A non-full block should result in the minimum gas price.
+	backend.notifier.Broadcast(&consensus.Block{Height: 2, Size: 100})
+	require.Eventually(func() bool {
+		price, _ := pd.GasPrice()
+		return price.Cmp(quantity.NewFromUint64(2)) == 0
+	}, time.Second, 10*time.Millisecond, "minimum gas price should be used")
+
+	// A full block should result in the minimum gas price paid by the included transactions.
+	backend.notifier.Broadcast(&consensus.Block{Height: 1, Size: 100})
+	require.Eventually(func() bool {
+		price, _ := pd.GasPrice()
+		return price.Cmp(quantity.NewFromUint64(7)) == 0
+	}, time.Second, 10*time.Millisecond, "minimum included gas price should be used")
+
+	fee = estimateFee()
+	require.EqualValues(100, fee.Gas)
+	require.EqualValues(quantity.NewFromUint64(700), &fee.Amount)
+}
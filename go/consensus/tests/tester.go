@@ -10,6 +10,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
@@ -61,6 +63,32 @@ func ConsensusImplementationTests(t *testing.T, backend consensus.ClientBackend)
 	require.EqualValues(blk.StateRoot, status.LatestStateRoot, "latest state roots should match")
 	require.EqualValues(blk.Size, status.LatestBlockSize, "latest block sizes should match")
 
+	blks, err := backend.GetBlocks(ctx, &consensus.GetBlocksRequest{
+		StartHeight: status.LatestHeight - 1,
+		EndHeight:   status.LatestHeight,
+	})
+	require.NoError(err, "GetBlocks")
+	require.Len(blks, 2, "GetBlocks should return the requested range")
+	require.EqualValues(status.LatestHeight-1, blks[0].Height, "GetBlocks should return blocks in order")
+	require.EqualValues(blk, blks[1], "GetBlocks should return the same blocks as GetBlock")
+
+	blks, err = backend.GetBlocks(ctx, &consensus.GetBlocksRequest{
+		StartHeight: 1,
+		Limit:       1,
+	})
+	require.NoError(err, "GetBlocks(Limit)")
+	require.Len(blks, 1, "GetBlocks should respect the limit")
+
+	_, err = backend.GetBlocks(ctx, &consensus.GetBlocksRequest{})
+	require.ErrorIs(err, consensus.ErrInvalidArgument, "GetBlocks with invalid range should fail")
+
+	blkByHash, err := backend.GetBlockByHash(ctx, blk.Hash)
+	require.NoError(err, "GetBlockByHash")
+	require.EqualValues(blk, blkByHash, "GetBlockByHash should return the same block as GetBlock")
+
+	_, err = backend.GetBlockByHash(ctx, hash.Hash{})
+	require.ErrorIs(err, consensus.ErrVersionNotFound, "GetBlockByHash with unknown hash should fail")
+
 	txs, err := backend.GetTransactions(ctx, status.LatestHeight)
 	require.NoError(err, "GetTransactions")
 	require.NotEmpty(txs, "number of transactions should be greater than zero")
@@ -77,6 +105,13 @@ func ConsensusImplementationTests(t *testing.T, backend consensus.ClientBackend)
 		len(txsWithResults.Transactions),
 		"GetTransactionsWithResults.Results length mismatch",
 	)
+	_, err = backend.GetEventsByHeight(ctx, status.LatestHeight)
+	require.NoError(err, "GetEventsByHeight")
+
+	// Transaction lookup by hash is only supported by archive nodes.
+	_, err = backend.GetTransactionByHash(ctx, hash.NewFromBytes(txs[0]))
+	require.ErrorIs(err, consensus.ErrUnsupported, "GetTransactionByHash should fail on non-archive nodes")
+
 	for _, res := range txsWithResults.Results {
 		// Quick and dirty filter for meta transactions, which don't use any gas. Most other
 		// transactions do emit events so this should be good enough for here.
@@ -85,6 +120,80 @@ func ConsensusImplementationTests(t *testing.T, backend consensus.ClientBackend)
 		}
 	}
 
+	decodedTxs, err := txsWithResults.Decode()
+	require.NoError(err, "TransactionsWithResults.Decode")
+	require.Len(decodedTxs, len(txs), "TransactionsWithResults.Decode length mismatch")
+
+	_, _, err = backend.WatchEvents(ctx, &consensus.WatchEventsRequest{
+		Filter: consensus.EventFilter{Kinds: []consensus.EventKind{"invalid"}},
+	})
+	require.Error(err, "WatchEvents with an invalid filter should fail")
+
+	var numEvents int
+	for _, res := range txsWithResults.Results {
+		numEvents += len(res.Events)
+	}
+	if numEvents > 0 {
+		evCh, evSub, err := backend.WatchEvents(ctx, &consensus.WatchEventsRequest{
+			Height: status.LatestHeight,
+		})
+		require.NoError(err, "WatchEvents")
+
+		var cursor consensus.EventCursor
+		select {
+		case ev, ok := <-evCh:
+			require.True(ok, "WatchEvents channel should not be closed")
+			require.NotNil(ev.Event, "returned event should not be nil")
+			require.EqualValues(status.LatestHeight, ev.Cursor.Height, "event cursor height should be correct")
+			cursor = ev.Cursor
+		case <-time.After(recvTimeout):
+			t.Fatalf("failed to receive consensus event")
+		}
+		evSub.Close()
+
+		// Resuming after the cursor should not return duplicate events.
+		evCh, evSub, err = backend.WatchEvents(ctx, &consensus.WatchEventsRequest{
+			After: &cursor,
+		})
+		require.NoError(err, "WatchEvents(After)")
+
+		select {
+		case ev, ok := <-evCh:
+			require.True(ok, "WatchEvents channel should not be closed")
+			require.True(
+				ev.Cursor.Height > cursor.Height || ev.Cursor.Index > cursor.Index,
+				"resumed event stream should start after the cursor",
+			)
+		case <-time.After(recvTimeout):
+			t.Fatalf("failed to receive consensus event")
+		}
+		evSub.Close()
+	}
+
+	_, _, err = backend.WatchValidatorSetChanges(ctx, &consensus.WatchValidatorSetChangesRequest{Height: -1})
+	require.Error(err, "WatchValidatorSetChanges with an invalid height should fail")
+
+	// The initial validator set should be reported as added at the genesis height.
+	if status.LastRetainedHeight == status.GenesisHeight {
+		valCh, valSub, err := backend.WatchValidatorSetChanges(ctx, &consensus.WatchValidatorSetChangesRequest{
+			Height: status.GenesisHeight,
+		})
+		require.NoError(err, "WatchValidatorSetChanges")
+
+		select {
+		case change, ok := <-valCh:
+			require.True(ok, "WatchValidatorSetChanges channel should not be closed")
+			require.EqualValues(status.GenesisHeight, change.Height, "validator set change height should be correct")
+			require.NotEmpty(change.Added, "initial validator set should be reported as added")
+			require.Empty(change.Removed, "initial validator set change should not remove validators")
+			require.Len(change.Proposers, consensus.ValidatorSetProposerHints, "proposer hints should be included")
+			require.Positive(change.TotalVotingPower, "total voting power should be positive")
+		case <-time.After(recvTimeout):
+			t.Fatalf("failed to receive validator set change")
+		}
+		valSub.Close()
+	}
+
 	txsWithProofs, err := backend.GetTransactionsWithProofs(ctx, status.LatestHeight)
 	require.NoError(err, "GetTransactionsWithProofs")
 	require.Len(
@@ -126,6 +235,16 @@ func ConsensusImplementationTests(t *testing.T, backend consensus.ClientBackend)
 	})
 	require.NoError(err, "EstimateGas")
 
+	_, err = backend.EstimateFee(ctx, &consensus.EstimateGasRequest{})
+	require.ErrorIs(err, consensus.ErrInvalidArgument, "EstimateFee with nil transaction should fail")
+
+	fee, err := backend.EstimateFee(ctx, &consensus.EstimateGasRequest{
+		Signer:      memorySigner.NewTestSigner("estimate gas signer").Public(),
+		Transaction: transaction.NewTransaction(0, nil, staking.MethodTransfer, &staking.Transfer{}),
+	})
+	require.NoError(err, "EstimateFee")
+	require.Greater(fee.Gas, transaction.Gas(0), "estimated fee gas should be greater than zero")
+
 	nonce, err := backend.GetSignerNonce(ctx, &consensus.GetSignerNonceRequest{
 		AccountAddress: staking.NewAddress(
 			signature.NewPublicKey("badfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"),
@@ -147,6 +266,26 @@ func ConsensusImplementationTests(t *testing.T, backend consensus.ClientBackend)
 	require.True(lshdr.Height >= shdr.Height, "returned latest light block height should be greater or equal")
 	require.NotNil(shdr.Meta, "returned latest light block should contain metadata")
 
+	lbRange, err := backend.GetLightBlockRange(ctx, &consensus.GetLightBlockRangeRequest{
+		StartHeight: blk.Height,
+		EndHeight:   lshdr.Height,
+	})
+	require.NoError(err, "GetLightBlockRange")
+	require.Len(lbRange.Blocks, int(lshdr.Height-blk.Height+1), "all light blocks in range should be returned")
+	require.NotEmpty(lbRange.ValidatorSets, "light block range should contain validator sets")
+	require.LessOrEqual(len(lbRange.ValidatorSets), len(lbRange.Blocks), "validator sets should be deduplicated")
+	for i, lb := range lbRange.Blocks {
+		require.EqualValues(blk.Height+int64(i), lb.Height, "light blocks should be ordered by height")
+		require.NotNil(lb.Meta, "returned light block should contain metadata")
+		require.Less(int(lb.ValidatorSetIndex), len(lbRange.ValidatorSets), "validator set index should be valid")
+	}
+
+	_, err = backend.GetLightBlockRange(ctx, &consensus.GetLightBlockRangeRequest{
+		StartHeight: blk.Height,
+		EndHeight:   blk.Height - 1,
+	})
+	require.ErrorIs(err, consensus.ErrInvalidArgument, "GetLightBlockRange should fail with invalid range")
+
 	params, err := backend.GetParameters(ctx, blk.Height)
 	require.NoError(err, "GetParameters")
 	require.Equal(params.Height, blk.Height, "returned parameters height should be correct")
@@ -175,6 +314,36 @@ func ConsensusImplementationTests(t *testing.T, backend consensus.ClientBackend)
 	require.Error(err, "SubmitTxNoWait(duplicate)")
 	require.True(errors.Is(err, consensus.ErrDuplicateTx), "SubmitTxNoWait should return ErrDuplicateTx on duplicate tx")
 
+	_, err = backend.SubmitTxAsync(ctx, testSigTx)
+	require.Error(err, "SubmitTxAsync(duplicate)")
+	require.True(errors.Is(err, consensus.ErrDuplicateTx), "SubmitTxAsync should return ErrDuplicateTx on duplicate tx")
+
+	testTx = transaction.NewTransaction(1, &transaction.Fee{Gas: 10_000}, staking.MethodTransfer, &staking.Transfer{})
+	testSigTx, err = transaction.Sign(testSigner, testTx)
+	require.NoError(err, "transaction.Sign")
+	txHash, err := backend.SubmitTxAsync(ctx, testSigTx)
+	require.NoError(err, "SubmitTxAsync")
+	require.Equal(hash.NewFromBytes(cbor.Marshal(testSigTx)), txHash, "SubmitTxAsync should return the transaction hash")
+
+	_, err = backend.SubmitTxWithResults(ctx, &transaction.SignedTransaction{})
+	require.Error(err, "SubmitTxWithResults should fail with invalid transaction")
+
+	testTx = transaction.NewTransaction(0, &transaction.Fee{Gas: 10_000}, staking.MethodTransfer, &staking.Transfer{})
+	testSigner = memorySigner.NewTestSigner(fmt.Sprintf("consensus tests tx results signer: %T", backend))
+	testSigTx, err = transaction.Sign(testSigner, testTx)
+	require.NoError(err, "transaction.Sign")
+	txResult, err := backend.SubmitTxWithResults(ctx, testSigTx)
+	require.NoError(err, "SubmitTxWithResults")
+	require.Equal(hash.NewFromBytes(cbor.Marshal(testSigTx)), txResult.Hash, "SubmitTxWithResults should return the transaction hash")
+	require.Greater(txResult.Height, int64(0), "SubmitTxWithResults should return the inclusion height")
+	require.NotNil(txResult.Result, "SubmitTxWithResults should return the execution result")
+
+	txsWithResults, err = backend.GetTransactionsWithResults(ctx, txResult.Height)
+	require.NoError(err, "GetTransactionsWithResults")
+	require.Less(int(txResult.Index), len(txsWithResults.Transactions), "SubmitTxWithResults should return a valid transaction index")
+	require.Equal(cbor.Marshal(testSigTx), txsWithResults.Transactions[txResult.Index], "SubmitTxWithResults should return the transaction index")
+	require.Equal(txsWithResults.Results[txResult.Index], txResult.Result, "SubmitTxWithResults should return the execution result")
+
 	// We should be able to do remote state queries. Of course the state format is backend-specific
 	// so we simply perform some usual storage operations like fetching random keys and iterating
 	// through everything.
@@ -10,13 +10,19 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/config"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	p2p "github.com/oasisprotocol/oasis-core/go/p2p/api"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	block "github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	runtime "github.com/oasisprotocol/oasis-core/go/runtime/api"
+	hostSgx "github.com/oasisprotocol/oasis-core/go/runtime/host/sgx"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
 	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
+	nodedb "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 	commonWorker "github.com/oasisprotocol/oasis-core/go/worker/common/api"
 	executorWorker "github.com/oasisprotocol/oasis-core/go/worker/compute/executor/api"
@@ -38,6 +44,13 @@ type NodeController interface {
 	// shutdown to complete.
 	RequestShutdown(ctx context.Context, wait bool) error
 
+	// RequestGracefulShutdown requests the node to hand off its committee duties and shut down.
+	//
+	// In contrast to RequestShutdown, the node does not wait for its registration to expire.
+	// Instead it finishes any runtime round it is currently participating in (including
+	// submitting pending commitments), stops participating in new rounds and then exits.
+	RequestGracefulShutdown(ctx context.Context, req *GracefulShutdownRequest) error
+
 	// WaitSync waits for the node to finish syncing.
 	WaitSync(ctx context.Context) error
 
@@ -60,6 +73,64 @@ type NodeController interface {
 
 	// GetStatus returns the current status overview of the node.
 	GetStatus(ctx context.Context) (*Status, error)
+
+	// GetDiscrepancyArtifacts returns the executor discrepancy artifacts retained by the node
+	// for the given runtime, ordered by round.
+	GetDiscrepancyArtifacts(ctx context.Context, runtimeID common.Namespace) ([]*executorWorker.DiscrepancyArtifact, error)
+
+	// WatchRuntimeLifecycleEvents subscribes to lifecycle events of the runtimes hosted by the
+	// node (bundle loaded, provisioned, started, attested, version activated, suspended, stopped).
+	//
+	// A snapshot of the latest state of each runtime, consisting of the most recent event of each
+	// kind, is replayed on subscription so that external orchestrators can reconstruct the current
+	// state of the node's runtimes.
+	WatchRuntimeLifecycleEvents(ctx context.Context) (<-chan *runtime.LifecycleEvent, pubsub.ClosableSubscription, error)
+
+	// GetRuntimeStorageStats returns detailed size statistics of the given runtime's local
+	// node database.
+	GetRuntimeStorageStats(ctx context.Context, runtimeID common.Namespace) (*nodedb.SizeStats, error)
+
+	// CompactRuntimeStorage triggers a manual compaction of the given runtime's local node
+	// database, reclaiming space used by pruned versions, and returns the size statistics
+	// after compaction.
+	CompactRuntimeStorage(ctx context.Context, runtimeID common.Namespace) (*nodedb.SizeStats, error)
+
+	// PauseRuntime pauses hosting of the given runtime. While paused, the node keeps following
+	// the runtime but does not participate in any of its committees.
+	PauseRuntime(ctx context.Context, runtimeID common.Namespace) error
+
+	// ResumeRuntime resumes hosting of the given previously paused runtime.
+	ResumeRuntime(ctx context.Context, runtimeID common.Namespace) error
+
+	// RestartRuntime forcibly restarts the given hosted runtime without restarting the node.
+	RestartRuntime(ctx context.Context, runtimeID common.Namespace) error
+
+	// SetLogLevel changes the log level of the given module on the running node.
+	SetLogLevel(ctx context.Context, req *SetLogLevelRequest) error
+
+	// GetAttestationAuditLog returns the persisted audit log of attestations produced by the node
+	// for the given runtime, ordered from oldest to newest.
+	GetAttestationAuditLog(ctx context.Context, runtimeID common.Namespace) ([]*hostSgx.AttestationRecord, error)
+}
+
+// GracefulShutdownRequest is a RequestGracefulShutdown request.
+type GracefulShutdownRequest struct {
+	// Wait specifies whether the call should wait for the shutdown to complete.
+	Wait bool `json:"wait,omitempty"`
+
+	// Deregister specifies whether the node should also request not to be registered in the
+	// next epoch, as done by RequestShutdown.
+	Deregister bool `json:"deregister,omitempty"`
+}
+
+// SetLogLevelRequest is a SetLogLevel request.
+type SetLogLevelRequest struct {
+	// Module is the module (prefix) whose log level should be changed. If empty or "default",
+	// the default log level is changed.
+	Module string `json:"module"`
+
+	// Level is the new log level.
+	Level logging.Level `json:"level"`
 }
 
 // Status is the current status overview.
@@ -99,6 +170,9 @@ type Status struct {
 
 	// Seed is the seed node status if the node is a seed node.
 	Seed *SeedStatus `json:"seed,omitempty"`
+
+	// TCBCache is the status of the cached SGX TCB bundle if the node runs SGX runtimes.
+	TCBCache *hostSgx.TCBCacheStatus `json:"tcb_cache,omitempty"`
 }
 
 // DebugStatus is the current node debug status, listing the various node
@@ -111,6 +185,9 @@ type DebugStatus struct {
 	// AllowRoot is true iff the node is running with DebugAllowRoot
 	// set.
 	AllowRoot bool `json:"allow_root"`
+
+	// Capabilities are the unsafe debug capabilities enabled on the node.
+	Capabilities []string `json:"capabilities,omitempty"`
 }
 
 // IdentityStatus is the current node identity status, listing all the public keys that identify
@@ -136,6 +213,21 @@ type RegistrationStatus struct {
 	// registration attempt has not been successful.
 	LastAttemptErrorMessage string `json:"last_attempt_error_message,omitempty"`
 
+	// LastAttemptErrorModule is the module of the error if the last registration attempt has not
+	// been successful.
+	LastAttemptErrorModule string `json:"last_attempt_error_module,omitempty"`
+
+	// LastAttemptErrorCode is the code of the error if the last registration attempt has not been
+	// successful.
+	LastAttemptErrorCode uint32 `json:"last_attempt_error_code,omitempty"`
+
+	// ConsecutiveFailures is the number of consecutive failed registration attempts.
+	ConsecutiveFailures uint64 `json:"consecutive_failures,omitempty"`
+
+	// NextAttemptEpoch is the epoch of the next re-registration attempt in case the node is
+	// backing off due to repeated failures. It is zero in case the node is not backing off.
+	NextAttemptEpoch beacon.EpochTime `json:"next_attempt_epoch,omitempty"`
+
 	// LastAttempt is the time of the last registration attempt.
 	// In case the node did not successfully register yet, it will be the zero timestamp.
 	LastAttempt time.Time `json:"last_attempt"`
@@ -186,6 +278,35 @@ type RuntimeStatus struct {
 
 	// Provisioner is the name of the runtime provisioner.
 	Provisioner string `json:"provisioner,omitempty"`
+
+	// Health is a summary of the runtime health in case this node hosts the runtime.
+	Health *RuntimeHealthStatus `json:"health,omitempty"`
+}
+
+// RuntimeHealthStatus is a summary of the health of a runtime hosted by the node.
+type RuntimeHealthStatus struct {
+	// Attestation is the attestation status in case the runtime is running in a TEE.
+	Attestation *RuntimeAttestationStatus `json:"attestation,omitempty"`
+
+	// Roles are the node's roles in the current runtime committee.
+	Roles []scheduler.Role `json:"roles"`
+
+	// LastProcessedRound is the last runtime round processed by the committee node.
+	LastProcessedRound uint64 `json:"last_processed_round"`
+	// PendingStorageSync is the number of rounds that have not yet been synced and finalized by
+	// the storage worker.
+	PendingStorageSync uint64 `json:"pending_storage_sync"`
+}
+
+// RuntimeAttestationStatus is the attestation status of a runtime hosted by the node.
+type RuntimeAttestationStatus struct {
+	// LastAttestation is the time of the last successful attestation.
+	LastAttestation time.Time `json:"last_attestation,omitempty"`
+	// Expiry is the time after which attestations are expected to fail unless the TCB bundle
+	// is refreshed.
+	Expiry time.Time `json:"expiry,omitempty"`
+	// TCBStatus is the TCB status of the platform as of the last successful attestation.
+	TCBStatus string `json:"tcb_status,omitempty"`
 }
 
 // SeedStatus is the status of the seed node.
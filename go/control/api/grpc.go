@@ -5,8 +5,14 @@ import (
 
 	"google.golang.org/grpc"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	runtime "github.com/oasisprotocol/oasis-core/go/runtime/api"
+	hostSgx "github.com/oasisprotocol/oasis-core/go/runtime/host/sgx"
+	nodedb "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
 	upgradeApi "github.com/oasisprotocol/oasis-core/go/upgrade/api"
+	executorWorker "github.com/oasisprotocol/oasis-core/go/worker/compute/executor/api"
 )
 
 var (
@@ -15,6 +21,8 @@ var (
 
 	// methodRequestShutdown is the RequestShutdown method.
 	methodRequestShutdown = serviceName.NewMethod("RequestShutdown", false)
+	// methodRequestGracefulShutdown is the RequestGracefulShutdown method.
+	methodRequestGracefulShutdown = serviceName.NewMethod("RequestGracefulShutdown", GracefulShutdownRequest{})
 	// methodWaitSync is the WaitSync method.
 	methodWaitSync = serviceName.NewMethod("WaitSync", nil)
 	// methodIsSynced is the IsSynced method.
@@ -29,6 +37,24 @@ var (
 	methodCancelUpgrade = serviceName.NewMethod("CancelUpgrade", nil)
 	// methodGetStatus is the GetStatus method.
 	methodGetStatus = serviceName.NewMethod("GetStatus", nil)
+	// methodGetDiscrepancyArtifacts is the GetDiscrepancyArtifacts method.
+	methodGetDiscrepancyArtifacts = serviceName.NewMethod("GetDiscrepancyArtifacts", common.Namespace{})
+	// methodWatchRuntimeLifecycleEvents is the WatchRuntimeLifecycleEvents method.
+	methodWatchRuntimeLifecycleEvents = serviceName.NewMethod("WatchRuntimeLifecycleEvents", nil)
+	// methodGetRuntimeStorageStats is the GetRuntimeStorageStats method.
+	methodGetRuntimeStorageStats = serviceName.NewMethod("GetRuntimeStorageStats", common.Namespace{})
+	// methodCompactRuntimeStorage is the CompactRuntimeStorage method.
+	methodCompactRuntimeStorage = serviceName.NewMethod("CompactRuntimeStorage", common.Namespace{})
+	// methodPauseRuntime is the PauseRuntime method.
+	methodPauseRuntime = serviceName.NewMethod("PauseRuntime", common.Namespace{})
+	// methodResumeRuntime is the ResumeRuntime method.
+	methodResumeRuntime = serviceName.NewMethod("ResumeRuntime", common.Namespace{})
+	// methodRestartRuntime is the RestartRuntime method.
+	methodRestartRuntime = serviceName.NewMethod("RestartRuntime", common.Namespace{})
+	// methodSetLogLevel is the SetLogLevel method.
+	methodSetLogLevel = serviceName.NewMethod("SetLogLevel", SetLogLevelRequest{})
+	// methodGetAttestationAuditLog is the GetAttestationAuditLog method.
+	methodGetAttestationAuditLog = serviceName.NewMethod("GetAttestationAuditLog", common.Namespace{})
 
 	// serviceDesc is the gRPC service descriptor.
 	serviceDesc = grpc.ServiceDesc{
@@ -39,6 +65,10 @@ var (
 				MethodName: methodRequestShutdown.ShortName(),
 				Handler:    handlerRequestShutdown,
 			},
+			{
+				MethodName: methodRequestGracefulShutdown.ShortName(),
+				Handler:    handlerRequestGracefulShutdown,
+			},
 			{
 				MethodName: methodWaitSync.ShortName(),
 				Handler:    handlerWaitSync,
@@ -67,8 +97,46 @@ var (
 				MethodName: methodGetStatus.ShortName(),
 				Handler:    handlerGetStatus,
 			},
+			{
+				MethodName: methodGetDiscrepancyArtifacts.ShortName(),
+				Handler:    handlerGetDiscrepancyArtifacts,
+			},
+			{
+				MethodName: methodGetRuntimeStorageStats.ShortName(),
+				Handler:    handlerGetRuntimeStorageStats,
+			},
+			{
+				MethodName: methodCompactRuntimeStorage.ShortName(),
+				Handler:    handlerCompactRuntimeStorage,
+			},
+			{
+				MethodName: methodPauseRuntime.ShortName(),
+				Handler:    handlerPauseRuntime,
+			},
+			{
+				MethodName: methodResumeRuntime.ShortName(),
+				Handler:    handlerResumeRuntime,
+			},
+			{
+				MethodName: methodRestartRuntime.ShortName(),
+				Handler:    handlerRestartRuntime,
+			},
+			{
+				MethodName: methodSetLogLevel.ShortName(),
+				Handler:    handlerSetLogLevel,
+			},
+			{
+				MethodName: methodGetAttestationAuditLog.ShortName(),
+				Handler:    handlerGetAttestationAuditLog,
+			},
+		},
+		Streams: []grpc.StreamDesc{
+			{
+				StreamName:    methodWatchRuntimeLifecycleEvents.ShortName(),
+				Handler:       handlerWatchRuntimeLifecycleEvents,
+				ServerStreams: true,
+			},
 		},
-		Streams: []grpc.StreamDesc{},
 	}
 )
 
@@ -95,6 +163,29 @@ func handlerRequestShutdown(
 	return interceptor(ctx, wait, info, handler)
 }
 
+func handlerRequestGracefulShutdown(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var req GracefulShutdownRequest
+	if err := dec(&req); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return nil, srv.(NodeController).RequestGracefulShutdown(ctx, &req)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodRequestGracefulShutdown.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, srv.(NodeController).RequestGracefulShutdown(ctx, req.(*GracefulShutdownRequest))
+	}
+	return interceptor(ctx, &req, info, handler)
+}
+
 func handlerWaitSync(
 	srv interface{},
 	ctx context.Context,
@@ -236,6 +327,218 @@ func handlerGetStatus(
 	return interceptor(ctx, nil, info, handler)
 }
 
+func handlerGetDiscrepancyArtifacts(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var runtimeID common.Namespace
+	if err := dec(&runtimeID); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeController).GetDiscrepancyArtifacts(ctx, runtimeID)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetDiscrepancyArtifacts.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeController).GetDiscrepancyArtifacts(ctx, *req.(*common.Namespace))
+	}
+	return interceptor(ctx, &runtimeID, info, handler)
+}
+
+func handlerGetRuntimeStorageStats(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var runtimeID common.Namespace
+	if err := dec(&runtimeID); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeController).GetRuntimeStorageStats(ctx, runtimeID)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetRuntimeStorageStats.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeController).GetRuntimeStorageStats(ctx, *req.(*common.Namespace))
+	}
+	return interceptor(ctx, &runtimeID, info, handler)
+}
+
+func handlerCompactRuntimeStorage(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var runtimeID common.Namespace
+	if err := dec(&runtimeID); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeController).CompactRuntimeStorage(ctx, runtimeID)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodCompactRuntimeStorage.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeController).CompactRuntimeStorage(ctx, *req.(*common.Namespace))
+	}
+	return interceptor(ctx, &runtimeID, info, handler)
+}
+
+func handlerPauseRuntime(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var runtimeID common.Namespace
+	if err := dec(&runtimeID); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return nil, srv.(NodeController).PauseRuntime(ctx, runtimeID)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodPauseRuntime.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, srv.(NodeController).PauseRuntime(ctx, *req.(*common.Namespace))
+	}
+	return interceptor(ctx, &runtimeID, info, handler)
+}
+
+func handlerResumeRuntime(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var runtimeID common.Namespace
+	if err := dec(&runtimeID); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return nil, srv.(NodeController).ResumeRuntime(ctx, runtimeID)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodResumeRuntime.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, srv.(NodeController).ResumeRuntime(ctx, *req.(*common.Namespace))
+	}
+	return interceptor(ctx, &runtimeID, info, handler)
+}
+
+func handlerRestartRuntime(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var runtimeID common.Namespace
+	if err := dec(&runtimeID); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return nil, srv.(NodeController).RestartRuntime(ctx, runtimeID)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodRestartRuntime.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, srv.(NodeController).RestartRuntime(ctx, *req.(*common.Namespace))
+	}
+	return interceptor(ctx, &runtimeID, info, handler)
+}
+
+func handlerSetLogLevel(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var req SetLogLevelRequest
+	if err := dec(&req); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return nil, srv.(NodeController).SetLogLevel(ctx, &req)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodSetLogLevel.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, srv.(NodeController).SetLogLevel(ctx, req.(*SetLogLevelRequest))
+	}
+	return interceptor(ctx, &req, info, handler)
+}
+
+func handlerGetAttestationAuditLog(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var runtimeID common.Namespace
+	if err := dec(&runtimeID); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeController).GetAttestationAuditLog(ctx, runtimeID)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetAttestationAuditLog.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeController).GetAttestationAuditLog(ctx, *req.(*common.Namespace))
+	}
+	return interceptor(ctx, &runtimeID, info, handler)
+}
+
+func handlerWatchRuntimeLifecycleEvents(srv interface{}, stream grpc.ServerStream) error {
+	if err := stream.RecvMsg(nil); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	ch, sub, err := srv.(NodeController).WatchRuntimeLifecycleEvents(ctx)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case ev, ok := <-ch:
+			if !ok {
+				return nil
+			}
+
+			if err := stream.SendMsg(ev); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
 // RegisterService registers a new node controller service with the given gRPC server.
 func RegisterService(server *grpc.Server, service NodeController) {
 	server.RegisterService(&serviceDesc, service)
@@ -249,6 +552,10 @@ func (c *nodeControllerClient) RequestShutdown(ctx context.Context, wait bool) e
 	return c.conn.Invoke(ctx, methodRequestShutdown.FullName(), wait, nil)
 }
 
+func (c *nodeControllerClient) RequestGracefulShutdown(ctx context.Context, req *GracefulShutdownRequest) error {
+	return c.conn.Invoke(ctx, methodRequestGracefulShutdown.FullName(), req, nil)
+}
+
 func (c *nodeControllerClient) WaitSync(ctx context.Context) error {
 	return c.conn.Invoke(ctx, methodWaitSync.FullName(), nil, nil)
 }
@@ -289,6 +596,89 @@ func (c *nodeControllerClient) GetStatus(ctx context.Context) (*Status, error) {
 	return &rsp, nil
 }
 
+func (c *nodeControllerClient) GetDiscrepancyArtifacts(ctx context.Context, runtimeID common.Namespace) ([]*executorWorker.DiscrepancyArtifact, error) {
+	var rsp []*executorWorker.DiscrepancyArtifact
+	if err := c.conn.Invoke(ctx, methodGetDiscrepancyArtifacts.FullName(), runtimeID, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+func (c *nodeControllerClient) GetRuntimeStorageStats(ctx context.Context, runtimeID common.Namespace) (*nodedb.SizeStats, error) {
+	var rsp nodedb.SizeStats
+	if err := c.conn.Invoke(ctx, methodGetRuntimeStorageStats.FullName(), runtimeID, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *nodeControllerClient) CompactRuntimeStorage(ctx context.Context, runtimeID common.Namespace) (*nodedb.SizeStats, error) {
+	var rsp nodedb.SizeStats
+	if err := c.conn.Invoke(ctx, methodCompactRuntimeStorage.FullName(), runtimeID, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *nodeControllerClient) PauseRuntime(ctx context.Context, runtimeID common.Namespace) error {
+	return c.conn.Invoke(ctx, methodPauseRuntime.FullName(), runtimeID, nil)
+}
+
+func (c *nodeControllerClient) ResumeRuntime(ctx context.Context, runtimeID common.Namespace) error {
+	return c.conn.Invoke(ctx, methodResumeRuntime.FullName(), runtimeID, nil)
+}
+
+func (c *nodeControllerClient) RestartRuntime(ctx context.Context, runtimeID common.Namespace) error {
+	return c.conn.Invoke(ctx, methodRestartRuntime.FullName(), runtimeID, nil)
+}
+
+func (c *nodeControllerClient) SetLogLevel(ctx context.Context, req *SetLogLevelRequest) error {
+	return c.conn.Invoke(ctx, methodSetLogLevel.FullName(), req, nil)
+}
+
+func (c *nodeControllerClient) GetAttestationAuditLog(ctx context.Context, runtimeID common.Namespace) ([]*hostSgx.AttestationRecord, error) {
+	var rsp []*hostSgx.AttestationRecord
+	if err := c.conn.Invoke(ctx, methodGetAttestationAuditLog.FullName(), runtimeID, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+func (c *nodeControllerClient) WatchRuntimeLifecycleEvents(ctx context.Context) (<-chan *runtime.LifecycleEvent, pubsub.ClosableSubscription, error) {
+	ctx, sub := pubsub.NewContextSubscription(ctx)
+
+	stream, err := c.conn.NewStream(ctx, &serviceDesc.Streams[0], methodWatchRuntimeLifecycleEvents.FullName())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = stream.SendMsg(nil); err != nil {
+		return nil, nil, err
+	}
+	if err = stream.CloseSend(); err != nil {
+		return nil, nil, err
+	}
+
+	ch := make(chan *runtime.LifecycleEvent)
+	go func() {
+		defer close(ch)
+
+		for {
+			var ev runtime.LifecycleEvent
+			if serr := stream.RecvMsg(&ev); serr != nil {
+				return
+			}
+
+			select {
+			case ch <- &ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, sub, nil
+}
+
 // NewNodeControllerClient creates a new gRPC node controller client service.
 func NewNodeControllerClient(c *grpc.ClientConn) NodeController {
 	return &nodeControllerClient{c}
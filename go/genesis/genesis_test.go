@@ -15,6 +15,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/debug"
 	"github.com/oasisprotocol/oasis-core/go/common/entity"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
@@ -140,6 +141,7 @@ func TestGenesisChainContext(t *testing.T) {
 
 func TestGenesisSanityCheck(t *testing.T) {
 	viper.Set(cmdFlags.CfgDebugDontBlameOasis, true)
+	viper.Set(cmdFlags.CfgDebugCapabilities, debug.AllCapabilities)
 	require := require.New(t)
 
 	// First, set up a few things we'll need in the tests below.
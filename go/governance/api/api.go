@@ -7,10 +7,14 @@ import (
 	"encoding/base64"
 	"fmt"
 	"io"
+	"slices"
+	"sort"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/prettyprint"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
@@ -39,22 +43,34 @@ var (
 	// ErrVotingIsClosed is the error returned when a vote is cast for a non-active proposal.
 	ErrVotingIsClosed = errors.New(ModuleName, 7, "governance: voting is closed")
 
+	// AggregatedVoteSignatureContext is the context used for entity signatures over aggregated
+	// votes.
+	AggregatedVoteSignatureContext = signature.NewContext("oasis-core/governance: aggregated vote", signature.WithChainSeparation())
+
 	// MethodSubmitProposal submits a new consensus layer governance proposal.
 	MethodSubmitProposal = transaction.NewMethodName(ModuleName, "SubmitProposal", ProposalContent{})
 	// MethodCastVote casts a vote for a consensus layer governance proposal.
 	MethodCastVote = transaction.NewMethodName(ModuleName, "CastVote", ProposalVote{})
+	// MethodCastAggregatedVote casts a vote for a consensus layer governance proposal on behalf
+	// of multiple entities.
+	MethodCastAggregatedVote = transaction.NewMethodName(ModuleName, "CastAggregatedVote", AggregatedVote{})
 
 	// Methods is the list of all methods supported by the governance backend.
 	Methods = []transaction.MethodName{
 		MethodSubmitProposal,
 		MethodCastVote,
+		MethodCastAggregatedVote,
 	}
 
 	_ prettyprint.PrettyPrinter = (*ProposalContent)(nil)
 	_ prettyprint.PrettyPrinter = (*UpgradeProposal)(nil)
 	_ prettyprint.PrettyPrinter = (*CancelUpgradeProposal)(nil)
 	_ prettyprint.PrettyPrinter = (*ChangeParametersProposal)(nil)
+	_ prettyprint.PrettyPrinter = (*FreezeEntityProposal)(nil)
+	_ prettyprint.PrettyPrinter = (*ThawEntityProposal)(nil)
+	_ prettyprint.PrettyPrinter = (*UpdateRuntimeProposal)(nil)
 	_ prettyprint.PrettyPrinter = (*ProposalVote)(nil)
+	_ prettyprint.PrettyPrinter = (*AggregatedVote)(nil)
 )
 
 // ProposalContent is a consensus layer governance proposal content.
@@ -65,6 +81,9 @@ type ProposalContent struct {
 	Upgrade          *UpgradeProposal          `json:"upgrade,omitempty"`
 	CancelUpgrade    *CancelUpgradeProposal    `json:"cancel_upgrade,omitempty"`
 	ChangeParameters *ChangeParametersProposal `json:"change_parameters,omitempty"`
+	FreezeEntity     *FreezeEntityProposal     `json:"freeze_entity,omitempty"`
+	ThawEntity       *ThawEntityProposal       `json:"thaw_entity,omitempty"`
+	UpdateRuntime    *UpdateRuntimeProposal    `json:"update_runtime,omitempty"`
 }
 
 // ValidateBasic performs basic proposal content validity checks.
@@ -95,6 +114,15 @@ func (p *ProposalContent) ValidateBasic(params *ConsensusParameters) error {
 	if p.ChangeParameters != nil {
 		numProposals++
 	}
+	if p.FreezeEntity != nil {
+		numProposals++
+	}
+	if p.ThawEntity != nil {
+		numProposals++
+	}
+	if p.UpdateRuntime != nil {
+		numProposals++
+	}
 
 	switch {
 	case numProposals > 1:
@@ -109,6 +137,18 @@ func (p *ProposalContent) ValidateBasic(params *ConsensusParameters) error {
 		if err := p.ChangeParameters.ValidateBasic(); err != nil {
 			return fmt.Errorf("change parameters proposal validation failed: %w", err)
 		}
+	case p.FreezeEntity != nil:
+		if err := p.FreezeEntity.ValidateBasic(); err != nil {
+			return fmt.Errorf("freeze entity proposal validation failed: %w", err)
+		}
+	case p.ThawEntity != nil:
+		if err := p.ThawEntity.ValidateBasic(); err != nil {
+			return fmt.Errorf("thaw entity proposal validation failed: %w", err)
+		}
+	case p.UpdateRuntime != nil:
+		if err := p.UpdateRuntime.ValidateBasic(); err != nil {
+			return fmt.Errorf("update runtime proposal validation failed: %w", err)
+		}
 	default:
 		return fmt.Errorf("proposal content has no fields set")
 	}
@@ -136,6 +176,15 @@ func (p *ProposalContent) Equals(other *ProposalContent) bool {
 	if !p.ChangeParameters.Equals(other.ChangeParameters) {
 		return false
 	}
+	if !p.FreezeEntity.Equals(other.FreezeEntity) {
+		return false
+	}
+	if !p.ThawEntity.Equals(other.ThawEntity) {
+		return false
+	}
+	if !p.UpdateRuntime.Equals(other.UpdateRuntime) {
+		return false
+	}
 	return true
 }
 
@@ -157,6 +206,18 @@ func (p ProposalContent) PrettyPrint(ctx context.Context, prefix string, w io.Wr
 		fmt.Fprintf(w, "%sChange Parameters:\n", prefix)
 		p.ChangeParameters.PrettyPrint(ctx, prefix+"  ", w)
 	}
+	if p.FreezeEntity != nil {
+		fmt.Fprintf(w, "%sFreeze Entity:\n", prefix)
+		p.FreezeEntity.PrettyPrint(ctx, prefix+"  ", w)
+	}
+	if p.ThawEntity != nil {
+		fmt.Fprintf(w, "%sThaw Entity:\n", prefix)
+		p.ThawEntity.PrettyPrint(ctx, prefix+"  ", w)
+	}
+	if p.UpdateRuntime != nil {
+		fmt.Fprintf(w, "%sUpdate Runtime:\n", prefix)
+		p.UpdateRuntime.PrettyPrint(ctx, prefix+"  ", w)
+	}
 }
 
 // PrettyType returns a representation of ProposalContent that can be used for
@@ -270,6 +331,80 @@ func (cu CancelUpgradeProposal) PrettyType() (interface{}, error) {
 	return cu, nil
 }
 
+// FreezeEntityProposal is a proposal to freeze an entity, e.g., after its keys have been
+// compromised. Frozen entities cannot register or update nodes and runtimes.
+type FreezeEntityProposal struct {
+	// EntityID is the identifier of the entity that should be frozen.
+	EntityID signature.PublicKey `json:"entity_id"`
+}
+
+// ValidateBasic performs a basic validation on the freeze entity proposal.
+func (p *FreezeEntityProposal) ValidateBasic() error {
+	if !p.EntityID.IsValid() {
+		return fmt.Errorf("%w: invalid entity identifier", ErrInvalidArgument)
+	}
+	return nil
+}
+
+// Equals checks if freeze entity proposals are equal.
+func (p *FreezeEntityProposal) Equals(other *FreezeEntityProposal) bool {
+	if p == other {
+		return true
+	}
+	if p == nil || other == nil {
+		return false
+	}
+	return p.EntityID.Equal(other.EntityID)
+}
+
+// PrettyPrint writes a pretty-printed representation of FreezeEntityProposal to the given
+// writer.
+func (p FreezeEntityProposal) PrettyPrint(_ context.Context, prefix string, w io.Writer) {
+	fmt.Fprintf(w, "%sEntity ID: %s\n", prefix, p.EntityID)
+}
+
+// PrettyType returns a representation of FreezeEntityProposal that can be used for pretty
+// printing.
+func (p FreezeEntityProposal) PrettyType() (interface{}, error) {
+	return p, nil
+}
+
+// ThawEntityProposal is a proposal to thaw a previously frozen entity.
+type ThawEntityProposal struct {
+	// EntityID is the identifier of the entity that should be thawed.
+	EntityID signature.PublicKey `json:"entity_id"`
+}
+
+// ValidateBasic performs a basic validation on the thaw entity proposal.
+func (p *ThawEntityProposal) ValidateBasic() error {
+	if !p.EntityID.IsValid() {
+		return fmt.Errorf("%w: invalid entity identifier", ErrInvalidArgument)
+	}
+	return nil
+}
+
+// Equals checks if thaw entity proposals are equal.
+func (p *ThawEntityProposal) Equals(other *ThawEntityProposal) bool {
+	if p == other {
+		return true
+	}
+	if p == nil || other == nil {
+		return false
+	}
+	return p.EntityID.Equal(other.EntityID)
+}
+
+// PrettyPrint writes a pretty-printed representation of ThawEntityProposal to the given writer.
+func (p ThawEntityProposal) PrettyPrint(_ context.Context, prefix string, w io.Writer) {
+	fmt.Fprintf(w, "%sEntity ID: %s\n", prefix, p.EntityID)
+}
+
+// PrettyType returns a representation of ThawEntityProposal that can be used for pretty
+// printing.
+func (p ThawEntityProposal) PrettyType() (interface{}, error) {
+	return p, nil
+}
+
 // ChangeParametersProposal is a consensus change parameters proposal.
 type ChangeParametersProposal struct {
 	// Module identifies the consensus backend module to which changes should be applied.
@@ -332,6 +467,108 @@ func (p *ChangeParametersProposal) ValidateBasic() error {
 	return nil
 }
 
+// ParameterAllowedParameterChanges is the name of the governance consensus parameter holding the
+// consensus parameter change whitelist.
+const ParameterAllowedParameterChanges = "allowed_parameter_changes"
+
+// CheckAllowed checks whether the proposal only changes consensus parameters that are present in
+// the given whitelist, which maps module names to the names of their changeable parameters.
+//
+// An empty whitelist allows all changes. Changes to the whitelist itself are always allowed so
+// that a whitelist can never lock itself.
+func (p *ChangeParametersProposal) CheckAllowed(allowed map[string][]string) error {
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	allowedParams, ok := allowed[p.Module]
+	switch {
+	case p.Module == ModuleName:
+		allowedParams = append(slices.Clone(allowedParams), ParameterAllowedParameterChanges)
+	case !ok:
+		return fmt.Errorf("changing parameters of module '%s' is not allowed", p.Module)
+	}
+
+	var changes map[string]cbor.RawMessage
+	if err := cbor.Unmarshal(p.Changes, &changes); err != nil {
+		return fmt.Errorf("malformed parameter changes: %w", err)
+	}
+
+	// Check parameters in a deterministic order so that the reported error is consistent.
+	params := make([]string, 0, len(changes))
+	for param := range changes {
+		params = append(params, param)
+	}
+	sort.Strings(params)
+
+	for _, param := range params {
+		if !slices.Contains(allowedParams, param) {
+			return fmt.Errorf("changing parameter '%s' of module '%s' is not allowed", param, p.Module)
+		}
+	}
+	return nil
+}
+
+// UpdateRuntimeProposal is a proposal to update the parameters of a runtime governed by the
+// consensus layer.
+type UpdateRuntimeProposal struct {
+	// RuntimeID is the identifier of the runtime that should be updated.
+	RuntimeID common.Namespace `json:"runtime_id"`
+	// Changes are runtime parameter changes that should be applied to the runtime descriptor.
+	Changes cbor.RawMessage `json:"changes"`
+}
+
+// Equals checks if update runtime proposals are equal.
+func (p *UpdateRuntimeProposal) Equals(other *UpdateRuntimeProposal) bool {
+	if p == other {
+		return true
+	}
+	if p == nil || other == nil {
+		return false
+	}
+	if !p.RuntimeID.Equal(&other.RuntimeID) {
+		return false
+	}
+	if !bytes.Equal(p.Changes, other.Changes) {
+		return false
+	}
+	return true
+}
+
+// PrettyPrint writes a pretty-printed representation of UpdateRuntimeProposal to the given
+// writer.
+func (p *UpdateRuntimeProposal) PrettyPrint(_ context.Context, prefix string, w io.Writer) {
+	var changes map[string]interface{}
+	if err := cbor.Unmarshal(p.Changes, &changes); err != nil {
+		fmt.Fprintf(w, "%s  <error: %s>\n", prefix, err)
+		fmt.Fprintf(w, "%s  <malformed: %s>\n", prefix, base64.StdEncoding.EncodeToString(p.Changes))
+		return
+	}
+	fmt.Fprintf(w, "%sRuntime ID: %s\n", prefix, p.RuntimeID)
+	fmt.Fprintf(w, "%sChanges: \n", prefix)
+	for param, value := range changes {
+		if value == nil {
+			continue
+		}
+		fmt.Fprintf(w, "%s  - Parameter: %s\n", prefix, param)
+		fmt.Fprintf(w, "%s    Value: %v\n", prefix, value)
+	}
+}
+
+// PrettyType returns a representation of UpdateRuntimeProposal that can be used for pretty
+// printing.
+func (p *UpdateRuntimeProposal) PrettyType() (interface{}, error) {
+	return p, nil
+}
+
+// ValidateBasic performs a basic validation on the update runtime proposal.
+func (p *UpdateRuntimeProposal) ValidateBasic() error {
+	if len(p.Changes) == 0 {
+		return fmt.Errorf("%w: runtime parameter changes should not be empty", ErrInvalidArgument)
+	}
+	return nil
+}
+
 // ProposalVote is a vote for a proposal.
 type ProposalVote struct {
 	// ID is the unique identifier of a proposal.
@@ -353,6 +590,119 @@ func (pv ProposalVote) PrettyType() (interface{}, error) {
 	return pv, nil
 }
 
+// MaxAggregatedVoters is the maximum number of entities that can vote via an aggregated vote.
+const MaxAggregatedVoters = 128
+
+// AggregatedVoter is an entity voting via an aggregated vote.
+type AggregatedVoter struct {
+	// EntityID is the public key identifying the voting entity.
+	EntityID signature.PublicKey `json:"entity_id"`
+	// Nonce is the nonce of the entity's account. It is incremented when the vote is cast, which
+	// prevents the signed vote from being replayed.
+	Nonce uint64 `json:"nonce"`
+}
+
+// AggregatedVoteBody is a vote for a proposal cast on behalf of multiple entities.
+//
+// This is the message that is signed by all of the voting entities.
+type AggregatedVoteBody struct {
+	// Vote is the vote.
+	Vote ProposalVote `json:"vote"`
+	// Voters are the entities on behalf of which the vote is cast.
+	Voters []AggregatedVoter `json:"voters"`
+}
+
+// ValidateBasic performs basic aggregated vote body validity checks.
+func (b *AggregatedVoteBody) ValidateBasic() error {
+	if len(b.Voters) == 0 {
+		return fmt.Errorf("%w: no voters", ErrInvalidArgument)
+	}
+	if len(b.Voters) > MaxAggregatedVoters {
+		return fmt.Errorf("%w: too many voters (max: %d)", ErrInvalidArgument, MaxAggregatedVoters)
+	}
+
+	voters := make(map[signature.PublicKey]struct{}, len(b.Voters))
+	for _, voter := range b.Voters {
+		if _, ok := voters[voter.EntityID]; ok {
+			return fmt.Errorf("%w: duplicate voter %s", ErrInvalidArgument, voter.EntityID)
+		}
+		voters[voter.EntityID] = struct{}{}
+	}
+	return nil
+}
+
+// PrettyPrint writes a pretty-printed representation of AggregatedVoteBody to the given writer.
+func (b AggregatedVoteBody) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	b.Vote.PrettyPrint(ctx, prefix, w)
+	fmt.Fprintf(w, "%sVoters:\n", prefix)
+	for _, voter := range b.Voters {
+		fmt.Fprintf(w, "%s  - %s (nonce: %d)\n", prefix, voter.EntityID, voter.Nonce)
+	}
+}
+
+// PrettyType returns a representation of AggregatedVoteBody that can be used for pretty printing.
+func (b AggregatedVoteBody) PrettyType() (interface{}, error) {
+	return b, nil
+}
+
+// AggregatedVote is a multi-signed blob containing a CBOR-serialized AggregatedVoteBody.
+//
+// The blob must be signed by exactly the voting entities.
+type AggregatedVote struct {
+	signature.MultiSigned
+}
+
+// Open first verifies the blob signatures and then unmarshals the blob. It also verifies that the
+// blob is signed by exactly the voting entities.
+func (av *AggregatedVote) Open(body *AggregatedVoteBody) error {
+	if err := av.MultiSigned.Open(AggregatedVoteSignatureContext, body); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidArgument, err)
+	}
+	if err := body.ValidateBasic(); err != nil {
+		return err
+	}
+
+	voters := make([]signature.PublicKey, 0, len(body.Voters))
+	for _, voter := range body.Voters {
+		voters = append(voters, voter.EntityID)
+	}
+	if len(av.Signatures) != len(voters) || !av.IsOnlySignedBy(voters) {
+		return fmt.Errorf("%w: aggregated vote not signed by exactly the voters", ErrInvalidArgument)
+	}
+	return nil
+}
+
+// PrettyPrint writes a pretty-printed representation of AggregatedVote to the given writer.
+func (av AggregatedVote) PrettyPrint(ctx context.Context, prefix string, w io.Writer) {
+	pt, err := av.PrettyType()
+	if err != nil {
+		fmt.Fprintf(w, "%s<error: %s>\n", prefix, err)
+		return
+	}
+
+	pt.(prettyprint.PrettyPrinter).PrettyPrint(ctx, prefix, w)
+}
+
+// PrettyType returns a representation of AggregatedVote that can be used for pretty printing.
+func (av AggregatedVote) PrettyType() (interface{}, error) {
+	var body AggregatedVoteBody
+	if err := cbor.Unmarshal(av.MultiSigned.Blob, &body); err != nil {
+		return nil, fmt.Errorf("malformed signed blob: %w", err)
+	}
+	return signature.NewPrettyMultiSigned(av.MultiSigned, body)
+}
+
+// SignAggregatedVote serializes the aggregated vote body and signs it by all of the voters.
+func SignAggregatedVote(signers []signature.Signer, body *AggregatedVoteBody) (*AggregatedVote, error) {
+	ms, err := signature.SignMultiSigned(signers, AggregatedVoteSignatureContext, body)
+	if err != nil {
+		return nil, err
+	}
+	return &AggregatedVote{
+		MultiSigned: *ms,
+	}, nil
+}
+
 // Backend is a governance implementation.
 type Backend interface {
 	// ActiveProposals returns a list of all proposals that have not yet closed.
@@ -367,6 +717,10 @@ type Backend interface {
 	// Votes looks up votes for a specific proposal.
 	Votes(ctx context.Context, query *ProposalQuery) ([]*VoteEntry, error)
 
+	// MissingVotes returns, for each active proposal, the tracked entities that have not yet
+	// voted on it.
+	MissingVotes(ctx context.Context, query *MissingVotesQuery) ([]*MissingVotes, error)
+
 	// PendingUpgrades returns a list of all pending upgrades.
 	PendingUpgrades(ctx context.Context, height int64) ([]*upgrade.Descriptor, error)
 
@@ -392,6 +746,29 @@ type ProposalQuery struct {
 	ProposalID uint64 `json:"id"`
 }
 
+// MissingVotesQuery is a missing votes query.
+type MissingVotesQuery struct {
+	Height int64 `json:"height"`
+
+	// ClosingWithin limits the results to proposals closing within the given number of epochs.
+	// Zero means that all active proposals are considered.
+	ClosingWithin beacon.EpochTime `json:"closing_within,omitempty"`
+
+	// Entities are the staking account addresses of the tracked entities. In case no entities
+	// are given, the entities of the current validator set are used.
+	Entities []staking.Address `json:"entities,omitempty"`
+}
+
+// MissingVotes contains the tracked entities that have not yet voted on an active proposal.
+type MissingVotes struct {
+	// ProposalID is the unique identifier of a proposal.
+	ProposalID uint64 `json:"id"`
+	// ClosesAt is the epoch at which the proposal closes.
+	ClosesAt beacon.EpochTime `json:"closes_at"`
+	// Voters are the staking account addresses of the entities that have not yet voted.
+	Voters []staking.Address `json:"voters"`
+}
+
 // VoteEntry contains data about a cast vote.
 type VoteEntry struct {
 	Voter staking.Address `json:"voter"`
@@ -448,6 +825,21 @@ type ConsensusParameters struct {
 
 	// AllowProposalMetadata is true iff proposals are allowed to contain metadata.
 	AllowProposalMetadata bool `json:"allow_proposal_metadata,omitempty"`
+
+	// EnableEntityFreezeProposal is true iff entity freeze and thaw proposals are allowed.
+	EnableEntityFreezeProposal bool `json:"enable_entity_freeze_proposal,omitempty"`
+
+	// EnableUpdateRuntimeProposal is true iff update runtime proposals are allowed.
+	EnableUpdateRuntimeProposal bool `json:"enable_update_runtime_proposal,omitempty"`
+
+	// AllowedParameterChanges is the whitelist of consensus parameters that can be changed via
+	// change parameters proposals, mapping module names to the names of their changeable
+	// parameters. If empty, all parameters of all modules can be changed.
+	AllowedParameterChanges map[string][]string `json:"allowed_parameter_changes,omitempty"`
+
+	// EnableAggregatedVotes is true iff casting votes on behalf of multiple entities in a single
+	// transaction is allowed.
+	EnableAggregatedVotes bool `json:"enable_aggregated_votes,omitempty"`
 }
 
 // ConsensusParameterChanges are allowed governance consensus parameter changes.
@@ -473,6 +865,19 @@ type ConsensusParameterChanges struct {
 
 	// EnableChangeParametersProposal is the new enable change parameters proposal flag.
 	EnableChangeParametersProposal *bool `json:"enable_change_parameters_proposal,omitempty"`
+
+	// EnableEntityFreezeProposal is the new enable entity freeze proposal flag.
+	EnableEntityFreezeProposal *bool `json:"enable_entity_freeze_proposal,omitempty"`
+
+	// EnableUpdateRuntimeProposal is the new enable update runtime proposal flag.
+	EnableUpdateRuntimeProposal *bool `json:"enable_update_runtime_proposal,omitempty"`
+
+	// AllowedParameterChanges is the new consensus parameter change whitelist. An empty
+	// whitelist clears the current one, allowing all changes.
+	AllowedParameterChanges *map[string][]string `json:"allowed_parameter_changes,omitempty"`
+
+	// EnableAggregatedVotes is the new enable aggregated votes flag.
+	EnableAggregatedVotes *bool `json:"enable_aggregated_votes,omitempty"`
 }
 
 // Apply applies changes to the given consensus parameters.
@@ -498,6 +903,21 @@ func (c *ConsensusParameterChanges) Apply(params *ConsensusParameters) error {
 	if c.EnableChangeParametersProposal != nil {
 		params.EnableChangeParametersProposal = *c.EnableChangeParametersProposal
 	}
+	if c.EnableEntityFreezeProposal != nil {
+		params.EnableEntityFreezeProposal = *c.EnableEntityFreezeProposal
+	}
+	if c.EnableUpdateRuntimeProposal != nil {
+		params.EnableUpdateRuntimeProposal = *c.EnableUpdateRuntimeProposal
+	}
+	if c.AllowedParameterChanges != nil {
+		params.AllowedParameterChanges = *c.AllowedParameterChanges
+		if len(params.AllowedParameterChanges) == 0 {
+			params.AllowedParameterChanges = nil
+		}
+	}
+	if c.EnableAggregatedVotes != nil {
+		params.EnableAggregatedVotes = *c.EnableAggregatedVotes
+	}
 	return nil
 }
 
@@ -505,11 +925,14 @@ func (c *ConsensusParameterChanges) Apply(params *ConsensusParameters) error {
 type Event struct {
 	Height int64     `json:"height,omitempty"`
 	TxHash hash.Hash `json:"tx_hash,omitempty"`
+	Index  uint32    `json:"index,omitempty"`
 
 	ProposalSubmitted *ProposalSubmittedEvent `json:"proposal_submitted,omitempty"`
 	ProposalExecuted  *ProposalExecutedEvent  `json:"proposal_executed,omitempty"`
 	ProposalFinalized *ProposalFinalizedEvent `json:"proposal_finalized,omitempty"`
 	Vote              *VoteEvent              `json:"vote,omitempty"`
+
+	ProposalVotingReminder *ProposalVotingReminderEvent `json:"proposal_voting_reminder,omitempty"`
 }
 
 // ProposalSubmittedEvent is the event emitted when a new proposal is submitted.
@@ -564,6 +987,23 @@ func (e *VoteEvent) EventKind() string {
 	return "vote"
 }
 
+// ProposalVotingReminderEvent is emitted at the start of the last epoch of a proposal's voting
+// period in case any validator entities have not yet voted.
+type ProposalVotingReminderEvent struct {
+	// ID is the unique identifier of a proposal.
+	ID uint64 `json:"id"`
+	// ClosesAt is the epoch at which the proposal closes.
+	ClosesAt beacon.EpochTime `json:"closes_at"`
+	// MissingVoters are the staking account addresses of the validator entities that have not
+	// yet voted.
+	MissingVoters []staking.Address `json:"missing_voters"`
+}
+
+// EventKind returns a string representation of this event's kind.
+func (e *ProposalVotingReminderEvent) EventKind() string {
+	return "proposal_voting_reminder"
+}
+
 // NewSubmitProposalTx creates a new submit proposal transaction.
 func NewSubmitProposalTx(nonce uint64, fee *transaction.Fee, proposal *ProposalContent) *transaction.Transaction {
 	return transaction.NewTransaction(nonce, fee, MethodSubmitProposal, proposal)
@@ -574,6 +1014,11 @@ func NewCastVoteTx(nonce uint64, fee *transaction.Fee, vote *ProposalVote) *tran
 	return transaction.NewTransaction(nonce, fee, MethodCastVote, vote)
 }
 
+// NewCastAggregatedVoteTx creates a new cast aggregated vote transaction.
+func NewCastAggregatedVoteTx(nonce uint64, fee *transaction.Fee, vote *AggregatedVote) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodCastAggregatedVote, vote)
+}
+
 const (
 	// GasOpSubmitProposal is the gas operation identifier for submitting proposal.
 	GasOpSubmitProposal transaction.Op = "submit_proposal"
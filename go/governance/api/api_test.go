@@ -10,11 +10,16 @@ import (
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 )
 
 func TestValidateBasic(t *testing.T) {
+	testEntityID := signature.NewPublicKey("4ea5328f943ef6f66daaed74cb0e99c3b1c45f76307b425003dbc7cb3638ed35")
+	invalidEntityID := signature.NewPublicKey("ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	require.NoError(t, invalidEntityID.Blacklist(), "blacklist invalid entity identifier")
+
 	for _, tc := range []struct {
 		msg       string
 		p         *ProposalContent
@@ -81,6 +86,51 @@ func TestValidateBasic(t *testing.T) {
 			},
 			shouldErr: false,
 		},
+		{
+			msg: "freeze entity with invalid entity identifier should fail",
+			p: &ProposalContent{
+				FreezeEntity: &FreezeEntityProposal{EntityID: invalidEntityID},
+			},
+			shouldErr: true,
+		},
+		{
+			msg: "freeze entity with valid proposal content should not fail",
+			p: &ProposalContent{
+				FreezeEntity: &FreezeEntityProposal{EntityID: testEntityID},
+			},
+			shouldErr: false,
+		},
+		{
+			msg: "thaw entity with valid proposal content should not fail",
+			p: &ProposalContent{
+				ThawEntity: &ThawEntityProposal{EntityID: testEntityID},
+			},
+			shouldErr: false,
+		},
+		{
+			msg: "only one of FreezeEntity/ThawEntity fields should be set",
+			p: &ProposalContent{
+				FreezeEntity: &FreezeEntityProposal{EntityID: testEntityID},
+				ThawEntity:   &ThawEntityProposal{EntityID: testEntityID},
+			},
+			shouldErr: true,
+		},
+		{
+			msg: "update runtime without changes should fail",
+			p: &ProposalContent{
+				UpdateRuntime: &UpdateRuntimeProposal{},
+			},
+			shouldErr: true,
+		},
+		{
+			msg: "update runtime with valid proposal content should not fail",
+			p: &ProposalContent{
+				UpdateRuntime: &UpdateRuntimeProposal{
+					Changes: cbor.Marshal(map[string]string{"test-parameter": "test-value"}),
+				},
+			},
+			shouldErr: false,
+		},
 	} {
 		err := tc.p.ValidateBasic(&tc.params) //nolint: gosec
 		if tc.shouldErr {
@@ -91,6 +141,105 @@ func TestValidateBasic(t *testing.T) {
 	}
 }
 
+func TestChangeParametersProposalCheckAllowed(t *testing.T) {
+	require := require.New(t)
+
+	votingPeriod := beacon.EpochTime(10)
+	stakeThreshold := uint8(80)
+	proposal := &ChangeParametersProposal{
+		Module: ModuleName,
+		Changes: cbor.Marshal(ConsensusParameterChanges{
+			VotingPeriod:   &votingPeriod,
+			StakeThreshold: &stakeThreshold,
+		}),
+	}
+
+	for _, tc := range []struct {
+		msg       string
+		allowed   map[string][]string
+		shouldErr bool
+	}{
+		{
+			msg:       "empty whitelist should allow all changes",
+			allowed:   nil,
+			shouldErr: false,
+		},
+		{
+			msg: "whitelisted parameters should be allowed",
+			allowed: map[string][]string{
+				ModuleName: {"voting_period", "stake_threshold", "min_proposal_deposit"},
+			},
+			shouldErr: false,
+		},
+		{
+			msg: "partially whitelisted parameters should not be allowed",
+			allowed: map[string][]string{
+				ModuleName: {"voting_period"},
+			},
+			shouldErr: true,
+		},
+		{
+			msg: "parameters of non-whitelisted modules should not be allowed",
+			allowed: map[string][]string{
+				"staking": {"voting_period", "stake_threshold"},
+			},
+			shouldErr: true,
+		},
+	} {
+		err := proposal.CheckAllowed(tc.allowed)
+		if tc.shouldErr {
+			require.Error(err, tc.msg)
+			continue
+		}
+		require.NoError(err, tc.msg)
+	}
+
+	// Changes to the whitelist itself should always be allowed.
+	allowAll := map[string][]string{}
+	reset := &ChangeParametersProposal{
+		Module: ModuleName,
+		Changes: cbor.Marshal(ConsensusParameterChanges{
+			AllowedParameterChanges: &allowAll,
+		}),
+	}
+	require.NoError(reset.CheckAllowed(map[string][]string{"staking": {"debonding_interval"}}),
+		"changing the whitelist should be allowed even if not whitelisted")
+	require.NoError(reset.CheckAllowed(map[string][]string{ModuleName: {"voting_period"}}),
+		"changing the whitelist should be allowed even if not whitelisted")
+	require.Error(proposal.CheckAllowed(map[string][]string{"staking": {"debonding_interval"}}),
+		"other governance parameters should still not be allowed")
+
+	// Malformed changes should be rejected.
+	malformed := &ChangeParametersProposal{
+		Module:  ModuleName,
+		Changes: []byte{0xFF},
+	}
+	require.Error(malformed.CheckAllowed(map[string][]string{ModuleName: {"voting_period"}}))
+}
+
+func TestConsensusParameterChangesAllowedParameterChanges(t *testing.T) {
+	require := require.New(t)
+
+	params := ConsensusParameters{
+		AllowedParameterChanges: map[string][]string{ModuleName: {"voting_period"}},
+	}
+
+	// An empty whitelist should survive serialization and clear the current whitelist.
+	allowAll := map[string][]string{}
+	var changes ConsensusParameterChanges
+	err := cbor.Unmarshal(cbor.Marshal(ConsensusParameterChanges{AllowedParameterChanges: &allowAll}), &changes)
+	require.NoError(err)
+	require.NoError(changes.SanityCheck(), "clearing the whitelist should not be an empty change")
+	require.NoError(changes.Apply(&params))
+	require.Nil(params.AllowedParameterChanges, "whitelist should be cleared")
+
+	// A non-empty whitelist should replace the current one.
+	whitelist := map[string][]string{"staking": {"debonding_interval"}}
+	changes = ConsensusParameterChanges{AllowedParameterChanges: &whitelist}
+	require.NoError(changes.Apply(&params))
+	require.Equal(whitelist, params.AllowedParameterChanges)
+}
+
 func TestProposalContentEquals(t *testing.T) {
 	for _, tc := range []struct {
 		msg    string
@@ -197,6 +346,16 @@ func TestProposalContentPrettyPrint(t *testing.T) {
 				},
 			},
 		},
+		{
+			expRegex: "^Update Runtime:",
+			p: &ProposalContent{
+				UpdateRuntime: &UpdateRuntimeProposal{
+					Changes: cbor.Marshal(map[string]string{
+						"test-parameter": "test-value",
+					}),
+				},
+			},
+		},
 	} {
 		var actualPrettyPrint bytes.Buffer
 		tc.p.PrettyPrint(context.Background(), "", &actualPrettyPrint)
@@ -22,6 +22,8 @@ var (
 	methodProposal = serviceName.NewMethod("Proposal", ProposalQuery{})
 	// methodVotes is the Votes method.
 	methodVotes = serviceName.NewMethod("Votes", ProposalQuery{})
+	// methodMissingVotes is the MissingVotes method.
+	methodMissingVotes = serviceName.NewMethod("MissingVotes", MissingVotesQuery{})
 	// methodPendingUpgrades is the PendingUpgrades method.
 	methodPendingUpgrades = serviceName.NewMethod("PendingUpgrades", int64(0))
 	// methodStateToGenesis is the StateToGenesis method.
@@ -55,6 +57,10 @@ var (
 				MethodName: methodVotes.ShortName(),
 				Handler:    handlerVotes,
 			},
+			{
+				MethodName: methodMissingVotes.ShortName(),
+				Handler:    handlerMissingVotes,
+			},
 			{
 				MethodName: methodPendingUpgrades.ShortName(),
 				Handler:    handlerPendingUpgrades,
@@ -151,6 +157,29 @@ func handlerVotes(
 	return interceptor(ctx, &query, info, handler)
 }
 
+func handlerMissingVotes(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query MissingVotesQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).MissingVotes(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodMissingVotes.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).MissingVotes(ctx, req.(*MissingVotesQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
 func handlerProposal(
 	srv interface{},
 	ctx context.Context,
@@ -334,6 +363,14 @@ func (c *governanceClient) Votes(ctx context.Context, request *ProposalQuery) ([
 	return rsp, nil
 }
 
+func (c *governanceClient) MissingVotes(ctx context.Context, request *MissingVotesQuery) ([]*MissingVotes, error) {
+	var rsp []*MissingVotes
+	if err := c.conn.Invoke(ctx, methodMissingVotes.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
 func (c *governanceClient) PendingUpgrades(ctx context.Context, height int64) ([]*upgrade.Descriptor, error) {
 	var rsp []*upgrade.Descriptor
 	if err := c.conn.Invoke(ctx, methodPendingUpgrades.FullName(), height, &rsp); err != nil {
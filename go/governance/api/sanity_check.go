@@ -30,6 +30,11 @@ func (p *ConsensusParameters) SanityCheck() error {
 	if p.VotingPeriod >= p.UpgradeCancelMinEpochDiff {
 		return fmt.Errorf("voting_period should be less than upgrade_cancel_min_epoch_diff")
 	}
+	for module := range p.AllowedParameterChanges {
+		if len(module) == 0 {
+			return fmt.Errorf("allowed_parameter_changes should not contain an empty module name")
+		}
+	}
 	return nil
 }
 
@@ -41,7 +46,11 @@ func (c *ConsensusParameterChanges) SanityCheck() error {
 		c.StakeThreshold == nil &&
 		c.UpgradeMinEpochDiff == nil &&
 		c.UpgradeCancelMinEpochDiff == nil &&
-		c.EnableChangeParametersProposal == nil {
+		c.EnableChangeParametersProposal == nil &&
+		c.EnableEntityFreezeProposal == nil &&
+		c.EnableUpdateRuntimeProposal == nil &&
+		c.AllowedParameterChanges == nil &&
+		c.EnableAggregatedVotes == nil {
 		return fmt.Errorf("consensus parameter changes should not be empty")
 	}
 	return nil
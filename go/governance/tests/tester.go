@@ -339,6 +339,23 @@ func voteAndCloseProposal(t *testing.T, backend api.Backend, consensus consensus
 	require.NoError(err, "WatchEvents")
 	defer sub.Close()
 
+	// Query missing votes.
+	entAddr := staking.NewAddress(testState.validatorEntity.ID)
+	missingVotesQuery := &api.MissingVotesQuery{
+		Height:   consensusAPI.HeightLatest,
+		Entities: []staking.Address{entAddr},
+	}
+	missingVotes, err := backend.MissingVotes(ctx, missingVotesQuery)
+	require.NoError(err, "MissingVotes query")
+	require.Condition(func() bool {
+		for _, mv := range missingVotes {
+			if mv.ProposalID == testState.proposal.ID {
+				return len(mv.Voters) == 1 && mv.Voters[0] == entAddr
+			}
+		}
+		return false
+	}, "validator entity vote should be missing")
+
 	// Vote for the submitted cancel proposal.
 	vote := &api.ProposalVote{ID: testState.proposal.ID, Vote: api.VoteYes}
 	tx := api.NewCastVoteTx(0, nil, vote)
@@ -364,7 +381,6 @@ WaitForSubmittedVote:
 	}
 
 	// Validate the vote.
-	entAddr := staking.NewAddress(testState.validatorEntity.ID)
 	require.EqualValues(api.VoteYes, ev.Vote.Vote, "vote should be a VoteYes vote")
 	require.EqualValues(entAddr, ev.Vote.Submitter, "vote submitter should be correct")
 
@@ -375,6 +391,12 @@ WaitForSubmittedVote:
 	require.EqualValues(ev.Vote.Vote, votes[0].Vote, "vote event should be equal to the queried vote")
 	require.EqualValues(ev.Vote.Submitter, votes[0].Voter, "vote event should be equal to the queried vote")
 
+	missingVotes, err = backend.MissingVotes(ctx, missingVotesQuery)
+	require.NoError(err, "MissingVotes query")
+	for _, mv := range missingVotes {
+		require.NotEqualValues(testState.proposal.ID, mv.ProposalID, "validator entity vote should not be missing")
+	}
+
 	// Transition to the voting close epoch.
 	timeSource := consensus.Beacon().(beacon.SetableBackend)
 	currentEpoch, err := timeSource.GetEpoch(ctx, consensusAPI.HeightLatest)
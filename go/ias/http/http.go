@@ -18,6 +18,7 @@ import (
 
 	"golang.org/x/net/context/ctxhttp"
 
+	"github.com/oasisprotocol/oasis-core/go/common/httpclient"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/sgx/ias"
 	"github.com/oasisprotocol/oasis-core/go/ias/api"
@@ -269,9 +270,7 @@ func New(cfg *Config) (api.Endpoint, error) {
 	}
 
 	e := &httpEndpoint{
-		httpClient: &http.Client{
-			Timeout: iasAPITimeout,
-		},
+		httpClient:      httpclient.New(iasAPITimeout),
 		subscriptionKey: cfg.SubscriptionKey,
 		trustRoots:      ias.IntelTrustRoots,
 		spidInfo: api.SPIDInfo{
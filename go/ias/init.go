@@ -15,7 +15,7 @@ var logger = logging.GetLogger("ias")
 
 // New creates a new IAS endpoint.
 func New(identity *identity.Identity) ([]api.Endpoint, error) {
-	if cmdFlags.DebugDontBlameOasis() {
+	if cmdFlags.DebugMockAttestation() {
 		if config.GlobalConfig.IAS.DebugSkipVerify {
 			logger.Warn("`ias.debug_skip_verify` set, AVR signature validation bypassed")
 			ias.SetSkipVerify()
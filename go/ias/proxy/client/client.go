@@ -115,6 +115,7 @@ func New(identity *identity.Identity, addresses []string) ([]api.Endpoint, error
 			spl[1],
 			grpc.WithTransportCredentials(creds),
 			grpc.WithDefaultCallOptions(grpc.WaitForReady(true)),
+			cmnGrpc.WithCallPolicy(cmnGrpc.DefaultCallPolicy),
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to dial IAS proxy address '%s': %w", addr, err)
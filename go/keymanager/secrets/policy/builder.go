@@ -0,0 +1,235 @@
+// Package policy provides helpers for building, serializing and signing key manager SGX access
+// control policies.
+package policy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/secrets"
+)
+
+// Builder is a key manager SGX access control policy builder.
+type Builder struct {
+	policy secrets.PolicySGX
+}
+
+// NewBuilder creates a new builder for the policy of the given key manager runtime.
+func NewBuilder(runtimeID common.Namespace) *Builder {
+	return &Builder{
+		policy: secrets.PolicySGX{
+			ID:       runtimeID,
+			Enclaves: make(map[sgx.EnclaveIdentity]*secrets.EnclavePolicySGX),
+		},
+	}
+}
+
+// NewBuilderFromPolicy creates a new builder initialized with a copy of the given policy.
+//
+// This is useful when preparing a policy update, in which case the serial number should be
+// increased via NextSerial.
+func NewBuilderFromPolicy(policy *secrets.PolicySGX) (*Builder, error) {
+	var b Builder
+	if err := cbor.Unmarshal(cbor.Marshal(policy), &b.policy); err != nil {
+		return nil, fmt.Errorf("failed to copy policy: %w", err)
+	}
+	if b.policy.Enclaves == nil {
+		b.policy.Enclaves = make(map[sgx.EnclaveIdentity]*secrets.EnclavePolicySGX)
+	}
+	return &b, nil
+}
+
+// WithSerial sets the policy serial number.
+func (b *Builder) WithSerial(serial uint32) *Builder {
+	b.policy.Serial = serial
+	return b
+}
+
+// NextSerial increments the policy serial number.
+func (b *Builder) NextSerial() *Builder {
+	b.policy.Serial++
+	return b
+}
+
+// WithMasterSecretRotationInterval sets the master secret rotation interval. Zero disables
+// rotations.
+func (b *Builder) WithMasterSecretRotationInterval(interval beacon.EpochTime) *Builder {
+	b.policy.MasterSecretRotationInterval = interval
+	return b
+}
+
+// WithMaxEphemeralSecretAge sets the maximum age of an ephemeral secret.
+func (b *Builder) WithMaxEphemeralSecretAge(age beacon.EpochTime) *Builder {
+	b.policy.MaxEphemeralSecretAge = age
+	return b
+}
+
+// Enclave returns a builder for the policy of the given key manager enclave, adding the enclave
+// to the policy if needed.
+func (b *Builder) Enclave(enclaveID sgx.EnclaveIdentity) *EnclaveBuilder {
+	policy, ok := b.policy.Enclaves[enclaveID]
+	if !ok {
+		policy = &secrets.EnclavePolicySGX{
+			MayQuery:     make(map[common.Namespace][]sgx.EnclaveIdentity),
+			MayReplicate: []sgx.EnclaveIdentity{},
+		}
+		b.policy.Enclaves[enclaveID] = policy
+	}
+	if policy.MayQuery == nil {
+		policy.MayQuery = make(map[common.Namespace][]sgx.EnclaveIdentity)
+	}
+	return &EnclaveBuilder{
+		builder: b,
+		policy:  policy,
+	}
+}
+
+// RemoveEnclave removes the given key manager enclave from the policy.
+func (b *Builder) RemoveEnclave(enclaveID sgx.EnclaveIdentity) *Builder {
+	delete(b.policy.Enclaves, enclaveID)
+	return b
+}
+
+// Validate checks whether the policy being built is valid.
+func (b *Builder) Validate() error {
+	if !b.policy.ID.IsKeyManager() {
+		return fmt.Errorf("runtime %s is not a key manager runtime", b.policy.ID)
+	}
+	for kmEnclaveID, policy := range b.policy.Enclaves {
+		for rtID, enclaves := range policy.MayQuery {
+			if len(enclaves) == 0 {
+				return fmt.Errorf("enclave %s: no enclaves may query for runtime %s", kmEnclaveID, rtID)
+			}
+		}
+	}
+	return nil
+}
+
+// Build validates and returns a copy of the policy.
+func (b *Builder) Build() (*secrets.PolicySGX, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	var policy secrets.PolicySGX
+	if err := cbor.Unmarshal(cbor.Marshal(b.policy), &policy); err != nil {
+		return nil, fmt.Errorf("failed to copy policy: %w", err)
+	}
+	return &policy, nil
+}
+
+// EnclaveBuilder is a builder for the policy of a single key manager enclave.
+type EnclaveBuilder struct {
+	builder *Builder
+	policy  *secrets.EnclavePolicySGX
+}
+
+// AllowQuery allows the given enclaves of a runtime to query key material.
+func (eb *EnclaveBuilder) AllowQuery(runtimeID common.Namespace, enclaveIDs ...sgx.EnclaveIdentity) *EnclaveBuilder {
+	eb.policy.MayQuery[runtimeID] = appendUnique(eb.policy.MayQuery[runtimeID], enclaveIDs...)
+	return eb
+}
+
+// DisallowQuery revokes the query permission of the given runtime.
+func (eb *EnclaveBuilder) DisallowQuery(runtimeID common.Namespace) *EnclaveBuilder {
+	delete(eb.policy.MayQuery, runtimeID)
+	return eb
+}
+
+// AllowReplication allows the given enclaves to replicate the master secret.
+func (eb *EnclaveBuilder) AllowReplication(enclaveIDs ...sgx.EnclaveIdentity) *EnclaveBuilder {
+	eb.policy.MayReplicate = appendUnique(eb.policy.MayReplicate, enclaveIDs...)
+	return eb
+}
+
+// Done returns the policy builder.
+func (eb *EnclaveBuilder) Done() *Builder {
+	return eb.builder
+}
+
+func appendUnique(ids []sgx.EnclaveIdentity, newIDs ...sgx.EnclaveIdentity) []sgx.EnclaveIdentity {
+NewIDs:
+	for _, newID := range newIDs {
+		for _, id := range ids {
+			if id == newID {
+				continue NewIDs
+			}
+		}
+		ids = append(ids, newID)
+	}
+	return ids
+}
+
+// Marshal serializes the policy.
+func Marshal(policy *secrets.PolicySGX) []byte {
+	return cbor.Marshal(policy)
+}
+
+// Unmarshal deserializes the policy and checks that the encoding is canonical, as otherwise
+// signatures over the serialized policy would not verify.
+func Unmarshal(raw []byte) (*secrets.PolicySGX, error) {
+	var policy secrets.PolicySGX
+	if err := cbor.Unmarshal(raw, &policy); err != nil {
+		return nil, err
+	}
+
+	// Re-marshal to check the canonicity.
+	if !bytes.Equal(raw, cbor.Marshal(policy)) {
+		return nil, errors.New("policy not in canonical form")
+	}
+
+	return &policy, nil
+}
+
+// Sign signs the policy with the given signer, returning a detached signature.
+func Sign(signer signature.Signer, policy *secrets.PolicySGX) (*signature.Signature, error) {
+	return signature.Sign(signer, secrets.PolicySGXSignatureContext, Marshal(policy))
+}
+
+// NewSignedPolicy combines the policy with detached signatures into a signed policy.
+//
+// All signatures must be valid and each signer may only sign once.
+func NewSignedPolicy(policy *secrets.PolicySGX, sigs ...signature.Signature) (*secrets.SignedPolicySGX, error) {
+	signed := secrets.SignedPolicySGX{
+		Policy: *policy,
+	}
+	for _, sig := range sigs {
+		if err := AddSignature(&signed, sig); err != nil {
+			return nil, err
+		}
+	}
+	return &signed, nil
+}
+
+// SignPolicy signs the policy with all of the given signers.
+func SignPolicy(policy *secrets.PolicySGX, signers ...signature.Signer) (*secrets.SignedPolicySGX, error) {
+	sigs := make([]signature.Signature, 0, len(signers))
+	for _, signer := range signers {
+		sig, err := Sign(signer, policy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign policy: %w", err)
+		}
+		sigs = append(sigs, *sig)
+	}
+	return NewSignedPolicy(policy, sigs...)
+}
+
+// AddSignature verifies the given signature and adds it to the signed policy.
+func AddSignature(signed *secrets.SignedPolicySGX, sig signature.Signature) error {
+	for _, s := range signed.Signatures {
+		if s.PublicKey.Equal(sig.PublicKey) {
+			return fmt.Errorf("policy already signed by %s", sig.PublicKey)
+		}
+	}
+	if !sig.Verify(secrets.PolicySGXSignatureContext, Marshal(&signed.Policy)) {
+		return fmt.Errorf("invalid policy signature from %s", sig.PublicKey)
+	}
+	signed.Signatures = append(signed.Signatures, sig)
+	return nil
+}
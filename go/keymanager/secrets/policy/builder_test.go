@@ -0,0 +1,112 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/secrets"
+)
+
+func newEnclaveID(seed byte) sgx.EnclaveIdentity {
+	var id sgx.EnclaveIdentity
+	id.MrEnclave[0] = seed
+	id.MrSigner[0] = seed
+	return id
+}
+
+func TestBuilder(t *testing.T) {
+	require := require.New(t)
+
+	kmID := common.NewTestNamespaceFromSeed([]byte("keymanager/secrets/policy: km"), common.NamespaceKeyManager)
+	rtID := common.NewTestNamespaceFromSeed([]byte("keymanager/secrets/policy: rt"), 0)
+	km1, km2 := newEnclaveID(1), newEnclaveID(2)
+	rt1, rt2 := newEnclaveID(3), newEnclaveID(4)
+
+	b := NewBuilder(kmID).
+		WithSerial(1).
+		WithMasterSecretRotationInterval(10)
+	b.Enclave(km1).
+		AllowQuery(rtID, rt1, rt2, rt1).
+		AllowReplication(km2, km2)
+	b.Enclave(km2).AllowQuery(rtID, rt1)
+
+	policy, err := b.Build()
+	require.NoError(err, "Build")
+	require.Equal(uint32(1), policy.Serial)
+	require.Equal(kmID, policy.ID)
+	require.EqualValues(10, policy.MasterSecretRotationInterval)
+	require.Len(policy.Enclaves, 2)
+	require.Equal([]sgx.EnclaveIdentity{rt1, rt2}, policy.Enclaves[km1].MayQuery[rtID], "duplicates should be ignored")
+	require.Equal([]sgx.EnclaveIdentity{km2}, policy.Enclaves[km1].MayReplicate, "duplicates should be ignored")
+	require.Equal([]sgx.EnclaveIdentity{rt1}, policy.Enclaves[km2].MayQuery[rtID])
+	require.Empty(policy.Enclaves[km2].MayReplicate)
+
+	// Modifying the builder should not affect built policies.
+	b.Enclave(km2).DisallowQuery(rtID)
+	require.Len(policy.Enclaves[km2].MayQuery, 1)
+
+	// Updates should start from an existing policy.
+	ub, err := NewBuilderFromPolicy(policy)
+	require.NoError(err, "NewBuilderFromPolicy")
+	updated, err := ub.NextSerial().RemoveEnclave(km2).Build()
+	require.NoError(err, "Build")
+	require.Equal(uint32(2), updated.Serial)
+	require.Len(updated.Enclaves, 1)
+	require.Len(policy.Enclaves, 2, "original policy should not change")
+
+	// Serialization should roundtrip.
+	raw := Marshal(updated)
+	decoded, err := Unmarshal(raw)
+	require.NoError(err, "Unmarshal")
+	require.Equal(updated, decoded)
+	_, err = Unmarshal(append(raw, 0x00))
+	require.Error(err, "Unmarshal should fail on malformed input")
+
+	// Invalid policies should be rejected.
+	_, err = NewBuilder(rtID).Build()
+	require.Error(err, "policies for non-key manager runtimes should be rejected")
+	ib := NewBuilder(kmID)
+	ib.Enclave(km1).AllowQuery(rtID)
+	_, err = ib.Build()
+	require.Error(err, "empty query permissions should be rejected")
+}
+
+func TestSigning(t *testing.T) {
+	require := require.New(t)
+
+	kmID := common.NewTestNamespaceFromSeed([]byte("keymanager/secrets/policy: km"), common.NamespaceKeyManager)
+	signer1 := memorySigner.NewTestSigner("keymanager/secrets/policy: signer 1")
+	signer2 := memorySigner.NewTestSigner("keymanager/secrets/policy: signer 2")
+
+	policy, err := NewBuilder(kmID).WithSerial(1).Build()
+	require.NoError(err, "Build")
+
+	signed, err := SignPolicy(policy, signer1, signer2)
+	require.NoError(err, "SignPolicy")
+	require.Len(signed.Signatures, 2)
+	err = secrets.SanityCheckSignedPolicySGX(nil, signed)
+	require.NoError(err, "SanityCheckSignedPolicySGX")
+
+	// Detached signatures should combine.
+	sig1, err := Sign(signer1, policy)
+	require.NoError(err, "Sign")
+	sig2, err := Sign(signer2, policy)
+	require.NoError(err, "Sign")
+	combined, err := NewSignedPolicy(policy, *sig1, *sig2)
+	require.NoError(err, "NewSignedPolicy")
+	require.Equal(signed, combined)
+
+	// Duplicate signers should be rejected.
+	_, err = NewSignedPolicy(policy, *sig1, *sig1)
+	require.Error(err, "duplicate signatures should be rejected")
+
+	// Signatures over a different policy should be rejected.
+	other, err := NewBuilder(kmID).WithSerial(2).Build()
+	require.NoError(err, "Build")
+	_, err = NewSignedPolicy(other, *sig1)
+	require.Error(err, "invalid signatures should be rejected")
+}
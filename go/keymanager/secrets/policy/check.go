@@ -0,0 +1,64 @@
+package policy
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/secrets"
+)
+
+// EnclavePermission is the result of evaluating a policy for a single key manager enclave.
+type EnclavePermission struct {
+	// KeyManagerEnclave is the identity of the key manager enclave enforcing the policy.
+	KeyManagerEnclave sgx.EnclaveIdentity `json:"km_enclave"`
+
+	// Allowed is true iff the key manager enclave would permit the request.
+	Allowed bool `json:"allowed"`
+}
+
+// CheckQuery evaluates whether the given enclave may query key material for the given runtime
+// from each of the key manager enclaves covered by the policy.
+func CheckQuery(policy *secrets.PolicySGX, runtimeID common.Namespace, enclaveID sgx.EnclaveIdentity) []EnclavePermission {
+	return checkEnclaves(policy, func(_ sgx.EnclaveIdentity, ep *secrets.EnclavePolicySGX) bool {
+		return slices.Contains(ep.MayQuery[runtimeID], enclaveID)
+	})
+}
+
+// CheckReplicate evaluates whether the given enclave may replicate the master secret from each
+// of the key manager enclaves covered by the policy.
+//
+// Key manager enclaves always implicitly allow replication to other instances of themselves.
+func CheckReplicate(policy *secrets.PolicySGX, enclaveID sgx.EnclaveIdentity) []EnclavePermission {
+	return checkEnclaves(policy, func(kmEnclaveID sgx.EnclaveIdentity, ep *secrets.EnclavePolicySGX) bool {
+		return kmEnclaveID == enclaveID || slices.Contains(ep.MayReplicate, enclaveID)
+	})
+}
+
+// Allowed returns true iff any of the evaluated key manager enclaves permits the request.
+func Allowed(perms []EnclavePermission) bool {
+	for _, perm := range perms {
+		if perm.Allowed {
+			return true
+		}
+	}
+	return false
+}
+
+func checkEnclaves(
+	policy *secrets.PolicySGX,
+	allowed func(sgx.EnclaveIdentity, *secrets.EnclavePolicySGX) bool,
+) []EnclavePermission {
+	perms := make([]EnclavePermission, 0, len(policy.Enclaves))
+	for kmEnclaveID, ep := range policy.Enclaves {
+		perms = append(perms, EnclavePermission{
+			KeyManagerEnclave: kmEnclaveID,
+			Allowed:           allowed(kmEnclaveID, ep),
+		})
+	}
+	sort.Slice(perms, func(i, j int) bool {
+		return perms[i].KeyManagerEnclave.String() < perms[j].KeyManagerEnclave.String()
+	})
+	return perms
+}
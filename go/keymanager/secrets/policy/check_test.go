@@ -0,0 +1,48 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+)
+
+func TestCheck(t *testing.T) {
+	require := require.New(t)
+
+	kmID := common.NewTestNamespaceFromSeed([]byte("keymanager/secrets/policy: km"), common.NamespaceKeyManager)
+	rtID := common.NewTestNamespaceFromSeed([]byte("keymanager/secrets/policy: rt"), 0)
+	otherRtID := common.NewTestNamespaceFromSeed([]byte("keymanager/secrets/policy: other rt"), 0)
+	km1, km2 := newEnclaveID(1), newEnclaveID(2)
+	rt1, rt2 := newEnclaveID(3), newEnclaveID(4)
+
+	b := NewBuilder(kmID)
+	b.Enclave(km1).
+		AllowQuery(rtID, rt1, rt2).
+		AllowReplication(km2)
+	b.Enclave(km2).AllowQuery(rtID, rt1)
+	policy, err := b.Build()
+	require.NoError(err, "Build")
+
+	perms := CheckQuery(policy, rtID, rt1)
+	require.Equal([]EnclavePermission{{km1, true}, {km2, true}}, perms)
+	require.True(Allowed(perms))
+
+	perms = CheckQuery(policy, rtID, rt2)
+	require.Equal([]EnclavePermission{{km1, true}, {km2, false}}, perms)
+	require.True(Allowed(perms))
+
+	perms = CheckQuery(policy, otherRtID, rt1)
+	require.Equal([]EnclavePermission{{km1, false}, {km2, false}}, perms)
+	require.False(Allowed(perms), "queries for runtimes not in the policy should be denied")
+
+	perms = CheckReplicate(policy, km2)
+	require.Equal([]EnclavePermission{{km1, true}, {km2, true}}, perms, "enclaves should implicitly replicate from themselves")
+
+	perms = CheckReplicate(policy, km1)
+	require.Equal([]EnclavePermission{{km1, true}, {km2, false}}, perms)
+
+	perms = CheckReplicate(policy, rt1)
+	require.False(Allowed(perms), "non key manager enclaves should not replicate")
+}
@@ -0,0 +1,144 @@
+package policy
+
+import (
+	"fmt"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+)
+
+// RegistryFilter restricts which runtimes and enclaves are included in a policy generated from
+// the registry state.
+type RegistryFilter struct {
+	// IncludeRuntimes, if non-empty, limits the compute runtimes that may query to the given ones.
+	IncludeRuntimes []common.Namespace
+
+	// ExcludeRuntimes are the compute runtimes that may not query.
+	ExcludeRuntimes []common.Namespace
+
+	// ExcludeEnclaves are the enclave identities that are omitted from the policy, both as key
+	// manager enclaves and as enclaves that may query.
+	ExcludeEnclaves []sgx.EnclaveIdentity
+}
+
+func (f *RegistryFilter) includesRuntime(id common.Namespace) bool {
+	if f == nil {
+		return true
+	}
+	for _, excluded := range f.ExcludeRuntimes {
+		if excluded.Equal(&id) {
+			return false
+		}
+	}
+	if len(f.IncludeRuntimes) == 0 {
+		return true
+	}
+	for _, included := range f.IncludeRuntimes {
+		if included.Equal(&id) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *RegistryFilter) filterEnclaves(ids []sgx.EnclaveIdentity) []sgx.EnclaveIdentity {
+	if f == nil {
+		return ids
+	}
+	var filtered []sgx.EnclaveIdentity
+EnclaveIDs:
+	for _, id := range ids {
+		for _, excluded := range f.ExcludeEnclaves {
+			if id == excluded {
+				continue EnclaveIDs
+			}
+		}
+		filtered = append(filtered, id)
+	}
+	return filtered
+}
+
+// EnclaveIdentities returns the SGX enclave identities allowed by the deployment of the given
+// runtime that is active at the given epoch and by any of the future deployments. Enclaves of
+// deployments that have been superseded are omitted.
+func EnclaveIdentities(rt *registry.Runtime, epoch beacon.EpochTime) ([]sgx.EnclaveIdentity, error) {
+	if rt.TEEHardware != node.TEEHardwareIntelSGX {
+		return nil, fmt.Errorf("runtime %s does not use Intel SGX", rt.ID)
+	}
+
+	active := rt.ActiveDeployment(epoch)
+	var ids []sgx.EnclaveIdentity
+	for _, deployment := range rt.Deployments {
+		if active != nil && deployment.ValidFrom < active.ValidFrom {
+			continue
+		}
+		var sc node.SGXConstraints
+		if err := cbor.Unmarshal(deployment.TEE, &sc); err != nil {
+			return nil, fmt.Errorf("runtime %s: malformed SGX constraints of version %s: %w",
+				rt.ID, deployment.Version, err,
+			)
+		}
+		ids = appendUnique(ids, sc.Enclaves...)
+	}
+	return ids, nil
+}
+
+// NewBuilderFromRegistry creates a new builder for the policy of the given key manager runtime
+// based on the registry state at the given epoch.
+//
+// All enclaves of the active and future key manager deployments are allowed to replicate from each other and all
+// enclaves of the SGX compute runtimes that use the key manager are allowed to query, subject to
+// the given filter. The serial number and other policy parameters must be set by the caller.
+func NewBuilderFromRegistry(km *registry.Runtime, runtimes []*registry.Runtime, epoch beacon.EpochTime, filter *RegistryFilter) (*Builder, error) {
+	if km.Kind != registry.KindKeyManager {
+		return nil, fmt.Errorf("runtime %s is not a key manager runtime", km.ID)
+	}
+	kmEnclaves, err := EnclaveIdentities(km, epoch)
+	if err != nil {
+		return nil, err
+	}
+	kmEnclaves = filter.filterEnclaves(kmEnclaves)
+	if len(kmEnclaves) == 0 {
+		return nil, fmt.Errorf("key manager runtime %s has no enclaves", km.ID)
+	}
+
+	b := NewBuilder(km.ID)
+	for _, kmEnclave := range kmEnclaves {
+		eb := b.Enclave(kmEnclave)
+		for _, other := range kmEnclaves {
+			// Each enclave may always implicitly replicate from other instances of itself.
+			if other == kmEnclave {
+				continue
+			}
+			eb.AllowReplication(other)
+		}
+	}
+
+	for _, rt := range runtimes {
+		if rt.Kind != registry.KindCompute || rt.KeyManager == nil || !rt.KeyManager.Equal(&km.ID) {
+			continue
+		}
+		if rt.TEEHardware != node.TEEHardwareIntelSGX || !filter.includesRuntime(rt.ID) {
+			continue
+		}
+
+		rtEnclaves, err := EnclaveIdentities(rt, epoch)
+		if err != nil {
+			return nil, err
+		}
+		rtEnclaves = filter.filterEnclaves(rtEnclaves)
+		if len(rtEnclaves) == 0 {
+			continue
+		}
+
+		for _, kmEnclave := range kmEnclaves {
+			b.Enclave(kmEnclave).AllowQuery(rt.ID, rtEnclaves...)
+		}
+	}
+
+	return b, nil
+}
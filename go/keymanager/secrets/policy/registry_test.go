@@ -0,0 +1,109 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+)
+
+func newSGXRuntime(id common.Namespace, kind registry.RuntimeKind, km *common.Namespace, enclaves ...[]sgx.EnclaveIdentity) *registry.Runtime {
+	rt := &registry.Runtime{
+		ID:          id,
+		Kind:        kind,
+		KeyManager:  km,
+		TEEHardware: node.TEEHardwareIntelSGX,
+	}
+	for i, ids := range enclaves {
+		rt.Deployments = append(rt.Deployments, &registry.VersionInfo{
+			Version:   version.Version{Major: uint16(i + 1)},
+			ValidFrom: beacon.EpochTime(10 * i),
+			TEE: cbor.Marshal(node.SGXConstraints{
+				Versioned: cbor.NewVersioned(node.LatestSGXConstraintsVersion),
+				Enclaves:  ids,
+			}),
+		})
+	}
+	return rt
+}
+
+func TestNewBuilderFromRegistry(t *testing.T) {
+	require := require.New(t)
+
+	kmID := common.NewTestNamespaceFromSeed([]byte("keymanager/secrets/policy: km"), common.NamespaceKeyManager)
+	otherKmID := common.NewTestNamespaceFromSeed([]byte("keymanager/secrets/policy: other km"), common.NamespaceKeyManager)
+	rt1ID := common.NewTestNamespaceFromSeed([]byte("keymanager/secrets/policy: rt1"), 0)
+	rt2ID := common.NewTestNamespaceFromSeed([]byte("keymanager/secrets/policy: rt2"), 0)
+	rt3ID := common.NewTestNamespaceFromSeed([]byte("keymanager/secrets/policy: rt3"), 0)
+	km1, km2 := newEnclaveID(1), newEnclaveID(2)
+	rt1a, rt1b, rt2a, rt3a := newEnclaveID(3), newEnclaveID(4), newEnclaveID(5), newEnclaveID(6)
+
+	km := newSGXRuntime(kmID, registry.KindKeyManager, nil, []sgx.EnclaveIdentity{km1}, []sgx.EnclaveIdentity{km2})
+	runtimes := []*registry.Runtime{
+		km,
+		newSGXRuntime(rt1ID, registry.KindCompute, &kmID, []sgx.EnclaveIdentity{rt1a}, []sgx.EnclaveIdentity{rt1a, rt1b}),
+		newSGXRuntime(rt2ID, registry.KindCompute, &kmID, []sgx.EnclaveIdentity{rt2a}),
+		// Runtime using a different key manager.
+		newSGXRuntime(rt3ID, registry.KindCompute, &otherKmID, []sgx.EnclaveIdentity{rt3a}),
+	}
+
+	// No filters.
+	b, err := NewBuilderFromRegistry(km, runtimes, 0, nil)
+	require.NoError(err, "NewBuilderFromRegistry")
+	policy, err := b.WithSerial(1).Build()
+	require.NoError(err, "Build")
+	require.Equal(kmID, policy.ID)
+	require.Len(policy.Enclaves, 2)
+	require.Equal([]sgx.EnclaveIdentity{km2}, policy.Enclaves[km1].MayReplicate)
+	require.Equal([]sgx.EnclaveIdentity{km1}, policy.Enclaves[km2].MayReplicate)
+	for _, kmEnclave := range []sgx.EnclaveIdentity{km1, km2} {
+		mayQuery := policy.Enclaves[kmEnclave].MayQuery
+		require.Len(mayQuery, 2)
+		require.Equal([]sgx.EnclaveIdentity{rt1a, rt1b}, mayQuery[rt1ID])
+		require.Equal([]sgx.EnclaveIdentity{rt2a}, mayQuery[rt2ID])
+	}
+
+	// Include and exclude filters.
+	b, err = NewBuilderFromRegistry(km, runtimes, 0, &RegistryFilter{
+		IncludeRuntimes: []common.Namespace{rt1ID, rt2ID},
+		ExcludeRuntimes: []common.Namespace{rt2ID},
+		ExcludeEnclaves: []sgx.EnclaveIdentity{km1, rt1a},
+	})
+	require.NoError(err, "NewBuilderFromRegistry")
+	policy, err = b.Build()
+	require.NoError(err, "Build")
+	require.Len(policy.Enclaves, 1)
+	require.Empty(policy.Enclaves[km2].MayReplicate)
+	require.Equal(map[common.Namespace][]sgx.EnclaveIdentity{
+		rt1ID: {rt1b},
+	}, policy.Enclaves[km2].MayQuery)
+
+	// Not a key manager.
+	_, err = NewBuilderFromRegistry(runtimes[1], runtimes, 0, nil)
+	require.Error(err, "NewBuilderFromRegistry should fail for compute runtimes")
+
+	// All key manager enclaves excluded.
+	_, err = NewBuilderFromRegistry(km, runtimes, 0, &RegistryFilter{
+		ExcludeEnclaves: []sgx.EnclaveIdentity{km1, km2},
+	})
+	require.Error(err, "NewBuilderFromRegistry should fail without key manager enclaves")
+
+	// Superseded deployments are omitted.
+	b, err = NewBuilderFromRegistry(km, runtimes, 10, nil)
+	require.NoError(err, "NewBuilderFromRegistry")
+	policy, err = b.Build()
+	require.NoError(err, "Build")
+	require.Len(policy.Enclaves, 1)
+	require.Empty(policy.Enclaves[km2].MayReplicate)
+	require.Equal(map[common.Namespace][]sgx.EnclaveIdentity{
+		rt1ID: {rt1a, rt1b},
+		rt2ID: {rt2a},
+	}, policy.Enclaves[km2].MayQuery)
+}
@@ -11,12 +11,14 @@ import (
 	flag "github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
+	"github.com/oasisprotocol/oasis-core/go/common/debug"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	"github.com/oasisprotocol/oasis-core/go/oasis-net-runner/fixtures"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
 	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
 )
 
 const (
@@ -40,6 +42,12 @@ var (
 		Run:   doDumpFixture,
 	}
 
+	devnetCmd = &cobra.Command{
+		Use:   "devnet",
+		Short: "run a minimal deterministic local network for runtime development",
+		RunE:  runDevnet,
+	}
+
 	rootFlags = flag.NewFlagSet("", flag.ContinueOnError)
 
 	cfgFile string
@@ -106,6 +114,14 @@ func initRootEnv(cmd *cobra.Command) (*env.Env, error) {
 }
 
 func runRoot(cmd *cobra.Command, _ []string) error {
+	return runNetwork(cmd, fixtures.GetFixture)
+}
+
+func runDevnet(cmd *cobra.Command, _ []string) error {
+	return runNetwork(cmd, fixtures.NewDevnetFixture)
+}
+
+func runNetwork(cmd *cobra.Command, getFixture func() (*oasis.NetworkFixture, error)) error {
 	cmd.SilenceUsage = true
 
 	// Initialize the base dir, logging, etc.
@@ -124,7 +140,7 @@ func runRoot(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("root: failed to setup child environment: %w", err)
 	}
 
-	fixture, err := fixtures.GetFixture()
+	fixture, err := getFixture()
 	if err != nil {
 		return err
 	}
@@ -204,6 +220,9 @@ func init() {
 	dumpFixtureCmd.Flags().AddFlagSet(fixtures.DefaultFixtureFlags)
 	rootCmd.AddCommand(dumpFixtureCmd)
 
+	devnetCmd.Flags().AddFlagSet(fixtures.DevnetFixtureFlags)
+	rootCmd.AddCommand(devnetCmd)
+
 	cobra.OnInitialize(func() {
 		if cfgFile != "" {
 			viper.SetConfigFile(cfgFile)
@@ -213,5 +232,6 @@ func init() {
 		}
 
 		viper.Set(cmdFlags.CfgDebugDontBlameOasis, true)
+		viper.Set(cmdFlags.CfgDebugCapabilities, debug.AllCapabilities)
 	})
 }
@@ -0,0 +1,212 @@
+package fixtures
+
+import (
+	"fmt"
+	"time"
+
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	consensusGenesis "github.com/oasisprotocol/oasis-core/go/consensus/genesis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
+	runtimeConfig "github.com/oasisprotocol/oasis-core/go/runtime/config"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+const (
+	cfgDevnetEpochInterval      = "fixture.devnet.epoch_interval"
+	cfgDevnetKeymanagerBinary   = "fixture.devnet.keymanager.binary"
+	cfgDevnetNodeBinary         = "fixture.devnet.node.binary"
+	cfgDevnetRuntimeID          = "fixture.devnet.runtime.id"
+	cfgDevnetRuntimeBinary      = "fixture.devnet.runtime.binary"
+	cfgDevnetRuntimeVersion     = "fixture.devnet.runtime.version"
+	cfgDevnetRuntimeProvisioner = "fixture.devnet.runtime.provisioner"
+	cfgDevnetRuntimeLoader      = "fixture.devnet.runtime.loader"
+	cfgDevnetTEEHardware        = "fixture.devnet.tee_hardware"
+)
+
+// DevnetFixtureFlags are command line flags for the fixture.devnet.* flags.
+var DevnetFixtureFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+// NewDevnetFixture returns a fixture of a minimal deterministic local network intended for
+// runtime development.
+//
+// The network consists of a single validator, a single compute node, a client node and an
+// optional key manager, all using deterministic identities and funded entities. The sample
+// runtime is registered in genesis and epochs are short so that runtime changes take effect
+// quickly.
+func NewDevnetFixture() (*oasis.NetworkFixture, error) {
+	var tee node.TEEHardware
+	if err := tee.FromString(viper.GetString(cfgDevnetTEEHardware)); err != nil {
+		return nil, err
+	}
+	var mrSigner *sgx.MrSigner
+	if tee == node.TEEHardwareIntelSGX {
+		mrSigner = &sgx.FortanixDummyMrSigner
+	}
+
+	epochInterval := viper.GetInt64(cfgDevnetEpochInterval)
+	if epochInterval <= 0 {
+		return nil, fmt.Errorf("invalid epoch interval: %d", epochInterval)
+	}
+
+	var runtimeID common.Namespace
+	if err := runtimeID.UnmarshalHex(viper.GetString(cfgDevnetRuntimeID)); err != nil {
+		return nil, fmt.Errorf("invalid runtime ID: %w", err)
+	}
+	runtimeVersion, err := version.FromString(viper.GetString(cfgDevnetRuntimeVersion))
+	if err != nil {
+		return nil, fmt.Errorf("invalid runtime version: %w", err)
+	}
+
+	var runtimeProvisioner runtimeConfig.RuntimeProvisioner
+	if err = runtimeProvisioner.UnmarshalText([]byte(viper.GetString(cfgDevnetRuntimeProvisioner))); err != nil {
+		return nil, err
+	}
+
+	fixture := &oasis.NetworkFixture{
+		TEE: oasis.TEEFixture{
+			Hardware: tee,
+			MrSigner: mrSigner,
+		},
+		Network: oasis.NetworkCfg{
+			NodeBinary:             viper.GetString(cfgDevnetNodeBinary),
+			RuntimeSGXLoaderBinary: viper.GetString(cfgDevnetRuntimeLoader),
+			Consensus: consensusGenesis.Genesis{
+				Parameters: consensusGenesis.Parameters{
+					TimeoutCommit: 1 * time.Second,
+				},
+			},
+			Beacon: beacon.ConsensusParameters{
+				Backend: beacon.BackendInsecure,
+				InsecureParameters: &beacon.InsecureParameters{
+					Interval: epochInterval,
+				},
+			},
+			IAS: oasis.IASCfg{
+				Mock: true,
+			},
+			DeterministicIdentities: true,
+			FundEntities:            true,
+			StakingGenesis: &staking.Genesis{
+				Parameters: staking.ConsensusParameters{
+					MaxAllowances: 16,
+				},
+			},
+		},
+		Entities: []oasis.EntityCfg{
+			{IsDebugTestEntity: true},
+			{},
+		},
+		Validators: []oasis.ValidatorFixture{
+			{Entity: 1},
+		},
+		Seeds: []oasis.SeedFixture{{}},
+	}
+
+	keymanagerIdx := -1
+	if binary := viper.GetString(cfgDevnetKeymanagerBinary); binary != "" {
+		fixture.Runtimes = append(fixture.Runtimes, oasis.RuntimeFixture{
+			ID:         keymanagerID,
+			Kind:       registry.KindKeyManager,
+			Entity:     0,
+			Keymanager: -1,
+			AdmissionPolicy: registry.RuntimeAdmissionPolicy{
+				AnyNode: &registry.AnyNodeRuntimeAdmissionPolicy{},
+			},
+			GovernanceModel: registry.GovernanceEntity,
+			Deployments: []oasis.DeploymentCfg{
+				{
+					Components: []oasis.ComponentCfg{
+						{
+							Kind: component.RONL,
+							Binaries: map[node.TEEHardware]string{
+								tee: binary,
+							},
+						},
+					},
+				},
+			},
+		})
+		fixture.KeymanagerPolicies = []oasis.KeymanagerPolicyFixture{
+			{Runtime: 0, Serial: 1},
+		}
+		fixture.Keymanagers = []oasis.KeymanagerFixture{
+			{
+				Runtime:            0,
+				Entity:             1,
+				Policy:             0,
+				SkipPolicy:         tee != node.TEEHardwareIntelSGX,
+				RuntimeProvisioner: runtimeProvisioner,
+			},
+		}
+		keymanagerIdx = 0
+	}
+
+	// Sample compute runtime, executed by a single compute node.
+	fixture.Runtimes = append(fixture.Runtimes, oasis.RuntimeFixture{
+		ID:         runtimeID,
+		Kind:       registry.KindCompute,
+		Entity:     0,
+		Keymanager: keymanagerIdx,
+		Executor: registry.ExecutorParameters{
+			GroupSize:    1,
+			RoundTimeout: 20,
+			MaxMessages:  128,
+		},
+		TxnScheduler: registry.TxnSchedulerParameters{
+			MaxBatchSize:      1000,
+			MaxBatchSizeBytes: 16 * 1024 * 1024, // 16 MiB
+			BatchFlushTimeout: time.Second,
+			ProposerTimeout:   2 * time.Second,
+		},
+		AdmissionPolicy: registry.RuntimeAdmissionPolicy{
+			AnyNode: &registry.AnyNodeRuntimeAdmissionPolicy{},
+		},
+		GovernanceModel: registry.GovernanceEntity,
+		Deployments: []oasis.DeploymentCfg{
+			{
+				Version: runtimeVersion,
+				Components: []oasis.ComponentCfg{
+					{
+						Kind: component.RONL,
+						Binaries: map[node.TEEHardware]string{
+							tee: viper.GetString(cfgDevnetRuntimeBinary),
+						},
+					},
+				},
+			},
+		},
+	})
+	rtIndex := len(fixture.Runtimes) - 1
+
+	fixture.ComputeWorkers = []oasis.ComputeWorkerFixture{
+		{Entity: 1, Runtimes: []int{rtIndex}, RuntimeProvisioner: runtimeProvisioner},
+	}
+	fixture.Clients = []oasis.ClientFixture{
+		{Runtimes: []int{rtIndex}, RuntimeProvisioner: runtimeProvisioner},
+	}
+
+	return fixture, nil
+}
+
+func init() {
+	DevnetFixtureFlags.Int64(cfgDevnetEpochInterval, 10, "epoch interval (in blocks)")
+	DevnetFixtureFlags.String(cfgDevnetKeymanagerBinary, "", "path to the keymanager runtime (no key manager if empty)")
+	DevnetFixtureFlags.String(cfgDevnetNodeBinary, "oasis-node", "path to the oasis-node binary")
+	DevnetFixtureFlags.String(cfgDevnetRuntimeID, "8000000000000000000000000000000000000000000000000000000000000000", "runtime ID")
+	DevnetFixtureFlags.String(cfgDevnetRuntimeBinary, "simple-keyvalue", "path to the runtime binary")
+	DevnetFixtureFlags.String(cfgDevnetRuntimeVersion, "0.1.0", "runtime version to register")
+	DevnetFixtureFlags.String(cfgDevnetRuntimeProvisioner, "sandboxed", "the runtime provisioner: mock, unconfined, or sandboxed")
+	DevnetFixtureFlags.String(cfgDevnetRuntimeLoader, "oasis-core-runtime-loader", "path to the runtime loader")
+	DevnetFixtureFlags.String(cfgDevnetTEEHardware, "", "TEE hardware to use")
+
+	_ = viper.BindPFlags(DevnetFixtureFlags)
+}
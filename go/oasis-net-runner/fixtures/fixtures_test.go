@@ -4,6 +4,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
@@ -20,6 +21,28 @@ func TestDefaultFixture(t *testing.T) {
 	require.NotNil(t, data)
 }
 
+func TestDevnetFixture(t *testing.T) {
+	require := require.New(t)
+
+	f, err := NewDevnetFixture()
+	require.NoError(err)
+	require.True(f.Network.DeterministicIdentities)
+	require.Len(f.Validators, 1)
+	require.Len(f.ComputeWorkers, 1)
+	require.Empty(f.Keymanagers)
+	require.Len(f.Runtimes, 1)
+	require.EqualValues(1, f.Runtimes[0].Executor.GroupSize)
+
+	viper.Set(cfgDevnetKeymanagerBinary, "simple-keymanager")
+	defer viper.Set(cfgDevnetKeymanagerBinary, "")
+
+	f, err = NewDevnetFixture()
+	require.NoError(err)
+	require.Len(f.Keymanagers, 1)
+	require.Len(f.Runtimes, 2)
+	require.Equal(0, f.Runtimes[1].Keymanager)
+}
+
 func TestCustomFixture(t *testing.T) {
 	f, _ := newDefaultFixture()
 	f.Network.NodeBinary = "myNodeBinary"
@@ -15,6 +15,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/entity"
+	"github.com/oasisprotocol/oasis-core/go/common/httpclient"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/sgx/ias"
 	"github.com/oasisprotocol/oasis-core/go/common/sgx/pcs"
@@ -108,6 +109,7 @@ func Init() error {
 		initDataDir,
 		initLogging,
 		initPublicKeyBlacklist,
+		initHTTPProxy,
 		initDebugEnclaves,
 		initDebugTCBLaxVerify,
 		initDebugSkipQuoteVerify,
@@ -190,15 +192,29 @@ func normalizePath(f string) string {
 }
 
 func initPublicKeyBlacklist() error {
-	allowTestKeys := flags.DebugDontBlameOasis() && viper.GetBool(CfgDebugAllowTestKeys)
+	allowTestKeys := flags.DebugTestKeys() && viper.GetBool(CfgDebugAllowTestKeys)
 	signature.BuildPublicKeyBlacklist(allowTestKeys)
 	ias.BuildMrSignerBlacklist(allowTestKeys)
 	pcs.BuildMrSignerBlacklist(allowTestKeys)
 	return nil
 }
 
+func initHTTPProxy() error {
+	cfg, err := config.GlobalConfig.Common.HTTPProxy.ProxyConfig()
+	if err != nil {
+		return fmt.Errorf("invalid HTTP proxy configuration: %w", err)
+	}
+	if cfg != nil {
+		rootLog.Info("using HTTP proxy for outbound traffic",
+			"proxy", cfg.URL.Redacted(),
+		)
+	}
+	httpclient.SetProxy(cfg)
+	return nil
+}
+
 func initDebugEnclaves() error {
-	if flags.DebugDontBlameOasis() && viper.GetBool(CfgDebugAllowDebugEnclaves) {
+	if flags.DebugMockAttestation() && viper.GetBool(CfgDebugAllowDebugEnclaves) {
 		rootLog.Warn("`debug.allow_debug_enclaves` set, enclaves in debug mode will be allowed")
 		ias.SetAllowDebugEnclaves()
 		pcs.SetAllowDebugEnclaves()
@@ -207,7 +223,7 @@ func initDebugEnclaves() error {
 }
 
 func initDebugTCBLaxVerify() error {
-	if flags.DebugDontBlameOasis() && viper.GetBool(CfgDebugTCBLaxVerify) {
+	if flags.DebugMockAttestation() && viper.GetBool(CfgDebugTCBLaxVerify) {
 		rootLog.Warn("`debug.tcb_lax_verify` set, TCB lax verification will be done")
 		pcs.SetUnsafeLaxVerify()
 	}
@@ -215,7 +231,7 @@ func initDebugTCBLaxVerify() error {
 }
 
 func initDebugSkipQuoteVerify() error {
-	if flags.DebugDontBlameOasis() && viper.GetBool(CfgDebugSkipQuoteVerify) {
+	if flags.DebugMockAttestation() && viper.GetBool(CfgDebugSkipQuoteVerify) {
 		rootLog.Warn("`debug.skip_quote_verify` set, PCS quotes will NOT be verified")
 		pcs.SetSkipVerify()
 	}
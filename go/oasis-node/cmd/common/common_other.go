@@ -23,7 +23,7 @@ func initRlimit() error {
 	}
 
 	desiredLimit := config.GlobalConfig.Common.Debug.Rlimit
-	if flags.DebugDontBlameOasis() && desiredLimit > 0 && desiredLimit != rlim.Cur {
+	if flags.DebugUnsafeProcess() && desiredLimit > 0 && desiredLimit != rlim.Cur {
 		rlim.Cur = desiredLimit
 		if err := syscall.Setrlimit(syscall.RLIMIT_NOFILE, &rlim); err != nil {
 			return fmt.Errorf("failed setting RLIMIT_NOFILE: %w", err)
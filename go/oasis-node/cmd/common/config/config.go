@@ -1,6 +1,16 @@
 // Package config implements global configuration options.
 package config
 
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/debug"
+	"github.com/oasisprotocol/oasis-core/go/common/httpclient"
+)
+
 // Config is the common configuration structure.
 type Config struct {
 	// Node's data directory.
@@ -9,6 +19,10 @@ type Config struct {
 	InternalSocketPath string `yaml:"internal_socket_path,omitempty"`
 	// Logging configuration options.
 	Log LogConfig `yaml:"log,omitempty"`
+	// gRPC configuration options.
+	GRPC GRPCConfig `yaml:"grpc,omitempty"`
+	// Outbound HTTP proxy configuration options.
+	HTTPProxy HTTPProxyConfig `yaml:"http_proxy,omitempty"`
 	// Debug configuration options (do not use).
 	Debug DebugConfig `yaml:"debug,omitempty"`
 }
@@ -23,16 +37,146 @@ type LogConfig struct {
 	Level map[string]string `yaml:"level,omitempty"`
 }
 
+// GRPCConfig is the common gRPC configuration structure.
+type GRPCConfig struct {
+	// Enable the standard gRPC health and server reflection services on the node's gRPC
+	// endpoint, so that load balancers can health-check the node and generic tooling can
+	// introspect the exposed services.
+	Introspection bool `yaml:"introspection,omitempty"`
+	// Additional gRPC listeners, each exposing a configured subset of the node's services.
+	//
+	// The internal unix socket always exposes all services.
+	Listeners []GRPCListenerConfig `yaml:"listeners,omitempty"`
+}
+
+// GRPCListenerConfig is the configuration of an additional gRPC listener.
+type GRPCListenerConfig struct {
+	// Name of the listener.
+	Name string `yaml:"name"`
+	// Address of the listener, either a TCP address (host:port) or a unix socket path
+	// prefixed by unix: (e.g. unix:/node/data/public.sock).
+	Address string `yaml:"address"`
+	// Names of the gRPC services exposed on the listener (e.g. oasis-core.Consensus).
+	Services []string `yaml:"services"`
+	// Serve TLS on the listener using the node's TLS identity.
+	TLS bool `yaml:"tls,omitempty"`
+	// TLS public keys of clients that are allowed to connect to the listener. If empty, any
+	// client is allowed to connect. Requires TLS to be enabled.
+	AuthorizedPubkeys []string `yaml:"authorized_pubkeys,omitempty"`
+}
+
+// Network returns the network and the address of the listener.
+func (c *GRPCListenerConfig) Network() (string, string) {
+	if path, ok := strings.CutPrefix(c.Address, "unix:"); ok {
+		return "unix", path
+	}
+	return "tcp", c.Address
+}
+
+// Validate validates the listener configuration settings.
+func (c *GRPCListenerConfig) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("name must be set")
+	}
+	if c.Address == "" {
+		return fmt.Errorf("listener '%s': address must be set", c.Name)
+	}
+	if len(c.Services) == 0 {
+		return fmt.Errorf("listener '%s': at least one service must be exposed", c.Name)
+	}
+	if len(c.AuthorizedPubkeys) > 0 && !c.TLS {
+		return fmt.Errorf("listener '%s': authorized_pubkeys require tls to be enabled", c.Name)
+	}
+	for _, pubkey := range c.AuthorizedPubkeys {
+		var pk signature.PublicKey
+		if err := pk.UnmarshalText([]byte(pubkey)); err != nil {
+			return fmt.Errorf("listener '%s': malformed authorized public key '%s': %w", c.Name, pubkey, err)
+		}
+	}
+	return nil
+}
+
+// HTTPProxyConfig is the outbound HTTP proxy configuration structure.
+type HTTPProxyConfig struct {
+	// URL of the proxy used for all outbound HTTP(S) traffic of the node (e.g., Intel PCS and
+	// IAS requests). Supported schemes are http, https, socks5 and socks5h.
+	//
+	// If not set, the standard HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are
+	// honored instead.
+	URL string `yaml:"url,omitempty"`
+	// Hosts that should be accessed directly, bypassing the proxy. Each entry is either a host
+	// name, a domain suffix starting with a dot (e.g. .example.com), an IP address or a CIDR
+	// range.
+	NoProxy []string `yaml:"no_proxy,omitempty"`
+}
+
+// Validate validates the configuration settings.
+func (c *HTTPProxyConfig) Validate() error {
+	if c.URL == "" {
+		if len(c.NoProxy) > 0 {
+			return fmt.Errorf("no_proxy requires url to be set")
+		}
+		return nil
+	}
+	if _, err := c.ProxyConfig(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ProxyConfig returns the parsed proxy configuration or nil in case no proxy is configured.
+func (c *HTTPProxyConfig) ProxyConfig() (*httpclient.ProxyConfig, error) {
+	if c.URL == "" {
+		return nil, nil
+	}
+	return httpclient.NewProxyConfig(c.URL, c.NoProxy)
+}
+
 // DebugConfig is the common debug configuration structure.
 type DebugConfig struct {
 	// Allow running the node as root.
 	AllowRoot bool `yaml:"allow_root,omitempty"`
 	// Set RLIMIT_NOFILE to this value on launch (0 means don't set).
 	Rlimit uint64 `yaml:"rlimit,omitempty"`
+	// Unsafe debug capabilities to enable. Each capability also requires the "don't blame oasis"
+	// flag to be set.
+	Capabilities []string `yaml:"capabilities,omitempty"`
+}
+
+// Validate validates the configuration settings.
+func (c *DebugConfig) Validate() error {
+	enabled := make(map[string]bool)
+	for _, capability := range c.Capabilities {
+		if !slices.Contains(debug.AllCapabilities, capability) {
+			return fmt.Errorf("capabilities: unknown debug capability '%s'", capability)
+		}
+		if enabled[capability] {
+			return fmt.Errorf("capabilities: duplicate debug capability '%s'", capability)
+		}
+		enabled[capability] = true
+	}
+	return nil
 }
 
 // Validate validates the configuration settings.
 func (c *Config) Validate() error {
+	names := make(map[string]bool)
+	for i := range c.GRPC.Listeners {
+		l := &c.GRPC.Listeners[i]
+		if err := l.Validate(); err != nil {
+			return fmt.Errorf("grpc.listeners: %w", err)
+		}
+		if names[l.Name] {
+			return fmt.Errorf("grpc.listeners: duplicate listener name '%s'", l.Name)
+		}
+		names[l.Name] = true
+	}
+	if err := c.HTTPProxy.Validate(); err != nil {
+		return fmt.Errorf("http_proxy: %w", err)
+	}
+	if err := c.Debug.Validate(); err != nil {
+		return fmt.Errorf("debug: %w", err)
+	}
 	return nil
 }
 
@@ -50,9 +194,18 @@ func DefaultConfig() Config {
 				"mkvs/db":           "info",  // Debug logs are too verbose and not very useful.
 			},
 		},
+		GRPC: GRPCConfig{
+			Introspection: false,
+			Listeners:     []GRPCListenerConfig{},
+		},
+		HTTPProxy: HTTPProxyConfig{
+			URL:     "",
+			NoProxy: []string{},
+		},
 		Debug: DebugConfig{
-			AllowRoot: false,
-			Rlimit:    0,
+			AllowRoot:    false,
+			Rlimit:       0,
+			Capabilities: []string{},
 		},
 	}
 }
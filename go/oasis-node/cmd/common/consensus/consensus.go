@@ -12,6 +12,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	signerFile "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/file"
+	signerLedger "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/ledger"
 	signerPlugin "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/plugin"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
@@ -20,6 +21,7 @@ import (
 	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
 	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 	cmdSigner "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/signer"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 )
 
 const (
@@ -104,6 +106,28 @@ func SignAndSaveTx(ctx context.Context, tx *transaction.Transaction, signer sign
 		return
 	}
 
+	sigTx := SignTx(ctx, tx, signer)
+
+	prettySigTx, err := cmdCommon.PrettyJSONMarshal(sigTx)
+	if err != nil {
+		logger.Error("failed to get pretty JSON of signed transaction",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	if err = os.WriteFile(viper.GetString(CfgTxFile), prettySigTx, 0o600); err != nil {
+		logger.Error("failed to save signed transaction",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+}
+
+// SignTx signs the given transaction after asking the user for confirmation (if needed by the
+// configured signer backend).
+//
+// If no signer is given, the entity signer is loaded based on the signer flags.
+func SignTx(ctx context.Context, tx *transaction.Transaction, signer signature.Signer) *transaction.SignedTransaction {
 	if signer == nil {
 		var err error
 		_, signer, err = cmdCommon.LoadEntitySigner()
@@ -130,6 +154,12 @@ func SignAndSaveTx(ctx context.Context, tx *transaction.Transaction, signer sign
 		if cmdCommon.Isatty(os.Stdin.Fd()) {
 			fmt.Println("\nYou may need to review the transaction on your device if you use a hardware-based signer plugin...")
 		}
+	case signerLedger.SignerName:
+		path := signerLedger.DerivationPath(viper.GetUint32(cmdSigner.CfgSignerLedgerIndex))
+		fmt.Printf("\nPlease review and confirm the transaction on your Ledger device:\n")
+		fmt.Printf("  Derivation path: %s\n", signerLedger.FormatDerivationPath(path))
+		fmt.Printf("  Signer address:  %s\n", staking.NewAddress(signer.Public()))
+		fmt.Printf("  Signer key:      %s\n", signer.Public())
 	}
 
 	sigTx, err := transaction.Sign(signer, tx)
@@ -140,19 +170,7 @@ func SignAndSaveTx(ctx context.Context, tx *transaction.Transaction, signer sign
 		os.Exit(1)
 	}
 
-	prettySigTx, err := cmdCommon.PrettyJSONMarshal(sigTx)
-	if err != nil {
-		logger.Error("failed to get pretty JSON of signed transaction",
-			"err", err,
-		)
-		os.Exit(1)
-	}
-	if err = os.WriteFile(viper.GetString(CfgTxFile), prettySigTx, 0o600); err != nil {
-		logger.Error("failed to save signed transaction",
-			"err", err,
-		)
-		os.Exit(1)
-	}
+	return sigTx
 }
 
 func init() {
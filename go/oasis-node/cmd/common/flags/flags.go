@@ -3,9 +3,12 @@
 package flags
 
 import (
+	"slices"
+
 	flag "github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
+	"github.com/oasisprotocol/oasis-core/go/common/debug"
 	"github.com/oasisprotocol/oasis-core/go/config"
 )
 
@@ -13,6 +16,9 @@ const (
 	// CfgDebugDontBlameOasis is the flag used to opt-in to unsafe/debug/test
 	// behavior.
 	CfgDebugDontBlameOasis = "debug.dont_blame_oasis"
+	// CfgDebugCapabilities is the flag used to enable unsafe debug capabilities in addition to
+	// the ones enabled in the configuration.
+	CfgDebugCapabilities = "debug.capabilities"
 	// CfgDebugTestEntity is the command line flag to enable the debug test
 	// entity.
 	CfgDebugTestEntity = "debug.test_entity"
@@ -64,12 +70,90 @@ func Force() bool {
 
 // DebugTestEntity returns true iff the test entity enable flag is set.
 func DebugTestEntity() bool {
-	return DebugDontBlameOasis() && viper.GetBool(CfgDebugTestEntity)
+	return DebugTestKeys() && viper.GetBool(CfgDebugTestEntity)
 }
 
 // DebugAllowRoot returns true iff the root account enable flag is set.
 func DebugAllowRoot() bool {
-	return DebugDontBlameOasis() && config.GlobalConfig.Common.Debug.AllowRoot
+	return DebugUnsafeProcess() && config.GlobalConfig.Common.Debug.AllowRoot
+}
+
+// DebugDummySigstruct returns true iff the dummy SIGSTRUCT debug capability is enabled.
+func DebugDummySigstruct() bool {
+	return debug.CapabilityEnabled(debug.CapabilityDummySigstruct)
+}
+
+// DebugMockAttestation returns true iff the mock attestation debug capability is enabled.
+func DebugMockAttestation() bool {
+	return debug.CapabilityEnabled(debug.CapabilityMockAttestation)
+}
+
+// DebugInsecureBeacon returns true iff the insecure beacon debug capability is enabled.
+func DebugInsecureBeacon() bool {
+	return debug.CapabilityEnabled(debug.CapabilityInsecureBeacon)
+}
+
+// DebugTestKeys returns true iff the test keys debug capability is enabled.
+func DebugTestKeys() bool {
+	return debug.CapabilityEnabled(debug.CapabilityTestKeys)
+}
+
+// DebugUnsafeGenesis returns true iff the unsafe genesis debug capability is enabled.
+func DebugUnsafeGenesis() bool {
+	return debug.CapabilityEnabled(debug.CapabilityUnsafeGenesis)
+}
+
+// DebugUnsafeConsensus returns true iff the unsafe consensus debug capability is enabled.
+func DebugUnsafeConsensus() bool {
+	return debug.CapabilityEnabled(debug.CapabilityUnsafeConsensus)
+}
+
+// DebugUnsafeP2P returns true iff the unsafe P2P debug capability is enabled.
+func DebugUnsafeP2P() bool {
+	return debug.CapabilityEnabled(debug.CapabilityUnsafeP2P)
+}
+
+// DebugUnsafeRuntimeHost returns true iff the unsafe runtime host debug capability is enabled.
+func DebugUnsafeRuntimeHost() bool {
+	return debug.CapabilityEnabled(debug.CapabilityUnsafeRuntimeHost)
+}
+
+// DebugProtocolCapture returns true iff the protocol capture debug capability is enabled.
+func DebugProtocolCapture() bool {
+	return debug.CapabilityEnabled(debug.CapabilityProtocolCapture)
+}
+
+// DebugCrashInjection returns true iff the crash injection debug capability is enabled.
+func DebugCrashInjection() bool {
+	return debug.CapabilityEnabled(debug.CapabilityCrashInjection)
+}
+
+// DebugServices returns true iff the debug services debug capability is enabled.
+func DebugServices() bool {
+	return debug.CapabilityEnabled(debug.CapabilityDebugServices)
+}
+
+// DebugUnsafeProcess returns true iff the unsafe process debug capability is enabled.
+func DebugUnsafeProcess() bool {
+	return debug.CapabilityEnabled(debug.CapabilityUnsafeProcess)
+}
+
+// DebugCapabilities returns the sorted list of enabled unsafe debug capabilities, either
+// configured or passed via the command line.
+func DebugCapabilities() []string {
+	return debug.Capabilities()
+}
+
+// debugSettings returns the unsafe debug settings based on the configuration and the command line
+// flags.
+func debugSettings() *debug.Settings {
+	capabilities := slices.Clone(config.GlobalConfig.Common.Debug.Capabilities)
+	capabilities = append(capabilities, viper.GetStringSlice(CfgDebugCapabilities)...)
+
+	return &debug.Settings{
+		DontBlameOasis: viper.GetBool(CfgDebugDontBlameOasis),
+		Capabilities:   capabilities,
+	}
 }
 
 // GenesisFile returns the set genesis file.
@@ -79,7 +163,7 @@ func GenesisFile() string {
 
 // DebugDontBlameOasis returns true iff the "don't blame oasis" flag is set.
 func DebugDontBlameOasis() bool {
-	return viper.GetBool(CfgDebugDontBlameOasis)
+	return debug.DontBlameOasis()
 }
 
 // DryRun returns true iff the dry-run flag is set.
@@ -93,6 +177,8 @@ func AssumeYes() bool {
 }
 
 func init() {
+	debug.SetSettingsProvider(debugSettings)
+
 	VerboseFlags.BoolP(cfgVerbose, "v", false, "verbose output")
 
 	ForceFlags.Bool(cfgForce, false, "force")
@@ -104,6 +190,8 @@ func init() {
 
 	DebugDontBlameOasisFlag.Bool(CfgDebugDontBlameOasis, false, "enable debug/unsafe/insecure options")
 	_ = DebugDontBlameOasisFlag.MarkHidden(CfgDebugDontBlameOasis)
+	DebugDontBlameOasisFlag.StringSlice(CfgDebugCapabilities, []string{}, "unsafe debug capabilities to enable (UNSAFE)")
+	_ = DebugDontBlameOasisFlag.MarkHidden(CfgDebugCapabilities)
 
 	DryRunFlag.BoolP(CfgDryRun, "n", false, "don't actually do anything, just show what will be done")
 
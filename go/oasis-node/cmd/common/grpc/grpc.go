@@ -13,9 +13,11 @@ import (
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/config"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
 )
 
@@ -61,16 +63,58 @@ func NewServerTCP(cert *tls.Certificate, installWrapper bool) (*cmnGrpc.Server,
 // NewServerLocal constructs a new gRPC server service listening on
 // a specific AF_LOCAL socket using default arguments.
 //
+// Any additional listeners configured in the node configuration are also
+// served, exposing their configured subset of services. The given identity
+// is used for listeners that serve TLS.
+//
 // This internally takes a snapshot of the current global tracer, so
 // make sure you initialize the global tracer before calling this.
-func NewServerLocal(installWrapper bool) (*cmnGrpc.Server, error) {
-	config := &cmnGrpc.ServerConfig{
-		Name:           "internal",
-		Path:           common.InternalSocketPath(),
-		InstallWrapper: installWrapper,
+func NewServerLocal(id *identity.Identity, installWrapper bool) (*cmnGrpc.Server, error) {
+	listeners, err := configuredListeners(id)
+	if err != nil {
+		return nil, err
 	}
 
-	return cmnGrpc.NewServer(config)
+	cfg := &cmnGrpc.ServerConfig{
+		Name:                "internal",
+		Path:                common.InternalSocketPath(),
+		InstallWrapper:      installWrapper,
+		EnableIntrospection: config.GlobalConfig.Common.GRPC.Introspection,
+		Listeners:           listeners,
+	}
+
+	return cmnGrpc.NewServer(cfg)
+}
+
+func configuredListeners(id *identity.Identity) ([]cmnGrpc.ListenerConfig, error) {
+	var listeners []cmnGrpc.ListenerConfig
+	for _, l := range config.GlobalConfig.Common.GRPC.Listeners {
+		network, address := l.Network()
+		lc := cmnGrpc.ListenerConfig{
+			Name:     l.Name,
+			Network:  network,
+			Address:  address,
+			Services: l.Services,
+		}
+		if l.TLS {
+			if id == nil {
+				return nil, fmt.Errorf("grpc: listener '%s' requires the node identity for TLS", l.Name)
+			}
+			lc.Identity = id
+		}
+		if len(l.AuthorizedPubkeys) > 0 {
+			lc.AuthorizedPubkeys = make(map[signature.PublicKey]bool)
+			for _, pubkey := range l.AuthorizedPubkeys {
+				var pk signature.PublicKey
+				if err := pk.UnmarshalText([]byte(pubkey)); err != nil {
+					return nil, fmt.Errorf("grpc: listener '%s': malformed authorized public key: %w", l.Name, err)
+				}
+				lc.AuthorizedPubkeys[pk] = true
+			}
+		}
+		listeners = append(listeners, lc)
+	}
+	return listeners, nil
 }
 
 func NewClient(cmd *cobra.Command) (*grpc.ClientConn, error) {
@@ -8,7 +8,7 @@ import (
 
 // Config is the metrics configuration structure.
 type Config struct {
-	// Metrics mode (none, pull, push).
+	// Metrics mode (none, pull, push, otlp).
 	Mode string `yaml:"mode"`
 	// Metrics pull address.
 	Address string `yaml:"address"`
@@ -19,6 +19,25 @@ type Config struct {
 	Labels map[string]string `yaml:"labels,omitempty"`
 	// Metrics push interval (debug-only).
 	Interval time.Duration `yaml:"interval,omitempty"`
+
+	// OTLP configures exporting metrics to an OpenTelemetry collector (otlp mode).
+	OTLP OTLPConfig `yaml:"otlp,omitempty"`
+}
+
+// OTLPConfig is the OpenTelemetry metrics export configuration structure.
+type OTLPConfig struct {
+	// Endpoint is the OTLP/HTTP metrics endpoint URL.
+	Endpoint string `yaml:"endpoint"`
+	// Headers are additional HTTP headers sent with each export (e.g. for authentication).
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// ResourceAttributes are additional resource attributes describing the node.
+	ResourceAttributes map[string]string `yaml:"resource_attributes,omitempty"`
+	// Interval is the export interval.
+	Interval time.Duration `yaml:"interval"`
+	// Subsystems enables or disables the export of metrics per subsystem, identified by a metric
+	// name prefix (e.g. oasis_worker). The longest matching prefix takes precedence and metrics
+	// of subsystems that are not listed are exported.
+	Subsystems map[string]bool `yaml:"subsystems,omitempty"`
 }
 
 // Validate validates the configuration settings.
@@ -42,6 +61,13 @@ func (c *Config) Validate() error {
 		if c.Interval == 0 {
 			return fmt.Errorf("missing interval in push mode")
 		}
+	case "otlp":
+		if len(c.OTLP.Endpoint) == 0 {
+			return fmt.Errorf("missing otlp.endpoint in otlp mode")
+		}
+		if c.OTLP.Interval <= 0 {
+			return fmt.Errorf("invalid otlp.interval in otlp mode")
+		}
 	default:
 		return fmt.Errorf("unknown metrics mode: %s", c.Mode)
 	}
@@ -57,5 +83,12 @@ func DefaultConfig() Config {
 		JobName:  "",
 		Labels:   map[string]string{},
 		Interval: 5 * time.Second,
+		OTLP: OTLPConfig{
+			Endpoint:           "http://127.0.0.1:4318/v1/metrics",
+			Headers:            map[string]string{},
+			ResourceAttributes: map[string]string{},
+			Interval:           30 * time.Second,
+			Subsystems:         map[string]bool{},
+		},
 	}
 }
@@ -1,4 +1,4 @@
-// Package metrics implements a prometheus metrics service.
+// Package metrics implements a metrics service supporting Prometheus and OpenTelemetry backends.
 package metrics
 
 import (
@@ -37,6 +37,7 @@ const (
 	MetricsModeNone = "none"
 	MetricsModePull = "pull"
 	MetricsModePush = "push"
+	MetricsModeOTLP = "otlp"
 )
 
 var (
@@ -257,8 +258,10 @@ func New(ctx context.Context) (service.BackgroundService, error) {
 		return newStubService()
 	case MetricsModePull:
 		return newPullService(ctx)
+	case MetricsModeOTLP:
+		return newOTLPService()
 	default:
-		if mode == MetricsModePush && flags.DebugDontBlameOasis() {
+		if mode == MetricsModePush && flags.DebugServices() {
 			return newPushService()
 		}
 		return nil, fmt.Errorf("metrics: unsupported mode: '%v'", mode)
@@ -0,0 +1,426 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/oasisprotocol/oasis-core/go/common/httpclient"
+	"github.com/oasisprotocol/oasis-core/go/common/service"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	"github.com/oasisprotocol/oasis-core/go/config"
+	metricsConfig "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/metrics/config"
+)
+
+const (
+	// otlpServiceName is the value of the service.name resource attribute.
+	otlpServiceName = "oasis-node"
+	// otlpScopeName is the name of the instrumentation scope of all exported metrics.
+	otlpScopeName = "github.com/oasisprotocol/oasis-core/go"
+	// otlpExportTimeout is the timeout for a single export request.
+	otlpExportTimeout = 10 * time.Second
+
+	// otlpTemporalityCumulative is the OTLP cumulative aggregation temporality.
+	otlpTemporalityCumulative = 2
+)
+
+// The following types are a minimal subset of the OTLP metrics data model in its protobuf JSON
+// encoding, as accepted by OTLP/HTTP receivers.
+
+type otlpExportRequest struct {
+	ResourceMetrics []otlpResourceMetrics `json:"resourceMetrics"`
+}
+
+type otlpResourceMetrics struct {
+	Resource     otlpResource       `json:"resource"`
+	ScopeMetrics []otlpScopeMetrics `json:"scopeMetrics"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpScopeMetrics struct {
+	Scope   otlpScope    `json:"scope"`
+	Metrics []otlpMetric `json:"metrics"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpMetric struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Gauge       *otlpGauge     `json:"gauge,omitempty"`
+	Sum         *otlpSum       `json:"sum,omitempty"`
+	Histogram   *otlpHistogram `json:"histogram,omitempty"`
+	Summary     *otlpSummary   `json:"summary,omitempty"`
+}
+
+type otlpGauge struct {
+	DataPoints []otlpNumberDataPoint `json:"dataPoints"`
+}
+
+type otlpSum struct {
+	DataPoints             []otlpNumberDataPoint `json:"dataPoints"`
+	AggregationTemporality int                   `json:"aggregationTemporality"`
+	IsMonotonic            bool                  `json:"isMonotonic"`
+}
+
+type otlpNumberDataPoint struct {
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano,omitempty"`
+	TimeUnixNano      string         `json:"timeUnixNano"`
+	AsDouble          float64        `json:"asDouble"`
+}
+
+type otlpHistogram struct {
+	DataPoints             []otlpHistogramDataPoint `json:"dataPoints"`
+	AggregationTemporality int                      `json:"aggregationTemporality"`
+}
+
+type otlpHistogramDataPoint struct {
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano,omitempty"`
+	TimeUnixNano      string         `json:"timeUnixNano"`
+	Count             string         `json:"count"`
+	Sum               float64        `json:"sum"`
+	BucketCounts      []string       `json:"bucketCounts"`
+	ExplicitBounds    []float64      `json:"explicitBounds"`
+}
+
+type otlpSummary struct {
+	DataPoints []otlpSummaryDataPoint `json:"dataPoints"`
+}
+
+type otlpSummaryDataPoint struct {
+	Attributes        []otlpKeyValue      `json:"attributes,omitempty"`
+	StartTimeUnixNano string              `json:"startTimeUnixNano,omitempty"`
+	TimeUnixNano      string              `json:"timeUnixNano"`
+	Count             string              `json:"count"`
+	Sum               float64             `json:"sum"`
+	QuantileValues    []otlpQuantileValue `json:"quantileValues"`
+}
+
+type otlpQuantileValue struct {
+	Quantile float64 `json:"quantile"`
+	Value    float64 `json:"value"`
+}
+
+// subsystemEnabled returns true iff metrics with the given name should be exported according to
+// the per-subsystem configuration. The longest matching subsystem prefix takes precedence.
+func subsystemEnabled(subsystems map[string]bool, name string) bool {
+	enabled, matchLen := true, -1
+	for prefix, en := range subsystems {
+		if name != prefix && !strings.HasPrefix(name, prefix+"_") {
+			continue
+		}
+		if len(prefix) > matchLen {
+			enabled, matchLen = en, len(prefix)
+		}
+	}
+	return enabled
+}
+
+func otlpAttributes(attrs map[string]string) []otlpKeyValue {
+	kvs := make([]otlpKeyValue, 0, len(attrs))
+	for k, v := range attrs {
+		kvs = append(kvs, otlpKeyValue{Key: k, Value: otlpAnyValue{StringValue: v}})
+	}
+	sort.Slice(kvs, func(i, j int) bool {
+		return kvs[i].Key < kvs[j].Key
+	})
+	return kvs
+}
+
+func otlpLabels(labels []*dto.LabelPair) []otlpKeyValue {
+	attrs := make(map[string]string, len(labels))
+	for _, l := range labels {
+		attrs[l.GetName()] = l.GetValue()
+	}
+	return otlpAttributes(attrs)
+}
+
+// convertMetricFamilies converts the gathered Prometheus metric families into OTLP metrics.
+//
+// Counters, histograms and summaries are exported with cumulative temporality starting at the
+// given start time.
+func convertMetricFamilies(
+	families []*dto.MetricFamily,
+	subsystems map[string]bool,
+	start, now time.Time,
+) []otlpMetric {
+	startTs := strconv.FormatInt(start.UnixNano(), 10)
+	nowTs := strconv.FormatInt(now.UnixNano(), 10)
+
+	metrics := make([]otlpMetric, 0, len(families))
+	for _, mf := range families {
+		if !subsystemEnabled(subsystems, mf.GetName()) {
+			continue
+		}
+
+		m := otlpMetric{
+			Name:        mf.GetName(),
+			Description: mf.GetHelp(),
+		}
+		switch mf.GetType() {
+		case dto.MetricType_COUNTER:
+			m.Sum = &otlpSum{
+				AggregationTemporality: otlpTemporalityCumulative,
+				IsMonotonic:            true,
+			}
+			for _, pm := range mf.GetMetric() {
+				m.Sum.DataPoints = append(m.Sum.DataPoints, otlpNumberDataPoint{
+					Attributes:        otlpLabels(pm.GetLabel()),
+					StartTimeUnixNano: startTs,
+					TimeUnixNano:      nowTs,
+					AsDouble:          pm.GetCounter().GetValue(),
+				})
+			}
+		case dto.MetricType_GAUGE, dto.MetricType_UNTYPED:
+			m.Gauge = &otlpGauge{}
+			for _, pm := range mf.GetMetric() {
+				value := pm.GetGauge().GetValue()
+				if mf.GetType() == dto.MetricType_UNTYPED {
+					value = pm.GetUntyped().GetValue()
+				}
+				m.Gauge.DataPoints = append(m.Gauge.DataPoints, otlpNumberDataPoint{
+					Attributes:   otlpLabels(pm.GetLabel()),
+					TimeUnixNano: nowTs,
+					AsDouble:     value,
+				})
+			}
+		case dto.MetricType_HISTOGRAM, dto.MetricType_GAUGE_HISTOGRAM:
+			m.Histogram = &otlpHistogram{
+				AggregationTemporality: otlpTemporalityCumulative,
+			}
+			for _, pm := range mf.GetMetric() {
+				h := pm.GetHistogram()
+
+				// Prometheus buckets are cumulative while OTLP buckets are not. OTLP also has an
+				// implicit overflow bucket after the last explicit bound.
+				dp := otlpHistogramDataPoint{
+					Attributes:        otlpLabels(pm.GetLabel()),
+					StartTimeUnixNano: startTs,
+					TimeUnixNano:      nowTs,
+					Count:             strconv.FormatUint(h.GetSampleCount(), 10),
+					Sum:               h.GetSampleSum(),
+				}
+				var prev uint64
+				for _, b := range h.GetBucket() {
+					if math.IsInf(b.GetUpperBound(), 1) {
+						continue
+					}
+					dp.ExplicitBounds = append(dp.ExplicitBounds, b.GetUpperBound())
+					dp.BucketCounts = append(dp.BucketCounts, strconv.FormatUint(b.GetCumulativeCount()-prev, 10))
+					prev = b.GetCumulativeCount()
+				}
+				dp.BucketCounts = append(dp.BucketCounts, strconv.FormatUint(h.GetSampleCount()-prev, 10))
+				m.Histogram.DataPoints = append(m.Histogram.DataPoints, dp)
+			}
+		case dto.MetricType_SUMMARY:
+			m.Summary = &otlpSummary{}
+			for _, pm := range mf.GetMetric() {
+				s := pm.GetSummary()
+				dp := otlpSummaryDataPoint{
+					Attributes:        otlpLabels(pm.GetLabel()),
+					StartTimeUnixNano: startTs,
+					TimeUnixNano:      nowTs,
+					Count:             strconv.FormatUint(s.GetSampleCount(), 10),
+					Sum:               s.GetSampleSum(),
+				}
+				for _, q := range s.GetQuantile() {
+					dp.QuantileValues = append(dp.QuantileValues, otlpQuantileValue{
+						Quantile: q.GetQuantile(),
+						Value:    q.GetValue(),
+					})
+				}
+				m.Summary.DataPoints = append(m.Summary.DataPoints, dp)
+			}
+		default:
+			continue
+		}
+		metrics = append(metrics, m)
+	}
+	return metrics
+}
+
+type otlpService struct {
+	service.BaseBackgroundService
+
+	cfg      *metricsConfig.OTLPConfig
+	client   *http.Client
+	gatherer prometheus.Gatherer
+	resource otlpResource
+	start    time.Time
+
+	rsvc *resourceService
+
+	stopCh chan struct{}
+	quitCh chan struct{}
+}
+
+func (s *otlpService) Start() error {
+	if err := s.rsvc.Start(); err != nil {
+		return err
+	}
+
+	go s.worker()
+	return nil
+}
+
+func (s *otlpService) Stop() {
+	close(s.stopCh)
+}
+
+func (s *otlpService) Quit() <-chan struct{} {
+	return s.quitCh
+}
+
+func (s *otlpService) Cleanup() {
+	s.rsvc.Cleanup()
+}
+
+func (s *otlpService) worker() {
+	defer func() {
+		s.rsvc.Stop()
+		<-s.rsvc.Quit()
+		close(s.quitCh)
+	}()
+
+	t := time.NewTicker(s.cfg.Interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			// Make a best-effort attempt to export the final values.
+			if err := s.export(); err != nil {
+				s.Logger.Warn("final export failed",
+					"err", err,
+				)
+			}
+			return
+		case <-t.C:
+		}
+
+		if err := s.export(); err != nil {
+			s.Logger.Warn("export failed",
+				"err", err,
+			)
+		}
+	}
+}
+
+func (s *otlpService) export() error {
+	families, err := s.gatherer.Gather()
+	if err != nil {
+		return fmt.Errorf("failed to gather metrics: %w", err)
+	}
+
+	req := otlpExportRequest{
+		ResourceMetrics: []otlpResourceMetrics{
+			{
+				Resource: s.resource,
+				ScopeMetrics: []otlpScopeMetrics{
+					{
+						Scope: otlpScope{
+							Name:    otlpScopeName,
+							Version: version.SoftwareVersion,
+						},
+						Metrics: convertMetricFamilies(families, s.cfg.Subsystems, s.start, time.Now()),
+					},
+				},
+			},
+		},
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to encode metrics: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), otlpExportTimeout)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	for k, v := range s.cfg.Headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	rsp, err := s.client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+	_, _ = io.Copy(io.Discard, rsp.Body)
+
+	if rsp.StatusCode < 200 || rsp.StatusCode > 299 {
+		return fmt.Errorf("unexpected response status: %s", rsp.Status)
+	}
+	return nil
+}
+
+func newOTLPService() (service.BackgroundService, error) {
+	cfg := &config.GlobalConfig.Metrics.OTLP
+	if cfg.Endpoint == "" {
+		return nil, fmt.Errorf("metrics: endpoint required for otlp mode")
+	}
+	if cfg.Interval <= 0 {
+		return nil, fmt.Errorf("metrics: invalid interval for otlp mode: %s", cfg.Interval)
+	}
+
+	attrs := map[string]string{
+		"service.name":    otlpServiceName,
+		"service.version": version.SoftwareVersion,
+	}
+	for k, v := range cfg.ResourceAttributes {
+		attrs[k] = v
+	}
+
+	svc := &otlpService{
+		BaseBackgroundService: *service.NewBaseBackgroundService("metrics"),
+		cfg:                   cfg,
+		client:                httpclient.New(otlpExportTimeout),
+		gatherer:              prometheus.DefaultGatherer,
+		resource:              otlpResource{Attributes: otlpAttributes(attrs)},
+		start:                 time.Now(),
+		rsvc:                  newResourceService(cfg.Interval),
+		stopCh:                make(chan struct{}),
+		quitCh:                make(chan struct{}),
+	}
+
+	svc.Logger.Debug("initializing metrics otlp service",
+		"mode", MetricsModeOTLP,
+		"endpoint", cfg.Endpoint,
+		"interval", cfg.Interval,
+		"subsystems", cfg.Subsystems,
+	)
+
+	return svc, nil
+}
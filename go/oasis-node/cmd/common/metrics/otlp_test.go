@@ -0,0 +1,124 @@
+package metrics
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/service"
+	metricsConfig "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/metrics/config"
+)
+
+func TestSubsystemEnabled(t *testing.T) {
+	require := require.New(t)
+
+	subsystems := map[string]bool{
+		"oasis_worker":          false,
+		"oasis_worker_executor": true,
+	}
+	require.True(subsystemEnabled(subsystems, "oasis_node_cpu_utime_seconds"))
+	require.False(subsystemEnabled(subsystems, "oasis_worker_storage_full_round"))
+	require.True(subsystemEnabled(subsystems, "oasis_worker_executor_discrepancy_detected_count"), "longest prefix should take precedence")
+	require.True(subsystemEnabled(subsystems, "oasis_workers"), "prefixes should only match whole name segments")
+	require.True(subsystemEnabled(nil, "oasis_worker"))
+}
+
+func TestConvertMetricFamilies(t *testing.T) {
+	require := require.New(t)
+
+	reg := prometheus.NewRegistry()
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test_counter", Help: "Counter."}, []string{"runtime"})
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "test_gauge", Help: "Gauge."})
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "test_histogram", Buckets: []float64{1, 2}})
+	disabled := prometheus.NewGauge(prometheus.GaugeOpts{Name: "disabled_gauge"})
+	reg.MustRegister(counter, gauge, histogram, disabled)
+
+	counter.WithLabelValues("rt").Add(3)
+	gauge.Set(42)
+	for _, v := range []float64{0.5, 1.5, 1.5, 5} {
+		histogram.Observe(v)
+	}
+
+	families, err := reg.Gather()
+	require.NoError(err, "Gather")
+
+	start := time.Unix(1, 0)
+	now := time.Unix(2, 0)
+	metrics := convertMetricFamilies(families, map[string]bool{"disabled": false}, start, now)
+	require.Len(metrics, 3, "disabled subsystems should not be exported")
+
+	byName := make(map[string]otlpMetric)
+	for _, m := range metrics {
+		byName[m.Name] = m
+	}
+
+	c := byName["test_counter"]
+	require.NotNil(c.Sum)
+	require.True(c.Sum.IsMonotonic)
+	require.Len(c.Sum.DataPoints, 1)
+	require.EqualValues(3, c.Sum.DataPoints[0].AsDouble)
+	require.Equal("1000000000", c.Sum.DataPoints[0].StartTimeUnixNano)
+	require.Equal("2000000000", c.Sum.DataPoints[0].TimeUnixNano)
+	require.Equal([]otlpKeyValue{{Key: "runtime", Value: otlpAnyValue{StringValue: "rt"}}}, c.Sum.DataPoints[0].Attributes)
+
+	g := byName["test_gauge"]
+	require.NotNil(g.Gauge)
+	require.EqualValues(42, g.Gauge.DataPoints[0].AsDouble)
+
+	h := byName["test_histogram"]
+	require.NotNil(h.Histogram)
+	require.Len(h.Histogram.DataPoints, 1)
+	require.Equal([]float64{1, 2}, h.Histogram.DataPoints[0].ExplicitBounds)
+	require.Equal([]string{"1", "2", "1"}, h.Histogram.DataPoints[0].BucketCounts, "buckets should not be cumulative")
+	require.Equal("4", h.Histogram.DataPoints[0].Count)
+	require.EqualValues(8.5, h.Histogram.DataPoints[0].Sum)
+}
+
+func TestOTLPExport(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		received otlpExportRequest
+		header   string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("X-Test")
+		_ = json.NewDecoder(r.Body).Decode(&received)
+	}))
+	defer srv.Close()
+
+	reg := prometheus.NewRegistry()
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "test_gauge"})
+	reg.MustRegister(gauge)
+
+	svc := &otlpService{
+		BaseBackgroundService: *service.NewBaseBackgroundService("metrics"),
+		cfg: &metricsConfig.OTLPConfig{
+			Endpoint: srv.URL,
+			Headers:  map[string]string{"X-Test": "value"},
+		},
+		client:   srv.Client(),
+		gatherer: reg,
+		resource: otlpResource{Attributes: otlpAttributes(map[string]string{"service.name": otlpServiceName})},
+		start:    time.Now(),
+	}
+	err := svc.export()
+	require.NoError(err, "export")
+	require.Equal("value", header)
+	require.Len(received.ResourceMetrics, 1)
+	require.Equal(svc.resource, received.ResourceMetrics[0].Resource)
+	require.Len(received.ResourceMetrics[0].ScopeMetrics, 1)
+	require.Len(received.ResourceMetrics[0].ScopeMetrics[0].Metrics, 1)
+	require.Equal("test_gauge", received.ResourceMetrics[0].ScopeMetrics[0].Metrics[0].Name)
+
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	err = svc.export()
+	require.Error(err, "export should fail on error responses")
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	compositeSigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/composite"
 	fileSigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/file"
+	ledgerSigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/ledger"
 	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
 	pluginSigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/plugin"
 	remoteSigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/remote"
@@ -40,6 +41,13 @@ const (
 	cfgSignerPluginName   = "signer.plugin.name"
 	cfgSignerPluginPath   = "signer.plugin.path"
 	cfgSignerPluginConfig = "signer.plugin.config"
+
+	// CfgSignerLedgerIndex is the flag used to specify the address index
+	// used for key derivation by the Ledger signer.
+	CfgSignerLedgerIndex = "signer.ledger.index"
+
+	cfgSignerLedgerPath     = "signer.ledger.path"
+	cfgSignerLedgerWalletID = "signer.ledger.wallet_id"
 )
 
 var (
@@ -124,6 +132,13 @@ func doNewFactory(signerBackend, signerDir string, roles ...signature.SignerRole
 			Config: viper.GetString(cfgSignerPluginConfig),
 		}
 		return pluginSigner.NewFactory(config, roles...)
+	case ledgerSigner.SignerName:
+		config := &ledgerSigner.FactoryConfig{
+			Path:     viper.GetString(cfgSignerLedgerPath),
+			WalletID: viper.GetString(cfgSignerLedgerWalletID),
+			Index:    viper.GetUint32(CfgSignerLedgerIndex),
+		}
+		return ledgerSigner.NewFactory(config, roles...)
 	default:
 		return nil, fmt.Errorf("unsupported signer backend: %s", signerBackend)
 	}
@@ -179,7 +194,7 @@ func doNewComposite(signerDir string, roles ...signature.SignerRole) (signature.
 }
 
 func init() {
-	Flags.StringP(CfgSigner, "s", "file", "signer backend [file, plugin, remote, composite]")
+	Flags.StringP(CfgSigner, "s", "file", "signer backend [file, ledger, plugin, remote, composite]")
 	Flags.String(cfgSignerRemoteAddress, "", "remote signer server address")
 	Flags.String(cfgSignerRemoteClientCert, "", "remote signer client certificate path")
 	Flags.String(cfgSignerRemoteClientKey, "", "remote signer client certificate key path")
@@ -188,6 +203,9 @@ func init() {
 	Flags.String(cfgSignerPluginName, "", "plugin signer backend name")
 	Flags.String(cfgSignerPluginPath, "", "plugin signer binary path")
 	Flags.String(cfgSignerPluginConfig, "", "plugin signer configuration")
+	Flags.String(cfgSignerLedgerPath, "", "Ledger signer plugin binary path")
+	Flags.String(cfgSignerLedgerWalletID, "", "Ledger wallet ID (if multiple devices are connected)")
+	Flags.Uint32(CfgSignerLedgerIndex, 0, "Ledger address index used for key derivation")
 
 	_ = viper.BindPFlags(Flags)
 
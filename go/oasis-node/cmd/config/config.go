@@ -5,6 +5,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/config/migrate"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/config/preflight"
 )
 
 var configCmd = &cobra.Command{
@@ -15,6 +16,7 @@ var configCmd = &cobra.Command{
 // Register registers the config sub-command and all of it's children.
 func Register(parentCmd *cobra.Command) {
 	migrate.Register(configCmd)
+	preflight.Register(configCmd)
 
 	parentCmd.AddCommand(configCmd)
 }
@@ -0,0 +1,31 @@
+//go:build linux
+
+package preflight
+
+import (
+	"fmt"
+	"syscall"
+	"time"
+)
+
+const (
+	// timeError is the clock state returned by adjtimex when the clock is not synchronized.
+	timeError = 5
+	// staUnsync is the clock status flag set when the clock is not synchronized.
+	staUnsync = 0x0040
+)
+
+// clockSynced returns true iff the system clock is synchronized (e.g., by an NTP daemon).
+func clockSynced() (bool, string, error) {
+	var tx syscall.Timex
+	state, err := syscall.Adjtimex(&tx)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to query clock state: %w", err)
+	}
+
+	if state == timeError || tx.Status&staUnsync != 0 {
+		return false, "system clock is not synchronized", nil
+	}
+	maxError := time.Duration(tx.Maxerror) * time.Microsecond
+	return true, fmt.Sprintf("system clock is synchronized (maximum error %s)", maxError), nil
+}
@@ -0,0 +1,10 @@
+//go:build !linux
+
+package preflight
+
+import "fmt"
+
+// clockSynced returns true iff the system clock is synchronized (e.g., by an NTP daemon).
+func clockSynced() (bool, string, error) {
+	return false, "", fmt.Errorf("clock synchronization check not supported on this platform")
+}
@@ -0,0 +1,409 @@
+// Package preflight implements the preflight command.
+package preflight
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common/sgx/pcs"
+	"github.com/oasisprotocol/oasis-core/go/config"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle"
+	rtConfig "github.com/oasisprotocol/oasis-core/go/runtime/config"
+)
+
+const (
+	cfgSGXFMSPC          = "sgx.fmspc"
+	cfgDiskTestSize      = "disk.test_size"
+	cfgDiskMinThroughput = "disk.min_throughput"
+
+	// aesmdSocketPath is the path to the AESM service socket.
+	aesmdSocketPath = "/var/run/aesmd/aesm.socket"
+	// tdxParameterPath is the path to the KVM module parameter indicating TDX support.
+	tdxParameterPath = "/sys/module/kvm_intel/parameters/tdx"
+
+	pcsTimeout = 30 * time.Second
+)
+
+var (
+	preflightCmd = &cobra.Command{
+		Use:   "preflight",
+		Short: "check that this machine satisfies the requirements of the node config",
+		Long: `Inspects this machine against the requirements of the roles and runtimes
+configured in the node config (given via --config) and reports any gaps. This is
+useful to validate a new machine before migrating a node to it.`,
+		Run: doPreflight,
+	}
+
+	preflightFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	// sgxDevices are the names of the SGX device provided by different versions of Intel SGX
+	// drivers.
+	sgxDevices = []string{"/dev/sgx_enclave", "/dev/sgx/enclave", "/dev/sgx", "/dev/isgx"}
+)
+
+// status is the outcome of a single check.
+type status string
+
+const (
+	statusOK      status = "OK"
+	statusWarn    status = "WARN"
+	statusFail    status = "FAIL"
+	statusSkipped status = "SKIP"
+)
+
+// result is the result of a single check.
+type result struct {
+	name    string
+	status  status
+	details string
+}
+
+// requirements are the requirements the machine needs to satisfy to run the configured node.
+type requirements struct {
+	// sgx is true iff any of the configured runtimes needs to run in SGX.
+	sgx bool
+	// storage is true iff the node keeps local state.
+	storage bool
+	// clockSync is true iff the node must have a synchronized clock.
+	clockSync bool
+	// ports are the addresses the node needs to be able to listen on.
+	ports map[string]string
+}
+
+// requirementsFromConfig derives the requirements from the node configuration and the manifests
+// of the configured runtime bundles.
+func requirementsFromConfig(cfg *config.Config, manifests []*bundle.Manifest) (*requirements, error) {
+	req := requirements{
+		storage:   cfg.Mode != config.ModeStatelessClient,
+		clockSync: cfg.Mode == config.ModeValidator,
+		ports:     make(map[string]string),
+	}
+
+	// Follow the same environment selection as the runtime registry.
+	runtimeEnv := cfg.Runtime.Environment
+	if runtimeEnv == rtConfig.RuntimeEnvironmentAuto {
+		for _, m := range manifests {
+			for _, comp := range m.GetAvailableComponents() {
+				if comp.IsTEERequired() {
+					runtimeEnv = rtConfig.RuntimeEnvironmentSGX
+				}
+			}
+		}
+	}
+	forceNoSGX := cfg.Mode.IsClientOnly() && cfg.Runtime.Environment != rtConfig.RuntimeEnvironmentSGX
+	req.sgx = len(manifests) > 0 && runtimeEnv == rtConfig.RuntimeEnvironmentSGX && !forceNoSGX
+
+	if cfg.Mode != config.ModeStatelessClient && cfg.Consensus.ListenAddress != "" {
+		u, err := url.Parse(cfg.Consensus.ListenAddress)
+		if err != nil {
+			return nil, fmt.Errorf("malformed consensus listen address: %w", err)
+		}
+		req.ports["consensus"] = u.Host
+	}
+	if cfg.P2P.Port != 0 {
+		req.ports["p2p"] = fmt.Sprintf("0.0.0.0:%d", cfg.P2P.Port)
+	}
+
+	return &req, nil
+}
+
+func checkSGX(req *requirements, loader string) result {
+	r := result{name: "sgx"}
+	if !req.sgx {
+		r.status = statusSkipped
+		r.details = "not required by the configured runtimes"
+		return r
+	}
+
+	var (
+		device string
+		gaps   []string
+	)
+	for _, dev := range sgxDevices {
+		fi, err := os.Stat(dev)
+		if err == nil && fi.Mode()&os.ModeDevice != 0 {
+			device = dev
+			break
+		}
+	}
+	if device == "" {
+		gaps = append(gaps, "no SGX device found")
+	}
+	if _, err := os.Stat(aesmdSocketPath); err != nil {
+		gaps = append(gaps, fmt.Sprintf("AESM service socket not found at %s", aesmdSocketPath))
+	}
+	switch loader {
+	case "":
+		gaps = append(gaps, "runtime.sgx_loader not configured")
+	default:
+		if _, err := os.Stat(loader); err != nil {
+			gaps = append(gaps, fmt.Sprintf("SGX runtime loader not found at %s", loader))
+		}
+	}
+
+	if len(gaps) > 0 {
+		r.status = statusFail
+		r.details = strings.Join(gaps, "; ")
+		return r
+	}
+	r.status = statusOK
+	r.details = fmt.Sprintf("SGX device %s, AESM service available", device)
+	return r
+}
+
+func checkTDX() result {
+	r := result{name: "tdx"}
+
+	// No runtime components require TDX, so report the capability for information only.
+	raw, err := os.ReadFile(tdxParameterPath)
+	if err != nil || !strings.EqualFold(strings.TrimSpace(string(raw)), "y") {
+		r.status = statusSkipped
+		r.details = "TDX not available, not required by the configured runtimes"
+		return r
+	}
+	r.status = statusOK
+	r.details = "TDX available"
+	return r
+}
+
+func checkFMSPC(ctx context.Context, req *requirements, rawFMSPC string, client pcs.Client) result {
+	r := result{name: "fmspc tcb"}
+	switch {
+	case !req.sgx:
+		r.status = statusSkipped
+		r.details = "not required by the configured runtimes"
+		return r
+	case rawFMSPC == "":
+		r.status = statusWarn
+		r.details = fmt.Sprintf("platform FMSPC not given (use --%s), skipping TCB status check", cfgSGXFMSPC)
+		return r
+	}
+
+	fmspc, err := hex.DecodeString(rawFMSPC)
+	if err != nil {
+		r.status = statusFail
+		r.details = fmt.Sprintf("malformed FMSPC: %s", err)
+		return r
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, pcsTimeout)
+	defer cancel()
+
+	tcbBundle, err := client.GetTCBBundle(ctx, fmspc, pcs.UpdateStandard)
+	if err != nil {
+		r.status = statusFail
+		r.details = fmt.Sprintf("failed to fetch TCB info for FMSPC: %s", err)
+		return r
+	}
+	var tcbInfo pcs.TCBInfo
+	if err = json.Unmarshal(tcbBundle.TCBInfo.TCBInfo, &tcbInfo); err != nil {
+		r.status = statusFail
+		r.details = fmt.Sprintf("malformed TCB info: %s", err)
+		return r
+	}
+	if len(tcbInfo.TCBLevels) == 0 {
+		r.status = statusFail
+		r.details = "no TCB levels published for FMSPC"
+		return r
+	}
+
+	// TCB levels are ordered from the latest to the oldest. A platform can only be up to date if
+	// the latest published TCB level is, and it still needs to run the latest microcode and BIOS.
+	latest := tcbInfo.TCBLevels[0]
+	r.details = fmt.Sprintf("latest TCB level is %s (TCB date %s, evaluation data number %d)",
+		latest.Status, latest.Date, tcbInfo.TCBEvaluationDataNumber,
+	)
+	switch latest.Status {
+	case pcs.StatusUpToDate, pcs.StatusSWHardeningNeeded:
+		r.status = statusOK
+	default:
+		r.status = statusFail
+	}
+	return r
+}
+
+func checkDisk(req *requirements, dataDir string, testSize, minThroughput uint64) result {
+	r := result{name: "disk"}
+	if !req.storage {
+		r.status = statusSkipped
+		r.details = "node does not keep local state"
+		return r
+	}
+	if dataDir == "" {
+		r.status = statusFail
+		r.details = "data directory not configured"
+		return r
+	}
+
+	throughput, err := measureWriteThroughput(dataDir, testSize)
+	if err != nil {
+		r.status = statusFail
+		r.details = err.Error()
+		return r
+	}
+
+	r.details = fmt.Sprintf("sequential write throughput %.1f MiB/s in %s (required: %d MiB/s)",
+		throughput, dataDir, minThroughput,
+	)
+	switch throughput < float64(minThroughput) {
+	case true:
+		r.status = statusFail
+	case false:
+		r.status = statusOK
+	}
+	return r
+}
+
+// measureWriteThroughput measures the synchronous sequential write throughput (in MiB/s) of the
+// file system holding the given directory by writing a test file of the given size (in MiB).
+func measureWriteThroughput(dir string, size uint64) (float64, error) {
+	if size == 0 {
+		return 0, fmt.Errorf("disk test size must be positive")
+	}
+
+	f, err := os.CreateTemp(dir, "preflight-")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create test file: %w", err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	chunk := make([]byte, 1024*1024)
+	start := time.Now()
+	for i := uint64(0); i < size; i++ {
+		if _, err = f.Write(chunk); err != nil {
+			return 0, fmt.Errorf("failed to write test file: %w", err)
+		}
+	}
+	if err = f.Sync(); err != nil {
+		return 0, fmt.Errorf("failed to sync test file: %w", err)
+	}
+	elapsed := time.Since(start)
+
+	return float64(size) / elapsed.Seconds(), nil
+}
+
+func checkClock(req *requirements) result {
+	r := result{name: "clock"}
+
+	synced, details, err := clockSynced()
+	switch {
+	case err != nil:
+		r.status = statusSkipped
+		r.details = err.Error()
+	case synced:
+		r.status = statusOK
+		r.details = details
+	case req.clockSync:
+		r.status = statusFail
+		r.details = details
+	default:
+		r.status = statusWarn
+		r.details = details
+	}
+	return r
+}
+
+func checkPorts(req *requirements) []result {
+	names := make([]string, 0, len(req.ports))
+	for name := range req.ports {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var results []result
+	for _, name := range names {
+		addr := req.ports[name]
+		r := result{name: fmt.Sprintf("%s port", name)}
+
+		ln, err := net.Listen("tcp", addr)
+		if err != nil {
+			r.status = statusFail
+			r.details = fmt.Sprintf("cannot listen on %s: %s", addr, err)
+			results = append(results, r)
+			continue
+		}
+		ln.Close()
+
+		r.status = statusOK
+		r.details = fmt.Sprintf("%s available", addr)
+		results = append(results, r)
+	}
+	return results
+}
+
+// report writes the results of all checks and returns true iff none of the checks failed.
+func report(w io.Writer, results []result) bool {
+	ok := true
+	for _, r := range results {
+		fmt.Fprintf(w, "[%4s] %s: %s\n", r.status, r.name, r.details)
+		if r.status == statusFail {
+			ok = false
+		}
+	}
+	return ok
+}
+
+func doPreflight(*cobra.Command, []string) {
+	cfg := &config.GlobalConfig
+
+	var manifests []*bundle.Manifest
+	for _, path := range cfg.Runtime.Paths {
+		bnd, err := bundle.Open(path)
+		if err != nil {
+			cmdCommon.EarlyLogAndExit(fmt.Errorf("failed to load runtime bundle '%s': %w", path, err))
+		}
+		manifests = append(manifests, bnd.Manifest)
+	}
+
+	req, err := requirementsFromConfig(cfg, manifests)
+	if err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	pcsClient, err := pcs.NewHTTPClient(&pcs.HTTPClientConfig{})
+	if err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	results := []result{
+		checkSGX(req, cfg.Runtime.SGXLoader),
+		checkTDX(),
+		checkFMSPC(context.Background(), req, viper.GetString(cfgSGXFMSPC), pcsClient),
+		checkDisk(req, cfg.Common.DataDir, viper.GetUint64(cfgDiskTestSize), viper.GetUint64(cfgDiskMinThroughput)),
+		checkClock(req),
+	}
+	results = append(results, checkPorts(req)...)
+
+	fmt.Printf("Preflight checks for a %s node:\n", cfg.Mode)
+	if !report(os.Stdout, results) {
+		os.Exit(1)
+	}
+}
+
+// Register registers the preflight sub-command.
+func Register(parentCmd *cobra.Command) {
+	preflightCmd.PersistentFlags().AddFlagSet(preflightFlags)
+	parentCmd.AddCommand(preflightCmd)
+}
+
+func init() {
+	preflightFlags.String(cfgSGXFMSPC, "", "hex-encoded FMSPC of the SGX platform to check the TCB status for")
+	preflightFlags.Uint64(cfgDiskTestSize, 256, "size of the disk throughput test file (MiB)")
+	preflightFlags.Uint64(cfgDiskMinThroughput, 100, "minimum required sequential disk write throughput (MiB/s)")
+	_ = viper.BindPFlags(preflightFlags)
+}
@@ -0,0 +1,122 @@
+package preflight
+
+import (
+	"bytes"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/config"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
+	rtConfig "github.com/oasisprotocol/oasis-core/go/runtime/config"
+)
+
+func TestRequirementsFromConfig(t *testing.T) {
+	require := require.New(t)
+
+	sgxManifest := &bundle.Manifest{
+		Components: []*bundle.Component{
+			{
+				Kind: component.RONL,
+				SGX: &bundle.SGXMetadata{
+					Executable: "runtime.sgxs",
+				},
+			},
+		},
+	}
+	elfManifest := &bundle.Manifest{
+		Components: []*bundle.Component{
+			{
+				Kind:       component.RONL,
+				Executable: "runtime.elf",
+			},
+		},
+	}
+
+	for _, tc := range []struct {
+		name          string
+		mode          config.NodeMode
+		env           rtConfig.RuntimeEnvironment
+		manifests     []*bundle.Manifest
+		sgx           bool
+		storage       bool
+		clockSync     bool
+		consensusPort bool
+	}{
+		{"Validator", config.ModeValidator, rtConfig.RuntimeEnvironmentAuto, nil, false, true, true, true},
+		{"ComputeSGX", config.ModeCompute, rtConfig.RuntimeEnvironmentAuto, []*bundle.Manifest{sgxManifest}, true, true, false, true},
+		{"ComputeELF", config.ModeCompute, rtConfig.RuntimeEnvironmentAuto, []*bundle.Manifest{elfManifest}, false, true, false, true},
+		{"ComputeForcedELF", config.ModeCompute, rtConfig.RuntimeEnvironmentELF, []*bundle.Manifest{sgxManifest}, false, true, false, true},
+		{"ClientAuto", config.ModeClient, rtConfig.RuntimeEnvironmentAuto, []*bundle.Manifest{sgxManifest}, false, true, false, true},
+		{"ClientSGX", config.ModeClient, rtConfig.RuntimeEnvironmentSGX, []*bundle.Manifest{sgxManifest}, true, true, false, true},
+		{"StatelessClient", config.ModeStatelessClient, rtConfig.RuntimeEnvironmentAuto, nil, false, false, false, false},
+	} {
+		t.Run(tc.name, func(_ *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.Mode = tc.mode
+			cfg.Runtime.Environment = tc.env
+
+			req, err := requirementsFromConfig(&cfg, tc.manifests)
+			require.NoError(err, "requirementsFromConfig")
+			require.Equal(tc.sgx, req.sgx, "sgx")
+			require.Equal(tc.storage, req.storage, "storage")
+			require.Equal(tc.clockSync, req.clockSync, "clockSync")
+			_, ok := req.ports["consensus"]
+			require.Equal(tc.consensusPort, ok, "consensus port")
+		})
+	}
+}
+
+func TestCheckPorts(t *testing.T) {
+	require := require.New(t)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err, "Listen")
+	defer ln.Close()
+
+	req := &requirements{
+		ports: map[string]string{
+			"busy": ln.Addr().String(),
+			"free": "127.0.0.1:0",
+		},
+	}
+	results := checkPorts(req)
+	require.Len(results, 2)
+	require.Equal("busy port", results[0].name)
+	require.Equal(statusFail, results[0].status, "port in use should fail")
+	require.Equal("free port", results[1].name)
+	require.Equal(statusOK, results[1].status, "free port should pass")
+}
+
+func TestCheckDisk(t *testing.T) {
+	require := require.New(t)
+
+	req := &requirements{storage: true}
+	r := checkDisk(req, t.TempDir(), 1, 0)
+	require.Equal(statusOK, r.status, r.details)
+
+	r = checkDisk(req, "/nonexistent", 1, 0)
+	require.Equal(statusFail, r.status, "missing data directory should fail")
+
+	r = checkDisk(&requirements{}, "/nonexistent", 1, 0)
+	require.Equal(statusSkipped, r.status, "disk check should be skipped without local state")
+}
+
+func TestReport(t *testing.T) {
+	require := require.New(t)
+
+	var buf bytes.Buffer
+	ok := report(&buf, []result{
+		{name: "a", status: statusOK, details: "fine"},
+		{name: "b", status: statusWarn, details: "meh"},
+	})
+	require.True(ok, "warnings should not fail the report")
+	require.Equal("[  OK] a: fine\n[WARN] b: meh\n", buf.String())
+
+	ok = report(&buf, []result{
+		{name: "c", status: statusFail, details: "broken"},
+	})
+	require.False(ok, "failures should fail the report")
+}
@@ -145,8 +145,8 @@ func doShowTx(*cobra.Command, []string) {
 	genesis := cmdConsensus.InitGenesis()
 
 	ctx := context.Background()
-	ctx = context.WithValue(ctx, prettyprint.ContextKeyTokenSymbol, genesis.Staking.TokenSymbol)
-	ctx = context.WithValue(ctx, prettyprint.ContextKeyTokenValueExponent, genesis.Staking.TokenValueExponent)
+	ctx = context.WithValue(ctx, prettyprint.ContextKeyTokenSymbol, genesis.Staking.DisplayTokenSymbol())
+	ctx = context.WithValue(ctx, prettyprint.ContextKeyTokenValueExponent, genesis.Staking.DisplayTokenValueExponent())
 	ctx = context.WithValue(ctx, prettyprint.ContextKeyGenesisHash, genesis.Hash())
 
 	sigTx := loadTx()
@@ -230,5 +230,7 @@ func Register(parentCmd *cobra.Command) {
 
 	nextBlockStateCmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
 
+	registerTxCmd(consensusCmd)
+
 	parentCmd.AddCommand(consensusCmd)
 }
@@ -0,0 +1,163 @@
+package consensus
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	cmdConsensus "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/consensus"
+	cmdContext "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/context"
+	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+	cmdGrpc "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/grpc"
+)
+
+const (
+	// CfgTxMethod configures the method of the constructed transaction.
+	CfgTxMethod = "transaction.method"
+
+	// CfgTxBody configures the path to the JSON-encoded body of the constructed transaction.
+	CfgTxBody = "transaction.body"
+)
+
+var (
+	txBuildFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	txCmd = &cobra.Command{
+		Use:   "tx",
+		Short: "offline transaction construction, signing and broadcast",
+	}
+
+	txMethodsCmd = &cobra.Command{
+		Use:   "methods",
+		Short: "list supported transaction methods",
+		Run:   doTxMethods,
+	}
+
+	txBuildCmd = &cobra.Command{
+		Use:   "build",
+		Short: "construct and sign a transaction offline from a JSON body",
+		Long: `Construct a transaction of any supported method from a JSON-encoded body and
+sign it without connecting to a node. The nonce and fee must be given explicitly.
+The (signed or unsigned) transaction is saved in CBOR encoding.`,
+		Run: doTxBuild,
+	}
+
+	txBroadcastCmd = &cobra.Command{
+		Use:   "broadcast",
+		Short: "broadcast a CBOR-encoded signed transaction",
+		Run:   doTxBroadcast,
+	}
+)
+
+func doTxMethods(*cobra.Command, []string) {
+	for _, method := range transaction.RegisteredMethods() {
+		fmt.Println(method)
+	}
+}
+
+func doTxBuild(*cobra.Command, []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	genesis := cmdConsensus.InitGenesis()
+	cmdConsensus.AssertTxFileOK()
+
+	rawBody, err := os.ReadFile(viper.GetString(CfgTxBody))
+	if err != nil {
+		logger.Error("failed to read transaction body",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	nonce, fee := cmdConsensus.GetTxNonceAndFee()
+	method := transaction.MethodName(viper.GetString(CfgTxMethod))
+	tx, err := transaction.NewTransactionFromJSON(nonce, fee, method, rawBody)
+	if err != nil {
+		logger.Error("failed to construct transaction",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	var rawTx []byte
+	switch viper.GetBool(cmdConsensus.CfgTxUnsigned) {
+	case true:
+		rawTx = cbor.Marshal(tx)
+	default:
+		sigTx := cmdConsensus.SignTx(cmdContext.GetCtxWithGenesisInfo(genesis), tx, nil)
+		rawTx = cbor.Marshal(sigTx)
+	}
+
+	if err = os.WriteFile(viper.GetString(cmdConsensus.CfgTxFile), rawTx, 0o600); err != nil {
+		logger.Error("failed to save transaction",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+}
+
+func doTxBroadcast(cmd *cobra.Command, _ []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	rawTx, err := os.ReadFile(viper.GetString(cmdConsensus.CfgTxFile))
+	if err != nil {
+		logger.Error("failed to read raw serialized transaction",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	var sigTx transaction.SignedTransaction
+	if err = cbor.Unmarshal(rawTx, &sigTx); err != nil {
+		logger.Error("failed to parse serialized transaction",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	conn, client := doConnect(cmd)
+	defer conn.Close()
+
+	if err = client.SubmitTx(context.Background(), &sigTx); err != nil {
+		logger.Error("failed to broadcast transaction",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+}
+
+func registerTxCmd(parentCmd *cobra.Command) {
+	for _, v := range []*cobra.Command{
+		txMethodsCmd,
+		txBuildCmd,
+		txBroadcastCmd,
+	} {
+		txCmd.AddCommand(v)
+	}
+
+	txBuildCmd.Flags().AddFlagSet(txBuildFlags)
+	txBuildCmd.Flags().AddFlagSet(cmdConsensus.TxFlags)
+	txBuildCmd.Flags().AddFlagSet(cmdFlags.AssumeYesFlag)
+
+	txBroadcastCmd.Flags().AddFlagSet(cmdConsensus.TxFileFlags)
+	txBroadcastCmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
+
+	parentCmd.AddCommand(txCmd)
+}
+
+func init() {
+	txBuildFlags.String(CfgTxMethod, "", "transaction method (as listed by the methods command)")
+	txBuildFlags.String(CfgTxBody, "", "path to the JSON-encoded transaction body")
+	_ = viper.BindPFlags(txBuildFlags)
+}
@@ -10,17 +10,21 @@ import (
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/persistent"
 	control "github.com/oasisprotocol/oasis-core/go/control/api"
 	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
 	cmdGrpc "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/grpc"
+	nodedb "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
 	"github.com/oasisprotocol/oasis-core/go/worker/registration"
 )
 
 var (
-	shutdownWait = false
+	shutdownWait       = false
+	shutdownGraceful   = false
+	shutdownDeregister = false
 
 	controlCmd = &cobra.Command{
 		Use:   "control",
@@ -70,6 +74,62 @@ var (
 		Run:   doStatus,
 	}
 
+	controlDiscrepanciesCmd = &cobra.Command{
+		Use:   "discrepancies <runtime-id>",
+		Short: "show executor discrepancy artifacts retained by the node",
+		Args:  cobra.ExactArgs(1),
+		Run:   doDiscrepancies,
+	}
+
+	controlStorageStatsCmd = &cobra.Command{
+		Use:   "storage-stats <runtime-id>",
+		Short: "show size statistics of the runtime's local storage",
+		Args:  cobra.ExactArgs(1),
+		Run:   doStorageStats,
+	}
+
+	controlCompactStorageCmd = &cobra.Command{
+		Use:   "compact-storage <runtime-id>",
+		Short: "compact the runtime's local storage and show size statistics",
+		Args:  cobra.ExactArgs(1),
+		Run:   doCompactStorage,
+	}
+
+	controlPauseRuntimeCmd = &cobra.Command{
+		Use:   "pause-runtime <runtime-id>",
+		Short: "pause hosting of the runtime without restarting the node",
+		Args:  cobra.ExactArgs(1),
+		Run:   doPauseRuntime,
+	}
+
+	controlResumeRuntimeCmd = &cobra.Command{
+		Use:   "resume-runtime <runtime-id>",
+		Short: "resume hosting of a paused runtime",
+		Args:  cobra.ExactArgs(1),
+		Run:   doResumeRuntime,
+	}
+
+	controlRestartRuntimeCmd = &cobra.Command{
+		Use:   "restart-runtime <runtime-id>",
+		Short: "restart the hosted runtime without restarting the node",
+		Args:  cobra.ExactArgs(1),
+		Run:   doRestartRuntime,
+	}
+
+	controlAttestationAuditLogCmd = &cobra.Command{
+		Use:   "attestation-audit-log <runtime-id>",
+		Short: "export the audit log of attestations produced by the node for the runtime",
+		Args:  cobra.ExactArgs(1),
+		Run:   doAttestationAuditLog,
+	}
+
+	controlSetLogLevelCmd = &cobra.Command{
+		Use:   "set-log-level <module> <level>",
+		Short: "change the log level of a module (or `default`) on the running node",
+		Args:  cobra.ExactArgs(2),
+		Run:   doSetLogLevel,
+	}
+
 	controlRuntimeStatsCmd = &cobra.Command{
 		Use:        "runtime-stats <runtime-id> [<start-height> [<end-height>]]",
 		Short:      "show runtime statistics",
@@ -146,7 +206,20 @@ func doShutdown(cmd *cobra.Command, _ []string) {
 	conn, client := DoConnect(cmd)
 	defer conn.Close()
 
-	err := client.RequestShutdown(context.Background(), shutdownWait)
+	var err error
+	switch shutdownGraceful {
+	case true:
+		err = client.RequestGracefulShutdown(context.Background(), &control.GracefulShutdownRequest{
+			Wait:       shutdownWait,
+			Deregister: shutdownDeregister,
+		})
+	default:
+		if shutdownDeregister {
+			logger.Error("deregister flag is only supported for graceful shutdown")
+			os.Exit(1)
+		}
+		err = client.RequestShutdown(context.Background(), shutdownWait)
+	}
 	if err != nil {
 		logger.Error("failed to send shutdown request",
 			"err", err,
@@ -297,11 +370,187 @@ func doStatus(cmd *cobra.Command, _ []string) {
 	fmt.Println(string(prettyStatus))
 }
 
+func doDiscrepancies(cmd *cobra.Command, args []string) {
+	var runtimeID common.Namespace
+	if err := runtimeID.UnmarshalHex(args[0]); err != nil {
+		logger.Error("malformed runtime ID",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	artifacts, err := client.GetDiscrepancyArtifacts(context.Background(), runtimeID)
+	if err != nil {
+		logger.Error("failed to query discrepancy artifacts",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	prettyArtifacts, err := cmdCommon.PrettyJSONMarshal(artifacts)
+	if err != nil {
+		logger.Error("failed to get pretty JSON of discrepancy artifacts",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	fmt.Println(string(prettyArtifacts))
+}
+
+func doStorageStats(cmd *cobra.Command, args []string) {
+	doRuntimeStorage(cmd, args[0], false)
+}
+
+func doCompactStorage(cmd *cobra.Command, args []string) {
+	doRuntimeStorage(cmd, args[0], true)
+}
+
+func doRuntimeStorage(cmd *cobra.Command, rawRuntimeID string, compact bool) {
+	var runtimeID common.Namespace
+	if err := runtimeID.UnmarshalHex(rawRuntimeID); err != nil {
+		logger.Error("malformed runtime ID",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	var (
+		stats *nodedb.SizeStats
+		err   error
+	)
+	if compact {
+		stats, err = client.CompactRuntimeStorage(context.Background(), runtimeID)
+	} else {
+		stats, err = client.GetRuntimeStorageStats(context.Background(), runtimeID)
+	}
+	if err != nil {
+		logger.Error("failed to query runtime storage",
+			"err", err,
+			"compact", compact,
+		)
+		os.Exit(1)
+	}
+
+	prettyStats, err := cmdCommon.PrettyJSONMarshal(stats)
+	if err != nil {
+		logger.Error("failed to get pretty JSON of runtime storage statistics",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	fmt.Println(string(prettyStats))
+}
+
+func doPauseRuntime(cmd *cobra.Command, args []string) {
+	doRuntimeLifecycle(cmd, args[0], "pause", func(client control.NodeController, runtimeID common.Namespace) error {
+		return client.PauseRuntime(context.Background(), runtimeID)
+	})
+}
+
+func doResumeRuntime(cmd *cobra.Command, args []string) {
+	doRuntimeLifecycle(cmd, args[0], "resume", func(client control.NodeController, runtimeID common.Namespace) error {
+		return client.ResumeRuntime(context.Background(), runtimeID)
+	})
+}
+
+func doRestartRuntime(cmd *cobra.Command, args []string) {
+	doRuntimeLifecycle(cmd, args[0], "restart", func(client control.NodeController, runtimeID common.Namespace) error {
+		return client.RestartRuntime(context.Background(), runtimeID)
+	})
+}
+
+func doRuntimeLifecycle(
+	cmd *cobra.Command,
+	rawRuntimeID string,
+	action string,
+	fn func(control.NodeController, common.Namespace) error,
+) {
+	var runtimeID common.Namespace
+	if err := runtimeID.UnmarshalHex(rawRuntimeID); err != nil {
+		logger.Error("malformed runtime ID",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	if err := fn(client, runtimeID); err != nil {
+		logger.Error("failed to "+action+" runtime",
+			"err", err,
+			"runtime_id", runtimeID,
+		)
+		os.Exit(1)
+	}
+}
+
+func doAttestationAuditLog(cmd *cobra.Command, args []string) {
+	var runtimeID common.Namespace
+	if err := runtimeID.UnmarshalHex(args[0]); err != nil {
+		logger.Error("malformed runtime ID",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	records, err := client.GetAttestationAuditLog(context.Background(), runtimeID)
+	if err != nil {
+		logger.Error("failed to query attestation audit log",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	prettyRecords, err := cmdCommon.PrettyJSONMarshal(records)
+	if err != nil {
+		logger.Error("failed to get pretty JSON of attestation audit log",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	fmt.Println(string(prettyRecords))
+}
+
+func doSetLogLevel(cmd *cobra.Command, args []string) {
+	req := control.SetLogLevelRequest{
+		Module: args[0],
+	}
+	if err := req.Level.Set(args[1]); err != nil {
+		logger.Error("malformed log level",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	conn, client := DoConnect(cmd)
+	defer conn.Close()
+
+	if err := client.SetLogLevel(context.Background(), &req); err != nil {
+		logger.Error("failed to set log level",
+			"err", err,
+			"module", req.Module,
+		)
+		os.Exit(1)
+	}
+}
+
 // Register registers the client sub-command and all of it's children.
 func Register(parentCmd *cobra.Command) {
 	controlCmd.PersistentFlags().AddFlagSet(cmdGrpc.ClientFlags)
 
 	controlShutdownCmd.Flags().BoolVarP(&shutdownWait, "wait", "w", false, "wait for the node to finish shutdown")
+	controlShutdownCmd.Flags().BoolVarP(&shutdownGraceful, "graceful", "g", false, "finish current committee rounds and shut down without waiting for the next epoch")
+	controlShutdownCmd.Flags().BoolVar(&shutdownDeregister, "deregister", false, "request deregistration in the next epoch on graceful shutdown")
 
 	controlCmd.AddCommand(controlIsSyncedCmd)
 	controlCmd.AddCommand(controlWaitSyncCmd)
@@ -310,6 +559,15 @@ func Register(parentCmd *cobra.Command) {
 	controlCmd.AddCommand(controlUpgradeBinaryCmd)
 	controlCmd.AddCommand(controlCancelUpgradeCmd)
 	controlCmd.AddCommand(controlStatusCmd)
+	controlCmd.AddCommand(controlDiscrepanciesCmd)
+	controlCmd.AddCommand(controlStorageStatsCmd)
+	controlCmd.AddCommand(controlCompactStorageCmd)
+	controlCmd.AddCommand(controlPauseRuntimeCmd)
+	controlCmd.AddCommand(controlResumeRuntimeCmd)
+	controlCmd.AddCommand(controlRestartRuntimeCmd)
+	controlCmd.AddCommand(controlAttestationAuditLogCmd)
+	controlCmd.AddCommand(controlSetLogLevelCmd)
 	controlCmd.AddCommand(controlRuntimeStatsCmd)
+	controlCmd.AddCommand(controlDutiesCmd)
 	parentCmd.AddCommand(controlCmd)
 }
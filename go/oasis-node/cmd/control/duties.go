@@ -0,0 +1,261 @@
+package control
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+
+	beaconAPI "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	consensusAPI "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	registryAPI "github.com/oasisprotocol/oasis-core/go/registry/api"
+	schedulerAPI "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+)
+
+const (
+	dutyRoleValidator = "validator"
+	dutyRoleEligible  = "eligible"
+)
+
+var controlDutiesCmd = &cobra.Command{
+	Use:   "duties",
+	Short: "show committee and validator roles of the node for the current and next epoch",
+	Run:   doDuties,
+}
+
+// duty is a single committee or validator role held by the node.
+type duty struct {
+	// Epoch is the epoch for which the duty was determined.
+	Epoch beaconAPI.EpochTime
+	// Runtime is the runtime the duty refers to, if any.
+	Runtime string
+	// Committee is the kind of the committee.
+	Committee string
+	// Role is the role of the node in the committee.
+	Role string
+	// CommitteeSize is the (maximum) size of the committee.
+	CommitteeSize int
+	// ValidFrom is the first epoch for which the duty is valid.
+	ValidFrom beaconAPI.EpochTime
+	// ValidTo is the last epoch for which the duty is valid.
+	ValidTo beaconAPI.EpochTime
+}
+
+func (d *duty) toRow() []string {
+	runtime := d.Runtime
+	if runtime == "" {
+		runtime = "-"
+	}
+	return []string{
+		strconv.FormatUint(uint64(d.Epoch), 10),
+		runtime,
+		d.Committee,
+		d.Role,
+		strconv.Itoa(d.CommitteeSize),
+		fmt.Sprintf("%d-%d", d.ValidFrom, d.ValidTo),
+	}
+}
+
+// currentDuties returns the duties that the node holds in the current epoch, based on the elected
+// committees and validators.
+func currentDuties(
+	nodeID signature.PublicKey,
+	epoch beaconAPI.EpochTime,
+	validators []*schedulerAPI.Validator,
+	committees []*schedulerAPI.Committee,
+) []*duty {
+	var duties []*duty
+	for _, v := range validators {
+		if !v.ID.Equal(nodeID) {
+			continue
+		}
+		duties = append(duties, &duty{
+			Epoch:         epoch,
+			Committee:     dutyRoleValidator,
+			Role:          dutyRoleValidator,
+			CommitteeSize: len(validators),
+			ValidFrom:     epoch,
+			ValidTo:       epoch,
+		})
+		break
+	}
+	for _, cmte := range committees {
+		for _, member := range cmte.Members {
+			if !member.PublicKey.Equal(nodeID) {
+				continue
+			}
+			duties = append(duties, &duty{
+				Epoch:         epoch,
+				Runtime:       cmte.RuntimeID.String(),
+				Committee:     cmte.Kind.String(),
+				Role:          member.Role.String(),
+				CommitteeSize: len(cmte.Members),
+				ValidFrom:     cmte.ValidFor,
+				ValidTo:       cmte.ValidFor,
+			})
+		}
+	}
+	return duties
+}
+
+// nextDuties returns the duties that the node is eligible for in the next epoch, based on its
+// registration. Committees for the next epoch are only elected at the epoch transition so the
+// committee sizes are the maximum sizes as configured at the current height.
+func nextDuties(
+	n *node.Node,
+	epoch beaconAPI.EpochTime,
+	schedulerParams *schedulerAPI.ConsensusParameters,
+	runtimes map[common.Namespace]*registryAPI.Runtime,
+) []*duty {
+	if n == nil || n.IsExpired(uint64(epoch)) {
+		return nil
+	}
+
+	var duties []*duty
+	if n.HasRoles(node.RoleValidator) {
+		duties = append(duties, &duty{
+			Epoch:         epoch,
+			Committee:     dutyRoleValidator,
+			Role:          dutyRoleEligible,
+			CommitteeSize: schedulerParams.MaxValidators,
+			ValidFrom:     epoch,
+			ValidTo:       beaconAPI.EpochTime(n.Expiration),
+		})
+	}
+	if n.HasRoles(node.RoleComputeWorker) {
+		for _, nodeRt := range n.Runtimes {
+			rt, ok := runtimes[nodeRt.ID]
+			if !ok || rt.Kind != registryAPI.KindCompute {
+				continue
+			}
+			duties = append(duties, &duty{
+				Epoch:         epoch,
+				Runtime:       nodeRt.ID.String(),
+				Committee:     schedulerAPI.KindComputeExecutor.String(),
+				Role:          dutyRoleEligible,
+				CommitteeSize: int(rt.Executor.GroupSize) + int(rt.Executor.GroupBackupSize),
+				ValidFrom:     epoch,
+				ValidTo:       beaconAPI.EpochTime(n.Expiration),
+			})
+		}
+	}
+	return duties
+}
+
+func doDuties(cmd *cobra.Command, _ []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	ctx := context.Background()
+
+	conn, client := doConnectOnly(cmd)
+	defer conn.Close()
+
+	status, err := client.GetStatus(ctx)
+	if err != nil {
+		logger.Error("failed to query status",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	nodeID := status.Identity.Node
+
+	// Perform all queries at the same height so that the results are consistent.
+	consensus := consensusAPI.NewConsensusClient(conn)
+	blk, err := consensus.GetBlock(ctx, consensusAPI.HeightLatest)
+	if err != nil {
+		logger.Error("failed to get latest block",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	height := blk.Height
+
+	duties, err := fetchDuties(ctx, conn, nodeID, height)
+	if err != nil {
+		logger.Error("failed to determine node duties",
+			"err", err,
+			"height", height,
+		)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Node: %s\n", nodeID)
+	fmt.Printf("Height: %d\n", height)
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+	table.SetHeader([]string{"Epoch", "Runtime", "Committee", "Role", "Committee size", "Epoch range"})
+	for _, d := range duties {
+		table.Append(d.toRow())
+	}
+	table.Render()
+}
+
+func fetchDuties(
+	ctx context.Context,
+	conn *grpc.ClientConn,
+	nodeID signature.PublicKey,
+	height int64,
+) ([]*duty, error) {
+	beacon := beaconAPI.NewBeaconClient(conn)
+	registry := registryAPI.NewRegistryClient(conn)
+	scheduler := schedulerAPI.NewSchedulerClient(conn)
+
+	epoch, err := beacon.GetEpoch(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query epoch: %w", err)
+	}
+	validators, err := scheduler.GetValidators(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query validators: %w", err)
+	}
+	schedulerParams, err := scheduler.ConsensusParameters(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query scheduler consensus parameters: %w", err)
+	}
+	rts, err := registry.GetRuntimes(ctx, &registryAPI.GetRuntimesQuery{Height: height})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query runtimes: %w", err)
+	}
+
+	runtimes := make(map[common.Namespace]*registryAPI.Runtime)
+	var committees []*schedulerAPI.Committee
+	for _, rt := range rts {
+		runtimes[rt.ID] = rt
+
+		var cmtes []*schedulerAPI.Committee
+		cmtes, err = scheduler.GetCommittees(ctx, &schedulerAPI.GetCommitteesRequest{
+			Height:    height,
+			RuntimeID: rt.ID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query committees of runtime %s: %w", rt.ID, err)
+		}
+		committees = append(committees, cmtes...)
+	}
+
+	n, err := registry.GetNode(ctx, &registryAPI.IDQuery{Height: height, ID: nodeID})
+	switch err {
+	case nil:
+	case registryAPI.ErrNoSuchNode:
+		// Node is not registered, so it is not eligible for any duties.
+		n = nil
+	default:
+		return nil, fmt.Errorf("failed to query node descriptor: %w", err)
+	}
+
+	duties := currentDuties(nodeID, epoch, validators, committees)
+	duties = append(duties, nextDuties(n, epoch+1, schedulerParams, runtimes)...)
+	return duties, nil
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
 	"github.com/oasisprotocol/oasis-core/go/runtime/bundle"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
 )
 
 const (
@@ -26,6 +27,9 @@ const (
 
 	CfgRuntimeBundle = "runtime.bundle"
 
+	CfgRuntimeSGXSignatures = "runtime.sgx.signatures"
+	CfgRuntimeOutputBundle  = "runtime.output_bundle"
+
 	execName    = "runtime.elf"
 	sgxExecName = "runtime.sgx"
 	sgxSigName  = "runtime.sgx.sig"
@@ -52,6 +56,18 @@ var (
 		Deprecated: "use `orc show` instead.",
 	}
 
+	migrateCmd = &cobra.Command{
+		Use:   "migrate",
+		Short: "convert a legacy runtime binary configuration into a runtime bundle",
+		RunE:  doMigrate,
+	}
+
+	sgxResignCmd = &cobra.Command{
+		Use:   "sgx-resign",
+		Short: "replace SGX signatures of runtime bundle components",
+		RunE:  doSGXResign,
+	}
+
 	logger = logging.GetLogger("cmd/debug/bundle")
 )
 
@@ -174,6 +190,168 @@ func doInfo(*cobra.Command, []string) error {
 	return nil
 }
 
+func doMigrate(*cobra.Command, []string) error {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	dstFn := viper.GetString(CfgRuntimeBundle)
+	if dstFn == "" {
+		logger.Error("missing runtime bundle name")
+		return fmt.Errorf("missing runtime bundle name")
+	}
+
+	var (
+		rt  bundle.LegacyRuntime
+		err error
+	)
+	rt.Name = viper.GetString(CfgRuntimeName)
+	if err = rt.ID.UnmarshalText([]byte(viper.GetString(CfgRuntimeID))); err != nil {
+		logger.Error("failed to parse runtime ID",
+			"err", err,
+		)
+		return err
+	}
+	if rt.Version, err = version.FromString(viper.GetString(CfgRuntimeVersion)); err != nil {
+		logger.Error("failed to parse runtime version",
+			"err", err,
+		)
+		return err
+	}
+
+	for _, v := range []struct {
+		fn, descr string
+		dst       *[]byte
+	}{
+		{viper.GetString(CfgRuntimeExecutable), "runtime ELF binary", &rt.Executable},
+		{viper.GetString(CfgRuntimeSGXExecutable), "runtime SGX binary", &rt.SGXExecutable},
+		{viper.GetString(CfgRuntimeSGXSignature), "runtime SGX signature", &rt.SGXSignature},
+	} {
+		if v.fn == "" {
+			continue
+		}
+		if *v.dst, err = os.ReadFile(v.fn); err != nil {
+			logger.Error("failed to load runtime asset",
+				"err", err,
+				"descr", v.descr,
+				"file_name", v.fn,
+			)
+			return err
+		}
+	}
+
+	// Create the bundle. This computes the digests and validates the bundle.
+	bnd, err := bundle.NewFromLegacyRuntime(&rt)
+	if err != nil {
+		logger.Error("failed to create runtime bundle",
+			"err", err,
+		)
+		return err
+	}
+	if err = bnd.Write(dstFn); err != nil {
+		logger.Error("failed to write runtime bundle",
+			"err", err,
+		)
+		return err
+	}
+
+	if comp := bnd.Manifest.GetComponentByID(component.ID_RONL); comp.SGX != nil && comp.SGX.Signature != "" {
+		eid, err := bnd.EnclaveIdentity(component.ID_RONL)
+		if err != nil {
+			logger.Error("failed to derive enclave identity",
+				"err", err,
+			)
+			return err
+		}
+		fmt.Printf("Component %s: MRENCLAVE=%s MRSIGNER=%s\n", component.ID_RONL, eid.MrEnclave, eid.MrSigner)
+	}
+	fmt.Printf("Manifest hash: %s\n", bnd.Manifest.Hash())
+
+	return nil
+}
+
+func doSGXResign(*cobra.Command, []string) error {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	dstFn := viper.GetString(CfgRuntimeOutputBundle)
+	if dstFn == "" {
+		logger.Error("missing output runtime bundle name")
+		return fmt.Errorf("missing output runtime bundle name")
+	}
+	sigs := viper.GetStringMapString(CfgRuntimeSGXSignatures)
+	if len(sigs) == 0 {
+		logger.Error("no SGX signatures specified")
+		return fmt.Errorf("no SGX signatures specified")
+	}
+
+	fn := viper.GetString(CfgRuntimeBundle)
+	bnd, err := bundle.Open(fn)
+	if err != nil {
+		logger.Error("failed to open bundle",
+			"err", err,
+			"file_name", fn,
+		)
+		return err
+	}
+	defer bnd.Close()
+
+	// Replace the signatures, making sure that they match the enclaves.
+	var ids []component.ID
+	for rawID, sigFn := range sigs {
+		var id component.ID
+		if err = id.UnmarshalText([]byte(rawID)); err != nil {
+			logger.Error("failed to parse component ID",
+				"err", err,
+				"component_id", rawID,
+			)
+			return err
+		}
+
+		var sig []byte
+		if sig, err = os.ReadFile(sigFn); err != nil {
+			logger.Error("failed to load SGX signature",
+				"err", err,
+				"component_id", id,
+				"file_name", sigFn,
+			)
+			return err
+		}
+		if err = bnd.SetSGXSignature(id, sig); err != nil {
+			logger.Error("failed to set SGX signature",
+				"err", err,
+				"component_id", id,
+			)
+			return err
+		}
+		ids = append(ids, id)
+	}
+
+	// Write the bundle. This recomputes the digests and validates the bundle.
+	if err = bnd.Write(dstFn); err != nil {
+		logger.Error("failed to write runtime bundle",
+			"err", err,
+		)
+		return err
+	}
+
+	for _, id := range ids {
+		eid, err := bnd.EnclaveIdentity(id)
+		if err != nil {
+			logger.Error("failed to derive enclave identity",
+				"err", err,
+				"component_id", id,
+			)
+			return err
+		}
+		fmt.Printf("Component %s: MRENCLAVE=%s MRSIGNER=%s\n", id, eid.MrEnclave, eid.MrSigner)
+	}
+	fmt.Printf("Manifest hash: %s\n", bnd.Manifest.Hash())
+
+	return nil
+}
+
 // Register registers the bundle sub-command and all of it's children.
 func Register(parentCmd *cobra.Command) {
 	commonFlags := flag.NewFlagSet("", flag.ContinueOnError)
@@ -189,10 +367,19 @@ func Register(parentCmd *cobra.Command) {
 	initFlags.String(CfgRuntimeSGXSignature, "", "path to runtime SGX signature")
 	_ = viper.BindPFlags(initFlags)
 	initCmd.Flags().AddFlagSet(initFlags)
+	migrateCmd.Flags().AddFlagSet(initFlags)
+
+	sgxResignFlags := flag.NewFlagSet("", flag.ContinueOnError)
+	sgxResignFlags.StringToString(CfgRuntimeSGXSignatures, map[string]string{}, "SGX signatures to use (component ID to SIGSTRUCT path, e.g. ronl=runtime.sgx.sig)")
+	sgxResignFlags.String(CfgRuntimeOutputBundle, "", "path to the output runtime bundle")
+	_ = viper.BindPFlags(sgxResignFlags)
+	sgxResignCmd.Flags().AddFlagSet(sgxResignFlags)
 
 	for _, cmd := range []*cobra.Command{
 		initCmd,
 		infoCmd,
+		migrateCmd,
+		sgxResignCmd,
 	} {
 		cmd.Flags().AddFlagSet(commonFlags)
 		bundleCmd.AddCommand(cmd)
@@ -0,0 +1,226 @@
+// Package consensus implements the consensus debug sub-commands.
+package consensus
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/full"
+	genesisFile "github.com/oasisprotocol/oasis-core/go/genesis/file"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+	cmdGrpc "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/grpc"
+)
+
+const (
+	// CfgCheckpointHeight is the flag used to specify the height of the exported checkpoint.
+	CfgCheckpointHeight = "checkpoint.height"
+	// CfgCheckpointFile is the flag used to specify the checkpoint file.
+	CfgCheckpointFile = "checkpoint.file"
+	// CfgCheckpointTrustHash is the flag used to specify the trusted block hash at the checkpoint
+	// height.
+	CfgCheckpointTrustHash = "checkpoint.trust_hash"
+)
+
+var (
+	consensusCmd = &cobra.Command{
+		Use:   "consensus",
+		Short: "consensus debug utilities",
+	}
+
+	exportCheckpointCmd = &cobra.Command{
+		Use:   "export-checkpoint",
+		Short: "export a verified consensus state checkpoint to a portable file",
+		Run:   doExportCheckpoint,
+	}
+
+	importCheckpointCmd = &cobra.Command{
+		Use:   "import-checkpoint",
+		Short: "verify and stage a portable consensus checkpoint for import into a fresh node",
+		Run:   doImportCheckpoint,
+	}
+
+	checkpointFileFlags   = flag.NewFlagSet("", flag.ContinueOnError)
+	exportCheckpointFlags = flag.NewFlagSet("", flag.ContinueOnError)
+	importCheckpointFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	logger = logging.GetLogger("cmd/debug/consensus")
+)
+
+func doExportCheckpoint(cmd *cobra.Command, _ []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	conn, err := cmdGrpc.NewClient(cmd)
+	if err != nil {
+		logger.Error("failed to establish connection with node",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	defer conn.Close()
+
+	client := consensus.NewConsensusClient(conn)
+
+	path := viper.GetString(CfgCheckpointFile)
+	f, err := os.Create(path)
+	if err != nil {
+		logger.Error("failed to create checkpoint file",
+			"err", err,
+			"path", path,
+		)
+		os.Exit(1)
+	}
+
+	hdr, err := full.ExportCheckpoint(context.Background(), client, viper.GetInt64(CfgCheckpointHeight), f)
+	if err == nil {
+		err = f.Close()
+	}
+	if err != nil {
+		logger.Error("failed to export checkpoint",
+			"err", err,
+		)
+		f.Close()
+		_ = os.Remove(path)
+		os.Exit(1)
+	}
+
+	// The header has been verified during export so this cannot fail.
+	blockHash, _ := hdr.BlockHash()
+
+	fmt.Printf("Exported checkpoint at height %d to %s.\n", hdr.Checkpoint.Root.Version, path)
+	fmt.Printf("Block hash: %s\n", blockHash)
+}
+
+func doImportCheckpoint(*cobra.Command, []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	if err := importCheckpoint(); err != nil {
+		logger.Error("failed to import checkpoint",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+}
+
+func importCheckpoint() error {
+	dataDir := cmdCommon.DataDir()
+	if dataDir == "" {
+		return fmt.Errorf("data directory must be set")
+	}
+
+	fp, err := genesisFile.NewFileProvider(flags.GenesisFile())
+	if err != nil {
+		return fmt.Errorf("failed to load genesis document: %w", err)
+	}
+	genesisDoc, err := fp.GetGenesisDocument()
+	if err != nil {
+		return fmt.Errorf("failed to get genesis document: %w", err)
+	}
+
+	rawTrustHash := viper.GetString(CfgCheckpointTrustHash)
+	if rawTrustHash == "" {
+		return fmt.Errorf("trusted block hash must be set")
+	}
+	trustHash, err := hex.DecodeString(rawTrustHash)
+	if err != nil {
+		return fmt.Errorf("malformed trust hash: %w", err)
+	}
+
+	// Verify the whole file before staging it.
+	src, err := os.Open(viper.GetString(CfgCheckpointFile))
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	cr, err := full.OpenCheckpointFile(src, genesisDoc.ChainContext(), trustHash)
+	if err != nil {
+		return err
+	}
+	for {
+		if _, _, err = cr.NextChunk(); err != nil {
+			break
+		}
+	}
+	if !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	// Stage the verified file so that it gets imported on the next node start.
+	dst := full.CheckpointImportPath(dataDir)
+	if err = common.Mkdir(filepath.Dir(dst)); err != nil {
+		return err
+	}
+	if _, err = src.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	f, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(f, src); err != nil {
+		f.Close()
+		_ = os.Remove(dst)
+		return err
+	}
+	if err = f.Close(); err != nil {
+		_ = os.Remove(dst)
+		return err
+	}
+
+	// Stage the trusted block hash so that the file is verified again on import.
+	if err = os.WriteFile(full.CheckpointImportTrustHashPath(dataDir), []byte(hex.EncodeToString(trustHash)), 0o600); err != nil {
+		_ = os.Remove(dst)
+		return err
+	}
+
+	fmt.Printf("Checkpoint at height %d staged for import on next node start.\n", cr.Header().Checkpoint.Root.Version)
+	fmt.Printf("Block hash: %s\n", cr.BlockHash())
+
+	return nil
+}
+
+// Register registers the consensus sub-command and all of its children.
+func Register(parentCmd *cobra.Command) {
+	exportCheckpointCmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
+	exportCheckpointCmd.Flags().AddFlagSet(checkpointFileFlags)
+	exportCheckpointCmd.Flags().AddFlagSet(exportCheckpointFlags)
+
+	importCheckpointCmd.Flags().AddFlagSet(flags.GenesisFileFlags)
+	importCheckpointCmd.Flags().AddFlagSet(checkpointFileFlags)
+	importCheckpointCmd.Flags().AddFlagSet(importCheckpointFlags)
+
+	stateDiffCmd.Flags().AddFlagSet(stateDiffFlags)
+
+	consensusCmd.AddCommand(exportCheckpointCmd)
+	consensusCmd.AddCommand(importCheckpointCmd)
+	consensusCmd.AddCommand(stateDiffCmd)
+	parentCmd.AddCommand(consensusCmd)
+}
+
+func init() {
+	checkpointFileFlags.String(CfgCheckpointFile, "checkpoint.bin", "path to checkpoint file")
+	_ = viper.BindPFlags(checkpointFileFlags)
+
+	exportCheckpointFlags.Int64(CfgCheckpointHeight, consensus.HeightLatest, "checkpoint height (0 = latest available)")
+	_ = viper.BindPFlags(exportCheckpointFlags)
+
+	importCheckpointFlags.String(CfgCheckpointTrustHash, "", "trusted hex-encoded block hash at the checkpoint height (required)")
+	_ = viper.BindPFlags(importCheckpointFlags)
+}
@@ -0,0 +1,402 @@
+package consensus
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/entity"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/config"
+	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft/abci"
+	cmtCommon "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/common"
+	consensusGenesis "github.com/oasisprotocol/oasis-core/go/consensus/genesis"
+	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/churp"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/secrets"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/message"
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	vault "github.com/oasisprotocol/oasis-core/go/vault/api"
+)
+
+const (
+	// CfgStateDiffReadOnlyDB configures read-only access to the consensus state database.
+	CfgStateDiffReadOnlyDB = "state_diff.read_only_db"
+	// CfgStateDiffApps configures the applications whose state changes should be reported.
+	CfgStateDiffApps = "state_diff.apps"
+)
+
+var (
+	stateDiffFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	stateDiffCmd = &cobra.Command{
+		Use:   "state-diff <height1> <height2>",
+		Short: "report consensus state changes between two heights",
+		Long: `Walk the ABCI application state trees at the two given heights and report
+which keys were added, removed or modified. Values of keys with a known schema
+are CBOR-decoded and shown as JSON, other values are shown in hex. The node
+must not be running and both heights must not have been pruned.`,
+		Args: cobra.ExactArgs(2),
+		Run:  doStateDiff,
+	}
+)
+
+// stateKeySchema describes a consensus state key prefix.
+type stateKeySchema struct {
+	app  string
+	name string
+	// value returns a new instance of the type that values under the prefix decode into or nil
+	// in case values are not CBOR-encoded.
+	value func() interface{}
+}
+
+// stateKeySchemas are the known consensus state key prefixes.
+var stateKeySchemas = map[byte]*stateKeySchema{
+	// Registry.
+	0x10: {"registry", "entity", func() interface{} { return new(entity.SignedEntity) }},
+	0x11: {"registry", "node", func() interface{} { return new(node.MultiSignedNode) }},
+	0x12: {"registry", "node_by_entity", nil},
+	0x13: {"registry", "runtime", func() interface{} { return new(registry.Runtime) }},
+	0x14: {"registry", "node_by_consensus_address", nil},
+	0x15: {"registry", "node_status", func() interface{} { return new(registry.NodeStatus) }},
+	0x16: {"registry", "parameters", func() interface{} { return new(registry.ConsensusParameters) }},
+	0x17: {"registry", "key_map", nil},
+	0x18: {"registry", "suspended_runtime", func() interface{} { return new(registry.Runtime) }},
+	0x19: {"registry", "runtime_by_entity", nil},
+	0x1a: {"registry", "runtime_tee_history", nil},
+	0x1b: {"registry", "node_by_runtime", nil},
+	0x1c: {"registry", "frozen_entity", nil},
+	// Root hash.
+	0x20: {"roothash", "runtime_state", func() interface{} { return new(roothash.RuntimeState) }},
+	0x21: {"roothash", "parameters", func() interface{} { return new(roothash.ConsensusParameters) }},
+	0x22: {"roothash", "round_timeout_queue", nil},
+	0x24: {"roothash", "evidence", nil},
+	0x25: {"roothash", "state_root", nil},
+	0x26: {"roothash", "io_root", nil},
+	0x27: {"roothash", "last_round_results", func() interface{} { return new(roothash.RoundResults) }},
+	0x28: {"roothash", "incoming_message_queue_meta", func() interface{} { return new(message.IncomingMessageQueueMeta) }},
+	0x29: {"roothash", "incoming_message_queue", func() interface{} { return new(message.IncomingMessage) }},
+	0x2a: {"roothash", "past_roots", nil},
+	0x2b: {"roothash", "runtime_statistics", func() interface{} { return new(roothash.RuntimeStatistics) }},
+	// Vault.
+	0x30: {"vault", "vault", func() interface{} { return new(vault.Vault) }},
+	0x31: {"vault", "address_state", func() interface{} { return new(vault.AddressState) }},
+	0x32: {"vault", "pending_action", func() interface{} { return new(vault.PendingAction) }},
+	0x33: {"vault", "parameters", func() interface{} { return new(vault.ConsensusParameters) }},
+	// Beacon.
+	0x40: {"beacon", "epoch_current", func() interface{} { return new(beacon.EpochTimeState) }},
+	0x41: {"beacon", "epoch_future", func() interface{} { return new(beacon.EpochTimeState) }},
+	0x42: {"beacon", "beacon", nil},
+	0x43: {"beacon", "parameters", func() interface{} { return new(beacon.ConsensusParameters) }},
+	0x45: {"beacon", "epoch_pending_mock", nil},
+	0x46: {"beacon", "vrf_state", func() interface{} { return new(beacon.VRFState) }},
+	0x47: {"beacon", "external_state", nil},
+	// Staking.
+	0x50: {"staking", "account", func() interface{} { return new(staking.Account) }},
+	0x51: {"staking", "total_supply", func() interface{} { return new(quantity.Quantity) }},
+	0x52: {"staking", "common_pool", func() interface{} { return new(quantity.Quantity) }},
+	0x53: {"staking", "delegation", func() interface{} { return new(staking.Delegation) }},
+	0x54: {"staking", "debonding_delegation", func() interface{} { return new(staking.DebondingDelegation) }},
+	0x55: {"staking", "debonding_queue", nil},
+	0x56: {"staking", "parameters", func() interface{} { return new(staking.ConsensusParameters) }},
+	0x57: {"staking", "last_block_fees", func() interface{} { return new(quantity.Quantity) }},
+	0x58: {"staking", "epoch_signing", nil},
+	0x59: {"staking", "governance_deposits", func() interface{} { return new(quantity.Quantity) }},
+	0x5a: {"staking", "delegation_reverse", nil},
+	0x5b: {"staking", "commission_schedule_addresses", nil},
+	0x5c: {"staking", "delegation_history", nil},
+	0x5d: {"staking", "delegation_history_reverse", nil},
+	0x5e: {"staking", "reward_history", nil},
+	0x5f: {"staking", "debonding_transfer", nil},
+	// Scheduler.
+	0x60: {"scheduler", "committee", func() interface{} { return new(scheduler.Committee) }},
+	0x61: {"scheduler", "validators_current", nil},
+	0x62: {"scheduler", "validators_pending", nil},
+	0x63: {"scheduler", "parameters", func() interface{} { return new(scheduler.ConsensusParameters) }},
+	// Key manager.
+	0x70: {"keymanager", "secrets_status", func() interface{} { return new(secrets.Status) }},
+	0x71: {"keymanager", "secrets_parameters", func() interface{} { return new(secrets.ConsensusParameters) }},
+	0x72: {"keymanager", "master_secret", func() interface{} { return new(secrets.SignedEncryptedMasterSecret) }},
+	0x73: {"keymanager", "ephemeral_secret", func() interface{} { return new(secrets.SignedEncryptedEphemeralSecret) }},
+	0x74: {"keymanager", "churp_parameters", func() interface{} { return new(churp.ConsensusParameters) }},
+	0x75: {"keymanager", "churp_status", func() interface{} { return new(churp.Status) }},
+	// Governance.
+	0x80: {"governance", "next_proposal_identifier", func() interface{} { return new(uint64) }},
+	0x81: {"governance", "proposal", func() interface{} { return new(governance.Proposal) }},
+	0x82: {"governance", "active_proposal", nil},
+	0x83: {"governance", "vote", func() interface{} { return new(governance.Vote) }},
+	0x84: {"governance", "pending_upgrade", nil},
+	0x85: {"governance", "parameters", func() interface{} { return new(governance.ConsensusParameters) }},
+	// Consensus.
+	0xf0: {"consensus", "chain_context", nil},
+	0xf1: {"consensus", "parameters", func() interface{} { return new(consensusGenesis.Parameters) }},
+}
+
+// stateKeyApps maps the high nibble of a consensus state key prefix to the owning application.
+var stateKeyApps = map[byte]string{
+	0x10: "registry",
+	0x20: "roothash",
+	0x30: "vault",
+	0x40: "beacon",
+	0x50: "staking",
+	0x60: "scheduler",
+	0x70: "keymanager",
+	0x80: "governance",
+	0xf0: "consensus",
+}
+
+// stateKeyInfo returns the application and the name of the given consensus state key.
+func stateKeyInfo(key []byte) (string, string) {
+	if len(key) == 0 {
+		return "unknown", "unknown"
+	}
+	if s, ok := stateKeySchemas[key[0]]; ok {
+		return s.app, s.name
+	}
+	app, ok := stateKeyApps[key[0]&0xf0]
+	if !ok {
+		app = "unknown"
+	}
+	return app, fmt.Sprintf("0x%02x", key[0])
+}
+
+// decodeStateValue decodes the value stored under the given consensus state key if its schema
+// is known. In case the schema is not known or decoding fails, the raw value is returned.
+func decodeStateValue(key, value []byte) (interface{}, bool) {
+	if len(key) == 0 {
+		return value, false
+	}
+	s, ok := stateKeySchemas[key[0]]
+	if !ok || s.value == nil {
+		return value, false
+	}
+	v := s.value()
+	if err := cbor.Unmarshal(value, v); err != nil {
+		return value, false
+	}
+	return v, true
+}
+
+// stateDiffEntry is a single changed consensus state key.
+type stateDiffEntry struct {
+	Key []byte
+	// Old is the value at the first height or nil in case the key has been added.
+	Old []byte
+	// New is the value at the second height or nil in case the key has been removed.
+	New []byte
+}
+
+func (e *stateDiffEntry) kind() string {
+	switch {
+	case e.Old == nil:
+		return "+"
+	case e.New == nil:
+		return "-"
+	default:
+		return "~"
+	}
+}
+
+// diffStateTrees walks both trees in key order and returns all keys whose values differ.
+func diffStateTrees(ctx context.Context, oldTree, newTree mkvs.ImmutableKeyValueTree) ([]*stateDiffEntry, error) {
+	oldIt := oldTree.NewIterator(ctx)
+	defer oldIt.Close()
+	newIt := newTree.NewIterator(ctx)
+	defer newIt.Close()
+
+	oldIt.Rewind()
+	newIt.Rewind()
+
+	var entries []*stateDiffEntry
+	for oldIt.Valid() || newIt.Valid() {
+		var cmp int
+		switch {
+		case !oldIt.Valid():
+			cmp = 1
+		case !newIt.Valid():
+			cmp = -1
+		default:
+			cmp = bytes.Compare(oldIt.Key(), newIt.Key())
+		}
+
+		switch {
+		case cmp < 0:
+			entries = append(entries, &stateDiffEntry{
+				Key: append([]byte{}, oldIt.Key()...),
+				Old: append([]byte{}, oldIt.Value()...),
+			})
+			oldIt.Next()
+		case cmp > 0:
+			entries = append(entries, &stateDiffEntry{
+				Key: append([]byte{}, newIt.Key()...),
+				New: append([]byte{}, newIt.Value()...),
+			})
+			newIt.Next()
+		default:
+			if !bytes.Equal(oldIt.Value(), newIt.Value()) {
+				entries = append(entries, &stateDiffEntry{
+					Key: append([]byte{}, oldIt.Key()...),
+					Old: append([]byte{}, oldIt.Value()...),
+					New: append([]byte{}, newIt.Value()...),
+				})
+			}
+			oldIt.Next()
+			newIt.Next()
+		}
+	}
+	if err := oldIt.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over old state: %w", err)
+	}
+	if err := newIt.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over new state: %w", err)
+	}
+	return entries, nil
+}
+
+func formatStateValue(key, value []byte) string {
+	if len(value) == 0 {
+		return "(empty)"
+	}
+	v, ok := decodeStateValue(key, value)
+	if !ok {
+		return hex.EncodeToString(value)
+	}
+	pretty, err := cmdCommon.PrettyJSONMarshal(v)
+	if err != nil {
+		return hex.EncodeToString(value)
+	}
+	return strings.ReplaceAll(string(pretty), "\n", "\n      ")
+}
+
+func openStateTree(ndb storage.NodeDB, height int64) (mkvs.Tree, error) {
+	roots, err := ndb.GetRootsForVersion(uint64(height))
+	if err != nil {
+		return nil, err
+	}
+	if len(roots) != 1 {
+		return nil, fmt.Errorf("state at height %d not available (roots: %d)", height, len(roots))
+	}
+	return mkvs.NewWithRoot(nil, ndb, roots[0], mkvs.WithoutWriteLog()), nil
+}
+
+func doStateDiff(_ *cobra.Command, args []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	var heights [2]int64
+	for i, arg := range args {
+		height, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil || height <= 0 {
+			logger.Error("malformed height",
+				"height", arg,
+			)
+			os.Exit(1)
+		}
+		heights[i] = height
+	}
+
+	apps := make(map[string]bool)
+	for _, app := range viper.GetStringSlice(CfgStateDiffApps) {
+		apps[app] = true
+	}
+
+	dataDir := cmdCommon.DataDir()
+	if dataDir == "" {
+		logger.Error("data directory must be set")
+		os.Exit(1)
+	}
+
+	ldb, ndb, stateRoot, err := abci.InitStateStorage(
+		&abci.ApplicationConfig{
+			DataDir:             filepath.Join(dataDir, cmtCommon.StateDir),
+			StorageBackend:      config.GlobalConfig.Storage.Backend,
+			ReadOnlyStorage:     viper.GetBool(CfgStateDiffReadOnlyDB),
+			DisableCheckpointer: true,
+		},
+	)
+	if err != nil {
+		logger.Error("failed to initialize ABCI storage backend",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	defer ldb.Cleanup()
+
+	var trees [2]mkvs.Tree
+	for i, height := range heights {
+		if height > int64(stateRoot.Version) {
+			logger.Error("state diff requested for height that does not exist",
+				"height", height,
+				"latest_height", stateRoot.Version,
+			)
+			os.Exit(1)
+		}
+		if trees[i], err = openStateTree(ndb, height); err != nil {
+			logger.Error("failed to open state tree",
+				"err", err,
+				"height", height,
+			)
+			os.Exit(1)
+		}
+		defer trees[i].Close()
+	}
+
+	entries, err := diffStateTrees(context.Background(), trees[0], trees[1])
+	if err != nil {
+		logger.Error("failed to diff state",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	var added, removed, modified int
+	for _, e := range entries {
+		app, name := stateKeyInfo(e.Key)
+		if len(apps) > 0 && !apps[app] {
+			continue
+		}
+
+		switch e.kind() {
+		case "+":
+			added++
+		case "-":
+			removed++
+		default:
+			modified++
+		}
+
+		fmt.Printf("%s %s/%s %s\n", e.kind(), app, name, hex.EncodeToString(e.Key))
+		if e.Old != nil {
+			fmt.Printf("    old: %s\n", formatStateValue(e.Key, e.Old))
+		}
+		if e.New != nil {
+			fmt.Printf("    new: %s\n", formatStateValue(e.Key, e.New))
+		}
+	}
+	fmt.Printf("Heights %d..%d: %d added, %d removed, %d modified\n", heights[0], heights[1], added, removed, modified)
+}
+
+func init() {
+	stateDiffFlags.Bool(CfgStateDiffReadOnlyDB, false, "read-only DB access")
+	stateDiffFlags.StringSlice(CfgStateDiffApps, []string{}, "only report changes of the given applications (default: all)")
+	_ = viper.BindPFlags(stateDiffFlags)
+}
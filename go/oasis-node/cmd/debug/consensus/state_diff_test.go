@@ -0,0 +1,81 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+)
+
+func newTestTree(ctx context.Context, t *testing.T, kvs map[string][]byte) mkvs.Tree {
+	tree := mkvs.New(nil, nil, node.RootTypeState)
+	for k, v := range kvs {
+		require.NoError(t, tree.Insert(ctx, []byte(k), v))
+	}
+	return tree
+}
+
+func TestDiffStateTrees(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	oldTree := newTestTree(ctx, t, map[string][]byte{
+		"\x10a": []byte("unchanged"),
+		"\x10b": []byte("removed"),
+		"\x51":  []byte("old"),
+	})
+	defer oldTree.Close()
+	newTree := newTestTree(ctx, t, map[string][]byte{
+		"\x10a": []byte("unchanged"),
+		"\x11c": []byte("added"),
+		"\x51":  []byte("new"),
+	})
+	defer newTree.Close()
+
+	entries, err := diffStateTrees(ctx, oldTree, newTree)
+	require.NoError(err)
+	require.Equal([]*stateDiffEntry{
+		{Key: []byte("\x10b"), Old: []byte("removed")},
+		{Key: []byte("\x11c"), New: []byte("added")},
+		{Key: []byte("\x51"), Old: []byte("old"), New: []byte("new")},
+	}, entries)
+	require.Equal("-", entries[0].kind())
+	require.Equal("+", entries[1].kind())
+	require.Equal("~", entries[2].kind())
+
+	entries, err = diffStateTrees(ctx, oldTree, oldTree)
+	require.NoError(err)
+	require.Empty(entries)
+}
+
+func TestDecodeStateValue(t *testing.T) {
+	require := require.New(t)
+
+	q := quantity.NewFromUint64(42)
+	v, ok := decodeStateValue([]byte{0x51}, cbor.Marshal(q))
+	require.True(ok)
+	require.Equal(q, v)
+
+	raw := []byte{0xde, 0xad}
+	v, ok = decodeStateValue([]byte{0x12, 0x01}, raw)
+	require.False(ok, "values of index keys should not be decoded")
+	require.Equal(raw, v)
+
+	v, ok = decodeStateValue([]byte{0x51}, raw)
+	require.False(ok, "malformed values should not be decoded")
+	require.Equal(raw, v)
+
+	app, name := stateKeyInfo([]byte{0x53, 0x01})
+	require.Equal("staking", app)
+	require.Equal("delegation", name)
+	app, name = stateKeyInfo([]byte{0x3f})
+	require.Equal("vault", app)
+	require.Equal("0x3f", name)
+	app, _ = stateKeyInfo([]byte{0x01})
+	require.Equal("unknown", app)
+}
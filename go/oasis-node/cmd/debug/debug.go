@@ -7,8 +7,11 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/beacon"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/bundle"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/byzantine"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/consensus"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/control"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/dumpdb"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/replay"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/rhpcapture"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/storage"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/debug/txsource"
 )
@@ -28,6 +31,9 @@ func Register(parentCmd *cobra.Command) {
 	dumpdb.Register(debugCmd)
 	beacon.Register(debugCmd)
 	bundle.Register(debugCmd)
+	consensus.Register(debugCmd)
+	replay.Register(debugCmd)
+	rhpcapture.Register(debugCmd)
 
 	parentCmd.AddCommand(debugCmd)
 }
@@ -0,0 +1,204 @@
+// Package replay implements the runtime round replay debug sub-command.
+package replay
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+	hostReplay "github.com/oasisprotocol/oasis-core/go/runtime/host/replay"
+	hostSandbox "github.com/oasisprotocol/oasis-core/go/runtime/host/sandbox"
+)
+
+const (
+	// CfgReplayFile is the flag used to specify the path to the recorded runtime round.
+	CfgReplayFile = "replay.file"
+	// CfgReplayBundle is the flag used to specify the path to the runtime bundle.
+	CfgReplayBundle = "replay.bundle"
+	// CfgReplaySandboxBinary is the flag used to specify the path to the sandbox binary. If not
+	// set, the runtime is executed without a sandbox.
+	CfgReplaySandboxBinary = "replay.sandbox_binary"
+	// CfgReplayTimeout is the flag used to specify the replay timeout.
+	CfgReplayTimeout = "replay.timeout"
+)
+
+var (
+	replayCmd = &cobra.Command{
+		Use:   "replay",
+		Short: "re-execute a recorded runtime round against a local runtime binary",
+		Run:   doReplay,
+	}
+
+	replayFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	logger = logging.GetLogger("cmd/debug/replay")
+)
+
+func doReplay(*cobra.Command, []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	if err := replay(); err != nil {
+		logger.Error("failed to replay runtime round",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+}
+
+func replay() error {
+	rec, err := hostReplay.ReadFile(viper.GetString(CfgReplayFile))
+	if err != nil {
+		return err
+	}
+	if rec.HostInfo == nil {
+		return fmt.Errorf("recording is missing host information")
+	}
+
+	bnd, err := bundle.Open(viper.GetString(CfgReplayBundle))
+	if err != nil {
+		return fmt.Errorf("failed to open runtime bundle: %w", err)
+	}
+	defer bnd.Close()
+
+	if bnd.Manifest.ID != rec.RuntimeID {
+		return fmt.Errorf("runtime bundle is for a different runtime (expected: %s got: %s)", rec.RuntimeID, bnd.Manifest.ID)
+	}
+	if bnd.Manifest.Version != rec.RuntimeVersion {
+		logger.Warn("runtime bundle version differs from the recorded version",
+			"recorded", rec.RuntimeVersion,
+			"bundle", bnd.Manifest.Version,
+		)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "oasis-replay")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err = bnd.WriteExploded(tmpDir); err != nil {
+		return fmt.Errorf("failed to explode runtime bundle: %w", err)
+	}
+
+	sandboxBinary := viper.GetString(CfgReplaySandboxBinary)
+	provisioner, err := hostSandbox.New(hostSandbox.Config{
+		HostInfo:          rec.HostInfo,
+		SandboxBinaryPath: sandboxBinary,
+		InsecureNoSandbox: sandboxBinary == "",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create runtime provisioner: %w", err)
+	}
+
+	player := hostReplay.NewPlayer(rec)
+	rt, err := provisioner.NewRuntime(host.Config{
+		Bundle: &host.RuntimeBundle{
+			Bundle:          bnd,
+			ExplodedDataDir: tmpDir,
+		},
+		Components:     []component.ID{component.ID_RONL},
+		MessageHandler: player,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to provision runtime: %w", err)
+	}
+
+	evCh, sub := rt.WatchEvents()
+	defer sub.Close()
+
+	rt.Start()
+	defer rt.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration(CfgReplayTimeout))
+	defer cancel()
+
+	if err = waitStarted(ctx, evCh); err != nil {
+		return err
+	}
+
+	logger.Info("replaying runtime round",
+		"round", rec.Round,
+		"request", rec.Request.Type(),
+		"host_calls", len(rec.HostCalls),
+	)
+
+	rsp, err := rt.Call(ctx, rec.Request)
+	if missing := player.Missing(); len(missing) > 0 {
+		logger.Warn("runtime made host calls that were not recorded",
+			"missing", missing,
+		)
+	}
+
+	if cerr := rec.Compare(rsp, err); cerr != nil {
+		printResults(rec.Response, rsp)
+		return cerr
+	}
+
+	fmt.Printf("Replay of round %d matches the recording.\n", rec.Round)
+	return nil
+}
+
+func waitStarted(ctx context.Context, evCh <-chan *host.Event) error {
+	for {
+		select {
+		case ev := <-evCh:
+			switch {
+			case ev.Started != nil:
+				return nil
+			case ev.FailedToStart != nil:
+				return fmt.Errorf("runtime failed to start: %w", ev.FailedToStart.Error)
+			}
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for the runtime to start")
+		}
+	}
+}
+
+func printResults(recorded, replayed *protocol.Body) {
+	for _, r := range []struct {
+		name string
+		body *protocol.Body
+	}{
+		{"Recorded", recorded},
+		{"Replayed", replayed},
+	} {
+		var out interface{} = r.body
+		if r.body != nil && r.body.RuntimeExecuteTxBatchResponse != nil {
+			// Only show the computed results header as the rest is usually too verbose.
+			out = r.body.RuntimeExecuteTxBatchResponse.Batch.Header
+		}
+
+		prettyJSON, err := cmdCommon.PrettyJSONMarshal(out)
+		if err != nil {
+			continue
+		}
+		fmt.Printf("%s:\n%s\n", r.name, prettyJSON)
+	}
+}
+
+// Register registers the replay sub-command.
+func Register(parentCmd *cobra.Command) {
+	replayCmd.Flags().AddFlagSet(replayFlags)
+	parentCmd.AddCommand(replayCmd)
+}
+
+func init() {
+	replayFlags.String(CfgReplayFile, "", "path to the recorded runtime round")
+	replayFlags.String(CfgReplayBundle, "", "path to the runtime bundle")
+	replayFlags.String(CfgReplaySandboxBinary, "", "path to the sandbox binary (empty = no sandbox)")
+	replayFlags.Duration(CfgReplayTimeout, 5*time.Minute, "replay timeout")
+	_ = viper.BindPFlags(replayFlags)
+}
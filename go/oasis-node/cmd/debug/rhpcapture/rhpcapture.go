@@ -0,0 +1,141 @@
+// Package rhpcapture implements the runtime host protocol capture decoder debug sub-command.
+package rhpcapture
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+)
+
+const (
+	// CfgCaptureFile is the flag used to specify the path to the capture file.
+	CfgCaptureFile = "capture.file"
+	// CfgCaptureBodyTypes is the flag used to specify the body types to show.
+	CfgCaptureBodyTypes = "capture.body_types"
+)
+
+var (
+	rhpCaptureCmd = &cobra.Command{
+		Use:   "rhp-capture",
+		Short: "decode a runtime host protocol capture file",
+		Long: `Decode a capture file recorded by a node configured with runtime host
+protocol capture and print the captured messages. In verbose mode the message
+bodies are decoded and shown as JSON. Bodies containing fields unknown to this
+version of the protocol are reported, as these usually indicate an
+incompatibility between the node and the runtime.`,
+		Run: doRHPCapture,
+	}
+
+	rhpCaptureFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	logger = logging.GetLogger("cmd/debug/rhpcapture")
+)
+
+func doRHPCapture(*cobra.Command, []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	if err := decodeCapture(); err != nil {
+		logger.Error("failed to decode capture file",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+}
+
+func decodeCapture() error {
+	f, err := os.Open(viper.GetString(CfgCaptureFile))
+	if err != nil {
+		return fmt.Errorf("failed to open capture file: %w", err)
+	}
+	defer f.Close()
+
+	bodyTypes := make(map[string]bool)
+	for _, bodyType := range viper.GetStringSlice(CfgCaptureBodyTypes) {
+		bodyTypes[bodyType] = true
+	}
+
+	var numRecords, numIncompatible int
+	cr := protocol.NewCaptureReader(f)
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if len(bodyTypes) > 0 && !bodyTypes[rec.BodyType] {
+			continue
+		}
+		numRecords++
+
+		fmt.Printf("%s %-8s %-8s #%d %s (%d bytes)\n",
+			time.Unix(0, rec.Timestamp).UTC().Format(time.RFC3339Nano),
+			rec.Direction,
+			rec.MessageType,
+			rec.ID,
+			rec.BodyType,
+			rec.BodySize,
+		)
+
+		switch {
+		case rec.Redacted:
+			if cmdFlags.Verbose() {
+				fmt.Printf("  [redacted]\n")
+			}
+			continue
+		case rec.Truncated:
+			if cmdFlags.Verbose() {
+				fmt.Printf("  [too large, not captured]\n")
+			}
+			continue
+		}
+
+		body, err := rec.DecodeBody(true)
+		if err != nil {
+			numIncompatible++
+			fmt.Printf("  WARNING: %s\n", err)
+
+			if body, err = rec.DecodeBody(false); err != nil {
+				continue
+			}
+		}
+
+		if cmdFlags.Verbose() {
+			prettyBody, err := cmdCommon.PrettyJSONMarshal(body)
+			if err != nil {
+				return fmt.Errorf("failed to get pretty JSON of message body: %w", err)
+			}
+			fmt.Printf("%s\n", prettyBody)
+		}
+	}
+
+	fmt.Printf("%d messages, %d with incompatible bodies\n", numRecords, numIncompatible)
+	return nil
+}
+
+// Register registers the rhp-capture sub-command.
+func Register(parentCmd *cobra.Command) {
+	rhpCaptureCmd.Flags().AddFlagSet(rhpCaptureFlags)
+	rhpCaptureCmd.Flags().AddFlagSet(cmdFlags.VerboseFlags)
+	parentCmd.AddCommand(rhpCaptureCmd)
+}
+
+func init() {
+	rhpCaptureFlags.String(CfgCaptureFile, "", "path to the runtime host protocol capture file")
+	rhpCaptureFlags.StringSlice(CfgCaptureBodyTypes, []string{}, "only show messages with the given body types (default: all)")
+	_ = viper.BindPFlags(rhpCaptureFlags)
+}
@@ -158,7 +158,7 @@ func newDirectStorageBackend(dataDir string, namespace common.Namespace) (storag
 	// The right thing to do will be to use storage.New, but the backend config
 	// assumes that identity is valid, and we don't have one.
 	cfg := &storageAPI.Config{
-		Backend:      config.GlobalConfig.Storage.Backend,
+		Backend:      config.GlobalConfig.Storage.RuntimeBackend(namespace),
 		Namespace:    namespace,
 		MaxCacheSize: int64(config.ParseSizeInBytes(config.GlobalConfig.Storage.MaxCacheSize)),
 	}
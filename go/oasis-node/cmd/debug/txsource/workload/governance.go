@@ -73,6 +73,8 @@ type governanceWorkload struct {
 		nonce   uint64
 	}
 	ensureYesVote map[uint64]bool
+	// Proposals submitted by the workload that have not yet been verified as closed.
+	pendingProposals map[uint64]*governance.Proposal
 
 	validatorEntities []signature.Signer
 	delegatorEntities []signature.Signer
@@ -150,10 +152,93 @@ func (g *governanceWorkload) submitProposalContent(pc *governance.ProposalConten
 	if proposal == nil {
 		return 0, fmt.Errorf("submitted proposal not found: %v", pc)
 	}
+	if proposal.ClosesAt <= g.currentEpoch {
+		g.Logger.Error("submitted proposal closes in the past",
+			"proposal", proposal,
+			"current_epoch", g.currentEpoch,
+		)
+		return 0, fmt.Errorf("%w: submitted proposal %d closes in the past", errUnexpectedGovTxResult, proposal.ID)
+	}
+	g.pendingProposals[proposal.ID] = proposal
 
 	return proposal.ID, nil
 }
 
+// verifyClosedProposals verifies the state and the vote tallies of submitted proposals that should
+// have been closed by the current epoch.
+func (g *governanceWorkload) verifyClosedProposals() error {
+	for id, pending := range g.pendingProposals {
+		if pending.ClosesAt > g.currentEpoch {
+			continue
+		}
+
+		query := &governance.ProposalQuery{
+			Height:     consensus.HeightLatest,
+			ProposalID: id,
+		}
+		proposal, err := g.governance.Proposal(g.ctx, query)
+		if err != nil {
+			return fmt.Errorf("querying proposal %d: %w", id, err)
+		}
+		votes, err := g.governance.Votes(g.ctx, query)
+		if err != nil {
+			return fmt.Errorf("querying votes for proposal %d: %w", id, err)
+		}
+		if err = g.verifyClosedProposal(proposal, votes); err != nil {
+			g.Logger.Error("invalid closed proposal",
+				"err", err,
+				"proposal", proposal,
+				"votes", votes,
+				"current_epoch", g.currentEpoch,
+			)
+			return fmt.Errorf("proposal %d: %w", id, err)
+		}
+
+		g.Logger.Debug("verified closed proposal",
+			"proposal_id", id,
+			"state", proposal.State,
+			"results", proposal.Results,
+		)
+		delete(g.pendingProposals, id)
+		delete(g.ensureYesVote, id)
+	}
+	return nil
+}
+
+func (g *governanceWorkload) verifyClosedProposal(proposal *governance.Proposal, votes []*governance.VoteEntry) error {
+	switch proposal.State {
+	case governance.StatePassed, governance.StateRejected, governance.StateFailed:
+	default:
+		return fmt.Errorf("unexpected state of a closed proposal: %s", proposal.State)
+	}
+	if proposal.Results == nil {
+		return fmt.Errorf("missing results of a closed proposal")
+	}
+	if _, err := proposal.VotedSum(); err != nil {
+		return err
+	}
+	if int(proposal.InvalidVotes) > len(votes) {
+		return fmt.Errorf("more invalid votes (%d) than cast votes (%d)", proposal.InvalidVotes, len(votes))
+	}
+
+	// Only votes that have actually been cast can be present in the results.
+	cast := make(map[governance.Vote]bool)
+	for _, v := range votes {
+		cast[v.Vote] = true
+	}
+	for vote, stake := range proposal.Results {
+		if !stake.IsZero() && !cast[vote] {
+			return fmt.Errorf("results contain stake for vote %s which was never cast", vote)
+		}
+	}
+
+	// Proposals without any yes votes must always be rejected.
+	if yes := proposal.Results[governance.VoteYes]; yes.IsZero() && proposal.State != governance.StateRejected {
+		return fmt.Errorf("proposal without yes votes in state %s", proposal.State)
+	}
+	return nil
+}
+
 func (g *governanceWorkload) doUpgradeProposal() error {
 	minUpgradeEpoch := int64(g.currentEpoch + g.parameters.UpgradeMinEpochDiff)
 	maxUpgradeEpoch := minUpgradeEpoch + int64(3*g.parameters.UpgradeMinEpochDiff)
@@ -528,6 +613,10 @@ func (g *governanceWorkload) doGovernanceVote() error {
 		proposal = p
 		break
 	}
+	if proposal == nil {
+		g.Logger.Debug("no proposals open for voting, skipping submit vote")
+		return nil
+	}
 
 	// Select vote based on the proposer.
 	proposerIdx := -1
@@ -658,6 +747,7 @@ func (g *governanceWorkload) Run(
 	}
 
 	g.ensureYesVote = make(map[uint64]bool)
+	g.pendingProposals = make(map[uint64]*governance.Proposal)
 
 	// Main workload loop.
 	for {
@@ -678,6 +768,11 @@ func (g *governanceWorkload) Run(
 		if epoch > g.currentEpoch {
 			g.currentEpoch = epoch
 
+			// Make sure proposals closed during the transition have been tallied correctly.
+			if err = g.verifyClosedProposals(); err != nil {
+				return fmt.Errorf("verifying closed proposals: %w", err)
+			}
+
 			// Make sure no pending upgrade will go through.
 			// XXX: this makes sure that any pending upgrades that are about to be executed are
 			// canceled. When txsource suite supports handling upgrades mid-run, remove this part.
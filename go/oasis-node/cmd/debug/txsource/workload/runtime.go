@@ -34,11 +34,15 @@ var Runtime = &runtime{
 const (
 	// CfgRuntimeID is the runtime workload runtime ID.
 	CfgRuntimeID = "runtime.runtime_id"
+	// CfgRuntimeRequestInterval is the interval between runtime workload requests.
+	CfgRuntimeRequestInterval = "runtime.request_interval"
 
 	// Ratio of insert requests that should be an upsert.
 	runtimeInsertExistingRatio = 0.3
 	// Ratio of get requests that should get an existing key.
 	runtimeGetExistingRatio = 0.9
+	// Ratio of query requests that should query an existing key.
+	runtimeQueryExistingRatio = 0.9
 	// Ratio of remove requests that should delete an existing key.
 	runtimeRemoveExistingRatio = 0.5
 
@@ -57,6 +61,7 @@ const (
 	runtimeRequestAddEscrow     runtimeRequest = 5
 	runtimeRequestReclaimEscrow runtimeRequest = 6
 	runtimeRequestInMsg         runtimeRequest = 7
+	runtimeRequestQuery         runtimeRequest = 8
 )
 
 // Weights to select between requests types.
@@ -69,6 +74,7 @@ var runtimeRequestWeights = map[runtimeRequest]int{
 	runtimeRequestAddEscrow:     1,
 	runtimeRequestReclaimEscrow: 1,
 	runtimeRequestInMsg:         1,
+	runtimeRequestQuery:         2,
 }
 
 // RuntimeFlags are the runtime workload flags.
@@ -97,6 +103,8 @@ type runtime struct {
 
 	runtimeID             common.Namespace
 	reckonedKeyValueState map[string]string
+	// reckonedRound is the latest runtime round that the reckoned key/value state reflects.
+	reckonedRound uint64
 
 	testAddress staking.Address
 
@@ -236,9 +244,111 @@ func (r *runtime) submitRuntimeRequest(ctx context.Context, rtc runtimeClient.Ru
 	if rsp.Error != nil {
 		return nil, 0, fmt.Errorf("runtime tx failed: %s", *rsp.Error)
 	}
+	r.updateReckonedRound(out.Round)
 	return &rsp, out.Round, nil
 }
 
+func (r *runtime) updateReckonedRound(round uint64) {
+	if round > r.reckonedRound {
+		r.reckonedRound = round
+	}
+}
+
+// waitRound waits for the runtime client to see the given runtime round.
+func (r *runtime) waitRound(ctx context.Context, rtc runtimeClient.RuntimeClient, round uint64) error {
+	ch, sub, err := rtc.WatchBlocks(ctx, r.runtimeID)
+	if err != nil {
+		return fmt.Errorf("failed to watch blocks: %w", err)
+	}
+	defer sub.Close()
+
+	waitCtx, cancel := context.WithTimeout(ctx, runtimeRequestTimeout)
+	defer cancel()
+
+	for {
+		select {
+		case blk, ok := <-ch:
+			if !ok {
+				return fmt.Errorf("block watcher closed")
+			}
+			if blk.Block.Header.Round >= round {
+				return nil
+			}
+		case <-waitCtx.Done():
+			return fmt.Errorf("timed out waiting for round %d: %w", round, waitCtx.Err())
+		}
+	}
+}
+
+func (r *runtime) doQueryRequest(ctx context.Context, rng *rand.Rand, rtc runtimeClient.RuntimeClient, existing bool) error {
+	key := r.generateVal(rng, existing)
+
+	// Make sure the queried round reflects all of the updates of the reckoned state.
+	round := runtimeClient.RoundLatest
+	if r.reckonedRound > 0 {
+		round = r.reckonedRound
+		if err := r.waitRound(ctx, rtc, round); err != nil {
+			return err
+		}
+	}
+
+	args := struct {
+		Key string `json:"key"`
+	}{
+		Key: key,
+	}
+	queryCtx, cancel := context.WithTimeout(ctx, runtimeRequestTimeout)
+	defer cancel()
+	rsp, err := rtc.Query(queryCtx, &runtimeClient.QueryRequest{
+		RuntimeID: r.runtimeID,
+		Round:     round,
+		Method:    "get",
+		Args:      cbor.Marshal(args),
+	})
+	if err != nil {
+		r.Logger.Error("Query request failure",
+			"key", key,
+			"round", round,
+			"existing_key", existing,
+			"err", err,
+		)
+		return fmt.Errorf("query request failed: %w", err)
+	}
+
+	var value *string
+	if err = cbor.Unmarshal(rsp.Data, &value); err != nil {
+		return fmt.Errorf("malformed query response from runtime: %w", err)
+	}
+
+	// Validate response.
+	expected, keyExists := r.reckonedKeyValueState[key]
+	switch {
+	case keyExists && value == nil:
+		err = fmt.Errorf("query for existing key returned no value")
+	case keyExists && *value != expected:
+		err = fmt.Errorf("query returned unexpected value (expected: %s, got: %s)", expected, *value)
+	case !keyExists && value != nil:
+		err = fmt.Errorf("query for non-existing key returned a value: %s", *value)
+	}
+	if err != nil {
+		r.Logger.Error("Query response validation failure",
+			"key", key,
+			"round", round,
+			"existing_key", existing,
+			"err", err,
+		)
+		return fmt.Errorf("invalid response: %w", err)
+	}
+
+	r.Logger.Debug("query request success",
+		"key", key,
+		"round", round,
+		"existing_key", existing,
+	)
+
+	return nil
+}
+
 func (r *runtime) doInsertRequest(ctx context.Context, rng *rand.Rand, rtc runtimeClient.RuntimeClient, existing bool) error {
 	key := r.generateVal(rng, existing)
 	value := r.generateVal(rng, false)
@@ -449,6 +559,7 @@ func (r *runtime) doInMsgRequest(ctx context.Context, rng *rand.Rand, _ runtimeC
 			if ev.InMsgProcessed.Tag != 42 {
 				continue
 			}
+			r.updateReckonedRound(ev.InMsgProcessed.Round)
 		case <-submitCtx.Done():
 			r.Logger.Error("timed out waiting for incoming message to be processed")
 			return ctx.Err()
@@ -879,12 +990,16 @@ func (r *runtime) Run(
 			if err := r.doInMsgRequest(ctx, rng, rtc); err != nil {
 				return fmt.Errorf("doInMsgRequest failure: %w", err)
 			}
+		case runtimeRequestQuery:
+			if err := r.doQueryRequest(ctx, rng, rtc, rng.Float64() < runtimeQueryExistingRatio); err != nil {
+				return fmt.Errorf("doQueryRequest failure: %w", err)
+			}
 		default:
 			return fmt.Errorf("unimplemented")
 		}
 
 		select {
-		case <-time.After(1 * time.Second):
+		case <-time.After(viper.GetDuration(CfgRuntimeRequestInterval)):
 		case <-gracefulExit.Done():
 			r.Logger.Debug("time's up")
 			return nil
@@ -894,5 +1009,6 @@ func (r *runtime) Run(
 
 func init() {
 	RuntimeFlags.String(CfgRuntimeID, "", "Simple-keyvalue runtime ID")
+	RuntimeFlags.Duration(CfgRuntimeRequestInterval, 1*time.Second, "Interval between runtime requests")
 	_ = viper.BindPFlags(RuntimeFlags)
 }
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -19,6 +20,7 @@ import (
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/diff"
 	"github.com/oasisprotocol/oasis-core/go/common/entity"
@@ -59,6 +61,7 @@ const (
 	CfgRegistryMaxNodeExpiration                      = "registry.max_node_expiration"
 	CfgRegistryDisableRuntimeRegistration             = "registry.disable_runtime_registration"
 	CfgRegistryDebugAllowUnroutableAddresses          = "registry.debug.allow_unroutable_addresses"
+	CfgRegistryDebugAllowDebugCapabilities            = "registry.debug.allow_debug_capabilities"
 	CfgRegistryDebugAllowTestRuntimes                 = "registry.debug.allow_test_runtimes"
 	CfgRegistryEnableRuntimeGovernanceModels          = "registry.enable_runtime_governance_models"
 	CfgRegistryTEEFeaturesSGXPCS                      = "registry.tee_features.sgx.pcs"
@@ -81,6 +84,8 @@ const (
 	CfgGovernanceUpgradeMinEpochDiff            = "governance.upgrade_min_epoch_diff"
 	CfgGovernanceVotingPeriod                   = "governance.voting_period"
 	CfgGovernanceEnableChangeParametersProposal = "governance.enable_change_parameters_proposal"
+	CfgGovernanceEnableEntityFreezeProposal     = "governance.enable_entity_freeze_proposal"
+	CfgGovernanceEnableUpdateRuntimeProposal    = "governance.enable_update_runtime_proposal"
 
 	// Beacon config flags.
 	CfgBeaconBackend                  = "beacon.backend"
@@ -89,6 +94,12 @@ const (
 	CfgBeaconVRFAlphaThreshold        = "beacon.vrf.alpha_threshold"
 	CfgBeaconVRFInterval              = "beacon.vrf.interval"
 	CfgBeaconVRFProofSubmissionDelay  = "beacon.vrf.submission_delay"
+	CfgBeaconExternalInterval         = "beacon.external.interval"
+	CfgBeaconExternalSignedPublicKey  = "beacon.external.signed.public_key"
+	CfgBeaconExternalSource           = "beacon.external.source"
+	CfgBeaconExternalDrandChainHash   = "beacon.external.drand.chain_hash"
+	CfgBeaconExternalDrandRelays      = "beacon.external.drand.relays"
+	CfgBeaconExternalGasCostsSubmit   = "beacon.external.gas_costs.submit"
 
 	// Roothash config flags.
 	cfgRoothashDebugDoNotSuspendRuntimes = "roothash.debug.do_not_suspend_runtimes"
@@ -249,6 +260,8 @@ func doInitGenesis(*cobra.Command, []string) {
 			UpgradeMinEpochDiff:            beacon.EpochTime(viper.GetUint64(CfgGovernanceUpgradeMinEpochDiff)),
 			VotingPeriod:                   beacon.EpochTime(viper.GetUint64(CfgGovernanceVotingPeriod)),
 			EnableChangeParametersProposal: viper.GetBool(CfgGovernanceEnableChangeParametersProposal),
+			EnableEntityFreezeProposal:     viper.GetBool(CfgGovernanceEnableEntityFreezeProposal),
+			EnableUpdateRuntimeProposal:    viper.GetBool(CfgGovernanceEnableUpdateRuntimeProposal),
 		},
 	}
 
@@ -270,6 +283,58 @@ func doInitGenesis(*cobra.Command, []string) {
 			ProofSubmissionDelay:      viper.GetInt64(CfgBeaconVRFProofSubmissionDelay),
 			GasCosts:                  beacon.DefaultVRFGasCosts, // TODO: configurable.
 		}
+	case beacon.BackendExternal:
+		var sourceParams cbor.RawMessage
+		switch source := viper.GetString(CfgBeaconExternalSource); source {
+		case beacon.ExternalSourceSigned:
+			var sourcePublicKey signature.PublicKey
+			if err := sourcePublicKey.UnmarshalText([]byte(viper.GetString(CfgBeaconExternalSignedPublicKey))); err != nil {
+				logger.Error("failed to parse external randomness source public key",
+					"err", err,
+				)
+				return
+			}
+			sourceParams = cbor.Marshal(&beacon.SignedSourceParameters{
+				PublicKey: sourcePublicKey,
+			})
+		case beacon.ExternalSourceDrand:
+			chainHash, err := hex.DecodeString(viper.GetString(CfgBeaconExternalDrandChainHash))
+			if err != nil {
+				logger.Error("failed to parse drand chain hash",
+					"err", err,
+				)
+				return
+			}
+			var relays []signature.PublicKey
+			for _, v := range viper.GetStringSlice(CfgBeaconExternalDrandRelays) {
+				var relay signature.PublicKey
+				if err = relay.UnmarshalText([]byte(v)); err != nil {
+					logger.Error("failed to parse drand relay public key",
+						"err", err,
+						"relay", v,
+					)
+					return
+				}
+				relays = append(relays, relay)
+			}
+			sourceParams = cbor.Marshal(&beacon.DrandSourceParameters{
+				ChainHash: chainHash,
+				Relays:    relays,
+			})
+		default:
+			logger.Error("unsupported external randomness source",
+				"source", source,
+			)
+			return
+		}
+		doc.Beacon.Parameters.ExternalParameters = &beacon.ExternalParameters{
+			Interval:         viper.GetInt64(CfgBeaconExternalInterval),
+			Source:           viper.GetString(CfgBeaconExternalSource),
+			SourceParameters: sourceParams,
+			GasCosts: transaction.Costs{
+				beacon.GasOpExternalSubmit: transaction.Gas(viper.GetUint64(CfgBeaconExternalGasCostsSubmit)),
+			},
+		}
 	default:
 		logger.Error("unsupported beacon backend",
 			"backend", doc.Beacon.Parameters.Backend,
@@ -350,6 +415,7 @@ func AppendRegistryState(doc *genesis.Document, entities, runtimes, nodes []stri
 		Parameters: registry.ConsensusParameters{
 			DebugAllowUnroutableAddresses: viper.GetBool(CfgRegistryDebugAllowUnroutableAddresses),
 			DebugAllowTestRuntimes:        viper.GetBool(CfgRegistryDebugAllowTestRuntimes),
+			DebugAllowDebugCapabilities:   viper.GetBool(CfgRegistryDebugAllowDebugCapabilities),
 			GasCosts:                      registry.DefaultGasCosts, // TODO: Make these configurable.
 			MaxNodeExpiration:             viper.GetUint64(CfgRegistryMaxNodeExpiration),
 			DisableRuntimeRegistration:    viper.GetBool(CfgRegistryDisableRuntimeRegistration),
@@ -808,6 +874,7 @@ func init() {
 	initGenesisFlags.Bool(CfgRegistryDisableRuntimeRegistration, false, "disable non-genesis runtime registration")
 	initGenesisFlags.Bool(CfgRegistryDebugAllowUnroutableAddresses, false, "allow unroutable addreses (UNSAFE)")
 	initGenesisFlags.Bool(CfgRegistryDebugAllowTestRuntimes, false, "enable test runtime registration")
+	initGenesisFlags.Bool(CfgRegistryDebugAllowDebugCapabilities, false, "allow nodes with debug capabilities (UNSAFE)")
 	initGenesisFlags.StringSlice(CfgRegistryEnableRuntimeGovernanceModels, []string{"entity"}, "set of enabled runtime governance models")
 	initGenesisFlags.Bool(CfgRegistryTEEFeaturesSGXPCS, true, "enable PCS support for SGX TEEs")
 	initGenesisFlags.Bool(CfgRegistryTEEFeaturesSGXSignedAttestations, true, "enable SGX RAK-signed attestations")
@@ -815,6 +882,7 @@ func init() {
 	initGenesisFlags.Bool(CfgRegistryTEEFeaturesFreshnessProofs, true, "enable freshness proofs")
 	_ = initGenesisFlags.MarkHidden(CfgRegistryDebugAllowUnroutableAddresses)
 	_ = initGenesisFlags.MarkHidden(CfgRegistryDebugAllowTestRuntimes)
+	_ = initGenesisFlags.MarkHidden(CfgRegistryDebugAllowDebugCapabilities)
 
 	// Scheduler config flags.
 	initGenesisFlags.Int(cfgSchedulerMinValidators, 1, "minimum number of validators")
@@ -834,6 +902,8 @@ func init() {
 	initGenesisFlags.Uint64(CfgGovernanceUpgradeMinEpochDiff, 300, "minimum number of epochs the upgrade needs to be scheduled in advance")
 	initGenesisFlags.Uint64(CfgGovernanceVotingPeriod, 100, "voting period (in epochs)")
 	initGenesisFlags.Bool(CfgGovernanceEnableChangeParametersProposal, true, "enable change parameters proposals")
+	initGenesisFlags.Bool(CfgGovernanceEnableEntityFreezeProposal, true, "enable entity freeze and thaw proposals")
+	initGenesisFlags.Bool(CfgGovernanceEnableUpdateRuntimeProposal, true, "enable update runtime proposals")
 
 	// Beacon config flags.
 	initGenesisFlags.String(CfgBeaconBackend, "insecure", "beacon backend")
@@ -842,6 +912,12 @@ func init() {
 	initGenesisFlags.Uint64(CfgBeaconVRFAlphaThreshold, 1, "Number of proofs required to allow runtime elections")
 	initGenesisFlags.Int64(CfgBeaconVRFInterval, 86300, "Epoch interval (in blocks)")
 	initGenesisFlags.Int64(CfgBeaconVRFProofSubmissionDelay, 43150, "Proof submission delay (in blocks)")
+	initGenesisFlags.Int64(CfgBeaconExternalInterval, 86400, "Epoch interval (in blocks)")
+	initGenesisFlags.String(CfgBeaconExternalSource, beacon.ExternalSourceSigned, "External randomness source (signed, drand)")
+	initGenesisFlags.String(CfgBeaconExternalSignedPublicKey, "", "Base64-encoded public key of the signed external randomness source")
+	initGenesisFlags.String(CfgBeaconExternalDrandChainHash, "", "Hex-encoded hash of the drand chain")
+	initGenesisFlags.StringSlice(CfgBeaconExternalDrandRelays, nil, "Base64-encoded public keys of the nodes relaying drand rounds")
+	initGenesisFlags.Uint64(CfgBeaconExternalGasCostsSubmit, uint64(beacon.DefaultExternalGasCosts[beacon.GasOpExternalSubmit]), "external beacon gas costs: each randomness submission")
 	_ = initGenesisFlags.MarkHidden(CfgBeaconDebugMockBackend)
 
 	// Roothash config flags.
@@ -233,7 +233,7 @@ func iasEndpointFromFlags() (ias.Endpoint, error) {
 	}
 
 	if viper.GetBool(cfgDebugMock) {
-		if !flags.DebugDontBlameOasis() {
+		if !flags.DebugMockAttestation() {
 			return nil, fmt.Errorf("ias: refusing to mock IAS responses")
 		}
 		cfg.DebugIsMock = true
@@ -251,7 +251,7 @@ func iasEndpointFromFlags() (ias.Endpoint, error) {
 
 func grpcAuthenticatorFromFlags(ctx context.Context, cmd *cobra.Command) (iasProxy.Authenticator, error) {
 	if viper.GetBool(cfgDebugSkipAuth) {
-		if !flags.DebugDontBlameOasis() {
+		if !flags.DebugMockAttestation() {
 			return nil, fmt.Errorf("ias: refusing to disable gRPC authentication")
 		}
 		logger.Warn("IAS gRPC authentication disabled, proxy is open")
@@ -2,7 +2,7 @@
 package keymanager
 
 import (
-	"bytes"
+	"context"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -15,17 +15,20 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common"
-	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	fileSigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/file"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/sgx"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	kmApi "github.com/oasisprotocol/oasis-core/go/keymanager/api"
 	"github.com/oasisprotocol/oasis-core/go/keymanager/secrets"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/secrets/policy"
 	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
 	cmdConsensus "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/consensus"
 	cmdContext "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/context"
 	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+	cmdGrpc "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/grpc"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 )
 
 const (
@@ -40,6 +43,9 @@ const (
 	CfgPolicySigFile                      = "keymanager.policy.signature.file"
 	CfgPolicyIgnoreSig                    = "keymanager.policy.ignore.signature"
 	CfgPolicyMasterSecretRotationInterval = "keymanager.policy.master_secret_rotation_interval"
+	CfgPolicyIncludeRuntime               = "keymanager.policy.include.runtime"
+	CfgPolicyExcludeRuntime               = "keymanager.policy.exclude.runtime"
+	CfgPolicyExcludeEnclave               = "keymanager.policy.exclude.enclave"
 
 	CfgStatusFile        = "keymanager.status.file"
 	CfgStatusID          = "keymanager.status.id"
@@ -68,6 +74,12 @@ var (
 		Deprecated: "use the `oasis` CLI instead.",
 	}
 
+	initPolicyFromRegistryCmd = &cobra.Command{
+		Use:   "init_policy_from_registry",
+		Short: "generate keymanager policy file from the current registry state",
+		Run:   doInitPolicyFromRegistry,
+	}
+
 	signPolicyCmd = &cobra.Command{
 		Use:        "sign_policy",
 		Short:      "sign keymanager policy file",
@@ -109,7 +121,7 @@ func doInitPolicy(*cobra.Command, []string) {
 		os.Exit(1)
 	}
 
-	c := cbor.Marshal(p)
+	c := policy.Marshal(p)
 	if err = os.WriteFile(viper.GetString(CfgPolicyFile), c, 0o644); err != nil { // nolint: gosec
 		logger.Error("failed to write key manager policy cbor file",
 			"err", err,
@@ -133,9 +145,9 @@ func policyFromFlags() (*secrets.PolicySGX, error) {
 		return nil, err
 	}
 
-	serial := viper.GetUint32(CfgPolicySerial)
-
-	enclaves := make(map[sgx.EnclaveIdentity]*secrets.EnclavePolicySGX)
+	b := policy.NewBuilder(id).
+		WithSerial(viper.GetUint32(CfgPolicySerial)).
+		WithMasterSecretRotationInterval(api.EpochTime(viper.GetUint64(CfgPolicyMasterSecretRotationInterval)))
 
 	// Replicate and query permissions are set per-key manager enclave ID.
 	// Since viper doesn't store order of arguments, go through os.Args by hand,
@@ -151,10 +163,7 @@ func policyFromFlags() (*secrets.PolicySGX, error) {
 				return nil, err
 			}
 
-			enclaves[kmEnclaveID] = &secrets.EnclavePolicySGX{
-				MayReplicate: []sgx.EnclaveIdentity{},
-				MayQuery:     make(map[common.Namespace][]sgx.EnclaveIdentity),
-			}
+			eb := b.Enclave(kmEnclaveID)
 
 			for curArgIdx = curArgIdx + 2; curArgIdx < len(os.Args); curArgIdx++ {
 				// Break, if the next enclave-id is caught.
@@ -174,7 +183,7 @@ func policyFromFlags() (*secrets.PolicySGX, error) {
 							)
 							return nil, err
 						}
-						enclaves[kmEnclaveID].MayReplicate = append(enclaves[kmEnclaveID].MayReplicate, replEnclaveID)
+						eb.AllowReplication(replEnclaveID)
 					}
 				}
 
@@ -204,20 +213,157 @@ func policyFromFlags() (*secrets.PolicySGX, error) {
 						}
 						queryEnclaveIDs = append(queryEnclaveIDs, queryEnclaveID)
 					}
-					enclaves[kmEnclaveID].MayQuery[qRuntimeID] = queryEnclaveIDs
+					eb.AllowQuery(qRuntimeID, queryEnclaveIDs...)
 				}
 			}
 		}
 	}
 
-	rotationInterval := api.EpochTime(viper.GetUint64(CfgPolicyMasterSecretRotationInterval))
+	p, err := b.Build()
+	if err != nil {
+		logger.Error("invalid key manager policy",
+			"err", err,
+		)
+		return nil, err
+	}
+	return p, nil
+}
 
-	return &secrets.PolicySGX{
-		Serial:                       serial,
-		ID:                           id,
-		Enclaves:                     enclaves,
-		MasterSecretRotationInterval: rotationInterval,
-	}, nil
+func doInitPolicyFromRegistry(cmd *cobra.Command, _ []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	p, err := policyFromRegistry(cmd)
+	if err != nil {
+		os.Exit(1)
+	}
+
+	c := policy.Marshal(p)
+	if err = os.WriteFile(viper.GetString(CfgPolicyFile), c, 0o644); err != nil { // nolint: gosec
+		logger.Error("failed to write key manager policy cbor file",
+			"err", err,
+			"CfgPolicyFile", viper.GetString(CfgPolicyFile),
+		)
+		os.Exit(1)
+	}
+
+	if cmdFlags.Verbose() {
+		prettyPolicy, err := cmdCommon.PrettyJSONMarshal(p)
+		if err == nil {
+			fmt.Println(string(prettyPolicy))
+		}
+	}
+
+	logger.Info("generated key manager policy file from registry",
+		"PolicySGX.ID", p.ID,
+		"enclaves", len(p.Enclaves),
+	)
+}
+
+func policyFromRegistry(cmd *cobra.Command) (*secrets.PolicySGX, error) {
+	var id common.Namespace
+	if err := id.UnmarshalHex(viper.GetString(CfgPolicyID)); err != nil {
+		logger.Error("failed to parse key manager runtime ID",
+			"err", err,
+			"CfgPolicyID", viper.GetString(CfgPolicyID),
+		)
+		return nil, err
+	}
+
+	var filter policy.RegistryFilter
+	for _, v := range []struct {
+		cfg string
+		ids *[]common.Namespace
+	}{
+		{CfgPolicyIncludeRuntime, &filter.IncludeRuntimes},
+		{CfgPolicyExcludeRuntime, &filter.ExcludeRuntimes},
+	} {
+		for _, rtIDStr := range viper.GetStringSlice(v.cfg) {
+			var rtID common.Namespace
+			if err := rtID.UnmarshalHex(rtIDStr); err != nil {
+				logger.Error("failed to parse runtime ID",
+					"err", err,
+					"runtime_id", rtIDStr,
+				)
+				return nil, err
+			}
+			*v.ids = append(*v.ids, rtID)
+		}
+	}
+	for _, enclaveIDStr := range viper.GetStringSlice(CfgPolicyExcludeEnclave) {
+		var enclaveID sgx.EnclaveIdentity
+		if err := enclaveID.UnmarshalHex(enclaveIDStr); err != nil {
+			logger.Error("failed to parse enclave ID",
+				"err", err,
+				"enclave_id", enclaveIDStr,
+			)
+			return nil, err
+		}
+		filter.ExcludeEnclaves = append(filter.ExcludeEnclaves, enclaveID)
+	}
+
+	conn, err := cmdGrpc.NewClient(cmd)
+	if err != nil {
+		logger.Error("failed to establish connection with node",
+			"err", err,
+		)
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := registry.NewRegistryClient(conn)
+	runtimes, err := client.GetRuntimes(context.Background(), &registry.GetRuntimesQuery{
+		Height: consensus.HeightLatest,
+	})
+	if err != nil {
+		logger.Error("failed to query runtimes",
+			"err", err,
+		)
+		return nil, err
+	}
+
+	var km *registry.Runtime
+	for _, rt := range runtimes {
+		if rt.ID.Equal(&id) {
+			km = rt
+			break
+		}
+	}
+	if km == nil {
+		err = fmt.Errorf("key manager runtime %s is not registered", id)
+		logger.Error("failed to find key manager runtime",
+			"err", err,
+		)
+		return nil, err
+	}
+
+	epoch, err := api.NewBeaconClient(conn).GetEpoch(context.Background(), consensus.HeightLatest)
+	if err != nil {
+		logger.Error("failed to query current epoch",
+			"err", err,
+		)
+		return nil, err
+	}
+
+	b, err := policy.NewBuilderFromRegistry(km, runtimes, epoch, &filter)
+	if err != nil {
+		logger.Error("failed to generate key manager policy",
+			"err", err,
+		)
+		return nil, err
+	}
+	b.WithSerial(viper.GetUint32(CfgPolicySerial)).
+		WithMasterSecretRotationInterval(api.EpochTime(viper.GetUint64(CfgPolicyMasterSecretRotationInterval)))
+
+	p, err := b.Build()
+	if err != nil {
+		logger.Error("invalid key manager policy",
+			"err", err,
+		)
+		return nil, err
+	}
+	return p, nil
 }
 
 func doSignPolicy(*cobra.Command, []string) {
@@ -264,7 +410,7 @@ func signPolicyFromFlags() (*signature.Signature, error) {
 			return nil, err
 		}
 	} else if viper.GetUint(CfgPolicyTestKey) != 0 {
-		if !cmdFlags.DebugDontBlameOasis() {
+		if !cmdFlags.DebugTestKeys() {
 			return nil, errors.New("refusing to use test keys for signing")
 		}
 		if viper.GetUint(CfgPolicyTestKey) > uint(len(kmApi.TestSigners)) {
@@ -281,16 +427,12 @@ func signPolicyFromFlags() (*signature.Signature, error) {
 	}
 
 	// Check whether input policy file is well formed.
-	if _, err = unmarshalPolicyCBOR(policyBytes); err != nil {
-		return nil, err
-	}
-
-	sig, err := signature.Sign(signer, secrets.PolicySGXSignatureContext, policyBytes)
+	p, err := policy.Unmarshal(policyBytes)
 	if err != nil {
 		return nil, err
 	}
 
-	return sig, nil
+	return policy.Sign(signer, p)
 }
 
 func doVerifyPolicy(*cobra.Command, []string) {
@@ -313,14 +455,14 @@ func verifyPolicyFromFlags() error {
 	}
 
 	// Check whether input policy file is well formed.
-	policy, err := unmarshalPolicyCBOR(policyBytes)
+	p, err := policy.Unmarshal(policyBytes)
 	if err != nil {
 		return err
 	}
 
 	// Output policy content in JSON, if verbose switch given.
 	if cmdFlags.Verbose() {
-		prettyPolicy, err := cmdCommon.PrettyJSONMarshal(policy)
+		prettyPolicy, err := cmdCommon.PrettyJSONMarshal(p)
 		if err != nil {
 			logger.Error("failed to get pretty JSON of policy",
 				"err", err,
@@ -353,22 +495,6 @@ func verifyPolicyFromFlags() error {
 	return nil
 }
 
-// / unmarshalPolicyChor checks whether given CBOR is a valid secrets.PolicySGX struct.
-func unmarshalPolicyCBOR(pb []byte) (*secrets.PolicySGX, error) {
-	var p *secrets.PolicySGX = &secrets.PolicySGX{}
-	if err := cbor.Unmarshal(pb, p); err != nil {
-		return nil, err
-	}
-
-	// Re-marshal to check the canonicity.
-	pb2 := cbor.Marshal(p)
-	if !bytes.Equal(pb, pb2) {
-		return nil, errors.New("policy file not in canonical form")
-	}
-
-	return p, nil
-}
-
 func doInitStatus(*cobra.Command, []string) {
 	if err := cmdCommon.Init(); err != nil {
 		cmdCommon.EarlyLogAndExit(err)
@@ -412,8 +538,6 @@ func doGenUpdate(*cobra.Command, []string) {
 
 	// Assemble the SignedPolicySGX from the policy document and detached
 	// signatures.
-	var signedPolicy secrets.SignedPolicySGX
-
 	policyBytes, err := os.ReadFile(viper.GetString(CfgPolicyFile))
 	if err != nil {
 		logger.Error("failed to read policy file",
@@ -421,13 +545,15 @@ func doGenUpdate(*cobra.Command, []string) {
 		)
 		os.Exit(1)
 	}
-	if err = cbor.Unmarshal(policyBytes, &signedPolicy.Policy); err != nil {
+	p, err := policy.Unmarshal(policyBytes)
+	if err != nil {
 		logger.Error("failed to unmarshal policy file",
 			"err", err,
 		)
 		os.Exit(1)
 	}
 
+	var sigs []signature.Signature
 	for _, sigFile := range viper.GetStringSlice(CfgPolicySigFile) {
 		var policySigBytes []byte
 		if policySigBytes, err = os.ReadFile(sigFile); err != nil {
@@ -446,11 +572,12 @@ func doGenUpdate(*cobra.Command, []string) {
 			)
 			os.Exit(1)
 		}
-		signedPolicy.Signatures = append(signedPolicy.Signatures, s)
+		sigs = append(sigs, s)
 	}
 
 	// Validate the SignedPolicySGX.
-	if err = secrets.SanityCheckSignedPolicySGX(nil, &signedPolicy); err != nil {
+	signedPolicy, err := policy.NewSignedPolicy(p, sigs...)
+	if err != nil {
 		logger.Error("failed to validate SignedPolicySGX",
 			"err", err,
 		)
@@ -459,7 +586,7 @@ func doGenUpdate(*cobra.Command, []string) {
 
 	// Build, sign, and write the UpdatePolicy transaction.
 	nonce, fee := cmdConsensus.GetTxNonceAndFee()
-	tx := secrets.NewUpdatePolicyTx(nonce, fee, &signedPolicy)
+	tx := secrets.NewUpdatePolicyTx(nonce, fee, signedPolicy)
 	cmdConsensus.SignAndSaveTx(cmdContext.GetCtxWithGenesisInfo(genesis), tx, nil)
 }
 
@@ -481,7 +608,7 @@ func statusFromFlags() (*secrets.Status, error) {
 			return nil, err
 		}
 
-		p, err := unmarshalPolicyCBOR(pb)
+		p, err := policy.Unmarshal(pb)
 		if err != nil {
 			return nil, err
 		}
@@ -578,6 +705,39 @@ func registerKMInitPolicyFlags(cmd *cobra.Command) {
 	}
 }
 
+func registerKMInitPolicyFromRegistryFlags(cmd *cobra.Command) {
+	if !cmd.Flags().Parsed() {
+		cmd.Flags().Uint32(CfgPolicySerial, 0, "monotonically increasing number of the policy")
+		cmd.Flags().String(CfgPolicyID, "", "256-bit Runtime ID this policy is valid for in hex")
+		cmd.Flags().Uint64(CfgPolicyMasterSecretRotationInterval, 0, "master secret rotation interval")
+		cmd.Flags().StringSlice(CfgPolicyIncludeRuntime, []string{}, "runtime_id1,runtime_id2... list of compute runtimes which are allowed to query (default: all)")
+		cmd.Flags().StringSlice(CfgPolicyExcludeRuntime, []string{}, "runtime_id1,runtime_id2... list of compute runtimes which are not allowed to query")
+		cmd.Flags().StringSlice(CfgPolicyExcludeEnclave, []string{}, "enclave_id1,enclave_id2... list of enclaves which are omitted from the policy")
+	}
+
+	cmd.Flags().AddFlagSet(policyFileFlag)
+	cmd.Flags().AddFlagSet(cmdGrpc.ClientFlags)
+	cmd.Flags().AddFlagSet(cmdFlags.VerboseFlags)
+
+	for _, v := range []string{
+		CfgPolicySerial,
+		CfgPolicyID,
+	} {
+		_ = cmd.MarkFlagRequired(v)
+	}
+
+	for _, v := range []string{
+		CfgPolicySerial,
+		CfgPolicyID,
+		CfgPolicyMasterSecretRotationInterval,
+		CfgPolicyIncludeRuntime,
+		CfgPolicyExcludeRuntime,
+		CfgPolicyExcludeEnclave,
+	} {
+		_ = viper.BindPFlag(v, cmd.Flags().Lookup(v))
+	}
+}
+
 func registerKMSignPolicyFlags(cmd *cobra.Command) {
 	if !cmd.Flags().Parsed() {
 		cmd.Flags().String(CfgPolicyKeyFile, "", "input file name containing client key")
@@ -654,6 +814,7 @@ func Register(parentCmd *cobra.Command) {
 
 	for _, v := range []*cobra.Command{
 		initPolicyCmd,
+		initPolicyFromRegistryCmd,
 		signPolicyCmd,
 		verifyPolicyCmd,
 		initStatusCmd,
@@ -663,6 +824,7 @@ func Register(parentCmd *cobra.Command) {
 	}
 
 	registerKMInitPolicyFlags(initPolicyCmd)
+	registerKMInitPolicyFromRegistryFlags(initPolicyFromRegistryCmd)
 	registerKMSignPolicyFlags(signPolicyCmd)
 	registerKMVerifyPolicyFlags(verifyPolicyCmd)
 	registerKMInitStatusFlags(initStatusCmd)
@@ -672,5 +834,7 @@ func Register(parentCmd *cobra.Command) {
 	genUpdateCmd.Flags().AddFlagSet(cmdConsensus.TxFlags)
 	genUpdateCmd.Flags().AddFlagSet(cmdFlags.AssumeYesFlag)
 
+	registerPolicyCmd(keyManagerCmd)
+
 	parentCmd.AddCommand(keyManagerCmd)
 }
@@ -0,0 +1,313 @@
+package keymanager
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/secrets"
+	"github.com/oasisprotocol/oasis-core/go/keymanager/secrets/policy"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+	cmdGrpc "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/grpc"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+)
+
+const (
+	// CfgKeyManagerID configures the key manager runtime whose live policy should be used.
+	CfgKeyManagerID = "keymanager.id"
+
+	// CfgRuntimeNames configures human readable names of runtimes referenced by the policy.
+	CfgRuntimeNames = "keymanager.policy.runtime_names"
+
+	// CfgCheckEnclaveID configures the enclave identity whose permissions should be verified.
+	CfgCheckEnclaveID = "keymanager.policy.check.enclave_id"
+
+	// CfgCheckRuntimeID configures the runtime for which query permissions should be verified.
+	CfgCheckRuntimeID = "keymanager.policy.check.runtime_id"
+)
+
+var (
+	livePolicyFlags  = flag.NewFlagSet("", flag.ContinueOnError)
+	policyCheckFlags = flag.NewFlagSet("", flag.ContinueOnError)
+
+	policyCmd = &cobra.Command{
+		Use:   "policy",
+		Short: "inspect the key manager policy published on the consensus layer",
+	}
+
+	policyShowCmd = &cobra.Command{
+		Use:   "show",
+		Short: "show the current key manager policy",
+		Run:   doPolicyShow,
+	}
+
+	policyVerifyCmd = &cobra.Command{
+		Use:   "verify",
+		Short: "verify whether an enclave may query or replicate under the current policy",
+		Long: `Verify whether the given enclave identity would be allowed to query key
+material for the given runtime or, if no runtime is given, to replicate the
+master secret under the current key manager policy. Exits with a non-zero
+status if none of the key manager enclaves would permit the request.`,
+		Run: doPolicyVerify,
+	}
+)
+
+func fetchLivePolicy(cmd *cobra.Command) (*secrets.SignedPolicySGX, []*registry.Runtime, error) {
+	var id common.Namespace
+	if err := id.UnmarshalHex(viper.GetString(CfgKeyManagerID)); err != nil {
+		return nil, nil, fmt.Errorf("malformed key manager runtime ID: %w", err)
+	}
+
+	conn, err := cmdGrpc.NewClient(cmd)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to establish connection with node: %w", err)
+	}
+	defer conn.Close()
+
+	ctx := context.Background()
+	status, err := secrets.NewClient(conn).GetStatus(ctx, &registry.NamespaceQuery{
+		Height: consensus.HeightLatest,
+		ID:     id,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query key manager status: %w", err)
+	}
+	if status.Policy == nil {
+		return nil, nil, fmt.Errorf("key manager %s has no policy", id)
+	}
+
+	runtimes, err := registry.NewRegistryClient(conn).GetRuntimes(ctx, &registry.GetRuntimesQuery{
+		Height:           consensus.HeightLatest,
+		IncludeSuspended: true,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query runtimes: %w", err)
+	}
+
+	return status.Policy, runtimes, nil
+}
+
+func runtimeNames(runtimes []*registry.Runtime) (map[common.Namespace]string, error) {
+	names := make(map[common.Namespace]string)
+	for _, rt := range runtimes {
+		names[rt.ID] = rt.Kind.String() + " runtime"
+	}
+	for rtIDStr, name := range viper.GetStringMapString(CfgRuntimeNames) {
+		var rtID common.Namespace
+		if err := rtID.UnmarshalHex(rtIDStr); err != nil {
+			return nil, fmt.Errorf("malformed runtime ID '%s': %w", rtIDStr, err)
+		}
+		names[rtID] = name
+	}
+	return names, nil
+}
+
+func runtimeName(names map[common.Namespace]string, id common.Namespace) string {
+	if name, ok := names[id]; ok {
+		return name
+	}
+	return "unregistered runtime"
+}
+
+func sortedEnclaveIDs(ids []sgx.EnclaveIdentity) []string {
+	strs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		strs = append(strs, id.String())
+	}
+	sort.Strings(strs)
+	return strs
+}
+
+func prettyPrintPolicy(sigPol *secrets.SignedPolicySGX, names map[common.Namespace]string) {
+	p := sigPol.Policy
+
+	sigStatus := "valid"
+	if err := secrets.SanityCheckSignedPolicySGX(nil, sigPol); err != nil {
+		sigStatus = fmt.Sprintf("invalid (%s)", err)
+	}
+
+	fmt.Printf("Key manager:                     %s\n", p.ID)
+	fmt.Printf("Serial:                          %d\n", p.Serial)
+	fmt.Printf("Master secret rotation interval: %d\n", p.MasterSecretRotationInterval)
+	fmt.Printf("Max ephemeral secret age:        %d\n", p.MaxEphemeralSecretAge)
+	fmt.Printf("Signatures:                      %s\n", sigStatus)
+	for _, sig := range sigPol.Signatures {
+		fmt.Printf("  - %s\n", sig.PublicKey)
+	}
+
+	kmEnclaveIDs := make([]sgx.EnclaveIdentity, 0, len(p.Enclaves))
+	for kmEnclaveID := range p.Enclaves {
+		kmEnclaveIDs = append(kmEnclaveIDs, kmEnclaveID)
+	}
+	sort.Slice(kmEnclaveIDs, func(i, j int) bool {
+		return kmEnclaveIDs[i].String() < kmEnclaveIDs[j].String()
+	})
+
+	fmt.Printf("Enclaves:\n")
+	for _, kmEnclaveID := range kmEnclaveIDs {
+		ep := p.Enclaves[kmEnclaveID]
+
+		fmt.Printf("  %s:\n", kmEnclaveID)
+		fmt.Printf("    May replicate:\n")
+		for _, id := range sortedEnclaveIDs(ep.MayReplicate) {
+			fmt.Printf("      - %s\n", id)
+		}
+
+		rtIDs := make([]common.Namespace, 0, len(ep.MayQuery))
+		for rtID := range ep.MayQuery {
+			rtIDs = append(rtIDs, rtID)
+		}
+		sort.Slice(rtIDs, func(i, j int) bool {
+			return rtIDs[i].String() < rtIDs[j].String()
+		})
+
+		fmt.Printf("    May query:\n")
+		for _, rtID := range rtIDs {
+			fmt.Printf("      %s (%s):\n", rtID, runtimeName(names, rtID))
+			for _, id := range sortedEnclaveIDs(ep.MayQuery[rtID]) {
+				fmt.Printf("        - %s\n", id)
+			}
+		}
+	}
+}
+
+func doPolicyShow(cmd *cobra.Command, _ []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	sigPol, runtimes, err := fetchLivePolicy(cmd)
+	if err != nil {
+		logger.Error("failed to fetch key manager policy",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	if cmdFlags.Verbose() {
+		prettyPolicy, err := cmdCommon.PrettyJSONMarshal(sigPol)
+		if err != nil {
+			logger.Error("failed to get pretty JSON of policy",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+		fmt.Println(string(prettyPolicy))
+		return
+	}
+
+	names, err := runtimeNames(runtimes)
+	if err != nil {
+		logger.Error("failed to parse runtime names",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+	prettyPrintPolicy(sigPol, names)
+}
+
+func doPolicyVerify(cmd *cobra.Command, _ []string) {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	var enclaveID sgx.EnclaveIdentity
+	if err := enclaveID.UnmarshalHex(viper.GetString(CfgCheckEnclaveID)); err != nil {
+		logger.Error("failed to parse enclave ID",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	var rtID *common.Namespace
+	if rtIDStr := viper.GetString(CfgCheckRuntimeID); rtIDStr != "" {
+		rtID = new(common.Namespace)
+		if err := rtID.UnmarshalHex(rtIDStr); err != nil {
+			logger.Error("failed to parse runtime ID",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+	}
+
+	sigPol, runtimes, err := fetchLivePolicy(cmd)
+	if err != nil {
+		logger.Error("failed to fetch key manager policy",
+			"err", err,
+		)
+		os.Exit(1)
+	}
+
+	var (
+		action string
+		perms  []policy.EnclavePermission
+	)
+	switch rtID {
+	case nil:
+		action = "replicate the master secret"
+		perms = policy.CheckReplicate(&sigPol.Policy, enclaveID)
+	default:
+		names, err := runtimeNames(runtimes)
+		if err != nil {
+			logger.Error("failed to parse runtime names",
+				"err", err,
+			)
+			os.Exit(1)
+		}
+		action = fmt.Sprintf("query keys for %s (%s)", rtID, runtimeName(names, *rtID))
+		perms = policy.CheckQuery(&sigPol.Policy, *rtID, enclaveID)
+	}
+
+	verdict := func(allowed bool) string {
+		if allowed {
+			return "allowed"
+		}
+		return "denied"
+	}
+
+	fmt.Printf("Enclave %s requesting to %s (policy serial %d):\n", enclaveID, action, sigPol.Policy.Serial)
+	for _, perm := range perms {
+		fmt.Printf("  key manager enclave %s: %s\n", perm.KeyManagerEnclave, verdict(perm.Allowed))
+	}
+
+	allowed := policy.Allowed(perms)
+	fmt.Printf("Result: %s\n", verdict(allowed))
+	if !allowed {
+		os.Exit(1)
+	}
+}
+
+func registerPolicyCmd(parentCmd *cobra.Command) {
+	for _, v := range []*cobra.Command{
+		policyShowCmd,
+		policyVerifyCmd,
+	} {
+		policyCmd.AddCommand(v)
+		v.Flags().AddFlagSet(livePolicyFlags)
+		v.Flags().AddFlagSet(cmdGrpc.ClientFlags)
+	}
+
+	policyShowCmd.Flags().AddFlagSet(cmdFlags.VerboseFlags)
+	policyVerifyCmd.Flags().AddFlagSet(policyCheckFlags)
+
+	parentCmd.AddCommand(policyCmd)
+}
+
+func init() {
+	livePolicyFlags.String(CfgKeyManagerID, "", "256-bit key manager runtime ID in hex")
+	livePolicyFlags.StringToString(CfgRuntimeNames, map[string]string{}, "runtime_id=name,... human readable names of runtimes referenced by the policy")
+	_ = viper.BindPFlags(livePolicyFlags)
+
+	policyCheckFlags.String(CfgCheckEnclaveID, "", "512-bit enclave ID in hex (concatenated MRENCLAVE and MRSIGNER) whose permissions to verify")
+	policyCheckFlags.String(CfgCheckRuntimeID, "", "256-bit runtime ID in hex to verify query permissions for (default: verify replication)")
+	_ = viper.BindPFlags(policyCheckFlags)
+}
@@ -17,7 +17,8 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	"github.com/oasisprotocol/oasis-core/go/config"
 	consensusAPI "github.com/oasisprotocol/oasis-core/go/consensus/api"
-	"github.com/oasisprotocol/oasis-core/go/consensus/cometbft"
+	consensusBackend "github.com/oasisprotocol/oasis-core/go/consensus/backend"
+	_ "github.com/oasisprotocol/oasis-core/go/consensus/cometbft" // Register the CometBFT consensus backend.
 	consensusLightP2P "github.com/oasisprotocol/oasis-core/go/consensus/p2p/light"
 	controlAPI "github.com/oasisprotocol/oasis-core/go/control/api"
 	genesisAPI "github.com/oasisprotocol/oasis-core/go/genesis/api"
@@ -333,6 +334,19 @@ func (n *Node) initRuntimeWorkers() error {
 	}
 	n.svcMgr.Register(n.ClientWorker)
 
+	// Restore runtimes that were paused before the node was restarted.
+	for _, runtimeID := range n.RegistrationWorker.PausedRuntimes() {
+		rtNode := n.CommonWorker.GetRuntime(runtimeID)
+		if rtNode == nil {
+			continue
+		}
+
+		n.logger.Info("runtime remains paused",
+			"runtime_id", runtimeID,
+		)
+		rtNode.PauseRuntime()
+	}
+
 	// Commit storage settings to the registered runtimes.
 	err = n.RuntimeRegistry.FinishInitialization()
 	if err != nil {
@@ -476,6 +490,16 @@ func NewNode() (node *Node, err error) { // nolint: gocyclo
 
 	node.chainContext = genesisDoc.ChainContext()
 
+	// Make sure that an insecure random beacon is explicitly allowed.
+	beaconParams := genesisDoc.Beacon.Parameters
+	if (beaconParams.Backend == beacon.BackendInsecure || beaconParams.DebugMockBackend) && !flags.DebugInsecureBeacon() {
+		logger.Error("insecure random beacon requires the insecure_beacon debug capability",
+			"backend", beaconParams.Backend,
+			"debug_mock_backend", beaconParams.DebugMockBackend,
+		)
+		return nil, fmt.Errorf("insecure random beacon not allowed")
+	}
+
 	// Configure a directory for the node to work in.
 	node.dataDir, err = configureDataDir(logger)
 	if err != nil {
@@ -502,7 +526,7 @@ func NewNode() (node *Node, err error) { // nolint: gocyclo
 	}
 
 	// Initialize the internal gRPC server.
-	node.grpcInternal, err = cmdGrpc.NewServerLocal(false)
+	node.grpcInternal, err = cmdGrpc.NewServerLocal(node.Identity, false)
 	if err != nil {
 		logger.Error("failed to initialize internal gRPC server",
 			"err", err,
@@ -542,11 +566,28 @@ func NewNode() (node *Node, err error) { // nolint: gocyclo
 		}
 	}
 
-	// Initialize CometBFT consensus backend.
-	node.Consensus, err = cometbft.New(node.svcMgr.Ctx, node.dataDir, node.Identity, node.Upgrader, node.Genesis)
+	// Initialize consensus backend.
+	backendName := config.GlobalConfig.Consensus.Backend
+	if backendName == "" {
+		backendName = genesisDoc.Consensus.Backend
+	}
+	consensusFactory, err := consensusBackend.Get(backendName)
+	if err != nil {
+		logger.Error("failed to select consensus backend",
+			"err", err,
+		)
+		return nil, err
+	}
+	node.Consensus, err = consensusFactory.New(node.svcMgr.Ctx, &consensusBackend.Args{
+		DataDir:         node.dataDir,
+		Identity:        node.Identity,
+		Upgrader:        node.Upgrader,
+		GenesisProvider: node.Genesis,
+	})
 	if err != nil {
-		logger.Error("failed to initialize cometbft service",
+		logger.Error("failed to initialize consensus service",
 			"err", err,
+			"backend", backendName,
 		)
 		return nil, err
 	}
@@ -578,11 +619,17 @@ func NewNode() (node *Node, err error) { // nolint: gocyclo
 		return nil, err
 	}
 
-	// Initialize CometBFT light client.
-	node.LightClient, err = cometbft.NewLightClient(node.svcMgr.Ctx, node.dataDir, genesisDoc, node.Consensus, node.P2P)
+	// Initialize consensus light client.
+	node.LightClient, err = consensusFactory.NewLightClient(node.svcMgr.Ctx, &consensusBackend.LightClientArgs{
+		DataDir:   node.dataDir,
+		Genesis:   genesisDoc,
+		Consensus: node.Consensus,
+		P2P:       node.P2P,
+	})
 	if err != nil {
-		logger.Error("failed to initialize cometbft light client service",
+		logger.Error("failed to initialize consensus light client service",
 			"err", err,
+			"backend", backendName,
 		)
 		return nil, err
 	}
@@ -601,7 +648,7 @@ func NewNode() (node *Node, err error) { // nolint: gocyclo
 			return nil, err
 		}
 
-		if flags.DebugDontBlameOasis() {
+		if flags.DebugServices() {
 			// Register the node as a debug controller if we are in debug mode.
 			controlAPI.RegisterDebugService(node.grpcInternal.Server(), node)
 
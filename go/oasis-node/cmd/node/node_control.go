@@ -6,6 +6,9 @@ import (
 	"time"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	"github.com/oasisprotocol/oasis-core/go/config"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
@@ -13,8 +16,14 @@ import (
 	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 	p2p "github.com/oasisprotocol/oasis-core/go/p2p/api"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	runtime "github.com/oasisprotocol/oasis-core/go/runtime/api"
+	hostSgx "github.com/oasisprotocol/oasis-core/go/runtime/host/sgx"
+	runtimeRegistry "github.com/oasisprotocol/oasis-core/go/runtime/registry"
 	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
+	nodedb "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
+	"github.com/oasisprotocol/oasis-core/go/worker/common/committee"
+	executorWorker "github.com/oasisprotocol/oasis-core/go/worker/compute/executor/api"
 	keymanagerWorker "github.com/oasisprotocol/oasis-core/go/worker/keymanager/api"
 )
 
@@ -59,6 +68,45 @@ func (n *Node) requestShutdown() (<-chan struct{}, error) {
 	return n.RegistrationWorker.Quit(), nil
 }
 
+// RequestGracefulShutdown implements control.NodeController.
+func (n *Node) RequestGracefulShutdown(ctx context.Context, req *control.GracefulShutdownRequest) error {
+	if req.Deregister && n.RegistrationWorker != nil {
+		// Request deregistration upfront so that the node does not re-register while it is
+		// still finishing its current rounds.
+		if err := n.RegistrationWorker.RequestDeregistration(); err != nil {
+			return err
+		}
+	}
+
+	ch := make(chan struct{})
+	go func() {
+		defer close(ch)
+
+		n.logger.Info("graceful shutdown requested, handing off committee duties",
+			"deregister", req.Deregister,
+		)
+
+		if err := n.ExecutorWorker.Drain(context.Background()); err != nil {
+			n.logger.Error("failed to drain executor worker",
+				"err", err,
+			)
+		}
+
+		n.logger.Info("committee duties handed off, shutting down")
+		n.RegistrationStopped()
+	}()
+
+	if req.Wait {
+		select {
+		case <-ch:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
 // WaitReady implements control.NodeController.
 func (n *Node) WaitReady(ctx context.Context) error {
 	select {
@@ -149,11 +197,17 @@ func (n *Node) GetStatus(ctx context.Context) (*control.Status, error) {
 
 	p2p := n.getP2PStatus()
 
+	tcbCache, err := n.getTCBCacheStatus()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get TCB cache status: %w", err)
+	}
+
 	var ds *control.DebugStatus
 	if debugEnabled := cmdFlags.DebugDontBlameOasis(); debugEnabled {
 		ds = &control.DebugStatus{
-			Enabled:   debugEnabled,
-			AllowRoot: cmdFlags.DebugAllowRoot(),
+			Enabled:      debugEnabled,
+			AllowRoot:    cmdFlags.DebugAllowRoot(),
+			Capabilities: cmdFlags.DebugCapabilities(),
 		}
 	}
 
@@ -169,9 +223,161 @@ func (n *Node) GetStatus(ctx context.Context) (*control.Status, error) {
 		Registration:    rs,
 		PendingUpgrades: pendingUpgrades,
 		P2P:             p2p,
+		TCBCache:        tcbCache,
 	}, nil
 }
 
+// GetDiscrepancyArtifacts implements control.NodeController.
+func (n *Node) GetDiscrepancyArtifacts(_ context.Context, runtimeID common.Namespace) ([]*executorWorker.DiscrepancyArtifact, error) {
+	execNode := n.ExecutorWorker.GetRuntime(runtimeID)
+	if execNode == nil {
+		return nil, control.ErrNotImplemented
+	}
+	return execNode.GetDiscrepancyArtifacts()
+}
+
+// WatchRuntimeLifecycleEvents implements control.NodeController.
+func (n *Node) WatchRuntimeLifecycleEvents(context.Context) (<-chan *runtime.LifecycleEvent, pubsub.ClosableSubscription, error) {
+	if n.RuntimeRegistry == nil {
+		return nil, nil, control.ErrNotImplemented
+	}
+	return n.RuntimeRegistry.WatchLifecycleEvents()
+}
+
+// GetRuntimeStorageStats implements control.NodeController.
+func (n *Node) GetRuntimeStorageStats(ctx context.Context, runtimeID common.Namespace) (*nodedb.SizeStats, error) {
+	ndb, err := n.getRuntimeNodeDB(runtimeID)
+	if err != nil {
+		return nil, err
+	}
+	return ndb.GetSizeStats(ctx)
+}
+
+// CompactRuntimeStorage implements control.NodeController.
+func (n *Node) CompactRuntimeStorage(ctx context.Context, runtimeID common.Namespace) (*nodedb.SizeStats, error) {
+	ndb, err := n.getRuntimeNodeDB(runtimeID)
+	if err != nil {
+		return nil, err
+	}
+
+	n.logger.Info("compacting runtime storage",
+		"runtime_id", runtimeID,
+	)
+	if err = ndb.Compact(); err != nil {
+		return nil, fmt.Errorf("failed to compact runtime storage: %w", err)
+	}
+	return ndb.GetSizeStats(ctx)
+}
+
+// PauseRuntime implements control.NodeController.
+func (n *Node) PauseRuntime(_ context.Context, runtimeID common.Namespace) error {
+	rtNode, err := n.getRuntimeCommitteeNode(runtimeID)
+	if err != nil {
+		return err
+	}
+
+	n.logger.Info("pausing runtime",
+		"runtime_id", runtimeID,
+	)
+	if err = n.RegistrationWorker.SetRuntimePaused(runtimeID, true); err != nil {
+		return err
+	}
+	rtNode.PauseRuntime()
+	return nil
+}
+
+// ResumeRuntime implements control.NodeController.
+func (n *Node) ResumeRuntime(_ context.Context, runtimeID common.Namespace) error {
+	rtNode, err := n.getRuntimeCommitteeNode(runtimeID)
+	if err != nil {
+		return err
+	}
+
+	n.logger.Info("resuming runtime",
+		"runtime_id", runtimeID,
+	)
+	if err = n.RegistrationWorker.SetRuntimePaused(runtimeID, false); err != nil {
+		return err
+	}
+	rtNode.ResumeRuntime()
+	return nil
+}
+
+// RestartRuntime implements control.NodeController.
+func (n *Node) RestartRuntime(ctx context.Context, runtimeID common.Namespace) error {
+	rtNode, err := n.getRuntimeCommitteeNode(runtimeID)
+	if err != nil {
+		return err
+	}
+
+	n.logger.Info("restarting runtime",
+		"runtime_id", runtimeID,
+	)
+	return rtNode.RestartRuntime(ctx)
+}
+
+// SetLogLevel implements control.NodeController.
+func (n *Node) SetLogLevel(_ context.Context, req *control.SetLogLevelRequest) error {
+	if err := logging.SetModuleLevel(req.Module, req.Level); err != nil {
+		return err
+	}
+
+	n.logger.Warn("log level changed",
+		"module", req.Module,
+		"level", req.Level.String(),
+	)
+	return nil
+}
+
+// GetAttestationAuditLog implements control.NodeController.
+func (n *Node) GetAttestationAuditLog(_ context.Context, runtimeID common.Namespace) ([]*hostSgx.AttestationRecord, error) {
+	if n.RuntimeRegistry == nil {
+		return nil, control.ErrNotImplemented
+	}
+	if _, err := n.RuntimeRegistry.GetRuntime(runtimeID); err != nil {
+		return nil, err
+	}
+	return hostSgx.GetAttestationAuditLog(n.commonStore, runtimeID)
+}
+
+// getRuntimeCommitteeNode returns the common committee node of the given hosted runtime.
+func (n *Node) getRuntimeCommitteeNode(runtimeID common.Namespace) (*committee.Node, error) {
+	if n.RuntimeRegistry == nil || n.CommonWorker == nil {
+		return nil, control.ErrNotImplemented
+	}
+	if _, err := n.RuntimeRegistry.GetRuntime(runtimeID); err != nil {
+		return nil, err
+	}
+
+	rtNode := n.CommonWorker.GetRuntime(runtimeID)
+	if rtNode == nil {
+		// Runtime is not hosted by this node.
+		return nil, control.ErrNotImplemented
+	}
+	return rtNode, nil
+}
+
+// getRuntimeNodeDB returns the local node database of the given runtime.
+func (n *Node) getRuntimeNodeDB(runtimeID common.Namespace) (nodedb.NodeDB, error) {
+	if n.RuntimeRegistry == nil {
+		return nil, control.ErrNotImplemented
+	}
+	rt, err := n.RuntimeRegistry.GetRuntime(runtimeID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch backend := rt.Storage().(type) {
+	case storage.LocalBackend:
+		return backend.NodeDB(), nil
+	case storage.WrappedLocalBackend:
+		return backend.Unwrap().NodeDB(), nil
+	default:
+		// Runtime does not have local storage.
+		return nil, control.ErrNotImplemented
+	}
+}
+
 func (n *Node) getIdentityStatus() control.IdentityStatus {
 	return control.IdentityStatus{
 		Node:      n.Identity.NodeSigner.Public(),
@@ -332,6 +538,8 @@ func (n *Node) getRuntimeStatus(ctx context.Context) (map[common.Namespace]contr
 			}
 		}
 
+		status.Health = n.getRuntimeHealth(rt, &status)
+
 		// Fetch provisioner type.
 		_, provisioner, err := rt.Host()
 		switch {
@@ -351,6 +559,57 @@ func (n *Node) getRuntimeStatus(ctx context.Context) (map[common.Namespace]contr
 	return runtimes, nil
 }
 
+// getRuntimeHealth derives the runtime health summary from the already populated runtime status.
+//
+// In case the runtime is not hosted by this node, nil is returned.
+func (n *Node) getRuntimeHealth(rt runtimeRegistry.Runtime, status *control.RuntimeStatus) *control.RuntimeHealthStatus {
+	if status.Committee == nil {
+		return nil
+	}
+
+	health := control.RuntimeHealthStatus{
+		Roles:              status.Committee.ExecutorRoles,
+		LastProcessedRound: status.Committee.LatestRound,
+	}
+	if status.Storage != nil && status.LatestRound > status.Storage.LastFinalizedRound {
+		health.PendingStorageSync = status.LatestRound - status.Storage.LastFinalizedRound
+	}
+
+	if status.Descriptor == nil || status.Descriptor.TEEHardware != node.TEEHardwareIntelSGX {
+		return &health
+	}
+
+	var attestation control.RuntimeAttestationStatus
+	records, err := hostSgx.GetAttestationAuditLog(n.commonStore, rt.ID())
+	if err != nil {
+		n.logger.Error("failed to fetch attestation audit log",
+			"err", err,
+			"runtime_id", rt.ID(),
+		)
+	}
+	for i := len(records) - 1; i >= 0; i-- {
+		if !records[i].Accepted {
+			continue
+		}
+		attestation.LastAttestation = records[i].Timestamp
+		attestation.TCBStatus = records[i].TCBStatus
+		break
+	}
+
+	tcbStatus, err := hostSgx.GetTCBCacheStatus(n.commonStore)
+	switch {
+	case err != nil:
+		n.logger.Error("failed to fetch TCB cache status",
+			"err", err,
+		)
+	case tcbStatus != nil:
+		attestation.Expiry = tcbStatus.ExpectedExpiry
+	}
+	health.Attestation = &attestation
+
+	return &health
+}
+
 func (n *Node) getKeymanagerStatus() (*keymanagerWorker.Status, error) {
 	if n.KeymanagerWorker == nil || !n.KeymanagerWorker.Enabled() {
 		return nil, nil
@@ -365,3 +624,7 @@ func (n *Node) getPendingUpgrades() ([]*upgrade.PendingUpgrade, error) {
 func (n *Node) getP2PStatus() *p2p.Status {
 	return n.P2P.GetStatus()
 }
+
+func (n *Node) getTCBCacheStatus() (*hostSgx.TCBCacheStatus, error) {
+	return hostSgx.GetTCBCacheStatus(n.commonStore)
+}
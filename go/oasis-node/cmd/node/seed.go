@@ -119,7 +119,7 @@ func NewSeedNode() (node *SeedNode, err error) {
 	}
 
 	// Initialize the internal gRPC server.
-	node.grpcInternal, err = cmdGrpc.NewServerLocal(false)
+	node.grpcInternal, err = cmdGrpc.NewServerLocal(node.identity, false)
 	if err != nil {
 		node.logger.Error("failed to initialize internal gRPC server",
 			"err", err,
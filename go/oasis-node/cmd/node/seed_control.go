@@ -3,10 +3,17 @@ package node
 import (
 	"context"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	"github.com/oasisprotocol/oasis-core/go/config"
 	control "github.com/oasisprotocol/oasis-core/go/control/api"
+	runtime "github.com/oasisprotocol/oasis-core/go/runtime/api"
+	hostSgx "github.com/oasisprotocol/oasis-core/go/runtime/host/sgx"
+	nodedb "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
 	upgrade "github.com/oasisprotocol/oasis-core/go/upgrade/api"
+	executorWorker "github.com/oasisprotocol/oasis-core/go/worker/compute/executor/api"
 )
 
 // Assert that the seed node implements NodeController interface.
@@ -18,6 +25,12 @@ func (n *SeedNode) RequestShutdown(context.Context, bool) error {
 	return nil
 }
 
+// RequestGracefulShutdown implements control.NodeController.
+func (n *SeedNode) RequestGracefulShutdown(context.Context, *control.GracefulShutdownRequest) error {
+	n.Stop()
+	return nil
+}
+
 // WaitReady implements control.NodeController.
 func (n *SeedNode) WaitReady(context.Context) error {
 	return control.ErrNotImplemented
@@ -48,6 +61,59 @@ func (n *SeedNode) CancelUpgrade(context.Context, *upgrade.Descriptor) error {
 	return control.ErrNotImplemented
 }
 
+// GetDiscrepancyArtifacts implements control.NodeController.
+func (n *SeedNode) GetDiscrepancyArtifacts(context.Context, common.Namespace) ([]*executorWorker.DiscrepancyArtifact, error) {
+	return nil, control.ErrNotImplemented
+}
+
+// WatchRuntimeLifecycleEvents implements control.NodeController.
+func (n *SeedNode) WatchRuntimeLifecycleEvents(context.Context) (<-chan *runtime.LifecycleEvent, pubsub.ClosableSubscription, error) {
+	return nil, nil, control.ErrNotImplemented
+}
+
+// GetRuntimeStorageStats implements control.NodeController.
+func (n *SeedNode) GetRuntimeStorageStats(context.Context, common.Namespace) (*nodedb.SizeStats, error) {
+	return nil, control.ErrNotImplemented
+}
+
+// CompactRuntimeStorage implements control.NodeController.
+func (n *SeedNode) CompactRuntimeStorage(context.Context, common.Namespace) (*nodedb.SizeStats, error) {
+	return nil, control.ErrNotImplemented
+}
+
+// PauseRuntime implements control.NodeController.
+func (n *SeedNode) PauseRuntime(context.Context, common.Namespace) error {
+	return control.ErrNotImplemented
+}
+
+// ResumeRuntime implements control.NodeController.
+func (n *SeedNode) ResumeRuntime(context.Context, common.Namespace) error {
+	return control.ErrNotImplemented
+}
+
+// RestartRuntime implements control.NodeController.
+func (n *SeedNode) RestartRuntime(context.Context, common.Namespace) error {
+	return control.ErrNotImplemented
+}
+
+// GetAttestationAuditLog implements control.NodeController.
+func (n *SeedNode) GetAttestationAuditLog(context.Context, common.Namespace) ([]*hostSgx.AttestationRecord, error) {
+	return nil, control.ErrNotImplemented
+}
+
+// SetLogLevel implements control.NodeController.
+func (n *SeedNode) SetLogLevel(_ context.Context, req *control.SetLogLevelRequest) error {
+	if err := logging.SetModuleLevel(req.Module, req.Level); err != nil {
+		return err
+	}
+
+	n.logger.Warn("log level changed",
+		"module", req.Module,
+		"level", req.Level.String(),
+	)
+	return nil
+}
+
 // GetStatus implements control.NodeController.
 func (n *SeedNode) GetStatus(_ context.Context) (*control.Status, error) {
 	tmAddresses, err := n.cometbftSeed.GetAddresses()
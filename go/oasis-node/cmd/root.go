@@ -19,6 +19,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/keymanager"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/node"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/registry"
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/runtime"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/signer"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/stake"
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/storage"
@@ -81,6 +82,7 @@ func init() {
 		identity.Register,
 		keymanager.Register,
 		registry.Register,
+		runtime.Register,
 		signer.Register,
 		stake.Register,
 		storage.Register,
@@ -0,0 +1,414 @@
+// Package bundle implements the runtime bundle sub-commands.
+package bundle
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
+)
+
+const (
+	// CfgOutput is the path to the output runtime bundle.
+	CfgOutput = "bundle.output"
+
+	// CfgExpectedManifestHash is the expected manifest hash of the verified bundle.
+	CfgExpectedManifestHash = "bundle.expected_manifest_hash"
+
+	// CfgComponentID is the identifier of the added component.
+	CfgComponentID = "bundle.component.id"
+	// CfgComponentExecutable is the path to the ELF executable of the added component.
+	CfgComponentExecutable = "bundle.component.executable"
+	// CfgComponentSGXExecutable is the path to the SGX executable of the added component.
+	CfgComponentSGXExecutable = "bundle.component.sgx.executable"
+	// CfgComponentSGXSignature is the path to the SGX signature of the added component.
+	CfgComponentSGXSignature = "bundle.component.sgx.signature"
+	// CfgComponentDisabled marks the added component as disabled by default.
+	CfgComponentDisabled = "bundle.component.disabled"
+
+	// CfgRuntimeName overrides the runtime name of the repackaged bundle.
+	CfgRuntimeName = "bundle.runtime.name"
+	// CfgRuntimeVersion overrides the runtime version of the repackaged bundle.
+	CfgRuntimeVersion = "bundle.runtime.version"
+)
+
+var (
+	outputFlags       = flag.NewFlagSet("", flag.ContinueOnError)
+	verifyFlags       = flag.NewFlagSet("", flag.ContinueOnError)
+	addComponentFlags = flag.NewFlagSet("", flag.ContinueOnError)
+	repackageFlags    = flag.NewFlagSet("", flag.ContinueOnError)
+
+	bundleCmd = &cobra.Command{
+		Use:   "bundle",
+		Short: "inspect and manipulate runtime bundles",
+	}
+
+	infoCmd = &cobra.Command{
+		Use:   "info <bundle>",
+		Short: "show the manifest, digests and enclave identities of a runtime bundle",
+		Args:  cobra.ExactArgs(1),
+		RunE:  doInfo,
+	}
+
+	verifyCmd = &cobra.Command{
+		Use:   "verify <bundle>",
+		Short: "verify the integrity of a runtime bundle",
+		Args:  cobra.ExactArgs(1),
+		RunE:  doVerify,
+	}
+
+	extractCmd = &cobra.Command{
+		Use:   "extract <bundle> <dir>",
+		Short: "extract the contents of a runtime bundle into a directory",
+		Args:  cobra.ExactArgs(2),
+		RunE:  doExtract,
+	}
+
+	addComponentCmd = &cobra.Command{
+		Use:   "add-component <bundle>",
+		Short: "add a component to a runtime bundle",
+		Args:  cobra.ExactArgs(1),
+		RunE:  doAddComponent,
+	}
+
+	repackageCmd = &cobra.Command{
+		Use:   "repackage <bundle>",
+		Short: "repackage a runtime bundle, optionally updating its metadata",
+		Args:  cobra.ExactArgs(1),
+		RunE:  doRepackage,
+	}
+
+	logger = logging.GetLogger("cmd/runtime/bundle")
+)
+
+// bundleInfo is the information about a runtime bundle.
+type bundleInfo struct {
+	// ManifestHash is the hash of the bundle manifest.
+	ManifestHash hash.Hash `json:"manifest_hash"`
+	// Manifest is the bundle manifest, including the digests of all bundle contents.
+	Manifest *bundle.Manifest `json:"manifest"`
+	// Components are the available bundle components.
+	Components []*componentInfo `json:"components"`
+}
+
+// componentInfo is the information about a runtime bundle component.
+type componentInfo struct {
+	// ID is the component identifier.
+	ID component.ID `json:"id"`
+	// MrEnclave is the SGX MRENCLAVE of the component, if any.
+	MrEnclave *sgx.MrEnclave `json:"mr_enclave,omitempty"`
+	// MrSigner is the SGX MRSIGNER of the component, if the component is signed.
+	MrSigner *sgx.MrSigner `json:"mr_signer,omitempty"`
+}
+
+func openBundle(fn string) (*bundle.Bundle, error) {
+	bnd, err := bundle.Open(fn)
+	if err != nil {
+		logger.Error("failed to open bundle",
+			"err", err,
+			"file_name", fn,
+		)
+		return nil, err
+	}
+	return bnd, nil
+}
+
+func writeBundle(bnd *bundle.Bundle) error {
+	dstFn := viper.GetString(CfgOutput)
+	if dstFn == "" {
+		logger.Error("missing output runtime bundle name")
+		return fmt.Errorf("missing output runtime bundle name")
+	}
+
+	// Write the bundle. This recomputes the digests and validates the bundle.
+	bnd.ResetManifest()
+	if err := bnd.Write(dstFn); err != nil {
+		logger.Error("failed to write runtime bundle",
+			"err", err,
+		)
+		return err
+	}
+	fmt.Printf("Manifest hash: %s\n", bnd.Manifest.Hash())
+
+	return nil
+}
+
+func doInfo(_ *cobra.Command, args []string) error {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	bnd, err := openBundle(args[0])
+	if err != nil {
+		return err
+	}
+	defer bnd.Close()
+
+	info := bundleInfo{
+		ManifestHash: bnd.Manifest.Hash(),
+		Manifest:     bnd.Manifest,
+		Components:   componentInfos(bnd),
+	}
+
+	b, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		logger.Error("failed to serialize bundle info",
+			"err", err,
+		)
+		return err
+	}
+	fmt.Println(string(b))
+
+	return nil
+}
+
+func componentInfos(bnd *bundle.Bundle) []*componentInfo {
+	var infos []*componentInfo
+	for _, id := range sortedComponentIDs(bnd) {
+		info := &componentInfo{
+			ID: id,
+		}
+		if comp := bnd.Manifest.GetComponentByID(id); comp.SGX != nil {
+			// Signatures are optional, so only report what can be derived.
+			info.MrEnclave, _ = bnd.MrEnclave(id)
+			if comp.SGX.Signature != "" {
+				info.MrSigner, _ = bnd.MrSigner(id)
+			}
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+func sortedComponentIDs(bnd *bundle.Bundle) []component.ID {
+	var ids []component.ID
+	for id := range bnd.Manifest.GetAvailableComponents() {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].String() < ids[j].String()
+	})
+	return ids
+}
+
+func doVerify(_ *cobra.Command, args []string) error {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	// Opening the bundle verifies the manifest, the digests of all contents, the executables and
+	// the SGX signatures (if any).
+	bnd, err := openBundle(args[0])
+	if err != nil {
+		return err
+	}
+	defer bnd.Close()
+
+	manifestHash := bnd.Manifest.Hash()
+	if raw := viper.GetString(CfgExpectedManifestHash); raw != "" {
+		var expected hash.Hash
+		if err = expected.UnmarshalHex(raw); err != nil {
+			logger.Error("failed to parse expected manifest hash",
+				"err", err,
+			)
+			return err
+		}
+		if !expected.Equal(&manifestHash) {
+			logger.Error("manifest hash mismatch",
+				"expected", expected,
+				"actual", manifestHash,
+			)
+			return fmt.Errorf("manifest hash mismatch")
+		}
+	}
+
+	// Make sure the MRENCLAVE of all SGX components can be derived.
+	for _, id := range sortedComponentIDs(bnd) {
+		comp := bnd.Manifest.GetComponentByID(id)
+		if comp.SGX == nil {
+			continue
+		}
+		if _, err = bnd.MrEnclave(id); err != nil {
+			logger.Error("failed to derive MRENCLAVE",
+				"err", err,
+				"component_id", id,
+			)
+			return err
+		}
+	}
+
+	fmt.Printf("Bundle OK, manifest hash: %s\n", manifestHash)
+
+	return nil
+}
+
+func doExtract(_ *cobra.Command, args []string) error {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	bnd, err := openBundle(args[0])
+	if err != nil {
+		return err
+	}
+	defer bnd.Close()
+
+	if err = bnd.Extract(args[1]); err != nil {
+		logger.Error("failed to extract bundle",
+			"err", err,
+		)
+		return err
+	}
+
+	return nil
+}
+
+func doAddComponent(_ *cobra.Command, args []string) error {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	var id component.ID
+	if err := id.UnmarshalText([]byte(viper.GetString(CfgComponentID))); err != nil {
+		logger.Error("failed to parse component ID",
+			"err", err,
+		)
+		return err
+	}
+
+	comp := &bundle.Component{
+		Kind:     id.Kind,
+		Name:     id.Name,
+		Disabled: viper.GetBool(CfgComponentDisabled),
+	}
+	files := make(map[string][]byte)
+	for _, v := range []struct {
+		cfg, descr string
+		dst        func(fn string)
+	}{
+		{CfgComponentExecutable, "ELF executable", func(fn string) { comp.Executable = fn }},
+		{CfgComponentSGXExecutable, "SGX executable", func(fn string) {
+			comp.SGX = &bundle.SGXMetadata{Executable: fn}
+		}},
+		{CfgComponentSGXSignature, "SGX signature", func(fn string) {
+			if comp.SGX != nil {
+				comp.SGX.Signature = fn
+			}
+		}},
+	} {
+		path := viper.GetString(v.cfg)
+		if path == "" {
+			continue
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			logger.Error("failed to load component asset",
+				"err", err,
+				"descr", v.descr,
+				"file_name", path,
+			)
+			return err
+		}
+		fn := filepath.Base(path)
+		files[fn] = b
+		v.dst(fn)
+	}
+	if comp.SGX == nil && viper.GetString(CfgComponentSGXSignature) != "" {
+		logger.Error("SGX signature given without an SGX executable")
+		return fmt.Errorf("SGX signature given without an SGX executable")
+	}
+
+	bnd, err := openBundle(args[0])
+	if err != nil {
+		return err
+	}
+	defer bnd.Close()
+
+	if err = bnd.AddComponent(comp, files); err != nil {
+		logger.Error("failed to add component",
+			"err", err,
+			"component_id", id,
+		)
+		return err
+	}
+
+	return writeBundle(bnd)
+}
+
+func doRepackage(_ *cobra.Command, args []string) error {
+	if err := cmdCommon.Init(); err != nil {
+		cmdCommon.EarlyLogAndExit(err)
+	}
+
+	bnd, err := openBundle(args[0])
+	if err != nil {
+		return err
+	}
+	defer bnd.Close()
+
+	if name := viper.GetString(CfgRuntimeName); name != "" {
+		bnd.Manifest.Name = name
+	}
+	if rawVersion := viper.GetString(CfgRuntimeVersion); rawVersion != "" {
+		if bnd.Manifest.Version, err = version.FromString(rawVersion); err != nil {
+			logger.Error("failed to parse runtime version",
+				"err", err,
+			)
+			return err
+		}
+	}
+
+	return writeBundle(bnd)
+}
+
+// Register registers the bundle sub-command and all of it's children.
+func Register(parentCmd *cobra.Command) {
+	verifyCmd.Flags().AddFlagSet(verifyFlags)
+	addComponentCmd.Flags().AddFlagSet(addComponentFlags)
+	addComponentCmd.Flags().AddFlagSet(outputFlags)
+	repackageCmd.Flags().AddFlagSet(repackageFlags)
+	repackageCmd.Flags().AddFlagSet(outputFlags)
+
+	for _, cmd := range []*cobra.Command{
+		infoCmd,
+		verifyCmd,
+		extractCmd,
+		addComponentCmd,
+		repackageCmd,
+	} {
+		bundleCmd.AddCommand(cmd)
+	}
+	parentCmd.AddCommand(bundleCmd)
+}
+
+func init() {
+	outputFlags.String(CfgOutput, "", "path to the output runtime bundle")
+	_ = viper.BindPFlags(outputFlags)
+
+	verifyFlags.String(CfgExpectedManifestHash, "", "expected manifest hash (hex-encoded)")
+	_ = viper.BindPFlags(verifyFlags)
+
+	addComponentFlags.String(CfgComponentID, "", "component identifier (e.g. rofl.name)")
+	addComponentFlags.String(CfgComponentExecutable, "", "path to the component ELF executable")
+	addComponentFlags.String(CfgComponentSGXExecutable, "", "path to the component SGX executable")
+	addComponentFlags.String(CfgComponentSGXSignature, "", "path to the component SGX signature")
+	addComponentFlags.Bool(CfgComponentDisabled, false, "whether the component is disabled by default")
+	_ = viper.BindPFlags(addComponentFlags)
+
+	repackageFlags.String(CfgRuntimeName, "", "new runtime name (unchanged if empty)")
+	repackageFlags.String(CfgRuntimeVersion, "", "new runtime version (unchanged if empty)")
+	_ = viper.BindPFlags(repackageFlags)
+}
@@ -0,0 +1,20 @@
+// Package runtime implements the runtime sub-commands.
+package runtime
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/runtime/bundle"
+)
+
+var runtimeCmd = &cobra.Command{
+	Use:   "runtime",
+	Short: "runtime utilities",
+}
+
+// Register registers the runtime sub-command and all of it's children.
+func Register(parentCmd *cobra.Command) {
+	bundle.Register(runtimeCmd)
+
+	parentCmd.AddCommand(runtimeCmd)
+}
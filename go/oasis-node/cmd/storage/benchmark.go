@@ -0,0 +1,444 @@
+package storage
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	mathRand "math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/config"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	"github.com/oasisprotocol/oasis-core/go/runtime/registry"
+	storageAPI "github.com/oasisprotocol/oasis-core/go/storage/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/database"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	mkvsDB "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db"
+	db "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
+	workerStorage "github.com/oasisprotocol/oasis-core/go/worker/storage"
+)
+
+const (
+	cfgBenchmarkBackends       = "storage.benchmark.backends"
+	cfgBenchmarkDir            = "storage.benchmark.dir"
+	cfgBenchmarkRounds         = "storage.benchmark.rounds"
+	cfgBenchmarkWritesPerRound = "storage.benchmark.writes_per_round"
+	cfgBenchmarkReads          = "storage.benchmark.reads"
+	cfgBenchmarkValueSize      = "storage.benchmark.value_size"
+	cfgBenchmarkFsync          = "storage.benchmark.fsync"
+	cfgBenchmarkRuntime        = "storage.benchmark.runtime"
+
+	// benchmarkMaxSampledKeys is the maximum number of keys sampled from an existing runtime
+	// database to use for reads.
+	benchmarkMaxSampledKeys = 10_000
+)
+
+var (
+	storageBenchmarkCmd = &cobra.Command{
+		Use:   "benchmark",
+		Short: "benchmark node database backends with a representative runtime workload",
+		Long: `Benchmark node database backends with a representative runtime workload.
+
+By default, each of the selected backends is benchmarked using a synthetic workload against a
+fresh database created in a temporary directory, so that backends and disks can be compared
+before joining a committee.
+
+When a runtime is specified, its configured node database is opened read-only and only the
+read and proof workload is performed against the latest state root, using keys sampled from the
+existing state. Since the database cannot be opened while it is in use, the node must be
+stopped first.`,
+		RunE: doBenchmark,
+	}
+
+	storageBenchmarkFlags = flag.NewFlagSet("", flag.ContinueOnError)
+)
+
+// benchmarkConfig is the node database benchmark workload configuration.
+type benchmarkConfig struct {
+	rounds         uint64
+	writesPerRound int
+	reads          int
+	valueSize      int
+}
+
+// latencies is a set of measured operation latencies.
+type latencies []time.Duration
+
+func (l latencies) percentile(p float64) time.Duration {
+	if len(l) == 0 {
+		return 0
+	}
+	idx := int(float64(len(l)-1) * p)
+	return l[idx]
+}
+
+func (l latencies) mean() time.Duration {
+	if len(l) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, v := range l {
+		total += v
+	}
+	return total / time.Duration(len(l))
+}
+
+// benchmarkResults are the results of benchmarking a single node database backend.
+type benchmarkResults struct {
+	backend string
+	ops     map[string]latencies
+	size    int64
+}
+
+func (r *benchmarkResults) record(op string, d time.Duration) {
+	r.ops[op] = append(r.ops[op], d)
+}
+
+func (r *benchmarkResults) print(w io.Writer) {
+	fmt.Fprintf(w, "Backend: %s (database size: %d bytes)\n", r.backend, r.size)
+	fmt.Fprintf(w, "  %-8s %8s %12s %12s %12s %12s %12s\n", "op", "count", "mean", "p50", "p90", "p99", "max")
+
+	ops := make([]string, 0, len(r.ops))
+	for op := range r.ops {
+		ops = append(ops, op)
+	}
+	sort.Strings(ops)
+
+	for _, op := range ops {
+		l := r.ops[op]
+		sort.Slice(l, func(i, j int) bool { return l[i] < l[j] })
+		fmt.Fprintf(w, "  %-8s %8d %12s %12s %12s %12s %12s\n",
+			op,
+			len(l),
+			l.mean(),
+			l.percentile(0.5),
+			l.percentile(0.9),
+			l.percentile(0.99),
+			l.percentile(1.0),
+		)
+	}
+}
+
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
+func benchmarkKey(i int) []byte {
+	return []byte(fmt.Sprintf("benchmark key %08d", i))
+}
+
+// runBenchmark runs the benchmark workload against the given node database.
+//
+// The workload commits the configured number of rounds, each updating existing keys and
+// inserting new ones, followed by random reads and proof generation against the last
+// finalized root.
+func runBenchmark(ctx context.Context, ndb db.NodeDB, ns common.Namespace, cfg *benchmarkConfig, results *benchmarkResults) error {
+	rng := mathRand.New(mathRand.NewSource(time.Now().UnixNano())) // nolint: gosec
+	value := make([]byte, cfg.valueSize)
+
+	var (
+		root    node.Root
+		numKeys int
+	)
+	for round := uint64(0); round < cfg.rounds; round++ {
+		var tree mkvs.Tree
+		switch round {
+		case 0:
+			tree = mkvs.New(nil, ndb, node.RootTypeState)
+		default:
+			tree = mkvs.NewWithRoot(nil, ndb, root)
+		}
+
+		start := time.Now()
+		for i := 0; i < cfg.writesPerRound; i++ {
+			// Half of the writes update existing keys and half insert new ones.
+			var key []byte
+			switch {
+			case numKeys > 0 && i%2 == 0:
+				key = benchmarkKey(rng.Intn(numKeys))
+			default:
+				key = benchmarkKey(numKeys)
+				numKeys++
+			}
+			if _, err := io.ReadFull(rand.Reader, value); err != nil {
+				tree.Close()
+				return err
+			}
+			if err := tree.Insert(ctx, key, value); err != nil {
+				tree.Close()
+				return fmt.Errorf("failed to insert: %w", err)
+			}
+		}
+		_, rootHash, err := tree.Commit(ctx, ns, round)
+		tree.Close()
+		if err != nil {
+			return fmt.Errorf("failed to commit: %w", err)
+		}
+		root = node.Root{
+			Namespace: ns,
+			Version:   round,
+			Type:      node.RootTypeState,
+			Hash:      rootHash,
+		}
+		if err = ndb.Finalize([]node.Root{root}); err != nil {
+			return fmt.Errorf("failed to finalize: %w", err)
+		}
+		results.record("commit", time.Since(start))
+	}
+
+	keys := make([][]byte, 0, numKeys)
+	for i := 0; i < numKeys; i++ {
+		keys = append(keys, benchmarkKey(i))
+	}
+	return runReadBenchmark(ctx, ndb, root, keys, cfg.reads, results)
+}
+
+// runReadBenchmark performs random reads and proof generation of the given keys against the
+// given root.
+func runReadBenchmark(ctx context.Context, ndb db.NodeDB, root node.Root, keys [][]byte, reads int, results *benchmarkResults) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	rng := mathRand.New(mathRand.NewSource(time.Now().UnixNano())) // nolint: gosec
+
+	for i := 0; i < reads; i++ {
+		key := keys[rng.Intn(len(keys))]
+
+		// Use a fresh tree for each operation so that the in-memory tree cache is not used.
+		tree := mkvs.NewWithRoot(nil, ndb, root)
+		start := time.Now()
+		if _, err := tree.Get(ctx, key); err != nil {
+			tree.Close()
+			return fmt.Errorf("failed to get: %w", err)
+		}
+		results.record("get", time.Since(start))
+		tree.Close()
+
+		tree = mkvs.NewWithRoot(nil, ndb, root)
+		start = time.Now()
+		_, err := tree.SyncGet(ctx, &syncer.GetRequest{
+			Tree: syncer.TreeID{
+				Root:     root,
+				Position: root.Hash,
+			},
+			Key:          key,
+			ProofVersion: syncer.LatestProofVersion,
+		})
+		if err != nil {
+			tree.Close()
+			return fmt.Errorf("failed to generate proof: %w", err)
+		}
+		results.record("proof", time.Since(start))
+		tree.Close()
+	}
+
+	return nil
+}
+
+// latestStateRoot returns the state root of the latest version in the given node database.
+func latestStateRoot(ndb db.NodeDB) (node.Root, error) {
+	version, ok := ndb.GetLatestVersion()
+	if !ok {
+		return node.Root{}, fmt.Errorf("node database is empty")
+	}
+	roots, err := ndb.GetRootsForVersion(version)
+	if err != nil {
+		return node.Root{}, fmt.Errorf("failed to get roots for version %d: %w", version, err)
+	}
+	for _, root := range roots {
+		if root.Type == node.RootTypeState {
+			return root, nil
+		}
+	}
+	return node.Root{}, fmt.Errorf("no state root for version %d", version)
+}
+
+// sampleKeys returns up to the given number of keys stored under the given root.
+func sampleKeys(ctx context.Context, ndb db.NodeDB, root node.Root, limit int) ([][]byte, error) {
+	tree := mkvs.NewWithRoot(nil, ndb, root)
+	defer tree.Close()
+
+	it := tree.NewIterator(ctx)
+	defer it.Close()
+
+	var keys [][]byte
+	for it.Rewind(); it.Valid() && len(keys) < limit; it.Next() {
+		keys = append(keys, append([]byte{}, it.Key()...))
+	}
+	if err := it.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate state: %w", err)
+	}
+	return keys, nil
+}
+
+// benchmarkRuntime benchmarks reads and proofs against the configured node database of the
+// given runtime, opened read-only so that the existing state is never modified.
+func benchmarkRuntime(ctx context.Context, runtimeID common.Namespace, reads int) (*benchmarkResults, error) {
+	runtimeDir := registry.GetRuntimeStateDir(cmdCommon.DataDir(), runtimeID)
+	dbCfg := &storageAPI.Config{
+		Backend:      config.GlobalConfig.Storage.RuntimeBackend(runtimeID),
+		Namespace:    runtimeID,
+		ReadOnly:     true,
+		MaxCacheSize: 16 * 1024 * 1024,
+	}
+	dbCfg.DB = workerStorage.GetLocalBackendDBDir(runtimeDir, dbCfg.Backend)
+	if err := database.AutoDetectBackend(dbCfg); err != nil {
+		return nil, err
+	}
+	backend, dbDir := dbCfg.Backend, dbCfg.DB
+	if _, err := os.Stat(dbDir); err != nil {
+		return nil, fmt.Errorf("failed to find node database: %w", err)
+	}
+
+	ndb, err := mkvsDB.New(backend, dbCfg.ToNodeDB())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open node database: %w", err)
+	}
+	defer ndb.Close()
+
+	root, err := latestStateRoot(ndb)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := sampleKeys(ctx, ndb, root, benchmarkMaxSampledKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	results := &benchmarkResults{
+		backend: fmt.Sprintf("%s (runtime %s, round %d)", backend, runtimeID, root.Version),
+		ops:     make(map[string]latencies),
+	}
+	if err = runReadBenchmark(ctx, ndb, root, keys, reads, results); err != nil {
+		return nil, err
+	}
+	if results.size, err = dirSize(dbDir); err != nil {
+		return nil, fmt.Errorf("failed to get database size: %w", err)
+	}
+	return results, nil
+}
+
+func doBenchmark(cmd *cobra.Command, _ []string) error {
+	ctx := context.Background()
+
+	cfg := &benchmarkConfig{
+		rounds:         viper.GetUint64(cfgBenchmarkRounds),
+		writesPerRound: viper.GetInt(cfgBenchmarkWritesPerRound),
+		reads:          viper.GetInt(cfgBenchmarkReads),
+		valueSize:      viper.GetInt(cfgBenchmarkValueSize),
+	}
+	if cfg.rounds == 0 || cfg.writesPerRound <= 0 || cfg.reads < 0 || cfg.valueSize <= 0 {
+		return fmt.Errorf("invalid benchmark workload configuration")
+	}
+
+	// Benchmark the existing node database of a runtime when configured.
+	if rawRuntimeID := viper.GetString(cfgBenchmarkRuntime); rawRuntimeID != "" {
+		var runtimeID common.Namespace
+		if err := runtimeID.UnmarshalHex(rawRuntimeID); err != nil {
+			return fmt.Errorf("malformed runtime identifier '%s': %w", rawRuntimeID, err)
+		}
+
+		if pretty {
+			fmt.Printf("Benchmarking node database of runtime %s...\n", runtimeID)
+		}
+
+		results, err := benchmarkRuntime(ctx, runtimeID, cfg.reads)
+		if err != nil {
+			logger.Error("error benchmarking node database", "rt", runtimeID, "err", err)
+			return fmt.Errorf("error benchmarking node database of runtime %s: %w", runtimeID, err)
+		}
+
+		results.print(cmd.OutOrStdout())
+		return nil
+	}
+
+	// Run the benchmark in the specified directory so that different disks can be compared.
+	baseDir, err := os.MkdirTemp(viper.GetString(cfgBenchmarkDir), "storage-benchmark")
+	if err != nil {
+		return fmt.Errorf("failed to create benchmark directory: %w", err)
+	}
+	defer os.RemoveAll(baseDir)
+
+	var ns common.Namespace
+	for _, backend := range viper.GetStringSlice(cfgBenchmarkBackends) {
+		factory, err := mkvsDB.GetBackendByName(backend)
+		if err != nil {
+			return err
+		}
+
+		if pretty {
+			fmt.Printf("Benchmarking node database backend '%s'...\n", backend)
+		}
+
+		results, err := func() (*benchmarkResults, error) {
+			dbDir := filepath.Join(baseDir, backend)
+			if err := common.Mkdir(dbDir); err != nil {
+				return nil, fmt.Errorf("failed to create database directory: %w", err)
+			}
+
+			ndb, err := factory.New(&db.Config{
+				DB:           workerStorage.GetLocalBackendDBDir(dbDir, backend),
+				NoFsync:      !viper.GetBool(cfgBenchmarkFsync),
+				Namespace:    ns,
+				MaxCacheSize: 16 * 1024 * 1024,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to open node database: %w", err)
+			}
+			results := &benchmarkResults{
+				backend: backend,
+				ops:     make(map[string]latencies),
+			}
+			err = runBenchmark(ctx, ndb, ns, cfg, results)
+			ndb.Close()
+			if err != nil {
+				return nil, err
+			}
+			if results.size, err = dirSize(dbDir); err != nil {
+				return nil, fmt.Errorf("failed to get database size: %w", err)
+			}
+			return results, nil
+		}()
+		if err != nil {
+			logger.Error("error benchmarking node database", "backend", backend, "err", err)
+			return fmt.Errorf("error benchmarking node database backend %s: %w", backend, err)
+		}
+
+		results.print(cmd.OutOrStdout())
+	}
+
+	return nil
+}
+
+func init() {
+	storageBenchmarkFlags.StringSlice(cfgBenchmarkBackends, mkvsDB.Names(), "node database backends to benchmark")
+	storageBenchmarkFlags.String(cfgBenchmarkDir, "", "directory in which to create the benchmark databases (defaults to the system temporary directory)")
+	storageBenchmarkFlags.Uint64(cfgBenchmarkRounds, 100, "number of rounds to commit")
+	storageBenchmarkFlags.Int(cfgBenchmarkWritesPerRound, 1000, "number of writes per round")
+	storageBenchmarkFlags.Int(cfgBenchmarkReads, 10000, "number of reads and proofs to perform")
+	storageBenchmarkFlags.Int(cfgBenchmarkValueSize, 128, "size of values in bytes")
+	storageBenchmarkFlags.Bool(cfgBenchmarkFsync, false, "enable fsync")
+	storageBenchmarkFlags.String(cfgBenchmarkRuntime, "", "benchmark the existing node database of the given runtime (read-only, reads and proofs only)")
+	_ = viper.BindPFlags(storageBenchmarkFlags)
+}
@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/config"
+	"github.com/oasisprotocol/oasis-core/go/runtime/registry"
+	mkvsDB "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db"
+	db "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+	workerStorage "github.com/oasisprotocol/oasis-core/go/worker/storage"
+)
+
+func TestBenchmark(t *testing.T) {
+	require := require.New(t)
+
+	defer func(cfg config.Config) {
+		config.GlobalConfig = cfg
+	}(config.GlobalConfig)
+	config.GlobalConfig = config.DefaultConfig()
+	config.GlobalConfig.Common.DataDir = t.TempDir()
+
+	viper.Set(cfgBenchmarkDir, t.TempDir())
+	viper.Set(cfgBenchmarkRounds, 3)
+	viper.Set(cfgBenchmarkWritesPerRound, 10)
+	viper.Set(cfgBenchmarkReads, 5)
+	viper.Set(cfgBenchmarkValueSize, 16)
+	defer func() {
+		for _, key := range []string{cfgBenchmarkDir, cfgBenchmarkRounds, cfgBenchmarkWritesPerRound, cfgBenchmarkReads, cfgBenchmarkValueSize, cfgBenchmarkRuntime} {
+			viper.Set(key, nil)
+		}
+	}()
+
+	var out bytes.Buffer
+	storageBenchmarkCmd.SetOut(&out)
+	defer storageBenchmarkCmd.SetOut(nil)
+
+	// Synthetic workload against fresh databases of all backends.
+	err := doBenchmark(storageBenchmarkCmd, nil)
+	require.NoError(err, "doBenchmark")
+	for _, backend := range mkvsDB.Names() {
+		require.Contains(out.String(), "Backend: "+backend+" ")
+	}
+	for _, op := range []string{"commit", "get", "proof"} {
+		require.Contains(out.String(), op)
+	}
+
+	// Prepare the node database of a runtime.
+	var runtimeID common.Namespace
+	err = runtimeID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000")
+	require.NoError(err, "UnmarshalHex")
+
+	// The configured backend is auto-detected from the existing node database.
+	backend := mkvsDB.Names()[0]
+	factory, err := mkvsDB.GetBackendByName(backend)
+	require.NoError(err, "GetBackendByName")
+	runtimeDir, err := registry.EnsureRuntimeStateDir(config.GlobalConfig.Common.DataDir, runtimeID)
+	require.NoError(err, "EnsureRuntimeStateDir")
+	dbCfg := &db.Config{
+		DB:           workerStorage.GetLocalBackendDBDir(runtimeDir, backend),
+		Namespace:    runtimeID,
+		MaxCacheSize: 16 * 1024 * 1024,
+	}
+
+	ndb, err := factory.New(dbCfg)
+	require.NoError(err, "New")
+	err = runBenchmark(context.Background(), ndb, runtimeID, &benchmarkConfig{
+		rounds:         3,
+		writesPerRound: 10,
+		valueSize:      16,
+	}, &benchmarkResults{ops: make(map[string]latencies)})
+	ndb.Close()
+	require.NoError(err, "runBenchmark")
+
+	// Read-only workload against the existing runtime node database.
+	viper.Set(cfgBenchmarkRuntime, runtimeID.String())
+	out.Reset()
+	err = doBenchmark(storageBenchmarkCmd, nil)
+	require.NoError(err, "doBenchmark")
+	require.Contains(out.String(), "runtime "+runtimeID.String()+", round 2")
+	require.Contains(out.String(), "get")
+	require.Contains(out.String(), "proof")
+	require.NotContains(out.String(), "commit", "runtime node database should not be written to")
+
+	// The runtime node database should be unchanged.
+	ndb, err = factory.New(dbCfg)
+	require.NoError(err, "New")
+	defer ndb.Close()
+	version, ok := ndb.GetLatestVersion()
+	require.True(ok)
+	require.EqualValues(2, version)
+}
@@ -0,0 +1,157 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/config"
+	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
+	"github.com/oasisprotocol/oasis-core/go/runtime/registry"
+	storageAPI "github.com/oasisprotocol/oasis-core/go/storage/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/database"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
+	mkvsDB "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db"
+	workerStorage "github.com/oasisprotocol/oasis-core/go/worker/storage"
+)
+
+const (
+	cfgConvertBackend   = "storage.convert.backend"
+	cfgConvertChunkSize = "storage.convert.chunk_size"
+
+	convertTmpDir = "convert-tmp"
+)
+
+var (
+	storageConvertCmd = &cobra.Command{
+		Use:   "convert <runtime...>",
+		Args:  cobra.MinimumNArgs(1),
+		Short: "convert node databases to a different backend",
+		Long: `Convert node databases to a different backend.
+
+All finalized versions of each runtime's node database are copied into a new
+database using the target backend. The earliest version is copied in full while
+the following versions are copied using the write logs of the source database,
+so write logs must not have been discarded. The original database is kept and
+must be removed manually once the conversion has been verified.`,
+		RunE: doConvert,
+	}
+
+	storageConvertFlags = flag.NewFlagSet("", flag.ContinueOnError)
+)
+
+func doConvert(_ *cobra.Command, args []string) error {
+	dataDir := cmdCommon.DataDir()
+	ctx := context.Background()
+
+	runtimes, err := parseRuntimes(args)
+	cobra.CheckErr(err)
+
+	dstBackend := strings.ToLower(viper.GetString(cfgConvertBackend))
+	if _, err = mkvsDB.GetBackendByName(dstBackend); err != nil {
+		return err
+	}
+
+	for _, rt := range runtimes {
+		if pretty {
+			fmt.Printf("Converting storage database for runtime %v to %s...\n", rt, dstBackend)
+		}
+		if err = convertRuntime(ctx, dataDir, rt, dstBackend); err != nil {
+			logger.Error("error converting node database", "rt", rt, "err", err)
+			if pretty {
+				fmt.Printf("error converting node database for runtime %v: %v\n", rt, err)
+			}
+			return fmt.Errorf("error converting node database for runtime %v: %w", rt, err)
+		}
+	}
+	return nil
+}
+
+func convertRuntime(ctx context.Context, dataDir string, rt common.Namespace, dstBackend string) error {
+	runtimeDir := registry.GetRuntimeStateDir(dataDir, rt)
+
+	srcCfg := &storageAPI.Config{
+		Backend:   config.GlobalConfig.Storage.RuntimeBackend(rt),
+		Namespace: rt,
+		ReadOnly:  true,
+	}
+	srcCfg.DB = workerStorage.GetLocalBackendDBDir(runtimeDir, srcCfg.Backend)
+	if err := database.AutoDetectBackend(srcCfg); err != nil {
+		return err
+	}
+	if srcCfg.Backend == dstBackend {
+		return fmt.Errorf("node database already uses the '%s' backend", dstBackend)
+	}
+
+	dstCfg := &storageAPI.Config{
+		Backend:   dstBackend,
+		DB:        workerStorage.GetLocalBackendDBDir(runtimeDir, dstBackend),
+		Namespace: rt,
+	}
+	if _, err := os.Stat(dstCfg.DB); err == nil {
+		return fmt.Errorf("destination node database '%s' already exists", dstCfg.DB)
+	}
+
+	src, err := mkvsDB.New(srcCfg.Backend, srcCfg.ToNodeDB())
+	if err != nil {
+		return fmt.Errorf("failed to open source node database: %w", err)
+	}
+	defer src.Close()
+
+	latestVersion, ok := src.GetLatestVersion()
+	if !ok {
+		return fmt.Errorf("source node database is empty")
+	}
+	earliestVersion := src.GetEarliestVersion()
+
+	dst, err := mkvsDB.New(dstCfg.Backend, dstCfg.ToNodeDB())
+	if err != nil {
+		return fmt.Errorf("failed to create destination node database: %w", err)
+	}
+
+	logger.Info("converting node database",
+		"rt", rt,
+		"src_backend", srcCfg.Backend,
+		"dst_backend", dstCfg.Backend,
+		"earliest_version", earliestVersion,
+		"latest_version", latestVersion,
+	)
+
+	chunkSize := viper.GetUint64(cfgConvertChunkSize)
+	tmpDir := filepath.Join(runtimeDir, convertTmpDir)
+	err = checkpoint.CopyVersions(ctx, src, dst, tmpDir, chunkSize, func(version uint64) {
+		logger.Debug("converted version",
+			"rt", rt,
+			"version", version,
+		)
+	})
+	dst.Close()
+	if err != nil {
+		_ = os.RemoveAll(dstCfg.DB)
+		return err
+	}
+
+	logger.Info("successfully converted node database",
+		"rt", rt,
+		"db", dstCfg.DB,
+		"earliest_version", earliestVersion,
+		"latest_version", latestVersion,
+	)
+	if pretty {
+		fmt.Printf("Converted versions %d-%d into %s.\n", earliestVersion, latestVersion, dstCfg.DB)
+	}
+	return nil
+}
+
+func init() {
+	storageConvertFlags.String(cfgConvertBackend, database.BackendNamePathBadger, fmt.Sprintf("target node database backend (%s)", strings.Join(mkvsDB.Names(), ", ")))
+	storageConvertFlags.Uint64(cfgConvertChunkSize, 8*1024*1024, "chunk size used when copying the node database")
+	_ = viper.BindPFlags(storageConvertFlags)
+}
@@ -171,7 +171,7 @@ func doMigrate(_ *cobra.Command, args []string) error {
 			defer history.Close()
 
 			nodeCfg := &db.Config{
-				DB:        workerStorage.GetLocalBackendDBDir(runtimeDir, config.GlobalConfig.Storage.Backend),
+				DB:        workerStorage.GetLocalBackendDBDir(runtimeDir, config.GlobalConfig.Storage.RuntimeBackend(rt)),
 				Namespace: rt,
 			}
 
@@ -214,7 +214,7 @@ func doCheck(_ *cobra.Command, args []string) error {
 			runtimeDir := registry.GetRuntimeStateDir(dataDir, rt)
 
 			nodeCfg := &db.Config{
-				DB:        workerStorage.GetLocalBackendDBDir(runtimeDir, config.GlobalConfig.Storage.Backend),
+				DB:        workerStorage.GetLocalBackendDBDir(runtimeDir, config.GlobalConfig.Storage.RuntimeBackend(rt)),
 				Namespace: rt,
 			}
 
@@ -260,7 +260,7 @@ func doRenameNs(_ *cobra.Command, args []string) error {
 	dstDir := registry.GetRuntimeStateDir(dataDir, dstID)
 
 	nodeCfg := &db.Config{
-		DB:        workerStorage.GetLocalBackendDBDir(srcDir, config.GlobalConfig.Storage.Backend),
+		DB:        workerStorage.GetLocalBackendDBDir(srcDir, config.GlobalConfig.Storage.RuntimeBackend(srcID)),
 		Namespace: srcID,
 	}
 
@@ -285,8 +285,13 @@ func doRenameNs(_ *cobra.Command, args []string) error {
 func Register(parentCmd *cobra.Command) {
 	storageMigrateCmd.Flags().AddFlagSet(registry.Flags)
 	storageCheckCmd.Flags().AddFlagSet(registry.Flags)
+	storageBenchmarkCmd.Flags().AddFlagSet(storageBenchmarkFlags)
+	storageConvertCmd.Flags().AddFlagSet(registry.Flags)
+	storageConvertCmd.Flags().AddFlagSet(storageConvertFlags)
 	storageCmd.AddCommand(storageMigrateCmd)
 	storageCmd.AddCommand(storageCheckCmd)
 	storageCmd.AddCommand(storageRenameNsCmd)
+	storageCmd.AddCommand(storageBenchmarkCmd)
+	storageCmd.AddCommand(storageConvertCmd)
 	parentCmd.AddCommand(storageCmd)
 }
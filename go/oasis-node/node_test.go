@@ -19,6 +19,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	fileSigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/file"
+	"github.com/oasisprotocol/oasis-core/go/common/debug"
 	"github.com/oasisprotocol/oasis-core/go/common/entity"
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
@@ -26,6 +27,7 @@ import (
 	consensusAPI "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	tmTestGenesis "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/tests/genesis"
 	consensusTests "github.com/oasisprotocol/oasis-core/go/consensus/tests"
+	control "github.com/oasisprotocol/oasis-core/go/control/api"
 	governance "github.com/oasisprotocol/oasis-core/go/governance/api"
 	governanceTests "github.com/oasisprotocol/oasis-core/go/governance/tests"
 	cmdCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
@@ -141,6 +143,7 @@ func newTestNode(t *testing.T) *testNode {
 
 		config.GlobalConfig.Consensus.Validator = true
 		config.GlobalConfig.Common.Debug.AllowRoot = true
+		config.GlobalConfig.Common.Debug.Capabilities = debug.AllCapabilities
 		config.GlobalConfig.Mode = config.ModeCompute
 		config.GlobalConfig.Runtime.Provisioner = runtimeConfig.RuntimeProvisionerMock
 		config.GlobalConfig.Storage.Backend = "badger"
@@ -269,6 +272,9 @@ func TestNode(t *testing.T) {
 		{"SchedulerClient", testSchedulerClient},
 		{"RootHash", testRootHash},
 		{"Vault", testVault},
+
+		// Graceful shutdown stops the node, so it must run last.
+		{"GracefulShutdown", testGracefulShutdown},
 	}
 
 	for _, tc := range testCases {
@@ -476,3 +482,26 @@ func init() {
 
 	testRuntime.Genesis.StateRoot.Empty()
 }
+
+func testGracefulShutdown(t *testing.T, node *testNode) {
+	require := require.New(t)
+
+	timeSource := (node.Consensus.Beacon()).(beacon.SetableBackend)
+
+	// Drain the executor first, as committee members only hand off their duties once the round
+	// they participate in has been finalized.
+	drainedCh := node.executorCommitteeNode.Drain()
+	beaconTests.MustAdvanceEpoch(t, timeSource)
+
+	select {
+	case <-drainedCh:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("failed to wait for the executor to drain")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	err := node.RequestGracefulShutdown(ctx, &control.GracefulShutdownRequest{Wait: true})
+	require.NoError(err, "RequestGracefulShutdown")
+}
@@ -20,6 +20,7 @@ import (
 	flag "github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
+	commonDebug "github.com/oasisprotocol/oasis-core/go/common/debug"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	nodeCommon "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common"
@@ -651,6 +652,7 @@ func init() {
 		}
 
 		viper.Set(nodeFlags.CfgDebugDontBlameOasis, true)
+		viper.Set(nodeFlags.CfgDebugCapabilities, commonDebug.AllCapabilities)
 		viper.Set(nodeCommon.CfgDebugAllowTestKeys, true)
 	})
 }
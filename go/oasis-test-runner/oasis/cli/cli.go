@@ -7,11 +7,16 @@ import (
 	"os/exec"
 	"strings"
 
+	"github.com/oasisprotocol/oasis-core/go/common/debug"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	cmdNode "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/node"
 	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
 )
 
+// AllDebugCapabilities is the value of the debug capabilities flag enabling all unsafe debug
+// capabilities.
+var AllDebugCapabilities = strings.Join(debug.AllCapabilities, ",")
+
 // Factory is an interface that can be used to construct CLI helpers.
 type Factory interface {
 	// GetCLIConfig returns the configuration required for constructing a CLI helper.
@@ -25,6 +25,7 @@ func (g *GenesisHelpers) Check(
 		"genesis", "check",
 		"--" + flags.CfgGenesisFile, genesisFilePath,
 		"--" + flags.CfgDebugDontBlameOasis,
+		"--" + flags.CfgDebugCapabilities, AllDebugCapabilities,
 		"--" + common.CfgDebugAllowTestKeys,
 	}
 
@@ -68,6 +69,7 @@ func (g *GenesisHelpers) Migrate(
 		"--" + flags.CfgGenesisFile, genesisFilePath,
 		"--" + genesis.CfgNewGenesisFile, newGenesisFilePath,
 		"--" + flags.CfgDebugDontBlameOasis,
+		"--" + flags.CfgDebugCapabilities, AllDebugCapabilities,
 		"--" + common.CfgDebugAllowTestKeys,
 	}
 
@@ -75,6 +75,7 @@ func (k *KeymanagerHelpers) SignPolicy(testKey, polPath, polSigPath string) erro
 	args := []string{
 		"keymanager", "sign_policy",
 		"--" + flags.CfgDebugDontBlameOasis,
+		"--" + flags.CfgDebugCapabilities, AllDebugCapabilities,
 		"--" + cmdCommon.CfgDebugAllowTestKeys,
 		"--" + cmdKM.CfgPolicyFile, polPath,
 		"--" + cmdKM.CfgPolicySigFile, polSigPath,
@@ -102,6 +103,7 @@ func (k *KeymanagerHelpers) GenUpdate(nonce uint64, polPath string, polSigPaths
 		"--" + cmdConsensus.CfgTxFeeGas, strconv.Itoa(10000), // TODO: Make fee configurable.
 		"--" + cmdKM.CfgPolicyFile, polPath,
 		"--" + flags.CfgDebugDontBlameOasis,
+		"--" + flags.CfgDebugCapabilities, AllDebugCapabilities,
 		"--" + cmdCommon.CfgDebugAllowTestKeys,
 		"--" + flags.CfgDebugTestEntity,
 		"--" + flags.CfgGenesisFile, k.cfg.GenesisFile,
@@ -45,6 +45,7 @@ func (r *RegistryHelpers) GenerateRegisterRuntimeTx(
 		"--" + consensus.CfgTxFeeAmount, strconv.Itoa(0), // TODO: Make fee configurable.
 		"--" + consensus.CfgTxFeeGas, strconv.Itoa(10000), // TODO: Make fee configurable.
 		"--" + flags.CfgDebugDontBlameOasis,
+		"--" + flags.CfgDebugCapabilities, AllDebugCapabilities,
 		"--" + cmdCommon.CfgDebugAllowTestKeys,
 		"--" + flags.CfgDebugTestEntity,
 		"--" + flags.CfgGenesisFile, r.cfg.GenesisFile,
@@ -71,6 +72,7 @@ func (r *RegistryHelpers) GenerateRegisterEntityTx(
 		"--" + consensus.CfgTxFeeAmount, strconv.Itoa(0), // TODO: Make fee configurable.
 		"--" + consensus.CfgTxFeeGas, strconv.Itoa(10000), // TODO: Make fee configurable.
 		"--" + flags.CfgDebugDontBlameOasis,
+		"--" + flags.CfgDebugCapabilities, AllDebugCapabilities,
 		"--" + cmdCommon.CfgDebugAllowTestKeys,
 		"--" + cmdSigner.CfgSigner, fileSigner.SignerName,
 		"--" + cmdSigner.CfgCLISignerDir, entDir,
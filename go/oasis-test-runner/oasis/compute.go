@@ -167,6 +167,7 @@ func (worker *Compute) ModifyConfig() error {
 	worker.Config.Runtime.Provisioner = worker.runtimeProvisioner
 	worker.Config.Runtime.SGXLoader = worker.net.cfg.RuntimeSGXLoaderBinary
 	worker.Config.Runtime.AttestInterval = worker.net.cfg.RuntimeAttestInterval
+	worker.Config.Runtime.BundleRecords.WebhookURL = worker.net.cfg.RuntimeBundleRecordsWebhookURL
 
 	worker.Config.Storage.Backend = worker.storageBackend
 	worker.Config.Storage.PublicRPCEnabled = !worker.disablePublicRPC
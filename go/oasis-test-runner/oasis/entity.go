@@ -13,12 +13,14 @@ import (
 	cmdSigner "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/signer"
 	cmdEntity "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/registry/entity"
 	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis/cli"
 )
 
 const entityIdentitySeedTemplate = "oasis entity %d"
 
 var entityArgsDebugTest = []string{
 	"--" + flags.CfgDebugDontBlameOasis,
+	"--" + flags.CfgDebugCapabilities, cli.AllDebugCapabilities,
 	"--" + flags.CfgDebugTestEntity,
 	"--" + common.CfgDebugAllowTestKeys,
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 	kmCmd "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/keymanager"
 	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis/cli"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	"github.com/oasisprotocol/oasis-core/go/runtime/bundle"
 	runtimeConfig "github.com/oasisprotocol/oasis-core/go/runtime/config"
@@ -57,6 +58,7 @@ func (pol *KeymanagerPolicy) provision() error {
 	policyArgs := []string{
 		"keymanager", "init_policy",
 		"--" + flags.CfgDebugDontBlameOasis,
+		"--" + flags.CfgDebugCapabilities, cli.AllDebugCapabilities,
 		"--" + kmCmd.CfgPolicyFile, policyPath,
 		"--" + kmCmd.CfgPolicyID, pol.runtime.ID().String(),
 		"--" + kmCmd.CfgPolicySerial, strconv.Itoa(pol.serial),
@@ -95,6 +97,7 @@ func (pol *KeymanagerPolicy) provision() error {
 		"keymanager", "sign_policy",
 		"--" + common.CfgDebugAllowTestKeys,
 		"--" + flags.CfgDebugDontBlameOasis,
+		"--" + flags.CfgDebugCapabilities, cli.AllDebugCapabilities,
 		"--" + kmCmd.CfgPolicyFile, policyPath,
 	}
 	for i := 1; i <= 3; i++ {
@@ -231,6 +234,7 @@ func (km *Keymanager) provisionGenesis() error {
 		"keymanager", "init_status",
 		"--" + common.CfgDebugAllowTestKeys,
 		"--" + flags.CfgDebugDontBlameOasis,
+		"--" + flags.CfgDebugCapabilities, cli.AllDebugCapabilities,
 		"--" + kmCmd.CfgStatusID, km.runtime.ID().String(),
 		"--" + kmCmd.CfgStatusFile, filepath.Join(km.dir.String(), kmStatusFile),
 	}
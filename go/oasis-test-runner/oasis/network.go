@@ -112,6 +112,14 @@ type NetworkCfg struct { // nolint: maligned
 	// RuntimeDefaultMaxAttestationAge is the default maximum attestation age (in blocks).
 	RuntimeDefaultMaxAttestationAge uint64 `json:"runtime_max_attestation_age,omitempty"`
 
+	// RuntimeBundleRecordsWebhookURL is the URL that compute nodes report loaded runtime bundles
+	// to. If not specified, bundle records are not reported.
+	RuntimeBundleRecordsWebhookURL string `json:"runtime_bundle_records_webhook_url,omitempty"`
+
+	// HTTPProxy is the URL of the proxy used by all nodes for outbound HTTP traffic. If not
+	// specified, no proxy is configured.
+	HTTPProxy string `json:"http_proxy,omitempty"`
+
 	// Consensus are the network-wide consensus parameters.
 	Consensus consensusGenesis.Genesis `json:"consensus"`
 
@@ -792,6 +800,7 @@ func (net *Network) MakeGenesis() error {
 		"--" + genesis.CfgRegistryEnableRuntimeGovernanceModels, "entity,runtime",
 		"--" + genesis.CfgRegistryDebugAllowUnroutableAddresses, "true",
 		"--" + genesis.CfgRegistryDebugAllowTestRuntimes, "true",
+		"--" + genesis.CfgRegistryDebugAllowDebugCapabilities, "true",
 		"--" + genesis.CfgSchedulerMaxValidatorsPerEntity, strconv.Itoa(len(net.Validators())),
 		"--" + genesis.CfgConsensusGasCostsTxByte, strconv.FormatUint(uint64(net.cfg.Consensus.Parameters.GasCosts[consensusGenesis.GasOpTxByte]), 10),
 		"--" + genesis.CfgConsensusStateCheckpointInterval, strconv.FormatUint(net.cfg.Consensus.Parameters.StateCheckpointInterval, 10),
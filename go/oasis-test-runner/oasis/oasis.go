@@ -16,6 +16,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	fileSigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/file"
+	"github.com/oasisprotocol/oasis-core/go/common/debug"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	commonNode "github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/config"
@@ -246,6 +247,8 @@ func (n *Node) Start() error {
 	n.Config.Common.DataDir = n.DataDir()
 	n.Config.Common.Debug.AllowRoot = true
 	n.Config.Common.Debug.Rlimit = cmdCommon.RequiredRlimit
+	n.Config.Common.Debug.Capabilities = debug.AllCapabilities
+	n.Config.Common.HTTPProxy.URL = n.net.cfg.HTTPProxy
 
 	n.Config.Pprof.BindAddress = "0.0.0.0:" + strconv.Itoa(int(n.pprofPort))
 
@@ -158,6 +158,7 @@ func (sc *Scenario) DumpRestoreNetwork(
 			"--config", sc.Net.ComputeWorkers()[0].ConfigFile(),
 			"--storage.export.dir", filepath.Join(childEnv.Dir(), "storage_dumps"),
 			"--debug.dont_blame_oasis",
+			"--debug.capabilities", cli.AllDebugCapabilities,
 			"--debug.allow_test_keys",
 		}
 		if err := cli.RunSubCommand(childEnv, sc.Logger, "storage-dump", sc.Net.Config().NodeBinary, args); err != nil {
@@ -239,6 +240,7 @@ func (sc *Scenario) dumpDatabase(childEnv *env.Env, _ *oasis.NetworkFixture, exp
 		"--dump.version", fmt.Sprintf("%d", exportedDoc.Height),
 		"--dump.output", dbDumpPath,
 		"--debug.dont_blame_oasis",
+		"--debug.capabilities", cli.AllDebugCapabilities,
 		"--debug.allow_test_keys",
 	}
 	if err = cli.RunSubCommand(childEnv, sc.Logger, "debug-dump", sc.Net.Config().NodeBinary, args); err != nil {
@@ -1,12 +1,15 @@
 package runtime
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"time"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/config"
 	consensusAPI "github.com/oasisprotocol/oasis-core/go/consensus/api"
@@ -162,6 +165,17 @@ func (sc *archiveAPI) testArchiveAPI(ctx context.Context, archiveCtrl *oasis.Con
 		return fmt.Errorf("archive node GetTransactions should work: %w", err)
 	}
 
+	sc.Logger.Info("testing GetEventsByHeight")
+	_, err = archiveCtrl.Consensus.GetEventsByHeight(ctx, consensusAPI.HeightLatest)
+	if err != nil {
+		return fmt.Errorf("archive node GetEventsByHeight should work: %w", err)
+	}
+
+	sc.Logger.Info("testing GetTransactionByHash")
+	if err = sc.testGetTransactionByHash(ctx, archiveCtrl, status.Consensus.LatestHeight); err != nil {
+		return err
+	}
+
 	sc.Logger.Info("testing GetUnconfirmedTransactions")
 	_, err = archiveCtrl.Consensus.GetUnconfirmedTransactions(ctx)
 	if err != consensusAPI.ErrUnsupported {
@@ -305,6 +319,51 @@ func (sc *archiveAPI) testArchiveAPI(ctx context.Context, archiveCtrl *oasis.Con
 	return nil
 }
 
+func (sc *archiveAPI) testGetTransactionByHash(ctx context.Context, archiveCtrl *oasis.Controller, latestHeight int64) error {
+	// Find the most recent block containing transactions.
+	var (
+		height int64
+		txs    [][]byte
+		err    error
+	)
+	for height = latestHeight; height > 0 && len(txs) == 0; height-- {
+		txs, err = archiveCtrl.Consensus.GetTransactions(ctx, height)
+		if err != nil {
+			return fmt.Errorf("archive node GetTransactions should work: %w", err)
+		}
+	}
+	if len(txs) == 0 {
+		return fmt.Errorf("archive node should have at least one block with transactions")
+	}
+	height++
+
+	// The transaction index is built in the background after the node starts.
+	txHash := hash.NewFromBytes(txs[0])
+	var tx *consensusAPI.TransactionWithResult
+	for attempt := 0; attempt < 30; attempt++ {
+		tx, err = archiveCtrl.Consensus.GetTransactionByHash(ctx, txHash)
+		if !errors.Is(err, consensusAPI.ErrTransactionNotFound) {
+			break
+		}
+		time.Sleep(time.Second)
+	}
+	if err != nil {
+		return fmt.Errorf("archive node GetTransactionByHash should work: %w", err)
+	}
+	if tx.Height != height || tx.Index != 0 || !bytes.Equal(tx.Transaction, txs[0]) {
+		return fmt.Errorf("archive node GetTransactionByHash returned unexpected transaction (height: %d, index: %d)", tx.Height, tx.Index)
+	}
+	if tx.Result == nil {
+		return fmt.Errorf("archive node GetTransactionByHash should return the transaction result")
+	}
+
+	_, err = archiveCtrl.Consensus.GetTransactionByHash(ctx, hash.Hash{})
+	if !errors.Is(err, consensusAPI.ErrTransactionNotFound) {
+		return fmt.Errorf("archive node GetTransactionByHash with unknown hash should fail with not found: %w", err)
+	}
+	return nil
+}
+
 func (sc *archiveAPI) Run(ctx context.Context, childEnv *env.Env) error {
 	if err := sc.StartNetworkAndTestClient(ctx, childEnv); err != nil {
 		return err
@@ -0,0 +1,306 @@
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	consensusAPI "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	runtimeClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+// ArchiveQuery is the scenario where a synced node is converted into an archive node and all
+// query backends are exercised at historical heights.
+var ArchiveQuery scenario.Scenario = &archiveQuery{
+	Scenario: *NewScenario(
+		"archive-query",
+		NewTestClient().WithScenario(InsertTransferScenario),
+	),
+}
+
+type archiveQuery struct {
+	Scenario
+}
+
+func (sc *archiveQuery) Fixture() (*oasis.NetworkFixture, error) {
+	f, err := sc.Scenario.Fixture()
+	if err != nil {
+		return nil, err
+	}
+	// Add a compute node that will be turned into an archive node.
+	f.ComputeWorkers = append(f.ComputeWorkers, oasis.ComputeWorkerFixture{Entity: 1, Runtimes: []int{1}, AllowEarlyTermination: true})
+	f.Runtimes[1].Executor.GroupSize++
+
+	f.Network.SetMockEpoch()
+
+	return f, nil
+}
+
+func (sc *archiveQuery) Clone() scenario.Scenario {
+	return &archiveQuery{
+		Scenario: *sc.Scenario.Clone().(*Scenario),
+	}
+}
+
+// archiveQueryFn is a query that is performed against both the archive and the reference node.
+type archiveQueryFn func(ctx context.Context, ctrl *oasis.Controller, height int64) (interface{}, error)
+
+// compareQuery performs the given query at the given height against both the archive and the
+// reference node and makes sure that the results are identical.
+func (sc *archiveQuery) compareQuery(
+	ctx context.Context,
+	archiveCtrl *oasis.Controller,
+	referenceCtrl *oasis.Controller,
+	name string,
+	height int64,
+	fn archiveQueryFn,
+) error {
+	sc.Logger.Info("testing historical query",
+		"query", name,
+		"height", height,
+	)
+
+	archiveRsp, err := fn(ctx, archiveCtrl, height)
+	if err != nil {
+		return fmt.Errorf("archive node %s at height %d: %w", name, height, err)
+	}
+	referenceRsp, err := fn(ctx, referenceCtrl, height)
+	if err != nil {
+		return fmt.Errorf("reference node %s at height %d: %w", name, height, err)
+	}
+	if !bytes.Equal(cbor.Marshal(archiveRsp), cbor.Marshal(referenceRsp)) {
+		return fmt.Errorf("archive node %s at height %d returned a different result (got: %+v, expected: %+v)",
+			name, height, archiveRsp, referenceRsp,
+		)
+	}
+	return nil
+}
+
+func (sc *archiveQuery) testConsensusQueries(
+	ctx context.Context,
+	archiveCtrl *oasis.Controller,
+	referenceCtrl *oasis.Controller,
+	height int64,
+) error {
+	entityAddr := staking.NewAddress(sc.Net.Entities()[1].Signer().Public())
+
+	for _, q := range []struct {
+		name string
+		fn   archiveQueryFn
+	}{
+		// Registry.
+		{"registry GetEntities", func(ctx context.Context, ctrl *oasis.Controller, height int64) (interface{}, error) {
+			return ctrl.Registry.GetEntities(ctx, height)
+		}},
+		{"registry GetNodes", func(ctx context.Context, ctrl *oasis.Controller, height int64) (interface{}, error) {
+			return ctrl.Registry.GetNodes(ctx, height)
+		}},
+		{"registry GetRuntimes", func(ctx context.Context, ctrl *oasis.Controller, height int64) (interface{}, error) {
+			return ctrl.Registry.GetRuntimes(ctx, &registry.GetRuntimesQuery{Height: height, IncludeSuspended: true})
+		}},
+		{"registry ConsensusParameters", func(ctx context.Context, ctrl *oasis.Controller, height int64) (interface{}, error) {
+			return ctrl.Registry.ConsensusParameters(ctx, height)
+		}},
+		{"registry GetEvents", func(ctx context.Context, ctrl *oasis.Controller, height int64) (interface{}, error) {
+			return ctrl.Registry.GetEvents(ctx, height)
+		}},
+		// Staking.
+		{"staking TotalSupply", func(ctx context.Context, ctrl *oasis.Controller, height int64) (interface{}, error) {
+			return ctrl.Staking.TotalSupply(ctx, height)
+		}},
+		{"staking CommonPool", func(ctx context.Context, ctrl *oasis.Controller, height int64) (interface{}, error) {
+			return ctrl.Staking.CommonPool(ctx, height)
+		}},
+		{"staking Addresses", func(ctx context.Context, ctrl *oasis.Controller, height int64) (interface{}, error) {
+			return ctrl.Staking.Addresses(ctx, height)
+		}},
+		{"staking Account", func(ctx context.Context, ctrl *oasis.Controller, height int64) (interface{}, error) {
+			return ctrl.Staking.Account(ctx, &staking.OwnerQuery{Height: height, Owner: entityAddr})
+		}},
+		{"staking DelegationsTo", func(ctx context.Context, ctrl *oasis.Controller, height int64) (interface{}, error) {
+			return ctrl.Staking.DelegationsTo(ctx, &staking.OwnerQuery{Height: height, Owner: entityAddr})
+		}},
+		{"staking ConsensusParameters", func(ctx context.Context, ctrl *oasis.Controller, height int64) (interface{}, error) {
+			return ctrl.Staking.ConsensusParameters(ctx, height)
+		}},
+		{"staking GetEvents", func(ctx context.Context, ctrl *oasis.Controller, height int64) (interface{}, error) {
+			return ctrl.Staking.GetEvents(ctx, height)
+		}},
+		// Roothash.
+		{"roothash GetLatestBlock", func(ctx context.Context, ctrl *oasis.Controller, height int64) (interface{}, error) {
+			return ctrl.Roothash.GetLatestBlock(ctx, &roothash.RuntimeRequest{RuntimeID: KeyValueRuntimeID, Height: height})
+		}},
+		{"roothash GetRuntimeState", func(ctx context.Context, ctrl *oasis.Controller, height int64) (interface{}, error) {
+			return ctrl.Roothash.GetRuntimeState(ctx, &roothash.RuntimeRequest{RuntimeID: KeyValueRuntimeID, Height: height})
+		}},
+		{"roothash ConsensusParameters", func(ctx context.Context, ctrl *oasis.Controller, height int64) (interface{}, error) {
+			return ctrl.Roothash.ConsensusParameters(ctx, height)
+		}},
+		{"roothash GetEvents", func(ctx context.Context, ctrl *oasis.Controller, height int64) (interface{}, error) {
+			return ctrl.Roothash.GetEvents(ctx, height)
+		}},
+		// Governance.
+		{"governance Proposals", func(ctx context.Context, ctrl *oasis.Controller, height int64) (interface{}, error) {
+			return ctrl.Governance.Proposals(ctx, height)
+		}},
+		{"governance ActiveProposals", func(ctx context.Context, ctrl *oasis.Controller, height int64) (interface{}, error) {
+			return ctrl.Governance.ActiveProposals(ctx, height)
+		}},
+		{"governance PendingUpgrades", func(ctx context.Context, ctrl *oasis.Controller, height int64) (interface{}, error) {
+			return ctrl.Governance.PendingUpgrades(ctx, height)
+		}},
+		{"governance ConsensusParameters", func(ctx context.Context, ctrl *oasis.Controller, height int64) (interface{}, error) {
+			return ctrl.Governance.ConsensusParameters(ctx, height)
+		}},
+		{"governance GetEvents", func(ctx context.Context, ctrl *oasis.Controller, height int64) (interface{}, error) {
+			return ctrl.Governance.GetEvents(ctx, height)
+		}},
+	} {
+		if err := sc.compareQuery(ctx, archiveCtrl, referenceCtrl, q.name, height, q.fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (sc *archiveQuery) testRuntimeQueries(
+	ctx context.Context,
+	archiveCtrl *oasis.Controller,
+	referenceCtrl *oasis.Controller,
+	height int64,
+) error {
+	// Resolve the runtime round that was the latest at the given consensus height.
+	blk, err := referenceCtrl.Roothash.GetLatestBlock(ctx, &roothash.RuntimeRequest{
+		RuntimeID: KeyValueRuntimeID,
+		Height:    height,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get latest runtime block at height %d: %w", height, err)
+	}
+	round := blk.Header.Round
+
+	sc.Logger.Info("testing historical runtime queries",
+		"height", height,
+		"round", round,
+	)
+
+	for _, q := range []struct {
+		name string
+		fn   func(ctx context.Context, ctrl *oasis.Controller) (interface{}, error)
+	}{
+		{"runtime GetBlock", func(ctx context.Context, ctrl *oasis.Controller) (interface{}, error) {
+			return ctrl.RuntimeClient.GetBlock(ctx, &runtimeClient.GetBlockRequest{RuntimeID: KeyValueRuntimeID, Round: round})
+		}},
+		{"runtime GetTransactionsWithResults", func(ctx context.Context, ctrl *oasis.Controller) (interface{}, error) {
+			return ctrl.RuntimeClient.GetTransactionsWithResults(ctx, &runtimeClient.GetTransactionsRequest{RuntimeID: KeyValueRuntimeID, Round: round})
+		}},
+		{"runtime GetEvents", func(ctx context.Context, ctrl *oasis.Controller) (interface{}, error) {
+			return ctrl.RuntimeClient.GetEvents(ctx, &runtimeClient.GetEventsRequest{RuntimeID: KeyValueRuntimeID, Round: round})
+		}},
+		{"runtime Query", func(ctx context.Context, ctrl *oasis.Controller) (interface{}, error) {
+			return ctrl.RuntimeClient.Query(ctx, &runtimeClient.QueryRequest{
+				RuntimeID: KeyValueRuntimeID,
+				Round:     round,
+				Method:    "get",
+				Args:      cbor.Marshal(GetCall{Key: "my_key"}),
+			})
+		}},
+	} {
+		fn := func(ctx context.Context, ctrl *oasis.Controller, _ int64) (interface{}, error) {
+			return q.fn(ctx, ctrl)
+		}
+		if err = sc.compareQuery(ctx, archiveCtrl, referenceCtrl, q.name, height, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (sc *archiveQuery) Run(ctx context.Context, childEnv *env.Env) error {
+	if err := sc.StartNetworkAndTestClient(ctx, childEnv); err != nil {
+		return err
+	}
+
+	fixture, err := sc.Fixture()
+	if err != nil {
+		return err
+	}
+	nextEpoch, err := sc.initialEpochTransitions(ctx, fixture)
+	if err != nil {
+		return err
+	}
+	nextEpoch++ // Next, after initial transitions.
+
+	// Wait for the client to exit.
+	sc.Logger.Info("waiting for test client to exit")
+	if err = sc.WaitTestClient(); err != nil {
+		return err
+	}
+
+	// Remember some historical heights.
+	doc, err := sc.Net.Controller().Consensus.GetGenesisDocument(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get genesis document: %w", err)
+	}
+	blk, err := sc.Net.Controller().Consensus.GetBlock(ctx, consensusAPI.HeightLatest)
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %w", err)
+	}
+	txHeight := blk.Height
+
+	// Perform another epoch transition so that the state changes between historical heights.
+	sc.Logger.Info("triggering epoch transition",
+		"epoch", nextEpoch,
+	)
+	if err = sc.Net.Controller().SetEpoch(ctx, nextEpoch); err != nil {
+		return fmt.Errorf("failed to set epoch %d: %w", nextEpoch, err)
+	}
+
+	// Convert the synced compute worker into an archive node.
+	archive := sc.Net.ComputeWorkers()[len(sc.Net.ComputeWorkers())-1]
+	sc.Logger.Info("converting compute worker into an archive node",
+		"node", archive.Name,
+	)
+	if err = archive.Stop(); err != nil {
+		return fmt.Errorf("stopping compute worker: %w", err)
+	}
+	archive.SetArchiveMode(true)
+	if err = archive.Start(); err != nil {
+		return fmt.Errorf("starting compute worker as archive: %w", err)
+	}
+	archiveCtrl, err := oasis.NewController(archive.SocketPath())
+	if err != nil {
+		return err
+	}
+	defer archiveCtrl.Close()
+
+	status, err := archiveCtrl.GetStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get archive node status: %w", err)
+	}
+	latestHeight := status.Consensus.LatestHeight
+	if latestHeight <= txHeight {
+		return fmt.Errorf("archive node latest height (%d) should be higher than %d", latestHeight, txHeight)
+	}
+
+	// Consensus queries are compared against a validator, runtime queries against the client.
+	for _, height := range []int64{doc.Height, txHeight, latestHeight} {
+		if err = sc.testConsensusQueries(ctx, archiveCtrl, sc.Net.Controller(), height); err != nil {
+			return err
+		}
+	}
+	for _, height := range []int64{txHeight, latestHeight} {
+		if err = sc.testRuntimeQueries(ctx, archiveCtrl, sc.Net.ClientController(), height); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,357 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+)
+
+// EgressProxy is the scenario where all outbound HTTP traffic of the nodes goes through a proxy.
+var EgressProxy scenario.Scenario = newEgressProxyImpl()
+
+const (
+	egressProxyWebhookWait = 30 * time.Second
+
+	// egressProxyAttestationHost is the host of the Intel PCS and IAS endpoints, which the
+	// proxy sees as the target of a CONNECT request.
+	egressProxyAttestationHost = "api.trustedservices.intel.com:443"
+)
+
+type egressProxyImpl struct {
+	Scenario
+}
+
+func newEgressProxyImpl() scenario.Scenario {
+	return &egressProxyImpl{
+		Scenario: *NewScenario(
+			"egress-proxy",
+			NewTestClient().WithScenario(SimpleScenario),
+		),
+	}
+}
+
+func (sc *egressProxyImpl) Clone() scenario.Scenario {
+	return &egressProxyImpl{
+		Scenario: *sc.Scenario.Clone().(*Scenario),
+	}
+}
+
+func (sc *egressProxyImpl) Run(ctx context.Context, childEnv *env.Env) error {
+	// Start the egress proxy and a webhook that compute nodes report loaded bundles to, so that
+	// there is outbound HTTP traffic even when attestation is mocked.
+	proxy, err := newEgressProxy(sc.Logger)
+	if err != nil {
+		return fmt.Errorf("failed to start egress proxy: %w", err)
+	}
+	defer proxy.Close()
+
+	webhook, err := newBundleRecordsWebhook()
+	if err != nil {
+		return fmt.Errorf("failed to start bundle records webhook: %w", err)
+	}
+	defer webhook.Close()
+
+	cfg := sc.Net.Config()
+	cfg.HTTPProxy = "http://" + proxy.Addr()
+	cfg.RuntimeBundleRecordsWebhookURL = "http://" + webhook.Addr() + "/bundles"
+
+	if err = sc.StartNetworkAndTestClient(ctx, childEnv); err != nil {
+		return err
+	}
+	if err = sc.WaitTestClientAndCheckLogs(); err != nil {
+		return err
+	}
+
+	// Make sure that compute nodes have attested and registered for the runtime and that the
+	// attestation traffic went through the proxy.
+	if err = sc.checkComputeNodesAttested(ctx, proxy); err != nil {
+		return err
+	}
+
+	// Make sure that all bundle records were delivered through the proxy.
+	numRecords := len(sc.Net.ComputeWorkers())
+	sc.Logger.Info("waiting for bundle records to be delivered",
+		"num_records", numRecords,
+	)
+
+	deadline := time.After(egressProxyWebhookWait)
+	for webhook.Count() < numRecords {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline:
+			return fmt.Errorf("bundle records not delivered (got: %d expected: %d)", webhook.Count(), numRecords)
+		case <-time.After(time.Second):
+		}
+	}
+
+	if proxied := proxy.Count(webhook.Addr()); proxied < webhook.Count() {
+		return fmt.Errorf("bundle records bypassed the proxy (proxied: %d delivered: %d)", proxied, webhook.Count())
+	}
+
+	sc.Logger.Info("outbound traffic went through the egress proxy",
+		"proxied", proxy.Counts(),
+	)
+
+	return nil
+}
+
+func (sc *egressProxyImpl) checkComputeNodesAttested(ctx context.Context, proxy *egressProxy) error {
+	tee, err := sc.TEEHardware()
+	if err != nil {
+		return err
+	}
+	if tee != node.TEEHardwareIntelSGX {
+		return nil
+	}
+
+	nodes, err := sc.Net.Controller().Registry.GetNodes(ctx, consensus.HeightLatest)
+	if err != nil {
+		return fmt.Errorf("failed to get nodes: %w", err)
+	}
+
+	rtID := sc.Net.Runtimes()[1].ID()
+	for _, n := range nodes {
+		if !n.HasRoles(node.RoleComputeWorker) {
+			continue
+		}
+		for _, rt := range n.Runtimes {
+			if rt.ID != rtID {
+				continue
+			}
+			if rt.Capabilities.TEE == nil {
+				return fmt.Errorf("compute node %s registered without TEE capability", n.ID)
+			}
+		}
+	}
+
+	// Quote verification fetches collateral from Intel PCS (or IAS when not mocked).
+	if proxied := proxy.Count(egressProxyAttestationHost); proxied == 0 {
+		return fmt.Errorf("attestation traffic bypassed the proxy (proxied: %v)", proxy.Counts())
+	}
+	return nil
+}
+
+// egressProxy is a simple forward HTTP proxy that keeps track of proxied requests.
+type egressProxy struct {
+	sync.Mutex
+
+	listener net.Listener
+	server   *http.Server
+
+	transport *http.Transport
+	counts    map[string]int
+
+	logger *logging.Logger
+}
+
+func newEgressProxy(logger *logging.Logger) (*egressProxy, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	p := &egressProxy{
+		listener: listener,
+		transport: &http.Transport{
+			Proxy: nil,
+		},
+		counts: make(map[string]int),
+		logger: logger,
+	}
+	p.server = &http.Server{
+		Handler:           p,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		_ = p.server.Serve(listener)
+	}()
+
+	return p, nil
+}
+
+// Addr returns the address the proxy is listening on.
+func (p *egressProxy) Addr() string {
+	return p.listener.Addr().String()
+}
+
+// Count returns the number of requests proxied to the given host.
+func (p *egressProxy) Count(host string) int {
+	p.Lock()
+	defer p.Unlock()
+
+	return p.counts[host]
+}
+
+// Counts returns the number of requests proxied to each host.
+func (p *egressProxy) Counts() map[string]int {
+	p.Lock()
+	defer p.Unlock()
+
+	counts := make(map[string]int, len(p.counts))
+	for host, count := range p.counts {
+		counts[host] = count
+	}
+	return counts
+}
+
+// Close stops the proxy.
+func (p *egressProxy) Close() {
+	_ = p.server.Close()
+	p.transport.CloseIdleConnections()
+}
+
+func (p *egressProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.logger.Debug("egress proxy request",
+		"method", r.Method,
+		"host", r.Host,
+	)
+
+	if r.Method == http.MethodConnect {
+		p.serveConnect(w, r)
+		return
+	}
+	if !r.URL.IsAbs() {
+		http.Error(w, "not a proxy request", http.StatusBadRequest)
+		return
+	}
+
+	p.record(r.URL.Host)
+
+	outReq := r.Clone(r.Context())
+	outReq.RequestURI = ""
+	outReq.Header.Del("Proxy-Connection")
+	outReq.Header.Del("Proxy-Authorization")
+
+	rsp, err := p.transport.RoundTrip(outReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer rsp.Body.Close()
+
+	for k, vs := range rsp.Header {
+		for _, v := range vs {
+			w.Header().Add(k, v)
+		}
+	}
+	w.WriteHeader(rsp.StatusCode)
+	_, _ = io.Copy(w, rsp.Body)
+}
+
+func (p *egressProxy) serveConnect(w http.ResponseWriter, r *http.Request) {
+	p.record(r.Host)
+
+	upstream, err := net.DialTimeout("tcp", r.Host, 10*time.Second)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		upstream.Close()
+		http.Error(w, "hijacking not supported", http.StatusInternalServerError)
+		return
+	}
+	conn, _, err := hj.Hijack()
+	if err != nil {
+		upstream.Close()
+		return
+	}
+	if _, err = conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n")); err != nil {
+		conn.Close()
+		upstream.Close()
+		return
+	}
+
+	go func() {
+		defer upstream.Close()
+		defer conn.Close()
+		_, _ = io.Copy(upstream, conn)
+	}()
+	go func() {
+		defer upstream.Close()
+		defer conn.Close()
+		_, _ = io.Copy(conn, upstream)
+	}()
+}
+
+func (p *egressProxy) record(host string) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.counts[host]++
+}
+
+// bundleRecordsWebhook is a webhook that counts delivered runtime bundle records.
+type bundleRecordsWebhook struct {
+	sync.Mutex
+
+	listener net.Listener
+	server   *http.Server
+
+	count int
+}
+
+func newBundleRecordsWebhook() (*bundleRecordsWebhook, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	wh := &bundleRecordsWebhook{
+		listener: listener,
+	}
+	wh.server = &http.Server{
+		Handler:           wh,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		_ = wh.server.Serve(listener)
+	}()
+
+	return wh, nil
+}
+
+// Addr returns the address the webhook is listening on.
+func (wh *bundleRecordsWebhook) Addr() string {
+	return wh.listener.Addr().String()
+}
+
+// Count returns the number of delivered bundle records.
+func (wh *bundleRecordsWebhook) Count() int {
+	wh.Lock()
+	defer wh.Unlock()
+
+	return wh.count
+}
+
+// Close stops the webhook.
+func (wh *bundleRecordsWebhook) Close() {
+	_ = wh.server.Close()
+}
+
+func (wh *bundleRecordsWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	_, _ = io.Copy(io.Discard, r.Body)
+
+	wh.Lock()
+	wh.count++
+	wh.Unlock()
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -376,12 +376,15 @@ func RegisterScenarios() error {
 		TrustRootChangeFailsTest,
 		// Archive node API test.
 		ArchiveAPI,
+		ArchiveQuery,
 		// Early query tests.
 		EarlyQuery,
 		EarlyQueryInitHeight,
 		EarlyQueryRuntime,
 		// ROFL.
 		ROFL,
+		// Egress proxy test.
+		EgressProxy,
 	} {
 		if err := cmd.Register(s); err != nil {
 			return err
@@ -396,6 +399,8 @@ func RegisterScenarios() error {
 		// it is identical to the txsource-multi-short, only using fewer nodes
 		// due to SGX CI instance resource constrains.
 		TxSourceMultiShortSGX,
+		// Transaction trace replay test. Non-default, because it requires a recorded trace.
+		TraceReplay,
 	} {
 		if err := cmd.RegisterNondefault(s); err != nil {
 			return err
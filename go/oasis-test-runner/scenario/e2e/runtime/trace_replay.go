@@ -0,0 +1,207 @@
+package runtime
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
+	runtimeClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+)
+
+const (
+	// cfgTraceFile is the path to the recorded runtime transaction trace.
+	cfgTraceFile = "trace.file"
+	// cfgTraceRate is the replay rate relative to the recorded rate.
+	cfgTraceRate = "trace.rate"
+	// cfgTraceMaxInFlight is the maximum number of transactions being submitted concurrently.
+	cfgTraceMaxInFlight = "trace.max_in_flight"
+)
+
+// TraceReplay is a scenario which replays a recorded runtime transaction trace through the
+// test network, reproducing the load pattern of the recorded traffic.
+//
+// The trace is a file with one JSON-encoded entry per line, as produced by an indexer or
+// a node capture. Each entry contains the time at which the transaction was observed and
+// the raw transaction:
+//
+//	{"timestamp":"2024-01-01T00:00:00.5Z","data":"<base64-encoded transaction>"}
+//
+// The recorded inter-arrival times are preserved, scaled by the configured rate.
+var TraceReplay = func() scenario.Scenario {
+	sc := &traceReplayImpl{
+		Scenario: *NewScenario("trace-replay", nil),
+	}
+	sc.Flags.String(cfgTraceFile, "", "path to the recorded runtime transaction trace")
+	sc.Flags.Float64(cfgTraceRate, 1.0, "replay rate relative to the recorded rate (e.g., 2 replays twice as fast)")
+	sc.Flags.Int(cfgTraceMaxInFlight, 64, "maximum number of transactions being submitted concurrently")
+
+	return sc
+}()
+
+// traceEntry is a single recorded runtime transaction.
+type traceEntry struct {
+	// Timestamp is the time at which the transaction was observed.
+	Timestamp time.Time `json:"timestamp"`
+	// Data is the raw runtime transaction.
+	Data []byte `json:"data"`
+}
+
+// loadTrace loads a recorded runtime transaction trace, ordered by timestamp.
+func loadTrace(path string) ([]*traceEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open trace: %w", err)
+	}
+	defer f.Close()
+
+	var trace []*traceEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var entry traceEntry
+		if err = json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("malformed trace entry at line %d: %w", line, err)
+		}
+		if len(entry.Data) == 0 {
+			return nil, fmt.Errorf("empty transaction at line %d", line)
+		}
+		trace = append(trace, &entry)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read trace: %w", err)
+	}
+
+	sort.SliceStable(trace, func(i, j int) bool {
+		return trace[i].Timestamp.Before(trace[j].Timestamp)
+	})
+
+	return trace, nil
+}
+
+type traceReplayImpl struct {
+	Scenario
+}
+
+func (sc *traceReplayImpl) Clone() scenario.Scenario {
+	return &traceReplayImpl{
+		Scenario: *sc.Scenario.Clone().(*Scenario),
+	}
+}
+
+func (sc *traceReplayImpl) Run(ctx context.Context, _ *env.Env) error {
+	traceFile, _ := sc.Flags.GetString(cfgTraceFile)
+	rate, _ := sc.Flags.GetFloat64(cfgTraceRate)
+	maxInFlight, _ := sc.Flags.GetInt(cfgTraceMaxInFlight)
+
+	switch {
+	case traceFile == "":
+		return fmt.Errorf("no trace file configured (use --%s)", cfgTraceFile)
+	case rate <= 0:
+		return fmt.Errorf("replay rate must be positive")
+	case maxInFlight < 1:
+		return fmt.Errorf("maximum number of in-flight transactions must be positive")
+	}
+
+	trace, err := loadTrace(traceFile)
+	if err != nil {
+		return err
+	}
+	if len(trace) == 0 {
+		return fmt.Errorf("trace is empty")
+	}
+
+	// Start the network.
+	if err = sc.StartNetworkAndWaitForClientSync(ctx); err != nil {
+		return err
+	}
+
+	ctrl := sc.Net.ClientController()
+	if ctrl == nil {
+		return fmt.Errorf("client controller not available")
+	}
+
+	sc.Logger.Info("replaying transaction trace",
+		"num_txs", len(trace),
+		"recorded_duration", trace[len(trace)-1].Timestamp.Sub(trace[0].Timestamp),
+		"rate", rate,
+	)
+
+	var (
+		wg        sync.WaitGroup
+		submitted atomic.Uint64
+		failed    atomic.Uint64
+		lagging   atomic.Uint64
+	)
+	sem := make(chan struct{}, maxInFlight)
+	start := time.Now()
+
+	for _, entry := range trace {
+		// Preserve the recorded inter-arrival times, scaled by the replay rate.
+		offset := time.Duration(float64(entry.Timestamp.Sub(trace[0].Timestamp)) / rate)
+		if delay := time.Until(start.Add(offset)); delay > 0 {
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		select {
+		case sem <- struct{}{}:
+		default:
+			// The network is not keeping up with the requested rate.
+			lagging.Add(1)
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		wg.Add(1)
+		go func(data []byte) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			err := ctrl.RuntimeClient.SubmitTxNoWait(ctx, &runtimeClient.SubmitTxRequest{
+				RuntimeID: KeyValueRuntimeID,
+				Data:      data,
+			})
+			if err != nil {
+				sc.Logger.Debug("failed to submit transaction",
+					"err", err,
+				)
+				failed.Add(1)
+				return
+			}
+			submitted.Add(1)
+		}(entry.Data)
+	}
+	wg.Wait()
+
+	elapsed := time.Since(start)
+	sc.Logger.Info("transaction trace replayed",
+		"submitted", submitted.Load(),
+		"failed", failed.Load(),
+		"lagging", lagging.Load(),
+		"elapsed", elapsed,
+		"txs_per_second", float64(submitted.Load())/elapsed.Seconds(),
+	)
+
+	if submitted.Load() == 0 {
+		return fmt.Errorf("failed to submit any transactions")
+	}
+	return nil
+}
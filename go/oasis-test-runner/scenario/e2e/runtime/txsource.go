@@ -31,6 +31,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/env"
 	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/log"
 	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis"
+	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/oasis/cli"
 	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario"
 	"github.com/oasisprotocol/oasis-core/go/oasis-test-runner/scenario/e2e"
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
@@ -45,6 +46,8 @@ const (
 	nodeRestartIntervalLong = 2 * time.Minute
 	nodeLongRestartInterval = 15 * time.Minute
 	nodeLongRestartDuration = 10 * time.Minute
+	nodeStateSyncInterval   = 30 * time.Minute
+	nodeStateSyncTimeout    = 10 * time.Minute
 	livenessCheckInterval   = 2 * time.Minute
 	txSourceGasPrice        = 1
 
@@ -127,6 +130,7 @@ var TxSourceMulti scenario.Scenario = &txSourceImpl{
 	nodeRestartInterval:               nodeRestartIntervalLong,
 	nodeLongRestartInterval:           nodeLongRestartInterval,
 	nodeLongRestartDuration:           nodeLongRestartDuration,
+	nodeStateSyncInterval:             nodeStateSyncInterval,
 	livenessCheckInterval:             livenessCheckInterval,
 	consensusPruneDisabledProbability: 0.1,
 	consensusPruneMinKept:             100,
@@ -162,6 +166,7 @@ type txSourceImpl struct { // nolint: maligned
 	nodeRestartInterval     time.Duration
 	nodeLongRestartInterval time.Duration
 	nodeLongRestartDuration time.Duration
+	nodeStateSyncInterval   time.Duration
 	livenessCheckInterval   time.Duration
 
 	consensusPruneDisabledProbability float32
@@ -453,6 +458,13 @@ func (sc *txSourceImpl) Fixture() (*oasis.NetworkFixture, error) {
 		f.Runtimes[1].Executor.RoundTimeout = 10
 	}
 
+	if sc.nodeStateSyncInterval > 0 {
+		// Enable consensus checkpoints so that wiped validators can state sync.
+		f.Network.Consensus.Parameters.StateCheckpointInterval = 100
+		f.Network.Consensus.Parameters.StateCheckpointNumKept = 2
+		f.Network.Consensus.Parameters.StateCheckpointChunkSize = 1024 * 1024
+	}
+
 	if sc.nodeRestartInterval > 0 || sc.nodeLongRestartInterval > 0 {
 		// If node restarts enabled, do not enable round timeouts, failures or
 		// discrepancy log watchers.
@@ -563,10 +575,18 @@ func (sc *txSourceImpl) manager(ctx context.Context, env *env.Env, errCh chan er
 	} else {
 		sc.nodeLongRestartInterval = math.MaxInt64
 	}
+	if sc.nodeStateSyncInterval > 0 {
+		sc.Logger.Info("random validator state syncs enabled",
+			"interval", sc.nodeStateSyncInterval,
+		)
+	} else {
+		sc.nodeStateSyncInterval = math.MaxInt64
+	}
 
 	// Setup restarable nodes.
 	var restartableLock sync.Mutex
 	var longRestartNode *oasis.Node
+	var stateSyncNode *oasis.Validator
 	var restartableNodes []*oasis.Node
 	// Keep one of each types of nodes always running.
 	for _, v := range sc.Net.Validators()[1:] {
@@ -588,6 +608,9 @@ func (sc *txSourceImpl) manager(ctx context.Context, env *env.Env, errCh chan er
 	longRestartTicker := time.NewTicker(sc.nodeLongRestartInterval)
 	defer longRestartTicker.Stop()
 
+	stateSyncTicker := time.NewTicker(sc.nodeStateSyncInterval)
+	defer stateSyncTicker.Stop()
+
 	var nodeIndex int
 	var lastHeight int64
 	for {
@@ -609,6 +632,10 @@ func (sc *txSourceImpl) manager(ctx context.Context, env *env.Env, errCh chan er
 				if longRestartNode != nil && restartableNodes[nodeIndex].NodeID.Equal(longRestartNode.NodeID) {
 					nodeIndex = (nodeIndex + 1) % len(restartableNodes)
 				}
+				// Ensure the current node is not being state synced.
+				if stateSyncNode != nil && restartableNodes[nodeIndex].NodeID.Equal(stateSyncNode.NodeID) {
+					nodeIndex = (nodeIndex + 1) % len(restartableNodes)
+				}
 
 				// Choose a random node and restart it.
 				node := restartableNodes[nodeIndex]
@@ -638,6 +665,14 @@ func (sc *txSourceImpl) manager(ctx context.Context, env *env.Env, errCh chan er
 				restartableLock.Unlock()
 				continue
 			}
+			// Avoid having two validators offline for a long time.
+			if stateSyncNode != nil {
+				sc.Logger.Info("validator is being state synced, skipping",
+					"node", stateSyncNode.Name,
+				)
+				restartableLock.Unlock()
+				continue
+			}
 
 			longRestartNode = restartableNodes[sc.rng.Intn(len(restartableNodes))]
 			selectedNode := longRestartNode
@@ -665,6 +700,35 @@ func (sc *txSourceImpl) manager(ctx context.Context, env *env.Env, errCh chan er
 				restartableLock.Unlock()
 			}()
 
+		case <-stateSyncTicker.C:
+			// Choose a random validator and state sync it from scratch.
+			restartableLock.Lock()
+			if stateSyncNode != nil || longRestartNode != nil {
+				sc.Logger.Info("node already stopped, skipping state sync")
+				restartableLock.Unlock()
+				continue
+			}
+
+			// Validator-0 is never stopped so nodes can always sync from it.
+			validators := sc.Net.Validators()[1:]
+			stateSyncNode = validators[sc.rng.Intn(len(validators))]
+			selectedNode := stateSyncNode
+			restartableLock.Unlock()
+			go func() {
+				if err := sc.stateSyncValidator(ctx, env, selectedNode); err != nil {
+					sc.Logger.Error("failed to state sync validator",
+						"node", selectedNode.Name,
+						"err", err,
+					)
+					errCh <- err
+					return
+				}
+
+				restartableLock.Lock()
+				stateSyncNode = nil
+				restartableLock.Unlock()
+			}()
+
 		case <-livenessTicker.C:
 			// Check if consensus has made any progress.
 			livenessCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
@@ -766,6 +830,96 @@ func (sc *txSourceImpl) manager(ctx context.Context, env *env.Env, errCh chan er
 	}
 }
 
+// stateSyncValidator wipes the state of the given validator and state syncs it from scratch while
+// the rest of the network is under load.
+func (sc *txSourceImpl) stateSyncValidator(ctx context.Context, childEnv *env.Env, val *oasis.Validator) error {
+	// Use the latest block as the trusted block.
+	blk, err := sc.Net.Controller().Consensus.GetBlock(ctx, consensus.HeightLatest)
+	if err != nil {
+		return fmt.Errorf("failed to query latest consensus block: %w", err)
+	}
+
+	sc.Logger.Info("wiping validator state",
+		"node", val.Name,
+		"trust_height", blk.Height,
+		"trust_hash", blk.Hash.Hex(),
+	)
+	if err = val.Stop(); err != nil {
+		return fmt.Errorf("failed to stop validator: %w", err)
+	}
+	cli := cli.New(childEnv, sc.Net, sc.Logger)
+	if err = cli.UnsafeReset(val.DataDir(), false, false, true); err != nil {
+		return fmt.Errorf("failed to reset validator state: %w", err)
+	}
+
+	val.SetConsensusStateSync(&oasis.ConsensusStateSyncCfg{
+		TrustHeight: uint64(blk.Height),
+		TrustHash:   blk.Hash.Hex(),
+	})
+	err = val.Start()
+	// Subsequent restarts should use the synced state.
+	val.SetConsensusStateSync(nil)
+	if err != nil {
+		return fmt.Errorf("failed to start validator: %w", err)
+	}
+
+	sc.Logger.Info("waiting for the validator to state sync",
+		"node", val.Name,
+	)
+	syncCtx, cancel := context.WithTimeout(ctx, nodeStateSyncTimeout)
+	defer cancel()
+
+	ctrl, err := oasis.NewController(val.SocketPath())
+	if err != nil {
+		return fmt.Errorf("failed to create controller for validator: %w", err)
+	}
+	defer ctrl.Close()
+	if err = ctrl.WaitSync(syncCtx); err != nil {
+		return fmt.Errorf("failed to wait for validator to sync: %w", err)
+	}
+
+	status, err := ctrl.GetStatus(syncCtx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch validator status: %w", err)
+	}
+	if status.Consensus.Status != consensus.StatusStateReady {
+		return fmt.Errorf("synced validator not ready")
+	}
+	// A validator that restored its state from a checkpoint does not have any earlier blocks.
+	if status.Consensus.LastRetainedHeight <= 1 {
+		return fmt.Errorf("validator did not state sync (last retained height: %d)", status.Consensus.LastRetainedHeight)
+	}
+
+	// Make sure the validator re-joined consensus and keeps up with the network.
+	latestBlk, err := sc.Net.Controller().Consensus.GetBlock(syncCtx, consensus.HeightLatest)
+	if err != nil {
+		return fmt.Errorf("failed to query latest consensus block: %w", err)
+	}
+	blkCh, blkSub, err := ctrl.Consensus.WatchBlocks(syncCtx)
+	if err != nil {
+		return fmt.Errorf("failed to watch validator blocks: %w", err)
+	}
+	defer blkSub.Close()
+	for {
+		select {
+		case valBlk := <-blkCh:
+			if valBlk.Height < latestBlk.Height {
+				continue
+			}
+		case <-syncCtx.Done():
+			return fmt.Errorf("timed out waiting for validator to catch up: %w", syncCtx.Err())
+		}
+		break
+	}
+
+	sc.Logger.Info("validator state synced",
+		"node", val.Name,
+		"last_retained_height", status.Consensus.LastRetainedHeight,
+	)
+
+	return nil
+}
+
 func (sc *txSourceImpl) startWorkload(childEnv *env.Env, errCh chan error, name string, node *oasis.Node) error {
 	sc.Logger.Info("starting workload",
 		"name", name,
@@ -791,6 +945,7 @@ func (sc *txSourceImpl) startWorkload(childEnv *env.Env, errCh chan error, name
 		"--address", "unix:" + node.SocketPath(),
 		"--" + common.CfgDebugAllowTestKeys,
 		"--" + flags.CfgDebugDontBlameOasis,
+		"--" + flags.CfgDebugCapabilities, cli.AllDebugCapabilities,
 		"--" + flags.CfgDebugTestEntity,
 		"--" + commonGrpc.CfgLogDebug,
 		"--" + flags.CfgGenesisFile, sc.Net.GenesisPath(),
@@ -855,6 +1010,7 @@ func (sc *txSourceImpl) Clone() scenario.Scenario {
 		nodeRestartInterval:               sc.nodeRestartInterval,
 		nodeLongRestartDuration:           sc.nodeLongRestartDuration,
 		nodeLongRestartInterval:           sc.nodeLongRestartInterval,
+		nodeStateSyncInterval:             sc.nodeStateSyncInterval,
 		livenessCheckInterval:             sc.livenessCheckInterval,
 		consensusPruneDisabledProbability: sc.consensusPruneDisabledProbability,
 		consensusPruneMinKept:             sc.consensusPruneMinKept,
@@ -139,6 +139,7 @@ func RegisterScenarios() error {
 		NodeUpgradeCancel,
 		NodeUpgradeConsensus240,
 		NodeUpgradeConsensus242,
+		NodeUpgradeConsensus250,
 		// Debonding entries from genesis test.
 		Debond,
 		// Consensus state sync.
@@ -271,6 +271,106 @@ func (c *upgrade242Checker) PostUpgradeFn(ctx context.Context, ctrl *oasis.Contr
 	return nil
 }
 
+type upgrade250Checker struct{}
+
+func (c *upgrade250Checker) PreUpgradeFn(ctx context.Context, ctrl *oasis.Controller) error {
+	// Check registry parameters.
+	regParams, err := ctrl.Registry.ConsensusParameters(ctx, consensus.HeightLatest)
+	if err != nil {
+		return fmt.Errorf("can't get registry consensus parameters: %w", err)
+	}
+	if regParams.EnableBackupOnlyNodes {
+		return fmt.Errorf("registry parameter EnableBackupOnlyNodes should not be set")
+	}
+	if regParams.EnableRuntimeTEEHistory {
+		return fmt.Errorf("registry parameter EnableRuntimeTEEHistory should not be set")
+	}
+	if regParams.EnableNodeByRuntimeIndex {
+		return fmt.Errorf("registry parameter EnableNodeByRuntimeIndex should not be set")
+	}
+	if regParams.EnableDeploymentMetadata {
+		return fmt.Errorf("registry parameter EnableDeploymentMetadata should not be set")
+	}
+
+	// Check staking parameters.
+	stakeParams, err := ctrl.Staking.ConsensusParameters(ctx, consensus.HeightLatest)
+	if err != nil {
+		return fmt.Errorf("can't get staking consensus parameters: %w", err)
+	}
+	if stakeParams.EnableDebondingTransfers {
+		return fmt.Errorf("staking parameter EnableDebondingTransfers should not be set")
+	}
+	if stakeParams.EnableTokenDisplayChanges {
+		return fmt.Errorf("staking parameter EnableTokenDisplayChanges should not be set")
+	}
+
+	// Check governance parameters.
+	govParams, err := ctrl.Governance.ConsensusParameters(ctx, consensus.HeightLatest)
+	if err != nil {
+		return fmt.Errorf("can't get governance consensus parameters: %w", err)
+	}
+	if govParams.EnableAggregatedVotes {
+		return fmt.Errorf("governance parameter EnableAggregatedVotes should not be set")
+	}
+
+	return nil
+}
+
+func (c *upgrade250Checker) PostUpgradeFn(ctx context.Context, ctrl *oasis.Controller) error {
+	// Check updated consensus parameters.
+	consParams, err := ctrl.Consensus.GetParameters(ctx, consensus.HeightLatest)
+	if err != nil {
+		return fmt.Errorf("can't get consensus parameters: %w", err)
+	}
+	if consParams.Parameters.FeatureVersion == nil || *consParams.Parameters.FeatureVersion != migrations.Version250 {
+		return fmt.Errorf("consensus parameter FeatureVersion not updated correctly (expected: %s actual: %s)",
+			migrations.Version250,
+			consParams.Parameters.FeatureVersion,
+		)
+	}
+
+	// Check updated registry parameters.
+	regParams, err := ctrl.Registry.ConsensusParameters(ctx, consensus.HeightLatest)
+	if err != nil {
+		return fmt.Errorf("can't get registry consensus parameters: %w", err)
+	}
+	if !regParams.EnableBackupOnlyNodes {
+		return fmt.Errorf("registry parameter EnableBackupOnlyNodes not updated correctly")
+	}
+	if !regParams.EnableRuntimeTEEHistory {
+		return fmt.Errorf("registry parameter EnableRuntimeTEEHistory not updated correctly")
+	}
+	if !regParams.EnableNodeByRuntimeIndex {
+		return fmt.Errorf("registry parameter EnableNodeByRuntimeIndex not updated correctly")
+	}
+	if !regParams.EnableDeploymentMetadata {
+		return fmt.Errorf("registry parameter EnableDeploymentMetadata not updated correctly")
+	}
+
+	// Check updated staking parameters.
+	stakeParams, err := ctrl.Staking.ConsensusParameters(ctx, consensus.HeightLatest)
+	if err != nil {
+		return fmt.Errorf("can't get staking consensus parameters: %w", err)
+	}
+	if !stakeParams.EnableDebondingTransfers {
+		return fmt.Errorf("staking parameter EnableDebondingTransfers not updated correctly")
+	}
+	if !stakeParams.EnableTokenDisplayChanges {
+		return fmt.Errorf("staking parameter EnableTokenDisplayChanges not updated correctly")
+	}
+
+	// Check updated governance parameters.
+	govParams, err := ctrl.Governance.ConsensusParameters(ctx, consensus.HeightLatest)
+	if err != nil {
+		return fmt.Errorf("can't get governance consensus parameters: %w", err)
+	}
+	if !govParams.EnableAggregatedVotes {
+		return fmt.Errorf("governance parameter EnableAggregatedVotes not updated correctly")
+	}
+
+	return nil
+}
+
 var (
 	// NodeUpgradeDummy is the node upgrade dummy scenario.
 	NodeUpgradeDummy scenario.Scenario = newNodeUpgradeImpl(migrations.DummyUpgradeHandler, &dummyUpgradeChecker{}, true)
@@ -280,6 +380,8 @@ var (
 	NodeUpgradeConsensus240 scenario.Scenario = newNodeUpgradeImpl(migrations.Consensus240, &upgrade240Checker{}, false)
 	// NodeUpgradeConsensus242 is the node upgrade scenario for migrating to consensus 24.2.
 	NodeUpgradeConsensus242 scenario.Scenario = newNodeUpgradeImpl(migrations.Consensus242, &upgrade242Checker{}, false)
+	// NodeUpgradeConsensus250 is the node upgrade scenario for migrating to consensus 25.0.
+	NodeUpgradeConsensus250 scenario.Scenario = newNodeUpgradeImpl(migrations.Consensus250, &upgrade250Checker{}, false)
 
 	malformedDescriptor = []byte(`{
 		"v": 1,
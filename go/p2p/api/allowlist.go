@@ -0,0 +1,251 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/config"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+)
+
+// PeerAllowlist is the set of peers that a node running in strict P2P mode is allowed to
+// connect to.
+//
+// Peers are identified by their P2P public keys and are either configured explicitly or
+// are nodes registered under one of the allowed entities.
+//
+// Since registered nodes are only known once the registry state is available, the configured
+// seed nodes and persistent peers (bootstrap peers) are also allowed until the bootstrap is
+// finished via FinishBootstrap, which should happen once the consensus layer is synced.
+// Connections to bootstrap peers established before that are not torn down.
+type PeerAllowlist struct {
+	mu sync.RWMutex
+
+	nodes      map[signature.PublicKey]struct{}
+	entities   map[signature.PublicKey]struct{}
+	registered map[signature.PublicKey]struct{}
+	bootstrap  map[signature.PublicKey]struct{}
+
+	logger *logging.Logger
+}
+
+// IsAllowed returns true iff the peer with the given P2P public key is allowed.
+func (a *PeerAllowlist) IsAllowed(pk signature.PublicKey) bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if _, ok := a.nodes[pk]; ok {
+		return true
+	}
+	if _, ok := a.bootstrap[pk]; ok {
+		return true
+	}
+	_, ok := a.registered[pk]
+	return ok
+}
+
+// IsAllowedPeerID returns true iff the peer with the given peer identifier is allowed.
+func (a *PeerAllowlist) IsAllowedPeerID(id core.PeerID) bool {
+	pk, err := PeerIDToPublicKey(id)
+	if err != nil {
+		return false
+	}
+	return a.IsAllowed(pk)
+}
+
+// Peers returns the P2P public keys of all allowed peers.
+func (a *PeerAllowlist) Peers() []signature.PublicKey {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	peers := make(map[signature.PublicKey]struct{}, len(a.nodes)+len(a.bootstrap)+len(a.registered))
+	for _, set := range []map[signature.PublicKey]struct{}{a.nodes, a.bootstrap, a.registered} {
+		for pk := range set {
+			peers[pk] = struct{}{}
+		}
+	}
+
+	result := make([]signature.PublicKey, 0, len(peers))
+	for pk := range peers {
+		result = append(result, pk)
+	}
+	return result
+}
+
+// IsBootstrapping returns true iff the bootstrap peers are still allowed.
+func (a *PeerAllowlist) IsBootstrapping() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return a.bootstrap != nil
+}
+
+// FinishBootstrap replaces the set of allowed registered nodes with the given nodes (see
+// UpdateNodes) and stops allowing bootstrap peers that are not otherwise allowed.
+//
+// It should be called with the latest registered nodes once the consensus layer is synced.
+func (a *PeerAllowlist) FinishBootstrap(nodes []*node.Node) {
+	a.UpdateNodes(nodes)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.bootstrap = nil
+
+	a.logger.Info("peer allowlist bootstrap finished")
+}
+
+// UpdateNodes replaces the set of allowed registered nodes with the nodes from the given node
+// list that are registered under one of the allowed entities.
+func (a *PeerAllowlist) UpdateNodes(nodes []*node.Node) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	registered := make(map[signature.PublicKey]struct{})
+	for _, n := range nodes {
+		if _, ok := a.entities[n.EntityID]; !ok {
+			continue
+		}
+		registered[n.P2P.ID] = struct{}{}
+	}
+	a.registered = registered
+
+	a.logger.Debug("updated allowed registered nodes",
+		"num_nodes", len(registered),
+	)
+}
+
+// addNode adds the given node to the set of allowed registered nodes in case it is registered
+// under one of the allowed entities.
+func (a *PeerAllowlist) addNode(n *node.Node) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.entities[n.EntityID]; !ok {
+		return
+	}
+	a.registered[n.P2P.ID] = struct{}{}
+}
+
+// Watch keeps the set of allowed registered nodes up to date until the context is canceled.
+func (a *PeerAllowlist) Watch(ctx context.Context, backend registry.Backend) {
+	if len(a.entities) == 0 {
+		return
+	}
+
+	// Listen to nodes on epoch transitions.
+	nodeListCh, nlSub, err := backend.WatchNodeList(ctx)
+	if err != nil {
+		a.logger.Error("failed to watch registry for node list changes",
+			"err", err,
+		)
+		return
+	}
+	defer nlSub.Close()
+
+	// Listen to nodes on node events.
+	nodeCh, nSub, err := backend.WatchNodes(ctx)
+	if err != nil {
+		a.logger.Error("failed to watch registry for node changes",
+			"err", err,
+		)
+		return
+	}
+	defer nSub.Close()
+
+	for {
+		select {
+		case nodes := <-nodeListCh:
+			a.UpdateNodes(nodes.Nodes)
+
+		case nodeEv := <-nodeCh:
+			if nodeEv.IsRegistration {
+				a.addNode(nodeEv.Node)
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// NewPeerAllowlist creates a new peer allowlist from the given P2P public keys of allowed
+// nodes, the public keys of entities whose registered nodes are allowed and the P2P public
+// keys of bootstrap peers which are allowed until the bootstrap is finished.
+func NewPeerAllowlist(nodes, entities, bootstrap []signature.PublicKey) *PeerAllowlist {
+	a := &PeerAllowlist{
+		nodes:      make(map[signature.PublicKey]struct{}),
+		entities:   make(map[signature.PublicKey]struct{}),
+		registered: make(map[signature.PublicKey]struct{}),
+		bootstrap:  make(map[signature.PublicKey]struct{}),
+		logger:     logging.GetLogger("p2p/allowlist"),
+	}
+	for _, pk := range nodes {
+		a.nodes[pk] = struct{}{}
+	}
+	for _, pk := range entities {
+		a.entities[pk] = struct{}{}
+	}
+	for _, pk := range bootstrap {
+		a.bootstrap[pk] = struct{}{}
+	}
+	return a
+}
+
+// LoadPeerAllowlist creates a new peer allowlist from the node configuration.
+//
+// In case strict P2P mode is not enabled, nil is returned.
+func LoadPeerAllowlist() (*PeerAllowlist, error) {
+	cfg := config.GlobalConfig.P2P.Allowlist
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	parse := func(raw []string) ([]signature.PublicKey, error) {
+		pks := make([]signature.PublicKey, 0, len(raw))
+		for _, s := range raw {
+			var pk signature.PublicKey
+			if err := pk.UnmarshalText([]byte(s)); err != nil {
+				return nil, fmt.Errorf("malformed public key (%s): %w", s, err)
+			}
+			pks = append(pks, pk)
+		}
+		return pks, nil
+	}
+
+	nodes, err := parse(cfg.Nodes)
+	if err != nil {
+		return nil, fmt.Errorf("p2p: invalid allowed node: %w", err)
+	}
+	entities, err := parse(cfg.Entities)
+	if err != nil {
+		return nil, fmt.Errorf("p2p: invalid allowed entity: %w", err)
+	}
+
+	// Seed nodes and persistent peers are needed to sync the registry state which is required
+	// to discover the nodes of allowed entities.
+	var addrs []string
+	addrs = append(addrs, config.GlobalConfig.P2P.Seeds...)
+	addrs = append(addrs, config.GlobalConfig.P2P.ConnectionManager.PersistentPeers...)
+	addrs = append(addrs, config.GlobalConfig.Consensus.P2P.PersistentPeer...)
+	rawBootstrap := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		rawBootstrap = append(rawBootstrap, strings.SplitN(addr, "@", 2)[0])
+	}
+	bootstrap, err := parse(rawBootstrap)
+	if err != nil {
+		return nil, fmt.Errorf("p2p: invalid seed node or persistent peer: %w", err)
+	}
+	if len(nodes) == 0 && len(bootstrap) == 0 {
+		return nil, fmt.Errorf("p2p: allowlist with only entities requires at least one allowed node, seed node or persistent peer to sync the registry")
+	}
+
+	return NewPeerAllowlist(nodes, entities, bootstrap), nil
+}
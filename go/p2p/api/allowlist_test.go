@@ -0,0 +1,128 @@
+package api
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/config"
+)
+
+func TestPeerAllowlist(t *testing.T) {
+	require := require.New(t)
+
+	newKey := func() signature.PublicKey {
+		signer, err := memorySigner.NewSigner(rand.Reader)
+		require.NoError(err, "NewSigner")
+		return signer.Public()
+	}
+	newNode := func(entityID signature.PublicKey) *node.Node {
+		n := &node.Node{EntityID: entityID}
+		n.P2P.ID = newKey()
+		return n
+	}
+
+	allowedNode := newKey()
+	allowedEntity := newKey()
+	otherEntity := newKey()
+
+	allowlist := NewPeerAllowlist([]signature.PublicKey{allowedNode}, []signature.PublicKey{allowedEntity}, nil)
+	require.True(allowlist.IsAllowed(allowedNode), "configured node should be allowed")
+	require.False(allowlist.IsAllowed(newKey()), "unknown node should not be allowed")
+	require.Len(allowlist.Peers(), 1)
+
+	peerID, err := PublicKeyToPeerID(allowedNode)
+	require.NoError(err, "PublicKeyToPeerID")
+	require.True(allowlist.IsAllowedPeerID(peerID), "configured node should be allowed by peer ID")
+
+	// Only nodes registered under allowed entities should be allowed.
+	n1 := newNode(allowedEntity)
+	n2 := newNode(otherEntity)
+	allowlist.UpdateNodes([]*node.Node{n1, n2})
+	require.True(allowlist.IsAllowed(n1.P2P.ID), "node of an allowed entity should be allowed")
+	require.False(allowlist.IsAllowed(n2.P2P.ID), "node of other entity should not be allowed")
+	require.Len(allowlist.Peers(), 2)
+
+	n3 := newNode(allowedEntity)
+	allowlist.addNode(n3)
+	require.True(allowlist.IsAllowed(n3.P2P.ID), "newly registered node should be allowed")
+
+	// Nodes no longer registered should be removed on update.
+	allowlist.UpdateNodes([]*node.Node{n3})
+	require.False(allowlist.IsAllowed(n1.P2P.ID), "deregistered node should not be allowed")
+	require.True(allowlist.IsAllowed(n3.P2P.ID), "registered node should be allowed")
+	require.True(allowlist.IsAllowed(allowedNode), "configured node should remain allowed")
+}
+
+func TestPeerAllowlistBootstrap(t *testing.T) {
+	require := require.New(t)
+
+	newKey := func() signature.PublicKey {
+		signer, err := memorySigner.NewSigner(rand.Reader)
+		require.NoError(err, "NewSigner")
+		return signer.Public()
+	}
+
+	allowedEntity := newKey()
+	seed := newKey()
+
+	// Bootstrap peers should be allowed until the bootstrap is finished.
+	allowlist := NewPeerAllowlist(nil, []signature.PublicKey{allowedEntity}, []signature.PublicKey{seed})
+	require.True(allowlist.IsBootstrapping())
+	require.True(allowlist.IsAllowed(seed), "bootstrap peer should be allowed while bootstrapping")
+	require.ElementsMatch([]signature.PublicKey{seed}, allowlist.Peers())
+
+	n := &node.Node{EntityID: allowedEntity}
+	n.P2P.ID = newKey()
+	allowlist.FinishBootstrap([]*node.Node{n})
+	require.False(allowlist.IsBootstrapping())
+	require.False(allowlist.IsAllowed(seed), "bootstrap peer should not be allowed after bootstrap")
+	require.True(allowlist.IsAllowed(n.P2P.ID), "registered node should be allowed after bootstrap")
+	require.ElementsMatch([]signature.PublicKey{n.P2P.ID}, allowlist.Peers())
+}
+
+func TestLoadPeerAllowlist(t *testing.T) {
+	require := require.New(t)
+
+	newKey := func() signature.PublicKey {
+		signer, err := memorySigner.NewSigner(rand.Reader)
+		require.NoError(err, "NewSigner")
+		return signer.Public()
+	}
+
+	defer func(cfg config.Config) {
+		config.GlobalConfig = cfg
+	}(config.GlobalConfig)
+	config.GlobalConfig = config.DefaultConfig()
+
+	allowlist, err := LoadPeerAllowlist()
+	require.NoError(err, "LoadPeerAllowlist")
+	require.Nil(allowlist, "allowlist should not be loaded when disabled")
+
+	// An entity-only allowlist without any bootstrap peers cannot sync the registry.
+	entity := newKey()
+	config.GlobalConfig.P2P.Allowlist.Enabled = true
+	config.GlobalConfig.P2P.Allowlist.Entities = []string{entity.String()}
+	_, err = LoadPeerAllowlist()
+	require.Error(err, "entity-only allowlist without bootstrap peers should be rejected")
+
+	// Seed nodes and persistent peers should be allowed while bootstrapping.
+	seed := newKey()
+	persistentPeer := newKey()
+	consensusPeer := newKey()
+	config.GlobalConfig.P2P.Seeds = []string{seed.String() + "@127.0.0.1:26656"}
+	config.GlobalConfig.P2P.ConnectionManager.PersistentPeers = []string{persistentPeer.String() + "@127.0.0.1:9200"}
+	config.GlobalConfig.Consensus.P2P.PersistentPeer = []string{consensusPeer.String() + "@127.0.0.1:26656"}
+	allowlist, err = LoadPeerAllowlist()
+	require.NoError(err, "LoadPeerAllowlist")
+	require.True(allowlist.IsBootstrapping())
+	require.ElementsMatch([]signature.PublicKey{seed, persistentPeer, consensusPeer}, allowlist.Peers())
+
+	config.GlobalConfig.P2P.Seeds = []string{"malformed@127.0.0.1:26656"}
+	_, err = LoadPeerAllowlist()
+	require.Error(err, "malformed seed node should be rejected")
+}
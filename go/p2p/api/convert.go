@@ -95,3 +95,22 @@ func AddrInfoToString(info peer.AddrInfo) []string {
 	}
 	return addrs
 }
+
+// PeerIDToPublicKey converts a peer identifier to a public key.
+func PeerIDToPublicKey(id core.PeerID) (signature.PublicKey, error) {
+	var pk signature.PublicKey
+
+	pubKey, err := id.ExtractPublicKey()
+	if err != nil {
+		return pk, fmt.Errorf("failed to extract public key: %w", err)
+	}
+	raw, err := pubKey.Raw()
+	if err != nil {
+		return pk, fmt.Errorf("failed to get raw public key: %w", err)
+	}
+	if err = pk.UnmarshalBinary(raw); err != nil {
+		return pk, fmt.Errorf("invalid public key: %w", err)
+	}
+
+	return pk, nil
+}
@@ -4,6 +4,8 @@ package config
 import (
 	"fmt"
 	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 )
 
 // Config is the P2P configuration structure.
@@ -20,6 +22,7 @@ type Config struct {
 	PeerManager       PeerManagerConfig       `yaml:"peer_manager,omitempty"`
 	ConnectionManager ConnectionManagerConfig `yaml:"connection_manager,omitempty"`
 	ConnectionGater   ConnectionGaterConfig   `yaml:"connection_gater,omitempty"`
+	Allowlist         AllowlistConfig         `yaml:"allowlist,omitempty"`
 }
 
 // DiscoveryConfig is the P2P discovery configuration structure.
@@ -77,6 +80,19 @@ type ConnectionGaterConfig struct {
 	BlockedPeerIPs []string `yaml:"blocked_peers"`
 }
 
+// AllowlistConfig is the P2P peer allowlist configuration structure.
+//
+// When enabled, the node only connects to allowed peers on both the consensus and the worker
+// P2P layers.
+type AllowlistConfig struct {
+	// Enable strict mode where only allowed peers can connect.
+	Enabled bool `yaml:"enabled"`
+	// List of P2P public keys of allowed peers.
+	Nodes []string `yaml:"nodes,omitempty"`
+	// List of entity public keys whose registered nodes are allowed peers.
+	Entities []string `yaml:"entities,omitempty"`
+}
+
 // Validate validates the configuration settings.
 func (c *Config) Validate() error {
 	if c.ConnectionManager.MaxNumPeers < 0 {
@@ -96,6 +112,22 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("gossipsub.validate_throttle must be >= 0")
 	}
 
+	if c.Allowlist.Enabled && len(c.Allowlist.Nodes) == 0 && len(c.Allowlist.Entities) == 0 {
+		return fmt.Errorf("allowlist must contain at least one node or entity when enabled")
+	}
+	for _, pk := range c.Allowlist.Nodes {
+		var pubKey signature.PublicKey
+		if err := pubKey.UnmarshalText([]byte(pk)); err != nil {
+			return fmt.Errorf("allowlist.nodes: malformed public key (%s): %w", pk, err)
+		}
+	}
+	for _, pk := range c.Allowlist.Entities {
+		var pubKey signature.PublicKey
+		if err := pubKey.UnmarshalText([]byte(pk)); err != nil {
+			return fmt.Errorf("allowlist.entities: malformed public key (%s): %w", pk, err)
+		}
+	}
+
 	return nil
 }
 
@@ -130,5 +162,10 @@ func DefaultConfig() Config {
 		ConnectionGater: ConnectionGaterConfig{
 			BlockedPeerIPs: []string{},
 		},
+		Allowlist: AllowlistConfig{
+			Enabled:  false,
+			Nodes:    []string{},
+			Entities: []string{},
+		},
 	}
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p/core"
+	connmgrCore "github.com/libp2p/go-libp2p/core/connmgr"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -56,13 +57,22 @@ func NewHost(cfg *HostConfig) (host.Host, *conngater.BasicConnectionGater, error
 		return nil, nil, err
 	}
 
+	// In strict mode, only allow connections with allowed peers.
+	var gater connmgrCore.ConnectionGater = cg
+	if cfg.Allowlist != nil {
+		gater = &allowlistGater{
+			BasicConnectionGater: cg,
+			allowlist:            cfg.Allowlist,
+		}
+	}
+
 	host, err := libp2p.New(
 		libp2p.UserAgent(cfg.UserAgent),
 		libp2p.ListenAddrs(cfg.ListenAddr),
 		libp2p.Identity(id),
 		libp2p.ResourceManager(rm),
 		libp2p.ConnectionManager(cm),
-		libp2p.ConnectionGater(cg),
+		libp2p.ConnectionGater(gater),
 	)
 	if err != nil {
 		return nil, nil, err
@@ -168,6 +178,7 @@ func (cfg *ConnManagerConfig) Load() error {
 // ConnGaterConfig describes a set of settings for a connection gater.
 type ConnGaterConfig struct {
 	BlockedPeers []net.IP
+	Allowlist    *api.PeerAllowlist
 }
 
 // NewConnGater constructs a new connection gater.
@@ -202,11 +213,34 @@ func (cfg *ConnGaterConfig) Load() error {
 		blockedPeers = append(blockedPeers, parsedIP)
 	}
 
+	allowlist, err := api.LoadPeerAllowlist()
+	if err != nil {
+		return err
+	}
+
 	cfg.BlockedPeers = blockedPeers
+	cfg.Allowlist = allowlist
 
 	return nil
 }
 
+// allowlistGater is a connection gater that only allows connections with allowed peers.
+type allowlistGater struct {
+	*conngater.BasicConnectionGater
+
+	allowlist *api.PeerAllowlist
+}
+
+// InterceptPeerDial implements connmgr.ConnectionGater.
+func (g *allowlistGater) InterceptPeerDial(p peer.ID) bool {
+	return g.allowlist.IsAllowedPeerID(p) && g.BasicConnectionGater.InterceptPeerDial(p)
+}
+
+// InterceptSecured implements connmgr.ConnectionGater.
+func (g *allowlistGater) InterceptSecured(dir network.Direction, p peer.ID, addrs network.ConnMultiaddrs) bool {
+	return g.allowlist.IsAllowedPeerID(p) && g.BasicConnectionGater.InterceptSecured(dir, p, addrs)
+}
+
 // NewResourceManager constructs a new resource manager.
 func NewResourceManager() (network.ResourceManager, error) {
 	// Use the default resource manager for non-seed nodes.
@@ -78,8 +78,10 @@ type p2p struct {
 	host   core.Host
 	pubsub *pubsub.PubSub
 
-	gater   *conngater.BasicConnectionGater
-	peerMgr *peermgmt.PeerManager
+	gater     *conngater.BasicConnectionGater
+	allowlist *api.PeerAllowlist
+	peerMgr   *peermgmt.PeerManager
+	consensus consensus.Backend
 
 	registerAddresses []multiaddr.Multiaddr
 	topics            map[string]*topicHandler
@@ -103,6 +105,10 @@ func (p *p2p) Start() error {
 	p.peerMgr.Start()
 	go p.metricsWorker()
 
+	if p.allowlist != nil {
+		go p.allowlistWorker()
+	}
+
 	return nil
 }
 
@@ -351,6 +357,31 @@ func (p *p2p) GetMinRepublishInterval() time.Duration {
 	return seenMessagesTTL + 5*time.Second
 }
 
+func (p *p2p) allowlistWorker() {
+	if p.consensus == nil {
+		return
+	}
+
+	// Wait for consensus sync before proceeding.
+	select {
+	case <-p.consensus.Synced():
+	case <-p.ctx.Done():
+		return
+	}
+
+	// Stop allowing bootstrap peers now that the registry state is available.
+	nodes, err := p.consensus.Registry().GetNodes(p.ctx, consensus.HeightLatest)
+	if err != nil {
+		p.logger.Error("failed to fetch registered nodes, bootstrap peers remain allowed",
+			"err", err,
+		)
+	} else {
+		p.allowlist.FinishBootstrap(nodes)
+	}
+
+	p.allowlist.Watch(p.ctx, p.consensus.Registry())
+}
+
 func messageIdFn(pmsg *pb.Message) string { // nolint: revive
 	// id := TupleHash[messageIdContext](topic, data)
 	h := tuplehash.New256(32, messageIdContext)
@@ -427,7 +458,9 @@ func New(identity *identity.Identity, consensus consensus.Backend, store *persis
 		signer:            identity.P2PSigner,
 		host:              host,
 		gater:             cg,
+		allowlist:         cfg.Allowlist,
 		peerMgr:           mgr,
+		consensus:         consensus,
 		pubsub:            pubsub,
 		registerAddresses: cfg.Addresses,
 		topics:            make(map[string]*topicHandler),
@@ -444,6 +477,10 @@ func New(identity *identity.Identity, consensus consensus.Backend, store *persis
 		)
 	}
 
+	if cfg.Allowlist != nil {
+		p.logger.Info("p2p strict mode enabled, only allowed peers can connect")
+	}
+
 	return p, nil
 }
 
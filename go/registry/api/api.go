@@ -120,6 +120,10 @@ var (
 	// has runtimes.
 	ErrEntityHasRuntimes = errors.New(ModuleName, 19, "registry: entity still has runtimes")
 
+	// ErrEntityFrozen is the error returned when trying to register or update nodes or runtimes
+	// of a frozen entity.
+	ErrEntityFrozen = errors.New(ModuleName, 20, "registry: entity is frozen")
+
 	// MethodRegisterEntity is the method name for entity registrations.
 	MethodRegisterEntity = transaction.NewMethodName(ModuleName, "RegisterEntity", entity.SignedEntity{})
 	// MethodDeregisterEntity is the method name for entity deregistrations.
@@ -172,6 +176,9 @@ type Backend interface {
 	// GetEntities gets a list of all registered entities.
 	GetEntities(context.Context, int64) ([]*entity.Entity, error)
 
+	// GetFrozenEntities gets a list of identifiers of all frozen entities.
+	GetFrozenEntities(context.Context, int64) ([]signature.PublicKey, error)
+
 	// WatchEntities returns a channel that produces a stream of
 	// EntityEvent on entity registration changes.
 	WatchEntities(context.Context) (<-chan *EntityEvent, pubsub.ClosableSubscription, error)
@@ -185,6 +192,12 @@ type Backend interface {
 	// GetNodes gets a list of all registered nodes.
 	GetNodes(context.Context, int64) ([]*node.Node, error)
 
+	// GetNodesForRuntime gets a list of all registered nodes that support the given runtime.
+	GetNodesForRuntime(context.Context, *NamespaceQuery) ([]*node.Node, error)
+
+	// ListNodes returns a page of registered nodes matching the given filter.
+	ListNodes(context.Context, *ListNodesQuery) (*ListNodesResponse, error)
+
 	// GetNodeByConsensusAddress looks up a node by its consensus address at the
 	// specified block height. The nature and format of the consensus address depends
 	// on the specific consensus backend implementation used.
@@ -209,10 +222,18 @@ type Backend interface {
 	// block height.
 	GetRuntimes(context.Context, *GetRuntimesQuery) ([]*Runtime, error)
 
+	// ListRuntimes returns a page of registered runtimes matching the given filter.
+	ListRuntimes(context.Context, *ListRuntimesQuery) (*ListRuntimesResponse, error)
+
 	// WatchRuntimes returns a stream of Runtime.  Upon subscription,
 	// all runtimes will be sent immediately.
 	WatchRuntimes(context.Context) (<-chan *Runtime, pubsub.ClosableSubscription, error)
 
+	// GetRuntimeTEEHistory returns the history of TEE configurations (e.g., allowed enclave
+	// identities) accepted for the given runtime up to the specified block height, ordered by
+	// height.
+	GetRuntimeTEEHistory(context.Context, *NamespaceQuery) ([]*RuntimeTEEHistoryRecord, error)
+
 	// StateToGenesis returns the genesis state at specified block height.
 	StateToGenesis(context.Context, int64) (*Genesis, error)
 
@@ -351,6 +372,17 @@ func (e *NodeUnfrozenEvent) EventKind() string {
 	return "node_unfrozen"
 }
 
+// EntityFrozenEvent signifies when an entity becomes frozen or thawed.
+type EntityFrozenEvent struct {
+	EntityID signature.PublicKey `json:"entity_id"`
+	Frozen   bool                `json:"frozen"`
+}
+
+// EventKind returns a string representation of this event's kind.
+func (e *EntityFrozenEvent) EventKind() string {
+	return "entity_frozen"
+}
+
 var _ events.CustomTypedAttribute = (*NodeListEpochEvent)(nil)
 
 // NodeListEpochEvent is the per epoch node list event.
@@ -376,12 +408,14 @@ func (e *NodeListEpochEvent) DecodeValue(string) error {
 type Event struct {
 	Height int64     `json:"height,omitempty"`
 	TxHash hash.Hash `json:"tx_hash,omitempty"`
+	Index  uint32    `json:"index,omitempty"`
 
 	RuntimeStartedEvent   *RuntimeStartedEvent   `json:"runtime_started,omitempty"`
 	RuntimeSuspendedEvent *RuntimeSuspendedEvent `json:"runtime_suspended,omitempty"`
 	EntityEvent           *EntityEvent           `json:"entity,omitempty"`
 	NodeEvent             *NodeEvent             `json:"node,omitempty"`
 	NodeUnfrozenEvent     *NodeUnfrozenEvent     `json:"node_unfrozen,omitempty"`
+	EntityFrozenEvent     *EntityFrozenEvent     `json:"entity_frozen,omitempty"`
 }
 
 // NodeList is a per-epoch immutable node list.
@@ -604,6 +638,14 @@ func VerifyRegisterNodeArgs( // nolint: gocyclo
 			}
 			rtVersionMap[rt.ID][rt.Version] = true
 
+			// Make sure that the backup-only capability is only used when enabled.
+			if rt.Capabilities.BackupOnly && !params.EnableBackupOnlyNodes {
+				logger.Error("RegisterNode: backup-only nodes are not enabled",
+					"runtime_id", rt.ID,
+				)
+				return nil, nil, fmt.Errorf("%w: backup-only nodes are not enabled", ErrInvalidArgument)
+			}
+
 			// Make sure that the claimed runtime actually exists.
 			regRt, err := runtimeLookup.AnyRuntime(ctx, rt.ID)
 			if err != nil {
@@ -641,6 +683,15 @@ func VerifyRegisterNodeArgs( // nolint: gocyclo
 		}
 	}
 
+	// Nodes with unsafe debug capabilities are only allowed on debug networks.
+	if len(n.DebugCapabilities) > 0 && !params.DebugAllowDebugCapabilities {
+		logger.Error("RegisterNode: debug capabilities not allowed",
+			"node", n,
+			"debug_capabilities", n.DebugCapabilities,
+		)
+		return nil, nil, fmt.Errorf("%w: debug capabilities not allowed", ErrInvalidArgument)
+	}
+
 	// Validate ConsensusInfo.
 	if !n.Consensus.ID.IsValid() {
 		logger.Error("RegisterNode: invalid consensus ID",
@@ -1375,6 +1426,9 @@ type Genesis struct {
 
 	// NodeStatuses is a set of node statuses.
 	NodeStatuses map[signature.PublicKey]*NodeStatus `json:"node_statuses,omitempty"`
+
+	// FrozenEntities is the list of frozen entities.
+	FrozenEntities []signature.PublicKey `json:"frozen_entities,omitempty"`
 }
 
 // ConsensusParameters are the registry consensus parameters.
@@ -1391,6 +1445,10 @@ type ConsensusParameters struct {
 	// allow immediate deployment.
 	DebugDeployImmediately bool `json:"debug_deploy_immediately,omitempty"`
 
+	// DebugAllowDebugCapabilities is true iff node registration should allow
+	// nodes with unsafe debug capabilities enabled.
+	DebugAllowDebugCapabilities bool `json:"debug_allow_debug_capabilities,omitempty"`
+
 	// DisableRuntimeRegistration is true iff runtime registration should be
 	// disabled outside of the genesis block.
 	DisableRuntimeRegistration bool `json:"disable_runtime_registration,omitempty"`
@@ -1419,6 +1477,46 @@ type ConsensusParameters struct {
 
 	// MaxRuntimeDeployments is the maximum number of runtime deployments.
 	MaxRuntimeDeployments uint8 `json:"max_runtime_deployments,omitempty"`
+
+	// MaxBundleComponents is the maximum number of components in a runtime bundle. Zero means
+	// that the number of components is not limited.
+	MaxBundleComponents uint16 `json:"max_bundle_components,omitempty"`
+
+	// MaxBundleImageSize is the maximum total size of the runtime bundle contents in bytes. Zero
+	// means that the size is not limited.
+	MaxBundleImageSize uint64 `json:"max_bundle_image_size,omitempty"`
+
+	// EnableBackupOnlyNodes is true iff nodes may register as backup-only executor workers.
+	EnableBackupOnlyNodes bool `json:"enable_backup_only_nodes,omitempty"`
+
+	// EnableRuntimeTEEHistory is true iff the history of runtime TEE configurations is recorded.
+	EnableRuntimeTEEHistory bool `json:"enable_runtime_tee_history,omitempty"`
+
+	// EnableNodeByRuntimeIndex is true iff nodes are indexed by the runtimes they support.
+	EnableNodeByRuntimeIndex bool `json:"enable_node_by_runtime_index,omitempty"`
+
+	// EnableDeploymentMetadata is true iff runtime deployments may carry metadata.
+	EnableDeploymentMetadata bool `json:"enable_deployment_metadata,omitempty"`
+}
+
+// HasBundleLimits returns true iff any runtime bundle limit is configured.
+func (p *ConsensusParameters) HasBundleLimits() bool {
+	return p.MaxBundleComponents > 0 || p.MaxBundleImageSize > 0
+}
+
+// CheckBundleLimits checks whether a runtime bundle with the given number of components and
+// total image size is within the configured limits.
+//
+// During runtime registration the limits are checked against the properties declared in the
+// deployment metadata, while nodes check them against the actual bundles when loading them.
+func (p *ConsensusParameters) CheckBundleLimits(numComponents int, imageSize uint64) error {
+	if p.MaxBundleComponents > 0 && numComponents > int(p.MaxBundleComponents) {
+		return fmt.Errorf("bundle has too many components (%d > %d)", numComponents, p.MaxBundleComponents)
+	}
+	if p.MaxBundleImageSize > 0 && imageSize > p.MaxBundleImageSize {
+		return fmt.Errorf("bundle image is too large (%d > %d bytes)", imageSize, p.MaxBundleImageSize)
+	}
+	return nil
 }
 
 // ConsensusParameterChanges are allowed registry consensus parameter changes.
@@ -1443,6 +1541,24 @@ type ConsensusParameterChanges struct {
 
 	// MaxRuntimeDeployments is the new maximum number of runtime deployments.
 	MaxRuntimeDeployments *uint8 `json:"max_runtime_deployments,omitempty"`
+
+	// MaxBundleComponents is the new maximum number of runtime bundle components.
+	MaxBundleComponents *uint16 `json:"max_bundle_components,omitempty"`
+
+	// MaxBundleImageSize is the new maximum runtime bundle image size.
+	MaxBundleImageSize *uint64 `json:"max_bundle_image_size,omitempty"`
+
+	// EnableBackupOnlyNodes is the new enable backup-only nodes flag.
+	EnableBackupOnlyNodes *bool `json:"enable_backup_only_nodes,omitempty"`
+
+	// EnableRuntimeTEEHistory is the new enable runtime TEE history flag.
+	EnableRuntimeTEEHistory *bool `json:"enable_runtime_tee_history,omitempty"`
+
+	// EnableNodeByRuntimeIndex is the new enable node by runtime index flag.
+	EnableNodeByRuntimeIndex *bool `json:"enable_node_by_runtime_index,omitempty"`
+
+	// EnableDeploymentMetadata is the new enable deployment metadata flag.
+	EnableDeploymentMetadata *bool `json:"enable_deployment_metadata,omitempty"`
 }
 
 // Apply applies changes to the given consensus parameters.
@@ -1468,6 +1584,24 @@ func (c *ConsensusParameterChanges) Apply(params *ConsensusParameters) error {
 	if c.MaxRuntimeDeployments != nil {
 		params.MaxRuntimeDeployments = *c.MaxRuntimeDeployments
 	}
+	if c.MaxBundleComponents != nil {
+		params.MaxBundleComponents = *c.MaxBundleComponents
+	}
+	if c.MaxBundleImageSize != nil {
+		params.MaxBundleImageSize = *c.MaxBundleImageSize
+	}
+	if c.EnableBackupOnlyNodes != nil {
+		params.EnableBackupOnlyNodes = *c.EnableBackupOnlyNodes
+	}
+	if c.EnableRuntimeTEEHistory != nil {
+		params.EnableRuntimeTEEHistory = *c.EnableRuntimeTEEHistory
+	}
+	if c.EnableNodeByRuntimeIndex != nil {
+		params.EnableNodeByRuntimeIndex = *c.EnableNodeByRuntimeIndex
+	}
+	if c.EnableDeploymentMetadata != nil {
+		params.EnableDeploymentMetadata = *c.EnableDeploymentMetadata
+	}
 	return nil
 }
 
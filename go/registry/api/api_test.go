@@ -173,6 +173,34 @@ func TestVerifyRegisterNodeArgs(t *testing.T) {
 			nil,
 			"valid consensus validator node",
 		},
+		{
+			node.Node{
+				Versioned: cbor.NewVersioned(node.LatestNodeDescriptorVersion),
+				ID:        nodeSigner.Public(),
+				EntityID:  entityID1,
+				Consensus: node.ConsensusInfo{
+					ID: nodeConsensusSigner.Public(),
+					Addresses: []node.ConsensusAddress{
+						{ID: nodeConsensusSigner.Public(), Address: node.Address{IP: net.IPv4(127, 0, 0, 1), Port: 9000}},
+					},
+				},
+				TLS: node.TLSInfo{
+					PubKey: nodeTLSSigner.Public(),
+				},
+				P2P: node.P2PInfo{
+					ID:        nodeP2PSigner.Public(),
+					Addresses: []node.Address{{IP: net.IPv4(127, 0, 0, 1), Port: 9002}},
+				},
+				VRF: node.VRFInfo{
+					ID: nodeVRFSigner.Public(),
+				},
+				Roles:             node.RoleValidator,
+				Expiration:        11,
+				DebugCapabilities: []string{"insecure_beacon"},
+			},
+			ErrInvalidArgument,
+			"invalid consensus validator node (debug capabilities not allowed)",
+		},
 		{
 			node.Node{
 				Versioned: cbor.NewVersioned(2),
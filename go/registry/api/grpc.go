@@ -5,6 +5,7 @@ import (
 
 	"google.golang.org/grpc"
 
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/entity"
 	cmnGrpc "github.com/oasisprotocol/oasis-core/go/common/grpc"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
@@ -19,6 +20,8 @@ var (
 	methodGetEntity = serviceName.NewMethod("GetEntity", IDQuery{})
 	// methodGetEntities is the GetEntities method.
 	methodGetEntities = serviceName.NewMethod("GetEntities", int64(0))
+	// methodGetFrozenEntities is the GetFrozenEntities method.
+	methodGetFrozenEntities = serviceName.NewMethod("GetFrozenEntities", int64(0))
 	// methodGetNode is the GetNode method.
 	methodGetNode = serviceName.NewMethod("GetNode", IDQuery{})
 	// methodGetNodeByConsensusAddress is the GetNodeByConsensusAddress method.
@@ -27,10 +30,18 @@ var (
 	methodGetNodeStatus = serviceName.NewMethod("GetNodeStatus", IDQuery{})
 	// methodGetNodes is the GetNodes method.
 	methodGetNodes = serviceName.NewMethod("GetNodes", int64(0))
+	// methodGetNodesForRuntime is the GetNodesForRuntime method.
+	methodGetNodesForRuntime = serviceName.NewMethod("GetNodesForRuntime", NamespaceQuery{})
+	// methodListNodes is the ListNodes method.
+	methodListNodes = serviceName.NewMethod("ListNodes", ListNodesQuery{})
 	// methodGetRuntime is the GetRuntime method.
 	methodGetRuntime = serviceName.NewMethod("GetRuntime", GetRuntimeQuery{})
 	// methodGetRuntimes is the GetRuntimes method.
 	methodGetRuntimes = serviceName.NewMethod("GetRuntimes", GetRuntimesQuery{})
+	// methodListRuntimes is the ListRuntimes method.
+	methodListRuntimes = serviceName.NewMethod("ListRuntimes", ListRuntimesQuery{})
+	// methodGetRuntimeTEEHistory is the GetRuntimeTEEHistory method.
+	methodGetRuntimeTEEHistory = serviceName.NewMethod("GetRuntimeTEEHistory", NamespaceQuery{})
 	// methodStateToGenesis is the StateToGenesis method.
 	methodStateToGenesis = serviceName.NewMethod("StateToGenesis", int64(0))
 	// methodGetEvents is the GetEvents method.
@@ -62,6 +73,10 @@ var (
 				MethodName: methodGetEntities.ShortName(),
 				Handler:    handlerGetEntities,
 			},
+			{
+				MethodName: methodGetFrozenEntities.ShortName(),
+				Handler:    handlerGetFrozenEntities,
+			},
 			{
 				MethodName: methodGetNode.ShortName(),
 				Handler:    handlerGetNode,
@@ -78,6 +93,14 @@ var (
 				MethodName: methodGetNodes.ShortName(),
 				Handler:    handlerGetNodes,
 			},
+			{
+				MethodName: methodGetNodesForRuntime.ShortName(),
+				Handler:    handlerGetNodesForRuntime,
+			},
+			{
+				MethodName: methodListNodes.ShortName(),
+				Handler:    handlerListNodes,
+			},
 			{
 				MethodName: methodGetRuntime.ShortName(),
 				Handler:    handlerGetRuntime,
@@ -86,6 +109,14 @@ var (
 				MethodName: methodGetRuntimes.ShortName(),
 				Handler:    handlerGetRuntimes,
 			},
+			{
+				MethodName: methodListRuntimes.ShortName(),
+				Handler:    handlerListRuntimes,
+			},
+			{
+				MethodName: methodGetRuntimeTEEHistory.ShortName(),
+				Handler:    handlerGetRuntimeTEEHistory,
+			},
 			{
 				MethodName: methodStateToGenesis.ShortName(),
 				Handler:    handlerStateToGenesis,
@@ -175,6 +206,29 @@ func handlerGetEntities(
 	return interceptor(ctx, height, info, handler)
 }
 
+func handlerGetFrozenEntities(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var height int64
+	if err := dec(&height); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetFrozenEntities(ctx, height)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetFrozenEntities.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetFrozenEntities(ctx, req.(int64))
+	}
+	return interceptor(ctx, height, info, handler)
+}
+
 func handlerGetNode(
 	srv interface{},
 	ctx context.Context,
@@ -267,6 +321,52 @@ func handlerGetNodes(
 	return interceptor(ctx, height, info, handler)
 }
 
+func handlerGetNodesForRuntime(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query NamespaceQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetNodesForRuntime(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetNodesForRuntime.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetNodesForRuntime(ctx, req.(*NamespaceQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
+func handlerListNodes(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query ListNodesQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).ListNodes(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodListNodes.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).ListNodes(ctx, req.(*ListNodesQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
 func handlerGetRuntime(
 	srv interface{},
 	ctx context.Context,
@@ -313,6 +413,52 @@ func handlerGetRuntimes(
 	return interceptor(ctx, &query, info, handler)
 }
 
+func handlerListRuntimes(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query ListRuntimesQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).ListRuntimes(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodListRuntimes.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).ListRuntimes(ctx, req.(*ListRuntimesQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
+func handlerGetRuntimeTEEHistory(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query NamespaceQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetRuntimeTEEHistory(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetRuntimeTEEHistory.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetRuntimeTEEHistory(ctx, req.(*NamespaceQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
 func handlerStateToGenesis(
 	srv interface{},
 	ctx context.Context,
@@ -546,6 +692,14 @@ func (c *registryClient) GetEntities(ctx context.Context, height int64) ([]*enti
 	return rsp, nil
 }
 
+func (c *registryClient) GetFrozenEntities(ctx context.Context, height int64) ([]signature.PublicKey, error) {
+	var rsp []signature.PublicKey
+	if err := c.conn.Invoke(ctx, methodGetFrozenEntities.FullName(), height, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
 func (c *registryClient) WatchEntities(ctx context.Context) (<-chan *EntityEvent, pubsub.ClosableSubscription, error) {
 	ctx, sub := pubsub.NewContextSubscription(ctx)
 
@@ -613,6 +767,22 @@ func (c *registryClient) GetNodes(ctx context.Context, height int64) ([]*node.No
 	return rsp, nil
 }
 
+func (c *registryClient) GetNodesForRuntime(ctx context.Context, query *NamespaceQuery) ([]*node.Node, error) {
+	var rsp []*node.Node
+	if err := c.conn.Invoke(ctx, methodGetNodesForRuntime.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+func (c *registryClient) ListNodes(ctx context.Context, query *ListNodesQuery) (*ListNodesResponse, error) {
+	var rsp ListNodesResponse
+	if err := c.conn.Invoke(ctx, methodListNodes.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *registryClient) WatchNodes(ctx context.Context) (<-chan *NodeEvent, pubsub.ClosableSubscription, error) {
 	ctx, sub := pubsub.NewContextSubscription(ctx)
 
@@ -699,6 +869,22 @@ func (c *registryClient) GetRuntimes(ctx context.Context, query *GetRuntimesQuer
 	return rsp, nil
 }
 
+func (c *registryClient) ListRuntimes(ctx context.Context, query *ListRuntimesQuery) (*ListRuntimesResponse, error) {
+	var rsp ListRuntimesResponse
+	if err := c.conn.Invoke(ctx, methodListRuntimes.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
+func (c *registryClient) GetRuntimeTEEHistory(ctx context.Context, query *NamespaceQuery) ([]*RuntimeTEEHistoryRecord, error) {
+	var rsp []*RuntimeTEEHistoryRecord
+	if err := c.conn.Invoke(ctx, methodGetRuntimeTEEHistory.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
 func (c *registryClient) WatchRuntimes(ctx context.Context) (<-chan *Runtime, pubsub.ClosableSubscription, error) {
 	ctx, sub := pubsub.NewContextSubscription(ctx)
 
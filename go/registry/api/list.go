@@ -0,0 +1,169 @@
+package api
+
+import (
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+)
+
+const (
+	// DefaultListLimit is the maximum number of items returned by a paginated listing query when
+	// no limit is specified.
+	DefaultListLimit = 100
+
+	// MaxListLimit is the maximum number of items returned by a paginated listing query.
+	MaxListLimit = 1000
+)
+
+// ListLimit returns the effective limit for a paginated listing query.
+func ListLimit(limit uint32) int {
+	switch {
+	case limit == 0:
+		return DefaultListLimit
+	case limit > MaxListLimit:
+		return MaxListLimit
+	default:
+		return int(limit)
+	}
+}
+
+// NodeFilter is a filter for nodes. Empty fields match everything.
+type NodeFilter struct {
+	// Roles matches nodes that have at least one of the given roles.
+	Roles node.RolesMask `json:"roles,omitempty"`
+
+	// EntityID matches nodes controlled by the given entity.
+	EntityID *signature.PublicKey `json:"entity_id,omitempty"`
+
+	// RuntimeID matches nodes that support the given runtime.
+	RuntimeID *common.Namespace `json:"runtime_id,omitempty"`
+
+	// TEEHardware matches nodes that support at least one runtime with the given TEE hardware.
+	// Runtimes without the TEE capability are treated as node.TEEHardwareInvalid.
+	TEEHardware *node.TEEHardware `json:"tee_hardware,omitempty"`
+
+	// MinExpiration matches nodes that expire at or after the given epoch.
+	MinExpiration uint64 `json:"min_expiration,omitempty"`
+
+	// MaxExpiration matches nodes that expire at or before the given epoch.
+	MaxExpiration uint64 `json:"max_expiration,omitempty"`
+}
+
+// Matches returns true iff the given node matches the filter.
+func (f *NodeFilter) Matches(n *node.Node) bool {
+	if f.Roles != 0 && !n.HasRoles(f.Roles) {
+		return false
+	}
+	if f.EntityID != nil && !n.EntityID.Equal(*f.EntityID) {
+		return false
+	}
+	if n.Expiration < f.MinExpiration {
+		return false
+	}
+	if f.MaxExpiration != 0 && n.Expiration > f.MaxExpiration {
+		return false
+	}
+	if f.RuntimeID == nil && f.TEEHardware == nil {
+		return true
+	}
+	for _, rt := range n.Runtimes {
+		if f.RuntimeID != nil && !rt.ID.Equal(f.RuntimeID) {
+			continue
+		}
+		if f.TEEHardware != nil {
+			hw := node.TEEHardwareInvalid
+			if rt.Capabilities.TEE != nil {
+				hw = rt.Capabilities.TEE.Hardware
+			}
+			if hw != *f.TEEHardware {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// ListNodesQuery is a registry paginated node listing query.
+//
+// Nodes are returned in an unspecified but stable order. To list all nodes consistently, all
+// pages should be queried at the same height.
+type ListNodesQuery struct {
+	Height int64 `json:"height"`
+
+	// After is the cursor returned by the previous query. If not set, the listing starts from
+	// the beginning.
+	After *signature.PublicKey `json:"after,omitempty"`
+
+	// Limit is the maximum number of nodes to return. If zero, DefaultListLimit is used.
+	Limit uint32 `json:"limit,omitempty"`
+
+	// Filter is the filter that the returned nodes must match.
+	Filter NodeFilter `json:"filter"`
+}
+
+// ListNodesResponse is a registry paginated node listing response.
+type ListNodesResponse struct {
+	// Nodes are the nodes on this page.
+	Nodes []*node.Node `json:"nodes"`
+
+	// Next is the cursor that should be used as the After field of the query for the next page.
+	// If not set, there are no more nodes.
+	Next *signature.PublicKey `json:"next,omitempty"`
+}
+
+// RuntimeFilter is a filter for runtimes. Empty fields match everything.
+type RuntimeFilter struct {
+	// Kind matches runtimes of the given kind.
+	Kind RuntimeKind `json:"kind,omitempty"`
+
+	// EntityID matches runtimes controlled by the given entity.
+	EntityID *signature.PublicKey `json:"entity_id,omitempty"`
+
+	// TEEHardware matches runtimes with the given TEE hardware requirements.
+	TEEHardware *node.TEEHardware `json:"tee_hardware,omitempty"`
+}
+
+// Matches returns true iff the given runtime matches the filter.
+func (f *RuntimeFilter) Matches(rt *Runtime) bool {
+	if f.Kind != KindInvalid && rt.Kind != f.Kind {
+		return false
+	}
+	if f.EntityID != nil && !rt.EntityID.Equal(*f.EntityID) {
+		return false
+	}
+	if f.TEEHardware != nil && rt.TEEHardware != *f.TEEHardware {
+		return false
+	}
+	return true
+}
+
+// ListRuntimesQuery is a registry paginated runtime listing query.
+//
+// Runtimes are returned in an unspecified but stable order, with suspended runtimes (if
+// included) following all other runtimes. To list all runtimes consistently, all pages should
+// be queried at the same height.
+type ListRuntimesQuery struct {
+	Height           int64 `json:"height"`
+	IncludeSuspended bool  `json:"include_suspended,omitempty"`
+
+	// After is the cursor returned by the previous query. If not set, the listing starts from
+	// the beginning.
+	After *common.Namespace `json:"after,omitempty"`
+
+	// Limit is the maximum number of runtimes to return. If zero, DefaultListLimit is used.
+	Limit uint32 `json:"limit,omitempty"`
+
+	// Filter is the filter that the returned runtimes must match.
+	Filter RuntimeFilter `json:"filter"`
+}
+
+// ListRuntimesResponse is a registry paginated runtime listing response.
+type ListRuntimesResponse struct {
+	// Runtimes are the runtimes on this page.
+	Runtimes []*Runtime `json:"runtimes"`
+
+	// Next is the cursor that should be used as the After field of the query for the next page.
+	// If not set, there are no more runtimes.
+	Next *common.Namespace `json:"next,omitempty"`
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+)
+
+func TestListLimit(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal(DefaultListLimit, ListLimit(0))
+	require.Equal(10, ListLimit(10))
+	require.Equal(MaxListLimit, ListLimit(MaxListLimit+1))
+}
+
+func TestNodeFilter(t *testing.T) {
+	require := require.New(t)
+
+	var entityID, otherEntityID signature.PublicKey
+	require.NoError(entityID.UnmarshalHex("4ea5328f943ef6f66daaed74cb0e99c3b1c45f76307b425003dbc7cb3638ed35"))
+	require.NoError(otherEntityID.UnmarshalHex("0ad80cd1b5a8ae8c2d3a1a50e16d52fd6c2a4e3bbc4d6bb21f33a5ed7a5ef4f1"))
+	var rtID, otherRtID common.Namespace
+	require.NoError(rtID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000"))
+	require.NoError(otherRtID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000001"))
+	sgx := node.TEEHardwareIntelSGX
+	noTEE := node.TEEHardwareInvalid
+
+	n := &node.Node{
+		EntityID:   entityID,
+		Expiration: 10,
+		Roles:      node.RoleComputeWorker,
+		Runtimes: []*node.Runtime{
+			{ID: rtID, Capabilities: node.Capabilities{TEE: &node.CapabilityTEE{Hardware: sgx}}},
+		},
+	}
+
+	for _, tc := range []struct {
+		filter  NodeFilter
+		matches bool
+		msg     string
+	}{
+		{NodeFilter{}, true, "empty filter should match"},
+		{NodeFilter{Roles: node.RoleComputeWorker | node.RoleValidator}, true, "any of the roles should match"},
+		{NodeFilter{Roles: node.RoleValidator}, false, "other roles should not match"},
+		{NodeFilter{EntityID: &entityID}, true, "entity should match"},
+		{NodeFilter{EntityID: &otherEntityID}, false, "other entity should not match"},
+		{NodeFilter{RuntimeID: &rtID}, true, "runtime should match"},
+		{NodeFilter{RuntimeID: &otherRtID}, false, "other runtime should not match"},
+		{NodeFilter{TEEHardware: &sgx}, true, "TEE hardware should match"},
+		{NodeFilter{TEEHardware: &noTEE}, false, "other TEE hardware should not match"},
+		{NodeFilter{RuntimeID: &rtID, TEEHardware: &noTEE}, false, "runtime and TEE hardware should match together"},
+		{NodeFilter{MinExpiration: 10, MaxExpiration: 10}, true, "expiration window should match"},
+		{NodeFilter{MinExpiration: 11}, false, "earlier expiration should not match"},
+		{NodeFilter{MaxExpiration: 9}, false, "later expiration should not match"},
+	} {
+		require.Equal(tc.matches, tc.filter.Matches(n), tc.msg)
+	}
+}
+
+func TestRuntimeFilter(t *testing.T) {
+	require := require.New(t)
+
+	var entityID, otherEntityID signature.PublicKey
+	require.NoError(entityID.UnmarshalHex("4ea5328f943ef6f66daaed74cb0e99c3b1c45f76307b425003dbc7cb3638ed35"))
+	require.NoError(otherEntityID.UnmarshalHex("0ad80cd1b5a8ae8c2d3a1a50e16d52fd6c2a4e3bbc4d6bb21f33a5ed7a5ef4f1"))
+	sgx := node.TEEHardwareIntelSGX
+	noTEE := node.TEEHardwareInvalid
+
+	rt := &Runtime{
+		EntityID:    entityID,
+		Kind:        KindCompute,
+		TEEHardware: sgx,
+	}
+
+	for _, tc := range []struct {
+		filter  RuntimeFilter
+		matches bool
+		msg     string
+	}{
+		{RuntimeFilter{}, true, "empty filter should match"},
+		{RuntimeFilter{Kind: KindCompute}, true, "kind should match"},
+		{RuntimeFilter{Kind: KindKeyManager}, false, "other kind should not match"},
+		{RuntimeFilter{EntityID: &entityID}, true, "entity should match"},
+		{RuntimeFilter{EntityID: &otherEntityID}, false, "other entity should not match"},
+		{RuntimeFilter{TEEHardware: &sgx}, true, "TEE hardware should match"},
+		{RuntimeFilter{TEEHardware: &noTEE}, false, "other TEE hardware should not match"},
+	} {
+		require.Equal(tc.matches, tc.filter.Matches(rt), tc.msg)
+	}
+}
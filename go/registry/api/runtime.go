@@ -5,19 +5,23 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
+	"slices"
 	"sort"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/debug"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
-	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 )
@@ -192,7 +196,7 @@ type StorageParameters struct {
 // ValidateBasic performs basic storage parameter validity checks.
 func (s *StorageParameters) ValidateBasic() error {
 	// Verify storage checkpointing configuration if enabled.
-	if s.CheckpointInterval > 0 && !flags.DebugDontBlameOasis() {
+	if s.CheckpointInterval > 0 && !debug.CapabilityEnabled(debug.CapabilityUnsafeGenesis) {
 		if s.CheckpointInterval < 10 {
 			return fmt.Errorf("storage CheckpointInterval parameter too small")
 		}
@@ -604,6 +608,32 @@ func (r *Runtime) ValidateDeployments(now beacon.EpochTime, params *ConsensusPar
 		if len(deployment.BundleChecksum) > 0 && len(deployment.BundleChecksum) != 32 {
 			return fmt.Errorf("%w: invalid bundle checksum", ErrInvalidArgument)
 		}
+
+		// Future deployments must declare the bundle properties that are limited so that
+		// deployments of bundles known to violate the limits are rejected early. Nodes enforce
+		// the limits on the actual bundles when loading them as declarations are self-reported.
+		if deployment.ValidFrom > now && params.HasBundleLimits() {
+			switch {
+			case deployment.Metadata == nil:
+				return fmt.Errorf("%w: deployment metadata required by bundle limits", ErrInvalidArgument)
+			case params.MaxBundleComponents > 0 && deployment.Metadata.NumComponents == 0:
+				return fmt.Errorf("%w: deployment metadata must declare the number of components", ErrInvalidArgument)
+			case params.MaxBundleImageSize > 0 && deployment.Metadata.ImageSize == 0:
+				return fmt.Errorf("%w: deployment metadata must declare the image size", ErrInvalidArgument)
+			}
+		}
+
+		if deployment.Metadata != nil {
+			if !params.EnableDeploymentMetadata {
+				return fmt.Errorf("%w: deployment metadata not enabled", ErrInvalidArgument)
+			}
+			if err := deployment.Metadata.ValidateBasic(); err != nil {
+				return fmt.Errorf("%w: invalid deployment metadata: %w", ErrInvalidArgument, err)
+			}
+			if err := params.CheckBundleLimits(int(deployment.Metadata.NumComponents), deployment.Metadata.ImageSize); err != nil {
+				return fmt.Errorf("%w: %w", ErrInvalidArgument, err)
+			}
+		}
 	}
 	if numFuture > 1 {
 		return fmt.Errorf("%w: more than one future deployment", ErrInvalidArgument)
@@ -638,6 +668,64 @@ func (r *Runtime) StakingAddress() *staking.Address {
 	return &acctAddr
 }
 
+// RuntimeParameterChanges are changes to the parameters of a registered runtime.
+//
+// Fields that are not set are left unchanged.
+type RuntimeParameterChanges struct {
+	// Executor are the new executor parameters.
+	Executor *ExecutorParameters `json:"executor,omitempty"`
+
+	// TxnScheduler are the new transaction scheduler parameters.
+	TxnScheduler *TxnSchedulerParameters `json:"txn_scheduler,omitempty"`
+
+	// Storage are the new storage parameters.
+	Storage *StorageParameters `json:"storage,omitempty"`
+
+	// AdmissionPolicy is the new node admission policy.
+	AdmissionPolicy *RuntimeAdmissionPolicy `json:"admission_policy,omitempty"`
+
+	// Constraints are the new per-committee scheduling constraints.
+	Constraints map[scheduler.CommitteeKind]map[scheduler.Role]SchedulingConstraints `json:"constraints,omitempty"`
+
+	// Staking are the new runtime staking parameters.
+	Staking *RuntimeStakingParameters `json:"staking,omitempty"`
+}
+
+// SanityCheck performs a sanity check on the runtime parameter changes.
+func (c *RuntimeParameterChanges) SanityCheck() error {
+	if c.Executor == nil &&
+		c.TxnScheduler == nil &&
+		c.Storage == nil &&
+		c.AdmissionPolicy == nil &&
+		c.Constraints == nil &&
+		c.Staking == nil {
+		return fmt.Errorf("runtime parameter changes should not be empty")
+	}
+	return nil
+}
+
+// Apply applies changes to the given runtime descriptor.
+func (c *RuntimeParameterChanges) Apply(rt *Runtime) {
+	if c.Executor != nil {
+		rt.Executor = *c.Executor
+	}
+	if c.TxnScheduler != nil {
+		rt.TxnScheduler = *c.TxnScheduler
+	}
+	if c.Storage != nil {
+		rt.Storage = *c.Storage
+	}
+	if c.AdmissionPolicy != nil {
+		rt.AdmissionPolicy = *c.AdmissionPolicy
+	}
+	if c.Constraints != nil {
+		rt.Constraints = c.Constraints
+	}
+	if c.Staking != nil {
+		rt.Staking = *c.Staking
+	}
+}
+
 // VersionInfo is the per-runtime version information.
 type VersionInfo struct {
 	// Version of the runtime.
@@ -652,6 +740,87 @@ type VersionInfo struct {
 
 	// BundleChecksum is the SHA256 hash of the runtime bundle (optional).
 	BundleChecksum []byte `json:"bundle_checksum,omitempty"`
+
+	// Metadata is the optional deployment metadata.
+	Metadata *VersionMetadata `json:"metadata,omitempty"`
+}
+
+const (
+	// MaxVersionMetadataURLs is the maximum number of URLs in deployment metadata.
+	MaxVersionMetadataURLs = 4
+	// MaxVersionMetadataURLLength is the maximum length of a URL in deployment metadata.
+	MaxVersionMetadataURLLength = 512
+	// MaxVersionMetadataReleaseNotesLength is the maximum length of the release notes in
+	// deployment metadata.
+	MaxVersionMetadataReleaseNotesLength = 1024
+)
+
+// VersionMetadata is optional metadata describing a runtime deployment, enabling automated
+// discovery of the runtime bundles corresponding to on-chain deployments.
+type VersionMetadata struct {
+	// ManifestHash is the hash of the runtime bundle manifest (optional).
+	ManifestHash *hash.Hash `json:"manifest_hash,omitempty"`
+
+	// URLs are the URLs from which the runtime bundle can be obtained (optional).
+	URLs []string `json:"urls,omitempty"`
+
+	// ReleaseNotes is the human-readable release information (optional).
+	ReleaseNotes string `json:"release_notes,omitempty"`
+
+	// NumComponents is the declared number of components in the runtime bundle (optional).
+	NumComponents uint16 `json:"num_components,omitempty"`
+
+	// ImageSize is the declared total size of the runtime bundle contents in bytes (optional).
+	ImageSize uint64 `json:"image_size,omitempty"`
+}
+
+// ValidateBasic performs basic deployment metadata validity checks.
+func (m *VersionMetadata) ValidateBasic() error {
+	if len(m.URLs) > MaxVersionMetadataURLs {
+		return errors.New("too many URLs")
+	}
+	for _, rawURL := range m.URLs {
+		if len(rawURL) > MaxVersionMetadataURLLength {
+			return errors.New("URL too long")
+		}
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return fmt.Errorf("malformed URL: %w", err)
+		}
+		if u.Scheme != "https" || u.Host == "" {
+			return errors.New("URL must be an absolute https URL")
+		}
+	}
+	if len(m.ReleaseNotes) > MaxVersionMetadataReleaseNotesLength {
+		return errors.New("release notes too long")
+	}
+	if !utf8.ValidString(m.ReleaseNotes) {
+		return errors.New("release notes must be valid UTF-8")
+	}
+	return nil
+}
+
+// Equal compares vs another VersionMetadata for equality.
+func (m *VersionMetadata) Equal(cmp *VersionMetadata) bool {
+	if m == cmp {
+		return true
+	}
+	if m == nil || cmp == nil {
+		return false
+	}
+	switch {
+	case m.ManifestHash == nil && cmp.ManifestHash == nil:
+	case m.ManifestHash == nil || cmp.ManifestHash == nil:
+		return false
+	case !m.ManifestHash.Equal(cmp.ManifestHash):
+		return false
+	}
+	if !slices.Equal(m.URLs, cmp.URLs) {
+		return false
+	}
+	return m.ReleaseNotes == cmp.ReleaseNotes &&
+		m.NumComponents == cmp.NumComponents &&
+		m.ImageSize == cmp.ImageSize
 }
 
 // Equal compares vs another VersionInfo for equality.
@@ -674,9 +843,74 @@ func (vi *VersionInfo) Equal(cmp *VersionInfo) bool {
 	if !bytes.Equal(vi.BundleChecksum, cmp.BundleChecksum) {
 		return false
 	}
+	if !vi.Metadata.Equal(cmp.Metadata) {
+		return false
+	}
 	return true
 }
 
+// RuntimeTEEHistoryRecord is a record of the TEE configuration accepted for a runtime. The
+// configuration is in effect from the given consensus height until the height of the next
+// record (if any).
+type RuntimeTEEHistoryRecord struct {
+	// Height is the consensus height at which the TEE configuration was accepted.
+	Height int64 `json:"height"`
+
+	// TEEHardware specifies the runtime's TEE hardware requirements.
+	TEEHardware node.TEEHardware `json:"tee_hardware"`
+
+	// Deployments are the runtime deployments, including the TEE constraints (e.g., the allowed
+	// enclave identities and the quote policy) of each deployment.
+	Deployments []*VersionInfo `json:"deployments"`
+}
+
+// EqualTEE returns true iff both records contain the same TEE configuration, ignoring the height.
+func (r *RuntimeTEEHistoryRecord) EqualTEE(cmp *RuntimeTEEHistoryRecord) bool {
+	if r.TEEHardware != cmp.TEEHardware {
+		return false
+	}
+	if len(r.Deployments) != len(cmp.Deployments) {
+		return false
+	}
+	for i, vi := range r.Deployments {
+		if !vi.Equal(cmp.Deployments[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// EnclaveIdentities returns the SGX enclave identities allowed by the deployment which was active
+// at the given epoch. In case there was no active deployment or the runtime does not use SGX, an
+// empty list is returned.
+func (r *RuntimeTEEHistoryRecord) EnclaveIdentities(epoch beacon.EpochTime) ([]sgx.EnclaveIdentity, error) {
+	if r.TEEHardware != node.TEEHardwareIntelSGX {
+		return nil, nil
+	}
+
+	rt := Runtime{Deployments: r.Deployments}
+	deployment := rt.ActiveDeployment(epoch)
+	if deployment == nil {
+		return nil, nil
+	}
+
+	var cs node.SGXConstraints
+	if err := cbor.Unmarshal(deployment.TEE, &cs); err != nil {
+		return nil, fmt.Errorf("%w: invalid SGX TEE constraints", ErrInvalidArgument)
+	}
+	return cs.Enclaves, nil
+}
+
+// NewRuntimeTEEHistoryRecord creates a new TEE history record for the given runtime descriptor
+// accepted at the given consensus height.
+func NewRuntimeTEEHistoryRecord(rt *Runtime, height int64) *RuntimeTEEHistoryRecord {
+	return &RuntimeTEEHistoryRecord{
+		Height:      height,
+		TEEHardware: rt.TEEHardware,
+		Deployments: rt.Deployments,
+	}
+}
+
 // RuntimeGenesis is the runtime genesis information that is used to
 // initialize runtime state in the first block.
 type RuntimeGenesis struct {
@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	"github.com/oasisprotocol/oasis-core/go/scheduler/api"
 )
@@ -763,3 +765,170 @@ func TestDeployments(t *testing.T) {
 	})
 	require.Nil(ad)
 }
+
+func TestRuntimeTEEHistoryRecord(t *testing.T) {
+	require := require.New(t)
+
+	enclave1 := sgx.EnclaveIdentity{MrEnclave: sgx.MrEnclave{1}, MrSigner: sgx.MrSigner{1}}
+	enclave2 := sgx.EnclaveIdentity{MrEnclave: sgx.MrEnclave{2}, MrSigner: sgx.MrSigner{1}}
+
+	rt := &Runtime{
+		TEEHardware: node.TEEHardwareIntelSGX,
+		Deployments: []*VersionInfo{
+			{
+				ValidFrom: 10,
+				TEE: cbor.Marshal(node.SGXConstraints{
+					Versioned: cbor.NewVersioned(node.LatestSGXConstraintsVersion),
+					Enclaves:  []sgx.EnclaveIdentity{enclave1},
+				}),
+			},
+			{
+				ValidFrom: 20,
+				TEE: cbor.Marshal(node.SGXConstraints{
+					Versioned: cbor.NewVersioned(node.LatestSGXConstraintsVersion),
+					Enclaves:  []sgx.EnclaveIdentity{enclave2},
+				}),
+			},
+		},
+	}
+	record := NewRuntimeTEEHistoryRecord(rt, 100)
+	require.EqualValues(100, record.Height)
+
+	for _, tc := range []struct {
+		epoch    beacon.EpochTime
+		enclaves []sgx.EnclaveIdentity
+	}{
+		{5, nil},
+		{10, []sgx.EnclaveIdentity{enclave1}},
+		{19, []sgx.EnclaveIdentity{enclave1}},
+		{20, []sgx.EnclaveIdentity{enclave2}},
+		{100, []sgx.EnclaveIdentity{enclave2}},
+	} {
+		enclaves, err := record.EnclaveIdentities(tc.epoch)
+		require.NoError(err, "EnclaveIdentities")
+		require.EqualValues(tc.enclaves, enclaves, "enclave identities at epoch %d", tc.epoch)
+	}
+
+	// Records should only be compared by their TEE configuration.
+	require.True(record.EqualTEE(NewRuntimeTEEHistoryRecord(rt, 200)))
+	rt2 := *rt
+	rt2.Deployments = rt.Deployments[:1]
+	require.False(record.EqualTEE(NewRuntimeTEEHistoryRecord(&rt2, 100)))
+	rt2 = *rt
+	rt2.TEEHardware = node.TEEHardwareInvalid
+	require.False(record.EqualTEE(NewRuntimeTEEHistoryRecord(&rt2, 100)))
+
+	// Runtimes without SGX have no enclave identities.
+	enclaves, err := NewRuntimeTEEHistoryRecord(&rt2, 100).EnclaveIdentities(20)
+	require.NoError(err, "EnclaveIdentities")
+	require.Empty(enclaves)
+}
+
+func TestVersionMetadata(t *testing.T) {
+	require := require.New(t)
+
+	var manifestHash hash.Hash
+	manifestHash.FromBytes([]byte("manifest"))
+
+	md := &VersionMetadata{
+		ManifestHash: &manifestHash,
+		URLs:         []string{"https://example.com/runtime.orc"},
+		ReleaseNotes: "Initial release.",
+	}
+	require.NoError(md.ValidateBasic(), "valid metadata should pass validation")
+
+	for _, tc := range []struct {
+		md  VersionMetadata
+		msg string
+	}{
+		{VersionMetadata{URLs: []string{"http://example.com/runtime.orc"}}, "non-https URLs should be rejected"},
+		{VersionMetadata{URLs: []string{"/runtime.orc"}}, "relative URLs should be rejected"},
+		{VersionMetadata{URLs: []string{"https://example.com/" + strings.Repeat("a", MaxVersionMetadataURLLength)}}, "long URLs should be rejected"},
+		{VersionMetadata{URLs: make([]string, MaxVersionMetadataURLs+1)}, "too many URLs should be rejected"},
+		{VersionMetadata{ReleaseNotes: strings.Repeat("a", MaxVersionMetadataReleaseNotesLength+1)}, "long release notes should be rejected"},
+		{VersionMetadata{ReleaseNotes: "\xff"}, "invalid UTF-8 release notes should be rejected"},
+	} {
+		require.Error(tc.md.ValidateBasic(), tc.msg)
+	}
+
+	// Equality.
+	cp := *md
+	require.True(md.Equal(&cp))
+	cp.URLs = []string{"https://example.org/runtime.orc"}
+	require.False(md.Equal(&cp))
+	cp = *md
+	cp.ManifestHash = nil
+	require.False(md.Equal(&cp))
+	cp = *md
+	cp.ImageSize = 1024
+	require.False(md.Equal(&cp))
+	require.False(md.Equal(nil))
+
+	vi := &VersionInfo{Metadata: md}
+	require.False(vi.Equal(&VersionInfo{}), "metadata should be compared")
+
+	// Deployment validation should validate metadata.
+	rt := Runtime{
+		Deployments: []*VersionInfo{
+			{Metadata: &VersionMetadata{URLs: []string{"ftp://example.com"}}},
+		},
+	}
+	params := &ConsensusParameters{EnableDeploymentMetadata: true}
+	err := rt.ValidateDeployments(0, params)
+	require.ErrorIs(err, ErrInvalidArgument, "invalid metadata should be rejected")
+	rt.Deployments[0].Metadata = md
+	require.NoError(rt.ValidateDeployments(0, params))
+
+	// Metadata should be rejected unless enabled.
+	err = rt.ValidateDeployments(0, &ConsensusParameters{})
+	require.ErrorIs(err, ErrInvalidArgument, "metadata should be rejected when not enabled")
+}
+
+func TestBundleLimits(t *testing.T) {
+	require := require.New(t)
+
+	params := &ConsensusParameters{EnableDeploymentMetadata: true}
+	require.NoError(params.CheckBundleLimits(100, 1<<40), "zero limits should not limit bundles")
+
+	params.MaxBundleComponents = 2
+	params.MaxBundleImageSize = 1024
+	require.NoError(params.CheckBundleLimits(2, 1024), "bundles within limits should be accepted")
+	require.Error(params.CheckBundleLimits(3, 1024), "bundles with too many components should be rejected")
+	require.Error(params.CheckBundleLimits(2, 1025), "too large bundles should be rejected")
+
+	// Deployment validation should enforce the limits on declared metadata.
+	rt := Runtime{
+		Deployments: []*VersionInfo{
+			{Metadata: &VersionMetadata{NumComponents: 2, ImageSize: 1024}},
+		},
+	}
+	require.NoError(rt.ValidateDeployments(0, params))
+	rt.Deployments[0].Metadata.NumComponents = 3
+	err := rt.ValidateDeployments(0, params)
+	require.ErrorIs(err, ErrInvalidArgument, "too many declared components should be rejected")
+	rt.Deployments[0].Metadata.NumComponents = 2
+	rt.Deployments[0].Metadata.ImageSize = 1025
+	err = rt.ValidateDeployments(0, params)
+	require.ErrorIs(err, ErrInvalidArgument, "too large declared image size should be rejected")
+
+	// Future deployments should be required to declare the limited properties.
+	rt.Deployments[0].ValidFrom = 1
+	rt.Deployments[0].Metadata = nil
+	err = rt.ValidateDeployments(0, params)
+	require.ErrorIs(err, ErrInvalidArgument, "future deployments without metadata should be rejected")
+	rt.Deployments[0].Metadata = &VersionMetadata{NumComponents: 2}
+	err = rt.ValidateDeployments(0, params)
+	require.ErrorIs(err, ErrInvalidArgument, "future deployments without declared image size should be rejected")
+	rt.Deployments[0].Metadata.ImageSize = 1024
+	require.NoError(rt.ValidateDeployments(0, params))
+
+	// Without limits, metadata should not be required.
+	rt.Deployments[0].Metadata = nil
+	require.NoError(rt.ValidateDeployments(0, &ConsensusParameters{}))
+
+	// Limits should require deployment metadata to be enabled.
+	params.MaxNodeExpiration = 1
+	require.NoError(params.SanityCheck())
+	params.EnableDeploymentMetadata = false
+	require.Error(params.SanityCheck(), "bundle limits without deployment metadata should be rejected")
+}
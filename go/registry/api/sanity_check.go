@@ -8,23 +8,26 @@ import (
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/debug"
 	"github.com/oasisprotocol/oasis-core/go/common/entity"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
-	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 )
 
 // SanityCheck performs a sanity check on the consensus parameters.
 func (p *ConsensusParameters) SanityCheck() error {
-	if !flags.DebugDontBlameOasis() {
-		if p.DebugAllowUnroutableAddresses || p.DebugDeployImmediately {
+	if !debug.CapabilityEnabled(debug.CapabilityUnsafeGenesis) {
+		if p.DebugAllowUnroutableAddresses || p.DebugDeployImmediately || p.DebugAllowDebugCapabilities {
 			return fmt.Errorf("one or more unsafe debug flags set")
 		}
 		if p.MaxNodeExpiration == 0 {
 			return fmt.Errorf("maximum node expiration not specified")
 		}
 	}
+	if p.HasBundleLimits() && !p.EnableDeploymentMetadata {
+		return fmt.Errorf("bundle limits require deployment metadata to be enabled")
+	}
 	return nil
 }
 
@@ -35,7 +38,13 @@ func (c *ConsensusParameterChanges) SanityCheck() error {
 		c.GasCosts == nil &&
 		c.MaxNodeExpiration == nil &&
 		c.EnableRuntimeGovernanceModels == nil &&
-		c.TEEFeatures == nil {
+		c.TEEFeatures == nil &&
+		c.MaxBundleComponents == nil &&
+		c.MaxBundleImageSize == nil &&
+		c.EnableBackupOnlyNodes == nil &&
+		c.EnableRuntimeTEEHistory == nil &&
+		c.EnableNodeByRuntimeIndex == nil &&
+		c.EnableDeploymentMetadata == nil {
 		return fmt.Errorf("consensus parameter changes should not be empty")
 	}
 	return nil
@@ -61,6 +70,18 @@ func (g *Genesis) SanityCheck(
 		return err
 	}
 
+	// Check frozen entities.
+	seenFrozenEntities := make(map[signature.PublicKey]bool)
+	for _, id := range g.FrozenEntities {
+		if !id.IsValid() {
+			return fmt.Errorf("registry: sanity check failed: invalid frozen entity: '%s'", id)
+		}
+		if seenFrozenEntities[id] {
+			return fmt.Errorf("registry: sanity check failed: duplicate frozen entity: '%s'", id)
+		}
+		seenFrozenEntities[id] = true
+	}
+
 	// Check runtimes.
 	runtimesLookup, err := SanityCheckRuntimes(logger, &g.Parameters, g.Runtimes, g.SuspendedRuntimes, true, baseEpoch)
 	if err != nil {
@@ -410,6 +410,23 @@ func testRegistryEntityNodes( // nolint: gocyclo
 		registeredNodes, nerr := backend.GetNodes(ctx, consensusAPI.HeightLatest)
 		require.NoError(nerr, "GetNodes")
 
+		// Nodes for each runtime should match the filtered node list.
+		nodesByRuntime := make(map[common.Namespace][]*node.Node)
+		for _, nd := range registeredNodes {
+			for _, rt := range nd.Runtimes {
+				rtNodes := nodesByRuntime[rt.ID]
+				if len(rtNodes) > 0 && rtNodes[len(rtNodes)-1] == nd {
+					continue
+				}
+				nodesByRuntime[rt.ID] = append(rtNodes, nd)
+			}
+		}
+		for id, expectedNodes := range nodesByRuntime {
+			rtNodes, rerr := backend.GetNodesForRuntime(ctx, &api.NamespaceQuery{ID: id, Height: consensusAPI.HeightLatest})
+			require.NoError(rerr, "GetNodesForRuntime")
+			require.EqualValues(expectedNodes, rtNodes, "node list for runtime %s", id)
+		}
+
 		// Remove the pre-exiting validator node.
 		for i, nd := range registeredNodes {
 			if nd.EntityID.Equal(validatorEntityID) {
@@ -63,7 +63,7 @@ var (
 	ErrRuntimeSuspended = errors.New(ModuleName, 5, "roothash: runtime is suspended")
 
 	// ErrNoCommittee is the error returned when there is no committee.
-	ErrNoCommittee = errors.New(ModuleName, 6, "roothash: no committee")
+	ErrNoCommittee = errors.NewRetriable(ModuleName, 6, "roothash: no committee")
 
 	// ErrMaxMessagesTooBig is the error returned when the MaxMessages parameter is set to a value
 	// larger than the MaxRuntimeMessages specified in consensus parameters.
@@ -80,7 +80,7 @@ var (
 	ErrInvalidEvidence = errors.New(ModuleName, 10, "roothash: invalid evidence")
 
 	// ErrIncomingMessageQueueFull is the error returned when the incoming message queue is full.
-	ErrIncomingMessageQueueFull = errors.New(ModuleName, 11, "roothash: incoming message queue full")
+	ErrIncomingMessageQueueFull = errors.NewRetriable(ModuleName, 11, "roothash: incoming message queue full")
 
 	// ErrIncomingMessageInsufficientFee is the error returned when the provided fee is smaller than
 	// the configured minimum incoming message submission fee.
@@ -90,6 +90,10 @@ var (
 	// value larger than the MaxInRuntimeMessages specified in consensus parameters.
 	ErrMaxInMessagesTooBig = errors.New(ModuleName, 13, "roothash: max incoming runtime messages is too big")
 
+	// ErrHistoryUnavailable is the error returned when the node does not keep the block history
+	// of the requested runtime.
+	ErrHistoryUnavailable = errors.New(ModuleName, 14, "roothash: runtime history not available")
+
 	// MethodExecutorCommit is the method name for executor commit submission.
 	MethodExecutorCommit = transaction.NewMethodName(ModuleName, "ExecutorCommit", ExecutorCommit{})
 
@@ -130,6 +134,21 @@ type Backend interface {
 	// GetLastRoundResults returns the given runtime's last normal round results.
 	GetLastRoundResults(ctx context.Context, request *RuntimeRequest) (*RoundResults, error)
 
+	// GetRoundResults returns the given runtime's normal round results for the requested range
+	// of rounds. Rounds which are not normal rounds are omitted.
+	//
+	// Results are only available for runtimes whose block history is kept by the node.
+	GetRoundResults(ctx context.Context, request *RoundResultsRequest) (map[uint64]*RoundResults, error)
+
+	// GetMessageReceipts returns the execution receipts of the messages emitted by the given
+	// runtime in the requested round.
+	//
+	// Receipts are only available for runtimes whose block history is kept by the node.
+	GetMessageReceipts(ctx context.Context, request *MessageReceiptsRequest) ([]*MessageReceipt, error)
+
+	// GetRuntimeStatistics returns the given runtime's execution statistics.
+	GetRuntimeStatistics(ctx context.Context, request *RuntimeRequest) (*RuntimeStatistics, error)
+
 	// GetIncomingMessageQueueMeta returns the given runtime's incoming message queue metadata.
 	GetIncomingMessageQueueMeta(ctx context.Context, request *RuntimeRequest) (*message.IncomingMessageQueueMeta, error)
 
@@ -183,6 +202,23 @@ type RoundRootsRequest struct {
 	Round     uint64           `json:"round"`
 }
 
+// RoundResultsRequest is a request for a specific runtime's round results over a range of rounds.
+type RoundResultsRequest struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+
+	// FromRound is the first round (inclusive) of the range.
+	FromRound uint64 `json:"from_round"`
+	// ToRound is the last round (inclusive) of the range. Passing the special value `RoundLatest`
+	// refers to the latest round.
+	ToRound uint64 `json:"to_round"`
+}
+
+// MessageReceiptsRequest is a request for a specific runtime and round's message receipts.
+type MessageReceiptsRequest struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+	Round     uint64           `json:"round"`
+}
+
 // InMessageQueueRequest is a request for queued incoming messages.
 type InMessageQueueRequest struct {
 	RuntimeID common.Namespace `json:"runtime_id"`
@@ -528,6 +564,7 @@ func (me *MessageEvent) IsSuccess() bool {
 type Event struct {
 	Height int64     `json:"height,omitempty"`
 	TxHash hash.Hash `json:"tx_hash,omitempty"`
+	Index  uint32    `json:"index,omitempty"`
 
 	RuntimeID common.Namespace `json:"runtime_id"`
 
@@ -590,6 +627,10 @@ type ConsensusParameters struct {
 	// MaxPastRootsStored is the maximum number of past runtime state and I/O
 	// roots that are stored in the consensus state.
 	MaxPastRootsStored uint64 `json:"max_past_roots_stored,omitempty"`
+
+	// EnableRuntimeStatistics enables aggregation of per-runtime execution statistics in the
+	// consensus state.
+	EnableRuntimeStatistics bool `json:"enable_runtime_statistics,omitempty"`
 }
 
 // ConsensusParameterChanges are allowed roothash consensus parameter changes.
@@ -609,6 +650,9 @@ type ConsensusParameterChanges struct {
 	// MaxPastRootsStored is the new maximum number of past runtime state and I/O
 	// roots that are stored in the consensus state.
 	MaxPastRootsStored *uint64 `json:"max_past_roots_stored,omitempty"`
+
+	// EnableRuntimeStatistics is the new runtime statistics aggregation flag.
+	EnableRuntimeStatistics *bool `json:"enable_runtime_statistics,omitempty"`
 }
 
 // Apply applies changes to the given consensus parameters.
@@ -628,6 +672,9 @@ func (c *ConsensusParameterChanges) Apply(params *ConsensusParameters) error {
 	if c.MaxPastRootsStored != nil {
 		params.MaxPastRootsStored = *c.MaxPastRootsStored
 	}
+	if c.EnableRuntimeStatistics != nil {
+		params.EnableRuntimeStatistics = *c.EnableRuntimeStatistics
+	}
 	return nil
 }
 
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+)
+
+// FinalityEstimateParams are the parameters used to estimate when a runtime transaction that is
+// submitted now is likely to be finalized.
+type FinalityEstimateParams struct {
+	// LatestBlock is the latest finalized runtime block.
+	LatestBlock *block.Block
+
+	// RoundInterval is the observed average interval between recent runtime rounds. If zero, the
+	// batch flush timeout is used instead.
+	RoundInterval time.Duration
+
+	// QueueDepth is the number of transactions in the transaction pool that are queued for
+	// scheduling ahead of the submitted transaction.
+	QueueDepth uint64
+
+	// MaxBatchSize is the maximum number of transactions in a scheduled batch.
+	MaxBatchSize uint64
+
+	// BatchFlushTimeout is the time the scheduler waits before proposing a non-full batch.
+	BatchFlushTimeout time.Duration
+
+	// Suspended is a flag indicating whether the runtime is currently suspended.
+	Suspended bool
+}
+
+// FinalityEstimate is an estimate of when a runtime transaction is likely to be finalized.
+type FinalityEstimate struct {
+	// Round is the runtime round in which the transaction is expected to be finalized.
+	Round uint64 `json:"round"`
+
+	// Time is the time at which the transaction is expected to be finalized.
+	Time time.Time `json:"time"`
+
+	// RoundInterval is the interval between runtime rounds used for the estimate.
+	RoundInterval time.Duration `json:"round_interval"`
+
+	// QueueDepth is the number of transactions queued ahead of the transaction.
+	QueueDepth uint64 `json:"queue_depth"`
+}
+
+// RoundInterval returns the average interval between the given consecutive runtime blocks,
+// ignoring any rounds that were not normal rounds.
+//
+// Blocks must be ordered by round. Zero is returned in case the interval cannot be determined.
+func RoundInterval(blocks []*block.Block) time.Duration {
+	var (
+		total time.Duration
+		count int64
+	)
+	for i := 1; i < len(blocks); i++ {
+		prev, cur := blocks[i-1], blocks[i]
+		if cur.Header.HeaderType != block.Normal || cur.Header.Timestamp < prev.Header.Timestamp {
+			continue
+		}
+		total += time.Duration(cur.Header.Timestamp-prev.Header.Timestamp) * time.Second
+		count++
+	}
+	if count == 0 {
+		return 0
+	}
+	return total / time.Duration(count)
+}
+
+// EstimateFinality estimates when a runtime transaction that is submitted at the given time is
+// likely to be finalized.
+func EstimateFinality(now time.Time, p *FinalityEstimateParams) (*FinalityEstimate, error) {
+	if p.Suspended {
+		return nil, ErrRuntimeSuspended
+	}
+	if p.LatestBlock == nil {
+		return nil, ErrNotFound
+	}
+
+	interval := p.RoundInterval
+	if interval == 0 {
+		interval = p.BatchFlushTimeout
+	}
+	maxBatchSize := p.MaxBatchSize
+	if maxBatchSize == 0 {
+		maxBatchSize = 1
+	}
+
+	// The transaction is included in the first batch with available space, assuming that
+	// each round schedules a full batch.
+	rounds := p.QueueDepth/maxBatchSize + 1
+
+	// The next round is expected one round interval after the latest one.
+	latest := time.Unix(int64(p.LatestBlock.Header.Timestamp), 0)
+	next := latest.Add(interval)
+	if next.Before(now) {
+		next = now.Add(p.BatchFlushTimeout)
+	}
+
+	return &FinalityEstimate{
+		Round:         p.LatestBlock.Header.Round + rounds,
+		Time:          next.Add(time.Duration(rounds-1) * interval),
+		RoundInterval: interval,
+		QueueDepth:    p.QueueDepth,
+	}, nil
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+)
+
+func TestRoundInterval(t *testing.T) {
+	require := require.New(t)
+
+	newBlock := func(round uint64, timestamp uint64, headerType block.HeaderType) *block.Block {
+		var blk block.Block
+		blk.Header.Round = round
+		blk.Header.Timestamp = block.Timestamp(timestamp)
+		blk.Header.HeaderType = headerType
+		return &blk
+	}
+
+	require.EqualValues(0, RoundInterval(nil))
+	require.EqualValues(0, RoundInterval([]*block.Block{newBlock(1, 100, block.Normal)}))
+
+	blocks := []*block.Block{
+		newBlock(1, 100, block.Normal),
+		newBlock(2, 106, block.Normal),
+		newBlock(3, 200, block.RoundFailed),
+		newBlock(4, 210, block.Normal),
+	}
+	require.EqualValues(8*time.Second, RoundInterval(blocks))
+}
+
+func TestEstimateFinality(t *testing.T) {
+	require := require.New(t)
+
+	var blk block.Block
+	blk.Header.Round = 10
+	blk.Header.Timestamp = 1000
+	latest := time.Unix(1000, 0)
+
+	params := &FinalityEstimateParams{
+		LatestBlock:       &blk,
+		RoundInterval:     6 * time.Second,
+		QueueDepth:        0,
+		MaxBatchSize:      100,
+		BatchFlushTimeout: time.Second,
+	}
+
+	// Empty queue, the transaction should be included in the next round.
+	est, err := EstimateFinality(latest.Add(2*time.Second), params)
+	require.NoError(err)
+	require.EqualValues(11, est.Round)
+	require.Equal(latest.Add(6*time.Second), est.Time)
+	require.Equal(6*time.Second, est.RoundInterval)
+
+	// Full batches queued ahead of the transaction.
+	params.QueueDepth = 250
+	est, err = EstimateFinality(latest.Add(2*time.Second), params)
+	require.NoError(err)
+	require.EqualValues(13, est.Round)
+	require.Equal(latest.Add(18*time.Second), est.Time)
+	require.EqualValues(250, est.QueueDepth)
+
+	// Overdue round.
+	params.QueueDepth = 0
+	now := latest.Add(time.Minute)
+	est, err = EstimateFinality(now, params)
+	require.NoError(err)
+	require.EqualValues(11, est.Round)
+	require.Equal(now.Add(time.Second), est.Time)
+
+	// Unknown round cadence.
+	params.RoundInterval = 0
+	est, err = EstimateFinality(latest, params)
+	require.NoError(err)
+	require.Equal(time.Second, est.RoundInterval)
+
+	// Suspended runtime.
+	params.Suspended = true
+	_, err = EstimateFinality(latest, params)
+	require.ErrorIs(err, ErrRuntimeSuspended)
+}
@@ -29,6 +29,12 @@ var (
 	methodGetRoundRoots = serviceName.NewMethod("GetRoundRoots", RoundRootsRequest{})
 	// methodGetPastRoundRoots is the GetPastRoundRoots method.
 	methodGetPastRoundRoots = serviceName.NewMethod("GetPastRoundRoots", RuntimeRequest{})
+	// methodGetRoundResults is the GetRoundResults method.
+	methodGetRoundResults = serviceName.NewMethod("GetRoundResults", RoundResultsRequest{})
+	// methodGetMessageReceipts is the GetMessageReceipts method.
+	methodGetMessageReceipts = serviceName.NewMethod("GetMessageReceipts", MessageReceiptsRequest{})
+	// methodGetRuntimeStatistics is the GetRuntimeStatistics method.
+	methodGetRuntimeStatistics = serviceName.NewMethod("GetRuntimeStatistics", RuntimeRequest{})
 	// methodGetIncomingMessageQueueMeta is the GetIncomingMessageQueueMeta method.
 	methodGetIncomingMessageQueueMeta = serviceName.NewMethod("GetIncomingMessageQueueMeta", RuntimeRequest{})
 	// methodGetIncomingMessageQueue is the GetIncomingMessageQueue method.
@@ -76,6 +82,18 @@ var (
 				MethodName: methodGetPastRoundRoots.ShortName(),
 				Handler:    handlerGetPastRoundRoots,
 			},
+			{
+				MethodName: methodGetRoundResults.ShortName(),
+				Handler:    handlerGetRoundResults,
+			},
+			{
+				MethodName: methodGetMessageReceipts.ShortName(),
+				Handler:    handlerGetMessageReceipts,
+			},
+			{
+				MethodName: methodGetRuntimeStatistics.ShortName(),
+				Handler:    handlerGetRuntimeStatistics,
+			},
 			{
 				MethodName: methodGetIncomingMessageQueueMeta.ShortName(),
 				Handler:    handlerGetIncomingMessageQueueMeta,
@@ -255,6 +273,75 @@ func handlerGetPastRoundRoots(
 	return interceptor(ctx, &rq, info, handler)
 }
 
+func handlerGetRoundResults(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rq RoundResultsRequest
+	if err := dec(&rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetRoundResults(ctx, &rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetRoundResults.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetRoundResults(ctx, req.(*RoundResultsRequest))
+	}
+	return interceptor(ctx, &rq, info, handler)
+}
+
+func handlerGetMessageReceipts(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rq MessageReceiptsRequest
+	if err := dec(&rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetMessageReceipts(ctx, &rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetMessageReceipts.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetMessageReceipts(ctx, req.(*MessageReceiptsRequest))
+	}
+	return interceptor(ctx, &rq, info, handler)
+}
+
+func handlerGetRuntimeStatistics(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rq RuntimeRequest
+	if err := dec(&rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetRuntimeStatistics(ctx, &rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetRuntimeStatistics.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetRuntimeStatistics(ctx, req.(*RuntimeRequest))
+	}
+	return interceptor(ctx, &rq, info, handler)
+}
+
 func handlerGetIncomingMessageQueueMeta(
 	srv interface{},
 	ctx context.Context,
@@ -514,6 +601,30 @@ func (c *roothashClient) GetPastRoundRoots(ctx context.Context, request *Runtime
 	return rsp, nil
 }
 
+func (c *roothashClient) GetRoundResults(ctx context.Context, request *RoundResultsRequest) (map[uint64]*RoundResults, error) {
+	var rsp map[uint64]*RoundResults
+	if err := c.conn.Invoke(ctx, methodGetRoundResults.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+func (c *roothashClient) GetMessageReceipts(ctx context.Context, request *MessageReceiptsRequest) ([]*MessageReceipt, error) {
+	var rsp []*MessageReceipt
+	if err := c.conn.Invoke(ctx, methodGetMessageReceipts.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+func (c *roothashClient) GetRuntimeStatistics(ctx context.Context, request *RuntimeRequest) (*RuntimeStatistics, error) {
+	var rsp RuntimeStatistics
+	if err := c.conn.Invoke(ctx, methodGetRuntimeStatistics.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *roothashClient) GetIncomingMessageQueueMeta(ctx context.Context, request *RuntimeRequest) (*message.IncomingMessageQueueMeta, error) {
 	var rsp message.IncomingMessageQueueMeta
 	if err := c.conn.Invoke(ctx, methodGetIncomingMessageQueueMeta.FullName(), request, &rsp); err != nil {
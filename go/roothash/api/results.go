@@ -1,6 +1,15 @@
 package api
 
-import "github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+import (
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/message"
+)
+
+// MaxRoundResultsRange is the maximum number of rounds that can be requested in a single
+// GetRoundResults query.
+const MaxRoundResultsRange = 1000
 
 // RoundResults contains information about how a particular round was executed by the consensus
 // layer.
@@ -15,3 +24,34 @@ type RoundResults struct {
 	// negatively contributed to the round by causing discrepancies.
 	BadComputeEntities []signature.PublicKey `json:"bad_compute_entities,omitempty"`
 }
+
+// MessageReceipts pairs the given messages, emitted by the runtime in the round, with the results
+// of their execution.
+func (rr *RoundResults) MessageReceipts(msgs []message.Message) ([]*MessageReceipt, error) {
+	if len(msgs) != len(rr.Messages) {
+		return nil, fmt.Errorf("roothash: number of messages (%d) does not match number of results (%d)",
+			len(msgs), len(rr.Messages),
+		)
+	}
+
+	receipts := make([]*MessageReceipt, 0, len(msgs))
+	for i, ev := range rr.Messages {
+		if ev.Index != uint32(i) {
+			return nil, fmt.Errorf("roothash: unexpected message result index (expected: %d got: %d)", i, ev.Index)
+		}
+		receipts = append(receipts, &MessageReceipt{
+			Message: msgs[i],
+			Result:  ev,
+		})
+	}
+	return receipts, nil
+}
+
+// MessageReceipt is the execution receipt of a message emitted by a runtime.
+type MessageReceipt struct {
+	// Message is the emitted runtime message which describes the triggered consensus call.
+	Message message.Message `json:"message"`
+
+	// Result is the result of executing the message.
+	Result *MessageEvent `json:"result"`
+}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/message"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 )
 
 func TestRoundResultsSerialization(t *testing.T) {
@@ -48,3 +50,34 @@ func TestRoundResultsSerialization(t *testing.T) {
 		require.EqualValues(tc.rr, dec, "RoundResults serialization should round-trip")
 	}
 }
+
+func TestRoundResultsMessageReceipts(t *testing.T) {
+	require := require.New(t)
+
+	msgs := []message.Message{
+		{Staking: &message.StakingMessage{Transfer: &staking.Transfer{}}},
+		{Staking: &message.StakingMessage{Withdraw: &staking.Withdraw{}}},
+	}
+	rr := RoundResults{
+		Messages: []*MessageEvent{
+			{Index: 0},
+			{Module: "staking", Code: 3, Index: 1},
+		},
+	}
+
+	receipts, err := rr.MessageReceipts(msgs)
+	require.NoError(err, "MessageReceipts")
+	require.Len(receipts, 2)
+	require.Equal(msgs[0], receipts[0].Message)
+	require.True(receipts[0].Result.IsSuccess())
+	require.Equal(msgs[1], receipts[1].Message)
+	require.False(receipts[1].Result.IsSuccess())
+	require.EqualValues(3, receipts[1].Result.Code)
+
+	_, err = rr.MessageReceipts(msgs[:1])
+	require.Error(err, "MessageReceipts should fail on message count mismatch")
+
+	rr.Messages[1].Index = 5
+	_, err = rr.MessageReceipts(msgs)
+	require.Error(err, "MessageReceipts should fail on index mismatch")
+}
@@ -5,7 +5,7 @@ import (
 	"time"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
-	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+	"github.com/oasisprotocol/oasis-core/go/common/debug"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 )
 
@@ -39,7 +39,7 @@ func (g *Genesis) SanityCheck() error {
 // SanityCheck performs a sanity check on the consensus parameters.
 func (p *ConsensusParameters) SanityCheck() error {
 	unsafeFlags := p.DebugDoNotSuspendRuntimes || p.DebugBypassStake
-	if unsafeFlags && !flags.DebugDontBlameOasis() {
+	if unsafeFlags && !debug.CapabilityEnabled(debug.CapabilityUnsafeGenesis) {
 		return fmt.Errorf("one or more unsafe debug flags set")
 	}
 	return nil
@@ -51,7 +51,8 @@ func (c *ConsensusParameterChanges) SanityCheck() error {
 		c.MaxRuntimeMessages == nil &&
 		c.MaxInRuntimeMessages == nil &&
 		c.MaxEvidenceAge == nil &&
-		c.MaxPastRootsStored == nil {
+		c.MaxPastRootsStored == nil &&
+		c.EnableRuntimeStatistics == nil {
 		return fmt.Errorf("consensus parameter changes should not be empty")
 	}
 	return nil
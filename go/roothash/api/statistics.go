@@ -0,0 +1,38 @@
+package api
+
+import "github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+
+// RuntimeStatistics are per-runtime execution statistics aggregated in consensus state.
+//
+// Statistics are only aggregated while the EnableRuntimeStatistics consensus parameter is set.
+type RuntimeStatistics struct {
+	// FinalizedRounds is the number of finalized normal rounds.
+	FinalizedRounds uint64 `json:"finalized_rounds,omitempty"`
+
+	// FailedRounds is the number of failed rounds.
+	FailedRounds uint64 `json:"failed_rounds,omitempty"`
+
+	// Discrepancies is the number of detected execution discrepancies.
+	Discrepancies uint64 `json:"discrepancies,omitempty"`
+
+	// TotalCommitLatency is the total number of consensus blocks between the previous runtime
+	// block and the finalization of each normal round.
+	TotalCommitLatency uint64 `json:"total_commit_latency,omitempty"`
+
+	// LastFaultyRound is the last round in which some nodes submitted commitments that did not
+	// match the finalized results.
+	LastFaultyRound uint64 `json:"last_faulty_round,omitempty"`
+
+	// LastFaultyNodes are the nodes that submitted commitments that did not match the finalized
+	// results in the last faulty round.
+	LastFaultyNodes []signature.PublicKey `json:"last_faulty_nodes,omitempty"`
+}
+
+// AverageCommitLatency returns the average number of consensus blocks between the previous
+// runtime block and the finalization of a normal round.
+func (s *RuntimeStatistics) AverageCommitLatency() float64 {
+	if s.FinalizedRounds == 0 {
+		return 0
+	}
+	return float64(s.TotalCommitLatency) / float64(s.FinalizedRounds)
+}
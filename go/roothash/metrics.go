@@ -1,15 +1,21 @@
 package roothash
 
 import (
+	"context"
 	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api"
 )
 
+const statisticsUpdateInterval = 60 * time.Second
+
 var (
 	rootHashFinalizedRounds = prometheus.NewCounter(
 		prometheus.CounterOpts{
@@ -29,9 +35,45 @@ var (
 		rootHashBlockInterval,
 	}
 
+	runtimeStatsFinalizedRounds = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "oasis_roothash_runtime_finalized_rounds",
+			Help: "Number of finalized normal runtime rounds, as aggregated in consensus state.",
+		},
+		[]string{"runtime"},
+	)
+	runtimeStatsFailedRounds = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "oasis_roothash_runtime_failed_rounds",
+			Help: "Number of failed runtime rounds, as aggregated in consensus state.",
+		},
+		[]string{"runtime"},
+	)
+	runtimeStatsDiscrepancies = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "oasis_roothash_runtime_discrepancies",
+			Help: "Number of detected runtime execution discrepancies, as aggregated in consensus state.",
+		},
+		[]string{"runtime"},
+	)
+	runtimeStatsCommitLatency = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "oasis_roothash_runtime_average_commit_latency",
+			Help: "Average number of consensus blocks needed to finalize a normal runtime round.",
+		},
+		[]string{"runtime"},
+	)
+	runtimeStatsCollectors = []prometheus.Collector{
+		runtimeStatsFinalizedRounds,
+		runtimeStatsFailedRounds,
+		runtimeStatsDiscrepancies,
+		runtimeStatsCommitLatency,
+	}
+
 	_ api.Backend = (*metricsWrapper)(nil)
 
-	metricsOnce sync.Once
+	metricsOnce      sync.Once
+	statsMetricsOnce sync.Once
 )
 
 type metricsWrapper struct {
@@ -76,3 +118,90 @@ func NewMetricsWrapper(base api.Backend) api.Backend {
 
 	return w
 }
+
+// StatisticsMetricsUpdater periodically exports the runtime statistics aggregated in consensus
+// state as metrics.
+type StatisticsMetricsUpdater struct {
+	logger *logging.Logger
+
+	backend  api.Backend
+	registry registry.Backend
+
+	closeOnce sync.Once
+	closeCh   chan struct{}
+	closedCh  chan struct{}
+}
+
+// Cleanup performs cleanup.
+func (m *StatisticsMetricsUpdater) Cleanup() {
+	m.closeOnce.Do(func() {
+		close(m.closeCh)
+		<-m.closedCh
+	})
+}
+
+func (m *StatisticsMetricsUpdater) worker(ctx context.Context) {
+	defer close(m.closedCh)
+
+	t := time.NewTicker(statisticsUpdateInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-m.closeCh:
+			return
+		case <-t.C:
+		}
+
+		m.updatePeriodicMetrics(ctx)
+	}
+}
+
+func (m *StatisticsMetricsUpdater) updatePeriodicMetrics(ctx context.Context) {
+	runtimes, err := m.registry.GetRuntimes(ctx, &registry.GetRuntimesQuery{Height: consensus.HeightLatest})
+	if err != nil {
+		m.logger.Warn("failed to query runtimes",
+			"err", err,
+		)
+		return
+	}
+
+	for _, rt := range runtimes {
+		stats, err := m.backend.GetRuntimeStatistics(ctx, &api.RuntimeRequest{
+			RuntimeID: rt.ID,
+			Height:    consensus.HeightLatest,
+		})
+		if err != nil {
+			m.logger.Warn("failed to query runtime statistics",
+				"err", err,
+				"runtime_id", rt.ID,
+			)
+			continue
+		}
+
+		labels := prometheus.Labels{"runtime": rt.ID.String()}
+		runtimeStatsFinalizedRounds.With(labels).Set(float64(stats.FinalizedRounds))
+		runtimeStatsFailedRounds.With(labels).Set(float64(stats.FailedRounds))
+		runtimeStatsDiscrepancies.With(labels).Set(float64(stats.Discrepancies))
+		runtimeStatsCommitLatency.With(labels).Set(stats.AverageCommitLatency())
+	}
+}
+
+// NewStatisticsMetricsUpdater creates a new runtime statistics metrics updater.
+func NewStatisticsMetricsUpdater(ctx context.Context, backend api.Backend, registry registry.Backend) *StatisticsMetricsUpdater {
+	statsMetricsOnce.Do(func() {
+		prometheus.MustRegister(runtimeStatsCollectors...)
+	})
+
+	m := &StatisticsMetricsUpdater{
+		logger:   logging.GetLogger("go/roothash/metrics"),
+		backend:  backend,
+		registry: registry,
+		closeCh:  make(chan struct{}),
+		closedCh: make(chan struct{}),
+	}
+
+	go m.worker(ctx)
+
+	return m
+}
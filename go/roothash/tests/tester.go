@@ -533,6 +533,23 @@ func (s *runtimeState) testSuccessfulRound(t *testing.T, backend api.Backend, co
 	require.EqualValues(liveRounds, livenessStatistics.LiveRounds, "there should be no live members")
 	require.EqualValues(finalizedProposals, livenessStatistics.FinalizedProposals, "there should be one finalized proposal")
 	require.EqualValues(missedProposals, livenessStatistics.MissedProposals, "there should be no failed proposals")
+
+	// The executor committee responsible for the round should be queryable.
+	round := parent.Block.Header.Round
+	committeesAt, err := consensus.Scheduler().GetCommitteesAt(ctx, &scheduler.GetCommitteesAtRequest{
+		RuntimeID: s.rt.Runtime.ID,
+		Height:    consensusAPI.HeightLatest,
+		Round:     &round,
+	})
+	require.NoError(err, "GetCommitteesAt")
+	require.EqualValues(parent.Height-1, committeesAt.Height, "committees should be at the height before finalization")
+	var executorCommittee *scheduler.Committee
+	for _, committee := range committeesAt.Committees {
+		if committee.Kind == scheduler.KindComputeExecutor {
+			executorCommittee = committee
+		}
+	}
+	require.EqualValues(s.executorCommittee.committee, executorCommittee, "executor committee should match")
 }
 
 func testRoundTimeout(t *testing.T, backend api.Backend, consensus consensusAPI.Backend, states []*runtimeState) {
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+)
+
+// LifecycleEventKind is the kind of a local runtime lifecycle event.
+type LifecycleEventKind uint8
+
+const (
+	// LifecycleEventBundleLoaded is emitted when a runtime bundle has been loaded.
+	LifecycleEventBundleLoaded LifecycleEventKind = 1
+	// LifecycleEventProvisioned is emitted when a runtime version has been provisioned.
+	LifecycleEventProvisioned LifecycleEventKind = 2
+	// LifecycleEventAttested is emitted when a runtime version running in a TEE has been
+	// re-attested.
+	LifecycleEventAttested LifecycleEventKind = 3
+	// LifecycleEventVersionActivated is emitted when a runtime version has become active.
+	LifecycleEventVersionActivated LifecycleEventKind = 4
+	// LifecycleEventSuspended is emitted when the runtime has been suspended.
+	LifecycleEventSuspended LifecycleEventKind = 5
+	// LifecycleEventResumed is emitted when a suspended runtime has been resumed.
+	LifecycleEventResumed LifecycleEventKind = 6
+	// LifecycleEventStopped is emitted when the runtime has stopped or failed to start.
+	LifecycleEventStopped LifecycleEventKind = 7
+	// LifecycleEventPaused is emitted when hosting of the runtime has been paused by the operator.
+	LifecycleEventPaused LifecycleEventKind = 8
+	// LifecycleEventUnpaused is emitted when hosting of a paused runtime has been resumed by the
+	// operator.
+	LifecycleEventUnpaused LifecycleEventKind = 9
+	// LifecycleEventRestarted is emitted when the hosted runtime has been restarted by the
+	// operator.
+	LifecycleEventRestarted LifecycleEventKind = 10
+	// LifecycleEventStarted is emitted when a runtime version has been started.
+	LifecycleEventStarted LifecycleEventKind = 11
+)
+
+var lifecycleEventKinds = []LifecycleEventKind{
+	LifecycleEventBundleLoaded,
+	LifecycleEventProvisioned,
+	LifecycleEventAttested,
+	LifecycleEventVersionActivated,
+	LifecycleEventSuspended,
+	LifecycleEventResumed,
+	LifecycleEventStopped,
+	LifecycleEventPaused,
+	LifecycleEventUnpaused,
+	LifecycleEventRestarted,
+	LifecycleEventStarted,
+}
+
+// String returns a string representation of a lifecycle event kind.
+func (k LifecycleEventKind) String() string {
+	switch k {
+	case LifecycleEventBundleLoaded:
+		return "bundle_loaded"
+	case LifecycleEventProvisioned:
+		return "provisioned"
+	case LifecycleEventAttested:
+		return "attested"
+	case LifecycleEventVersionActivated:
+		return "version_activated"
+	case LifecycleEventSuspended:
+		return "suspended"
+	case LifecycleEventResumed:
+		return "resumed"
+	case LifecycleEventStopped:
+		return "stopped"
+	case LifecycleEventPaused:
+		return "paused"
+	case LifecycleEventUnpaused:
+		return "unpaused"
+	case LifecycleEventRestarted:
+		return "restarted"
+	case LifecycleEventStarted:
+		return "started"
+	default:
+		return "[invalid lifecycle event kind]"
+	}
+}
+
+// MarshalText encodes a LifecycleEventKind into text form.
+func (k LifecycleEventKind) MarshalText() ([]byte, error) {
+	for _, kind := range lifecycleEventKinds {
+		if k == kind {
+			return []byte(k.String()), nil
+		}
+	}
+	return nil, fmt.Errorf("invalid LifecycleEventKind: %d", k)
+}
+
+// UnmarshalText decodes a text slice into a LifecycleEventKind.
+func (k *LifecycleEventKind) UnmarshalText(text []byte) error {
+	for _, kind := range lifecycleEventKinds {
+		if string(text) == kind.String() {
+			*k = kind
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid LifecycleEventKind: %s", string(text))
+}
+
+// LifecycleEvent is a local runtime lifecycle event.
+type LifecycleEvent struct {
+	// RuntimeID is the identifier of the runtime.
+	RuntimeID common.Namespace `json:"runtime_id"`
+	// Kind is the kind of the event.
+	Kind LifecycleEventKind `json:"kind"`
+	// Timestamp is the time when the event was emitted.
+	Timestamp time.Time `json:"timestamp"`
+
+	// Version is the runtime version the event refers to, if any.
+	Version *version.Version `json:"version,omitempty"`
+	// CapabilityTEE is the runtime's TEE capability for started and attested events of runtimes
+	// running in a TEE.
+	CapabilityTEE *node.CapabilityTEE `json:"capability_tee,omitempty"`
+	// Error is the error that caused the runtime to stop, if any.
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,28 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLifecycleEventKind(t *testing.T) {
+	require := require.New(t)
+
+	for _, kind := range lifecycleEventKinds {
+		text, err := kind.MarshalText()
+		require.NoError(err, "MarshalText")
+
+		var decKind LifecycleEventKind
+		err = decKind.UnmarshalText(text)
+		require.NoError(err, "UnmarshalText")
+		require.Equal(kind, decKind)
+	}
+
+	_, err := LifecycleEventKind(0).MarshalText()
+	require.Error(err, "MarshalText should fail for invalid kinds")
+
+	var kind LifecycleEventKind
+	err = kind.UnmarshalText([]byte("invalid"))
+	require.Error(err, "UnmarshalText should fail for invalid kinds")
+}
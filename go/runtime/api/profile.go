@@ -0,0 +1,18 @@
+package api
+
+import "time"
+
+// CallLatencyStats are the rolling latency statistics of calls into the hosted runtime.
+type CallLatencyStats struct {
+	// Count is the total number of calls.
+	Count uint64 `json:"count"`
+	// Failures is the total number of failed calls.
+	Failures uint64 `json:"failures"`
+
+	// P50 is the median latency over the most recent calls.
+	P50 time.Duration `json:"p50"`
+	// P95 is the 95th percentile latency over the most recent calls.
+	P95 time.Duration `json:"p95"`
+	// P99 is the 99th percentile latency over the most recent calls.
+	P99 time.Duration `json:"p99"`
+}
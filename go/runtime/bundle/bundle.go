@@ -14,7 +14,6 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/sgx"
 	"github.com/oasisprotocol/oasis-core/go/common/sgx/sigstruct"
-	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
 )
 
@@ -25,6 +24,23 @@ type Bundle struct {
 
 	// manifestHash is the original manifest hash of the bundle at time the bundle was loaded.
 	manifestHash hash.Hash
+
+	// debugDummySigner is true if the dummy MRSIGNER should be used for SGX components without
+	// a signature.
+	debugDummySigner bool
+}
+
+// OpenOption is a runtime bundle open option.
+type OpenOption func(bnd *Bundle)
+
+// WithDebugDummySigner configures whether the dummy enclave signer should be used as MRSIGNER
+// for SGX components that do not have a signature.
+//
+// This must only be enabled in tests.
+func WithDebugDummySigner(enabled bool) OpenOption {
+	return func(bnd *Bundle) {
+		bnd.debugDummySigner = enabled
+	}
 }
 
 // Validate validates the runtime bundle for well-formedness.
@@ -110,6 +126,18 @@ func (bnd *Bundle) Validate() error {
 	return nil
 }
 
+// ImageSize returns the total size of the bundle contents in bytes, excluding the manifest.
+func (bnd *Bundle) ImageSize() uint64 {
+	var size uint64
+	for fn, b := range bnd.Data {
+		if fn == manifestName {
+			continue
+		}
+		size += uint64(len(b))
+	}
+	return size
+}
+
 // Add adds/overwrites a file to/in the bundle.
 func (bnd *Bundle) Add(fn string, b []byte) error {
 	if filepath.Dir(fn) != "." {
@@ -129,6 +157,34 @@ func (bnd *Bundle) Add(fn string, b []byte) error {
 	return nil
 }
 
+// AddComponent adds a new component together with its files to the bundle.
+//
+// The files are keyed by the file names referenced by the component. After adding components the
+// bundle needs to be written out to regenerate the manifest.
+func (bnd *Bundle) AddComponent(comp *Component, files map[string][]byte) error {
+	if err := comp.Validate(); err != nil {
+		return fmt.Errorf("runtime/bundle: malformed component: %w", err)
+	}
+	if bnd.Manifest.GetComponentByID(comp.ID()) != nil {
+		return fmt.Errorf("runtime/bundle: component '%s' already exists", comp.ID())
+	}
+	for fn := range files {
+		if _, ok := bnd.Data[fn]; ok || fn == manifestName {
+			return fmt.Errorf("runtime/bundle: file '%s' already exists", fn)
+		}
+	}
+
+	for fn, b := range files {
+		if err := bnd.Add(fn, b); err != nil {
+			return err
+		}
+	}
+	bnd.Manifest.Components = append(bnd.Manifest.Components, comp)
+	bnd.ResetManifest()
+
+	return nil
+}
+
 // MrEnclave returns the MRENCLAVE of the SGX excutable.
 func (bnd *Bundle) MrEnclave(id component.ID) (*sgx.MrEnclave, error) {
 	comp := bnd.Manifest.GetComponentByID(id)
@@ -162,7 +218,7 @@ func (bnd *Bundle) MrSigner(id component.ID) (*sgx.MrSigner, error) {
 
 	var mrSigner sgx.MrSigner
 	switch {
-	case comp.SGX.Signature == "" && cmdFlags.DebugDontBlameOasis():
+	case comp.SGX.Signature == "" && bnd.debugDummySigner:
 		// Use dummy signer (only in tests).
 		mrSigner = sgx.FortanixDummyMrSigner
 	default:
@@ -217,6 +273,58 @@ func (bnd *Bundle) verifySgxSignature(comp *Component) error {
 	return nil
 }
 
+// SetSGXSignature replaces the SGX signature (SIGSTRUCT) of the given component, e.g., with one
+// produced during an offline signing ceremony.
+//
+// The signature must be valid and must match the MRENCLAVE of the component's SGX executable. Any
+// previous signature of the component is removed from the bundle. The serialized manifest is reset
+// so that it is regenerated on the next call to Write.
+func (bnd *Bundle) SetSGXSignature(id component.ID, sig []byte) error {
+	comp := bnd.Manifest.GetComponentByID(id)
+	if comp == nil {
+		return fmt.Errorf("runtime/bundle: component '%s' not available", id)
+	}
+	if comp.SGX == nil {
+		return fmt.Errorf("runtime/bundle: no SGX metadata for '%s'", id)
+	}
+
+	mrEnclave, err := bnd.MrEnclave(id)
+	if err != nil {
+		return err
+	}
+	_, sigStruct, err := sigstruct.Verify(sig)
+	if err != nil {
+		return fmt.Errorf("runtime/bundle: failed to verify sigstruct for '%s': %w", id, err)
+	}
+	if sigStruct.EnclaveHash != *mrEnclave {
+		return fmt.Errorf("runtime/bundle: sigstruct for '%s' does not match SGXS (got: %s expected: %s)", id, sigStruct.EnclaveHash, *mrEnclave)
+	}
+
+	// Remove the previous signature unless it is also referenced by another component.
+	if oldFn := comp.SGX.Signature; oldFn != "" {
+		var referenced bool
+		for otherID, other := range bnd.Manifest.GetAvailableComponents() {
+			if otherID != id && other.SGX != nil && other.SGX.Signature == oldFn {
+				referenced = true
+				break
+			}
+		}
+		if !referenced {
+			delete(bnd.Data, oldFn)
+			delete(bnd.Manifest.Digests, oldFn)
+		}
+	}
+
+	fn := comp.SGX.Executable + ".sig"
+	if err = bnd.Add(fn, sig); err != nil {
+		return err
+	}
+	comp.SGX.Signature = fn
+	bnd.ResetManifest()
+
+	return nil
+}
+
 // ResetManifest removes the serialized manifest from the bundle so that it can be regenerated on
 // the next call to Write.
 //
@@ -297,6 +405,18 @@ func DetachedExplodedPath(dataDir string) string {
 	return filepath.Join(ExplodedPath(dataDir), "detached")
 }
 
+// FetchedPath returns the path under the data directory that contains all of the runtime bundles
+// fetched from peers.
+func FetchedPath(dataDir string) string {
+	return filepath.Join(dataDir, "runtimes", "fetched")
+}
+
+// FetchedBundlePath returns the path that the runtime bundle with the given manifest hash is
+// written to when fetched from peers.
+func FetchedBundlePath(dataDir string, manifestHash hash.Hash) string {
+	return filepath.Join(FetchedPath(dataDir), manifestHash.String()+".orc")
+}
+
 // ExplodedPath returns the path that the corresponding asset will be written to via WriteExploded.
 func (bnd *Bundle) ExplodedPath(dataDir, fn string) string {
 	var subDir string
@@ -380,6 +500,32 @@ func (bnd *Bundle) WriteExploded(dataDir string) error {
 	return nil
 }
 
+// Extract writes all of the bundle contents, including the manifest, to the given directory.
+func (bnd *Bundle) Extract(dir string) error {
+	for _, v := range []string{
+		dir,
+		filepath.Join(dir, manifestPath),
+	} {
+		if err := os.MkdirAll(v, 0o700); err != nil {
+			return fmt.Errorf("runtime/bundle: failed to create directory '%s': %w", v, err)
+		}
+	}
+	for fn, data := range bnd.Data {
+		if err := os.WriteFile(filepath.Join(dir, fn), data, 0o600); err != nil {
+			return fmt.Errorf("runtime/bundle: failed to write '%s': %w", fn, err)
+		}
+	}
+	for id, comp := range bnd.Manifest.GetAvailableComponents() {
+		if comp.Executable == "" {
+			continue
+		}
+		if err := os.Chmod(filepath.Join(dir, comp.Executable), 0o700); err != nil {
+			return fmt.Errorf("runtime/bundle: failed to fixup executable permissions for '%s': %w", id, err)
+		}
+	}
+	return nil
+}
+
 // Close closes the bundle, releasing resources.
 func (bnd *Bundle) Close() error {
 	bnd.Manifest = nil
@@ -389,7 +535,7 @@ func (bnd *Bundle) Close() error {
 }
 
 // Open opens and validates a runtime bundle instance.
-func Open(fn string) (*Bundle, error) {
+func Open(fn string, opts ...OpenOption) (*Bundle, error) {
 	r, err := zip.OpenReader(fn)
 	if err != nil {
 		return nil, fmt.Errorf("runtime/bundle: failed to open bundle: %w", err)
@@ -447,6 +593,9 @@ func Open(fn string) (*Bundle, error) {
 		Data:         data,
 		manifestHash: manifest.Hash(),
 	}
+	for _, opt := range opts {
+		opt(bnd)
+	}
 	if err = bnd.Validate(); err != nil {
 		return nil, err
 	}
@@ -2,6 +2,8 @@ package bundle
 
 import (
 	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"os"
 	"path/filepath"
 	"testing"
@@ -9,6 +11,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx/sigstruct"
 	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
 )
 
@@ -71,6 +75,10 @@ func TestBundle(t *testing.T) {
 		bundle2, err := Open(bundleFn)
 		require.NoError(t, err, "Open")
 
+		// The image size should not include the manifest.
+		expectedSize := len(execBuf) + len(bundle.Data[manifest.Components[0].SGX.Executable])
+		require.EqualValues(t, expectedSize, bundle2.ImageSize(), "ImageSize")
+
 		// Ignore the manifest, the bundle we used to create the file
 		// will not have it.
 		delete(bundle2.Manifest.Digests, manifestName)
@@ -92,6 +100,110 @@ func TestBundle(t *testing.T) {
 		require.NoError(t, err, "bundle.Write")
 	})
 
+	t.Run("SetSGXSignature", func(t *testing.T) {
+		bundle2, err := Open(bundleFn)
+		require.NoError(t, err, "Open")
+
+		sgxs := bundle2.Data[manifest.Components[0].SGX.Executable]
+		sig := testSigstruct(t, sgxs, 1)
+		badSig := testSigstruct(t, []byte("not the enclave"), 1)
+
+		err = bundle2.SetSGXSignature(component.ID_RONL, badSig)
+		require.Error(t, err, "SetSGXSignature should fail with mismatched enclave hash")
+		err = bundle2.SetSGXSignature(component.ID{Kind: component.ROFL, Name: "foo"}, sig)
+		require.Error(t, err, "SetSGXSignature should fail with unknown component")
+
+		err = bundle2.SetSGXSignature(component.ID_RONL, sig)
+		require.NoError(t, err, "SetSGXSignature")
+		err = bundle2.Write(bundleFn + ".signed")
+		require.NoError(t, err, "bundle.Write")
+
+		bundle3, err := Open(bundleFn + ".signed")
+		require.NoError(t, err, "Open(signed)")
+		comp := bundle3.Manifest.GetComponentByID(component.ID_RONL)
+		require.Equal(t, sig, bundle3.Data[comp.SGX.Signature], "signature should be present in bundle")
+
+		// Replacing the signature again should remove the previous one.
+		sig2 := testSigstruct(t, sgxs, 2)
+		err = bundle3.SetSGXSignature(component.ID_RONL, sig2)
+		require.NoError(t, err, "SetSGXSignature(again)")
+		require.Equal(t, sig2, bundle3.Data[comp.SGX.Signature], "signature should be replaced")
+		require.Len(t, bundle3.Data, 3, "previous signature should be removed")
+	})
+
+	t.Run("Record", func(t *testing.T) {
+		bundle2, err := Open(bundleFn + ".signed")
+		require.NoError(t, err, "Open(signed)")
+
+		rec, err := bundle2.Record(bundleFn + ".signed")
+		require.NoError(t, err, "Record")
+		require.Equal(t, bundle2.Manifest.Hash(), rec.ManifestHash)
+		require.Equal(t, manifest.ID, rec.RuntimeID)
+		require.Equal(t, manifest.Name, rec.RuntimeName)
+		require.Equal(t, bundleFn+".signed", rec.Source)
+		require.Len(t, rec.Components, 1)
+		require.Equal(t, component.ID_RONL, rec.Components[0].ID)
+
+		identity, err := bundle2.EnclaveIdentity(component.ID_RONL)
+		require.NoError(t, err, "EnclaveIdentity")
+		require.Equal(t, identity, rec.Components[0].EnclaveIdentity)
+
+		// Enclave identities cannot be derived without a signature.
+		bundle2, err = Open(bundleFn)
+		require.NoError(t, err, "Open")
+		_, err = bundle2.Record(bundleFn)
+		require.Error(t, err, "Record should fail without an enclave signature")
+
+		// Unless the dummy signer is explicitly enabled.
+		bundle2, err = Open(bundleFn, WithDebugDummySigner(true))
+		require.NoError(t, err, "Open(WithDebugDummySigner)")
+		rec, err = bundle2.Record(bundleFn)
+		require.NoError(t, err, "Record")
+		require.Equal(t, sgx.FortanixDummyMrSigner, rec.Components[0].EnclaveIdentity.MrSigner)
+	})
+
+	t.Run("AddComponent_Extract", func(t *testing.T) {
+		bundle2, err := Open(bundleFn)
+		require.NoError(t, err, "Open")
+
+		roflComp := &Component{
+			Kind:       component.ROFL,
+			Name:       "test",
+			Executable: "rofl.bin",
+		}
+		err = bundle2.AddComponent(&Component{Kind: component.RONL, Executable: "ronl2.bin"}, map[string][]byte{
+			"ronl2.bin": execBuf,
+		})
+		require.Error(t, err, "AddComponent should fail with duplicate component")
+		err = bundle2.AddComponent(roflComp, map[string][]byte{
+			manifest.Components[0].Executable: execBuf,
+		})
+		require.Error(t, err, "AddComponent should fail with duplicate file")
+		err = bundle2.AddComponent(roflComp, map[string][]byte{
+			roflComp.Executable: execBuf,
+		})
+		require.NoError(t, err, "AddComponent")
+
+		err = bundle2.Write(bundleFn + ".rofl")
+		require.NoError(t, err, "bundle.Write")
+
+		bundle3, err := Open(bundleFn + ".rofl")
+		require.NoError(t, err, "Open(rofl)")
+		require.NotNil(t, bundle3.Manifest.GetComponentByID(roflComp.ID()), "added component should be present")
+
+		extractDir := filepath.Join(tmpDir, "extracted")
+		err = bundle3.Extract(extractDir)
+		require.NoError(t, err, "Extract")
+		for fn, expected := range bundle3.Data {
+			b, err := os.ReadFile(filepath.Join(extractDir, fn))
+			require.NoError(t, err, "ReadFile(%s)", fn)
+			require.Equal(t, expected, b, "extracted file '%s' should match", fn)
+		}
+		fi, err := os.Stat(filepath.Join(extractDir, roflComp.Executable))
+		require.NoError(t, err, "Stat")
+		require.EqualValues(t, 0o700, fi.Mode().Perm(), "executables should be executable")
+	})
+
 	t.Run("Explode", func(t *testing.T) {
 		err := bundle.WriteExploded(tmpDir)
 		require.NoError(t, err, "WriteExploded")
@@ -181,3 +293,73 @@ func TestDeatchedBundle(t *testing.T) {
 		require.NoError(t, err, "WriteExploded(again)")
 	})
 }
+
+func testSigstruct(t *testing.T, sgxs []byte, isvSVN uint16) []byte {
+	rawPEM, err := os.ReadFile("../../common/sgx/testdata/sig1.key.pem")
+	require.NoError(t, err, "ReadFile(signing key)")
+	blk, _ := pem.Decode(rawPEM)
+	privateKey, err := x509.ParsePKCS1PrivateKey(blk.Bytes)
+	require.NoError(t, err, "ParsePKCS1PrivateKey")
+
+	var enclaveHash sgx.MrEnclave
+	err = enclaveHash.FromSgxsBytes(sgxs)
+	require.NoError(t, err, "FromSgxsBytes")
+
+	sig, err := sigstruct.New(
+		sigstruct.WithEnclaveHash(enclaveHash),
+		sigstruct.WithISVSVN(isvSVN),
+	).Sign(privateKey)
+	require.NoError(t, err, "sigstruct.Sign")
+	return sig
+}
+
+func TestNewFromLegacyRuntime(t *testing.T) {
+	require := require.New(t)
+
+	execBuf, err := os.ReadFile(os.Args[0])
+	require.NoError(err, "ReadFile")
+	sgxs := make([]byte, 1024*256)
+	_, err = rand.Read(sgxs)
+	require.NoError(err, "rand.Read")
+	sig := testSigstruct(t, sgxs, 1)
+
+	var id common.Namespace
+	err = id.UnmarshalHex("c000000000000000ffffffffffffffffffffffffffffffffffffffffffffffff")
+	require.NoError(err, "UnmarshalHex")
+
+	_, err = NewFromLegacyRuntime(&LegacyRuntime{ID: id})
+	require.Error(err, "NewFromLegacyRuntime should fail without an executable")
+	_, err = NewFromLegacyRuntime(&LegacyRuntime{ID: id, Executable: execBuf, SGXSignature: sig})
+	require.Error(err, "NewFromLegacyRuntime should fail with a signature but no SGX executable")
+	_, err = NewFromLegacyRuntime(&LegacyRuntime{
+		ID:            id,
+		Executable:    execBuf,
+		SGXExecutable: sgxs,
+		SGXSignature:  testSigstruct(t, []byte("not the enclave"), 1),
+	})
+	require.Error(err, "NewFromLegacyRuntime should fail with a mismatched signature")
+
+	bnd, err := NewFromLegacyRuntime(&LegacyRuntime{
+		ID:            id,
+		Name:          "legacy-runtime",
+		Executable:    execBuf,
+		SGXExecutable: sgxs,
+		SGXSignature:  sig,
+	})
+	require.NoError(err, "NewFromLegacyRuntime")
+	require.False(bnd.Manifest.IsLegacy(), "migrated manifest should not be legacy")
+	require.False(bnd.Manifest.IsDetached(), "migrated manifest should include the RONL component")
+
+	bundleFn := filepath.Join(t.TempDir(), "legacy.orc")
+	err = bnd.Write(bundleFn)
+	require.NoError(err, "Write")
+
+	bnd2, err := Open(bundleFn)
+	require.NoError(err, "Open")
+	require.Equal("legacy-runtime", bnd2.Manifest.Name)
+	comp := bnd2.Manifest.GetComponentByID(component.ID_RONL)
+	require.NotNil(comp, "RONL component should exist")
+	require.Equal(execBuf, bnd2.Data[comp.Executable], "ELF executable should match")
+	require.Equal(sgxs, bnd2.Data[comp.SGX.Executable], "SGX executable should match")
+	require.Equal(sig, bnd2.Data[comp.SGX.Signature], "SGX signature should match")
+}
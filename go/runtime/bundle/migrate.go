@@ -0,0 +1,83 @@
+package bundle
+
+import (
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
+)
+
+const (
+	legacyExecName    = "runtime.elf"
+	legacySGXExecName = "runtime.sgxs"
+	legacySGXSigName  = legacySGXExecName + ".sig"
+)
+
+// LegacyRuntime is a runtime configured using separate runtime binaries and an optional SGX
+// signature (SIGSTRUCT) instead of a runtime bundle.
+type LegacyRuntime struct {
+	// ID is the runtime ID.
+	ID common.Namespace
+	// Name is the optional human readable runtime name.
+	Name string
+	// Version is the runtime version.
+	Version version.Version
+
+	// Executable is the runtime ELF executable.
+	Executable []byte
+	// SGXExecutable is the optional runtime SGX enclave executable (SGXS).
+	SGXExecutable []byte
+	// SGXSignature is the optional SGX signature (SIGSTRUCT) of the enclave.
+	SGXSignature []byte
+}
+
+// NewFromLegacyRuntime creates a new runtime bundle with a RONL component containing the given
+// legacy runtime binaries.
+//
+// The resulting bundle is validated, so in case an SGX signature is given it must match the
+// SGX executable.
+func NewFromLegacyRuntime(rt *LegacyRuntime) (*Bundle, error) {
+	if len(rt.Executable) == 0 {
+		return nil, fmt.Errorf("runtime/bundle: missing runtime ELF executable")
+	}
+	if len(rt.SGXSignature) > 0 && len(rt.SGXExecutable) == 0 {
+		return nil, fmt.Errorf("runtime/bundle: SGX signature given without SGX executable")
+	}
+
+	comp := &Component{
+		Kind:       component.RONL,
+		Executable: legacyExecName,
+	}
+	bnd := &Bundle{
+		Manifest: &Manifest{
+			Name:       rt.Name,
+			ID:         rt.ID,
+			Version:    rt.Version,
+			Components: []*Component{comp},
+		},
+	}
+
+	if err := bnd.Add(legacyExecName, rt.Executable); err != nil {
+		return nil, err
+	}
+	if len(rt.SGXExecutable) > 0 {
+		comp.SGX = &SGXMetadata{
+			Executable: legacySGXExecName,
+		}
+		if err := bnd.Add(legacySGXExecName, rt.SGXExecutable); err != nil {
+			return nil, err
+		}
+	}
+	if len(rt.SGXSignature) > 0 {
+		comp.SGX.Signature = legacySGXSigName
+		if err := bnd.Add(legacySGXSigName, rt.SGXSignature); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := bnd.Validate(); err != nil {
+		return nil, err
+	}
+	return bnd, nil
+}
@@ -0,0 +1,79 @@
+package bundle
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
+)
+
+// Record is a record of a loaded runtime bundle, identifying exactly which code is being run.
+type Record struct {
+	// ManifestHash is the hash of the bundle manifest.
+	ManifestHash hash.Hash `json:"manifest_hash"`
+
+	// RuntimeID is the runtime ID.
+	RuntimeID common.Namespace `json:"runtime_id"`
+
+	// RuntimeName is the optional human readable runtime name.
+	RuntimeName string `json:"runtime_name,omitempty"`
+
+	// Version is the runtime version.
+	Version version.Version `json:"version"`
+
+	// Source is the path the bundle was loaded from.
+	Source string `json:"source"`
+
+	// Components are the records of the available bundle components.
+	Components []*ComponentRecord `json:"components"`
+}
+
+// ComponentRecord is a record of a runtime bundle component.
+type ComponentRecord struct {
+	// ID is the component identifier.
+	ID component.ID `json:"id"`
+
+	// EnclaveIdentity is the SGX enclave identity of the component if any.
+	EnclaveIdentity *sgx.EnclaveIdentity `json:"enclave_identity,omitempty"`
+}
+
+// Record creates a record of the bundle loaded from the given source path.
+//
+// The bundle data must still be available as it is needed to derive the enclave identities.
+func (bnd *Bundle) Record(source string) (*Record, error) {
+	comps := bnd.Manifest.GetAvailableComponents()
+	ids := make([]component.ID, 0, len(comps))
+	for id := range comps {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].String() < ids[j].String()
+	})
+
+	rec := &Record{
+		ManifestHash: bnd.manifestHash,
+		RuntimeID:    bnd.Manifest.ID,
+		RuntimeName:  bnd.Manifest.Name,
+		Version:      bnd.Manifest.Version,
+		Source:       source,
+		Components:   make([]*ComponentRecord, 0, len(ids)),
+	}
+	for _, id := range ids {
+		compRec := &ComponentRecord{
+			ID: id,
+		}
+		if comps[id].SGX != nil {
+			var err error
+			if compRec.EnclaveIdentity, err = bnd.EnclaveIdentity(id); err != nil {
+				return nil, fmt.Errorf("runtime/bundle: failed to derive enclave identity for '%s': %w", id, err)
+			}
+		}
+		rec.Components = append(rec.Components, compRec)
+	}
+
+	return rec, nil
+}
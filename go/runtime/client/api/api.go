@@ -1,7 +1,9 @@
 package api
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
@@ -11,6 +13,8 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
 	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+	"github.com/oasisprotocol/oasis-core/go/runtime/transaction"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
 )
 
 const (
@@ -19,6 +23,9 @@ const (
 
 	// RoundLatest is a special round number always referring to the latest round.
 	RoundLatest = roothash.RoundLatest
+
+	// MaxBatchQuerySize is the maximum number of queries in a single batch query.
+	MaxBatchQuerySize = 64
 )
 
 var (
@@ -30,11 +37,13 @@ var (
 	ErrTransactionExpired = errors.New(ModuleName, 3, "client: transaction expired")
 	// ErrNotSynced is an error returned if transaction is submitted before node has finished
 	// initial syncing.
-	ErrNotSynced = errors.New(ModuleName, 4, "client: not finished initial sync")
+	ErrNotSynced = errors.NewRetriable(ModuleName, 4, "client: not finished initial sync")
 	// ErrCheckTxFailed is an error returned if the local transaction check fails.
 	ErrCheckTxFailed = errors.New(ModuleName, 5, "client: transaction check failed")
 	// ErrNoHostedRuntime is returned when the hosted runtime is not available locally.
-	ErrNoHostedRuntime = errors.New(ModuleName, 6, "client: no hosted runtime is available")
+	ErrNoHostedRuntime = errors.NewRetriable(ModuleName, 6, "client: no hosted runtime is available")
+	// ErrInvalidArgument is returned when the request contains invalid arguments.
+	ErrInvalidArgument = errors.New(ModuleName, 7, "client: invalid argument")
 )
 
 // RuntimeClient is the runtime client interface.
@@ -73,18 +82,43 @@ type RuntimeClient interface {
 	// its results (outputs and emitted events).
 	GetTransactionsWithResults(ctx context.Context, request *GetTransactionsRequest) ([]*TransactionWithResults, error)
 
+	// GetTransactionReceipt fetches the receipt of a runtime transaction executed in a given
+	// block, including a proof of its inclusion in the block's I/O root.
+	GetTransactionReceipt(ctx context.Context, request *GetTransactionReceiptRequest) (*TransactionReceipt, error)
+
 	// GetUnconfirmedTransactions fetches all unconfirmed runtime transactions
 	// that are currently pending to be included in a block.
 	GetUnconfirmedTransactions(ctx context.Context, runtimeID common.Namespace) ([][]byte, error)
 
+	// EstimateFinality estimates when a runtime transaction that is submitted now is likely to
+	// be finalized, based on the current round cadence, transaction pool depth and scheduler
+	// parameters.
+	EstimateFinality(ctx context.Context, runtimeID common.Namespace) (*roothash.FinalityEstimate, error)
+
 	// GetEvents returns all events emitted in a given block.
 	GetEvents(ctx context.Context, request *GetEventsRequest) ([]*Event, error)
 
 	// Query makes a runtime-specific query.
 	Query(ctx context.Context, request *QueryRequest) (*QueryResponse, error)
 
+	// BatchQuery makes multiple runtime-specific queries against the same runtime instance and
+	// round, reducing the number of round-trips needed for independent queries.
+	BatchQuery(ctx context.Context, request *BatchQueryRequest) (*BatchQueryResponse, error)
+
 	// WatchBlocks subscribes to blocks for a specific runtimes.
 	WatchBlocks(ctx context.Context, runtimeID common.Namespace) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error)
+
+	// WatchEvents subscribes to runtime events matching the given filter, emitted in finalized
+	// blocks of a specific runtime, optionally starting with a given round.
+	WatchEvents(ctx context.Context, request *WatchEventsRequest) (<-chan *WatchedEvent, pubsub.ClosableSubscription, error)
+
+	// ReplayBlocks replays the blocks (and optionally the events) in the given range of rounds
+	// from the local runtime history.
+	//
+	// Blocks are only read from history as fast as they are consumed. The returned channel is
+	// closed after the last block in the range has been replayed, or earlier in case of an
+	// error, so consumers should check that the last replayed round matches the requested one.
+	ReplayBlocks(ctx context.Context, request *ReplayBlocksRequest) (<-chan *ReplayedBlock, pubsub.ClosableSubscription, error)
 }
 
 // SubmitTxResult is the raw result of submitting a transaction for processing.
@@ -107,6 +141,9 @@ type SubmitTxMetaResponse struct {
 	Round uint64 `json:"round,omitempty"`
 	// BatchOrder is the order of the transaction in the execution batch.
 	BatchOrder uint32 `json:"batch_order,omitempty"`
+	// FinalityEstimate is the finality estimate made at the time the transaction was accepted
+	// into the transaction pool.
+	FinalityEstimate *roothash.FinalityEstimate `json:"finality_estimate,omitempty"`
 
 	// CheckTxError is the CheckTx error in case transaction failed the transaction check.
 	CheckTxError *protocol.Error `json:"check_tx_error,omitempty"`
@@ -137,6 +174,46 @@ type TransactionWithResults struct {
 	Events []*PlainEvent `json:"events,omitempty"`
 }
 
+// GetTransactionReceiptRequest is a GetTransactionReceipt request.
+type GetTransactionReceiptRequest struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+	Round     uint64           `json:"round"`
+	TxHash    hash.Hash        `json:"tx_hash"`
+}
+
+// TransactionReceipt is a receipt of runtime transaction execution.
+type TransactionReceipt struct {
+	// Round is the roothash round in which the transaction was executed.
+	Round uint64 `json:"round"`
+	// BatchOrder is the order of the transaction in the execution batch.
+	BatchOrder uint32 `json:"batch_order"`
+	// Result is the raw transaction result.
+	Result []byte `json:"result"`
+	// Proof is the proof of inclusion of the transaction artifacts in the I/O root of the
+	// runtime block.
+	Proof syncer.Proof `json:"proof"`
+}
+
+// Verify verifies the transaction receipt against the header of the runtime block in which the
+// transaction with the given hash was executed.
+func (r *TransactionReceipt) Verify(ctx context.Context, header *block.Header, txHash hash.Hash) error {
+	if r.Round != header.Round {
+		return fmt.Errorf("client: receipt for unexpected round (expected: %d got: %d)", header.Round, r.Round)
+	}
+
+	tx, err := transaction.VerifyTransactionProof(ctx, header.IORoot, txHash, &r.Proof)
+	if err != nil {
+		return fmt.Errorf("client: bad receipt proof: %w", err)
+	}
+	if tx.BatchOrder != r.BatchOrder {
+		return fmt.Errorf("client: receipt batch order does not match proof")
+	}
+	if !bytes.Equal(tx.Output, r.Result) {
+		return fmt.Errorf("client: receipt result does not match proof")
+	}
+	return nil
+}
+
 // GetEventsRequest is a GetEvents request.
 type GetEventsRequest struct {
 	RuntimeID common.Namespace `json:"runtime_id"`
@@ -161,6 +238,104 @@ type PlainEvent struct {
 	Value []byte `json:"value"`
 }
 
+// WatchEventsRequest is a WatchEvents request.
+type WatchEventsRequest struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+	// Round is the first round to stream events for. If not set, events are streamed starting
+	// with the next finalized round.
+	//
+	// Clients resuming a subscription should use the round following the last fully processed
+	// round.
+	Round *uint64 `json:"round,omitempty"`
+	// After is the cursor of the last event received by the client. If set, events are streamed
+	// starting with the event immediately following the cursor and Round must not be set.
+	After *EventCursor `json:"after,omitempty"`
+	// KeyPrefixes are the prefixes of the keys of the events that should be streamed. If empty,
+	// all events are streamed.
+	KeyPrefixes [][]byte `json:"key_prefixes,omitempty"`
+}
+
+// SanityCheck performs a basic sanity check on the WatchEvents request.
+func (r *WatchEventsRequest) SanityCheck() error {
+	if r.After != nil && r.Round != nil {
+		return fmt.Errorf("%w: round and cursor are mutually exclusive", ErrInvalidArgument)
+	}
+	return nil
+}
+
+// StartCursor returns the cursor of the first event that should be streamed, if any.
+func (r *WatchEventsRequest) StartCursor() (EventCursor, bool) {
+	switch {
+	case r.After != nil:
+		return EventCursor{Round: r.After.Round, Index: r.After.Index + 1}, true
+	case r.Round != nil:
+		return EventCursor{Round: *r.Round}, true
+	default:
+		return EventCursor{}, false
+	}
+}
+
+// Matches returns true iff the given event matches the request's filter.
+func (r *WatchEventsRequest) Matches(ev *Event) bool {
+	if len(r.KeyPrefixes) == 0 {
+		return true
+	}
+	for _, prefix := range r.KeyPrefixes {
+		if bytes.HasPrefix(ev.Key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// EventCursor is the position of a runtime event in the event stream.
+type EventCursor struct {
+	// Round is the round of the block in which the event was emitted.
+	Round uint64 `json:"round"`
+	// Index is the index of the event among all events emitted in the block.
+	Index uint32 `json:"index"`
+}
+
+// String returns a string representation of the event cursor.
+func (c EventCursor) String() string {
+	return fmt.Sprintf("%d:%d", c.Round, c.Index)
+}
+
+// WatchedEvent is an event returned by WatchEvents.
+type WatchedEvent struct {
+	// Cursor is the position of the event in the event stream. It can be used to resume watching
+	// events after a disconnect without duplicates or gaps.
+	Cursor EventCursor `json:"cursor"`
+	// Event is the event.
+	Event *Event `json:"event"`
+	// Error is the error that terminated the subscription. If set, this is the last item in the
+	// stream and the remaining fields are not set.
+	Error error `json:"-"`
+}
+
+// ReplayBlocksRequest is a ReplayBlocks request.
+type ReplayBlocksRequest struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+	// FromRound is the first round to replay.
+	FromRound uint64 `json:"from_round"`
+	// ToRound is the last round to replay (inclusive).
+	ToRound uint64 `json:"to_round"`
+	// IncludeEvents specifies whether the events emitted in each block should be replayed. This
+	// requires the I/O state of all replayed rounds to be available locally.
+	IncludeEvents bool `json:"include_events,omitempty"`
+}
+
+// ReplayedBlock is a block replayed from the local runtime history.
+type ReplayedBlock struct {
+	// Block is the annotated runtime block.
+	Block *roothash.AnnotatedBlock `json:"block"`
+	// Events are the events emitted in the block (if requested).
+	Events []*Event `json:"events,omitempty"`
+	// Error is the error that terminated the replay. If set, this is the last item in the stream
+	// and the remaining fields are not set.
+	Error error `json:"-"`
+}
+
 // QueryRequest is a Query request.
 type QueryRequest struct {
 	RuntimeID common.Namespace `json:"runtime_id"`
@@ -175,3 +350,36 @@ type QueryRequest struct {
 type QueryResponse struct {
 	Data []byte `json:"data"`
 }
+
+// BatchQueryRequest is a BatchQuery request.
+type BatchQueryRequest struct {
+	RuntimeID common.Namespace `json:"runtime_id"`
+	Component *component.ID    `json:"component,omitempty"`
+
+	// Round is the round against which all of the queries are executed.
+	Round uint64 `json:"round"`
+	// Queries are the queries to execute.
+	Queries []*BatchQueryItem `json:"queries"`
+}
+
+// BatchQueryItem is a single query in a batch query.
+type BatchQueryItem struct {
+	Method string `json:"method"`
+	Args   []byte `json:"args"`
+}
+
+// BatchQueryResponse is a response to the runtime batch query.
+type BatchQueryResponse struct {
+	// Round is the round against which the queries were executed.
+	Round uint64 `json:"round"`
+	// Results are the query results in the same order as the queries in the request.
+	Results []*BatchQueryResult `json:"results"`
+}
+
+// BatchQueryResult is the result of a single query in a batch query.
+type BatchQueryResult struct {
+	// Data is the query response in case the query succeeded.
+	Data []byte `json:"data,omitempty"`
+	// Error is the query error in case the query failed.
+	Error *protocol.Error `json:"error,omitempty"`
+}
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchEventsRequest(t *testing.T) {
+	require := require.New(t)
+
+	var req WatchEventsRequest
+	require.NoError(req.SanityCheck(), "empty request should be valid")
+	_, ok := req.StartCursor()
+	require.False(ok, "empty request should not have a start cursor")
+
+	round := uint64(10)
+	req = WatchEventsRequest{Round: &round}
+	require.NoError(req.SanityCheck())
+	cursor, ok := req.StartCursor()
+	require.True(ok)
+	require.Equal(EventCursor{Round: 10}, cursor)
+
+	req = WatchEventsRequest{After: &EventCursor{Round: 10, Index: 3}}
+	require.NoError(req.SanityCheck())
+	cursor, ok = req.StartCursor()
+	require.True(ok)
+	require.Equal(EventCursor{Round: 10, Index: 4}, cursor, "stream should resume after the cursor")
+
+	req = WatchEventsRequest{Round: &round, After: &EventCursor{Round: 10}}
+	require.ErrorIs(req.SanityCheck(), ErrInvalidArgument, "round and cursor should be mutually exclusive")
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"io"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -37,15 +38,25 @@ var (
 	methodGetTransactions = serviceName.NewMethod("GetTransactions", GetTransactionsRequest{})
 	// methodGetTransactionsWithResults is the GetTransactionsWithResults method.
 	methodGetTransactionsWithResults = serviceName.NewMethod("GetTransactionsWithResults", GetTransactionsRequest{})
+	// methodGetTransactionReceipt is the GetTransactionReceipt method.
+	methodGetTransactionReceipt = serviceName.NewMethod("GetTransactionReceipt", GetTransactionReceiptRequest{})
 	// methodGetUnconfirmedTransactions is the GetUnconfirmedTransactions method.
 	methodGetUnconfirmedTransactions = serviceName.NewMethod("GetUnconfirmedTransactions", common.Namespace{})
+	// methodEstimateFinality is the EstimateFinality method.
+	methodEstimateFinality = serviceName.NewMethod("EstimateFinality", common.Namespace{})
 	// methodGetEvents is the GetEvents method.
 	methodGetEvents = serviceName.NewMethod("GetEvents", GetEventsRequest{})
 	// methodQuery is the Query method.
 	methodQuery = serviceName.NewMethod("Query", QueryRequest{})
+	// methodBatchQuery is the BatchQuery method.
+	methodBatchQuery = serviceName.NewMethod("BatchQuery", BatchQueryRequest{})
 
 	// methodWatchBlocks is the WatchBlocks method.
 	methodWatchBlocks = serviceName.NewMethod("WatchBlocks", common.Namespace{})
+	// methodWatchEvents is the WatchEvents method.
+	methodWatchEvents = serviceName.NewMethod("WatchEvents", WatchEventsRequest{})
+	// methodReplayBlocks is the ReplayBlocks method.
+	methodReplayBlocks = serviceName.NewMethod("ReplayBlocks", ReplayBlocksRequest{})
 
 	// serviceDesc is the gRPC service descriptor.
 	serviceDesc = grpc.ServiceDesc{
@@ -88,10 +99,18 @@ var (
 				MethodName: methodGetTransactionsWithResults.ShortName(),
 				Handler:    handlerGetTransactionsWithResults,
 			},
+			{
+				MethodName: methodGetTransactionReceipt.ShortName(),
+				Handler:    handlerGetTransactionReceipt,
+			},
 			{
 				MethodName: methodGetUnconfirmedTransactions.ShortName(),
 				Handler:    handlerGetUnconfirmedTransactions,
 			},
+			{
+				MethodName: methodEstimateFinality.ShortName(),
+				Handler:    handlerEstimateFinality,
+			},
 			{
 				MethodName: methodGetEvents.ShortName(),
 				Handler:    handlerGetEvents,
@@ -100,6 +119,10 @@ var (
 				MethodName: methodQuery.ShortName(),
 				Handler:    handlerQuery,
 			},
+			{
+				MethodName: methodBatchQuery.ShortName(),
+				Handler:    handlerBatchQuery,
+			},
 		},
 		Streams: []grpc.StreamDesc{
 			{
@@ -107,6 +130,16 @@ var (
 				Handler:       handlerWatchBlocks,
 				ServerStreams: true,
 			},
+			{
+				StreamName:    methodReplayBlocks.ShortName(),
+				Handler:       handlerReplayBlocks,
+				ServerStreams: true,
+			},
+			{
+				StreamName:    methodWatchEvents.ShortName(),
+				Handler:       handlerWatchEvents,
+				ServerStreams: true,
+			},
 		},
 	}
 )
@@ -351,6 +384,29 @@ func handlerGetTransactionsWithResults(
 	return interceptor(ctx, &rq, info, handler)
 }
 
+func handlerGetTransactionReceipt(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rq GetTransactionReceiptRequest
+	if err := dec(&rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeClient).GetTransactionReceipt(ctx, &rq)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetTransactionReceipt.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeClient).GetTransactionReceipt(ctx, req.(*GetTransactionReceiptRequest))
+	}
+	return interceptor(ctx, &rq, info, handler)
+}
+
 func handlerGetUnconfirmedTransactions(
 	srv interface{},
 	ctx context.Context,
@@ -374,6 +430,29 @@ func handlerGetUnconfirmedTransactions(
 	return interceptor(ctx, runtimeID, info, handler)
 }
 
+func handlerEstimateFinality(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var runtimeID common.Namespace
+	if err := dec(&runtimeID); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RuntimeClient).EstimateFinality(ctx, runtimeID)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodEstimateFinality.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RuntimeClient).EstimateFinality(ctx, req.(common.Namespace))
+	}
+	return interceptor(ctx, runtimeID, info, handler)
+}
+
 func handlerGetEvents(
 	srv interface{},
 	ctx context.Context,
@@ -422,6 +501,31 @@ func handlerQuery( // nolint: revive
 	return interceptor(ctx, &rq, info, handler)
 }
 
+func handlerBatchQuery( // nolint: revive
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var rq BatchQueryRequest
+	if err := dec(&rq); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		rsp, err := srv.(RuntimeClient).BatchQuery(ctx, &rq)
+		return rsp, errorWrapNotFound(err)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodBatchQuery.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		rsp, err := srv.(RuntimeClient).BatchQuery(ctx, req.(*BatchQueryRequest))
+		return rsp, errorWrapNotFound(err)
+	}
+	return interceptor(ctx, &rq, info, handler)
+}
+
 func handlerWatchBlocks(srv interface{}, stream grpc.ServerStream) error {
 	var runtimeID common.Namespace
 	if err := stream.RecvMsg(&runtimeID); err != nil {
@@ -451,6 +555,70 @@ func handlerWatchBlocks(srv interface{}, stream grpc.ServerStream) error {
 	}
 }
 
+func handlerReplayBlocks(srv interface{}, stream grpc.ServerStream) error {
+	var rq ReplayBlocksRequest
+	if err := stream.RecvMsg(&rq); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	ch, sub, err := srv.(RuntimeClient).ReplayBlocks(ctx, &rq)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case blk, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			if blk.Error != nil {
+				return blk.Error
+			}
+
+			if err := stream.SendMsg(blk); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func handlerWatchEvents(srv interface{}, stream grpc.ServerStream) error {
+	var rq WatchEventsRequest
+	if err := stream.RecvMsg(&rq); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	ch, sub, err := srv.(RuntimeClient).WatchEvents(ctx, &rq)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	for {
+		select {
+		case ev, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			if ev.Error != nil {
+				return ev.Error
+			}
+
+			if err := stream.SendMsg(ev); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
 // RegisterService registers a new runtime client service with the given gRPC server.
 func RegisterService(server *grpc.Server, service RuntimeClient) {
 	server.RegisterService(&serviceDesc, service)
@@ -524,6 +692,14 @@ func (c *runtimeClient) GetTransactionsWithResults(ctx context.Context, request
 	return rsp, nil
 }
 
+func (c *runtimeClient) GetTransactionReceipt(ctx context.Context, request *GetTransactionReceiptRequest) (*TransactionReceipt, error) {
+	var rsp TransactionReceipt
+	if err := c.conn.Invoke(ctx, methodGetTransactionReceipt.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *runtimeClient) GetUnconfirmedTransactions(ctx context.Context, runtimeID common.Namespace) ([][]byte, error) {
 	var rsp [][]byte
 	if err := c.conn.Invoke(ctx, methodGetUnconfirmedTransactions.FullName(), runtimeID, &rsp); err != nil {
@@ -532,6 +708,14 @@ func (c *runtimeClient) GetUnconfirmedTransactions(ctx context.Context, runtimeI
 	return rsp, nil
 }
 
+func (c *runtimeClient) EstimateFinality(ctx context.Context, runtimeID common.Namespace) (*roothash.FinalityEstimate, error) {
+	var rsp roothash.FinalityEstimate
+	if err := c.conn.Invoke(ctx, methodEstimateFinality.FullName(), runtimeID, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *runtimeClient) GetEvents(ctx context.Context, request *GetEventsRequest) ([]*Event, error) {
 	var rsp []*Event
 	if err := c.conn.Invoke(ctx, methodGetEvents.FullName(), request, &rsp); err != nil {
@@ -548,6 +732,14 @@ func (c *runtimeClient) Query(ctx context.Context, request *QueryRequest) (*Quer
 	return &rsp, nil
 }
 
+func (c *runtimeClient) BatchQuery(ctx context.Context, request *BatchQueryRequest) (*BatchQueryResponse, error) {
+	var rsp BatchQueryResponse
+	if err := c.conn.Invoke(ctx, methodBatchQuery.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *runtimeClient) WatchBlocks(ctx context.Context, runtimeID common.Namespace) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error) {
 	ctx, sub := pubsub.NewContextSubscription(ctx)
 
@@ -583,6 +775,112 @@ func (c *runtimeClient) WatchBlocks(ctx context.Context, runtimeID common.Namesp
 	return ch, sub, nil
 }
 
+func (c *runtimeClient) WatchEvents(ctx context.Context, request *WatchEventsRequest) (<-chan *WatchedEvent, pubsub.ClosableSubscription, error) {
+	if err := request.SanityCheck(); err != nil {
+		return nil, nil, err
+	}
+
+	ctx, sub := pubsub.NewContextSubscription(ctx)
+
+	stream, err := c.conn.NewStream(ctx, &serviceDesc.Streams[2], methodWatchEvents.FullName())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = stream.SendMsg(request); err != nil {
+		return nil, nil, err
+	}
+	if err = stream.CloseSend(); err != nil {
+		return nil, nil, err
+	}
+
+	ch := make(chan *WatchedEvent)
+	go func() {
+		defer close(ch)
+
+		for {
+			var ev WatchedEvent
+			switch serr := stream.RecvMsg(&ev); serr {
+			case nil:
+			case io.EOF:
+				return
+			default:
+				if ctx.Err() != nil {
+					return
+				}
+				// Propagate the error that terminated the subscription to the caller.
+				ev = WatchedEvent{Error: serr}
+			}
+
+			select {
+			case ch <- &ev:
+			case <-ctx.Done():
+				return
+			}
+			if ev.Error != nil {
+				return
+			}
+		}
+	}()
+
+	return ch, sub, nil
+}
+
+func (c *runtimeClient) ReplayBlocks(ctx context.Context, request *ReplayBlocksRequest) (<-chan *ReplayedBlock, pubsub.ClosableSubscription, error) {
+	ctx, sub := pubsub.NewContextSubscription(ctx)
+
+	stream, err := c.conn.NewStream(ctx, &serviceDesc.Streams[1], methodReplayBlocks.FullName())
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = stream.SendMsg(request); err != nil {
+		return nil, nil, err
+	}
+	if err = stream.CloseSend(); err != nil {
+		return nil, nil, err
+	}
+
+	// Receive the first block synchronously so that request validation errors are propagated.
+	var first ReplayedBlock
+	if err = stream.RecvMsg(&first); err != nil {
+		sub.Close()
+		return nil, nil, err
+	}
+
+	ch := make(chan *ReplayedBlock)
+	go func() {
+		defer close(ch)
+
+		blk := &first
+		for {
+			select {
+			case ch <- blk:
+			case <-ctx.Done():
+				return
+			}
+
+			blk = new(ReplayedBlock)
+			switch serr := stream.RecvMsg(blk); serr {
+			case nil:
+			case io.EOF:
+				return
+			default:
+				if ctx.Err() != nil {
+					return
+				}
+				// Propagate the error that terminated the replay to the caller.
+				blk = &ReplayedBlock{Error: serr}
+				select {
+				case ch <- blk:
+				case <-ctx.Done():
+				}
+				return
+			}
+		}
+	}()
+
+	return ch, sub, nil
+}
+
 // NewRuntimeClient creates a new gRPC runtime client service.
 func NewRuntimeClient(c *grpc.ClientConn) RuntimeClient {
 	return &runtimeClient{
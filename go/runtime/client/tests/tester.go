@@ -11,6 +11,7 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/runtime/client/api"
 	"github.com/oasisprotocol/oasis-core/go/runtime/host/mock"
 	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
@@ -60,6 +61,11 @@ func testSubmitTransaction(
 	c api.RuntimeClient,
 	input string,
 ) {
+	// Estimate finality of a transaction submitted now.
+	estimate, err := c.EstimateFinality(ctx, runtimeID)
+	require.NoError(t, err, "EstimateFinality")
+	require.True(t, estimate.Round > 0, "EstimateFinality round should be non zero")
+
 	// Submit a test transaction.
 	testInput := []byte(input)
 	resp, err := c.SubmitTxMeta(ctx, &api.SubmitTxRequest{Data: testInput, RuntimeID: runtimeID})
@@ -69,6 +75,8 @@ func testSubmitTransaction(
 	require.Nil(t, resp.CheckTxError, "SubmitTxMeta check tx error")
 	require.EqualValues(t, testInput, resp.Output)
 	require.True(t, resp.Round > 0, "SubmitTxMeta round should be non zero")
+	require.NotNil(t, resp.FinalityEstimate, "SubmitTxMeta should include a finality estimate")
+	require.True(t, resp.FinalityEstimate.Round >= estimate.Round, "finality estimate should not go back")
 }
 
 func testFailSubmitTransaction(
@@ -161,6 +169,28 @@ func testQuery(
 	require.EqualValues(t, []byte("txn_foo"), txnsWithResults[0].Events[0].Key)
 	require.EqualValues(t, []byte("txn_bar"), txnsWithResults[0].Events[0].Value)
 
+	// Transaction receipt.
+	txHash := hash.NewFromBytes(testInput)
+	receipt, err := c.GetTransactionReceipt(ctx, &api.GetTransactionReceiptRequest{
+		RuntimeID: runtimeID,
+		Round:     blk.Header.Round,
+		TxHash:    txHash,
+	})
+	require.NoError(t, err, "GetTransactionReceipt")
+	require.EqualValues(t, blk.Header.Round, receipt.Round)
+	require.EqualValues(t, testInput, receipt.Result)
+	err = receipt.Verify(ctx, &blk.Header, txHash)
+	require.NoError(t, err, "TransactionReceipt.Verify")
+	err = receipt.Verify(ctx, &genBlk.Header, txHash)
+	require.Error(t, err, "TransactionReceipt.Verify should fail for a different block")
+
+	_, err = c.GetTransactionReceipt(ctx, &api.GetTransactionReceiptRequest{
+		RuntimeID: runtimeID,
+		Round:     blk.Header.Round,
+		TxHash:    hash.NewFromBytes([]byte("missing")),
+	})
+	require.ErrorIs(t, err, api.ErrNotFound, "GetTransactionReceipt should fail for a missing transaction")
+
 	// Check events query (see mock worker for emitted events).
 	events, err := c.GetEvents(ctx, &api.GetEventsRequest{RuntimeID: runtimeID, Round: 3})
 	require.NoError(t, err, "GetEvents")
@@ -168,6 +198,87 @@ func testQuery(
 	require.EqualValues(t, []byte("txn_foo"), events[0].Key)
 	require.EqualValues(t, []byte("txn_bar"), events[0].Value)
 
+	// Watch events from history.
+	eventsRound := uint64(3)
+	var cursor api.EventCursor
+	evCh, evSub, err := c.WatchEvents(ctx, &api.WatchEventsRequest{
+		RuntimeID:   runtimeID,
+		Round:       &eventsRound,
+		KeyPrefixes: [][]byte{[]byte("txn_")},
+	})
+	require.NoError(t, err, "WatchEvents")
+	select {
+	case ev, ok := <-evCh:
+		require.True(t, ok, "WatchEvents channel should not be closed")
+		require.NoError(t, ev.Error, "WatchEvents should not fail")
+		require.EqualValues(t, 3, ev.Cursor.Round, "WatchEvents should start with the requested round")
+		require.EqualValues(t, []byte("txn_foo"), ev.Event.Key)
+		require.EqualValues(t, []byte("txn_bar"), ev.Event.Value)
+		cursor = ev.Cursor
+	case <-time.After(timeout):
+		t.Fatalf("failed to receive runtime event")
+	}
+	evSub.Close()
+
+	// Resume watching events after the last received event.
+	evCh, evSub, err = c.WatchEvents(ctx, &api.WatchEventsRequest{
+		RuntimeID:   runtimeID,
+		After:       &cursor,
+		KeyPrefixes: [][]byte{[]byte("txn_")},
+	})
+	require.NoError(t, err, "WatchEvents")
+	select {
+	case ev, ok := <-evCh:
+		require.True(t, ok, "WatchEvents channel should not be closed")
+		require.NoError(t, ev.Error, "WatchEvents should not fail")
+		require.True(t, ev.Cursor.Round > cursor.Round || ev.Cursor.Index > cursor.Index,
+			"WatchEvents should resume after the last received event")
+	case <-time.After(time.Second):
+		// No further matching events have been emitted so far.
+	}
+	evSub.Close()
+
+	_, _, err = c.WatchEvents(ctx, &api.WatchEventsRequest{
+		RuntimeID: runtimeID,
+		Round:     &eventsRound,
+		After:     &cursor,
+	})
+	require.ErrorIs(t, err, api.ErrInvalidArgument, "WatchEvents should reject both round and cursor")
+
+	// Replay blocks with events from history.
+	replayCh, replaySub, err := c.ReplayBlocks(ctx, &api.ReplayBlocksRequest{
+		RuntimeID:     runtimeID,
+		FromRound:     genBlk.Header.Round,
+		ToRound:       blk.Header.Round,
+		IncludeEvents: true,
+	})
+	require.NoError(t, err, "ReplayBlocks")
+	expectedRound := genBlk.Header.Round
+	for replayed := range replayCh {
+		require.NoError(t, replayed.Error, "ReplayBlocks should not fail")
+		require.EqualValues(t, expectedRound, replayed.Block.Block.Header.Round, "ReplayBlocks should replay rounds in order")
+		if expectedRound == 3 {
+			require.Len(t, replayed.Events, 1)
+			require.EqualValues(t, []byte("txn_foo"), replayed.Events[0].Key)
+		}
+		expectedRound++
+	}
+	replaySub.Close()
+	require.EqualValues(t, blk.Header.Round+1, expectedRound, "ReplayBlocks should replay all requested rounds")
+
+	_, _, err = c.ReplayBlocks(ctx, &api.ReplayBlocksRequest{
+		RuntimeID: runtimeID,
+		FromRound: blk.Header.Round,
+		ToRound:   blk.Header.Round + 1,
+	})
+	require.ErrorIs(t, err, api.ErrNotFound, "ReplayBlocks should fail for unavailable rounds")
+	_, _, err = c.ReplayBlocks(ctx, &api.ReplayBlocksRequest{
+		RuntimeID: runtimeID,
+		FromRound: blk.Header.Round,
+		ToRound:   genBlk.Header.Round,
+	})
+	require.ErrorIs(t, err, api.ErrInvalidArgument, "ReplayBlocks should fail for an invalid range")
+
 	// Query genesis block again.
 	genBlk2, err := c.GetGenesisBlock(ctx, runtimeID)
 	require.NoError(t, err, "GetGenesisBlock2")
@@ -223,6 +334,43 @@ func testQuery(
 	require.NoError(t, err, "cbor.Unmarshal(<QueryResponse.Data>)")
 	require.True(t, strings.HasPrefix(decResp4, "hello world"), "Query response at latest round should be correct")
 
+	// Batch queries should be executed against the same round.
+	batchRsp, err := c.BatchQuery(ctx, &api.BatchQueryRequest{
+		RuntimeID: runtimeID,
+		Round:     1,
+		Queries: []*api.BatchQueryItem{
+			{Method: "hello"},
+			{Method: "hi"},
+		},
+	})
+	require.NoError(t, err, "BatchQuery")
+	require.EqualValues(t, 1, batchRsp.Round, "BatchQuery should report the queried round")
+	require.Len(t, batchRsp.Results, 2, "BatchQuery should return a result for each query")
+	for _, result := range batchRsp.Results {
+		require.Nil(t, result.Error, "BatchQuery result should not fail")
+	}
+	var decBatchResp string
+	err = cbor.Unmarshal(batchRsp.Results[0].Data, &decBatchResp)
+	require.NoError(t, err, "cbor.Unmarshal(<BatchQueryResult.Data>)")
+	require.EqualValues(t, decResp2, decBatchResp, "BatchQuery response should match Query response for the same round")
+	err = cbor.Unmarshal(batchRsp.Results[1].Data, &decBatchResp)
+	require.NoError(t, err, "cbor.Unmarshal(<BatchQueryResult.Data>)")
+	require.True(t, strings.HasPrefix(decBatchResp, "hi world"), "BatchQuery responses should be in request order")
+
+	batchRsp, err = c.BatchQuery(ctx, &api.BatchQueryRequest{
+		RuntimeID: runtimeID,
+		Round:     api.RoundLatest,
+		Queries:   []*api.BatchQueryItem{{Method: "hello"}},
+	})
+	require.NoError(t, err, "BatchQuery")
+	require.True(t, batchRsp.Round >= blk.Header.Round, "BatchQuery should resolve the latest round")
+
+	_, err = c.BatchQuery(ctx, &api.BatchQueryRequest{
+		RuntimeID: runtimeID,
+		Round:     api.RoundLatest,
+	})
+	require.ErrorIs(t, err, api.ErrInvalidArgument, "BatchQuery should fail for an empty batch")
+
 	// Execute CheckTx using the mock runtime host.
 	err = c.CheckTx(ctx, &api.CheckTxRequest{
 		RuntimeID: runtimeID,
@@ -3,10 +3,12 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"time"
 
 	"gopkg.in/yaml.v3"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
 	tpConfig "github.com/oasisprotocol/oasis-core/go/runtime/txpool/config"
 )
@@ -108,6 +110,42 @@ type Config struct {
 
 	// Components is the list of components to configure.
 	Components []ComponentConfig `yaml:"components,omitempty"`
+
+	// Replay is the runtime round recording configuration.
+	Replay ReplayConfig `yaml:"replay,omitempty"`
+
+	// ShadowExecution is the shadow round re-execution configuration.
+	ShadowExecution ShadowExecutionConfig `yaml:"shadow_execution,omitempty"`
+
+	// BundleRecords is the loaded runtime bundle record reporting configuration.
+	BundleRecords BundleRecordsConfig `yaml:"bundle_records,omitempty"`
+
+	// BackupOnly configures compute nodes to only participate in executor committees as backup
+	// workers. Such nodes never schedule transactions, which lowers their resource requirements.
+	BackupOnly bool `yaml:"backup_only,omitempty"`
+
+	// Runtime ID -> host-side query cache configuration.
+	QueryCache map[string]QueryCacheConfig `yaml:"query_cache,omitempty"`
+
+	// BundleSync is the peer-to-peer runtime bundle distribution configuration.
+	BundleSync BundleSyncConfig `yaml:"bundle_sync,omitempty"`
+
+	// Runtime ID -> runtime host protocol capture configuration.
+	//
+	// Use of protocol capture is only allowed if DebugDontBlameOasis flag is set.
+	ProtocolCapture map[string]ProtocolCaptureConfig `yaml:"protocol_capture,omitempty"`
+}
+
+// GetQueryCache returns the query cache configuration for the given runtime.
+func (c *Config) GetQueryCache(id common.Namespace) QueryCacheConfig {
+	return c.QueryCache[id.String()]
+}
+
+// GetProtocolCapture returns the runtime host protocol capture configuration for the given
+// runtime if it exists.
+func (c *Config) GetProtocolCapture(id common.Namespace) (ProtocolCaptureConfig, bool) {
+	pc, ok := c.ProtocolCapture[id.String()]
+	return pc, ok
 }
 
 // GetComponent returns configuration for the given component if it exists.
@@ -147,7 +185,10 @@ func (c *ComponentConfig) UnmarshalYAML(value *yaml.Node) error {
 
 // PruneConfig is the history pruner configuration structure.
 type PruneConfig struct {
-	// History pruner strategy.
+	// History pruner strategy (none, keep_last or keep_checkpoints).
+	//
+	// The keep_checkpoints strategy keeps the last NumKept rounds and, in addition, all rounds
+	// starting with the earliest locally offered storage checkpoint.
 	Strategy string `yaml:"strategy"`
 	// History pruning interval.
 	Interval time.Duration `yaml:"interval"`
@@ -162,6 +203,94 @@ type LoadBalancerConfig struct {
 	NumInstances uint64 `yaml:"num_instances,omitempty"`
 }
 
+// ReplayConfig is the runtime round recording configuration.
+type ReplayConfig struct {
+	// RecordDir is the directory where compute nodes record all inputs of executed runtime
+	// rounds so that they can be replayed locally for debugging. Empty (default) disables
+	// recording.
+	RecordDir string `yaml:"record_dir,omitempty"`
+	// NumKept is the number of most recent round recordings to keep. Zero keeps all recordings.
+	NumKept uint64 `yaml:"num_kept,omitempty"`
+}
+
+// ShadowExecutionConfig is the shadow round re-execution configuration.
+//
+// This is a debugging aid intended as an early warning system for nondeterminism in runtime
+// builds. When enabled, compute nodes re-execute each round that they executed once it has been
+// finalized, and compare the resulting roots with the finalized block. Shadow execution results
+// are never committed or submitted, so divergences are only reported locally.
+//
+// Shadow execution is only performed while the executor is idle and is aborted as soon as the
+// executor starts processing a batch, so rounds may be skipped on busy nodes.
+type ShadowExecutionConfig struct {
+	// Enabled enables shadow re-execution of finalized rounds.
+	Enabled bool `yaml:"enabled,omitempty"`
+}
+
+// BundleRecordsConfig is the loaded runtime bundle record reporting configuration.
+//
+// Records of all loaded runtime bundles (manifest hash, enclave identities, source path) are
+// always logged and can additionally be sent to a webhook for compliance inventories.
+type BundleRecordsConfig struct {
+	// WebhookURL is the URL that bundle records are POSTed to in JSON format. Empty (default)
+	// disables the webhook.
+	WebhookURL string `yaml:"webhook_url,omitempty"`
+	// WebhookTimeout is the timeout for a single webhook request.
+	WebhookTimeout time.Duration `yaml:"webhook_timeout,omitempty"`
+}
+
+// BundleSyncConfig is the peer-to-peer runtime bundle distribution configuration.
+//
+// Nodes serving bundles make the bundles that they have loaded available to peers, identified by
+// their manifest hash. Nodes fetching bundles download the bundles of on-chain deployments that
+// declare a manifest hash but are not available locally, and load them without a restart.
+type BundleSyncConfig struct {
+	// Serve enables serving locally loaded runtime bundles to peers.
+	Serve bool `yaml:"serve,omitempty"`
+	// Fetch enables fetching runtime bundles of on-chain deployments from peers.
+	Fetch bool `yaml:"fetch,omitempty"`
+	// MaxServeRate is the maximum rate (in bytes per second) at which bundles are served to all
+	// peers combined. Zero means no limit.
+	MaxServeRate uint64 `yaml:"max_serve_rate,omitempty"`
+}
+
+// QueryCacheConfig is the host-side runtime query cache configuration.
+//
+// The cache stores responses of identical queries (same method, arguments and component) made
+// against the same round. It is cleared whenever a new round is finalized.
+type QueryCacheConfig struct {
+	// MaxEntries is the maximum number of cached query responses. Zero (default) disables the
+	// cache.
+	MaxEntries uint64 `yaml:"max_entries,omitempty"`
+	// MaxResponseSize is the maximum size (in bytes) of a cached query response. Larger responses
+	// are never cached. Zero means no limit.
+	MaxResponseSize uint64 `yaml:"max_response_size,omitempty"`
+}
+
+// ProtocolCaptureConfig is the runtime host protocol capture configuration.
+//
+// This is a debugging aid for protocol-level incompatibilities between the node and the runtime.
+// When configured, all messages exchanged with the runtime are recorded into a capture file which
+// can be inspected using the `oasis-node debug rhp-capture` command. Captured messages may
+// contain sensitive data, so the bodies of such messages are redacted by default.
+type ProtocolCaptureConfig struct {
+	// File is the path to the capture file. Messages are appended in case the file exists.
+	File string `yaml:"file"`
+	// MaxBodySize is the maximum size (in bytes) of a captured message body. Only the type and
+	// size of larger bodies are recorded. Zero means no limit.
+	MaxBodySize uint64 `yaml:"max_body_size,omitempty"`
+	// MaxFileSize is the maximum size (in bytes) of the capture file after which capturing stops.
+	// Zero means the default limit of 1 GiB.
+	MaxFileSize uint64 `yaml:"max_file_size,omitempty"`
+	// Redact is a list of additional message body types whose contents are never captured. Only
+	// the type and size of such bodies are recorded. Bodies of RPC calls, local storage accesses
+	// and attestation messages are always redacted unless listed in Unredact.
+	Redact []string `yaml:"redact,omitempty"`
+	// Unredact is a list of message body types that are redacted by default (e.g.
+	// RuntimeRPCCallRequest) whose contents should be captured.
+	Unredact []string `yaml:"unredact,omitempty"`
+}
+
 // Validate validates the configuration settings.
 func (c *Config) Validate() error {
 	switch c.Provisioner {
@@ -189,7 +318,7 @@ func (c *Config) Validate() error {
 
 	switch c.Prune.Strategy {
 	case "none":
-	case "keep_last":
+	case "keep_last", "keep_checkpoints":
 		if c.Prune.Interval < 1*time.Second {
 			return fmt.Errorf("prune.interval must be >= 1 second")
 		}
@@ -201,6 +330,39 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("cannot specify more than 128 instances for load balancing")
 	}
 
+	for id, qc := range c.QueryCache {
+		var ns common.Namespace
+		if err := ns.UnmarshalHex(id); err != nil {
+			return fmt.Errorf("malformed runtime identifier in query_cache: %w", err)
+		}
+		if qc.MaxEntries > 100_000 {
+			return fmt.Errorf("query_cache.max_entries cannot be larger than 100000")
+		}
+	}
+
+	for id, pc := range c.ProtocolCapture {
+		var ns common.Namespace
+		if err := ns.UnmarshalHex(id); err != nil {
+			return fmt.Errorf("malformed runtime identifier in protocol_capture: %w", err)
+		}
+		if pc.File == "" {
+			return fmt.Errorf("protocol_capture.file must be set")
+		}
+	}
+
+	if c.BundleRecords.WebhookURL != "" {
+		u, err := url.Parse(c.BundleRecords.WebhookURL)
+		if err != nil {
+			return fmt.Errorf("malformed bundle_records.webhook_url: %w", err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("bundle_records.webhook_url must be an http(s) URL")
+		}
+		if c.BundleRecords.WebhookTimeout < 1*time.Second {
+			return fmt.Errorf("bundle_records.webhook_timeout must be >= 1 second")
+		}
+	}
+
 	return nil
 }
 
@@ -230,5 +392,22 @@ func DefaultConfig() Config {
 		LoadBalancer: LoadBalancerConfig{
 			NumInstances: 0,
 		},
+		Replay: ReplayConfig{
+			RecordDir: "",
+			NumKept:   100,
+		},
+		ShadowExecution: ShadowExecutionConfig{
+			Enabled: false,
+		},
+		BundleRecords: BundleRecordsConfig{
+			WebhookURL:     "",
+			WebhookTimeout: 10 * time.Second,
+		},
+		BackupOnly: false,
+		BundleSync: BundleSyncConfig{
+			Serve:        false,
+			Fetch:        false,
+			MaxServeRate: 1024 * 1024,
+		},
 	}
 }
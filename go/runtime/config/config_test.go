@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
 )
 
@@ -54,3 +55,34 @@ components:
 	require.EqualValues(compCfg.ID.Name, "another")
 	require.True(compCfg.Disabled)
 }
+
+func TestQueryCacheConfig(t *testing.T) {
+	require := require.New(t)
+
+	yamlCfg := `
+query_cache:
+    8000000000000000000000000000000000000000000000000000000000000000:
+        max_entries: 1000
+        max_response_size: 4096
+`
+	cfg := DefaultConfig()
+	err := yaml.Unmarshal([]byte(yamlCfg), &cfg)
+	require.NoError(err, "yaml.Unmarshal")
+	require.NoError(cfg.Validate())
+
+	var id common.Namespace
+	require.NoError(id.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000"))
+	qc := cfg.GetQueryCache(id)
+	require.EqualValues(1000, qc.MaxEntries)
+	require.EqualValues(4096, qc.MaxResponseSize)
+
+	var other common.Namespace
+	require.Equal(QueryCacheConfig{}, cfg.GetQueryCache(other), "unconfigured runtimes should have the cache disabled")
+
+	cfg.QueryCache["not a runtime id"] = QueryCacheConfig{MaxEntries: 1}
+	require.Error(cfg.Validate(), "malformed runtime identifiers should be rejected")
+	delete(cfg.QueryCache, "not a runtime id")
+
+	cfg.QueryCache[id.String()] = QueryCacheConfig{MaxEntries: 1_000_000}
+	require.Error(cfg.Validate(), "too large caches should be rejected")
+}
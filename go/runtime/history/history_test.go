@@ -397,6 +397,94 @@ func TestHistoryPrune(t *testing.T) {
 	}
 }
 
+type testRetainingPruneHandler struct {
+	testPruneHandler
+
+	retainedRound uint64
+}
+
+func (h *testRetainingPruneHandler) EarliestRetainedRound() (uint64, bool, error) {
+	return h.retainedRound, true, nil
+}
+
+func TestHistoryPruneKeepCheckpoints(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	// Create a new random temporary directory under /tmp.
+	dataDir, err := os.MkdirTemp("", "oasis-runtime-history-test_")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dataDir)
+
+	runtimeID := common.NewTestNamespaceFromSeed([]byte("history prune keep checkpoints test ns"), 0)
+
+	history, err := New(dataDir, runtimeID, &Config{
+		Pruner:        NewKeepCheckpointsPruner(10),
+		PruneInterval: 100 * time.Millisecond,
+	}, true)
+	require.NoError(err, "New")
+	defer history.Close()
+
+	ph := testRetainingPruneHandler{
+		testPruneHandler: testPruneHandler{
+			doneCh:     make(chan struct{}),
+			waitRounds: 25,
+		},
+		retainedRound: 25,
+	}
+	history.Pruner().RegisterHandler(&ph)
+
+	// Create some blocks.
+	for i := 0; i <= 50; i++ {
+		blk := roothash.AnnotatedBlock{
+			Height: int64(i),
+			Block:  block.NewGenesisBlock(runtimeID, 0),
+		}
+		blk.Block.Header.Round = uint64(i)
+
+		err = history.Commit(&blk, nil, true)
+		require.NoError(err, "Commit")
+
+		err = history.StorageSyncCheckpoint(blk.Block.Header.Round)
+		require.NoError(err, "StorageSyncCheckpoint")
+	}
+
+	// Wait for pruning to complete.
+	select {
+	case <-ph.doneCh:
+	case <-time.After(recvTimeout):
+		t.Fatalf("failed to wait for prune to complete")
+	}
+
+	// Wait until the pruning transaction has been committed.
+	ctx, cancel := context.WithTimeout(ctx, recvTimeout)
+	defer cancel()
+	for {
+		_, err = history.GetBlock(ctx, 24)
+		if err == nil {
+			time.Sleep(10 * time.Millisecond)
+			continue
+		}
+
+		require.Equal(roothash.ErrNotFound, err)
+		break
+	}
+
+	// Give the pruner a chance to prune more (it shouldn't).
+	time.Sleep(200 * time.Millisecond)
+
+	// Ensure the retained rounds have been kept even though they are not among the last 10.
+	for i := 0; i <= 50; i++ {
+		_, err = history.GetBlock(ctx, uint64(i))
+		if i < 25 {
+			require.Equal(roothash.ErrNotFound, err, "GetBlock should fail for pruned block %d", i)
+		} else {
+			require.NoError(err, "GetBlock(%d)", i)
+		}
+	}
+	require.Len(ph.prunedRounds, 25)
+}
+
 type testPruneFailingHandler struct{}
 
 func (h *testPruneFailingHandler) Prune([]uint64) error {
@@ -14,6 +14,8 @@ const (
 	PrunerStrategyNone = "none"
 	// PrunerStrategyKeepLast is the name of the keep last pruner strategy.
 	PrunerStrategyKeepLast = "keep_last"
+	// PrunerStrategyKeepCheckpoints is the name of the keep checkpoints pruner strategy.
+	PrunerStrategyKeepCheckpoints = "keep_checkpoints"
 
 	// maxBatchSize is the maximum number of rounds to prune in one pass.
 	maxBatchSize = 64
@@ -36,6 +38,18 @@ type PruneHandler interface {
 	Prune(rounds []uint64) error
 }
 
+// PruneRetainer is an optional interface that can be implemented by prune handlers which need
+// some rounds to be retained (e.g., because they are referenced by checkpoints).
+//
+// It is only consulted by the keep checkpoints pruner.
+type PruneRetainer interface {
+	// EarliestRetainedRound returns the earliest round that must not be pruned. As rounds are
+	// always pruned in order, this also retains all of the following rounds.
+	//
+	// In case the handler does not need any rounds to be retained, false is returned.
+	EarliestRetainedRound() (uint64, bool, error)
+}
+
 // Pruner is the runtime history pruner interface.
 type Pruner interface {
 	// Prune purges unneeded history, given the latest round.
@@ -85,6 +99,8 @@ type keepLastPruner struct {
 	db     *DB
 
 	numKept uint64
+	// retain specifies whether the rounds retained by prune handlers should be kept.
+	retain bool
 }
 
 func (p *keepLastPruner) Prune(latestRound uint64) error {
@@ -97,6 +113,29 @@ func (p *keepLastPruner) Prune(latestRound uint64) error {
 
 	lastPrunedRound := latestRound - p.numKept
 
+	if p.retain {
+		for _, ph := range p.prunerBase.handlers {
+			pr, ok := ph.(PruneRetainer)
+			if !ok {
+				continue
+			}
+			round, ok, err := pr.EarliestRetainedRound()
+			if err != nil {
+				return fmt.Errorf("runtime/history: failed to query retained round: %w", err)
+			}
+			if !ok {
+				continue
+			}
+			if round == 0 {
+				// Everything needs to be retained.
+				return nil
+			}
+			if round-1 < lastPrunedRound {
+				lastPrunedRound = round - 1
+			}
+		}
+	}
+
 	return p.db.db.Update(func(tx *badger.Txn) error {
 		// NOTE: Do not prefetch values as we are only looking at keys.
 		it := tx.NewIterator(badger.IteratorOptions{
@@ -169,3 +208,18 @@ func NewKeepLastPruner(numKept uint64) PrunerFactory {
 		}, nil
 	}
 }
+
+// NewKeepCheckpointsPruner creates a pruner that keeps the last configured number of rounds and
+// all rounds retained by prune handlers (e.g., rounds referenced by locally offered checkpoints
+// or rounds that have not yet been checkpointed).
+func NewKeepCheckpointsPruner(numKept uint64) PrunerFactory {
+	return func(db *DB) (Pruner, error) {
+		return &keepLastPruner{
+			prunerBase: newPrunerBase(),
+			logger:     logging.GetLogger("history/prune/keep_checkpoints"),
+			db:         db,
+			numKept:    numKept,
+			retain:     true,
+		}, nil
+	}
+}
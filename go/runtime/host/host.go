@@ -31,6 +31,10 @@ type Config struct {
 
 	// LocalConfig is the node-local runtime configuration.
 	LocalConfig map[string]interface{}
+
+	// ProtocolCapture is an optional configuration for recording all Runtime Host Protocol
+	// messages exchanged with the runtime. This should only be used for debugging.
+	ProtocolCapture *protocol.CaptureConfig
 }
 
 // RuntimeBundle is a exploded runtime bundle ready for execution.
@@ -4,8 +4,6 @@ import (
 	"sync"
 
 	"github.com/prometheus/client_golang/prometheus"
-
-	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/metrics"
 )
 
 var (
@@ -31,12 +29,8 @@ var (
 	metricsOnce sync.Once
 )
 
-// initMetrics registers the metrics collectors if metrics are enabled.
+// initMetrics registers the metrics collectors.
 func initMetrics() {
-	if !metrics.Enabled() {
-		return
-	}
-
 	metricsOnce.Do(func() {
 		prometheus.MustRegister(nodeCollectors...)
 	})
@@ -18,6 +18,9 @@ const maxLogBufferSize = 10_000_000
 // translates runtime log levels to oasis-node log levels, because the two have
 // slightly different formats.
 //
+// Lines that are not valid JSON are treated as plain text and are logged with
+// the severity they are prefixed with (if any) or as warnings otherwise.
+//
 // It hardcodes some assumptions about the format of the runtime logs.
 type RuntimeLogWrapper struct {
 	// Logger for wrapper-internal info/errors.
@@ -75,57 +78,118 @@ func (w *RuntimeLogWrapper) rtLogger(module string) *logging.Logger {
 	return l
 }
 
+// parseLogLevel maps the severity of a runtime log entry to a node log level.
+//
+// Both the abbreviated slog levels (e.g., "DEBG", "ERRO") and the more
+// common full level names (e.g., "debug", "error") are supported, as the
+// runtimes may use different logging backends.
+func parseLogLevel(level string) (logging.Level, bool) {
+	switch strings.ToUpper(strings.TrimSpace(level)) {
+	case "TRCE", "TRACE", "DEBG", "DEBUG":
+		return logging.LevelDebug, true
+	case "INFO":
+		return logging.LevelInfo, true
+	case "WARN", "WARNING":
+		return logging.LevelWarn, true
+	case "ERRO", "ERROR", "CRIT", "CRITICAL":
+		return logging.LevelError, true
+	default:
+		return logging.LevelInfo, false
+	}
+}
+
+// runtimeModule returns the module name enforcing the "runtime" scope.
+func runtimeModule(module string) string {
+	module = strings.ReplaceAll(module, "::", "/")
+	switch {
+	case module == "":
+		return "runtime"
+	case module == "runtime", strings.HasPrefix(module, "runtime/"):
+		return module
+	default:
+		return "runtime/" + module
+	}
+}
+
+func (w RuntimeLogWrapper) log(logger *logging.Logger, level logging.Level, msg string, kv ...interface{}) {
+	switch level {
+	case logging.LevelDebug:
+		logger.Debug(msg, kv...)
+	case logging.LevelInfo:
+		logger.Info(msg, kv...)
+	case logging.LevelWarn:
+		logger.Warn(msg, kv...)
+	default:
+		logger.Error(msg, kv...)
+	}
+}
+
+func (w RuntimeLogWrapper) processPlainLogLine(line []byte) {
+	logger := w.rtLogger("runtime").With("ts", log.DefaultTimestampUTC)
+	msg := string(line)
+
+	// Plain text lines that start with a severity (e.g., "ERROR something failed"
+	// or "[WARN] something happened") are logged with the corresponding level,
+	// everything else (e.g., panic messages) is treated as a warning.
+	trimmed := strings.TrimSpace(msg)
+	if fields := strings.SplitN(trimmed, " ", 2); len(fields) == 2 {
+		if level, ok := parseLogLevel(strings.Trim(fields[0], "[]:")); ok {
+			w.log(logger, level, strings.TrimSpace(fields[1]))
+			return
+		}
+	}
+	logger.Warn(msg)
+}
+
 func (w RuntimeLogWrapper) processLogLine(line []byte) {
 	// Interpret line as JSON.
 	var m map[string]interface{}
 	if err := json.Unmarshal(line, &m); err != nil {
 		// If not valid JSON, forward line as normal log message with local timestamp.
-		w.rtLogger("runtime").With("ts", log.DefaultTimestampUTC).Warn(string(line))
+		w.processPlainLogLine(line)
 		return
 	}
 
 	// Destructure JSON into key-value pairs, parse common fields.
-	var kv []interface{}
-	var msg string
-	var level string
-	var module string
+	var (
+		kv       []interface{}
+		msg      string
+		rawLevel string
+		module   string
+	)
 	for k, v := range m {
-		if k == "msg" {
-			if _msg, ok := v.(string); ok {
-				msg = _msg
-			} else {
-				w.logger.Warn("malformed log line from runtime", "log_line", string(line), "err", "msg is not a string")
+		switch k {
+		case "msg", "message":
+			_msg, ok := v.(string)
+			if !ok {
+				w.logger.Warn("malformed log line from runtime", "log_line", string(line), "err", k+" is not a string")
 				return
 			}
-		} else if k == "level" {
-			level, _ = v.(string)
-		} else if k == "module" {
-			module, _ = v.(string)
-			if module == "" {
-				module = "runtime"
+			msg = _msg
+		case "level", "severity":
+			rawLevel, _ = v.(string)
+		case "module", "target":
+			if module == "" || k == "module" {
+				module, _ = v.(string)
 			}
-			// Enforce "runtime" scope in the module name.
-			if !(module == "runtime" || strings.HasPrefix(module, "runtime/")) {
-				module = "runtime/" + module
+		case "fields":
+			// Flatten nested structured fields.
+			if fields, ok := v.(map[string]interface{}); ok {
+				for fk, fv := range fields {
+					kv = append(kv, fk, fv)
+				}
+				continue
 			}
-		} else {
+			kv = append(kv, k, v)
+		default:
 			kv = append(kv, k, v)
 		}
 	}
 
 	// Output the log.
-	rtLogger := w.rtLogger(module)
-	switch level {
-	case "DEBG":
-		rtLogger.Debug(msg, kv...)
-	case "INFO":
-		rtLogger.Info(msg, kv...)
-	case "WARN":
-		rtLogger.Warn(msg, kv...)
-	case "ERRO":
-		rtLogger.Error(msg, kv...)
-	default:
+	level, ok := parseLogLevel(rawLevel)
+	if !ok {
 		w.logger.Warn("log line from runtime has no known error level set, using INFO", "log_line", string(line))
-		rtLogger.Info(msg, kv...)
 	}
+	w.log(w.rtLogger(runtimeModule(module)), level, msg, kv...)
 }
@@ -3,6 +3,7 @@ package host
 import (
 	"bytes"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -10,13 +11,25 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 )
 
+var (
+	testLogBuf     bytes.Buffer
+	testLogBufOnce sync.Once
+)
+
+// initTestLogging redirects all logs to a buffer where we can inspect them later.
+// Use JSON format because it uses a deterministic (a-z) order of keys.
+func initTestLogging() *bytes.Buffer {
+	testLogBufOnce.Do(func() {
+		_ = logging.Initialize(&testLogBuf, logging.FmtJSON, logging.LevelDebug, map[string]logging.Level{})
+	})
+	testLogBuf.Reset()
+	return &testLogBuf
+}
+
 func TestRuntimeLogWrapper(t *testing.T) {
 	require := require.New(t)
 
-	// Redirect all logs to a buffer where we can inspect them later.
-	// Use JSON format because it uses a deterministic (a-z) order of keys.
-	var buf bytes.Buffer
-	_ = logging.Initialize(&buf, logging.FmtJSON, logging.LevelDebug, map[string]logging.Level{})
+	buf := initTestLogging()
 
 	// Simulated runtime output.
 	logChunks := []string{
@@ -65,3 +78,43 @@ func TestRuntimeLogWrapper(t *testing.T) {
 			i+1, actual[i], expected[i])
 	}
 }
+
+func TestRuntimeLogWrapperSeverityMapping(t *testing.T) {
+	require := require.New(t)
+
+	buf := initTestLogging()
+
+	logLines := []string{
+		// Full level names and alternative message/module fields.
+		`{"message":"My trace","level":"trace","ts":"2022","target":"runtime::dispatcher"}`,
+		`{"msg":"My warning","level":"WARNING","ts":"2022","module":"runtime/protocol"}`,
+		`{"msg":"My critical","level":"CRIT","ts":"2022","module":"runtime"}`,
+		// Nested structured fields.
+		`{"msg":"With fields","level":"INFO","ts":"2022","module":"runtime","fields":{"round":42}}`,
+		// Plain text with a recognizable severity.
+		`ERROR something failed`,
+		`[INFO] something happened`,
+	}
+
+	w := NewRuntimeLogWrapper(logging.GetLogger("testenv"))
+	for _, line := range logLines {
+		_, err := w.Write([]byte(line + "\n"))
+		require.NoError(err)
+	}
+
+	actual := strings.Split(buf.String(), "\n")
+	expected := []string{
+		`{"level":"debug","module":"runtime/dispatcher","msg":"My trace","ts":"2022"}`,
+		`{"level":"warn","module":"runtime/protocol","msg":"My warning","ts":"2022"}`,
+		`{"level":"error","module":"runtime","msg":"My critical","ts":"2022"}`,
+		`{"level":"info","module":"runtime","msg":"With fields","round":42,"ts":"2022"}`,
+		`{"level":"error","module":"runtime","msg":"something failed","ts":"[^"]+"}`,
+		`{"level":"info","module":"runtime","msg":"something happened","ts":"[^"]+"}`,
+		``,
+	}
+
+	require.EqualValues(len(expected), len(actual), "Unexpected number of log entries: %#v", actual)
+	for i := range actual {
+		require.Regexp(expected[i], actual[i], "Log line %2d", i+1)
+	}
+}
@@ -311,6 +311,32 @@ func (agg *Aggregate) GetVersion(version version.Version) (host.Runtime, error)
 	return host.host, nil
 }
 
+// AddVersion adds a new runtime version to the aggregate. The runtime provided must be freshly
+// provisioned (ie: Start() must not have been called) and is only started once the version is
+// activated via SetVersion.
+func (agg *Aggregate) AddVersion(version version.Version, rt host.Runtime) error {
+	if rt.ID() != agg.id {
+		return fmt.Errorf("runtime/host/multi: sub-runtime mismatch: got '%s', expected '%s'",
+			rt.ID().String(),
+			agg.id.String(),
+		)
+	}
+
+	agg.l.Lock()
+	defer agg.l.Unlock()
+
+	if agg.hosts[version] != nil {
+		return fmt.Errorf("runtime/host/multi: duplicate sub-runtime version: %v", version)
+	}
+
+	agg.logger.Info("added version",
+		"version", version,
+	)
+
+	agg.hosts[version] = newAggregatedHost(version, rt)
+	return nil
+}
+
 // SetVersion sets the active and next runtime versions.  This routine will:
 //   - Do nothing if the active version is already the requested version.
 //   - Unconditionally tear down the currently active version (via Stop()).
@@ -531,20 +557,23 @@ func New(
 			return nil, fmt.Errorf("runtime/host/multi: duplicate sub-runtime version: %v", version)
 		}
 
-		ch, sub := rt.WatchEvents()
-
-		ah := &aggregatedHost{
-			host:             rts[version],
-			ch:               ch,
-			sub:              sub,
-			stopCh:           make(chan struct{}),
-			stoppedCh:        make(chan struct{}),
-			stopDiscardCh:    make(chan struct{}),
-			stoppedDiscardCh: make(chan *host.Event),
-			version:          version,
-		}
-		agg.hosts[version] = ah
+		agg.hosts[version] = newAggregatedHost(version, rt)
 	}
 
 	return agg, nil
 }
+
+func newAggregatedHost(version version.Version, rt host.Runtime) *aggregatedHost {
+	ch, sub := rt.WatchEvents()
+
+	return &aggregatedHost{
+		host:             rt,
+		ch:               ch,
+		sub:              sub,
+		stopCh:           make(chan struct{}),
+		stoppedCh:        make(chan struct{}),
+		stopDiscardCh:    make(chan struct{}),
+		stoppedDiscardCh: make(chan *host.Event),
+		version:          version,
+	}
+}
@@ -0,0 +1,304 @@
+package protocol
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+)
+
+const (
+	// maxCaptureRecordSize is the maximum size of a single encoded capture record.
+	maxCaptureRecordSize = 64 * 1024 * 1024 // 64 MiB
+
+	// DefaultCaptureMaxFileSize is the default maximum size of the capture file.
+	DefaultCaptureMaxFileSize = 1024 * 1024 * 1024 // 1 GiB
+)
+
+// DefaultCaptureRedact is the list of body types whose contents are redacted by default as they
+// may contain sensitive data (e.g. confidential RPC calls, runtime local storage or attestation
+// material).
+var DefaultCaptureRedact = []string{
+	"RuntimeCapabilityTEERakInitRequest",
+	"RuntimeCapabilityTEERakReportResponse",
+	"RuntimeCapabilityTEERakAvrRequest",
+	"RuntimeCapabilityTEERakQuoteRequest",
+	"RuntimeCapabilityTEERakQuoteResponse",
+	"RuntimeCapabilityTEEUpdateEndorsementRequest",
+	"RuntimeRPCCallRequest",
+	"RuntimeRPCCallResponse",
+	"RuntimeLocalRPCCallRequest",
+	"RuntimeLocalRPCCallResponse",
+	"HostRPCCallRequest",
+	"HostRPCCallResponse",
+	"HostLocalStorageGetRequest",
+	"HostLocalStorageGetResponse",
+	"HostLocalStorageSetRequest",
+}
+
+// CaptureDirection is the direction of a captured message.
+type CaptureDirection uint8
+
+const (
+	// CaptureDirectionSent indicates a message sent by the capturing side.
+	CaptureDirectionSent CaptureDirection = 0
+	// CaptureDirectionReceived indicates a message received by the capturing side.
+	CaptureDirectionReceived CaptureDirection = 1
+)
+
+// String returns a string representation of a capture direction.
+func (d CaptureDirection) String() string {
+	switch d {
+	case CaptureDirectionSent:
+		return "sent"
+	case CaptureDirectionReceived:
+		return "received"
+	default:
+		return fmt.Sprintf("[malformed: %d]", d)
+	}
+}
+
+// CaptureConfig is the Runtime Host Protocol capture configuration.
+type CaptureConfig struct {
+	// Path is the path to the capture file. Records are appended in case the file exists.
+	Path string
+	// MaxBodySize is the maximum size (in bytes) of a captured message body. Larger bodies are
+	// omitted from the capture and only their type and size are recorded. Zero means no limit.
+	MaxBodySize uint64
+	// MaxFileSize is the maximum size (in bytes) of the capture file. Once reached, no further
+	// messages are captured. Zero means DefaultCaptureMaxFileSize.
+	MaxFileSize uint64
+	// Redact is a list of additional body types whose contents are never captured. Only the
+	// type and size of such bodies is recorded. Types in DefaultCaptureRedact are always
+	// redacted unless listed in Unredact.
+	Redact []string
+	// Unredact is a list of body types from DefaultCaptureRedact whose contents should be
+	// captured.
+	Unredact []string
+}
+
+// CaptureRecord is a single captured Runtime Host Protocol message.
+type CaptureRecord struct {
+	// Timestamp is the time (in nanoseconds since the UNIX epoch) the message was captured at.
+	Timestamp int64 `json:"timestamp"`
+	// Direction is the direction of the message.
+	Direction CaptureDirection `json:"direction"`
+	// ID is the message identifier.
+	ID uint64 `json:"id"`
+	// MessageType is the message type.
+	MessageType MessageType `json:"message_type"`
+	// BodyType is the type of the message body.
+	BodyType string `json:"body_type"`
+	// BodySize is the size of the CBOR-encoded message body.
+	BodySize uint64 `json:"body_size"`
+	// Body is the CBOR-encoded message body. It is omitted in case the body has been redacted or
+	// exceeds the maximum captured body size.
+	Body cbor.RawMessage `json:"body,omitempty"`
+	// Redacted is true iff the body has been omitted because its type is redacted.
+	Redacted bool `json:"redacted,omitempty"`
+	// Truncated is true iff the body has been omitted because it is too large.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// Capture records Runtime Host Protocol messages into a capture file.
+//
+// The capture file is a sequence of length-prefixed CBOR-encoded CaptureRecord structures which
+// can be read back using a CaptureReader.
+type Capture struct {
+	sync.Mutex
+
+	cfg    CaptureConfig
+	redact map[string]bool
+
+	f       *os.File
+	size    uint64
+	stopped bool
+
+	logger *logging.Logger
+}
+
+// NewCapture opens the capture file configured in the given capture configuration.
+func NewCapture(cfg CaptureConfig, logger *logging.Logger) (*Capture, error) {
+	f, err := os.OpenFile(cfg.Path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("rhp: failed to open capture file: %w", err)
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("rhp: failed to stat capture file: %w", err)
+	}
+
+	if cfg.MaxFileSize == 0 {
+		cfg.MaxFileSize = DefaultCaptureMaxFileSize
+	}
+
+	redact := make(map[string]bool, len(DefaultCaptureRedact)+len(cfg.Redact))
+	for _, bodyType := range DefaultCaptureRedact {
+		redact[bodyType] = true
+	}
+	for _, bodyType := range cfg.Unredact {
+		delete(redact, bodyType)
+	}
+	for _, bodyType := range cfg.Redact {
+		redact[bodyType] = true
+	}
+
+	return &Capture{
+		cfg:    cfg,
+		redact: redact,
+		f:      f,
+		size:   uint64(fi.Size()),
+		logger: logger,
+	}, nil
+}
+
+func (c *Capture) newRecord(dir CaptureDirection, msg *Message) *CaptureRecord {
+	body := cbor.Marshal(msg.Body)
+	rec := &CaptureRecord{
+		Timestamp:   time.Now().UnixNano(),
+		Direction:   dir,
+		ID:          msg.ID,
+		MessageType: msg.MessageType,
+		BodyType:    msg.Body.Type(),
+		BodySize:    uint64(len(body)),
+	}
+	switch {
+	case c.redact[rec.BodyType]:
+		rec.Redacted = true
+	case c.cfg.MaxBodySize > 0 && rec.BodySize > c.cfg.MaxBodySize:
+		rec.Truncated = true
+	default:
+		rec.Body = body
+	}
+	return rec
+}
+
+// record appends the given message to the capture file.
+func (c *Capture) record(dir CaptureDirection, msg *Message) {
+	if c == nil {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	if c.stopped {
+		return
+	}
+
+	data := cbor.Marshal(c.newRecord(dir, msg))
+	if len(data) > maxCaptureRecordSize {
+		c.logger.Warn("capture record too large, skipping",
+			"size", len(data),
+		)
+		return
+	}
+	if c.size+4+uint64(len(data)) > c.cfg.MaxFileSize {
+		c.logger.Warn("capture file size limit reached, stopping capture",
+			"path", c.cfg.Path,
+		)
+		c.stopped = true
+		return
+	}
+
+	// Write the length prefix and the record at once to keep records from concurrent writers to
+	// the same file intact.
+	buf := make([]byte, 4, 4+len(data))
+	binary.BigEndian.PutUint32(buf, uint32(len(data)))
+	buf = append(buf, data...)
+	if _, err := c.f.Write(buf); err != nil {
+		c.logger.Error("failed to write capture record, stopping capture",
+			"err", err,
+		)
+		c.stopped = true
+		return
+	}
+	c.size += uint64(len(buf))
+}
+
+// Close closes the capture file.
+func (c *Capture) Close() {
+	if c == nil {
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+
+	if c.f == nil {
+		return
+	}
+	if err := c.f.Close(); err != nil {
+		c.logger.Error("failed to close capture file",
+			"err", err,
+		)
+	}
+	c.f = nil
+	c.stopped = true
+}
+
+// CaptureReader reads records from a capture file.
+type CaptureReader struct {
+	r io.Reader
+}
+
+// NewCaptureReader creates a new capture file reader.
+func NewCaptureReader(r io.Reader) *CaptureReader {
+	return &CaptureReader{r: r}
+}
+
+// Read reads the next record from the capture file. It returns io.EOF when there are no more
+// records.
+func (cr *CaptureReader) Read() (*CaptureRecord, error) {
+	var rawLength [4]byte
+	if _, err := io.ReadFull(cr.r, rawLength[:]); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("rhp: truncated capture record length")
+		}
+		return nil, err
+	}
+	length := binary.BigEndian.Uint32(rawLength[:])
+	if length > maxCaptureRecordSize {
+		return nil, fmt.Errorf("rhp: capture record too large (size: %d)", length)
+	}
+
+	data := make([]byte, length)
+	if _, err := io.ReadFull(cr.r, data); err != nil {
+		return nil, fmt.Errorf("rhp: truncated capture record: %w", err)
+	}
+
+	var rec CaptureRecord
+	if err := cbor.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("rhp: malformed capture record: %w", err)
+	}
+	return &rec, nil
+}
+
+// DecodeBody decodes the captured message body.
+//
+// In strict mode decoding fails in case the body contains fields that are not known to this
+// version of the protocol, which usually indicates an incompatibility between the node and the
+// runtime. Otherwise such fields are ignored, the same as when handling messages.
+func (rec *CaptureRecord) DecodeBody(strict bool) (*Body, error) {
+	if rec.Body == nil {
+		return nil, fmt.Errorf("rhp: body not captured")
+	}
+
+	unmarshal := cbor.UnmarshalRPC
+	if strict {
+		unmarshal = cbor.Unmarshal
+	}
+
+	var body Body
+	if err := unmarshal(rec.Body, &body); err != nil {
+		return nil, fmt.Errorf("rhp: malformed body: %w", err)
+	}
+	return &body, nil
+}
@@ -0,0 +1,134 @@
+package protocol
+
+import (
+	"context"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+)
+
+func readCapture(t *testing.T, path string) []*CaptureRecord {
+	f, err := os.Open(path)
+	require.NoError(t, err, "Open")
+	defer f.Close()
+
+	var records []*CaptureRecord
+	cr := NewCaptureReader(f)
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			return records
+		}
+		require.NoError(t, err, "Read")
+		records = append(records, rec)
+	}
+}
+
+func TestCapture(t *testing.T) {
+	require := require.New(t)
+	runtimeID := common.NewTestNamespaceFromSeed([]byte("test conn"), 0)
+	logger := logging.GetLogger("test")
+	path := filepath.Join(t.TempDir(), "capture.bin")
+
+	capture, err := NewCapture(CaptureConfig{
+		Path:        path,
+		MaxBodySize: 1024,
+		Redact:      []string{"RuntimeQueryRequest"},
+		Unredact:    []string{"RuntimeRPCCallRequest"},
+	}, logger)
+	require.NoError(err, "NewCapture")
+
+	connA, connB := net.Pipe()
+	protoA, err := NewConnection(logger, runtimeID, &testHandler{})
+	require.NoError(err, "A.New()")
+	protoB, err := NewConnection(logger, runtimeID, &testHandler{}, WithCapture(capture))
+	require.NoError(err, "B.New()")
+
+	err = protoA.InitGuest(connA)
+	require.NoError(err, "A.InitGuest()")
+	_, err = protoB.InitHost(context.Background(), connB, &HostInfo{})
+	require.NoError(err, "B.InitHost()")
+
+	for _, body := range []*Body{
+		{RuntimeRPCCallRequest: &RuntimeRPCCallRequest{Request: []byte("small")}},
+		{RuntimeRPCCallRequest: &RuntimeRPCCallRequest{Request: make([]byte, 2048)}},
+		{RuntimeLocalRPCCallRequest: &RuntimeLocalRPCCallRequest{Request: []byte("secret")}},
+		{RuntimeQueryRequest: &RuntimeQueryRequest{Method: "secret"}},
+	} {
+		_, err = protoB.Call(context.Background(), body)
+		require.NoError(err, "B.Call()")
+	}
+
+	protoA.Close()
+	protoB.Close()
+
+	records := readCapture(t, path)
+	require.Len(records, 10, "all messages should be captured")
+
+	// Initialization.
+	require.Equal(CaptureDirectionSent, records[0].Direction)
+	require.Equal(MessageRequest, records[0].MessageType)
+	require.Equal("RuntimeInfoRequest", records[0].BodyType)
+	require.Equal(CaptureDirectionReceived, records[1].Direction)
+	require.Equal(MessageResponse, records[1].MessageType)
+	require.Equal("RuntimeInfoResponse", records[1].BodyType)
+	require.Equal(records[0].ID, records[1].ID)
+
+	// Small body is captured.
+	require.Equal("RuntimeRPCCallRequest", records[2].BodyType)
+	require.False(records[2].Truncated)
+	body, err := records[2].DecodeBody(true)
+	require.NoError(err, "DecodeBody")
+	require.Equal([]byte("small"), body.RuntimeRPCCallRequest.Request)
+
+	// Large body is omitted.
+	require.Equal("RuntimeRPCCallRequest", records[4].BodyType)
+	require.True(records[4].Truncated)
+	require.Greater(records[4].BodySize, uint64(2048))
+	_, err = records[4].DecodeBody(false)
+	require.Error(err, "DecodeBody should fail for omitted bodies")
+
+	// Bodies redacted by default are omitted.
+	require.Equal("RuntimeLocalRPCCallRequest", records[6].BodyType)
+	require.True(records[6].Redacted)
+	require.Nil(records[6].Body)
+
+	// Explicitly redacted body is omitted.
+	require.Equal("RuntimeQueryRequest", records[8].BodyType)
+	require.True(records[8].Redacted)
+	require.Nil(records[8].Body)
+}
+
+func TestCaptureMaxFileSize(t *testing.T) {
+	require := require.New(t)
+	logger := logging.GetLogger("test")
+	path := filepath.Join(t.TempDir(), "capture.bin")
+
+	capture, err := NewCapture(CaptureConfig{
+		Path:        path,
+		MaxFileSize: 100,
+	}, logger)
+	require.NoError(err, "NewCapture")
+
+	msg := &Message{ID: 1, MessageType: MessageRequest, Body: Body{Empty: &Empty{}}}
+	for i := 0; i < 10; i++ {
+		capture.record(CaptureDirectionSent, msg)
+	}
+	capture.Close()
+	capture.Close()
+
+	fi, err := os.Stat(path)
+	require.NoError(err, "Stat")
+	require.LessOrEqual(fi.Size(), int64(100), "capture file should not exceed the limit")
+
+	records := readCapture(t, path)
+	require.NotEmpty(records)
+	require.Less(len(records), 10)
+}
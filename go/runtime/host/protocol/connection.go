@@ -15,7 +15,6 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
-	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/metrics"
 )
 
 const (
@@ -221,9 +220,22 @@ type connection struct { // nolint: maligned
 	closeCh chan struct{}
 	quitWg  sync.WaitGroup
 
+	capture *Capture
+
 	logger *logging.Logger
 }
 
+// ConnectionOption is an option that can be used when creating a connection.
+type ConnectionOption func(c *connection)
+
+// WithCapture configures the connection to record all sent and received messages into the given
+// capture. The connection takes ownership of the capture and closes it when closed.
+func WithCapture(capture *Capture) ConnectionOption {
+	return func(c *connection) {
+		c.capture = capture
+	}
+}
+
 func (c *connection) getState() state {
 	c.RLock()
 	s := c.state
@@ -268,6 +280,7 @@ func (c *connection) Close() {
 	c.Lock()
 	if c.state != stateReady && c.state != stateInitializing {
 		c.Unlock()
+		c.capture.Close()
 		return
 	}
 
@@ -282,6 +295,8 @@ func (c *connection) Close() {
 
 	// Wait for all the connection-handling goroutines to terminate.
 	c.quitWg.Wait()
+
+	c.capture.Close()
 }
 
 // Implements Connection.
@@ -308,18 +323,16 @@ func (c *connection) Call(ctx context.Context, body *Body) (*Body, error) {
 func (c *connection) call(ctx context.Context, body *Body) (result *Body, err error) {
 	start := time.Now()
 	defer func() {
-		if metrics.Enabled() {
-			rhpLatency.With(prometheus.Labels{"call": body.Type()}).Observe(time.Since(start).Seconds())
-			if err != nil {
-				rhpCallFailures.With(prometheus.Labels{"call": body.Type()}).Inc()
-
-				// Specifically measure timeouts.
-				if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
-					rhpCallTimeouts.Inc()
-				}
-			} else {
-				rhpCallSuccesses.With(prometheus.Labels{"call": body.Type()}).Inc()
+		rhpLatency.With(prometheus.Labels{"call": body.Type()}).Observe(time.Since(start).Seconds())
+		if err != nil {
+			rhpCallFailures.With(prometheus.Labels{"call": body.Type()}).Inc()
+
+			// Specifically measure timeouts.
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				rhpCallTimeouts.Inc()
 			}
+		} else {
+			rhpCallSuccesses.With(prometheus.Labels{"call": body.Type()}).Inc()
 		}
 	}()
 
@@ -395,6 +408,7 @@ func (c *connection) workerOutgoing() {
 				)
 			}
 			// Outgoing message, send it.
+			c.capture.record(CaptureDirectionSent, msg)
 			if err := c.codec.Write(msg); err != nil {
 				c.logger.Error("error while sending message",
 					"err", err,
@@ -499,6 +513,7 @@ func (c *connection) workerIncoming() {
 			)
 			break
 		}
+		c.capture.record(CaptureDirectionReceived, &message)
 
 		// Handle message in a separate goroutine.
 		wg.Add(1)
@@ -603,7 +618,12 @@ func (c *connection) InitHost(ctx context.Context, conn net.Conn, hi *HostInfo)
 }
 
 // NewConnection creates a new uninitialized RHP connection.
-func NewConnection(logger *logging.Logger, runtimeID common.Namespace, handler Handler) (Connection, error) {
+func NewConnection(
+	logger *logging.Logger,
+	runtimeID common.Namespace,
+	handler Handler,
+	opts ...ConnectionOption,
+) (Connection, error) {
 	metricsOnce.Do(func() {
 		prometheus.MustRegister(rhpCollectors...)
 	})
@@ -618,6 +638,9 @@ func NewConnection(logger *logging.Logger, runtimeID common.Namespace, handler H
 		closeCh:         make(chan struct{}),
 		logger:          logger,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
 
 	return c, nil
 }
@@ -105,36 +105,46 @@ type Body struct {
 	RuntimeConsensusSyncResponse                  *Empty                                        `json:",omitempty"`
 	RuntimeNotifyRequest                          *RuntimeNotifyRequest                         `json:",omitempty"`
 	RuntimeNotifyResponse                         *Empty                                        `json:",omitempty"`
+	RuntimeBuildAppRegistrationRequest            *RuntimeBuildAppRegistrationRequest           `json:",omitempty"`
+	RuntimeBuildAppRegistrationResponse           *RuntimeBuildAppRegistrationResponse          `json:",omitempty"`
 
 	// Host interface.
-	HostRPCCallRequest               *HostRPCCallRequest               `json:",omitempty"`
-	HostRPCCallResponse              *HostRPCCallResponse              `json:",omitempty"`
-	HostSubmitPeerFeedbackRequest    *HostSubmitPeerFeedbackRequest    `json:",omitempty"`
-	HostSubmitPeerFeedbackResponse   *Empty                            `json:",omitempty"`
-	HostStorageSyncRequest           *HostStorageSyncRequest           `json:",omitempty"`
-	HostStorageSyncResponse          *HostStorageSyncResponse          `json:",omitempty"`
-	HostLocalStorageGetRequest       *HostLocalStorageGetRequest       `json:",omitempty"`
-	HostLocalStorageGetResponse      *HostLocalStorageGetResponse      `json:",omitempty"`
-	HostLocalStorageSetRequest       *HostLocalStorageSetRequest       `json:",omitempty"`
-	HostLocalStorageSetResponse      *Empty                            `json:",omitempty"`
-	HostFetchConsensusBlockRequest   *HostFetchConsensusBlockRequest   `json:",omitempty"`
-	HostFetchConsensusBlockResponse  *HostFetchConsensusBlockResponse  `json:",omitempty"`
-	HostFetchConsensusEventsRequest  *HostFetchConsensusEventsRequest  `json:",omitempty"`
-	HostFetchConsensusEventsResponse *HostFetchConsensusEventsResponse `json:",omitempty"`
-	HostFetchTxBatchRequest          *HostFetchTxBatchRequest          `json:",omitempty"`
-	HostFetchTxBatchResponse         *HostFetchTxBatchResponse         `json:",omitempty"`
-	HostFetchGenesisHeightRequest    *HostFetchGenesisHeightRequest    `json:",omitempty"`
-	HostFetchGenesisHeightResponse   *HostFetchGenesisHeightResponse   `json:",omitempty"`
-	HostFetchBlockMetadataTxRequest  *HostFetchBlockMetadataTxRequest  `json:",omitempty"`
-	HostFetchBlockMetadataTxResponse *HostFetchBlockMetadataTxResponse `json:",omitempty"`
-	HostProveFreshnessRequest        *HostProveFreshnessRequest        `json:",omitempty"`
-	HostProveFreshnessResponse       *HostProveFreshnessResponse       `json:",omitempty"`
-	HostIdentityRequest              *HostIdentityRequest              `json:",omitempty"`
-	HostIdentityResponse             *HostIdentityResponse             `json:",omitempty"`
-	HostSubmitTxRequest              *HostSubmitTxRequest              `json:",omitempty"`
-	HostSubmitTxResponse             *HostSubmitTxResponse             `json:",omitempty"`
-	HostRegisterNotifyRequest        *HostRegisterNotifyRequest        `json:",omitempty"`
-	HostRegisterNotifyResponse       *Empty                            `json:",omitempty"`
+	HostRPCCallRequest                   *HostRPCCallRequest                   `json:",omitempty"`
+	HostRPCCallResponse                  *HostRPCCallResponse                  `json:",omitempty"`
+	HostSubmitPeerFeedbackRequest        *HostSubmitPeerFeedbackRequest        `json:",omitempty"`
+	HostSubmitPeerFeedbackResponse       *Empty                                `json:",omitempty"`
+	HostStorageSyncRequest               *HostStorageSyncRequest               `json:",omitempty"`
+	HostStorageSyncResponse              *HostStorageSyncResponse              `json:",omitempty"`
+	HostLocalStorageGetRequest           *HostLocalStorageGetRequest           `json:",omitempty"`
+	HostLocalStorageGetResponse          *HostLocalStorageGetResponse          `json:",omitempty"`
+	HostLocalStorageSetRequest           *HostLocalStorageSetRequest           `json:",omitempty"`
+	HostLocalStorageSetResponse          *Empty                                `json:",omitempty"`
+	HostFetchConsensusBlockRequest       *HostFetchConsensusBlockRequest       `json:",omitempty"`
+	HostFetchConsensusBlockResponse      *HostFetchConsensusBlockResponse      `json:",omitempty"`
+	HostFetchConsensusBlockRangeRequest  *HostFetchConsensusBlockRangeRequest  `json:",omitempty"`
+	HostFetchConsensusBlockRangeResponse *HostFetchConsensusBlockRangeResponse `json:",omitempty"`
+	HostFetchConsensusEventsRequest      *HostFetchConsensusEventsRequest      `json:",omitempty"`
+	HostFetchConsensusEventsResponse     *HostFetchConsensusEventsResponse     `json:",omitempty"`
+	HostFetchConsensusStateProofRequest  *HostFetchConsensusStateProofRequest  `json:",omitempty"`
+	HostFetchConsensusStateProofResponse *HostFetchConsensusStateProofResponse `json:",omitempty"`
+	HostFetchTxBatchRequest              *HostFetchTxBatchRequest              `json:",omitempty"`
+	HostFetchTxBatchResponse             *HostFetchTxBatchResponse             `json:",omitempty"`
+	HostFetchGenesisHeightRequest        *HostFetchGenesisHeightRequest        `json:",omitempty"`
+	HostFetchGenesisHeightResponse       *HostFetchGenesisHeightResponse       `json:",omitempty"`
+	HostFetchBlockMetadataTxRequest      *HostFetchBlockMetadataTxRequest      `json:",omitempty"`
+	HostFetchBlockMetadataTxResponse     *HostFetchBlockMetadataTxResponse     `json:",omitempty"`
+	HostProveFreshnessRequest            *HostProveFreshnessRequest            `json:",omitempty"`
+	HostProveFreshnessResponse           *HostProveFreshnessResponse           `json:",omitempty"`
+	HostIdentityRequest                  *HostIdentityRequest                  `json:",omitempty"`
+	HostIdentityResponse                 *HostIdentityResponse                 `json:",omitempty"`
+	HostSubmitTxRequest                  *HostSubmitTxRequest                  `json:",omitempty"`
+	HostSubmitTxResponse                 *HostSubmitTxResponse                 `json:",omitempty"`
+	HostRegisterNotifyRequest            *HostRegisterNotifyRequest            `json:",omitempty"`
+	HostRegisterNotifyResponse           *Empty                                `json:",omitempty"`
+	HostReportTelemetryRequest           *HostReportTelemetryRequest           `json:",omitempty"`
+	HostReportTelemetryResponse          *Empty                                `json:",omitempty"`
+	HostRegisterAppRequest               *HostRegisterAppRequest               `json:",omitempty"`
+	HostRegisterAppResponse              *HostRegisterAppResponse              `json:",omitempty"`
 }
 
 // Type returns the message type by determining the name of the first non-nil member.
@@ -568,6 +578,20 @@ type HostFetchConsensusBlockResponse struct {
 	Block consensus.LightBlock `json:"block"`
 }
 
+// HostFetchConsensusBlockRangeRequest is a request to host to fetch a batch of consensus light
+// blocks in the given height range.
+type HostFetchConsensusBlockRangeRequest struct {
+	StartHeight uint64 `json:"start_height"`
+	EndHeight   uint64 `json:"end_height"`
+}
+
+// HostFetchConsensusBlockRangeResponse is a response from host fetching a batch of consensus light
+// blocks, with validator sets shared between the light blocks stored only once.
+type HostFetchConsensusBlockRangeResponse struct {
+	Blocks        []*consensus.CompactLightBlock `json:"blocks"`
+	ValidatorSets [][]byte                       `json:"validator_sets"`
+}
+
 // EventKind is the consensus event kind.
 type EventKind uint8
 
@@ -592,6 +616,25 @@ type HostFetchConsensusEventsResponse struct {
 	Events []*consensusResults.Event `json:"events,omitempty"`
 }
 
+// HostFetchConsensusStateProofRequest is a request to host to fetch proofs of the given registry
+// or staking consensus state keys at the given height.
+type HostFetchConsensusStateProofRequest struct {
+	// Height is the consensus height at which the state should be proven.
+	Height uint64 `json:"height"`
+	// Keys are the raw consensus state keys that should be proven.
+	Keys [][]byte `json:"keys"`
+}
+
+// HostFetchConsensusStateProofResponse is a response from host fetching proofs of the given
+// consensus state keys.
+type HostFetchConsensusStateProofResponse struct {
+	// StateRoot is the consensus state root at the requested height against which the proofs
+	// should be verified. The runtime must check it against a verified light block.
+	StateRoot hash.Hash `json:"state_root"`
+	// Proofs are the proofs of the requested keys, in the same order as the keys.
+	Proofs []*storage.Proof `json:"proofs"`
+}
+
 // HostFetchGenesisHeightRequest is a request to host to fetch the consensus genesis height.
 type HostFetchGenesisHeightRequest struct{}
 
@@ -653,3 +696,24 @@ type HostIdentityResponse struct {
 	// NodeID is the host node identifier.
 	NodeID signature.PublicKey `json:"node_id"`
 }
+
+// HostReportTelemetryRequest is a request to host to record the runtime's internal telemetry.
+type HostReportTelemetryRequest struct {
+	// Telemetry is the runtime's internal telemetry.
+	Telemetry RuntimeTelemetry `json:"telemetry"`
+}
+
+// RuntimeTelemetry is the internal telemetry reported by a runtime.
+type RuntimeTelemetry struct {
+	// HeapUsed is the number of heap bytes currently in use.
+	HeapUsed uint64 `json:"heap_used,omitempty"`
+	// HeapCapacity is the total heap size in bytes or zero when unknown.
+	HeapCapacity uint64 `json:"heap_capacity,omitempty"`
+	// StackUsed is the peak number of stack bytes used.
+	StackUsed uint64 `json:"stack_used,omitempty"`
+	// StackCapacity is the total stack size in bytes or zero when unknown.
+	StackCapacity uint64 `json:"stack_capacity,omitempty"`
+	// QueueDepths are the current depths of the runtime's internal request queues, keyed by
+	// queue name.
+	QueueDepths map[string]uint64 `json:"queue_depths,omitempty"`
+}
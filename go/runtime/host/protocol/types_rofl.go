@@ -1,7 +1,9 @@
 package protocol
 
 import (
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
 )
@@ -57,3 +59,38 @@ type RuntimeNotifyRequest struct {
 	// RuntimeEvent notifies about a specific runtime event being emitted.
 	RuntimeEvent *RuntimeNotifyEvent `json:"runtime_event,omitempty"`
 }
+
+// HostRegisterAppRequest is a request to host to register the ROFL app instance and to keep the
+// registration up to date for as long as the component is running.
+type HostRegisterAppRequest struct {
+	// RuntimeID is the identifier of the runtime in which the app instance is registered.
+	RuntimeID common.Namespace `json:"runtime_id"`
+	// ValidityEpochs is the number of epochs for which each registration remains valid.
+	ValidityEpochs beacon.EpochTime `json:"validity_epochs"`
+}
+
+// HostRegisterAppResponse is a response from host on app instance registration.
+type HostRegisterAppResponse struct {
+	// Expiration is the epoch at which the registration expires unless renewed.
+	Expiration beacon.EpochTime `json:"expiration"`
+	// Output is the output of the registration transaction.
+	Output []byte `json:"output,omitempty"`
+	// Round is the roothash round in which the registration transaction was executed.
+	Round uint64 `json:"round,omitempty"`
+}
+
+// RuntimeBuildAppRegistrationRequest is a request to the ROFL component to build a signed app
+// instance registration transaction.
+type RuntimeBuildAppRegistrationRequest struct {
+	// EndorsedCapabilityTEE is the component's TEE capability endorsed by the host node.
+	EndorsedCapabilityTEE node.EndorsedCapabilityTEE `json:"ect"` //nolint: misspell
+	// Expiration is the epoch at which the registration should expire.
+	Expiration beacon.EpochTime `json:"expiration"`
+}
+
+// RuntimeBuildAppRegistrationResponse is a response from the ROFL component containing the
+// registration transaction.
+type RuntimeBuildAppRegistrationResponse struct {
+	// Tx is the signed raw registration transaction.
+	Tx []byte `json:"tx"`
+}
@@ -0,0 +1,289 @@
+// Package replay implements recording and deterministic replay of runtime calls.
+//
+// A recording captures a single call into the runtime (e.g., batch execution for a round)
+// together with all of the host calls (storage reads via proofs, consensus queries, ...) that
+// the runtime made while processing it. The recording can later be replayed against a runtime
+// binary without access to the network, which makes it possible to debug discrepancies locally.
+package replay
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	cmnErrors "github.com/oasisprotocol/oasis-core/go/common/errors"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+)
+
+// recordVersion is the version of the replay record format.
+const recordVersion = 1
+
+// ErrNotRecorded is the error returned during replay when the runtime makes a host call that
+// has not been recorded.
+var ErrNotRecorded = errors.New("replay: host call not recorded")
+
+// HostCall is a recorded host call made by the runtime.
+type HostCall struct {
+	// Request is the request made by the runtime.
+	Request *protocol.Body `json:"request"`
+	// Response is the response returned by the host.
+	Response *protocol.Body `json:"response,omitempty"`
+	// Error is the error returned by the host, if any.
+	Error *protocol.Error `json:"error,omitempty"`
+}
+
+// Record is a recording of a single call into the runtime.
+type Record struct {
+	cbor.Versioned
+
+	// RuntimeID is the identifier of the runtime.
+	RuntimeID common.Namespace `json:"runtime_id"`
+	// RuntimeVersion is the version of the runtime that processed the call.
+	RuntimeVersion version.Version `json:"runtime_version"`
+	// Round is the runtime round the call was made for.
+	Round uint64 `json:"round"`
+	// HostInfo is the host environment information that was passed to the runtime.
+	HostInfo *protocol.HostInfo `json:"host_info,omitempty"`
+
+	// Request is the request sent to the runtime.
+	Request *protocol.Body `json:"request"`
+	// Response is the response returned by the runtime.
+	Response *protocol.Body `json:"response,omitempty"`
+	// Error is the error returned by the runtime, if any.
+	Error *protocol.Error `json:"error,omitempty"`
+
+	// HostCalls are all of the host calls the runtime made while processing the request.
+	HostCalls []*HostCall `json:"host_calls"`
+}
+
+// WriteFile writes the record to the given file.
+func (r *Record) WriteFile(fn string) error {
+	if err := common.Mkdir(filepath.Dir(fn)); err != nil {
+		return err
+	}
+	return os.WriteFile(fn, cbor.Marshal(r), 0o600)
+}
+
+// RecordPath returns the path of the record for the given runtime round under the given
+// directory.
+func RecordPath(dir string, runtimeID common.Namespace, round uint64) string {
+	return filepath.Join(dir, runtimeID.String(), fmt.Sprintf("%d.replay", round))
+}
+
+// Prune removes all records for the given runtime under the given directory that are for rounds
+// less than or equal to the given round.
+func Prune(dir string, runtimeID common.Namespace, round uint64) error {
+	rtDir := filepath.Join(dir, runtimeID.String())
+	entries, err := os.ReadDir(rtDir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		var recRound uint64
+		if _, err = fmt.Sscanf(entry.Name(), "%d.replay", &recRound); err != nil {
+			continue
+		}
+		if recRound > round {
+			continue
+		}
+		if err = os.Remove(filepath.Join(rtDir, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadFile reads a record from the given file.
+func ReadFile(fn string) (*Record, error) {
+	data, err := os.ReadFile(fn)
+	if err != nil {
+		return nil, err
+	}
+	var r Record
+	if err = cbor.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("replay: malformed record: %w", err)
+	}
+	if r.V != recordVersion {
+		return nil, fmt.Errorf("replay: unsupported record version: %d", r.V)
+	}
+	if r.Request == nil {
+		return nil, fmt.Errorf("replay: record is missing the runtime request")
+	}
+	return &r, nil
+}
+
+// Compare compares the response of a replayed call with the recorded response and returns an
+// error describing the discrepancy if they differ.
+func (r *Record) Compare(rsp *protocol.Body, err error) error {
+	replayedRsp, replayedErr := errorFromBody(rsp, err)
+
+	switch {
+	case r.Error != nil && replayedErr != nil:
+		if r.Error.Module != replayedErr.Module || r.Error.Code != replayedErr.Code {
+			return fmt.Errorf("replay: error mismatch (recorded: %s replayed: %s)", r.Error, replayedErr)
+		}
+		return nil
+	case r.Error != nil:
+		return fmt.Errorf("replay: recorded call failed (%s) but replayed call succeeded", r.Error)
+	case replayedErr != nil:
+		return fmt.Errorf("replay: recorded call succeeded but replayed call failed (%s)", replayedErr)
+	}
+
+	if !bytes.Equal(cbor.Marshal(r.Response), cbor.Marshal(replayedRsp)) {
+		return fmt.Errorf("replay: response mismatch")
+	}
+	return nil
+}
+
+func errorFromBody(body *protocol.Body, err error) (*protocol.Body, *protocol.Error) {
+	if err == nil {
+		return body, nil
+	}
+	module, code := cmnErrors.Code(err)
+	return nil, &protocol.Error{
+		Module:  module,
+		Code:    code,
+		Message: err.Error(),
+	}
+}
+
+// Recorder is a runtime host handler that can record the host calls made by the runtime.
+type Recorder struct {
+	host.RuntimeHandler
+
+	callLock sync.Mutex
+
+	mu     sync.Mutex
+	active *Record
+}
+
+// Implements protocol.Handler.
+func (r *Recorder) Handle(ctx context.Context, body *protocol.Body) (*protocol.Body, error) {
+	rsp, err := r.RuntimeHandler.Handle(ctx, body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.active != nil {
+		hc := &HostCall{Request: body}
+		hc.Response, hc.Error = errorFromBody(rsp, err)
+		r.active.HostCalls = append(r.active.HostCalls, hc)
+	}
+
+	return rsp, err
+}
+
+// Call performs the given call into the runtime while recording all of the host calls made by
+// the runtime, returning the recording alongside the runtime's response.
+//
+// Only a single call is recorded at a time. Note that host calls made concurrently by the
+// runtime for other reasons (e.g., queries) will also be included in the recording.
+func (r *Recorder) Call(ctx context.Context, rt host.Runtime, round uint64, body *protocol.Body) (*Record, *protocol.Body, error) {
+	r.callLock.Lock()
+	defer r.callLock.Unlock()
+
+	rec := &Record{
+		Versioned: cbor.NewVersioned(recordVersion),
+		RuntimeID: rt.ID(),
+		Round:     round,
+		Request:   body,
+	}
+	if info, err := rt.GetInfo(ctx); err == nil {
+		rec.RuntimeVersion = info.RuntimeVersion
+	}
+
+	r.mu.Lock()
+	r.active = rec
+	r.mu.Unlock()
+
+	rsp, err := rt.Call(ctx, body)
+
+	r.mu.Lock()
+	r.active = nil
+	r.mu.Unlock()
+
+	rec.Response, rec.Error = errorFromBody(rsp, err)
+
+	return rec, rsp, err
+}
+
+// NewRecorder creates a new recorder wrapping the given runtime host handler.
+func NewRecorder(handler host.RuntimeHandler) *Recorder {
+	return &Recorder{
+		RuntimeHandler: handler,
+	}
+}
+
+// Player is a runtime host handler that serves host calls from a recording.
+type Player struct {
+	mu      sync.Mutex
+	calls   map[string][]*HostCall
+	missing map[string]int
+}
+
+// Implements host.RuntimeHandler.
+func (p *Player) NewSubHandler(host.CompositeRuntime, *bundle.Component) (host.RuntimeHandler, error) {
+	return nil, fmt.Errorf("replay: sub-handlers not supported")
+}
+
+// Implements host.RuntimeHandler.
+func (p *Player) AttachRuntime(host.Runtime) error {
+	return nil
+}
+
+// Implements protocol.Handler.
+func (p *Player) Handle(_ context.Context, body *protocol.Body) (*protocol.Body, error) {
+	key := string(cbor.Marshal(body))
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Identical requests are answered in the order they were recorded, with the last response
+	// being repeated if the runtime makes more such requests than recorded.
+	calls := p.calls[key]
+	if len(calls) == 0 {
+		p.missing[body.Type()]++
+		return nil, fmt.Errorf("%w: %s", ErrNotRecorded, body.Type())
+	}
+	hc := calls[0]
+	if len(calls) > 1 {
+		p.calls[key] = calls[1:]
+	}
+
+	if hc.Error != nil {
+		return nil, cmnErrors.FromCode(hc.Error.Module, hc.Error.Code, hc.Error.Message)
+	}
+	return hc.Response, nil
+}
+
+// NewPlayer creates a new runtime host handler that serves host calls from the given record.
+func NewPlayer(rec *Record) *Player {
+	calls := make(map[string][]*HostCall)
+	for _, hc := range rec.HostCalls {
+		key := string(cbor.Marshal(hc.Request))
+		calls[key] = append(calls[key], hc)
+	}
+	return &Player{
+		calls:   calls,
+		missing: make(map[string]int),
+	}
+}
+
+// Missing returns the number of host calls of each type that the runtime made during replay but
+// which were not present in the recording.
+func (p *Player) Missing() map[string]int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return maps.Clone(p.missing)
+}
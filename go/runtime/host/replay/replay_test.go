@@ -0,0 +1,119 @@
+package replay
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+)
+
+// testHandler is a host handler that answers local storage requests from a map.
+type testHandler struct {
+	values map[string][]byte
+}
+
+func (h *testHandler) NewSubHandler(host.CompositeRuntime, *bundle.Component) (host.RuntimeHandler, error) {
+	return nil, fmt.Errorf("not supported")
+}
+
+func (h *testHandler) AttachRuntime(host.Runtime) error {
+	return nil
+}
+
+func (h *testHandler) Handle(_ context.Context, body *protocol.Body) (*protocol.Body, error) {
+	if body.HostLocalStorageGetRequest == nil {
+		return nil, fmt.Errorf("method not supported")
+	}
+	value, ok := h.values[string(body.HostLocalStorageGetRequest.Key)]
+	if !ok {
+		return nil, fmt.Errorf("key not found")
+	}
+	return &protocol.Body{HostLocalStorageGetResponse: &protocol.HostLocalStorageGetResponse{Value: value}}, nil
+}
+
+// testRuntime is a fake runtime that sums values fetched from local storage.
+type testRuntime struct {
+	host.Runtime
+
+	handler host.RuntimeHandler
+}
+
+func (r *testRuntime) ID() common.Namespace {
+	return common.Namespace{}
+}
+
+func (r *testRuntime) GetInfo(context.Context) (*protocol.RuntimeInfoResponse, error) {
+	return &protocol.RuntimeInfoResponse{RuntimeVersion: version.Version{Major: 1}}, nil
+}
+
+func (r *testRuntime) Call(ctx context.Context, body *protocol.Body) (*protocol.Body, error) {
+	var sum byte
+	for _, key := range []string{"a", "b", "a"} {
+		rsp, err := r.handler.Handle(ctx, &protocol.Body{
+			HostLocalStorageGetRequest: &protocol.HostLocalStorageGetRequest{Key: []byte(key)},
+		})
+		if err != nil {
+			return nil, err
+		}
+		sum += rsp.HostLocalStorageGetResponse.Value[0]
+	}
+	return &protocol.Body{HostLocalStorageGetResponse: &protocol.HostLocalStorageGetResponse{Value: []byte{sum}}}, nil
+}
+
+func TestRecordReplay(t *testing.T) {
+	require := require.New(t)
+
+	recorder := NewRecorder(&testHandler{values: map[string][]byte{"a": {1}, "b": {2}}})
+	rt := &testRuntime{handler: recorder}
+	rq := &protocol.Body{RuntimePingRequest: &protocol.Empty{}}
+
+	// Host calls outside recorded calls should not be recorded.
+	_, err := rt.Call(context.Background(), rq)
+	require.NoError(err)
+
+	rec, rsp, err := recorder.Call(context.Background(), rt, 42, rq)
+	require.NoError(err, "Call")
+	require.Equal([]byte{4}, rsp.HostLocalStorageGetResponse.Value)
+	require.EqualValues(42, rec.Round)
+	require.Equal(version.Version{Major: 1}, rec.RuntimeVersion)
+	require.Len(rec.HostCalls, 3)
+
+	// Round trip through a file.
+	dir := t.TempDir()
+	fn := RecordPath(dir, rec.RuntimeID, rec.Round)
+	require.NoError(rec.WriteFile(fn))
+	rec, err = ReadFile(fn)
+	require.NoError(err, "ReadFile")
+
+	// Replay against the same runtime.
+	player := NewPlayer(rec)
+	rt = &testRuntime{handler: player}
+	rsp, err = rt.Call(context.Background(), rec.Request)
+	require.NoError(rec.Compare(rsp, err), "replay should match the recording")
+	require.Empty(player.Missing())
+
+	// Replay with a diverging response.
+	rsp.HostLocalStorageGetResponse.Value = []byte{5}
+	require.Error(rec.Compare(rsp, nil), "diverging replay should not match the recording")
+
+	// Unrecorded host calls should fail.
+	_, err = player.Handle(context.Background(), &protocol.Body{
+		HostLocalStorageGetRequest: &protocol.HostLocalStorageGetRequest{Key: []byte("c")},
+	})
+	require.ErrorIs(err, ErrNotRecorded)
+	require.Equal(map[string]int{"HostLocalStorageGetRequest": 1}, player.Missing())
+
+	// Pruning should remove old records only.
+	require.NoError((&Record{RuntimeID: rec.RuntimeID, Round: 43}).WriteFile(RecordPath(dir, rec.RuntimeID, 43)))
+	require.NoError(Prune(dir, rec.RuntimeID, 42))
+	require.NoFileExists(fn)
+	require.FileExists(filepath.Join(dir, rec.RuntimeID.String(), "43.replay"))
+}
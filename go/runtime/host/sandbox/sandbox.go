@@ -388,7 +388,20 @@ func (r *sandboxedRuntime) startProcess() (err error) {
 		"pid", p.GetPID(),
 	)
 
-	pc, err := protocol.NewConnection(r.logger, r.id, r.rtCfg.MessageHandler)
+	var connOpts []protocol.ConnectionOption
+	if r.rtCfg.ProtocolCapture != nil {
+		var capture *protocol.Capture
+		if capture, err = protocol.NewCapture(*r.rtCfg.ProtocolCapture, r.logger); err != nil {
+			return fmt.Errorf("failed to create protocol capture: %w", err)
+		}
+		connOpts = append(connOpts, protocol.WithCapture(capture))
+
+		r.logger.Warn("capturing runtime host protocol messages",
+			"path", r.rtCfg.ProtocolCapture.Path,
+		)
+	}
+
+	pc, err := protocol.NewConnection(r.logger, r.id, r.rtCfg.MessageHandler, connOpts...)
 	if err != nil {
 		return fmt.Errorf("failed to create connection: %w", err)
 	}
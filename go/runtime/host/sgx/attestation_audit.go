@@ -0,0 +1,122 @@
+package sgx
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/persistent"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+)
+
+const (
+	attestationAuditLogKeyPrefix = "attestation_audit_log."
+
+	// attestationAuditLogSize is the maximum number of most recent attestation records that are
+	// kept for each runtime.
+	attestationAuditLogSize = 1024
+)
+
+// AttestationMethod is the method used to produce an attestation.
+type AttestationMethod string
+
+const (
+	// AttestationMethodECDSA is the ECDSA-based (DCAP) attestation method.
+	AttestationMethodECDSA AttestationMethod = "ecdsa"
+	// AttestationMethodEPID is the EPID-based (IAS) attestation method.
+	AttestationMethodEPID AttestationMethod = "epid"
+	// AttestationMethodMock is the insecure mock attestation method.
+	AttestationMethodMock AttestationMethod = "mock"
+)
+
+// AttestationRecord is an audit record of an attestation attempt of a runtime hosted by the node.
+type AttestationRecord struct {
+	// Timestamp is the time when the attestation was produced.
+	Timestamp time.Time `json:"timestamp"`
+	// Version is the version of the attested runtime.
+	Version version.Version `json:"version"`
+	// Method is the attestation method.
+	Method AttestationMethod `json:"method,omitempty"`
+	// QuoteHash is the hash of the quote, if one has been obtained.
+	QuoteHash *hash.Hash `json:"quote_hash,omitempty"`
+	// TCBStatus is the TCB status of the platform (or the quote status in case of EPID), if
+	// known.
+	TCBStatus string `json:"tcb_status,omitempty"`
+	// Accepted is true iff the attestation has been successfully produced and accepted by the
+	// runtime.
+	Accepted bool `json:"accepted"`
+	// Error is the error that caused the attestation to fail, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// GetAttestationAuditLog returns the attestation audit log of the given runtime stored in the
+// given common store, ordered from oldest to newest record.
+func GetAttestationAuditLog(commonStore *persistent.CommonStore, runtimeID common.Namespace) ([]*AttestationRecord, error) {
+	log := newAttestationAuditLog(commonStore.GetServiceStore(serviceStoreName), nil)
+	return log.get(runtimeID)
+}
+
+type attestationAuditLog struct {
+	sync.Mutex
+
+	serviceStore *persistent.ServiceStore
+	logger       *logging.Logger
+}
+
+func attestationAuditLogKey(runtimeID common.Namespace) []byte {
+	return []byte(attestationAuditLogKeyPrefix + runtimeID.String())
+}
+
+func (al *attestationAuditLog) get(runtimeID common.Namespace) ([]*AttestationRecord, error) {
+	al.Lock()
+	defer al.Unlock()
+
+	return al.getLocked(runtimeID)
+}
+
+func (al *attestationAuditLog) getLocked(runtimeID common.Namespace) ([]*AttestationRecord, error) {
+	var records []*AttestationRecord
+	switch err := al.serviceStore.GetCBOR(attestationAuditLogKey(runtimeID), &records); err {
+	case nil, persistent.ErrNotFound:
+		return records, nil
+	default:
+		return nil, fmt.Errorf("failed to read attestation audit log: %w", err)
+	}
+}
+
+// append appends the given record to the audit log of the given runtime, discarding the oldest
+// records in case the log is full.
+func (al *attestationAuditLog) append(runtimeID common.Namespace, rec *AttestationRecord) {
+	al.Lock()
+	defer al.Unlock()
+
+	records, err := al.getLocked(runtimeID)
+	if err != nil {
+		al.logger.Error("failed to read attestation audit log, resetting",
+			"err", err,
+			"runtime_id", runtimeID,
+		)
+	}
+
+	records = append(records, rec)
+	if n := len(records); n > attestationAuditLogSize {
+		records = records[n-attestationAuditLogSize:]
+	}
+
+	if err = al.serviceStore.PutCBOR(attestationAuditLogKey(runtimeID), records); err != nil {
+		al.logger.Error("failed to persist attestation audit log",
+			"err", err,
+			"runtime_id", runtimeID,
+		)
+	}
+}
+
+func newAttestationAuditLog(serviceStore *persistent.ServiceStore, logger *logging.Logger) *attestationAuditLog {
+	return &attestationAuditLog{
+		serviceStore: serviceStore,
+		logger:       logger,
+	}
+}
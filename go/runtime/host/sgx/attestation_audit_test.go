@@ -0,0 +1,75 @@
+package sgx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/persistent"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+)
+
+func TestAttestationAuditLog(t *testing.T) {
+	require := require.New(t)
+
+	commonStore, err := persistent.NewCommonStore(t.TempDir())
+	require.NoError(err, "NewCommonStore")
+	defer commonStore.Close()
+
+	auditLog := newAttestationAuditLog(commonStore.GetServiceStore(serviceStoreName), logging.GetLogger(loggerModule))
+
+	var runtimeID1, runtimeID2 common.Namespace
+	require.NoError(runtimeID1.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000001"))
+	require.NoError(runtimeID2.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000002"))
+
+	records, err := GetAttestationAuditLog(commonStore, runtimeID1)
+	require.NoError(err, "GetAttestationAuditLog")
+	require.Empty(records, "audit log should initially be empty")
+
+	quoteHash := hash.NewFromBytes([]byte("quote"))
+	now := time.Unix(1700000000, 0).UTC()
+	auditLog.append(runtimeID1, &AttestationRecord{
+		Timestamp: now,
+		Version:   version.Version{Major: 1},
+		Method:    AttestationMethodECDSA,
+		QuoteHash: &quoteHash,
+		TCBStatus: "UpToDate",
+		Accepted:  true,
+	})
+	auditLog.append(runtimeID1, &AttestationRecord{
+		Timestamp: now.Add(time.Hour),
+		Version:   version.Version{Major: 1},
+		Method:    AttestationMethodECDSA,
+		TCBStatus: "OutOfDate",
+		Error:     "TCB is not up to date",
+	})
+
+	records, err = GetAttestationAuditLog(commonStore, runtimeID1)
+	require.NoError(err, "GetAttestationAuditLog")
+	require.Len(records, 2)
+	require.True(records[0].Timestamp.Equal(now))
+	require.EqualValues(&quoteHash, records[0].QuoteHash)
+	require.True(records[0].Accepted)
+	require.False(records[1].Accepted)
+	require.Equal("OutOfDate", records[1].TCBStatus)
+
+	records, err = GetAttestationAuditLog(commonStore, runtimeID2)
+	require.NoError(err, "GetAttestationAuditLog")
+	require.Empty(records, "audit logs should be kept per runtime")
+
+	// Make sure only the most recent records are kept.
+	for i := 0; i < attestationAuditLogSize; i++ {
+		auditLog.append(runtimeID1, &AttestationRecord{
+			Timestamp: now.Add(time.Duration(i+2) * time.Hour),
+			Accepted:  true,
+		})
+	}
+	records, err = GetAttestationAuditLog(commonStore, runtimeID1)
+	require.NoError(err, "GetAttestationAuditLog")
+	require.Len(records, attestationAuditLogSize)
+	require.True(records[0].Timestamp.Equal(now.Add(2*time.Hour)), "oldest records should be discarded")
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/common/sgx/aesm"
 	"github.com/oasisprotocol/oasis-core/go/common/sgx/pcs"
@@ -23,6 +24,7 @@ type teeStateECDSA struct {
 	key *aesm.AttestationKeyID
 
 	tcbCache *tcbCache
+	platform *platformTracker
 }
 
 func (ec *teeStateECDSA) Init(ctx context.Context, sp *sgxProvisioner, runtimeID common.Namespace, version version.Version) ([]byte, error) {
@@ -64,6 +66,7 @@ func (ec *teeStateECDSA) Init(ctx context.Context, sp *sgxProvisioner, runtimeID
 	ec.key = key
 
 	ec.tcbCache = newTcbCache(sp.serviceStore, sp.logger)
+	ec.platform = newPlatformTracker(sp.serviceStore, sp.logger)
 
 	return targetInfo, nil
 }
@@ -90,11 +93,15 @@ func (ec *teeStateECDSA) verifyBundle(quote pcs.Quote, quotePolicy *pcs.QuotePol
 	}
 }
 
-func (ec *teeStateECDSA) Update(ctx context.Context, sp *sgxProvisioner, conn protocol.Connection, report []byte, _ string) ([]byte, error) {
+func (ec *teeStateECDSA) Update(ctx context.Context, sp *sgxProvisioner, conn protocol.Connection, report []byte, _ string, audit *AttestationRecord) ([]byte, error) {
+	audit.Method = AttestationMethodECDSA
+
 	rawQuote, err := sp.aesm.GetQuoteEx(ctx, ec.key, report)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get quote: %w", err)
 	}
+	quoteHash := hash.NewFromBytes(rawQuote)
+	audit.QuoteHash = &quoteHash
 
 	var quote pcs.Quote
 	if err = quote.UnmarshalBinary(rawQuote); err != nil {
@@ -129,6 +136,17 @@ func (ec *teeStateECDSA) Update(ctx context.Context, sp *sgxProvisioner, conn pr
 		return nil, fmt.Errorf("PCK verification failed: %w", err)
 	}
 
+	// Detect platform changes (e.g., hardware swap or microcode update) and make sure that any
+	// cached TCB information is not reused in that case.
+	platform, err := newPlatformInfo(pckInfo)
+	if err != nil {
+		return nil, err
+	}
+	if ec.platform.check(platform) {
+		updatePlatformChangeMetrics(ec.runtimeID.String())
+		ec.tcbCache.invalidate()
+	}
+
 	// Get current quote policy from the consensus layer.
 	var quotePolicy *pcs.QuotePolicy
 	var policies *sgxQuote.Policy
@@ -163,6 +181,7 @@ func (ec *teeStateECDSA) Update(ctx context.Context, sp *sgxProvisioner, conn pr
 				"err", err,
 				"update", update,
 			)
+			ec.tcbCache.refreshFailed()
 		}
 
 		if err = ec.verifyBundle(quote, quotePolicy, cached, sp, "cached"); err == nil {
@@ -199,8 +218,15 @@ func (ec *teeStateECDSA) Update(ctx context.Context, sp *sgxProvisioner, conn pr
 		}
 	}
 	if err != nil {
+		var tcbErr *pcs.TCBOutOfDateError
+		if errors.As(err, &tcbErr) {
+			audit.TCBStatus = tcbErr.Status.String()
+		}
 		return nil, err
 	}
+	if tcbStatus, tcbErr := tcbBundle.PlatformTCBStatus(time.Now(), quotePolicy, pckInfo); tcbErr == nil {
+		audit.TCBStatus = tcbStatus.String()
+	}
 
 	// Prepare quote structure.
 	q := sgxQuote.Quote{
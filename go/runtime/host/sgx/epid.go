@@ -8,6 +8,7 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	cmnIAS "github.com/oasisprotocol/oasis-core/go/common/sgx/ias"
 	sgxQuote "github.com/oasisprotocol/oasis-core/go/common/sgx/quote"
@@ -41,7 +42,9 @@ func (ep *teeStateEPID) Init(ctx context.Context, sp *sgxProvisioner, runtimeID
 	return qi.TargetInfo, nil
 }
 
-func (ep *teeStateEPID) Update(ctx context.Context, sp *sgxProvisioner, conn protocol.Connection, report []byte, nonce string) ([]byte, error) {
+func (ep *teeStateEPID) Update(ctx context.Context, sp *sgxProvisioner, conn protocol.Connection, report []byte, nonce string, audit *AttestationRecord) ([]byte, error) {
+	audit.Method = AttestationMethodEPID
+
 	// Check if new format of attestations is supported in the consensus layer and use it.
 	regParams, err := sp.consensus.Registry().ConsensusParameters(ctx, consensus.HeightLatest)
 	if err != nil {
@@ -55,7 +58,7 @@ func (ep *teeStateEPID) Update(ctx context.Context, sp *sgxProvisioner, conn pro
 	// not so frequent and this is the only code that uses the IAS clients, so this is good enough.
 	for i := ep.prevIAS; i < ep.prevIAS+len(sp.ias); i++ {
 		idx := i % len(sp.ias)
-		resp, err := ep.update(ctx, sp, conn, report, nonce, supportsAttestationV1, sp.ias[idx])
+		resp, err := ep.update(ctx, sp, conn, report, nonce, supportsAttestationV1, sp.ias[idx], audit)
 		if err == nil {
 			ep.prevIAS = idx
 			return resp, nil
@@ -84,6 +87,7 @@ func (ep *teeStateEPID) update(
 	nonce string,
 	supportsAttestationV1 bool,
 	iasClient ias.Endpoint,
+	audit *AttestationRecord,
 ) ([]byte, error) {
 	// Obtain SPID info.
 	spidInfo, err := iasClient.GetSPIDInfo(ctx)
@@ -108,6 +112,8 @@ func (ep *teeStateEPID) update(
 	if err != nil {
 		return nil, fmt.Errorf("error while getting quote: %w", err)
 	}
+	quoteHash := hash.NewFromBytes(quote)
+	audit.QuoteHash = &quoteHash
 
 	// Get current quote policy from the consensus layer.
 	var quotePolicy *cmnIAS.QuotePolicy
@@ -148,6 +154,7 @@ func (ep *teeStateEPID) update(
 	if decErr != nil {
 		return nil, fmt.Errorf("unable to decode AVR: %w", decErr)
 	}
+	audit.TCBStatus = avr.ISVEnclaveQuoteStatus.String()
 	if avr.TCBEvaluationDataNumber < quotePolicy.MinTCBEvaluationDataNumber {
 		return nil, fmt.Errorf(
 			"AVR TCB data evaluation number invalid (%v < %v)",
@@ -2,10 +2,9 @@ package sgx
 
 import (
 	"sync"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
-
-	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/metrics"
 )
 
 var (
@@ -36,21 +35,54 @@ var (
 		[]string{"runtime"},
 	)
 
+	// Number of detected SGX platform changes.
+	teePlatformChanges = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_tee_platform_changes",
+			Help: "Number of detected TEE platform changes.",
+		},
+		[]string{"runtime"},
+	)
+
+	// Time until the cached TCB bundle is expected to expire.
+	teeTCBBundleExpiry = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "oasis_tee_tcb_bundle_expiry_seconds",
+			Help: "Time until the cached TCB bundle is expected to expire (seconds).",
+		},
+	)
+
+	// Whether the cached TCB bundle is stale.
+	teeTCBBundleStale = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "oasis_tee_tcb_bundle_stale",
+			Help: "Whether the cached TCB bundle is close to expiry without a successful refresh (1 = stale).",
+		},
+	)
+
+	// Number of failed TCB bundle refreshes.
+	teeTCBRefreshFailures = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "oasis_tee_tcb_refresh_failures",
+			Help: "Number of failed TCB bundle refreshes.",
+		},
+	)
+
 	teeCollectors = []prometheus.Collector{
 		teeAttestationsPerformed,
 		teeAttestationsSuccessful,
 		teeAttestationsFailed,
+		teePlatformChanges,
+		teeTCBBundleExpiry,
+		teeTCBBundleStale,
+		teeTCBRefreshFailures,
 	}
 
 	metricsOnce sync.Once
 )
 
-// updateAttestationMetrics updates the attestation metrics if metrics are enabled.
+// updateAttestationMetrics updates the attestation metrics.
 func updateAttestationMetrics(runtime string, err error) {
-	if !metrics.Enabled() {
-		return
-	}
-
 	teeAttestationsPerformed.With(prometheus.Labels{"runtime": runtime}).Inc()
 	if err != nil {
 		teeAttestationsFailed.With(prometheus.Labels{"runtime": runtime}).Inc()
@@ -59,12 +91,28 @@ func updateAttestationMetrics(runtime string, err error) {
 	}
 }
 
-// initMetrics registers the metrics collectors if metrics are enabled.
-func initMetrics() {
-	if !metrics.Enabled() {
-		return
+// updatePlatformChangeMetrics updates the platform change metrics.
+func updatePlatformChangeMetrics(runtime string) {
+	teePlatformChanges.With(prometheus.Labels{"runtime": runtime}).Inc()
+}
+
+// updateTCBCacheMetrics updates the TCB cache metrics.
+func updateTCBCacheMetrics(expiry time.Duration, stale bool) {
+	teeTCBBundleExpiry.Set(expiry.Seconds())
+	if stale {
+		teeTCBBundleStale.Set(1)
+	} else {
+		teeTCBBundleStale.Set(0)
 	}
+}
 
+// updateTCBRefreshFailureMetrics updates the TCB refresh failure metrics.
+func updateTCBRefreshFailureMetrics() {
+	teeTCBRefreshFailures.Inc()
+}
+
+// initMetrics registers the metrics collectors.
+func initMetrics() {
 	metricsOnce.Do(func() {
 		prometheus.MustRegister(teeCollectors...)
 	})
@@ -7,6 +7,7 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/common/sgx/pcs"
 	sgxQuote "github.com/oasisprotocol/oasis-core/go/common/sgx/quote"
@@ -33,11 +34,15 @@ func (ec *teeStateMock) Init(ctx context.Context, sp *sgxProvisioner, _ common.N
 	return targetInfo[:], nil
 }
 
-func (ec *teeStateMock) Update(ctx context.Context, sp *sgxProvisioner, conn protocol.Connection, report []byte, _ string) ([]byte, error) {
+func (ec *teeStateMock) Update(ctx context.Context, sp *sgxProvisioner, conn protocol.Connection, report []byte, _ string, audit *AttestationRecord) ([]byte, error) {
+	audit.Method = AttestationMethodMock
+
 	rawQuote, err := pcs.NewMockQuote(report)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get quote: %w", err)
 	}
+	quoteHash := hash.NewFromBytes(rawQuote)
+	audit.QuoteHash = &quoteHash
 
 	var quote pcs.Quote
 	if err = quote.UnmarshalBinary(rawQuote); err != nil {
@@ -0,0 +1,111 @@
+package sgx
+
+import (
+	"bytes"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/persistent"
+	"github.com/oasisprotocol/oasis-core/go/common/sgx/pcs"
+)
+
+const platformInfoKey = "platform_info"
+
+// platformInfo is the persisted information about the SGX platform the node was last attested
+// on, as extracted from the PCK certificate.
+type platformInfo struct {
+	FMSPC        []byte    `json:"fmspc"`
+	PCKPublicKey []byte    `json:"pck_public_key"`
+	CPUSVN       [16]byte  `json:"cpusvn"`
+	PCESVN       uint16    `json:"pcesvn"`
+	TCBCompSVN   [16]int32 `json:"tcb_comp_svn"`
+	LastSeen     time.Time `json:"last_seen"`
+}
+
+func newPlatformInfo(pckInfo *pcs.PCKInfo) (*platformInfo, error) {
+	pk, err := x509.MarshalPKIXPublicKey(pckInfo.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal PCK public key: %w", err)
+	}
+
+	return &platformInfo{
+		FMSPC:        pckInfo.FMSPC,
+		PCKPublicKey: pk,
+		CPUSVN:       pckInfo.CPUSVN,
+		PCESVN:       pckInfo.PCESVN,
+		TCBCompSVN:   pckInfo.TCBCompSVN,
+	}, nil
+}
+
+// changes returns the list of platform properties that differ between the two platforms.
+func (pi *platformInfo) changes(other *platformInfo) []string {
+	var changes []string
+	if !bytes.Equal(pi.FMSPC, other.FMSPC) {
+		changes = append(changes, "fmspc")
+	}
+	if !bytes.Equal(pi.PCKPublicKey, other.PCKPublicKey) {
+		changes = append(changes, "pck")
+	}
+	if pi.CPUSVN != other.CPUSVN || pi.TCBCompSVN != other.TCBCompSVN {
+		changes = append(changes, "cpusvn")
+	}
+	if pi.PCESVN != other.PCESVN {
+		changes = append(changes, "pcesvn")
+	}
+	return changes
+}
+
+type platformTracker struct {
+	serviceStore *persistent.ServiceStore
+	logger       *logging.Logger
+	now          func() time.Time
+}
+
+// check compares the current platform against the last known one and persists the current
+// platform information. It returns true in case the platform has changed since the last
+// attestation (e.g., after a hardware swap or a microcode update).
+func (pt *platformTracker) check(current *platformInfo) bool {
+	var (
+		stored  platformInfo
+		changed bool
+	)
+	switch err := pt.serviceStore.GetCBOR([]byte(platformInfoKey), &stored); err {
+	case nil:
+		if changes := stored.changes(current); len(changes) > 0 {
+			pt.logger.Warn("SGX platform has changed since the last attestation, forcing TCB refresh and re-attestation",
+				"changes", changes,
+				"last_seen", stored.LastSeen,
+				"previous_fmspc", fmt.Sprintf("%X", stored.FMSPC),
+				"current_fmspc", fmt.Sprintf("%X", current.FMSPC),
+				"previous_pcesvn", stored.PCESVN,
+				"current_pcesvn", current.PCESVN,
+			)
+			changed = true
+		}
+	case persistent.ErrNotFound:
+		// Platform not seen before.
+	default:
+		pt.logger.Warn("error checking common store for platform information",
+			"err", err,
+		)
+	}
+
+	current.LastSeen = pt.now()
+	if err := pt.serviceStore.PutCBOR([]byte(platformInfoKey), current); err != nil {
+		pt.logger.Error("could not store platform information, ignoring",
+			"err", err,
+		)
+	}
+
+	return changed
+}
+
+func newPlatformTracker(serviceStore *persistent.ServiceStore, logger *logging.Logger) *platformTracker {
+	return &platformTracker{
+		serviceStore: serviceStore,
+		logger:       logger,
+		now:          time.Now,
+	}
+}
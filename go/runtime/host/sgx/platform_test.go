@@ -0,0 +1,56 @@
+package sgx
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/persistent"
+)
+
+func TestPlatformTracker(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := os.MkdirTemp("", "oasis-core-unittests")
+	require.NoError(err, "os.MkdirTemp")
+	defer os.RemoveAll(dir)
+
+	common, err := persistent.NewCommonStore(dir)
+	require.NoError(err, "NewCommonStore")
+	defer common.Close()
+
+	store := common.GetServiceStore("persistent_test")
+	tracker := newPlatformTracker(store, logging.GetLogger(loggerModule))
+
+	platform := func() *platformInfo {
+		return &platformInfo{
+			FMSPC:        []byte("fmspc"),
+			PCKPublicKey: []byte("pck"),
+			PCESVN:       11,
+		}
+	}
+
+	// First time the platform is seen, it is not considered changed.
+	require.False(tracker.check(platform()), "check 1")
+	// Same platform after a restart.
+	require.False(tracker.check(platform()), "check 2")
+
+	// Microcode update changes the CPUSVN.
+	p := platform()
+	p.CPUSVN[0] = 1
+	require.Equal([]string{"cpusvn"}, platform().changes(p))
+	require.True(tracker.check(p), "check 3")
+
+	// Change is only reported once.
+	p = platform()
+	p.CPUSVN[0] = 1
+	require.False(tracker.check(p), "check 4")
+
+	// Hardware swap.
+	p = platform()
+	p.FMSPC = []byte("other")
+	p.PCKPublicKey = []byte("other")
+	require.True(tracker.check(p), "check 5")
+}
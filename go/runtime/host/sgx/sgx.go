@@ -12,8 +12,6 @@ import (
 	"time"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
-	"github.com/oasisprotocol/oasis-core/go/common/cbor"
-	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
@@ -25,7 +23,6 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/version"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	ias "github.com/oasisprotocol/oasis-core/go/ias/api"
-	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 	"github.com/oasisprotocol/oasis-core/go/runtime/bundle"
 	"github.com/oasisprotocol/oasis-core/go/runtime/host"
 	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
@@ -88,6 +85,10 @@ type Config struct {
 	// This is useful in tests so most SGX code can be tested even on machines that lack SGX. Note
 	// that this also requires quote verification to be skipped.
 	InsecureMock bool
+	// InsecureDummySigstruct generates a dummy SIGSTRUCT for enclaves that are not signed.
+	//
+	// This is useful in tests as runtime bundles do not need to be signed.
+	InsecureDummySigstruct bool
 }
 
 type teeStateImpl interface {
@@ -95,7 +96,9 @@ type teeStateImpl interface {
 	Init(ctx context.Context, sp *sgxProvisioner, runtimeID common.Namespace, version version.Version) ([]byte, error)
 
 	// Update updates the TEE state and returns a new attestation.
-	Update(ctx context.Context, sp *sgxProvisioner, conn protocol.Connection, report []byte, nonce string) ([]byte, error)
+	//
+	// Information about the attestation should be recorded in the given audit record.
+	Update(ctx context.Context, sp *sgxProvisioner, conn protocol.Connection, report []byte, nonce string, audit *AttestationRecord) ([]byte, error)
 }
 
 type teeState struct {
@@ -155,7 +158,16 @@ func (ts *teeState) update(ctx context.Context, sp *sgxProvisioner, conn protoco
 		return nil, fmt.Errorf("not initialized")
 	}
 
-	attestation, err := ts.impl.Update(ctx, sp, conn, report, nonce)
+	audit := AttestationRecord{
+		Timestamp: time.Now(),
+		Version:   ts.version,
+	}
+	attestation, err := ts.impl.Update(ctx, sp, conn, report, nonce, &audit)
+	audit.Accepted = err == nil
+	if err != nil {
+		audit.Error = err.Error()
+	}
+	sp.auditLog.append(ts.runtimeID, &audit)
 
 	updateAttestationMetrics(ts.runtimeID.String(), err)
 
@@ -176,6 +188,7 @@ type sgxProvisioner struct {
 
 	logger       *logging.Logger
 	serviceStore *persistent.ServiceStore
+	auditLog     *attestationAuditLog
 }
 
 func (s *sgxProvisioner) loadEnclaveBinaries(rtCfg host.Config, comp *bundle.Component) ([]byte, []byte, error) {
@@ -203,7 +216,7 @@ func (s *sgxProvisioner) loadEnclaveBinaries(rtCfg host.Config, comp *bundle.Com
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to load SIGSTRUCT: %w", err)
 		}
-	} else if cmdFlags.DebugDontBlameOasis() {
+	} else if s.cfg.InsecureDummySigstruct {
 		s.logger.Warn("generating dummy enclave SIGSTRUCT",
 			"enclave_hash", enclaveHash,
 		)
@@ -445,11 +458,7 @@ func (s *sgxProvisioner) endorseCapabilityTEE(ctx context.Context, capabilityTEE
 	}
 
 	// Endorse CapabilityTEE by signing it under the proper domain separation context.
-	nodeSignature, err := signature.Sign(
-		s.identity.NodeSigner,
-		node.EndorseCapabilityTEESignatureContext,
-		cbor.Marshal(capabilityTEE),
-	)
+	ect, err := node.EndorseCapabilityTEE(s.identity.NodeSigner, capabilityTEE)
 	if err != nil {
 		s.logger.Error("failed to sign endorsement of local component",
 			"err", err,
@@ -459,10 +468,7 @@ func (s *sgxProvisioner) endorseCapabilityTEE(ctx context.Context, capabilityTEE
 
 	_, err = conn.Call(ctx, &protocol.Body{
 		RuntimeCapabilityTEEUpdateEndorsementRequest: &protocol.RuntimeCapabilityTEEUpdateEndorsementRequest{
-			EndorsedCapabilityTEE: node.EndorsedCapabilityTEE{
-				CapabilityTEE:   *capabilityTEE,
-				NodeEndorsement: *nodeSignature,
-			},
+			EndorsedCapabilityTEE: *ect,
 		},
 	})
 	if err != nil {
@@ -537,6 +543,10 @@ func New(cfg Config) (host.Provisioner, error) {
 
 	initMetrics()
 
+	if cfg.InsecureDummySigstruct {
+		sgx.UnsafeEnableFortanixDummyKey()
+	}
+
 	s := &sgxProvisioner{
 		cfg:          cfg,
 		ias:          cfg.IAS,
@@ -547,6 +557,7 @@ func New(cfg Config) (host.Provisioner, error) {
 		logger:       logging.GetLogger("runtime/host/sgx"),
 		serviceStore: cfg.CommonStore.GetServiceStore(serviceStoreName),
 	}
+	s.auditLog = newAttestationAuditLog(s.serviceStore, s.logger)
 	p, err := sandbox.New(sandbox.Config{
 		GetSandboxConfig:  s.getSandboxConfig,
 		HostInfo:          cfg.HostInfo,
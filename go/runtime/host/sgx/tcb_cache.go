@@ -16,8 +16,41 @@ const (
 
 	tcbCacheRefreshThreshold    = 14 * 24 * time.Hour
 	tcbCacheSlowRefreshInterval = 24 * time.Hour
+
+	// tcbCacheStaleInterval is the time without a successful refresh after which a cached TCB
+	// bundle that is within the refresh threshold is considered stale.
+	tcbCacheStaleInterval = 2 * tcbCacheSlowRefreshInterval
 )
 
+// TCBCacheStatus is the status of the cached TCB bundle.
+type TCBCacheStatus struct {
+	// FMSPC is the FMSPC of the platform the cached bundle is for.
+	FMSPC []byte `json:"fmspc"`
+	// ExpectedExpiry is the earliest next update timestamp of the cached bundle. After this time
+	// attestations using the cached bundle are expected to fail.
+	ExpectedExpiry time.Time `json:"expected_expiry"`
+	// LastUpdate is the time of the last successful bundle refresh.
+	LastUpdate time.Time `json:"last_update"`
+	// Stale is true iff the cached bundle is close to its expected expiry (or has already expired)
+	// and it could not be refreshed, e.g. due to PCS outages or egress problems.
+	Stale bool `json:"stale"`
+}
+
+// GetTCBCacheStatus returns the status of the TCB bundle cached in the given common store.
+//
+// In case no bundle has been cached yet, nil is returned.
+func GetTCBCacheStatus(commonStore *persistent.CommonStore) (*TCBCacheStatus, error) {
+	var stored tcbBundleCache
+	switch err := commonStore.GetServiceStore(serviceStoreName).GetCBOR([]byte(tcbCacheKey), &stored); err {
+	case nil:
+	case persistent.ErrNotFound:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("failed to read cached TCB bundle: %w", err)
+	}
+	return stored.status(time.Now()), nil
+}
+
 func readBundleMinTimestamp(bundle *pcs.TCBBundle) (time.Time, error) {
 	var err error
 	var info pcs.TCBInfo
@@ -51,6 +84,18 @@ type tcbBundleCache struct {
 	LastUpdate     time.Time      `json:"last_update"`
 }
 
+func (c *tcbBundleCache) status(now time.Time) *TCBCacheStatus {
+	delta := c.ExpectedExpiry.Sub(now)
+	stale := delta < 0 || (delta < tcbCacheRefreshThreshold && now.Sub(c.LastUpdate) > tcbCacheStaleInterval)
+
+	return &TCBCacheStatus{
+		FMSPC:          c.FMSPC,
+		ExpectedExpiry: c.ExpectedExpiry,
+		LastUpdate:     c.LastUpdate,
+		Stale:          stale,
+	}
+}
+
 type tcbCache struct {
 	serviceStore *persistent.ServiceStore
 	logger       *logging.Logger
@@ -82,6 +127,7 @@ func (tc *tcbCache) check(fmspc []byte) (*pcs.TCBBundle, bool) {
 	if !bytes.Equal(stored.FMSPC, fmspc) {
 		return nil, true
 	}
+	tc.watch(&stored)
 
 	refresh := func() bool {
 		now := tc.now()
@@ -117,6 +163,35 @@ func (tc *tcbCache) cache(tcbBundle *pcs.TCBBundle, fmspc []byte) {
 		tc.logger.Error("could not store new TCB bundle to cache, ignoring",
 			"err", err,
 		)
+		return
+	}
+	tc.watch(&cached)
+}
+
+// watch checks whether the cached TCB bundle is stale and reports it via metrics and logs.
+func (tc *tcbCache) watch(cached *tcbBundleCache) {
+	now := tc.now()
+	status := cached.status(now)
+	updateTCBCacheMetrics(cached.ExpectedExpiry.Sub(now), status.Stale)
+
+	if status.Stale {
+		tc.logger.Warn("cached TCB bundle is stale, attestations may start failing",
+			"expected_expiry", status.ExpectedExpiry,
+			"last_update", status.LastUpdate,
+		)
+	}
+}
+
+// refreshFailed records a failed TCB bundle refresh.
+func (tc *tcbCache) refreshFailed() {
+	updateTCBRefreshFailureMetrics()
+}
+
+func (tc *tcbCache) invalidate() {
+	if err := tc.serviceStore.Delete([]byte(tcbCacheKey)); err != nil && err != persistent.ErrNotFound {
+		tc.logger.Error("could not invalidate cached TCB bundle, ignoring",
+			"err", err,
+		)
 	}
 }
 
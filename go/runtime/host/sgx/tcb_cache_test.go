@@ -128,6 +128,52 @@ func testCheckIntervals(t *testing.T, store *persistent.ServiceStore, bundle *pc
 	}
 }
 
+func testStaleDetection(t *testing.T, store *persistent.ServiceStore, bundle *pcs.TCBBundle) {
+	require := require.New(t)
+	fmspc := []byte("fmspc")
+	expiryTime, err := readBundleMinTimestamp(bundle)
+	require.NoError(err, "readBundleMinTimestamp")
+
+	timer := fakeTime{
+		now: expiryTime.Add(-(tcbCacheRefreshThreshold + 24*time.Hour)),
+	}
+	tcbCache := newMockTcbCache(store, logging.GetLogger(loggerModule), timer.get)
+	tcbCache.cache(bundle, fmspc)
+
+	var stored tcbBundleCache
+	err = store.GetCBOR([]byte(tcbCacheKey), &stored)
+	require.NoError(err, "GetCBOR")
+
+	// Before the refresh threshold, the bundle is never stale.
+	timer.now = timer.now.Add(24 * time.Hour)
+	status := stored.status(timer.now)
+	require.False(status.Stale, "status before refresh threshold")
+	require.EqualValues(fmspc, status.FMSPC)
+	require.Equal(stored.ExpectedExpiry, status.ExpectedExpiry)
+
+	// Within the refresh threshold, the bundle is not stale while refreshes succeed.
+	timer.now = expiryTime.Add(-tcbCacheRefreshThreshold / 2)
+	tcbCache.cache(bundle, fmspc)
+	err = store.GetCBOR([]byte(tcbCacheKey), &stored)
+	require.NoError(err, "GetCBOR")
+	timer.now = timer.now.Add(tcbCacheStaleInterval)
+	status = stored.status(timer.now)
+	require.False(status.Stale, "status after successful refresh")
+
+	// Once refreshes fail for too long, the bundle is stale.
+	timer.now = timer.now.Add(time.Hour)
+	status = stored.status(timer.now)
+	require.True(status.Stale, "status after failed refreshes")
+
+	// An expired bundle is always stale.
+	timer.now = expiryTime.Add(time.Hour)
+	tcbCache.cache(bundle, fmspc)
+	err = store.GetCBOR([]byte(tcbCacheKey), &stored)
+	require.NoError(err, "GetCBOR")
+	status = stored.status(timer.now)
+	require.True(status.Stale, "status after expiry")
+}
+
 func TestTCBCache(t *testing.T) {
 	require := require.New(t)
 
@@ -167,6 +213,7 @@ func TestTCBCache(t *testing.T) {
 		"StorageRoundtrip":  testStorageRoundtrip,
 		"CheckIntervals":    testCheckIntervals,
 		"FMSPCInvalidation": testFMSPCInvalidation,
+		"StaleDetection":    testStaleDetection,
 	} {
 		t.Run(name, func(t *testing.T) {
 			fun(t, store, &tcbBundle)
@@ -0,0 +1,84 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/oasisprotocol/oasis-core/go/common/httpclient"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/config"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle"
+)
+
+// LogEventRuntimeBundleLoaded is a log event value that signals a runtime bundle was loaded.
+const LogEventRuntimeBundleLoaded = "runtime/registry/bundle_loaded"
+
+var bundleRecordsLogger = logging.GetLogger("runtime/registry/bundle_records")
+
+// reportBundle emits a record of the bundle loaded from the given path to the configured sinks.
+//
+// The bundle data must still be available as it is needed to derive the enclave identities.
+func reportBundle(bnd *bundle.Bundle, path string) {
+	rec, err := bnd.Record(path)
+	if err != nil {
+		bundleRecordsLogger.Error("failed to create runtime bundle record",
+			"err", err,
+			"path", path,
+		)
+		return
+	}
+
+	bundleRecordsLogger.Info("loaded runtime bundle",
+		"manifest_hash", rec.ManifestHash,
+		"runtime_id", rec.RuntimeID,
+		"version", rec.Version,
+		"source", rec.Source,
+		"components", rec.Components,
+		logging.LogEvent, LogEventRuntimeBundleLoaded,
+	)
+
+	cfg := config.GlobalConfig.Runtime.BundleRecords
+	if cfg.WebhookURL == "" {
+		return
+	}
+
+	// Deliver the record in the background so that an unavailable sink does not block startup.
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.WebhookTimeout)
+		defer cancel()
+
+		if err := postBundleRecord(ctx, cfg.WebhookURL, rec); err != nil {
+			bundleRecordsLogger.Error("failed to deliver runtime bundle record to webhook",
+				"err", err,
+				"manifest_hash", rec.ManifestHash,
+			)
+		}
+	}()
+}
+
+func postBundleRecord(ctx context.Context, url string, rec *bundle.Record) error {
+	body, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("failed to marshal record: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	rsp, err := httpclient.New(0).Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status: %s", rsp.Status)
+	}
+	return nil
+}
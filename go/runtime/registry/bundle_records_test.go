@@ -0,0 +1,53 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
+)
+
+func TestPostBundleRecord(t *testing.T) {
+	require := require.New(t)
+
+	rec := &bundle.Record{
+		RuntimeID: common.NewTestNamespaceFromSeed([]byte("bundle records test"), 0),
+		Source:    "/path/to/bundle.orc",
+		Components: []*bundle.ComponentRecord{
+			{ID: component.ID_RONL},
+		},
+	}
+
+	var received bundle.Record
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	err := postBundleRecord(context.Background(), srv.URL, rec)
+	require.NoError(err, "postBundleRecord")
+	require.Equal(*rec, received, "webhook should receive the record")
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	err = postBundleRecord(context.Background(), failing.URL, rec)
+	require.Error(err, "postBundleRecord should fail on non-2xx responses")
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -11,7 +13,10 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/debug"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/common/persistent"
 	"github.com/oasisprotocol/oasis-core/go/common/sgx/pcs"
@@ -20,6 +25,8 @@ import (
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	ias "github.com/oasisprotocol/oasis-core/go/ias/api"
 	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	runtimeAPI "github.com/oasisprotocol/oasis-core/go/runtime/api"
 	"github.com/oasisprotocol/oasis-core/go/runtime/bundle"
 	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
 	rtConfig "github.com/oasisprotocol/oasis-core/go/runtime/config"
@@ -63,6 +70,21 @@ func (cfg *RuntimeConfig) Runtimes() (runtimes []common.Namespace) {
 	return
 }
 
+// getRegistryParameters returns the latest registry consensus parameters, falling back to the
+// ones from the genesis document in case the consensus layer has no committed blocks yet.
+func getRegistryParameters(consensusBackend consensus.Backend) (*registry.ConsensusParameters, error) {
+	params, err := consensusBackend.Registry().ConsensusParameters(context.Background(), consensus.HeightLatest)
+	if err == nil {
+		return params, nil
+	}
+
+	doc, gErr := consensusBackend.GetGenesisDocument(context.Background())
+	if gErr != nil {
+		return nil, fmt.Errorf("failed to get registry consensus parameters: %w", err)
+	}
+	return &doc.Registry.Parameters, nil
+}
+
 // RuntimeHostConfig is configuration for a node that hosts runtimes.
 type RuntimeHostConfig struct {
 	// Provisioners contains a set of supported runtime provisioners, based on TEE hardware.
@@ -71,6 +93,163 @@ type RuntimeHostConfig struct {
 	// Runtimes contains per-runtime provisioning configuration. Some fields may be omitted as they
 	// are provided when the runtime is provisioned.
 	Runtimes map[common.Namespace]map[version.Version]*runtimeHost.Config
+
+	// Bundles contains the paths of all loaded runtime bundles, keyed by manifest hash.
+	Bundles map[common.Namespace]map[hash.Hash]string
+
+	// dataDir is the node's data directory that runtime bundles are exploded into.
+	dataDir string
+
+	// detachedBundles contains the detached bundles of each runtime, which are also merged into
+	// any bundles loaded after initialization.
+	detachedBundles map[common.Namespace][]*bundle.Bundle
+}
+
+// openBundle opens the runtime bundle at the given path.
+func openBundle(path string) (*bundle.Bundle, error) {
+	bnd, err := bundle.Open(path, bundle.WithDebugDummySigner(cmdFlags.DebugDummySigstruct()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load runtime bundle '%s': %w", path, err)
+	}
+	return bnd, nil
+}
+
+// explodeBundle verifies that the given runtime bundle conforms to the consensus limits and
+// explodes it into the data directory.
+func explodeBundle(dataDir string, path string, bnd *bundle.Bundle, registryParams *registry.ConsensusParameters) error {
+	numComponents := len(bnd.Manifest.GetAvailableComponents())
+	if err := registryParams.CheckBundleLimits(numComponents, bnd.ImageSize()); err != nil {
+		return fmt.Errorf("runtime bundle '%s' violates consensus limits: %w", path, err)
+	}
+	if err := bnd.WriteExploded(dataDir); err != nil {
+		return fmt.Errorf("failed to explode runtime bundle '%s': %w", path, err)
+	}
+	reportBundle(bnd, path)
+	// Release resources as the bundle has been exploded anyway.
+	bnd.Data = nil
+	return nil
+}
+
+// rejectFetchedBundle removes a previously fetched runtime bundle that cannot be loaded so that
+// it can be fetched again in case it is still needed.
+func rejectFetchedBundle(path string, err error) {
+	logger := logging.GetLogger("runtime/registry")
+	logger.Error("rejecting fetched runtime bundle",
+		"err", err,
+		"path", path,
+	)
+	if err = os.Remove(path); err != nil {
+		logger.Error("failed to remove rejected runtime bundle",
+			"err", err,
+			"path", path,
+		)
+	}
+}
+
+// getFetchedBundlePaths returns the paths of all runtime bundles previously fetched from peers in
+// case fetching runtime bundles is enabled.
+func getFetchedBundlePaths(dataDir string) ([]string, error) {
+	if !config.GlobalConfig.Runtime.BundleSync.Fetch {
+		return nil, nil
+	}
+	paths, err := filepath.Glob(filepath.Join(bundle.FetchedPath(dataDir), "*.orc"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fetched runtime bundles: %w", err)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// newRuntimeHostConfig creates the runtime host configuration for the given non-detached runtime
+// bundle, merging in any components of the given detached bundles.
+func newRuntimeHostConfig(dataDir string, bnd *bundle.Bundle, detachedBundles []*bundle.Bundle) (*runtimeHost.Config, error) {
+	id := bnd.Manifest.ID
+
+	// Get any local runtime configuration.
+	var localConfig map[string]interface{}
+	if config.GlobalConfig.Runtime.RuntimeConfig != nil {
+		if lcRaw, ok := config.GlobalConfig.Runtime.RuntimeConfig[id.String()]; ok {
+			if lc, ok := lcRaw.(map[string]interface{}); ok {
+				localConfig = lc
+			} else {
+				return nil, fmt.Errorf("malformed runtime configuration for runtime %s", id.String())
+			}
+		}
+	}
+
+	// Configure protocol capture if requested.
+	var protocolCapture *hostProtocol.CaptureConfig
+	if pc, ok := config.GlobalConfig.Runtime.GetProtocolCapture(id); ok {
+		if !cmdFlags.DebugProtocolCapture() {
+			return nil, fmt.Errorf("runtime host protocol capture requires use of unsafe debug flags")
+		}
+		protocolCapture = &hostProtocol.CaptureConfig{
+			Path:        pc.File,
+			MaxBodySize: pc.MaxBodySize,
+			MaxFileSize: pc.MaxFileSize,
+			Redact:      pc.Redact,
+			Unredact:    pc.Unredact,
+		}
+	}
+
+	rtBnd := &runtimeHost.RuntimeBundle{
+		Bundle:               bnd,
+		ExplodedDataDir:      dataDir,
+		ExplodedDetachedDirs: make(map[component.ID]string),
+	}
+
+	// Merge in detached components.
+	for _, detachedBnd := range detachedBundles {
+		for _, detachedComp := range detachedBnd.Manifest.Components {
+			// Skip components that already exist in the bundle itself.
+			if bnd.Manifest.GetComponentByID(detachedComp.ID()) != nil {
+				continue
+			}
+
+			bnd.Manifest.Components = append(bnd.Manifest.Components, detachedComp)
+			rtBnd.ExplodedDetachedDirs[detachedComp.ID()] = detachedBnd.ExplodedPath(dataDir, "")
+		}
+	}
+
+	// Determine what kind of components we want.
+	wantedComponents := []component.ID{
+		component.ID_RONL,
+	}
+	for _, comp := range bnd.Manifest.Components {
+		if comp.ID().IsRONL() {
+			continue // Always enabled above.
+		}
+
+		// By default honor the status of the component itself.
+		enabled := !comp.Disabled
+		// On non-compute nodes, assume all components are disabled by default.
+		if config.GlobalConfig.Mode != config.ModeCompute {
+			enabled = false
+		}
+		// Detached components are explicit and they should be enabled by default.
+		if _, ok := rtBnd.ExplodedDetachedDirs[comp.ID()]; ok {
+			enabled = true
+		}
+
+		// Check for any overrides in the node configuration.
+		compCfg, ok := config.GlobalConfig.Runtime.GetComponent(comp.ID())
+		if ok {
+			enabled = !compCfg.Disabled
+		}
+
+		if !enabled {
+			continue
+		}
+
+		wantedComponents = append(wantedComponents, comp.ID())
+	}
+
+	return &runtimeHost.Config{
+		Bundle:          rtBnd,
+		Components:      wantedComponents,
+		LocalConfig:     localConfig,
+		ProtocolCapture: protocolCapture,
+	}, nil
 }
 
 func newConfig( //nolint: gocyclo
@@ -79,6 +258,7 @@ func newConfig( //nolint: gocyclo
 	identity *identity.Identity,
 	consensus consensus.Backend,
 	ias []ias.Endpoint,
+	lifecycle *lifecycleNotifier,
 ) (*RuntimeConfig, error) {
 	var cfg RuntimeConfig
 
@@ -88,12 +268,12 @@ func newConfig( //nolint: gocyclo
 	switch config.GlobalConfig.Mode {
 	case config.ModeValidator, config.ModeSeed:
 		// No runtimes should be configured.
-		if haveSetRuntimes && !cmdFlags.DebugDontBlameOasis() {
+		if haveSetRuntimes && !cmdFlags.DebugUnsafeRuntimeHost() {
 			return nil, fmt.Errorf("no runtimes should be configured when in validator or seed modes")
 		}
 	case config.ModeCompute, config.ModeKeyManager, config.ModeStatelessClient:
 		// At least one runtime should be configured.
-		if !haveSetRuntimes && !cmdFlags.DebugDontBlameOasis() {
+		if !haveSetRuntimes && !cmdFlags.DebugUnsafeRuntimeHost() {
 			return nil, fmt.Errorf("at least one runtime must be configured when in compute, keymanager, or client-stateless modes")
 		}
 	default:
@@ -101,7 +281,7 @@ func newConfig( //nolint: gocyclo
 	}
 
 	// Check if any runtimes are configured to be hosted.
-	if haveSetRuntimes || (cmdFlags.DebugDontBlameOasis() && viper.IsSet(CfgDebugMockIDs)) {
+	if haveSetRuntimes || (cmdFlags.DebugUnsafeRuntimeHost() && viper.IsSet(CfgDebugMockIDs)) {
 		// By default start with the environment specified in configuration.
 		runtimeEnv := config.GlobalConfig.Runtime.Environment
 
@@ -111,22 +291,75 @@ func newConfig( //nolint: gocyclo
 			comp    component.ID
 		}
 
+		type versionKey struct {
+			runtime common.Namespace
+			version version.Version
+		}
+
 		var (
 			regularBundles []*bundle.Bundle
 			err            error
 		)
 		detachedBundles := make(map[common.Namespace][]*bundle.Bundle)
 		existingNames := make(map[nameKey]struct{})
-		for _, path := range config.GlobalConfig.Runtime.Paths {
+		existingVersions := make(map[versionKey]struct{})
+		bundlePaths := make(map[common.Namespace]map[hash.Hash]string)
+		registryParams, err := getRegistryParameters(consensus)
+		if err != nil {
+			return nil, err
+		}
+		fetchedPaths, err := getFetchedBundlePaths(dataDir)
+		if err != nil {
+			return nil, err
+		}
+		numConfigured := len(config.GlobalConfig.Runtime.Paths)
+		paths := make([]string, 0, numConfigured+len(fetchedPaths))
+		paths = append(paths, config.GlobalConfig.Runtime.Paths...)
+		paths = append(paths, fetchedPaths...)
+		for i, path := range paths {
+			isFetched := i >= numConfigured
+
 			var bnd *bundle.Bundle
-			if bnd, err = bundle.Open(path); err != nil {
-				return nil, fmt.Errorf("failed to load runtime bundle '%s': %w", path, err)
+			if bnd, err = openBundle(path); err != nil {
+				if isFetched {
+					rejectFetchedBundle(path, err)
+					continue
+				}
+				return nil, err
+			}
+			if isFetched {
+				// Fetched bundles are only used for runtimes that are explicitly configured and
+				// never override any of the configured bundles.
+				vk := versionKey{bnd.Manifest.ID, bnd.Manifest.Version}
+				_, haveRuntime := bundlePaths[bnd.Manifest.ID]
+				_, haveVersion := existingVersions[vk]
+				if !haveRuntime || haveVersion || bnd.Manifest.IsDetached() {
+					continue
+				}
+			}
+			manifestHash := bnd.Manifest.Hash()
+			if err = explodeBundle(dataDir, path, bnd, registryParams); err != nil {
+				if isFetched {
+					// Fetched bundles may violate limits that have changed since they were
+					// fetched, reject them instead of failing to start.
+					rejectFetchedBundle(path, err)
+					continue
+				}
+				return nil, err
+			}
+			if !bnd.Manifest.IsDetached() {
+				existingVersions[versionKey{bnd.Manifest.ID, bnd.Manifest.Version}] = struct{}{}
 			}
-			if err = bnd.WriteExploded(dataDir); err != nil {
-				return nil, fmt.Errorf("failed to explode runtime bundle '%s': %w", path, err)
+			if bundlePaths[bnd.Manifest.ID] == nil {
+				bundlePaths[bnd.Manifest.ID] = make(map[hash.Hash]string)
 			}
-			// Release resources as the bundle has been exploded anyway.
-			bnd.Data = nil
+			bundlePaths[bnd.Manifest.ID][manifestHash] = path
+			bndVersion := bnd.Manifest.Version
+			lifecycle.notify(&runtimeAPI.LifecycleEvent{
+				RuntimeID: bnd.Manifest.ID,
+				Kind:      runtimeAPI.LifecycleEventBundleLoaded,
+				Version:   &bndVersion,
+			})
 
 			switch bnd.Manifest.IsDetached() {
 			case false:
@@ -160,7 +393,7 @@ func newConfig( //nolint: gocyclo
 
 		isEnvSGX := runtimeEnv == rtConfig.RuntimeEnvironmentSGX || runtimeEnv == rtConfig.RuntimeEnvironmentSGXMock
 		forceNoSGX := (config.GlobalConfig.Mode.IsClientOnly() && !isEnvSGX) ||
-			(cmdFlags.DebugDontBlameOasis() && runtimeEnv == rtConfig.RuntimeEnvironmentELF)
+			(cmdFlags.DebugUnsafeRuntimeHost() && runtimeEnv == rtConfig.RuntimeEnvironmentELF)
 
 		var rh RuntimeHostConfig
 
@@ -187,14 +420,14 @@ func newConfig( //nolint: gocyclo
 		switch p := config.GlobalConfig.Runtime.Provisioner; p {
 		case rtConfig.RuntimeProvisionerMock:
 			// Mock provisioner, only supported when the runtime requires no TEE hardware.
-			if !cmdFlags.DebugDontBlameOasis() {
+			if !cmdFlags.DebugUnsafeRuntimeHost() {
 				return nil, fmt.Errorf("mock provisioner requires use of unsafe debug flags")
 			}
 
 			rh.Provisioners[node.TEEHardwareInvalid] = hostMock.New()
 		case rtConfig.RuntimeProvisionerUnconfined:
 			// Unconfined provisioner, can be used with no TEE or with Intel SGX.
-			if !cmdFlags.DebugDontBlameOasis() {
+			if !cmdFlags.DebugUnsafeRuntimeHost() {
 				return nil, fmt.Errorf("unconfined provisioner requires use of unsafe debug flags")
 			}
 
@@ -249,22 +482,23 @@ func newConfig( //nolint: gocyclo
 
 				// Configure mock SGX if configured and we are in a debug mode.
 				insecureMock := runtimeEnv == rtConfig.RuntimeEnvironmentSGXMock
-				if insecureMock && !cmdFlags.DebugDontBlameOasis() {
-					return nil, fmt.Errorf("mock SGX requires use of unsafe debug flags")
+				if insecureMock && !cmdFlags.DebugMockAttestation() {
+					return nil, fmt.Errorf("mock SGX requires the %s debug capability", debug.CapabilityMockAttestation)
 				}
 
 				rh.Provisioners[node.TEEHardwareIntelSGX], err = hostSgx.New(hostSgx.Config{
-					HostInfo:              hostInfo,
-					CommonStore:           commonStore,
-					LoaderPath:            sgxLoader,
-					IAS:                   ias,
-					PCS:                   pc,
-					Consensus:             consensus,
-					Identity:              identity,
-					SandboxBinaryPath:     sandboxBinary,
-					InsecureNoSandbox:     insecureNoSandbox,
-					InsecureMock:          insecureMock,
-					RuntimeAttestInterval: attestInterval,
+					HostInfo:               hostInfo,
+					CommonStore:            commonStore,
+					LoaderPath:             sgxLoader,
+					IAS:                    ias,
+					PCS:                    pc,
+					Consensus:              consensus,
+					Identity:               identity,
+					SandboxBinaryPath:      sandboxBinary,
+					InsecureNoSandbox:      insecureNoSandbox,
+					InsecureMock:           insecureMock,
+					InsecureDummySigstruct: cmdFlags.DebugDummySigstruct(),
+					RuntimeAttestInterval:  attestInterval,
 				})
 				if err != nil {
 					return nil, fmt.Errorf("failed to create SGX runtime provisioner: %w", err)
@@ -281,6 +515,10 @@ func newConfig( //nolint: gocyclo
 			})
 		}
 
+		rh.Bundles = bundlePaths
+		rh.dataDir = dataDir
+		rh.detachedBundles = detachedBundles
+
 		// Configure runtimes.
 		rh.Runtimes = make(map[common.Namespace]map[version.Version]*runtimeHost.Config)
 		for _, bnd := range regularBundles {
@@ -292,77 +530,13 @@ func newConfig( //nolint: gocyclo
 				return nil, fmt.Errorf("duplicate runtime '%s' version '%s'", id, bnd.Manifest.Version)
 			}
 
-			// Get any local runtime configuration.
-			var localConfig map[string]interface{}
-			if config.GlobalConfig.Runtime.RuntimeConfig != nil {
-				if lcRaw, ok := config.GlobalConfig.Runtime.RuntimeConfig[id.String()]; ok {
-					if lc, ok := lcRaw.(map[string]interface{}); ok {
-						localConfig = lc
-					} else {
-						return nil, fmt.Errorf("malformed runtime configuration for runtime %s", id.String())
-					}
-				}
-			}
-
-			rtBnd := &runtimeHost.RuntimeBundle{
-				Bundle:               bnd,
-				ExplodedDataDir:      dataDir,
-				ExplodedDetachedDirs: make(map[component.ID]string),
-			}
-
-			// Merge in detached components.
-			for _, detachedBnd := range detachedBundles[id] {
-				for _, detachedComp := range detachedBnd.Manifest.Components {
-					// Skip components that already exist in the bundle itself.
-					if bnd.Manifest.GetComponentByID(detachedComp.ID()) != nil {
-						continue
-					}
-
-					bnd.Manifest.Components = append(bnd.Manifest.Components, detachedComp)
-					rtBnd.ExplodedDetachedDirs[detachedComp.ID()] = detachedBnd.ExplodedPath(dataDir, "")
-				}
-			}
-
-			// Determine what kind of components we want.
-			wantedComponents := []component.ID{
-				component.ID_RONL,
-			}
-			for _, comp := range bnd.Manifest.Components {
-				if comp.ID().IsRONL() {
-					continue // Always enabled above.
-				}
-
-				// By default honor the status of the component itself.
-				enabled := !comp.Disabled
-				// On non-compute nodes, assume all components are disabled by default.
-				if config.GlobalConfig.Mode != config.ModeCompute {
-					enabled = false
-				}
-				// Detached components are explicit and they should be enabled by default.
-				if _, ok := rtBnd.ExplodedDetachedDirs[comp.ID()]; ok {
-					enabled = true
-				}
-
-				// Check for any overrides in the node configuration.
-				compCfg, ok := config.GlobalConfig.Runtime.GetComponent(comp.ID())
-				if ok {
-					enabled = !compCfg.Disabled
-				}
-
-				if !enabled {
-					continue
-				}
-
-				wantedComponents = append(wantedComponents, comp.ID())
-			}
-
-			rh.Runtimes[id][bnd.Manifest.Version] = &runtimeHost.Config{
-				Bundle:      rtBnd,
-				Components:  wantedComponents,
-				LocalConfig: localConfig,
+			var hostCfg *runtimeHost.Config
+			if hostCfg, err = newRuntimeHostConfig(dataDir, bnd, detachedBundles[id]); err != nil {
+				return nil, err
 			}
+			rh.Runtimes[id][bnd.Manifest.Version] = hostCfg
 		}
-		if cmdFlags.DebugDontBlameOasis() {
+		if cmdFlags.DebugUnsafeRuntimeHost() {
 			// This is to allow the mock provisioner to function, as it does
 			// not use an actual runtime, thus is missing a bundle.  This is
 			// only used for the basic node tests.
@@ -409,6 +583,9 @@ func newConfig( //nolint: gocyclo
 	case history.PrunerStrategyKeepLast:
 		numKept := config.GlobalConfig.Runtime.Prune.NumKept
 		cfg.History.Pruner = history.NewKeepLastPruner(numKept)
+	case history.PrunerStrategyKeepCheckpoints:
+		numKept := config.GlobalConfig.Runtime.Prune.NumKept
+		cfg.History.Pruner = history.NewKeepCheckpointsPruner(numKept)
 	default:
 		return nil, fmt.Errorf("runtime/registry: unknown history pruner strategy: %s", strategy)
 	}
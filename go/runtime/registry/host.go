@@ -11,24 +11,29 @@ import (
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cache/lru"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
 	"github.com/oasisprotocol/oasis-core/go/common/sgx/quote"
 	"github.com/oasisprotocol/oasis-core/go/common/version"
+	"github.com/oasisprotocol/oasis-core/go/config"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
 	consensusResults "github.com/oasisprotocol/oasis-core/go/consensus/api/transaction/results"
 	"github.com/oasisprotocol/oasis-core/go/keymanager/secrets"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	runtimeAPI "github.com/oasisprotocol/oasis-core/go/runtime/api"
 	"github.com/oasisprotocol/oasis-core/go/runtime/bundle"
 	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
 	"github.com/oasisprotocol/oasis-core/go/runtime/host"
 	"github.com/oasisprotocol/oasis-core/go/runtime/host/composite"
 	"github.com/oasisprotocol/oasis-core/go/runtime/host/multi"
 	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/replay"
 	runtimeKeymanager "github.com/oasisprotocol/oasis-core/go/runtime/keymanager/api"
 	"github.com/oasisprotocol/oasis-core/go/runtime/txpool"
 	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
@@ -54,8 +59,12 @@ type RuntimeHostNode struct {
 	notifier protocol.Notifier
 
 	agg           *multi.Aggregate
+	profiler      *callProfiler
+	recorder      *replay.Recorder
 	runtime       host.RichRuntime
 	runtimeNotify chan struct{}
+
+	logger *logging.Logger
 }
 
 // ProvisionHostedRuntime provisions the configured runtime.
@@ -70,14 +79,24 @@ func (n *RuntimeHostNode) ProvisionHostedRuntime(ctx context.Context) (host.Rich
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to wait for registry descriptor: %w", err)
 	}
+	// Subscribe to new versions before fetching the configuration to not miss any.
+	hvCh, hvSub := runtime.WatchHostVersions()
 	cfgs, provisioner, err := runtime.Host()
 	if err != nil {
+		hvSub.Close()
 		return nil, nil, fmt.Errorf("failed to get runtime host: %w", err)
 	}
 
 	// Provision the handler that implements the host RHP methods.
 	msgHandler := n.factory.NewRuntimeHostHandler()
 
+	// Optionally record host calls so that runtime rounds can be replayed.
+	var recorder *replay.Recorder
+	if config.GlobalConfig.Runtime.Replay.RecordDir != "" {
+		recorder = replay.NewRecorder(msgHandler)
+		msgHandler = recorder
+	}
+
 	rts := make(map[version.Version]host.Runtime)
 	for version, cfg := range cfgs {
 		rtCfg := *cfg
@@ -85,29 +104,147 @@ func (n *RuntimeHostNode) ProvisionHostedRuntime(ctx context.Context) (host.Rich
 
 		// Provision the runtime.
 		if rts[version], err = composite.New(rtCfg, provisioner); err != nil {
+			hvSub.Close()
 			return nil, nil, fmt.Errorf("failed to provision runtime version %s: %w", version, err)
 		}
 	}
 
 	agg, err := multi.New(runtime.ID(), rts)
 	if err != nil {
+		hvSub.Close()
 		return nil, nil, fmt.Errorf("failed to provision aggregate runtime: %w", err)
 	}
 
 	notifier := n.factory.NewRuntimeHostNotifier(ctx, agg)
-	rr := host.NewRichRuntime(agg)
+	profiler := newCallProfiler(runtime.ID(), agg)
+	rr := host.NewRichRuntime(profiler)
 
 	n.Lock()
 	n.agg = agg.(*multi.Aggregate)
+	n.profiler = profiler
+	n.recorder = recorder
 	n.runtime = rr
 	n.notifier = notifier
 	n.Unlock()
 
 	close(n.runtimeNotify)
 
+	for version := range rts {
+		runtime.NotifyLifecycleEvent(&runtimeAPI.LifecycleEvent{
+			Kind:    runtimeAPI.LifecycleEventProvisioned,
+			Version: &version,
+		})
+	}
+	evCh, evSub := agg.WatchEvents()
+	go n.watchLifecycleEvents(ctx, runtime, evCh, evSub)
+	go n.watchHostVersions(ctx, runtime, provisioner, msgHandler, hvCh, hvSub)
+
 	return rr, notifier, nil
 }
 
+// watchHostVersions provisions runtime versions that become available after the hosted runtime
+// has been provisioned (e.g., bundles fetched from peers) and adds them to the aggregate.
+func (n *RuntimeHostNode) watchHostVersions(
+	ctx context.Context,
+	runtime Runtime,
+	provisioner host.Provisioner,
+	msgHandler host.RuntimeHandler,
+	hvCh <-chan version.Version,
+	hvSub pubsub.ClosableSubscription,
+) {
+	defer hvSub.Close()
+
+	for {
+		var ver version.Version
+		select {
+		case <-ctx.Done():
+			return
+		case ver = <-hvCh:
+		}
+
+		cfgs, _, err := runtime.Host()
+		if err != nil {
+			continue
+		}
+		cfg, ok := cfgs[ver]
+		if !ok {
+			continue
+		}
+		rtCfg := *cfg
+		rtCfg.MessageHandler = msgHandler
+
+		rt, err := composite.New(rtCfg, provisioner)
+		if err != nil {
+			n.logger.Error("failed to provision runtime version",
+				"err", err,
+				"version", ver,
+			)
+			continue
+		}
+
+		n.Lock()
+		agg := n.agg
+		n.Unlock()
+
+		if err = agg.AddVersion(ver, rt); err != nil {
+			n.logger.Error("failed to add runtime version",
+				"err", err,
+				"version", ver,
+			)
+			continue
+		}
+
+		runtime.NotifyLifecycleEvent(&runtimeAPI.LifecycleEvent{
+			Kind:    runtimeAPI.LifecycleEventProvisioned,
+			Version: &ver,
+		})
+	}
+}
+
+// watchLifecycleEvents translates hosted runtime events into runtime lifecycle events.
+func (n *RuntimeHostNode) watchLifecycleEvents(ctx context.Context, runtime Runtime, evCh <-chan *host.Event, evSub pubsub.ClosableSubscription) {
+	defer evSub.Close()
+
+	for {
+		var (
+			ev *host.Event
+			ok bool
+		)
+		select {
+		case <-ctx.Done():
+			return
+		case ev, ok = <-evCh:
+			if !ok {
+				return
+			}
+		}
+
+		switch {
+		case ev.Started != nil:
+			runtime.NotifyLifecycleEvent(&runtimeAPI.LifecycleEvent{
+				Kind:          runtimeAPI.LifecycleEventStarted,
+				Version:       &ev.Started.Version,
+				CapabilityTEE: ev.Started.CapabilityTEE,
+			})
+		case ev.Updated != nil && ev.Updated.CapabilityTEE != nil:
+			runtime.NotifyLifecycleEvent(&runtimeAPI.LifecycleEvent{
+				Kind:          runtimeAPI.LifecycleEventAttested,
+				Version:       &ev.Updated.Version,
+				CapabilityTEE: ev.Updated.CapabilityTEE,
+			})
+		case ev.FailedToStart != nil:
+			runtime.NotifyLifecycleEvent(&runtimeAPI.LifecycleEvent{
+				Kind:  runtimeAPI.LifecycleEventStopped,
+				Error: ev.FailedToStart.Error.Error(),
+			})
+		case ev.Stopped != nil:
+			runtime.NotifyLifecycleEvent(&runtimeAPI.LifecycleEvent{
+				Kind: runtimeAPI.LifecycleEventStopped,
+			})
+		}
+	}
+}
+
 // GetHostedRuntime returns the provisioned hosted runtime (if any).
 func (n *RuntimeHostNode) GetHostedRuntime() host.RichRuntime {
 	n.Lock()
@@ -140,6 +277,30 @@ func (n *RuntimeHostNode) GetHostedRuntimeActiveVersion() (*version.Version, err
 	return agg.GetActiveVersion()
 }
 
+// GetHostedRuntimeCallStats returns the rolling latency statistics of calls into the hosted
+// runtime, keyed by call type.
+func (n *RuntimeHostNode) GetHostedRuntimeCallStats() map[string]runtimeAPI.CallLatencyStats {
+	n.Lock()
+	profiler := n.profiler
+	n.Unlock()
+
+	if profiler == nil {
+		return nil
+	}
+
+	return profiler.Stats()
+}
+
+// GetHostedRuntimeRecorder returns the recorder of host calls made by the hosted runtime.
+//
+// It is nil in case recording of runtime rounds is not enabled.
+func (n *RuntimeHostNode) GetHostedRuntimeRecorder() *replay.Recorder {
+	n.Lock()
+	defer n.Unlock()
+
+	return n.recorder
+}
+
 // GetHostedRuntimeCapabilityTEE returns the CapabilityTEE for the active runtime version.
 //
 // It may be nil in case the CapabilityTEE is not available or if the runtime is not running
@@ -186,7 +347,17 @@ func (n *RuntimeHostNode) SetHostedRuntimeVersion(active version.Version, next *
 		return fmt.Errorf("runtime not available")
 	}
 
-	return agg.SetVersion(active, next)
+	prev, _ := agg.GetActiveVersion()
+	if err := agg.SetVersion(active, next); err != nil {
+		return err
+	}
+	if prev == nil || *prev != active {
+		n.factory.GetRuntime().NotifyLifecycleEvent(&runtimeAPI.LifecycleEvent{
+			Kind:    runtimeAPI.LifecycleEventVersionActivated,
+			Version: &active,
+		})
+	}
+	return nil
 }
 
 // RuntimeHostHandlerFactory is an interface that can be used to create new runtime handlers and
@@ -207,6 +378,7 @@ func NewRuntimeHostNode(factory RuntimeHostHandlerFactory) (*RuntimeHostNode, er
 	return &RuntimeHostNode{
 		factory:       factory,
 		runtimeNotify: make(chan struct{}),
+		logger:        logging.GetLogger("runtime/registry/host").With("runtime_id", factory.GetRuntime().ID()),
 	}, nil
 }
 
@@ -234,6 +406,8 @@ type runtimeHostHandler struct {
 	env       RuntimeHostHandlerEnvironment
 	runtime   Runtime
 	consensus consensus.Backend
+
+	stateProofCache *lru.Cache
 }
 
 func (h *runtimeHostHandler) handleHostRPCCall(
@@ -373,6 +547,28 @@ func (h *runtimeHostHandler) handleHostFetchConsensusBlock(
 	return &protocol.HostFetchConsensusBlockResponse{Block: *blk}, nil
 }
 
+func (h *runtimeHostHandler) handleHostFetchConsensusBlockRange(
+	ctx context.Context,
+	rq *protocol.HostFetchConsensusBlockRangeRequest,
+) (*protocol.HostFetchConsensusBlockRangeResponse, error) {
+	lc, err := h.env.GetLightClient()
+	if err != nil {
+		return nil, err
+	}
+	lbr, _, err := lc.GetLightBlockRange(ctx, &consensus.GetLightBlockRangeRequest{
+		StartHeight: int64(rq.StartHeight),
+		EndHeight:   int64(rq.EndHeight),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("light block range fetch failure: %w", err)
+	}
+
+	return &protocol.HostFetchConsensusBlockRangeResponse{
+		Blocks:        lbr.Blocks,
+		ValidatorSets: lbr.ValidatorSets,
+	}, nil
+}
+
 func (h *runtimeHostHandler) handleHostFetchConsensusEvents(
 	ctx context.Context,
 	rq *protocol.HostFetchConsensusEventsRequest,
@@ -561,9 +757,15 @@ func (h *runtimeHostHandler) Handle(ctx context.Context, rq *protocol.Body) (*pr
 	case rq.HostFetchConsensusBlockRequest != nil:
 		// Consensus light client.
 		rsp.HostFetchConsensusBlockResponse, err = h.handleHostFetchConsensusBlock(ctx, rq.HostFetchConsensusBlockRequest)
+	case rq.HostFetchConsensusBlockRangeRequest != nil:
+		// Consensus light client.
+		rsp.HostFetchConsensusBlockRangeResponse, err = h.handleHostFetchConsensusBlockRange(ctx, rq.HostFetchConsensusBlockRangeRequest)
 	case rq.HostFetchConsensusEventsRequest != nil:
 		// Consensus events.
 		rsp.HostFetchConsensusEventsResponse, err = h.handleHostFetchConsensusEvents(ctx, rq.HostFetchConsensusEventsRequest)
+	case rq.HostFetchConsensusStateProofRequest != nil:
+		// Consensus state proofs.
+		rsp.HostFetchConsensusStateProofResponse, err = h.handleHostFetchConsensusStateProof(ctx, rq.HostFetchConsensusStateProofRequest)
 	case rq.HostFetchGenesisHeightRequest != nil:
 		// Consensus genesis height.
 		rsp.HostFetchGenesisHeightResponse, err = h.handleHostFetchGenesisHeight(ctx)
@@ -579,6 +781,9 @@ func (h *runtimeHostHandler) Handle(ctx context.Context, rq *protocol.Body) (*pr
 	case rq.HostIdentityRequest != nil:
 		// Host identity.
 		rsp.HostIdentityResponse, err = h.handleHostIdentity()
+	case rq.HostReportTelemetryRequest != nil:
+		// Runtime telemetry.
+		rsp.HostReportTelemetryResponse, err = h.handleHostReportTelemetry(rq.HostReportTelemetryRequest)
 	default:
 		err = fmt.Errorf("method not supported")
 	}
@@ -1020,9 +1225,12 @@ func NewRuntimeHostHandler(
 	runtime Runtime,
 	consensus consensus.Backend,
 ) host.RuntimeHandler {
+	initTelemetryMetrics()
+
 	return &runtimeHostHandler{
-		env:       env,
-		runtime:   runtime,
-		consensus: consensus,
+		env:             env,
+		runtime:         runtime,
+		consensus:       consensus,
+		stateProofCache: lru.New(lru.Capacity(stateProofCacheCapacity, false)),
 	}
 }
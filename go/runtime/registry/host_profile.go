@@ -0,0 +1,156 @@
+package registry
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	runtimeAPI "github.com/oasisprotocol/oasis-core/go/runtime/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+)
+
+// callProfileWindowSize is the number of most recent runtime calls of each type that are used
+// to compute the rolling latency percentiles.
+const callProfileWindowSize = 1024
+
+var (
+	runtimeCallLatency = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Name:       "oasis_runtime_host_call_latency_seconds",
+			Help:       "Latency of calls into the hosted runtime by call type (seconds).",
+			Objectives: map[float64]float64{0.5: 0.05, 0.95: 0.01, 0.99: 0.001},
+		},
+		[]string{"runtime", "call"},
+	)
+	runtimeCallFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_runtime_host_call_failures",
+			Help: "Number of failed calls into the hosted runtime by call type.",
+		},
+		[]string{"runtime", "call"},
+	)
+
+	profileCollectors = []prometheus.Collector{
+		runtimeCallLatency,
+		runtimeCallFailures,
+	}
+
+	profileMetricsOnce sync.Once
+)
+
+func initProfileMetrics() {
+	profileMetricsOnce.Do(func() {
+		prometheus.MustRegister(profileCollectors...)
+	})
+}
+
+type callLatencyWindow struct {
+	count    uint64
+	failures uint64
+
+	samples []time.Duration
+	next    int
+}
+
+func (w *callLatencyWindow) add(latency time.Duration, failed bool) {
+	w.count++
+	if failed {
+		w.failures++
+	}
+
+	if len(w.samples) < callProfileWindowSize {
+		w.samples = append(w.samples, latency)
+		return
+	}
+	w.samples[w.next] = latency
+	w.next = (w.next + 1) % callProfileWindowSize
+}
+
+func (w *callLatencyWindow) stats() runtimeAPI.CallLatencyStats {
+	sorted := slices.Clone(w.samples)
+	slices.Sort(sorted)
+
+	return runtimeAPI.CallLatencyStats{
+		Count:    w.count,
+		Failures: w.failures,
+		P50:      percentile(sorted, 50),
+		P95:      percentile(sorted, 95),
+		P99:      percentile(sorted, 99),
+	}
+}
+
+// percentile returns the given percentile of a sorted list of samples using the nearest-rank
+// method.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// callProfiler is a hosted runtime wrapper that records latencies of all calls into the runtime.
+type callProfiler struct {
+	host.Runtime
+
+	runtimeLabel string
+
+	mu      sync.Mutex
+	windows map[string]*callLatencyWindow
+}
+
+func newCallProfiler(runtimeID common.Namespace, rt host.Runtime) *callProfiler {
+	initProfileMetrics()
+
+	return &callProfiler{
+		Runtime:      rt,
+		runtimeLabel: runtimeID.String(),
+		windows:      make(map[string]*callLatencyWindow),
+	}
+}
+
+// Call implements host.Runtime.
+func (p *callProfiler) Call(ctx context.Context, body *protocol.Body) (*protocol.Body, error) {
+	call := body.Type()
+
+	start := time.Now()
+	rsp, err := p.Runtime.Call(ctx, body)
+	latency := time.Since(start)
+
+	runtimeCallLatency.WithLabelValues(p.runtimeLabel, call).Observe(latency.Seconds())
+	if err != nil {
+		runtimeCallFailures.WithLabelValues(p.runtimeLabel, call).Inc()
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	w, ok := p.windows[call]
+	if !ok {
+		w = &callLatencyWindow{}
+		p.windows[call] = w
+	}
+	w.add(latency, err != nil)
+
+	return rsp, err
+}
+
+// Stats returns the rolling latency statistics for each call type.
+func (p *callProfiler) Stats() map[string]runtimeAPI.CallLatencyStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := make(map[string]runtimeAPI.CallLatencyStats, len(p.windows))
+	for call, w := range p.windows {
+		stats[call] = w.stats()
+	}
+	return stats
+}
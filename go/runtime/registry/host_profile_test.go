@@ -0,0 +1,36 @@
+package registry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallLatencyWindow(t *testing.T) {
+	require := require.New(t)
+
+	var w callLatencyWindow
+	stats := w.stats()
+	require.EqualValues(0, stats.Count)
+	require.EqualValues(0, stats.P50)
+
+	for i := 1; i <= 100; i++ {
+		w.add(time.Duration(i)*time.Millisecond, i%10 == 0)
+	}
+	stats = w.stats()
+	require.EqualValues(100, stats.Count)
+	require.EqualValues(10, stats.Failures)
+	require.Equal(50*time.Millisecond, stats.P50)
+	require.Equal(95*time.Millisecond, stats.P95)
+	require.Equal(99*time.Millisecond, stats.P99)
+
+	// Older samples should be evicted once the window is full.
+	for i := 0; i < callProfileWindowSize; i++ {
+		w.add(time.Second, false)
+	}
+	stats = w.stats()
+	require.EqualValues(100+callProfileWindowSize, stats.Count)
+	require.Equal(time.Second, stats.P50)
+	require.Equal(time.Second, stats.P99)
+}
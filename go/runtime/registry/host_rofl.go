@@ -43,6 +43,7 @@ type roflHostHandler struct {
 
 	client        runtimeClient.RuntimeClient
 	eventNotifier *roflEventNotifier
+	registrator   *roflAppRegistrator
 
 	logger *logging.Logger
 }
@@ -63,6 +64,7 @@ func newSubHandlerROFL(parent *runtimeHostHandler, cr host.CompositeRuntime, com
 		comp:          comp,
 		client:        client,
 		eventNotifier: newROFLEventNotifier(parent.runtime, client, logger),
+		registrator:   newROFLAppRegistrator(parent, client, logger),
 		logger:        logger,
 	}, nil
 }
@@ -74,6 +76,7 @@ func (rh *roflHostHandler) NewSubHandler(host.CompositeRuntime, *bundle.Componen
 
 // Implements host.RuntimeHandler.
 func (rh *roflHostHandler) AttachRuntime(rt host.Runtime) error {
+	rh.registrator.AttachRuntime(rt)
 	return rh.eventNotifier.AttachRuntime(rt)
 }
 
@@ -109,6 +112,9 @@ func (rh *roflHostHandler) Handle(ctx context.Context, rq *protocol.Body) (*prot
 	case rq.HostRegisterNotifyRequest != nil:
 		// Subscription to host notifications.
 		rsp.HostRegisterNotifyResponse, err = rh.handleHostRegisterNotify(ctx, rq.HostRegisterNotifyRequest)
+	case rq.HostRegisterAppRequest != nil:
+		// App instance registration.
+		rsp.HostRegisterAppResponse, err = rh.registrator.RegisterApp(ctx, rq.HostRegisterAppRequest)
 	default:
 		// All other requests handled by parent.
 		return rh.parent.Handle(ctx, rq)
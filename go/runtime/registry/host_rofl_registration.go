@@ -0,0 +1,265 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	cmnSync "github.com/oasisprotocol/oasis-core/go/common/sync"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	runtimeClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+)
+
+const (
+	// roflMaxRegistrationValidity is the maximum number of epochs for which an app instance
+	// registration can be valid.
+	roflMaxRegistrationValidity = beacon.EpochTime(30)
+	// roflBuildRegistrationTimeout is the maximum amount of time the ROFL component can take to
+	// build the registration transaction.
+	roflBuildRegistrationTimeout = 5 * time.Second
+)
+
+// roflAppRegistrator registers the app instance of a ROFL component on its behalf and keeps the
+// registration up to date.
+//
+// The host takes care of endorsing the component's TEE capability, submitting the registration
+// transaction and renewing the registration before it expires or when the component is attested
+// again. The component only needs to build and sign the (runtime-specific) transaction.
+type roflAppRegistrator struct {
+	sync.Mutex
+
+	// registerLock serializes registrations. It is separate from the main lock so that
+	// registrations waiting for the transaction to be included in a block do not prevent the
+	// component from being replaced.
+	registerLock sync.Mutex
+
+	startOne cmnSync.One
+
+	parent *runtimeHostHandler
+	client runtimeClient.RuntimeClient
+
+	rt             host.Runtime
+	runtimeID      common.Namespace
+	validityEpochs beacon.EpochTime
+	expiration     beacon.EpochTime
+
+	logger *logging.Logger
+}
+
+func newROFLAppRegistrator(parent *runtimeHostHandler, client runtimeClient.RuntimeClient, logger *logging.Logger) *roflAppRegistrator {
+	return &roflAppRegistrator{
+		startOne: cmnSync.NewOne(),
+		parent:   parent,
+		client:   client,
+		logger:   logger,
+	}
+}
+
+// AttachRuntime sets the ROFL component whose app instance is being registered.
+func (ar *roflAppRegistrator) AttachRuntime(rt host.Runtime) {
+	ar.Lock()
+	defer ar.Unlock()
+
+	ar.rt = rt
+}
+
+// RegisterApp registers the app instance and starts renewing the registration in the background.
+func (ar *roflAppRegistrator) RegisterApp(ctx context.Context, rq *protocol.HostRegisterAppRequest) (*protocol.HostRegisterAppResponse, error) {
+	if rq.ValidityEpochs == 0 || rq.ValidityEpochs > roflMaxRegistrationValidity {
+		return nil, fmt.Errorf("registration validity must be between 1 and %d epochs", roflMaxRegistrationValidity)
+	}
+
+	ar.registerLock.Lock()
+	defer ar.registerLock.Unlock()
+
+	ar.Lock()
+	ar.runtimeID = rq.RuntimeID
+	ar.validityEpochs = rq.ValidityEpochs
+	ar.Unlock()
+
+	rsp, err := ar.register(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ar.startOne.TryStart(ar.run)
+
+	return rsp, nil
+}
+
+// register builds and submits the registration transaction. The caller must hold the register
+// lock.
+func (ar *roflAppRegistrator) register(ctx context.Context) (*protocol.HostRegisterAppResponse, error) {
+	ar.Lock()
+	rt, runtimeID, validityEpochs := ar.rt, ar.runtimeID, ar.validityEpochs
+	ar.Unlock()
+
+	if rt == nil {
+		return nil, fmt.Errorf("runtime not attached")
+	}
+
+	// Endorse the component's current TEE capability.
+	capabilityTEE, err := rt.GetCapabilityTEE()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get TEE capability: %w", err)
+	}
+	if capabilityTEE == nil {
+		return nil, fmt.Errorf("TEE capability not available")
+	}
+	identity, err := ar.parent.env.GetNodeIdentity()
+	if err != nil {
+		return nil, err
+	}
+	ect, err := node.EndorseCapabilityTEE(identity.NodeSigner, capabilityTEE)
+	if err != nil {
+		return nil, fmt.Errorf("failed to endorse TEE capability: %w", err)
+	}
+
+	epoch, err := ar.parent.consensus.Beacon().GetEpoch(ctx, consensus.HeightLatest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query current epoch: %w", err)
+	}
+	expiration := epoch + validityEpochs
+
+	// Ask the component to build and sign the registration transaction.
+	buildCtx, cancelBuild := context.WithTimeout(ctx, roflBuildRegistrationTimeout)
+	defer cancelBuild()
+
+	rspRaw, err := rt.Call(buildCtx, &protocol.Body{
+		RuntimeBuildAppRegistrationRequest: &protocol.RuntimeBuildAppRegistrationRequest{
+			EndorsedCapabilityTEE: *ect,
+			Expiration:            expiration,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build registration transaction: %w", err)
+	}
+	buildRsp := rspRaw.RuntimeBuildAppRegistrationResponse
+	if buildRsp == nil {
+		ar.logger.Warn("malformed response from runtime",
+			"response", rspRaw,
+		)
+		return nil, fmt.Errorf("malformed response from ROFL component")
+	}
+
+	// Submit the transaction and wait for it to be included in a block.
+	submitCtx, cancelSubmit := context.WithTimeout(ctx, roflSubmitTxTimeout)
+	defer cancelSubmit()
+
+	rsp, err := ar.client.SubmitTxMeta(submitCtx, &runtimeClient.SubmitTxRequest{
+		RuntimeID: runtimeID,
+		Data:      buildRsp.Tx,
+	})
+	switch {
+	case err != nil:
+		return nil, fmt.Errorf("failed to submit registration transaction: %w", err)
+	case rsp.CheckTxError != nil:
+		return nil, errors.WithContext(runtimeClient.ErrCheckTxFailed, rsp.CheckTxError.String())
+	default:
+	}
+
+	ar.Lock()
+	ar.expiration = expiration
+	ar.Unlock()
+
+	ar.logger.Info("app instance registered",
+		"rak", capabilityTEE.RAK,
+		"expiration", expiration,
+		"round", rsp.Round,
+	)
+
+	return &protocol.HostRegisterAppResponse{
+		Expiration: expiration,
+		Output:     rsp.Output,
+		Round:      rsp.Round,
+	}, nil
+}
+
+// needsRenewal returns true iff the registration should be renewed in the given epoch.
+func (ar *roflAppRegistrator) needsRenewal(epoch beacon.EpochTime) bool {
+	ar.Lock()
+	defer ar.Unlock()
+
+	// Renew the registration once half of its validity period has passed.
+	return epoch+(ar.validityEpochs+1)/2 >= ar.expiration
+}
+
+func (ar *roflAppRegistrator) run(ctx context.Context) {
+	epochCh, epochSub, err := ar.parent.consensus.Beacon().WatchEpochs(ctx)
+	if err != nil {
+		ar.logger.Error("failed to watch epochs, app instance registration will not be renewed",
+			"err", err,
+		)
+		return
+	}
+	defer epochSub.Close()
+
+	var (
+		rt    host.Runtime
+		evCh  <-chan *host.Event
+		evSub pubsub.ClosableSubscription
+	)
+	defer func() {
+		if evSub != nil {
+			evSub.Close()
+		}
+	}()
+
+	for {
+		// Make sure to watch the events of the currently attached component as it may have been
+		// replaced in the meantime.
+		ar.Lock()
+		currentRt := ar.rt
+		ar.Unlock()
+
+		if currentRt != rt {
+			if evSub != nil {
+				evSub.Close()
+			}
+			rt = currentRt
+			evCh, evSub = nil, nil
+			if rt != nil {
+				evCh, evSub = rt.WatchEvents()
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case ev, ok := <-evCh:
+			if !ok {
+				evCh = nil
+				continue
+			}
+
+			// Re-register when the TEE capability changes (e.g., after re-attestation) so that the
+			// registration always refers to the current capability.
+			if ev.Updated == nil || ev.Updated.CapabilityTEE == nil {
+				continue
+			}
+		case epoch := <-epochCh:
+			if !ar.needsRenewal(epoch) {
+				continue
+			}
+		}
+
+		ar.registerLock.Lock()
+		_, err = ar.register(ctx)
+		ar.registerLock.Unlock()
+		if err != nil {
+			// Failed renewals are retried in the next epoch.
+			ar.logger.Warn("failed to renew app instance registration",
+				"err", err,
+			)
+		}
+	}
+}
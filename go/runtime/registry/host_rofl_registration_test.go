@@ -0,0 +1,200 @@
+package registry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/identity"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	runtimeClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+)
+
+type testROFLEnvironment struct {
+	RuntimeHostHandlerEnvironment
+
+	identity *identity.Identity
+}
+
+func (env *testROFLEnvironment) GetNodeIdentity() (*identity.Identity, error) {
+	return env.identity, nil
+}
+
+type testROFLConsensus struct {
+	consensus.Backend
+}
+
+func (c *testROFLConsensus) Beacon() beacon.Backend {
+	return &testROFLBeacon{}
+}
+
+type testROFLBeacon struct {
+	beacon.Backend
+}
+
+func (b *testROFLBeacon) GetEpoch(context.Context, int64) (beacon.EpochTime, error) {
+	return 10, nil
+}
+
+func (b *testROFLBeacon) WatchEpochs(context.Context) (<-chan beacon.EpochTime, pubsub.ClosableSubscription, error) {
+	ch := make(chan beacon.EpochTime)
+	sub := pubsub.NewBroker(false).Subscribe()
+	sub.Unwrap(ch)
+	return ch, sub, nil
+}
+
+type testROFLRuntime struct {
+	host.Runtime
+
+	calls int
+}
+
+func (rt *testROFLRuntime) GetCapabilityTEE() (*node.CapabilityTEE, error) {
+	return &node.CapabilityTEE{
+		Hardware: node.TEEHardwareIntelSGX,
+		RAK:      memorySigner.NewTestSigner("rofl registration test rak").Public(),
+	}, nil
+}
+
+func (rt *testROFLRuntime) WatchEvents() (<-chan *host.Event, pubsub.ClosableSubscription) {
+	ch := make(chan *host.Event)
+	sub := pubsub.NewBroker(false).Subscribe()
+	sub.Unwrap(ch)
+	return ch, sub
+}
+
+func (rt *testROFLRuntime) Call(context.Context, *protocol.Body) (*protocol.Body, error) {
+	rt.calls++
+	return &protocol.Body{
+		RuntimeBuildAppRegistrationResponse: &protocol.RuntimeBuildAppRegistrationResponse{
+			Tx: []byte("registration tx"),
+		},
+	}, nil
+}
+
+type testROFLClient struct {
+	runtimeClient.RuntimeClient
+
+	submitted chan struct{}
+	unblock   chan struct{}
+}
+
+func (c *testROFLClient) SubmitTxMeta(context.Context, *runtimeClient.SubmitTxRequest) (*runtimeClient.SubmitTxMetaResponse, error) {
+	c.submitted <- struct{}{}
+	<-c.unblock
+	return &runtimeClient.SubmitTxMetaResponse{Round: 42}, nil
+}
+
+func TestROFLAppRegistrator(t *testing.T) {
+	require := require.New(t)
+
+	ar := newROFLAppRegistrator(nil, nil, logging.GetLogger("test"))
+
+	for _, validity := range []beacon.EpochTime{0, roflMaxRegistrationValidity + 1} {
+		_, err := ar.RegisterApp(context.Background(), &protocol.HostRegisterAppRequest{
+			ValidityEpochs: validity,
+		})
+		require.Error(err, "invalid registration validity should be rejected")
+	}
+
+	_, err := ar.RegisterApp(context.Background(), &protocol.HostRegisterAppRequest{
+		ValidityEpochs: 1,
+	})
+	require.ErrorContains(err, "runtime not attached")
+
+	// Registration valid for 4 epochs made in epoch 10.
+	ar.validityEpochs = 4
+	ar.expiration = 14
+	require.False(ar.needsRenewal(10))
+	require.False(ar.needsRenewal(11))
+	require.True(ar.needsRenewal(12))
+	require.True(ar.needsRenewal(15), "expired registration should be renewed")
+
+	// Registration valid for a single epoch made in epoch 10.
+	ar.validityEpochs = 1
+	ar.expiration = 11
+	require.False(ar.needsRenewal(9))
+	require.True(ar.needsRenewal(10))
+}
+
+func TestROFLAppRegistratorAttachDuringSubmission(t *testing.T) {
+	require := require.New(t)
+
+	parent := &runtimeHostHandler{
+		env: &testROFLEnvironment{
+			identity: &identity.Identity{
+				NodeSigner: memorySigner.NewTestSigner("rofl registration test node"),
+			},
+		},
+		consensus: &testROFLConsensus{},
+	}
+	client := &testROFLClient{
+		submitted: make(chan struct{}, 1),
+		unblock:   make(chan struct{}),
+	}
+	ar := newROFLAppRegistrator(parent, client, logging.GetLogger("test"))
+	defer ar.startOne.TryStop()
+
+	rt1 := &testROFLRuntime{}
+	ar.AttachRuntime(rt1)
+
+	type result struct {
+		rsp *protocol.HostRegisterAppResponse
+		err error
+	}
+	register := func() <-chan result {
+		ch := make(chan result, 1)
+		go func() {
+			rsp, err := ar.RegisterApp(context.Background(), &protocol.HostRegisterAppRequest{
+				ValidityEpochs: 4,
+			})
+			ch <- result{rsp, err}
+		}()
+		return ch
+	}
+
+	resCh := register()
+	select {
+	case <-client.submitted:
+	case <-time.After(time.Second):
+		require.FailNow("registration transaction should be submitted")
+	}
+
+	// Attaching a new component must not wait for the pending submission.
+	rt2 := &testROFLRuntime{}
+	attached := make(chan struct{})
+	go func() {
+		ar.AttachRuntime(rt2)
+		close(attached)
+	}()
+	select {
+	case <-attached:
+	case <-time.After(time.Second):
+		require.FailNow("attaching runtime should not block during submission")
+	}
+
+	client.unblock <- struct{}{}
+	res := <-resCh
+	require.NoError(res.err, "RegisterApp")
+	require.EqualValues(14, res.rsp.Expiration)
+	require.EqualValues(42, res.rsp.Round)
+	require.Equal(1, rt1.calls)
+
+	// Further registrations should use the newly attached component.
+	resCh = register()
+	<-client.submitted
+	client.unblock <- struct{}{}
+	res = <-resCh
+	require.NoError(res.err, "RegisterApp")
+	require.Equal(1, rt1.calls)
+	require.Equal(1, rt2.calls)
+}
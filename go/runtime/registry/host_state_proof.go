@@ -0,0 +1,115 @@
+package registry
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
+)
+
+const (
+	// stateProofMaxKeys is the maximum number of keys that may be proven in a single request.
+	stateProofMaxKeys = 64
+	// stateProofCacheCapacity is the maximum number of cached state proofs.
+	stateProofCacheCapacity = 1024
+)
+
+// stateProofKeyRanges are the consensus state key prefix ranges for which proofs may be requested.
+//
+// These cover the registry (0x10-0x1f) and staking (0x50-0x5f) consensus application state.
+var stateProofKeyRanges = [][2]byte{
+	{0x10, 0x1f},
+	{0x50, 0x5f},
+}
+
+type stateProofCacheEntry struct {
+	stateRoot hash.Hash
+	proof     *storage.Proof
+}
+
+// validateStateProofRequest checks that the given consensus state proof request only refers to
+// keys that runtimes are allowed to request proofs for.
+func validateStateProofRequest(rq *protocol.HostFetchConsensusStateProofRequest) error {
+	if rq.Height == 0 {
+		return fmt.Errorf("height must be specified")
+	}
+	if l := len(rq.Keys); l == 0 || l > stateProofMaxKeys {
+		return fmt.Errorf("invalid number of keys (max: %d, count: %d)", stateProofMaxKeys, l)
+	}
+
+Keys:
+	for _, key := range rq.Keys {
+		if len(key) == 0 {
+			return fmt.Errorf("empty key")
+		}
+		for _, r := range stateProofKeyRanges {
+			if key[0] >= r[0] && key[0] <= r[1] {
+				continue Keys
+			}
+		}
+		return fmt.Errorf("key prefix 0x%02x not allowed", key[0])
+	}
+	return nil
+}
+
+func stateProofCacheKey(height uint64, key []byte) string {
+	var rawHeight [8]byte
+	binary.BigEndian.PutUint64(rawHeight[:], height)
+	return string(rawHeight[:]) + string(key)
+}
+
+func (h *runtimeHostHandler) handleHostFetchConsensusStateProof(
+	ctx context.Context,
+	rq *protocol.HostFetchConsensusStateProofRequest,
+) (*protocol.HostFetchConsensusStateProofResponse, error) {
+	if err := validateStateProofRequest(rq); err != nil {
+		return nil, fmt.Errorf("malformed state proof request: %w", err)
+	}
+
+	rsp := protocol.HostFetchConsensusStateProofResponse{
+		Proofs: make([]*storage.Proof, len(rq.Keys)),
+	}
+	var tree *storage.TreeID
+	for i, key := range rq.Keys {
+		cacheKey := stateProofCacheKey(rq.Height, key)
+		if cached, ok := h.stateProofCache.Get(cacheKey); ok {
+			entry := cached.(*stateProofCacheEntry)
+			rsp.StateRoot = entry.stateRoot
+			rsp.Proofs[i] = entry.proof
+			continue
+		}
+
+		if tree == nil {
+			blk, err := h.consensus.GetBlock(ctx, int64(rq.Height))
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch consensus block: %w", err)
+			}
+			tree = &storage.TreeID{
+				Root:     blk.StateRoot,
+				Position: blk.StateRoot.Hash,
+			}
+			rsp.StateRoot = blk.StateRoot.Hash
+		}
+
+		proofRsp, err := h.consensus.State().SyncGet(ctx, &storage.GetRequest{
+			Tree:         *tree,
+			Key:          key,
+			ProofVersion: syncer.LatestProofVersion,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch state proof: %w", err)
+		}
+		rsp.Proofs[i] = &proofRsp.Proof
+
+		_ = h.stateProofCache.Put(cacheKey, &stateProofCacheEntry{
+			stateRoot: rsp.StateRoot,
+			proof:     rsp.Proofs[i],
+		})
+	}
+
+	return &rsp, nil
+}
@@ -0,0 +1,41 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+)
+
+func TestValidateStateProofRequest(t *testing.T) {
+	require := require.New(t)
+
+	rq := protocol.HostFetchConsensusStateProofRequest{
+		Height: 42,
+		Keys: [][]byte{
+			{0x11, 0x01, 0x02}, // Registry node.
+			{0x50, 0x03, 0x04}, // Staking account.
+			{0x56},             // Staking parameters.
+		},
+	}
+	require.NoError(validateStateProofRequest(&rq), "valid request")
+
+	rq.Height = 0
+	require.Error(validateStateProofRequest(&rq), "missing height")
+	rq.Height = 42
+
+	rq.Keys = append(rq.Keys, []byte{0x20, 0x01}) // Roothash runtime state.
+	require.Error(validateStateProofRequest(&rq), "disallowed key prefix")
+
+	rq.Keys = [][]byte{{}}
+	require.Error(validateStateProofRequest(&rq), "empty key")
+
+	rq.Keys = nil
+	require.Error(validateStateProofRequest(&rq), "no keys")
+
+	for range stateProofMaxKeys + 1 {
+		rq.Keys = append(rq.Keys, []byte{0x16})
+	}
+	require.Error(validateStateProofRequest(&rq), "too many keys")
+}
@@ -0,0 +1,107 @@
+package registry
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+)
+
+const (
+	// telemetryMaxQueues is the maximum number of queues that a runtime may report.
+	telemetryMaxQueues = 32
+	// telemetryMaxQueueNameLength is the maximum length of a reported queue name.
+	telemetryMaxQueueNameLength = 64
+)
+
+var (
+	runtimeHeapUsed = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "oasis_runtime_heap_used_bytes",
+			Help: "Heap memory in use by the hosted runtime, as reported by the runtime (bytes).",
+		},
+		[]string{"runtime"},
+	)
+	runtimeHeapCapacity = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "oasis_runtime_heap_capacity_bytes",
+			Help: "Heap memory capacity of the hosted runtime, as reported by the runtime (bytes).",
+		},
+		[]string{"runtime"},
+	)
+	runtimeStackUsed = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "oasis_runtime_stack_used_bytes",
+			Help: "Peak stack memory used by the hosted runtime, as reported by the runtime (bytes).",
+		},
+		[]string{"runtime"},
+	)
+	runtimeStackCapacity = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "oasis_runtime_stack_capacity_bytes",
+			Help: "Stack memory capacity of the hosted runtime, as reported by the runtime (bytes).",
+		},
+		[]string{"runtime"},
+	)
+	runtimeQueueDepth = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "oasis_runtime_queue_depth",
+			Help: "Total depth of internal request queues of the hosted runtime, as reported by the runtime.",
+		},
+		[]string{"runtime"},
+	)
+
+	telemetryCollectors = []prometheus.Collector{
+		runtimeHeapUsed,
+		runtimeHeapCapacity,
+		runtimeStackUsed,
+		runtimeStackCapacity,
+		runtimeQueueDepth,
+	}
+
+	telemetryMetricsOnce sync.Once
+)
+
+func initTelemetryMetrics() {
+	telemetryMetricsOnce.Do(func() {
+		prometheus.MustRegister(telemetryCollectors...)
+	})
+}
+
+// validateTelemetry checks that the reported telemetry is well-formed.
+func validateTelemetry(telemetry *protocol.RuntimeTelemetry) error {
+	// Bound the number and names of queues to keep reports small.
+	if l := len(telemetry.QueueDepths); l > telemetryMaxQueues {
+		return fmt.Errorf("too many queues (max: %d, count: %d)", telemetryMaxQueues, l)
+	}
+	for name := range telemetry.QueueDepths {
+		if l := len(name); l == 0 || l > telemetryMaxQueueNameLength {
+			return fmt.Errorf("malformed queue name: invalid length (max length: %d, length: %d)", telemetryMaxQueueNameLength, l)
+		}
+	}
+	return nil
+}
+
+func (h *runtimeHostHandler) handleHostReportTelemetry(rq *protocol.HostReportTelemetryRequest) (*protocol.Empty, error) {
+	telemetry := &rq.Telemetry
+	if err := validateTelemetry(telemetry); err != nil {
+		return nil, err
+	}
+
+	runtimeLabel := h.runtime.ID().String()
+	runtimeHeapUsed.WithLabelValues(runtimeLabel).Set(float64(telemetry.HeapUsed))
+	runtimeHeapCapacity.WithLabelValues(runtimeLabel).Set(float64(telemetry.HeapCapacity))
+	runtimeStackUsed.WithLabelValues(runtimeLabel).Set(float64(telemetry.StackUsed))
+	runtimeStackCapacity.WithLabelValues(runtimeLabel).Set(float64(telemetry.StackCapacity))
+	// Queue names are chosen by the runtime, so only the total depth is exposed to avoid
+	// unbounded metric label cardinality.
+	var queueDepth uint64
+	for _, depth := range telemetry.QueueDepths {
+		queueDepth += depth
+	}
+	runtimeQueueDepth.WithLabelValues(runtimeLabel).Set(float64(queueDepth))
+
+	return &protocol.Empty{}, nil
+}
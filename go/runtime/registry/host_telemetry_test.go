@@ -0,0 +1,38 @@
+package registry
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+)
+
+func TestValidateTelemetry(t *testing.T) {
+	require := require.New(t)
+
+	telemetry := protocol.RuntimeTelemetry{
+		HeapUsed:     1024,
+		HeapCapacity: 4096,
+		QueueDepths: map[string]uint64{
+			"check_tx": 10,
+			"rpc":      2,
+		},
+	}
+	require.NoError(validateTelemetry(&telemetry), "valid telemetry")
+
+	telemetry.QueueDepths[""] = 1
+	require.Error(validateTelemetry(&telemetry), "empty queue name")
+	delete(telemetry.QueueDepths, "")
+
+	telemetry.QueueDepths[strings.Repeat("a", telemetryMaxQueueNameLength+1)] = 1
+	require.Error(validateTelemetry(&telemetry), "queue name too long")
+
+	telemetry.QueueDepths = make(map[string]uint64)
+	for i := range telemetryMaxQueues + 1 {
+		telemetry.QueueDepths[fmt.Sprintf("queue%d", i)] = uint64(i)
+	}
+	require.Error(validateTelemetry(&telemetry), "too many queues")
+}
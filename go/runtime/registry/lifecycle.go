@@ -0,0 +1,120 @@
+package registry
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/eapache/channels"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	runtimeAPI "github.com/oasisprotocol/oasis-core/go/runtime/api"
+)
+
+// lifecycleSnapshotEvent is an event retained in the lifecycle state snapshot.
+type lifecycleSnapshotEvent struct {
+	seq uint64
+	ev  *runtimeAPI.LifecycleEvent
+}
+
+// lifecycleNotifier distributes lifecycle events to subscribers.
+//
+// Unlike a pubsub broker, it keeps a snapshot of the latest state of each runtime, consisting of
+// the most recent event of each kind, which is replayed to new subscribers so that they can
+// reconstruct the current state without missing or duplicating any events.
+type lifecycleNotifier struct {
+	sync.Mutex
+
+	seq         uint64
+	snapshot    map[common.Namespace]map[runtimeAPI.LifecycleEventKind]*lifecycleSnapshotEvent
+	subscribers map[*lifecycleSubscription]struct{}
+}
+
+type lifecycleSubscription struct {
+	n  *lifecycleNotifier
+	ch channels.Channel
+}
+
+// Implements pubsub.ClosableSubscription.
+func (s *lifecycleSubscription) Close() {
+	s.n.Lock()
+	defer s.n.Unlock()
+
+	if _, ok := s.n.subscribers[s]; !ok {
+		return
+	}
+	delete(s.n.subscribers, s)
+	s.ch.Close()
+}
+
+func (n *lifecycleNotifier) notify(ev *runtimeAPI.LifecycleEvent) {
+	if ev.Timestamp.IsZero() {
+		ev.Timestamp = time.Now()
+	}
+
+	n.Lock()
+	defer n.Unlock()
+
+	rtSnapshot := n.snapshot[ev.RuntimeID]
+	if rtSnapshot == nil {
+		rtSnapshot = make(map[runtimeAPI.LifecycleEventKind]*lifecycleSnapshotEvent)
+		n.snapshot[ev.RuntimeID] = rtSnapshot
+	}
+	n.seq++
+	rtSnapshot[ev.Kind] = &lifecycleSnapshotEvent{seq: n.seq, ev: ev}
+
+	for sub := range n.subscribers {
+		sub.ch.In() <- ev
+	}
+}
+
+// snapshotEventsLocked returns the events in the state snapshot in the order they were emitted.
+func (n *lifecycleNotifier) snapshotEventsLocked() []*runtimeAPI.LifecycleEvent {
+	var events []*lifecycleSnapshotEvent
+	for _, rtSnapshot := range n.snapshot {
+		for _, sev := range rtSnapshot {
+			events = append(events, sev)
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].seq < events[j].seq
+	})
+
+	evs := make([]*runtimeAPI.LifecycleEvent, 0, len(events))
+	for _, sev := range events {
+		evs = append(evs, sev.ev)
+	}
+	return evs
+}
+
+func (n *lifecycleNotifier) watch() (<-chan *runtimeAPI.LifecycleEvent, pubsub.ClosableSubscription) {
+	sub := &lifecycleSubscription{
+		n:  n,
+		ch: channels.NewInfiniteChannel(),
+	}
+
+	n.Lock()
+	for _, ev := range n.snapshotEventsLocked() {
+		sub.ch.In() <- ev
+	}
+	n.subscribers[sub] = struct{}{}
+	n.Unlock()
+
+	typedCh := make(chan *runtimeAPI.LifecycleEvent)
+	go func() {
+		defer close(typedCh)
+		for v := range sub.ch.Out() {
+			typedCh <- v.(*runtimeAPI.LifecycleEvent)
+		}
+	}()
+
+	return typedCh, sub
+}
+
+func newLifecycleNotifier() *lifecycleNotifier {
+	return &lifecycleNotifier{
+		snapshot:    make(map[common.Namespace]map[runtimeAPI.LifecycleEventKind]*lifecycleSnapshotEvent),
+		subscribers: make(map[*lifecycleSubscription]struct{}),
+	}
+}
@@ -0,0 +1,91 @@
+package registry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	runtimeAPI "github.com/oasisprotocol/oasis-core/go/runtime/api"
+)
+
+func TestLifecycleNotifier(t *testing.T) {
+	require := require.New(t)
+
+	recv := func(ch <-chan *runtimeAPI.LifecycleEvent) *runtimeAPI.LifecycleEvent {
+		select {
+		case ev := <-ch:
+			return ev
+		case <-time.After(time.Second):
+			require.FailNow("timed out waiting for lifecycle event")
+			return nil
+		}
+	}
+
+	runtimeID := common.NewTestNamespaceFromSeed([]byte("lifecycle notifier test"), 0)
+	otherRuntimeID := common.NewTestNamespaceFromSeed([]byte("lifecycle notifier test"), 1)
+	v := version.Version{Major: 1}
+	n := newLifecycleNotifier()
+
+	// Events emitted before subscribing should be replayed.
+	n.notify(&runtimeAPI.LifecycleEvent{RuntimeID: runtimeID, Kind: runtimeAPI.LifecycleEventBundleLoaded, Version: &v})
+
+	ch, sub := n.watch()
+	ev := recv(ch)
+	require.Equal(runtimeAPI.LifecycleEventBundleLoaded, ev.Kind)
+	require.Equal(runtimeID, ev.RuntimeID)
+	require.False(ev.Timestamp.IsZero(), "timestamp should be set")
+
+	// New events should be delivered exactly once and in order.
+	n.notify(&runtimeAPI.LifecycleEvent{RuntimeID: runtimeID, Kind: runtimeAPI.LifecycleEventProvisioned, Version: &v})
+	n.notify(&runtimeAPI.LifecycleEvent{RuntimeID: runtimeID, Kind: runtimeAPI.LifecycleEventStopped, Error: "failed"})
+	require.Equal(runtimeAPI.LifecycleEventProvisioned, recv(ch).Kind)
+	ev = recv(ch)
+	require.Equal(runtimeAPI.LifecycleEventStopped, ev.Kind)
+	require.Equal("failed", ev.Error)
+
+	sub.Close()
+	sub.Close()
+	_, ok := <-ch
+	require.False(ok, "channel should be closed after the subscription is closed")
+
+	// Only the latest event of each kind should be retained for each runtime.
+	for i := 0; i < 100; i++ {
+		n.notify(&runtimeAPI.LifecycleEvent{RuntimeID: runtimeID, Kind: runtimeAPI.LifecycleEventAttested})
+	}
+	n.notify(&runtimeAPI.LifecycleEvent{RuntimeID: otherRuntimeID, Kind: runtimeAPI.LifecycleEventStarted, Version: &v})
+	n.notify(&runtimeAPI.LifecycleEvent{RuntimeID: runtimeID, Kind: runtimeAPI.LifecycleEventStarted, Version: &v})
+	require.Len(n.snapshot, 2)
+	require.Len(n.snapshot[runtimeID], 5)
+	require.Len(n.snapshot[otherRuntimeID], 1)
+
+	// The snapshot should be replayed in the order events were emitted.
+	ch, sub = n.watch()
+	defer sub.Close()
+	for _, expected := range []struct {
+		runtimeID common.Namespace
+		kind      runtimeAPI.LifecycleEventKind
+	}{
+		{runtimeID, runtimeAPI.LifecycleEventBundleLoaded},
+		{runtimeID, runtimeAPI.LifecycleEventProvisioned},
+		{runtimeID, runtimeAPI.LifecycleEventStopped},
+		{runtimeID, runtimeAPI.LifecycleEventAttested},
+		{otherRuntimeID, runtimeAPI.LifecycleEventStarted},
+		{runtimeID, runtimeAPI.LifecycleEventStarted},
+	} {
+		ev = recv(ch)
+		require.Equal(expected.runtimeID, ev.RuntimeID)
+		require.Equal(expected.kind, ev.Kind)
+	}
+
+	// Events should survive serialization.
+	var decEv runtimeAPI.LifecycleEvent
+	err := cbor.Unmarshal(cbor.Marshal(ev), &decEv)
+	require.NoError(err, "cbor.Unmarshal")
+	require.Equal(ev.Kind, decEv.Kind)
+	require.Equal(ev.Version, decEv.Version)
+	require.Equal(ev.Timestamp.Unix(), decEv.Timestamp.Unix(), "timestamp should round-trip")
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"sync"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
@@ -22,6 +23,8 @@ import (
 	ias "github.com/oasisprotocol/oasis-core/go/ias/api"
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	runtimeAPI "github.com/oasisprotocol/oasis-core/go/runtime/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle"
 	runtimeClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
 	"github.com/oasisprotocol/oasis-core/go/runtime/history"
 	runtimeHost "github.com/oasisprotocol/oasis-core/go/runtime/host"
@@ -70,6 +73,11 @@ type Registry interface {
 
 	// FinishInitialization finalizes setup for all runtimes.
 	FinishInitialization() error
+
+	// WatchLifecycleEvents subscribes to lifecycle events of all supported runtimes.
+	//
+	// The most recent event of each kind is replayed for each runtime to new subscribers.
+	WatchLifecycleEvents() (<-chan *runtimeAPI.LifecycleEvent, pubsub.ClosableSubscription, error)
 }
 
 // Runtime is the running node's supported runtime interface.
@@ -123,6 +131,23 @@ type Runtime interface {
 
 	// HostVersions returns a list of supported runtime versions.
 	HostVersions() []version.Version
+
+	// BundlePath returns the path of the locally loaded runtime bundle with the given manifest
+	// hash if any.
+	BundlePath(manifestHash hash.Hash) (string, bool)
+
+	// LoadBundle loads the runtime bundle at the given path, making the runtime version it
+	// contains available for hosting without restarting the node.
+	LoadBundle(path string) error
+
+	// WatchHostVersions subscribes to runtime versions that become available for hosting after
+	// the runtime has been initialized.
+	WatchHostVersions() (<-chan version.Version, pubsub.ClosableSubscription)
+
+	// NotifyLifecycleEvent emits a local lifecycle event for this runtime.
+	//
+	// Events of runtimes not managed by the registry are discarded.
+	NotifyLifecycleEvent(ev *runtimeAPI.LifecycleEvent)
 }
 
 type runtime struct { // nolint: maligned
@@ -148,8 +173,14 @@ type runtime struct { // nolint: maligned
 	activeDescriptorCh         chan struct{}
 	activeDescriptorNotifier   *pubsub.Broker
 
-	hostProvisioners map[node.TEEHardware]runtimeHost.Provisioner
-	hostConfig       map[version.Version]*runtimeHost.Config
+	hostProvisioners    map[node.TEEHardware]runtimeHost.Provisioner
+	hostConfig          map[version.Version]*runtimeHost.Config
+	hostBundles         map[hash.Hash]string
+	hostDataDir         string
+	hostDetachedBundles []*bundle.Bundle
+	hostVersionNotifier *pubsub.Broker
+
+	lifecycle *lifecycleNotifier
 
 	logger *logging.Logger
 }
@@ -268,10 +299,13 @@ func (r *runtime) Host() (map[version.Version]*runtimeHost.Config, runtimeHost.P
 		return nil, nil, fmt.Errorf("no provisioner suitable for TEE hardware '%s'", r.registryDescriptor.TEEHardware)
 	}
 
-	return r.hostConfig, provisioner, nil
+	return maps.Clone(r.hostConfig), provisioner, nil
 }
 
 func (r *runtime) HostVersions() []version.Version {
+	r.RLock()
+	defer r.RUnlock()
+
 	var versions []version.Version
 	for v := range r.hostConfig {
 		versions = append(versions, v)
@@ -279,6 +313,95 @@ func (r *runtime) HostVersions() []version.Version {
 	return versions
 }
 
+func (r *runtime) BundlePath(manifestHash hash.Hash) (string, bool) {
+	r.RLock()
+	defer r.RUnlock()
+
+	path, ok := r.hostBundles[manifestHash]
+	return path, ok
+}
+
+func (r *runtime) LoadBundle(path string) error {
+	if r.hostProvisioners == nil || r.hostConfig == nil {
+		return ErrRuntimeHostNotConfigured
+	}
+
+	bnd, err := openBundle(path)
+	if err != nil {
+		return err
+	}
+	if bnd.Manifest.ID != r.id {
+		return fmt.Errorf("runtime bundle '%s' is for a different runtime (expected: %s got: %s)", path, r.id, bnd.Manifest.ID)
+	}
+	if bnd.Manifest.IsDetached() {
+		return fmt.Errorf("runtime bundle '%s' is detached", path)
+	}
+	bndVersion := bnd.Manifest.Version
+	manifestHash := bnd.Manifest.Hash()
+
+	r.RLock()
+	_, exists := r.hostConfig[bndVersion]
+	r.RUnlock()
+	if exists {
+		return fmt.Errorf("runtime version '%s' is already loaded", bndVersion)
+	}
+
+	registryParams, err := getRegistryParameters(r.consensus)
+	if err != nil {
+		return err
+	}
+	if err = explodeBundle(r.hostDataDir, path, bnd, registryParams); err != nil {
+		return err
+	}
+	hostCfg, err := newRuntimeHostConfig(r.hostDataDir, bnd, r.hostDetachedBundles)
+	if err != nil {
+		return err
+	}
+
+	r.Lock()
+	if _, exists = r.hostConfig[bndVersion]; exists {
+		r.Unlock()
+		return fmt.Errorf("runtime version '%s' is already loaded", bndVersion)
+	}
+	r.hostConfig[bndVersion] = hostCfg
+	if r.hostBundles == nil {
+		r.hostBundles = make(map[hash.Hash]string)
+	}
+	r.hostBundles[manifestHash] = path
+	r.Unlock()
+
+	r.logger.Info("loaded runtime bundle",
+		"version", bndVersion,
+		"manifest_hash", manifestHash,
+		"path", path,
+	)
+
+	r.NotifyLifecycleEvent(&runtimeAPI.LifecycleEvent{
+		Kind:    runtimeAPI.LifecycleEventBundleLoaded,
+		Version: &bndVersion,
+	})
+	r.hostVersionNotifier.Broadcast(bndVersion)
+
+	return nil
+}
+
+func (r *runtime) WatchHostVersions() (<-chan version.Version, pubsub.ClosableSubscription) {
+	sub := r.hostVersionNotifier.Subscribe()
+	ch := make(chan version.Version)
+	sub.Unwrap(ch)
+
+	return ch, sub
+}
+
+func (r *runtime) NotifyLifecycleEvent(ev *runtimeAPI.LifecycleEvent) {
+	if r.lifecycle == nil {
+		return
+	}
+
+	ev.RuntimeID = r.id
+	r.lifecycle.notify(ev)
+}
+
 func (r *runtime) stop() {
 	// Stop watching runtime updates.
 	r.cancelCtx()
@@ -423,6 +546,7 @@ type runtimeRegistry struct {
 
 	consensus consensus.Backend
 	client    runtimeClient.RuntimeClient
+	lifecycle *lifecycleNotifier
 
 	runtimes map[common.Namespace]*runtime
 }
@@ -514,6 +638,11 @@ func (r *runtimeRegistry) FinishInitialization() error {
 	return nil
 }
 
+func (r *runtimeRegistry) WatchLifecycleEvents() (<-chan *runtimeAPI.LifecycleEvent, pubsub.ClosableSubscription, error) {
+	ch, sub := r.lifecycle.watch()
+	return ch, sub, nil
+}
+
 func (r *runtimeRegistry) addSupportedRuntime(ctx context.Context, id common.Namespace) (rerr error) {
 	r.Lock()
 	defer r.Unlock()
@@ -536,6 +665,7 @@ func (r *runtimeRegistry) addSupportedRuntime(ctx context.Context, id common.Nam
 		}
 	}()
 	rt.managed = true
+	rt.lifecycle = r.lifecycle
 
 	// Create runtime history keeper.
 	// NOTE: Archive node won't commit any new blocks, so disable waiting for storage sync commits.
@@ -592,6 +722,7 @@ func newRuntime(
 		registryDescriptorNotifier: pubsub.NewBroker(true),
 		activeDescriptorCh:         make(chan struct{}),
 		activeDescriptorNotifier:   pubsub.NewBroker(true),
+		hostVersionNotifier:        pubsub.NewBroker(false),
 		logger:                     logger.With("runtime_id", id),
 	}
 	go rt.watchUpdates(watchCtx)
@@ -600,6 +731,9 @@ func newRuntime(
 	if cfg.Host != nil {
 		rt.hostProvisioners = cfg.Host.Provisioners
 		rt.hostConfig = cfg.Host.Runtimes[id]
+		rt.hostBundles = cfg.Host.Bundles[id]
+		rt.hostDataDir = cfg.Host.dataDir
+		rt.hostDetachedBundles = cfg.Host.detachedBundles[id]
 	}
 
 	return rt, nil
@@ -614,7 +748,8 @@ func New(
 	consensus consensus.Backend,
 	ias []ias.Endpoint,
 ) (Registry, error) {
-	cfg, err := newConfig(dataDir, commonStore, identity, consensus, ias)
+	lifecycle := newLifecycleNotifier()
+	cfg, err := newConfig(dataDir, commonStore, identity, consensus, ias, lifecycle)
 	if err != nil {
 		return nil, err
 	}
@@ -624,6 +759,7 @@ func New(
 		dataDir:   dataDir,
 		cfg:       cfg,
 		consensus: consensus,
+		lifecycle: lifecycle,
 		runtimes:  make(map[common.Namespace]*runtime),
 	}
 
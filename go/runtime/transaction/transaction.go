@@ -377,10 +377,54 @@ func (t *Tree) GetTransactions(ctx context.Context) ([]*Transaction, error) {
 	return txs, nil
 }
 
+// decodeArtifacts decodes the given serialized transaction artifacts into the transaction.
+func (t *Transaction) decodeArtifacts(kind artifactKind, value []byte) error {
+	switch kind {
+	case kindInput:
+		var ia inputArtifacts
+		if err := cbor.Unmarshal(value, &ia); err != nil {
+			return fmt.Errorf("transaction: malformed input artifacts: %w", err)
+		}
+
+		t.Input = ia.Input
+		t.BatchOrder = ia.BatchOrder
+	case kindOutput:
+		var oa outputArtifacts
+		if err := cbor.Unmarshal(value, &oa); err != nil {
+			return fmt.Errorf("transaction: malformed output artifacts: %w", err)
+		}
+
+		t.Output = oa.Output
+	}
+	return nil
+}
+
 // GetTransaction looks up a transaction by its hash and retrieves all of
 // its artifacts.
 func (t *Tree) GetTransaction(ctx context.Context, txHash hash.Hash) (*Transaction, error) {
-	it := t.tree.NewIterator(ctx)
+	return t.getTransaction(ctx, txHash)
+}
+
+// GetTransactionWithProof looks up a transaction by its hash and retrieves all of its artifacts
+// together with a proof of their inclusion in the tree.
+//
+// The proof can be verified against the I/O root using VerifyTransactionProof.
+func (t *Tree) GetTransactionWithProof(ctx context.Context, txHash hash.Hash) (*Transaction, *syncer.Proof, error) {
+	pb := syncer.NewProofBuilder(t.ioRoot.Hash, t.ioRoot.Hash)
+	tx, err := t.getTransaction(ctx, txHash, mkvs.WithProofBuilder(pb))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	proof, err := pb.Build(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("transaction: failed to build proof: %w", err)
+	}
+	return tx, proof, nil
+}
+
+func (t *Tree) getTransaction(ctx context.Context, txHash hash.Hash, options ...mkvs.IteratorOption) (*Transaction, error) {
+	it := t.tree.NewIterator(ctx, options...)
 	defer it.Close()
 
 	var tx Transaction
@@ -391,24 +435,9 @@ func (t *Tree) GetTransaction(ctx context.Context, txHash hash.Hash) (*Transacti
 			break
 		}
 
-		switch decKind {
-		case kindInput:
-			var ia inputArtifacts
-			if err := cbor.Unmarshal(it.Value(), &ia); err != nil {
-				return nil, fmt.Errorf("transaction: malformed input artifacts: %w", err)
-			}
-
-			tx.Input = ia.Input
-			tx.BatchOrder = ia.BatchOrder
-		case kindOutput:
-			var oa outputArtifacts
-			if err := cbor.Unmarshal(it.Value(), &oa); err != nil {
-				return nil, fmt.Errorf("transaction: malformed output artifacts: %w", err)
-			}
-
-			tx.Output = oa.Output
+		if err := tx.decodeArtifacts(decKind, it.Value()); err != nil {
+			return nil, err
 		}
-
 	}
 	if it.Err() != nil {
 		return nil, fmt.Errorf("transaction: get transaction failed: %w", it.Err())
@@ -420,6 +449,44 @@ func (t *Tree) GetTransaction(ctx context.Context, txHash hash.Hash) (*Transacti
 	return &tx, nil
 }
 
+// VerifyTransactionProof verifies a proof of inclusion of the artifacts of the transaction with
+// the given hash in the I/O tree with the given root and returns the proven transaction.
+//
+// Both the input and the output artifacts of the transaction must be included in the proof.
+func VerifyTransactionProof(ctx context.Context, ioRoot hash.Hash, txHash hash.Hash, proof *syncer.Proof) (*Transaction, error) {
+	var pv syncer.ProofVerifier
+	wl, err := pv.VerifyProofToWriteLog(ctx, ioRoot, proof)
+	if err != nil {
+		return nil, fmt.Errorf("transaction: invalid proof: %w", err)
+	}
+
+	var (
+		tx                  Transaction
+		hasInput, hasOutput bool
+	)
+	for _, entry := range wl {
+		var decHash hash.Hash
+		var decKind artifactKind
+		if !txnKeyFmt.Decode(entry.Key, &decHash, &decKind) || !decHash.Equal(&txHash) {
+			continue
+		}
+
+		if err = tx.decodeArtifacts(decKind, entry.Value); err != nil {
+			return nil, err
+		}
+		hasInput = hasInput || decKind == kindInput
+		hasOutput = hasOutput || decKind == kindOutput
+	}
+	if !hasInput || !hasOutput {
+		return nil, fmt.Errorf("transaction: proof does not include all transaction artifacts")
+	}
+	if h := tx.Hash(); !h.Equal(&txHash) {
+		return nil, fmt.Errorf("transaction: proven input does not match transaction hash")
+	}
+
+	return &tx, nil
+}
+
 // GetTransactionMultiple looks up multiple transactions by their hashes at
 // once and retrieves all of their artifacts.
 //
@@ -197,6 +197,24 @@ func TestTransaction(t *testing.T) {
 		require.Contains(t, txnsByHash, checkTx.Hash(), "transaction should exist")
 		require.True(t, txnsByHash[checkTx.Hash()].Equal(&checkTx), "transaction should have the correct artifacts") // nolint: gosec
 	}
+
+	// Get transaction with proof.
+	checkTx := testTxns[5]
+	provenTx, proof, err := tree.GetTransactionWithProof(ctx, checkTx.Hash())
+	require.NoError(t, err, "GetTransactionWithProof")
+	require.True(t, provenTx.Equal(&checkTx), "transaction should have the correct artifacts")
+
+	verifiedTx, err := VerifyTransactionProof(ctx, storeRootHash, checkTx.Hash(), proof)
+	require.NoError(t, err, "VerifyTransactionProof")
+	require.True(t, verifiedTx.Equal(&checkTx), "proven transaction should have the correct artifacts")
+
+	_, err = VerifyTransactionProof(ctx, rootHash, testTxns[6].Hash(), proof)
+	require.Error(t, err, "VerifyTransactionProof should fail for a different transaction")
+	_, err = VerifyTransactionProof(ctx, hash.NewFromBytes([]byte("other root")), checkTx.Hash(), proof)
+	require.Error(t, err, "VerifyTransactionProof should fail for a different root")
+
+	_, _, err = tree.GetTransactionWithProof(ctx, hash.NewFromBytes([]byte("missing")))
+	require.ErrorIs(t, err, ErrNotFound, "GetTransactionWithProof should fail for a missing transaction")
 }
 
 type disableReadSync struct {
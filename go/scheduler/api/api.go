@@ -356,6 +356,11 @@ type Backend interface {
 	// Iff the callback is nil, `beacon.GetBlockBeacon` will be used.
 	GetCommittees(ctx context.Context, request *GetCommitteesRequest) ([]*Committee, error)
 
+	// GetCommitteesAt returns the committees of a given runtime that were
+	// active at the specified block height or, if a runtime round is
+	// specified, the committees that were responsible for the given round.
+	GetCommitteesAt(ctx context.Context, request *GetCommitteesAtRequest) (*CommitteesAt, error)
+
 	// WatchCommittees returns a channel that produces a stream of
 	// Committee.
 	//
@@ -369,6 +374,15 @@ type Backend interface {
 	// ConsensusParameters returns the scheduler consensus parameters.
 	ConsensusParameters(ctx context.Context, height int64) (*ConsensusParameters, error)
 
+	// SimulateElection simulates the next committee election under the
+	// proposed scheduler consensus parameter and runtime committee
+	// changes using the registry state at the specified block height.
+	//
+	// Since the simulation uses the entropy of the current epoch, the
+	// exact membership of the simulated committees will differ from the
+	// ones elected at the next epoch transition.
+	SimulateElection(ctx context.Context, request *SimulateElectionRequest) (*ElectionSimulation, error)
+
 	// Cleanup cleans up the scheduler backend.
 	Cleanup()
 }
@@ -379,6 +393,98 @@ type GetCommitteesRequest struct {
 	RuntimeID common.Namespace `json:"runtime_id"`
 }
 
+// GetCommitteesAtRequest is a GetCommitteesAt request.
+type GetCommitteesAtRequest struct {
+	// RuntimeID is the runtime identifier.
+	RuntimeID common.Namespace `json:"runtime_id"`
+
+	// Height is the consensus block height at which the committees should
+	// be returned. In case a round is specified, this is the latest height
+	// that is considered when searching for the round.
+	Height int64 `json:"height"`
+
+	// Round is an optional runtime round. If specified, the committees
+	// that were responsible for the given round are returned. Rounds other
+	// than the latest one can only be resolved by nodes that keep the block
+	// history of the runtime.
+	Round *uint64 `json:"round,omitempty"`
+}
+
+// CommitteesAt are the committees of a runtime that were active at a given
+// consensus block height.
+type CommitteesAt struct {
+	// Height is the consensus block height at which the committees were
+	// active.
+	Height int64 `json:"height"`
+
+	// Committees are the runtime committees.
+	Committees []*Committee `json:"committees"`
+}
+
+// SimulateElectionRequest is a SimulateElection request.
+type SimulateElectionRequest struct {
+	// Height is the consensus block height at which the election should
+	// be simulated.
+	Height int64 `json:"height"`
+
+	// Changes are the proposed scheduler consensus parameter changes.
+	Changes *ConsensusParameterChanges `json:"changes,omitempty"`
+
+	// Runtimes are the proposed executor committee changes of individual
+	// runtimes.
+	Runtimes map[common.Namespace]map[Role]*CommitteeRoleChanges `json:"runtimes,omitempty"`
+}
+
+// CommitteeRoleChanges are proposed changes to the executor committee
+// parameters of a runtime for a given role.
+type CommitteeRoleChanges struct {
+	// Size is the new number of nodes elected to the role.
+	Size *uint16 `json:"size,omitempty"`
+
+	// MaxNodesPerEntity is the new maximum number of nodes per entity
+	// eligible for the role. Zero removes the constraint.
+	MaxNodesPerEntity *uint16 `json:"max_nodes_per_entity,omitempty"`
+
+	// MinPoolSize is the new minimum candidate pool size for the role.
+	// Zero removes the constraint.
+	MinPoolSize *uint16 `json:"min_pool_size,omitempty"`
+
+	// ValidatorSet specifies whether only entities that are part of the
+	// validator set are eligible for the role.
+	ValidatorSet *bool `json:"validator_set,omitempty"`
+}
+
+// ElectionSimulation is the result of a simulated election.
+type ElectionSimulation struct {
+	// Height is the consensus block height at which the election was
+	// simulated.
+	Height int64 `json:"height"`
+
+	// Epoch is the epoch at which the election was simulated.
+	Epoch beacon.EpochTime `json:"epoch"`
+
+	// Current is the outcome of the election under the current
+	// parameters.
+	Current *ElectionOutcome `json:"current"`
+
+	// Proposed is the outcome of the election under the proposed
+	// parameters.
+	Proposed *ElectionOutcome `json:"proposed"`
+}
+
+// ElectionOutcome is the outcome of a simulated election.
+type ElectionOutcome struct {
+	// Validators is the elected validator set.
+	Validators []*Validator `json:"validators,omitempty"`
+
+	// Committees are the elected committees. Runtimes for which no
+	// committee could be elected are not included.
+	Committees []*Committee `json:"committees,omitempty"`
+
+	// Error is the reason why the election failed, if it did.
+	Error string `json:"error,omitempty"`
+}
+
 // Genesis is the committee scheduler genesis state.
 type Genesis struct {
 	// Parameters are the scheduler consensus parameters.
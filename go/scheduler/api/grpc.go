@@ -17,10 +17,14 @@ var (
 	methodGetValidators = serviceName.NewMethod("GetValidators", int64(0))
 	// methodGetCommittees is the GetCommittees method.
 	methodGetCommittees = serviceName.NewMethod("GetCommittees", GetCommitteesRequest{})
+	// methodGetCommitteesAt is the GetCommitteesAt method.
+	methodGetCommitteesAt = serviceName.NewMethod("GetCommitteesAt", GetCommitteesAtRequest{})
 	// methodStateToGenesis is the StateToGenesis method.
 	methodStateToGenesis = serviceName.NewMethod("StateToGenesis", int64(0))
 	// methodConsensusParameters is the ConsensusParameters method.
 	methodConsensusParameters = serviceName.NewMethod("ConsensusParameters", int64(0))
+	// methodSimulateElection is the SimulateElection method.
+	methodSimulateElection = serviceName.NewMethod("SimulateElection", SimulateElectionRequest{})
 
 	// methodWatchCommittees is the WatchCommittees method.
 	methodWatchCommittees = serviceName.NewMethod("WatchCommittees", nil)
@@ -38,6 +42,10 @@ var (
 				MethodName: methodGetCommittees.ShortName(),
 				Handler:    handlerGetCommittees,
 			},
+			{
+				MethodName: methodGetCommitteesAt.ShortName(),
+				Handler:    handlerGetCommitteesAt,
+			},
 			{
 				MethodName: methodStateToGenesis.ShortName(),
 				Handler:    handlerStateToGenesis,
@@ -46,6 +54,10 @@ var (
 				MethodName: methodConsensusParameters.ShortName(),
 				Handler:    handlerConsensusParameters,
 			},
+			{
+				MethodName: methodSimulateElection.ShortName(),
+				Handler:    handlerSimulateElection,
+			},
 		},
 		Streams: []grpc.StreamDesc{
 			{
@@ -103,6 +115,29 @@ func handlerGetCommittees(
 	return interceptor(ctx, &req, info, handler)
 }
 
+func handlerGetCommitteesAt(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var req GetCommitteesAtRequest
+	if err := dec(&req); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).GetCommitteesAt(ctx, &req)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodGetCommitteesAt.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).GetCommitteesAt(ctx, req.(*GetCommitteesAtRequest))
+	}
+	return interceptor(ctx, &req, info, handler)
+}
+
 func handlerStateToGenesis(
 	srv interface{},
 	ctx context.Context,
@@ -149,6 +184,29 @@ func handlerConsensusParameters(
 	return interceptor(ctx, height, info, handler)
 }
 
+func handlerSimulateElection(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var req SimulateElectionRequest
+	if err := dec(&req); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).SimulateElection(ctx, &req)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodSimulateElection.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).SimulateElection(ctx, req.(*SimulateElectionRequest))
+	}
+	return interceptor(ctx, &req, info, handler)
+}
+
 func handlerWatchCommittees(srv interface{}, stream grpc.ServerStream) error {
 	if err := stream.RecvMsg(nil); err != nil {
 		return err
@@ -202,6 +260,14 @@ func (c *schedulerClient) GetCommittees(ctx context.Context, request *GetCommitt
 	return rsp, nil
 }
 
+func (c *schedulerClient) GetCommitteesAt(ctx context.Context, request *GetCommitteesAtRequest) (*CommitteesAt, error) {
+	var rsp CommitteesAt
+	if err := c.conn.Invoke(ctx, methodGetCommitteesAt.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *schedulerClient) StateToGenesis(ctx context.Context, height int64) (*Genesis, error) {
 	var rsp Genesis
 	if err := c.conn.Invoke(ctx, methodStateToGenesis.FullName(), height, &rsp); err != nil {
@@ -218,6 +284,14 @@ func (c *schedulerClient) ConsensusParameters(ctx context.Context, height int64)
 	return &rsp, nil
 }
 
+func (c *schedulerClient) SimulateElection(ctx context.Context, request *SimulateElectionRequest) (*ElectionSimulation, error) {
+	var rsp ElectionSimulation
+	if err := c.conn.Invoke(ctx, methodSimulateElection.FullName(), request, &rsp); err != nil {
+		return nil, err
+	}
+	return &rsp, nil
+}
+
 func (c *schedulerClient) WatchCommittees(ctx context.Context) (<-chan *Committee, pubsub.ClosableSubscription, error) {
 	ctx, sub := pubsub.NewContextSubscription(ctx)
 
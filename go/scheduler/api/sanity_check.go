@@ -4,8 +4,8 @@ import (
 	"fmt"
 	"math"
 
+	"github.com/oasisprotocol/oasis-core/go/common/debug"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
-	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 )
 
 // SanityCheck does basic sanity checking on the genesis state.
@@ -33,7 +33,7 @@ func (g *Genesis) SanityCheck(stakingTotalSupply *quantity.Quantity, votingPower
 // SanityCheck performs a sanity check on the consensus parameters.
 func (p *ConsensusParameters) SanityCheck() error {
 	unsafeFlags := p.DebugBypassStake || p.DebugAllowWeakAlpha || p.DebugForceElect != nil
-	if unsafeFlags && !flags.DebugDontBlameOasis() {
+	if unsafeFlags && !debug.CapabilityEnabled(debug.CapabilityUnsafeGenesis) {
 		return fmt.Errorf("one or more unsafe debug flags set")
 	}
 	return nil
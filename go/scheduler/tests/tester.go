@@ -11,6 +11,7 @@ import (
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	beaconTests "github.com/oasisprotocol/oasis-core/go/beacon/tests"
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
@@ -93,6 +94,19 @@ func SchedulerImplementationTests(t *testing.T, name string, identity *identity.
 		}
 
 		require.Nil(executor, "fetched an executor committee")
+
+		committeesAt, err := backend.GetCommitteesAt(context.Background(), &api.GetCommitteesAtRequest{
+			RuntimeID: rt.Runtime.ID,
+			Height:    consensusAPI.HeightLatest,
+		})
+		require.NoError(err, "GetCommitteesAt")
+		require.NotEqualValues(consensusAPI.HeightLatest, committeesAt.Height, "height should be resolved")
+		committees, err = backend.GetCommittees(context.Background(), &api.GetCommitteesRequest{
+			RuntimeID: rt.Runtime.ID,
+			Height:    committeesAt.Height,
+		})
+		require.NoError(err, "GetCommittees")
+		require.EqualValues(committees, committeesAt.Committees, "GetCommitteesAt should return committees at height")
 	}
 
 	var nExecutor int
@@ -105,6 +119,46 @@ func SchedulerImplementationTests(t *testing.T, name string, identity *identity.
 		nExecutor,
 	)
 
+	// Simulate an election with less nodes.
+	groupSize, groupBackupSize := uint16(2), uint16(1)
+	minPoolSize, minBackupPoolSize := uint16(2), uint16(1)
+	sim, err := backend.SimulateElection(ctx, &api.SimulateElectionRequest{
+		Height: consensusAPI.HeightLatest,
+		Runtimes: map[common.Namespace]map[api.Role]*api.CommitteeRoleChanges{
+			rt.Runtime.ID: {
+				api.RoleWorker:       {Size: &groupSize, MinPoolSize: &minPoolSize},
+				api.RoleBackupWorker: {Size: &groupBackupSize, MinPoolSize: &minBackupPoolSize},
+			},
+		},
+	})
+	require.NoError(err, "SimulateElection")
+	require.Equal(epoch, sim.Epoch, "election should be simulated at the current epoch")
+	requireSimulatedCommittee := func(outcome *api.ElectionOutcome, expectedExecutor int) {
+		require.Empty(outcome.Error, "simulated election should succeed")
+		require.NotEmpty(outcome.Validators, "simulated election should elect validators")
+		var found bool
+		for _, committee := range outcome.Committees {
+			if !rt.Runtime.ID.Equal(&committee.RuntimeID) || committee.Kind != api.KindComputeExecutor {
+				continue
+			}
+			require.Len(committee.Members, expectedExecutor, "simulated committee has the expected size")
+			found = true
+		}
+		require.True(found, "simulated election should elect an executor committee")
+	}
+	requireSimulatedCommittee(sim.Current, nExecutor)
+	requireSimulatedCommittee(sim.Proposed, 3)
+
+	_, err = backend.SimulateElection(ctx, &api.SimulateElectionRequest{
+		Height: consensusAPI.HeightLatest,
+		Runtimes: map[common.Namespace]map[api.Role]*api.CommitteeRoleChanges{
+			rt.Runtime.ID: {
+				api.RoleInvalid: {Size: &groupSize},
+			},
+		},
+	})
+	require.Error(err, "SimulateElection should fail for invalid roles")
+
 	// Re-register the runtime with less nodes.
 	rt.Runtime.Executor.GroupSize = 2
 	rt.Runtime.Executor.GroupBackupSize = 1
@@ -49,9 +49,11 @@ func (c *Client) createConnection() error {
 	if err != nil {
 		return err
 	}
-	opts := grpc.WithTransportCredentials(creds)
-
-	conn, err := cmnGrpc.Dial(c.sentryAddress.String(), opts) // nolint: staticcheck
+	conn, err := cmnGrpc.Dial( // nolint: staticcheck
+		c.sentryAddress.String(),
+		grpc.WithTransportCredentials(creds),
+		cmnGrpc.WithCallPolicy(cmnGrpc.DefaultCallPolicy),
+	)
 	if err != nil {
 		c.logger.Error("failed to dial the sentry node",
 			"err", err,
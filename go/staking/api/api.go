@@ -70,6 +70,10 @@ var (
 	// total supply value.
 	ErrAllowanceGreaterThanSupply = errors.New(ModuleName, 11, "staking: allowance greater than total supply")
 
+	// ErrHistoryUnavailable is the error returned when history is queried while history indices
+	// are not enabled.
+	ErrHistoryUnavailable = errors.New(ModuleName, 12, "staking: history indices are not enabled")
+
 	// MethodTransfer is the method name for transfers.
 	MethodTransfer = transaction.NewMethodName(ModuleName, "Transfer", Transfer{})
 	// MethodBurn is the method name for burns.
@@ -78,6 +82,9 @@ var (
 	MethodAddEscrow = transaction.NewMethodName(ModuleName, "AddEscrow", Escrow{})
 	// MethodReclaimEscrow is the method name for escrow reclamations.
 	MethodReclaimEscrow = transaction.NewMethodName(ModuleName, "ReclaimEscrow", ReclaimEscrow{})
+	// MethodReclaimEscrowAndTransfer is the method name for escrow reclamations with a transfer
+	// of the debonded stake once debonding completes.
+	MethodReclaimEscrowAndTransfer = transaction.NewMethodName(ModuleName, "ReclaimEscrowAndTransfer", ReclaimEscrowAndTransfer{})
 	// MethodAmendCommissionSchedule is the method name for amending commission schedules.
 	MethodAmendCommissionSchedule = transaction.NewMethodName(ModuleName, "AmendCommissionSchedule", AmendCommissionSchedule{})
 	// MethodAllow is the method name for setting a beneficiary allowance.
@@ -91,6 +98,7 @@ var (
 		MethodBurn,
 		MethodAddEscrow,
 		MethodReclaimEscrow,
+		MethodReclaimEscrowAndTransfer,
 		MethodAmendCommissionSchedule,
 		MethodAllow,
 		MethodWithdraw,
@@ -100,6 +108,7 @@ var (
 	_ prettyprint.PrettyPrinter = (*Burn)(nil)
 	_ prettyprint.PrettyPrinter = (*Escrow)(nil)
 	_ prettyprint.PrettyPrinter = (*ReclaimEscrow)(nil)
+	_ prettyprint.PrettyPrinter = (*ReclaimEscrowAndTransfer)(nil)
 	_ prettyprint.PrettyPrinter = (*AmendCommissionSchedule)(nil)
 	_ prettyprint.PrettyPrinter = (*Allow)(nil)
 	_ prettyprint.PrettyPrinter = (*Withdraw)(nil)
@@ -170,6 +179,25 @@ type Backend interface {
 	// delegations to the given account.
 	DebondingDelegationsTo(ctx context.Context, query *OwnerQuery) (map[Address][]*DebondingDelegation, error)
 
+	// DelegationHistoryFor returns the history of (outgoing) delegation changes
+	// for the given owner (delegator) in the given height range, keyed by the
+	// escrow account.
+	//
+	// History is only available if history indices are enabled.
+	DelegationHistoryFor(ctx context.Context, query *HistoryQuery) (map[Address][]*DelegationHistoryEntry, error)
+
+	// DelegationHistoryTo returns the history of (incoming) delegation changes
+	// to the given account in the given height range, keyed by the delegator.
+	//
+	// History is only available if history indices are enabled.
+	DelegationHistoryTo(ctx context.Context, query *HistoryQuery) (map[Address][]*DelegationHistoryEntry, error)
+
+	// RewardsAt returns the rewards distributed to the escrow account of the
+	// given owner in the given height range, ordered by height.
+	//
+	// History is only available if history indices are enabled.
+	RewardsAt(ctx context.Context, query *HistoryQuery) ([]*RewardEntry, error)
+
 	// Allowance looks up the allowance for the given owner/beneficiary combination.
 	Allowance(ctx context.Context, query *AllowanceQuery) (*quantity.Quantity, error)
 
@@ -208,6 +236,48 @@ type AllowanceQuery struct {
 	Beneficiary Address `json:"beneficiary"`
 }
 
+// HistoryQuery is a history query for the given owner and height range.
+type HistoryQuery struct {
+	Height int64   `json:"height"`
+	Owner  Address `json:"owner"`
+
+	// FromHeight is the first (inclusive) height of the range.
+	FromHeight int64 `json:"from_height,omitempty"`
+	// ToHeight is the last (inclusive) height of the range. Zero means that the range is not
+	// bounded from above.
+	ToHeight int64 `json:"to_height,omitempty"`
+}
+
+// Contains returns true iff the given height is in the queried height range.
+func (q *HistoryQuery) Contains(height int64) bool {
+	if height < q.FromHeight {
+		return false
+	}
+	if q.ToHeight != 0 && height > q.ToHeight {
+		return false
+	}
+	return true
+}
+
+// DelegationHistoryEntry is an entry in the delegation history.
+type DelegationHistoryEntry struct {
+	// Height is the height at which the delegation changed.
+	Height int64 `json:"height"`
+	// Shares is the number of delegated shares after the change.
+	Shares quantity.Quantity `json:"shares"`
+}
+
+// RewardEntry is an entry in the reward history of an escrow account.
+type RewardEntry struct {
+	// Height is the height at which the rewards were distributed.
+	Height int64 `json:"height"`
+	// Amount is the amount added to the escrow pool, increasing the share price of all
+	// delegators.
+	Amount quantity.Quantity `json:"amount"`
+	// Commission is the amount deposited as commission to the account's self-delegation.
+	Commission quantity.Quantity `json:"commission"`
+}
+
 // TransferEvent is the event emitted when stake is transferred, either by a
 // call to Transfer or Withdraw.
 type TransferEvent struct {
@@ -264,12 +334,15 @@ type EscrowEvent struct {
 	Take           *TakeEscrowEvent           `json:"take,omitempty"`
 	DebondingStart *DebondingStartEscrowEvent `json:"debonding_start,omitempty"`
 	Reclaim        *ReclaimEscrowEvent        `json:"reclaim,omitempty"`
+
+	DebondingTransfer *DebondingTransferEscrowEvent `json:"debonding_transfer,omitempty"`
 }
 
 // Event signifies a staking event, returned via GetEvents.
 type Event struct {
 	Height int64     `json:"height,omitempty"`
 	TxHash hash.Hash `json:"tx_hash,omitempty"`
+	Index  uint32    `json:"index,omitempty"`
 
 	Transfer        *TransferEvent        `json:"transfer,omitempty"`
 	Burn            *BurnEvent            `json:"burn,omitempty"`
@@ -277,6 +350,35 @@ type Event struct {
 	AllowanceChange *AllowanceChangeEvent `json:"allowance_change,omitempty"`
 }
 
+// Involves returns true iff the given account is involved in the event.
+func (e *Event) Involves(addr Address) bool {
+	var addrs []Address
+	switch {
+	case e.Transfer != nil:
+		addrs = []Address{e.Transfer.From, e.Transfer.To}
+	case e.Burn != nil:
+		addrs = []Address{e.Burn.Owner}
+	case e.Escrow != nil && e.Escrow.Add != nil:
+		addrs = []Address{e.Escrow.Add.Owner, e.Escrow.Add.Escrow}
+	case e.Escrow != nil && e.Escrow.Take != nil:
+		addrs = []Address{e.Escrow.Take.Owner}
+	case e.Escrow != nil && e.Escrow.DebondingStart != nil:
+		addrs = []Address{e.Escrow.DebondingStart.Owner, e.Escrow.DebondingStart.Escrow}
+	case e.Escrow != nil && e.Escrow.Reclaim != nil:
+		addrs = []Address{e.Escrow.Reclaim.Owner, e.Escrow.Reclaim.Escrow}
+	case e.Escrow != nil && e.Escrow.DebondingTransfer != nil:
+		addrs = []Address{e.Escrow.DebondingTransfer.Owner, e.Escrow.DebondingTransfer.Escrow, e.Escrow.DebondingTransfer.To}
+	case e.AllowanceChange != nil:
+		addrs = []Address{e.AllowanceChange.Owner, e.AllowanceChange.Beneficiary}
+	}
+	for _, a := range addrs {
+		if a.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
+
 // AddEscrowEvent is the event emitted when stake is transferred into an escrow
 // account.
 type AddEscrowEvent struct {
@@ -393,6 +495,34 @@ func (e *ReclaimEscrowEvent) ProvableRepresentation() any {
 	return e
 }
 
+// DebondingTransferEscrowEvent is the event emitted when a transfer of debonding stake is
+// scheduled to happen once debonding completes.
+type DebondingTransferEscrowEvent struct {
+	Owner           Address           `json:"owner"`
+	Escrow          Address           `json:"escrow"`
+	To              Address           `json:"to"`
+	DebondingShares quantity.Quantity `json:"debonding_shares"`
+	DebondEndTime   beacon.EpochTime  `json:"debond_end_time"`
+}
+
+// EventKind returns a string representation of this event's kind.
+func (e *DebondingTransferEscrowEvent) EventKind() string {
+	return "debonding_transfer"
+}
+
+// ShouldProve returns true iff the event should be included in the event proof tree.
+func (e *DebondingTransferEscrowEvent) ShouldProve() bool {
+	return true
+}
+
+// ProvableRepresentation returns the provable representation of an event.
+//
+// Since this representation is part of commitments that are included in consensus layer state
+// any changes to this representation are consensus-breaking.
+func (e *DebondingTransferEscrowEvent) ProvableRepresentation() any {
+	return e
+}
+
 // AllowanceChangeEvent is the event emitted when allowance is changed for a beneficiary.
 type AllowanceChangeEvent struct { // nolint: maligned
 	Owner        Address           `json:"owner"`
@@ -523,6 +653,35 @@ func NewReclaimEscrowTx(nonce uint64, fee *transaction.Fee, reclaim *ReclaimEscr
 	return transaction.NewTransaction(nonce, fee, MethodReclaimEscrow, reclaim)
 }
 
+// ReclaimEscrowAndTransfer is a reclamation of stake from an escrow where the debonded stake is
+// transferred to the given account once debonding completes.
+type ReclaimEscrowAndTransfer struct {
+	Account Address           `json:"account"`
+	Shares  quantity.Quantity `json:"shares"`
+	To      Address           `json:"to"`
+}
+
+// PrettyPrint writes a pretty-printed representation of ReclaimEscrowAndTransfer to the
+// given writer.
+func (rt ReclaimEscrowAndTransfer) PrettyPrint(_ context.Context, prefix string, w io.Writer) {
+	fmt.Fprintf(w, "%sFrom:   %s\n", prefix, rt.Account)
+
+	fmt.Fprintf(w, "%sShares: %s\n", prefix, rt.Shares)
+
+	fmt.Fprintf(w, "%sTo:     %s\n", prefix, rt.To)
+}
+
+// PrettyType returns a representation of ReclaimEscrowAndTransfer that can be used for pretty
+// printing.
+func (rt ReclaimEscrowAndTransfer) PrettyType() (interface{}, error) {
+	return rt, nil
+}
+
+// NewReclaimEscrowAndTransferTx creates a new reclaim escrow and transfer transaction.
+func NewReclaimEscrowAndTransferTx(nonce uint64, fee *transaction.Fee, reclaim *ReclaimEscrowAndTransfer) *transaction.Transaction {
+	return transaction.NewTransaction(nonce, fee, MethodReclaimEscrowAndTransfer, reclaim)
+}
+
 // AmendCommissionSchedule is an amendment to a commission schedule.
 type AmendCommissionSchedule struct {
 	Amendment CommissionSchedule `json:"amendment"`
@@ -1186,6 +1345,14 @@ func (d *DebondingDelegation) Merge(other DebondingDelegation) error {
 	return nil
 }
 
+// DebondingTransfer is a scheduled transfer of debonding stake to a destination account once
+// debonding completes.
+type DebondingTransfer struct {
+	To            Address           `json:"to"`
+	Shares        quantity.Quantity `json:"shares"`
+	DebondEndTime beacon.EpochTime  `json:"debond_end"`
+}
+
 // DebondingDelegationInfo is a debonding delegation descriptor with additional
 // information.
 //
@@ -1226,6 +1393,27 @@ type Genesis struct {
 	// DebondingDelegations is a nested map of staking delegations of the form:
 	// DEBONDING-DELEGATEE-ACCOUNT-ADDRESS: DEBONDING-DELEGATOR-ACCOUNT-ADDRESS: list of DEBONDING-DELEGATIONs.
 	DebondingDelegations map[Address]map[Address][]*DebondingDelegation `json:"debonding_delegations,omitempty"`
+	// DebondingTransfers is a nested map of scheduled debonding transfers of the form:
+	// DEBONDING-DELEGATEE-ACCOUNT-ADDRESS: DEBONDING-DELEGATOR-ACCOUNT-ADDRESS: list of DEBONDING-TRANSFERs.
+	DebondingTransfers map[Address]map[Address][]*DebondingTransfer `json:"debonding_transfers,omitempty"`
+}
+
+// DisplayTokenSymbol returns the token's ticker symbol that should be used for display, preferring
+// the one configured in the consensus parameters.
+func (g *Genesis) DisplayTokenSymbol() string {
+	if g.Parameters.TokenSymbol != "" {
+		return g.Parameters.TokenSymbol
+	}
+	return g.TokenSymbol
+}
+
+// DisplayTokenValueExponent returns the token's value base-10 exponent that should be used for
+// display, preferring the one configured in the consensus parameters.
+func (g *Genesis) DisplayTokenValueExponent() uint8 {
+	if g.Parameters.TokenValueExponent != nil {
+		return *g.Parameters.TokenValueExponent
+	}
+	return g.TokenValueExponent
 }
 
 // ConsensusParameters are the staking consensus parameters.
@@ -1235,7 +1423,7 @@ type ConsensusParameters struct { // nolint: maligned
 	TokenSymbol string `json:"token_symbol,omitempty"`
 	// TokenValueExponent is the token's value base-10 exponent, i.e.
 	// 1 token = 10**TokenValueExponent base units.
-	TokenValueExponent uint8 `json:"token_value_exponent,omitempty"`
+	TokenValueExponent *uint8 `json:"token_value_exponent,omitempty"`
 
 	Thresholds                        map[ThresholdKind]quantity.Quantity `json:"thresholds,omitempty"`
 	DebondingInterval                 beacon.EpochTime                    `json:"debonding_interval,omitempty"`
@@ -1260,6 +1448,20 @@ type ConsensusParameters struct { // nolint: maligned
 	// MaxAllowances is the maximum number of allowances an account can have. Zero means disabled.
 	MaxAllowances uint32 `json:"max_allowances,omitempty"`
 
+	// EnableHistoryIndices enables maintaining the delegation and reward history indices.
+	EnableHistoryIndices bool `json:"enable_history_indices,omitempty"`
+
+	// HistoryRetention is the number of most recent blocks for which the delegation and reward
+	// history entries are retained. It must be set when history indices are enabled.
+	HistoryRetention uint64 `json:"history_retention,omitempty"`
+
+	// EnableDebondingTransfers enables reclaiming escrow with a transfer of the debonded stake
+	// once debonding completes.
+	EnableDebondingTransfers bool `json:"enable_debonding_transfers,omitempty"`
+
+	// EnableTokenDisplayChanges enables changing the token display metadata via governance.
+	EnableTokenDisplayChanges bool `json:"enable_token_display_changes,omitempty"`
+
 	// FeeSplitWeightPropose is the proportion of block fee portions that go to the proposer.
 	FeeSplitWeightPropose quantity.Quantity `json:"fee_split_weight_propose"`
 	// FeeSplitWeightVote is the proportion of block fee portions that go to the validator that votes.
@@ -1281,6 +1483,11 @@ type ConsensusParameters struct { // nolint: maligned
 
 // ConsensusParameterChanges are allowed staking consensus parameter changes.
 type ConsensusParameterChanges struct {
+	// TokenSymbol is the new token's ticker symbol.
+	TokenSymbol *string `json:"token_symbol,omitempty"`
+	// TokenValueExponent is the new token's value base-10 exponent.
+	TokenValueExponent *uint8 `json:"token_value_exponent,omitempty"`
+
 	// DebondingInterval is the new debonding interval.
 	DebondingInterval *beacon.EpochTime `json:"debonding_interval,omitempty"`
 
@@ -1310,6 +1517,18 @@ type ConsensusParameterChanges struct {
 	// MaxAllowances is the new maximum number of allowances.
 	MaxAllowances *uint32 `json:"max_allowances,omitempty"`
 
+	// EnableHistoryIndices is the new enable history indices flag.
+	EnableHistoryIndices *bool `json:"enable_history_indices,omitempty"`
+
+	// HistoryRetention is the new history retention.
+	HistoryRetention *uint64 `json:"history_retention,omitempty"`
+
+	// EnableDebondingTransfers is the new enable debonding transfers flag.
+	EnableDebondingTransfers *bool `json:"enable_debonding_transfers,omitempty"`
+
+	// EnableTokenDisplayChanges is the new enable token display changes flag.
+	EnableTokenDisplayChanges *bool `json:"enable_token_display_changes,omitempty"`
+
 	// FeeSplitWeightPropose is the new propose fee split weight.
 	FeeSplitWeightPropose *quantity.Quantity `json:"fee_split_weight_propose"`
 	// FeeSplitWeightVote is the new vote fee split weight.
@@ -1323,8 +1542,20 @@ type ConsensusParameterChanges struct {
 	RewardFactorBlockProposed *quantity.Quantity `json:"reward_factor_block_proposed"`
 }
 
+// ChangesTokenDisplay returns true iff the changes modify the token display metadata.
+func (c *ConsensusParameterChanges) ChangesTokenDisplay() bool {
+	return c.TokenSymbol != nil || c.TokenValueExponent != nil
+}
+
 // Apply applies changes to the given consensus parameters.
 func (c *ConsensusParameterChanges) Apply(params *ConsensusParameters) error {
+	if c.TokenSymbol != nil {
+		params.TokenSymbol = *c.TokenSymbol
+	}
+	if c.TokenValueExponent != nil {
+		exp := *c.TokenValueExponent
+		params.TokenValueExponent = &exp
+	}
 	if c.DebondingInterval != nil {
 		params.DebondingInterval = *c.DebondingInterval
 	}
@@ -1358,6 +1589,18 @@ func (c *ConsensusParameterChanges) Apply(params *ConsensusParameters) error {
 	if c.MaxAllowances != nil {
 		params.MaxAllowances = *c.MaxAllowances
 	}
+	if c.EnableHistoryIndices != nil {
+		params.EnableHistoryIndices = *c.EnableHistoryIndices
+	}
+	if c.HistoryRetention != nil {
+		params.HistoryRetention = *c.HistoryRetention
+	}
+	if c.EnableDebondingTransfers != nil {
+		params.EnableDebondingTransfers = *c.EnableDebondingTransfers
+	}
+	if c.EnableTokenDisplayChanges != nil {
+		params.EnableTokenDisplayChanges = *c.EnableTokenDisplayChanges
+	}
 	if c.FeeSplitWeightPropose != nil {
 		params.FeeSplitWeightPropose = *c.FeeSplitWeightPropose
 	}
@@ -1385,6 +1628,10 @@ const (
 	GasOpAddEscrow transaction.Op = "add_escrow"
 	// GasOpReclaimEscrow is the gas operation identifier for reclaim escrow.
 	GasOpReclaimEscrow transaction.Op = "reclaim_escrow"
+	// GasOpReclaimEscrowAndTransfer is the gas operation identifier for reclaim escrow and transfer.
+	//
+	// If the consensus parameters do not specify its cost, the cost from DefaultGasCosts is used.
+	GasOpReclaimEscrowAndTransfer transaction.Op = "reclaim_escrow_and_transfer"
 	// GasOpAmendCommissionSchedule is the gas operation identifier for amend commission schedule.
 	GasOpAmendCommissionSchedule transaction.Op = "amend_commission_schedule"
 	// GasOpAllow is the gas operation identifier for allow.
@@ -1393,6 +1640,12 @@ const (
 	GasOpWithdraw transaction.Op = "withdraw"
 )
 
+// DefaultGasCosts are the default gas costs of staking operations which are used when the
+// consensus parameters do not specify them.
+var DefaultGasCosts = transaction.Costs{
+	GasOpReclaimEscrowAndTransfer: 1000,
+}
+
 // TransferResult is the result of staking transfer.
 type TransferResult struct {
 	From   Address           `json:"from"`
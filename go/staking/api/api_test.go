@@ -135,6 +135,74 @@ func TestConsensusParameters(t *testing.T) {
 		},
 	}
 	require.Error(r9.SanityCheck(), "reward schedule step scale should not be greater than the reward amount denominator")
+
+	// Token display metadata.
+	exp := uint8(6)
+	t1 := ConsensusParameters{
+		TokenSymbol:        "TEST",
+		TokenValueExponent: &exp,
+		Thresholds:         validThresholds,
+		FeeSplitWeightVote: mustInitQuantity(t, 1),
+	}
+	require.NoError(t1.SanityCheck(), "valid token display metadata should be valid")
+
+	t2 := t1
+	t2.TokenSymbol = "test"
+	require.Error(t2.SanityCheck(), "token symbol with invalid characters should be invalid")
+
+	t3 := t1
+	invalidExp := uint8(21)
+	t3.TokenValueExponent = &invalidExp
+	require.Error(t3.SanityCheck(), "too large token value exponent should be invalid")
+
+	// History indices.
+	h1 := t1
+	h1.EnableHistoryIndices = true
+	require.Error(h1.SanityCheck(), "history indices without retention should be invalid")
+	h1.HistoryRetention = 100
+	require.NoError(h1.SanityCheck(), "history indices with retention should be valid")
+}
+
+func TestConsensusParameterChanges(t *testing.T) {
+	require := require.New(t)
+
+	var changes ConsensusParameterChanges
+	require.Error(changes.SanityCheck(), "empty changes should be invalid")
+
+	symbol := "ABC"
+	exp := uint8(3)
+	changes.TokenSymbol = &symbol
+	changes.TokenValueExponent = &exp
+	require.NoError(changes.SanityCheck(), "valid token display metadata changes should be valid")
+
+	var params ConsensusParameters
+	require.NoError(changes.Apply(&params))
+	require.Equal("ABC", params.TokenSymbol)
+	require.EqualValues(3, *params.TokenValueExponent)
+	require.True(changes.ChangesTokenDisplay())
+
+	genesis := Genesis{
+		Parameters:         params,
+		TokenSymbol:        "TEST",
+		TokenValueExponent: 6,
+	}
+	require.Equal("ABC", genesis.DisplayTokenSymbol())
+	require.EqualValues(3, genesis.DisplayTokenValueExponent())
+	genesis.Parameters = ConsensusParameters{}
+	require.Equal("TEST", genesis.DisplayTokenSymbol())
+	require.EqualValues(6, genesis.DisplayTokenValueExponent())
+
+	// Changing the exponent back to zero should override the genesis exponent.
+	zero := uint8(0)
+	require.NoError((&ConsensusParameterChanges{TokenValueExponent: &zero}).Apply(&genesis.Parameters))
+	require.EqualValues(0, genesis.DisplayTokenValueExponent())
+
+	enable := true
+	require.False((&ConsensusParameterChanges{EnableTokenDisplayChanges: &enable}).ChangesTokenDisplay())
+
+	invalidSymbol := "TOOLONGSYMBOL"
+	changes.TokenSymbol = &invalidSymbol
+	require.Error(changes.SanityCheck(), "invalid token symbol change should be invalid")
 }
 
 func TestThresholdKind(t *testing.T) {
@@ -46,6 +46,12 @@ var (
 	methodDebondingDelegationInfosFor = serviceName.NewMethod("DebondingDelegationInfosFor", OwnerQuery{})
 	// methodDebondingDelegationsTo is the DebondingDelegationsTo method.
 	methodDebondingDelegationsTo = serviceName.NewMethod("DebondingDelegationsTo", OwnerQuery{})
+	// methodDelegationHistoryFor is the DelegationHistoryFor method.
+	methodDelegationHistoryFor = serviceName.NewMethod("DelegationHistoryFor", HistoryQuery{})
+	// methodDelegationHistoryTo is the DelegationHistoryTo method.
+	methodDelegationHistoryTo = serviceName.NewMethod("DelegationHistoryTo", HistoryQuery{})
+	// methodRewardsAt is the RewardsAt method.
+	methodRewardsAt = serviceName.NewMethod("RewardsAt", HistoryQuery{})
 	// methodAllowance is the Allowance method.
 	methodAllowance = serviceName.NewMethod("Allowance", AllowanceQuery{})
 	// methodStateToGenesis is the StateToGenesis method.
@@ -127,6 +133,18 @@ var (
 				MethodName: methodDebondingDelegationsTo.ShortName(),
 				Handler:    handlerDebondingDelegationsTo,
 			},
+			{
+				MethodName: methodDelegationHistoryFor.ShortName(),
+				Handler:    handlerDelegationHistoryFor,
+			},
+			{
+				MethodName: methodDelegationHistoryTo.ShortName(),
+				Handler:    handlerDelegationHistoryTo,
+			},
+			{
+				MethodName: methodRewardsAt.ShortName(),
+				Handler:    handlerRewardsAt,
+			},
 			{
 				MethodName: methodAllowance.ShortName(),
 				Handler:    handlerAllowance,
@@ -522,6 +540,75 @@ func handlerDebondingDelegationsTo(
 	return interceptor(ctx, &query, info, handler)
 }
 
+func handlerDelegationHistoryFor(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query HistoryQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).DelegationHistoryFor(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodDelegationHistoryFor.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).DelegationHistoryFor(ctx, req.(*HistoryQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
+func handlerDelegationHistoryTo(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query HistoryQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).DelegationHistoryTo(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodDelegationHistoryTo.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).DelegationHistoryTo(ctx, req.(*HistoryQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
+func handlerRewardsAt(
+	srv interface{},
+	ctx context.Context,
+	dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor,
+) (interface{}, error) {
+	var query HistoryQuery
+	if err := dec(&query); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(Backend).RewardsAt(ctx, &query)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: methodRewardsAt.FullName(),
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(Backend).RewardsAt(ctx, req.(*HistoryQuery))
+	}
+	return interceptor(ctx, &query, info, handler)
+}
+
 func handlerAllowance(
 	srv interface{},
 	ctx context.Context,
@@ -779,6 +866,30 @@ func (c *stakingClient) DebondingDelegationsTo(ctx context.Context, query *Owner
 	return rsp, nil
 }
 
+func (c *stakingClient) DelegationHistoryFor(ctx context.Context, query *HistoryQuery) (map[Address][]*DelegationHistoryEntry, error) {
+	var rsp map[Address][]*DelegationHistoryEntry
+	if err := c.conn.Invoke(ctx, methodDelegationHistoryFor.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+func (c *stakingClient) DelegationHistoryTo(ctx context.Context, query *HistoryQuery) (map[Address][]*DelegationHistoryEntry, error) {
+	var rsp map[Address][]*DelegationHistoryEntry
+	if err := c.conn.Invoke(ctx, methodDelegationHistoryTo.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+func (c *stakingClient) RewardsAt(ctx context.Context, query *HistoryQuery) ([]*RewardEntry, error) {
+	var rsp []*RewardEntry
+	if err := c.conn.Invoke(ctx, methodRewardsAt.FullName(), query, &rsp); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
 func (c *stakingClient) Allowance(ctx context.Context, query *AllowanceQuery) (*quantity.Quantity, error) {
 	var rsp quantity.Quantity
 	if err := c.conn.Invoke(ctx, methodAllowance.FullName(), query, &rsp); err != nil {
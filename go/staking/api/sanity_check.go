@@ -3,22 +3,38 @@ package api
 
 import (
 	"fmt"
-	"regexp"
 
 	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/debug"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
-	"github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
 	"github.com/oasisprotocol/oasis-core/go/staking/api/token"
 )
 
 // SanityCheck performs a sanity check on the consensus parameters.
 func (p *ConsensusParameters) SanityCheck() error {
-	if !flags.DebugDontBlameOasis() {
+	if !debug.CapabilityEnabled(debug.CapabilityUnsafeGenesis) {
 		if p.DebugBypassStake {
 			return fmt.Errorf("one or more unsafe debug flags set")
 		}
 	}
 
+	// Token display metadata overrides.
+	if p.TokenSymbol != "" {
+		if err := token.SanityCheckSymbol(p.TokenSymbol); err != nil {
+			return err
+		}
+	}
+	if p.TokenValueExponent != nil {
+		if err := token.SanityCheckValueExponent(*p.TokenValueExponent); err != nil {
+			return err
+		}
+	}
+
+	// History indices.
+	if p.EnableHistoryIndices && p.HistoryRetention == 0 {
+		return fmt.Errorf("history retention must be set when history indices are enabled")
+	}
+
 	// Thresholds.
 	for _, kind := range ThresholdKinds {
 		val, ok := p.Thresholds[kind]
@@ -69,7 +85,9 @@ func (p *ConsensusParameters) SanityCheck() error {
 
 // SanityCheck performs a sanity check on the consensus parameter changes.
 func (c *ConsensusParameterChanges) SanityCheck() error {
-	if c.DebondingInterval == nil &&
+	if c.TokenSymbol == nil &&
+		c.TokenValueExponent == nil &&
+		c.DebondingInterval == nil &&
 		c.RewardSchedule == nil &&
 		c.GasCosts == nil &&
 		c.MinDelegationAmount == nil &&
@@ -80,6 +98,10 @@ func (c *ConsensusParameterChanges) SanityCheck() error {
 		c.DisableDelegation == nil &&
 		c.AllowEscrowMessages == nil &&
 		c.MaxAllowances == nil &&
+		c.EnableHistoryIndices == nil &&
+		c.HistoryRetention == nil &&
+		c.EnableDebondingTransfers == nil &&
+		c.EnableTokenDisplayChanges == nil &&
 		c.FeeSplitWeightPropose == nil &&
 		c.FeeSplitWeightVote == nil &&
 		c.FeeSplitWeightNextPropose == nil &&
@@ -87,6 +109,16 @@ func (c *ConsensusParameterChanges) SanityCheck() error {
 		c.RewardFactorBlockProposed == nil {
 		return fmt.Errorf("consensus parameter changes should not be empty")
 	}
+	if c.TokenSymbol != nil {
+		if err := token.SanityCheckSymbol(*c.TokenSymbol); err != nil {
+			return err
+		}
+	}
+	if c.TokenValueExponent != nil {
+		if err := token.SanityCheckValueExponent(*c.TokenValueExponent); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -187,6 +219,59 @@ func SanityCheckDelegations(addr Address, account *Account, delegations map[Addr
 	return nil
 }
 
+// SanityCheckDebondingTransfers examines the debonding transfers scheduled for an account's
+// debonding delegations.
+func SanityCheckDebondingTransfers(
+	addr Address,
+	delegations map[Address][]*DebondingDelegation,
+	transfers map[Address][]*DebondingTransfer,
+) error {
+	for delegatorAddr, xfers := range transfers {
+		// Sum up transfer shares per debonding delegation.
+		transferShares := make(map[beacon.EpochTime]*quantity.Quantity)
+		for _, xfer := range xfers {
+			if xfer == nil {
+				return fmt.Errorf(
+					"staking: sanity check failed: nil debonding transfer of %s from %s",
+					delegatorAddr, addr,
+				)
+			}
+			if !xfer.To.IsValid() {
+				return fmt.Errorf(
+					"staking: sanity check failed: debonding transfer of %s from %s: destination address %s is invalid",
+					delegatorAddr, addr, xfer.To,
+				)
+			}
+			if xfer.Shares.IsZero() {
+				return fmt.Errorf(
+					"staking: sanity check failed: debonding transfer of %s from %s to %s: zero shares",
+					delegatorAddr, addr, xfer.To,
+				)
+			}
+			if transferShares[xfer.DebondEndTime] == nil {
+				transferShares[xfer.DebondEndTime] = quantity.NewQuantity()
+			}
+			_ = transferShares[xfer.DebondEndTime].Add(&xfer.Shares)
+		}
+
+		for debondEndTime, shares := range transferShares {
+			var delegationShares quantity.Quantity
+			for _, delegation := range delegations[delegatorAddr] {
+				if delegation.DebondEndTime == debondEndTime {
+					_ = delegationShares.Add(&delegation.Shares)
+				}
+			}
+			if shares.Cmp(&delegationShares) > 0 {
+				return fmt.Errorf(
+					"staking: sanity check failed: debonding transfers of %s from %s ending at epoch %d (%s) exceed debonding shares (%s)",
+					delegatorAddr, addr, debondEndTime, shares, delegationShares,
+				)
+			}
+		}
+	}
+	return nil
+}
+
 // SanityCheckDebondingDelegations examines an account's debonding delegations.
 func SanityCheckDebondingDelegations(addr Address, account *Account, delegations map[Address][]*DebondingDelegation) error {
 	if !addr.IsValid() {
@@ -293,20 +378,11 @@ func (g *Genesis) SanityCheck(now beacon.EpochTime) error { // nolint: gocyclo
 		return fmt.Errorf("staking: sanity check failed: %w", err)
 	}
 
-	tokenSymbolLength := len(g.TokenSymbol)
-	if tokenSymbolLength == 0 {
-		return fmt.Errorf("staking: sanity check failed: token symbol is empty")
-	}
-	if tokenSymbolLength > token.TokenSymbolMaxLength {
-		return fmt.Errorf("staking: sanity check failed: token symbol exceeds maximum length")
-	}
-	match := regexp.MustCompile(token.TokenSymbolRegexp).FindString(g.TokenSymbol)
-	if match == "" {
-		return fmt.Errorf("staking: sanity check failed: token symbol should match '%s'", token.TokenSymbolRegexp)
+	if err := token.SanityCheckSymbol(g.TokenSymbol); err != nil {
+		return fmt.Errorf("staking: sanity check failed: %w", err)
 	}
-
-	if g.TokenValueExponent > token.TokenValueExponentMaxValue {
-		return fmt.Errorf("staking: sanity check failed: token value exponent is invalid")
+	if err := token.SanityCheckValueExponent(g.TokenValueExponent); err != nil {
+		return fmt.Errorf("staking: sanity check failed: %w", err)
 	}
 
 	if !g.TotalSupply.IsValid() {
@@ -374,6 +450,16 @@ func (g *Genesis) SanityCheck(now beacon.EpochTime) error { // nolint: gocyclo
 		}
 	}
 
+	// Debonding transfers must be backed by the corresponding debonding delegations.
+	if len(g.DebondingTransfers) > 0 && !g.Parameters.EnableDebondingTransfers {
+		return fmt.Errorf("staking: sanity check failed: debonding transfers are disabled")
+	}
+	for addr, transfers := range g.DebondingTransfers {
+		if err := SanityCheckDebondingTransfers(addr, g.DebondingDelegations[addr], transfers); err != nil {
+			return err
+		}
+	}
+
 	// The burn address is actually "unused" for reasonable definitions of "unused".
 	if ba := g.Ledger[BurnAddress]; ba != nil {
 		if !ba.General.Balance.IsZero() {
@@ -1,7 +1,12 @@
 // Package token implements the token-related parts of the staking API.
 package token
 
-import "github.com/oasisprotocol/oasis-core/go/common/errors"
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
+)
 
 const (
 	// ModuleName is a unique module name for the staking/token module.
@@ -18,3 +23,27 @@ const (
 // ErrInvalidTokenValueExponent is the error returned when an invalid token's
 // value base-10 exponent is specified.
 var ErrInvalidTokenValueExponent = errors.New(ModuleName, 1, "staking/token: invalid token's value exponent")
+
+var tokenSymbolRegexp = regexp.MustCompile(TokenSymbolRegexp)
+
+// SanityCheckSymbol performs a sanity check on the token's ticker symbol.
+func SanityCheckSymbol(symbol string) error {
+	if len(symbol) == 0 {
+		return fmt.Errorf("token symbol is empty")
+	}
+	if len(symbol) > TokenSymbolMaxLength {
+		return fmt.Errorf("token symbol exceeds maximum length")
+	}
+	if !tokenSymbolRegexp.MatchString(symbol) {
+		return fmt.Errorf("token symbol should match '%s'", TokenSymbolRegexp)
+	}
+	return nil
+}
+
+// SanityCheckValueExponent performs a sanity check on the token's value base-10 exponent.
+func SanityCheckValueExponent(exp uint8) error {
+	if exp > TokenValueExponentMaxValue {
+		return fmt.Errorf("token value exponent is invalid")
+	}
+	return nil
+}
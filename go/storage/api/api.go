@@ -94,6 +94,10 @@ type Config struct { // nolint: maligned
 
 	// ReadOnly will make the storage read-only.
 	ReadOnly bool
+
+	// CheckpointParallelism is the maximum number of checkpoint chunks that are created or
+	// restored in parallel.
+	CheckpointParallelism uint
 }
 
 // ToNodeDB converts from a Config to a node DB Config.
@@ -48,7 +48,7 @@ type databaseBackend struct {
 
 // New constructs a new database backed storage Backend instance.
 func New(cfg *api.Config) (api.LocalBackend, error) {
-	if err := autoDetectBackend(cfg); err != nil {
+	if err := AutoDetectBackend(cfg); err != nil {
 		return nil, err
 	}
 
@@ -68,12 +68,15 @@ func New(cfg *api.Config) (api.LocalBackend, error) {
 	close(initCh)
 
 	// Create the checkpointer.
-	creator, err := checkpoint.NewFileCreator(filepath.Join(cfg.DB, checkpointDir), ndb)
+	cpOpts := []checkpoint.Option{
+		checkpoint.WithParallelism(cfg.CheckpointParallelism),
+	}
+	creator, err := checkpoint.NewFileCreator(filepath.Join(cfg.DB, checkpointDir), ndb, cpOpts...)
 	if err != nil {
 		ndb.Close()
 		return nil, fmt.Errorf("storage/database: failed to create checkpoint creator: %w", err)
 	}
-	restorer, err := checkpoint.NewRestorer(ndb)
+	restorer, err := checkpoint.NewRestorer(ndb, cpOpts...)
 	if err != nil {
 		ndb.Close()
 		return nil, fmt.Errorf("storage/database: failed to create checkpoint restorer: %w", err)
@@ -178,8 +181,9 @@ func (ba *databaseBackend) NodeDB() dbApi.NodeDB {
 	return ba.ndb
 }
 
-// autoDetectBackend attempts automatic backend detection, modifying the configuration in place.
-func autoDetectBackend(cfg *api.Config) error {
+// AutoDetectBackend attempts automatic backend detection in case the "auto" backend is
+// configured, modifying the configuration in place.
+func AutoDetectBackend(cfg *api.Config) error {
 	if cfg.Backend != BackendNameAuto {
 		return nil
 	}
@@ -200,7 +204,7 @@ func autoDetectBackend(cfg *api.Config) error {
 	}
 	var backends []foundBackend
 
-	for _, b := range db.Backends {
+	for _, b := range db.Backends() {
 		// Generate expected filename for the given backend.
 		fn := DefaultFileName(b.Name())
 		maybeDb := filepath.Join(filepath.Dir(cfg.DB), fn)
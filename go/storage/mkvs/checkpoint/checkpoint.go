@@ -88,6 +88,10 @@ type Restorer interface {
 	// progress, this method may return nil.
 	GetCurrentCheckpoint() *Metadata
 
+	// GetRestoreProgress returns the progress of the checkpoint restore in progress. If no
+	// restoration is in progress, this method returns nil.
+	GetRestoreProgress() *RestoreProgress
+
 	// RestoreChunk restores the given chunk into the underlying node database.
 	//
 	// This method requires that a restoration is in progress.
@@ -118,6 +122,41 @@ func NewCreateRestorer(creator Creator, restorer Restorer) CreateRestorer {
 	}
 }
 
+// Option is a checkpoint creator or restorer option.
+type Option func(o *options)
+
+type options struct {
+	parallelism uint
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{
+		parallelism: 1,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithParallelism sets the maximum number of chunks that are processed in parallel while
+// creating or restoring checkpoints. This also bounds the number of chunks held in memory.
+//
+// If not specified or zero, chunks are processed sequentially.
+func WithParallelism(parallelism uint) Option {
+	return func(o *options) {
+		o.parallelism = max(parallelism, 1)
+	}
+}
+
+// RestoreProgress is the progress of a checkpoint restore.
+type RestoreProgress struct {
+	// Restored is the number of restored chunks.
+	Restored uint64 `json:"restored"`
+	// Total is the total number of chunks in the checkpoint.
+	Total uint64 `json:"total"`
+}
+
 // ChunkMetadata is chunk metadata.
 type ChunkMetadata struct {
 	Version uint16    `json:"version"`
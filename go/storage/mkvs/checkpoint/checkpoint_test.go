@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"sync"
 	"testing"
 
 	"github.com/golang/snappy"
@@ -26,7 +27,7 @@ import (
 var testNs = common.NewTestNamespaceFromSeed([]byte("oasis mkvs checkpoint test ns"), 0)
 
 func TestFileCheckpointCreator(t *testing.T) {
-	dbTesting.TestMultipleBackends(t, db.Backends, testFileCheckpointCreator)
+	dbTesting.TestMultipleBackends(t, db.Backends(), testFileCheckpointCreator)
 }
 
 func testFileCheckpointCreator(t *testing.T, factory dbApi.Factory) {
@@ -265,7 +266,7 @@ func testFileCheckpointCreator(t *testing.T, factory dbApi.Factory) {
 }
 
 func TestOversizedChunks(t *testing.T) {
-	dbTesting.TestMultipleBackends(t, db.Backends, testOversizedChunks)
+	dbTesting.TestMultipleBackends(t, db.Backends(), testOversizedChunks)
 }
 
 func testOversizedChunks(t *testing.T, factory dbApi.Factory) {
@@ -314,8 +315,124 @@ func testOversizedChunks(t *testing.T, factory dbApi.Factory) {
 	require.Len(cp.Chunks, 100, "there should be the correct number of chunks")
 }
 
+func TestParallelCheckpoint(t *testing.T) {
+	dbTesting.TestMultipleBackends(t, db.Backends(), testParallelCheckpoint)
+}
+
+func testParallelCheckpoint(t *testing.T, factory dbApi.Factory) {
+	require := require.New(t)
+
+	// Generate some data.
+	dir, err := os.MkdirTemp("", "mkvs.checkpoint")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dir)
+
+	ndb1, err := factory.New(&dbApi.Config{
+		DB:        filepath.Join(dir, "db1"),
+		Namespace: testNs,
+	})
+	require.NoError(err, "New")
+
+	ndb2, err := factory.New(&dbApi.Config{
+		DB:        filepath.Join(dir, "db2"),
+		Namespace: testNs,
+	})
+	require.NoError(err, "New")
+
+	ctx := context.Background()
+	tree := mkvs.New(nil, ndb1, node.RootTypeState)
+	for i := 0; i < 1000; i++ {
+		err = tree.Insert(ctx, []byte(strconv.Itoa(i)), []byte(strconv.Itoa(i)))
+		require.NoError(err, "Insert")
+	}
+
+	_, rootHash, err := tree.Commit(ctx, testNs, 1)
+	require.NoError(err, "Commit")
+	root := node.Root{
+		Namespace: testNs,
+		Version:   1,
+		Type:      node.RootTypeState,
+		Hash:      rootHash,
+	}
+	err = ndb1.Finalize([]node.Root{root})
+	require.NoError(err, "Finalize")
+
+	// Create the same checkpoint sequentially and in parallel.
+	fc, err := NewFileCreator(filepath.Join(dir, "checkpoints"), ndb1)
+	require.NoError(err, "NewFileCreator")
+	cp, err := fc.CreateCheckpoint(ctx, root, 1024)
+	require.NoError(err, "CreateCheckpoint")
+	require.Greater(len(cp.Chunks), 10, "there should be many chunks")
+
+	pfc, err := NewFileCreator(filepath.Join(dir, "checkpoints-parallel"), ndb1, WithParallelism(4))
+	require.NoError(err, "NewFileCreator")
+	pcp, err := pfc.CreateCheckpoint(ctx, root, 1024)
+	require.NoError(err, "CreateCheckpoint")
+	require.Equal(cp, pcp, "parallel checkpoint should be the same as the sequential one")
+
+	// Restore the checkpoint in the second database in parallel.
+	rs, err := NewRestorer(ndb2, WithParallelism(4))
+	require.NoError(err, "NewRestorer")
+	require.Nil(rs.GetRestoreProgress(), "there should be no progress before restore is started")
+
+	err = ndb2.StartMultipartInsert(pcp.Root.Version)
+	require.NoError(err, "StartMultipartInsert")
+	err = rs.StartRestore(ctx, pcp)
+	require.NoError(err, "StartRestore")
+	require.Equal(&RestoreProgress{Restored: 0, Total: uint64(len(pcp.Chunks))}, rs.GetRestoreProgress())
+
+	var (
+		wg     sync.WaitGroup
+		doneCh = make(chan struct{}, len(pcp.Chunks))
+		errCh  = make(chan error, len(pcp.Chunks))
+	)
+	for i := 0; i < len(pcp.Chunks); i++ {
+		var cm *ChunkMetadata
+		cm, err = pcp.GetChunkMetadata(uint64(i))
+		require.NoError(err, "GetChunkMetadata")
+
+		var buf bytes.Buffer
+		err = pfc.GetCheckpointChunk(ctx, cm, &buf)
+		require.NoError(err, "GetChunk")
+
+		wg.Add(1)
+		go func(idx uint64) {
+			defer wg.Done()
+
+			done, rerr := rs.RestoreChunk(ctx, idx, &buf)
+			if rerr != nil {
+				errCh <- rerr
+				return
+			}
+			if done {
+				doneCh <- struct{}{}
+			}
+		}(uint64(i))
+	}
+	wg.Wait()
+	close(errCh)
+	for err = range errCh {
+		require.NoError(err, "RestoreChunk")
+	}
+	require.Len(doneCh, 1, "restore should complete exactly once")
+	require.Nil(rs.GetRestoreProgress(), "there should be no progress after restore is done")
+
+	err = ndb2.Finalize([]node.Root{root})
+	require.NoError(err, "Finalize")
+
+	// Make sure all data has been restored.
+	restored := mkvs.NewWithRoot(nil, ndb2, root)
+	defer restored.Close()
+	for i := 0; i < 1000; i++ {
+		var value []byte
+		value, err = restored.Get(ctx, []byte(strconv.Itoa(i)))
+		require.NoError(err, "Get")
+		require.Equal([]byte(strconv.Itoa(i)), value, "restored value should be correct")
+	}
+}
+
 func TestPruneGapAfterCheckpointRestore(t *testing.T) {
-	dbTesting.TestMultipleBackends(t, db.Backends, testPruneGapAfterCheckpointRestore)
+	dbTesting.TestMultipleBackends(t, db.Backends(), testPruneGapAfterCheckpointRestore)
 }
 
 func testPruneGapAfterCheckpointRestore(t *testing.T, factory dbApi.Factory) {
@@ -3,6 +3,7 @@ package checkpoint
 import (
 	"context"
 	"fmt"
+	"math"
 	"math/rand"
 	"sort"
 	"time"
@@ -90,6 +91,16 @@ type Checkpointer interface {
 	// intervals; after unpausing, a checkpoint won't be created immediately, but the checkpointer
 	// will wait for the next regular event.
 	Pause(pause bool)
+
+	// EarliestRetainedVersion returns the earliest version that must be retained in the node
+	// database. This is the version of the earliest locally offered checkpoint (excluding the
+	// checkpoint of the initial version), so that peers syncing from any of the offered
+	// checkpoints can fetch the following versions. In case no checkpoints have been created yet,
+	// only the versions that would still be checkpointed given the latest version are retained,
+	// so that they are not pruned before being checkpointed.
+	//
+	// In case checkpointing is disabled, false is returned.
+	EarliestRetainedVersion(ctx context.Context) (uint64, bool, error)
 }
 
 type checkpointer struct {
@@ -135,6 +146,80 @@ func (c *checkpointer) Pause(pause bool) {
 	c.pausedCh <- pause
 }
 
+// Implements Checkpointer.
+func (c *checkpointer) EarliestRetainedVersion(ctx context.Context) (uint64, bool, error) {
+	if c.cfg.CheckInterval == CheckIntervalDisabled {
+		return 0, false, nil
+	}
+	params, err := c.getParameters(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	if params.Interval == 0 {
+		return 0, false, nil
+	}
+
+	cps, err := c.creator.GetCheckpoints(ctx, &GetCheckpointsRequest{
+		Version:   checkpointVersion,
+		Namespace: c.cfg.Namespace,
+	})
+	if err != nil {
+		return 0, false, fmt.Errorf("checkpointer: failed to get existing checkpoints: %w", err)
+	}
+
+	earliestVersion := uint64(math.MaxUint64)
+	for _, cp := range cps {
+		if cp.Root.Version == params.InitialVersion {
+			continue
+		}
+		if cp.Root.Version < earliestVersion {
+			earliestVersion = cp.Root.Version
+		}
+	}
+	if earliestVersion == math.MaxUint64 {
+		// Nothing has been checkpointed yet, retain the versions that would be checkpointed.
+		version, exists := c.ndb.GetLatestVersion()
+		if !exists {
+			return params.InitialVersion, true, nil
+		}
+		return pendingCheckpointVersion(version, params), true, nil
+	}
+	return earliestVersion, true, nil
+}
+
+// pendingCheckpointVersion returns the earliest version that would be checkpointed given the
+// latest version and the checkpoint creation parameters.
+func pendingCheckpointVersion(version uint64, params *CreationParameters) uint64 {
+	if version < params.InitialVersion {
+		return params.InitialVersion
+	}
+
+	cpVersion := ((version-params.InitialVersion)/params.Interval)*params.Interval + params.InitialVersion
+	if params.NumKept > 1 {
+		span := (params.NumKept - 1) * params.Interval
+		if span/params.Interval != params.NumKept-1 || cpVersion-params.InitialVersion < span {
+			return params.InitialVersion
+		}
+		cpVersion -= span
+	}
+	return cpVersion
+}
+
+// getParameters returns the current checkpoint creation parameters.
+func (c *checkpointer) getParameters(ctx context.Context) (*CreationParameters, error) {
+	params := c.cfg.Parameters
+	if params == nil && c.cfg.GetParameters != nil {
+		var err error
+		if params, err = c.cfg.GetParameters(ctx); err != nil {
+			return nil, fmt.Errorf("checkpointer: failed to get checkpoint parameters: %w", err)
+		}
+	}
+	if params == nil {
+		return nil, fmt.Errorf("checkpointer: no checkpoint parameters")
+	}
+	return params, nil
+}
+
 func (c *checkpointer) checkpoint(ctx context.Context, version uint64, params *CreationParameters) (err error) {
 	// Notify watchers about the checkpoint we are about to make.
 	c.cpNotifier.Broadcast(version)
@@ -327,20 +412,12 @@ func (c *checkpointer) worker(ctx context.Context) {
 		}
 
 		// Fetch current checkpoint parameters.
-		params := c.cfg.Parameters
-		if params == nil && c.cfg.GetParameters != nil {
-			var err error
-			params, err = c.cfg.GetParameters(ctx)
-			if err != nil {
-				c.logger.Error("failed to get checkpoint parameters",
-					"err", err,
-					"version", version,
-				)
-				continue
-			}
-		}
-		if params == nil {
-			c.logger.Error("no checkpoint parameters")
+		params, err := c.getParameters(ctx)
+		if err != nil {
+			c.logger.Error("failed to get checkpoint parameters",
+				"err", err,
+				"version", version,
+			)
 			continue
 		}
 
@@ -357,7 +434,6 @@ func (c *checkpointer) worker(ctx context.Context) {
 		default:
 		}
 
-		var err error
 		switch force {
 		case false:
 			err = c.maybeCheckpoint(ctx, version, params)
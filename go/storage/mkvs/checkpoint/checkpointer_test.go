@@ -3,6 +3,7 @@ package checkpoint
 import (
 	"context"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"testing"
@@ -96,6 +97,13 @@ func testCheckpointer(t *testing.T, factory dbApi.Factory, earliestVersion, inte
 	require.NoError(err, "WatchCheckpoints")
 	defer sub.Close()
 
+	// Nothing has been checkpointed yet, so everything that would be checkpointed should be
+	// retained.
+	retainedVersion, ok, err := cp.EarliestRetainedVersion(ctx)
+	require.NoError(err, "EarliestRetainedVersion")
+	require.True(ok, "EarliestRetainedVersion should be available")
+	require.EqualValues(earliestVersion, retainedVersion, "all versions should be retained")
+
 	// Finalize a few rounds.
 	var round uint64
 	for round = earliestVersion; round < earliestVersion+(testNumKept+1)*interval; round++ {
@@ -138,6 +146,23 @@ func testCheckpointer(t *testing.T, factory dbApi.Factory, earliestVersion, inte
 		}
 	}
 
+	// Make sure that the earliest offered checkpoint is retained.
+	cps, err := fc.GetCheckpoints(ctx, &GetCheckpointsRequest{
+		Version:   checkpointVersion,
+		Namespace: testNs,
+	})
+	require.NoError(err, "GetCheckpoints")
+	expectedRetainedVersion := uint64(math.MaxUint64)
+	for _, cpm := range cps {
+		if cpm.Root.Version != earliestVersion && cpm.Root.Version < expectedRetainedVersion {
+			expectedRetainedVersion = cpm.Root.Version
+		}
+	}
+	retainedVersion, ok, err = cp.EarliestRetainedVersion(ctx)
+	require.NoError(err, "EarliestRetainedVersion")
+	require.True(ok, "EarliestRetainedVersion should be available")
+	require.EqualValues(expectedRetainedVersion, retainedVersion, "earliest offered checkpoint should be retained")
+
 	// Force a checkpoint at a version outside the regular interval.
 	if interval > 1 {
 		cpVersion := round - interval + 1
@@ -168,7 +193,7 @@ func testCheckpointer(t *testing.T, factory dbApi.Factory, earliestVersion, inte
 }
 
 func TestCheckpointer(t *testing.T) {
-	dbTesting.TestMultipleBackends(t, db.Backends, testCheckpointerWithBackend)
+	dbTesting.TestMultipleBackends(t, db.Backends(), testCheckpointerWithBackend)
 }
 
 func testCheckpointerWithBackend(t *testing.T, factory dbApi.Factory) {
@@ -188,3 +213,31 @@ func testCheckpointerWithBackend(t *testing.T, factory dbApi.Factory) {
 		testCheckpointer(t, factory, 0, 10, false)
 	})
 }
+
+func TestPendingCheckpointVersion(t *testing.T) {
+	require := require.New(t)
+
+	for _, tc := range []struct {
+		version        uint64
+		initialVersion uint64
+		interval       uint64
+		numKept        uint64
+		expected       uint64
+	}{
+		{0, 5, 10, 2, 5},
+		{5, 5, 10, 2, 5},
+		{14, 5, 10, 2, 5},
+		{27, 5, 10, 2, 15},
+		{27, 5, 10, 1, 25},
+		{27, 5, 10, 0, 25},
+		{27, 5, 10, 5, 5},
+		{27, 5, 10, math.MaxUint64, 5},
+	} {
+		params := CreationParameters{
+			Interval:       tc.interval,
+			NumKept:        tc.numKept,
+			InitialVersion: tc.initialVersion,
+		}
+		require.Equal(tc.expected, pendingCheckpointVersion(tc.version, &params), "version %d num kept %d", tc.version, tc.numKept)
+	}
+}
@@ -16,15 +16,18 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
 )
 
-func createChunk(
+// createChunkProof builds the proof for the chunk starting at the given offset.
+//
+// Building proofs requires iterating over the tree in order, so this needs to be done sequentially
+// for all chunks of a checkpoint.
+func createChunkProof(
 	ctx context.Context,
 	tree mkvs.Tree,
 	root node.Root,
 	offset node.Key,
 	chunkSize uint64,
-	w io.Writer,
 ) (
-	chunkHash hash.Hash,
+	proof *syncer.Proof,
 	nextOffset node.Key,
 	err error,
 ) {
@@ -49,7 +52,7 @@ func createChunk(
 	}
 
 	// Build our chunk.
-	proof, err := it.GetProof()
+	proof, err = it.GetProof()
 	if err != nil {
 		err = fmt.Errorf("chunk: failed to build proof: %w", err)
 		return
@@ -58,23 +61,26 @@ func createChunk(
 	// Determine the next offset (not included in proof).
 	it.Next()
 	nextOffset = it.Key()
+	return
+}
 
+// writeChunk encodes the chunk proof, writes it to the given writer and returns the chunk hash.
+//
+// Chunks can be written independently of each other.
+func writeChunk(proof *syncer.Proof, w io.Writer) (hash.Hash, error) {
 	hb := hash.NewBuilder()
 	sw := snappy.NewBufferedWriter(io.MultiWriter(w, hb))
 	enc := cbor.NewEncoder(sw)
 	for _, entry := range proof.Entries {
-		if err = enc.Encode(entry); err != nil {
-			err = fmt.Errorf("chunk: failed to encode chunk part: %w", err)
-			return
+		if err := enc.Encode(entry); err != nil {
+			return hash.Hash{}, fmt.Errorf("chunk: failed to encode chunk part: %w", err)
 		}
 	}
-	if err = sw.Close(); err != nil {
-		err = fmt.Errorf("chunk: failed to close chunk: %w", err)
-		return
+	if err := sw.Close(); err != nil {
+		return hash.Hash{}, fmt.Errorf("chunk: failed to close chunk: %w", err)
 	}
 
-	chunkHash = hb.Build()
-	return
+	return hb.Build(), nil
 }
 
 func restoreChunk(ctx context.Context, ndb db.NodeDB, chunk *ChunkMetadata, r io.Reader) error {
@@ -0,0 +1,252 @@
+package checkpoint
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	db "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/writelog"
+)
+
+// errNoWriteLog is the error returned when no write log is available for a root.
+var errNoWriteLog = errors.New("checkpoint: no write log available")
+
+// CopyVersions copies all versions from the source node database into the destination node
+// database, which may use a different backend and must be empty.
+//
+// All versions are copied by applying the write logs of the source node database. In case the
+// write logs of the earliest version are not available (e.g., because earlier versions have been
+// pruned), the earliest version is copied via checkpoints instead (see CopyVersion). The given
+// progress callback, if any, is invoked after each copied version.
+func CopyVersions(
+	ctx context.Context,
+	src, dst db.NodeDB,
+	tmpDir string,
+	chunkSize uint64,
+	progress func(version uint64),
+) error {
+	latestVersion, ok := src.GetLatestVersion()
+	if !ok {
+		return fmt.Errorf("checkpoint: source node database is empty")
+	}
+	earliestVersion := src.GetEarliestVersion()
+
+	var prevRoots []node.Root
+	for version := earliestVersion; version <= latestVersion; version++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		roots, err := src.GetRootsForVersion(version)
+		if err != nil {
+			return fmt.Errorf("checkpoint: failed to get roots for version %d: %w", version, err)
+		}
+		if len(roots) == 0 {
+			// Nothing has been finalized in this version.
+			continue
+		}
+
+		err = copyVersionWriteLogs(ctx, src, dst, prevRoots, roots)
+		switch {
+		case err == nil:
+		case errors.Is(err, errNoWriteLog) && prevRoots == nil:
+			if err = CopyVersion(ctx, src, dst, version, tmpDir, chunkSize); err != nil {
+				return err
+			}
+		default:
+			return err
+		}
+		if progress != nil {
+			progress(version)
+		}
+
+		prevRoots = roots
+	}
+	return nil
+}
+
+// copyVersionWriteLogs copies the given finalized roots of a version from the source node
+// database into the destination node database by applying the write logs between each root and
+// one of the given previous roots or the empty root of the same version.
+//
+// In case a write log is not available, errNoWriteLog is returned and nothing is copied.
+func copyVersionWriteLogs(ctx context.Context, src, dst db.NodeDB, prevRoots, roots []node.Root) error {
+	type pendingRoot struct {
+		srcRoot  node.Root
+		root     node.Root
+		writeLog writelog.WriteLog
+	}
+
+	// Fetch all write logs first so that nothing is copied unless all are available.
+	var pending []pendingRoot
+	for _, root := range roots {
+		if root.Hash.IsEmpty() || dst.HasRoot(root) {
+			continue
+		}
+
+		srcRoot, wl, err := getWriteLog(ctx, src, prevRoots, root)
+		if err != nil {
+			return err
+		}
+		pending = append(pending, pendingRoot{srcRoot, root, wl})
+	}
+
+	for _, pr := range pending {
+		if err := func() error {
+			tree := mkvs.NewWithRoot(nil, dst, pr.srcRoot)
+			defer tree.Close()
+
+			if err := tree.ApplyWriteLog(ctx, writelog.NewStaticIterator(pr.writeLog)); err != nil {
+				return fmt.Errorf("checkpoint: failed to apply write log for root %s: %w", pr.root, err)
+			}
+			if _, err := tree.CommitKnown(ctx, pr.root); err != nil {
+				return fmt.Errorf("checkpoint: failed to commit root %s: %w", pr.root, err)
+			}
+			return nil
+		}(); err != nil {
+			return err
+		}
+	}
+
+	if err := dst.Finalize(roots); err != nil {
+		return fmt.Errorf("checkpoint: failed to finalize version %d: %w", roots[0].Version, err)
+	}
+	return nil
+}
+
+// getWriteLog returns the write log between one of the given previous roots or the empty root of
+// the same version and the given root.
+func getWriteLog(ctx context.Context, src db.NodeDB, prevRoots []node.Root, root node.Root) (node.Root, writelog.WriteLog, error) {
+	emptyRoot := node.Root{
+		Namespace: root.Namespace,
+		Version:   root.Version,
+		Type:      root.Type,
+	}
+	emptyRoot.Hash.Empty()
+
+	candidates := append([]node.Root{}, prevRoots...)
+	candidates = append(candidates, emptyRoot)
+	for _, srcRoot := range candidates {
+		if !root.Follows(&srcRoot) {
+			continue
+		}
+
+		it, err := src.GetWriteLog(ctx, srcRoot, root)
+		switch {
+		case err == nil:
+		case errors.Is(err, db.ErrWriteLogNotFound):
+			continue
+		default:
+			return node.Root{}, nil, fmt.Errorf("checkpoint: failed to get write log for root %s: %w", root, err)
+		}
+
+		var wl writelog.WriteLog
+		for {
+			more, err := it.Next()
+			if err != nil {
+				return node.Root{}, nil, fmt.Errorf("checkpoint: failed to read write log for root %s: %w", root, err)
+			}
+			if !more {
+				break
+			}
+
+			entry, err := it.Value()
+			if err != nil {
+				return node.Root{}, nil, fmt.Errorf("checkpoint: failed to read write log for root %s: %w", root, err)
+			}
+			wl = append(wl, entry)
+		}
+		return srcRoot, wl, nil
+	}
+	return node.Root{}, nil, fmt.Errorf("%w for root %s", errNoWriteLog, root)
+}
+
+// CopyVersion copies all finalized roots of the given version from the source node database into
+// the destination node database, which may use a different backend. The roots are transferred via
+// checkpoints that are temporarily stored in the given directory, so memory usage is bounded by
+// the chunk size.
+//
+// The destination node database will have the given version finalized.
+func CopyVersion(ctx context.Context, src, dst db.NodeDB, version uint64, tmpDir string, chunkSize uint64) error {
+	roots, err := src.GetRootsForVersion(version)
+	if err != nil {
+		return fmt.Errorf("checkpoint: failed to get roots for version %d: %w", version, err)
+	}
+
+	if err = os.MkdirAll(tmpDir, 0o700); err != nil {
+		return fmt.Errorf("checkpoint: failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	creator, err := NewFileCreator(tmpDir, src)
+	if err != nil {
+		return fmt.Errorf("checkpoint: failed to create checkpoint creator: %w", err)
+	}
+	restorer, err := NewRestorer(dst)
+	if err != nil {
+		return fmt.Errorf("checkpoint: failed to create checkpoint restorer: %w", err)
+	}
+
+	if err = dst.StartMultipartInsert(version); err != nil {
+		return fmt.Errorf("checkpoint: failed to start multipart insert: %w", err)
+	}
+	finalized := false
+	defer func() {
+		if !finalized {
+			_ = dst.AbortMultipartInsert()
+		}
+	}()
+
+	for _, root := range roots {
+		if root.Hash.IsEmpty() {
+			// Empty roots do not have any nodes.
+			continue
+		}
+
+		meta, err := creator.CreateCheckpoint(ctx, root, chunkSize)
+		if err != nil {
+			return fmt.Errorf("checkpoint: failed to create checkpoint for root %s: %w", root, err)
+		}
+		if err = restorer.StartRestore(ctx, meta); err != nil {
+			return fmt.Errorf("checkpoint: failed to start restore for root %s: %w", root, err)
+		}
+
+		var done bool
+		for idx := range meta.Chunks {
+			var cm *ChunkMetadata
+			if cm, err = meta.GetChunkMetadata(uint64(idx)); err != nil {
+				_ = restorer.AbortRestore(ctx)
+				return err
+			}
+
+			var buf bytes.Buffer
+			if err = creator.GetCheckpointChunk(ctx, cm, &buf); err != nil {
+				_ = restorer.AbortRestore(ctx)
+				return fmt.Errorf("checkpoint: failed to get chunk %d of root %s: %w", idx, root, err)
+			}
+			if done, err = restorer.RestoreChunk(ctx, uint64(idx), &buf); err != nil {
+				return fmt.Errorf("checkpoint: failed to restore chunk %d of root %s: %w", idx, root, err)
+			}
+		}
+		if !done {
+			_ = restorer.AbortRestore(ctx)
+			return fmt.Errorf("checkpoint: restore of root %s did not complete", root)
+		}
+
+		if err = creator.DeleteCheckpoint(ctx, checkpointVersion, root); err != nil {
+			return fmt.Errorf("checkpoint: failed to delete checkpoint for root %s: %w", root, err)
+		}
+	}
+
+	if err = dst.Finalize(roots); err != nil {
+		return fmt.Errorf("checkpoint: failed to finalize version %d: %w", version, err)
+	}
+	finalized = true
+
+	return nil
+}
@@ -0,0 +1,240 @@
+package checkpoint
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/db"
+	dbApi "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+)
+
+func TestCopyVersion(t *testing.T) {
+	for _, srcFactory := range db.Backends() {
+		for _, dstFactory := range db.Backends() {
+			t.Run(fmt.Sprintf("%s->%s", srcFactory.Name(), dstFactory.Name()), func(t *testing.T) {
+				testCopyVersion(t, srcFactory, dstFactory)
+			})
+		}
+	}
+}
+
+func testCopyVersion(t *testing.T, srcFactory, dstFactory dbApi.Factory) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	dir, err := os.MkdirTemp("", "mkvs.checkpoint.copy")
+	require.NoError(err, "TempDir")
+	defer os.RemoveAll(dir)
+
+	newDB := func(factory dbApi.Factory, name string) dbApi.NodeDB {
+		ndb, nerr := factory.New(&dbApi.Config{
+			DB:           filepath.Join(dir, name),
+			Namespace:    testNs,
+			MaxCacheSize: 16 * 1024 * 1024,
+		})
+		require.NoError(nerr, "New")
+		return ndb
+	}
+	src := newDB(srcFactory, "src")
+	defer src.Close()
+	dst := newDB(dstFactory, "dst")
+	defer dst.Close()
+
+	// Populate the source database with a few versions.
+	const numVersions = 5
+	var root node.Root
+	root.Empty()
+	root.Namespace = testNs
+	root.Type = node.RootTypeState
+	for version := uint64(0); version < numVersions; version++ {
+		tree := mkvs.NewWithRoot(nil, src, root)
+		for i := 0; i < 100; i++ {
+			err = tree.Insert(ctx, []byte(fmt.Sprintf("key %d", i)), []byte(fmt.Sprintf("value %d/%d", i, version)))
+			require.NoError(err, "Insert")
+		}
+		if version > 0 {
+			err = tree.Remove(ctx, []byte(fmt.Sprintf("key %d", version)))
+			require.NoError(err, "Remove")
+		}
+		_, root.Hash, err = tree.Commit(ctx, testNs, version)
+		require.NoError(err, "Commit")
+		tree.Close()
+
+		root.Version = version
+		err = src.Finalize([]node.Root{root})
+		require.NoError(err, "Finalize")
+	}
+
+	// Copy the latest version.
+	latest, ok := src.GetLatestVersion()
+	require.True(ok, "GetLatestVersion")
+	err = CopyVersion(ctx, src, dst, latest, filepath.Join(dir, "tmp"), 1024)
+	require.NoError(err, "CopyVersion")
+
+	// Make sure the destination has exactly the copied version.
+	dstLatest, ok := dst.GetLatestVersion()
+	require.True(ok, "GetLatestVersion")
+	require.EqualValues(latest, dstLatest, "latest version should be copied")
+	require.EqualValues(latest, dst.GetEarliestVersion(), "only the copied version should exist")
+	roots, err := dst.GetRootsForVersion(latest)
+	require.NoError(err, "GetRootsForVersion")
+	require.Equal([]node.Root{root}, roots, "roots should be copied")
+
+	// Make sure the contents are the same.
+	srcTree := mkvs.NewWithRoot(nil, src, root)
+	defer srcTree.Close()
+	dstTree := mkvs.NewWithRoot(nil, dst, root)
+	defer dstTree.Close()
+
+	srcIt := srcTree.NewIterator(ctx)
+	defer srcIt.Close()
+	dstIt := dstTree.NewIterator(ctx)
+	defer dstIt.Close()
+	var numEntries int
+	dstIt.Rewind()
+	for srcIt.Rewind(); srcIt.Valid(); srcIt.Next() {
+		require.True(dstIt.Valid(), "destination iterator should be valid")
+		require.Equal(srcIt.Key(), dstIt.Key(), "keys should match")
+		require.Equal(srcIt.Value(), dstIt.Value(), "values should match")
+		dstIt.Next()
+		numEntries++
+	}
+	require.False(dstIt.Valid(), "destination iterator should be exhausted")
+	require.NoError(srcIt.Err(), "source iterator")
+	require.NoError(dstIt.Err(), "destination iterator")
+	require.Equal(99, numEntries, "all entries should be copied")
+}
+
+func TestCopyVersions(t *testing.T) {
+	for _, srcFactory := range db.Backends() {
+		for _, dstFactory := range db.Backends() {
+			t.Run(fmt.Sprintf("%s->%s", srcFactory.Name(), dstFactory.Name()), func(t *testing.T) {
+				testCopyVersions(t, srcFactory, dstFactory)
+			})
+		}
+	}
+}
+
+func testCopyVersions(t *testing.T, srcFactory, dstFactory dbApi.Factory) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	newDB := func(factory dbApi.Factory, name string) dbApi.NodeDB {
+		ndb, nerr := factory.New(&dbApi.Config{
+			DB:           filepath.Join(dir, name),
+			Namespace:    testNs,
+			MaxCacheSize: 16 * 1024 * 1024,
+		})
+		require.NoError(nerr, "New")
+		return ndb
+	}
+	src := newDB(srcFactory, "src")
+	defer src.Close()
+	dst := newDB(dstFactory, "dst")
+	defer dst.Close()
+
+	// Populate the source database with a few versions, each having a state and an I/O root.
+	const numVersions = 5
+	var stateRoot node.Root
+	stateRoot.Empty()
+	stateRoot.Namespace = testNs
+	stateRoot.Type = node.RootTypeState
+	versionRoots := make(map[uint64][]node.Root)
+	for version := uint64(0); version < numVersions; version++ {
+		tree := mkvs.NewWithRoot(nil, src, stateRoot)
+		for i := 0; i < 100; i++ {
+			err := tree.Insert(ctx, []byte(fmt.Sprintf("key %d", i)), []byte(fmt.Sprintf("value %d/%d", i, version)))
+			require.NoError(err, "Insert")
+		}
+		if version > 0 {
+			err := tree.Remove(ctx, []byte(fmt.Sprintf("key %d", version)))
+			require.NoError(err, "Remove")
+		}
+		_, hash, err := tree.Commit(ctx, testNs, version)
+		require.NoError(err, "Commit")
+		tree.Close()
+		stateRoot.Version = version
+		stateRoot.Hash = hash
+
+		ioRoot := node.Root{
+			Namespace: testNs,
+			Version:   version,
+			Type:      node.RootTypeIO,
+		}
+		ioRoot.Hash.Empty()
+		tree = mkvs.NewWithRoot(nil, src, ioRoot)
+		err = tree.Insert(ctx, []byte("io"), []byte(fmt.Sprintf("io %d", version)))
+		require.NoError(err, "Insert")
+		_, ioRoot.Hash, err = tree.Commit(ctx, testNs, version)
+		require.NoError(err, "Commit")
+		tree.Close()
+
+		versionRoots[version] = []node.Root{stateRoot, ioRoot}
+		err = src.Finalize(versionRoots[version])
+		require.NoError(err, "Finalize")
+	}
+
+	var copied []uint64
+	err := CopyVersions(ctx, src, dst, filepath.Join(dir, "tmp"), 1024, func(version uint64) {
+		copied = append(copied, version)
+	})
+	require.NoError(err, "CopyVersions")
+	require.Equal([]uint64{0, 1, 2, 3, 4}, copied, "all versions should be copied")
+
+	dstLatest, ok := dst.GetLatestVersion()
+	require.True(ok, "GetLatestVersion")
+	require.EqualValues(numVersions-1, dstLatest, "latest version should be copied")
+	require.EqualValues(0, dst.GetEarliestVersion(), "earliest version should be copied")
+
+	for version := uint64(0); version < numVersions; version++ {
+		for _, root := range versionRoots[version] {
+			require.True(dst.HasRoot(root), "root %s should be copied", root)
+
+			srcTree := mkvs.NewWithRoot(nil, src, root)
+			dstTree := mkvs.NewWithRoot(nil, dst, root)
+			srcIt := srcTree.NewIterator(ctx)
+			dstIt := dstTree.NewIterator(ctx)
+			dstIt.Rewind()
+			for srcIt.Rewind(); srcIt.Valid(); srcIt.Next() {
+				require.True(dstIt.Valid(), "destination iterator should be valid")
+				require.Equal(srcIt.Key(), dstIt.Key(), "keys should match")
+				require.Equal(srcIt.Value(), dstIt.Value(), "values should match")
+				dstIt.Next()
+			}
+			require.False(dstIt.Valid(), "destination iterator should be exhausted")
+			require.NoError(srcIt.Err(), "source iterator")
+			require.NoError(dstIt.Err(), "destination iterator")
+			srcIt.Close()
+			dstIt.Close()
+			srcTree.Close()
+			dstTree.Close()
+		}
+	}
+
+	// When earlier versions have been pruned, the earliest version should be copied in full.
+	err = src.Prune(0)
+	require.NoError(err, "Prune")
+	dst2 := newDB(dstFactory, "dst2")
+	defer dst2.Close()
+
+	copied = nil
+	err = CopyVersions(ctx, src, dst2, filepath.Join(dir, "tmp"), 1024, func(version uint64) {
+		copied = append(copied, version)
+	})
+	require.NoError(err, "CopyVersions (pruned)")
+	require.Equal([]uint64{1, 2, 3, 4}, copied, "all remaining versions should be copied")
+	require.EqualValues(1, dst2.GetEarliestVersion(), "earliest version should be copied")
+	for version := uint64(1); version < numVersions; version++ {
+		for _, root := range versionRoots[version] {
+			require.True(dst2.HasRoot(root), "root %s should be copied", root)
+		}
+	}
+}
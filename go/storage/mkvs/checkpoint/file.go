@@ -7,13 +7,16 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"sync"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
 	db "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
 )
 
 const (
@@ -24,11 +27,18 @@ const (
 	// Versions 1 of checkpoint chunks use proofs version 0. Consider bumping
 	// this to latest version when introducing new checkpoint versions.
 	checkpointProofsVersion = 0
+
+	// creationProgressInterval is the number of chunks after which checkpoint creation progress
+	// is logged.
+	creationProgressInterval = 100
 )
 
 type fileCreator struct {
-	dataDir string
-	ndb     db.NodeDB
+	dataDir     string
+	ndb         db.NodeDB
+	parallelism uint
+
+	logger *logging.Logger
 }
 
 func (fc *fileCreator) CreateCheckpoint(ctx context.Context, root node.Root, chunkSize uint64) (meta *Metadata, err error) {
@@ -67,32 +77,90 @@ func (fc *fileCreator) CreateCheckpoint(ctx context.Context, root node.Root, chu
 		return nil, fmt.Errorf("checkpoint: failed to create chunk directory: %w", err)
 	}
 
-	// Create chunks until we are done.
-	var chunks []hash.Hash
-	var nextOffset node.Key
-	for chunkIndex := 0; ; chunkIndex++ {
-		dataFilename := filepath.Join(chunksDir, strconv.Itoa(chunkIndex))
+	// Create chunks until we are done. Chunk proofs need to be built sequentially as each chunk
+	// starts where the previous one ended, but encoding, hashing and writing chunks is done in
+	// parallel. The number of chunks in flight is bounded to limit memory usage.
+	var (
+		wg         sync.WaitGroup
+		mu         sync.Mutex
+		writeErr   error
+		chunks     []hash.Hash
+		nextOffset node.Key
+	)
+	sem := make(chan struct{}, fc.parallelism)
+	getWriteErr := func() error {
+		mu.Lock()
+		defer mu.Unlock()
+		return writeErr
+	}
 
-		// Generate chunk.
-		var f *os.File
-		if f, err = os.Create(dataFilename); err != nil {
-			return nil, fmt.Errorf("checkpoint: failed to create chunk file for chunk %d: %w", chunkIndex, err)
+	for chunkIndex := 0; ; chunkIndex++ {
+		// Wait for a free slot before building the next chunk.
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+		if err == nil {
+			err = getWriteErr()
+		}
+		if err != nil {
+			break
 		}
 
-		var chunkHash hash.Hash
-		chunkHash, nextOffset, err = createChunk(ctx, tree, root, nextOffset, chunkSize, f)
-		f.Close()
+		var proof *syncer.Proof
+		proof, nextOffset, err = createChunkProof(ctx, tree, root, nextOffset, chunkSize)
 		if err != nil {
-			return nil, fmt.Errorf("checkpoint: failed to create chunk %d: %w", chunkIndex, err)
+			<-sem
+			err = fmt.Errorf("checkpoint: failed to create chunk %d: %w", chunkIndex, err)
+			break
 		}
 
-		chunks = append(chunks, chunkHash)
+		mu.Lock()
+		chunks = append(chunks, hash.Hash{})
+		mu.Unlock()
+
+		wg.Add(1)
+		go func(chunkIndex int, proof *syncer.Proof) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			dataFilename := filepath.Join(chunksDir, strconv.Itoa(chunkIndex))
+			chunkHash, cerr := writeChunkFile(dataFilename, proof)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if cerr != nil {
+				if writeErr == nil {
+					writeErr = fmt.Errorf("checkpoint: failed to create chunk %d: %w", chunkIndex, cerr)
+				}
+				return
+			}
+			chunks[chunkIndex] = chunkHash
+		}(chunkIndex, proof)
+
+		if (chunkIndex+1)%creationProgressInterval == 0 {
+			fc.logger.Info("checkpoint creation in progress",
+				"version", root.Version,
+				"root", root.Hash,
+				"chunks", chunkIndex+1,
+			)
+		}
 
 		// Check if we are finished.
 		if nextOffset == nil {
 			break
 		}
 	}
+	wg.Wait()
+
+	if err == nil {
+		err = writeErr
+	}
+	if err != nil {
+		return nil, err
+	}
 
 	// Generate and write checkpoint metadata.
 	meta = &Metadata{
@@ -230,10 +298,24 @@ func (fc *fileCreator) GetCheckpointChunk(_ context.Context, chunk *ChunkMetadat
 	return nil
 }
 
+func writeChunkFile(filename string, proof *syncer.Proof) (hash.Hash, error) {
+	f, err := os.Create(filename)
+	if err != nil {
+		return hash.Hash{}, fmt.Errorf("failed to create chunk file: %w", err)
+	}
+	defer f.Close()
+
+	return writeChunk(proof, f)
+}
+
 // NewFileCreator creates a new checkpoint creator that writes created chunks into the filesystem.
-func NewFileCreator(dataDir string, ndb db.NodeDB) (Creator, error) {
+func NewFileCreator(dataDir string, ndb db.NodeDB, opts ...Option) (Creator, error) {
+	o := newOptions(opts...)
+
 	return &fileCreator{
-		dataDir: dataDir,
-		ndb:     ndb,
+		dataDir:     dataDir,
+		ndb:         ndb,
+		parallelism: o.parallelism,
+		logger:      logging.GetLogger("storage/mkvs/checkpoint/file"),
 	}, nil
 }
@@ -15,6 +15,9 @@ type restorer struct {
 
 	ndb db.NodeDB
 
+	// sem bounds the number of chunks that are being restored in parallel.
+	sem chan struct{}
+
 	// currentCheckpoint contains the metadata of the checkpoint that is currently being restored.
 	// If it is nil then no restore is in progress.
 	currentCheckpoint *Metadata
@@ -22,6 +25,22 @@ type restorer struct {
 	pendingChunks map[uint64]bool
 }
 
+// Implements Restorer.
+func (rs *restorer) GetRestoreProgress() *RestoreProgress {
+	rs.Lock()
+	defer rs.Unlock()
+
+	if rs.currentCheckpoint == nil {
+		return nil
+	}
+
+	total := uint64(len(rs.currentCheckpoint.Chunks))
+	return &RestoreProgress{
+		Restored: total - uint64(len(rs.pendingChunks)),
+		Total:    total,
+	}
+}
+
 // Implements Restorer.
 func (rs *restorer) StartRestore(_ context.Context, checkpoint *Metadata) error {
 	rs.Lock()
@@ -83,7 +102,15 @@ func (rs *restorer) RestoreChunk(ctx context.Context, idx uint64, r io.Reader) (
 		return false, err
 	}
 
+	// Bound the number of chunks being restored at the same time.
+	select {
+	case rs.sem <- struct{}{}:
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
 	err = restoreChunk(ctx, rs.ndb, chunk, r)
+	<-rs.sem
+
 	switch {
 	case err == nil:
 	case errors.Is(err, ErrChunkProofVerificationFailed):
@@ -98,6 +125,14 @@ func (rs *restorer) RestoreChunk(ctx context.Context, idx uint64, r io.Reader) (
 	rs.Lock()
 	defer rs.Unlock()
 
+	// Restore may have been aborted or the same chunk restored concurrently.
+	if rs.currentCheckpoint == nil {
+		return false, ErrNoRestoreInProgress
+	}
+	if !rs.pendingChunks[idx] {
+		return false, ErrChunkAlreadyRestored
+	}
+
 	// Mark the given chunk as restored.
 	delete(rs.pendingChunks, idx)
 
@@ -112,6 +147,11 @@ func (rs *restorer) RestoreChunk(ctx context.Context, idx uint64, r io.Reader) (
 }
 
 // NewRestorer creates a new checkpoint restorer.
-func NewRestorer(ndb db.NodeDB) (Restorer, error) {
-	return &restorer{ndb: ndb}, nil
+func NewRestorer(ndb db.NodeDB, opts ...Option) (Restorer, error) {
+	o := newOptions(opts...)
+
+	return &restorer{
+		ndb: ndb,
+		sem: make(chan struct{}, o.parallelism),
+	}, nil
 }
@@ -181,6 +181,13 @@ type NodeDB interface {
 	// Size returns the size of the database in bytes.
 	Size() (int64, error)
 
+	// GetSizeStats returns detailed size statistics of the database.
+	GetSizeStats(ctx context.Context) (*SizeStats, error)
+
+	// Compact performs a manual compaction of the database, reclaiming space used by pruned
+	// versions.
+	Compact() error
+
 	// Sync syncs the database to disk. This is useful if the NoFsync option is used to explicitly
 	// perform a sync.
 	Sync() error
@@ -310,6 +317,14 @@ func (d *nopNodeDB) Size() (int64, error) {
 	return 0, nil
 }
 
+func (d *nopNodeDB) GetSizeStats(context.Context) (*SizeStats, error) {
+	return NewSizeStatsBuilder().Build(0, 0), nil
+}
+
+func (d *nopNodeDB) Compact() error {
+	return nil
+}
+
 func (d *nopNodeDB) Sync() error {
 	return nil
 }
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+)
+
+// SizeStats are node database size statistics, aggregated over all stored versions.
+//
+// Per-root type sizes are estimates of the key and value sizes of the stored entries and do not
+// account for any compression or space that has not yet been reclaimed.
+type SizeStats struct {
+	// LSMSize is the on-disk size of the LSM tree in bytes.
+	LSMSize int64 `json:"lsm_size"`
+	// ValueLogSize is the on-disk size of the value log in bytes.
+	ValueLogSize int64 `json:"value_log_size"`
+
+	// MetadataSize is the estimated size of database metadata not belonging to any version.
+	MetadataSize uint64 `json:"metadata_size"`
+	// EarliestVersion is the earliest version with any stored data. Note that data written in a
+	// pruned version may remain in case it is still referenced by later versions.
+	EarliestVersion uint64 `json:"earliest_version"`
+	// LatestVersion is the latest version with any stored data.
+	LatestVersion uint64 `json:"latest_version"`
+	// RootTypes are the per-root type size statistics.
+	RootTypes map[node.RootType]*RootTypeSizeStats `json:"root_types"`
+	// OtherSize is the estimated size of version data that cannot be attributed to a specific
+	// root type (e.g., version metadata or nodes in databases that do not track root types).
+	OtherSize uint64 `json:"other_size"`
+}
+
+// RootTypeSizeStats are the size statistics of all roots of a given type.
+type RootTypeSizeStats struct {
+	// Roots is the number of roots.
+	Roots uint64 `json:"roots"`
+	// RootSize is the estimated size of root entries.
+	RootSize uint64 `json:"root_size"`
+	// NodeSize is the estimated size of nodes.
+	NodeSize uint64 `json:"node_size"`
+	// WriteLogSize is the estimated size of write logs.
+	WriteLogSize uint64 `json:"write_log_size"`
+}
+
+// SizeStatsBuilder is a helper for building node database size statistics.
+type SizeStatsBuilder struct {
+	stats       SizeStats
+	haveVersion bool
+}
+
+func (b *SizeStatsBuilder) version(version uint64) {
+	if !b.haveVersion || version < b.stats.EarliestVersion {
+		b.stats.EarliestVersion = version
+	}
+	if !b.haveVersion || version > b.stats.LatestVersion {
+		b.stats.LatestVersion = version
+	}
+	b.haveVersion = true
+}
+
+func (b *SizeStatsBuilder) rootType(version uint64, rootType node.RootType) *RootTypeSizeStats {
+	b.version(version)
+	rs, ok := b.stats.RootTypes[rootType]
+	if !ok {
+		rs = &RootTypeSizeStats{}
+		b.stats.RootTypes[rootType] = rs
+	}
+	return rs
+}
+
+// AddRoot accounts for a root of the given type in the given version.
+func (b *SizeStatsBuilder) AddRoot(version uint64, rootType node.RootType, size uint64) {
+	rs := b.rootType(version, rootType)
+	rs.Roots++
+	rs.RootSize += size
+}
+
+// AddNode accounts for a node of the given type in the given version.
+func (b *SizeStatsBuilder) AddNode(version uint64, rootType node.RootType, size uint64) {
+	b.rootType(version, rootType).NodeSize += size
+}
+
+// AddWriteLog accounts for a write log of the given type in the given version.
+func (b *SizeStatsBuilder) AddWriteLog(version uint64, rootType node.RootType, size uint64) {
+	b.rootType(version, rootType).WriteLogSize += size
+}
+
+// AddOther accounts for version data that cannot be attributed to a specific root type.
+func (b *SizeStatsBuilder) AddOther(version uint64, size uint64) {
+	b.version(version)
+	b.stats.OtherSize += size
+}
+
+// AddMetadata accounts for database metadata not belonging to any version.
+func (b *SizeStatsBuilder) AddMetadata(size uint64) {
+	b.stats.MetadataSize += size
+}
+
+// Build builds the size statistics.
+func (b *SizeStatsBuilder) Build(lsmSize, valueLogSize int64) *SizeStats {
+	stats := b.stats
+	stats.LSMSize = lsmSize
+	stats.ValueLogSize = valueLogSize
+	return &stats
+}
+
+// NewSizeStatsBuilder creates a new size statistics builder.
+func NewSizeStatsBuilder() *SizeStatsBuilder {
+	return &SizeStatsBuilder{
+		stats: SizeStats{
+			RootTypes: make(map[node.RootType]*RootTypeSizeStats),
+		},
+	}
+}
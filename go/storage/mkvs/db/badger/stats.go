@@ -0,0 +1,83 @@
+package badger
+
+import (
+	"context"
+	"math"
+
+	"github.com/dgraph-io/badger/v4"
+
+	cmnBadger "github.com/oasisprotocol/oasis-core/go/common/badger"
+	"github.com/oasisprotocol/oasis-core/go/common/keyformat"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
+)
+
+// statsCheckInterval is the number of keys after which the context is checked for cancellation
+// while collecting size statistics.
+const statsCheckInterval = 10_000
+
+// decodeKey decodes the given key if it has the given key format.
+func decodeKey(key []byte, kf *keyformat.KeyFormat, values ...interface{}) bool {
+	if len(key) < kf.Size() {
+		return false
+	}
+	return kf.Decode(key, values...)
+}
+
+// Implements api.NodeDB.
+func (d *badgerNodeDB) GetSizeStats(ctx context.Context) (*api.SizeStats, error) {
+	tx := d.db.NewTransactionAt(math.MaxUint64, false)
+	defer tx.Discard()
+
+	opts := badger.DefaultIteratorOptions
+	opts.PrefetchValues = false
+	it := tx.NewIterator(opts)
+	defer it.Close()
+
+	b := api.NewSizeStatsBuilder()
+	var n uint64
+	for it.Rewind(); it.Valid(); it.Next() {
+		if n++; n%statsCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+
+		item := it.Item()
+		key := item.Key()
+		size := uint64(item.EstimatedSize())
+		if item.Version() <= tsMetadata {
+			b.AddMetadata(size)
+			continue
+		}
+		version := tsToVersion(item.Version())
+
+		var (
+			decVersion uint64
+			th1, th2   api.TypedHash
+		)
+		switch {
+		case decodeKey(key, rootNodeKeyFmt, &th1):
+			b.AddRoot(version, th1.Type(), size)
+		case decodeKey(key, writeLogKeyFmt, &decVersion, &th1, &th2):
+			b.AddWriteLog(decVersion, th1.Type(), size)
+		case decodeKey(key, rootUpdatedNodesKeyFmt, &decVersion, &th1):
+			b.AddOther(decVersion, size)
+		case decodeKey(key, rootsMetadataKeyFmt, &decVersion):
+			b.AddOther(decVersion, size)
+		default:
+			// Nodes are addressed by hash only, so their root type is not known.
+			b.AddOther(version, size)
+		}
+	}
+
+	lsm, vlog := d.db.Size()
+	return b.Build(lsm, vlog), nil
+}
+
+// Implements api.NodeDB.
+func (d *badgerNodeDB) Compact() error {
+	if d.readOnly {
+		return api.ErrReadOnly
+	}
+	return cmnBadger.Compact(d.db)
+}
@@ -1,27 +1,74 @@
+// Package db implements the node database backend registry.
+//
+// Node database backends register a factory under their name so that the storage backend can
+// select the backend based on its configuration. Currently only the badger and pathbadger
+// backends are provided.
 package db
 
 import (
 	"fmt"
+	"sort"
+	"sync"
 
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/api"
 	backendBadger "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/badger"
 	backendPathBadger "github.com/oasisprotocol/oasis-core/go/storage/mkvs/db/pathbadger"
 )
 
-// Backends contains the factories for all the backend implementations.
-var Backends = []api.Factory{
-	backendBadger.Factory,
-	backendPathBadger.Factory,
+var (
+	factoriesLock sync.RWMutex
+	factories     = make(map[string]api.Factory)
+)
+
+// Register registers a new node database backend factory.
+//
+// Registering multiple factories under the same name will panic.
+func Register(factory api.Factory) {
+	factoriesLock.Lock()
+	defer factoriesLock.Unlock()
+
+	name := factory.Name()
+	if _, exists := factories[name]; exists {
+		panic(fmt.Sprintf("storage/mkvs/db: backend '%s' already registered", name))
+	}
+	factories[name] = factory
+}
+
+// Backends returns the factories for all registered backend implementations, sorted by name.
+func Backends() []api.Factory {
+	factoriesLock.RLock()
+	defer factoriesLock.RUnlock()
+
+	backends := make([]api.Factory, 0, len(factories))
+	for _, factory := range factories {
+		backends = append(backends, factory)
+	}
+	sort.Slice(backends, func(i, j int) bool {
+		return backends[i].Name() < backends[j].Name()
+	})
+	return backends
+}
+
+// Names returns the sorted names of all registered backend implementations.
+func Names() []string {
+	backends := Backends()
+	names := make([]string, 0, len(backends))
+	for _, factory := range backends {
+		names = append(names, factory.Name())
+	}
+	return names
 }
 
 // GetBackendByName returns the backend implementation factory with the given name.
 func GetBackendByName(name string) (api.Factory, error) {
-	for _, factory := range Backends {
-		if name == factory.Name() {
-			return factory, nil
-		}
+	factoriesLock.RLock()
+	defer factoriesLock.RUnlock()
+
+	factory, exists := factories[name]
+	if !exists {
+		return nil, fmt.Errorf("unsupported node database backend: %s", name)
 	}
-	return nil, fmt.Errorf("unsupported node database backend: %s", name)
+	return factory, nil
 }
 
 // New creates a given named database backend.
@@ -32,3 +79,8 @@ func New(name string, cfg *api.Config) (api.NodeDB, error) {
 	}
 	return factory.New(cfg)
 }
+
+func init() {
+	Register(backendBadger.Factory)
+	Register(backendPathBadger.Factory)
+}
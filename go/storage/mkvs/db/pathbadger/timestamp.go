@@ -9,3 +9,11 @@ func versionToTs(version uint64) uint64 {
 	// Version 0 starts at timestamp after metadata.
 	return tsMetadata + 1 + version
 }
+
+// tsToVersion converts a Badger timestamp to a MKVS version.
+func tsToVersion(ts uint64) uint64 {
+	if ts < tsMetadata+1 {
+		return 0
+	}
+	return ts - tsMetadata - 1
+}
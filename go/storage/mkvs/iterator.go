@@ -3,13 +3,24 @@ package mkvs
 import (
 	"context"
 	"errors"
+	"fmt"
 
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
 )
 
 var errClosed = errors.New("iterator: use of closed iterator")
 
+// iterateContinuation is the continuation of a SyncIterate request whose proof exceeded the
+// requested proof size budget.
+type iterateContinuation struct {
+	// Key is the key at which iteration should resume.
+	Key node.Key `json:"key"`
+	// Prefetch is the number of remaining items to iterate over.
+	Prefetch uint16 `json:"prefetch"`
+}
+
 // Implements syncer.ReadSyncer.
 func (t *tree) SyncIterate(ctx context.Context, request *syncer.IterateRequest) (*syncer.ProofResponse, error) {
 	t.cache.Lock()
@@ -29,20 +40,38 @@ func (t *tree) SyncIterate(ctx context.Context, request *syncer.IterateRequest)
 		return nil, err
 	}
 
+	key, prefetch := node.Key(request.Key), request.Prefetch
+	if request.Continuation != nil {
+		var cont iterateContinuation
+		if err = cbor.Unmarshal(request.Continuation, &cont); err != nil {
+			return nil, fmt.Errorf("mkvs: malformed continuation: %w", err)
+		}
+		key, prefetch = cont.Key, cont.Prefetch
+	}
+
 	// Create an iterator which generates proofs. Always anchor the proof at the
 	// root as an iterator may encompass many subtrees. Make sure to propagate
 	// prefetching to any upstream remote syncers.
 	it := t.NewIterator(ctx,
 		WithProofBuilder(pb),
-		IteratorPrefetch(request.Prefetch),
+		IteratorPrefetch(prefetch),
 	)
 	defer it.Close()
 
-	it.Seek(request.Key)
+	it.Seek(key)
 	if it.Err() != nil {
 		return nil, it.Err()
 	}
-	for i := 0; it.Valid() && i < int(request.Prefetch); i++ {
+	var continuation []byte
+	for i := 0; it.Valid() && i < int(prefetch); i++ {
+		// Stop once the proof size budget is exhausted, but always make progress.
+		if request.ProofSizeLimit > 0 && i > 0 && pb.Size() >= request.ProofSizeLimit {
+			continuation = cbor.Marshal(&iterateContinuation{
+				Key:      it.Key(),
+				Prefetch: prefetch - uint16(i),
+			})
+			break
+		}
 		it.Next()
 	}
 	if it.Err() != nil {
@@ -56,7 +85,8 @@ func (t *tree) SyncIterate(ctx context.Context, request *syncer.IterateRequest)
 	}
 
 	return &syncer.ProofResponse{
-		Proof: *proof,
+		Proof:        *proof,
+		Continuation: continuation,
 	}, nil
 }
 
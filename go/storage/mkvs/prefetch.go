@@ -3,11 +3,24 @@ package mkvs
 import (
 	"bytes"
 	"context"
+	"fmt"
 
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
 )
 
+// getPrefixesContinuation is the continuation of a SyncGetPrefixes request whose proof exceeded
+// the requested proof size budget.
+type getPrefixesContinuation struct {
+	// Prefix is the index of the prefix at which iteration should resume.
+	Prefix uint64 `json:"prefix"`
+	// Key is the key at which iteration should resume.
+	Key node.Key `json:"key"`
+	// Total is the number of items already iterated over.
+	Total uint16 `json:"total"`
+}
+
 // Implements Tree.
 func (t *tree) PrefetchPrefixes(ctx context.Context, prefixes [][]byte, limit uint16) error {
 	t.cache.Lock()
@@ -80,13 +93,32 @@ func (t *tree) SyncGetPrefixes(ctx context.Context, request *syncer.GetPrefixesR
 	if err != nil {
 		return nil, err
 	}
+	var cont getPrefixesContinuation
+	if request.Continuation != nil {
+		if err = cbor.Unmarshal(request.Continuation, &cont); err != nil {
+			return nil, fmt.Errorf("mkvs: malformed continuation: %w", err)
+		}
+		if cont.Prefix >= uint64(len(request.Prefixes)) {
+			return nil, fmt.Errorf("mkvs: malformed continuation: prefix index out of range")
+		}
+	}
+
 	it := t.NewIterator(ctx, WithProofBuilder(pb))
 	defer it.Close()
 
-	var total int
+	var (
+		continuation []byte
+		consumed     int
+	)
+	total := int(cont.Total)
 prefixLoop:
-	for _, prefix := range request.Prefixes {
-		it.Seek(prefix)
+	for idx := cont.Prefix; idx < uint64(len(request.Prefixes)); idx++ {
+		prefix := request.Prefixes[idx]
+		if idx == cont.Prefix && cont.Key != nil {
+			it.Seek(cont.Key)
+		} else {
+			it.Seek(prefix)
+		}
 		if it.Err() != nil {
 			return nil, it.Err()
 		}
@@ -97,7 +129,17 @@ prefixLoop:
 			if !bytes.HasPrefix(it.Key(), prefix) {
 				break
 			}
+			// Stop once the proof size budget is exhausted, but always make progress.
+			if request.ProofSizeLimit > 0 && consumed > 0 && pb.Size() >= request.ProofSizeLimit {
+				continuation = cbor.Marshal(&getPrefixesContinuation{
+					Prefix: idx,
+					Key:    it.Key(),
+					Total:  uint16(total),
+				})
+				break prefixLoop
+			}
 			it.Next()
+			consumed++
 		}
 		if it.Err() != nil {
 			return nil, it.Err()
@@ -110,6 +152,7 @@ prefixLoop:
 	}
 
 	return &syncer.ProofResponse{
-		Proof: *proof,
+		Proof:        *proof,
+		Continuation: continuation,
 	}, nil
 }
@@ -0,0 +1,190 @@
+package syncer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/node"
+)
+
+const (
+	// DefaultProofSizeLimit is the default proof size budget used by paging read syncers.
+	DefaultProofSizeLimit = 8 * 1024 * 1024
+
+	// maxProofPages is the maximum number of pages fetched for a single proof.
+	maxProofPages = 1 << 16
+)
+
+// pagingReadSyncer is a ReadSyncer which requests proofs in pages of bounded size and reassembles
+// them into a single proof.
+type pagingReadSyncer struct {
+	rs             ReadSyncer
+	proofSizeLimit uint64
+}
+
+// NewPagingReadSyncer creates a new read syncer which requests proofs from the given remote read
+// syncer in pages of at most the given size (on a best-effort basis), following continuations
+// until the proof is complete and merging all pages into a single proof.
+//
+// SyncGet proofs are bounded by the depth of the tree and are passed through unchanged.
+func NewPagingReadSyncer(rs ReadSyncer, proofSizeLimit uint64) ReadSyncer {
+	return &pagingReadSyncer{
+		rs:             rs,
+		proofSizeLimit: proofSizeLimit,
+	}
+}
+
+func (p *pagingReadSyncer) SyncGet(ctx context.Context, request *GetRequest) (*ProofResponse, error) {
+	return p.rs.SyncGet(ctx, request)
+}
+
+func (p *pagingReadSyncer) SyncGetPrefixes(ctx context.Context, request *GetPrefixesRequest) (*ProofResponse, error) {
+	rq := *request
+	rq.ProofSizeLimit = p.proofSizeLimit
+	return fetchProofPages(ctx, func(continuation []byte) (*ProofResponse, error) {
+		rq.Continuation = continuation
+		return p.rs.SyncGetPrefixes(ctx, &rq)
+	})
+}
+
+func (p *pagingReadSyncer) SyncIterate(ctx context.Context, request *IterateRequest) (*ProofResponse, error) {
+	rq := *request
+	rq.ProofSizeLimit = p.proofSizeLimit
+	return fetchProofPages(ctx, func(continuation []byte) (*ProofResponse, error) {
+		rq.Continuation = continuation
+		return p.rs.SyncIterate(ctx, &rq)
+	})
+}
+
+func fetchProofPages(ctx context.Context, fetch func([]byte) (*ProofResponse, error)) (*ProofResponse, error) {
+	var (
+		pages        []*Proof
+		continuation []byte
+	)
+	for {
+		if len(pages) >= maxProofPages {
+			return nil, fmt.Errorf("syncer: too many proof pages")
+		}
+
+		rsp, err := fetch(continuation)
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, &rsp.Proof)
+
+		if rsp.Continuation == nil {
+			break
+		}
+		continuation = rsp.Continuation
+	}
+
+	proof, err := MergeProofs(ctx, pages)
+	if err != nil {
+		return nil, err
+	}
+	return &ProofResponse{Proof: *proof}, nil
+}
+
+// MergeProofs merges multiple proofs for the same root into a single proof that includes all of
+// the nodes included in any of the given proofs.
+//
+// The proofs are only checked for consistency with their untrusted roots, so the resulting proof
+// must still be verified against an independently obtained root.
+func MergeProofs(ctx context.Context, proofs []*Proof) (*Proof, error) {
+	switch len(proofs) {
+	case 0:
+		return nil, errors.New("syncer: no proofs to merge")
+	case 1:
+		return proofs[0], nil
+	default:
+	}
+
+	var (
+		pv     ProofVerifier
+		merged *node.Pointer
+	)
+	root := proofs[0].UntrustedRoot
+	version := proofs[0].V
+	for _, proof := range proofs {
+		if proof.V != version {
+			return nil, fmt.Errorf("syncer: proof version mismatch (expected: %d got: %d)", version, proof.V)
+		}
+
+		subtree, err := pv.VerifyProof(ctx, root, proof)
+		if err != nil {
+			return nil, err
+		}
+		if merged, err = mergeProofSubtrees(merged, subtree); err != nil {
+			return nil, err
+		}
+	}
+	if merged == nil {
+		// Empty tree, all proofs are the same.
+		return proofs[0], nil
+	}
+
+	pb, err := NewProofBuilderForVersion(root, root, version)
+	if err != nil {
+		return nil, err
+	}
+	includeProofSubtree(pb, merged)
+	return pb.Build(ctx)
+}
+
+// mergeProofSubtrees merges two verified subtrees for the same root.
+func mergeProofSubtrees(dst, src *node.Pointer) (*node.Pointer, error) {
+	if dst == nil || dst.Node == nil {
+		return src, nil
+	}
+	if src == nil || src.Node == nil {
+		return dst, nil
+	}
+	if !dst.Hash.Equal(&src.Hash) {
+		return nil, fmt.Errorf("syncer: hash mismatch during merge (expected: %s got: %s)",
+			dst.Hash,
+			src.Hash,
+		)
+	}
+
+	dn, ok := dst.Node.(*node.InternalNode)
+	if !ok {
+		// Leaf nodes with the same hash are the same.
+		return dst, nil
+	}
+	sn, ok := src.Node.(*node.InternalNode)
+	if !ok {
+		return nil, errors.New("syncer: node type mismatch during merge")
+	}
+
+	var err error
+	if dn.LeafNode, err = mergeProofSubtrees(dn.LeafNode, sn.LeafNode); err != nil {
+		return nil, err
+	}
+	if dn.Left, err = mergeProofSubtrees(dn.Left, sn.Left); err != nil {
+		return nil, err
+	}
+	if dn.Right, err = mergeProofSubtrees(dn.Right, sn.Right); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
+// includeProofSubtree includes all nodes of a verified subtree in the given proof builder.
+func includeProofSubtree(pb *ProofBuilder, ptr *node.Pointer) {
+	if ptr == nil || ptr.Node == nil {
+		return
+	}
+	pb.Include(ptr.Node)
+
+	n, ok := ptr.Node.(*node.InternalNode)
+	if !ok {
+		return
+	}
+	if pb.Version() > 0 {
+		// Since version 1, the leaf node is included separately, as a child.
+		includeProofSubtree(pb, n.LeafNode)
+	}
+	includeProofSubtree(pb, n.Left)
+	includeProofSubtree(pb, n.Right)
+}
@@ -51,6 +51,12 @@ type GetPrefixesRequest struct {
 	// ProofVersion specifies the proof version to use. If not specified,
 	// the default (0) version is used for backwards compatibility.
 	ProofVersion uint16 `json:"proof_version,omitempty"`
+
+	// ProofSizeLimit is the optional proof size budget in bytes. In case the proof would exceed
+	// the budget, a partial proof is returned together with a continuation.
+	ProofSizeLimit uint64 `json:"proof_size_limit,omitempty"`
+	// Continuation is the optional continuation returned by a previous request.
+	Continuation []byte `json:"continuation,omitempty"`
 }
 
 // IterateRequest is a request for the SyncIterate operation.
@@ -62,11 +68,21 @@ type IterateRequest struct {
 	// ProofVersion specifies the proof version to use. If not specified,
 	// the default (0) version is used for backwards compatibility.
 	ProofVersion uint16 `json:"proof_version,omitempty"`
+
+	// ProofSizeLimit is the optional proof size budget in bytes. In case the proof would exceed
+	// the budget, a partial proof is returned together with a continuation.
+	ProofSizeLimit uint64 `json:"proof_size_limit,omitempty"`
+	// Continuation is the optional continuation returned by a previous request.
+	Continuation []byte `json:"continuation,omitempty"`
 }
 
 // ProofResponse is a response for requests that produce proofs.
 type ProofResponse struct {
 	Proof Proof `json:"proof"`
+
+	// Continuation is an opaque token that can be used to request the next part of a proof that
+	// exceeded the requested proof size budget. It is nil if the proof is complete.
+	Continuation []byte `json:"continuation,omitempty"`
 }
 
 // ReadSyncer is the interface for synchronizing the in-memory cache
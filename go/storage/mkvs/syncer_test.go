@@ -540,3 +540,76 @@ func TestTreeProofs(t *testing.T) {
 		}
 	}
 }
+
+func TestPagedProofs(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	keys, values := generateKeyValuePairsEx("T", 100)
+	var items writelog.WriteLog
+
+	tree := New(nil, nil, node.RootTypeState).(*tree)
+	for i, key := range keys {
+		err := tree.Insert(ctx, key, values[i])
+		require.NoError(err, "Insert")
+		items = append(items, writelog.LogEntry{Key: key, Value: values[i]})
+	}
+	root := node.Root{Type: node.RootTypeState}
+	_, rootHash, err := tree.Commit(ctx, root.Namespace, root.Version)
+	require.NoError(err, "Commit")
+	root.Hash = rootHash
+
+	const proofSizeLimit = 512
+
+	for _, proofVersion := range []uint16{0, 1} {
+		treeID := syncer.TreeID{Root: root, Position: root.Hash}
+
+		// SyncIterate.
+		iterateRq := &syncer.IterateRequest{
+			Tree:         treeID,
+			Key:          keys[10],
+			Prefetch:     50,
+			ProofVersion: proofVersion,
+		}
+		full, err := tree.SyncIterate(ctx, iterateRq)
+		require.NoError(err, "SyncIterate")
+		require.Nil(full.Continuation, "unbounded proofs should not have a continuation")
+
+		stats := syncer.NewStatsCollector(tree)
+		paged, err := syncer.NewPagingReadSyncer(stats, proofSizeLimit).SyncIterate(ctx, iterateRq)
+		require.NoError(err, "SyncIterate (paged)")
+		require.Greater(stats.SyncIterateCount, 1, "proof should be fetched in multiple pages")
+		require.EqualValues(full.Proof, paged.Proof, "reassembled proof should match the unbounded proof")
+
+		// SyncGetPrefixes.
+		prefixesRq := &syncer.GetPrefixesRequest{
+			Tree:         treeID,
+			Prefixes:     [][]byte{[]byte("Tkey 1"), []byte("Tkey 5")},
+			Limit:        15,
+			ProofVersion: proofVersion,
+		}
+		full, err = tree.SyncGetPrefixes(ctx, prefixesRq)
+		require.NoError(err, "SyncGetPrefixes")
+		require.Nil(full.Continuation, "unbounded proofs should not have a continuation")
+
+		stats = syncer.NewStatsCollector(tree)
+		paged, err = syncer.NewPagingReadSyncer(stats, proofSizeLimit).SyncGetPrefixes(ctx, prefixesRq)
+		require.NoError(err, "SyncGetPrefixes (paged)")
+		require.Greater(stats.SyncGetPrefixesCount, 1, "proof should be fetched in multiple pages")
+		require.EqualValues(full.Proof, paged.Proof, "reassembled proof should match the unbounded proof")
+
+		// Malformed continuations should be rejected.
+		iterateRq.Continuation = []byte("invalid")
+		_, err = tree.SyncIterate(ctx, iterateRq)
+		require.Error(err, "SyncIterate should fail with a malformed continuation")
+	}
+
+	// Remote trees should work transparently with paged proofs.
+	remote := NewWithRoot(syncer.NewPagingReadSyncer(tree, proofSizeLimit), nil, root)
+	defer remote.Close()
+
+	it := remote.NewIterator(ctx, IteratorPrefetch(1000))
+	defer it.Close()
+
+	testIterator(t, items, it, nil)
+}
@@ -1193,6 +1193,46 @@ func testSize(t *testing.T, ndb db.NodeDB, factory NodeDBFactory) {
 	require.True(t, newSize > size, "Size should be greater than before")
 }
 
+func testSizeStats(t *testing.T, ndb db.NodeDB, _ NodeDBFactory) {
+	ctx := context.Background()
+
+	// Put something in the database in two versions.
+	tree := New(nil, ndb, node.RootTypeState)
+	for version, key := range []string{"foo", "moo"} {
+		err := tree.Insert(ctx, []byte(key), []byte("bar"))
+		require.NoError(t, err, "Insert")
+		_, rootHash, err := tree.Commit(ctx, testNs, uint64(version))
+		require.NoError(t, err, "Commit")
+		err = ndb.Finalize([]node.Root{{
+			Namespace: testNs,
+			Version:   uint64(version),
+			Type:      node.RootTypeState,
+			Hash:      rootHash,
+		}})
+		require.NoError(t, err, "Finalize")
+	}
+
+	stats, err := ndb.GetSizeStats(ctx)
+	require.NoError(t, err, "GetSizeStats")
+	require.EqualValues(t, 0, stats.EarliestVersion, "earliest version should be correct")
+	require.EqualValues(t, 1, stats.LatestVersion, "latest version should be correct")
+	rs := stats.RootTypes[node.RootTypeState]
+	require.NotNil(t, rs, "state roots should have statistics")
+	require.EqualValues(t, 2, rs.Roots, "there should be a state root in each version")
+
+	// Prune the first version and compact the database.
+	err = ndb.Prune(0)
+	require.NoError(t, err, "Prune")
+	err = ndb.Compact()
+	require.NoError(t, err, "Compact")
+
+	stats, err = ndb.GetSizeStats(ctx)
+	require.NoError(t, err, "GetSizeStats")
+	require.EqualValues(t, 1, stats.LatestVersion, "latest version should be correct")
+	require.NotNil(t, stats.RootTypes[node.RootTypeState], "state roots should have statistics")
+	require.NotZero(t, stats.RootTypes[node.RootTypeState].Roots, "the state root of version 1 should remain")
+}
+
 func testEmptyValueWriteLog(t *testing.T, ndb db.NodeDB, _ NodeDBFactory) {
 	ctx := context.Background()
 
@@ -2376,6 +2416,7 @@ func testBackend(
 		{"HasRoot", testHasRoot},
 		{"GetRootsForVersion", testGetRootsForVersion},
 		{"Size", testSize},
+		{"SizeStats", testSizeStats},
 		{"FinalizeEmpty", testFinalizeEmpty},
 		{"PruneBasic", testPruneBasic},
 		{"PruneManyVersions", testPruneManyVersions},
@@ -0,0 +1,140 @@
+package migrations
+
+import (
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	consensusState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/abci/state"
+	abciAPI "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/api"
+	governanceState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/governance/state"
+	registryState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/registry/state"
+	stakingState "github.com/oasisprotocol/oasis-core/go/consensus/cometbft/apps/staking/state"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+)
+
+// Consensus250 is the name of the upgrade that enables features introduced in Oasis Core 25.0.
+//
+// This upgrade includes:
+//   - The staking `ReclaimEscrowAndTransfer` transaction which transfers the debonded stake to
+//     another account once debonding completes.
+//   - The governance `CastAggregatedVote` transaction which casts a vote on behalf of multiple
+//     entities at once.
+//   - Backup-only compute nodes which are only elected as backup executor workers.
+//   - The runtime TEE configuration history, which is backfilled with the current TEE
+//     configuration of all runtimes.
+//   - The node by runtime index, which is built from all registered nodes.
+//   - The per-block event index record, which is stored in consensus state.
+const Consensus250 = "consensus250"
+
+// Version250 is the Oasis Core 25.0 version.
+var Version250 = version.MustFromString("25.0")
+
+var _ Handler = (*Handler250)(nil)
+
+// Handler250 is the upgrade handler that transitions Oasis Core from version 24.2 to 25.0.
+type Handler250 struct{}
+
+// HasStartupUpgrade implements Handler.
+func (h *Handler250) HasStartupUpgrade() bool {
+	return false
+}
+
+// StartupUpgrade implements Handler.
+func (h *Handler250) StartupUpgrade() error {
+	return nil
+}
+
+// ConsensusUpgrade implements Handler.
+func (h *Handler250) ConsensusUpgrade(privateCtx interface{}) error {
+	abciCtx := privateCtx.(*abciAPI.Context)
+	switch abciCtx.Mode() {
+	case abciAPI.ContextBeginBlock:
+		// Nothing to do.
+	case abciAPI.ContextEndBlock:
+		// Consensus parameters.
+		consState := consensusState.NewMutableState(abciCtx.State())
+		consParams, err := consState.ConsensusParameters(abciCtx)
+		if err != nil {
+			return fmt.Errorf("failed to load consensus parameters: %w", err)
+		}
+
+		consParams.FeatureVersion = &Version250
+
+		if err = consState.SetConsensusParameters(abciCtx, consParams); err != nil {
+			return fmt.Errorf("failed to set consensus parameters: %w", err)
+		}
+
+		// Registry.
+		regState := registryState.NewMutableState(abciCtx.State())
+
+		regParams, err := regState.ConsensusParameters(abciCtx)
+		if err != nil {
+			return fmt.Errorf("failed to load registry consensus parameters: %w", err)
+		}
+		regParams.EnableBackupOnlyNodes = true
+		regParams.EnableRuntimeTEEHistory = true
+		regParams.EnableNodeByRuntimeIndex = true
+		regParams.EnableDeploymentMetadata = true
+
+		if err = regState.SetConsensusParameters(abciCtx, regParams); err != nil {
+			return fmt.Errorf("failed to update registry consensus parameters: %w", err)
+		}
+
+		runtimes, err := regState.AllRuntimes(abciCtx)
+		if err != nil {
+			return fmt.Errorf("failed to load runtimes: %w", err)
+		}
+		for _, rt := range runtimes {
+			if err = regState.AppendRuntimeTEEHistory(abciCtx, rt, abciCtx.BlockHeight()+1); err != nil {
+				return fmt.Errorf("failed to record TEE history of runtime %s: %w", rt.ID, err)
+			}
+		}
+
+		nodes, err := regState.Nodes(abciCtx)
+		if err != nil {
+			return fmt.Errorf("failed to load nodes: %w", err)
+		}
+		for _, n := range nodes {
+			if err = regState.SetNodeRuntimes(abciCtx, nil, n); err != nil {
+				return fmt.Errorf("failed to index runtimes of node %s: %w", n.ID, err)
+			}
+		}
+
+		// Staking.
+		stakeState := stakingState.NewMutableState(abciCtx.State())
+
+		stakeParams, err := stakeState.ConsensusParameters(abciCtx)
+		if err != nil {
+			return fmt.Errorf("failed to load staking consensus parameters: %w", err)
+		}
+		stakeParams.EnableDebondingTransfers = true
+		stakeParams.EnableTokenDisplayChanges = true
+		if _, ok := stakeParams.GasCosts[staking.GasOpReclaimEscrowAndTransfer]; !ok && stakeParams.GasCosts != nil {
+			stakeParams.GasCosts[staking.GasOpReclaimEscrowAndTransfer] = staking.DefaultGasCosts[staking.GasOpReclaimEscrowAndTransfer]
+		}
+
+		if err = stakeState.SetConsensusParameters(abciCtx, stakeParams); err != nil {
+			return fmt.Errorf("failed to update staking consensus parameters: %w", err)
+		}
+
+		// Governance.
+		govState := governanceState.NewMutableState(abciCtx.State())
+
+		govParams, err := govState.ConsensusParameters(abciCtx)
+		if err != nil {
+			return fmt.Errorf("failed to load governance consensus parameters: %w", err)
+		}
+		govParams.EnableAggregatedVotes = true
+
+		if err = govState.SetConsensusParameters(abciCtx, govParams); err != nil {
+			return fmt.Errorf("failed to update governance consensus parameters: %w", err)
+		}
+	default:
+		return fmt.Errorf("upgrade handler called in unexpected context: %s", abciCtx.Mode())
+	}
+	return nil
+}
+
+func init() {
+	Register(Consensus250, &Handler250{})
+}
@@ -9,6 +9,7 @@ import (
 type Event struct {
 	Height int64     `json:"height,omitempty"`
 	TxHash hash.Hash `json:"tx_hash,omitempty"`
+	Index  uint32    `json:"index,omitempty"`
 
 	ActionSubmitted  *ActionSubmittedEvent  `json:"action_submitted,omitempty"`
 	ActionCanceled   *ActionCanceledEvent   `json:"action_canceled,omitempty"`
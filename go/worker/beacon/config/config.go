@@ -0,0 +1,46 @@
+// Package config implements global configuration options.
+package config
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Config is the beacon worker configuration structure.
+type Config struct {
+	// Drand is the configuration of the drand relay.
+	Drand DrandConfig `yaml:"drand,omitempty"`
+}
+
+// DrandConfig is the drand relay configuration structure.
+type DrandConfig struct {
+	// URL is the base URL of the drand HTTP API that rounds are fetched from. If empty, the node
+	// does not relay drand rounds.
+	//
+	// NOTE: This should only be set on nodes that are configured as drand relays in the beacon
+	// consensus parameters.
+	URL string `yaml:"url,omitempty"`
+}
+
+// Validate validates the configuration settings.
+func (c *Config) Validate() error {
+	if c.Drand.URL != "" {
+		u, err := url.Parse(c.Drand.URL)
+		if err != nil {
+			return fmt.Errorf("drand.url: malformed URL: %w", err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("drand.url: unsupported URL scheme '%s'", u.Scheme)
+		}
+	}
+	return nil
+}
+
+// DefaultConfig returns the default configuration settings.
+func DefaultConfig() Config {
+	return Config{
+		Drand: DrandConfig{
+			URL: "",
+		},
+	}
+}
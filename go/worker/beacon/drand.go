@@ -0,0 +1,78 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/httpclient"
+)
+
+const drandRequestTimeout = 10 * time.Second
+
+// drandRound is a drand round as returned by the drand HTTP API.
+type drandRound struct {
+	Round      uint64 `json:"round"`
+	Randomness string `json:"randomness"`
+	Signature  string `json:"signature"`
+}
+
+// drandClient is a client for the drand HTTP API.
+type drandClient struct {
+	baseURL   string
+	chainHash []byte
+
+	client *http.Client
+}
+
+// Latest fetches the latest round of the drand chain and returns the round number and the
+// round's signature.
+func (c *drandClient) Latest(ctx context.Context) (uint64, []byte, error) {
+	url := fmt.Sprintf("%s/%s/public/latest", strings.TrimRight(c.baseURL, "/"), hex.EncodeToString(c.chainHash))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	rsp, err := c.client.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to fetch latest round: %w", err)
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode != http.StatusOK {
+		return 0, nil, fmt.Errorf("failed to fetch latest round: status code %d", rsp.StatusCode)
+	}
+
+	var round drandRound
+	if err = json.NewDecoder(rsp.Body).Decode(&round); err != nil {
+		return 0, nil, fmt.Errorf("malformed round: %w", err)
+	}
+	signature, err := hex.DecodeString(round.Signature)
+	if err != nil || len(signature) == 0 {
+		return 0, nil, fmt.Errorf("malformed round signature")
+	}
+	randomness, err := hex.DecodeString(round.Randomness)
+	if err != nil {
+		return 0, nil, fmt.Errorf("malformed round randomness: %w", err)
+	}
+
+	// The randomness of a drand round is the hash of its signature.
+	if expected := sha256.Sum256(signature); !bytes.Equal(randomness, expected[:]) {
+		return 0, nil, fmt.Errorf("round randomness does not match its signature")
+	}
+
+	return round.Round, signature, nil
+}
+
+func newDrandClient(baseURL string, chainHash []byte) *drandClient {
+	return &drandClient{
+		baseURL:   baseURL,
+		chainHash: chainHash,
+		client:    httpclient.New(drandRequestTimeout),
+	}
+}
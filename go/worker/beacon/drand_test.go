@@ -0,0 +1,51 @@
+package beacon
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDrandClient(t *testing.T) {
+	require := require.New(t)
+
+	chainHash := sha256.Sum256([]byte("drand chain"))
+	signature := []byte("drand signature")
+	randomness := sha256.Sum256(signature)
+
+	var rsp string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != fmt.Sprintf("/%x/public/latest", chainHash) {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(rsp))
+	}))
+	defer srv.Close()
+
+	client := newDrandClient(srv.URL+"/", chainHash[:])
+
+	rsp = fmt.Sprintf(`{"round":42,"randomness":"%x","signature":"%x"}`, randomness, signature)
+	round, sig, err := client.Latest(context.Background())
+	require.NoError(err, "Latest")
+	require.EqualValues(42, round)
+	require.Equal(signature, sig)
+
+	rsp = fmt.Sprintf(`{"round":42,"randomness":"%s","signature":"%x"}`, hex.EncodeToString(make([]byte, 32)), signature)
+	_, _, err = client.Latest(context.Background())
+	require.Error(err, "Latest should fail when the randomness does not match the signature")
+
+	rsp = `{"round":42}`
+	_, _, err = client.Latest(context.Background())
+	require.Error(err, "Latest should fail without a signature")
+
+	client = newDrandClient(srv.URL, make([]byte, 32))
+	_, _, err = client.Latest(context.Background())
+	require.Error(err, "Latest should fail for an unknown chain")
+}
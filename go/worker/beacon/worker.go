@@ -15,7 +15,8 @@ import (
 const workerName = "worker/beacon"
 
 type Worker struct {
-	vrf *vrfWorker
+	vrf   *vrfWorker
+	drand *drandWorker
 
 	ctx context.Context
 
@@ -32,6 +33,11 @@ func (w *Worker) Start() error {
 			return fmt.Errorf("worker/beacon: failed to start VRF worker: %w", err)
 		}
 	}
+	if w.drand != nil {
+		if err := w.drand.Start(); err != nil {
+			return fmt.Errorf("worker/beacon: failed to start drand worker: %w", err)
+		}
+	}
 
 	return nil
 }
@@ -40,6 +46,9 @@ func (w *Worker) Stop() {
 	if w.vrf != nil {
 		w.vrf.Stop()
 	}
+	if w.drand != nil {
+		w.drand.Stop()
+	}
 }
 
 func (w *Worker) Quit() <-chan struct{} {
@@ -50,6 +59,9 @@ func (w *Worker) Cleanup() {
 	if w.vrf != nil {
 		w.vrf.Cleanup()
 	}
+	if w.drand != nil {
+		w.drand.Cleanup()
+	}
 }
 
 func (w *Worker) Name() string {
@@ -95,6 +107,20 @@ func New(
 		)
 	}
 
+	if w.drand, err = newDrand(w); err == nil {
+		w.allQuitWg.Add(1)
+		go func() {
+			defer w.allQuitWg.Done()
+			<-w.drand.Quit()
+		}()
+
+		created = true
+	} else {
+		initLogger.Error("failed to initialize drand worker",
+			"err", err,
+		)
+	}
+
 	if created {
 		go func() {
 			defer close(w.allQuitCh)
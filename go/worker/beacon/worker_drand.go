@@ -0,0 +1,212 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/config"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	"github.com/oasisprotocol/oasis-core/go/consensus/api/transaction"
+)
+
+// drandRetryInterval is the interval at which fetching the latest drand round is retried.
+const drandRetryInterval = 5 * time.Second
+
+// drandWorker relays drand rounds to the external beacon backend.
+type drandWorker struct {
+	parent *Worker
+	logger *logging.Logger
+
+	url     string
+	txRetry *txRetry
+
+	stopCh chan struct{}
+	quitCh chan struct{}
+
+	enabled bool
+}
+
+func (w *drandWorker) Start() error {
+	if w.enabled {
+		go w.worker()
+	}
+
+	return nil
+}
+
+func (w *drandWorker) Stop() {
+	if !w.enabled {
+		close(w.quitCh)
+		return
+	}
+
+	w.txRetry.Cancel()
+
+	close(w.stopCh)
+}
+
+func (w *drandWorker) Quit() <-chan struct{} {
+	return w.quitCh
+}
+
+func (w *drandWorker) Cleanup() {
+}
+
+func (w *drandWorker) worker() {
+	defer func() {
+		close(w.quitCh)
+	}()
+
+	// Wait for consensus to be synced.
+	select {
+	case <-w.stopCh:
+		return
+	case <-w.parent.consensus.Synced():
+	}
+
+	client, err := w.newClient()
+	if err != nil {
+		w.logger.Error("not relaying drand rounds",
+			"err", err,
+		)
+		return
+	}
+
+	// Subscribe to epoch transitions.
+	epochCh, epochSub, err := w.parent.consensus.Beacon().WatchLatestEpoch(w.parent.ctx)
+	if err != nil {
+		w.logger.Error("failed to subscribe to epoch transitions",
+			"err", err,
+		)
+		return
+	}
+	defer epochSub.Close()
+
+	var (
+		epoch   beacon.EpochTime
+		pending bool
+		retryCh <-chan time.Time
+	)
+	for {
+		select {
+		case <-w.stopCh:
+			return
+		case epoch = <-epochCh:
+			w.txRetry.Cancel()
+			pending = true
+		case <-retryCh:
+		}
+		if !pending {
+			continue
+		}
+
+		// Submit the latest round as randomness for the next epoch.
+		tx, err := w.newSubmitTx(client, epoch+1)
+		if err != nil {
+			w.logger.Warn("failed to prepare drand round submission, retrying",
+				"err", err,
+				"epoch", epoch+1,
+			)
+			retryCh = time.After(drandRetryInterval)
+			continue
+		}
+
+		w.retrySubmitTx(tx, epoch)
+		pending = false
+		retryCh = nil
+	}
+}
+
+func (w *drandWorker) newClient() (*drandClient, error) {
+	params, err := w.parent.consensus.Beacon().ConsensusParameters(w.parent.ctx, consensus.HeightLatest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query beacon consensus parameters: %w", err)
+	}
+	if params.Backend != beacon.BackendExternal || params.ExternalParameters.Source != beacon.ExternalSourceDrand {
+		return nil, fmt.Errorf("beacon backend does not use drand")
+	}
+
+	var sourceParams beacon.DrandSourceParameters
+	if err = cbor.Unmarshal(params.ExternalParameters.SourceParameters, &sourceParams); err != nil {
+		return nil, fmt.Errorf("malformed drand source parameters: %w", err)
+	}
+	if !slices.Contains(sourceParams.Relays, w.parent.identity.NodeSigner.Public()) {
+		return nil, fmt.Errorf("node is not a drand relay")
+	}
+
+	return newDrandClient(w.url, sourceParams.ChainHash), nil
+}
+
+func (w *drandWorker) newSubmitTx(client *drandClient, epoch beacon.EpochTime) (*transaction.Transaction, error) {
+	ctx, cancel := context.WithTimeout(w.parent.ctx, drandRequestTimeout)
+	defer cancel()
+
+	round, drandSignature, err := client.Latest(ctx)
+	if err != nil {
+		return nil, err
+	}
+	relaySignature, err := signature.Sign(
+		w.parent.identity.NodeSigner,
+		beacon.DrandRelaySignatureContext,
+		beacon.DrandRelayMessage(client.chainHash, round, drandSignature),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign drand round: %w", err)
+	}
+
+	w.logger.Debug("relaying drand round",
+		"epoch", epoch,
+		"round", round,
+	)
+
+	submit := beacon.ExternalSubmit{
+		Epoch: epoch,
+		Round: round,
+		Proof: cbor.Marshal(&beacon.DrandProof{
+			Signature:      drandSignature,
+			RelaySignature: *relaySignature,
+		}),
+	}
+	return transaction.NewTransaction(0, nil, beacon.MethodExternalSubmit, submit), nil
+}
+
+func (w *drandWorker) retrySubmitTx(tx *transaction.Transaction, epoch beacon.EpochTime) {
+	checkFn := func(ctx context.Context) error {
+		// Query state to make sure submitting the tx is still sensible.
+		current, err := w.parent.consensus.Beacon().GetEpoch(ctx, consensus.HeightLatest)
+		if err != nil {
+			return err
+		}
+		if current != epoch {
+			return backoff.Permanent(fmt.Errorf("worker/beacon: epoch changed: %d", current))
+		}
+
+		return nil
+	}
+
+	w.txRetry.SubmitTx(w.parent.ctx, tx, checkFn)
+}
+
+func newDrand(parent *Worker) (*drandWorker, error) {
+	url := config.GlobalConfig.Beacon.Drand.URL
+
+	w := &drandWorker{
+		parent:  parent,
+		logger:  logging.GetLogger(workerName + "/drand"),
+		url:     url,
+		stopCh:  make(chan struct{}),
+		quitCh:  make(chan struct{}),
+		enabled: url != "",
+	}
+	w.txRetry = newTxRetry(w.logger, parent.consensus, parent.identity)
+
+	return w, nil
+}
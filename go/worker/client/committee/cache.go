@@ -0,0 +1,67 @@
+package committee
+
+import (
+	"github.com/oasisprotocol/oasis-core/go/common/cache/lru"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
+	"github.com/oasisprotocol/oasis-core/go/runtime/config"
+)
+
+type queryCacheKey struct {
+	round     uint64
+	method    string
+	argsHash  hash.Hash
+	component component.ID
+	hasComp   bool
+}
+
+func newQueryCacheKey(round uint64, method string, args []byte, comp *component.ID) queryCacheKey {
+	key := queryCacheKey{
+		round:    round,
+		method:   method,
+		argsHash: hash.NewFromBytes(args),
+	}
+	if comp != nil {
+		key.component = *comp
+		key.hasComp = true
+	}
+	return key
+}
+
+// queryCache is a cache of runtime query responses.
+//
+// Queries against the same round with identical arguments are expected to produce identical
+// responses, so responses can be reused until a new round is finalized.
+type queryCache struct {
+	cache           *lru.Cache
+	maxResponseSize uint64
+}
+
+func newQueryCache(cfg config.QueryCacheConfig) *queryCache {
+	if cfg.MaxEntries == 0 {
+		return nil
+	}
+	return &queryCache{
+		cache:           lru.New(lru.Capacity(cfg.MaxEntries, false)),
+		maxResponseSize: cfg.MaxResponseSize,
+	}
+}
+
+func (qc *queryCache) get(key queryCacheKey) ([]byte, bool) {
+	v, ok := qc.cache.Get(key)
+	if !ok {
+		return nil, false
+	}
+	return v.([]byte), true
+}
+
+func (qc *queryCache) put(key queryCacheKey, rsp []byte) {
+	if qc.maxResponseSize > 0 && uint64(len(rsp)) > qc.maxResponseSize {
+		return
+	}
+	_ = qc.cache.Put(key, rsp)
+}
+
+func (qc *queryCache) clear() {
+	qc.cache.Clear()
+}
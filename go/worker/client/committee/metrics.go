@@ -0,0 +1,44 @@
+package committee
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	queryCacheHits = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_worker_client_query_cache_hits",
+			Help: "Number of runtime queries served from the query cache.",
+		},
+		[]string{"runtime"},
+	)
+
+	queryCacheMisses = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_worker_client_query_cache_misses",
+			Help: "Number of cacheable runtime queries not found in the query cache.",
+		},
+		[]string{"runtime"},
+	)
+
+	clientCollectors = []prometheus.Collector{
+		queryCacheHits,
+		queryCacheMisses,
+	}
+
+	prometheusOnce sync.Once
+)
+
+func (n *Node) getMetricLabels() prometheus.Labels {
+	return prometheus.Labels{
+		"runtime": n.commonNode.Runtime.ID().String(),
+	}
+}
+
+func initMetrics() {
+	prometheusOnce.Do(func() {
+		prometheus.MustRegister(clientCollectors...)
+	})
+}
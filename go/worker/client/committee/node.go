@@ -9,9 +9,14 @@ import (
 	"github.com/cenkalti/backoff/v4"
 	"github.com/eapache/channels"
 
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
 	cmnBackoff "github.com/oasisprotocol/oasis-core/go/common/backoff"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/config"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	runtime "github.com/oasisprotocol/oasis-core/go/runtime/api"
 	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
@@ -23,6 +28,10 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/worker/common/committee"
 )
 
+// finalityEstimateRounds is the number of recent runtime rounds used to determine the round
+// cadence when estimating time-to-finality.
+const finalityEstimateRounds = 10
+
 type pendingTx struct {
 	chs map[chan *api.SubmitTxResult]struct{}
 }
@@ -44,6 +53,8 @@ type Node struct {
 
 	txCh *channels.InfiniteChannel
 
+	queryCache *queryCache
+
 	logger *logging.Logger
 }
 
@@ -85,7 +96,10 @@ func (n *Node) HandleNewBlockEarlyLocked(*runtime.BlockInfo) {
 
 // HandleNewBlockLocked is guarded by CrossNode.
 func (n *Node) HandleNewBlockLocked(*runtime.BlockInfo) {
-	// Nothing to do here.
+	// Cached query responses are only kept for the duration of a round.
+	if n.queryCache != nil {
+		n.queryCache.clear()
+	}
 }
 
 // HandleRuntimeHostEventLocked is guarded by CrossNode.
@@ -107,6 +121,15 @@ func (sr *SubmitTxSubscription) Result() <-chan *api.SubmitTxResult {
 	return sr.ch
 }
 
+// EstimateFinality estimates when the submitted transaction is likely to be finalized.
+//
+// It should be called before waiting for the result as the estimate assumes that the transaction
+// is still queued in the transaction pool.
+func (sr *SubmitTxSubscription) EstimateFinality(ctx context.Context) (*roothash.FinalityEstimate, error) {
+	// Exclude the submitted transaction from the queue depth.
+	return sr.n.estimateFinality(ctx, 1)
+}
+
 // Stop notifies the client to stop watching for the transaction submission result.
 func (sr *SubmitTxSubscription) Stop() {
 	sr.n.txCh.In() <- &wantTx{
@@ -156,11 +179,116 @@ func (n *Node) SubmitTx(ctx context.Context, tx []byte) (*SubmitTxSubscription,
 	return sub, nil, nil
 }
 
+// EstimateFinality estimates when a runtime transaction that is submitted now is likely to be
+// finalized.
+func (n *Node) EstimateFinality(ctx context.Context) (*roothash.FinalityEstimate, error) {
+	return n.estimateFinality(ctx, 0)
+}
+
+func (n *Node) estimateFinality(ctx context.Context, submitted int) (*roothash.FinalityEstimate, error) {
+	n.commonNode.CrossNode.Lock()
+	dsc := n.commonNode.CurrentDescriptor
+	blk := n.commonNode.CurrentBlock
+	n.commonNode.CrossNode.Unlock()
+
+	if dsc == nil || blk == nil {
+		return nil, api.ErrNoHostedRuntime
+	}
+
+	state, err := n.commonNode.Consensus.RootHash().GetRuntimeState(ctx, &roothash.RuntimeRequest{
+		RuntimeID: dsc.ID,
+		Height:    consensus.HeightLatest,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("client: failed to get runtime state: %w", err)
+	}
+
+	// Determine the round cadence from recent blocks in local history.
+	var blocks []*block.Block
+	start := blk.Header.Round - min(blk.Header.Round, finalityEstimateRounds)
+	for round := start; round < blk.Header.Round; round++ {
+		b, err := n.commonNode.Runtime.History().GetBlock(ctx, round)
+		if err != nil {
+			// Older blocks may have been pruned.
+			continue
+		}
+		blocks = append(blocks, b)
+	}
+	blocks = append(blocks, blk)
+
+	queueDepth := len(n.commonNode.TxPool.GetTxs()) - submitted
+	if queueDepth < 0 {
+		queueDepth = 0
+	}
+
+	return roothash.EstimateFinality(time.Now(), &roothash.FinalityEstimateParams{
+		LatestBlock:       blk,
+		RoundInterval:     roothash.RoundInterval(blocks),
+		QueueDepth:        uint64(queueDepth),
+		MaxBatchSize:      dsc.TxnScheduler.MaxBatchSize,
+		BatchFlushTimeout: dsc.TxnScheduler.BatchFlushTimeout,
+		Suspended:         state.Suspended,
+	})
+}
+
 func (n *Node) CheckTx(ctx context.Context, tx []byte) (*protocol.CheckTxResult, error) {
 	return n.commonNode.TxPool.SubmitTx(ctx, tx, &txpool.TransactionMeta{Local: true, Discard: true})
 }
 
 func (n *Node) Query(ctx context.Context, round uint64, method string, args []byte, comp *component.ID) ([]byte, error) {
+	qc, err := n.prepareQuery(ctx, round, comp)
+	if err != nil {
+		return nil, err
+	}
+	return n.query(ctx, qc, method, args)
+}
+
+// BatchQuery executes multiple queries against the same runtime instance and round.
+//
+// Failures of individual queries are reported in the corresponding results.
+func (n *Node) BatchQuery(ctx context.Context, round uint64, queries []*api.BatchQueryItem, comp *component.ID) (*api.BatchQueryResponse, error) {
+	qc, err := n.prepareQuery(ctx, round, comp)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := &api.BatchQueryResponse{
+		Round:   qc.annBlk.Block.Header.Round,
+		Results: make([]*api.BatchQueryResult, 0, len(queries)),
+	}
+	for _, q := range queries {
+		data, err := n.query(ctx, qc, q.Method, q.Args)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+
+			module, code := errors.Code(err)
+			rsp.Results = append(rsp.Results, &api.BatchQueryResult{
+				Error: &protocol.Error{
+					Module:  module,
+					Code:    code,
+					Message: err.Error(),
+				},
+			})
+			continue
+		}
+		rsp.Results = append(rsp.Results, &api.BatchQueryResult{Data: data})
+	}
+	return rsp, nil
+}
+
+// queryContext is the context shared by queries made against the same round.
+type queryContext struct {
+	hrt         host.RichRuntime
+	annBlk      *roothash.AnnotatedBlock
+	lb          *consensus.LightBlock
+	epoch       beacon.EpochTime
+	maxMessages uint32
+	comp        *component.ID
+}
+
+func (n *Node) prepareQuery(ctx context.Context, round uint64, comp *component.ID) (*queryContext, error) {
 	hrt := n.commonNode.GetHostedRuntime()
 	if hrt == nil {
 		return nil, api.ErrNoHostedRuntime
@@ -175,7 +303,6 @@ func (n *Node) Query(ctx context.Context, round uint64, method string, args []by
 	if dsc == nil || blk == nil {
 		return nil, api.ErrNoHostedRuntime
 	}
-	maxMessages := dsc.Executor.MaxMessages
 
 	annBlk, err := n.commonNode.Runtime.History().GetAnnotatedBlock(ctx, round)
 	if err != nil {
@@ -208,7 +335,35 @@ func (n *Node) Query(ctx context.Context, round uint64, method string, args []by
 		hrt = host.NewRichRuntime(rt)
 	}
 
-	return hrt.Query(ctx, annBlk.Block, lb, epoch, maxMessages, method, args)
+	return &queryContext{
+		hrt:         hrt,
+		annBlk:      annBlk,
+		lb:          lb,
+		epoch:       epoch,
+		maxMessages: dsc.Executor.MaxMessages,
+		comp:        comp,
+	}, nil
+}
+
+func (n *Node) query(ctx context.Context, qc *queryContext, method string, args []byte) ([]byte, error) {
+	var cacheKey queryCacheKey
+	if n.queryCache != nil {
+		cacheKey = newQueryCacheKey(qc.annBlk.Block.Header.Round, method, args, qc.comp)
+		if rsp, ok := n.queryCache.get(cacheKey); ok {
+			queryCacheHits.With(n.getMetricLabels()).Inc()
+			return rsp, nil
+		}
+		queryCacheMisses.With(n.getMetricLabels()).Inc()
+	}
+
+	rsp, err := qc.hrt.Query(ctx, qc.annBlk.Block, qc.lb, qc.epoch, qc.maxMessages, method, args)
+	if err != nil {
+		return nil, err
+	}
+	if n.queryCache != nil {
+		n.queryCache.put(cacheKey, rsp)
+	}
+	return rsp, nil
 }
 
 func (n *Node) checkBlock(ctx context.Context, blk *block.Block, pending map[hash.Hash]*pendingTx) error {
@@ -367,12 +522,15 @@ func (n *Node) worker() {
 
 // NewNode creates a new client node.
 func NewNode(commonNode *committee.Node) (*Node, error) {
+	initMetrics()
+
 	n := &Node{
 		commonNode: commonNode,
 		stopCh:     make(chan struct{}),
 		quitCh:     make(chan struct{}),
 		initCh:     make(chan struct{}),
 		txCh:       channels.NewInfiniteChannel(),
+		queryCache: newQueryCache(config.GlobalConfig.Runtime.GetQueryCache(commonNode.Runtime.ID())),
 		logger:     logging.GetLogger("worker/client/committee").With("runtime_id", commonNode.Runtime.ID()),
 	}
 	return n, nil
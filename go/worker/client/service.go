@@ -13,6 +13,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	"github.com/oasisprotocol/oasis-core/go/runtime/client/api"
 	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+	runtimeRegistry "github.com/oasisprotocol/oasis-core/go/runtime/registry"
 	"github.com/oasisprotocol/oasis-core/go/runtime/transaction"
 	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
 	"github.com/oasisprotocol/oasis-core/go/worker/client/committee"
@@ -33,7 +34,7 @@ func (s *service) submitTx(ctx context.Context, request *api.SubmitTxRequest) (*
 
 // Implements api.RuntimeClient.
 func (s *service) SubmitTx(ctx context.Context, request *api.SubmitTxRequest) ([]byte, error) {
-	resp, err := s.SubmitTxMeta(ctx, request)
+	resp, err := s.submitTxMeta(ctx, request, false)
 	if err != nil {
 		return nil, err
 	}
@@ -45,6 +46,10 @@ func (s *service) SubmitTx(ctx context.Context, request *api.SubmitTxRequest) ([
 
 // Implements api.RuntimeClient.
 func (s *service) SubmitTxMeta(ctx context.Context, request *api.SubmitTxRequest) (*api.SubmitTxMetaResponse, error) {
+	return s.submitTxMeta(ctx, request, true)
+}
+
+func (s *service) submitTxMeta(ctx context.Context, request *api.SubmitTxRequest, estimate bool) (*api.SubmitTxMetaResponse, error) {
 	sub, checkTxErr, err := s.submitTx(ctx, request)
 	if err != nil {
 		return nil, err
@@ -56,6 +61,18 @@ func (s *service) SubmitTxMeta(ctx context.Context, request *api.SubmitTxRequest
 	}
 	defer sub.Stop() // Ensure subscription is stopped.
 
+	// Estimate finality while the transaction is still queued. The estimate is best-effort so
+	// a failure to estimate is not an error.
+	var finality *roothash.FinalityEstimate
+	if estimate {
+		if finality, err = sub.EstimateFinality(ctx); err != nil {
+			s.w.logger.Debug("failed to estimate transaction finality",
+				"err", err,
+				"runtime_id", request.RuntimeID,
+			)
+		}
+	}
+
 	// Wait for result.
 	for {
 		var resp *api.SubmitTxResult
@@ -69,6 +86,9 @@ func (s *service) SubmitTxMeta(ctx context.Context, request *api.SubmitTxRequest
 			if !ok {
 				return nil, fmt.Errorf("client: channel closed unexpectedly")
 			}
+			if resp.Result != nil {
+				resp.Result.FinalityEstimate = finality
+			}
 			return resp.Result, resp.Error
 		}
 	}
@@ -114,6 +134,207 @@ func (s *service) WatchBlocks(_ context.Context, runtimeID common.Namespace) (<-
 	return rt.History().WatchBlocks()
 }
 
+// Implements api.RuntimeClient.
+func (s *service) WatchEvents(ctx context.Context, request *api.WatchEventsRequest) (<-chan *api.WatchedEvent, pubsub.ClosableSubscription, error) {
+	if err := request.SanityCheck(); err != nil {
+		return nil, nil, err
+	}
+	rt, err := s.w.commonWorker.RuntimeRegistry.GetRuntime(request.RuntimeID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Subscribe to new blocks before catching up so that no blocks are missed.
+	blkCh, blkSub, err := rt.History().WatchBlocks()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	next, catchUp := request.StartCursor()
+	if catchUp {
+		earliestBlk, err := s.GetLastRetainedBlock(ctx, request.RuntimeID)
+		if err != nil {
+			blkSub.Close()
+			return nil, nil, err
+		}
+		if next.Round < earliestBlk.Header.Round {
+			blkSub.Close()
+			return nil, nil, errors.WithContext(api.ErrNotFound, fmt.Sprintf(
+				"round %d is not available (last retained round: %d)", next.Round, earliestBlk.Header.Round,
+			))
+		}
+	}
+
+	ctx, sub := pubsub.NewContextSubscription(ctx)
+	ch := make(chan *api.WatchedEvent)
+
+	// sendError sends the error that terminated the subscription to the caller.
+	sendError := func(err error) {
+		select {
+		case ch <- &api.WatchedEvent{Error: err}:
+		case <-ctx.Done():
+		}
+	}
+
+	// sendEvents sends all matching events starting with the next cursor up to and including the
+	// given round.
+	sendEvents := func(round uint64) bool {
+		for ; next.Round <= round; next = (api.EventCursor{Round: next.Round + 1}) {
+			blk, err := rt.History().GetBlock(ctx, next.Round)
+			if err != nil {
+				s.w.logger.Error("failed to get block",
+					"err", err,
+					"runtime_id", request.RuntimeID,
+					"round", next.Round,
+				)
+				sendError(fmt.Errorf("failed to get block for round %d: %w", next.Round, err))
+				return false
+			}
+			events, err := s.getEvents(ctx, rt, blk)
+			if err != nil {
+				s.w.logger.Error("failed to get events",
+					"err", err,
+					"runtime_id", request.RuntimeID,
+					"round", next.Round,
+				)
+				sendError(fmt.Errorf("failed to get events for round %d: %w", next.Round, err))
+				return false
+			}
+
+			for ; int(next.Index) < len(events); next.Index++ {
+				ev := events[next.Index]
+				if !request.Matches(ev) {
+					continue
+				}
+
+				select {
+				case ch <- &api.WatchedEvent{Cursor: next, Event: ev}:
+				case <-ctx.Done():
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	go func() {
+		defer close(ch)
+		defer blkSub.Close()
+
+		// Catch up with the already finalized blocks, if requested.
+		if catchUp {
+			latestBlk, err := rt.History().GetBlock(ctx, api.RoundLatest)
+			if err != nil {
+				s.w.logger.Error("failed to get latest block",
+					"err", err,
+					"runtime_id", request.RuntimeID,
+				)
+				sendError(fmt.Errorf("failed to get latest block: %w", err))
+				return
+			}
+			if !sendEvents(latestBlk.Header.Round) {
+				return
+			}
+		}
+
+		for {
+			select {
+			case annBlk, ok := <-blkCh:
+				if !ok {
+					return
+				}
+				round := annBlk.Block.Header.Round
+				if !catchUp {
+					next, catchUp = api.EventCursor{Round: round}, true
+				}
+				if !sendEvents(round) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, sub, nil
+}
+
+// Implements api.RuntimeClient.
+func (s *service) ReplayBlocks(ctx context.Context, request *api.ReplayBlocksRequest) (<-chan *api.ReplayedBlock, pubsub.ClosableSubscription, error) {
+	rt, err := s.w.commonWorker.RuntimeRegistry.GetRuntime(request.RuntimeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if request.FromRound > request.ToRound {
+		return nil, nil, errors.WithContext(api.ErrInvalidArgument, "from round must not be greater than to round")
+	}
+
+	// Make sure that the requested range is available.
+	earliestBlk, err := s.GetLastRetainedBlock(ctx, request.RuntimeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	latestBlk, err := rt.History().GetBlock(ctx, api.RoundLatest)
+	if err != nil {
+		return nil, nil, err
+	}
+	if request.FromRound < earliestBlk.Header.Round || request.ToRound > latestBlk.Header.Round {
+		return nil, nil, errors.WithContext(api.ErrNotFound, fmt.Sprintf(
+			"requested rounds not available (available: %d-%d)", earliestBlk.Header.Round, latestBlk.Header.Round,
+		))
+	}
+
+	ctx, sub := pubsub.NewContextSubscription(ctx)
+	ch := make(chan *api.ReplayedBlock)
+	go func() {
+		defer close(ch)
+
+		for round := request.FromRound; round <= request.ToRound; round++ {
+			blk, err := s.replayBlock(ctx, rt, round, request.IncludeEvents)
+			if err != nil {
+				s.w.logger.Error("failed to replay block",
+					"err", err,
+					"runtime_id", request.RuntimeID,
+					"round", round,
+				)
+				blk = &api.ReplayedBlock{
+					Error: fmt.Errorf("failed to replay round %d: %w", round, err),
+				}
+			}
+
+			select {
+			case ch <- blk:
+			case <-ctx.Done():
+				return
+			}
+			if blk.Error != nil {
+				return
+			}
+		}
+	}()
+
+	return ch, sub, nil
+}
+
+func (s *service) replayBlock(ctx context.Context, rt runtimeRegistry.Runtime, round uint64, includeEvents bool) (*api.ReplayedBlock, error) {
+	annBlk, err := rt.History().GetAnnotatedBlock(ctx, round)
+	if err != nil {
+		return nil, err
+	}
+	if !includeEvents {
+		return &api.ReplayedBlock{Block: annBlk}, nil
+	}
+
+	events, err := s.getEvents(ctx, rt, annBlk.Block)
+	if err != nil {
+		return nil, err
+	}
+	return &api.ReplayedBlock{
+		Block:  annBlk,
+		Events: events,
+	}, nil
+}
+
 // Implements api.RuntimeClient.
 func (s *service) GetGenesisBlock(ctx context.Context, runtimeID common.Namespace) (*block.Block, error) {
 	return s.w.commonWorker.Consensus.RootHash().GetGenesisBlock(ctx, &roothash.RuntimeRequest{
@@ -241,6 +462,38 @@ func (s *service) GetTransactionsWithResults(ctx context.Context, request *api.G
 	return results, nil
 }
 
+// Implements api.RuntimeClient.
+func (s *service) GetTransactionReceipt(ctx context.Context, request *api.GetTransactionReceiptRequest) (*api.TransactionReceipt, error) {
+	rt, err := s.w.commonWorker.RuntimeRegistry.GetRuntime(request.RuntimeID)
+	if err != nil {
+		return nil, err
+	}
+
+	blk, err := s.GetBlock(ctx, &api.GetBlockRequest{RuntimeID: request.RuntimeID, Round: request.Round})
+	if err != nil {
+		return nil, err
+	}
+
+	tree := s.getTxnTree(rt.Storage(), blk)
+	defer tree.Close()
+
+	tx, proof, err := tree.GetTransactionWithProof(ctx, request.TxHash)
+	switch err {
+	case nil:
+	case transaction.ErrNotFound:
+		return nil, api.ErrNotFound
+	default:
+		return nil, err
+	}
+
+	return &api.TransactionReceipt{
+		Round:      blk.Header.Round,
+		BatchOrder: tx.BatchOrder,
+		Result:     tx.Output,
+		Proof:      *proof,
+	}, nil
+}
+
 // Implements api.RuntimeClient.
 func (s *service) GetUnconfirmedTransactions(_ context.Context, runtimeID common.Namespace) ([][]byte, error) {
 	rt := s.w.commonWorker.GetRuntime(runtimeID)
@@ -259,6 +512,15 @@ func (s *service) GetUnconfirmedTransactions(_ context.Context, runtimeID common
 	return out, nil
 }
 
+// Implements api.RuntimeClient.
+func (s *service) EstimateFinality(ctx context.Context, runtimeID common.Namespace) (*roothash.FinalityEstimate, error) {
+	rt := s.w.runtimes[runtimeID]
+	if rt == nil {
+		return nil, api.ErrNoHostedRuntime
+	}
+	return rt.EstimateFinality(ctx)
+}
+
 // Implements api.RuntimeClient.
 func (s *service) GetEvents(ctx context.Context, request *api.GetEventsRequest) ([]*api.Event, error) {
 	rt, err := s.w.commonWorker.RuntimeRegistry.GetRuntime(request.RuntimeID)
@@ -271,6 +533,10 @@ func (s *service) GetEvents(ctx context.Context, request *api.GetEventsRequest)
 		return nil, err
 	}
 
+	return s.getEvents(ctx, rt, blk)
+}
+
+func (s *service) getEvents(ctx context.Context, rt runtimeRegistry.Runtime, blk *block.Block) ([]*api.Event, error) {
 	tree := s.getTxnTree(rt.Storage(), blk)
 	defer tree.Close()
 
@@ -303,3 +569,22 @@ func (s *service) Query(ctx context.Context, request *api.QueryRequest) (*api.Qu
 	}
 	return &api.QueryResponse{Data: data}, nil
 }
+
+// Implements api.RuntimeClient.
+func (s *service) BatchQuery(ctx context.Context, request *api.BatchQueryRequest) (*api.BatchQueryResponse, error) {
+	switch n := len(request.Queries); {
+	case n == 0:
+		return nil, errors.WithContext(api.ErrInvalidArgument, "no queries")
+	case n > api.MaxBatchQuerySize:
+		return nil, errors.WithContext(api.ErrInvalidArgument, fmt.Sprintf(
+			"too many queries (max: %d)", api.MaxBatchQuerySize,
+		))
+	}
+
+	rt := s.w.runtimes[request.RuntimeID]
+	if rt == nil {
+		return nil, api.ErrNoHostedRuntime
+	}
+
+	return rt.BatchQuery(ctx, request.Round, request.Queries, request.Component)
+}
@@ -8,28 +8,59 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/p2p/rpc"
 	storage "github.com/oasisprotocol/oasis-core/go/storage/api"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
 	storagePub "github.com/oasisprotocol/oasis-core/go/worker/storage/p2p/pub"
 )
 
-type statelessStorage struct {
+// rpcReadSyncer is a read syncer that uses the storagepub protocol.
+type rpcReadSyncer struct {
 	rpc storagePub.Client
 }
 
-func (s *statelessStorage) SyncGet(ctx context.Context, request *storage.GetRequest) (*storage.ProofResponse, error) {
+func (s *rpcReadSyncer) SyncGet(ctx context.Context, request *storage.GetRequest) (*storage.ProofResponse, error) {
 	rsp, _, err := s.rpc.Get(ctx, request)
 	return rsp, err
 }
 
-func (s *statelessStorage) SyncGetPrefixes(ctx context.Context, request *storage.GetPrefixesRequest) (*storage.ProofResponse, error) {
+func (s *rpcReadSyncer) SyncGetPrefixes(ctx context.Context, request *storage.GetPrefixesRequest) (*storage.ProofResponse, error) {
 	rsp, _, err := s.rpc.GetPrefixes(ctx, request)
 	return rsp, err
 }
 
-func (s *statelessStorage) SyncIterate(ctx context.Context, request *storage.IterateRequest) (*storage.ProofResponse, error) {
+func (s *rpcReadSyncer) SyncIterate(ctx context.Context, request *storage.IterateRequest) (*storage.ProofResponse, error) {
 	rsp, _, err := s.rpc.Iterate(ctx, request)
 	return rsp, err
 }
 
+type statelessStorage struct {
+	// paging requests proofs in pages from peers that support it, to avoid exceeding message size
+	// limits for wide subtrees.
+	paging       syncer.ReadSyncer
+	pagingClient storagePub.Client
+
+	// legacy requests unbounded proofs from peers that do not support paging.
+	legacy syncer.ReadSyncer
+}
+
+func (s *statelessStorage) readSyncer() syncer.ReadSyncer {
+	if s.pagingClient.HasPeers() {
+		return s.paging
+	}
+	return s.legacy
+}
+
+func (s *statelessStorage) SyncGet(ctx context.Context, request *storage.GetRequest) (*storage.ProofResponse, error) {
+	return s.readSyncer().SyncGet(ctx, request)
+}
+
+func (s *statelessStorage) SyncGetPrefixes(ctx context.Context, request *storage.GetPrefixesRequest) (*storage.ProofResponse, error) {
+	return s.readSyncer().SyncGetPrefixes(ctx, request)
+}
+
+func (s *statelessStorage) SyncIterate(ctx context.Context, request *storage.IterateRequest) (*storage.ProofResponse, error) {
+	return s.readSyncer().SyncIterate(ctx, request)
+}
+
 func (s *statelessStorage) GetDiff(context.Context, *storage.GetDiffRequest) (storage.WriteLogIterator, error) {
 	return nil, storage.ErrUnsupported
 }
@@ -54,7 +85,10 @@ func (s *statelessStorage) Initialized() <-chan struct{} {
 // NewStatelessStorage creates a stateless storage backend that uses the P2P transport and the
 // storagepub protocol to query storage state.
 func NewStatelessStorage(p2p rpc.P2P, chainContext string, runtimeID common.Namespace) storage.Backend {
+	pagingClient := storagePub.NewPagingClient(p2p, chainContext, runtimeID)
 	return &statelessStorage{
-		rpc: storagePub.NewClient(p2p, chainContext, runtimeID),
+		paging:       syncer.NewPagingReadSyncer(&rpcReadSyncer{pagingClient}, syncer.DefaultProofSizeLimit),
+		pagingClient: pagingClient,
+		legacy:       &rpcReadSyncer{storagePub.NewClient(p2p, chainContext, runtimeID)},
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/oasisprotocol/oasis-core/go/common/version"
+	runtime "github.com/oasisprotocol/oasis-core/go/runtime/api"
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
 )
 
@@ -27,6 +28,8 @@ const (
 	StatusStateWaitingWorkersInit StatusState = 6
 	// StatusStateRuntimeSuspended is the runtime suspended status state.
 	StatusStateRuntimeSuspended StatusState = 7
+	// StatusStateRuntimePaused is the runtime paused by the operator status state.
+	StatusStateRuntimePaused StatusState = 8
 )
 
 // String returns a string representation of a status state.
@@ -48,6 +51,8 @@ func (s StatusState) String() string {
 		return "waiting for workers to initialize"
 	case StatusStateRuntimeSuspended:
 		return "runtime suspended"
+	case StatusStateRuntimePaused:
+		return "runtime paused"
 	default:
 		return "[invalid status state]"
 	}
@@ -72,6 +77,8 @@ func (s StatusState) MarshalText() ([]byte, error) {
 		return []byte(StatusStateWaitingWorkersInit.String()), nil
 	case StatusStateRuntimeSuspended:
 		return []byte(StatusStateRuntimeSuspended.String()), nil
+	case StatusStateRuntimePaused:
+		return []byte(StatusStateRuntimePaused.String()), nil
 	default:
 		return nil, fmt.Errorf("invalid StatusState: %d", s)
 	}
@@ -96,6 +103,8 @@ func (s *StatusState) UnmarshalText(text []byte) error {
 		*s = StatusStateWaitingWorkersInit
 	case StatusStateRuntimeSuspended.String():
 		*s = StatusStateRuntimeSuspended
+	case StatusStateRuntimePaused.String():
+		*s = StatusStateRuntimePaused
 	default:
 		return fmt.Errorf("invalid StatusState: %s", string(text))
 	}
@@ -134,6 +143,10 @@ type Status struct {
 type HostStatus struct {
 	// Versions are the locally supported versions.
 	Versions []version.Version `json:"versions"`
+
+	// Calls are the rolling latency statistics of calls into the hosted runtime, keyed by call
+	// type (e.g. RuntimeExecuteTxBatchRequest).
+	Calls map[string]runtime.CallLatencyStats `json:"calls,omitempty"`
 }
 
 // LivenessStatus is the liveness status for the current epoch.
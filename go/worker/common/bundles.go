@@ -0,0 +1,167 @@
+package common
+
+import (
+	"context"
+	"os"
+	"slices"
+	"time"
+
+	cmnBackoff "github.com/oasisprotocol/oasis-core/go/common/backoff"
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/config"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	cmdFlags "github.com/oasisprotocol/oasis-core/go/oasis-node/cmd/common/flags"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle"
+	runtimeRegistry "github.com/oasisprotocol/oasis-core/go/runtime/registry"
+	"github.com/oasisprotocol/oasis-core/go/worker/common/p2p/bundlesync"
+)
+
+// maxBundleFetchRetryInterval is the maximum interval between attempts to fetch runtime bundles.
+const maxBundleFetchRetryInterval = 10 * time.Minute
+
+// bundleFetcher fetches runtime bundles of on-chain deployments that are not available locally
+// from peers.
+type bundleFetcher struct {
+	w       *Worker
+	runtime runtimeRegistry.Runtime
+	client  bundlesync.Client
+
+	logger *logging.Logger
+}
+
+func (f *bundleFetcher) worker(ctx context.Context) {
+	ch, sub, err := f.runtime.WatchRegistryDescriptor()
+	if err != nil {
+		f.logger.Error("failed to watch registry descriptor",
+			"err", err,
+		)
+		return
+	}
+	defer sub.Close()
+
+	boff := cmnBackoff.NewExponentialBackOff()
+	boff.MaxInterval = maxBundleFetchRetryInterval
+
+	var (
+		rt      *registry.Runtime
+		retryCh <-chan time.Time
+	)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case rt = <-ch:
+			boff.Reset()
+		case <-retryCh:
+		}
+
+		retryCh = nil
+		if f.fetchDeploymentBundles(ctx, rt) {
+			continue
+		}
+
+		// Retry fetching failed bundles with backoff, unless the descriptor changes first.
+		delay := boff.NextBackOff()
+		f.logger.Debug("retrying fetching runtime bundles",
+			"delay", delay,
+		)
+		retryCh = time.After(delay)
+	}
+}
+
+// fetchDeploymentBundles fetches and loads any missing runtime bundles of current and future
+// deployments and returns true iff all of them were fetched successfully.
+func (f *bundleFetcher) fetchDeploymentBundles(ctx context.Context, rt *registry.Runtime) bool {
+	epoch, err := f.w.Consensus.Beacon().GetEpoch(ctx, consensus.HeightLatest)
+	if err != nil {
+		f.logger.Error("failed to get current epoch",
+			"err", err,
+		)
+		return false
+	}
+	active := rt.ActiveDeployment(epoch)
+	hostVersions := f.runtime.HostVersions()
+
+	done := true
+
+	for _, deployment := range rt.Deployments {
+		// Skip past deployments.
+		if active != nil && deployment.ValidFrom < active.ValidFrom {
+			continue
+		}
+		// Only deployments that declare a manifest hash can be fetched.
+		if deployment.Metadata == nil || deployment.Metadata.ManifestHash == nil {
+			continue
+		}
+		// Skip versions that are already available.
+		if slices.Contains(hostVersions, deployment.Version) {
+			continue
+		}
+		manifestHash := *deployment.Metadata.ManifestHash
+		if _, ok := f.runtime.BundlePath(manifestHash); ok {
+			continue
+		}
+		fn := bundle.FetchedBundlePath(f.w.DataDir, manifestHash)
+		if _, err = os.Stat(fn); err == nil {
+			continue
+		}
+
+		f.logger.Info("fetching runtime bundle from peers",
+			"version", deployment.Version,
+			"manifest_hash", manifestHash,
+		)
+
+		err = bundlesync.FetchBundle(ctx, f.client, manifestHash, deployment.BundleChecksum, fn,
+			bundle.WithDebugDummySigner(cmdFlags.DebugDummySigstruct()),
+		)
+		if err != nil {
+			f.logger.Error("failed to fetch runtime bundle",
+				"err", err,
+				"version", deployment.Version,
+				"manifest_hash", manifestHash,
+			)
+			done = false
+			continue
+		}
+
+		f.logger.Info("fetched runtime bundle",
+			"version", deployment.Version,
+			"manifest_hash", manifestHash,
+			"path", fn,
+		)
+
+		if err = f.runtime.LoadBundle(fn); err != nil {
+			f.logger.Error("failed to load fetched runtime bundle",
+				"err", err,
+				"version", deployment.Version,
+				"manifest_hash", manifestHash,
+				"path", fn,
+			)
+			// Remove the bundle so that it is not loaded on the next restart either.
+			_ = os.Remove(fn)
+		}
+	}
+
+	return done
+}
+
+func (w *Worker) registerBundleSync(runtime runtimeRegistry.Runtime) {
+	cfg := config.GlobalConfig.Runtime.BundleSync
+
+	if cfg.Serve {
+		if w.bundleLimiter == nil {
+			w.bundleLimiter = bundlesync.NewBandwidthLimiter(cfg.MaxServeRate)
+		}
+		w.P2P.RegisterProtocolServer(bundlesync.NewServer(w.ChainContext, runtime.ID(), runtime, w.bundleLimiter))
+	}
+
+	if cfg.Fetch {
+		w.bundleFetchers = append(w.bundleFetchers, &bundleFetcher{
+			w:       w,
+			runtime: runtime,
+			client:  bundlesync.NewClient(w.P2P, w.ChainContext, runtime.ID()),
+			logger:  w.logger.With("runtime_id", runtime.ID()),
+		})
+	}
+}
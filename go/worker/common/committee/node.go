@@ -188,6 +188,10 @@ type Node struct {
 	CurrentDescriptor     *registry.Runtime
 	CurrentEpoch          beacon.EpochTime
 
+	// paused is a flag indicating whether hosting of the runtime has been paused by the operator.
+	// Guarded by .CrossNode.
+	paused bool
+
 	logger *logging.Logger
 }
 
@@ -210,6 +214,9 @@ func (n *Node) getStatusStateLocked() api.StatusState {
 	if atomic.LoadUint32(&n.hostedRuntimeProvisioned) == 0 {
 		return api.StatusStateWaitingHostedRuntime
 	}
+	if n.paused {
+		return api.StatusStateRuntimePaused
+	}
 	// If resumeCh exists the runtime is suspended (safe to check since the cross node lock should be held).
 	if n.resumeCh != nil {
 		return api.StatusStateRuntimeSuspended
@@ -321,6 +328,7 @@ func (n *Node) GetStatus() (*api.Status, error) {
 	status.Peers = n.P2P.Peers(n.Runtime.ID())
 
 	status.Host.Versions = n.Runtime.HostVersions()
+	status.Host.Calls = n.GetHostedRuntimeCallStats()
 
 	return &status, nil
 }
@@ -358,6 +366,75 @@ func (n *Node) handleEpochTransitionLocked(height int64) {
 	epochNumber.With(n.getMetricLabels()).Set(float64(epoch.epochNumber))
 }
 
+// PauseRuntime pauses hosting of the runtime.
+//
+// While paused, the node keeps following runtime blocks but does not participate in any runtime
+// committees. Rounds that are already in progress are finished by the workers as committee
+// membership is only re-evaluated on the next round.
+func (n *Node) PauseRuntime() {
+	n.CrossNode.Lock()
+	defer n.CrossNode.Unlock()
+
+	if n.paused {
+		return
+	}
+
+	n.logger.Warn("pausing runtime")
+
+	n.paused = true
+	n.Group.Suspend()
+
+	n.Runtime.NotifyLifecycleEvent(&runtime.LifecycleEvent{
+		Kind: runtime.LifecycleEventPaused,
+	})
+}
+
+// ResumeRuntime resumes hosting of a previously paused runtime.
+func (n *Node) ResumeRuntime() {
+	n.CrossNode.Lock()
+	defer n.CrossNode.Unlock()
+
+	if !n.paused {
+		return
+	}
+
+	n.logger.Info("resuming paused runtime")
+
+	n.paused = false
+
+	// Resumption is processed as a regular epoch transition, unless the runtime is currently
+	// suspended in which case the transition will happen once it is resumed.
+	if n.CurrentBlock != nil && n.resumeCh == nil {
+		n.handleEpochTransitionLocked(n.CurrentBlockHeight)
+	}
+
+	n.Runtime.NotifyLifecycleEvent(&runtime.LifecycleEvent{
+		Kind: runtime.LifecycleEventUnpaused,
+	})
+}
+
+// RestartRuntime forcibly restarts the hosted runtime.
+//
+// Any in-flight requests to the runtime are aborted and the workers handle them as failed.
+func (n *Node) RestartRuntime(ctx context.Context) error {
+	rt := n.GetHostedRuntime()
+	if rt == nil {
+		return fmt.Errorf("hosted runtime not provisioned")
+	}
+
+	n.logger.Warn("restarting hosted runtime")
+
+	if err := rt.Abort(ctx, true); err != nil {
+		return fmt.Errorf("failed to restart hosted runtime: %w", err)
+	}
+
+	n.Runtime.NotifyLifecycleEvent(&runtime.LifecycleEvent{
+		Kind: runtime.LifecycleEventRestarted,
+	})
+
+	return nil
+}
+
 // Guarded by n.CrossNode.
 func (n *Node) handleSuspendLocked(int64) {
 	n.logger.Warn("runtime has been suspended")
@@ -369,6 +446,10 @@ func (n *Node) handleSuspendLocked(int64) {
 	// descriptor instead of the active one as otherwise we may miss deployment updates and never
 	// register, keeping the runtime suspended.
 	if n.resumeCh == nil {
+		n.Runtime.NotifyLifecycleEvent(&runtime.LifecycleEvent{
+			Kind: runtime.LifecycleEventSuspended,
+		})
+
 		resumeCh := make(chan struct{})
 		n.resumeCh = resumeCh
 		rt := n.CurrentDescriptor
@@ -509,6 +590,10 @@ func (n *Node) handleNewBlockLocked(blk *block.Block, height int64) {
 		if !rs.Suspended && n.resumeCh != nil {
 			close(n.resumeCh)
 			n.resumeCh = nil
+
+			n.Runtime.NotifyLifecycleEvent(&runtime.LifecycleEvent{
+				Kind: runtime.LifecycleEventResumed,
+			})
 		}
 
 		n.updateHostedRuntimeVersionLocked()
@@ -531,6 +616,10 @@ func (n *Node) handleNewBlockLocked(blk *block.Block, height int64) {
 	// Perform actions based on block type.
 	switch header.HeaderType {
 	case block.Normal:
+		if n.paused {
+			// Committee transitions are skipped while paused and processed on resume.
+			break
+		}
 		if firstBlockReceived {
 			n.logger.Warn("forcing an epoch transition on first received block")
 			n.handleEpochTransitionLocked(height)
@@ -539,6 +628,9 @@ func (n *Node) handleNewBlockLocked(blk *block.Block, height int64) {
 			n.Group.RoundTransition()
 		}
 	case block.RoundFailed:
+		if n.paused {
+			break
+		}
 		if firstBlockReceived {
 			n.logger.Warn("forcing an epoch transition on first received block")
 			n.handleEpochTransitionLocked(height)
@@ -550,6 +642,9 @@ func (n *Node) handleNewBlockLocked(blk *block.Block, height int64) {
 			failedRoundCount.With(n.getMetricLabels()).Inc()
 		}
 	case block.EpochTransition:
+		if n.paused {
+			break
+		}
 		// Process an epoch transition.
 		n.handleEpochTransitionLocked(height)
 	case block.Suspended:
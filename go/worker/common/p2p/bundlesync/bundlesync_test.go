@@ -0,0 +1,221 @@
+package bundlesync
+
+import (
+	"context"
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/p2p/rpc"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle/component"
+)
+
+type testBundles map[hash.Hash]string
+
+func (b testBundles) BundlePath(manifestHash hash.Hash) (string, bool) {
+	path, ok := b[manifestHash]
+	return path, ok
+}
+
+type testPeerFeedback struct {
+	success atomic.Bool
+	bad     atomic.Bool
+}
+
+func (pf *testPeerFeedback) RecordSuccess() {
+	pf.success.Store(true)
+}
+
+func (pf *testPeerFeedback) RecordFailure() {
+}
+
+func (pf *testPeerFeedback) RecordBadPeer() {
+	pf.bad.Store(true)
+}
+
+func (pf *testPeerFeedback) PeerID() core.PeerID {
+	return ""
+}
+
+type testPeer struct {
+	pf      *testPeerFeedback
+	corrupt bool
+}
+
+type testClient struct {
+	srv   *service
+	peers []*testPeer
+
+	next atomic.Uint64
+}
+
+func (c *testClient) GetBundleInfo(ctx context.Context, request *GetBundleInfoRequest) (*GetBundleInfoResponse, rpc.PeerFeedback, error) {
+	rsp, err := c.srv.handleGetBundleInfo(ctx, request)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rsp, c.peers[0].pf, nil
+}
+
+func (c *testClient) GetBundleChunk(ctx context.Context, request *GetBundleChunkRequest) (*GetBundleChunkResponse, rpc.PeerFeedback, error) {
+	// Spread requests across peers, similar to the real client.
+	peer := c.peers[c.next.Add(1)%uint64(len(c.peers))]
+
+	rsp, err := c.srv.handleGetBundleChunk(ctx, request)
+	if err != nil {
+		return nil, nil, err
+	}
+	if peer.corrupt {
+		chunk := append([]byte{}, rsp.Chunk...)
+		chunk[len(chunk)-1] ^= 0xff
+		rsp = &GetBundleChunkResponse{Chunk: chunk}
+	}
+	return rsp, peer.pf, nil
+}
+
+func writeTestBundle(t *testing.T, dir string) (string, hash.Hash) {
+	require := require.New(t)
+
+	var id common.Namespace
+	err := id.UnmarshalHex("c000000000000000ffffffffffffffffffffffffffffffffffffffffffffffff")
+	require.NoError(err, "UnmarshalHex")
+
+	bnd := &bundle.Bundle{
+		Manifest: &bundle.Manifest{
+			Name: "test-runtime",
+			ID:   id,
+			Components: []*bundle.Component{
+				{
+					Kind:       component.RONL,
+					Executable: "runtime.bin",
+				},
+			},
+		},
+	}
+
+	// Use the test executable, which also makes the bundle span multiple chunks.
+	data, err := os.ReadFile(os.Args[0])
+	require.NoError(err, "ReadFile")
+	require.Greater(len(data), MaxBundleChunkSize, "bundle should span multiple chunks")
+	err = bnd.Add(bnd.Manifest.Components[0].Executable, data)
+	require.NoError(err, "Add")
+
+	fn := filepath.Join(dir, "bundle.orc")
+	err = bnd.Write(fn)
+	require.NoError(err, "Write")
+
+	return fn, bnd.Manifest.Hash()
+}
+
+func TestFetchBundle(t *testing.T) {
+	ctx := context.Background()
+
+	srcFn, manifestHash := writeTestBundle(t, t.TempDir())
+	raw, err := os.ReadFile(srcFn)
+	require.NoError(t, err, "ReadFile")
+	checksum := sha256.Sum256(raw)
+
+	srv := &service{bundles: testBundles{manifestHash: srcFn}}
+	dataDir := t.TempDir()
+
+	t.Run("Valid", func(t *testing.T) {
+		require := require.New(t)
+
+		peers := []*testPeer{{pf: &testPeerFeedback{}}, {pf: &testPeerFeedback{}}}
+		fn := bundle.FetchedBundlePath(dataDir, manifestHash)
+		err := FetchBundle(ctx, &testClient{srv: srv, peers: peers}, manifestHash, checksum[:], fn)
+		require.NoError(err, "FetchBundle")
+		for _, peer := range peers {
+			require.True(peer.pf.success.Load(), "all peers should serve chunks and be recorded as successful")
+		}
+
+		fetched, err := os.ReadFile(fn)
+		require.NoError(err, "ReadFile")
+		require.Equal(raw, fetched, "fetched bundle should match the served bundle")
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		require := require.New(t)
+
+		var unknown hash.Hash
+		unknown.FromBytes([]byte("unknown bundle"))
+		fn := bundle.FetchedBundlePath(dataDir, unknown)
+		peers := []*testPeer{{pf: &testPeerFeedback{}}}
+		err := FetchBundle(ctx, &testClient{srv: srv, peers: peers}, unknown, nil, fn)
+		require.ErrorIs(err, ErrBundleNotFound, "FetchBundle should fail for unknown bundles")
+		require.NoFileExists(fn)
+	})
+
+	t.Run("ChecksumMismatch", func(t *testing.T) {
+		require := require.New(t)
+
+		peers := []*testPeer{{pf: &testPeerFeedback{}}}
+		fn := filepath.Join(t.TempDir(), "bundle.orc")
+		badChecksum := sha256.Sum256([]byte("other bundle"))
+		err := FetchBundle(ctx, &testClient{srv: srv, peers: peers}, manifestHash, badChecksum[:], fn)
+		require.ErrorContains(err, "checksum mismatch")
+		require.True(peers[0].pf.bad.Load(), "peer should be recorded as bad")
+		require.NoFileExists(fn)
+	})
+
+	t.Run("CorruptedPeer", func(t *testing.T) {
+		require := require.New(t)
+
+		peers := []*testPeer{{pf: &testPeerFeedback{}}, {pf: &testPeerFeedback{}, corrupt: true}}
+		fn := filepath.Join(t.TempDir(), "bundle.orc")
+		err := FetchBundle(ctx, &testClient{srv: srv, peers: peers}, manifestHash, checksum[:], fn)
+		require.NoError(err, "FetchBundle should retry corrupted chunks with other peers")
+		require.False(peers[0].pf.bad.Load(), "honest peer should not be recorded as bad")
+		require.True(peers[1].pf.bad.Load(), "corrupting peer should be recorded as bad")
+
+		fetched, err := os.ReadFile(fn)
+		require.NoError(err, "ReadFile")
+		require.Equal(raw, fetched, "fetched bundle should match the served bundle")
+	})
+
+	t.Run("Corrupted", func(t *testing.T) {
+		require := require.New(t)
+
+		peers := []*testPeer{{pf: &testPeerFeedback{}, corrupt: true}}
+		fn := filepath.Join(t.TempDir(), "bundle.orc")
+		err := FetchBundle(ctx, &testClient{srv: srv, peers: peers}, manifestHash, nil, fn)
+		require.ErrorContains(err, "chunk hash mismatch", "FetchBundle should fail for corrupted bundles")
+		require.True(peers[0].pf.bad.Load(), "peer should be recorded as bad")
+		require.NoFileExists(fn)
+	})
+}
+
+func TestBandwidthLimiter(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	// No limit.
+	l := NewBandwidthLimiter(0)
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		require.NoError(l.Wait(ctx, MaxBundleChunkSize))
+	}
+	require.Less(time.Since(start), 100*time.Millisecond)
+
+	// Limited to 10 chunks per second, the first transfer is not delayed.
+	l = NewBandwidthLimiter(10 * MaxBundleChunkSize)
+	start = time.Now()
+	for i := 0; i < 4; i++ {
+		require.NoError(l.Wait(ctx, MaxBundleChunkSize))
+	}
+	require.GreaterOrEqual(time.Since(start), 300*time.Millisecond)
+
+	// Waiting should be aborted when the context is canceled.
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	require.ErrorIs(l.Wait(cctx, MaxBundleChunkSize), context.Canceled)
+}
@@ -0,0 +1,95 @@
+package bundlesync
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/p2p/protocol"
+	"github.com/oasisprotocol/oasis-core/go/p2p/rpc"
+)
+
+const (
+	// minProtocolPeers is the minimum number of peers from the registry we want to have connected
+	// for BundleSync protocol.
+	minProtocolPeers = 3
+
+	// totalProtocolPeers is the number of peers we want to have connected for BundleSync protocol.
+	totalProtocolPeers = 5
+)
+
+// Client is a runtime bundle sync protocol client.
+type Client interface {
+	// GetBundleInfo requests information about the runtime bundle with the given manifest hash.
+	GetBundleInfo(
+		ctx context.Context,
+		request *GetBundleInfoRequest,
+	) (*GetBundleInfoResponse, rpc.PeerFeedback, error)
+
+	// GetBundleChunk requests a chunk of the runtime bundle with the given manifest hash and
+	// checksum.
+	//
+	// Subsequent requests are spread across all peers.
+	GetBundleChunk(
+		ctx context.Context,
+		request *GetBundleChunkRequest,
+	) (*GetBundleChunkResponse, rpc.PeerFeedback, error)
+}
+
+type client struct {
+	rc  rpc.Client
+	mgr rpc.PeerManager
+
+	nextPeer atomic.Uint64
+}
+
+func (c *client) GetBundleInfo(
+	ctx context.Context,
+	request *GetBundleInfoRequest,
+) (*GetBundleInfoResponse, rpc.PeerFeedback, error) {
+	var rsp GetBundleInfoResponse
+	pf, err := c.rc.CallOne(ctx, c.mgr.GetBestPeers(), MethodGetBundleInfo, request, &rsp,
+		rpc.WithMaxPeerResponseTime(MaxGetBundleInfoResponseTime),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &rsp, pf, nil
+}
+
+func (c *client) GetBundleChunk(
+	ctx context.Context,
+	request *GetBundleChunkRequest,
+) (*GetBundleChunkResponse, rpc.PeerFeedback, error) {
+	// Rotate the best peers so that concurrent chunk requests are spread across peers, while
+	// still falling back to the remaining peers in case of failures.
+	peers := c.mgr.GetBestPeers()
+	if n := uint64(len(peers)); n > 1 {
+		offset := c.nextPeer.Add(1) % n
+		peers = append(peers[offset:], peers[:offset]...)
+	}
+
+	var rsp GetBundleChunkResponse
+	pf, err := c.rc.CallOne(ctx, peers, MethodGetBundleChunk, request, &rsp,
+		rpc.WithMaxPeerResponseTime(MaxGetBundleChunkResponseTime),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &rsp, pf, nil
+}
+
+// NewClient creates a new runtime bundle sync protocol client.
+func NewClient(p2p rpc.P2P, chainContext string, runtimeID common.Namespace) Client {
+	pid := protocol.NewRuntimeProtocolID(chainContext, runtimeID, BundleSyncProtocolID, BundleSyncProtocolVersion)
+	mgr := rpc.NewPeerManager(p2p, pid)
+	rc := rpc.NewClient(p2p.Host(), pid)
+	rc.RegisterListener(mgr)
+
+	p2p.RegisterProtocol(pid, minProtocolPeers, totalProtocolPeers)
+
+	return &client{
+		rc:  rc,
+		mgr: mgr,
+	}
+}
@@ -0,0 +1,198 @@
+package bundlesync
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/runtime/bundle"
+)
+
+const (
+	// maxConcurrentChunks is the maximum number of bundle chunks fetched concurrently.
+	maxConcurrentChunks = 4
+
+	// maxChunkAttempts is the maximum number of attempts to fetch a single bundle chunk.
+	maxChunkAttempts = 5
+)
+
+// FetchBundle fetches the runtime bundle with the given manifest hash from peers and writes it to
+// the given path.
+//
+// The bundle information is requested from a single peer, while the chunks are fetched from
+// all peers serving the same bundle, each verified against the chunk hashes. Before being
+// written, the fetched bundle is verified to have the requested manifest hash and, if given, to
+// match the checksum of the bundle as published on-chain.
+func FetchBundle(
+	ctx context.Context,
+	c Client,
+	manifestHash hash.Hash,
+	checksum []byte,
+	fn string,
+	opts ...bundle.OpenOption,
+) error {
+	info, infoPf, err := c.GetBundleInfo(ctx, &GetBundleInfoRequest{
+		ManifestHash: manifestHash,
+	})
+	if err != nil {
+		return fmt.Errorf("bundlesync: failed to fetch bundle info: %w", err)
+	}
+	if err = validateBundleInfo(info, checksum); err != nil {
+		infoPf.RecordBadPeer()
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(fn), 0o700); err != nil {
+		return fmt.Errorf("bundlesync: failed to create bundle directory: %w", err)
+	}
+	f, err := os.CreateTemp(filepath.Dir(fn), "fetch-*.orc.tmp")
+	if err != nil {
+		return fmt.Errorf("bundlesync: failed to create temporary file: %w", err)
+	}
+	tmpFn := f.Name()
+	defer os.Remove(tmpFn)
+
+	err = fetchBundleChunks(ctx, c, manifestHash, info, f)
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	if err = VerifyBundle(tmpFn, manifestHash, info.Checksum, opts...); err != nil {
+		// All chunks matched the chunk hashes, so the peer serving the information is at fault.
+		infoPf.RecordBadPeer()
+		return err
+	}
+	infoPf.RecordSuccess()
+
+	if err = os.Rename(tmpFn, fn); err != nil {
+		return fmt.Errorf("bundlesync: failed to move fetched bundle: %w", err)
+	}
+	return nil
+}
+
+func validateBundleInfo(info *GetBundleInfoResponse, checksum []byte) error {
+	if info.Size == 0 || info.Size > MaxBundleSize {
+		return fmt.Errorf("bundlesync: invalid bundle size: %d", info.Size)
+	}
+	if numChunks := NumChunks(info.Size); uint64(len(info.ChunkHashes)) != numChunks {
+		return fmt.Errorf("bundlesync: invalid number of chunk hashes (expected: %d got: %d)", numChunks, len(info.ChunkHashes))
+	}
+	if len(info.Checksum) != sha256.Size {
+		return fmt.Errorf("bundlesync: invalid bundle checksum size: %d", len(info.Checksum))
+	}
+	if len(checksum) > 0 && !bytes.Equal(info.Checksum, checksum) {
+		return fmt.Errorf("bundlesync: bundle checksum mismatch (expected: %x got: %x)", checksum, info.Checksum)
+	}
+	return nil
+}
+
+func fetchBundleChunks(ctx context.Context, c Client, manifestHash hash.Hash, info *GetBundleInfoResponse, w io.WriterAt) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	indexCh := make(chan uint64)
+	go func() {
+		defer close(indexCh)
+		for i := range uint64(len(info.ChunkHashes)) {
+			select {
+			case indexCh <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for range maxConcurrentChunks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for index := range indexCh {
+				chunk, err := fetchBundleChunk(ctx, c, manifestHash, info, index)
+				if err != nil {
+					cancel(err)
+					return
+				}
+				if _, err = w.WriteAt(chunk, int64(index*MaxBundleChunkSize)); err != nil {
+					cancel(fmt.Errorf("bundlesync: failed to write bundle chunk: %w", err))
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return context.Cause(ctx)
+}
+
+func fetchBundleChunk(ctx context.Context, c Client, manifestHash hash.Hash, info *GetBundleInfoResponse, index uint64) ([]byte, error) {
+	expectedSize := min(info.Size-index*MaxBundleChunkSize, MaxBundleChunkSize)
+
+	var err error
+	for range maxChunkAttempts {
+		rsp, pf, rerr := c.GetBundleChunk(ctx, &GetBundleChunkRequest{
+			ManifestHash: manifestHash,
+			Checksum:     info.Checksum,
+			Index:        index,
+		})
+		if rerr != nil {
+			err = rerr
+			if ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+
+		if uint64(len(rsp.Chunk)) != expectedSize {
+			pf.RecordBadPeer()
+			err = fmt.Errorf("invalid chunk size (expected: %d got: %d)", expectedSize, len(rsp.Chunk))
+			continue
+		}
+		if h := hash.NewFromBytes(rsp.Chunk); !h.Equal(&info.ChunkHashes[index]) {
+			pf.RecordBadPeer()
+			err = fmt.Errorf("chunk hash mismatch (expected: %s got: %s)", info.ChunkHashes[index], h)
+			continue
+		}
+		pf.RecordSuccess()
+
+		return rsp.Chunk, nil
+	}
+	return nil, fmt.Errorf("bundlesync: failed to fetch bundle chunk %d: %w", index, err)
+}
+
+// VerifyBundle verifies that the runtime bundle at the given path is well-formed, has the given
+// manifest hash and, if given, matches the SHA256 checksum of the bundle as published on-chain.
+func VerifyBundle(fn string, manifestHash hash.Hash, checksum []byte, opts ...bundle.OpenOption) error {
+	if len(checksum) > 0 {
+		f, err := os.Open(fn)
+		if err != nil {
+			return fmt.Errorf("bundlesync: failed to open bundle: %w", err)
+		}
+		defer f.Close()
+
+		h := sha256.New()
+		if _, err = io.Copy(h, f); err != nil {
+			return fmt.Errorf("bundlesync: failed to hash bundle: %w", err)
+		}
+		if sum := h.Sum(nil); !bytes.Equal(sum, checksum) {
+			return fmt.Errorf("bundlesync: bundle checksum mismatch (expected: %x got: %x)", checksum, sum)
+		}
+	}
+
+	bnd, err := bundle.Open(fn, opts...)
+	if err != nil {
+		return fmt.Errorf("bundlesync: invalid bundle: %w", err)
+	}
+	if h := bnd.Manifest.Hash(); !h.Equal(&manifestHash) {
+		return fmt.Errorf("bundlesync: manifest hash mismatch (expected: %s got: %s)", manifestHash, h)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package bundlesync
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// BandwidthLimiter limits the rate at which bundle data is transferred.
+//
+// A single limiter may be shared between multiple servers to enforce a combined limit.
+type BandwidthLimiter struct {
+	sync.Mutex
+
+	rate uint64
+	next time.Time
+}
+
+// Wait blocks until the given number of bytes may be transferred without exceeding the limit or
+// until the context is canceled.
+func (l *BandwidthLimiter) Wait(ctx context.Context, n int) error {
+	if l == nil || l.rate == 0 || n <= 0 {
+		return nil
+	}
+
+	l.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(time.Duration(uint64(n) * uint64(time.Second) / l.rate))
+	l.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// NewBandwidthLimiter creates a new bandwidth limiter allowing the given number of bytes per
+// second. Zero means no limit.
+func NewBandwidthLimiter(rate uint64) *BandwidthLimiter {
+	return &BandwidthLimiter{
+		rate: rate,
+	}
+}
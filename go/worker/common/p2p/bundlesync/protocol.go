@@ -0,0 +1,97 @@
+package bundlesync
+
+import (
+	"time"
+
+	"github.com/libp2p/go-libp2p/core"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
+	"github.com/oasisprotocol/oasis-core/go/p2p/peermgmt"
+	"github.com/oasisprotocol/oasis-core/go/p2p/protocol"
+)
+
+// BundleSyncProtocolID is a unique protocol identifier for the runtime bundle sync protocol.
+const BundleSyncProtocolID = "bundlesync"
+
+// BundleSyncProtocolVersion is the supported version of the runtime bundle sync protocol.
+var BundleSyncProtocolVersion = version.Version{Major: 1, Minor: 0, Patch: 0}
+
+// ModuleName is the bundle sync protocol module name.
+const ModuleName = "worker/common/p2p/bundlesync"
+
+// ErrBundleNotFound is the error returned when the requested bundle is not available.
+var ErrBundleNotFound = errors.New(ModuleName, 1, "bundlesync: bundle not found")
+
+// MaxBundleSize is the maximum size of a runtime bundle that can be fetched from peers.
+const MaxBundleSize = 1024 * 1024 * 1024
+
+// Constants related to the GetBundleInfo method.
+const (
+	MethodGetBundleInfo          = "GetBundleInfo"
+	MaxGetBundleInfoResponseTime = 5 * time.Second
+)
+
+// GetBundleInfoRequest is a GetBundleInfo request.
+type GetBundleInfoRequest struct {
+	ManifestHash hash.Hash `json:"manifest_hash"`
+}
+
+// GetBundleInfoResponse is a response to a GetBundleInfo request.
+type GetBundleInfoResponse struct {
+	// Size is the total size of the bundle.
+	Size uint64 `json:"size"`
+	// Checksum is the SHA256 checksum of the bundle.
+	Checksum []byte `json:"checksum"`
+	// ChunkHashes are the hashes of all bundle chunks, each of MaxBundleChunkSize bytes except
+	// for the last one.
+	ChunkHashes []hash.Hash `json:"chunk_hashes"`
+}
+
+// NumChunks returns the number of chunks of a bundle of the given size.
+func NumChunks(size uint64) uint64 {
+	return (size + MaxBundleChunkSize - 1) / MaxBundleChunkSize
+}
+
+// Constants related to the GetBundleChunk method.
+const (
+	MethodGetBundleChunk          = "GetBundleChunk"
+	MaxGetBundleChunkResponseTime = 60 * time.Second
+	MaxBundleChunkSize            = 1024 * 1024
+)
+
+// GetBundleChunkRequest is a GetBundleChunk request.
+type GetBundleChunkRequest struct {
+	ManifestHash hash.Hash `json:"manifest_hash"`
+	// Checksum is the SHA256 checksum of the bundle that the chunk is requested from. Since
+	// different bundle files may have the same manifest, this makes it possible to request the
+	// chunks of the same bundle from different peers.
+	Checksum []byte `json:"checksum"`
+	// Index is the index of the requested chunk.
+	Index uint64 `json:"index"`
+}
+
+// GetBundleChunkResponse is a response to a GetBundleChunk request.
+type GetBundleChunkResponse struct {
+	// Chunk is the requested bundle chunk.
+	Chunk []byte `json:"chunk,omitempty"`
+}
+
+func init() {
+	peermgmt.RegisterNodeHandler(&peermgmt.NodeHandlerBundle{
+		ProtocolsFn: func(n *node.Node, chainContext string) []core.ProtocolID {
+			if !n.HasRoles(node.RoleComputeWorker) {
+				return []core.ProtocolID{}
+			}
+
+			protocols := make([]core.ProtocolID, len(n.Runtimes))
+			for i, rt := range n.Runtimes {
+				protocols[i] = protocol.NewRuntimeProtocolID(chainContext, rt.ID, BundleSyncProtocolID, BundleSyncProtocolVersion)
+			}
+
+			return protocols
+		},
+	})
+}
@@ -0,0 +1,158 @@
+package bundlesync
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/p2p/protocol"
+	"github.com/oasisprotocol/oasis-core/go/p2p/rpc"
+)
+
+// BundleProvider provides access to locally loaded runtime bundles.
+type BundleProvider interface {
+	// BundlePath returns the path of the locally loaded runtime bundle with the given manifest
+	// hash if any.
+	BundlePath(manifestHash hash.Hash) (string, bool)
+}
+
+type service struct {
+	sync.Mutex
+
+	bundles BundleProvider
+	limiter *BandwidthLimiter
+
+	// infos caches the bundle information of served bundles, keyed by path.
+	infos map[string]*GetBundleInfoResponse
+}
+
+func (s *service) HandleRequest(ctx context.Context, method string, body cbor.RawMessage) (interface{}, error) {
+	switch method {
+	case MethodGetBundleInfo:
+		var rq GetBundleInfoRequest
+		if err := cbor.Unmarshal(body, &rq); err != nil {
+			return nil, rpc.ErrBadRequest
+		}
+
+		return s.handleGetBundleInfo(ctx, &rq)
+	case MethodGetBundleChunk:
+		var rq GetBundleChunkRequest
+		if err := cbor.Unmarshal(body, &rq); err != nil {
+			return nil, rpc.ErrBadRequest
+		}
+
+		return s.handleGetBundleChunk(ctx, &rq)
+	default:
+		return nil, rpc.ErrMethodNotSupported
+	}
+}
+
+// getBundleInfo returns the information about the given bundle file, computing it on first use.
+func (s *service) getBundleInfo(path string) (*GetBundleInfoResponse, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	if info, ok := s.infos[path]; ok {
+		return info, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, ErrBundleNotFound
+	}
+	defer f.Close()
+
+	var (
+		info  GetBundleInfoResponse
+		chunk = make([]byte, MaxBundleChunkSize)
+		h     = sha256.New()
+	)
+	for {
+		n, rerr := io.ReadFull(f, chunk)
+		if n > 0 {
+			_, _ = h.Write(chunk[:n])
+			info.ChunkHashes = append(info.ChunkHashes, hash.NewFromBytes(chunk[:n]))
+			info.Size += uint64(n)
+		}
+		if rerr == io.EOF || rerr == io.ErrUnexpectedEOF {
+			break
+		}
+		if rerr != nil {
+			return nil, fmt.Errorf("bundlesync: failed to read bundle: %w", rerr)
+		}
+	}
+	info.Checksum = h.Sum(nil)
+
+	if s.infos == nil {
+		s.infos = make(map[string]*GetBundleInfoResponse)
+	}
+	s.infos[path] = &info
+
+	return &info, nil
+}
+
+func (s *service) handleGetBundleInfo(_ context.Context, request *GetBundleInfoRequest) (*GetBundleInfoResponse, error) {
+	path, ok := s.bundles.BundlePath(request.ManifestHash)
+	if !ok {
+		return nil, ErrBundleNotFound
+	}
+	return s.getBundleInfo(path)
+}
+
+func (s *service) handleGetBundleChunk(ctx context.Context, request *GetBundleChunkRequest) (*GetBundleChunkResponse, error) {
+	path, ok := s.bundles.BundlePath(request.ManifestHash)
+	if !ok {
+		return nil, ErrBundleNotFound
+	}
+	info, err := s.getBundleInfo(path)
+	if err != nil {
+		return nil, err
+	}
+	// Only serve chunks of the exact bundle that has been requested.
+	if !bytes.Equal(info.Checksum, request.Checksum) {
+		return nil, ErrBundleNotFound
+	}
+	if request.Index >= uint64(len(info.ChunkHashes)) {
+		return nil, rpc.ErrBadRequest
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, ErrBundleNotFound
+	}
+	defer f.Close()
+
+	offset := request.Index * MaxBundleChunkSize
+	chunk := make([]byte, min(info.Size-offset, MaxBundleChunkSize))
+	if _, err = f.ReadAt(chunk, int64(offset)); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("bundlesync: failed to read bundle: %w", err)
+	}
+
+	if err = s.limiter.Wait(ctx, len(chunk)); err != nil {
+		return nil, err
+	}
+
+	return &GetBundleChunkResponse{
+		Chunk: chunk,
+	}, nil
+}
+
+// NewServer creates a new runtime bundle sync protocol server.
+//
+// The given bandwidth limiter may be nil in which case bundles are served without limits.
+func NewServer(chainContext string, runtimeID common.Namespace, bundles BundleProvider, limiter *BandwidthLimiter) rpc.Server {
+	return rpc.NewServer(
+		protocol.NewRuntimeProtocolID(chainContext, runtimeID, BundleSyncProtocolID, BundleSyncProtocolVersion),
+		&service{
+			bundles: bundles,
+			limiter: limiter,
+		},
+	)
+}
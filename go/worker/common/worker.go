@@ -14,6 +14,7 @@ import (
 	p2p "github.com/oasisprotocol/oasis-core/go/p2p/api"
 	runtimeRegistry "github.com/oasisprotocol/oasis-core/go/runtime/registry"
 	"github.com/oasisprotocol/oasis-core/go/worker/common/committee"
+	"github.com/oasisprotocol/oasis-core/go/worker/common/p2p/bundlesync"
 )
 
 // Worker is a garbage bag with lower level services and common runtime objects.
@@ -33,6 +34,9 @@ type Worker struct {
 
 	runtimes map[common.Namespace]*committee.Node
 
+	bundleLimiter  *bundlesync.BandwidthLimiter
+	bundleFetchers []*bundleFetcher
+
 	ctx       context.Context
 	cancelCtx context.CancelFunc
 	quitCh    chan struct{}
@@ -86,6 +90,11 @@ func (w *Worker) Start() error {
 		}
 	}
 
+	// Start runtime bundle fetchers.
+	for _, f := range w.bundleFetchers {
+		go f.worker(w.ctx)
+	}
+
 	return nil
 }
 
@@ -174,6 +183,8 @@ func (w *Worker) registerRuntime(runtime runtimeRegistry.Runtime) error {
 	}
 	w.runtimes[id] = node
 
+	w.registerBundleSync(runtime)
+
 	w.logger.Info("new runtime registered",
 		"runtime_id", id,
 	)
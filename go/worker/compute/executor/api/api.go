@@ -1,6 +1,13 @@
 package api
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/commitment"
+)
 
 // StatusState is the concise status state of the common runtime worker.
 type StatusState uint8
@@ -62,3 +69,38 @@ type Status struct {
 	// Status is a concise status of the committee node.
 	Status StatusState `json:"status"`
 }
+
+// DiscrepancyArtifact is a post-mortem record of an executor discrepancy, captured by a compute
+// node that was involved in the round in which the discrepancy was detected.
+type DiscrepancyArtifact struct {
+	// RuntimeID is the identifier of the runtime.
+	RuntimeID common.Namespace `json:"runtime_id"`
+	// Round is the runtime round in which the discrepancy was detected.
+	Round uint64 `json:"round"`
+	// Height is the consensus height at which the discrepancy was detected.
+	Height uint64 `json:"height"`
+	// Rank is the rank of the transaction scheduler whose proposal was discrepant.
+	Rank uint64 `json:"rank"`
+	// Timeout is true iff the discrepancy was detected due to a round timeout.
+	Timeout bool `json:"timeout,omitempty"`
+	// Authoritative is true iff the discrepancy was detected by the consensus layer and not
+	// merely predicted from observed commitments.
+	Authoritative bool `json:"authoritative,omitempty"`
+	// DetectedAt is the local time at which the discrepancy was detected.
+	DetectedAt time.Time `json:"detected_at"`
+
+	// Proposal is the discrepant proposal, if known.
+	Proposal *commitment.Proposal `json:"proposal,omitempty"`
+	// Batch is the raw transaction batch of the discrepant proposal in case the node has
+	// executed it.
+	Batch [][]byte `json:"batch,omitempty"`
+	// Computed are the results computed locally for the discrepant proposal in case the node
+	// has executed it.
+	Computed *commitment.ComputeResultsHeader `json:"computed,omitempty"`
+	// Commitments are all of the executor commitments for the round seen by the node, including
+	// their signatures.
+	Commitments []*commitment.ExecutorCommitment `json:"commitments,omitempty"`
+
+	// Finalized is the header of the block that finalized the round, if any.
+	Finalized *block.Header `json:"finalized,omitempty"`
+}
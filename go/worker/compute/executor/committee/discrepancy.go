@@ -39,6 +39,8 @@ func (n *Node) handleDiscrepancy(ctx context.Context, ev *discrepancyEvent) {
 
 	discrepancyDetectedCount.With(n.getMetricLabels()).Inc()
 
+	n.captureDiscrepancy(ev)
+
 	// Make sure that the runtime has synced this consensus block.
 	err := n.rt.ConsensusSync(ctx, ev.height)
 	if err != nil {
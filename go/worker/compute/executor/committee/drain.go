@@ -0,0 +1,51 @@
+package committee
+
+// Drain requests the node to stop participating in new rounds once the round it is currently
+// working on (if any) has been finalized.
+//
+// The returned channel is closed once the node has no more pending work, meaning that the round
+// it participated in as a member of the executor committee has been finalized, or that it is not
+// a member of the executor committee.
+func (n *Node) Drain() <-chan struct{} {
+	n.drainOnce.Do(func() {
+		n.logger.Info("draining requested, finishing current round")
+		close(n.drainCh)
+	})
+	n.reselect()
+
+	return n.drainedCh
+}
+
+// isDraining returns true iff draining has been requested.
+func (n *Node) isDraining() bool {
+	select {
+	case <-n.drainCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// checkDrained marks the node as drained in case draining has been requested and the node has
+// no more pending work, returning true iff the node has been drained.
+//
+// The node has no more pending work once the round it participated in has been finalized or in
+// case it does not participate in the current round as a member of the executor committee.
+func (n *Node) checkDrained(roundFinalized bool) bool {
+	if !n.isDraining() {
+		return false
+	}
+	if !roundFinalized && n.participating {
+		return false
+	}
+	n.markDrained()
+	return true
+}
+
+// markDrained notifies any waiters that the node has no more pending work.
+func (n *Node) markDrained() {
+	n.drainedOnce.Do(func() {
+		n.logger.Info("node drained")
+		close(n.drainedCh)
+	})
+}
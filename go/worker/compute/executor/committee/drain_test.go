@@ -0,0 +1,62 @@
+package committee
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+)
+
+func newDrainTestNode() *Node {
+	return &Node{
+		reselectCh: make(chan struct{}, 1),
+		drainCh:    make(chan struct{}),
+		drainedCh:  make(chan struct{}),
+		logger:     logging.GetLogger("worker/executor/committee/test"),
+	}
+}
+
+func requireDrained(t *testing.T, ch <-chan struct{}, drained bool) {
+	select {
+	case <-ch:
+		require.True(t, drained, "node should not be drained")
+	default:
+		require.False(t, drained, "node should be drained")
+	}
+}
+
+func TestDrain(t *testing.T) {
+	t.Run("Member", func(t *testing.T) {
+		require := require.New(t)
+
+		n := newDrainTestNode()
+		n.participating = true
+		require.False(n.checkDrained(true), "node should not be drained without a request")
+
+		ch := n.Drain()
+		require.True(n.isDraining(), "node should be draining")
+		require.Equal(ch, n.Drain(), "repeated drain requests should return the same channel")
+		requireDrained(t, ch, false)
+
+		// Committee members must finish the current round.
+		require.False(n.checkDrained(false), "member should not be drained before round finalization")
+		requireDrained(t, ch, false)
+
+		require.True(n.checkDrained(true), "member should be drained after round finalization")
+		requireDrained(t, ch, true)
+	})
+
+	t.Run("NonMember", func(t *testing.T) {
+		require := require.New(t)
+
+		n := newDrainTestNode()
+		ch := n.Drain()
+
+		require.True(n.checkDrained(false), "non-member should be drained immediately")
+		requireDrained(t, ch, true)
+
+		// Marking the node as drained again should be a no-op.
+		require.True(n.checkDrained(true), "node should remain drained")
+	})
+}
@@ -58,6 +58,20 @@ var (
 		},
 		[]string{"runtime"},
 	)
+	shadowExecutionCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_worker_shadow_execution_count",
+			Help: "Number of finalized rounds re-executed in shadow execution mode.",
+		},
+		[]string{"runtime"},
+	)
+	shadowExecutionDivergenceCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_worker_shadow_execution_divergence_count",
+			Help: "Number of shadow re-executed rounds that diverged from the finalized block.",
+		},
+		[]string{"runtime"},
+	)
 	nodeCollectors = []prometheus.Collector{
 		processedEventCount,
 		discrepancyDetectedCount,
@@ -66,6 +80,8 @@ var (
 		batchProcessingTime,
 		batchRuntimeProcessingTime,
 		batchSize,
+		shadowExecutionCount,
+		shadowExecutionDivergenceCount,
 	}
 
 	metricsOnce sync.Once
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/exp/maps"
@@ -14,7 +15,9 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/crash"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
+	"github.com/oasisprotocol/oasis-core/go/common/node"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	"github.com/oasisprotocol/oasis-core/go/config"
 	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	p2p "github.com/oasisprotocol/oasis-core/go/p2p/api"
 	p2pProtocol "github.com/oasisprotocol/oasis-core/go/p2p/protocol"
@@ -24,6 +27,7 @@ import (
 	runtime "github.com/oasisprotocol/oasis-core/go/runtime/api"
 	"github.com/oasisprotocol/oasis-core/go/runtime/host"
 	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/replay"
 	"github.com/oasisprotocol/oasis-core/go/runtime/transaction"
 	"github.com/oasisprotocol/oasis-core/go/runtime/txpool"
 	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
@@ -31,6 +35,7 @@ import (
 	commonWorker "github.com/oasisprotocol/oasis-core/go/worker/common"
 	"github.com/oasisprotocol/oasis-core/go/worker/common/committee"
 	"github.com/oasisprotocol/oasis-core/go/worker/common/p2p/txsync"
+	"github.com/oasisprotocol/oasis-core/go/worker/compute/executor/api"
 	"github.com/oasisprotocol/oasis-core/go/worker/registration"
 )
 
@@ -57,6 +62,12 @@ type Node struct { // nolint: maligned
 	commonCfg    commonWorker.Config
 	roleProvider registration.RoleProvider
 
+	// backupOnly is true iff the node is configured to only participate as a backup worker.
+	backupOnly bool
+	// backupOnlyAdvertised is true iff the backup-only capability is advertised in the node
+	// descriptor, which requires backup-only nodes to be enabled in consensus.
+	backupOnlyAdvertised atomic.Bool
+
 	committeeTopic string
 
 	ctx       context.Context
@@ -99,6 +110,34 @@ type Node struct { // nolint: maligned
 	poolRank      uint64
 	proposedBatch *proposedBatch
 
+	// Post-mortem capture of discrepancies.
+
+	artifacts        *discrepancyArtifactStore
+	artifact         *api.DiscrepancyArtifact
+	roundBatches     map[uint64]*roundBatch
+	roundCommitments map[hash.Hash]*commitment.ExecutorCommitment
+
+	// pendingShadow is the finalized round waiting to be re-executed in shadow execution mode.
+	pendingShadow *pendingShadowRound
+	// shadowCancel aborts the shadow execution in progress.
+	shadowCancel context.CancelCauseFunc
+	// shadowDone is closed once the shadow execution in progress has finished.
+	shadowDone chan struct{}
+
+	// replayCh is the queue of runtime round recordings waiting to be saved.
+	replayCh chan *replay.Record
+
+	// Graceful handoff of committee duties on shutdown.
+
+	drainCh     chan struct{}
+	drainOnce   sync.Once
+	drainedCh   chan struct{}
+	drainedOnce sync.Once
+
+	// participating is true iff the node participates in the current round as a member of the
+	// executor committee.
+	participating bool
+
 	logger *logging.Logger
 }
 
@@ -121,6 +160,7 @@ func (n *Node) Start() error {
 	n.storage = lsb
 
 	go n.worker()
+	go n.replayWriter()
 	return nil
 }
 
@@ -182,6 +222,11 @@ func (n *Node) transitionState(state NodeState) {
 		panic(fmt.Sprintf("invalid state transition: %s -> %s", n.state, state))
 	}
 
+	// Shadow execution must never delay batch processing.
+	if state.Name() != WaitingForBatch {
+		n.abortShadowExecution(errors.New("executor is no longer idle"))
+	}
+
 	n.state = state
 	n.stateTransitions.Broadcast(state)
 }
@@ -190,6 +235,8 @@ func (n *Node) transitionStateToProcessing(ctx context.Context, proposal *commit
 	ctx, cancel := context.WithCancelCause(ctx)
 	done := make(chan struct{})
 
+	n.recordBatchInput(rank, proposal, batch)
+
 	n.transitionState(StateProcessingBatch{
 		mode:           protocol.ExecutionModeExecute,
 		rank:           rank,
@@ -349,6 +396,13 @@ func (n *Node) scheduleBatch(ctx context.Context, round uint64, force bool) {
 		return
 	}
 
+	// Backup-only nodes never schedule batches, even if they were elected as workers before
+	// their preference was registered.
+	if n.backupOnlyAdvertised.Load() {
+		n.logger.Debug("not scheduling, backup-only node")
+		return
+	}
+
 	// If the next block will be an epoch transition block, do not propose anything as it will be
 	// reverted anyway (since the committee will change).
 	epochState, err := n.commonNode.Consensus.Beacon().GetFutureEpoch(ctx, n.blockInfo.ConsensusBlock.Height) // TODO: is this height ok?
@@ -579,7 +633,7 @@ func (n *Node) runtimeExecuteTxBatch(
 	)
 	defer cancelCallFn()
 
-	rsp, err := rt.Call(callCtx, rq)
+	rsp, err := n.callRuntime(callCtx, rt, blk.Header.Round+1, rq)
 	switch {
 	case err == nil:
 	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
@@ -794,6 +848,7 @@ func (n *Node) proposeBatch(
 		batchStartTime: state.batchStartTime,
 		proposedIORoot: *ec.Header.Header.IORoot,
 		txHashes:       processed.proposal.Batch,
+		shadow:         n.newShadowRound(processed.proposal),
 	}
 
 	n.transitionState(StateWaitingForBatch{})
@@ -811,6 +866,8 @@ func (n *Node) signAndSubmitCommitment(roundCtx context.Context, ec *commitment.
 		return err
 	}
 
+	n.recordCommitment(ec)
+
 	tx := roothash.NewExecutorCommitTx(0, nil, n.commonNode.Runtime.ID(), []commitment.ExecutorCommitment{*ec})
 	go func() {
 		commitErr := consensus.SignAndSubmitTx(roundCtx, n.commonNode.Consensus, n.commonNode.Identity.NodeSigner, tx)
@@ -972,7 +1029,7 @@ func (n *Node) nudgeAvailabilityLocked(force bool) {
 			break
 		}
 
-		n.roleProvider.SetAvailable(n.commonNode.RegisterNodeRuntime)
+		n.roleProvider.SetAvailable(n.registerNodeRuntime)
 	default:
 		// Executor is not ready to process requests.
 		if !n.roleProvider.IsAvailable() && !force {
@@ -983,6 +1040,37 @@ func (n *Node) nudgeAvailabilityLocked(force bool) {
 	}
 }
 
+// registerNodeRuntime adds our runtime registration to an existing node descriptor, including
+// the executor-specific capabilities.
+func (n *Node) registerNodeRuntime(nd *node.Node) error {
+	if err := n.commonNode.RegisterNodeRuntime(nd); err != nil {
+		return err
+	}
+
+	if !n.backupOnly {
+		return nil
+	}
+
+	// Only advertise the backup-only capability when enabled as registration would fail otherwise.
+	params, err := n.commonNode.Consensus.Registry().ConsensusParameters(n.ctx, consensus.HeightLatest)
+	if err != nil {
+		return fmt.Errorf("failed to query registry consensus parameters: %w", err)
+	}
+	if !params.EnableBackupOnlyNodes {
+		n.logger.Warn("backup-only nodes are not enabled, registering as a regular executor")
+	}
+	n.backupOnlyAdvertised.Store(params.EnableBackupOnlyNodes)
+
+	id := n.commonNode.Runtime.ID()
+	for _, rt := range nd.Runtimes {
+		if !rt.ID.Equal(&id) {
+			continue
+		}
+		rt.Capabilities.BackupOnly = params.EnableBackupOnlyNodes
+	}
+	return nil
+}
+
 func (n *Node) HandleRuntimeHostEventLocked(ev *host.Event) {
 	switch {
 	case ev.Started != nil:
@@ -1053,6 +1141,8 @@ func (n *Node) handleProcessedBatch(ctx context.Context, batch *processedBatch)
 		return
 	}
 
+	n.recordBatchOutput(batch)
+
 	// Check if there was an issue during batch processing.
 	if batch.computed == nil {
 		n.logger.Warn("worker has aborted batch processing")
@@ -1131,6 +1221,8 @@ func (n *Node) handleExecutorCommitment(ctx context.Context, ec *commitment.Exec
 		"commitment", ec,
 	)
 
+	n.recordCommitment(ec)
+
 	id := n.commonNode.Identity.NodeSigner.Public()
 	switch {
 	case n.committee.IsWorker(id):
@@ -1143,6 +1235,8 @@ func (n *Node) handleObservedExecutorCommitment(ctx context.Context, ec *commitm
 		"commitment", ec,
 	)
 
+	n.recordCommitment(ec)
+
 	id := n.commonNode.Identity.NodeSigner.Public()
 	switch {
 	case n.committee.IsWorker(id):
@@ -1234,6 +1328,9 @@ func (n *Node) finalizePreviousRound() {
 
 			// Remove processed transactions from queue.
 			n.commonNode.TxPool.HandleTxsUsed(n.proposedBatch.txHashes)
+
+			// Re-execute the finalized round to detect nondeterminism once idle.
+			n.queueShadowExecution(n.proposedBatch.shadow, n.blockInfo.RuntimeBlock)
 		}
 	}
 
@@ -1242,6 +1339,9 @@ func (n *Node) finalizePreviousRound() {
 
 	// Clear proposal queue.
 	n.commonNode.TxPool.ClearProposedBatch()
+
+	// Persist any captured discrepancy artifact.
+	n.finalizeDiscrepancyArtifact()
 }
 
 // resetNodeState transitions to the StateWaitingForBatch state.
@@ -1269,8 +1369,14 @@ func (n *Node) resetNodeState() {
 // This ensures that channels do not accumulate obsolete data when the round worker exits
 // early due to non-membership in the executor committee or errors.
 func (n *Node) drainChannels(ctx context.Context) {
+	drainCh := n.drainCh
 	for {
 		select {
+		case <-drainCh:
+			// The round worker is not active, but the node may still need to participate in
+			// the current round in case it is a committee member.
+			n.checkDrained(false)
+			drainCh = nil
 		case <-n.txCh:
 		case <-n.ecCh:
 		case <-n.evCh:
@@ -1394,6 +1500,16 @@ func (n *Node) roundWorker(ctx context.Context) {
 
 	n.finalizePreviousRound()
 	defer n.resetNodeState()
+	defer n.abortShadowExecution(errors.New("round finished"))
+
+	// The previous round has been finalized, so there is no more pending work.
+	n.participating = false
+	if n.checkDrained(true) {
+		n.logger.Debug("skipping round, node is drained",
+			"round", round,
+		)
+		return
+	}
 
 	// Prune proposals.
 	n.proposals.Prune(round)
@@ -1413,6 +1529,7 @@ func (n *Node) roundWorker(ctx context.Context) {
 		)
 		return
 	}
+	n.participating = true
 
 	// This should never fail as we only register to be an executor worker
 	// once the hosted runtime is ready.
@@ -1503,6 +1620,9 @@ func (n *Node) roundWorker(ctx context.Context) {
 			}
 		}
 
+		// Use idle time to re-execute the previous round.
+		n.maybeStartShadowExecution(ctx)
+
 		select {
 		case <-ctx.Done():
 			n.logger.Debug("exiting round, context canceled")
@@ -1554,6 +1674,7 @@ func NewNode(
 		commonNode:       commonNode,
 		commonCfg:        commonCfg,
 		roleProvider:     roleProvider,
+		backupOnly:       config.GlobalConfig.Runtime.BackupOnly,
 		committeeTopic:   committeeTopic,
 		proposals:        newPendingProposals(),
 		ctx:              ctx,
@@ -1568,6 +1689,12 @@ func NewNode(
 		processedBatchCh: make(chan *processedBatch, 1),
 		reselectCh:       make(chan struct{}, 1),
 		missingTxCh:      make(chan [][]byte, 1),
+		artifacts:        newDiscrepancyArtifactStore(commonNode.Runtime.ID()),
+		replayCh:         make(chan *replay.Record, maxPendingReplayRecords),
+		roundBatches:     make(map[uint64]*roundBatch),
+		roundCommitments: make(map[hash.Hash]*commitment.ExecutorCommitment),
+		drainCh:          make(chan struct{}),
+		drainedCh:        make(chan struct{}),
 		logger:           logging.GetLogger("worker/executor/committee").With("runtime_id", commonNode.Runtime.ID()),
 	}
 
@@ -0,0 +1,229 @@
+package committee
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/config"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/commitment"
+	"github.com/oasisprotocol/oasis-core/go/runtime/transaction"
+	"github.com/oasisprotocol/oasis-core/go/worker/compute/executor/api"
+)
+
+const (
+	// discrepancyArtifactsDir is the name of the directory under the node's data directory where
+	// discrepancy artifacts are stored.
+	discrepancyArtifactsDir = "discrepancies"
+
+	// discrepancyArtifactExt is the file extension of discrepancy artifacts.
+	discrepancyArtifactExt = ".artifact"
+
+	// maxDiscrepancyArtifacts is the maximum number of discrepancy artifacts kept per runtime.
+	maxDiscrepancyArtifacts = 100
+)
+
+// roundBatch are the inputs and outputs of a batch processed by the node in the current round.
+type roundBatch struct {
+	proposal *commitment.Proposal
+	batch    transaction.RawBatch
+	computed *commitment.ComputeResultsHeader
+}
+
+// discrepancyArtifactStore is a local store of discrepancy artifacts for a runtime.
+type discrepancyArtifactStore struct {
+	dir string
+}
+
+func (s *discrepancyArtifactStore) path(round uint64) string {
+	return filepath.Join(s.dir, strconv.FormatUint(round, 10)+discrepancyArtifactExt)
+}
+
+func (s *discrepancyArtifactStore) save(artifact *api.DiscrepancyArtifact) error {
+	if err := common.Mkdir(s.dir); err != nil {
+		return err
+	}
+	// Write atomically so that concurrent readers never see a partial artifact.
+	fn := s.path(artifact.Round)
+	if err := os.WriteFile(fn+".tmp", cbor.Marshal(artifact), 0o600); err != nil {
+		return err
+	}
+	if err := os.Rename(fn+".tmp", fn); err != nil {
+		return err
+	}
+
+	// Prune old artifacts.
+	rounds, err := s.rounds()
+	if err != nil {
+		return err
+	}
+	for len(rounds) > maxDiscrepancyArtifacts {
+		if err = os.Remove(s.path(rounds[0])); err != nil {
+			return err
+		}
+		rounds = rounds[1:]
+	}
+	return nil
+}
+
+// rounds returns the sorted rounds for which artifacts are available.
+func (s *discrepancyArtifactStore) rounds() ([]uint64, error) {
+	entries, err := os.ReadDir(s.dir)
+	switch {
+	case err == nil:
+	case os.IsNotExist(err):
+		return nil, nil
+	default:
+		return nil, err
+	}
+
+	var rounds []uint64
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), discrepancyArtifactExt)
+		if !ok {
+			continue
+		}
+		round, err := strconv.ParseUint(name, 10, 64)
+		if err != nil {
+			continue
+		}
+		rounds = append(rounds, round)
+	}
+	slices.Sort(rounds)
+	return rounds, nil
+}
+
+func (s *discrepancyArtifactStore) list() ([]*api.DiscrepancyArtifact, error) {
+	rounds, err := s.rounds()
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts := make([]*api.DiscrepancyArtifact, 0, len(rounds))
+	for _, round := range rounds {
+		data, err := os.ReadFile(s.path(round))
+		if err != nil {
+			return nil, err
+		}
+		var artifact api.DiscrepancyArtifact
+		if err = cbor.Unmarshal(data, &artifact); err != nil {
+			return nil, fmt.Errorf("malformed discrepancy artifact for round %d: %w", round, err)
+		}
+		artifacts = append(artifacts, &artifact)
+	}
+	return artifacts, nil
+}
+
+func newDiscrepancyArtifactStore(runtimeID common.Namespace) *discrepancyArtifactStore {
+	return &discrepancyArtifactStore{
+		dir: filepath.Join(config.GlobalConfig.Common.DataDir, discrepancyArtifactsDir, runtimeID.String()),
+	}
+}
+
+// GetDiscrepancyArtifacts returns all locally retained discrepancy artifacts, ordered by round.
+func (n *Node) GetDiscrepancyArtifacts() ([]*api.DiscrepancyArtifact, error) {
+	return n.artifacts.list()
+}
+
+func (n *Node) recordBatchInput(rank uint64, proposal *commitment.Proposal, batch transaction.RawBatch) {
+	rb, ok := n.roundBatches[rank]
+	if !ok {
+		rb = &roundBatch{}
+		n.roundBatches[rank] = rb
+	}
+	rb.proposal = proposal
+	rb.batch = batch
+}
+
+func (n *Node) recordBatchOutput(processed *processedBatch) {
+	rb, ok := n.roundBatches[processed.rank]
+	if !ok {
+		rb = &roundBatch{}
+		n.roundBatches[processed.rank] = rb
+	}
+	rb.proposal = processed.proposal
+	if processed.computed != nil {
+		rb.computed = &processed.computed.Header
+	}
+}
+
+func (n *Node) recordCommitment(ec *commitment.ExecutorCommitment) {
+	if ec.Header.Header.Round != n.blockInfo.RuntimeBlock.Header.Round+1 {
+		return
+	}
+	n.roundCommitments[hash.NewFrom(ec)] = ec
+}
+
+// captureDiscrepancy starts capturing a post-mortem artifact for the given discrepancy. The
+// artifact is persisted once the round is finalized.
+func (n *Node) captureDiscrepancy(ev *discrepancyEvent) {
+	// Prefer authoritative discrepancy events, the same as discrepancy resolution does.
+	if n.artifact != nil && (n.artifact.Authoritative || !ev.authoritative) {
+		return
+	}
+
+	n.artifact = &api.DiscrepancyArtifact{
+		RuntimeID:     n.commonNode.Runtime.ID(),
+		Round:         ev.round,
+		Height:        ev.height,
+		Rank:          ev.rank,
+		Timeout:       ev.timeout,
+		Authoritative: ev.authoritative,
+		DetectedAt:    time.Now(),
+	}
+}
+
+// finalizeDiscrepancyArtifact persists the discrepancy artifact for the previous round, if any,
+// and resets the per-round capture state.
+func (n *Node) finalizeDiscrepancyArtifact() {
+	defer func() {
+		n.artifact = nil
+		n.roundBatches = make(map[uint64]*roundBatch)
+		n.roundCommitments = make(map[hash.Hash]*commitment.ExecutorCommitment)
+	}()
+
+	artifact := n.artifact
+	if artifact == nil {
+		return
+	}
+
+	if rb, ok := n.roundBatches[artifact.Rank]; ok {
+		artifact.Proposal = rb.proposal
+		artifact.Batch = rb.batch
+		artifact.Computed = rb.computed
+	}
+	for _, ec := range n.roundCommitments {
+		artifact.Commitments = append(artifact.Commitments, ec)
+	}
+	slices.SortFunc(artifact.Commitments, func(a, b *commitment.ExecutorCommitment) int {
+		if c := bytes.Compare(a.NodeID[:], b.NodeID[:]); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.Header.SchedulerID[:], b.Header.SchedulerID[:])
+	})
+	if hdr := n.blockInfo.RuntimeBlock.Header; hdr.Round == artifact.Round {
+		artifact.Finalized = &hdr
+	}
+
+	if err := n.artifacts.save(artifact); err != nil {
+		n.logger.Error("failed to save discrepancy artifact",
+			"err", err,
+			"round", artifact.Round,
+		)
+		return
+	}
+
+	n.logger.Info("saved discrepancy artifact",
+		"round", artifact.Round,
+		"rank", artifact.Rank,
+		"commitments", len(artifact.Commitments),
+	)
+}
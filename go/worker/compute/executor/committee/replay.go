@@ -0,0 +1,87 @@
+package committee
+
+import (
+	"context"
+
+	"github.com/oasisprotocol/oasis-core/go/config"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/replay"
+)
+
+// maxPendingReplayRecords is the maximum number of runtime round recordings waiting to be saved.
+// Further recordings are dropped until the writer catches up.
+const maxPendingReplayRecords = 16
+
+// callRuntime performs the given call into the runtime for the given round, recording it for
+// later replay in case recording is enabled.
+func (n *Node) callRuntime(ctx context.Context, rt host.Runtime, round uint64, rq *protocol.Body) (*protocol.Body, error) {
+	recorder := n.commonNode.GetHostedRuntimeRecorder()
+	if recorder == nil {
+		return rt.Call(ctx, rq)
+	}
+
+	rec, rsp, err := recorder.Call(ctx, rt, round, rq)
+
+	// Make sure that saving the recording never delays round processing.
+	select {
+	case n.replayCh <- rec:
+	default:
+		n.logger.Warn("dropping runtime round recording, writer is falling behind",
+			"round", rec.Round,
+		)
+	}
+
+	return rsp, err
+}
+
+// replayWriter saves runtime round recordings in the background.
+func (n *Node) replayWriter() {
+	for {
+		select {
+		case rec := <-n.replayCh:
+			n.saveReplayRecord(rec)
+		case <-n.ctx.Done():
+			return
+		}
+	}
+}
+
+func (n *Node) saveReplayRecord(rec *replay.Record) {
+	cfg := config.GlobalConfig.Runtime.Replay
+
+	// Include host environment information so that the runtime can be initialized in the same
+	// way during replay.
+	rec.HostInfo = &protocol.HostInfo{}
+	if cs, err := n.commonNode.Consensus.GetStatus(n.ctx); err == nil {
+		rec.HostInfo.ConsensusBackend = cs.Backend
+		rec.HostInfo.ConsensusProtocolVersion = cs.Version
+	}
+	if chainCtx, err := n.commonNode.Consensus.GetChainContext(n.ctx); err == nil {
+		rec.HostInfo.ConsensusChainContext = chainCtx
+	}
+
+	fn := replay.RecordPath(cfg.RecordDir, rec.RuntimeID, rec.Round)
+	if err := rec.WriteFile(fn); err != nil {
+		n.logger.Error("failed to save runtime round recording",
+			"err", err,
+			"round", rec.Round,
+		)
+		return
+	}
+
+	n.logger.Debug("saved runtime round recording",
+		"round", rec.Round,
+		"path", fn,
+	)
+
+	// Prune old recordings.
+	if cfg.NumKept == 0 || rec.Round < cfg.NumKept {
+		return
+	}
+	if err := replay.Prune(cfg.RecordDir, rec.RuntimeID, rec.Round-cfg.NumKept); err != nil {
+		n.logger.Warn("failed to prune old runtime round recordings",
+			"err", err,
+		)
+	}
+}
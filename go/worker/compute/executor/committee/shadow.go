@@ -0,0 +1,230 @@
+package committee
+
+import (
+	"context"
+	"fmt"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	"github.com/oasisprotocol/oasis-core/go/config"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/commitment"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host"
+	"github.com/oasisprotocol/oasis-core/go/runtime/host/protocol"
+	"github.com/oasisprotocol/oasis-core/go/runtime/transaction"
+)
+
+// shadowRound is the context in which the node executed a batch, retained so that the round can
+// be re-executed once it has been finalized.
+type shadowRound struct {
+	epoch        beacon.EpochTime
+	consensusBlk *consensus.LightBlock
+	blk          *block.Block
+	state        *roothash.RuntimeState
+	roundResults *roothash.RoundResults
+	inputRoot    hash.Hash
+}
+
+// pendingShadowRound is a finalized round waiting for the executor to become idle so that it can
+// be re-executed.
+type pendingShadowRound struct {
+	*shadowRound
+
+	finalized *block.Block
+}
+
+// newShadowRound captures the context of the current round for shadow execution, returning nil
+// in case shadow execution is disabled.
+func (n *Node) newShadowRound(proposal *commitment.Proposal) *shadowRound {
+	if !config.GlobalConfig.Runtime.ShadowExecution.Enabled {
+		return nil
+	}
+
+	return &shadowRound{
+		epoch:        n.blockInfo.Epoch,
+		consensusBlk: n.blockInfo.ConsensusBlock,
+		blk:          n.blockInfo.RuntimeBlock,
+		state:        n.rtState,
+		roundResults: n.roundResults,
+		inputRoot:    proposal.Header.BatchHash,
+	}
+}
+
+// queueShadowExecution queues the round finalized in the given block for shadow execution.
+//
+// Only the most recently finalized round is retained, as shadow execution is only performed
+// while the executor is idle and should never compete with batch processing for the runtime.
+func (n *Node) queueShadowExecution(sr *shadowRound, finalized *block.Block) {
+	if sr == nil {
+		return
+	}
+
+	if n.pendingShadow != nil {
+		n.logger.Warn("skipping shadow execution, executor was not idle",
+			"round", n.pendingShadow.finalized.Header.Round,
+		)
+	}
+	n.pendingShadow = &pendingShadowRound{
+		shadowRound: sr,
+		finalized:   finalized,
+	}
+}
+
+// maybeStartShadowExecution starts re-executing the queued finalized round in the background,
+// provided that the executor is idle and no other shadow execution is in progress.
+func (n *Node) maybeStartShadowExecution(ctx context.Context) {
+	if n.pendingShadow == nil || n.shadowDone != nil {
+		return
+	}
+	if _, idle := n.state.(StateWaitingForBatch); !idle {
+		return
+	}
+
+	rt := n.commonNode.GetHostedRuntime()
+	if rt == nil {
+		return
+	}
+
+	ps := n.pendingShadow
+	n.pendingShadow = nil
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	done := make(chan struct{})
+	n.shadowCancel = cancel
+	n.shadowDone = done
+
+	go func() {
+		defer close(done)
+
+		err := n.shadowExecute(ctx, rt, ps.shadowRound, ps.finalized)
+		switch {
+		case err == nil:
+		case context.Cause(ctx) != nil:
+			n.logger.Debug("shadow execution aborted",
+				"err", context.Cause(ctx),
+				"round", ps.finalized.Header.Round,
+			)
+		default:
+			n.logger.Error("shadow execution failed",
+				"err", err,
+				"round", ps.finalized.Header.Round,
+			)
+		}
+	}()
+}
+
+// abortShadowExecution aborts the shadow execution in progress, if any, and waits for it to
+// release the runtime.
+func (n *Node) abortShadowExecution(cause error) {
+	if n.shadowDone == nil {
+		return
+	}
+
+	n.shadowCancel(cause)
+	<-n.shadowDone
+
+	n.shadowCancel = nil
+	n.shadowDone = nil
+}
+
+// shadowExecute re-executes the inputs of the finalized round against the state of the previous
+// round and compares the results with the finalized block.
+//
+// Execution results are discarded as the runtime only returns write logs, which are never
+// applied to local storage.
+func (n *Node) shadowExecute(ctx context.Context, rt host.RichRuntime, sr *shadowRound, finalized *block.Block) error {
+	round := finalized.Header.Round
+
+	// Fetch the inputs of the finalized round from the local I/O tree.
+	tree := transaction.NewTree(n.storage, finalized.Header.StorageRootIO())
+	defer tree.Close()
+
+	txnScheduler := sr.state.Runtime.TxnScheduler
+	inputs, err := tree.GetInputBatch(ctx, txnScheduler.MaxBatchSize, txnScheduler.MaxBatchSizeBytes)
+	if err != nil {
+		return fmt.Errorf("failed to fetch round inputs: %w", err)
+	}
+
+	inMsgs, err := n.commonNode.Consensus.RootHash().GetIncomingMessageQueue(ctx, &roothash.InMessageQueueRequest{
+		RuntimeID: n.commonNode.Runtime.ID(),
+		Height:    sr.consensusBlk.Height,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch incoming runtime message queue: %w", err)
+	}
+
+	rq := &protocol.Body{
+		RuntimeExecuteTxBatchRequest: &protocol.RuntimeExecuteTxBatchRequest{
+			Mode:           protocol.ExecutionModeExecute,
+			ConsensusBlock: *sr.consensusBlk,
+			RoundResults:   sr.roundResults,
+			IORoot:         sr.inputRoot,
+			Inputs:         inputs,
+			InMessages:     inMsgs,
+			Block:          *sr.blk,
+			Epoch:          sr.epoch,
+			MaxMessages:    sr.state.Runtime.Executor.MaxMessages,
+		},
+	}
+
+	callCtx, cancel := context.WithTimeout(ctx, executeBatchTimeoutFactor*txnScheduler.ProposerTimeout)
+	defer cancel()
+
+	// The call is intentionally not recorded as it would overwrite the recording of the round.
+	rsp, err := rt.Call(callCtx, rq)
+	if err != nil {
+		return fmt.Errorf("failed to execute batch: %w", err)
+	}
+	if rsp.RuntimeExecuteTxBatchResponse == nil {
+		return fmt.Errorf("malformed response from runtime")
+	}
+	shadowExecutionCount.With(n.getMetricLabels()).Inc()
+
+	computed := &rsp.RuntimeExecuteTxBatchResponse.Batch.Header
+	diverged := shadowDivergence(computed, &finalized.Header)
+	if len(diverged) == 0 {
+		n.logger.Debug("shadow execution matches finalized round",
+			"round", round,
+		)
+		return nil
+	}
+
+	shadowExecutionDivergenceCount.With(n.getMetricLabels()).Inc()
+
+	n.logger.Error("shadow execution diverged from finalized round, runtime may be nondeterministic",
+		"round", round,
+		"diverged", diverged,
+		"io_root", computed.IORoot,
+		"state_root", computed.StateRoot,
+		"messages_hash", computed.MessagesHash,
+		"in_msgs_hash", computed.InMessagesHash,
+		"finalized_io_root", finalized.Header.IORoot,
+		"finalized_state_root", finalized.Header.StateRoot,
+		"finalized_messages_hash", finalized.Header.MessagesHash,
+		"finalized_in_msgs_hash", finalized.Header.InMessagesHash,
+	)
+	return nil
+}
+
+// shadowDivergence returns the names of the roots that differ between the computed results and
+// the finalized block header.
+func shadowDivergence(computed *commitment.ComputeResultsHeader, finalized *block.Header) []string {
+	var diverged []string
+	for _, root := range []struct {
+		name      string
+		computed  *hash.Hash
+		finalized hash.Hash
+	}{
+		{"io_root", computed.IORoot, finalized.IORoot},
+		{"state_root", computed.StateRoot, finalized.StateRoot},
+		{"messages_hash", computed.MessagesHash, finalized.MessagesHash},
+		{"in_msgs_hash", computed.InMessagesHash, finalized.InMessagesHash},
+	} {
+		if root.computed == nil || !root.computed.Equal(&root.finalized) {
+			diverged = append(diverged, root.name)
+		}
+	}
+	return diverged
+}
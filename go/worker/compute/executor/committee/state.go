@@ -175,4 +175,5 @@ type proposedBatch struct {
 	batchStartTime time.Time
 	proposedIORoot hash.Hash
 	txHashes       []hash.Hash
+	shadow         *shadowRound
 }
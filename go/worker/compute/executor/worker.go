@@ -126,6 +126,34 @@ func (w *Worker) Initialized() <-chan struct{} {
 	return w.initCh
 }
 
+// Drain requests all runtime committee nodes to finish their current rounds and stop
+// participating in new ones and waits for them to have no more pending work.
+func (w *Worker) Drain(ctx context.Context) error {
+	if !w.enabled {
+		return nil
+	}
+
+	// Request all runtimes to drain first, so that they can do so concurrently.
+	drainedChs := make(map[common.Namespace]<-chan struct{}, len(w.runtimes))
+	for id, rt := range w.runtimes {
+		w.logger.Info("draining runtime",
+			"runtime_id", id,
+		)
+		drainedChs[id] = rt.Drain()
+	}
+
+	for id, rt := range w.runtimes {
+		select {
+		case <-drainedChs[id]:
+		case <-rt.Quit():
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
 // GetRuntime returns a registered runtime.
 //
 // In case the runtime with the specified id was not registered it
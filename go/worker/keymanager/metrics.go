@@ -118,6 +118,46 @@ var (
 		},
 		[]string{"runtime"},
 	)
+	enclaveRPCCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_worker_keymanager_enclave_rpc_method_count",
+			Help: "Number of remote enclave rpc calls.",
+		},
+		[]string{"runtime", "method"},
+	)
+
+	enclaveRPCLatency = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Name: "oasis_worker_keymanager_enclave_rpc_latency_seconds",
+			Help: "Latency of remote enclave rpc calls in seconds.",
+		},
+		[]string{"runtime", "method"},
+	)
+
+	enclaveRPCFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_worker_keymanager_enclave_rpc_failures_total",
+			Help: "Number of failed remote enclave rpc calls.",
+		},
+		[]string{"runtime", "method"},
+	)
+
+	policyCheckFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "oasis_worker_keymanager_policy_check_failures_total",
+			Help: "Number of remote enclave rpc calls rejected by access control.",
+		},
+		[]string{"runtime", "method"},
+	)
+
+	masterSecretReplicationLag = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "oasis_worker_keymanager_master_secret_replication_lag",
+			Help: "Number of master secret generations the enclave is behind consensus.",
+		},
+		[]string{"runtime"},
+	)
+
 	churpThresholdNumber = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "oasis_worker_keymanager_churp_threshold_number",
@@ -204,6 +244,11 @@ var (
 		enclaveGeneratedMasterSecretEpochNumber,
 		enclaveGeneratedMasterSecretGenerationNumber,
 		enclaveGeneratedEphemeralSecretEpochNumber,
+		enclaveRPCCount,
+		enclaveRPCLatency,
+		enclaveRPCFailures,
+		policyCheckFailures,
+		masterSecretReplicationLag,
 		churpThresholdNumber,
 		churpExtraSharesNumber,
 		churpHandoffNumber,
@@ -75,8 +75,9 @@ type secretsWorker struct {
 	roleProvider registration.RoleProvider
 	backend      api.Backend
 
-	status   workerKm.SecretsStatus // Guarded by mutex.
-	kmStatus *secrets.Status
+	status            workerKm.SecretsStatus // Guarded by mutex.
+	enclaveGeneration *uint64                // Guarded by mutex.
+	kmStatus          *secrets.Status
 
 	initEnclaveInProgress  bool
 	initEnclaveRequired    bool
@@ -468,6 +469,7 @@ func (w *secretsWorker) handleStatusUpdate(ctx context.Context, kmStatus *secret
 	w.kmStatus = kmStatus
 	w.mu.Lock()
 	w.status.Status = kmStatus
+	w.updateReplicationLagLocked()
 	w.mu.Unlock()
 
 	// (Re)Initialize the enclave.
@@ -551,6 +553,8 @@ func (w *secretsWorker) initEnclave(ctx context.Context, kmStatus *secrets.Statu
 
 	// Update metrics.
 	enclaveMasterSecretGenerationNumber.WithLabelValues(w.runtimeLabel).Set(float64(kmStatus.Generation))
+	w.enclaveGeneration = &kmStatus.Generation
+	w.updateReplicationLagLocked()
 	if !bytes.Equal(w.status.Worker.PolicyChecksum, rsp.InitResponse.PolicyChecksum) {
 		policyUpdateCount.WithLabelValues(w.runtimeLabel).Inc()
 	}
@@ -562,6 +566,22 @@ func (w *secretsWorker) initEnclave(ctx context.Context, kmStatus *secrets.Statu
 	return &rsp, nil
 }
 
+// updateReplicationLagLocked updates the number of master secret generations the enclave
+// is behind the latest key manager status published on the consensus layer.
+//
+// The caller must hold the mutex.
+func (w *secretsWorker) updateReplicationLagLocked() {
+	if w.enclaveGeneration == nil || w.status.Status == nil {
+		return
+	}
+
+	var lag uint64
+	if w.status.Status.Generation > *w.enclaveGeneration {
+		lag = w.status.Status.Generation - *w.enclaveGeneration
+	}
+	masterSecretReplicationLag.WithLabelValues(w.runtimeLabel).Set(float64(lag))
+}
+
 func (w *secretsWorker) handleInitEnclaveDone(ctx context.Context, rsp *secrets.SignedInitResponse) {
 	// Discard the response if the runtime is not ready and retry later.
 	version, err := w.kmWorker.GetHostedRuntimeActiveVersion()
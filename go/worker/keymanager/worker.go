@@ -161,6 +161,7 @@ func (w *Worker) CallEnclave(ctx context.Context, data []byte, kind enclaverpc.K
 			return ctrl.Connect(ctx, peerID)
 		}
 		if !slices.ContainsFunc(w.accessControllers, fn) {
+			policyCheckFailures.WithLabelValues(w.runtimeLabel, method).Inc()
 			return nil, fmt.Errorf("not authorized to connect")
 		}
 	default:
@@ -169,6 +170,7 @@ func (w *Worker) CallEnclave(ctx context.Context, data []byte, kind enclaverpc.K
 			return nil, fmt.Errorf("unsupported RPC method")
 		}
 		if err := ctrl.Authorize(ctx, method, kind, peerID); err != nil {
+			policyCheckFailures.WithLabelValues(w.runtimeLabel, method).Inc()
 			return nil, fmt.Errorf("not authorized: %w", err)
 		}
 	}
@@ -189,8 +191,12 @@ func (w *Worker) CallEnclave(ctx context.Context, data []byte, kind enclaverpc.K
 		return nil, fmt.Errorf("not initialized")
 	}
 
+	start := time.Now()
 	response, err := rt.Call(ctx, req)
+	enclaveRPCCount.WithLabelValues(w.runtimeLabel, method).Inc()
+	enclaveRPCLatency.WithLabelValues(w.runtimeLabel, method).Observe(time.Since(start).Seconds())
 	if err != nil {
+		enclaveRPCFailures.WithLabelValues(w.runtimeLabel, method).Inc()
 		w.logger.Error("failed to dispatch RPC call to runtime",
 			"err", err,
 			"kind", kind,
@@ -0,0 +1,62 @@
+package registration
+
+import (
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	registrationConfig "github.com/oasisprotocol/oasis-core/go/worker/registration/config"
+)
+
+// registrationBackoff tracks consecutive failed re-registration attempts and determines the
+// epochs in which re-registration should be attempted.
+//
+// Once the number of consecutive failures reaches the configured threshold, an exponentially
+// growing number of epochs (up to the configured maximum) is skipped between attempts.
+type registrationBackoff struct {
+	threshold  uint64
+	maxSkipped uint64
+
+	failures    uint64
+	nextAttempt beacon.EpochTime
+}
+
+// shouldAttempt returns true iff re-registration should be attempted in the given epoch.
+func (b *registrationBackoff) shouldAttempt(epoch beacon.EpochTime) bool {
+	return epoch >= b.nextAttempt
+}
+
+// isBackingOff returns true iff re-registration attempts are currently being skipped.
+func (b *registrationBackoff) isBackingOff() bool {
+	return b.nextAttempt != 0
+}
+
+// failed records a failed re-registration attempt in the given epoch.
+func (b *registrationBackoff) failed(epoch beacon.EpochTime) {
+	b.failures++
+	if b.threshold == 0 || b.failures < b.threshold {
+		return
+	}
+
+	skipped := b.maxSkipped
+	if exp := b.failures - b.threshold; exp < 64 && uint64(1)<<exp < skipped {
+		skipped = uint64(1) << exp
+	}
+	b.nextAttempt = epoch + 1 + beacon.EpochTime(skipped)
+}
+
+// succeeded records a successful registration, resetting the backoff.
+func (b *registrationBackoff) succeeded() {
+	b.failures = 0
+	b.nextAttempt = 0
+}
+
+// retry allows the next re-registration attempt to proceed immediately. The number of
+// consecutive failures is retained so that the backoff keeps growing in case the attempt fails.
+func (b *registrationBackoff) retry() {
+	b.nextAttempt = 0
+}
+
+func newRegistrationBackoff(cfg *registrationConfig.BackoffConfig) *registrationBackoff {
+	return &registrationBackoff{
+		threshold:  cfg.Threshold,
+		maxSkipped: cfg.MaxSkippedEpochs,
+	}
+}
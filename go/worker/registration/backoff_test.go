@@ -0,0 +1,62 @@
+package registration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	beacon "github.com/oasisprotocol/oasis-core/go/beacon/api"
+	registrationConfig "github.com/oasisprotocol/oasis-core/go/worker/registration/config"
+)
+
+func TestRegistrationBackoff(t *testing.T) {
+	require := require.New(t)
+
+	b := newRegistrationBackoff(&registrationConfig.BackoffConfig{
+		Threshold:        2,
+		MaxSkippedEpochs: 4,
+	})
+
+	// Below the threshold every epoch is attempted.
+	epoch := beacon.EpochTime(10)
+	require.True(b.shouldAttempt(epoch))
+	b.failed(epoch)
+	require.False(b.isBackingOff())
+	require.True(b.shouldAttempt(epoch + 1))
+
+	// Reaching the threshold skips an exponentially growing number of epochs.
+	for _, skipped := range []beacon.EpochTime{1, 2, 4, 4} {
+		epoch++
+		require.True(b.shouldAttempt(epoch))
+		b.failed(epoch)
+		require.True(b.isBackingOff())
+		for e := epoch + 1; e <= epoch+skipped; e++ {
+			require.False(b.shouldAttempt(e), "epoch %d should be skipped", e)
+		}
+		epoch += skipped + 1
+		require.True(b.shouldAttempt(epoch))
+		epoch--
+	}
+	require.EqualValues(5, b.failures)
+
+	// Relevant events allow an immediate retry while the backoff keeps growing.
+	epoch++
+	b.failed(epoch)
+	require.False(b.shouldAttempt(epoch + 1))
+	b.retry()
+	require.False(b.isBackingOff())
+	require.True(b.shouldAttempt(epoch + 1))
+	require.EqualValues(6, b.failures)
+
+	// Success resets the backoff.
+	b.succeeded()
+	require.EqualValues(0, b.failures)
+	require.True(b.shouldAttempt(epoch + 1))
+
+	// A zero threshold disables the backoff.
+	b = newRegistrationBackoff(&registrationConfig.BackoffConfig{})
+	for i := 0; i < 100; i++ {
+		b.failed(epoch)
+		require.True(b.shouldAttempt(epoch + 1))
+	}
+}
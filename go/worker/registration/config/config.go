@@ -15,6 +15,18 @@ type Config struct {
 
 	// EntityID to use as the node owner in registrations (public key).
 	EntityID string `yaml:"entity_id"`
+
+	// Backoff configures backing off from re-registration after repeated failures.
+	Backoff BackoffConfig `yaml:"backoff,omitempty"`
+}
+
+// BackoffConfig is the re-registration backoff configuration structure.
+type BackoffConfig struct {
+	// Number of consecutive failed re-registration attempts after which the node starts skipping
+	// epochs between attempts (0 disables backoff, which is the default).
+	Threshold uint64 `yaml:"threshold"`
+	// Maximum number of epochs to skip between re-registration attempts.
+	MaxSkippedEpochs uint64 `yaml:"max_skipped_epochs"`
 }
 
 // Validate validates the configuration settings.
@@ -34,6 +46,10 @@ func (c *Config) Validate() error {
 			return fmt.Errorf("malformed entity ID: %w", err)
 		}
 	}
+
+	if c.Backoff.Threshold > 0 && c.Backoff.MaxSkippedEpochs == 0 {
+		return fmt.Errorf("backoff.max_skipped_epochs must be set when backoff is enabled")
+	}
 	return nil
 }
 
@@ -42,5 +58,9 @@ func DefaultConfig() Config {
 	return Config{
 		Entity:   "",
 		EntityID: "",
+		Backoff: BackoffConfig{
+			Threshold:        0,
+			MaxSkippedEpochs: 0,
+		},
 	}
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/entity"
+	"github.com/oasisprotocol/oasis-core/go/common/errors"
 	"github.com/oasisprotocol/oasis-core/go/common/identity"
 	"github.com/oasisprotocol/oasis-core/go/common/logging"
 	"github.com/oasisprotocol/oasis-core/go/common/node"
@@ -33,6 +34,7 @@ import (
 	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
 	runtimeRegistry "github.com/oasisprotocol/oasis-core/go/runtime/registry"
 	sentryClient "github.com/oasisprotocol/oasis-core/go/sentry/client"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 	workerCommon "github.com/oasisprotocol/oasis-core/go/worker/common"
 )
 
@@ -46,6 +48,7 @@ const (
 
 var (
 	deregistrationRequestStoreKey = []byte("deregistration requested")
+	pausedRuntimesStoreKey        = []byte("paused runtimes")
 
 	allowUnroutableAddresses bool
 
@@ -206,8 +209,9 @@ type Worker struct { // nolint: maligned
 	logger    *logging.Logger
 	consensus consensus.Backend
 
-	roleProviders []*roleProvider
-	registerCh    chan struct{}
+	roleProviders  []*roleProvider
+	registerCh     chan struct{}
+	pausedRuntimes map[common.Namespace]struct{}
 
 	status control.RegistrationStatus
 }
@@ -336,12 +340,32 @@ func (w *Worker) registrationLoop() { // nolint: gocyclo
 	entityCh, entitySub, _ := w.registry.WatchEntities(w.ctx)
 	defer entitySub.Close()
 
+	// Retry re-registration when backing off and the accounts of the entity or the registration
+	// signer are updated (e.g., stake is escrowed), as this may resolve the failure.
+	var stakingCh <-chan *staking.Event
+	if w.consensus != nil {
+		var stakingSub pubsub.ClosableSubscription
+		stakingCh, stakingSub, err = w.consensus.Staking().WatchEvents(w.ctx)
+		switch err {
+		case nil:
+			defer stakingSub.Close()
+		default:
+			w.logger.Error("failed to watch staking events",
+				"err", err,
+			)
+		}
+	}
+	entityAddr := staking.NewAddress(w.entityID)
+	signerAddr := staking.NewAddress(w.registrationSigner.Public())
+
 	var (
 		epoch beacon.EpochTime = beacon.EpochInvalid
 
 		reregisterHeight int64 = math.MaxInt64
 
 		first = true
+
+		regBackoff = newRegistrationBackoff(&config.GlobalConfig.Registration.Backoff)
 	)
 Loop:
 	for {
@@ -387,8 +411,19 @@ Loop:
 			if !ev.IsRegistration || !ev.Entity.ID.Equal(w.entityID) {
 				continue
 			}
+			regBackoff.retry()
+		case ev := <-stakingCh:
+			// Account update, only relevant when backing off.
+			if !regBackoff.isBackingOff() || (!ev.Involves(entityAddr) && !ev.Involves(signerAddr)) {
+				continue
+			}
+			w.logger.Info("retrying re-registration after account update",
+				"height", ev.Height,
+			)
+			regBackoff.retry()
 		case <-w.registerCh:
 			// Notification that a role provider has been updated.
+			regBackoff.retry()
 		}
 
 		// We need to know the current epoch before we can register.
@@ -399,6 +434,16 @@ Loop:
 		// Disarm the re-registration delay height.
 		reregisterHeight = math.MaxInt64
 
+		// Skip re-registration while backing off after repeated failures.
+		if !regBackoff.shouldAttempt(epoch) {
+			w.logger.Info("backing off from re-registration after repeated failures",
+				"epoch", epoch,
+				"next_attempt_epoch", regBackoff.nextAttempt,
+				"failures", regBackoff.failures,
+			)
+			continue
+		}
+
 		// If there are any role providers which are still not ready, we must wait for more
 		// notifications.
 		hooks, cbs, vers := func() (h []RegisterNodeHook, cbs []RegisterNodeCallback, vers []uint64) {
@@ -410,11 +455,26 @@ Loop:
 			for _, rp := range w.roleProviders {
 				rp.Lock()
 				role := rp.role
+				runtimeID := rp.runtimeID
 				hook := rp.hook
 				cb := rp.cb
 				ver := rp.version
 				rp.Unlock()
 
+				// Paused runtimes are not included in the node descriptor.
+				if runtimeID != nil {
+					if _, paused := w.pausedRuntimes[*runtimeID]; paused {
+						w.logger.Debug("skipping role provider for paused runtime",
+							"role", role,
+							"runtime_id", *runtimeID,
+						)
+						h = append(h, func(*node.Node) error { return nil })
+						cbs = append(cbs, nil)
+						vers = append(vers, ver)
+						continue
+					}
+				}
+
 				w.logger.Debug("role provider hook",
 					"ver", ver,
 					"role", role,
@@ -505,11 +565,27 @@ Loop:
 				// and abort early.
 				return
 			}
+			if errors.IsRetriable(err) {
+				// Transient failures (e.g., connectivity issues) do not count towards the backoff
+				// as they do not indicate that the node is unable to register.
+				w.logger.Error("failed to re-register node, will retry",
+					"err", err,
+				)
+				continue
+			}
+			regBackoff.failed(epoch)
+			w.updateBackoffStatus(regBackoff)
+
 			w.logger.Error("failed to re-register node",
 				"err", err,
+				"failures", regBackoff.failures,
+				"next_attempt_epoch", regBackoff.nextAttempt,
 			)
 			continue
 		}
+		regBackoff.succeeded()
+		w.updateBackoffStatus(regBackoff)
+
 		if first {
 			close(w.initialRegCh)
 			first = false
@@ -540,6 +616,13 @@ Loop:
 	}
 }
 
+func (w *Worker) updateBackoffStatus(regBackoff *registrationBackoff) {
+	w.Lock()
+	defer w.Unlock()
+
+	w.status.NextAttemptEpoch = regBackoff.nextAttempt
+}
+
 func (w *Worker) metricsWorker() {
 	w.logger.Info("delaying metrics worker start until initial registration")
 	select {
@@ -856,7 +939,8 @@ func (w *Worker) registerNode(epoch beacon.EpochTime, hook RegisterNodeHook) (er
 		VRF: node.VRFInfo{
 			ID: w.identity.VRFSigner.Public(),
 		},
-		SoftwareVersion: node.SoftwareVersion(version.SoftwareVersion),
+		SoftwareVersion:   node.SoftwareVersion(version.SoftwareVersion),
+		DebugCapabilities: flags.DebugCapabilities(),
 	}
 
 	// Update the registration status on successful or failed registration.
@@ -868,12 +952,17 @@ func (w *Worker) registerNode(epoch beacon.EpochTime, hook RegisterNodeHook) (er
 		case nil:
 			w.status.LastAttemptSuccessful = true
 			w.status.LastAttemptErrorMessage = ""
+			w.status.LastAttemptErrorModule = ""
+			w.status.LastAttemptErrorCode = 0
+			w.status.ConsecutiveFailures = 0
 			w.status.LastAttempt = time.Now()
 			w.status.LastRegistration = w.status.LastAttempt
 			w.status.Descriptor = &nodeDesc
 		default:
 			w.status.LastAttemptSuccessful = false
 			w.status.LastAttemptErrorMessage = err.Error()
+			w.status.LastAttemptErrorModule, w.status.LastAttemptErrorCode = errors.Code(err)
+			w.status.ConsecutiveFailures++
 			w.status.LastAttempt = time.Now()
 			if w.status.Descriptor != nil {
 				if w.status.Descriptor.Expiration < uint64(epoch) {
@@ -1009,6 +1098,63 @@ func (w *Worker) RequestDeregistration() error {
 	return nil
 }
 
+// SetRuntimePaused marks the given runtime as paused or resumed and triggers
+// a re-registration. Paused runtimes are excluded from the node descriptor
+// and the paused state is persisted across node restarts.
+func (w *Worker) SetRuntimePaused(runtimeID common.Namespace, paused bool) error {
+	w.Lock()
+	defer w.Unlock()
+
+	_, wasPaused := w.pausedRuntimes[runtimeID]
+	if wasPaused == paused {
+		return nil
+	}
+
+	pausedRuntimes := make([]common.Namespace, 0, len(w.pausedRuntimes)+1)
+	for id := range w.pausedRuntimes {
+		if id == runtimeID {
+			continue
+		}
+		pausedRuntimes = append(pausedRuntimes, id)
+	}
+	if paused {
+		pausedRuntimes = append(pausedRuntimes, runtimeID)
+	}
+	if err := w.store.PutCBOR(pausedRuntimesStoreKey, pausedRuntimes); err != nil {
+		w.logger.Error("can't persist paused runtimes",
+			"err", err,
+		)
+		return err
+	}
+
+	if paused {
+		w.pausedRuntimes[runtimeID] = struct{}{}
+	} else {
+		delete(w.pausedRuntimes, runtimeID)
+	}
+
+	// Trigger a re-registration so that the node descriptor is updated.
+	select {
+	case w.registerCh <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// PausedRuntimes returns the identifiers of all runtimes that are currently
+// paused.
+func (w *Worker) PausedRuntimes() []common.Namespace {
+	w.RLock()
+	defer w.RUnlock()
+
+	pausedRuntimes := make([]common.Namespace, 0, len(w.pausedRuntimes))
+	for id := range w.pausedRuntimes {
+		pausedRuntimes = append(pausedRuntimes, id)
+	}
+	return pausedRuntimes
+}
+
 // WillNeverRegister returns true iff the worker will never register.
 func (w *Worker) WillNeverRegister() bool {
 	return !w.entityID.IsValid() || w.registrationSigner == nil
@@ -1082,6 +1228,16 @@ func New(
 		return nil, err
 	}
 
+	var storedPausedRuntimes []common.Namespace
+	err = serviceStore.GetCBOR(pausedRuntimesStoreKey, &storedPausedRuntimes)
+	if err != nil && err != persistent.ErrNotFound {
+		return nil, err
+	}
+	pausedRuntimes := make(map[common.Namespace]struct{}, len(storedPausedRuntimes))
+	for _, id := range storedPausedRuntimes {
+		pausedRuntimes[id] = struct{}{}
+	}
+
 	w := &Worker{
 		workerCommonCfg:    workerCommonCfg,
 		store:              serviceStore,
@@ -1102,6 +1258,7 @@ func New(
 		consensus:          consensus,
 		p2p:                p2p,
 		registerCh:         make(chan struct{}, 1),
+		pausedRuntimes:     pausedRuntimes,
 	}
 
 	w.storedDeregister = storedDeregister
@@ -17,6 +17,10 @@ type ControlConfig struct {
 
 	// Public keys of upstream nodes that are allowed to connect to sentry control endpoint.
 	AuthorizedPubkeys []string `yaml:"authorized_pubkeys"`
+
+	// Enable the standard gRPC health and server reflection services on the sentry control
+	// endpoint.
+	Introspection bool `yaml:"introspection,omitempty"`
 }
 
 // Validate validates the configuration settings.
@@ -111,10 +111,11 @@ func New(backend api.Backend, identity *identity.Identity) (*Worker, error) {
 			peerPubkeyAuth.AllowPeerPublicKey(pk)
 		}
 		grpcServer, err := grpc.NewServer(&grpc.ServerConfig{
-			Name:     "sentry",
-			Port:     config.GlobalConfig.Sentry.Control.Port,
-			Identity: identity,
-			AuthFunc: peerPubkeyAuth.AuthFunc,
+			Name:                "sentry",
+			Port:                config.GlobalConfig.Sentry.Control.Port,
+			Identity:            identity,
+			AuthFunc:            peerPubkeyAuth.AuthFunc,
+			EnableIntrospection: config.GlobalConfig.Sentry.Control.Introspection,
 		})
 		if err != nil {
 			return nil, fmt.Errorf("worker/sentry: failed to create a new gRPC server: %w", err)
@@ -10,6 +10,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/oasisprotocol/oasis-core/go/p2p/rpc"
 	storageApi "github.com/oasisprotocol/oasis-core/go/storage/api"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/checkpoint"
 	storageSync "github.com/oasisprotocol/oasis-core/go/worker/storage/p2p/sync"
@@ -20,6 +21,14 @@ const (
 	cpListsTimeout = 30 * time.Second
 	// cpRestoreTimeout is the timeout for restoring a checkpoint chunk from a node.
 	cpRestoreTimeout = 60 * time.Second
+	// restoreProgressLogSteps is the number of times restore progress is logged per checkpoint.
+	restoreProgressLogSteps = 10
+	// maxChunkFetchAttempts is the maximum number of failed attempts to fetch a single chunk
+	// before giving up on the checkpoint.
+	maxChunkFetchAttempts = 16
+	// chunkRetryDelay is the time to wait before retrying a chunk in case all peers that
+	// advertised the checkpoint are blacklisted.
+	chunkRetryDelay = 1 * time.Second
 
 	checkpointStatusDone = 0
 	checkpointStatusNext = 1
@@ -55,6 +64,9 @@ type chunk struct {
 
 	// checkpoint points to the checkpoint this chunk originated from.
 	checkpoint *storageSync.Checkpoint
+
+	// attempts is the number of failed attempts to fetch and restore this chunk.
+	attempts int
 }
 
 type chunkHeap struct {
@@ -80,6 +92,7 @@ func (h *chunkHeap) Pop() interface{} {
 
 func (n *Node) checkpointChunkFetcher(
 	ctx context.Context,
+	scorer *peerScorer,
 	chunkDispatchCh chan *chunk,
 	chunkReturnCh chan *chunk,
 	errorCh chan int,
@@ -96,22 +109,46 @@ func (n *Node) checkpointChunkFetcher(
 			}
 		}
 
+		// Select one of the peers that advertised the checkpoint, spreading chunks among peers.
+		peer := scorer.selectPeer(chunk.checkpoint.Peers)
+		if peer == nil {
+			// All peers are currently blacklisted, retry later.
+			select {
+			case <-time.After(chunkRetryDelay):
+			case <-ctx.Done():
+				return
+			}
+			chunkReturnCh <- chunk
+			continue
+		}
+		peerID := peer.PeerID()
+
 		chunkCtx, cancel := context.WithTimeout(ctx, cpRestoreTimeout)
-		defer cancel()
 
-		// Fetch chunk from peers.
+		// Fetch chunk from the selected peer.
+		start := time.Now()
 		rsp, pf, err := n.storageSync.GetCheckpointChunk(chunkCtx, &storageSync.GetCheckpointChunkRequest{
 			Version: chunk.Version,
 			Root:    chunk.Root,
 			Index:   chunk.Index,
 			Digest:  chunk.Digest,
-		}, chunk.checkpoint)
+		}, &storageSync.Checkpoint{
+			Metadata: chunk.checkpoint.Metadata,
+			Peers:    []rpc.PeerFeedback{peer},
+		})
+		latency := time.Since(start)
 		if err != nil {
-			n.logger.Error("failed to fetch chunk from peers",
+			cancel()
+			blacklisted := scorer.recordFailure(peerID)
+			n.logger.Error("failed to fetch chunk from peer",
 				"err", err,
 				"chunk", chunk.Index,
+				"peer_id", peerID,
+				"blacklisted", blacklisted,
 			)
-			chunkReturnCh <- chunk
+			if !n.retryChunk(chunk, chunkReturnCh, errorCh) {
+				return
+			}
 			continue
 		}
 
@@ -121,6 +158,7 @@ func (n *Node) checkpointChunkFetcher(
 
 		switch {
 		case done:
+			scorer.recordSuccess(peerID, latency)
 			pf.RecordSuccess()
 			// Signal to the toplevel handler that we're done.
 			chunkReturnCh <- nil
@@ -129,14 +167,19 @@ func (n *Node) checkpointChunkFetcher(
 			n.logger.Error("chunk restoration failed",
 				"chunk", chunk.Index,
 				"root", chunk.Root,
+				"peer_id", peerID,
 				"err", err,
 			)
 
 			switch {
 			case errors.Is(err, checkpoint.ErrChunkCorrupted):
+				scorer.recordCorrupt(peerID)
 				pf.RecordFailure()
-				chunkReturnCh <- chunk
+				if !n.retryChunk(chunk, chunkReturnCh, errorCh) {
+					return
+				}
 			case errors.Is(err, checkpoint.ErrChunkProofVerificationFailed):
+				scorer.recordCorrupt(peerID)
 				pf.RecordBadPeer()
 
 				// Also punish all peers that advertised this checkpoint.
@@ -151,12 +194,51 @@ func (n *Node) checkpointChunkFetcher(
 				return
 			}
 		default:
+			scorer.recordSuccess(peerID, latency)
 			pf.RecordSuccess()
+			n.logCheckpointRestoreProgress(chunk.checkpoint)
 		}
 	}
 }
 
-func (n *Node) handleCheckpoint(check *storageSync.Checkpoint, maxParallelRequests uint) (cpStatus int, rerr error) {
+// retryChunk returns the given chunk to the dispatcher so that it can be retried, possibly from
+// a different peer. Already restored chunks are kept, so the restore resumes where it left off.
+//
+// Returns false in case the chunk failed too many times and the checkpoint should be skipped.
+func (n *Node) retryChunk(chunk *chunk, chunkReturnCh chan *chunk, errorCh chan int) bool {
+	chunk.attempts++
+	if chunk.attempts >= maxChunkFetchAttempts {
+		n.logger.Error("too many failed attempts to fetch chunk, skipping checkpoint",
+			"chunk", chunk.Index,
+			"root", chunk.Root,
+			"attempts", chunk.attempts,
+		)
+		errorCh <- checkpointStatusNext
+		return false
+	}
+	chunkReturnCh <- chunk
+	return true
+}
+
+// logCheckpointRestoreProgress periodically logs the progress of the checkpoint restore.
+func (n *Node) logCheckpointRestoreProgress(check *storageSync.Checkpoint) {
+	progress := n.localStorage.Checkpointer().GetRestoreProgress()
+	if progress == nil || progress.Total == 0 {
+		return
+	}
+
+	step := max(progress.Total/restoreProgressLogSteps, 1)
+	if progress.Restored%step != 0 {
+		return
+	}
+	n.logger.Info("checkpoint restore in progress",
+		"root", check.Root,
+		"restored", progress.Restored,
+		"total", progress.Total,
+	)
+}
+
+func (n *Node) handleCheckpoint(check *storageSync.Checkpoint, scorer *peerScorer, maxParallelRequests uint) (cpStatus int, rerr error) {
 	if err := n.localStorage.Checkpointer().StartRestore(n.ctx, check.Metadata); err != nil {
 		// Any previous restores were already aborted by the driver up the call stack, so
 		// things should have been going smoothly here; bail.
@@ -193,7 +275,7 @@ func (n *Node) handleCheckpoint(check *storageSync.Checkpoint, maxParallelReques
 		workerGroup.Add(1)
 		go func() {
 			defer workerGroup.Done()
-			n.checkpointChunkFetcher(ctx, chunkDispatchCh, chunkReturnCh, errorCh)
+			n.checkpointChunkFetcher(ctx, scorer, chunkDispatchCh, chunkReturnCh, errorCh)
 		}()
 	}
 	go func() {
@@ -253,7 +335,6 @@ func (n *Node) handleCheckpoint(check *storageSync.Checkpoint, maxParallelReques
 				// Restoration completed, no more chunks.
 				return checkpointStatusDone, nil
 			}
-			// TODO: Per-chunk backoff?
 			heap.Push(chunks, returned)
 
 		case status := <-errorCh:
@@ -359,6 +440,9 @@ func (n *Node) syncCheckpoints(genesisRound uint64, wantOnlyGenesis bool) (*bloc
 		cps = filteredCps
 	}
 
+	// Peer scores are shared among all checkpoints so that misbehaving peers are avoided.
+	scorer := newPeerScorer()
+
 	// Try all the checkpoints now, from most recent backwards.
 	var (
 		prevVersion      = ^uint64(0)
@@ -417,7 +501,7 @@ func (n *Node) syncCheckpoints(genesisRound uint64, wantOnlyGenesis bool) (*bloc
 			}
 		}
 
-		status, err := n.handleCheckpoint(check, n.checkpointSyncCfg.ChunkFetcherCount)
+		status, err := n.handleCheckpoint(check, scorer, n.checkpointSyncCfg.ChunkFetcherCount)
 		switch status {
 		case checkpointStatusDone:
 			n.logger.Info("successfully restored from checkpoint", "root", check.Root, "mask", mask)
@@ -39,11 +39,20 @@ var (
 		[]string{"runtime"},
 	)
 
+	storageWorkerPrefetchDuration = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Name: "oasis_worker_storage_prefetch_duration",
+			Help: "Duration of state prefetches after committee elections (seconds).",
+		},
+		[]string{"runtime"},
+	)
+
 	storageWorkerCollectors = []prometheus.Collector{
 		storageWorkerLastFullRound,
 		storageWorkerLastSyncedRound,
 		storageWorkerLastPendingRound,
 		storageWorkerRoundSyncLatency,
+		storageWorkerPrefetchDuration,
 	}
 
 	prometheusOnce sync.Once
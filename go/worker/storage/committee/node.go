@@ -122,6 +122,9 @@ type Node struct { // nolint: maligned
 	localStorage storageApi.LocalBackend
 
 	storageSync storageSync.Client
+	storagePub  storagePub.Client
+
+	checkpointTracker *storageSync.CheckpointTracker
 
 	undefinedRound uint64
 
@@ -142,6 +145,7 @@ type Node struct { // nolint: maligned
 	blockCh    *channels.InfiniteChannel
 	diffCh     chan *fetchedDiff
 	finalizeCh chan finalizeResult
+	prefetchCh chan struct{}
 
 	ctx       context.Context
 	ctxCancel context.CancelFunc
@@ -187,6 +191,7 @@ func NewNode(
 		blockCh:    channels.NewInfiniteChannel(),
 		diffCh:     make(chan *fetchedDiff),
 		finalizeCh: make(chan finalizeResult),
+		prefetchCh: make(chan struct{}, 1),
 
 		quitCh:       make(chan struct{}),
 		workerQuitCh: make(chan struct{}),
@@ -262,12 +267,15 @@ func NewNode(
 	})
 
 	// Register storage sync service.
-	commonNode.P2P.RegisterProtocolServer(storageSync.NewServer(commonNode.ChainContext, commonNode.Runtime.ID(), localStorage))
+	n.checkpointTracker = storageSync.NewCheckpointTracker()
+	commonNode.P2P.RegisterProtocolServer(storageSync.NewServer(commonNode.ChainContext, commonNode.Runtime.ID(), localStorage, n.checkpointTracker))
 	n.storageSync = storageSync.NewClient(commonNode.P2P, commonNode.ChainContext, commonNode.Runtime.ID())
+	n.storagePub = storagePub.NewPagingClient(commonNode.P2P, commonNode.ChainContext, commonNode.Runtime.ID())
 
 	// Register storage pub service if configured.
 	if rpcRoleProvider != nil {
 		commonNode.P2P.RegisterProtocolServer(storagePub.NewServer(commonNode.ChainContext, commonNode.Runtime.ID(), localStorage))
+		commonNode.P2P.RegisterProtocolServer(storagePub.NewPagingServer(commonNode.ChainContext, commonNode.Runtime.ID(), localStorage))
 	}
 
 	return n, nil
@@ -287,6 +295,9 @@ func (n *Node) Start() error {
 	if config.GlobalConfig.Storage.Checkpointer.Enabled {
 		go n.consensusCheckpointSyncer()
 	}
+	if config.GlobalConfig.Mode == config.ModeCompute {
+		go n.electionWatcher()
+	}
 	return nil
 }
 
@@ -331,7 +342,7 @@ func (n *Node) GetStatus(context.Context) (*api.Status, error) {
 }
 
 func (n *Node) PauseCheckpointer(pause bool) error {
-	if !commonFlags.DebugDontBlameOasis() {
+	if !commonFlags.DebugServices() {
 		return api.ErrCantPauseCheckpointer
 	}
 	n.checkpointer.Pause(pause)
@@ -1259,6 +1270,18 @@ mainLoop:
 			triggerRoundFetches()
 			heartbeat.reset()
 
+		case <-n.prefetchCh:
+			// The node has been elected into a committee, make sure any outstanding rounds are
+			// fetched immediately and warm up local state.
+			if latestBlockRound != n.undefinedRound {
+				triggerRoundFetches()
+			}
+			fetcherGroup.Add(1)
+			go func() {
+				defer fetcherGroup.Done()
+				n.prefetchState(n.ctx)
+			}()
+
 		case <-heartbeat.C:
 			if latestBlockRound != n.undefinedRound {
 				n.logger.Debug("heartbeat", "in_flight_rounds", len(syncingRounds))
@@ -1339,7 +1362,8 @@ func (p *pruneHandler) Prune(rounds []uint64) error {
 			)
 		}
 
-		// TODO: Make sure we don't prune rounds that need to be checkpointed but haven't been yet.
+		// NOTE: Rounds that need to be checkpointed but haven't been yet are only retained when
+		//       using the keep checkpoints pruning strategy (see EarliestRetainedRound).
 
 		p.logger.Debug("pruning storage for round", "round", round)
 
@@ -1362,3 +1386,18 @@ func (p *pruneHandler) Prune(rounds []uint64) error {
 
 	return nil
 }
+
+// EarliestRetainedRound implements history.PruneRetainer.
+func (p *pruneHandler) EarliestRetainedRound() (uint64, bool, error) {
+	round, ok, err := p.node.checkpointer.EarliestRetainedVersion(p.node.ctx)
+	if err != nil {
+		return 0, false, err
+	}
+
+	// Also retain rounds following checkpoints that are still being downloaded by peers, even
+	// if those checkpoints have been garbage collected in the meantime.
+	if inFlight, iok := p.node.checkpointTracker.EarliestInFlightVersion(); iok && (!ok || inFlight < round) {
+		round, ok = inFlight, true
+	}
+	return round, ok, nil
+}
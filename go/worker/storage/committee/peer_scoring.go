@@ -0,0 +1,164 @@
+package committee
+
+import (
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core"
+
+	"github.com/oasisprotocol/oasis-core/go/p2p/rpc"
+)
+
+const (
+	// maxPeerConsecutiveFailures is the number of consecutive failed requests after which a peer
+	// is temporarily blacklisted.
+	maxPeerConsecutiveFailures = 3
+	// peerBlacklistDuration is the initial duration for which a peer is blacklisted. The duration
+	// doubles every time the same peer is blacklisted again, up to maxPeerBlacklistDuration.
+	peerBlacklistDuration = 30 * time.Second
+	// maxPeerBlacklistDuration is the maximum duration for which a peer is blacklisted.
+	maxPeerBlacklistDuration = 10 * time.Minute
+
+	// peerLatencyInvAlpha is the inverse alpha (1/alpha) value for computing the exponential
+	// moving average of per-peer request latencies.
+	peerLatencyInvAlpha = 5
+)
+
+type peerScore struct {
+	inflight            int
+	successes           uint64
+	failures            uint64
+	consecutiveFailures int
+	avgLatency          time.Duration
+
+	blacklistCount   int
+	blacklistedUntil time.Time
+}
+
+// score returns the peer score (lower is better).
+func (ps *peerScore) score() float64 {
+	if ps.successes+ps.failures == 0 {
+		return 0
+	}
+	failRate := float64(ps.failures) / float64(ps.successes+ps.failures)
+	return float64(ps.avgLatency) * (1 + failRate)
+}
+
+// peerScorer keeps track of peer performance during a storage sync session in order to spread
+// requests among multiple peers and to temporarily blacklist slow or misbehaving peers.
+//
+// This complements the protocol-wide peer manager scoring which only determines peer order.
+type peerScorer struct {
+	sync.Mutex
+
+	peers map[core.PeerID]*peerScore
+	now   func() time.Time
+}
+
+func (s *peerScorer) getLocked(peerID core.PeerID) *peerScore {
+	ps, ok := s.peers[peerID]
+	if !ok {
+		ps = &peerScore{}
+		s.peers[peerID] = ps
+	}
+	return ps
+}
+
+// selectPeer selects the best candidate peer that is not blacklisted and reserves a request slot
+// for it. Peers with fewer in-flight requests are preferred so that requests are spread among all
+// usable peers.
+//
+// Returns nil in case all candidates are blacklisted. After the request completes, the caller
+// must record the outcome using one of the record methods.
+func (s *peerScorer) selectPeer(candidates []rpc.PeerFeedback) rpc.PeerFeedback {
+	s.Lock()
+	defer s.Unlock()
+
+	now := s.now()
+	var (
+		best      rpc.PeerFeedback
+		bestScore *peerScore
+	)
+	for _, pf := range candidates {
+		ps := s.getLocked(pf.PeerID())
+		if now.Before(ps.blacklistedUntil) {
+			continue
+		}
+
+		switch {
+		case bestScore == nil:
+		case ps.inflight < bestScore.inflight:
+		case ps.inflight == bestScore.inflight && ps.score() < bestScore.score():
+		default:
+			continue
+		}
+		best, bestScore = pf, ps
+	}
+	if bestScore != nil {
+		bestScore.inflight++
+	}
+	return best
+}
+
+// recordSuccess records a successful request to the given peer.
+func (s *peerScorer) recordSuccess(peerID core.PeerID, latency time.Duration) {
+	s.Lock()
+	defer s.Unlock()
+
+	ps := s.getLocked(peerID)
+	ps.inflight = max(ps.inflight-1, 0)
+	ps.successes++
+	ps.consecutiveFailures = 0
+	if ps.avgLatency == 0 {
+		ps.avgLatency = latency
+	} else {
+		ps.avgLatency += (latency - ps.avgLatency) / peerLatencyInvAlpha
+	}
+}
+
+// recordFailure records a failed (e.g., timed out) request to the given peer. The peer is
+// blacklisted after too many consecutive failures.
+//
+// Returns true iff the peer has been blacklisted.
+func (s *peerScorer) recordFailure(peerID core.PeerID) bool {
+	s.Lock()
+	defer s.Unlock()
+
+	ps := s.getLocked(peerID)
+	ps.inflight = max(ps.inflight-1, 0)
+	ps.failures++
+	ps.consecutiveFailures++
+	if ps.consecutiveFailures < maxPeerConsecutiveFailures {
+		return false
+	}
+	s.blacklistLocked(ps)
+	return true
+}
+
+// recordCorrupt records a request to the given peer that returned corrupted data. The peer is
+// blacklisted immediately.
+func (s *peerScorer) recordCorrupt(peerID core.PeerID) {
+	s.Lock()
+	defer s.Unlock()
+
+	ps := s.getLocked(peerID)
+	ps.inflight = max(ps.inflight-1, 0)
+	ps.failures++
+	s.blacklistLocked(ps)
+}
+
+func (s *peerScorer) blacklistLocked(ps *peerScore) {
+	duration := peerBlacklistDuration << min(ps.blacklistCount, 10)
+	duration = min(duration, maxPeerBlacklistDuration)
+
+	ps.blacklistCount++
+	ps.consecutiveFailures = 0
+	ps.blacklistedUntil = s.now().Add(duration)
+}
+
+func newPeerScorer() *peerScorer {
+	return &peerScorer{
+		peers: make(map[core.PeerID]*peerScore),
+		now:   time.Now,
+	}
+}
@@ -0,0 +1,88 @@
+package committee
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/p2p/rpc"
+)
+
+type testPeerFeedback struct {
+	peerID core.PeerID
+}
+
+func (pf *testPeerFeedback) RecordSuccess() {}
+
+func (pf *testPeerFeedback) RecordFailure() {}
+
+func (pf *testPeerFeedback) RecordBadPeer() {}
+
+func (pf *testPeerFeedback) PeerID() core.PeerID {
+	return pf.peerID
+}
+
+func TestPeerScorer(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1000, 0)
+	scorer := newPeerScorer()
+	scorer.now = func() time.Time { return now }
+
+	peerA := &testPeerFeedback{peerID: "peer-a"}
+	peerB := &testPeerFeedback{peerID: "peer-b"}
+	peers := []rpc.PeerFeedback{peerA, peerB}
+
+	// Requests should be spread among peers.
+	first := scorer.selectPeer(peers)
+	second := scorer.selectPeer(peers)
+	require.NotNil(first)
+	require.NotNil(second)
+	require.NotEqual(first.PeerID(), second.PeerID())
+	scorer.recordSuccess(peerA.PeerID(), 100*time.Millisecond)
+	scorer.recordSuccess(peerB.PeerID(), 500*time.Millisecond)
+
+	// With no requests in flight, the faster peer should be preferred.
+	pf := scorer.selectPeer(peers)
+	require.Equal(peerA.PeerID(), pf.PeerID())
+	scorer.recordSuccess(peerA.PeerID(), 100*time.Millisecond)
+
+	// Corrupt data should blacklist the peer immediately.
+	pf = scorer.selectPeer(peers)
+	require.Equal(peerA.PeerID(), pf.PeerID())
+	scorer.recordCorrupt(peerA.PeerID())
+	for i := 0; i < 3; i++ {
+		pf = scorer.selectPeer(peers)
+		require.Equal(peerB.PeerID(), pf.PeerID())
+		scorer.recordSuccess(peerB.PeerID(), 500*time.Millisecond)
+	}
+
+	// Too many consecutive failures should blacklist the peer.
+	for i := 0; i < maxPeerConsecutiveFailures; i++ {
+		pf = scorer.selectPeer(peers)
+		require.Equal(peerB.PeerID(), pf.PeerID())
+		blacklisted := scorer.recordFailure(peerB.PeerID())
+		require.Equal(i == maxPeerConsecutiveFailures-1, blacklisted)
+	}
+	require.Nil(scorer.selectPeer(peers), "all peers should be blacklisted")
+
+	// Blacklisting should expire.
+	now = now.Add(peerBlacklistDuration)
+	pf = scorer.selectPeer(peers)
+	require.Equal(peerA.PeerID(), pf.PeerID())
+	scorer.recordSuccess(peerA.PeerID(), 100*time.Millisecond)
+
+	// Repeated blacklisting should take longer to expire.
+	pf = scorer.selectPeer(peers)
+	require.Equal(peerA.PeerID(), pf.PeerID())
+	scorer.recordCorrupt(peerA.PeerID())
+	now = now.Add(peerBlacklistDuration)
+	pf = scorer.selectPeer(peers)
+	require.Equal(peerB.PeerID(), pf.PeerID())
+	scorer.recordSuccess(peerB.PeerID(), 500*time.Millisecond)
+	now = now.Add(peerBlacklistDuration)
+	pf = scorer.selectPeer(peers)
+	require.NotNil(pf)
+}
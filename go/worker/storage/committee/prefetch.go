@@ -0,0 +1,134 @@
+package committee
+
+import (
+	"context"
+	"time"
+
+	scheduler "github.com/oasisprotocol/oasis-core/go/scheduler/api"
+	storageApi "github.com/oasisprotocol/oasis-core/go/storage/api"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs"
+	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/syncer"
+	storagePub "github.com/oasisprotocol/oasis-core/go/worker/storage/p2p/pub"
+)
+
+const (
+	// maxPrefetchNodes is the maximum number of state entries that are prefetched after the node
+	// gets elected into a committee.
+	maxPrefetchNodes = 1_000_000
+
+	// prefetchBatchSize is the number of state entries fetched from peers in a single request in
+	// case they are missing locally.
+	prefetchBatchSize = 1_000
+
+	// prefetchNodeCapacity is the maximum number of tree nodes held in memory during a prefetch.
+	prefetchNodeCapacity = 50_000
+
+	// prefetchValueCapacity is the maximum size of values held in memory during a prefetch.
+	prefetchValueCapacity = 16 * 1024 * 1024
+)
+
+// electionWatcher watches committee elections and triggers a state prefetch as soon as the node
+// learns that it has been newly elected into the runtime's executor committee.
+//
+// Committee elections are observed directly from the scheduler, which happens before the
+// corresponding runtime epoch transition block is processed by the workers.
+func (n *Node) electionWatcher() {
+	ch, sub, err := n.commonNode.Consensus.Scheduler().WatchCommittees(n.ctx)
+	if err != nil {
+		n.logger.Error("failed to watch committees",
+			"err", err,
+		)
+		return
+	}
+	defer sub.Close()
+
+	runtimeID := n.commonNode.Runtime.ID()
+	nodeID := n.commonNode.Identity.NodeSigner.Public()
+
+	var wasMember bool
+	for {
+		var cmte *scheduler.Committee
+		select {
+		case cmte = <-ch:
+		case <-n.ctx.Done():
+			return
+		}
+
+		if cmte.Kind != scheduler.KindComputeExecutor || !cmte.RuntimeID.Equal(&runtimeID) {
+			continue
+		}
+
+		isMember := cmte.IsMember(nodeID)
+		if isMember && !wasMember {
+			n.logger.Info("elected into executor committee, prefetching state",
+				"valid_for", cmte.ValidFor,
+			)
+
+			select {
+			case n.prefetchCh <- struct{}{}:
+			default:
+				// A prefetch is already pending.
+			}
+		}
+		wasMember = isMember
+	}
+}
+
+// prefetchState warms up local storage by reading the latest synced state.
+//
+// Subtrees that are missing locally are fetched from peers serving the storagepub protocol.
+func (n *Node) prefetchState(ctx context.Context) {
+	start := time.Now()
+	round, _, stateRoot := n.GetLastSynced()
+	if round == n.undefinedRound || stateRoot.Hash.IsEmpty() {
+		return
+	}
+
+	rs := syncer.NewPagingReadSyncer(&pubReadSyncer{n.storagePub}, syncer.DefaultProofSizeLimit)
+	tree := mkvs.NewWithRoot(rs, n.localStorage.NodeDB(), stateRoot,
+		mkvs.Capacity(prefetchNodeCapacity, prefetchValueCapacity),
+	)
+	defer tree.Close()
+
+	it := tree.NewIterator(ctx, mkvs.IteratorPrefetch(prefetchBatchSize))
+	defer it.Close()
+
+	var count int
+	for it.Rewind(); it.Valid() && count < maxPrefetchNodes; it.Next() {
+		count++
+	}
+	if err := it.Err(); err != nil {
+		n.logger.Warn("failed to prefetch state",
+			"err", err,
+			"round", round,
+		)
+		return
+	}
+
+	storageWorkerPrefetchDuration.With(n.getMetricLabels()).Observe(time.Since(start).Seconds())
+	n.logger.Info("state prefetched",
+		"round", round,
+		"entries", count,
+		"duration", time.Since(start),
+	)
+}
+
+// pubReadSyncer is a read syncer that uses the storagepub protocol.
+type pubReadSyncer struct {
+	client storagePub.Client
+}
+
+func (s *pubReadSyncer) SyncGet(ctx context.Context, request *storageApi.GetRequest) (*storageApi.ProofResponse, error) {
+	rsp, _, err := s.client.Get(ctx, request)
+	return rsp, err
+}
+
+func (s *pubReadSyncer) SyncGetPrefixes(ctx context.Context, request *storageApi.GetPrefixesRequest) (*storageApi.ProofResponse, error) {
+	rsp, _, err := s.client.GetPrefixes(ctx, request)
+	return rsp, err
+}
+
+func (s *pubReadSyncer) SyncIterate(ctx context.Context, request *storageApi.IterateRequest) (*storageApi.ProofResponse, error) {
+	rsp, _, err := s.client.Iterate(ctx, request)
+	return rsp, err
+}
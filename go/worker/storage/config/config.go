@@ -2,8 +2,11 @@
 package config
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/storage/mkvs/db"
 )
 
@@ -11,6 +14,8 @@ import (
 type Config struct {
 	// Storage backend.
 	Backend string `yaml:"backend"`
+	// Per-runtime storage backend overrides (runtime ID -> backend).
+	RuntimeBackends map[string]string `yaml:"runtime_backends,omitempty"`
 	// Maximum in-memory cache size.
 	MaxCacheSize string `yaml:"max_cache_size"`
 	// Number of concurrent storage diff fetchers.
@@ -20,6 +25,8 @@ type Config struct {
 	PublicRPCEnabled bool `yaml:"public_rpc_enabled,omitempty"`
 	// Disable initial storage sync from checkpoints.
 	CheckpointSyncDisabled bool `yaml:"checkpoint_sync_disabled,omitempty"`
+	// Maximum number of checkpoint chunks created or restored in parallel.
+	CheckpointParallelism uint `yaml:"checkpoint_parallelism,omitempty"`
 
 	// Storage checkpointer configuration.
 	Checkpointer CheckpointerConfig `yaml:"checkpointer,omitempty"`
@@ -35,13 +42,44 @@ type CheckpointerConfig struct {
 
 // Validate validates the configuration settings.
 func (c *Config) Validate() error {
-	if c.Backend != "auto" {
-		_, err := db.GetBackendByName(c.Backend)
+	if err := validateBackend(c.Backend); err != nil {
 		return err
 	}
+	for id, backend := range c.RuntimeBackends {
+		var runtimeID common.Namespace
+		if err := runtimeID.UnmarshalHex(id); err != nil {
+			return fmt.Errorf("malformed runtime identifier in runtime_backends: %w", err)
+		}
+		if err := validateBackend(backend); err != nil {
+			return fmt.Errorf("invalid backend for runtime %s: %w", id, err)
+		}
+	}
 	return nil
 }
 
+func validateBackend(backend string) error {
+	backend = strings.ToLower(backend)
+	if backend == "auto" {
+		return nil
+	}
+	_, err := db.GetBackendByName(backend)
+	return err
+}
+
+// RuntimeBackend returns the storage backend configured for the given runtime.
+func (c *Config) RuntimeBackend(runtimeID common.Namespace) string {
+	for id, backend := range c.RuntimeBackends {
+		var ns common.Namespace
+		if err := ns.UnmarshalHex(id); err != nil {
+			continue
+		}
+		if ns.Equal(&runtimeID) {
+			return strings.ToLower(backend)
+		}
+	}
+	return strings.ToLower(c.Backend)
+}
+
 // DefaultConfig returns the default configuration settings.
 func DefaultConfig() Config {
 	return Config{
@@ -50,6 +88,7 @@ func DefaultConfig() Config {
 		FetcherCount:           4,
 		PublicRPCEnabled:       false,
 		CheckpointSyncDisabled: false,
+		CheckpointParallelism:  4,
 		Checkpointer: CheckpointerConfig{
 			Enabled:       false,
 			CheckInterval: 1 * time.Minute,
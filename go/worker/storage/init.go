@@ -3,7 +3,6 @@ package storage
 
 import (
 	"path/filepath"
-	"strings"
 
 	flag "github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -32,11 +31,13 @@ func NewLocalBackend(
 	namespace common.Namespace,
 ) (api.LocalBackend, error) {
 	cfg := &api.Config{
-		Backend:      strings.ToLower(config.GlobalConfig.Storage.Backend),
+		Backend:      config.GlobalConfig.Storage.RuntimeBackend(namespace),
 		DB:           dataDir,
 		Namespace:    namespace,
 		MaxCacheSize: int64(config.ParseSizeInBytes(config.GlobalConfig.Storage.MaxCacheSize)),
 		NoFsync:      true, // Should be safe, storage will be re-applied on crashes.
+
+		CheckpointParallelism: config.GlobalConfig.Storage.CheckpointParallelism,
 	}
 
 	cfg.DB = GetLocalBackendDBDir(dataDir, cfg.Backend)
@@ -45,7 +46,7 @@ func NewLocalBackend(
 		return nil, err
 	}
 
-	crashEnabled := viper.GetBool(cfgCrashEnabled) && cmdFlags.DebugDontBlameOasis()
+	crashEnabled := viper.GetBool(cfgCrashEnabled) && cmdFlags.DebugCrashInjection()
 	if crashEnabled {
 		impl = newCrashingWrapper(impl)
 	}
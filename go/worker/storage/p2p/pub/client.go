@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/version"
 	"github.com/oasisprotocol/oasis-core/go/p2p/protocol"
 	"github.com/oasisprotocol/oasis-core/go/p2p/rpc"
 )
@@ -28,6 +29,9 @@ type Client interface {
 	// Iterate seeks to a given key and then fetches the specified number of following items based
 	// on key iteration order.
 	Iterate(ctx context.Context, request *IterateRequest) (*ProofResponse, rpc.PeerFeedback, error)
+
+	// HasPeers returns true iff there are any peers supporting the protocol.
+	HasPeers() bool
 }
 
 type client struct {
@@ -62,9 +66,23 @@ func (c *client) Iterate(ctx context.Context, request *IterateRequest) (*ProofRe
 	return &rsp, pf, nil
 }
 
+func (c *client) HasPeers() bool {
+	return len(c.mgr.GetBestPeers()) > 0
+}
+
 // NewClient creates a new storage pub protocol client.
 func NewClient(p2p rpc.P2P, chainContext string, runtimeID common.Namespace) Client {
-	pid := protocol.NewRuntimeProtocolID(chainContext, runtimeID, StoragePubProtocolID, StoragePubProtocolVersion)
+	return newClient(p2p, chainContext, runtimeID, StoragePubProtocolVersion)
+}
+
+// NewPagingClient creates a new storage pub protocol client which only uses peers supporting
+// proof size limits and continuations.
+func NewPagingClient(p2p rpc.P2P, chainContext string, runtimeID common.Namespace) Client {
+	return newClient(p2p, chainContext, runtimeID, StoragePubPagingProtocolVersion)
+}
+
+func newClient(p2p rpc.P2P, chainContext string, runtimeID common.Namespace, ver version.Version) Client {
+	pid := protocol.NewRuntimeProtocolID(chainContext, runtimeID, StoragePubProtocolID, ver)
 	mgr := rpc.NewPeerManager(p2p, pid)
 	rc := rpc.NewClient(p2p.Host(), pid)
 	rc.RegisterListener(mgr)
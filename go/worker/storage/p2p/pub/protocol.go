@@ -16,6 +16,13 @@ const StoragePubProtocolID = "storagepub"
 // StoragePubProtocolVersion is the supported version of the storage pub protocol.
 var StoragePubProtocolVersion = version.Version{Major: 2, Minor: 0, Patch: 0}
 
+// StoragePubPagingProtocolVersion is the version of the storage pub protocol that supports proof
+// size limits and continuations.
+//
+// Peers that only support StoragePubProtocolVersion reject requests that set the ProofSizeLimit
+// or Continuation fields, so these must only be sent to peers supporting this version.
+var StoragePubPagingProtocolVersion = version.Version{Major: 3, Minor: 0, Patch: 0}
+
 // Constants related to the Get method.
 const (
 	MethodGet = "Get"
@@ -50,9 +57,12 @@ func init() {
 				return []core.ProtocolID{}
 			}
 
-			protocols := make([]core.ProtocolID, len(n.Runtimes))
-			for i, rt := range n.Runtimes {
-				protocols[i] = protocol.NewRuntimeProtocolID(chainContext, rt.ID, StoragePubProtocolID, StoragePubProtocolVersion)
+			protocols := make([]core.ProtocolID, 0, 2*len(n.Runtimes))
+			for _, rt := range n.Runtimes {
+				protocols = append(protocols,
+					protocol.NewRuntimeProtocolID(chainContext, rt.ID, StoragePubProtocolID, StoragePubProtocolVersion),
+					protocol.NewRuntimeProtocolID(chainContext, rt.ID, StoragePubProtocolID, StoragePubPagingProtocolVersion),
+				)
 			}
 
 			return protocols
@@ -46,3 +46,9 @@ func (s *service) HandleRequest(ctx context.Context, method string, body cbor.Ra
 func NewServer(chainContext string, runtimeID common.Namespace, backend storage.Backend) rpc.Server {
 	return rpc.NewServer(protocol.NewRuntimeProtocolID(chainContext, runtimeID, StoragePubProtocolID, StoragePubProtocolVersion), &service{backend})
 }
+
+// NewPagingServer creates a new storage pub protocol server supporting proof size limits and
+// continuations.
+func NewPagingServer(chainContext string, runtimeID common.Namespace, backend storage.Backend) rpc.Server {
+	return rpc.NewServer(protocol.NewRuntimeProtocolID(chainContext, runtimeID, StoragePubProtocolID, StoragePubPagingProtocolVersion), &service{backend})
+}
@@ -14,6 +14,7 @@ import (
 
 type service struct {
 	backend storage.Backend
+	tracker *CheckpointTracker
 }
 
 func (s *service) HandleRequest(ctx context.Context, method string, body cbor.RawMessage) (interface{}, error) {
@@ -98,12 +99,24 @@ func (s *service) handleGetCheckpointChunk(ctx context.Context, request *GetChec
 		return nil, err
 	}
 
+	if s.tracker != nil {
+		s.tracker.served(request.Root.Version)
+	}
+
 	return &GetCheckpointChunkResponse{
 		Chunk: buf.Bytes(),
 	}, nil
 }
 
 // NewServer creates a new storage sync protocol server.
-func NewServer(chainContext string, runtimeID common.Namespace, backend storage.Backend) rpc.Server {
-	return rpc.NewServer(protocol.NewRuntimeProtocolID(chainContext, runtimeID, StorageSyncProtocolID, StorageSyncProtocolVersion), &service{backend})
+//
+// The given checkpoint tracker may be nil in which case checkpoint downloads are not tracked.
+func NewServer(chainContext string, runtimeID common.Namespace, backend storage.Backend, tracker *CheckpointTracker) rpc.Server {
+	return rpc.NewServer(
+		protocol.NewRuntimeProtocolID(chainContext, runtimeID, StorageSyncProtocolID, StorageSyncProtocolVersion),
+		&service{
+			backend: backend,
+			tracker: tracker,
+		},
+	)
 }
@@ -0,0 +1,62 @@
+package sync
+
+import (
+	"sync"
+	"time"
+)
+
+// checkpointDownloadTimeout is the time after the last served chunk after which a checkpoint
+// download is no longer considered to be in progress. It also covers the time needed by the peer
+// to fetch the diffs following the checkpoint.
+const checkpointDownloadTimeout = 30 * time.Minute
+
+// CheckpointTracker tracks checkpoints that are being downloaded by peers.
+type CheckpointTracker struct {
+	sync.Mutex
+
+	// downloads maps checkpoint versions to the time a chunk of the checkpoint was last served.
+	downloads map[uint64]time.Time
+
+	now func() time.Time
+}
+
+func (t *CheckpointTracker) served(version uint64) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.downloads[version] = t.now()
+}
+
+// EarliestInFlightVersion returns the version of the earliest checkpoint that is being downloaded
+// by peers.
+//
+// In case no checkpoints are being downloaded, false is returned.
+func (t *CheckpointTracker) EarliestInFlightVersion() (uint64, bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	var (
+		earliest uint64
+		ok       bool
+	)
+	now := t.now()
+	for version, lastServed := range t.downloads {
+		if now.Sub(lastServed) > checkpointDownloadTimeout {
+			delete(t.downloads, version)
+			continue
+		}
+		if !ok || version < earliest {
+			earliest = version
+			ok = true
+		}
+	}
+	return earliest, ok
+}
+
+// NewCheckpointTracker creates a new checkpoint download tracker.
+func NewCheckpointTracker() *CheckpointTracker {
+	return &CheckpointTracker{
+		downloads: make(map[uint64]time.Time),
+		now:       time.Now,
+	}
+}
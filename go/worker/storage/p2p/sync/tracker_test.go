@@ -0,0 +1,44 @@
+package sync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpointTracker(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1_000_000, 0)
+	tracker := NewCheckpointTracker()
+	tracker.now = func() time.Time { return now }
+
+	_, ok := tracker.EarliestInFlightVersion()
+	require.False(ok, "nothing should be in flight")
+
+	tracker.served(20)
+	now = now.Add(checkpointDownloadTimeout / 2)
+	tracker.served(30)
+	tracker.served(10)
+
+	version, ok := tracker.EarliestInFlightVersion()
+	require.True(ok)
+	require.EqualValues(10, version)
+
+	// Downloads should expire after the timeout.
+	now = now.Add(checkpointDownloadTimeout/2 + time.Second)
+	tracker.served(30)
+	version, ok = tracker.EarliestInFlightVersion()
+	require.True(ok)
+	require.EqualValues(10, version)
+
+	now = now.Add(checkpointDownloadTimeout/2 + time.Second)
+	version, ok = tracker.EarliestInFlightVersion()
+	require.True(ok)
+	require.EqualValues(30, version)
+
+	now = now.Add(checkpointDownloadTimeout)
+	_, ok = tracker.EarliestInFlightVersion()
+	require.False(ok, "all downloads should have expired")
+}
@@ -7,7 +7,10 @@
 use std::collections::BTreeMap;
 
 use crate::{
-    common::{quantity::Quantity, version::ProtocolVersions},
+    common::{
+        crypto::signature::PublicKey, namespace::Namespace, quantity::Quantity,
+        version::ProtocolVersions,
+    },
     consensus::beacon::EpochTime,
 };
 
@@ -60,6 +63,25 @@ pub struct ChangeParametersProposal {
     pub changes: Option<cbor::Value>,
 }
 
+/// Freeze entity proposal content.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct FreezeEntityProposal {
+    pub entity_id: PublicKey,
+}
+
+/// Thaw entity proposal content.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct ThawEntityProposal {
+    pub entity_id: PublicKey,
+}
+
+/// Update runtime proposal content.
+#[derive(Clone, Debug, Default, PartialEq, Eq, cbor::Encode, cbor::Decode)]
+pub struct UpdateRuntimeProposal {
+    pub runtime_id: Namespace,
+    pub changes: Option<cbor::Value>,
+}
+
 /// Consensus layer governance proposal content.
 #[derive(Clone, Debug, Default, PartialEq, Eq, cbor::Encode, cbor::Decode)]
 pub struct ProposalContent {
@@ -69,6 +91,12 @@ pub struct ProposalContent {
     pub cancel_upgrade: Option<CancelUpgradeProposal>,
     #[cbor(optional)]
     pub change_parameters: Option<ChangeParametersProposal>,
+    #[cbor(optional)]
+    pub freeze_entity: Option<FreezeEntityProposal>,
+    #[cbor(optional)]
+    pub thaw_entity: Option<ThawEntityProposal>,
+    #[cbor(optional)]
+    pub update_runtime: Option<UpdateRuntimeProposal>,
 }
 
 // Allowed governance consensus parameter changes.
@@ -88,6 +116,12 @@ pub struct ConsensusParameterChanges {
     pub upgrade_cancel_min_epoch_diff: Option<EpochTime>,
     #[cbor(optional)]
     pub enable_change_parameters_proposal: Option<bool>,
+    #[cbor(optional)]
+    pub enable_entity_freeze_proposal: Option<bool>,
+    #[cbor(optional)]
+    pub enable_update_runtime_proposal: Option<bool>,
+    #[cbor(optional)]
+    pub allowed_parameter_changes: Option<BTreeMap<String, Vec<String>>>,
 }
 
 #[cfg(test)]
@@ -31,6 +31,14 @@ pub struct LightBlock {
     pub meta: Vec<u8>,
 }
 
+/// Light consensus block without the validator set.
+#[derive(Clone, Default, Debug, cbor::Encode, cbor::Decode)]
+pub struct CompactLightBlock {
+    pub height: u64,
+    pub meta: Vec<u8>,
+    pub validator_set_index: u32,
+}
+
 /// An event emitted by the consensus layer.
 #[derive(Clone, Debug, cbor::Encode, cbor::Decode)]
 pub enum Event {
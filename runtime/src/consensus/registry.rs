@@ -260,6 +260,10 @@ pub struct Capabilities {
     /// Is the capability of a node executing batches in a TEE.
     #[cbor(optional)]
     pub tee: Option<CapabilityTEE>,
+
+    /// Whether the node only participates in executor committees as a backup worker.
+    #[cbor(optional)]
+    pub backup_only: bool,
 }
 
 /// Represents the runtimes supported by a given Oasis node.
@@ -388,6 +392,10 @@ pub struct Node {
     /// Node's oasis-node software version.
     #[cbor(optional)]
     pub software_version: Option<String>,
+
+    /// Unsafe debug capabilities enabled on the node.
+    #[cbor(optional)]
+    pub debug_capabilities: Vec<String>,
 }
 
 impl Node {
@@ -664,6 +672,29 @@ pub struct VersionInfo {
     /// The SHA256 hash of the runtime bundle (optional).
     #[cbor(optional)]
     pub bundle_checksum: Vec<u8>,
+    /// Optional deployment metadata.
+    #[cbor(optional)]
+    pub metadata: Option<VersionMetadata>,
+}
+
+/// Optional metadata describing a runtime deployment.
+#[derive(Clone, Debug, Default, PartialEq, Eq, Hash, cbor::Encode, cbor::Decode)]
+pub struct VersionMetadata {
+    /// Hash of the runtime bundle manifest.
+    #[cbor(optional)]
+    pub manifest_hash: Option<Hash>,
+    /// URLs from which the runtime bundle can be obtained.
+    #[cbor(optional)]
+    pub urls: Vec<String>,
+    /// Human-readable release information.
+    #[cbor(optional)]
+    pub release_notes: String,
+    /// Declared number of components in the runtime bundle.
+    #[cbor(optional)]
+    pub num_components: u16,
+    /// Declared total size of the runtime bundle contents in bytes.
+    #[cbor(optional)]
+    pub image_size: u64,
 }
 
 impl VersionInfo {
@@ -1035,6 +1066,7 @@ mod tests {
                         valid_from: 0,
                         tee: b"version tee".to_vec(),
                         bundle_checksum: vec![0x1; 32],
+                        ..Default::default()
                     }],
                     key_manager: Some(Namespace::from(
                         "8000000000000000000000000000000000000000000000000000000000000001",
@@ -1159,6 +1191,7 @@ mod tests {
                                     attestation: vec![0, 1,2,3,4,5],
                                     ..Default::default()
                                }),
+                                ..Default::default()
                             },
                             extra_info: Some(vec![5,3,2,1]),
                         },
@@ -1284,6 +1317,7 @@ mod tests {
                                     attestation: vec![0, 1,2,3,4,5],
                                     ..Default::default()
                                 }),
+                                ..Default::default()
                             },
                             extra_info: Some(vec![5,3,2,1]),
                         },
@@ -1333,6 +1367,7 @@ mod tests {
                                     rek: Some(x25519::PublicKey::from([0;32])),
                                     attestation: vec![0, 1,2,3,4,5],
                                 }),
+                                ..Default::default()
                             },
                             extra_info: Some(vec![5,3,2,1]),
                         },
@@ -235,6 +235,8 @@ pub struct Event {
     pub height: i64,
     #[cbor(optional)]
     pub tx_hash: Hash,
+    #[cbor(optional)]
+    pub index: u32,
 
     // TODO: Consider refactoring this to be an enum.
     #[cbor(optional)]
@@ -166,7 +166,7 @@ mod test {
         let mock_consensus_root = Root {
             version: 1,
             root_type: RootType::State,
-            hash: Hash::from("8e39bf193f8a954ab8f8d7cb6388c591fd0785ea060bbd8e3752e266b54499d3"),
+            hash: Hash::from("2a25f7a9e977bc38fbe23acfe72309c1f9aa273f23383a483b42d5c8aad54aac"),
             ..Default::default()
         };
         let mkvs = Tree::builder()
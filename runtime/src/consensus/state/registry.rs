@@ -132,7 +132,7 @@ mod test {
         let mock_consensus_root = Root {
             version: 1,
             root_type: RootType::State,
-            hash: Hash::from("8e39bf193f8a954ab8f8d7cb6388c591fd0785ea060bbd8e3752e266b54499d3"),
+            hash: Hash::from("2a25f7a9e977bc38fbe23acfe72309c1f9aa273f23383a483b42d5c8aad54aac"),
             ..Default::default()
         };
         let mkvs = Tree::builder()
@@ -193,6 +193,7 @@ mod test {
                                     attestation: vec![0, 1,2,3,4,5],
                                     ..Default::default()
                                }),
+                                ..Default::default()
                             },
                             extra_info: Some(vec![5,3,2,1]),
                         },
@@ -263,6 +264,7 @@ mod test {
                         valid_from: 42,
                         tee: vec![1, 2, 3, 4, 5],
                         bundle_checksum: vec![0x5; 32],
+                        ..Default::default()
                     },
                     VersionInfo {
                         version: Version::from(120),
@@ -478,6 +478,12 @@ impl Dispatcher {
 
                 Ok(Body::Empty {})
             }
+            Body::RuntimeBuildAppRegistrationRequest { ect, expiration } => state
+                .app
+                .build_registration_tx(ect, expiration)
+                .await
+                .map(|tx| Body::RuntimeBuildAppRegistrationResponse { tx })
+                .map_err(Into::into),
 
             // Other requests.
             Body::RuntimeKeyManagerStatusUpdateRequest { status } => {
@@ -3,7 +3,11 @@ use async_trait::async_trait;
 use thiserror::Error;
 
 use crate::{
-    common::{crypto::signature::PublicKey, namespace::Namespace},
+    common::{
+        crypto::{hash::Hash, signature::PublicKey},
+        namespace::Namespace,
+    },
+    consensus::beacon::EpochTime,
     protocol::Protocol,
     storage::mkvs::sync,
     types::{self, Body},
@@ -51,6 +55,26 @@ pub struct RegisterNotifyOpts {
     pub runtime_event: Vec<Vec<u8>>,
 }
 
+/// App instance registration options.
+#[derive(Clone, Default, Debug)]
+pub struct RegisterAppOpts {
+    /// Target runtime identifier. If not specified, own runtime identifier is used.
+    pub runtime_id: Option<Namespace>,
+    /// Number of epochs for which each registration remains valid.
+    pub validity_epochs: EpochTime,
+}
+
+/// App instance registration result.
+#[derive(Clone, Default, Debug)]
+pub struct RegisterAppResult {
+    /// Epoch at which the registration expires unless renewed.
+    pub expiration: EpochTime,
+    /// Registration transaction output.
+    pub output: Vec<u8>,
+    /// Round in which the registration transaction was executed.
+    pub round: u64,
+}
+
 /// Interface to the (untrusted) host node.
 #[async_trait]
 pub trait Host: Send + Sync {
@@ -63,6 +87,25 @@ pub trait Host: Send + Sync {
 
     /// Register for receiving notifications.
     async fn register_notify(&self, opts: RegisterNotifyOpts) -> Result<(), Error>;
+
+    /// Report internal runtime telemetry to the host.
+    async fn report_telemetry(&self, telemetry: types::RuntimeTelemetry) -> Result<(), Error>;
+
+    /// Register the ROFL app instance and keep the registration up to date.
+    ///
+    /// The host endorses the component's TEE capability and requests the application to build
+    /// the registration transaction whenever the registration needs to be (re)submitted.
+    async fn register_app(&self, opts: RegisterAppOpts) -> Result<RegisterAppResult, Error>;
+
+    /// Fetch proofs of the given registry or staking consensus state keys at the given height.
+    ///
+    /// Returns the consensus state root the proofs are for and the proofs in the same order as
+    /// the keys. The state root is untrusted and must be checked against a verified light block.
+    async fn fetch_consensus_state_proof(
+        &self,
+        height: u64,
+        keys: Vec<Vec<u8>>,
+    ) -> Result<(Hash, Vec<sync::Proof>), Error>;
 }
 
 #[async_trait]
@@ -125,4 +168,54 @@ impl Host for Protocol {
             _ => Err(Error::BadResponse),
         }
     }
+
+    async fn report_telemetry(&self, telemetry: types::RuntimeTelemetry) -> Result<(), Error> {
+        match self
+            .call_host_async(Body::HostReportTelemetryRequest { telemetry })
+            .await?
+        {
+            Body::HostReportTelemetryResponse {} => Ok(()),
+            _ => Err(Error::BadResponse),
+        }
+    }
+
+    async fn register_app(&self, opts: RegisterAppOpts) -> Result<RegisterAppResult, Error> {
+        match self
+            .call_host_async(Body::HostRegisterAppRequest {
+                runtime_id: opts.runtime_id.unwrap_or_else(|| self.get_runtime_id()),
+                validity_epochs: opts.validity_epochs,
+            })
+            .await?
+        {
+            Body::HostRegisterAppResponse {
+                expiration,
+                output,
+                round,
+            } => Ok(RegisterAppResult {
+                expiration,
+                output,
+                round,
+            }),
+            _ => Err(Error::BadResponse),
+        }
+    }
+
+    async fn fetch_consensus_state_proof(
+        &self,
+        height: u64,
+        keys: Vec<Vec<u8>>,
+    ) -> Result<(Hash, Vec<sync::Proof>), Error> {
+        let num_keys = keys.len();
+        match self
+            .call_host_async(Body::HostFetchConsensusStateProofRequest { height, keys })
+            .await?
+        {
+            Body::HostFetchConsensusStateProofResponse { state_root, proofs }
+                if proofs.len() == num_keys =>
+            {
+                Ok((state_root, proofs))
+            }
+            _ => Err(Error::BadResponse),
+        }
+    }
 }
@@ -390,6 +390,7 @@ impl Protocol {
             | Body::RuntimeCheckTxBatchRequest { .. }
             | Body::RuntimeExecuteTxBatchRequest { .. }
             | Body::RuntimeNotifyRequest { .. }
+            | Body::RuntimeBuildAppRegistrationRequest { .. }
             | Body::RuntimeKeyManagerStatusUpdateRequest { .. }
             | Body::RuntimeKeyManagerQuotePolicyUpdateRequest { .. }
             | Body::RuntimeQueryRequest { .. }
@@ -5,7 +5,7 @@ use anyhow::{bail, Result};
 use async_trait::async_trait;
 
 use crate::{
-    consensus::roothash,
+    consensus::{beacon::EpochTime, registry::EndorsedCapabilityTEE, roothash},
     dispatcher::{Initializer, PostInitState, PreInitState},
     host::Host,
 };
@@ -41,6 +41,19 @@ pub trait App: Send + Sync {
         Ok(())
     }
 
+    /// Called when the host needs a signed app instance registration transaction.
+    ///
+    /// The transaction should register the given endorsed TEE capability and expire at the given
+    /// epoch. This is only called after registration has been requested via `Host::register_app`.
+    async fn build_registration_tx(
+        &self,
+        ect: EndorsedCapabilityTEE,
+        expiration: EpochTime,
+    ) -> Result<Vec<u8>> {
+        // Default implementation does not support registration.
+        bail!("app instance registration not supported");
+    }
+
     /// Called for runtime queries.
     async fn query(&self, method: &str, args: Vec<u8>) -> Result<Vec<u8>> {
         // Default implementation rejects all requests.
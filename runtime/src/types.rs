@@ -21,7 +21,7 @@ use crate::{
         roothash::{self, Block, ComputeResultsHeader, Header},
         state::keymanager::Status as KeyManagerStatus,
         transaction::{Proof, SignedTransaction},
-        LightBlock,
+        CompactLightBlock, LightBlock,
     },
     enclave_rpc,
     storage::mkvs::{sync, WriteLog},
@@ -225,6 +225,13 @@ pub enum Body {
         runtime_event: Option<RuntimeNotifyEvent>,
     },
     RuntimeNotifyResponse {},
+    RuntimeBuildAppRegistrationRequest {
+        ect: EndorsedCapabilityTEE,
+        expiration: EpochTime,
+    },
+    RuntimeBuildAppRegistrationResponse {
+        tx: Vec<u8>,
+    },
 
     // Host interface.
     HostRPCCallRequest {
@@ -263,8 +270,24 @@ pub enum Body {
     HostFetchConsensusBlockResponse {
         block: LightBlock,
     },
+    HostFetchConsensusBlockRangeRequest {
+        start_height: u64,
+        end_height: u64,
+    },
+    HostFetchConsensusBlockRangeResponse {
+        blocks: Vec<CompactLightBlock>,
+        validator_sets: Vec<Vec<u8>>,
+    },
     HostFetchConsensusEventsRequest(HostFetchConsensusEventsRequest),
     HostFetchConsensusEventsResponse(HostFetchConsensusEventsResponse),
+    HostFetchConsensusStateProofRequest {
+        height: u64,
+        keys: Vec<Vec<u8>>,
+    },
+    HostFetchConsensusStateProofResponse {
+        state_root: Hash,
+        proofs: Vec<sync::Proof>,
+    },
     HostFetchTxBatchRequest {
         #[cbor(optional)]
         offset: Option<Hash>,
@@ -315,6 +338,21 @@ pub enum Body {
         runtime_event: Option<RegisterNotifyRuntimeEvent>,
     },
     HostRegisterNotifyResponse {},
+    HostReportTelemetryRequest {
+        telemetry: RuntimeTelemetry,
+    },
+    HostReportTelemetryResponse {},
+    HostRegisterAppRequest {
+        runtime_id: Namespace,
+        validity_epochs: EpochTime,
+    },
+    HostRegisterAppResponse {
+        expiration: EpochTime,
+        #[cbor(optional)]
+        output: Vec<u8>,
+        #[cbor(optional)]
+        round: u64,
+    },
 }
 
 impl Default for Body {
@@ -487,6 +525,26 @@ pub struct HostFetchConsensusEventsResponse {
     pub events: Vec<consensus::Event>,
 }
 
+/// Internal telemetry reported by a runtime.
+#[derive(Clone, Debug, Default, PartialEq, Eq, cbor::Encode, cbor::Decode)]
+pub struct RuntimeTelemetry {
+    /// Number of heap bytes currently in use.
+    #[cbor(optional)]
+    pub heap_used: u64,
+    /// Total heap size in bytes or zero when unknown.
+    #[cbor(optional)]
+    pub heap_capacity: u64,
+    /// Peak number of stack bytes used.
+    #[cbor(optional)]
+    pub stack_used: u64,
+    /// Total stack size in bytes or zero when unknown.
+    #[cbor(optional)]
+    pub stack_capacity: u64,
+    /// Current depths of internal request queues, keyed by queue name.
+    #[cbor(optional)]
+    pub queue_depths: BTreeMap<String, u64>,
+}
+
 /// Registration for runtime event notifications.
 #[derive(Clone, Debug, Default, cbor::Encode, cbor::Decode)]
 pub struct RegisterNotifyRuntimeEvent {